@@ -0,0 +1,13 @@
+// Package jsonschema embeds the JSON schema of the workflow kinds, generated from the OpenAPI spec, so that manifests
+// can be validated without a cluster.
+package jsonschema
+
+import _ "embed"
+
+// Schema is the JSON schema in schema.json, with the definitions of the kinds under "#/definitions/"
+//
+//go:embed schema.json
+var Schema []byte
+
+// ID is the $id of the schema, which references to its definitions are resolved against
+const ID = "http://workflows.argoproj.io/workflows.json"
@@ -0,0 +1,100 @@
+// argo-admin-replay is the "argo admin replay" plugin of the CLI. It is a binary of its own, rather than a command of
+// the CLI, so that the CLI does not link the workflow controller.
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/argoproj/pkg/cli"
+	"github.com/spf13/cobra"
+
+	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/common"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/util/errors"
+	"github.com/argoproj/argo-workflows/v3/workflow/controller"
+	"github.com/argoproj/argo-workflows/v3/workflow/recording"
+)
+
+func main() {
+	if err := NewReplayCommand().Execute(); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+}
+
+func NewReplayCommand() *cobra.Command {
+	var (
+		reconciliation int
+		logLevel       string
+	)
+	command := &cobra.Command{
+		Use:   "argo admin replay FILE",
+		Short: "replay the recorded reconciliations of a workflow offline and compare the results",
+		Long: `Replay the reconciliations of a workflow recorded by the workflow controller, as saved by ` + "`argo admin controller recording`" + `.
+
+Each reconciliation is replayed by running the controller's logic on the recorded workflow, with the recorded configuration, pods and task results, and fake Kubernetes clients, so nothing in the cluster is read or changed. The differences between the recorded and the replayed workflow are printed, recorded values with - and replayed values with +. Use --loglevel debug to see the controller's logs of each reconciliation.`,
+		Example: `# Replay all the recorded reconciliations of a workflow:
+
+  argo admin replay my-wf.jsonl
+
+# Replay the third recorded reconciliation with the controller's debug logs:
+
+  argo admin replay my-wf.jsonl --reconciliation 3 --loglevel debug
+`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			cli.SetLogLevel(logLevel)
+			f, err := os.Open(args[0])
+			errors.CheckError(err)
+			defer f.Close()
+			reconciliations, err := recording.Read(f)
+			errors.CheckError(err)
+			errors.CheckError(replayReconciliations(cmd.Context(), os.Stdout, reconciliations, reconciliation, controller.Replay))
+		},
+	}
+	command.Flags().IntVar(&reconciliation, "reconciliation", 0, "Only replay the reconciliation with this number, starting from one. Defaults to all reconciliations.")
+	command.Flags().BoolVar(&common.NoColor, "no-color", false, "Disable colorized output")
+	command.Flags().StringVar(&logLevel, "loglevel", "info", "Set the logging level. One of: debug|info|warn|error")
+	return command
+}
+
+type replayFunc func(ctx context.Context, reconciliation recording.Reconciliation) (*wfv1.Workflow, error)
+
+// replayReconciliations replays the reconciliations, or only the one with the number if it is not zero, and prints how
+// the replayed workflows differ from the recorded ones
+func replayReconciliations(ctx context.Context, out io.Writer, reconciliations []recording.Reconciliation, number int, replay replayFunc) error {
+	if number < 0 || number > len(reconciliations) {
+		return fmt.Errorf("there are %d recorded reconciliations, --reconciliation must be between one and %d", len(reconciliations), len(reconciliations))
+	}
+	differing := 0
+	replayed := 0
+	for i, r := range reconciliations {
+		if number > 0 && i+1 != number {
+			continue
+		}
+		if r.Result == nil {
+			return fmt.Errorf("reconciliation %d has no result", i+1)
+		}
+		_, _ = fmt.Fprintf(out, "Reconciliation %d at %s:\n", i+1, r.Time.Format(time.RFC3339))
+		wf, err := replay(ctx, r)
+		if err != nil {
+			return fmt.Errorf("failed to replay reconciliation %d: %w", i+1, err)
+		}
+		differ, err := common.PrintWorkflowDiff(out, r.Result, wf)
+		if err != nil {
+			return err
+		}
+		if differ {
+			differing++
+		} else {
+			_, _ = fmt.Fprintln(out, "Replayed workflow does not differ")
+		}
+		replayed++
+	}
+	_, _ = fmt.Fprintf(out, "%d of %d replayed reconciliations differ\n", differing, replayed)
+	return nil
+}
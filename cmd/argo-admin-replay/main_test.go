@@ -0,0 +1,52 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/common"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/recording"
+)
+
+func TestReplayReconciliations(t *testing.T) {
+	common.NoColor = true
+	newWorkflow := func(phase wfv1.WorkflowPhase) *wfv1.Workflow {
+		return &wfv1.Workflow{ObjectMeta: metav1.ObjectMeta{Name: "my-wf"}, Status: wfv1.WorkflowStatus{Phase: phase}}
+	}
+	reconciliations := []recording.Reconciliation{
+		{Workflow: newWorkflow(""), Result: newWorkflow(wfv1.WorkflowRunning)},
+		{Workflow: newWorkflow(wfv1.WorkflowRunning), Result: newWorkflow(wfv1.WorkflowFailed)},
+	}
+	// the replay of the second reconciliation succeeds the workflow instead of failing it
+	replay := func(ctx context.Context, r recording.Reconciliation) (*wfv1.Workflow, error) {
+		if r.Workflow.Status.Phase == wfv1.WorkflowRunning {
+			return newWorkflow(wfv1.WorkflowSucceeded), nil
+		}
+		return newWorkflow(wfv1.WorkflowRunning), nil
+	}
+	t.Run("All", func(t *testing.T) {
+		var out bytes.Buffer
+		require.NoError(t, replayReconciliations(context.Background(), &out, reconciliations, 0, replay))
+		assert.Contains(t, out.String(), "Replayed workflow does not differ")
+		assert.Contains(t, out.String(), `- status.phase: "Failed"`)
+		assert.Contains(t, out.String(), `+ status.phase: "Succeeded"`)
+		assert.Contains(t, out.String(), "1 of 2 replayed reconciliations differ")
+	})
+	t.Run("One", func(t *testing.T) {
+		var out bytes.Buffer
+		require.NoError(t, replayReconciliations(context.Background(), &out, reconciliations, 1, replay))
+		assert.Contains(t, out.String(), "Reconciliation 1 at")
+		assert.NotContains(t, out.String(), "Reconciliation 2 at")
+		assert.Contains(t, out.String(), "0 of 1 replayed reconciliations differ")
+	})
+	t.Run("OutOfRange", func(t *testing.T) {
+		err := replayReconciliations(context.Background(), &bytes.Buffer{}, reconciliations, 3, replay)
+		assert.EqualError(t, err, "there are 2 recorded reconciliations, --reconciliation must be between one and 2")
+	})
+}
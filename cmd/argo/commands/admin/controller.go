@@ -0,0 +1,185 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/yaml"
+
+	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/client"
+	"github.com/argoproj/argo-workflows/v3/util/errors"
+	"github.com/argoproj/argo-workflows/v3/workflow/diagnostics"
+)
+
+// controllerStatus is the status of the leading controller, as printed by `argo admin controller status`
+type controllerStatus struct {
+	// Pod is the name of the controller pod the status was read from
+	Pod string `json:"pod"`
+	// LeaseRenewTime is when the leader last renewed its lease, if leader election is enabled
+	LeaseRenewTime *metav1.MicroTime `json:"leaseRenewTime,omitempty"`
+	diagnostics.Status
+}
+
+type controllerStatusFlags struct {
+	pod    string
+	output string
+}
+
+func NewControllerCommand() *cobra.Command {
+	command := &cobra.Command{
+		Use:   "controller",
+		Short: "inspect the workflow controller",
+		Run: func(cmd *cobra.Command, args []string) {
+			cmd.HelpFunc()(cmd, args)
+		},
+	}
+	command.AddCommand(NewControllerStatusCommand())
+	command.AddCommand(NewControllerRecordingCommand())
+	return command
+}
+
+func NewControllerStatusCommand() *cobra.Command {
+	var flags controllerStatusFlags
+	command := &cobra.Command{
+		Use:   "status",
+		Short: "print the leader, queue depths, workflows by phase, informer sync state, configuration hash and recent errors of the workflow controller",
+		Example: `# Print the status of the workflow controller in the "argo" namespace:
+
+  argo admin controller status -n argo
+
+# Print the status of the controller with an instance ID as JSON:
+
+  argo admin controller status -n argo --instanceid my-instance -o json
+
+# Print the status of a specific controller pod, e.g. when leader election is disabled:
+
+  argo admin controller status -n argo --pod workflow-controller-6d8c9b7f5-x2x4q
+`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if flags.output != "" && flags.output != "json" && flags.output != "yaml" {
+				errors.CheckError(fmt.Errorf("unknown output format: %s", flags.output))
+			}
+			restConfig, err := client.GetConfig().ClientConfig()
+			errors.CheckError(err)
+			kubeClient := kubernetes.NewForConfigOrDie(restConfig)
+			status, err := getControllerStatus(cmd.Context(), kubeClient, client.Namespace(), client.InstanceID(), flags.pod)
+			errors.CheckError(err)
+			errors.CheckError(printControllerStatus(os.Stdout, status, flags.output))
+		},
+	}
+	command.Flags().StringVar(&flags.pod, "pod", "", "Controller pod to query, instead of the leader")
+	command.Flags().StringVarP(&flags.output, "output", "o", "", "Output format. One of: json|yaml")
+	return command
+}
+
+// getLeaderLease returns the lease of the leading controller
+func getLeaderLease(ctx context.Context, kubeClient kubernetes.Interface, namespace, instanceID string) (*coordinationv1.Lease, error) {
+	leaseName := "workflow-controller"
+	if instanceID != "" {
+		leaseName = fmt.Sprintf("%s-%s", leaseName, instanceID)
+	}
+	lease, err := kubeClient.CoordinationV1().Leases(namespace).Get(ctx, leaseName, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the lease of the leading controller, use --pod if leader election is disabled: %w", err)
+	}
+	if lease.Spec.HolderIdentity == nil || *lease.Spec.HolderIdentity == "" {
+		return nil, fmt.Errorf("lease %s/%s has no holder, no controller is leading", namespace, leaseName)
+	}
+	return lease, nil
+}
+
+// getControllerStatus finds the leading controller from its lease, unless a pod is given, and reads its diagnostics
+// through the API server's pod proxy
+func getControllerStatus(ctx context.Context, kubeClient kubernetes.Interface, namespace, instanceID, pod string) (*controllerStatus, error) {
+	status := &controllerStatus{Pod: pod}
+	if pod == "" {
+		lease, err := getLeaderLease(ctx, kubeClient, namespace, instanceID)
+		if err != nil {
+			return nil, err
+		}
+		status.Pod = *lease.Spec.HolderIdentity
+		status.LeaseRenewTime = lease.Spec.RenewTime
+	}
+	data, err := kubeClient.CoreV1().Pods(namespace).ProxyGet("http", status.Pod, strconv.Itoa(diagnostics.Port), diagnostics.Path, nil).DoRaw(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the diagnostics of controller pod %s/%s: %w", namespace, status.Pod, err)
+	}
+	if err := json.Unmarshal(data, &status.Status); err != nil {
+		return nil, fmt.Errorf("failed to parse the diagnostics of controller pod %s/%s: %w", namespace, status.Pod, err)
+	}
+	return status, nil
+}
+
+func printControllerStatus(out io.Writer, status *controllerStatus, output string) error {
+	switch output {
+	case "json":
+		data, err := json.MarshalIndent(status, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(out, string(data))
+		return err
+	case "yaml":
+		data, err := yaml.Marshal(status)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprint(out, string(data))
+		return err
+	}
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	pod := status.Pod
+	if status.LeaseRenewTime != nil {
+		pod = fmt.Sprintf("%s (lease renewed %s ago)", pod, duration.HumanDuration(time.Since(status.LeaseRenewTime.Time)))
+	}
+	_, _ = fmt.Fprintf(w, "Pod:\t%s\n", pod)
+	_, _ = fmt.Fprintf(w, "Leading:\t%t\n", status.Leading)
+	_, _ = fmt.Fprintf(w, "Version:\t%s\n", status.Version)
+	if status.InstanceID != "" {
+		_, _ = fmt.Fprintf(w, "Instance ID:\t%s\n", status.InstanceID)
+	}
+	if status.ManagedNamespace != "" {
+		_, _ = fmt.Fprintf(w, "Managed Namespace:\t%s\n", status.ManagedNamespace)
+	}
+	_, _ = fmt.Fprintf(w, "Config Hash:\t%s\n", status.ConfigHash)
+	if status.Leading {
+		_, _ = fmt.Fprint(w, "\nQUEUE\tDEPTH\n")
+		for _, name := range sortedKeys(status.Queues) {
+			_, _ = fmt.Fprintf(w, "%s\t%d\n", name, status.Queues[name])
+		}
+		_, _ = fmt.Fprint(w, "\nPHASE\tWORKFLOWS\n")
+		for _, phase := range sortedKeys(status.Workflows) {
+			_, _ = fmt.Fprintf(w, "%s\t%d\n", phase, status.Workflows[phase])
+		}
+		_, _ = fmt.Fprint(w, "\nINFORMER\tSYNCED\n")
+		for _, name := range sortedKeys(status.Informers) {
+			_, _ = fmt.Fprintf(w, "%s\t%t\n", name, status.Informers[name])
+		}
+	}
+	_, _ = fmt.Fprint(w, "\nERROR CAUSE\tLAST HOUR\n")
+	for _, cause := range sortedKeys(status.RecentErrors) {
+		_, _ = fmt.Fprintf(w, "%s\t%d\n", cause, status.RecentErrors[cause])
+	}
+	return w.Flush()
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,73 @@
+package admin
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/argoproj/argo-workflows/v3/workflow/diagnostics"
+)
+
+func TestGetControllerStatus(t *testing.T) {
+	ctx := context.Background()
+	t.Run("NoLease", func(t *testing.T) {
+		_, err := getControllerStatus(ctx, kubefake.NewSimpleClientset(), "argo", "", "")
+		assert.ErrorContains(t, err, "use --pod if leader election is disabled")
+	})
+	t.Run("NoHolder", func(t *testing.T) {
+		kubeClient := kubefake.NewSimpleClientset(&coordinationv1.Lease{ObjectMeta: metav1.ObjectMeta{Namespace: "argo", Name: "workflow-controller-my-instance"}})
+		_, err := getControllerStatus(ctx, kubeClient, "argo", "my-instance", "")
+		assert.EqualError(t, err, "lease argo/workflow-controller-my-instance has no holder, no controller is leading")
+	})
+}
+
+func TestPrintControllerStatus(t *testing.T) {
+	status := &controllerStatus{
+		Pod: "workflow-controller-0",
+		Status: diagnostics.Status{
+			Version:      "v3.6.0",
+			Leading:      true,
+			ConfigHash:   "0123456789ab",
+			Queues:       map[string]int{"workflow": 2, "podCleanup": 0},
+			Workflows:    map[string]int{"Running": 3, "Pending": 1},
+			Informers:    map[string]bool{"workflows": true, "pods": false},
+			RecentErrors: map[string]int{"OperationPanic": 1},
+		},
+	}
+	t.Run("Text", func(t *testing.T) {
+		out := &bytes.Buffer{}
+		require.NoError(t, printControllerStatus(out, status, ""))
+		assert.Equal(t, `Pod:          workflow-controller-0
+Leading:      true
+Version:      v3.6.0
+Config Hash:  0123456789ab
+
+QUEUE       DEPTH
+podCleanup  0
+workflow    2
+
+PHASE    WORKFLOWS
+Pending  1
+Running  3
+
+INFORMER   SYNCED
+pods       false
+workflows  true
+
+ERROR CAUSE     LAST HOUR
+OperationPanic  1
+`, out.String())
+	})
+	t.Run("JSON", func(t *testing.T) {
+		out := &bytes.Buffer{}
+		require.NoError(t, printControllerStatus(out, status, "json"))
+		assert.Contains(t, out.String(), `"pod": "workflow-controller-0"`)
+		assert.Contains(t, out.String(), `"configHash": "0123456789ab"`)
+	})
+}
@@ -0,0 +1,291 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/yaml"
+
+	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/client"
+	"github.com/argoproj/argo-workflows/v3/pkg/apis/workflow"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/util/errors"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+const (
+	executorRoleName          = "executor"
+	artifactRepositoriesName  = "artifact-repositories"
+	defaultArtifactRepository = "default-v1"
+	resourceQuotaName         = "argo-workflows"
+	workflowDefaultsName      = "workflow-defaults"
+)
+
+// namespaceProfile describes what a tenant namespace needs
+type namespaceProfile struct {
+	// ServiceAccounts to create for workflows to run as, all bound to the executor role. Defaults to "default".
+	ServiceAccounts []string `json:"serviceAccounts,omitempty"`
+	// ArtifactRepository is the namespace's default artifact repository
+	ArtifactRepository *wfv1.ArtifactRepository `json:"artifactRepository,omitempty"`
+	// ResourceQuota limits the resources the namespace's workflows may use
+	ResourceQuota *corev1.ResourceQuotaSpec `json:"resourceQuota,omitempty"`
+	// WorkflowDefaults override the controller's workflow defaults for workflows in the namespace
+	WorkflowDefaults *wfv1.Workflow `json:"workflowDefaults,omitempty"`
+}
+
+func NewInitNamespaceCommand() *cobra.Command {
+	var (
+		profileFile string
+		dryRun      bool
+	)
+	command := &cobra.Command{
+		Use:   "init-namespace NAMESPACE",
+		Short: "provision the service accounts, RBAC, artifact repository, quota and workflow defaults a namespace needs to run workflows",
+		Long: `Provision the service accounts, RBAC, artifact repository, quota and workflow defaults a namespace needs to run workflows.
+
+The command is idempotent: resources that already exist are updated to match the profile, so you can re-run it after changing the profile.`,
+		Example: `# Provision a namespace with the default service account and the executor role only:
+
+  argo admin init-namespace my-team
+
+# Provision a namespace from a profile:
+
+  argo admin init-namespace my-team --profile tenant.yaml
+
+# Print what would be changed, without changing anything:
+
+  argo admin init-namespace my-team --profile tenant.yaml --dry-run
+`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			profile := &namespaceProfile{}
+			if profileFile != "" {
+				data, err := os.ReadFile(profileFile)
+				errors.CheckError(err)
+				errors.CheckError(yaml.UnmarshalStrict(data, profile))
+			}
+			restConfig, err := client.GetConfig().ClientConfig()
+			errors.CheckError(err)
+			kubeClient := kubernetes.NewForConfigOrDie(restConfig)
+			results, err := initNamespace(cmd.Context(), kubeClient, args[0], profile, dryRun)
+			for _, r := range results {
+				fmt.Println(r)
+			}
+			errors.CheckError(err)
+		},
+	}
+	command.Flags().StringVar(&profileFile, "profile", "", "YAML file describing the service accounts, artifact repository, resource quota and workflow defaults of the namespace")
+	command.Flags().BoolVar(&dryRun, "dry-run", false, "Print what would be created or configured, without changing anything")
+	return command
+}
+
+// initNamespace creates or updates each resource the profile describes, and returns what it did to each
+func initNamespace(ctx context.Context, kubeClient kubernetes.Interface, namespace string, profile *namespaceProfile, dryRun bool) ([]string, error) {
+	var results []string
+	apply := func(kind, name string, f func() (string, error)) error {
+		action, err := f()
+		if err != nil {
+			return fmt.Errorf("failed to apply %s/%s: %w", kind, name, err)
+		}
+		if dryRun && action != "unchanged" {
+			action += " (dry run)"
+		}
+		results = append(results, fmt.Sprintf("%s/%s %s", kind, name, action))
+		return nil
+	}
+
+	err := apply("Namespace", namespace, func() (string, error) {
+		_, err := kubeClient.CoreV1().Namespaces().Get(ctx, namespace, metav1.GetOptions{})
+		if !apierr.IsNotFound(err) {
+			return "unchanged", err
+		}
+		if dryRun {
+			return "created", nil
+		}
+		_, err = kubeClient.CoreV1().Namespaces().Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}}, metav1.CreateOptions{})
+		return "created", err
+	})
+	if err != nil {
+		return results, err
+	}
+
+	serviceAccounts := profile.ServiceAccounts
+	if len(serviceAccounts) == 0 {
+		serviceAccounts = []string{"default"}
+	}
+	var subjects []rbacv1.Subject
+	for _, name := range serviceAccounts {
+		name := name
+		subjects = append(subjects, rbacv1.Subject{Kind: rbacv1.ServiceAccountKind, Name: name, Namespace: namespace})
+		err := apply("ServiceAccount", name, func() (string, error) {
+			_, err := kubeClient.CoreV1().ServiceAccounts(namespace).Get(ctx, name, metav1.GetOptions{})
+			if !apierr.IsNotFound(err) {
+				return "unchanged", err
+			}
+			if dryRun {
+				return "created", nil
+			}
+			_, err = kubeClient.CoreV1().ServiceAccounts(namespace).Create(ctx, &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: name}}, metav1.CreateOptions{})
+			return "created", err
+		})
+		if err != nil {
+			return results, err
+		}
+	}
+
+	rules := []rbacv1.PolicyRule{{
+		APIGroups: []string{workflow.Group},
+		Resources: []string{"workflowtaskresults"},
+		Verbs:     []string{"create", "patch"},
+	}}
+	err = apply("Role", executorRoleName, func() (string, error) {
+		roles := kubeClient.RbacV1().Roles(namespace)
+		role, err := roles.Get(ctx, executorRoleName, metav1.GetOptions{})
+		if apierr.IsNotFound(err) {
+			if dryRun {
+				return "created", nil
+			}
+			_, err = roles.Create(ctx, &rbacv1.Role{ObjectMeta: metav1.ObjectMeta{Name: executorRoleName}, Rules: rules}, metav1.CreateOptions{})
+			return "created", err
+		}
+		if err != nil || equality.Semantic.DeepEqual(role.Rules, rules) {
+			return "unchanged", err
+		}
+		role.Rules = rules
+		if !dryRun {
+			_, err = roles.Update(ctx, role, metav1.UpdateOptions{})
+		}
+		return "configured", err
+	})
+	if err != nil {
+		return results, err
+	}
+
+	roleRef := rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "Role", Name: executorRoleName}
+	err = apply("RoleBinding", executorRoleName, func() (string, error) {
+		bindings := kubeClient.RbacV1().RoleBindings(namespace)
+		binding, err := bindings.Get(ctx, executorRoleName, metav1.GetOptions{})
+		if apierr.IsNotFound(err) {
+			if dryRun {
+				return "created", nil
+			}
+			_, err = bindings.Create(ctx, &rbacv1.RoleBinding{ObjectMeta: metav1.ObjectMeta{Name: executorRoleName}, RoleRef: roleRef, Subjects: subjects}, metav1.CreateOptions{})
+			return "created", err
+		}
+		if err != nil || equality.Semantic.DeepEqual(binding.Subjects, subjects) {
+			return "unchanged", err
+		}
+		if binding.RoleRef != roleRef {
+			return "", fmt.Errorf("role binding exists for role %q, not %q", binding.RoleRef.Name, executorRoleName)
+		}
+		binding.Subjects = subjects
+		if !dryRun {
+			_, err = bindings.Update(ctx, binding, metav1.UpdateOptions{})
+		}
+		return "configured", err
+	})
+	if err != nil {
+		return results, err
+	}
+
+	if profile.ArtifactRepository != nil {
+		data, err := yaml.Marshal(profile.ArtifactRepository)
+		if err != nil {
+			return results, err
+		}
+		err = applyConfigMap(ctx, kubeClient, apply, namespace, artifactRepositoriesName, nil,
+			map[string]string{"workflows.argoproj.io/default-artifact-repository": defaultArtifactRepository},
+			map[string]string{defaultArtifactRepository: string(data)}, dryRun)
+		if err != nil {
+			return results, err
+		}
+	}
+
+	if profile.ResourceQuota != nil {
+		err := apply("ResourceQuota", resourceQuotaName, func() (string, error) {
+			quotas := kubeClient.CoreV1().ResourceQuotas(namespace)
+			quota, err := quotas.Get(ctx, resourceQuotaName, metav1.GetOptions{})
+			if apierr.IsNotFound(err) {
+				if dryRun {
+					return "created", nil
+				}
+				_, err = quotas.Create(ctx, &corev1.ResourceQuota{ObjectMeta: metav1.ObjectMeta{Name: resourceQuotaName}, Spec: *profile.ResourceQuota}, metav1.CreateOptions{})
+				return "created", err
+			}
+			if err != nil || equality.Semantic.DeepEqual(quota.Spec, *profile.ResourceQuota) {
+				return "unchanged", err
+			}
+			quota.Spec = *profile.ResourceQuota
+			if !dryRun {
+				_, err = quotas.Update(ctx, quota, metav1.UpdateOptions{})
+			}
+			return "configured", err
+		})
+		if err != nil {
+			return results, err
+		}
+	}
+
+	if profile.WorkflowDefaults != nil {
+		data, err := yaml.Marshal(profile.WorkflowDefaults)
+		if err != nil {
+			return results, err
+		}
+		err = applyConfigMap(ctx, kubeClient, apply, namespace, workflowDefaultsName,
+			map[string]string{common.LabelKeyConfigMapType: common.LabelValueTypeConfigMapWorkflowDefaults}, nil,
+			map[string]string{common.ConfigMapKeyWorkflowDefaults: string(data)}, dryRun)
+		if err != nil {
+			return results, err
+		}
+	}
+	return results, nil
+}
+
+// applyConfigMap creates the config map, or adds the labels, annotations and data to it if it exists, leaving any
+// other keys, e.g. other artifact repositories, as they are
+func applyConfigMap(ctx context.Context, kubeClient kubernetes.Interface, apply func(kind, name string, f func() (string, error)) error, namespace, name string, labels, annotations, data map[string]string, dryRun bool) error {
+	return apply("ConfigMap", name, func() (string, error) {
+		configMaps := kubeClient.CoreV1().ConfigMaps(namespace)
+		cm, err := configMaps.Get(ctx, name, metav1.GetOptions{})
+		if apierr.IsNotFound(err) {
+			if dryRun {
+				return "created", nil
+			}
+			_, err = configMaps.Create(ctx, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels, Annotations: annotations}, Data: data}, metav1.CreateOptions{})
+			return "created", err
+		}
+		if err != nil {
+			return "", err
+		}
+		changed := false
+		set := func(m *map[string]string, values map[string]string) {
+			for k, v := range values {
+				if *m == nil {
+					*m = map[string]string{}
+				}
+				if (*m)[k] != v {
+					(*m)[k] = v
+					changed = true
+				}
+			}
+		}
+		set(&cm.Labels, labels)
+		set(&cm.Annotations, annotations)
+		set(&cm.Data, data)
+		if !changed {
+			return "unchanged", nil
+		}
+		if !dryRun {
+			_, err = configMaps.Update(ctx, cm, metav1.UpdateOptions{})
+		}
+		return "configured", err
+	})
+}
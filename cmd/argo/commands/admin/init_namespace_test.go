@@ -0,0 +1,70 @@
+package admin
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+func TestInitNamespace(t *testing.T) {
+	ctx := context.Background()
+	profile := &namespaceProfile{
+		ServiceAccounts:    []string{"workflow"},
+		ArtifactRepository: &wfv1.ArtifactRepository{S3: &wfv1.S3ArtifactRepository{KeyFormat: "{{workflow.name}}"}},
+		ResourceQuota:      &corev1.ResourceQuotaSpec{Hard: corev1.ResourceList{corev1.ResourcePods: resource.MustParse("10")}},
+		WorkflowDefaults:   &wfv1.Workflow{Spec: wfv1.WorkflowSpec{ServiceAccountName: "workflow"}},
+	}
+
+	t.Run("DryRun", func(t *testing.T) {
+		kubeClient := kubefake.NewSimpleClientset()
+		results, err := initNamespace(ctx, kubeClient, "tenant", profile, true)
+		if assert.NoError(t, err) {
+			assert.Contains(t, results, "Namespace/tenant created (dry run)")
+			_, err := kubeClient.CoreV1().Namespaces().Get(ctx, "tenant", metav1.GetOptions{})
+			assert.Error(t, err)
+		}
+	})
+	t.Run("Idempotent", func(t *testing.T) {
+		kubeClient := kubefake.NewSimpleClientset(&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "tenant", Name: "artifact-repositories"},
+			Data:       map[string]string{"other": "gcs: {}"},
+		})
+		results, err := initNamespace(ctx, kubeClient, "tenant", profile, false)
+		if assert.NoError(t, err) {
+			assert.Equal(t, []string{
+				"Namespace/tenant created",
+				"ServiceAccount/workflow created",
+				"Role/executor created",
+				"RoleBinding/executor created",
+				"ConfigMap/artifact-repositories configured",
+				"ResourceQuota/argo-workflows created",
+				"ConfigMap/workflow-defaults created",
+			}, results)
+		}
+		cm, err := kubeClient.CoreV1().ConfigMaps("tenant").Get(ctx, "artifact-repositories", metav1.GetOptions{})
+		if assert.NoError(t, err) {
+			assert.Equal(t, "default-v1", cm.Annotations["workflows.argoproj.io/default-artifact-repository"])
+			assert.Contains(t, cm.Data, "other")
+			assert.Contains(t, cm.Data["default-v1"], "keyFormat")
+		}
+		cm, err = kubeClient.CoreV1().ConfigMaps("tenant").Get(ctx, "workflow-defaults", metav1.GetOptions{})
+		if assert.NoError(t, err) {
+			assert.Equal(t, common.LabelValueTypeConfigMapWorkflowDefaults, cm.Labels[common.LabelKeyConfigMapType])
+		}
+
+		results, err = initNamespace(ctx, kubeClient, "tenant", profile, false)
+		if assert.NoError(t, err) {
+			for _, r := range results {
+				assert.Contains(t, r, "unchanged")
+			}
+		}
+	})
+}
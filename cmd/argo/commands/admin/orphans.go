@@ -0,0 +1,198 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/client"
+	"github.com/argoproj/argo-workflows/v3/pkg/client/clientset/versioned"
+	"github.com/argoproj/argo-workflows/v3/util/errors"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+// orphan is a resource created by the controller whose owning workflow no longer exists
+type orphan struct {
+	namespace   string
+	kind        string
+	name        string
+	workflow    string
+	workflowUID string
+}
+
+type orphansFlags struct {
+	allNamespaces bool
+	delete        bool
+}
+
+func NewOrphansCommand() *cobra.Command {
+	var flags orphansFlags
+	command := &cobra.Command{
+		Use:   "orphans",
+		Short: "find (and optionally delete) pods, PVCs and config maps whose owning workflow is gone",
+		Example: `# List orphaned resources in the current namespace:
+
+  argo admin orphans
+
+# List orphaned resources in all namespaces:
+
+  argo admin orphans -A
+
+# Delete orphaned resources:
+
+  argo admin orphans --delete
+`,
+		Run: func(cmd *cobra.Command, args []string) {
+			restConfig, err := client.GetConfig().ClientConfig()
+			errors.CheckError(err)
+			kubeClient := kubernetes.NewForConfigOrDie(restConfig)
+			wfClient := versioned.NewForConfigOrDie(restConfig)
+			namespace := client.Namespace()
+			if flags.allNamespaces {
+				namespace = ""
+			}
+			ctx := cmd.Context()
+			orphans, err := findOrphans(ctx, kubeClient, wfClient, namespace)
+			errors.CheckError(err)
+			if len(orphans) == 0 {
+				fmt.Printf("No orphaned resources found\n")
+				return
+			}
+			printOrphans(orphans)
+			if flags.delete {
+				for _, o := range orphans {
+					deleted, err := deleteOrphan(ctx, kubeClient, wfClient, o)
+					errors.CheckError(err)
+					if deleted {
+						fmt.Printf("%s/%s deleted\n", o.kind, o.name)
+					} else {
+						fmt.Printf("%s/%s not deleted, its workflow exists\n", o.kind, o.name)
+					}
+				}
+			}
+		},
+	}
+	command.Flags().BoolVarP(&flags.allNamespaces, "all-namespaces", "A", false, "Look for orphaned resources in all namespaces")
+	command.Flags().BoolVar(&flags.delete, "delete", false, "Delete the orphaned resources that are found")
+	return command
+}
+
+// findOrphans lists the resources labelled as belonging to a workflow, and returns those whose workflow no longer exists.
+// Resources labelled with a workflow UID are matched by UID, older resources that only carry the workflow name are matched by name.
+// The resources are listed before the workflows, so that the workflow of a resource created in between is always seen.
+// Memoization caches are shared by workflows, and only labelled with the workflow that last saved to them, so they are
+// never reported.
+func findOrphans(ctx context.Context, kubeClient kubernetes.Interface, wfClient versioned.Interface, namespace string) ([]orphan, error) {
+	opts := metav1.ListOptions{LabelSelector: common.LabelKeyWorkflow}
+	var candidates []orphan
+
+	pods, err := kubeClient.CoreV1().Pods(namespace).List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	for _, pod := range pods.Items {
+		candidates = append(candidates, newOrphan("Pod", pod.ObjectMeta))
+	}
+
+	pvcs, err := kubeClient.CoreV1().PersistentVolumeClaims(namespace).List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	for _, pvc := range pvcs.Items {
+		candidates = append(candidates, newOrphan("PersistentVolumeClaim", pvc.ObjectMeta))
+	}
+
+	cms, err := kubeClient.CoreV1().ConfigMaps(namespace).List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	for _, cm := range cms.Items {
+		if cm.Labels[common.LabelKeyConfigMapType] == common.LabelValueTypeConfigMapCache {
+			continue
+		}
+		candidates = append(candidates, newOrphan("ConfigMap", cm.ObjectMeta))
+	}
+
+	wfList, err := wfClient.ArgoprojV1alpha1().Workflows(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	uids := make(map[types.UID]bool)
+	names := make(map[string]bool)
+	for _, wf := range wfList.Items {
+		uids[wf.UID] = true
+		names[wf.Namespace+"/"+wf.Name] = true
+	}
+	var orphans []orphan
+	for _, o := range candidates {
+		if o.workflowUID != "" {
+			if uids[types.UID(o.workflowUID)] {
+				continue
+			}
+		} else if names[o.namespace+"/"+o.workflow] {
+			continue
+		}
+		orphans = append(orphans, o)
+	}
+	return orphans, nil
+}
+
+func newOrphan(kind string, m metav1.ObjectMeta) orphan {
+	return orphan{
+		namespace:   m.Namespace,
+		kind:        kind,
+		name:        m.Name,
+		workflow:    m.Labels[common.LabelKeyWorkflow],
+		workflowUID: m.Labels[common.LabelKeyWorkflowUID],
+	}
+}
+
+// workflowExists returns whether the workflow of the orphan exists, e.g. because it was created after the orphans were found
+func workflowExists(ctx context.Context, wfClient versioned.Interface, o orphan) (bool, error) {
+	wf, err := wfClient.ArgoprojV1alpha1().Workflows(o.namespace).Get(ctx, o.workflow, metav1.GetOptions{})
+	if apierr.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return o.workflowUID == "" || string(wf.UID) == o.workflowUID, nil
+}
+
+// deleteOrphan deletes the orphan, unless its workflow exists by now, and returns whether it was deleted
+func deleteOrphan(ctx context.Context, kubeClient kubernetes.Interface, wfClient versioned.Interface, o orphan) (bool, error) {
+	exists, err := workflowExists(ctx, wfClient, o)
+	if err != nil || exists {
+		return false, err
+	}
+	switch o.kind {
+	case "Pod":
+		err = kubeClient.CoreV1().Pods(o.namespace).Delete(ctx, o.name, metav1.DeleteOptions{})
+	case "PersistentVolumeClaim":
+		err = kubeClient.CoreV1().PersistentVolumeClaims(o.namespace).Delete(ctx, o.name, metav1.DeleteOptions{})
+	case "ConfigMap":
+		err = kubeClient.CoreV1().ConfigMaps(o.namespace).Delete(ctx, o.name, metav1.DeleteOptions{})
+	default:
+		return false, fmt.Errorf("unknown kind %q", o.kind)
+	}
+	if err != nil && !apierr.IsNotFound(err) {
+		return false, err
+	}
+	return true, nil
+}
+
+func printOrphans(orphans []orphan) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	_, _ = fmt.Fprint(w, "NAMESPACE\tKIND\tNAME\tWORKFLOW\tWORKFLOW UID\n")
+	for _, o := range orphans {
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", o.namespace, o.kind, o.name, o.workflow, o.workflowUID)
+	}
+	_ = w.Flush()
+}
@@ -0,0 +1,68 @@
+package admin
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	wffake "github.com/argoproj/argo-workflows/v3/pkg/client/clientset/versioned/fake"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+func meta(name string, labels map[string]string) metav1.ObjectMeta {
+	return metav1.ObjectMeta{Namespace: "argo", Name: name, Labels: labels}
+}
+
+func TestFindOrphans(t *testing.T) {
+	ctx := context.Background()
+	wfClient := wffake.NewSimpleClientset(&wfv1.Workflow{ObjectMeta: metav1.ObjectMeta{Namespace: "argo", Name: "live", UID: "live-uid"}})
+	kubeClient := kubefake.NewSimpleClientset(
+		&corev1.Pod{ObjectMeta: meta("live-pod", map[string]string{common.LabelKeyWorkflow: "live", common.LabelKeyWorkflowUID: "live-uid"})},
+		&corev1.Pod{ObjectMeta: meta("legacy-live-pod", map[string]string{common.LabelKeyWorkflow: "live"})},
+		&corev1.Pod{ObjectMeta: meta("reused-name-pod", map[string]string{common.LabelKeyWorkflow: "live", common.LabelKeyWorkflowUID: "old-uid"})},
+		&corev1.Pod{ObjectMeta: meta("legacy-gone-pod", map[string]string{common.LabelKeyWorkflow: "gone"})},
+		&corev1.Pod{ObjectMeta: meta("unrelated-pod", nil)},
+		&corev1.PersistentVolumeClaim{ObjectMeta: meta("gone-pvc", map[string]string{common.LabelKeyWorkflow: "gone", common.LabelKeyWorkflowUID: "gone-uid"})},
+		&corev1.ConfigMap{ObjectMeta: meta("gone-cache", map[string]string{
+			common.LabelKeyWorkflow:      "gone",
+			common.LabelKeyWorkflowUID:   "gone-uid",
+			common.LabelKeyConfigMapType: common.LabelValueTypeConfigMapCache,
+		})},
+	)
+
+	t.Run("Find", func(t *testing.T) {
+		orphans, err := findOrphans(ctx, kubeClient, wfClient, "argo")
+		if assert.NoError(t, err) {
+			var names []string
+			for _, o := range orphans {
+				names = append(names, o.name)
+			}
+			// memoization caches are shared by workflows, so they are never orphans
+			assert.ElementsMatch(t, []string{"reused-name-pod", "legacy-gone-pod", "gone-pvc"}, names)
+		}
+	})
+	t.Run("Delete", func(t *testing.T) {
+		deleted, err := deleteOrphan(ctx, kubeClient, wfClient, orphan{namespace: "argo", kind: "PersistentVolumeClaim", name: "gone-pvc", workflow: "gone", workflowUID: "gone-uid"})
+		if assert.NoError(t, err) {
+			assert.True(t, deleted)
+			_, err := kubeClient.CoreV1().PersistentVolumeClaims("argo").Get(ctx, "gone-pvc", metav1.GetOptions{})
+			assert.Error(t, err)
+		}
+	})
+	t.Run("WorkflowCreatedSinceFound", func(t *testing.T) {
+		_, err := wfClient.ArgoprojV1alpha1().Workflows("argo").Create(ctx, &wfv1.Workflow{ObjectMeta: metav1.ObjectMeta{Namespace: "argo", Name: "gone"}}, metav1.CreateOptions{})
+		if assert.NoError(t, err) {
+			deleted, err := deleteOrphan(ctx, kubeClient, wfClient, orphan{namespace: "argo", kind: "Pod", name: "legacy-gone-pod", workflow: "gone"})
+			if assert.NoError(t, err) {
+				assert.False(t, deleted)
+				_, err := kubeClient.CoreV1().Pods("argo").Get(ctx, "legacy-gone-pod", metav1.GetOptions{})
+				assert.NoError(t, err)
+			}
+		}
+	})
+}
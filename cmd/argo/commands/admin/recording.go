@@ -0,0 +1,65 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/client"
+	"github.com/argoproj/argo-workflows/v3/util/errors"
+	"github.com/argoproj/argo-workflows/v3/workflow/diagnostics"
+	"github.com/argoproj/argo-workflows/v3/workflow/recording"
+)
+
+func NewControllerRecordingCommand() *cobra.Command {
+	var pod string
+	command := &cobra.Command{
+		Use:   "recording WORKFLOW",
+		Short: "print the recorded reconciliations of a workflow, to replay them with `argo admin replay`",
+		Long: `Print the reconciliations the workflow controller recorded of a workflow with the workflows.argoproj.io/record-reconciliations: "true" annotation, or named by the controller's --record-workflows flag.
+
+The workflow is given as namespace/name, a workflow without a namespace is in the controller's namespace. The controller keeps the last ` + strconv.Itoa(recording.MaxReconciliations) + ` reconciliations of each workflow in memory, until it restarts.`,
+		Example: `# Save the recorded reconciliations of a workflow in the "my-ns" namespace, with the controller in the "argo" namespace:
+
+  argo admin controller recording my-ns/my-wf -n argo > my-wf.jsonl
+`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			restConfig, err := client.GetConfig().ClientConfig()
+			errors.CheckError(err)
+			kubeClient := kubernetes.NewForConfigOrDie(restConfig)
+			key := args[0]
+			if !strings.Contains(key, "/") {
+				key = client.Namespace() + "/" + key
+			}
+			data, err := getControllerRecording(cmd.Context(), kubeClient, client.Namespace(), client.InstanceID(), pod, key)
+			errors.CheckError(err)
+			_, err = os.Stdout.Write(data)
+			errors.CheckError(err)
+		},
+	}
+	command.Flags().StringVar(&pod, "pod", "", "Controller pod to query, instead of the leader")
+	return command
+}
+
+// getControllerRecording reads the recording of the workflow with the key from the leading controller, unless a pod is
+// given, through the API server's pod proxy
+func getControllerRecording(ctx context.Context, kubeClient kubernetes.Interface, namespace, instanceID, pod, key string) ([]byte, error) {
+	if pod == "" {
+		lease, err := getLeaderLease(ctx, kubeClient, namespace, instanceID)
+		if err != nil {
+			return nil, err
+		}
+		pod = *lease.Spec.HolderIdentity
+	}
+	data, err := kubeClient.CoreV1().Pods(namespace).ProxyGet("http", pod, strconv.Itoa(diagnostics.Port), recording.Path+key, nil).DoRaw(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the recording of %s from controller pod %s/%s: %w", key, namespace, pod, err)
+	}
+	return data, nil
+}
@@ -0,0 +1,20 @@
+package admin
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func NewAdminCommand() *cobra.Command {
+	command := &cobra.Command{
+		Use:   "admin",
+		Short: "administrative commands for cluster operators",
+		Run: func(cmd *cobra.Command, args []string) {
+			cmd.HelpFunc()(cmd, args)
+		},
+	}
+
+	command.AddCommand(NewInitNamespaceCommand())
+	command.AddCommand(NewControllerCommand())
+	command.AddCommand(NewOrphansCommand())
+	return command
+}
@@ -3,11 +3,11 @@ package archive
 import (
 	"fmt"
 
-	"github.com/argoproj/pkg/errors"
 	"github.com/spf13/cobra"
 
 	client "github.com/argoproj/argo-workflows/v3/cmd/argo/commands/client"
 	workflowarchivepkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflowarchive"
+	"github.com/argoproj/argo-workflows/v3/util/errors"
 )
 
 func NewDeleteCommand() *cobra.Command {
@@ -6,7 +6,6 @@ import (
 	"log"
 	"os"
 
-	"github.com/argoproj/pkg/errors"
 	"github.com/argoproj/pkg/humanize"
 	"github.com/spf13/cobra"
 	"sigs.k8s.io/yaml"
@@ -14,6 +13,7 @@ import (
 	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/client"
 	workflowarchivepkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflowarchive"
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/util/errors"
 )
 
 func NewGetCommand() *cobra.Command {
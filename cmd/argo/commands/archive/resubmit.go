@@ -3,7 +3,6 @@ package archive
 import (
 	"context"
 
-	"github.com/argoproj/pkg/errors"
 	"github.com/spf13/cobra"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -13,6 +12,7 @@ import (
 	workflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
 	workflowarchivepkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflowarchive"
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/util/errors"
 )
 
 type resubmitOps struct {
@@ -39,10 +39,15 @@ func NewResubmitCommand() *cobra.Command {
 	command := &cobra.Command{
 		Use:   "resubmit [WORKFLOW...]",
 		Short: "resubmit one or more workflows",
+		Long:  "Resubmit archived workflows, given by their UIDs, even if they have been deleted from the cluster. Each is reconstructed from the archive, keeping its spec, labels, annotations, and owner references, with any parameters overridden.",
 		Example: `# Resubmit a workflow:
 
   argo archive resubmit uid
 
+# Resubmit a workflow with a parameter overridden:
+
+  argo archive resubmit uid -p message=hello
+
 # Resubmit multiple workflows:
 
   argo archive resubmit uid another-uid
@@ -0,0 +1,104 @@
+package artifacts
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/client"
+	workflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/util/errors"
+	"github.com/argoproj/argo-workflows/v3/util/printer"
+)
+
+type gcOps struct {
+	dryRun        bool   // --dry-run
+	output        string // --output
+	labelSelector string // --selector
+}
+
+func NewGCCommand() *cobra.Command {
+	var gcOpts gcOps
+	command := &cobra.Command{
+		Use:   "gc [WORKFLOW...]",
+		Short: "garbage collect the artifacts of zero or more workflows",
+		Long: `Retry the artifact garbage collection of workflows, for example after fixing the permissions that made it fail.
+
+With --dry-run, print the artifacts that garbage collection would delete according to their current strategies, with their keys and sizes, and the garbage collection attempts that failed, without deleting anything.`,
+		Example: `# Print the artifacts that would be deleted, and previous failures:
+
+  argo artifacts gc --dry-run my-wf
+
+# Print the artifacts that would be deleted for workflows by label selector:
+
+  argo artifacts gc --dry-run -l workflows.argoproj.io/test=true
+
+# Retry garbage collection of the artifacts that failed to be deleted:
+
+  argo artifacts gc my-wf
+`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) == 0 && gcOpts.labelSelector == "" {
+				cmd.HelpFunc()(cmd, args)
+				os.Exit(1)
+			}
+			ctx, apiClient := client.NewAPIClient(cmd.Context())
+			serviceClient := apiClient.NewWorkflowServiceClient()
+			err := gcWorkflowArtifacts(ctx, serviceClient, client.Namespace(), gcOpts, args)
+			errors.CheckError(err)
+		},
+	}
+	command.Flags().BoolVar(&gcOpts.dryRun, "dry-run", false, "print the artifacts that would be deleted and previous failures, without deleting anything")
+	command.Flags().StringVarP(&gcOpts.output, "output", "o", "", "Output format of --dry-run. One of: json|yaml")
+	command.Flags().StringVarP(&gcOpts.labelSelector, "selector", "l", "", "Selector (label query) to filter on, not including uninitialized ones, supports '=', '==', and '!='.(e.g. -l key1=value1,key2=value2)")
+	return command
+}
+
+// gcWorkflowArtifacts prints the artifact garbage collection reports of the workflows, or retries their garbage collection
+func gcWorkflowArtifacts(ctx context.Context, serviceClient workflowpkg.WorkflowServiceClient, namespace string, gcOpts gcOps, args []string) error {
+	names := args
+	if gcOpts.labelSelector != "" {
+		wfList, err := serviceClient.ListWorkflows(ctx, &workflowpkg.WorkflowListRequest{
+			Namespace:   namespace,
+			ListOptions: &metav1.ListOptions{LabelSelector: gcOpts.labelSelector},
+			Fields:      "items.metadata.name",
+		})
+		if err != nil {
+			return err
+		}
+		for _, wf := range wfList.Items {
+			names = append(names, wf.Name)
+		}
+	}
+
+	reports := make(map[string]*wfv1.ArtifactGCReport)
+	seen := make(map[string]bool)
+	for _, name := range names {
+		if seen[name] {
+			// de-duplication in case there is an overlap between the selector and given workflow names
+			continue
+		}
+		seen[name] = true
+		req := &workflowpkg.WorkflowArtifactGCRequest{Name: name, Namespace: namespace}
+		if gcOpts.dryRun {
+			report, err := serviceClient.GetWorkflowArtifactGC(ctx, req)
+			if err != nil {
+				return err
+			}
+			reports[name] = report
+			continue
+		}
+		if _, err := serviceClient.RetryWorkflowArtifactGC(ctx, req); err != nil {
+			return err
+		}
+		fmt.Printf("Workflow %s artifact garbage collection retried\n", name)
+	}
+	if gcOpts.dryRun {
+		return printer.PrintArtifactGCReports(reports, os.Stdout, printer.PrintOpts{Output: gcOpts.output})
+	}
+	return nil
+}
@@ -0,0 +1,18 @@
+package artifacts
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func NewArtifactsCommand() *cobra.Command {
+	command := &cobra.Command{
+		Use:   "artifacts",
+		Short: "manage the artifacts of workflows",
+		Run: func(cmd *cobra.Command, args []string) {
+			cmd.HelpFunc()(cmd, args)
+		},
+	}
+
+	command.AddCommand(NewGCCommand())
+	return command
+}
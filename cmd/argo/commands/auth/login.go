@@ -0,0 +1,56 @@
+package auth
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/client"
+	"github.com/argoproj/argo-workflows/v3/server/auth/sso"
+	"github.com/argoproj/argo-workflows/v3/util/errors"
+)
+
+func NewLoginCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "login",
+		Short: "Log in to the Argo Server with SSO",
+		Long: `Log in to the Argo Server with its SSO provider, using the OAuth2 device authorization grant, so that you can log in on a machine without a browser by opening a link on another device.
+
+The token is cached in ~/.config/argo/tokens.json and used by every command that connects to the same Argo Server, unless ARGO_TOKEN is set. It is refreshed when it expires if the SSO provider gave a refresh token, for which the "offline_access" scope may need to be in the server's SSO scopes. Requires the Argo Server, with SSO configured and an SSO provider that supports the device authorization grant.`,
+		Example: `# Log in to the Argo Server:
+  argo auth login --argo-server argo.example.com:443
+
+# Print the cached token:
+  argo auth token`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			if client.ArgoServerOpts.URL == "" {
+				log.Fatal("argo auth login needs the Argo Server, set --argo-server or ARGO_SERVER")
+			}
+			ctx := cmd.Context()
+			da, err := client.StartDeviceAuth(ctx)
+			errors.CheckError(err)
+			if da.VerificationURIComplete != "" {
+				fmt.Printf("To log in, open %s and check that the code is %s\n", da.VerificationURIComplete, da.UserCode)
+			} else {
+				fmt.Printf("To log in, open %s and enter the code %s\n", da.VerificationURI, da.UserCode)
+			}
+			form := url.Values{
+				"grant_type":  {sso.GrantTypeDeviceCode},
+				"device_code": {da.DeviceCode},
+				"interval":    {strconv.FormatInt(da.Interval, 10)},
+			}
+			if !da.Expiry.IsZero() {
+				form.Set("expires_in", strconv.FormatInt(int64(time.Until(da.Expiry).Seconds()), 10))
+			}
+			token, err := client.RequestToken(ctx, form)
+			errors.CheckError(err)
+			errors.CheckError(client.SaveToken(*token))
+			fmt.Printf("Logged in to %s until %s\n", client.ArgoServerOpts.URL, token.Expiry.Local().Format(time.RFC3339))
+		},
+	}
+}
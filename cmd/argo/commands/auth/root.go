@@ -12,6 +12,8 @@ func NewAuthCommand() *cobra.Command {
 			cmd.HelpFunc()(cmd, args)
 		},
 	}
+	command.AddCommand(NewLoginCommand())
 	command.AddCommand(NewTokenCommand())
+	command.AddCommand(NewWhoamiCommand())
 	return command
 }
@@ -0,0 +1,120 @@
+package auth
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+
+	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/client"
+	infopkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/info"
+	"github.com/argoproj/argo-workflows/v3/util/errors"
+)
+
+func NewWhoamiCommand() *cobra.Command {
+	var output string
+	command := &cobra.Command{
+		Use:   "whoami",
+		Short: "Print the identity and permissions of the current user",
+		Long:  "Print the identity the Argo Server resolved for the current token (subject, groups and service account), the namespaces it can access and the verbs it is permitted for workflows and templates. Requires the Argo Server.",
+		Example: `# Print the identity and permissions in the current namespace:
+  argo auth whoami
+
+# Print the permissions in another namespace:
+  argo auth whoami -n my-ns
+
+# Print as JSON:
+  argo auth whoami -o json`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 0 {
+				cmd.HelpFunc()(cmd, args)
+				os.Exit(1)
+			}
+			ctx, apiClient := client.NewAPIClient(cmd.Context())
+			serviceClient, err := apiClient.NewInfoServiceClient()
+			errors.CheckError(err)
+			namespace := client.Namespace()
+			info, err := serviceClient.GetUserInfo(ctx, &infopkg.GetUserInfoRequest{Namespace: namespace})
+			errors.CheckError(err)
+			errors.CheckError(printUserInfo(os.Stdout, info, namespace, output))
+		},
+	}
+	command.Flags().StringVarP(&output, "output", "o", "wide", "Output format. One of: json|yaml|wide")
+	return command
+}
+
+func printUserInfo(w io.Writer, info *infopkg.GetUserInfoResponse, namespace, output string) error {
+	switch output {
+	case "json":
+		data, err := json.MarshalIndent(info, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(data))
+		return err
+	case "yaml":
+		data, err := yaml.Marshal(info)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprint(w, string(data))
+		return err
+	case "wide", "":
+	default:
+		return fmt.Errorf("unknown output format: %s", output)
+	}
+	const fmtStr = "%-20s %v\n"
+	if info.Subject != "" {
+		fmt.Fprintf(w, fmtStr, "Subject:", info.Subject)
+	}
+	if info.Issuer != "" {
+		fmt.Fprintf(w, fmtStr, "Issuer:", info.Issuer)
+	}
+	if info.Name != "" {
+		fmt.Fprintf(w, fmtStr, "Name:", info.Name)
+	}
+	if info.Email != "" {
+		fmt.Fprintf(w, fmtStr, "Email:", fmt.Sprintf("%s (verified: %v)", info.Email, info.EmailVerified))
+	}
+	if len(info.Groups) > 0 {
+		fmt.Fprintf(w, fmtStr, "Groups:", strings.Join(info.Groups, ", "))
+	}
+	if info.ServiceAccountName != "" {
+		fmt.Fprintf(w, fmtStr, "ServiceAccount:", info.ServiceAccountNamespace+"/"+info.ServiceAccountName)
+	}
+	namespaces := "none"
+	if len(info.Namespaces) == 1 && info.Namespaces[0] == "*" {
+		namespaces = "all"
+	} else if len(info.Namespaces) > 0 {
+		namespaces = strings.Join(info.Namespaces, ", ")
+	}
+	fmt.Fprintf(w, fmtStr, "Namespaces:", namespaces)
+	fmt.Fprintf(w, "\nPermissions in namespace %s:\n", namespace)
+	if len(info.Permissions) == 0 {
+		fmt.Fprintln(w, "none")
+		return nil
+	}
+	var resources []string
+	verbs := map[string][]string{}
+	for _, permission := range info.Permissions {
+		parts := strings.SplitN(permission, " ", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		if _, ok := verbs[parts[1]]; !ok {
+			resources = append(resources, parts[1])
+		}
+		verbs[parts[1]] = append(verbs[parts[1]], parts[0])
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "RESOURCE\tVERBS")
+	for _, resource := range resources {
+		_, _ = fmt.Fprintf(tw, "%s\t%s\n", resource, strings.Join(verbs[resource], ","))
+	}
+	return tw.Flush()
+}
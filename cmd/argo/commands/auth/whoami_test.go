@@ -0,0 +1,48 @@
+package auth
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	infopkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/info"
+)
+
+func Test_printUserInfo(t *testing.T) {
+	info := &infopkg.GetUserInfoResponse{
+		Subject:                 "my-sub",
+		Groups:                  []string{"admins", "devs"},
+		ServiceAccountName:      "my-sa",
+		ServiceAccountNamespace: "argo",
+		Namespaces:              []string{"*"},
+		Permissions:             []string{"get workflows", "create workflows", "list workflowtemplates"},
+	}
+	t.Run("Wide", func(t *testing.T) {
+		w := &bytes.Buffer{}
+		assert.NoError(t, printUserInfo(w, info, "my-ns", "wide"))
+		assert.Equal(t, `Subject:             my-sub
+Groups:              admins, devs
+ServiceAccount:      argo/my-sa
+Namespaces:          all
+
+Permissions in namespace my-ns:
+RESOURCE           VERBS
+workflows          get,create
+workflowtemplates  list
+`, w.String())
+	})
+	t.Run("NoPermissions", func(t *testing.T) {
+		w := &bytes.Buffer{}
+		assert.NoError(t, printUserInfo(w, &infopkg.GetUserInfoResponse{}, "my-ns", "wide"))
+		assert.Equal(t, "Namespaces:          none\n\nPermissions in namespace my-ns:\nnone\n", w.String())
+	})
+	t.Run("JSON", func(t *testing.T) {
+		w := &bytes.Buffer{}
+		assert.NoError(t, printUserInfo(w, info, "my-ns", "json"))
+		assert.Contains(t, w.String(), `"serviceAccountName": "my-sa"`)
+	})
+	t.Run("Unknown", func(t *testing.T) {
+		assert.EqualError(t, printUserInfo(&bytes.Buffer{}, info, "my-ns", "table"), "unknown output format: table")
+	})
+}
@@ -44,7 +44,7 @@ func GetConfig() clientcmd.ClientConfig {
 func AddAPIClientFlagsToCmd(cmd *cobra.Command) {
 	cmd.PersistentFlags().StringVar(&instanceID, "instanceid", os.Getenv("ARGO_INSTANCEID"), "submit with a specific controller's instance id label. Default to the ARGO_INSTANCEID environment variable.")
 	// "-s" like kubectl
-	cmd.PersistentFlags().StringVarP(&ArgoServerOpts.URL, "argo-server", "s", os.Getenv("ARGO_SERVER"), "API server `host:port`. e.g. localhost:2746. Defaults to the ARGO_SERVER environment variable.")
+	cmd.PersistentFlags().StringVarP(&ArgoServerOpts.URL, "argo-server", "s", os.Getenv("ARGO_SERVER"), "API server `host:port`. e.g. localhost:2746. A comma separated list of endpoints, or dns+srv:// and a DNS SRV name, fails over between the endpoints of a highly available server. Defaults to the ARGO_SERVER environment variable.")
 	cmd.PersistentFlags().StringVar(&ArgoServerOpts.Path, "argo-base-href", os.Getenv("ARGO_BASE_HREF"), "An path to use with HTTP client (e.g. due to BASE_HREF). Defaults to the ARGO_BASE_HREF environment variable.")
 	cmd.PersistentFlags().BoolVar(&ArgoServerOpts.HTTP1, "argo-http1", os.Getenv("ARGO_HTTP1") == "true", "If true, use the HTTP client. Defaults to the ARGO_HTTP1 environment variable.")
 	cmd.PersistentFlags().StringSliceVarP(&ArgoServerOpts.Headers, "header", "H", []string{}, "Sets additional header to all requests made by Argo CLI. (Can be repeated multiple times to add multiple headers, also supports comma separated headers) Used only when either ARGO_HTTP1 or --argo-http1 is set to true.")
@@ -73,6 +73,11 @@ func NewAPIClient(ctx context.Context) (context.Context, apiclient.Client) {
 	return ctx, client
 }
 
+// InstanceID returns the controller instance ID given by the --instanceid flag or the ARGO_INSTANCEID environment variable
+func InstanceID() string {
+	return instanceID
+}
+
 func Namespace() string {
 	if Offline {
 		return ""
@@ -96,6 +101,9 @@ func GetAuthString() string {
 	if ok {
 		return token
 	}
+	if token, ok := getCachedToken(); ok {
+		return token
+	}
 	restConfig, err := GetConfig().ClientConfig()
 	if err != nil {
 		log.Fatal(err)
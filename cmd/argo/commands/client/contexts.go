@@ -0,0 +1,156 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/yaml"
+
+	"github.com/argoproj/argo-workflows/v3/pkg/apiclient"
+)
+
+// Context is a cluster that commands run with --all-contexts work across, either by an Argo Server or by a kubeconfig
+// context
+type Context struct {
+	// Name is shown in the CONTEXT column of the output
+	Name string `json:"name"`
+	// ArgoServer is the host:port of the Argo Server of the cluster
+	ArgoServer string `json:"argoServer,omitempty"`
+	// BaseHref is the path of the Argo Server, if it is not served at the root
+	BaseHref string `json:"baseHref,omitempty"`
+	// Secure is whether the Argo Server uses TLS, true if unset
+	Secure *bool `json:"secure,omitempty"`
+	// InsecureSkipVerify skips verifying the Argo Server's certificate
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+	// HTTP1 uses the HTTP client rather than gRPC to connect to the Argo Server
+	HTTP1 bool `json:"http1,omitempty"`
+	// Token authenticates with the Argo Server, the token cached by `argo auth login` for it if unset
+	Token string `json:"token,omitempty"`
+	// KubeContext is the kubeconfig context of the cluster, if it is not reached by an Argo Server. It is the name of
+	// the context if both are unset.
+	KubeContext string `json:"kubeContext,omitempty"`
+	// Namespace is the namespace of the context, that of the kubeconfig context, or "default", if unset
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// ContextsPath returns the path of the file that the contexts of commands run with --all-contexts are read from
+func ContextsPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".config", "argo", "contexts.yaml"), nil
+}
+
+// Contexts returns the contexts of the contexts file or, if there is none, a context for each kubeconfig context
+func Contexts() ([]Context, error) {
+	path, err := ContextsPath()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return kubeContexts()
+	} else if err != nil {
+		return nil, err
+	}
+	return parseContexts(data)
+}
+
+func parseContexts(data []byte) ([]Context, error) {
+	var contexts []Context
+	if err := yaml.UnmarshalStrict(data, &contexts); err != nil {
+		return nil, fmt.Errorf("failed to parse the contexts: %w", err)
+	}
+	names := make(map[string]bool)
+	for _, c := range contexts {
+		if c.Name == "" {
+			return nil, fmt.Errorf("a context has no name")
+		}
+		if names[c.Name] {
+			return nil, fmt.Errorf("there is more than one context named %q", c.Name)
+		}
+		names[c.Name] = true
+		if c.ArgoServer != "" && c.KubeContext != "" {
+			return nil, fmt.Errorf("context %q cannot have both an Argo Server and a kubeconfig context", c.Name)
+		}
+	}
+	return contexts, nil
+}
+
+func kubeContexts() ([]Context, error) {
+	config, err := GetConfig().RawConfig()
+	if err != nil {
+		return nil, err
+	}
+	var contexts []Context
+	for name := range config.Contexts {
+		contexts = append(contexts, Context{Name: name, KubeContext: name})
+	}
+	sort.Slice(contexts, func(i, j int) bool { return contexts[i].Name < contexts[j].Name })
+	return contexts, nil
+}
+
+func (c Context) clientConfig() clientcmd.ClientConfig {
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	loadingRules.DefaultClientConfig = &clientcmd.DefaultClientConfig
+	loadingRules.ExplicitPath = explicitPath
+	kubeContext := c.KubeContext
+	if kubeContext == "" {
+		kubeContext = c.Name
+	}
+	return clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, &clientcmd.ConfigOverrides{CurrentContext: kubeContext})
+}
+
+// NewAPIClient returns a client of the context, and the context's namespace
+func (c Context) NewAPIClient(ctx context.Context) (context.Context, apiclient.Client, string, error) {
+	opts := apiclient.Opts{Context: ctx}
+	if c.ArgoServer != "" {
+		opts.ArgoServerOpts = apiclient.ArgoServerOpts{
+			URL:                c.ArgoServer,
+			Path:               c.BaseHref,
+			Secure:             c.Secure == nil || *c.Secure,
+			InsecureSkipVerify: c.InsecureSkipVerify,
+			HTTP1:              c.HTTP1,
+		}
+		opts.AuthSupplier = func() string { return c.token() }
+	} else {
+		opts.InstanceID = instanceID
+		opts.ClientConfigSupplier = c.clientConfig
+	}
+	ctx, client, err := apiclient.NewClientFromOpts(opts)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	namespace := c.Namespace
+	if namespace == "" && c.ArgoServer == "" {
+		namespace, _, err = c.clientConfig().Namespace()
+		if err != nil {
+			return nil, nil, "", err
+		}
+	}
+	if namespace == "" {
+		namespace = "default"
+	}
+	return ctx, client, namespace, nil
+}
+
+// token returns the token of the context, or else the unexpired token cached for its Argo Server
+func (c Context) token() string {
+	if c.Token != "" {
+		return c.Token
+	}
+	tokens, err := loadTokens()
+	if err != nil {
+		return ""
+	}
+	if token, ok := tokens[c.ArgoServer]; ok && time.Until(token.Expiry) > tokenRefreshMargin {
+		return token.Token
+	}
+	return ""
+}
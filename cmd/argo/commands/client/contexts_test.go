@@ -0,0 +1,77 @@
+package client
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_parseContexts(t *testing.T) {
+	contexts, err := parseContexts([]byte(`
+- name: east
+  argoServer: argo-east.example.com:443
+  namespace: argo
+- name: west
+  kubeContext: west-admin
+`))
+	require.NoError(t, err)
+	assert.Equal(t, []Context{
+		{Name: "east", ArgoServer: "argo-east.example.com:443", Namespace: "argo"},
+		{Name: "west", KubeContext: "west-admin"},
+	}, contexts)
+
+	for data, message := range map[string]string{
+		`[{argoServer: localhost:2746}]`:                             "a context has no name",
+		`[{name: east}, {name: east}]`:                               `there is more than one context named "east"`,
+		`[{name: east, argoServer: localhost:2746, kubeContext: x}]`: `context "east" cannot have both an Argo Server and a kubeconfig context`,
+		`[{name: east, server: localhost:2746}]`:                     "failed to parse the contexts",
+	} {
+		_, err := parseContexts([]byte(data))
+		assert.ErrorContains(t, err, message, data)
+	}
+}
+
+func TestContexts(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	kubeconfig := filepath.Join(t.TempDir(), "config")
+	require.NoError(t, os.WriteFile(kubeconfig, []byte(`
+apiVersion: v1
+kind: Config
+clusters:
+- name: west
+  cluster: {server: https://west.example.com}
+- name: east
+  cluster: {server: https://east.example.com}
+users:
+- name: admin
+contexts:
+- name: west
+  context: {cluster: west, user: admin, namespace: argo}
+- name: east
+  context: {cluster: east, user: admin}
+current-context: east
+`), 0o600))
+	explicitPath = kubeconfig
+	defer func() { explicitPath = "" }()
+
+	t.Run("Kubeconfig", func(t *testing.T) {
+		contexts, err := Contexts()
+		require.NoError(t, err)
+		assert.Equal(t, []Context{{Name: "east", KubeContext: "east"}, {Name: "west", KubeContext: "west"}}, contexts)
+		namespace, _, err := contexts[1].clientConfig().Namespace()
+		require.NoError(t, err)
+		assert.Equal(t, "argo", namespace)
+	})
+	t.Run("File", func(t *testing.T) {
+		path, err := ContextsPath()
+		require.NoError(t, err)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o700))
+		require.NoError(t, os.WriteFile(path, []byte(`[{name: prod, kubeContext: west}]`), 0o600))
+		contexts, err := Contexts()
+		require.NoError(t, err)
+		assert.Equal(t, []Context{{Name: "prod", KubeContext: "west"}}, contexts)
+	})
+}
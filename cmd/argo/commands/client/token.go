@@ -0,0 +1,141 @@
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/oauth2"
+
+	"github.com/argoproj/argo-workflows/v3/server/auth/sso"
+)
+
+// tokenRefreshMargin is how long before it expires that a cached token is refreshed
+const tokenRefreshMargin = time.Minute
+
+// TokenCachePath returns the path of the file that the tokens of `argo auth login` are cached in, by Argo Server
+func TokenCachePath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".config", "argo", "tokens.json"), nil
+}
+
+func loadTokens() (map[string]sso.TokenResponse, error) {
+	path, err := TokenCachePath()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return map[string]sso.TokenResponse{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+	tokens := map[string]sso.TokenResponse{}
+	return tokens, json.Unmarshal(data, &tokens)
+}
+
+// SaveToken caches the token of the Argo Server, so that it is used until it expires, and then refreshed
+func SaveToken(token sso.TokenResponse) error {
+	tokens, err := loadTokens()
+	if err != nil {
+		return err
+	}
+	tokens[ArgoServerOpts.URL] = token
+	data, err := json.Marshal(tokens)
+	if err != nil {
+		return err
+	}
+	path, err := TokenCachePath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o600)
+}
+
+// getCachedToken returns the cached token of the Argo Server, refreshing it if it has expired
+func getCachedToken() (string, bool) {
+	if ArgoServerOpts.URL == "" {
+		return "", false
+	}
+	tokens, err := loadTokens()
+	if err != nil {
+		log.WithError(err).Warn("Failed to read the cached tokens")
+		return "", false
+	}
+	token, ok := tokens[ArgoServerOpts.URL]
+	if !ok {
+		return "", false
+	}
+	if time.Until(token.Expiry) > tokenRefreshMargin {
+		return token.Token, true
+	}
+	if token.RefreshToken == "" {
+		log.Warn("The cached token has expired, log in again with `argo auth login`")
+		return "", false
+	}
+	refreshed, err := RequestToken(context.Background(), url.Values{"grant_type": {sso.GrantTypeRefreshToken}, "refresh_token": {token.RefreshToken}})
+	if err != nil {
+		log.WithError(err).Warn("Failed to refresh the cached token, log in again with `argo auth login`")
+		return "", false
+	}
+	if err := SaveToken(*refreshed); err != nil {
+		log.WithError(err).Warn("Failed to cache the refreshed token")
+	}
+	return refreshed.Token, true
+}
+
+// StartDeviceAuth starts logging in to the Argo Server's SSO provider with the device authorization grant
+func StartDeviceAuth(ctx context.Context) (*oauth2.DeviceAuthResponse, error) {
+	da := &oauth2.DeviceAuthResponse{}
+	return da, postOAuth2(ctx, "device", url.Values{}, da)
+}
+
+// RequestToken requests a token of the Argo Server, for a device code or a refresh token
+func RequestToken(ctx context.Context, form url.Values) (*sso.TokenResponse, error) {
+	token := &sso.TokenResponse{}
+	return token, postOAuth2(ctx, "token", form, token)
+}
+
+func postOAuth2(ctx context.Context, endpoint string, form url.Values, v interface{}) error {
+	baseURL, err := ArgoServerOpts.ResolveURL()
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(baseURL, "/")+"/oauth2/"+endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	httpClient := &http.Client{Transport: &http.Transport{
+		TLSClientConfig: &tls.Config{InsecureSkipVerify: ArgoServerOpts.InsecureSkipVerify},
+		Proxy:           http.ProxyFromEnvironment,
+	}}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s: %s", resp.Status, strings.TrimSpace(string(data)))
+	}
+	return json.Unmarshal(data, v)
+}
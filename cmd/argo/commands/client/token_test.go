@@ -0,0 +1,50 @@
+package client
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/argoproj/argo-workflows/v3/pkg/apiclient"
+	"github.com/argoproj/argo-workflows/v3/server/auth/sso"
+)
+
+func TestCachedToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/oauth2/token", r.URL.Path)
+		assert.NoError(t, r.ParseForm())
+		assert.Equal(t, sso.GrantTypeRefreshToken, r.PostForm.Get("grant_type"))
+		assert.Equal(t, "my-refresh-token", r.PostForm.Get("refresh_token"))
+		_, _ = w.Write([]byte(`{"token": "Bearer v2:refreshed", "expiry": "2099-01-01T00:00:00Z", "refreshToken": "my-refresh-token"}`))
+	}))
+	defer server.Close()
+	t.Setenv("HOME", t.TempDir())
+	defer func(o apiclient.ArgoServerOpts) { ArgoServerOpts = o }(ArgoServerOpts)
+	ArgoServerOpts = apiclient.ArgoServerOpts{URL: strings.TrimPrefix(server.URL, "http://")}
+
+	_, ok := getCachedToken()
+	assert.False(t, ok, "no token is cached")
+
+	require.NoError(t, SaveToken(sso.TokenResponse{Token: "Bearer v2:cached", Expiry: time.Now().Add(time.Hour), RefreshToken: "my-refresh-token"}))
+	token, ok := getCachedToken()
+	assert.True(t, ok)
+	assert.Equal(t, "Bearer v2:cached", token)
+	assert.Equal(t, "Bearer v2:cached", GetAuthString())
+
+	require.NoError(t, SaveToken(sso.TokenResponse{Token: "Bearer v2:cached", Expiry: time.Now(), RefreshToken: "my-refresh-token"}))
+	token, ok = getCachedToken()
+	assert.True(t, ok)
+	assert.Equal(t, "Bearer v2:refreshed", token)
+	tokens, err := loadTokens()
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer v2:refreshed", tokens[ArgoServerOpts.URL].Token, "the refreshed token is cached")
+
+	require.NoError(t, SaveToken(sso.TokenResponse{Token: "Bearer v2:cached", Expiry: time.Now()}))
+	_, ok = getCachedToken()
+	assert.False(t, ok, "an expired token without a refresh token is not used")
+}
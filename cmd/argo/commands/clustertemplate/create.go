@@ -9,9 +9,10 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/client"
+	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/common"
 	"github.com/argoproj/argo-workflows/v3/pkg/apiclient/clusterworkflowtemplate"
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
-	"github.com/argoproj/argo-workflows/v3/workflow/common"
+	wfcommon "github.com/argoproj/argo-workflows/v3/workflow/common"
 	"github.com/argoproj/argo-workflows/v3/workflow/util"
 )
 
@@ -23,8 +24,9 @@ type cliCreateOpts struct {
 func NewCreateCommand() *cobra.Command {
 	var cliCreateOpts cliCreateOpts
 	command := &cobra.Command{
-		Use:   "create FILE1 FILE2...",
-		Short: "create a cluster workflow template",
+		Use:               "create FILE1 FILE2...",
+		Short:             "create a cluster workflow template",
+		ValidArgsFunction: common.CompleteManifestFiles,
 		Run: func(cmd *cobra.Command, args []string) {
 			if len(args) == 0 {
 				cmd.HelpFunc()(cmd, args)
@@ -90,7 +92,7 @@ func unmarshalClusterWorkflowTemplates(wfBytes []byte, strict bool) ([]wfv1.Clus
 	if err == nil {
 		return []wfv1.ClusterWorkflowTemplate{cwft}, nil
 	}
-	yamlWfs, err := common.SplitClusterWorkflowTemplateYAMLFile(wfBytes, strict)
+	yamlWfs, err := wfcommon.SplitClusterWorkflowTemplateYAMLFile(wfBytes, strict)
 	if err == nil {
 		return yamlWfs, nil
 	}
@@ -4,11 +4,12 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/argoproj/pkg/errors"
 	"github.com/spf13/cobra"
 
 	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/client"
+	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/common"
 	"github.com/argoproj/argo-workflows/v3/pkg/apiclient/clusterworkflowtemplate"
+	"github.com/argoproj/argo-workflows/v3/util/errors"
 )
 
 // NewDeleteCommand returns a new instance of an `argo delete` command
@@ -16,8 +17,9 @@ func NewDeleteCommand() *cobra.Command {
 	var all bool
 
 	command := &cobra.Command{
-		Use:   "delete WORKFLOW_TEMPLATE",
-		Short: "delete a cluster workflow template",
+		Use:               "delete WORKFLOW_TEMPLATE",
+		Short:             "delete a cluster workflow template",
+		ValidArgsFunction: common.CompleteClusterWorkflowTemplates,
 		Run: func(cmd *cobra.Command, args []string) {
 			apiServerDeleteClusterWorkflowTemplates(cmd.Context(), all, args)
 		},
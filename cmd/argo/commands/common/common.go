@@ -59,8 +59,9 @@ func initializeSession() {
 			wfv1.NodeTypeSuspend: ansiFormat("Suspend", FgCyan),
 		}
 		WorkflowConditionIconMap = map[wfv1.ConditionType]string{
-			wfv1.ConditionTypeMetricsError: ansiFormat("Error", FgRed),
-			wfv1.ConditionTypeSpecWarning:  ansiFormat("Warning", FgYellow),
+			wfv1.ConditionTypeMetricsError:    ansiFormat("Error", FgRed),
+			wfv1.ConditionTypeSpecWarning:     ansiFormat("Warning", FgYellow),
+			wfv1.ConditionTypeStoppedByPolicy: ansiFormat("Stopped", FgRed),
 		}
 	} else {
 		JobStatusIconMap = map[wfv1.NodePhase]string{
@@ -75,8 +76,9 @@ func initializeSession() {
 			wfv1.NodeTypeSuspend: ansiFormat("ǁ", FgCyan),
 		}
 		WorkflowConditionIconMap = map[wfv1.ConditionType]string{
-			wfv1.ConditionTypeMetricsError: ansiFormat("✖", FgRed),
-			wfv1.ConditionTypeSpecWarning:  ansiFormat("⚠", FgYellow),
+			wfv1.ConditionTypeMetricsError:    ansiFormat("✖", FgRed),
+			wfv1.ConditionTypeSpecWarning:     ansiFormat("⚠", FgYellow),
+			wfv1.ConditionTypeStoppedByPolicy: ansiFormat("✖", FgRed),
 		}
 	}
 }
@@ -0,0 +1,203 @@
+package common
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/client"
+	clusterworkflowtmplpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/clusterworkflowtemplate"
+	cronworkflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/cronworkflow"
+	workflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
+	workflowtemplatepkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflowtemplate"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	wfcommon "github.com/argoproj/argo-workflows/v3/workflow/common"
+	"github.com/argoproj/argo-workflows/v3/workflow/util"
+)
+
+// CompletionFunc completes the arguments, or the value of a flag, of a command
+type CompletionFunc func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective)
+
+// CompleteArgs completes each argument of a command with the function at its position, and no further arguments
+func CompleteArgs(fs ...CompletionFunc) CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) >= len(fs) {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return fs[len(args)](cmd, args, toComplete)
+	}
+}
+
+// CompleteManifestFiles completes the YAML and JSON files that manifests are read from
+func CompleteManifestFiles(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return []string{"yaml", "yml", "json"}, cobra.ShellCompDirectiveFilterFileExt
+}
+
+// CompleteWorkflows completes the names of the workflows in the namespace, of any of the phases if some are given
+func CompleteWorkflows(phases ...wfv1.WorkflowPhase) CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		ctx, apiClient := client.NewAPIClient(cmd.Context())
+		listOpts := &metav1.ListOptions{}
+		if len(phases) > 0 {
+			values := make([]string, len(phases))
+			for i, p := range phases {
+				values[i] = string(p)
+			}
+			listOpts.LabelSelector = fmt.Sprintf("%s in (%s)", wfcommon.LabelKeyPhase, strings.Join(values, ","))
+		}
+		wfList, err := apiClient.NewWorkflowServiceClient().ListWorkflows(ctx, &workflowpkg.WorkflowListRequest{
+			Namespace:   client.Namespace(),
+			ListOptions: listOpts,
+			Fields:      "items.metadata.name",
+		})
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+		var names []string
+		for _, wf := range wfList.Items {
+			names = append(names, wf.Name)
+		}
+		return completeNames(names, args, toComplete), cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// CompleteWorkflowTemplates completes the names of the workflow templates in the namespace
+func CompleteWorkflowTemplates(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	ctx, apiClient := client.NewAPIClient(cmd.Context())
+	serviceClient, err := apiClient.NewWorkflowTemplateServiceClient()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	wftmplList, err := serviceClient.ListWorkflowTemplates(ctx, &workflowtemplatepkg.WorkflowTemplateListRequest{
+		Namespace:   client.Namespace(),
+		ListOptions: &metav1.ListOptions{},
+	})
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	var names []string
+	for _, wftmpl := range wftmplList.Items {
+		names = append(names, wftmpl.Name)
+	}
+	return completeNames(names, args, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// CompleteClusterWorkflowTemplates completes the names of the cluster workflow templates
+func CompleteClusterWorkflowTemplates(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	ctx, apiClient := client.NewAPIClient(cmd.Context())
+	serviceClient, err := apiClient.NewClusterWorkflowTemplateServiceClient()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	cwftmplList, err := serviceClient.ListClusterWorkflowTemplates(ctx, &clusterworkflowtmplpkg.ClusterWorkflowTemplateListRequest{
+		ListOptions: &metav1.ListOptions{},
+	})
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	var names []string
+	for _, cwftmpl := range cwftmplList.Items {
+		names = append(names, cwftmpl.Name)
+	}
+	return completeNames(names, args, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// CompleteCronWorkflows completes the names of the cron workflows in the namespace
+func CompleteCronWorkflows(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	ctx, apiClient := client.NewAPIClient(cmd.Context())
+	serviceClient, err := apiClient.NewCronWorkflowServiceClient()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	cronWfList, err := serviceClient.ListCronWorkflows(ctx, &cronworkflowpkg.ListCronWorkflowsRequest{
+		Namespace:   client.Namespace(),
+		ListOptions: &metav1.ListOptions{},
+	})
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	var names []string
+	for _, cronWf := range cronWfList.Items {
+		names = append(names, cronWf.Name)
+	}
+	return completeNames(names, args, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// CompleteNodeFieldSelector completes a --node-field-selector of the display name of a node of the workflow that is
+// the argument at the index
+func CompleteNodeFieldSelector(workflowArg int) CompletionFunc {
+	return completeNodes(workflowArg, func(wf *wfv1.Workflow) []string {
+		var selectors []string
+		for _, node := range wf.Status.Nodes {
+			selectors = append(selectors, "displayName="+node.DisplayName)
+		}
+		return selectors
+	})
+}
+
+// CompleteNodeIDs completes the IDs of the nodes of the workflow that is the argument at the index
+func CompleteNodeIDs(workflowArg int) CompletionFunc {
+	return completeNodes(workflowArg, func(wf *wfv1.Workflow) []string {
+		var ids []string
+		for id := range wf.Status.Nodes {
+			ids = append(ids, id)
+		}
+		return ids
+	})
+}
+
+// CompleteWorkflowPods completes the names of the pods of the workflow that is the argument at the index
+func CompleteWorkflowPods(workflowArg int) CompletionFunc {
+	return completeNodes(workflowArg, workflowPodNames)
+}
+
+// completeNodes completes the names that the function returns of the nodes of the workflow that is the argument at the
+// index
+func completeNodes(workflowArg int, f func(wf *wfv1.Workflow) []string) CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) <= workflowArg {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		ctx, apiClient := client.NewAPIClient(cmd.Context())
+		wf, err := apiClient.NewWorkflowServiceClient().GetWorkflow(ctx, &workflowpkg.WorkflowGetRequest{
+			Name:      args[workflowArg],
+			Namespace: client.Namespace(),
+		})
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+		return completeNames(f(wf), nil, toComplete), cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// workflowPodNames returns the names of the pods of the workflow's pod nodes
+func workflowPodNames(wf *wfv1.Workflow) []string {
+	podNameVersion := util.GetWorkflowPodNameVersion(wf)
+	var podNames []string
+	for _, node := range wf.Status.Nodes {
+		if node.Type == wfv1.NodeTypePod {
+			podNames = append(podNames, util.GeneratePodName(wf.Name, node.Name, util.GetTemplateFromNode(node), node.ID, podNameVersion))
+		}
+	}
+	return podNames
+}
+
+// completeNames returns the sorted names that start with the text being completed, excluding those already given as
+// arguments
+func completeNames(names, args []string, toComplete string) []string {
+	given := make(map[string]bool, len(args))
+	for _, arg := range args {
+		given[arg] = true
+	}
+	var completions []string
+	for _, name := range names {
+		if !given[name] && strings.HasPrefix(name, toComplete) {
+			completions = append(completions, name)
+		}
+	}
+	sort.Strings(completions)
+	return completions
+}
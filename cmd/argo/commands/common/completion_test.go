@@ -0,0 +1,26 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompleteArgs(t *testing.T) {
+	f := CompleteArgs(cobra.FixedCompletions([]string{"a"}, cobra.ShellCompDirectiveNoFileComp), CompleteManifestFiles)
+	completions, directive := f(nil, nil, "")
+	assert.Equal(t, []string{"a"}, completions)
+	assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
+	completions, directive = f(nil, []string{"a"}, "")
+	assert.Equal(t, []string{"yaml", "yml", "json"}, completions)
+	assert.Equal(t, cobra.ShellCompDirectiveFilterFileExt, directive)
+	completions, directive = f(nil, []string{"a", "b.yaml"}, "")
+	assert.Empty(t, completions)
+	assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
+}
+
+func Test_completeNames(t *testing.T) {
+	assert.Equal(t, []string{"my-wf-a", "my-wf-c"}, completeNames([]string{"my-wf-c", "other", "my-wf-b", "my-wf-a"}, []string{"my-wf-b"}, "my-"))
+	assert.Empty(t, completeNames([]string{"my-wf"}, nil, "other"))
+}
@@ -0,0 +1,174 @@
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+// diffSections are the prefixes of the paths of the values that are compared, in the order they are printed
+var diffSections = []string{"parameters", "spec", "status", "nodes"}
+
+// nodeDiffResult is what is compared of each node, other fields such as the pod name and times differ in every run
+type nodeDiffResult struct {
+	TemplateName string           `json:"templateName,omitempty"`
+	Phase        wfv1.NodePhase   `json:"phase,omitempty"`
+	Message      string           `json:"message,omitempty"`
+	Parameters   []wfv1.Parameter `json:"parameters,omitempty"`
+	Result       *string          `json:"result,omitempty"`
+	ExitCode     *string          `json:"exitCode,omitempty"`
+}
+
+// PrintWorkflowDiff prints the differences between the rendered spec, parameters and node results of two workflows,
+// and returns whether they differ
+func PrintWorkflowDiff(out io.Writer, a, b *wfv1.Workflow) (bool, error) {
+	aValues, err := getDiffValues(a)
+	if err != nil {
+		return false, err
+	}
+	bValues, err := getDiffValues(b)
+	if err != nil {
+		return false, err
+	}
+	paths := make([]string, 0, len(aValues))
+	for path := range aValues {
+		paths = append(paths, path)
+	}
+	for path := range bValues {
+		if _, ok := aValues[path]; !ok {
+			paths = append(paths, path)
+		}
+	}
+	sort.Slice(paths, func(i, j int) bool {
+		si, sj := diffSection(paths[i]), diffSection(paths[j])
+		if si != sj {
+			return si < sj
+		}
+		return paths[i] < paths[j]
+	})
+	_, _ = fmt.Fprintln(out, ansiFormat("--- "+a.Name, FgRed))
+	_, _ = fmt.Fprintln(out, ansiFormat("+++ "+b.Name, FgGreen))
+	differ := false
+	for _, path := range paths {
+		aValue, aOK := aValues[path]
+		bValue, bOK := bValues[path]
+		if aOK == bOK && aValue == bValue {
+			continue
+		}
+		differ = true
+		if aOK {
+			_, _ = fmt.Fprintln(out, ansiFormat(fmt.Sprintf("- %s: %s", path, aValue), FgRed))
+		}
+		if bOK {
+			_, _ = fmt.Fprintln(out, ansiFormat(fmt.Sprintf("+ %s: %s", path, bValue), FgGreen))
+		}
+	}
+	return differ, nil
+}
+
+func diffSection(path string) int {
+	for i, s := range diffSections {
+		if strings.HasPrefix(path, s) {
+			return i
+		}
+	}
+	return len(diffSections)
+}
+
+// getDiffValues returns the values of the workflow that are compared, keyed by their path
+func getDiffValues(wf *wfv1.Workflow) (map[string]string, error) {
+	values := make(map[string]string)
+	// the stored spec is the spec the workflow ran, including its workflow template's and the defaults
+	spec := wf.Spec.DeepCopy()
+	if wf.Status.StoredWorkflowSpec != nil {
+		spec = wf.Status.StoredWorkflowSpec.DeepCopy()
+	}
+	for _, p := range spec.Arguments.Parameters {
+		values["parameters."+p.Name] = strconv.Quote(p.GetValue())
+	}
+	spec.Arguments.Parameters = nil
+	if err := addDiffValues("spec", spec, values); err != nil {
+		return nil, err
+	}
+	if err := addDiffValues("status", map[string]string{"phase": string(wf.Status.Phase), "message": wf.Status.Message}, values); err != nil {
+		return nil, err
+	}
+	for _, n := range wf.Status.Nodes {
+		// node names start with the workflow's name, which differs, and the root node is the workflow's status
+		name := strings.TrimPrefix(strings.TrimPrefix(n.Name, wf.Name), ".")
+		if name == "" {
+			continue
+		}
+		result := nodeDiffResult{TemplateName: n.TemplateName, Phase: n.Phase, Message: n.Message}
+		if n.Outputs != nil {
+			result.Parameters = n.Outputs.Parameters
+			result.Result = n.Outputs.Result
+			result.ExitCode = n.Outputs.ExitCode
+		}
+		if err := addDiffValues(fmt.Sprintf("nodes[%s]", name), result, values); err != nil {
+			return nil, err
+		}
+	}
+	return values, nil
+}
+
+func addDiffValues(path string, v interface{}, values map[string]string) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	var obj interface{}
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return err
+	}
+	flattenDiffValues(path, obj, values)
+	return nil
+}
+
+// flattenDiffValues adds the values of the object's leaves to the values, keyed by their path. Items of lists of
+// named objects, such as templates and parameters, are keyed by their name so that adding or removing an item does not
+// change the paths of the others.
+func flattenDiffValues(path string, obj interface{}, values map[string]string) {
+	switch obj := obj.(type) {
+	case map[string]interface{}:
+		for k, v := range obj {
+			flattenDiffValues(path+"."+k, v, values)
+		}
+	case []interface{}:
+		names := getItemNames(obj)
+		for i, v := range obj {
+			key := strconv.Itoa(i)
+			if names != nil {
+				key = names[i]
+			}
+			flattenDiffValues(fmt.Sprintf("%s[%s]", path, key), v, values)
+		}
+	default:
+		data, _ := json.Marshal(obj)
+		values[path] = string(data)
+	}
+}
+
+// getItemNames returns the names of the items, or nil if they are not all objects with unique names
+func getItemNames(items []interface{}) []string {
+	names := make([]string, len(items))
+	seen := make(map[string]bool, len(items))
+	for i, item := range items {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		name, ok := m["name"].(string)
+		if !ok || name == "" || seen[name] {
+			return nil
+		}
+		seen[name] = true
+		names[i] = name
+	}
+	return names
+}
@@ -0,0 +1,93 @@
+package common
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+const diffWorkflow = `
+metadata:
+  name: my-wf
+spec:
+  entrypoint: main
+  arguments:
+    parameters:
+    - name: message
+      value: hello
+  templates:
+  - name: main
+    steps:
+    - - name: print
+        template: print
+  - name: print
+    container:
+      image: argoproj/argosay:v2
+status:
+  phase: Succeeded
+  nodes:
+    my-wf:
+      name: my-wf
+      phase: Succeeded
+    my-wf-1:
+      name: my-wf[0].print
+      templateName: print
+      phase: Succeeded
+      outputs:
+        exitCode: "0"
+`
+
+func TestPrintWorkflowDiff(t *testing.T) {
+	NoColor = true
+	defer func() { NoColor = false }()
+	a := wfv1.MustUnmarshalWorkflow(diffWorkflow)
+	t.Run("Same", func(t *testing.T) {
+		b := a.DeepCopy()
+		b.Name = "my-wf-2"
+		b.Status.Nodes = wfv1.Nodes{}
+		for id, n := range a.Status.Nodes {
+			n.Name = "my-wf-2" + n.Name[len("my-wf"):]
+			b.Status.Nodes[id] = n
+		}
+		out := &bytes.Buffer{}
+		differ, err := PrintWorkflowDiff(out, a, b)
+		require.NoError(t, err)
+		assert.False(t, differ)
+		assert.Equal(t, "--- my-wf\n+++ my-wf-2\n", out.String())
+	})
+	t.Run("Different", func(t *testing.T) {
+		b := a.DeepCopy()
+		b.Spec.Arguments.Parameters[0].Value = wfv1.AnyStringPtr("world")
+		// a template that is added does not change the paths of the others
+		b.Spec.Templates = append([]wfv1.Template{{Name: "other"}}, b.Spec.Templates...)
+		b.Status.Phase = wfv1.WorkflowFailed
+		n := b.Status.Nodes["my-wf-1"]
+		n.Phase = wfv1.NodeFailed
+		n.Outputs.ExitCode = pointerTo("1")
+		b.Status.Nodes["my-wf-1"] = n
+		out := &bytes.Buffer{}
+		differ, err := PrintWorkflowDiff(out, a, b)
+		require.NoError(t, err)
+		assert.True(t, differ)
+		assert.Equal(t, `--- my-wf
++++ my-wf
+- parameters.message: "hello"
++ parameters.message: "world"
++ spec.templates[other].name: "other"
+- status.phase: "Succeeded"
++ status.phase: "Failed"
+- nodes[[0].print].exitCode: "0"
++ nodes[[0].print].exitCode: "1"
+- nodes[[0].print].phase: "Succeeded"
++ nodes[[0].print].phase: "Failed"
+`, out.String())
+	})
+}
+
+func pointerTo(s string) *string {
+	return &s
+}
@@ -4,8 +4,10 @@ import (
 	"bytes"
 	"fmt"
 	"log"
+	"sort"
 	"strings"
 	"text/tabwriter"
+	"time"
 
 	"github.com/argoproj/pkg/humanize"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -14,6 +16,7 @@ import (
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 	argoutil "github.com/argoproj/argo-workflows/v3/util"
 	"github.com/argoproj/argo-workflows/v3/util/printer"
+	wfcommon "github.com/argoproj/argo-workflows/v3/workflow/common"
 	"github.com/argoproj/argo-workflows/v3/workflow/util"
 )
 
@@ -54,6 +57,9 @@ func PrintWorkflowHelper(wf *wfv1.Workflow, getArgs GetFlags) string {
 	out := ""
 	out += fmt.Sprintf(fmtStr, "Name:", wf.ObjectMeta.Name)
 	out += fmt.Sprintf(fmtStr, "Namespace:", wf.ObjectMeta.Namespace)
+	if context := wf.Annotations[wfcommon.AnnotationKeyContext]; context != "" {
+		out += fmt.Sprintf(fmtStr, "Context:", context)
+	}
 	serviceAccount := wf.GetExecSpec().ServiceAccountName
 	if serviceAccount == "" {
 		// if serviceAccountName was not specified in a submitted Workflow, we will
@@ -88,6 +94,9 @@ func PrintWorkflowHelper(wf *wfv1.Workflow, getArgs GetFlags) string {
 		if wf.Status.EstimatedDuration > 0 {
 			out += fmt.Sprintf(fmtStr, "EstimatedDuration:", humanize.Duration(wf.Status.EstimatedDuration.ToDuration()))
 		}
+		if wf.Status.EstimatedFinishedAt != nil {
+			out += fmt.Sprintf(fmtStr, "ETA:", humanize.Timestamp(wf.Status.EstimatedFinishedAt.Time))
+		}
 	}
 	out += fmt.Sprintf(fmtStr, "Progress:", wf.Status.Progress)
 	if !wf.Status.ResourcesDuration.IsZero() {
@@ -177,11 +186,51 @@ func PrintWorkflowHelper(wf *wfv1.Workflow, getArgs GetFlags) string {
 			out += writerBuffer.String()
 		}
 	}
+	if getArgs.Output != "short" {
+		out += printNotes(wf)
+	}
 	writerBuffer := new(bytes.Buffer)
 	out += writerBuffer.String()
 	return out
 }
 
+// printNotes returns the notes users annotated the workflow's nodes with, in the order they were added, or nothing if
+// there are none
+func printNotes(wf *wfv1.Workflow) string {
+	type nodeNote struct {
+		node string
+		wfv1.NodeNote
+	}
+	var notes []nodeNote
+	for _, node := range wf.Status.Nodes {
+		for _, n := range node.Notes {
+			notes = append(notes, nodeNote{node.DisplayName, n})
+		}
+	}
+	if len(notes) == 0 {
+		return ""
+	}
+	sort.SliceStable(notes, func(i, j int) bool {
+		if !notes[i].AddedAt.Equal(&notes[j].AddedAt) {
+			return notes[i].AddedAt.Before(&notes[j].AddedAt)
+		}
+		return notes[i].node < notes[j].node
+	})
+	writerBuffer := new(bytes.Buffer)
+	w := tabwriter.NewWriter(writerBuffer, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintf(w, "\nNOTES\n")
+	_, _ = fmt.Fprintf(w, "STEP\tADDED\tAUTHOR\tNOTE\n")
+	for _, n := range notes {
+		author := n.Author
+		if author == "" {
+			author = "-"
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", n.node, n.AddedAt.Format(time.RFC3339), author, n.Note)
+	}
+	_ = w.Flush()
+	return writerBuffer.String()
+}
+
 type nodeInfoInterface interface {
 	getID() string
 	getNodeStatus(wf *wfv1.Workflow) wfv1.NodeStatus
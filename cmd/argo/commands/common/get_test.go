@@ -429,3 +429,21 @@ func Test_printWorkflowHelperNudges(t *testing.T) {
 		assert.NotContains(t, output, securityNudges)
 	})
 }
+
+func Test_printNotes(t *testing.T) {
+	wf := &wfv1.Workflow{Status: wfv1.WorkflowStatus{Nodes: wfv1.Nodes{
+		"my-wf-1": {ID: "my-wf-1", DisplayName: "build", Notes: []wfv1.NodeNote{
+			{Note: "reran manually, see INC-1234", Author: "jane", AddedAt: metav1.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)},
+		}},
+		"my-wf-2": {ID: "my-wf-2", DisplayName: "test", Notes: []wfv1.NodeNote{
+			{Note: "flaky", AddedAt: metav1.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+		}},
+	}}}
+	assert.Equal(t, `
+NOTES
+STEP   ADDED                 AUTHOR  NOTE
+test   2024-01-01T00:00:00Z  -       flaky
+build  2024-01-02T00:00:00Z  jane    reran manually, see INC-1234
+`, printNotes(wf))
+	assert.Empty(t, printNotes(&wfv1.Workflow{}))
+}
@@ -0,0 +1,154 @@
+package common
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/argoproj/pkg/humanize"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+// graphPhaseColors are the fill colors of the nodes of each phase, the same as the UI's
+var graphPhaseColors = map[wfv1.NodePhase]string{
+	wfv1.NodePending:   "#f4c030",
+	wfv1.NodeRunning:   "#0dadea",
+	wfv1.NodeSucceeded: "#18be94",
+	wfv1.NodeSkipped:   "#8fa4b1",
+	wfv1.NodeOmitted:   "#8fa4b1",
+	wfv1.NodeFailed:    "#e96d76",
+	wfv1.NodeError:     "#e96d76",
+}
+
+// graphNode is a node of the workflow's graph
+type graphNode struct {
+	id    string
+	node  wfv1.NodeStatus
+	edges []string
+}
+
+// getWorkflowGraph returns the nodes of the workflow's graph, in the order they started, with the IDs of the graph
+// nodes they lead to. Step and task groups, and the attempts of retried nodes, are left out and their children are
+// linked to the nodes that lead to them instead, so that each retried node is shown once with its retry count.
+func getWorkflowGraph(wf *wfv1.Workflow) []*graphNode {
+	attempts := make(map[string]bool)
+	for _, n := range wf.Status.Nodes {
+		if n.Type == wfv1.NodeTypeRetry {
+			for _, childID := range n.Children {
+				attempts[childID] = true
+			}
+		}
+	}
+	var nodes []wfv1.NodeStatus
+	for _, n := range wf.Status.Nodes {
+		if n.Type != wfv1.NodeTypeStepGroup && n.Type != wfv1.NodeTypeTaskGroup && !attempts[n.ID] {
+			nodes = append(nodes, n)
+		}
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		if !nodes[i].StartedAt.Equal(&nodes[j].StartedAt) {
+			return nodes[i].StartedAt.Before(&nodes[j].StartedAt)
+		}
+		return nodes[i].ID < nodes[j].ID
+	})
+	ids := make(map[string]string, len(nodes))
+	for i, n := range nodes {
+		ids[n.ID] = fmt.Sprintf("n%d", i)
+	}
+	var graph []*graphNode
+	for _, n := range nodes {
+		g := &graphNode{id: ids[n.ID], node: n}
+		seen := make(map[string]bool)
+		var visit func(children []string)
+		visit = func(children []string) {
+			for _, childID := range children {
+				child, ok := wf.Status.Nodes[childID]
+				switch {
+				case !ok:
+				case ids[childID] == "":
+					visit(child.Children)
+				case !seen[childID]:
+					seen[childID] = true
+					g.edges = append(g.edges, ids[childID])
+				}
+			}
+		}
+		visit(n.Children)
+		graph = append(graph, g)
+	}
+	return graph
+}
+
+// getGraphNodeLabel returns the lines of the label of the node: its name, and its phase with its duration and retry
+// count
+func getGraphNodeLabel(n wfv1.NodeStatus) []string {
+	status := string(n.Phase)
+	if !n.StartedAt.IsZero() {
+		status += " " + humanize.RelativeDurationShort(n.StartedAt.Time, n.FinishedAt.Time)
+	}
+	if n.Type == wfv1.NodeTypeRetry && len(n.Children) > 1 {
+		status += fmt.Sprintf(" (retries: %d)", len(n.Children)-1)
+	}
+	return []string{n.DisplayName, status}
+}
+
+// PrintWorkflowMermaid returns the workflow's graph as a Mermaid flowchart
+func PrintWorkflowMermaid(wf *wfv1.Workflow) string {
+	out := "flowchart TD\n"
+	graph := getWorkflowGraph(wf)
+	for _, g := range graph {
+		label := strings.ReplaceAll(strings.Join(getGraphNodeLabel(g.node), "<br/>"), `"`, "#quot;")
+		out += fmt.Sprintf("  %s[\"%s\"]", g.id, label)
+		if _, ok := graphPhaseColors[g.node.Phase]; ok {
+			out += ":::" + string(g.node.Phase)
+		}
+		out += "\n"
+	}
+	for _, g := range graph {
+		for _, e := range g.edges {
+			out += fmt.Sprintf("  %s --> %s\n", g.id, e)
+		}
+	}
+	// only the classes of the phases of the nodes are defined
+	used := make(map[wfv1.NodePhase]bool)
+	for _, g := range graph {
+		used[g.node.Phase] = true
+	}
+	var phases []string
+	for phase := range graphPhaseColors {
+		if used[phase] {
+			phases = append(phases, string(phase))
+		}
+	}
+	sort.Strings(phases)
+	for _, phase := range phases {
+		out += fmt.Sprintf("  classDef %s fill:%s\n", phase, graphPhaseColors[wfv1.NodePhase(phase)])
+	}
+	return out
+}
+
+// PrintWorkflowDot returns the workflow's graph in the Graphviz DOT language
+func PrintWorkflowDot(wf *wfv1.Workflow) string {
+	escape := strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+	out := fmt.Sprintf("digraph \"%s\" {\n", escape.Replace(wf.Name))
+	out += "  node [shape=box, style=\"rounded,filled\"];\n"
+	graph := getWorkflowGraph(wf)
+	for _, g := range graph {
+		lines := getGraphNodeLabel(g.node)
+		for i, l := range lines {
+			lines[i] = escape.Replace(l)
+		}
+		out += fmt.Sprintf("  %s [label=\"%s\"", g.id, strings.Join(lines, `\n`))
+		if color, ok := graphPhaseColors[g.node.Phase]; ok {
+			out += fmt.Sprintf(", fillcolor=\"%s\"", color)
+		}
+		out += "];\n"
+	}
+	for _, g := range graph {
+		for _, e := range g.edges {
+			out += fmt.Sprintf("  %s -> %s;\n", g.id, e)
+		}
+	}
+	return out + "}\n"
+}
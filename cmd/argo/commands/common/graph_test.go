@@ -0,0 +1,52 @@
+package common
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+func newGraphWorkflow() *wfv1.Workflow {
+	t0 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(s int) metav1.Time { return metav1.NewTime(t0.Add(time.Duration(s) * time.Second)) }
+	return &wfv1.Workflow{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-wf"},
+		Status: wfv1.WorkflowStatus{
+			Nodes: wfv1.Nodes{
+				"my-wf":   {ID: "my-wf", DisplayName: "my-wf", Type: wfv1.NodeTypeDAG, Phase: wfv1.NodeFailed, StartedAt: at(0), FinishedAt: at(10), Children: []string{"my-wf-a"}},
+				"my-wf-a": {ID: "my-wf-a", DisplayName: "a", Type: wfv1.NodeTypeRetry, Phase: wfv1.NodeSucceeded, StartedAt: at(1), FinishedAt: at(6), Children: []string{"my-wf-1", "my-wf-2"}},
+				"my-wf-1": {ID: "my-wf-1", DisplayName: "a(0)", Type: wfv1.NodeTypePod, Phase: wfv1.NodeFailed, StartedAt: at(1), FinishedAt: at(3)},
+				"my-wf-2": {ID: "my-wf-2", DisplayName: "a(1)", Type: wfv1.NodeTypePod, Phase: wfv1.NodeSucceeded, StartedAt: at(3), FinishedAt: at(6), Children: []string{"my-wf-b"}},
+				"my-wf-b": {ID: "my-wf-b", DisplayName: `b "quoted"`, Type: wfv1.NodeTypePod, Phase: wfv1.NodeFailed, StartedAt: at(6), FinishedAt: at(10)},
+			},
+		},
+	}
+}
+
+func TestPrintWorkflowMermaid(t *testing.T) {
+	assert.Equal(t, `flowchart TD
+  n0["my-wf<br/>Failed 10s"]:::Failed
+  n1["a<br/>Succeeded 5s (retries: 1)"]:::Succeeded
+  n2["b #quot;quoted#quot;<br/>Failed 4s"]:::Failed
+  n0 --> n1
+  n1 --> n2
+  classDef Failed fill:#e96d76
+  classDef Succeeded fill:#18be94
+`, PrintWorkflowMermaid(newGraphWorkflow()))
+}
+
+func TestPrintWorkflowDot(t *testing.T) {
+	assert.Equal(t, `digraph "my-wf" {
+  node [shape=box, style="rounded,filled"];
+  n0 [label="my-wf\nFailed 10s", fillcolor="#e96d76"];
+  n1 [label="a\nSucceeded 5s (retries: 1)", fillcolor="#18be94"];
+  n2 [label="b \"quoted\"\nFailed 4s", fillcolor="#e96d76"];
+  n0 -> n1;
+  n1 -> n2;
+}
+`, PrintWorkflowDot(newGraphWorkflow()))
+}
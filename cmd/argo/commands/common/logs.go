@@ -1,35 +1,182 @@
 package common
 
 import (
+	"bufio"
 	"context"
+	"crypto/tls"
 	"fmt"
 	"io"
+	"net/http"
+	"regexp"
+	"sort"
+	"time"
 
-	"github.com/argoproj/pkg/errors"
-	corev1 "k8s.io/api/core/v1"
+	log "github.com/sirupsen/logrus"
 
+	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/client"
 	workflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/util/errors"
+	"github.com/argoproj/argo-workflows/v3/util/logs"
+	"github.com/argoproj/argo-workflows/v3/workflow/util"
 )
 
-func LogWorkflow(ctx context.Context, serviceClient workflowpkg.WorkflowServiceClient, namespace, workflow, podName, grep, selector string, logOptions *corev1.PodLogOptions) {
+func LogWorkflow(ctx context.Context, serviceClient workflowpkg.WorkflowServiceClient, req *workflowpkg.WorkflowLogRequest) {
 	// logs
-	stream, err := serviceClient.WorkflowLogs(ctx, &workflowpkg.WorkflowLogRequest{
-		Name:       workflow,
-		Namespace:  namespace,
-		PodName:    podName,
-		LogOptions: logOptions,
-		Selector:   selector,
-		Grep:       grep,
-	})
+	stream, err := serviceClient.WorkflowLogs(ctx, req)
 	errors.CheckError(err)
+	containers := req.Containers
+	if len(containers) == 0 {
+		containers = []string{req.LogOptions.Container}
+	}
+	// the lines are only prefixed with their container when they may be from several
+	showContainer := len(containers) > 1
 
-	// loop on log lines
+	// loop on log lines, which are printed as they are received when following, and otherwise once they have been
+	// merged with the archived logs
+	var entries []*workflowpkg.LogEntry
+	loggedPods := make(map[string]bool)
 	for {
 		event, err := stream.Recv()
 		if err == io.EOF {
-			return
+			break
+		}
+		errors.CheckError(err)
+		loggedPods[event.PodName] = true
+		if req.LogOptions.Follow {
+			printLogEntry(event, showContainer)
+		} else {
+			entries = append(entries, event)
 		}
+	}
+
+	// the pods may have been deleted, e.g. by pod GC, but their logs archived, which the server sends first when asked
+	var archived []archivedLog
+	startedAt := make(map[string]time.Time)
+	if !req.Archived {
+		archived, startedAt, err = getArchivedLogs(ctx, serviceClient, req, containers, loggedPods)
 		errors.CheckError(err)
-		fmt.Println(ansiFormat(fmt.Sprintf("%s: %s", event.PodName, event.Content), ansiColorCode(event.PodName)))
 	}
+	for _, e := range mergeLogEntries(entries, archived, startedAt) {
+		printLogEntry(e, showContainer)
+	}
+}
+
+func printLogEntry(e *workflowpkg.LogEntry, showContainer bool) {
+	fmt.Println(formatLogEntry(e, showContainer))
+}
+
+// formatLogEntry returns the line of the log entry prefixed with its pod, and its container if showContainer is true,
+// colored by pod
+func formatLogEntry(e *workflowpkg.LogEntry, showContainer bool) string {
+	prefix := e.PodName
+	if showContainer && e.Container != "" {
+		prefix += "/" + e.Container
+	}
+	return ansiFormat(fmt.Sprintf("%s: %s", prefix, e.Content), ansiColorCode(e.PodName))
+}
+
+// archivedLog is the archived log of a pod that did not have logs to stream
+type archivedLog struct {
+	startedAt time.Time
+	entries   []*workflowpkg.LogEntry
+}
+
+// mergeLogEntries inserts the archived logs of each pod before the streamed logs of the pods that started after it
+func mergeLogEntries(streamed []*workflowpkg.LogEntry, archived []archivedLog, startedAt map[string]time.Time) []*workflowpkg.LogEntry {
+	sort.SliceStable(archived, func(i, j int) bool { return archived[i].startedAt.Before(archived[j].startedAt) })
+	var entries []*workflowpkg.LogEntry
+	i := 0
+	for _, e := range streamed {
+		for ; i < len(archived) && archived[i].startedAt.Before(startedAt[e.PodName]); i++ {
+			entries = append(entries, archived[i].entries...)
+		}
+		entries = append(entries, e)
+	}
+	for ; i < len(archived); i++ {
+		entries = append(entries, archived[i].entries...)
+	}
+	return entries
+}
+
+// getArchivedLogs returns the archived logs of the containers of the pods, in the attempt if one, or the previous one, is
+// requested, that did not have logs to stream, e.g. because they were deleted, and when each of the workflow's pods
+// started
+func getArchivedLogs(ctx context.Context, serviceClient workflowpkg.WorkflowServiceClient, req *workflowpkg.WorkflowLogRequest, containers []string, loggedPods map[string]bool) ([]archivedLog, map[string]time.Time, error) {
+	rx, err := regexp.Compile(req.Grep)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to compile %q: %w", req.Grep, err)
+	}
+	wf, err := serviceClient.GetWorkflow(ctx, &workflowpkg.WorkflowGetRequest{Name: req.Name, Namespace: req.Namespace})
+	if err != nil {
+		return nil, nil, err
+	}
+	c := &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				InsecureSkipVerify: client.ArgoServerOpts.InsecureSkipVerify,
+			},
+		},
+	}
+	var archived []archivedLog
+	startedAt := make(map[string]time.Time)
+	podNameVersion := util.GetWorkflowPodNameVersion(wf)
+	for _, node := range wf.Status.Nodes {
+		if node.Type != wfv1.NodeTypePod {
+			continue
+		}
+		nodePodName := util.GeneratePodName(wf.Name, node.Name, util.GetTemplateFromNode(node), node.ID, podNameVersion)
+		startedAt[nodePodName] = node.StartedAt.Time
+		if (req.PodName != "" && nodePodName != req.PodName) || loggedPods[nodePodName] {
+			continue
+		}
+		// only the logs of the last container of each pod are archived, not those of its previous container
+		if selected, previousContainer := logs.SelectAttempt(wf.Status.Nodes, node.Name, req.Attempt, req.LogOptions.Previous); !selected || previousContainer {
+			continue
+		}
+		for _, container := range containers {
+			artifactName := container + "-logs"
+			if node.GetOutputs().GetArtifactByName(artifactName) == nil {
+				continue
+			}
+			if client.ArgoServerOpts.URL == "" {
+				log.Warnf("the logs of %s are archived, but can only be printed when using the Argo Server", nodePodName)
+				continue
+			}
+			entries, err := getArchivedLog(c, req.Namespace, wf.Name, node.ID, artifactName, nodePodName, container, rx, req.Invert)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to get the archived logs of %s: %w", nodePodName, err)
+			}
+			archived = append(archived, archivedLog{startedAt: node.StartedAt.Time, entries: entries})
+		}
+	}
+	return archived, startedAt, nil
+}
+
+func getArchivedLog(c *http.Client, namespace, workflowName, nodeID, artifactName, podName, container string, rx *regexp.Regexp, invert bool) ([]*workflowpkg.LogEntry, error) {
+	argoServerURL, err := client.ArgoServerOpts.ResolveURL()
+	if err != nil {
+		return nil, err
+	}
+	request, err := http.NewRequest("GET", fmt.Sprintf("%s/artifacts/%s/%s/%s/%s", argoServerURL, namespace, workflowName, nodeID, artifactName), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	request.Header.Set("Authorization", client.GetAuthString())
+	resp, err := c.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("request failed with: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("request failed %s", resp.Status)
+	}
+	var entries []*workflowpkg.LogEntry
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		if rx.MatchString(scanner.Text()) != invert {
+			entries = append(entries, &workflowpkg.LogEntry{PodName: podName, Container: container, Content: scanner.Text()})
+		}
+	}
+	return entries, scanner.Err()
 }
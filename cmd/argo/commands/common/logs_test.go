@@ -0,0 +1,37 @@
+package common
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	workflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
+)
+
+func Test_mergeLogEntries(t *testing.T) {
+	t0 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	entry := func(podName, content string) *workflowpkg.LogEntry {
+		return &workflowpkg.LogEntry{PodName: podName, Content: content}
+	}
+	streamed := []*workflowpkg.LogEntry{entry("b", "1"), entry("d", "1"), entry("b", "2")}
+	archived := []archivedLog{
+		{startedAt: t0.Add(4 * time.Minute), entries: []*workflowpkg.LogEntry{entry("e", "1")}},
+		{startedAt: t0, entries: []*workflowpkg.LogEntry{entry("a", "1"), entry("a", "2")}},
+		{startedAt: t0.Add(2 * time.Minute), entries: []*workflowpkg.LogEntry{entry("c", "1")}},
+	}
+	startedAt := map[string]time.Time{"b": t0.Add(time.Minute), "d": t0.Add(3 * time.Minute)}
+	var lines []string
+	for _, e := range mergeLogEntries(streamed, archived, startedAt) {
+		lines = append(lines, e.PodName+":"+e.Content)
+	}
+	assert.Equal(t, []string{"a:1", "a:2", "b:1", "c:1", "d:1", "b:2", "e:1"}, lines)
+}
+
+func Test_formatLogEntry(t *testing.T) {
+	NoColor = true
+	e := &workflowpkg.LogEntry{PodName: "my-pod", Container: "my-sidecar", Content: "hello"}
+	assert.Equal(t, "my-pod: hello", formatLogEntry(e, false))
+	assert.Equal(t, "my-pod/my-sidecar: hello", formatLogEntry(e, true))
+	assert.Equal(t, "my-pod: hello", formatLogEntry(&workflowpkg.LogEntry{PodName: "my-pod", Content: "hello"}, true))
+}
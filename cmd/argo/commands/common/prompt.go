@@ -0,0 +1,113 @@
+package common
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+// PromptParameters prompts for the value of each parameter on out, reads the values from in, and returns them in the
+// form NAME=VALUE of --parameter
+func PromptParameters(in *bufio.Reader, out io.Writer, parameters []wfv1.Parameter) ([]string, error) {
+	var values []string
+	for _, p := range parameters {
+		value, err := PromptParameter(in, out, p)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, p.Name+"="+value)
+	}
+	return values, nil
+}
+
+// PromptParameter prompts for the value of the parameter until one is given. The value of a parameter with an enum
+// is chosen by its number, or completed from the start of one of the allowed values.
+func PromptParameter(in *bufio.Reader, out io.Writer, p wfv1.Parameter) (string, error) {
+	var enum []string
+	for _, v := range p.Enum {
+		enum = append(enum, v.String())
+	}
+	if p.Description != nil && p.Description.String() != "" {
+		_, _ = fmt.Fprintf(out, "%s: %s\n", p.Name, p.Description.String())
+	}
+	for i, v := range enum {
+		_, _ = fmt.Fprintf(out, "  %d) %s\n", i+1, v)
+	}
+	for {
+		if len(enum) > 0 {
+			_, _ = fmt.Fprintf(out, "%s [1-%d]: ", p.Name, len(enum))
+		} else {
+			_, _ = fmt.Fprintf(out, "%s: ", p.Name)
+		}
+		line, err := in.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return "", err
+		}
+		value := strings.TrimRight(line, "\r\n")
+		if len(enum) > 0 {
+			if v, ok := chooseEnumValue(enum, strings.TrimSpace(value)); ok {
+				return v, nil
+			}
+			_, _ = fmt.Fprintf(out, "%q is not one of the allowed values\n", value)
+		} else if value != "" {
+			return value, nil
+		} else {
+			_, _ = fmt.Fprintln(out, "a value is required")
+		}
+		if err == io.EOF {
+			return "", fmt.Errorf("no value given for parameter %q", p.Name)
+		}
+	}
+}
+
+// Confirm asks the question on out, and returns whether it is answered yes on in
+func Confirm(in *bufio.Reader, out io.Writer, question string) (bool, error) {
+	_, _ = fmt.Fprintf(out, "%s [y/N]: ", question)
+	line, err := in.ReadString('\n')
+	if err != nil && err != io.EOF {
+		return false, err
+	}
+	switch strings.ToLower(strings.TrimSpace(line)) {
+	case "y", "yes":
+		return true, nil
+	default:
+		return false, nil
+	}
+}
+
+// IsTerminal returns whether the file is a terminal, which a user can answer prompts on
+func IsTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// chooseEnumValue returns the allowed value that is the input, is numbered by the input, or is the only one that
+// starts with the input
+func chooseEnumValue(enum []string, input string) (string, bool) {
+	if input == "" {
+		return "", false
+	}
+	for _, v := range enum {
+		if v == input {
+			return v, true
+		}
+	}
+	if n, err := strconv.Atoi(input); err == nil && n >= 1 && n <= len(enum) {
+		return enum[n-1], true
+	}
+	var matches []string
+	for _, v := range enum {
+		if strings.HasPrefix(v, input) {
+			matches = append(matches, v)
+		}
+	}
+	if len(matches) == 1 {
+		return matches[0], true
+	}
+	return "", false
+}
@@ -0,0 +1,68 @@
+package common
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+func TestPromptParameters(t *testing.T) {
+	parameters := []wfv1.Parameter{
+		{Name: "message", Description: wfv1.AnyStringPtr("the message to print")},
+		{Name: "env", Enum: []wfv1.AnyString{"development", "staging", "production"}},
+		{Name: "region", Enum: []wfv1.AnyString{"eu-west-1", "us-east-1"}},
+	}
+	t.Run("Values", func(t *testing.T) {
+		out := &bytes.Buffer{}
+		in := bufio.NewReader(strings.NewReader("\nhello world\nprod\n2\n"))
+		values, err := PromptParameters(in, out, parameters)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"message=hello world", "env=production", "region=us-east-1"}, values)
+		assert.Equal(t, `message: the message to print
+message: a value is required
+message:   1) development
+  2) staging
+  3) production
+env [1-3]:   1) eu-west-1
+  2) us-east-1
+region [1-2]: `, out.String())
+	})
+	t.Run("NotAllowed", func(t *testing.T) {
+		out := &bytes.Buffer{}
+		in := bufio.NewReader(strings.NewReader("test\n4\nstag"))
+		value, err := PromptParameter(in, out, parameters[1])
+		require.NoError(t, err)
+		assert.Equal(t, "staging", value)
+		assert.Contains(t, out.String(), `"test" is not one of the allowed values`)
+		assert.Contains(t, out.String(), `"4" is not one of the allowed values`)
+	})
+	t.Run("EOF", func(t *testing.T) {
+		_, err := PromptParameter(bufio.NewReader(strings.NewReader("")), &bytes.Buffer{}, parameters[0])
+		assert.EqualError(t, err, `no value given for parameter "message"`)
+	})
+}
+
+func Test_chooseEnumValue(t *testing.T) {
+	enum := []string{"1", "10", "a"}
+	for input, expected := range map[string]string{"1": "1", "10": "10", "2": "10", "3": "a", "a": "a", "": "", "4": "", "b": ""} {
+		v, ok := chooseEnumValue(enum, input)
+		assert.Equal(t, expected, v, input)
+		assert.Equal(t, expected != "", ok, input)
+	}
+}
+
+func TestConfirm(t *testing.T) {
+	for input, expected := range map[string]bool{"y\n": true, "YES\n": true, "n\n": false, "\n": false, "": false} {
+		out := &bytes.Buffer{}
+		ok, err := Confirm(bufio.NewReader(strings.NewReader(input)), out, "Stop 2 workflows?")
+		require.NoError(t, err)
+		assert.Equal(t, expected, ok, input)
+		assert.Equal(t, "Stop 2 workflows? [y/N]: ", out.String())
+	}
+}
@@ -13,6 +13,7 @@ import (
 type CliSubmitOpts struct {
 	Output        string // --output
 	Wait          bool   // --wait
+	WaitArgs      WaitFlags
 	Watch         bool   // --watch
 	Log           bool   // --log
 	Strict        bool   // --strict
@@ -20,20 +21,25 @@ type CliSubmitOpts struct {
 	GetArgs       GetFlags
 	ScheduledTime string   // --scheduled-time
 	Parameters    []string // --parameter
+	Interactive   bool     // --interactive
 }
 
 func WaitWatchOrLog(ctx context.Context, serviceClient workflowpkg.WorkflowServiceClient, namespace string, workflowNames []string, cliSubmitOpts CliSubmitOpts) {
 	if cliSubmitOpts.Log {
 		for _, workflow := range workflowNames {
-			LogWorkflow(ctx, serviceClient, namespace, workflow, "", "", "", &corev1.PodLogOptions{
-				Container: common.MainContainerName,
-				Follow:    true,
-				Previous:  false,
+			LogWorkflow(ctx, serviceClient, &workflowpkg.WorkflowLogRequest{
+				Name:      workflow,
+				Namespace: namespace,
+				LogOptions: &corev1.PodLogOptions{
+					Container: common.MainContainerName,
+					Follow:    true,
+					Previous:  false,
+				},
 			})
 		}
 	}
 	if cliSubmitOpts.Wait {
-		WaitWorkflows(ctx, serviceClient, namespace, workflowNames, false, !(cliSubmitOpts.Output == "" || cliSubmitOpts.Output == "wide"))
+		WaitWorkflows(ctx, serviceClient, namespace, workflowNames, false, !(cliSubmitOpts.Output == "" || cliSubmitOpts.Output == "wide"), cliSubmitOpts.WaitArgs)
 	} else if cliSubmitOpts.Watch {
 		for _, workflow := range workflowNames {
 			WatchWorkflow(ctx, serviceClient, namespace, workflow, cliSubmitOpts.GetArgs)
@@ -0,0 +1,243 @@
+package common
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+// templateCall is a call of a template in the static structure of a workflow spec, before anything is run
+type templateCall struct {
+	id string
+	// name is the name of the step or task that calls the template, or of the spec's field for the entrypoint and exit
+	// handler
+	name string
+	// group is the index of the step group of a step, or -1
+	group    int
+	template string
+	// tmplType is the type of the called template, empty if it is not in the spec
+	tmplType wfv1.TemplateType
+	// notes are the loops, conditions and dependencies of the call, and why it is not expanded
+	notes []string
+	// dependencies are the IDs of the calls of the same parent this call runs after
+	dependencies []string
+	calls        []*templateCall
+}
+
+// dependsTaskNameRegex matches the task names in a DAG task's depends expression
+var dependsTaskNameRegex = regexp.MustCompile(`[a-zA-Z0-9_-]+`)
+
+// getTemplateGraph returns the calls of the entrypoint, and of the exit handler if the spec has one, and the templates
+// they call in turn. Loops are noted on the call rather than expanded, and templates of other workflow templates, and
+// templates that call themselves, are not expanded.
+func getTemplateGraph(spec *wfv1.WorkflowSpec) []*templateCall {
+	templates := make(map[string]*wfv1.Template, len(spec.Templates))
+	for i := range spec.Templates {
+		templates[spec.Templates[i].Name] = &spec.Templates[i]
+	}
+	ids := 0
+	newCall := func(name string, group int) *templateCall {
+		c := &templateCall{id: fmt.Sprintf("n%d", ids), name: name, group: group}
+		ids++
+		return c
+	}
+	var expand func(c *templateCall, tmpl *wfv1.Template, stack map[string]bool)
+	resolve := func(c *templateCall, name string, inline *wfv1.Template, ref *wfv1.TemplateRef, stack map[string]bool) {
+		switch {
+		case inline != nil:
+			c.template = "(inline)"
+			expand(c, inline, stack)
+		case ref != nil:
+			c.template = ref.Name + "/" + ref.Template
+			if ref.ClusterScope {
+				c.notes = append(c.notes, "cluster template ref")
+			} else {
+				c.notes = append(c.notes, "template ref")
+			}
+		default:
+			c.template = name
+			tmpl, ok := templates[name]
+			switch {
+			case !ok:
+				c.notes = append(c.notes, "not found")
+			case stack[name]:
+				c.tmplType = tmpl.GetType()
+				c.notes = append(c.notes, "recursive")
+			default:
+				stack[name] = true
+				expand(c, tmpl, stack)
+				delete(stack, name)
+			}
+		}
+	}
+	expand = func(c *templateCall, tmpl *wfv1.Template, stack map[string]bool) {
+		c.tmplType = tmpl.GetType()
+		switch {
+		case tmpl.Steps != nil:
+			var previous []string
+			for i, group := range tmpl.Steps {
+				var current []string
+				for _, step := range group.Steps {
+					s := newCall(step.Name, i)
+					s.notes = getLoopNotes(step.WithItems, step.WithParam, step.WithSequence, step.When)
+					s.dependencies = previous
+					resolve(s, step.Template, step.Inline, step.TemplateRef, stack)
+					c.calls = append(c.calls, s)
+					current = append(current, s.id)
+				}
+				previous = current
+			}
+		case tmpl.DAG != nil:
+			taskIDs := make(map[string]string, len(tmpl.DAG.Tasks))
+			var tasks []*templateCall
+			for _, task := range tmpl.DAG.Tasks {
+				t := newCall(task.Name, -1)
+				taskIDs[task.Name] = t.id
+				tasks = append(tasks, t)
+			}
+			for i, task := range tmpl.DAG.Tasks {
+				t := tasks[i]
+				dependencies := task.Dependencies
+				if task.Depends != "" {
+					t.notes = append(t.notes, "depends: "+task.Depends)
+					dependencies = dependsTaskNameRegex.FindAllString(task.Depends, -1)
+				} else if len(task.Dependencies) > 0 {
+					t.notes = append(t.notes, "depends: "+strings.Join(task.Dependencies, ", "))
+				}
+				seen := make(map[string]bool)
+				for _, d := range dependencies {
+					if id, ok := taskIDs[d]; ok && !seen[id] {
+						seen[id] = true
+						t.dependencies = append(t.dependencies, id)
+					}
+				}
+				t.notes = append(t.notes, getLoopNotes(task.WithItems, task.WithParam, task.WithSequence, task.When)...)
+				resolve(t, task.Template, task.Inline, task.TemplateRef, stack)
+				c.calls = append(c.calls, t)
+			}
+		}
+	}
+	var graph []*templateCall
+	if spec.Entrypoint != "" {
+		c := newCall("entrypoint", -1)
+		resolve(c, spec.Entrypoint, nil, nil, map[string]bool{})
+		graph = append(graph, c)
+	}
+	if spec.OnExit != "" {
+		c := newCall("onExit", -1)
+		resolve(c, spec.OnExit, nil, nil, map[string]bool{})
+		graph = append(graph, c)
+	}
+	return graph
+}
+
+// getLoopNotes returns the notes of the loop and condition of a step or task
+func getLoopNotes(items []wfv1.Item, param string, sequence *wfv1.Sequence, when string) []string {
+	var notes []string
+	switch {
+	case len(items) > 0:
+		notes = append(notes, fmt.Sprintf("loop: withItems (%d)", len(items)))
+	case param != "":
+		notes = append(notes, "loop: withParam "+param)
+	case sequence != nil:
+		switch {
+		case sequence.Count != nil:
+			notes = append(notes, "loop: withSequence count "+sequence.Count.String())
+		default:
+			start, end := "0", "0"
+			if sequence.Start != nil {
+				start = sequence.Start.String()
+			}
+			if sequence.End != nil {
+				end = sequence.End.String()
+			}
+			notes = append(notes, fmt.Sprintf("loop: withSequence %s..%s", start, end))
+		}
+	}
+	if when != "" {
+		notes = append(notes, "when: "+when)
+	}
+	return notes
+}
+
+// getTemplateCallLabel returns the label of the call: the step or task and the template it calls, with its type and
+// notes
+func getTemplateCallLabel(c *templateCall) string {
+	label := c.name + ": " + c.template
+	if c.group >= 0 {
+		label = fmt.Sprintf("[%d] %s", c.group, label)
+	}
+	if c.tmplType != "" {
+		label += " (" + string(c.tmplType) + ")"
+	}
+	if len(c.notes) > 0 {
+		label += " [" + strings.Join(c.notes, "; ") + "]"
+	}
+	return label
+}
+
+// PrintTemplateTree returns the calls of the spec's templates, starting from its entrypoint and exit handler, as a tree
+func PrintTemplateTree(spec *wfv1.WorkflowSpec) string {
+	return printTemplateCallTree(getTemplateGraph(spec))
+}
+
+// printTemplateCallTree returns the calls and the calls of their templates as a tree
+func printTemplateCallTree(graph []*templateCall) string {
+	out := ""
+	var write func(c *templateCall, prefix, childPrefix string)
+	write = func(c *templateCall, prefix, childPrefix string) {
+		out += prefix + getTemplateCallLabel(c) + "\n"
+		for i, child := range c.calls {
+			if i == len(c.calls)-1 {
+				write(child, childPrefix+"└─ ", childPrefix+"   ")
+			} else {
+				write(child, childPrefix+"├─ ", childPrefix+"│  ")
+			}
+		}
+	}
+	for _, c := range graph {
+		write(c, "", "")
+	}
+	return out
+}
+
+// PrintTemplateDot returns the calls of the spec's templates in the Graphviz DOT language. Each call leads to the calls
+// of its template that run first, and the calls of a template lead to those that run after them. Looped calls have a
+// double border.
+func PrintTemplateDot(name string, spec *wfv1.WorkflowSpec) string {
+	return printTemplateCallDot(name, getTemplateGraph(spec))
+}
+
+// printTemplateCallDot returns the calls and the calls of their templates in the Graphviz DOT language
+func printTemplateCallDot(name string, graph []*templateCall) string {
+	escape := strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+	out := fmt.Sprintf("digraph \"%s\" {\n", escape.Replace(name))
+	out += "  node [shape=box, style=rounded];\n"
+	var edges []string
+	var write func(c *templateCall)
+	write = func(c *templateCall) {
+		out += fmt.Sprintf("  %s [label=\"%s\"", c.id, escape.Replace(getTemplateCallLabel(c)))
+		for _, n := range c.notes {
+			if strings.HasPrefix(n, "loop: ") {
+				out += ", peripheries=2"
+				break
+			}
+		}
+		out += "];\n"
+		for _, child := range c.calls {
+			if len(child.dependencies) == 0 {
+				edges = append(edges, fmt.Sprintf("  %s -> %s;\n", c.id, child.id))
+			}
+			for _, d := range child.dependencies {
+				edges = append(edges, fmt.Sprintf("  %s -> %s;\n", d, child.id))
+			}
+			write(child)
+		}
+	}
+	for _, c := range graph {
+		write(c)
+	}
+	return out + strings.Join(edges, "") + "}\n"
+}
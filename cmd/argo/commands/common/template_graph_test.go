@@ -0,0 +1,81 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+var graphWorkflowTemplate = wfv1.MustUnmarshalWorkflowTemplate(`
+metadata:
+  name: my-wftmpl
+spec:
+  entrypoint: main
+  onExit: exit
+  templates:
+    - name: main
+      dag:
+        tasks:
+          - name: A
+            template: echo
+          - name: B
+            template: echo
+            depends: A
+            withItems: [1, 2]
+          - name: C
+            template: nested
+            depends: A && B.Succeeded
+          - name: D
+            templateRef:
+              name: other
+              template: t
+    - name: nested
+      steps:
+        - - name: hello
+            template: echo
+            when: "{{workflow.status}} == Succeeded"
+        - - name: again
+            template: nested
+    - name: echo
+      container:
+        image: argoproj/argosay:v2
+    - name: exit
+      container:
+        image: argoproj/argosay:v2
+`)
+
+func TestPrintTemplateTree(t *testing.T) {
+	assert.Equal(t, `entrypoint: main (DAG)
+├─ A: echo (Container)
+├─ B: echo (Container) [depends: A; loop: withItems (2)]
+├─ C: nested (Steps) [depends: A && B.Succeeded]
+│  ├─ [0] hello: echo (Container) [when: {{workflow.status}} == Succeeded]
+│  └─ [1] again: nested (Steps) [recursive]
+└─ D: other/t [template ref]
+onExit: exit (Container)
+`, PrintTemplateTree(&graphWorkflowTemplate.Spec))
+}
+
+func TestPrintTemplateDot(t *testing.T) {
+	assert.Equal(t, `digraph "my-wftmpl" {
+  node [shape=box, style=rounded];
+  n0 [label="entrypoint: main (DAG)"];
+  n1 [label="A: echo (Container)"];
+  n2 [label="B: echo (Container) [depends: A; loop: withItems (2)]", peripheries=2];
+  n3 [label="C: nested (Steps) [depends: A && B.Succeeded]"];
+  n5 [label="[0] hello: echo (Container) [when: {{workflow.status}} == Succeeded]"];
+  n6 [label="[1] again: nested (Steps) [recursive]"];
+  n4 [label="D: other/t [template ref]"];
+  n7 [label="onExit: exit (Container)"];
+  n0 -> n1;
+  n1 -> n2;
+  n1 -> n3;
+  n2 -> n3;
+  n3 -> n5;
+  n5 -> n6;
+  n0 -> n4;
+}
+`, PrintTemplateDot(graphWorkflowTemplate.Name, &graphWorkflowTemplate.Spec))
+}
@@ -0,0 +1,56 @@
+package common
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/argoproj/argo-workflows/v3/workflow/render"
+)
+
+// getRenderedGraph returns the calls of the rendered nodes, with the input parameters of their templates noted last
+func getRenderedGraph(nodes []*render.Node) []*templateCall {
+	ids := 0
+	var convert func(nodes []*render.Node) []*templateCall
+	convert = func(nodes []*render.Node) []*templateCall {
+		names := make(map[string]string, len(nodes))
+		calls := make([]*templateCall, 0, len(nodes))
+		for _, n := range nodes {
+			c := &templateCall{id: fmt.Sprintf("n%d", ids), name: n.Name, group: n.StepGroup, template: n.Template, tmplType: n.Type}
+			ids++
+			names[n.Name] = c.id
+			calls = append(calls, c)
+		}
+		for i, n := range nodes {
+			c := calls[i]
+			var params []string
+			for _, p := range n.Parameters {
+				if p.Value != nil {
+					params = append(params, p.Name+"="+p.Value.String())
+				}
+			}
+			c.notes = append(c.notes, n.Notes...)
+			if len(params) > 0 {
+				c.notes = append(c.notes, strings.Join(params, ", "))
+			}
+			for _, d := range n.Dependencies {
+				if id, ok := names[d]; ok {
+					c.dependencies = append(c.dependencies, id)
+				}
+			}
+			c.calls = convert(n.Children)
+		}
+		return calls
+	}
+	return convert(nodes)
+}
+
+// PrintRenderedTree returns the rendered nodes of a workflow as a tree
+func PrintRenderedTree(nodes []*render.Node) string {
+	return printTemplateCallTree(getRenderedGraph(nodes))
+}
+
+// PrintRenderedDot returns the rendered nodes of a workflow in the Graphviz DOT language, as PrintTemplateDot does the
+// calls of its templates
+func PrintRenderedDot(name string, nodes []*render.Node) string {
+	return printTemplateCallDot(name, getRenderedGraph(nodes))
+}
@@ -0,0 +1,49 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/render"
+)
+
+var renderedNodes = []*render.Node{{
+	Name:      "my-wf-",
+	StepGroup: -1,
+	Template:  "main",
+	Type:      wfv1.TemplateTypeDAG,
+	Children: []*render.Node{
+		{Name: "B", StepGroup: -1, Template: "echo", Type: wfv1.TemplateTypeContainer, Dependencies: []string{"A(0:1)", "A(1:2)"}, Notes: []string{"depends: A"}},
+		{Name: "A(0:1)", StepGroup: -1, Template: "echo", Type: wfv1.TemplateTypeContainer, Parameters: []wfv1.Parameter{{Name: "message", Value: wfv1.AnyStringPtr("1")}}},
+		{Name: "A(1:2)", StepGroup: -1, Template: "echo", Type: wfv1.TemplateTypeContainer, Parameters: []wfv1.Parameter{{Name: "message", Value: wfv1.AnyStringPtr("2")}}},
+		{Name: "C", StepGroup: -1, Template: "echo", Notes: []string{"skipped: when 1 > 2"}},
+	},
+}}
+
+func TestPrintRenderedTree(t *testing.T) {
+	assert.Equal(t, `my-wf-: main (DAG)
+├─ B: echo (Container) [depends: A]
+├─ A(0:1): echo (Container) [message=1]
+├─ A(1:2): echo (Container) [message=2]
+└─ C: echo [skipped: when 1 > 2]
+`, PrintRenderedTree(renderedNodes))
+}
+
+func TestPrintRenderedDot(t *testing.T) {
+	assert.Equal(t, `digraph "my-wf-" {
+  node [shape=box, style=rounded];
+  n0 [label="my-wf-: main (DAG)"];
+  n1 [label="B: echo (Container) [depends: A]"];
+  n2 [label="A(0:1): echo (Container) [message=1]"];
+  n3 [label="A(1:2): echo (Container) [message=2]"];
+  n4 [label="C: echo [skipped: when 1 > 2]"];
+  n2 -> n1;
+  n3 -> n1;
+  n0 -> n2;
+  n0 -> n3;
+  n0 -> n4;
+}
+`, PrintRenderedDot("my-wf-", renderedNodes))
+}
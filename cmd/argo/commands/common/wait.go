@@ -7,7 +7,6 @@ import (
 	"os"
 	"sync"
 
-	"github.com/argoproj/pkg/errors"
 	log "github.com/sirupsen/logrus"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -16,31 +15,106 @@ import (
 	workflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 	"github.com/argoproj/argo-workflows/v3/util"
+	"github.com/argoproj/argo-workflows/v3/util/errors"
 )
 
+// The exit codes of waiting for workflows with --exit-codes, one for each way a workflow completes
+const (
+	ExitCodeSucceeded  = 0
+	ExitCodeFailed     = 2
+	ExitCodeError      = 3
+	ExitCodeTerminated = 4
+)
+
+// WaitFlags are the flags of waiting for workflows
+type WaitFlags struct {
+	ExitCodes    bool // --exit-codes
+	PrintFailure bool // --print-failure
+}
+
 // waitWorkflows waits for the given workflowNames.
-func WaitWorkflows(ctx context.Context, serviceClient workflowpkg.WorkflowServiceClient, namespace string, workflowNames []string, ignoreNotFound, quiet bool) {
+func WaitWorkflows(ctx context.Context, serviceClient workflowpkg.WorkflowServiceClient, namespace string, workflowNames []string, ignoreNotFound, quiet bool, flags WaitFlags) {
 	var wg sync.WaitGroup
-	wfSuccessStatus := true
+	var mu sync.Mutex
+	code := ExitCodeSucceeded
 
 	for _, name := range workflowNames {
 		wg.Add(1)
 		go func(name string) {
-			if !waitOnOne(serviceClient, ctx, name, namespace, ignoreNotFound, quiet) {
-				wfSuccessStatus = false
+			defer wg.Done()
+			wf := waitOnOne(serviceClient, ctx, name, namespace, ignoreNotFound, quiet)
+			if wf == nil {
+				return
+			}
+			if flags.PrintFailure {
+				if message := failureMessage(wf); message != "" {
+					fmt.Println(message)
+				}
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			// the exit code is that of the worst outcome
+			if c := exitCode(wf); c > code {
+				code = c
 			}
-			wg.Done()
 		}(name)
 
 	}
 	wg.Wait()
 
-	if !wfSuccessStatus {
-		os.Exit(1)
+	if code != ExitCodeSucceeded {
+		if !flags.ExitCodes {
+			code = 1
+		}
+		os.Exit(code)
 	}
 }
 
-func waitOnOne(serviceClient workflowpkg.WorkflowServiceClient, ctx context.Context, wfName, namespace string, ignoreNotFound, quiet bool) bool {
+// WaitWorkflow waits for the workflow to finish, printing its phase unless quiet, and returns whether it succeeded
+func WaitWorkflow(ctx context.Context, serviceClient workflowpkg.WorkflowServiceClient, namespace, workflowName string, quiet bool) bool {
+	wf := waitOnOne(serviceClient, ctx, workflowName, namespace, false, quiet)
+	return wf != nil && exitCode(wf) == ExitCodeSucceeded
+}
+
+// exitCode returns the exit code of the completed workflow, that of a terminated or stopped workflow whether it
+// failed or errored
+func exitCode(wf *wfv1.Workflow) int {
+	if !wf.Status.Phase.Completed() || wf.Status.Phase == wfv1.WorkflowSucceeded {
+		return ExitCodeSucceeded
+	}
+	if wf.Spec.Shutdown != "" {
+		return ExitCodeTerminated
+	}
+	if wf.Status.Phase == wfv1.WorkflowError {
+		return ExitCodeError
+	}
+	return ExitCodeFailed
+}
+
+// failureMessage returns the message of the first pod node of the workflow to fail, or of the workflow if none did,
+// or the empty string if the workflow succeeded
+func failureMessage(wf *wfv1.Workflow) string {
+	if exitCode(wf) == ExitCodeSucceeded {
+		return ""
+	}
+	var first *wfv1.NodeStatus
+	for _, node := range wf.Status.Nodes {
+		if node.Type != wfv1.NodeTypePod || !node.FailedOrError() {
+			continue
+		}
+		if first == nil || node.FinishedAt.Before(&first.FinishedAt) {
+			node := node
+			first = &node
+		}
+	}
+	if first == nil {
+		return fmt.Sprintf("%s %s: %s", wf.Name, wf.Status.Phase, wf.Status.Message)
+	}
+	return fmt.Sprintf("%s %s: %s %s: %s", wf.Name, wf.Status.Phase, first.Name, first.Phase, first.Message)
+}
+
+// waitOnOne waits for the workflow to complete, and returns it, or nil if it was not found and that is ignored
+func waitOnOne(serviceClient workflowpkg.WorkflowServiceClient, ctx context.Context, wfName, namespace string, ignoreNotFound, quiet bool) *wfv1.Workflow {
 	req := &workflowpkg.WatchWorkflowsRequest{
 		Namespace: namespace,
 		ListOptions: &metav1.ListOptions{
@@ -51,10 +125,10 @@ func waitOnOne(serviceClient workflowpkg.WorkflowServiceClient, ctx context.Cont
 	stream, err := serviceClient.WatchWorkflows(ctx, req)
 	if err != nil {
 		if status.Code(err) == codes.NotFound && ignoreNotFound {
-			return true
+			return nil
 		}
 		errors.CheckError(err)
-		return false
+		return nil
 	}
 	for {
 		event, err := stream.Recv()
@@ -73,10 +147,7 @@ func waitOnOne(serviceClient workflowpkg.WorkflowServiceClient, ctx context.Cont
 			if !quiet {
 				fmt.Printf("%s %s at %v\n", wfName, wf.Status.Phase, wf.Status.FinishedAt)
 			}
-			if wf.Status.Phase == wfv1.WorkflowFailed || wf.Status.Phase == wfv1.WorkflowError {
-				return false
-			}
-			return true
+			return wf
 		}
 	}
 }
@@ -0,0 +1,58 @@
+package common
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+func Test_exitCode(t *testing.T) {
+	for _, tt := range []struct {
+		phase    wfv1.WorkflowPhase
+		shutdown wfv1.ShutdownStrategy
+		want     int
+	}{
+		{wfv1.WorkflowSucceeded, "", ExitCodeSucceeded},
+		{wfv1.WorkflowFailed, "", ExitCodeFailed},
+		{wfv1.WorkflowError, "", ExitCodeError},
+		{wfv1.WorkflowFailed, wfv1.ShutdownStrategyTerminate, ExitCodeTerminated},
+		{wfv1.WorkflowFailed, wfv1.ShutdownStrategyStop, ExitCodeTerminated},
+		{wfv1.WorkflowSucceeded, wfv1.ShutdownStrategyStop, ExitCodeSucceeded},
+	} {
+		t.Run(string(tt.phase)+string(tt.shutdown), func(t *testing.T) {
+			wf := &wfv1.Workflow{Spec: wfv1.WorkflowSpec{Shutdown: tt.shutdown}, Status: wfv1.WorkflowStatus{Phase: tt.phase}}
+			assert.Equal(t, tt.want, exitCode(wf))
+		})
+	}
+}
+
+func Test_failureMessage(t *testing.T) {
+	now := time.Now()
+	wf := &wfv1.Workflow{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-wf"},
+		Status: wfv1.WorkflowStatus{
+			Phase:   wfv1.WorkflowFailed,
+			Message: "child 'my-wf-2' failed",
+			Nodes: wfv1.Nodes{
+				"my-wf":   {Name: "my-wf", Type: wfv1.NodeTypeSteps, Phase: wfv1.NodeFailed, Message: "child 'my-wf-2' failed", FinishedAt: metav1.NewTime(now.Add(2 * time.Second))},
+				"my-wf-1": {Name: "my-wf[0].a", Type: wfv1.NodeTypePod, Phase: wfv1.NodeFailed, Message: "Error (exit code 1)", FinishedAt: metav1.NewTime(now.Add(time.Second))},
+				"my-wf-2": {Name: "my-wf[0].b", Type: wfv1.NodeTypePod, Phase: wfv1.NodeError, Message: "OOMKilled", FinishedAt: metav1.NewTime(now)},
+				"my-wf-3": {Name: "my-wf[0].c", Type: wfv1.NodeTypePod, Phase: wfv1.NodeSucceeded, FinishedAt: metav1.NewTime(now.Add(-time.Second))},
+			},
+		},
+	}
+	assert.Equal(t, "my-wf Failed: my-wf[0].b Error: OOMKilled", failureMessage(wf))
+
+	t.Run("NoFailedNode", func(t *testing.T) {
+		wf := &wfv1.Workflow{ObjectMeta: metav1.ObjectMeta{Name: "my-wf"}, Status: wfv1.WorkflowStatus{Phase: wfv1.WorkflowError, Message: "invalid spec"}}
+		assert.Equal(t, "my-wf Error: invalid spec", failureMessage(wf))
+	})
+	t.Run("Succeeded", func(t *testing.T) {
+		wf := &wfv1.Workflow{Status: wfv1.WorkflowStatus{Phase: wfv1.WorkflowSucceeded}}
+		assert.Empty(t, failureMessage(wf))
+	})
+}
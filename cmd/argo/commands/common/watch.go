@@ -4,15 +4,21 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
 	"time"
 
-	"github.com/argoproj/pkg/errors"
+	"github.com/argoproj/pkg/humanize"
 	log "github.com/sirupsen/logrus"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	workflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 	"github.com/argoproj/argo-workflows/v3/util"
+	"github.com/argoproj/argo-workflows/v3/util/errors"
+	"github.com/argoproj/argo-workflows/v3/util/printer"
 	"github.com/argoproj/argo-workflows/v3/workflow/packer"
 )
 
@@ -75,7 +81,107 @@ func printWorkflowStatus(wf *wfv1.Workflow, getArgs GetFlags) {
 	}
 	err := packer.DecompressWorkflow(wf)
 	errors.CheckError(err)
-	print("\033[H\033[2J")
-	print("\033[0;0H")
-	fmt.Print(PrintWorkflowHelper(wf, getArgs))
+	redraw(os.Stdout, PrintWorkflowHelper(wf, getArgs))
+}
+
+// redraw replaces what is on the terminal with the output, overwriting each line and clearing the rest of it, and then
+// clearing the lines below, rather than clearing the screen first, so that it is updated in place without flickering
+func redraw(out io.Writer, output string) {
+	lines := strings.Split(strings.TrimSuffix(output, "\n"), "\n")
+	_, _ = io.WriteString(out, "\033[H"+strings.Join(lines, "\033[K\n")+"\033[K\n\033[J")
+}
+
+// WatchWorkflowsBySelector watches the workflows matching the label selector in a table that is updated in place, until
+// they have all completed
+func WatchWorkflowsBySelector(ctx context.Context, serviceClient workflowpkg.WorkflowServiceClient, namespace string, labelSelector string) {
+	req := &workflowpkg.WatchWorkflowsRequest{
+		Namespace: namespace,
+		ListOptions: &metav1.ListOptions{
+			LabelSelector:   labelSelector,
+			ResourceVersion: "0",
+		},
+	}
+	stream, err := serviceClient.WatchWorkflows(ctx, req)
+	errors.CheckError(err)
+
+	eventChan := make(chan *workflowpkg.WorkflowWatchEvent)
+	go func() {
+		for {
+			event, err := stream.Recv()
+			if err == io.EOF {
+				log.Debug("Re-establishing workflow watch")
+				stream, err = serviceClient.WatchWorkflows(ctx, req)
+				errors.CheckError(err)
+				continue
+			}
+			errors.CheckError(err)
+			if event == nil || event.Object == nil {
+				continue
+			}
+			eventChan <- event
+		}
+	}()
+
+	workflows := make(map[string]wfv1.Workflow)
+	ticker := time.NewTicker(time.Second)
+	for {
+		select {
+		case event := <-eventChan:
+			if event.Type == "DELETED" {
+				delete(workflows, event.Object.Name)
+			} else {
+				workflows[event.Object.Name] = *event.Object
+			}
+		case <-ticker.C:
+			// refresh the durations of the running workflows every second
+		case <-ctx.Done():
+			return
+		}
+
+		var list []wfv1.Workflow
+		completed := true
+		for _, wf := range workflows {
+			list = append(list, wf)
+			completed = completed && !wf.Status.FinishedAt.IsZero()
+		}
+		out := &strings.Builder{}
+		printWorkflowsStatus(out, list, time.Now())
+		redraw(os.Stdout, out.String())
+		if len(list) > 0 && completed {
+			return
+		}
+	}
+}
+
+// printWorkflowsStatus prints the phase, progress and duration of each workflow, by name
+func printWorkflowsStatus(out io.Writer, workflows []wfv1.Workflow, now time.Time) {
+	if len(workflows) == 0 {
+		_, _ = fmt.Fprintln(out, "Waiting for workflows matching the selector")
+		return
+	}
+	sort.Slice(workflows, func(i, j int) bool { return workflows[i].Name < workflows[j].Name })
+	w := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+	_, _ = fmt.Fprintln(w, "NAME\tSTATUS\tPROGRESS\tDURATION\tMESSAGE")
+	running := 0
+	for i := range workflows {
+		wf := &workflows[i]
+		duration := "-"
+		if !wf.Status.StartedAt.IsZero() {
+			finishedAt := now
+			if !wf.Status.FinishedAt.IsZero() {
+				finishedAt = wf.Status.FinishedAt.Time
+			}
+			duration = humanize.RelativeDurationShort(wf.Status.StartedAt.Time, finishedAt)
+		}
+		progress := string(wf.Status.Progress)
+		if progress == "" {
+			progress = "-"
+		}
+		if wf.Status.FinishedAt.IsZero() {
+			running++
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", wf.Name, printer.WorkflowStatus(wf), progress, duration, wf.Status.Message)
+	}
+	_ = w.Flush()
+	_, _ = fmt.Fprintf(out, "\n%d of %d workflows completed\n", len(workflows)-running, len(workflows))
 }
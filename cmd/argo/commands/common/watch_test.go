@@ -0,0 +1,54 @@
+package common
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+func Test_printWorkflowsStatus(t *testing.T) {
+	now := time.Now()
+	t.Run("NoWorkflows", func(t *testing.T) {
+		out := &bytes.Buffer{}
+		printWorkflowsStatus(out, nil, now)
+		assert.Equal(t, "Waiting for workflows matching the selector\n", out.String())
+	})
+	t.Run("Workflows", func(t *testing.T) {
+		workflows := []wfv1.Workflow{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "wf-b", CreationTimestamp: metav1.NewTime(now)},
+				Status:     wfv1.WorkflowStatus{Phase: wfv1.WorkflowRunning, Progress: "1/3", StartedAt: metav1.NewTime(now.Add(-30 * time.Second))},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "wf-c", CreationTimestamp: metav1.NewTime(now)},
+				Status:     wfv1.WorkflowStatus{Phase: wfv1.WorkflowPending},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "wf-a", CreationTimestamp: metav1.NewTime(now)},
+				Status:     wfv1.WorkflowStatus{Phase: wfv1.WorkflowFailed, Progress: "1/2", Message: "child failed", StartedAt: metav1.NewTime(now.Add(-2 * time.Minute)), FinishedAt: metav1.NewTime(now.Add(-time.Minute))},
+			},
+		}
+		out := &bytes.Buffer{}
+		printWorkflowsStatus(out, workflows, now)
+		lines := strings.Split(out.String(), "\n")
+		if assert.Len(t, lines, 7) {
+			assert.Equal(t, []string{"NAME", "STATUS", "PROGRESS", "DURATION", "MESSAGE"}, strings.Fields(lines[0]))
+			assert.Equal(t, []string{"wf-a", "Failed", "1/2", "1m", "child", "failed"}, strings.Fields(lines[1]))
+			assert.Equal(t, []string{"wf-b", "Running", "1/3", "30s"}, strings.Fields(lines[2]))
+			assert.Equal(t, []string{"wf-c", "Pending", "-", "-"}, strings.Fields(lines[3]))
+			assert.Equal(t, "1 of 3 workflows completed", lines[5])
+		}
+	})
+}
+
+func Test_redraw(t *testing.T) {
+	out := &bytes.Buffer{}
+	redraw(out, "Name: my-wf\nStatus: Running\n")
+	assert.Equal(t, "\033[HName: my-wf\033[K\nStatus: Running\033[K\n\033[J", out.String())
+}
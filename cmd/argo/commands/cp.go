@@ -1,6 +1,7 @@
 package commands
 
 import (
+	"context"
 	"crypto/tls"
 	"fmt"
 	"io"
@@ -10,9 +11,12 @@ import (
 	"path"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
+	"k8s.io/client-go/kubernetes"
 
+	"github.com/argoproj/argo-workflows/v3"
 	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/client"
 	"github.com/argoproj/argo-workflows/v3/pkg/apiclient"
 	workflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
@@ -26,10 +30,13 @@ func NewCpCommand() *cobra.Command {
 		templateName string // --template-name
 		artifactName string // --artifact-name
 		customPath   string // --path
+		volume       string // --volume
+		image        string // --image
+		timeout      time.Duration
 	)
 	command := &cobra.Command{
 		Use:   "cp my-wf output-directory ...",
-		Short: "copy artifacts from workflow",
+		Short: "copy artifacts from workflow, or a local file into a suspended workflow's volume",
 		Example: `# Copy a workflow's artifacts to a local output directory:
 
   argo cp my-wf output-directory
@@ -37,6 +44,15 @@ func NewCpCommand() *cobra.Command {
 # Copy artifacts from a specific node in a workflow to a local output directory:
 
   argo cp my-wf output-directory --node-id=my-wf-node-id-123
+
+# Copy a local file into the persistent volume claim of a workflow while a step is suspended, then resume it:
+
+  argo cp corrected.csv my-wf:data/corrected.csv
+  argo resume my-wf
+
+# Copy a local file into a specific volume of a workflow, while a specific step is suspended:
+
+  argo cp corrected.csv my-wf:data/corrected.csv --volume=workdir --node-id=my-wf-node-id-123
 `,
 		SilenceUsage: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -46,12 +62,19 @@ func NewCpCommand() *cobra.Command {
 			}
 			workflowName := args[0]
 			outputDir := args[1]
+			uploadWorkflowName, dest, upload := parseUploadDestination(args[1])
+			if upload {
+				workflowName = uploadWorkflowName
+			}
 
 			ctx, apiClient := client.NewAPIClient(cmd.Context())
 			serviceClient := apiClient.NewWorkflowServiceClient()
 			if len(namespace) == 0 {
 				namespace = client.Namespace()
 			}
+			if upload {
+				return uploadToWorkflow(ctx, serviceClient, namespace, workflowName, nodeId, volume, image, args[0], dest, timeout)
+			}
 			workflow, err := serviceClient.GetWorkflow(ctx, &workflowpkg.WorkflowGetRequest{
 				Name:      workflowName,
 				Namespace: namespace,
@@ -107,12 +130,47 @@ func NewCpCommand() *cobra.Command {
 	command.Flags().StringVar(&nodeId, "node-id", "", "id of node in workflow")
 	command.Flags().StringVar(&templateName, "template-name", "", "name of template in workflow")
 	command.Flags().StringVar(&artifactName, "artifact-name", "", "name of output artifact in workflow")
+	command.Flags().StringVar(&volume, "volume", "", "name of the persistent volume claim volume of the workflow to upload into, required if it has more than one")
+	command.Flags().StringVar(&image, "image", "quay.io/argoproj/argoexec:"+argo.ImageTag(), "executor image of the pod that uploads the file")
+	command.Flags().DurationVar(&timeout, "timeout", 5*time.Minute, "how long to wait for the pod that uploads the file to start and complete")
 	command.Flags().StringVar(&customPath, "path", "{namespace}/{workflowName}/{nodeId}/outputs/{artifactName}", "use variables {workflowName}, {nodeId}, {templateName}, {artifactName}, and {namespace} to create a customized path to store the artifacts; example: {workflowName}/{templateName}/{artifactName}")
 	return command
 }
 
+func uploadToWorkflow(ctx context.Context, serviceClient workflowpkg.WorkflowServiceClient, namespace, workflowName, nodeID, volumeName, image, src, dest string, timeout time.Duration) error {
+	wf, err := serviceClient.GetWorkflow(ctx, &workflowpkg.WorkflowGetRequest{Name: workflowName, Namespace: namespace})
+	if err != nil {
+		return fmt.Errorf("failed to get workflow: %w", err)
+	}
+	node, err := getSuspendedNode(wf, nodeID)
+	if err != nil {
+		return err
+	}
+	volume, err := getUploadVolume(wf, volumeName)
+	if err != nil {
+		return err
+	}
+	restConfig, err := client.GetConfig().ClientConfig()
+	if err != nil {
+		return err
+	}
+	kubeClient, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return err
+	}
+	if err := uploadFile(ctx, restConfig, kubeClient, wf, volume, image, src, dest, timeout); err != nil {
+		return err
+	}
+	log.Printf("Uploaded %q to %q of volume %q while %q is suspended", src, dest, volume.Name, node.DisplayName)
+	return nil
+}
+
 func getAndStoreArtifactData(namespace string, workflowName string, nodeId string, artifactName string, fileName string, customPath string, c *http.Client, argoServerOpts apiclient.ArgoServerOpts) error {
-	request, err := http.NewRequest("GET", fmt.Sprintf("%s/artifacts/%s/%s/%s/%s", argoServerOpts.GetURL(), namespace, workflowName, nodeId, artifactName), nil)
+	argoServerURL, err := argoServerOpts.ResolveURL()
+	if err != nil {
+		return err
+	}
+	request, err := http.NewRequest("GET", fmt.Sprintf("%s/artifacts/%s/%s/%s/%s", argoServerURL, namespace, workflowName, nodeId, artifactName), nil)
 	if err != nil {
 		return fmt.Errorf("failed to create request: %w", err)
 	}
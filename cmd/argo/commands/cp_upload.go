@@ -0,0 +1,184 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"regexp"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+
+	"github.com/argoproj/argo-workflows/v3/pkg/apis/workflow"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+// uploadMountPath is where the uploader pod mounts the workflow's volume
+const uploadMountPath = "/mnt/upload"
+
+var uploadDestination = regexp.MustCompile(`^([a-z0-9]([-.a-z0-9]*[a-z0-9])?):(.+)$`)
+
+// parseUploadDestination returns the workflow name and the path within the volume of a destination such as
+// `my-wf:data/corrected.csv`, or false if the argument is not a destination
+func parseUploadDestination(arg string) (string, string, bool) {
+	m := uploadDestination.FindStringSubmatch(arg)
+	if m == nil {
+		return "", "", false
+	}
+	return m[1], m[3], true
+}
+
+// getUploadVolume returns the volume of the workflow with the name, or its only persistent volume claim if the name is
+// empty
+func getUploadVolume(wf *wfv1.Workflow, name string) (*apiv1.Volume, error) {
+	var volumes []apiv1.Volume
+	volumes = append(volumes, wf.Status.PersistentVolumeClaims...)
+	if wf.Status.StoredWorkflowSpec != nil {
+		volumes = append(volumes, wf.Status.StoredWorkflowSpec.Volumes...)
+	}
+	volumes = append(volumes, wf.Spec.Volumes...)
+	var claims []apiv1.Volume
+	for _, v := range volumes {
+		if v.PersistentVolumeClaim == nil {
+			continue
+		}
+		if v.Name == name {
+			return &v, nil
+		}
+		claims = append(claims, v)
+	}
+	switch {
+	case name != "":
+		return nil, fmt.Errorf("workflow has no persistent volume claim named %q", name)
+	case len(claims) == 1:
+		return &claims[0], nil
+	case len(claims) == 0:
+		return nil, fmt.Errorf("workflow has no persistent volume claims")
+	default:
+		return nil, fmt.Errorf("workflow has %d persistent volume claims, use --volume to choose one", len(claims))
+	}
+}
+
+// getSuspendedNode returns the running suspend node of the workflow with the ID or name, or its only one if nodeID is
+// empty
+func getSuspendedNode(wf *wfv1.Workflow, nodeID string) (*wfv1.NodeStatus, error) {
+	var suspended []wfv1.NodeStatus
+	for _, n := range wf.Status.Nodes {
+		if n.Type != wfv1.NodeTypeSuspend || n.Phase != wfv1.NodeRunning {
+			continue
+		}
+		if n.ID == nodeID || n.Name == nodeID {
+			return &n, nil
+		}
+		suspended = append(suspended, n)
+	}
+	switch {
+	case nodeID != "":
+		return nil, fmt.Errorf("node %q is not a suspended step of the workflow", nodeID)
+	case len(suspended) == 1:
+		return &suspended[0], nil
+	case len(suspended) == 0:
+		return nil, fmt.Errorf("workflow has no suspended steps, files can only be uploaded while a step is suspended")
+	default:
+		return nil, fmt.Errorf("workflow has %d suspended steps, use --node-id to choose one", len(suspended))
+	}
+}
+
+// newUploaderPod returns a pod that mounts the volume and writes the file streamed to its stdin to the path within it
+func newUploaderPod(wf *wfv1.Workflow, volume *apiv1.Volume, image, dest string) *apiv1.Pod {
+	return &apiv1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName:    wf.Name + "-upload-",
+			Namespace:       wf.Namespace,
+			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(wf, wfv1.SchemeGroupVersion.WithKind(workflow.WorkflowKind))},
+		},
+		Spec: apiv1.PodSpec{
+			RestartPolicy: apiv1.RestartPolicyNever,
+			// run as the workflow's pods do, so that they can read the file
+			SecurityContext: wf.Spec.SecurityContext,
+			Volumes:         []apiv1.Volume{*volume},
+			Containers: []apiv1.Container{{
+				Name:         common.MainContainerName,
+				Image:        image,
+				Command:      []string{"argoexec", "receive", path.Join(uploadMountPath, path.Clean("/"+dest))},
+				Stdin:        true,
+				StdinOnce:    true,
+				VolumeMounts: []apiv1.VolumeMount{{Name: volume.Name, MountPath: uploadMountPath}},
+			}},
+		},
+	}
+}
+
+// uploadFile copies the local file into the volume of the workflow with a pod that it deletes afterwards
+func uploadFile(ctx context.Context, restConfig *rest.Config, kubeClient kubernetes.Interface, wf *wfv1.Workflow, volume *apiv1.Volume, image, src, dest string, timeout time.Duration) error {
+	f, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	pods := kubeClient.CoreV1().Pods(wf.Namespace)
+	pod, err := pods.Create(ctx, newUploaderPod(wf, volume, image, dest), metav1.CreateOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to create uploader pod: %w", err)
+	}
+	defer func() {
+		if err := pods.Delete(context.Background(), pod.Name, metav1.DeleteOptions{}); err != nil {
+			log.WithError(err).Warnf("failed to delete uploader pod %s", pod.Name)
+		}
+	}()
+	phase, err := waitForPodPhase(ctx, kubeClient, pod, timeout, apiv1.PodRunning, apiv1.PodSucceeded, apiv1.PodFailed)
+	if err != nil {
+		return fmt.Errorf("uploader pod %s did not start: %w", pod.Name, err)
+	}
+	if phase != apiv1.PodRunning {
+		return fmt.Errorf("uploader pod %s is %s", pod.Name, phase)
+	}
+	req := kubeClient.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Name(pod.Name).
+		Namespace(pod.Namespace).
+		SubResource("attach").
+		VersionedParams(&apiv1.PodAttachOptions{Container: common.MainContainerName, Stdin: true, Stdout: true, Stderr: true}, scheme.ParameterCodec)
+	attach, err := remotecommand.NewSPDYExecutor(restConfig, "POST", req.URL())
+	if err != nil {
+		return err
+	}
+	if err := attach.Stream(remotecommand.StreamOptions{Stdin: f, Stdout: os.Stdout, Stderr: os.Stderr}); err != nil {
+		return fmt.Errorf("failed to stream file to uploader pod %s: %w", pod.Name, err)
+	}
+	phase, err = waitForPodPhase(ctx, kubeClient, pod, timeout, apiv1.PodSucceeded, apiv1.PodFailed)
+	if err != nil {
+		return fmt.Errorf("uploader pod %s did not complete: %w", pod.Name, err)
+	}
+	if phase != apiv1.PodSucceeded {
+		return fmt.Errorf("uploader pod %s failed", pod.Name)
+	}
+	return nil
+}
+
+func waitForPodPhase(ctx context.Context, kubeClient kubernetes.Interface, pod *apiv1.Pod, timeout time.Duration, phases ...apiv1.PodPhase) (apiv1.PodPhase, error) {
+	var phase apiv1.PodPhase
+	err := wait.PollImmediate(time.Second, timeout, func() (bool, error) {
+		p, err := kubeClient.CoreV1().Pods(pod.Namespace).Get(ctx, pod.Name, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		phase = p.Status.Phase
+		for _, v := range phases {
+			if phase == v {
+				return true, nil
+			}
+		}
+		return false, nil
+	})
+	return phase, err
+}
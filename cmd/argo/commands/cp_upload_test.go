@@ -0,0 +1,81 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+func TestParseUploadDestination(t *testing.T) {
+	name, dest, ok := parseUploadDestination("my-wf:data/corrected.csv")
+	assert.True(t, ok)
+	assert.Equal(t, "my-wf", name)
+	assert.Equal(t, "data/corrected.csv", dest)
+	_, _, ok = parseUploadDestination("output-directory")
+	assert.False(t, ok)
+	_, _, ok = parseUploadDestination(`C:\output-directory`)
+	assert.False(t, ok)
+}
+
+func pvcVolume(name string) apiv1.Volume {
+	return apiv1.Volume{Name: name, VolumeSource: apiv1.VolumeSource{PersistentVolumeClaim: &apiv1.PersistentVolumeClaimVolumeSource{ClaimName: "my-wf-" + name}}}
+}
+
+func TestGetUploadVolume(t *testing.T) {
+	wf := &wfv1.Workflow{
+		Spec:   wfv1.WorkflowSpec{Volumes: []apiv1.Volume{{Name: "config", VolumeSource: apiv1.VolumeSource{EmptyDir: &apiv1.EmptyDirVolumeSource{}}}}},
+		Status: wfv1.WorkflowStatus{PersistentVolumeClaims: []apiv1.Volume{pvcVolume("workdir")}},
+	}
+	t.Run("Only", func(t *testing.T) {
+		v, err := getUploadVolume(wf, "")
+		require.NoError(t, err)
+		assert.Equal(t, "workdir", v.Name)
+	})
+	t.Run("NotPersistentVolumeClaim", func(t *testing.T) {
+		_, err := getUploadVolume(wf, "config")
+		assert.EqualError(t, err, `workflow has no persistent volume claim named "config"`)
+	})
+	t.Run("More", func(t *testing.T) {
+		wf := wf.DeepCopy()
+		wf.Spec.Volumes = append(wf.Spec.Volumes, pvcVolume("data"))
+		_, err := getUploadVolume(wf, "")
+		assert.EqualError(t, err, "workflow has 2 persistent volume claims, use --volume to choose one")
+		v, err := getUploadVolume(wf, "data")
+		require.NoError(t, err)
+		assert.Equal(t, "my-wf-data", v.PersistentVolumeClaim.ClaimName)
+	})
+}
+
+func TestGetSuspendedNode(t *testing.T) {
+	wf := &wfv1.Workflow{Status: wfv1.WorkflowStatus{Nodes: wfv1.Nodes{
+		"my-wf-1": {ID: "my-wf-1", Name: "my-wf.approve", Type: wfv1.NodeTypeSuspend, Phase: wfv1.NodeRunning},
+		"my-wf-2": {ID: "my-wf-2", Name: "my-wf.process", Type: wfv1.NodeTypePod, Phase: wfv1.NodeRunning},
+		"my-wf-3": {ID: "my-wf-3", Name: "my-wf.review", Type: wfv1.NodeTypeSuspend, Phase: wfv1.NodeSucceeded},
+	}}}
+	node, err := getSuspendedNode(wf, "")
+	require.NoError(t, err)
+	assert.Equal(t, "my-wf-1", node.ID)
+	node, err = getSuspendedNode(wf, "my-wf.approve")
+	require.NoError(t, err)
+	assert.Equal(t, "my-wf-1", node.ID)
+	_, err = getSuspendedNode(wf, "my-wf-3")
+	assert.EqualError(t, err, `node "my-wf-3" is not a suspended step of the workflow`)
+}
+
+func TestNewUploaderPod(t *testing.T) {
+	wf := &wfv1.Workflow{ObjectMeta: metav1.ObjectMeta{Name: "my-wf", Namespace: "my-ns"}}
+	volume := pvcVolume("workdir")
+	pod := newUploaderPod(wf, &volume, "argoexec:latest", "../../data/corrected.csv")
+	assert.Equal(t, "my-wf-upload-", pod.GenerateName)
+	assert.Equal(t, "my-ns", pod.Namespace)
+	require.Len(t, pod.OwnerReferences, 1)
+	assert.Equal(t, "Workflow", pod.OwnerReferences[0].Kind)
+	require.Len(t, pod.Spec.Containers, 1)
+	assert.Equal(t, []string{"argoexec", "receive", "/mnt/upload/data/corrected.csv"}, pod.Spec.Containers[0].Command)
+	assert.True(t, pod.Spec.Containers[0].StdinOnce)
+}
@@ -0,0 +1,157 @@
+package cron
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/client"
+	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/common"
+	"github.com/argoproj/argo-workflows/v3/pkg/apiclient/cronworkflow"
+	workflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
+	"github.com/argoproj/argo-workflows/v3/pkg/apis/workflow"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/util/errors"
+	wfcommon "github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+type backfillOpts struct {
+	start         string // --start
+	end           string // --end
+	parallelism   int    // --parallelism
+	parameterName string // --parameter-name
+}
+
+// NewBackfillCommand returns a new instance of an `argo cron backfill` command
+func NewBackfillCommand() *cobra.Command {
+	var opts backfillOpts
+	command := &cobra.Command{
+		Use:   "backfill CRON_WORKFLOW",
+		Short: "submit a workflow for each time a cron workflow was scheduled in a window",
+		Long: `Submit a workflow from the cron workflow for each time its schedule falls between the start and end, inclusive.
+
+Each workflow is named, and has its scheduled time, as if the cron workflow controller had created it, so times that
+have already run are skipped. The scheduled time is passed to the workflow as a parameter, as well as being available
+as {{workflow.scheduledTime}}.`,
+		Example: `# Run the workflows that were scheduled on the first of January, one at a time:
+  argo cron backfill my-cron-wf --start 2024-01-01T00:00:00Z --end 2024-01-01T23:59:59Z
+
+# Run up to four at a time, passing the scheduled time as the "date" parameter:
+  argo cron backfill my-cron-wf --start 2024-01-01T00:00:00Z --end 2024-01-31T23:59:59Z --parallelism 4 --parameter-name date`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: common.CompleteArgs(common.CompleteCronWorkflows),
+		Run: func(cmd *cobra.Command, args []string) {
+			start, err := time.Parse(time.RFC3339, opts.start)
+			errors.CheckError(err)
+			end, err := time.Parse(time.RFC3339, opts.end)
+			errors.CheckError(err)
+			if end.Before(start) {
+				log.Fatalf("--end %s is before --start %s", opts.end, opts.start)
+			}
+			if opts.parallelism < 0 {
+				log.Fatalf("--parallelism must not be negative")
+			}
+
+			ctx, apiClient := client.NewAPIClient(cmd.Context())
+			cronClient, err := apiClient.NewCronWorkflowServiceClient()
+			errors.CheckError(err)
+			serviceClient := apiClient.NewWorkflowServiceClient()
+			namespace := client.Namespace()
+
+			cronWf, err := cronClient.GetCronWorkflow(ctx, &cronworkflow.GetCronWorkflowRequest{Name: args[0], Namespace: namespace})
+			errors.CheckError(err)
+			times, err := GetScheduledTimes(cronWf, start, end)
+			errors.CheckError(err)
+			if len(times) == 0 {
+				fmt.Printf("CronWorkflow '%s' was not scheduled between %s and %s\n", cronWf.Name, opts.start, opts.end)
+				return
+			}
+			if !backfill(ctx, serviceClient, cronWf, times, opts) {
+				os.Exit(1)
+			}
+		},
+	}
+	command.Flags().StringVar(&opts.start, "start", "", "the start of the window, as an RFC3339 timestamp, e.g. 2024-01-01T00:00:00Z")
+	command.Flags().StringVar(&opts.end, "end", "", "the end of the window, as an RFC3339 timestamp, e.g. 2024-01-31T23:59:59Z")
+	command.Flags().IntVar(&opts.parallelism, "parallelism", 1, "the number of workflows to run at once, or 0 to submit them all without waiting for them to finish")
+	command.Flags().StringVar(&opts.parameterName, "parameter-name", "scheduledTime", "the name of the parameter to pass the scheduled time, as an RFC3339 timestamp, in")
+	errors.CheckError(command.MarkFlagRequired("start"))
+	errors.CheckError(command.MarkFlagRequired("end"))
+	return command
+}
+
+// backfill submits a workflow for each of the times in order, waiting for them to finish if the parallelism is limited,
+// and returns whether they were all submitted and succeeded
+func backfill(ctx context.Context, serviceClient workflowpkg.WorkflowServiceClient, cronWf *wfv1.CronWorkflow, times []time.Time, opts backfillOpts) bool {
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		succeeded = true
+		// running limits the number of workflows running at once
+		running = make(chan struct{}, max(opts.parallelism, 1))
+	)
+	fail := func() {
+		mu.Lock()
+		defer mu.Unlock()
+		succeeded = false
+	}
+	for _, t := range times {
+		running <- struct{}{}
+		name, err := submitScheduled(ctx, serviceClient, cronWf, t, opts.parameterName)
+		if err != nil {
+			log.WithError(err).WithField("scheduledTime", t).Error("Failed to submit the workflow")
+			fail()
+			<-running
+			continue
+		}
+		if name == "" || opts.parallelism == 0 {
+			<-running
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-running }()
+			if !common.WaitWorkflow(ctx, serviceClient, cronWf.Namespace, name, false) {
+				fail()
+			}
+		}()
+	}
+	wg.Wait()
+	return succeeded
+}
+
+// submitScheduled submits the workflow the cron workflow controller would have created at the scheduled time, and
+// returns its name, or an empty name if it already exists
+func submitScheduled(ctx context.Context, serviceClient workflowpkg.WorkflowServiceClient, cronWf *wfv1.CronWorkflow, scheduledTime time.Time, parameterName string) (string, error) {
+	name := fmt.Sprintf("%s-%d", cronWf.Name, scheduledTime.Unix())
+	submitOpts := &wfv1.SubmitOpts{
+		Name:        name,
+		Annotations: wfcommon.AnnotationKeyCronWfScheduledTime + "=" + scheduledTime.Format(time.RFC3339),
+	}
+	if parameterName != "" {
+		submitOpts.Parameters = []string{parameterName + "=" + scheduledTime.Format(time.RFC3339)}
+	}
+	wf, err := serviceClient.SubmitWorkflow(ctx, &workflowpkg.WorkflowSubmitRequest{
+		Namespace:     cronWf.Namespace,
+		ResourceKind:  workflow.CronWorkflowKind,
+		ResourceName:  cronWf.Name,
+		SubmitOptions: submitOpts,
+	})
+	if status.Code(err) == codes.AlreadyExists {
+		fmt.Printf("Workflow '%s' scheduled at %s already exists\n", name, scheduledTime.Format(time.RFC3339))
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	fmt.Printf("Workflow '%s' scheduled at %s submitted\n", wf.Name, scheduledTime.Format(time.RFC3339))
+	return wf.Name, nil
+}
@@ -6,14 +6,15 @@ import (
 	"log"
 	"os"
 
-	"github.com/argoproj/pkg/errors"
 	"github.com/argoproj/pkg/json"
 	"github.com/spf13/cobra"
 
 	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/client"
+	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/common"
 	cronworkflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/cronworkflow"
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
-	"github.com/argoproj/argo-workflows/v3/workflow/common"
+	"github.com/argoproj/argo-workflows/v3/util/errors"
+	wfcommon "github.com/argoproj/argo-workflows/v3/workflow/common"
 	"github.com/argoproj/argo-workflows/v3/workflow/util"
 )
 
@@ -30,8 +31,9 @@ func NewCreateCommand() *cobra.Command {
 		parametersFile string
 	)
 	command := &cobra.Command{
-		Use:   "create FILE1 FILE2...",
-		Short: "create a cron workflow",
+		Use:               "create FILE1 FILE2...",
+		Short:             "create a cron workflow",
+		ValidArgsFunction: common.CompleteManifestFiles,
 		Run: func(cmd *cobra.Command, args []string) {
 			if len(args) == 0 {
 				cmd.HelpFunc()(cmd, args)
@@ -125,7 +127,7 @@ func unmarshalCronWorkflows(wfBytes []byte, strict bool) []wfv1.CronWorkflow {
 	if err == nil {
 		return []wfv1.CronWorkflow{cronWf}
 	}
-	yamlWfs, err := common.SplitCronWorkflowYAMLFile(wfBytes, strict)
+	yamlWfs, err := wfcommon.SplitCronWorkflowYAMLFile(wfBytes, strict)
 	if err == nil {
 		return yamlWfs
 	}
@@ -1,11 +1,12 @@
 package cron
 
 import (
-	"github.com/argoproj/pkg/errors"
 	"github.com/spf13/cobra"
 
 	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/client"
+	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/common"
 	cronworkflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/cronworkflow"
+	"github.com/argoproj/argo-workflows/v3/util/errors"
 )
 
 // NewDeleteCommand returns a new instance of an `argo delete` command
@@ -13,8 +14,9 @@ func NewDeleteCommand() *cobra.Command {
 	var all bool
 
 	command := &cobra.Command{
-		Use:   "delete [CRON_WORKFLOW... | --all]",
-		Short: "delete a cron workflow",
+		Use:               "delete [CRON_WORKFLOW... | --all]",
+		Short:             "delete a cron workflow",
+		ValidArgsFunction: common.CompleteCronWorkflows,
 		Run: func(cmd *cobra.Command, args []string) {
 			ctx, apiClient := client.NewAPIClient(cmd.Context())
 			serviceClient, err := apiClient.NewCronWorkflowServiceClient()
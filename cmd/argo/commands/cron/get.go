@@ -7,22 +7,24 @@ import (
 	"os"
 	"strings"
 
-	"github.com/argoproj/pkg/errors"
 	"github.com/argoproj/pkg/humanize"
 	"github.com/spf13/cobra"
 	"sigs.k8s.io/yaml"
 
 	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/client"
+	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/common"
 	"github.com/argoproj/argo-workflows/v3/pkg/apiclient/cronworkflow"
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/util/errors"
 )
 
 func NewGetCommand() *cobra.Command {
 	var output string
 
 	command := &cobra.Command{
-		Use:   "get CRON_WORKFLOW...",
-		Short: "display details about a cron workflow",
+		Use:               "get CRON_WORKFLOW...",
+		Short:             "display details about a cron workflow",
+		ValidArgsFunction: common.CompleteCronWorkflows,
 		Run: func(cmd *cobra.Command, args []string) {
 			if len(args) == 0 {
 				cmd.HelpFunc()(cmd, args)
@@ -73,7 +75,20 @@ func getCronWorkflowGet(cwf *wfv1.CronWorkflow) string {
 	out += fmt.Sprintf(fmtStr, "Name:", cwf.ObjectMeta.Name)
 	out += fmt.Sprintf(fmtStr, "Namespace:", cwf.ObjectMeta.Namespace)
 	out += fmt.Sprintf(fmtStr, "Created:", humanize.Timestamp(cwf.ObjectMeta.CreationTimestamp.Time))
-	out += fmt.Sprintf(fmtStr, "Schedule:", cwf.Spec.Schedule)
+	if len(cwf.Spec.Schedules) > 0 {
+		out += fmt.Sprintf(fmtStr, "Schedules:", "")
+		for _, schedule := range cwf.Spec.Schedules {
+			value := schedule.Schedule
+			for _, status := range cwf.Status.Schedules {
+				if status.Name == schedule.Name && status.LastScheduledTime != nil {
+					value += " (last scheduled " + humanize.Timestamp(status.LastScheduledTime.Time) + ")"
+				}
+			}
+			out += fmt.Sprintf(fmtStr, "  "+schedule.Name+":", value)
+		}
+	} else {
+		out += fmt.Sprintf(fmtStr, "Schedule:", cwf.Spec.Schedule)
+	}
 	out += fmt.Sprintf(fmtStr, "Suspended:", cwf.Spec.Suspend)
 	if cwf.Spec.Timezone != "" {
 		out += fmt.Sprintf(fmtStr, "Timezone:", cwf.Spec.Timezone)
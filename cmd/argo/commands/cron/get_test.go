@@ -67,3 +67,16 @@ func TestNextRuntime(t *testing.T) {
 		assert.Greater(t, next.Unix(), time.Now().Unix())
 	}
 }
+
+func TestNextRuntimeSchedules(t *testing.T) {
+	cronWf := &v1alpha1.CronWorkflow{Spec: v1alpha1.CronWorkflowSpec{Schedules: []v1alpha1.CronSchedule{
+		{Name: "yearly", Schedule: "0 0 1 1 *"},
+		{Name: "every-minute", Schedule: "* * * * *"},
+	}}}
+	next, err := GetNextRuntime(cronWf)
+	if assert.NoError(t, err) {
+		assert.LessOrEqual(t, next.Unix(), time.Now().Add(1*time.Minute).Unix())
+		assert.Greater(t, next.Unix(), time.Now().Unix())
+	}
+	assert.Contains(t, getCronWorkflowGet(cronWf), "  every-minute:                * * * * *")
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/client"
+	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/common"
 	"github.com/argoproj/argo-workflows/v3/cmd/argo/lint"
 	wf "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow"
 )
@@ -17,8 +18,9 @@ func NewLintCommand() *cobra.Command {
 	)
 
 	command := &cobra.Command{
-		Use:   "lint FILE...",
-		Short: "validate files or directories of cron workflow manifests",
+		Use:               "lint FILE...",
+		Short:             "validate files or directories of cron workflow manifests",
+		ValidArgsFunction: common.CompleteManifestFiles,
 		Run: func(cmd *cobra.Command, args []string) {
 			if len(args) == 0 {
 				cmd.HelpFunc()(cmd, args)
@@ -3,18 +3,20 @@ package cron
 import (
 	"fmt"
 
-	"github.com/argoproj/pkg/errors"
 	"github.com/spf13/cobra"
 
 	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/client"
+	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/common"
 	cronworkflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/cronworkflow"
+	"github.com/argoproj/argo-workflows/v3/util/errors"
 )
 
 // NewSuspendCommand returns a new instance of an `argo suspend` command
 func NewSuspendCommand() *cobra.Command {
 	command := &cobra.Command{
-		Use:   "suspend CRON_WORKFLOW...",
-		Short: "suspend zero or more cron workflows",
+		Use:               "suspend CRON_WORKFLOW...",
+		Short:             "suspend zero or more cron workflows",
+		ValidArgsFunction: common.CompleteCronWorkflows,
 		Run: func(cmd *cobra.Command, args []string) {
 			ctx, apiClient := client.NewAPIClient(cmd.Context())
 			serviceClient, err := apiClient.NewCronWorkflowServiceClient()
@@ -1,6 +1,7 @@
 package cron
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/robfig/cron/v3"
@@ -11,9 +12,34 @@ import (
 // GetNextRuntime returns the next time the workflow should run in local time. It assumes the workflow-controller is in
 // UTC, but nevertheless returns the time in the local timezone.
 func GetNextRuntime(cwf *v1alpha1.CronWorkflow) (time.Time, error) {
+	var next time.Time
+	now := time.Now().UTC()
+	// the earliest of the next times of each of its named schedules
+	for _, schedule := range cwf.Spec.GetScheduleStrings() {
+		cronSchedule, err := cron.ParseStandard(schedule)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if t := cronSchedule.Next(now); next.IsZero() || t.Before(next) {
+			next = t
+		}
+	}
+	return next.Local(), nil
+}
+
+// GetScheduledTimes returns the times the cron workflow was scheduled to run between start and end, inclusive, in the
+// timezone of its schedule
+func GetScheduledTimes(cwf *v1alpha1.CronWorkflow, start, end time.Time) ([]time.Time, error) {
+	if len(cwf.Spec.Schedules) > 0 {
+		return nil, fmt.Errorf("cron workflow %q has named schedules, which cannot be backfilled", cwf.Name)
+	}
 	cronSchedule, err := cron.ParseStandard(cwf.Spec.GetScheduleString())
 	if err != nil {
-		return time.Time{}, err
+		return nil, err
+	}
+	var times []time.Time
+	for t := cronSchedule.Next(start.Add(-time.Nanosecond)); !t.IsZero() && !t.After(end); t = cronSchedule.Next(t) {
+		times = append(times, t)
 	}
-	return cronSchedule.Next(time.Now().UTC()).Local(), nil
+	return times, nil
 }
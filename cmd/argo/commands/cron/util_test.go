@@ -0,0 +1,49 @@
+package cron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+func TestGetScheduledTimes(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	t.Run("Inclusive", func(t *testing.T) {
+		cwf := &v1alpha1.CronWorkflow{Spec: v1alpha1.CronWorkflowSpec{Schedule: "0 */6 * * *", Timezone: "UTC"}}
+		times, err := GetScheduledTimes(cwf, start, start.Add(12*time.Hour))
+		if assert.NoError(t, err) {
+			assert.Equal(t, []time.Time{start, start.Add(6 * time.Hour), start.Add(12 * time.Hour)}, times)
+		}
+	})
+	t.Run("Timezone", func(t *testing.T) {
+		cwf := &v1alpha1.CronWorkflow{Spec: v1alpha1.CronWorkflowSpec{Schedule: "0 9 * * *", Timezone: "Asia/Tokyo"}}
+		times, err := GetScheduledTimes(cwf, start, start.Add(47*time.Hour))
+		if assert.NoError(t, err) {
+			if assert.Len(t, times, 2) {
+				assert.True(t, start.Equal(times[0]))
+				assert.True(t, start.Add(24*time.Hour).Equal(times[1]))
+			}
+		}
+	})
+	t.Run("None", func(t *testing.T) {
+		cwf := &v1alpha1.CronWorkflow{Spec: v1alpha1.CronWorkflowSpec{Schedule: "0 0 1 * *", Timezone: "UTC"}}
+		times, err := GetScheduledTimes(cwf, start.Add(time.Second), start.Add(24*time.Hour))
+		if assert.NoError(t, err) {
+			assert.Empty(t, times)
+		}
+	})
+	t.Run("InvalidSchedule", func(t *testing.T) {
+		cwf := &v1alpha1.CronWorkflow{Spec: v1alpha1.CronWorkflowSpec{Schedule: "invalid"}}
+		_, err := GetScheduledTimes(cwf, start, start)
+		assert.Error(t, err)
+	})
+	t.Run("Schedules", func(t *testing.T) {
+		cwf := &v1alpha1.CronWorkflow{ObjectMeta: metav1.ObjectMeta{Name: "my-cwf"}, Spec: v1alpha1.CronWorkflowSpec{Schedules: []v1alpha1.CronSchedule{{Name: "hourly", Schedule: "0 * * * *"}}}}
+		_, err := GetScheduledTimes(cwf, start, start)
+		assert.EqualError(t, err, `cron workflow "my-cwf" has named schedules, which cannot be backfilled`)
+	})
+}
@@ -2,17 +2,22 @@ package commands
 
 import (
 	"fmt"
+	"io"
 	"os"
+	"sort"
 
-	"github.com/argoproj/pkg/errors"
 	"github.com/spf13/cobra"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/client"
+	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/common"
 	workflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/util/errors"
+	wfcommon "github.com/argoproj/argo-workflows/v3/workflow/common"
+	"github.com/argoproj/argo-workflows/v3/workflow/util"
 )
 
 // NewDeleteCommand returns a new instance of an `argo delete` command
@@ -34,7 +39,12 @@ func NewDeleteCommand() *cobra.Command {
 # Delete the latest workflow:
 
   argo delete @latest
+
+# Print the workflows completed over a week ago, and their pods, that would be deleted, without deleting them:
+
+  argo delete --completed --older 7d --dry-run
 `,
+		ValidArgsFunction: common.CompleteWorkflows(),
 		Run: func(cmd *cobra.Command, args []string) {
 			hasFilterFlag := all || allNamespaces || flags.completed || flags.resubmitted || flags.prefix != "" ||
 				flags.labels != "" || flags.fields != "" || flags.finishedBefore != "" || len(flags.status) > 0
@@ -51,11 +61,26 @@ func NewDeleteCommand() *cobra.Command {
 				flags.namespace = client.Namespace()
 			}
 			for _, name := range args {
+				if dryRun {
+					// the dry run needs the whole workflow to find what deleting it would remove
+					wf, err := serviceClient.GetWorkflow(ctx, &workflowpkg.WorkflowGetRequest{Name: name, Namespace: flags.namespace})
+					if err != nil && status.Code(err) == codes.NotFound {
+						fmt.Printf("Workflow '%s' not found\n", name)
+						continue
+					}
+					errors.CheckError(err)
+					workflows = append(workflows, *wf)
+					continue
+				}
 				workflows = append(workflows, wfv1.Workflow{
 					ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: flags.namespace},
 				})
 			}
 			if hasFilterFlag {
+				if dryRun {
+					// list whole workflows, rather than the fields displayed by `argo list`
+					flags.output = "json"
+				}
 				listed, err := listWorkflows(ctx, serviceClient, flags)
 				errors.CheckError(err)
 				workflows = append(workflows, listed...)
@@ -66,12 +91,12 @@ func NewDeleteCommand() *cobra.Command {
 				return
 			}
 
-			for _, wf := range workflows {
-				if dryRun {
-					fmt.Printf("Workflow '%s' deleted (dry-run)\n", wf.Name)
-					continue
-				}
+			if dryRun {
+				printDeleteDryRun(workflows, os.Stdout)
+				return
+			}
 
+			for _, wf := range workflows {
 				_, err := serviceClient.DeleteWorkflow(ctx, &workflowpkg.WorkflowDeleteRequest{Name: wf.Name, Namespace: wf.Namespace, Force: force})
 				if err != nil && status.Code(err) == codes.NotFound {
 					fmt.Printf("Workflow '%s' not found\n", wf.Name)
@@ -93,7 +118,63 @@ func NewDeleteCommand() *cobra.Command {
 	command.Flags().StringVar(&flags.finishedBefore, "older", "", "Delete completed workflows finished before the specified duration (e.g. 10m, 3h, 1d)")
 	command.Flags().StringSliceVar(&flags.status, "status", []string{}, "Delete by status (comma separated)")
 	command.Flags().Int64VarP(&flags.chunkSize, "query-chunk-size", "", 0, "Run the list query in chunks (deletes will still be executed individually)")
-	command.Flags().BoolVar(&dryRun, "dry-run", false, "Do not delete the workflow, only print what would happen")
+	command.Flags().BoolVar(&dryRun, "dry-run", false, "Do not delete the workflows, only print the workflows and pods that would be deleted, the archived workflows that would be kept, and their counts")
 	command.Flags().BoolVar(&force, "force", false, "Force delete workflows by removing finalizers")
 	return command
 }
+
+// printDeleteDryRun prints what deleting the workflows would do, and a summary of it. Deleting a workflow deletes its
+// pods, but not its archived record: workflows that are only in the archive are not deleted.
+func printDeleteDryRun(workflows wfv1.Workflows, out io.Writer) {
+	deleted, pods, archived := 0, 0, 0
+	for _, wf := range workflows {
+		switch wf.Labels[wfcommon.LabelKeyWorkflowArchivingStatus] {
+		case "Persisted":
+			_, _ = fmt.Fprintf(out, "Workflow '%s' is only archived, not deleted (dry-run)\n", wf.Name)
+			archived++
+			continue
+		case "Archived":
+			archived++
+		}
+		_, _ = fmt.Fprintf(out, "Workflow '%s' deleted (dry-run)\n", wf.Name)
+		deleted++
+		for _, pod := range getWorkflowPods(&wf) {
+			_, _ = fmt.Fprintf(out, "  Pod '%s' deleted (dry-run)\n", pod)
+			pods++
+		}
+		if wf.Labels[wfcommon.LabelKeyWorkflowArchivingStatus] == "Archived" {
+			_, _ = fmt.Fprintf(out, "  Archived workflow kept (dry-run)\n")
+		}
+	}
+	_, _ = fmt.Fprintf(out, "%d workflows and %d pods deleted, %d archived workflows kept (dry-run)\n", deleted, pods, archived)
+}
+
+// getWorkflowPods returns the names of the pods of the workflow's pod nodes, less those its pod GC strategy has
+// already deleted. The pods a label selector or delay of the strategy keeps are not known, so they are all returned.
+func getWorkflowPods(wf *wfv1.Workflow) []string {
+	strategy := wf.Spec.PodGC.GetStrategy()
+	if gc := wf.Spec.PodGC; gc != nil && (gc.LabelSelector != nil || gc.DeleteDelayDuration != nil) {
+		strategy = wfv1.PodGCOnPodNone
+	}
+	var nodes []wfv1.NodeStatus
+	for _, node := range wf.Status.Nodes {
+		if node.Type != wfv1.NodeTypePod {
+			continue
+		}
+		switch {
+		case strategy == wfv1.PodGCOnPodCompletion && node.Fulfilled(),
+			strategy == wfv1.PodGCOnPodSuccess && node.Succeeded(),
+			strategy == wfv1.PodGCOnWorkflowCompletion && wf.Status.Fulfilled(),
+			strategy == wfv1.PodGCOnWorkflowSuccess && wf.Status.Successful():
+			continue
+		}
+		nodes = append(nodes, node)
+	}
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].StartedAt.Before(&nodes[j].StartedAt) })
+	podNameVersion := util.GetWorkflowPodNameVersion(wf)
+	var pods []string
+	for _, node := range nodes {
+		pods = append(pods, util.GeneratePodName(wf.Name, node.Name, util.GetTemplateFromNode(node), node.ID, podNameVersion))
+	}
+	return pods
+}
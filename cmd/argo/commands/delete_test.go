@@ -0,0 +1,63 @@
+package commands
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+func newDeleteTestWorkflow(name string, labels map[string]string, podGC *wfv1.PodGC) wfv1.Workflow {
+	// the pods are named after the nodes' IDs
+	annotations := map[string]string{common.AnnotationKeyPodNameVersion: "v1"}
+	return wfv1.Workflow{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels, Annotations: annotations},
+		Spec:       wfv1.WorkflowSpec{PodGC: podGC},
+		Status: wfv1.WorkflowStatus{Phase: wfv1.WorkflowFailed, Nodes: wfv1.Nodes{
+			name:        {ID: name, Name: name, Type: wfv1.NodeTypeSteps, Phase: wfv1.NodeFailed},
+			name + "-1": {ID: name + "-1", Name: name + "[0].a", Type: wfv1.NodeTypePod, Phase: wfv1.NodeSucceeded, StartedAt: metav1.Unix(1, 0)},
+			name + "-2": {ID: name + "-2", Name: name + "[1].b", Type: wfv1.NodeTypePod, Phase: wfv1.NodeFailed, StartedAt: metav1.Unix(2, 0)},
+		}},
+	}
+}
+
+func Test_getWorkflowPods(t *testing.T) {
+	for strategy, pods := range map[wfv1.PodGCStrategy][]string{
+		wfv1.PodGCOnPodNone:            {"my-wf-1", "my-wf-2"},
+		wfv1.PodGCOnPodSuccess:         {"my-wf-2"},
+		wfv1.PodGCOnPodCompletion:      nil,
+		wfv1.PodGCOnWorkflowSuccess:    {"my-wf-1", "my-wf-2"},
+		wfv1.PodGCOnWorkflowCompletion: nil,
+	} {
+		t.Run(string(strategy), func(t *testing.T) {
+			wf := newDeleteTestWorkflow("my-wf", nil, &wfv1.PodGC{Strategy: strategy})
+			assert.Equal(t, pods, getWorkflowPods(&wf))
+		})
+	}
+	t.Run("LabelSelector", func(t *testing.T) {
+		wf := newDeleteTestWorkflow("my-wf", nil, &wfv1.PodGC{Strategy: wfv1.PodGCOnPodCompletion, LabelSelector: &metav1.LabelSelector{}})
+		assert.Equal(t, []string{"my-wf-1", "my-wf-2"}, getWorkflowPods(&wf))
+	})
+}
+
+func Test_printDeleteDryRun(t *testing.T) {
+	out := &bytes.Buffer{}
+	printDeleteDryRun(wfv1.Workflows{
+		newDeleteTestWorkflow("live", nil, nil),
+		newDeleteTestWorkflow("both", map[string]string{common.LabelKeyWorkflowArchivingStatus: "Archived"}, &wfv1.PodGC{Strategy: wfv1.PodGCOnPodSuccess}),
+		newDeleteTestWorkflow("archived", map[string]string{common.LabelKeyWorkflowArchivingStatus: "Persisted"}, nil),
+	}, out)
+	assert.Equal(t, `Workflow 'live' deleted (dry-run)
+  Pod 'live-1' deleted (dry-run)
+  Pod 'live-2' deleted (dry-run)
+Workflow 'both' deleted (dry-run)
+  Pod 'both-2' deleted (dry-run)
+  Archived workflow kept (dry-run)
+Workflow 'archived' is only archived, not deleted (dry-run)
+2 workflows and 3 pods deleted, 2 archived workflows kept (dry-run)
+`, out.String())
+}
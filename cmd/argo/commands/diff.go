@@ -0,0 +1,52 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/client"
+	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/common"
+	workflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/util/errors"
+)
+
+func NewDiffCommand() *cobra.Command {
+	command := &cobra.Command{
+		Use:   "diff WORKFLOW1 WORKFLOW2",
+		Short: "compare two workflows",
+		Long: `Compare the spec two workflows ran, including the workflow templates they reference, their parameters and the results of their nodes.
+
+Workflows that are no longer in the cluster are read from the archive. Nodes are compared by their name without the workflow's name, and items of lists such as templates and parameters by their name.`,
+		Example: `# Compare a resubmitted workflow with the original:
+
+  argo diff my-wf my-wf-resubmitted
+
+# Compare a workflow with the latest workflow:
+
+  argo diff my-wf @latest
+`,
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: common.CompleteWorkflows(),
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx, apiClient := client.NewAPIClient(cmd.Context())
+			serviceClient := apiClient.NewWorkflowServiceClient()
+			namespace := client.Namespace()
+			var workflows []*wfv1.Workflow
+			for _, name := range args {
+				wf, err := serviceClient.GetWorkflow(ctx, &workflowpkg.WorkflowGetRequest{Name: name, Namespace: namespace})
+				errors.CheckError(err)
+				workflows = append(workflows, wf)
+			}
+			differ, err := common.PrintWorkflowDiff(os.Stdout, workflows[0], workflows[1])
+			errors.CheckError(err)
+			if !differ {
+				fmt.Println("Workflows do not differ")
+			}
+		},
+	}
+	command.Flags().BoolVar(&common.NoColor, "no-color", false, "Disable colorized output")
+	return command
+}
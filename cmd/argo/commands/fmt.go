@@ -0,0 +1,220 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+
+	jsonpkg "github.com/argoproj/pkg/json"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+
+	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/common"
+	wf "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/util/errors"
+	fileutil "github.com/argoproj/argo-workflows/v3/util/file"
+)
+
+var yamlSeparator = regexp.MustCompile(`\n---`)
+
+// formattedKinds are the kinds of the manifests that are formatted, manifests of other kinds are left as they are
+var formattedKinds = map[string]func() interface{}{
+	wf.WorkflowKind:                func() interface{} { return &wfv1.Workflow{} },
+	wf.WorkflowTemplateKind:        func() interface{} { return &wfv1.WorkflowTemplate{} },
+	wf.ClusterWorkflowTemplateKind: func() interface{} { return &wfv1.ClusterWorkflowTemplate{} },
+	wf.CronWorkflowKind:            func() interface{} { return &wfv1.CronWorkflow{} },
+}
+
+func NewFmtCommand() *cobra.Command {
+	var (
+		write bool
+		check bool
+	)
+
+	command := &cobra.Command{
+		Use:   "fmt FILE...",
+		Short: "format files or directories of manifests",
+		Long: `Format files or directories of manifests of workflows, workflow templates, cluster workflow templates and cron workflows, so that the same manifest is always written the same way.
+
+Fields are written in alphabetical order, and fields that are empty are removed, unless they are given in the manifest. Manifests of other kinds are left as they are. Comments are not kept.
+
+By default, the formatted manifests are printed. With --write, the files are formatted in place. With --check, the files that are not formatted are printed, and the command fails if there are any, e.g. in CI.`,
+		Example: `
+# Print the formatted manifests of a directory:
+
+  argo fmt ./manifests
+
+# Format the manifests of a directory in place:
+
+  argo fmt --write ./manifests
+
+# Fail if any manifests of a directory are not formatted, e.g. in CI:
+
+  argo fmt --check ./manifests`,
+		ValidArgsFunction: common.CompleteManifestFiles,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) == 0 {
+				cmd.HelpFunc()(cmd, args)
+				os.Exit(1)
+			}
+
+			formatted, err := formatManifests(args, write, check, os.Stdout)
+			errors.CheckError(err)
+			if !formatted {
+				os.Exit(1)
+			}
+		},
+	}
+
+	command.Flags().BoolVarP(&write, "write", "w", false, "Write the formatted manifests to their files, rather than print them")
+	command.Flags().BoolVar(&check, "check", false, "Print the files that are not formatted, rather than the formatted manifests, and fail if there are any")
+
+	return command
+}
+
+// formatManifests formats the manifests of the files, and returns false if any were not formatted and check is true
+func formatManifests(files []string, write, check bool, out io.Writer) (bool, error) {
+	formatted := true
+	for _, file := range files {
+		err := fileutil.WalkManifests(file, func(path string, data []byte) error {
+			result, err := formatManifest(data)
+			if err != nil {
+				return fmt.Errorf("failed to format %s: %w", path, err)
+			}
+			changed := !bytes.Equal(data, result)
+			if check {
+				if changed {
+					formatted = false
+					_, err = fmt.Fprintln(out, path)
+				}
+				if !write {
+					return err
+				}
+			}
+			if write && path != "stdin" {
+				if !changed {
+					return nil
+				}
+				info, err := os.Stat(path)
+				if err != nil {
+					return err
+				}
+				return os.WriteFile(path, result, info.Mode())
+			}
+			_, err = out.Write(result)
+			return err
+		})
+		if err != nil {
+			return false, err
+		}
+	}
+	return formatted, nil
+}
+
+// formatManifest formats the YAML documents, or the JSON document, of a file
+func formatManifest(data []byte) ([]byte, error) {
+	if jsonpkg.IsJSON(data) {
+		v, err := formatDocument(data)
+		if err != nil || v == nil {
+			return data, err
+		}
+		result, err := json.MarshalIndent(v, "", "  ")
+		return append(result, '\n'), err
+	}
+	var docs []string
+	for _, text := range yamlSeparator.Split(string(data), -1) {
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+		v, err := formatDocument([]byte(text))
+		if err != nil {
+			return nil, err
+		}
+		if v == nil {
+			docs = append(docs, strings.Trim(text, "\n")+"\n")
+			continue
+		}
+		result, err := yaml.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, string(result))
+	}
+	return []byte(strings.Join(docs, "---\n")), nil
+}
+
+// formatDocument returns the formatted fields of the manifest, or nil if it is not of a kind that is formatted
+func formatDocument(data []byte) (interface{}, error) {
+	var original map[string]interface{}
+	if err := yaml.Unmarshal(data, &original); err != nil {
+		return nil, err
+	}
+	kind, _ := original["kind"].(string)
+	newObject, ok := formattedKinds[kind]
+	if !ok {
+		return nil, nil
+	}
+	// strict, so that fields that are not known are an error, rather than removed
+	obj := newObject()
+	if err := yaml.UnmarshalStrict(data, obj); err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+	return prune(v, original), nil
+}
+
+// prune removes the fields that are null, and the fields that are empty and not in the original manifest, i.e. the
+// defaults that marshalling the manifest adds, but not empty fields that are given, e.g. "emptyDir: {}"
+func prune(v, original interface{}) interface{} {
+	switch x := v.(type) {
+	case map[string]interface{}:
+		o, _ := original.(map[string]interface{})
+		for k, value := range x {
+			ov, given := o[k]
+			value = prune(value, ov)
+			if value == nil || (!given && isEmpty(value)) {
+				delete(x, k)
+			} else {
+				x[k] = value
+			}
+		}
+	case []interface{}:
+		o, _ := original.([]interface{})
+		for i, value := range x {
+			var ov interface{}
+			if i < len(o) {
+				ov = o[i]
+			}
+			x[i] = prune(value, ov)
+		}
+	}
+	return v
+}
+
+func isEmpty(v interface{}) bool {
+	switch x := v.(type) {
+	case map[string]interface{}:
+		return len(x) == 0
+	case []interface{}:
+		return len(x) == 0
+	case string:
+		return x == ""
+	case bool:
+		return !x
+	case float64:
+		return x == 0
+	}
+	return false
+}
@@ -0,0 +1,131 @@
+package commands
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const unformattedWorkflow = `apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: hello-
+spec:
+  entrypoint: main
+  volumes:
+    - name: workdir
+      emptyDir: {}
+  templates:
+    - name: main
+      inputs:
+        parameters:
+          - name: message
+            value: ""
+      container:
+        image: argoproj/argosay:v2
+        args: [echo, "{{inputs.parameters.message}}"]
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: my-cm
+`
+
+const formattedWorkflow = `apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: hello-
+spec:
+  entrypoint: main
+  templates:
+  - container:
+      args:
+      - echo
+      - '{{inputs.parameters.message}}'
+      image: argoproj/argosay:v2
+    inputs:
+      parameters:
+      - name: message
+        value: ""
+    name: main
+  volumes:
+  - emptyDir: {}
+    name: workdir
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: my-cm
+`
+
+func Test_formatManifest(t *testing.T) {
+	t.Run("YAML", func(t *testing.T) {
+		result, err := formatManifest([]byte(unformattedWorkflow))
+		require.NoError(t, err)
+		assert.Equal(t, formattedWorkflow, string(result))
+	})
+	t.Run("Formatted", func(t *testing.T) {
+		result, err := formatManifest([]byte(formattedWorkflow))
+		require.NoError(t, err)
+		assert.Equal(t, formattedWorkflow, string(result))
+	})
+	t.Run("JSON", func(t *testing.T) {
+		result, err := formatManifest([]byte(`{"kind": "WorkflowTemplate", "apiVersion": "argoproj.io/v1alpha1", "metadata": {"name": "my-wftmpl"}, "spec": {"entrypoint": "main"}}`))
+		require.NoError(t, err)
+		assert.Equal(t, `{
+  "apiVersion": "argoproj.io/v1alpha1",
+  "kind": "WorkflowTemplate",
+  "metadata": {
+    "name": "my-wftmpl"
+  },
+  "spec": {
+    "entrypoint": "main"
+  }
+}
+`, string(result))
+	})
+	t.Run("UnknownField", func(t *testing.T) {
+		_, err := formatManifest([]byte("apiVersion: argoproj.io/v1alpha1\nkind: Workflow\nspec:\n  entrypont: main\n"))
+		assert.ErrorContains(t, err, "entrypont")
+	})
+}
+
+func Test_formatManifests(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "wf.yaml")
+	require.NoError(t, os.WriteFile(file, []byte(unformattedWorkflow), 0o600))
+
+	t.Run("Print", func(t *testing.T) {
+		out := &bytes.Buffer{}
+		formatted, err := formatManifests([]string{dir}, false, false, out)
+		require.NoError(t, err)
+		assert.True(t, formatted)
+		assert.Equal(t, formattedWorkflow, out.String())
+	})
+	t.Run("Check", func(t *testing.T) {
+		out := &bytes.Buffer{}
+		formatted, err := formatManifests([]string{dir}, false, true, out)
+		require.NoError(t, err)
+		assert.False(t, formatted)
+		assert.Equal(t, file+"\n", out.String())
+	})
+	t.Run("Write", func(t *testing.T) {
+		out := &bytes.Buffer{}
+		formatted, err := formatManifests([]string{dir}, true, false, out)
+		require.NoError(t, err)
+		assert.True(t, formatted)
+		assert.Empty(t, out.String())
+		data, err := os.ReadFile(file)
+		require.NoError(t, err)
+		assert.Equal(t, formattedWorkflow, string(data))
+
+		formatted, err = formatManifests([]string{dir}, false, true, out)
+		require.NoError(t, err)
+		assert.True(t, formatted)
+		assert.Empty(t, out.String())
+	})
+}
@@ -1,23 +1,32 @@
 package commands
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
 	"os"
 
-	"github.com/argoproj/pkg/errors"
 	"github.com/spf13/cobra"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
 	"sigs.k8s.io/yaml"
 
 	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/client"
 	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/common"
 	workflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/util/errors"
+	wfcommon "github.com/argoproj/argo-workflows/v3/workflow/common"
 )
 
 func NewGetCommand() *cobra.Command {
-	var getArgs common.GetFlags
+	var (
+		getArgs     common.GetFlags
+		follow      bool
+		allContexts bool
+	)
 
 	command := &cobra.Command{
 		Use:   "get WORKFLOW...",
@@ -28,15 +37,58 @@ func NewGetCommand() *cobra.Command {
 
 # Get the latest workflow:
   argo get @latest
+
+# Render a workflow's graph as an image with Graphviz:
+  argo get my-wf -o dot | dot -Tpng > my-wf.png
+
+# Keep the details of a workflow updated in place until it completes:
+  argo get my-wf --follow -o wide
+
+# Get a workflow from whichever kubeconfig context, or context of ~/.config/argo/contexts.yaml, it is in:
+  argo get my-wf --all-contexts
 `,
+		ValidArgsFunction: common.CompleteWorkflows(),
 		Run: func(cmd *cobra.Command, args []string) {
 			if len(args) == 0 {
 				cmd.HelpFunc()(cmd, args)
 				os.Exit(1)
 			}
+			if follow && (len(args) != 1 || (getArgs.Output != "" && getArgs.Output != "short" && getArgs.Output != "wide")) {
+				log.Fatal("--follow requires one workflow, and the short or wide output")
+			}
+			if follow && allContexts {
+				log.Fatal("--follow cannot be used with --all-contexts")
+			}
+			if allContexts {
+				contexts, err := client.Contexts()
+				errors.CheckError(err)
+				for _, name := range args {
+					wfs := getContextWorkflows(cmd.Context(), contexts, name)
+					if len(wfs) == 0 {
+						log.Fatalf("Workflow %s not found in any context", name)
+					}
+					for _, wf := range wfs {
+						printWorkflow(wf, getArgs)
+					}
+				}
+				return
+			}
 			ctx, apiClient := client.NewAPIClient(cmd.Context())
 			serviceClient := apiClient.NewWorkflowServiceClient()
 			namespace := client.Namespace()
+			if follow {
+				wf, err := serviceClient.GetWorkflow(ctx, &workflowpkg.WorkflowGetRequest{
+					Name:      args[0],
+					Namespace: namespace,
+				})
+				errors.CheckError(err)
+				if !wf.Status.FinishedAt.IsZero() {
+					printWorkflow(wf, getArgs)
+					return
+				}
+				common.WatchWorkflow(ctx, serviceClient, namespace, wf.Name, getArgs)
+				return
+			}
 			for _, name := range args {
 				wf, err := serviceClient.GetWorkflow(ctx, &workflowpkg.WorkflowGetRequest{
 					Name:      name,
@@ -48,14 +100,47 @@ func NewGetCommand() *cobra.Command {
 		},
 	}
 
-	command.Flags().StringVarP(&getArgs.Output, "output", "o", "", "Output format. One of: json|yaml|short|wide")
+	command.Flags().StringVarP(&getArgs.Output, "output", "o", "", "Output format. One of: json|yaml|short|wide|mermaid|dot")
 	command.Flags().BoolVar(&common.NoColor, "no-color", false, "Disable colorized output")
 	command.Flags().BoolVar(&common.NoUtf8, "no-utf8", false, "Use plain 7-bits ascii characters")
 	command.Flags().StringVar(&getArgs.Status, "status", "", "Filter by status (Pending, Running, Succeeded, Skipped, Failed, Error)")
 	command.Flags().StringVar(&getArgs.NodeFieldSelectorString, "node-field-selector", "", "selector of node to display, eg: --node-field-selector phase=abc")
+	command.Flags().BoolVarP(&follow, "follow", "f", false, "Keep the details of the workflow updated in place until it completes")
+	command.Flags().BoolVar(&allContexts, "all-contexts", false, "Get the workflow from each context of ~/.config/argo/contexts.yaml, or each kubeconfig context if there is no such file, that it is found in")
 	return command
 }
 
+// getContextWorkflows gets the workflow of the name from each context it is found in, annotated with the name of the
+// context. A context that cannot be reached is warned about and skipped.
+func getContextWorkflows(ctx context.Context, contexts []client.Context, name string) []*wfv1.Workflow {
+	var workflows []*wfv1.Workflow
+	for _, c := range contexts {
+		wf, err := func() (*wfv1.Workflow, error) {
+			ctx, apiClient, namespace, err := c.NewAPIClient(ctx)
+			if err != nil {
+				return nil, err
+			}
+			return apiClient.NewWorkflowServiceClient().GetWorkflow(ctx, &workflowpkg.WorkflowGetRequest{
+				Name:      name,
+				Namespace: namespace,
+			})
+		}()
+		if status.Code(err) == codes.NotFound || apierr.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			log.Printf("Failed to get workflow %s from context %s: %v", name, c.Name, err)
+			continue
+		}
+		if wf.Annotations == nil {
+			wf.Annotations = map[string]string{}
+		}
+		wf.Annotations[wfcommon.AnnotationKeyContext] = c.Name
+		workflows = append(workflows, wf)
+	}
+	return workflows
+}
+
 func printWorkflow(wf *wfv1.Workflow, getArgs common.GetFlags) {
 	switch getArgs.Output {
 	case "name":
@@ -66,6 +151,10 @@ func printWorkflow(wf *wfv1.Workflow, getArgs common.GetFlags) {
 	case "yaml":
 		outBytes, _ := yaml.Marshal(wf)
 		fmt.Print(string(outBytes))
+	case "mermaid":
+		fmt.Print(common.PrintWorkflowMermaid(wf))
+	case "dot":
+		fmt.Print(common.PrintWorkflowDot(wf))
 	case "short", "wide", "":
 		fmt.Print(common.PrintWorkflowHelper(wf, getArgs))
 	default:
@@ -9,6 +9,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/client"
+	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/common"
 	"github.com/argoproj/argo-workflows/v3/cmd/argo/lint"
 	wf "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow"
 )
@@ -26,6 +27,9 @@ func NewLintCommand() *cobra.Command {
 	command := &cobra.Command{
 		Use:   "lint FILE...",
 		Short: "validate files or directories of manifests",
+		Long: `Validate files or directories of manifests with the Argo Server, or the cluster if no Argo Server is configured.
+
+With --offline, the manifests are validated without connecting to a cluster or server, so that CI pipelines do not need credentials. Templates referenced by workflowTemplateRef and templateRef are resolved from the WorkflowTemplates and ClusterWorkflowTemplates in the given files only. With --strict, which is the default, the manifests are also validated against the JSON schema of the workflow kinds that is built into the CLI.`,
 		Example: `
 # Lint all manifests in a specified directory:
 
@@ -33,7 +37,12 @@ func NewLintCommand() *cobra.Command {
 
 # Lint only manifests of Workflows and CronWorkflows from stdin:
 
-  cat manifests.yaml | argo lint --kinds=workflows,cronworkflows -`,
+  cat manifests.yaml | argo lint --kinds=workflows,cronworkflows -
+
+# Lint manifests and the templates they reference without a cluster, e.g. in CI:
+
+  argo lint --offline ./workflows ./workflow-templates`,
+		ValidArgsFunction: common.CompleteManifestFiles,
 		Run: func(cmd *cobra.Command, args []string) {
 			if len(args) == 0 {
 				cmd.HelpFunc()(cmd, args)
@@ -46,7 +55,7 @@ func NewLintCommand() *cobra.Command {
 
 	command.Flags().StringSliceVar(&lintKinds, "kinds", []string{"all"}, fmt.Sprintf("Which kinds will be linted. Can be: %s", strings.Join(allKinds, "|")))
 	command.Flags().StringVarP(&output, "output", "o", "pretty", "Linting results output format. One of: pretty|simple")
-	command.Flags().BoolVar(&strict, "strict", true, "Perform strict workflow validation")
+	command.Flags().BoolVar(&strict, "strict", true, "Perform strict workflow validation. With --offline, also validate the manifests against the built-in JSON schema")
 	command.Flags().BoolVar(&offline, "offline", false, "perform offline linting. For resources referencing other resources, the references will be resolved from the provided args")
 
 	return command
@@ -65,6 +74,7 @@ func runLint(ctx context.Context, args []string, offline bool, lintKinds []strin
 		Strict:           strict,
 		DefaultNamespace: client.Namespace(),
 		Printer:          os.Stdout,
+		ValidateSchema:   offline && strict,
 	}
 	lint.RunLint(ctx, apiClient, lintKinds, output, offline, ops)
 }
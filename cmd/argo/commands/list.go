@@ -2,21 +2,23 @@ package commands
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"sort"
 	"strings"
 
-	"github.com/argoproj/pkg/errors"
 	argotime "github.com/argoproj/pkg/time"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/selection"
 
 	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/client"
 	workflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/util/errors"
 	"github.com/argoproj/argo-workflows/v3/util/printer"
 	"github.com/argoproj/argo-workflows/v3/workflow/common"
 )
@@ -35,15 +37,22 @@ type listFlags struct {
 	noHeaders      bool
 	labels         string
 	fields         string
+	allClusters    bool
+	allContexts    bool
+	groupBy        string
+	summary        bool
 }
 
 var (
 	// finishedAt and creationTimestamp must be included to have a consistent display order of workflows
 	nameFields    = "metadata,items.metadata.name,items.metadata.creationTimestamp,items.status.finishedAt"
-	defaultFields = "metadata,items.metadata,items.spec,items.status.phase,items.status.message,items.status.finishedAt,items.status.startedAt,items.status.estimatedDuration,items.status.progress"
+	defaultFields = "metadata,items.metadata,items.spec,items.status.phase,items.status.message,items.status.finishedAt,items.status.startedAt,items.status.estimatedDuration,items.status.estimatedFinishedAt,items.status.progress"
 )
 
 func (f listFlags) displayFields() string {
+	if printer.IsTemplateOutput(f.output) {
+		return ""
+	}
 	switch f.output {
 	case "name":
 		return nameFields
@@ -64,17 +73,61 @@ func NewListCommand() *cobra.Command {
 	command := &cobra.Command{
 		Use:   "list",
 		Short: "list workflows",
+		Example: `# List all workflows:
+
+  argo list
+
+# Summarise the workflows created in the last week by workflow template:
+
+  argo list --summary --group-by workflowTemplate --since 7d
+
+# Summarise workflows by the value of their "team" label:
+
+  argo list --summary --group-by label:team
+
+# List the name, phase and cost center label of workflows:
+
+  argo list -o custom-columns=NAME:.metadata.name,PHASE:.status.phase,COST:.metadata.labels.cost-center
+
+# List the names of the failed workflows on one line:
+
+  argo list --status Failed -o jsonpath='{.items[*].metadata.name}'
+
+# List the running workflows of each kubeconfig context, or of each context of ~/.config/argo/contexts.yaml:
+
+  argo list --all-contexts --running
+`,
 		Run: func(cmd *cobra.Command, args []string) {
-			ctx, apiClient := client.NewAPIClient(cmd.Context())
-			serviceClient := apiClient.NewWorkflowServiceClient()
-			if !allNamespaces {
-				listArgs.namespace = client.Namespace()
+			if listArgs.groupBy != "" && !listArgs.summary {
+				log.Fatal("--group-by can only be used with --summary")
+			}
+			groupFunc, err := printer.WorkflowGroupFunc(listArgs.groupBy)
+			errors.CheckError(err)
+			var workflows wfv1.Workflows
+			if listArgs.allContexts {
+				workflows, err = listContextWorkflows(cmd.Context(), listArgs, allNamespaces)
+			} else {
+				ctx, apiClient := client.NewAPIClient(cmd.Context())
+				serviceClient := apiClient.NewWorkflowServiceClient()
+				if !allNamespaces {
+					listArgs.namespace = client.Namespace()
+				}
+				workflows, err = listWorkflows(ctx, serviceClient, listArgs)
 			}
-			workflows, err := listWorkflows(ctx, serviceClient, listArgs)
 			errors.CheckError(err)
+			if listArgs.summary {
+				err = printer.PrintWorkflowSummaries(printer.SummarizeWorkflows(workflows, groupFunc), os.Stdout, printer.PrintOpts{
+					NoHeaders: listArgs.noHeaders,
+					Output:    listArgs.output,
+				})
+				errors.CheckError(err)
+				return
+			}
 			err = printer.PrintWorkflows(workflows, os.Stdout, printer.PrintOpts{
 				NoHeaders: listArgs.noHeaders,
 				Namespace: allNamespaces,
+				Context:   listArgs.allContexts,
+				Cluster:   listArgs.allClusters,
 				Output:    listArgs.output,
 			})
 			errors.CheckError(err)
@@ -87,11 +140,15 @@ func NewListCommand() *cobra.Command {
 	command.Flags().BoolVar(&listArgs.completed, "completed", false, "Show completed workflows. Mutually exclusive with --running.")
 	command.Flags().BoolVar(&listArgs.running, "running", false, "Show running workflows. Mutually exclusive with --completed.")
 	command.Flags().BoolVar(&listArgs.resubmitted, "resubmitted", false, "Show resubmitted workflows")
-	command.Flags().StringVarP(&listArgs.output, "output", "o", "", "Output format. One of: name|wide|yaml|json")
+	command.Flags().StringVarP(&listArgs.output, "output", "o", "", "Output format. One of: name|wide|yaml|json|custom-columns=<HEADER>:<JSONPATH>,...|jsonpath=<TEMPLATE>")
 	command.Flags().StringVar(&listArgs.createdSince, "since", "", "Show only workflows created after than a relative duration")
 	command.Flags().Int64VarP(&listArgs.chunkSize, "chunk-size", "", 0, "Return large lists in chunks rather than all at once. Pass 0 to disable.")
 	command.Flags().BoolVar(&listArgs.noHeaders, "no-headers", false, "Don't print headers (default print headers).")
 	command.Flags().StringVarP(&listArgs.labels, "selector", "l", "", "Selector (label query) to filter on, not including uninitialized ones, supports '=', '==', and '!='.(e.g. -l key1=value1,key2=value2)")
+	command.Flags().BoolVar(&listArgs.allClusters, "all-clusters", false, "Show workflows from all clusters aggregated by the Argo Server")
+	command.Flags().BoolVar(&listArgs.allContexts, "all-contexts", false, "Show workflows from each context of ~/.config/argo/contexts.yaml, or each kubeconfig context if there is no such file, in their namespace unless --all-namespaces")
+	command.Flags().BoolVar(&listArgs.summary, "summary", false, "Print the number of workflows, success rate and average duration instead of the workflows")
+	command.Flags().StringVar(&listArgs.groupBy, "group-by", "", "Group the summary by workflowTemplate, cronWorkflow, namespace or label:<KEY>")
 	command.Flags().StringVar(&listArgs.fields, "field-selector", "", "Selector (field query) to filter on, supports '=', '==', and '!='.(e.g. --field-selector key1=value1,key2=value2). The server only supports a limited number of field queries per type.")
 	return command
 }
@@ -123,8 +180,13 @@ func listWorkflows(ctx context.Context, serviceClient workflowpkg.WorkflowServic
 		req, _ := labels.NewRequirement(common.LabelKeyPreviousWorkflowName, selection.Exists, []string{})
 		labelSelector = labelSelector.Add(*req)
 	}
+	phases, fieldSelector, err := splitPhaseFieldSelector(flags.fields)
+	if err != nil {
+		return nil, err
+	}
+	labelSelector = labelSelector.Add(phases...)
 	listOpts.LabelSelector = labelSelector.String()
-	listOpts.FieldSelector = flags.fields
+	listOpts.FieldSelector = fieldSelector
 	var workflows wfv1.Workflows
 	for {
 		log.WithField("listOpts", listOpts).Debug()
@@ -132,6 +194,7 @@ func listWorkflows(ctx context.Context, serviceClient workflowpkg.WorkflowServic
 			Namespace:   flags.namespace,
 			ListOptions: listOpts,
 			Fields:      flags.displayFields(),
+			AllClusters: flags.allClusters,
 		})
 		if err != nil {
 			return nil, err
@@ -167,3 +230,70 @@ func listWorkflows(ctx context.Context, serviceClient workflowpkg.WorkflowServic
 	sort.Sort(workflows)
 	return workflows, nil
 }
+
+// listContextWorkflows lists the workflows of each context, annotated with the name of the context. A context that
+// cannot be listed is warned about and skipped, so that one unreachable cluster does not hide the others.
+func listContextWorkflows(ctx context.Context, flags listFlags, allNamespaces bool) (wfv1.Workflows, error) {
+	contexts, err := client.Contexts()
+	if err != nil {
+		return nil, err
+	}
+	var workflows wfv1.Workflows
+	for _, c := range contexts {
+		wfs, err := func() (wfv1.Workflows, error) {
+			ctx, apiClient, namespace, err := c.NewAPIClient(ctx)
+			if err != nil {
+				return nil, err
+			}
+			flags.namespace = namespace
+			if allNamespaces {
+				flags.namespace = ""
+			}
+			return listWorkflows(ctx, apiClient.NewWorkflowServiceClient(), flags)
+		}()
+		if err != nil {
+			log.WithField("context", c.Name).WithError(err).Warn("Failed to list workflows")
+			continue
+		}
+		for _, wf := range wfs {
+			if wf.Annotations == nil {
+				wf.Annotations = map[string]string{}
+			}
+			wf.Annotations[common.AnnotationKeyContext] = c.Name
+			workflows = append(workflows, wf)
+		}
+	}
+	sort.Sort(workflows)
+	return workflows, nil
+}
+
+// splitPhaseFieldSelector splits the status.phase terms out of the field selector, as the Kubernetes API cannot select
+// workflows by them, into requirements of the phase label, which is the same. Field selectors that cannot be parsed are
+// left for the API to reject.
+func splitPhaseFieldSelector(fieldSelector string) ([]labels.Requirement, string, error) {
+	selector, err := fields.ParseSelector(fieldSelector)
+	if err != nil {
+		return nil, fieldSelector, nil
+	}
+	var phases []labels.Requirement
+	var others []fields.Selector
+	for _, r := range selector.Requirements() {
+		if r.Field != "status.phase" {
+			if r.Operator == selection.NotEquals {
+				others = append(others, fields.OneTermNotEqualSelector(r.Field, r.Value))
+			} else {
+				others = append(others, fields.OneTermEqualSelector(r.Field, r.Value))
+			}
+			continue
+		}
+		req, err := labels.NewRequirement(common.LabelKeyPhase, r.Operator, []string{r.Value})
+		if err != nil {
+			return nil, "", fmt.Errorf("invalid field selector %q: %w", fieldSelector, err)
+		}
+		phases = append(phases, *req)
+	}
+	if len(phases) == 0 {
+		return nil, fieldSelector, nil
+	}
+	return phases, fields.AndSelectors(others...).String(), nil
+}
@@ -7,7 +7,9 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 
 	"github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
 	workflowmocks "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow/mocks"
@@ -88,6 +90,31 @@ func Test_listWorkflows(t *testing.T) {
 	})
 }
 
+func Test_splitPhaseFieldSelector(t *testing.T) {
+	for fieldSelector, expected := range map[string][2]string{
+		"":                                       {"", ""},
+		"metadata.name=foo":                      {"", "metadata.name=foo"},
+		"status.phase=Running":                   {"workflows.argoproj.io/phase=Running", ""},
+		"metadata.name=foo,status.phase!=Failed": {"workflows.argoproj.io/phase!=Failed", "metadata.name=foo"},
+		nameFields:                               {"", nameFields},
+	} {
+		phases, fields, err := splitPhaseFieldSelector(fieldSelector)
+		require.NoError(t, err)
+		assert.Equal(t, expected[0], labels.NewSelector().Add(phases...).String(), fieldSelector)
+		assert.Equal(t, expected[1], fields, fieldSelector)
+	}
+	t.Run("Invalid", func(t *testing.T) {
+		_, _, err := splitPhaseFieldSelector("status.phase=Not Running")
+		assert.Error(t, err)
+	})
+	t.Run("List", func(t *testing.T) {
+		workflows, err := list(&metav1.ListOptions{LabelSelector: "foo,workflows.argoproj.io/phase=Running"}, listFlags{labels: "foo", fields: "status.phase=Running"})
+		if assert.NoError(t, err) {
+			assert.NotNil(t, workflows)
+		}
+	})
+}
+
 func list(listOptions *metav1.ListOptions, flags listFlags) (wfv1.Workflows, error) {
 	c := &workflowmocks.WorkflowServiceClient{}
 	c.On("ListWorkflows", mock.Anything, &workflow.WorkflowListRequest{ListOptions: listOptions, Fields: flags.displayFields()}).Return(&wfv1.WorkflowList{Items: wfv1.Workflows{
@@ -5,23 +5,29 @@ import (
 	"os"
 	"time"
 
-	"github.com/argoproj/pkg/errors"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/pointer"
 
 	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/client"
 	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/common"
+	workflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
+	"github.com/argoproj/argo-workflows/v3/util/errors"
 )
 
 func NewLogsCommand() *cobra.Command {
 	var (
-		since     time.Duration
-		sinceTime string
-		tailLines int64
-		grep      string
-		selector  string
+		since        time.Duration
+		sinceTime    string
+		tailLines    int64
+		grep         string
+		invert       bool
+		contextLines int32
+		selector     string
+		attempt      int32
+		containers   []string
 	)
 	logOptions := &corev1.PodLogOptions{}
 	command := &cobra.Command{
@@ -43,6 +49,18 @@ func NewLogsCommand() *cobra.Command {
 
   argo logs my-wf my-pod -c my-container
 
+# Print the logs of the main and a sidecar container of all the pods of a workflow:
+
+  argo logs my-wf -c main -c my-sidecar
+
+# Print the lines of a workflow's logs that do not match a regular expression:
+
+  argo logs my-wf --grep 'DEBUG|TRACE' --invert
+
+# Print the lines of a workflow's logs, live or archived, that match a regular expression, with two lines of context:
+
+  argo logs my-wf --grep 'panic|fatal' -C 2
+
 # Print the logs of a workflow's pods:
 
   argo logs my-wf my-pod
@@ -53,7 +71,24 @@ func NewLogsCommand() *cobra.Command {
 
 # Print the logs of the latest workflow:
   argo logs @latest
+
+# Print the logs of the second attempt of a workflow's retried steps:
+
+  argo logs my-wf --retry-attempt 2
+
+# Print the logs of the attempt before the last of a workflow's retried steps, live or archived:
+
+  argo logs my-wf --previous
+
+# Print the logs of the previous instance of a pod's container, e.g. after it was restarted:
+
+  argo logs my-wf my-pod --previous
+
+# Print the archived logs of a pod that has been deleted, when using the Argo Server:
+
+  argo logs my-wf my-deleted-pod
 `,
+		ValidArgsFunction: common.CompleteArgs(common.CompleteWorkflows(), common.CompleteWorkflowPods(0)),
 		Run: func(cmd *cobra.Command, args []string) {
 			// parse all the args
 			workflow := ""
@@ -74,6 +109,14 @@ func NewLogsCommand() *cobra.Command {
 				log.Fatal("--since-time and --since cannot be used together")
 			}
 
+			if attempt < 0 {
+				log.Fatal("--attempt must be one or more")
+			}
+
+			if contextLines < 0 {
+				log.Fatal("--context-lines must be zero or more")
+			}
+
 			if since > 0 {
 				logOptions.SinceSeconds = pointer.Int64Ptr(int64(since.Seconds()))
 			}
@@ -94,17 +137,47 @@ func NewLogsCommand() *cobra.Command {
 			serviceClient := apiClient.NewWorkflowServiceClient()
 			namespace := client.Namespace()
 
-			common.LogWorkflow(ctx, serviceClient, namespace, workflow, podName, grep, selector, logOptions)
+			req := &workflowpkg.WorkflowLogRequest{
+				Name:         workflow,
+				Namespace:    namespace,
+				PodName:      podName,
+				LogOptions:   logOptions,
+				Selector:     selector,
+				Grep:         grep,
+				Invert:       invert,
+				Attempt:      attempt,
+				ContextLines: contextLines,
+				// the Argo Server reads the archived logs of the pods that were deleted itself
+				Archived: client.ArgoServerOpts.URL != "",
+			}
+			// a single container is requested with the log options, so that older servers still understand it
+			if len(containers) == 1 {
+				logOptions.Container = containers[0]
+			} else {
+				req.Containers = containers
+			}
+
+			common.LogWorkflow(ctx, serviceClient, req)
 		},
 	}
-	command.Flags().StringVarP(&logOptions.Container, "container", "c", "main", "Print the logs of this container")
+	command.Flags().StringArrayVarP(&containers, "container", "c", []string{"main"}, "Print the logs of this container, can be repeated to print the logs of several containers of each pod")
 	command.Flags().BoolVarP(&logOptions.Follow, "follow", "f", false, "Specify if the logs should be streamed.")
-	command.Flags().BoolVarP(&logOptions.Previous, "previous", "p", false, "Specify if the previously terminated container logs should be returned.")
+	command.Flags().BoolVarP(&logOptions.Previous, "previous", "p", false, "Specify if the previously terminated container logs should be returned. For retried nodes, the logs of the attempt before their last are returned instead.")
 	command.Flags().DurationVar(&since, "since", 0, "Only return logs newer than a relative duration like 5s, 2m, or 3h. Defaults to all logs. Only one of since-time / since may be used.")
 	command.Flags().StringVar(&sinceTime, "since-time", "", "Only return logs after a specific date (RFC3339). Defaults to all logs. Only one of since-time / since may be used.")
 	command.Flags().Int64Var(&tailLines, "tail", -1, "If set, the number of lines from the end of the logs to show. If not specified, logs are shown from the creation of the container or sinceSeconds or sinceTime")
 	command.Flags().StringVar(&grep, "grep", "", "grep for lines")
+	command.Flags().BoolVar(&invert, "invert", false, "Print the lines that do not match --grep instead of those that do")
+	command.Flags().Int32VarP(&contextLines, "context-lines", "C", 0, "Print this many lines before and after each line selected by --grep")
 	command.Flags().StringVarP(&selector, "selector", "l", "", "log selector for some pod")
+	command.Flags().Int32Var(&attempt, "attempt", 0, "Only print the logs of this retry attempt, starting from one, of retried nodes. Nodes that were not retried only have attempt one. Defaults to all attempts. Also --retry-attempt.")
+	// --retry-attempt is another name for --attempt
+	command.Flags().SetNormalizeFunc(func(f *pflag.FlagSet, name string) pflag.NormalizedName {
+		if name == "retry-attempt" {
+			name = "attempt"
+		}
+		return pflag.NormalizedName(name)
+	})
 	command.Flags().BoolVar(&logOptions.Timestamps, "timestamps", false, "Include timestamps on each line in the log output")
 	command.Flags().BoolVar(&common.NoColor, "no-color", false, "Disable colorized output")
 	return command
@@ -0,0 +1,197 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	wf "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/util/errors"
+)
+
+// newKinds are the kinds of manifests that can be generated, and their default names
+var newKinds = map[string]string{
+	"dag":      "my-dag",
+	"steps":    "my-steps",
+	"cron":     "my-cron-workflow",
+	"template": "my-workflow-template",
+}
+
+type newOpts struct {
+	parameters         []string // --parameter
+	exitHandler        bool     // --exit-handler
+	artifactRepository string   // --artifact-repository
+}
+
+func NewNewCommand() *cobra.Command {
+	var opts newOpts
+
+	command := &cobra.Command{
+		Use:   "new KIND [NAME]",
+		Short: "print the manifest of a new workflow, cron workflow or workflow template",
+		Long: `Print the manifest of a new workflow, cron workflow or workflow template, ready to edit, so that you do not need to start from scratch.
+
+KIND is one of:
+
+  dag       a workflow of a DAG of tasks
+  steps     a workflow of steps
+  cron      a cron workflow that runs steps every day
+  template  a workflow template of a DAG of tasks
+
+The manifest is formatted as "argo fmt" would format it.`,
+		Example: `
+# Print the manifest of a new workflow of a DAG of tasks:
+
+  argo new dag my-dag
+
+# Write the manifest of a new cron workflow with parameters and an exit handler to a file:
+
+  argo new cron nightly -p env=dev --exit-handler > nightly.yaml
+
+# Print the manifest of a new workflow template that saves an artifact to the "my-key" artifact repository:
+
+  argo new template my-template --artifact-repository my-key`,
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			if len(args) != 0 {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+			return []string{"dag", "steps", "cron", "template"}, cobra.ShellCompDirectiveNoFileComp
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) == 0 || len(args) > 2 {
+				cmd.HelpFunc()(cmd, args)
+				os.Exit(1)
+			}
+			name := ""
+			if len(args) == 2 {
+				name = args[1]
+			}
+			data, err := newManifest(args[0], name, opts)
+			errors.CheckError(err)
+			_, err = os.Stdout.Write(data)
+			errors.CheckError(err)
+		},
+	}
+
+	command.Flags().StringArrayVarP(&opts.parameters, "parameter", "p", []string{}, "Add a workflow parameter, as NAME=VALUE, or NAME for a parameter that must be given on submission, unless the kind is cron")
+	command.Flags().BoolVar(&opts.exitHandler, "exit-handler", false, "Add an exit handler, that runs when the workflow completes")
+	command.Flags().StringVar(&opts.artifactRepository, "artifact-repository", "", "Save an output artifact to the artifact repository of this key in the \"artifact-repositories\" config map")
+
+	return command
+}
+
+// newManifest returns the formatted manifest of a new object of the kind
+func newManifest(kind, name string, opts newOpts) ([]byte, error) {
+	defaultName, ok := newKinds[kind]
+	if !ok {
+		return nil, fmt.Errorf("unknown kind %q, must be one of: dag, steps, cron, template", kind)
+	}
+	if name == "" {
+		name = defaultName
+	}
+	var obj interface{}
+	switch kind {
+	case "dag", "steps":
+		obj = &wfv1.Workflow{
+			TypeMeta:   metav1.TypeMeta{APIVersion: wf.APIVersion, Kind: wf.WorkflowKind},
+			ObjectMeta: metav1.ObjectMeta{GenerateName: name + "-"},
+			Spec:       newWorkflowSpec(kind == "dag", opts),
+		}
+	case "cron":
+		// cron workflows are not submitted by a user, so their parameters must have values
+		for _, p := range opts.parameters {
+			if !strings.Contains(p, "=") {
+				return nil, fmt.Errorf("parameter %q of a cron workflow must have a value, as NAME=VALUE", p)
+			}
+		}
+		obj = &wfv1.CronWorkflow{
+			TypeMeta:   metav1.TypeMeta{APIVersion: wf.APIVersion, Kind: wf.CronWorkflowKind},
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: wfv1.CronWorkflowSpec{
+				Schedule:          "0 0 * * *",
+				ConcurrencyPolicy: wfv1.ForbidConcurrent,
+				WorkflowSpec:      newWorkflowSpec(false, opts),
+			},
+		}
+	case "template":
+		obj = &wfv1.WorkflowTemplate{
+			TypeMeta:   metav1.TypeMeta{APIVersion: wf.APIVersion, Kind: wf.WorkflowTemplateKind},
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       newWorkflowSpec(true, opts),
+		}
+	}
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+	// the fields that marshalling adds, such as the status, are removed, as "argo fmt" would
+	return yaml.Marshal(prune(v, nil))
+}
+
+// newWorkflowSpec returns the spec of a workflow that prints a greeting, and then a farewell, as a DAG of tasks or
+// as steps
+func newWorkflowSpec(dag bool, opts newOpts) wfv1.WorkflowSpec {
+	spec := wfv1.WorkflowSpec{Entrypoint: "main"}
+	for _, p := range opts.parameters {
+		parts := strings.SplitN(p, "=", 2)
+		param := wfv1.Parameter{Name: parts[0]}
+		if len(parts) == 2 {
+			param.Value = wfv1.AnyStringPtr(parts[1])
+		}
+		spec.Arguments.Parameters = append(spec.Arguments.Parameters, param)
+	}
+
+	arguments := func(message string) wfv1.Arguments {
+		return wfv1.Arguments{Parameters: []wfv1.Parameter{{Name: "message", Value: wfv1.AnyStringPtr(message)}}}
+	}
+	main := wfv1.Template{Name: "main"}
+	if dag {
+		main.DAG = &wfv1.DAGTemplate{Tasks: []wfv1.DAGTask{
+			{Name: "hello", Template: "print", Arguments: arguments("hello")},
+			{Name: "goodbye", Template: "print", Arguments: arguments("goodbye"), Depends: "hello"},
+		}}
+	} else {
+		main.Steps = []wfv1.ParallelSteps{
+			{Steps: []wfv1.WorkflowStep{{Name: "hello", Template: "print", Arguments: arguments("hello")}}},
+			{Steps: []wfv1.WorkflowStep{{Name: "goodbye", Template: "print", Arguments: arguments("goodbye")}}},
+		}
+	}
+
+	printTmpl := wfv1.Template{
+		Name:   "print",
+		Inputs: wfv1.Inputs{Parameters: []wfv1.Parameter{{Name: "message"}}},
+		Container: &apiv1.Container{
+			Image: "argoproj/argosay:v2",
+			Args:  []string{"echo", "{{inputs.parameters.message}}"},
+		},
+	}
+	if opts.artifactRepository != "" {
+		spec.ArtifactRepositoryRef = &wfv1.ArtifactRepositoryRef{ConfigMap: "artifact-repositories", Key: opts.artifactRepository}
+		printTmpl.Container.Args = append(printTmpl.Container.Args, "/tmp/message")
+		printTmpl.Outputs.Artifacts = []wfv1.Artifact{{Name: "message", Path: "/tmp/message"}}
+	}
+	spec.Templates = []wfv1.Template{main, printTmpl}
+
+	if opts.exitHandler {
+		spec.OnExit = "exit-handler"
+		spec.Templates = append(spec.Templates, wfv1.Template{
+			Name: "exit-handler",
+			Container: &apiv1.Container{
+				Image: "argoproj/argosay:v2",
+				Args:  []string{"echo", "{{workflow.name}} {{workflow.status}}"},
+			},
+		})
+	}
+	return spec
+}
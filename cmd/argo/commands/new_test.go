@@ -0,0 +1,65 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/validate"
+)
+
+func Test_newManifest(t *testing.T) {
+	opts := newOpts{parameters: []string{"env=dev", "version"}, exitHandler: true, artifactRepository: "my-key"}
+	for _, kind := range []string{"dag", "steps", "cron", "template"} {
+		t.Run(kind, func(t *testing.T) {
+			opts := opts
+			if kind == "cron" {
+				opts.parameters = []string{"env=dev"}
+			}
+			for _, opts := range []newOpts{{}, opts} {
+				data, err := newManifest(kind, "", opts)
+				require.NoError(t, err)
+
+				// already formatted
+				formatted, err := formatManifest(data)
+				require.NoError(t, err)
+				assert.Equal(t, string(formatted), string(data))
+
+				switch kind {
+				case "dag", "steps":
+					wf := wfv1.MustUnmarshalWorkflow(data)
+					assert.Equal(t, newKinds[kind]+"-", wf.GenerateName)
+					assert.NoError(t, validate.ValidateWorkflow(nil, nil, wf, validate.ValidateOpts{Lint: true}))
+				case "cron":
+					cronWf := wfv1.MustUnmarshalCronWorkflow(data)
+					assert.Equal(t, newKinds[kind], cronWf.Name)
+					assert.NoError(t, validate.ValidateCronWorkflow(nil, nil, cronWf))
+				case "template":
+					wftmpl := wfv1.MustUnmarshalWorkflowTemplate(data)
+					assert.Equal(t, newKinds[kind], wftmpl.Name)
+					assert.NoError(t, validate.ValidateWorkflowTemplate(nil, nil, wftmpl, validate.ValidateOpts{Lint: true}))
+				}
+			}
+		})
+	}
+	t.Run("Options", func(t *testing.T) {
+		data, err := newManifest("dag", "my-wf", opts)
+		require.NoError(t, err)
+		wf := wfv1.MustUnmarshalWorkflow(data)
+		assert.Equal(t, "my-wf-", wf.GenerateName)
+		assert.Equal(t, "dev", wf.Spec.Arguments.GetParameterByName("env").Value.String())
+		assert.Nil(t, wf.Spec.Arguments.GetParameterByName("version").Value)
+		assert.Equal(t, "exit-handler", wf.Spec.OnExit)
+		assert.Equal(t, &wfv1.ArtifactRepositoryRef{ConfigMap: "artifact-repositories", Key: "my-key"}, wf.Spec.ArtifactRepositoryRef)
+	})
+	t.Run("CronParameterWithoutValue", func(t *testing.T) {
+		_, err := newManifest("cron", "", newOpts{parameters: []string{"version"}})
+		assert.EqualError(t, err, `parameter "version" of a cron workflow must have a value, as NAME=VALUE`)
+	})
+	t.Run("UnknownKind", func(t *testing.T) {
+		_, err := newManifest("foo", "", newOpts{})
+		assert.EqualError(t, err, `unknown kind "foo", must be one of: dag, steps, cron, template`)
+	})
+}
@@ -8,23 +8,29 @@ import (
 	"strconv"
 	"strings"
 
-	"github.com/argoproj/pkg/errors"
 	"github.com/spf13/cobra"
 	"k8s.io/apimachinery/pkg/fields"
 
 	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/client"
+	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/common"
 	workflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/util/errors"
 )
 
 type setOps struct {
 	message           string   // --message
 	phase             string   // --phase
 	outputParameters  []string // --output-parameters
+	outputArtifacts   []string // --output-artifact
 	nodeFieldSelector string   // --node-field-selector
+	signal            string   // --signal
+	note              string   // --note
 }
 
 func NewNodeCommand() *cobra.Command {
 	var setArgs setOps
+	var describeArgs describeOps
 
 	command := &cobra.Command{
 		Use:   "node ACTION WORKFLOW FLAGS",
@@ -33,17 +39,89 @@ func NewNodeCommand() *cobra.Command {
 
   argo node set my-wf --output-parameter parameter-name="Hello, world!" --node-field-selector displayName=approve
 
+# Attach a reviewed file, already uploaded to the artifact repository, to a suspended node:
+
+  argo node set my-wf --output-artifact report=reviews/my-wf/report.pdf --node-field-selector displayName=approve
+
 # Set the message of a node within a workflow:
 
   argo node set my-wf --message "We did it!"" --node-field-selector displayName=approve
+
+# Complete a manual task, supplying its outputs:
+
+  argo node complete my-wf --output-parameter approved=true --node-field-selector displayName=review
+
+# Send SIGUSR1 to the main container of a running node, e.g. to make it checkpoint:
+
+  argo node signal my-wf my-wf-1234567890 --signal SIGUSR1
+
+# Annotate a node with a note, shown by "argo get", e.g. to keep the context of a manual action with the run:
+
+  argo node annotate my-wf my-wf-1234567890 --note "reran manually, see INC-1234"
+
+# Describe a node, with its pods' events and executor logs in one chronological view:
+
+  argo node describe my-wf my-wf-1234567890 --events
 `,
+		ValidArgsFunction: common.CompleteArgs(
+			cobra.FixedCompletions([]string{"set", "complete", "signal", "annotate", "describe"}, cobra.ShellCompDirectiveNoFileComp),
+			common.CompleteWorkflows(),
+			common.CompleteNodeIDs(1),
+		),
 		Run: func(cmd *cobra.Command, args []string) {
 			if len(args) < 2 {
 				cmd.HelpFunc()(cmd, args)
 				os.Exit(1)
 			}
 
-			if args[0] != "set" {
+			switch args[0] {
+			case "describe":
+				if len(args) != 3 {
+					cmd.HelpFunc()(cmd, args)
+					os.Exit(1)
+				}
+				ctx, apiClient := client.NewAPIClient(cmd.Context())
+				serviceClient := apiClient.NewWorkflowServiceClient()
+				errors.CheckError(describeNode(ctx, serviceClient, client.Namespace(), args[1], args[2], describeArgs))
+				return
+			case "signal":
+				if len(args) != 3 {
+					cmd.HelpFunc()(cmd, args)
+					os.Exit(1)
+				}
+				ctx, apiClient := client.NewAPIClient(cmd.Context())
+				serviceClient := apiClient.NewWorkflowServiceClient()
+				_, err := serviceClient.SignalWorkflow(ctx, &workflowpkg.WorkflowSignalRequest{
+					Name:      args[1],
+					Namespace: client.Namespace(),
+					Node:      args[2],
+					Signal:    setArgs.signal,
+				})
+				errors.CheckError(err)
+				fmt.Printf("node %s signaled with %s\n", args[2], setArgs.signal)
+				return
+			case "annotate":
+				if len(args) != 3 || setArgs.note == "" {
+					cmd.HelpFunc()(cmd, args)
+					os.Exit(1)
+				}
+				ctx, apiClient := client.NewAPIClient(cmd.Context())
+				serviceClient := apiClient.NewWorkflowServiceClient()
+				_, err := serviceClient.AnnotateWorkflowNode(ctx, &workflowpkg.WorkflowNodeAnnotateRequest{
+					Name:      args[1],
+					Namespace: client.Namespace(),
+					Node:      args[2],
+					Note:      setArgs.note,
+				})
+				errors.CheckError(err)
+				fmt.Printf("node %s annotated\n", args[2])
+				return
+			case "set":
+			case "complete":
+				if setArgs.phase == "" {
+					setArgs.phase = string(wfv1.NodeSucceeded)
+				}
+			default:
 				log.Fatalf("unknown action '%s'", args[0])
 			}
 
@@ -68,6 +146,23 @@ func NewNodeCommand() *cobra.Command {
 				outputParameters = string(res)
 			}
 
+			outputArtifacts := ""
+			if len(setArgs.outputArtifacts) > 0 {
+				outputArts := make(map[string]string)
+				for _, art := range setArgs.outputArtifacts {
+					parts := strings.SplitN(art, "=", 2)
+					if len(parts) != 2 {
+						log.Fatalf("expected artifact of the form: NAME=KEY. Received: %s", art)
+					}
+					outputArts[parts[0]] = parts[1]
+				}
+				res, err := json.Marshal(outputArts)
+				if err != nil {
+					log.Fatalf("unable to parse output artifact set request: %s", err)
+				}
+				outputArtifacts = string(res)
+			}
+
 			ctx, apiClient := client.NewAPIClient(cmd.Context())
 			serviceClient := apiClient.NewWorkflowServiceClient()
 			namespace := client.Namespace()
@@ -84,14 +179,25 @@ func NewNodeCommand() *cobra.Command {
 				Message:           setArgs.message,
 				Phase:             setArgs.phase,
 				OutputParameters:  outputParameters,
+				OutputArtifacts:   outputArtifacts,
 			})
 			errors.CheckError(err)
-			fmt.Printf("workflow values set\n")
+			if args[0] == "complete" {
+				fmt.Printf("manual task completed\n")
+			} else {
+				fmt.Printf("workflow values set\n")
+			}
 		},
 	}
 	command.Flags().StringVar(&setArgs.nodeFieldSelector, "node-field-selector", "", "Selector of node to set, eg: --node-field-selector inputs.paramaters.myparam.value=abc")
+	_ = command.RegisterFlagCompletionFunc("node-field-selector", common.CompleteNodeFieldSelector(1))
 	command.Flags().StringVar(&setArgs.phase, "phase", "", "Phase to set the node to, eg: --phase Succeeded")
 	command.Flags().StringArrayVarP(&setArgs.outputParameters, "output-parameter", "p", []string{}, "Set a \"supplied\" output parameter of node, eg: --output-parameter parameter-name=\"Hello, world!\"")
+	command.Flags().StringArrayVarP(&setArgs.outputArtifacts, "output-artifact", "a", []string{}, "Set a supplied output artifact of node to a key in the workflow's artifact repository, eg: --output-artifact artifact-name=path/to/file.txt")
 	command.Flags().StringVarP(&setArgs.message, "message", "m", "", "Set the message of a node, eg: --message \"Hello, world!\"")
+	command.Flags().StringVar(&setArgs.signal, "signal", "SIGUSR1", "Signal to send to the main container of the node, eg: --signal SIGUSR2")
+	command.Flags().StringVar(&setArgs.note, "note", "", "Note to annotate the node with, eg: --note \"reran manually, see INC-1234\"")
+	command.Flags().BoolVar(&describeArgs.events, "events", false, "Describe the node with the events of its pods, and the tail of their executor logs, in chronological order")
+	command.Flags().Int64Var(&describeArgs.tail, "tail", 20, "The number of lines of executor logs to describe the node with")
 	return command
 }
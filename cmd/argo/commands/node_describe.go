@@ -0,0 +1,261 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	workflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+	"github.com/argoproj/argo-workflows/v3/workflow/util"
+)
+
+// eventsIdleTimeout is how long to wait for more events, as events are watched rather than listed
+var eventsIdleTimeout = 2 * time.Second
+
+type describeOps struct {
+	events bool  // --events
+	tail   int64 // --tail
+}
+
+// timelineEntry is something that happened to a node, from the node's status, a Kubernetes event, or a log line
+type timelineEntry struct {
+	time    time.Time
+	source  string
+	message string
+}
+
+func describeNode(ctx context.Context, serviceClient workflowpkg.WorkflowServiceClient, namespace, workflowName, nodeKey string, opts describeOps) error {
+	wf, err := serviceClient.GetWorkflow(ctx, &workflowpkg.WorkflowGetRequest{Name: workflowName, Namespace: namespace})
+	if err != nil {
+		return err
+	}
+	node := findNode(wf.Status.Nodes, nodeKey)
+	if node == nil {
+		return fmt.Errorf("node %q not found in workflow %q", nodeKey, workflowName)
+	}
+	podNodes := getPodNodes(wf.Status.Nodes, node)
+	podNameVersion := util.GetWorkflowPodNameVersion(wf)
+	podNames := make([]string, len(podNodes))
+	for i, n := range podNodes {
+		podNames[i] = util.GeneratePodName(wf.Name, n.Name, util.GetTemplateFromNode(n), n.ID, podNameVersion)
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintf(w, "ID:\t%s\n", node.ID)
+	_, _ = fmt.Fprintf(w, "Name:\t%s\n", node.Name)
+	_, _ = fmt.Fprintf(w, "Type:\t%s\n", node.Type)
+	_, _ = fmt.Fprintf(w, "Phase:\t%s\n", node.Phase)
+	if node.Message != "" {
+		_, _ = fmt.Fprintf(w, "Message:\t%s\n", node.Message)
+	}
+	if len(podNames) > 0 {
+		_, _ = fmt.Fprintf(w, "Pods:\t%s\n", strings.Join(podNames, ", "))
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	if !opts.events {
+		return nil
+	}
+
+	entries := nodeEntries(node)
+	nodeIDs := map[string]bool{node.ID: true}
+	for i, n := range podNodes {
+		if n.ID != node.ID {
+			entries = append(entries, nodeEntries(&podNodes[i])...)
+		}
+		nodeIDs[n.ID] = true
+	}
+	// the controller records node events against the workflow, or against the pod with nodeEvents.sendAsPod
+	workflowEvents, err := watchEvents(ctx, serviceClient, namespace, "involvedObject.kind=Workflow,involvedObject.name="+wf.Name)
+	if err != nil {
+		return err
+	}
+	for _, e := range workflowEvents {
+		if nodeIDs[e.Annotations[common.AnnotationKeyNodeID]] {
+			entries = append(entries, eventEntry(e))
+		}
+	}
+	for _, podName := range podNames {
+		podEvents, err := watchEvents(ctx, serviceClient, namespace, "involvedObject.kind=Pod,involvedObject.name="+podName)
+		if err != nil {
+			return err
+		}
+		for _, e := range podEvents {
+			entries = append(entries, eventEntry(e))
+		}
+		logEntries, err := tailExecutorLogs(ctx, serviceClient, namespace, wf.Name, podName, opts.tail)
+		if err != nil {
+			// the pod may have been deleted
+			log.WithError(err).WithField("pod", podName).Warn("failed to get executor logs")
+		}
+		entries = append(entries, logEntries...)
+	}
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].time.Before(entries[j].time) })
+
+	fmt.Println()
+	w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "TIME\tSOURCE\tMESSAGE")
+	for _, e := range entries {
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n", e.time.Format(time.RFC3339), e.source, e.message)
+	}
+	return w.Flush()
+}
+
+// findNode finds the node by its ID, name or display name
+func findNode(nodes wfv1.Nodes, key string) *wfv1.NodeStatus {
+	if node, ok := nodes[key]; ok {
+		return &node
+	}
+	if node := nodes.FindByName(key); node != nil {
+		return node
+	}
+	return nodes.FindByDisplayName(key)
+}
+
+// getPodNodes returns the pod nodes of the node, which is either the node itself or the attempts of a retry node
+func getPodNodes(nodes wfv1.Nodes, node *wfv1.NodeStatus) []wfv1.NodeStatus {
+	switch node.Type {
+	case wfv1.NodeTypePod:
+		return []wfv1.NodeStatus{*node}
+	case wfv1.NodeTypeRetry:
+		var podNodes []wfv1.NodeStatus
+		for _, id := range node.Children {
+			if child, ok := nodes[id]; ok && child.Type == wfv1.NodeTypePod {
+				podNodes = append(podNodes, child)
+			}
+		}
+		return podNodes
+	}
+	return nil
+}
+
+func nodeEntries(node *wfv1.NodeStatus) []timelineEntry {
+	var entries []timelineEntry
+	if !node.StartedAt.IsZero() {
+		entries = append(entries, timelineEntry{node.StartedAt.Time, "node", fmt.Sprintf("%s started", node.DisplayName)})
+	}
+	if !node.FinishedAt.IsZero() {
+		message := fmt.Sprintf("%s %s", node.DisplayName, node.Phase)
+		if node.Message != "" {
+			message += ": " + node.Message
+		}
+		entries = append(entries, timelineEntry{node.FinishedAt.Time, "node", message})
+	}
+	return entries
+}
+
+func eventEntry(e *corev1.Event) timelineEntry {
+	t := e.LastTimestamp.Time
+	if t.IsZero() {
+		t = e.EventTime.Time
+	}
+	if t.IsZero() {
+		t = e.CreationTimestamp.Time
+	}
+	source := e.Source.Component
+	if source == "" {
+		source = e.ReportingController
+	}
+	message := fmt.Sprintf("%s: %s", e.Reason, e.Message)
+	if e.Count > 1 {
+		message += fmt.Sprintf(" (x%d)", e.Count)
+	}
+	return timelineEntry{t, source, message}
+}
+
+// watchEvents returns the events matching the field selector. Events can only be watched, so it returns once no more
+// events have been received for a while.
+func watchEvents(ctx context.Context, serviceClient workflowpkg.WorkflowServiceClient, namespace, fieldSelector string) ([]*corev1.Event, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := serviceClient.WatchEvents(ctx, &workflowpkg.WatchEventsRequest{
+		Namespace:   namespace,
+		ListOptions: &metav1.ListOptions{FieldSelector: fieldSelector},
+	})
+	if err != nil {
+		return nil, err
+	}
+	type result struct {
+		event *corev1.Event
+		err   error
+	}
+	results := make(chan result)
+	go func() {
+		for {
+			event, err := stream.Recv()
+			select {
+			case results <- result{event, err}:
+			case <-ctx.Done():
+				return
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+	var events []*corev1.Event
+	for {
+		select {
+		case r := <-results:
+			if r.err == io.EOF {
+				return events, nil
+			}
+			if r.err != nil {
+				return nil, r.err
+			}
+			events = append(events, r.event)
+		case <-time.After(eventsIdleTimeout):
+			return events, nil
+		}
+	}
+}
+
+// tailExecutorLogs returns the last lines of the logs of the pod's executor
+func tailExecutorLogs(ctx context.Context, serviceClient workflowpkg.WorkflowServiceClient, namespace, workflowName, podName string, tail int64) ([]timelineEntry, error) {
+	if tail <= 0 {
+		return nil, nil
+	}
+	stream, err := serviceClient.PodLogs(ctx, &workflowpkg.WorkflowLogRequest{
+		Name:       workflowName,
+		Namespace:  namespace,
+		PodName:    podName,
+		LogOptions: &corev1.PodLogOptions{Container: common.WaitContainerName, TailLines: &tail, Timestamps: true},
+	})
+	if err != nil {
+		return nil, err
+	}
+	var entries []timelineEntry
+	for {
+		e, err := stream.Recv()
+		if err == io.EOF {
+			return entries, nil
+		}
+		if err != nil {
+			return entries, err
+		}
+		entries = append(entries, logEntry(podName, e.Content))
+	}
+}
+
+// logEntry parses a log line prefixed with its timestamp
+func logEntry(podName, content string) timelineEntry {
+	source := podName + "/" + common.WaitContainerName
+	parts := strings.SplitN(content, " ", 2)
+	if len(parts) == 2 {
+		if t, err := time.Parse(time.RFC3339Nano, parts[0]); err == nil {
+			return timelineEntry{t, source, parts[1]}
+		}
+	}
+	return timelineEntry{source: source, message: content}
+}
@@ -0,0 +1,51 @@
+package commands
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+func Test_findNode(t *testing.T) {
+	nodes := wfv1.Nodes{
+		"my-wf-1": {ID: "my-wf-1", Name: "my-wf.main", DisplayName: "main", Type: wfv1.NodeTypeRetry, Children: []string{"my-wf-2", "my-wf-3"}},
+		"my-wf-2": {ID: "my-wf-2", Name: "my-wf.main(0)", DisplayName: "main(0)", Type: wfv1.NodeTypePod},
+		"my-wf-3": {ID: "my-wf-3", Name: "my-wf.main(1)", DisplayName: "main(1)", Type: wfv1.NodeTypePod},
+	}
+	for _, key := range []string{"my-wf-1", "my-wf.main", "main"} {
+		node := findNode(nodes, key)
+		if assert.NotNil(t, node, key) {
+			assert.Equal(t, "my-wf-1", node.ID)
+			assert.Len(t, getPodNodes(nodes, node), 2, "the pods of the attempts of a retry node")
+		}
+	}
+	assert.Nil(t, findNode(nodes, "missing"))
+	assert.Len(t, getPodNodes(nodes, findNode(nodes, "main(1)")), 1)
+}
+
+func Test_eventEntry(t *testing.T) {
+	now := metav1.NewTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	e := eventEntry(&corev1.Event{
+		Reason:        "FailedScheduling",
+		Message:       "0/3 nodes are available",
+		Source:        corev1.EventSource{Component: "default-scheduler"},
+		LastTimestamp: now,
+		Count:         2,
+	})
+	assert.Equal(t, timelineEntry{now.Time, "default-scheduler", "FailedScheduling: 0/3 nodes are available (x2)"}, e)
+}
+
+func Test_logEntry(t *testing.T) {
+	e := logEntry("my-pod", "2024-01-01T00:00:01.5Z level=info msg=\"Starting\"")
+	assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 1, 500000000, time.UTC), e.time)
+	assert.Equal(t, "my-pod/wait", e.source)
+	assert.Equal(t, "level=info msg=\"Starting\"", e.message)
+	e = logEntry("my-pod", "no timestamp")
+	assert.True(t, e.time.IsZero())
+	assert.Equal(t, "no timestamp", e.message)
+}
@@ -0,0 +1,50 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/client"
+	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/common"
+	workflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
+	"github.com/argoproj/argo-workflows/v3/util/errors"
+	"github.com/argoproj/argo-workflows/v3/util/printer"
+)
+
+func NewOutputsCommand() *cobra.Command {
+	var output string
+	command := &cobra.Command{
+		Use:   "outputs WORKFLOW",
+		Short: "print the declared outputs of a workflow",
+		Long:  "Print the outputs a workflow declares in `spec.outputs`, with their values taken from the outputs of its nodes.",
+		Example: `# Print the outputs of a workflow:
+
+  argo outputs my-wf
+
+# Print the outputs of the latest workflow as a JSON object of each output's name to its value:
+
+  argo outputs @latest -o json
+`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: common.CompleteArgs(common.CompleteWorkflows()),
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx, apiClient := client.NewAPIClient(cmd.Context())
+			serviceClient := apiClient.NewWorkflowServiceClient()
+			outputs, err := serviceClient.GetWorkflowOutputs(ctx, &workflowpkg.WorkflowOutputsRequest{
+				Name:      args[0],
+				Namespace: client.Namespace(),
+			})
+			errors.CheckError(err)
+			if len(outputs.Outputs) == 0 && output == "" {
+				fmt.Printf("Workflow %s does not declare any outputs\n", args[0])
+				return
+			}
+			err = printer.PrintWorkflowOutputs(outputs, os.Stdout, printer.PrintOpts{Output: output})
+			errors.CheckError(err)
+		},
+	}
+	command.Flags().StringVarP(&output, "output", "o", "", "Output format. One of: json|yaml")
+	return command
+}
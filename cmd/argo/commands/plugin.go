@@ -0,0 +1,69 @@
+package commands
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// pluginPrefix is the prefix of the names of the executables that extend the CLI, e.g. "argo cost-report" runs the
+// first "argo-cost_report" on the PATH
+const pluginPrefix = CLIName + "-"
+
+// lookPath finds plugin executables, tests replace it
+var lookPath = exec.LookPath
+
+// RunPlugin runs the plugin that the args name, unless they name one of the CLI's own commands, with the remaining
+// args and the environment of the CLI, so that the plugin uses the same client configuration, e.g. ARGO_SERVER,
+// ARGO_TOKEN, ARGO_NAMESPACE and KUBECONFIG. It returns whether it found a plugin to run.
+func RunPlugin(command *cobra.Command, args []string) (bool, error) {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") || args[0] == cobra.ShellCompRequestCmd || args[0] == cobra.ShellCompNoDescRequestCmd {
+		return false, nil
+	}
+	command.InitDefaultHelpCmd()
+	var names []string
+	if c, rest, err := command.Find(args); err == nil {
+		// a command's group can be extended too, e.g. "argo admin replay" runs "argo-admin-replay"
+		if !c.HasSubCommands() || len(rest) == 0 || strings.HasPrefix(rest[0], "-") {
+			return false, nil
+		}
+		for ; c.HasParent(); c = c.Parent() {
+			names = append([]string{c.Name()}, names...)
+		}
+		args = rest
+	}
+	path, pluginArgs := findPlugin(names, args)
+	if path == "" {
+		return false, nil
+	}
+	cmd := exec.Command(path, pluginArgs...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Env = os.Environ()
+	return true, cmd.Run()
+}
+
+// findPlugin returns the path of the plugin with the longest name that the args name, after the names of the
+// commands it extends, and the args that follow its name, like kubectl, dashes in the names are replaced with
+// underscores, so "argo foo-bar baz" runs "argo-foo_bar-baz", or "argo-foo_bar baz"
+func findPlugin(commands []string, args []string) (string, []string) {
+	var names []string
+	for _, name := range commands {
+		names = append(names, strings.ReplaceAll(name, "-", "_"))
+	}
+	for _, arg := range args {
+		if strings.HasPrefix(arg, "-") {
+			break
+		}
+		names = append(names, strings.ReplaceAll(arg, "-", "_"))
+	}
+	for i := len(names); i > len(commands); i-- {
+		if path, err := lookPath(pluginPrefix + strings.Join(names[:i], "-")); err == nil {
+			return path, args[i-len(commands):]
+		}
+	}
+	return "", nil
+}
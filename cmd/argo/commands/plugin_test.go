@@ -0,0 +1,100 @@
+package commands
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_findPlugin(t *testing.T) {
+	defer func() { lookPath = exec.LookPath }()
+	lookPath = func(file string) (string, error) {
+		switch file {
+		case "argo-foo", "argo-foo_bar-baz", "argo-admin-foo":
+			return "/bin/" + file, nil
+		}
+		return "", errors.New("not found")
+	}
+	t.Run("NotFound", func(t *testing.T) {
+		path, _ := findPlugin(nil, []string{"bar"})
+		assert.Empty(t, path)
+	})
+	t.Run("Found", func(t *testing.T) {
+		path, args := findPlugin(nil, []string{"foo", "qux", "--quux"})
+		assert.Equal(t, "/bin/argo-foo", path)
+		assert.Equal(t, []string{"qux", "--quux"}, args)
+	})
+	t.Run("Longest", func(t *testing.T) {
+		path, args := findPlugin(nil, []string{"foo-bar", "baz", "qux"})
+		assert.Equal(t, "/bin/argo-foo_bar-baz", path)
+		assert.Equal(t, []string{"qux"}, args)
+	})
+	t.Run("Flags", func(t *testing.T) {
+		path, args := findPlugin(nil, []string{"foo-bar", "--baz"})
+		assert.Empty(t, path)
+		assert.Empty(t, args)
+	})
+	t.Run("Command", func(t *testing.T) {
+		path, args := findPlugin([]string{"admin"}, []string{"foo", "qux"})
+		assert.Equal(t, "/bin/argo-admin-foo", path)
+		assert.Equal(t, []string{"qux"}, args)
+	})
+	t.Run("CommandOnly", func(t *testing.T) {
+		path, _ := findPlugin([]string{"foo"}, []string{"bar"})
+		assert.Empty(t, path)
+	})
+}
+
+func TestRunPlugin(t *testing.T) {
+	dir := t.TempDir()
+	out := filepath.Join(dir, "out")
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "argo-foo"), []byte("#!/bin/sh\necho \"$ARGO_SERVER $*\" > "+out+"\nexit 3\n"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "argo-list"), []byte("#!/bin/sh\n"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "argo-admin-foo"), []byte("#!/bin/sh\necho \"$*\" > "+out+"\n"), 0o755))
+	t.Setenv("PATH", dir)
+	t.Setenv("ARGO_SERVER", "localhost:2746")
+	t.Run("Command", func(t *testing.T) {
+		ok, err := RunPlugin(NewCommand(), []string{"list", "foo"})
+		assert.False(t, ok)
+		assert.NoError(t, err)
+	})
+	t.Run("Flag", func(t *testing.T) {
+		ok, err := RunPlugin(NewCommand(), []string{"--foo"})
+		assert.False(t, ok)
+		assert.NoError(t, err)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		ok, err := RunPlugin(NewCommand(), []string{"bar"})
+		assert.False(t, ok)
+		assert.NoError(t, err)
+	})
+	t.Run("Plugin", func(t *testing.T) {
+		ok, err := RunPlugin(NewCommand(), []string{"foo", "bar", "--baz"})
+		assert.True(t, ok)
+		exitErr := &exec.ExitError{}
+		if assert.ErrorAs(t, err, &exitErr) {
+			assert.Equal(t, 3, exitErr.ExitCode())
+		}
+		data, err := os.ReadFile(out)
+		require.NoError(t, err)
+		assert.Equal(t, "localhost:2746 bar --baz\n", string(data))
+	})
+	t.Run("SubCommand", func(t *testing.T) {
+		ok, err := RunPlugin(NewCommand(), []string{"admin", "orphans", "foo"})
+		assert.False(t, ok)
+		assert.NoError(t, err)
+	})
+	t.Run("CommandPlugin", func(t *testing.T) {
+		ok, err := RunPlugin(NewCommand(), []string{"admin", "foo", "bar"})
+		assert.True(t, ok)
+		assert.NoError(t, err)
+		data, err := os.ReadFile(out)
+		require.NoError(t, err)
+		assert.Equal(t, "bar\n", string(data))
+	})
+}
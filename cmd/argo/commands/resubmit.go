@@ -2,8 +2,9 @@ package commands
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
-	"github.com/argoproj/pkg/errors"
 	"github.com/spf13/cobra"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -11,6 +12,8 @@ import (
 	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/common"
 	workflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/util/errors"
+	"github.com/argoproj/argo-workflows/v3/workflow/util"
 )
 
 type resubmitOps struct {
@@ -19,6 +22,7 @@ type resubmitOps struct {
 	namespace     string // --namespace
 	labelSelector string // --selector
 	fieldSelector string // --field-selector
+	parameterFile string // --parameter-file
 }
 
 // hasSelector returns true if the CLI arguments selects multiple workflows
@@ -66,10 +70,19 @@ func NewResubmitCommand() *cobra.Command {
 
   argo resubmit --log my-wf.yaml
 
+# Resubmit a workflow with different parameters:
+
+  argo resubmit my-wf -p message=goodbye
+
+# Resubmit a workflow with the parameters of a file, overriding one of them:
+
+  argo resubmit my-wf -f params.yaml -p message=goodbye
+
 # Resubmit the latest workflow:
 
   argo resubmit @latest
 `,
+		ValidArgsFunction: common.CompleteWorkflows(),
 		Run: func(cmd *cobra.Command, args []string) {
 			if cmd.Flag("priority").Changed {
 				cliSubmitOpts.Priority = &resubmitOpts.priority
@@ -84,6 +97,7 @@ func NewResubmitCommand() *cobra.Command {
 	}
 
 	command.Flags().StringArrayVarP(&cliSubmitOpts.Parameters, "parameter", "p", []string{}, "input parameter to override on the original workflow spec")
+	command.Flags().StringVarP(&resubmitOpts.parameterFile, "parameter-file", "f", "", "pass a file containing input parameters to override on the original workflow spec, --parameter overrides its parameters")
 	command.Flags().Int32Var(&resubmitOpts.priority, "priority", 0, "workflow priority")
 	command.Flags().StringVarP(&cliSubmitOpts.Output, "output", "o", "", "Output format. One of: name|json|yaml|wide")
 	command.Flags().BoolVarP(&cliSubmitOpts.Wait, "wait", "w", false, "wait for the workflow to complete, only works when a single workflow is resubmitted")
@@ -121,6 +135,11 @@ func resubmitWorkflows(ctx context.Context, serviceClient workflowpkg.WorkflowSe
 		})
 	}
 
+	parameters, err := getResubmitParameters(resubmitOpts.parameterFile, cliSubmitOpts.Parameters)
+	if err != nil {
+		return err
+	}
+
 	var lastResubmitted *wfv1.Workflow
 	resubmittedNames := make(map[string]bool)
 
@@ -135,7 +154,7 @@ func resubmitWorkflows(ctx context.Context, serviceClient workflowpkg.WorkflowSe
 			Namespace:  wf.Namespace,
 			Name:       wf.Name,
 			Memoized:   resubmitOpts.memoized,
-			Parameters: cliSubmitOpts.Parameters,
+			Parameters: parameters,
 		})
 		if err != nil {
 			return err
@@ -148,3 +167,26 @@ func resubmitWorkflows(ctx context.Context, serviceClient workflowpkg.WorkflowSe
 	}
 	return nil
 }
+
+// getResubmitParameters returns the parameters of the file, if there is one, and the parameters, which override those
+// of the file with the same name
+func getResubmitParameters(parameterFile string, parameters []string) ([]string, error) {
+	if parameterFile == "" {
+		return parameters, nil
+	}
+	var opts wfv1.SubmitOpts
+	if err := util.ReadParametersFile(parameterFile, &opts); err != nil {
+		return nil, fmt.Errorf("failed to read the parameter file %s: %w", parameterFile, err)
+	}
+	overridden := make(map[string]bool)
+	for _, p := range parameters {
+		overridden[strings.SplitN(p, "=", 2)[0]] = true
+	}
+	result := append([]string{}, parameters...)
+	for _, p := range opts.Parameters {
+		if !overridden[strings.SplitN(p, "=", 2)[0]] {
+			result = append(result, p)
+		}
+	}
+	return result, nil
+}
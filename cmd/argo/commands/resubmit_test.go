@@ -3,6 +3,8 @@ package commands
 import (
 	"context"
 	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -52,6 +54,39 @@ func Test_resubmitWorkflows(t *testing.T) {
 		assert.NoError(t, err)
 	})
 
+	t.Run("Resubmit workflow with a parameter file", func(t *testing.T) {
+		parameterFile := filepath.Join(t.TempDir(), "params.yaml")
+		assert.NoError(t, os.WriteFile(parameterFile, []byte("message: hello\ncount: 3\n"), 0o600))
+		c := &workflowmocks.WorkflowServiceClient{}
+		resubmitOpts := resubmitOps{
+			namespace:     "argo",
+			parameterFile: parameterFile,
+		}
+		cliSubmitOpts := common.CliSubmitOpts{Parameters: []string{"message=goodbye"}}
+
+		c.On("ResubmitWorkflow", mock.Anything, mock.Anything).Return(&wfv1.Workflow{}, nil)
+
+		err := resubmitWorkflows(context.Background(), c, resubmitOpts, cliSubmitOpts, []string{"foo"})
+		c.AssertCalled(t, "ResubmitWorkflow", mock.Anything, &workflowpkg.WorkflowResubmitRequest{
+			Name:       "foo",
+			Namespace:  "argo",
+			Parameters: []string{"message=goodbye", "count=3"},
+		})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("Resubmit workflow with a missing parameter file", func(t *testing.T) {
+		c := &workflowmocks.WorkflowServiceClient{}
+		resubmitOpts := resubmitOps{
+			namespace:     "argo",
+			parameterFile: filepath.Join(t.TempDir(), "missing.yaml"),
+		}
+		err := resubmitWorkflows(context.Background(), c, resubmitOpts, common.CliSubmitOpts{}, []string{"foo"})
+		assert.Error(t, err)
+		c.AssertNotCalled(t, "ResubmitWorkflow", mock.Anything, mock.Anything)
+	})
+
 	t.Run("Resubmit workflow by selector", func(t *testing.T) {
 		c := &workflowmocks.WorkflowServiceClient{}
 		resubmitOpts := resubmitOps{
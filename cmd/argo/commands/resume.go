@@ -9,11 +9,14 @@ import (
 	"k8s.io/apimachinery/pkg/fields"
 
 	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/client"
+	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/common"
 	workflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 )
 
 type resumeOps struct {
 	nodeFieldSelector string // --node-field-selector
+	othersPhase       string // --others-phase
 }
 
 func NewResumeCommand() *cobra.Command {
@@ -37,12 +40,24 @@ func NewResumeCommand() *cobra.Command {
 # Resume multiple workflows by node field selector:
 		
   argo resume --node-field-selector inputs.paramaters.myparam.value=abc		
+
+# Resume a paused DAG branch:
+
+  argo resume my-wf --node-field-selector displayName=deploy
+
+# Resume only the children of a fan-out that were approved, skipping the rest:
+
+  argo resume my-wf --node-field-selector inputs.parameters.region.value=eu --others-phase Skipped
 `,
+		ValidArgsFunction: common.CompleteWorkflows(wfv1.WorkflowRunning),
 		Run: func(cmd *cobra.Command, args []string) {
 			if len(args) == 0 && resumeArgs.nodeFieldSelector == "" {
 				cmd.HelpFunc()(cmd, args)
 				os.Exit(1)
 			}
+			if resumeArgs.othersPhase != "" && resumeArgs.nodeFieldSelector == "" {
+				log.Fatalf("--others-phase can only be used with --node-field-selector")
+			}
 
 			ctx, apiClient := client.NewAPIClient(cmd.Context())
 			serviceClient := apiClient.NewWorkflowServiceClient()
@@ -58,6 +73,7 @@ func NewResumeCommand() *cobra.Command {
 					Name:              wfName,
 					Namespace:         namespace,
 					NodeFieldSelector: selector.String(),
+					OthersPhase:       resumeArgs.othersPhase,
 				})
 				if err != nil {
 					log.Fatalf("Failed to resume %s: %+v", wfName, err)
@@ -67,5 +83,7 @@ func NewResumeCommand() *cobra.Command {
 		},
 	}
 	command.Flags().StringVar(&resumeArgs.nodeFieldSelector, "node-field-selector", "", "selector of node to resume, eg: --node-field-selector inputs.paramaters.myparam.value=abc")
+	_ = command.RegisterFlagCompletionFunc("node-field-selector", common.CompleteNodeFieldSelector(0))
+	command.Flags().StringVar(&resumeArgs.othersPhase, "others-phase", "", "phase to set the suspended nodes not selected by --node-field-selector to, if they are in the same fan-out as a selected node. One of: Failed|Skipped")
 	return command
 }
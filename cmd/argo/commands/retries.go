@@ -0,0 +1,51 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/client"
+	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/common"
+	workflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
+	"github.com/argoproj/argo-workflows/v3/util/errors"
+	"github.com/argoproj/argo-workflows/v3/util/printer"
+)
+
+func NewRetriesCommand() *cobra.Command {
+	var output string
+	command := &cobra.Command{
+		Use:   "retries WORKFLOW",
+		Short: "summarize the retried nodes of a workflow",
+		Long:  "Summarize each node of a workflow that was retried: its attempts, why they failed, the time lost to failed attempts and backoff, and why it stopped retrying.",
+		Example: `# Summarize the retries of a workflow:
+
+  argo retries my-wf
+
+# Summarize the retries of the latest workflow, listing every failure reason:
+
+  argo retries @latest -o wide
+`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: common.CompleteArgs(common.CompleteWorkflows()),
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx, apiClient := client.NewAPIClient(cmd.Context())
+			serviceClient := apiClient.NewWorkflowServiceClient()
+			wf, err := serviceClient.GetWorkflow(ctx, &workflowpkg.WorkflowGetRequest{
+				Name:      args[0],
+				Namespace: client.Namespace(),
+			})
+			errors.CheckError(err)
+			insights := printer.AnalyzeRetries(wf)
+			if len(insights) == 0 && (output == "" || output == "wide") {
+				fmt.Printf("No nodes of %s were retried\n", wf.Name)
+				return
+			}
+			err = printer.PrintRetryInsights(insights, os.Stdout, printer.PrintOpts{Output: output})
+			errors.CheckError(err)
+		},
+	}
+	command.Flags().StringVarP(&output, "output", "o", "", "Output format. One of: json|yaml|wide")
+	return command
+}
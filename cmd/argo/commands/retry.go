@@ -4,8 +4,9 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"sort"
+	"strings"
 
-	"github.com/argoproj/pkg/errors"
 	"github.com/spf13/cobra"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
@@ -14,10 +15,12 @@ import (
 	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/common"
 	workflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/util/errors"
 )
 
 type retryOps struct {
 	nodeFieldSelector string // --node-field-selector
+	fromNode          string // --from-node
 	restartSuccessful bool   // --restart-successful
 	namespace         string // --namespace
 	labelSelector     string // --selector
@@ -75,7 +78,12 @@ func NewRetryCommand() *cobra.Command {
 
 # Restart node with id 5 on successful workflow, using node-field-selector
   argo retry my-wf --restart-successful --node-field-selector id=5
+
+# Rerun a workflow from the train-model step, keeping the outputs of the steps before it:
+
+  argo retry my-wf --from-node train-model
 `,
+		ValidArgsFunction: common.CompleteWorkflows(wfv1.WorkflowFailed, wfv1.WorkflowError),
 		Run: func(cmd *cobra.Command, args []string) {
 			if len(args) == 0 && !retryOpts.hasSelector() {
 				cmd.HelpFunc()(cmd, args)
@@ -96,6 +104,8 @@ func NewRetryCommand() *cobra.Command {
 	command.Flags().BoolVar(&cliSubmitOpts.Log, "log", false, "log the workflow until it completes")
 	command.Flags().BoolVar(&retryOpts.restartSuccessful, "restart-successful", false, "indicates to restart successful nodes matching the --node-field-selector")
 	command.Flags().StringVar(&retryOpts.nodeFieldSelector, "node-field-selector", "", "selector of nodes to reset, eg: --node-field-selector inputs.paramaters.myparam.value=abc")
+	_ = command.RegisterFlagCompletionFunc("node-field-selector", common.CompleteNodeFieldSelector(0))
+	command.Flags().StringVar(&retryOpts.fromNode, "from-node", "", "rerun the workflow from the node with this display name, full name or ID: the node and the nodes that run after it are reset, even if they succeeded, and the nodes before it are kept")
 	command.Flags().StringVarP(&retryOpts.labelSelector, "selector", "l", "", "Selector (label query) to filter on, not including uninitialized ones, supports '=', '==', and '!='.(e.g. -l key1=value1,key2=value2)")
 	command.Flags().StringVar(&retryOpts.fieldSelector, "field-selector", "", "Selector (field query) to filter on, supports '=', '==', and '!='.(e.g. --field-selector key1=value1,key2=value2). The server only supports a limited number of field queries per type.")
 	return command
@@ -103,6 +113,23 @@ func NewRetryCommand() *cobra.Command {
 
 // retryWorkflows retries workflows by given retryArgs or workflow names
 func retryWorkflows(ctx context.Context, serviceClient workflowpkg.WorkflowServiceClient, retryOpts retryOps, cliSubmitOpts common.CliSubmitOpts, args []string) error {
+	if retryOpts.fromNode != "" {
+		if retryOpts.hasSelector() || len(args) != 1 || retryOpts.nodeFieldSelector != "" {
+			return fmt.Errorf("--from-node can only be used to retry one workflow given by name, without --node-field-selector")
+		}
+		wf, err := serviceClient.GetWorkflow(ctx, &workflowpkg.WorkflowGetRequest{Name: args[0], Namespace: retryOpts.namespace})
+		if err != nil {
+			return err
+		}
+		nodeID, err := getRetryFromNodeID(wf, retryOpts.fromNode)
+		if err != nil {
+			return err
+		}
+		// resetting the node resets the nodes that run after it, its children, as well
+		retryOpts.restartSuccessful = true
+		retryOpts.nodeFieldSelector = "id=" + nodeID
+		args = []string{wf.Name}
+	}
 	selector, err := fields.ParseSelector(retryOpts.nodeFieldSelector)
 	if err != nil {
 		return fmt.Errorf("unable to parse node field selector '%s': %s", retryOpts.nodeFieldSelector, err)
@@ -155,3 +182,28 @@ func retryWorkflows(ctx context.Context, serviceClient workflowpkg.WorkflowServi
 	}
 	return nil
 }
+
+// getRetryFromNodeID returns the ID of the workflow's node with the ID, full name or display name, which must be unique
+func getRetryFromNodeID(wf *wfv1.Workflow, name string) (string, error) {
+	if node, ok := wf.Status.Nodes[name]; ok {
+		return node.ID, nil
+	}
+	var matches []string
+	for _, node := range wf.Status.Nodes {
+		if node.Name == name {
+			return node.ID, nil
+		}
+		if node.DisplayName == name {
+			matches = append(matches, node.Name)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("workflow %s has no node named %q", wf.Name, name)
+	case 1:
+		return wf.Status.Nodes.FindByName(matches[0]).ID, nil
+	default:
+		sort.Strings(matches)
+		return "", fmt.Errorf("%q is the display name of several nodes, use the full name of one of them: %s", name, strings.Join(matches, ", "))
+	}
+}
@@ -146,4 +146,62 @@ func Test_retryWorkflows(t *testing.T) {
 		err := retryWorkflows(context.Background(), c, retryOpts, cliSubmitOpts, []string{"foo"})
 		assert.Errorf(t, err, "mock error")
 	})
+	t.Run("Retry workflow from node", func(t *testing.T) {
+		c := &workflowmocks.WorkflowServiceClient{}
+		retryOpts := retryOps{
+			namespace: "argo",
+			fromNode:  "train-model",
+		}
+		cliSubmitOpts := common.CliSubmitOpts{}
+		wf := &wfv1.Workflow{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-wf", Namespace: "argo"},
+			Status: wfv1.WorkflowStatus{Nodes: wfv1.Nodes{
+				"my-wf":   {ID: "my-wf", Name: "my-wf", DisplayName: "my-wf"},
+				"my-wf-1": {ID: "my-wf-1", Name: "my-wf.prepare", DisplayName: "prepare"},
+				"my-wf-2": {ID: "my-wf-2", Name: "my-wf.train-model", DisplayName: "train-model"},
+			}},
+		}
+		c.On("GetWorkflow", mock.Anything, &workflowpkg.WorkflowGetRequest{Name: "@latest", Namespace: "argo"}).Return(wf, nil)
+		c.On("RetryWorkflow", mock.Anything, mock.Anything).Return(&wfv1.Workflow{}, nil)
+
+		err := retryWorkflows(context.Background(), c, retryOpts, cliSubmitOpts, []string{"@latest"})
+
+		assert.NoError(t, err)
+		c.AssertCalled(t, "RetryWorkflow", mock.Anything, &workflowpkg.WorkflowRetryRequest{
+			Name:              "my-wf",
+			Namespace:         "argo",
+			RestartSuccessful: true,
+			NodeFieldSelector: "id=my-wf-2",
+		})
+	})
+
+	t.Run("Retry workflow from unknown node", func(t *testing.T) {
+		c := &workflowmocks.WorkflowServiceClient{}
+		retryOpts := retryOps{
+			namespace: "argo",
+			fromNode:  "train-model",
+		}
+		c.On("GetWorkflow", mock.Anything, mock.Anything).Return(&wfv1.Workflow{ObjectMeta: metav1.ObjectMeta{Name: "my-wf"}}, nil)
+		err := retryWorkflows(context.Background(), c, retryOpts, common.CliSubmitOpts{}, []string{"my-wf"})
+		assert.EqualError(t, err, `workflow my-wf has no node named "train-model"`)
+		c.AssertNotCalled(t, "RetryWorkflow", mock.Anything, mock.Anything)
+	})
+}
+
+func Test_getRetryFromNodeID(t *testing.T) {
+	wf := &wfv1.Workflow{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-wf"},
+		Status: wfv1.WorkflowStatus{Nodes: wfv1.Nodes{
+			"my-wf-1": {ID: "my-wf-1", Name: "my-wf[0].train(0:a)", DisplayName: "train(0:a)"},
+			"my-wf-2": {ID: "my-wf-2", Name: "my-wf[0].a.train", DisplayName: "train"},
+			"my-wf-3": {ID: "my-wf-3", Name: "my-wf[0].b.train", DisplayName: "train"},
+		}},
+	}
+	for name, id := range map[string]string{"my-wf-1": "my-wf-1", "train(0:a)": "my-wf-1", "my-wf[0].b.train": "my-wf-3"} {
+		nodeID, err := getRetryFromNodeID(wf, name)
+		assert.NoError(t, err)
+		assert.Equal(t, id, nodeID)
+	}
+	_, err := getRetryFromNodeID(wf, "train")
+	assert.EqualError(t, err, `"train" is the display name of several nodes, use the full name of one of them: my-wf[0].a.train, my-wf[0].b.train`)
 }
@@ -10,7 +10,9 @@ import (
 	infopkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/info"
 
 	"github.com/argoproj/argo-workflows/v3"
+	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/admin"
 	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/archive"
+	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/artifacts"
 	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/auth"
 	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/client"
 	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/clustertemplate"
@@ -86,21 +88,44 @@ Use the same configuration as GRPC mode, but also set:
 If your server is behind an ingress with a path (you'll be running "argo server --basehref /...) or "BASE_HREF=/... argo server"):
 
 	ARGO_BASE_HREF=/argo
+
+# Plugins
+
+Commands that are not built in are run by executables on your PATH named "argo-<command>", e.g. "argo cost-report --days 7" runs "argo-cost_report --days 7". Plugins can add commands to groups too, e.g. "argo admin replay" runs "argo-admin-replay". Plugins get the same environment as the CLI, so they can use the same configuration, e.g. ARGO_SERVER and ARGO_TOKEN.
+
+# Multiple Contexts
+
+"argo list" and "argo get" with --all-contexts work across several clusters, showing the context of each workflow. The contexts are each of your kubeconfig contexts, or, if it exists, those of ~/.config/argo/contexts.yaml, each an Argo Server or a kubeconfig context:
+
+	- name: east
+	  argoServer: argo-east.example.com:443
+	  namespace: argo
+	  token: 'Bearer ******' # Optional, the token cached by "argo auth login" otherwise.
+	- name: west
+	  kubeContext: west-admin
 `,
 		Run: func(cmd *cobra.Command, args []string) {
 			cmd.HelpFunc()(cmd, args)
 		},
 	}
 
+	command.AddCommand(admin.NewAdminCommand())
 	command.AddCommand(NewCompletionCommand())
 	command.AddCommand(NewDeleteCommand())
+	command.AddCommand(NewDiffCommand())
+	command.AddCommand(NewFmtCommand())
 	command.AddCommand(NewGetCommand())
 	command.AddCommand(NewLintCommand())
 	command.AddCommand(NewListCommand())
+	command.AddCommand(NewNewCommand())
 	command.AddCommand(NewLogsCommand())
 	command.AddCommand(NewResubmitCommand())
 	command.AddCommand(NewResumeCommand())
 	command.AddCommand(NewRetryCommand())
+	command.AddCommand(NewRetriesCommand())
+	command.AddCommand(NewRunCommand())
+	command.AddCommand(NewOutputsCommand())
+	command.AddCommand(NewVariablesCommand())
 	command.AddCommand(NewServerCommand())
 	command.AddCommand(NewSubmitCommand())
 	command.AddCommand(NewSuspendCommand())
@@ -108,10 +133,13 @@ If your server is behind an ingress with a path (you'll be running "argo server
 	command.AddCommand(NewWaitCommand())
 	command.AddCommand(NewWatchCommand())
 	command.AddCommand(NewCpCommand())
+	command.AddCommand(NewStatusCommand())
 	command.AddCommand(NewStopCommand())
 	command.AddCommand(NewNodeCommand())
 	command.AddCommand(NewTerminateCommand())
+	command.AddCommand(NewTopCommand())
 	command.AddCommand(archive.NewArchiveCommand())
+	command.AddCommand(artifacts.NewArtifactsCommand())
 	command.AddCommand(NewVersionCommand())
 	command.AddCommand(template.NewTemplateCommand())
 	command.AddCommand(cron.NewCronWorkflowCommand())
@@ -0,0 +1,202 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/util/errors"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+	"github.com/argoproj/argo-workflows/v3/workflow/util"
+)
+
+type runOpts struct {
+	local      bool     // --local
+	template   string   // --template
+	parameters []string // --parameter
+	fixtures   string   // --fixtures
+	runtime    string   // --runtime
+}
+
+func NewRunCommand() *cobra.Command {
+	var opts runOpts
+	command := &cobra.Command{
+		Use:   "run FILE --local",
+		Short: "run a template of a workflow locally",
+		Long: `Run a container or script template of a workflow or workflow template locally, in a container run by Docker or Podman, so that template authors can iterate on it without a cluster.
+
+The template's parameters are substituted, with the values of its input parameters given by --parameter, or their values or defaults, and of workflow.parameters by the workflow's arguments. Each input artifact is mounted at its path from the file or directory with its name in the --fixtures directory, or from its raw data. Output parameters and artifacts are not collected.`,
+		Example: `# Run the entrypoint of a workflow:
+
+  argo run --local my-wf.yaml
+
+# Run a template with a parameter, mounting its input artifacts from ./fixtures:
+
+  argo run --local my-wf.yaml --template print-message -p message=hello --fixtures ./fixtures
+
+# Run a template with Podman:
+
+  argo run --local my-wf.yaml --runtime podman
+`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			if !opts.local {
+				log.Fatalf("argo run only supports running templates locally, with --local")
+			}
+			manifests, err := util.ReadManifest(args[0])
+			errors.CheckError(err)
+			wf := &wfv1.Workflow{}
+			errors.CheckError(yaml.Unmarshal(manifests[0], wf))
+			tmpDir, err := os.MkdirTemp("", "argo-run-")
+			errors.CheckError(err)
+			defer func() { _ = os.RemoveAll(tmpDir) }()
+			runtimeArgs, err := localRunArgs(wf, opts, tmpDir)
+			errors.CheckError(err)
+			c := exec.CommandContext(cmd.Context(), opts.runtime, runtimeArgs...)
+			c.Stdin, c.Stdout, c.Stderr = os.Stdin, os.Stdout, os.Stderr
+			log.Debugf("%s %s", opts.runtime, strings.Join(runtimeArgs, " "))
+			if err := c.Run(); err != nil {
+				if exitErr, ok := err.(*exec.ExitError); ok {
+					_ = os.RemoveAll(tmpDir)
+					os.Exit(exitErr.ExitCode())
+				}
+				log.Fatal(err)
+			}
+		},
+	}
+	command.Flags().BoolVar(&opts.local, "local", false, "run the template locally, in a container run by the --runtime")
+	command.Flags().StringVar(&opts.template, "template", "", "the name of the template to run, by default the entrypoint")
+	command.Flags().StringArrayVarP(&opts.parameters, "parameter", "p", []string{}, "input parameter, or workflow parameter, to run the template with, NAME=VALUE")
+	command.Flags().StringVar(&opts.fixtures, "fixtures", "fixtures", "directory of the input artifacts, each the file or directory named after the artifact")
+	command.Flags().StringVar(&opts.runtime, "runtime", "docker", "container runtime to run the template with, docker or podman")
+	return command
+}
+
+// localRunArgs returns the arguments of the container runtime's run command that runs the template of the workflow,
+// writing the script and raw artifacts it mounts to the temporary directory
+func localRunArgs(wf *wfv1.Workflow, opts runOpts, tmpDir string) ([]string, error) {
+	name := opts.template
+	if name == "" {
+		name = wf.Spec.Entrypoint
+	}
+	tmpl := wf.GetTemplateByName(name)
+	if tmpl == nil {
+		return nil, fmt.Errorf("template %q not found", name)
+	}
+	if tmpl.Container == nil && tmpl.Script == nil {
+		return nil, fmt.Errorf("template %q is a %s template, only container and script templates can be run locally", name, tmpl.GetType())
+	}
+	values := make(map[string]string)
+	for _, p := range opts.parameters {
+		k, v, ok := strings.Cut(p, "=")
+		if !ok {
+			return nil, fmt.Errorf("expected parameter of the form: NAME=VALUE. Received: %s", p)
+		}
+		values[k] = v
+	}
+	globalParams := common.Parameters{
+		common.GlobalVarWorkflowName:      wf.Name,
+		common.GlobalVarWorkflowNamespace: wf.Namespace,
+	}
+	if wf.Name == "" {
+		globalParams[common.GlobalVarWorkflowName] = wf.GenerateName + "local"
+	}
+	for _, p := range wf.Spec.Arguments.Parameters {
+		if v, ok := values[p.Name]; ok {
+			globalParams["workflow.parameters."+p.Name] = v
+		} else if p.Value != nil {
+			globalParams["workflow.parameters."+p.Name] = p.Value.String()
+		}
+	}
+	tmpl = tmpl.DeepCopy()
+	for i, p := range tmpl.Inputs.Parameters {
+		if v, ok := values[p.Name]; ok {
+			tmpl.Inputs.Parameters[i].Value = wfv1.AnyStringPtr(v)
+		} else if p.Value == nil && p.Default != nil {
+			tmpl.Inputs.Parameters[i].Value = p.Default
+		} else if p.Value == nil {
+			return nil, fmt.Errorf("input parameter %q has no value, give it with --parameter %s=VALUE", p.Name, p.Name)
+		}
+	}
+	tmpl, err := common.SubstituteParams(tmpl, globalParams, common.Parameters{common.LocalVarPodName: globalParams[common.GlobalVarWorkflowName]})
+	if err != nil {
+		return nil, err
+	}
+
+	args := []string{"run", "--rm", "-i"}
+	for _, a := range tmpl.Inputs.Artifacts {
+		source, err := localArtifactSource(a, opts.fixtures, tmpDir)
+		if err != nil {
+			return nil, err
+		}
+		if source != "" {
+			args = append(args, "-v", source+":"+a.Path+":ro")
+		}
+	}
+	c := tmpl.Container
+	if tmpl.Script != nil {
+		c = &tmpl.Script.Container
+	}
+	command := c.Command
+	if tmpl.Script != nil {
+		source := filepath.Join(tmpDir, "script")
+		if err := os.WriteFile(source, []byte(tmpl.Script.Source), 0o644); err != nil {
+			return nil, err
+		}
+		args = append(args, "-v", source+":"+common.ExecutorScriptSourcePath+":ro")
+		command = append(append([]string{}, c.Command...), common.ExecutorScriptSourcePath)
+	}
+	for _, e := range c.Env {
+		if e.ValueFrom != nil {
+			log.WithField("name", e.Name).Warn("Environment variables from sources other than a value are not set locally")
+			continue
+		}
+		args = append(args, "-e", e.Name+"="+e.Value)
+	}
+	if c.WorkingDir != "" {
+		args = append(args, "-w", c.WorkingDir)
+	}
+	if len(command) > 0 {
+		args = append(args, "--entrypoint", command[0])
+	}
+	args = append(args, c.Image)
+	if len(command) > 1 {
+		args = append(args, command[1:]...)
+	}
+	return append(args, c.Args...), nil
+}
+
+// localArtifactSource returns the absolute path of the fixture of the input artifact, or of its raw data written to the
+// temporary directory, or an empty path if it has neither and is optional
+func localArtifactSource(a wfv1.Artifact, fixtures, tmpDir string) (string, error) {
+	if a.Path == "" {
+		return "", fmt.Errorf("input artifact %q has no path to mount it at", a.Name)
+	}
+	source, err := filepath.Abs(filepath.Join(fixtures, a.Name))
+	if err != nil {
+		return "", err
+	}
+	if _, err := os.Stat(source); err == nil {
+		return source, nil
+	} else if !os.IsNotExist(err) {
+		return "", err
+	}
+	if a.Raw != nil {
+		source = filepath.Join(tmpDir, "artifacts", a.Name)
+		if err := os.MkdirAll(filepath.Dir(source), 0o755); err != nil {
+			return "", err
+		}
+		return source, os.WriteFile(source, []byte(a.Raw.Data), 0o644)
+	}
+	if a.Optional {
+		return "", nil
+	}
+	return "", fmt.Errorf("input artifact %q has no fixture, %s does not exist", a.Name, source)
+}
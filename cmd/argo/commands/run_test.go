@@ -0,0 +1,91 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+var localRunWf = wfv1.MustUnmarshalWorkflow(`
+metadata:
+  generateName: my-wf-
+spec:
+  entrypoint: main
+  arguments:
+    parameters:
+      - name: greeting
+        value: hello
+  templates:
+    - name: main
+      steps:
+        - - name: print
+            template: print
+    - name: print
+      inputs:
+        parameters:
+          - name: message
+          - name: times
+            default: "1"
+        artifacts:
+          - name: data
+            path: /tmp/data
+          - name: config
+            path: /tmp/config
+            raw:
+              data: debug
+          - name: extra
+            path: /tmp/extra
+            optional: true
+      script:
+        image: python:alpine3.6
+        command: [python]
+        args: ["{{inputs.parameters.times}}"]
+        env:
+          - name: GREETING
+            value: "{{workflow.parameters.greeting}}"
+        source: |
+          print("{{workflow.name}}: {{inputs.parameters.message}}")
+`)
+
+func Test_localRunArgs(t *testing.T) {
+	fixtures := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(fixtures, "data"), []byte("data"), 0o644))
+	tmpDir := t.TempDir()
+	t.Run("Script", func(t *testing.T) {
+		args, err := localRunArgs(localRunWf, runOpts{template: "print", parameters: []string{"message=hi", "greeting=hey"}, fixtures: fixtures}, tmpDir)
+		if assert.NoError(t, err) {
+			assert.Equal(t, []string{
+				"run", "--rm", "-i",
+				"-v", filepath.Join(fixtures, "data") + ":/tmp/data:ro",
+				"-v", filepath.Join(tmpDir, "artifacts", "config") + ":/tmp/config:ro",
+				"-v", filepath.Join(tmpDir, "script") + ":/argo/staging/script:ro",
+				"-e", "GREETING=hey",
+				"--entrypoint", "python",
+				"python:alpine3.6", "/argo/staging/script", "1",
+			}, args)
+			script, err := os.ReadFile(filepath.Join(tmpDir, "script"))
+			require.NoError(t, err)
+			assert.Equal(t, "print(\"my-wf-local: hi\")\n", string(script))
+			config, err := os.ReadFile(filepath.Join(tmpDir, "artifacts", "config"))
+			require.NoError(t, err)
+			assert.Equal(t, "debug", string(config))
+		}
+	})
+	t.Run("MissingParameter", func(t *testing.T) {
+		_, err := localRunArgs(localRunWf, runOpts{template: "print", fixtures: fixtures}, tmpDir)
+		assert.EqualError(t, err, `input parameter "message" has no value, give it with --parameter message=VALUE`)
+	})
+	t.Run("MissingFixture", func(t *testing.T) {
+		_, err := localRunArgs(localRunWf, runOpts{template: "print", parameters: []string{"message=hi"}, fixtures: tmpDir}, tmpDir)
+		assert.ErrorContains(t, err, `input artifact "data" has no fixture`)
+	})
+	t.Run("NotPodTemplate", func(t *testing.T) {
+		_, err := localRunArgs(localRunWf, runOpts{}, tmpDir)
+		assert.EqualError(t, err, `template "main" is a Steps template, only container and script templates can be run locally`)
+	})
+}
@@ -53,6 +53,9 @@ func NewServerCommand() *cobra.Command {
 		eventOperationQueueSize  int
 		eventWorkerCount         int
 		eventAsyncDispatch       bool
+		eventQueue               string
+		eventQueueDir            string
+		eventDiskQueueSize       int
 		frameOptions             string
 		accessControlAllowOrigin string
 		apiRateLimit             uint64
@@ -60,6 +63,8 @@ func NewServerCommand() *cobra.Command {
 		kubeAPIBurst             int
 		allowedLinkProtocol      []string
 		logFormat                string // --log-format
+		multiCluster             bool
+		clusterName              string
 	)
 
 	command := cobra.Command{
@@ -89,6 +94,7 @@ See %s`, help.ArgoServer),
 				Kubernetes:  kubernetes.NewForConfigOrDie(config),
 				Sensor:      sensor.NewForConfigOrDie(config),
 				Workflow:    wfclientset.NewForConfigOrDie(config),
+				RestConfig:  config,
 			}
 			ctx, cancel := context.WithCancel(context.Background())
 			defer cancel()
@@ -167,10 +173,15 @@ See %s`, help.ArgoServer),
 				EventOperationQueueSize:  eventOperationQueueSize,
 				EventWorkerCount:         eventWorkerCount,
 				EventAsyncDispatch:       eventAsyncDispatch,
+				EventQueue:               eventQueue,
+				EventQueueDir:            eventQueueDir,
+				EventDiskQueueSize:       eventDiskQueueSize,
 				XFrameOptions:            frameOptions,
 				AccessControlAllowOrigin: accessControlAllowOrigin,
 				APIRateLimit:             apiRateLimit,
 				AllowedLinkProtocol:      allowedLinkProtocol,
+				MultiCluster:             multiCluster,
+				ClusterName:              clusterName,
 			}
 			browserOpenFunc := func(url string) {}
 			if enableOpenBrowser {
@@ -229,6 +240,9 @@ See %s`, help.ArgoServer),
 	command.Flags().IntVar(&eventOperationQueueSize, "event-operation-queue-size", 16, "how many events operations that can be queued at once")
 	command.Flags().IntVar(&eventWorkerCount, "event-worker-count", 4, "how many event workers to run")
 	command.Flags().BoolVar(&eventAsyncDispatch, "event-async-dispatch", false, "dispatch event async")
+	command.Flags().StringVar(&eventQueue, "event-queue", "memory", "Where async events are queued. One of: memory|disk|redis. Events queued on disk are replayed when the server restarts, events queued in redis are shared by all replicas of the server, and both imply --event-async-dispatch.")
+	command.Flags().StringVar(&eventQueueDir, "event-queue-dir", "/var/lib/argo-server/events", "The directory of the disk event queue, which should be a persistent volume only readable by the server")
+	command.Flags().IntVar(&eventDiskQueueSize, "event-disk-queue-size", 10000, "how many events the disk or redis event queue can hold")
 	command.Flags().StringVar(&frameOptions, "x-frame-options", "DENY", "Set X-Frame-Options header in HTTP responses.")
 	command.Flags().StringVar(&accessControlAllowOrigin, "access-control-allow-origin", "", "Set Access-Control-Allow-Origin header in HTTP responses.")
 	command.Flags().Uint64Var(&apiRateLimit, "api-rate-limit", 1000, "Set limit per IP for api ratelimiter")
@@ -236,6 +250,8 @@ See %s`, help.ArgoServer),
 	command.Flags().StringVar(&logFormat, "log-format", "text", "The formatter to use for logs. One of: text|json")
 	command.Flags().Float32Var(&kubeAPIQPS, "kube-api-qps", 20.0, "QPS to use while talking with kube-apiserver.")
 	command.Flags().IntVar(&kubeAPIBurst, "kube-api-burst", 30, "Burst to use while talking with kube-apiserver.")
+	command.Flags().BoolVar(&multiCluster, "multi-cluster", false, "Aggregate workflows from the clusters registered by secrets labelled with workflows.argoproj.io/cluster in the server's namespace.")
+	command.Flags().StringVar(&clusterName, "cluster-name", "local", "Name of the cluster the server runs in, as shown in aggregated responses. Only used with --multi-cluster.")
 
 	viper.AutomaticEnv()
 	viper.SetEnvPrefix("ARGO")
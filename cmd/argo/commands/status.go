@@ -0,0 +1,150 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"text/tabwriter"
+
+	"github.com/argoproj/pkg/humanize"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/client"
+	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/common"
+	workflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/util/errors"
+)
+
+// The statuses of kstatus, https://github.com/kubernetes-sigs/cli-utils/tree/master/pkg/kstatus
+const (
+	kstatusInProgress  = "InProgress"
+	kstatusFailed      = "Failed"
+	kstatusCurrent     = "Current"
+	kstatusTerminating = "Terminating"
+)
+
+// workflowStatus is the status of a workflow printed as JSON or YAML
+type workflowStatus struct {
+	Name       string             `json:"name"`
+	Namespace  string             `json:"namespace"`
+	Phase      wfv1.WorkflowPhase `json:"phase"`
+	Message    string             `json:"message,omitempty"`
+	Conditions wfv1.Conditions    `json:"conditions,omitempty"`
+}
+
+// kstatusResult is the status of a workflow as computed by kstatus, which GitOps tools use for health checks
+type kstatusResult struct {
+	Status  string `json:"status"`
+	Message string `json:"message"`
+}
+
+func NewStatusCommand() *cobra.Command {
+	var output string
+	command := &cobra.Command{
+		Use:   "status WORKFLOW",
+		Short: "print the conditions of a workflow",
+		Example: `# Print the conditions of a workflow:
+
+  argo status my-wf
+
+# Print the status of a workflow as kstatus computes it, e.g. for a GitOps health check:
+
+  argo status my-wf -o kstatus
+`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: common.CompleteArgs(common.CompleteWorkflows()),
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx, apiClient := client.NewAPIClient(cmd.Context())
+			serviceClient := apiClient.NewWorkflowServiceClient()
+			wf, err := serviceClient.GetWorkflow(ctx, &workflowpkg.WorkflowGetRequest{
+				Name:      args[0],
+				Namespace: client.Namespace(),
+			})
+			errors.CheckError(err)
+			errors.CheckError(printWorkflowStatus(wf, output))
+		},
+	}
+	command.Flags().StringVarP(&output, "output", "o", "", "Output format. One of: json|yaml|kstatus")
+	return command
+}
+
+func printWorkflowStatus(wf *wfv1.Workflow, output string) error {
+	status := workflowStatus{
+		Name:       wf.Name,
+		Namespace:  wf.Namespace,
+		Phase:      wf.Status.Phase,
+		Message:    wf.Status.Message,
+		Conditions: wf.Status.Conditions,
+	}
+	switch output {
+	case "json":
+		data, err := json.MarshalIndent(status, "", "    ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(data))
+	case "yaml":
+		data, err := yaml.Marshal(status)
+		if err != nil {
+			return err
+		}
+		fmt.Print(string(data))
+	case "kstatus":
+		data, err := json.MarshalIndent(getKStatus(wf), "", "    ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(data))
+	case "":
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		_, _ = fmt.Fprintf(w, "Name:\t%s\n", wf.Name)
+		_, _ = fmt.Fprintf(w, "Namespace:\t%s\n", wf.Namespace)
+		_, _ = fmt.Fprintf(w, "Phase:\t%s\n", wf.Status.Phase)
+		if wf.Status.Message != "" {
+			_, _ = fmt.Fprintf(w, "Message:\t%s\n", wf.Status.Message)
+		}
+		_, _ = fmt.Fprintf(w, "Status:\t%s\n", getKStatus(wf).Status)
+		_, _ = fmt.Fprintln(w)
+		_, _ = fmt.Fprintln(w, "TYPE\tSTATUS\tREASON\tLAST TRANSITION\tMESSAGE")
+		for _, c := range wf.Status.Conditions {
+			transitioned := "-"
+			if c.LastTransitionTime != nil {
+				transitioned = humanize.Timestamp(c.LastTransitionTime.Time)
+			}
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", c.Type, c.Status, c.Reason, transitioned, c.Message)
+		}
+		return w.Flush()
+	default:
+		log.Fatalf("Unknown output format: %s", output)
+	}
+	return nil
+}
+
+// getKStatus returns the status of the workflow in the terms of kstatus, so that a completed workflow is healthy if it
+// succeeded
+func getKStatus(wf *wfv1.Workflow) kstatusResult {
+	if wf.DeletionTimestamp != nil {
+		return kstatusResult{Status: kstatusTerminating, Message: "Workflow is being deleted"}
+	}
+	message := wf.Status.Message
+	switch wf.Status.Phase {
+	case wfv1.WorkflowSucceeded:
+		return kstatusResult{Status: kstatusCurrent, Message: "Workflow succeeded"}
+	case wfv1.WorkflowFailed, wfv1.WorkflowError:
+		if message == "" {
+			message = fmt.Sprintf("Workflow %s", wf.Status.Phase)
+		}
+		return kstatusResult{Status: kstatusFailed, Message: message}
+	}
+	if c := wf.Status.Conditions.Get(wfv1.ConditionTypeSubmissionPending); c != nil && c.Message != "" && c.Status == metav1.ConditionTrue {
+		message = c.Message
+	}
+	if message == "" {
+		message = "Workflow is running"
+	}
+	return kstatusResult{Status: kstatusInProgress, Message: message}
+}
@@ -0,0 +1,30 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+func Test_getKStatus(t *testing.T) {
+	wf := func(phase wfv1.WorkflowPhase, message string, conditions ...wfv1.Condition) *wfv1.Workflow {
+		return &wfv1.Workflow{Status: wfv1.WorkflowStatus{Phase: phase, Message: message, Conditions: conditions}}
+	}
+	assert.Equal(t, kstatusResult{kstatusInProgress, "Workflow is running"}, getKStatus(wf(wfv1.WorkflowUnknown, "")))
+	assert.Equal(t, kstatusResult{kstatusInProgress, "Workflow is running"}, getKStatus(wf(wfv1.WorkflowRunning, "")))
+	assert.Equal(t, kstatusResult{kstatusInProgress, "too many workflows"}, getKStatus(wf(wfv1.WorkflowPending, "", wfv1.Condition{
+		Type:    wfv1.ConditionTypeSubmissionPending,
+		Status:  metav1.ConditionTrue,
+		Message: "too many workflows",
+	})))
+	assert.Equal(t, kstatusResult{kstatusCurrent, "Workflow succeeded"}, getKStatus(wf(wfv1.WorkflowSucceeded, "")))
+	assert.Equal(t, kstatusResult{kstatusFailed, "child 'main' failed"}, getKStatus(wf(wfv1.WorkflowFailed, "child 'main' failed")))
+	assert.Equal(t, kstatusResult{kstatusFailed, "Workflow Error"}, getKStatus(wf(wfv1.WorkflowError, "")))
+
+	deleted := wf(wfv1.WorkflowRunning, "")
+	deleted.DeletionTimestamp = &metav1.Time{}
+	assert.Equal(t, kstatusTerminating, getKStatus(deleted).Status)
+}
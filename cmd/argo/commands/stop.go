@@ -1,18 +1,20 @@
 package commands
 
 import (
+	"bufio"
 	"context"
 	"fmt"
 	"os"
 
-	"github.com/argoproj/pkg/errors"
 	"github.com/spf13/cobra"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 
 	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/client"
+	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/common"
 	workflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/util/errors"
 )
 
 type stopOps struct {
@@ -22,6 +24,9 @@ type stopOps struct {
 	labelSelector     string // --selector
 	fieldSelector     string // --field-selector
 	dryRun            bool   // --dry-run
+	yes               bool   // --yes
+	// confirm asks whether to stop the workflows the selectors select, if it is set
+	confirm func(wfs wfv1.Workflows) (bool, error)
 }
 
 // hasSelector returns true if the CLI arguments selects multiple workflows
@@ -54,7 +59,12 @@ func NewStopCommand() *cobra.Command {
 # Stop multiple workflows by field selector
 
   argo stop --field-selector metadata.namespace=argo
+
+# Stop the running workflows of a team, without being asked to confirm
+
+  argo stop -l team=data --field-selector status.phase=Running --yes
 `,
+		ValidArgsFunction: common.CompleteWorkflows(wfv1.WorkflowRunning, wfv1.WorkflowPending),
 		Run: func(cmd *cobra.Command, args []string) {
 			if len(args) == 0 && !stopArgs.hasSelector() {
 				cmd.HelpFunc()(cmd, args)
@@ -63,6 +73,9 @@ func NewStopCommand() *cobra.Command {
 			ctx, apiClient := client.NewAPIClient(cmd.Context())
 			serviceClient := apiClient.NewWorkflowServiceClient()
 			stopArgs.namespace = client.Namespace()
+			if stopArgs.hasSelector() && !stopArgs.yes && !stopArgs.dryRun && common.IsTerminal(os.Stdin) {
+				stopArgs.confirm = confirmWorkflows("Stop")
+			}
 
 			err := stopWorkflows(ctx, serviceClient, stopArgs, args)
 			errors.CheckError(err)
@@ -70,9 +83,11 @@ func NewStopCommand() *cobra.Command {
 	}
 	command.Flags().StringVar(&stopArgs.message, "message", "", "Message to add to previously running nodes")
 	command.Flags().StringVar(&stopArgs.nodeFieldSelector, "node-field-selector", "", "selector of node to stop, eg: --node-field-selector inputs.paramaters.myparam.value=abc")
+	_ = command.RegisterFlagCompletionFunc("node-field-selector", common.CompleteNodeFieldSelector(0))
 	command.Flags().StringVarP(&stopArgs.labelSelector, "selector", "l", "", "Selector (label query) to filter on, not including uninitialized ones, supports '=', '==', and '!='.(e.g. -l key1=value1,key2=value2)")
 	command.Flags().StringVar(&stopArgs.fieldSelector, "field-selector", "", "Selector (field query) to filter on, supports '=', '==', and '!='.(e.g. --field-selector key1=value1,key2=value2). The server only supports a limited number of field queries per type.")
 	command.Flags().BoolVar(&stopArgs.dryRun, "dry-run", false, "If true, only stop the workflows that would be stopped, without stopping them.")
+	command.Flags().BoolVarP(&stopArgs.yes, "yes", "y", false, "Stop the workflows the selectors select without asking to confirm")
 	return command
 }
 
@@ -92,6 +107,15 @@ func stopWorkflows(ctx context.Context, serviceClient workflowpkg.WorkflowServic
 		if err != nil {
 			return err
 		}
+		if stopArgs.confirm != nil && len(wfs) > 0 {
+			ok, err := stopArgs.confirm(wfs)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				return fmt.Errorf("stopping %d workflows was not confirmed", len(wfs))
+			}
+		}
 	}
 
 	for _, n := range args {
@@ -128,3 +152,13 @@ func stopWorkflows(ctx context.Context, serviceClient workflowpkg.WorkflowServic
 	}
 	return nil
 }
+
+// confirmWorkflows returns a function that lists the workflows, and asks whether to do the action to them
+func confirmWorkflows(action string) func(wfs wfv1.Workflows) (bool, error) {
+	return func(wfs wfv1.Workflows) (bool, error) {
+		for _, wf := range wfs {
+			fmt.Printf("%s/%s\n", wf.Namespace, wf.Name)
+		}
+		return common.Confirm(bufio.NewReader(os.Stdin), os.Stdout, fmt.Sprintf("%s %d workflows?", action, len(wfs)))
+	}
+}
@@ -120,4 +120,25 @@ func Test_stopWorkflows(t *testing.T) {
 		err := stopWorkflows(context.Background(), c, stopArgs, []string{"foo"})
 		assert.Errorf(t, err, "mock error")
 	})
+
+	t.Run("Stop workflow by selector not confirmed", func(t *testing.T) {
+		c := &workflowmocks.WorkflowServiceClient{}
+		var confirmed wfv1.Workflows
+		stopArgs := stopOps{
+			namespace:     "argo",
+			labelSelector: "custom-label=true",
+			confirm: func(wfs wfv1.Workflows) (bool, error) {
+				confirmed = wfs
+				return false, nil
+			},
+		}
+		c.On("ListWorkflows", mock.Anything, mock.Anything).Return(&wfv1.WorkflowList{Items: wfv1.Workflows{
+			{ObjectMeta: metav1.ObjectMeta{Name: "foo"}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "bar"}},
+		}}, nil)
+		err := stopWorkflows(context.Background(), c, stopArgs, []string{})
+		assert.EqualError(t, err, "stopping 2 workflows was not confirmed")
+		assert.Len(t, confirmed, 2)
+		c.AssertNotCalled(t, "StopWorkflow", mock.Anything, mock.Anything)
+	})
 }
@@ -1,22 +1,30 @@
 package commands
 
 import (
+	"bufio"
 	"context"
 	"fmt"
 	"os"
 	"strings"
 	"time"
 
-	"github.com/argoproj/pkg/errors"
 	argoJson "github.com/argoproj/pkg/json"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/client"
 	common "github.com/argoproj/argo-workflows/v3/cmd/argo/commands/common"
+	"github.com/argoproj/argo-workflows/v3/pkg/apiclient"
+	clusterworkflowtmplpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/clusterworkflowtemplate"
+	cronworkflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/cronworkflow"
 	workflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
+	workflowtemplatepkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflowtemplate"
+	"github.com/argoproj/argo-workflows/v3/pkg/apis/workflow"
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/util/errors"
 	wfcommon "github.com/argoproj/argo-workflows/v3/workflow/common"
 	"github.com/argoproj/argo-workflows/v3/workflow/util"
 )
@@ -51,7 +59,12 @@ func NewSubmitCommand() *cobra.Command {
 # Submit a single workflow from an existing resource
 
   argo submit --from cronwf/my-cron-wf
+
+# Submit a workflow template, prompting for the parameters that have no value:
+
+  argo submit --from workflowtemplate/my-wftmpl --interactive
 `,
+		ValidArgsFunction: common.CompleteManifestFiles,
 		Run: func(cmd *cobra.Command, args []string) {
 			if cmd.Flag("priority").Changed {
 				cliSubmitOpts.Priority = &priority
@@ -66,23 +79,32 @@ func NewSubmitCommand() *cobra.Command {
 				errors.CheckError(err)
 			}
 
+			if cliSubmitOpts.Interactive {
+				for _, arg := range args {
+					if arg == "-" {
+						log.Fatalf("--interactive cannot be used to submit workflows from stdin")
+					}
+				}
+			}
+
 			ctx, apiClient := client.NewAPIClient(cmd.Context())
-			serviceClient := apiClient.NewWorkflowServiceClient()
 			namespace := client.Namespace()
 			if from != "" {
 				if len(args) != 0 {
 					cmd.HelpFunc()(cmd, args)
 					os.Exit(1)
 				}
-				submitWorkflowFromResource(ctx, serviceClient, namespace, from, &submitOpts, &cliSubmitOpts)
+				submitWorkflowFromResource(ctx, apiClient, namespace, from, &submitOpts, &cliSubmitOpts)
 			} else {
-				submitWorkflowsFromFile(ctx, serviceClient, namespace, args, &submitOpts, &cliSubmitOpts)
+				submitWorkflowsFromFile(ctx, apiClient, namespace, args, &submitOpts, &cliSubmitOpts)
 			}
 		},
 	}
 	util.PopulateSubmitOpts(command, &submitOpts, &parametersFile, true)
 	command.Flags().StringVarP(&cliSubmitOpts.Output, "output", "o", "", "Output format. One of: name|json|yaml|wide")
 	command.Flags().BoolVarP(&cliSubmitOpts.Wait, "wait", "w", false, "wait for the workflow to complete")
+	command.Flags().BoolVar(&cliSubmitOpts.WaitArgs.ExitCodes, "exit-codes", false, "Exit with a code for how the workflows completed, rather than 1 if any did not succeed: 0 if all succeeded, otherwise the highest of 2 if one failed, 3 if one errored and 4 if one was terminated or stopped. Should only be used with --wait.")
+	command.Flags().BoolVar(&cliSubmitOpts.WaitArgs.PrintFailure, "print-failure", false, "Print the message of the first node to fail of each workflow that did not succeed. Should only be used with --wait.")
 	command.Flags().BoolVar(&cliSubmitOpts.Watch, "watch", false, "watch the workflow until it completes")
 	command.Flags().BoolVar(&cliSubmitOpts.Log, "log", false, "log the workflow until it completes")
 	command.Flags().BoolVar(&cliSubmitOpts.Strict, "strict", true, "perform strict workflow validation")
@@ -90,6 +112,7 @@ func NewSubmitCommand() *cobra.Command {
 	command.Flags().StringVar(&from, "from", "", "Submit from an existing `kind/name` E.g., --from=cronwf/hello-world-cwf")
 	command.Flags().StringVar(&cliSubmitOpts.GetArgs.Status, "status", "", "Filter by status (Pending, Running, Succeeded, Skipped, Failed, Error). Should only be used with --watch.")
 	command.Flags().StringVar(&cliSubmitOpts.GetArgs.NodeFieldSelectorString, "node-field-selector", "", "selector of node to display, eg: --node-field-selector phase=abc")
+	command.Flags().BoolVar(&cliSubmitOpts.Interactive, "interactive", false, "Prompt for the value of each parameter of the workflow, or of the template it references, that has no value and is not given with --parameter")
 	command.Flags().StringVar(&cliSubmitOpts.ScheduledTime, "scheduled-time", "", "Override the workflow's scheduledTime parameter (useful for backfilling). The time must be RFC3339")
 
 	// Only complete files with appropriate extension.
@@ -100,7 +123,7 @@ func NewSubmitCommand() *cobra.Command {
 	return command
 }
 
-func submitWorkflowsFromFile(ctx context.Context, serviceClient workflowpkg.WorkflowServiceClient, namespace string, filePaths []string, submitOpts *wfv1.SubmitOpts, cliOpts *common.CliSubmitOpts) {
+func submitWorkflowsFromFile(ctx context.Context, apiClient apiclient.Client, namespace string, filePaths []string, submitOpts *wfv1.SubmitOpts, cliOpts *common.CliSubmitOpts) {
 	fileContents, err := util.ReadManifest(filePaths...)
 	errors.CheckError(err)
 
@@ -110,7 +133,77 @@ func submitWorkflowsFromFile(ctx context.Context, serviceClient workflowpkg.Work
 		workflows = append(workflows, wfs...)
 	}
 
-	submitWorkflows(ctx, serviceClient, namespace, workflows, submitOpts, cliOpts)
+	if cliOpts.Interactive {
+		in := bufio.NewReader(os.Stdin)
+		for i := range workflows {
+			wf := &workflows[i]
+			if len(workflows) > 1 {
+				name := wf.Name
+				if name == "" {
+					name = wf.GenerateName
+				}
+				_, _ = fmt.Fprintf(os.Stderr, "Parameters of %s:\n", name)
+			}
+			values, err := promptWorkflowParameters(ctx, apiClient, in, namespace, wf, submitOpts)
+			errors.CheckError(err)
+			errors.CheckError(util.ApplySubmitOpts(wf, &wfv1.SubmitOpts{Parameters: values}))
+		}
+	}
+
+	submitWorkflows(ctx, apiClient.NewWorkflowServiceClient(), namespace, workflows, submitOpts, cliOpts)
+}
+
+// promptWorkflowParameters prompts for the parameters of the workflow, and of the template it references, that have
+// no value and are not given with --parameter, and returns their values in the form NAME=VALUE
+func promptWorkflowParameters(ctx context.Context, apiClient apiclient.Client, in *bufio.Reader, namespace string, wf *wfv1.Workflow, submitOpts *wfv1.SubmitOpts) ([]string, error) {
+	if wf.Namespace != "" {
+		namespace = wf.Namespace
+	}
+	parameters := wf.Spec.Arguments.Parameters
+	if ref := wf.Spec.WorkflowTemplateRef; ref != nil {
+		arguments, err := getTemplateArguments(ctx, apiClient, namespace, ref)
+		if err != nil {
+			return nil, err
+		}
+		// the workflow's own arguments override the template's
+		parameters = append(append([]wfv1.Parameter{}, parameters...), arguments.Parameters...)
+	}
+	given := map[string]bool{}
+	for _, p := range submitOpts.Parameters {
+		given[strings.SplitN(p, "=", 2)[0]] = true
+	}
+	var missing []wfv1.Parameter
+	for _, p := range parameters {
+		if !given[p.Name] && p.Value == nil && p.Default == nil && p.ValueFrom == nil {
+			missing = append(missing, p)
+		}
+		given[p.Name] = true
+	}
+	return common.PromptParameters(in, os.Stderr, missing)
+}
+
+// getTemplateArguments returns the arguments of the workflow template or cluster workflow template the reference names
+func getTemplateArguments(ctx context.Context, apiClient apiclient.Client, namespace string, ref *wfv1.WorkflowTemplateRef) (*wfv1.Arguments, error) {
+	if ref.ClusterScope {
+		serviceClient, err := apiClient.NewClusterWorkflowTemplateServiceClient()
+		if err != nil {
+			return nil, err
+		}
+		tmpl, err := serviceClient.GetClusterWorkflowTemplate(ctx, &clusterworkflowtmplpkg.ClusterWorkflowTemplateGetRequest{Name: ref.Name})
+		if err != nil {
+			return nil, err
+		}
+		return &tmpl.Spec.Arguments, nil
+	}
+	serviceClient, err := apiClient.NewWorkflowTemplateServiceClient()
+	if err != nil {
+		return nil, err
+	}
+	tmpl, err := serviceClient.GetWorkflowTemplate(ctx, &workflowtemplatepkg.WorkflowTemplateGetRequest{Name: ref.Name, Namespace: namespace})
+	if err != nil {
+		return nil, err
+	}
+	return &tmpl.Spec.Arguments, nil
 }
 
 func validateOptions(workflows []wfv1.Workflow, submitOpts *wfv1.SubmitOpts, cliOpts *common.CliSubmitOpts) {
@@ -154,7 +247,8 @@ func validateOptions(workflows []wfv1.Workflow, submitOpts *wfv1.SubmitOpts, cli
 	}
 }
 
-func submitWorkflowFromResource(ctx context.Context, serviceClient workflowpkg.WorkflowServiceClient, namespace string, resourceIdentifier string, submitOpts *wfv1.SubmitOpts, cliOpts *common.CliSubmitOpts) {
+func submitWorkflowFromResource(ctx context.Context, apiClient apiclient.Client, namespace string, resourceIdentifier string, submitOpts *wfv1.SubmitOpts, cliOpts *common.CliSubmitOpts) {
+	serviceClient := apiClient.NewWorkflowServiceClient()
 	parts := strings.SplitN(resourceIdentifier, "/", 2)
 	if len(parts) != 2 {
 		log.Fatalf("resource identifier '%s' is malformed. Should be `kind/name`, e.g. cronwf/hello-world-cwf", resourceIdentifier)
@@ -173,15 +267,25 @@ func submitWorkflowFromResource(ctx context.Context, serviceClient workflowpkg.W
 		submitOpts.Annotations = fmt.Sprintf("%s=%s", wfcommon.AnnotationKeyCronWfScheduledTime, cliOpts.ScheduledTime)
 	}
 
+	if cliOpts.Interactive {
+		wf, err := getResourceWorkflow(ctx, apiClient, namespace, kind, name)
+		errors.CheckError(err)
+		values, err := promptWorkflowParameters(ctx, apiClient, bufio.NewReader(os.Stdin), namespace, wf, submitOpts)
+		errors.CheckError(err)
+		submitOpts.Parameters = append(submitOpts.Parameters, values...)
+	}
+
+	var header metadata.MD
 	created, err := serviceClient.SubmitWorkflow(ctx, &workflowpkg.WorkflowSubmitRequest{
 		Namespace:     namespace,
 		ResourceKind:  kind,
 		ResourceName:  name,
 		SubmitOptions: submitOpts,
-	})
+	}, grpc.Header(&header))
 	if err != nil {
 		log.Fatalf("Failed to submit workflow: %v", err)
 	}
+	printWarnings(header)
 
 	printWorkflow(created, common.GetFlags{Output: cliOpts.Output})
 
@@ -212,15 +316,17 @@ func submitWorkflows(ctx context.Context, serviceClient workflowpkg.WorkflowServ
 		if submitOpts.DryRun {
 			options.DryRun = []string{"All"}
 		}
+		var header metadata.MD
 		created, err := serviceClient.CreateWorkflow(ctx, &workflowpkg.WorkflowCreateRequest{
 			Namespace:     wf.Namespace,
 			Workflow:      &wf,
 			ServerDryRun:  submitOpts.ServerDryRun,
 			CreateOptions: options,
-		})
+		}, grpc.Header(&header))
 		if err != nil {
 			log.Fatalf("Failed to submit workflow: %v", err)
 		}
+		printWarnings(header)
 
 		printWorkflow(created, common.GetFlags{Output: cliOpts.Output, Status: cliOpts.GetArgs.Status})
 		workflowNames = append(workflowNames, created.Name)
@@ -229,6 +335,35 @@ func submitWorkflows(ctx context.Context, serviceClient workflowpkg.WorkflowServ
 	common.WaitWatchOrLog(ctx, serviceClient, namespace, workflowNames, *cliOpts)
 }
 
+// getResourceWorkflow returns the workflow that submitting the resource of the kind creates, as the Argo Server does
+func getResourceWorkflow(ctx context.Context, apiClient apiclient.Client, namespace, kind, name string) (*wfv1.Workflow, error) {
+	switch kind {
+	case workflow.CronWorkflowKind, workflow.CronWorkflowSingular, workflow.CronWorkflowPlural, workflow.CronWorkflowShortName:
+		serviceClient, err := apiClient.NewCronWorkflowServiceClient()
+		if err != nil {
+			return nil, err
+		}
+		cronWf, err := serviceClient.GetCronWorkflow(ctx, &cronworkflowpkg.GetCronWorkflowRequest{Name: name, Namespace: namespace})
+		if err != nil {
+			return nil, err
+		}
+		return wfcommon.ConvertCronWorkflowToWorkflow(cronWf), nil
+	case workflow.WorkflowTemplateKind, workflow.WorkflowTemplateSingular, workflow.WorkflowTemplatePlural, workflow.WorkflowTemplateShortName:
+		return wfcommon.NewWorkflowFromWorkflowTemplate(name, false), nil
+	case workflow.ClusterWorkflowTemplateKind, workflow.ClusterWorkflowTemplateSingular, workflow.ClusterWorkflowTemplatePlural, workflow.ClusterWorkflowTemplateShortName:
+		return wfcommon.NewWorkflowFromWorkflowTemplate(name, true), nil
+	default:
+		return nil, fmt.Errorf("resource kind '%s' is not supported for submitting", kind)
+	}
+}
+
+// printWarnings prints the warnings of the Argo Server about a submitted workflow
+func printWarnings(header metadata.MD) {
+	for _, warning := range header.Get(workflowpkg.WarningHeader) {
+		log.Warn(warning)
+	}
+}
+
 // unmarshalWorkflows unmarshals the input bytes as either json or yaml
 func unmarshalWorkflows(wfBytes []byte, strict bool) []wfv1.Workflow {
 	var wf wfv1.Workflow
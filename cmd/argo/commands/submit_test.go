@@ -20,7 +20,7 @@ func Test_submitWorkflows(t *testing.T) {
 		priority := int32(70)
 		workflow := wfv1.Workflow{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "argo"}, Spec: wfv1.WorkflowSpec{Priority: &priority}}
 
-		c.On("CreateWorkflow", mock.Anything, mock.Anything).Return(&wfv1.Workflow{}, nil)
+		c.On("CreateWorkflow", mock.Anything, mock.Anything, mock.Anything).Return(&wfv1.Workflow{}, nil)
 		submitWorkflows(context.TODO(), c, "argo", []wfv1.Workflow{workflow}, &wfv1.SubmitOpts{}, &common.CliSubmitOpts{})
 
 		arg := c.Mock.Calls[0].Arguments[1]
@@ -40,7 +40,7 @@ func Test_submitWorkflows(t *testing.T) {
 		priorityCLI := int32(100)
 		cliSubmitOpts := common.CliSubmitOpts{Priority: &priorityCLI}
 
-		c.On("CreateWorkflow", mock.Anything, mock.Anything).Return(&wfv1.Workflow{}, nil)
+		c.On("CreateWorkflow", mock.Anything, mock.Anything, mock.Anything).Return(&wfv1.Workflow{}, nil)
 		submitWorkflows(context.TODO(), c, "argo", []wfv1.Workflow{workflow}, &wfv1.SubmitOpts{}, &cliSubmitOpts)
 
 		arg := c.Mock.Calls[0].Arguments[1]
@@ -5,12 +5,21 @@ import (
 	"log"
 
 	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/fields"
 
 	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/client"
+	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/common"
 	workflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 )
 
+type suspendOps struct {
+	nodeFieldSelector string // --node-field-selector
+}
+
 func NewSuspendCommand() *cobra.Command {
+	var suspendArgs suspendOps
+
 	command := &cobra.Command{
 		Use:   "suspend WORKFLOW1 WORKFLOW2...",
 		Short: "suspend zero or more workflows (opposite of resume)",
@@ -20,22 +29,40 @@ func NewSuspendCommand() *cobra.Command {
 
 # Suspend the latest workflow:
   argo suspend @latest
+
+# Pause the DAG branch that starts at a task, running tasks finish but no new tasks of the branch start:
+
+  argo suspend my-wf --node-field-selector displayName=deploy
 `,
+		ValidArgsFunction: common.CompleteWorkflows(wfv1.WorkflowRunning, wfv1.WorkflowPending),
 		Run: func(cmd *cobra.Command, args []string) {
 			ctx, apiClient := client.NewAPIClient(cmd.Context())
 			serviceClient := apiClient.NewWorkflowServiceClient()
 			namespace := client.Namespace()
+
+			selector, err := fields.ParseSelector(suspendArgs.nodeFieldSelector)
+			if err != nil {
+				log.Fatalf("Unable to parse node field selector '%s': %s", suspendArgs.nodeFieldSelector, err)
+			}
+
 			for _, wfName := range args {
 				_, err := serviceClient.SuspendWorkflow(ctx, &workflowpkg.WorkflowSuspendRequest{
-					Name:      wfName,
-					Namespace: namespace,
+					Name:              wfName,
+					Namespace:         namespace,
+					NodeFieldSelector: selector.String(),
 				})
 				if err != nil {
 					log.Fatalf("Failed to suspended %s: %+v", wfName, err)
 				}
-				fmt.Printf("workflow %s suspended\n", wfName)
+				if suspendArgs.nodeFieldSelector != "" {
+					fmt.Printf("workflow %s branches paused\n", wfName)
+				} else {
+					fmt.Printf("workflow %s suspended\n", wfName)
+				}
 			}
 		},
 	}
+	command.Flags().StringVar(&suspendArgs.nodeFieldSelector, "node-field-selector", "", "selector of the DAG or DAG task nodes whose branches to pause instead of suspending the workflow, eg: --node-field-selector displayName=deploy")
+	_ = command.RegisterFlagCompletionFunc("node-field-selector", common.CompleteNodeFieldSelector(0))
 	return command
 }
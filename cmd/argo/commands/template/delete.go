@@ -5,11 +5,12 @@ import (
 	"fmt"
 	"log"
 
-	"github.com/argoproj/pkg/errors"
 	"github.com/spf13/cobra"
 
 	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/client"
+	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/common"
 	workflowtemplatepkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflowtemplate"
+	"github.com/argoproj/argo-workflows/v3/util/errors"
 )
 
 // NewDeleteCommand returns a new instance of an `argo delete` command
@@ -17,8 +18,9 @@ func NewDeleteCommand() *cobra.Command {
 	var all bool
 
 	command := &cobra.Command{
-		Use:   "delete WORKFLOW_TEMPLATE",
-		Short: "delete a workflow template",
+		Use:               "delete WORKFLOW_TEMPLATE",
+		Short:             "delete a workflow template",
+		ValidArgsFunction: common.CompleteWorkflowTemplates,
 		Run: func(cmd *cobra.Command, args []string) {
 			apiServerDeleteWorkflowTemplates(cmd.Context(), all, args)
 		},
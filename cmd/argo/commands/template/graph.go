@@ -0,0 +1,57 @@
+package template
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/spf13/cobra"
+
+	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/client"
+	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/common"
+	workflowtemplatepkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflowtemplate"
+)
+
+func NewGraphCommand() *cobra.Command {
+	var output string
+
+	command := &cobra.Command{
+		Use:   "graph WORKFLOW_TEMPLATE",
+		Short: "display the static structure of a workflow template",
+		Long:  "Display the templates a workflow template's entrypoint and exit handler call, and the templates those call in turn, without submitting anything. Loops and conditions are shown on the steps and tasks that have them rather than expanded, and templates of other workflow templates are not expanded.",
+		Example: `# Display the structure of a workflow template as a tree:
+
+  argo template graph my-wftmpl
+
+# Render the structure of a workflow template as an image with Graphviz:
+
+  argo template graph my-wftmpl -o dot | dot -Tsvg > my-wftmpl.svg
+`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: common.CompleteArgs(common.CompleteWorkflowTemplates),
+		Run: func(cmd *cobra.Command, args []string) {
+			if output != "tree" && output != "dot" {
+				log.Fatalf("Unknown output format: %s", output)
+			}
+			ctx, apiClient := client.NewAPIClient(cmd.Context())
+			serviceClient, err := apiClient.NewWorkflowTemplateServiceClient()
+			if err != nil {
+				log.Fatal(err)
+			}
+			wftmpl, err := serviceClient.GetWorkflowTemplate(ctx, &workflowtemplatepkg.WorkflowTemplateGetRequest{
+				Name:      args[0],
+				Namespace: client.Namespace(),
+			})
+			if err != nil {
+				log.Fatal(err)
+			}
+			if output == "dot" {
+				fmt.Print(common.PrintTemplateDot(wftmpl.Name, &wftmpl.Spec))
+			} else {
+				fmt.Print(common.PrintTemplateTree(&wftmpl.Spec))
+			}
+		},
+	}
+
+	command.Flags().StringVarP(&output, "output", "o", "tree", "Output format. One of: tree|dot")
+	return command
+}
@@ -0,0 +1,36 @@
+package template
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/client"
+	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/common"
+	workflowtemplatepkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflowtemplate"
+	"github.com/argoproj/argo-workflows/v3/util/errors"
+)
+
+// NewPromoteCommand returns a new instance of an `argo template promote` command
+func NewPromoteCommand() *cobra.Command {
+	command := &cobra.Command{
+		Use:               "promote WORKFLOW_TEMPLATE...",
+		Short:             "promote the canary of zero or more workflow templates, replacing their spec with the canary's",
+		ValidArgsFunction: common.CompleteArgs(common.CompleteWorkflowTemplates),
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx, apiClient := client.NewAPIClient(cmd.Context())
+			serviceClient, err := apiClient.NewWorkflowTemplateServiceClient()
+			errors.CheckError(err)
+			namespace := client.Namespace()
+			for _, name := range args {
+				_, err := serviceClient.PromoteWorkflowTemplateCanary(ctx, &workflowtemplatepkg.WorkflowTemplateCanaryRequest{
+					Name:      name,
+					Namespace: namespace,
+				})
+				errors.CheckError(err)
+				fmt.Printf("WorkflowTemplate '%s' canary promoted\n", name)
+			}
+		},
+	}
+	return command
+}
@@ -0,0 +1,166 @@
+package template
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/client"
+	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/common"
+	clusterworkflowtmplpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/clusterworkflowtemplate"
+	workflowtemplatepkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflowtemplate"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	wfcommon "github.com/argoproj/argo-workflows/v3/workflow/common"
+	"github.com/argoproj/argo-workflows/v3/workflow/render"
+	"github.com/argoproj/argo-workflows/v3/workflow/util"
+)
+
+// workflowTemplateGetter gets the workflow templates of a namespace from the Argo Server or the cluster, or from the
+// files of an offline client
+type workflowTemplateGetter struct {
+	ctx       context.Context
+	client    workflowtemplatepkg.WorkflowTemplateServiceClient
+	namespace string
+}
+
+func (g *workflowTemplateGetter) Get(name string) (*wfv1.WorkflowTemplate, error) {
+	return g.client.GetWorkflowTemplate(g.ctx, &workflowtemplatepkg.WorkflowTemplateGetRequest{Name: name, Namespace: g.namespace})
+}
+
+// clusterWorkflowTemplateGetter gets the cluster workflow templates from the Argo Server or the cluster, or from the
+// files of an offline client
+type clusterWorkflowTemplateGetter struct {
+	ctx    context.Context
+	client clusterworkflowtmplpkg.ClusterWorkflowTemplateServiceClient
+}
+
+func (g *clusterWorkflowTemplateGetter) Get(name string) (*wfv1.ClusterWorkflowTemplate, error) {
+	return g.client.GetClusterWorkflowTemplate(g.ctx, &clusterworkflowtmplpkg.ClusterWorkflowTemplateGetRequest{Name: name})
+}
+
+func NewRenderCommand() *cobra.Command {
+	var (
+		output     string
+		offline    bool
+		parameters []string
+	)
+
+	command := &cobra.Command{
+		Use:   "render FILE [TEMPLATE_FILE...]",
+		Short: "display the steps and tasks a workflow would run, with its loops expanded",
+		Long: `Display the steps and tasks the workflow, workflow template, cluster workflow template or cron workflow in FILE would run when submitted, without submitting anything.
+
+Templates referenced by workflowTemplateRef and templateRef are resolved from the Argo Server, or the cluster if no Argo Server is configured. With --offline, they are resolved from the WorkflowTemplates and ClusterWorkflowTemplates in the given files instead, as "argo lint --offline" does.
+
+Loops over withItems, withSequence and JSON lists in withParam are expanded into a step or task for each item, named as the controller would name them. Workflow parameters, input parameters, items and expressions are substituted and evaluated, and steps and tasks whose "when" condition is false are shown as skipped. Values that are only known when the workflow runs, such as the outputs of other steps and tasks, are left as they are, and so are the loops and conditions that depend on them.`,
+		Example: `# Display the steps and tasks of a workflow as a tree, resolving the templates it references from the cluster:
+
+  argo template render my-wf.yaml
+
+# Display them with a different parameter, resolving the templates it references from files:
+
+  argo template render my-wf.yaml ./workflow-templates -p message=goodbye --offline
+
+# Render them as an image with Graphviz:
+
+  argo template render my-wf.yaml -o dot | dot -Tsvg > my-wf.svg
+`,
+		Args:              cobra.MinimumNArgs(1),
+		ValidArgsFunction: common.CompleteManifestFiles,
+		Run: func(cmd *cobra.Command, args []string) {
+			if output != "tree" && output != "dot" && output != "json" {
+				log.Fatalf("Unknown output format: %s", output)
+			}
+			if !offline && len(args) > 1 {
+				log.Fatal("TEMPLATE_FILE can only be given with --offline")
+			}
+			wf, err := readRenderWorkflow(args[0])
+			if err != nil {
+				log.Fatal(err)
+			}
+			if err := util.ApplySubmitOpts(wf, &wfv1.SubmitOpts{Parameters: parameters}); err != nil {
+				log.Fatal(err)
+			}
+			client.Offline = offline
+			client.OfflineFiles = args
+			ctx, apiClient := client.NewAPIClient(cmd.Context())
+			if wf.Namespace == "" {
+				wf.Namespace = client.Namespace()
+			}
+			wftmplClient, err := apiClient.NewWorkflowTemplateServiceClient()
+			if err != nil {
+				log.Fatal(err)
+			}
+			cwftmplClient, err := apiClient.NewClusterWorkflowTemplateServiceClient()
+			if err != nil {
+				log.Fatal(err)
+			}
+			nodes, err := render.Workflow(wf,
+				&workflowTemplateGetter{ctx: ctx, client: wftmplClient, namespace: wf.Namespace},
+				&clusterWorkflowTemplateGetter{ctx: ctx, client: cwftmplClient})
+			if err != nil {
+				log.Fatal(err)
+			}
+			switch output {
+			case "dot":
+				name := wf.Name
+				if name == "" {
+					name = wf.GenerateName
+				}
+				fmt.Print(common.PrintRenderedDot(name, nodes))
+			case "json":
+				data, err := json.MarshalIndent(nodes, "", "  ")
+				if err != nil {
+					log.Fatal(err)
+				}
+				fmt.Println(string(data))
+			default:
+				fmt.Print(common.PrintRenderedTree(nodes))
+			}
+		},
+	}
+
+	command.Flags().StringVarP(&output, "output", "o", "tree", "Output format. One of: tree|dot|json")
+	command.Flags().BoolVar(&offline, "offline", false, "Resolve the templates the workflow references from the given files, rather than the Argo Server or cluster")
+	command.Flags().StringArrayVarP(&parameters, "parameter", "p", []string{}, "Override a workflow parameter, as NAME=VALUE")
+	return command
+}
+
+// readRenderWorkflow returns the workflow of the manifest in the file, or the workflow that submitting the cron
+// workflow, workflow template or cluster workflow template in the file would create. Workflows and cron workflows are
+// preferred, so that the file may also have the templates they reference.
+func readRenderWorkflow(file string) (*wfv1.Workflow, error) {
+	contents, err := util.ReadManifest(file)
+	if err != nil {
+		return nil, err
+	}
+	var wfs, tmpls []*wfv1.Workflow
+	for _, body := range contents {
+		for _, pr := range wfcommon.ParseObjects(body, false) {
+			if pr.Err != nil {
+				return nil, pr.Err
+			}
+			switch v := pr.Object.(type) {
+			case *wfv1.Workflow:
+				wfs = append(wfs, v)
+			case *wfv1.CronWorkflow:
+				wfs = append(wfs, wfcommon.ConvertCronWorkflowToWorkflow(v))
+			case *wfv1.WorkflowTemplate:
+				tmpls = append(tmpls, &wfv1.Workflow{ObjectMeta: metav1.ObjectMeta{GenerateName: v.Name + "-", Namespace: v.Namespace}, Spec: v.Spec})
+			case *wfv1.ClusterWorkflowTemplate:
+				tmpls = append(tmpls, &wfv1.Workflow{ObjectMeta: metav1.ObjectMeta{GenerateName: v.Name + "-"}, Spec: v.Spec})
+			}
+		}
+	}
+	if len(wfs) == 0 {
+		wfs = tmpls
+	}
+	if len(wfs) != 1 {
+		return nil, fmt.Errorf("%s must have one workflow or cron workflow, or else one workflow template or cluster workflow template, found %d", file, len(wfs))
+	}
+	return wfs[0], nil
+}
@@ -14,10 +14,14 @@ func NewTemplateCommand() *cobra.Command {
 	}
 
 	command.AddCommand(NewGetCommand())
+	command.AddCommand(NewGraphCommand())
 	command.AddCommand(NewListCommand())
 	command.AddCommand(NewCreateCommand())
 	command.AddCommand(NewDeleteCommand())
 	command.AddCommand(NewLintCommand())
+	command.AddCommand(NewPromoteCommand())
+	command.AddCommand(NewRenderCommand())
+	command.AddCommand(NewRollbackCommand())
 
 	return command
 }
@@ -1,16 +1,18 @@
 package commands
 
 import (
+	"context"
 	"fmt"
 	"os"
 
-	"github.com/argoproj/pkg/errors"
 	"github.com/spf13/cobra"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/client"
+	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/common"
 	workflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/util/errors"
 )
 
 type terminateOption struct {
@@ -18,6 +20,9 @@ type terminateOption struct {
 	labels    string
 	fields    string
 	dryRun    bool
+	yes       bool
+	// confirm asks whether to terminate the workflows the selectors select, if it is set
+	confirm func(wfs wfv1.Workflows) (bool, error)
 }
 
 func (t *terminateOption) isList() bool {
@@ -62,7 +67,12 @@ func NewTerminateCommand() *cobra.Command {
 # Terminate multiple workflows by field selector
 
   argo terminate --field-selector metadata.namespace=argo
+
+# Terminate the running workflows of a team, without being asked to confirm
+
+  argo terminate -l team=data --field-selector status.phase=Running --yes
 `,
+		ValidArgsFunction: common.CompleteWorkflows(wfv1.WorkflowRunning, wfv1.WorkflowPending),
 		Run: func(cmd *cobra.Command, args []string) {
 			if len(args) == 0 && !t.isList() {
 				cmd.HelpFunc()(cmd, args)
@@ -73,38 +83,62 @@ func NewTerminateCommand() *cobra.Command {
 			serviceClient := apiClient.NewWorkflowServiceClient()
 			t.namespace = client.Namespace()
 
-			var workflows wfv1.Workflows
-
-			if t.isList() {
-				listed, err := listWorkflows(ctx, serviceClient, listFlags{
-					namespace: t.namespace,
-					fields:    t.fields,
-					labels:    t.labels,
-				})
-				errors.CheckError(err)
-				workflows = append(workflows, listed...)
-			} else {
-				workflows = t.convertToWorkflows(args)
+			if t.isList() && !t.yes && !t.dryRun && common.IsTerminal(os.Stdin) {
+				t.confirm = confirmWorkflows("Terminate")
 			}
 
-			for _, w := range workflows {
-				if t.dryRun {
-					fmt.Printf("workflow %s terminated (dry-run)\n", w.Name)
-					continue
-				}
-
-				wf, err := serviceClient.TerminateWorkflow(ctx, &workflowpkg.WorkflowTerminateRequest{
-					Name:      w.Name,
-					Namespace: w.Namespace,
-				})
-				errors.CheckError(err)
-				fmt.Printf("workflow %s terminated\n", wf.Name)
-			}
+			err := terminateWorkflows(ctx, serviceClient, t, args)
+			errors.CheckError(err)
 		},
 	}
 
 	command.Flags().StringVarP(&t.labels, "selector", "l", "", "Selector (label query) to filter on, not including uninitialized ones, supports '=', '==', and '!='.(e.g. -l key1=value1,key2=value2)")
 	command.Flags().StringVar(&t.fields, "field-selector", "", "Selector (field query) to filter on, supports '=', '==', and '!='.(e.g. --field-selector key1=value1,key2=value2). The server only supports a limited number of field queries per type.")
 	command.Flags().BoolVar(&t.dryRun, "dry-run", false, "Do not terminate the workflow, only print what would happen")
+	command.Flags().BoolVarP(&t.yes, "yes", "y", false, "Terminate the workflows the selectors select without asking to confirm")
 	return command
 }
+
+// terminateWorkflows terminates the workflows the selectors select, or else the named workflows
+func terminateWorkflows(ctx context.Context, serviceClient workflowpkg.WorkflowServiceClient, t *terminateOption, args []string) error {
+	var workflows wfv1.Workflows
+	if t.isList() {
+		listed, err := listWorkflows(ctx, serviceClient, listFlags{
+			namespace: t.namespace,
+			fields:    t.fields,
+			labels:    t.labels,
+		})
+		if err != nil {
+			return err
+		}
+		if t.confirm != nil && len(listed) > 0 {
+			ok, err := t.confirm(listed)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				return fmt.Errorf("terminating %d workflows was not confirmed", len(listed))
+			}
+		}
+		workflows = append(workflows, listed...)
+	} else {
+		workflows = t.convertToWorkflows(args)
+	}
+
+	for _, w := range workflows {
+		if t.dryRun {
+			fmt.Printf("workflow %s terminated (dry-run)\n", w.Name)
+			continue
+		}
+
+		wf, err := serviceClient.TerminateWorkflow(ctx, &workflowpkg.WorkflowTerminateRequest{
+			Name:      w.Name,
+			Namespace: w.Namespace,
+		})
+		if err != nil {
+			return err
+		}
+		fmt.Printf("workflow %s terminated\n", wf.Name)
+	}
+	return nil
+}
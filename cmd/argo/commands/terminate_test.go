@@ -0,0 +1,56 @@
+package commands
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	workflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
+	workflowmocks "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow/mocks"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+func Test_terminateWorkflows(t *testing.T) {
+	listed := &wfv1.WorkflowList{Items: wfv1.Workflows{
+		{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "argo"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "bar", Namespace: "argo"}},
+	}}
+	t.Run("ByNames", func(t *testing.T) {
+		c := &workflowmocks.WorkflowServiceClient{}
+		c.On("TerminateWorkflow", mock.Anything, mock.Anything).Return(&wfv1.Workflow{}, nil)
+		err := terminateWorkflows(context.Background(), c, &terminateOption{namespace: "argo"}, []string{"foo", "bar"})
+		assert.NoError(t, err)
+		c.AssertNumberOfCalls(t, "TerminateWorkflow", 2)
+	})
+	t.Run("BySelectorConfirmed", func(t *testing.T) {
+		c := &workflowmocks.WorkflowServiceClient{}
+		c.On("ListWorkflows", mock.Anything, &workflowpkg.WorkflowListRequest{
+			Namespace:   "argo",
+			ListOptions: &metav1.ListOptions{LabelSelector: "team=data,workflows.argoproj.io/phase=Running"},
+			Fields:      defaultFields,
+		}).Return(listed, nil)
+		c.On("TerminateWorkflow", mock.Anything, mock.Anything).Return(&wfv1.Workflow{}, nil)
+		err := terminateWorkflows(context.Background(), c, &terminateOption{
+			namespace: "argo",
+			labels:    "team=data",
+			fields:    "status.phase=Running",
+			confirm:   func(wfs wfv1.Workflows) (bool, error) { return true, nil },
+		}, nil)
+		assert.NoError(t, err)
+		c.AssertNumberOfCalls(t, "TerminateWorkflow", 2)
+	})
+	t.Run("BySelectorNotConfirmed", func(t *testing.T) {
+		c := &workflowmocks.WorkflowServiceClient{}
+		c.On("ListWorkflows", mock.Anything, mock.Anything).Return(listed, nil)
+		err := terminateWorkflows(context.Background(), c, &terminateOption{
+			namespace: "argo",
+			labels:    "team=data",
+			confirm:   func(wfs wfv1.Workflows) (bool, error) { return false, nil },
+		}, nil)
+		assert.EqualError(t, err, "terminating 2 workflows was not confirmed")
+		c.AssertNotCalled(t, "TerminateWorkflow", mock.Anything, mock.Anything)
+	})
+}
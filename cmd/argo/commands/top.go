@@ -0,0 +1,142 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	apiv1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/client"
+	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/common"
+	workflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/util/errors"
+	"github.com/argoproj/argo-workflows/v3/util/printer"
+	wfcommon "github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+// podMetricsList is the list of pod metrics of the metrics API, which is read without its client to not depend on it
+type podMetricsList struct {
+	Items []struct {
+		metav1.ObjectMeta `json:"metadata"`
+		Containers        []struct {
+			Usage apiv1.ResourceList `json:"usage"`
+		} `json:"containers"`
+	} `json:"items"`
+}
+
+type topFlags struct {
+	output   string
+	sortBy   string
+	watch    bool
+	interval time.Duration
+}
+
+func NewTopCommand() *cobra.Command {
+	var flags topFlags
+	command := &cobra.Command{
+		Use:   "top WORKFLOW",
+		Short: "display the live CPU and memory usage of the running nodes of a workflow",
+		Long:  "Display the CPU and memory usage of the pod of each running node of a workflow, as reported by the metrics server, next to the resources its containers request, to help tune the requests of the workflow's templates.",
+		Example: `# Display the usage of the running nodes of a workflow:
+
+  argo top my-wf
+
+# Display the nodes using the most memory first, with their limits:
+
+  argo top my-wf --sort-by memory -o wide
+
+# Refresh the usage of the latest workflow until it completes:
+
+  argo top @latest --watch
+`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: common.CompleteArgs(common.CompleteWorkflows()),
+		Run: func(cmd *cobra.Command, args []string) {
+			errors.CheckError(printer.SortNodeUsages(nil, flags.sortBy))
+			ctx, apiClient := client.NewAPIClient(cmd.Context())
+			serviceClient := apiClient.NewWorkflowServiceClient()
+			restConfig, err := client.GetConfig().ClientConfig()
+			errors.CheckError(err)
+			kubeClient, err := kubernetes.NewForConfig(restConfig)
+			errors.CheckError(err)
+			namespace := client.Namespace()
+			name := args[0]
+			for {
+				wf, err := serviceClient.GetWorkflow(ctx, &workflowpkg.WorkflowGetRequest{Name: name, Namespace: namespace})
+				errors.CheckError(err)
+				// @latest is resolved to the workflow's name, so that every refresh shows the same workflow
+				name = wf.Name
+				usages, err := getNodeUsages(ctx, kubeClient, wf)
+				errors.CheckError(err)
+				errors.CheckError(printer.SortNodeUsages(usages, flags.sortBy))
+				if flags.watch {
+					print("\033[H\033[2J")
+					print("\033[0;0H")
+					fmt.Printf("%s\t%s\n\n", wf.Name, wf.Status.Phase)
+				}
+				if len(usages) == 0 && (flags.output == "" || flags.output == "wide") {
+					fmt.Printf("No nodes of %s are running\n", wf.Name)
+				} else {
+					errors.CheckError(printer.PrintNodeUsages(usages, os.Stdout, printer.PrintOpts{Output: flags.output}))
+				}
+				if !flags.watch || wf.Status.Fulfilled() {
+					return
+				}
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(flags.interval):
+				}
+			}
+		},
+	}
+	command.Flags().StringVarP(&flags.output, "output", "o", "", "Output format. One of: json|yaml|wide")
+	command.Flags().StringVar(&flags.sortBy, "sort-by", "", "Sort the nodes by their usage of cpu or memory, highest first, or by name. One of: cpu|memory|name. Defaults to the order the nodes started.")
+	command.Flags().BoolVarP(&flags.watch, "watch", "w", false, "Refresh the usage until the workflow completes")
+	command.Flags().DurationVar(&flags.interval, "interval", 15*time.Second, "How often to refresh the usage with --watch. The metrics server updates its metrics every 15s by default.")
+	return command
+}
+
+// getNodeUsages returns the usage of the running nodes of the workflow, from the metrics of its pods
+func getNodeUsages(ctx context.Context, kubeClient kubernetes.Interface, wf *wfv1.Workflow) ([]printer.NodeUsage, error) {
+	labelSelector := wfcommon.LabelKeyWorkflow + "=" + wf.Name
+	pods, err := kubeClient.CoreV1().Pods(wf.Namespace).List(ctx, metav1.ListOptions{LabelSelector: labelSelector})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the workflow's pods: %w", err)
+	}
+	data, err := kubeClient.CoreV1().RESTClient().Get().
+		AbsPath("/apis/metrics.k8s.io/v1beta1/namespaces", wf.Namespace, "pods").
+		Param("labelSelector", labelSelector).
+		DoRaw(ctx)
+	if apierr.IsNotFound(err) {
+		return nil, fmt.Errorf("the metrics API is not available, install the metrics server to use argo top: %w", err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the metrics of the workflow's pods: %w", err)
+	}
+	var list podMetricsList
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, fmt.Errorf("failed to parse the metrics of the workflow's pods: %w", err)
+	}
+	usages := make(map[string]apiv1.ResourceList, len(list.Items))
+	for _, item := range list.Items {
+		usage := apiv1.ResourceList{}
+		for _, c := range item.Containers {
+			for name, q := range c.Usage {
+				sum := usage[name]
+				sum.Add(q)
+				usage[name] = sum
+			}
+		}
+		usages[item.Name] = usage
+	}
+	return printer.GetNodeUsages(wf, pods.Items, usages), nil
+}
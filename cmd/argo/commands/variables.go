@@ -0,0 +1,82 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	wf "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/util/errors"
+	fileutil "github.com/argoproj/argo-workflows/v3/util/file"
+	"github.com/argoproj/argo-workflows/v3/util/printer"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+	"github.com/argoproj/argo-workflows/v3/workflow/util"
+)
+
+func NewVariablesCommand() *cobra.Command {
+	var output string
+	command := &cobra.Command{
+		Use:   "variables FILE...",
+		Short: "list the variables referenced by files or directories of manifests",
+		Long:  "List the variables that the workflows, workflow templates and cron workflows in the manifests reference, without resolving them, so that typos can be found before they run. Set `spec.strictVariables` to have validation fail on variables that cannot be resolved.",
+		Example: `# List the variables referenced by the manifests in a directory:
+
+  argo variables ./manifests
+
+# List the variables referenced by a workflow as JSON:
+
+  argo variables my-wf.yaml -o json
+`,
+		Args: cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			variables := make(map[string][]util.ReferencedVariable)
+			for _, file := range args {
+				err := fileutil.WalkManifests(file, func(path string, data []byte) error {
+					for i, pr := range common.ParseObjects(data, false) {
+						if pr.Object == nil {
+							continue // could not parse to kubernetes object
+						}
+						if pr.Err != nil {
+							return fmt.Errorf("%s: %w", path, pr.Err)
+						}
+						var object string
+						var spec *wfv1.WorkflowSpec
+						switch v := pr.Object.(type) {
+						case *wfv1.Workflow:
+							object, spec = wf.WorkflowSingular, &v.Spec
+						case *wfv1.WorkflowTemplate:
+							object, spec = wf.WorkflowTemplateSingular, &v.Spec
+						case *wfv1.ClusterWorkflowTemplate:
+							object, spec = wf.ClusterWorkflowTemplateSingular, &v.Spec
+						case *wfv1.CronWorkflow:
+							object, spec = wf.CronWorkflowSingular, &v.Spec.WorkflowSpec
+						default:
+							continue // silently ignore other kinds
+						}
+						switch {
+						case pr.Object.GetName() != "":
+							object += "/" + pr.Object.GetName()
+						case pr.Object.GetGenerateName() != "":
+							object += "/" + pr.Object.GetGenerateName()
+						default:
+							object += fmt.Sprintf("/%s#%d", path, i+1)
+						}
+						referenced, err := util.ReferencedVariables(spec)
+						if err != nil {
+							return fmt.Errorf("%s: %w", object, err)
+						}
+						variables[object] = referenced
+					}
+					return nil
+				})
+				errors.CheckError(err)
+			}
+			err := printer.PrintReferencedVariables(variables, os.Stdout, printer.PrintOpts{Output: output})
+			errors.CheckError(err)
+		},
+	}
+	command.Flags().StringVarP(&output, "output", "o", "", "Output format. One of: json|yaml")
+	return command
+}
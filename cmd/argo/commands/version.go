@@ -3,13 +3,13 @@ package commands
 import (
 	"os"
 
-	"github.com/argoproj/pkg/errors"
 	"github.com/spf13/cobra"
 
 	"github.com/argoproj/argo-workflows/v3"
 	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/client"
 	infopkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/info"
 	cmdutil "github.com/argoproj/argo-workflows/v3/util/cmd"
+	"github.com/argoproj/argo-workflows/v3/util/errors"
 )
 
 // NewVersionCmd returns a new `version` command to be used as a sub-command to root
@@ -5,10 +5,14 @@ import (
 
 	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/client"
 	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/common"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 )
 
 func NewWaitCommand() *cobra.Command {
-	var ignoreNotFound bool
+	var (
+		ignoreNotFound bool
+		waitArgs       common.WaitFlags
+	)
 	command := &cobra.Command{
 		Use:   "wait [WORKFLOW...]",
 		Short: "waits for workflows to complete",
@@ -19,14 +23,21 @@ func NewWaitCommand() *cobra.Command {
 # Wait on the latest workflow:
 
   argo wait @latest
+
+# Wait on a workflow, exiting with a code for how it completed, and printing the message of the first node to fail:
+
+  argo wait my-wf --exit-codes --print-failure
 `,
+		ValidArgsFunction: common.CompleteWorkflows(wfv1.WorkflowRunning, wfv1.WorkflowPending),
 		Run: func(cmd *cobra.Command, args []string) {
 			ctx, apiClient := client.NewAPIClient(cmd.Context())
 			serviceClient := apiClient.NewWorkflowServiceClient()
 			namespace := client.Namespace()
-			common.WaitWorkflows(ctx, serviceClient, namespace, args, ignoreNotFound, false)
+			common.WaitWorkflows(ctx, serviceClient, namespace, args, ignoreNotFound, false, waitArgs)
 		},
 	}
 	command.Flags().BoolVar(&ignoreNotFound, "ignore-not-found", false, "Ignore the wait if the workflow is not found")
+	command.Flags().BoolVar(&waitArgs.ExitCodes, "exit-codes", false, "Exit with a code for how the workflows completed, rather than 1 if any did not succeed: 0 if all succeeded, otherwise the highest of 2 if one failed, 3 if one errored and 4 if one was terminated or stopped")
+	command.Flags().BoolVar(&waitArgs.PrintFailure, "print-failure", false, "Print the message of the first node to fail of each workflow that did not succeed")
 	return command
 }
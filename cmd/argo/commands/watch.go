@@ -4,17 +4,23 @@ import (
 	"os"
 
 	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/labels"
 
 	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/client"
 	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/common"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/util/errors"
 )
 
 func NewWatchCommand() *cobra.Command {
-	var getArgs common.GetFlags
+	var (
+		getArgs       common.GetFlags
+		labelSelector string
+	)
 
 	command := &cobra.Command{
-		Use:   "watch WORKFLOW",
-		Short: "watch a workflow until it completes",
+		Use:   "watch [WORKFLOW | -l SELECTOR]",
+		Short: "watch a workflow, or the workflows matching a label selector, until they complete",
 		Example: `# Watch a workflow:
 
   argo watch my-wf
@@ -22,18 +28,32 @@ func NewWatchCommand() *cobra.Command {
 # Watch the latest workflow:
 
   argo watch @latest
+
+# Watch the status of the workflows with a label until they all complete:
+
+  argo watch -l batch=my-batch
 `,
+		ValidArgsFunction: common.CompleteArgs(common.CompleteWorkflows(wfv1.WorkflowRunning, wfv1.WorkflowPending)),
 		Run: func(cmd *cobra.Command, args []string) {
-			if len(args) != 1 {
+			if (labelSelector == "" && len(args) != 1) || (labelSelector != "" && len(args) != 0) {
 				cmd.HelpFunc()(cmd, args)
 				os.Exit(1)
 			}
+			if labelSelector != "" {
+				_, err := labels.Parse(labelSelector)
+				errors.CheckError(err)
+			}
 			ctx, apiClient := client.NewAPIClient(cmd.Context())
 			serviceClient := apiClient.NewWorkflowServiceClient()
 			namespace := client.Namespace()
-			common.WatchWorkflow(ctx, serviceClient, namespace, args[0], getArgs)
+			if labelSelector != "" {
+				common.WatchWorkflowsBySelector(ctx, serviceClient, namespace, labelSelector)
+			} else {
+				common.WatchWorkflow(ctx, serviceClient, namespace, args[0], getArgs)
+			}
 		},
 	}
+	command.Flags().StringVarP(&labelSelector, "selector", "l", "", "Watch the workflows matching the selector (label query) instead of one workflow, supports '=', '==', and '!='.(e.g. -l key1=value1,key2=value2)")
 	command.Flags().StringVar(&getArgs.Status, "status", "", "Filter by status (Pending, Running, Succeeded, Skipped, Failed, Error)")
 	command.Flags().StringVar(&getArgs.NodeFieldSelectorString, "node-field-selector", "", "selector of node to display, eg: --node-field-selector phase=abc")
 	return command
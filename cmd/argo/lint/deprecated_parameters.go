@@ -0,0 +1,40 @@
+package lint
+
+import (
+	"context"
+	"fmt"
+
+	workflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/validate"
+)
+
+// getDeprecatedParameterUsage returns a warning for each use of the deprecated parameters of the workflow template by
+// the workflows stored in its namespace
+func getDeprecatedParameterUsage(ctx context.Context, client workflowpkg.WorkflowServiceClient, namespace string, tmpl *wfv1.WorkflowTemplate) []string {
+	deprecated := false
+	for _, p := range tmpl.Spec.Arguments.Parameters {
+		deprecated = deprecated || p.Deprecated != nil
+	}
+	if !deprecated {
+		return nil
+	}
+	list, err := client.ListWorkflows(ctx, &workflowpkg.WorkflowListRequest{
+		Namespace: namespace,
+		Fields:    "items.metadata.name,items.spec.workflowTemplateRef,items.spec.arguments",
+	})
+	if err != nil {
+		return []string{fmt.Sprintf("failed to list the workflows that use deprecated parameters: %v", err)}
+	}
+	var warnings []string
+	for _, wf := range list.Items {
+		ref := wf.Spec.WorkflowTemplateRef
+		if ref == nil || ref.ClusterScope || ref.Name != tmpl.Name {
+			continue
+		}
+		for _, w := range validate.GetDeprecatedArguments(tmpl.Spec.Arguments.Parameters, wf.Spec.Arguments.Parameters) {
+			warnings = append(warnings, fmt.Sprintf("workflow %q: %s", wf.Name, w))
+		}
+	}
+	return warnings
+}
@@ -18,7 +18,7 @@ func (f formatterPretty) Format(l *LintResult) string {
 		return ""
 	}
 
-	if len(l.Errs) == 0 {
+	if len(l.Errs) == 0 && len(l.Warnings) == 0 {
 		return ""
 	}
 
@@ -28,6 +28,9 @@ func (f formatterPretty) Format(l *LintResult) string {
 	for _, e := range l.Errs {
 		fmt.Fprintf(sb, "%s%s %s\n", lintIndentation, color.Ize(color.Red, "✖"), e)
 	}
+	for _, w := range l.Warnings {
+		fmt.Fprintf(sb, "%s%s %s\n", lintIndentation, color.Ize(color.Yellow, "⚠"), w)
+	}
 	sb.WriteString("\n")
 
 	return sb.String()
@@ -6,7 +6,6 @@ import (
 	"io"
 	"strings"
 
-	"github.com/argoproj/pkg/errors"
 	log "github.com/sirupsen/logrus"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -17,6 +16,7 @@ import (
 	workflowtemplatepkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflowtemplate"
 	wf "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow"
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/util/errors"
 	fileutil "github.com/argoproj/argo-workflows/v3/util/file"
 	"github.com/argoproj/argo-workflows/v3/workflow/common"
 )
@@ -35,16 +35,25 @@ type LintOptions struct {
 	Formatter        Formatter
 	ServiceClients   ServiceClients
 
+	// UsageClient if not nil is used to report the stored workflows that use the
+	// deprecated parameters of the linted workflow templates.
+	UsageClient workflowpkg.WorkflowServiceClient
+
 	// Printer if not nil the lint result is written to this writer after each
 	// file is linted.
 	Printer io.Writer
+
+	// ValidateSchema if true validates the manifests against the embedded JSON
+	// schema, for offline linting without the server's validation.
+	ValidateSchema bool
 }
 
 // LintResult represents the result of linting objects from a single source
 type LintResult struct {
-	File   string
-	Errs   []error
-	Linted bool
+	File     string
+	Errs     []error
+	Warnings []string
+	Linted   bool
 }
 
 // LintResults represents the result of linting objects from multiple sources
@@ -147,10 +156,12 @@ func lintData(ctx context.Context, src string, data []byte, opts *LintOptions) *
 			namespace = opts.DefaultNamespace
 		}
 		objName := ""
+		kind := ""
 
 		switch v := obj.(type) {
 		case *wfv1.ClusterWorkflowTemplate:
-			objName = getObjectName(wf.ClusterWorkflowTemplateKind, v, i)
+			kind = wf.ClusterWorkflowTemplateKind
+			objName = getObjectName(kind, v, i)
 			if opts.ServiceClients.ClusterWorkflowTemplateClient == nil {
 				log.Debugf("ignoring %s, not in lint options", objName)
 				continue
@@ -163,7 +174,8 @@ func lintData(ctx context.Context, src string, data []byte, opts *LintOptions) *
 				)
 			}
 		case *wfv1.CronWorkflow:
-			objName = getObjectName(wf.CronWorkflowKind, v, i)
+			kind = wf.CronWorkflowKind
+			objName = getObjectName(kind, v, i)
 			if opts.ServiceClients.CronWorkflowsClient == nil {
 				log.Debugf("ignoring %s, not in lint options kinds", objName)
 				continue
@@ -176,7 +188,8 @@ func lintData(ctx context.Context, src string, data []byte, opts *LintOptions) *
 				)
 			}
 		case *wfv1.Workflow:
-			objName = getObjectName(wf.WorkflowKind, v, i)
+			kind = wf.WorkflowKind
+			objName = getObjectName(kind, v, i)
 			if opts.ServiceClients.WorkflowsClient == nil {
 				log.Debugf("ignoring %s, not in lint options kinds", objName)
 				continue
@@ -191,7 +204,8 @@ func lintData(ctx context.Context, src string, data []byte, opts *LintOptions) *
 		case *wfv1.WorkflowEventBinding:
 			// noop
 		case *wfv1.WorkflowTemplate:
-			objName = getObjectName(wf.WorkflowTemplateKind, v, i)
+			kind = wf.WorkflowTemplateKind
+			objName = getObjectName(kind, v, i)
 			if opts.ServiceClients.WorkflowTemplatesClient == nil {
 				log.Debugf("ignoring %s, not in lint options kinds", objName)
 				continue
@@ -203,10 +217,19 @@ func lintData(ctx context.Context, src string, data []byte, opts *LintOptions) *
 					&workflowtemplatepkg.WorkflowTemplateLintRequest{Namespace: namespace, Template: v},
 				)
 			}
+			if err == nil && opts.UsageClient != nil {
+				for _, w := range getDeprecatedParameterUsage(ctx, opts.UsageClient, namespace, v) {
+					res.Warnings = append(res.Warnings, fmt.Sprintf("in %s: %s", objName, w))
+				}
+			}
 		default:
 			continue // silently ignore unknown kinds
 		}
 
+		if err == nil && kind != "" && opts.ValidateSchema {
+			err = validateSchema(kind, pr.Manifest)
+		}
+
 		if err != nil {
 			res.Errs = append(res.Errs, fmt.Errorf("in %s: %w", objName, err))
 		}
@@ -125,6 +125,54 @@ func TestLintMultipleKinds(t *testing.T) {
 	wftServiceSclientMock.AssertNumberOfCalls(t, "LintWorkflowTemplate", 1)
 }
 
+func TestLintSchema(t *testing.T) {
+	file, err := os.CreateTemp("", "*.yaml")
+	assert.NoError(t, err)
+	err = os.WriteFile(file.Name(), append(lintFileData, []byte(`
+---
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: dag-
+spec:
+  entrypoint: main
+  templates:
+  - name: main
+    dag:
+      tasks:
+      - template: whalesay
+  - name: whalesay
+    container:
+      command: [cowsay]
+`)...), 0o600)
+	assert.NoError(t, err)
+	defer os.Remove(file.Name())
+
+	fmtr, err := GetFormatter("simple")
+	assert.NoError(t, err)
+
+	wfServiceClientMock := &workflowmocks.WorkflowServiceClient{}
+	wftServiceSclientMock := &wftemplatemocks.WorkflowTemplateServiceClient{}
+	wfServiceClientMock.On("LintWorkflow", mock.Anything, mock.Anything).Return(nil, nil)
+	wftServiceSclientMock.On("LintWorkflowTemplate", mock.Anything, mock.Anything).Return(nil, nil)
+
+	res, err := Lint(context.Background(), &LintOptions{
+		Files: []string{file.Name()},
+		ServiceClients: ServiceClients{
+			WorkflowsClient:         wfServiceClientMock,
+			WorkflowTemplatesClient: wftServiceSclientMock,
+		},
+		Formatter:      fmtr,
+		ValidateSchema: true,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, res.Success, false)
+	assert.Contains(t, res.msg, fmt.Sprintf(`%s: in "dag-" (Workflow): schema validation failed: spec.templates.0.dag.tasks.0: name is required; spec.templates.1.container: image is required`, file.Name()))
+	assert.NotContains(t, res.msg, `in "steps-" (Workflow)`)
+	assert.NotContains(t, res.msg, `in "foo" (WorkflowTemplate)`)
+}
+
 func TestLintWithOutput(t *testing.T) {
 	file, err := os.CreateTemp("", "*.yaml")
 	assert.NoError(t, err)
@@ -177,6 +225,78 @@ func TestLintWithOutput(t *testing.T) {
 	assert.Equal(t, strings.Join(expected, ""), res.Msg())
 }
 
+var deprecatedParametersFileData = []byte(`
+apiVersion: argoproj.io/v1alpha1
+kind: WorkflowTemplate
+metadata:
+  name: foo
+spec:
+  entrypoint: main
+  arguments:
+    parameters:
+      - name: msg
+        deprecated:
+          replacedBy: message
+      - name: message
+  templates:
+    - name: main
+      container:
+        image: argoproj/argosay:v2
+`)
+
+func TestLintDeprecatedParameterUsage(t *testing.T) {
+	file, err := os.CreateTemp("", "*.yaml")
+	assert.NoError(t, err)
+	err = os.WriteFile(file.Name(), deprecatedParametersFileData, 0o600)
+	assert.NoError(t, err)
+	defer os.Remove(file.Name())
+
+	fmtr, err := GetFormatter("simple")
+	assert.NoError(t, err)
+
+	wfServiceClientMock := &workflowmocks.WorkflowServiceClient{}
+	wftServiceSclientMock := &wftemplatemocks.WorkflowTemplateServiceClient{}
+	wftServiceSclientMock.On("LintWorkflowTemplate", mock.Anything, mock.Anything).Return(nil, nil)
+	wfServiceClientMock.On("ListWorkflows", mock.Anything, mock.Anything).Return(&v1alpha1.WorkflowList{Items: v1alpha1.Workflows{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "old"},
+			Spec: v1alpha1.WorkflowSpec{
+				WorkflowTemplateRef: &v1alpha1.WorkflowTemplateRef{Name: "foo"},
+				Arguments:           v1alpha1.Arguments{Parameters: []v1alpha1.Parameter{{Name: "msg"}}},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "new"},
+			Spec: v1alpha1.WorkflowSpec{
+				WorkflowTemplateRef: &v1alpha1.WorkflowTemplateRef{Name: "foo"},
+				Arguments:           v1alpha1.Arguments{Parameters: []v1alpha1.Parameter{{Name: "message"}}},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "other"},
+			Spec: v1alpha1.WorkflowSpec{
+				WorkflowTemplateRef: &v1alpha1.WorkflowTemplateRef{Name: "bar"},
+				Arguments:           v1alpha1.Arguments{Parameters: []v1alpha1.Parameter{{Name: "msg"}}},
+			},
+		},
+	}}, nil)
+
+	res, err := Lint(context.Background(), &LintOptions{
+		Files: []string{file.Name()},
+		ServiceClients: ServiceClients{
+			WorkflowTemplatesClient: wftServiceSclientMock,
+		},
+		UsageClient: wfServiceClientMock,
+		Formatter:   fmtr,
+	})
+
+	assert.NoError(t, err)
+	assert.True(t, res.Success)
+	assert.Contains(t, res.msg, fmt.Sprintf(`%s: warning: in "foo" (WorkflowTemplate): workflow "old": parameter "msg" is deprecated, use "message" instead`, file.Name()))
+	assert.NotContains(t, res.msg, `workflow "new"`)
+	assert.NotContains(t, res.msg, `workflow "other"`)
+}
+
 func TestLintStdin(t *testing.T) {
 	r, w, err := os.Pipe()
 	assert.NoError(t, err)
@@ -0,0 +1,69 @@
+package lint
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/xeipuuv/gojsonschema"
+	"sigs.k8s.io/yaml"
+
+	"github.com/argoproj/argo-workflows/v3/api/jsonschema"
+)
+
+var (
+	schemasMutex sync.Mutex
+	schemas      = map[string]*gojsonschema.Schema{}
+)
+
+// getSchema returns the compiled schema of the kind from the embedded JSON schema, so that linting does not need a
+// cluster
+func getSchema(kind string) (*gojsonschema.Schema, error) {
+	schemasMutex.Lock()
+	defer schemasMutex.Unlock()
+	if schema, ok := schemas[kind]; ok {
+		return schema, nil
+	}
+	// a loader can only compile one schema without an $id
+	loader := gojsonschema.NewSchemaLoader()
+	if err := loader.AddSchemas(gojsonschema.NewBytesLoader(jsonschema.Schema)); err != nil {
+		return nil, fmt.Errorf("failed to load the embedded schema: %w", err)
+	}
+	ref := fmt.Sprintf(`{"$ref": "%s#/definitions/io.argoproj.workflow.v1alpha1.%s"}`, jsonschema.ID, kind)
+	schema, err := loader.Compile(gojsonschema.NewStringLoader(ref))
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile the schema of %s: %w", kind, err)
+	}
+	schemas[kind] = schema
+	return schema, nil
+}
+
+// validateSchema validates the manifest of an object of the kind against the embedded JSON schema, which finds
+// missing required fields and invalid enum values that parsing the manifest does not
+func validateSchema(kind string, manifest []byte) error {
+	schema, err := getSchema(kind)
+	if err != nil {
+		return err
+	}
+	data, err := yaml.YAMLToJSON(manifest)
+	if err != nil {
+		return err
+	}
+	result, err := schema.Validate(gojsonschema.NewBytesLoader(data))
+	if err != nil {
+		return err
+	}
+	var messages []string
+	for _, e := range result.Errors() {
+		// int-or-string and any-string fields, such as ports and parameter values, are typed as strings in the schema,
+		// and wrongly typed values of other fields already fail to parse
+		if e.Type() == "invalid_type" && e.Details()["expected"] == gojsonschema.TYPE_STRING {
+			continue
+		}
+		messages = append(messages, fmt.Sprintf("%s: %s", e.Field(), e.Description()))
+	}
+	if len(messages) > 0 {
+		return fmt.Errorf("schema validation failed: %s", strings.Join(messages, "; "))
+	}
+	return nil
+}
@@ -1,8 +1,10 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"os/exec"
 
 	// load authentication plugin for obtaining credentials from cloud providers.
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
@@ -11,7 +13,19 @@ import (
 )
 
 func main() {
-	if err := commands.NewCommand().Execute(); err != nil {
+	command := commands.NewCommand()
+	if ok, err := commands.RunPlugin(command, os.Args[1:]); ok {
+		exitErr := &exec.ExitError{}
+		if errors.As(err, &exitErr) {
+			os.Exit(exitErr.ExitCode())
+		}
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		return
+	}
+	if err := command.Execute(); err != nil {
 		fmt.Println(err)
 		os.Exit(1)
 	}
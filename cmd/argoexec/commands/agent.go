@@ -64,6 +64,19 @@ func getPluginNames() []string {
 	return names
 }
 
+// getPluginVersions returns the versions of the plugins by name, or nil if the controller did not set them
+func getPluginVersions() map[string]string {
+	value, ok := os.LookupEnv(common.EnvVarPluginVersions)
+	if !ok {
+		return nil
+	}
+	versions := map[string]string{}
+	if err := json.Unmarshal([]byte(value), &versions); err != nil {
+		log.Fatal(err)
+	}
+	return versions
+}
+
 func getPluginAddresses() []string {
 	var addresses []string
 	if err := json.Unmarshal([]byte(os.Getenv(common.EnvVarPluginAddresses)), &addresses); err != nil {
@@ -124,5 +137,5 @@ func initAgentExecutor() *executor.AgentExecutor {
 		plugins = append(plugins, rpc.New(address, string(data)))
 	}
 
-	return executor.NewAgentExecutor(clientSet, restClient, config, namespace, workflowName, workflowUID, plugins)
+	return executor.NewAgentExecutor(clientSet, restClient, config, namespace, workflowName, workflowUID, plugins, getPluginVersions())
 }
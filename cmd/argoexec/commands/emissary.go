@@ -210,7 +210,7 @@ func NewEmissaryCommand() *cobra.Command {
 				}
 				for _, x := range template.Outputs.Artifacts {
 					if x.Path != "" {
-						if err := saveArtifact(x.Path); err != nil {
+						if err := saveArtifact(x.Path, x.Paths); err != nil {
 							return err
 						}
 					}
@@ -233,7 +233,8 @@ func startCommand(name string, args []string, template *wfv1.Template) (*exec.Cm
 	var stderr io.Writer = os.Stderr
 
 	// this may not be that important an optimisation, except for very long logs we don't want to capture
-	if includeScriptOutput || template.SaveLogsAsArtifact() {
+	// the heartbeat monitor in the wait container watches the combined log for signs of life
+	if includeScriptOutput || template.SaveLogsAsArtifact() || template.Heartbeat != nil {
 		logger.Info("capturing logs")
 		stdoutf, err := os.OpenFile(varRunArgo+"/ctr/"+containerName+"/stdout", os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0666)
 		if err != nil {
@@ -270,7 +271,7 @@ func startCommand(name string, args []string, template *wfv1.Template) (*exec.Cm
 	return command, closer, nil
 }
 
-func saveArtifact(srcPath string) error {
+func saveArtifact(srcPath string, patterns []string) error {
 	if common.FindOverlappingVolume(template, srcPath) != nil {
 		logger.Infof("no need to save artifact - on overlapping volume: %s", srcPath)
 		return nil
@@ -279,7 +280,7 @@ func saveArtifact(srcPath string) error {
 		logger.WithError(err).Warnf("cannot save artifact %s", srcPath)
 		return nil
 	}
-	dstPath := filepath.Join(varRunArgo, "/outputs/artifacts/", strings.TrimSuffix(srcPath, "/")+".tgz")
+	dstPath := common.GetOutputStagingPath(varRunArgo, "artifacts", strings.TrimSuffix(srcPath, "/")+".tgz")
 	logger.Infof("%s -> %s", srcPath, dstPath)
 	z := filepath.Dir(dstPath)
 	if err := os.MkdirAll(z, 0o755); err != nil { // chmod rwxr-xr-x
@@ -290,7 +291,7 @@ func saveArtifact(srcPath string) error {
 		return fmt.Errorf("failed to create destination %s: %w", dstPath, err)
 	}
 	defer func() { _ = dst.Close() }()
-	if err = archive.TarGzToWriter(srcPath, gzip.DefaultCompression, dst); err != nil {
+	if err = archive.TarGzToWriter(srcPath, gzip.DefaultCompression, dst, patterns...); err != nil {
 		return fmt.Errorf("failed to tarball the output %s to %s: %w", srcPath, dstPath, err)
 	}
 	if err = dst.Close(); err != nil {
@@ -313,7 +314,7 @@ func saveParameter(srcPath string) error {
 		return fmt.Errorf("failed to open %s: %w", srcPath, err)
 	}
 	defer func() { _ = src.Close() }()
-	dstPath := varRunArgo + "/outputs/parameters/" + srcPath
+	dstPath := common.GetOutputStagingPath(varRunArgo, "parameters", srcPath)
 	logger.Infof("%s -> %s", srcPath, dstPath)
 	z := filepath.Dir(dstPath)
 	if err := os.MkdirAll(z, 0o755); err != nil { // chmod rwxr-xr-x
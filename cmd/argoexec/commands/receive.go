@@ -0,0 +1,54 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+)
+
+// NewReceiveCommand returns the command of the pods that `argo cp` creates to upload a file into a workflow's volume,
+// the file is streamed to the command's stdin
+func NewReceiveCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:          "receive PATH",
+		Short:        "write the file on stdin to PATH",
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			n, err := receive(os.Stdin, args[0])
+			if err != nil {
+				return err
+			}
+			fmt.Printf("received %d bytes\n", n)
+			return nil
+		},
+	}
+}
+
+// receive writes the file to a temporary file next to the path, then renames it, so that the path is never a partial file
+func receive(r io.Reader, path string) (int64, error) {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return 0, err
+	}
+	f, err := os.CreateTemp(dir, "."+filepath.Base(path)+".*")
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = os.Remove(f.Name()) }()
+	n, err := io.Copy(f, r)
+	if err != nil {
+		_ = f.Close()
+		return 0, err
+	}
+	if err := f.Close(); err != nil {
+		return 0, err
+	}
+	if err := os.Chmod(f.Name(), 0o644); err != nil {
+		return 0, err
+	}
+	return n, os.Rename(f.Name(), path)
+}
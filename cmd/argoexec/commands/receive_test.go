@@ -0,0 +1,24 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReceive(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data", "corrected.csv")
+	n, err := receive(strings.NewReader("a,b\n1,2\n"), path)
+	require.NoError(t, err)
+	assert.Equal(t, int64(8), n)
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "a,b\n1,2\n", string(data))
+	entries, err := os.ReadDir(filepath.Dir(path))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1, "the temporary file is renamed")
+}
@@ -39,12 +39,20 @@ func execResource(ctx context.Context, action string) error {
 		wfExecutor.AddError(err)
 		return err
 	}
+	operator, err := common.GetResourceOperator(wfExecutor.Template.Resource)
+	if err != nil {
+		wfExecutor.AddError(err)
+		return err
+	}
 	isDelete := action == "delete"
 	if isDelete && (wfExecutor.Template.Resource.SuccessCondition != "" || wfExecutor.Template.Resource.FailureCondition != "" || len(wfExecutor.Template.Outputs.Parameters) > 0) {
 		err = fmt.Errorf("successCondition, failureCondition and outputs are not supported for delete action")
 		wfExecutor.AddError(err)
 		return err
 	}
+	if !isDelete {
+		wfExecutor.ApplyResourceOperator(operator)
+	}
 	manifestPath := common.ExecutorResourceManifestPath
 	if wfExecutor.Template.Resource.ManifestFrom != nil {
 		targetArtName := wfExecutor.Template.Resource.ManifestFrom.Artifact.Name
@@ -63,7 +71,9 @@ func execResource(ctx context.Context, action string) error {
 		return err
 	}
 	if !isDelete {
+		stopLogs := wfExecutor.StreamResourceOperatorLogs(ctx, operator, resourceNamespace, resourceName)
 		err = wfExecutor.WaitResource(ctx, resourceNamespace, resourceName, selfLink)
+		stopLogs()
 		if err != nil {
 			wfExecutor.AddError(err)
 			return err
@@ -64,6 +64,7 @@ func NewRootCommand() *cobra.Command {
 	command.AddCommand(NewResourceCommand())
 	command.AddCommand(NewWaitCommand())
 	command.AddCommand(NewDataCommand())
+	command.AddCommand(NewReceiveCommand())
 	command.AddCommand(cmd.NewVersionCmd(CLIName))
 	command.AddCommand(artifact.NewArtifactCommand())
 
@@ -31,12 +31,15 @@ import (
 	wfclientset "github.com/argoproj/argo-workflows/v3/pkg/client/clientset/versioned"
 	cmdutil "github.com/argoproj/argo-workflows/v3/util/cmd"
 	"github.com/argoproj/argo-workflows/v3/util/env"
+	"github.com/argoproj/argo-workflows/v3/util/faultinjection"
 	"github.com/argoproj/argo-workflows/v3/util/logs"
 	pprofutil "github.com/argoproj/argo-workflows/v3/util/pprof"
 	"github.com/argoproj/argo-workflows/v3/workflow/common"
 	"github.com/argoproj/argo-workflows/v3/workflow/controller"
+	"github.com/argoproj/argo-workflows/v3/workflow/diagnostics"
 	"github.com/argoproj/argo-workflows/v3/workflow/events"
 	"github.com/argoproj/argo-workflows/v3/workflow/metrics"
+	"github.com/argoproj/argo-workflows/v3/workflow/recording"
 )
 
 const (
@@ -63,6 +66,8 @@ func NewRootCommand() *cobra.Command {
 		namespaced              bool   // --namespaced
 		managedNamespace        string // --managed-namespace
 		executorPlugins         bool
+		faultInjection          bool     // --fault-injection
+		recordWorkflows         []string // --record-workflows
 	)
 
 	command := cobra.Command{
@@ -90,6 +95,13 @@ func NewRootCommand() *cobra.Command {
 			logs.AddK8SLogTransportWrapper(config)
 			metrics.AddMetricsTransportWrapper(config)
 
+			var faultInjector *faultinjection.Injector
+			if faultInjection {
+				log.Warn("Fault injection is enabled, faults are injected into the workflows of the namespaces in the faultInjection config. Never use this in production.")
+				faultInjector = faultinjection.NewInjector()
+				faultInjector.AddTransportWrapper(config)
+			}
+
 			namespace, _, err := clientConfig.Namespace()
 			if err != nil {
 				return err
@@ -112,6 +124,11 @@ func NewRootCommand() *cobra.Command {
 
 			wfController, err := controller.NewWorkflowController(ctx, config, kubeclientset, wfclientset, namespace, managedNamespace, executorImage, executorImagePullPolicy, logFormat, configMap, executorPlugins)
 			errors.CheckError(err)
+			if faultInjector != nil {
+				wfController.SetFaultInjector(faultInjector)
+			}
+			recorder := recording.NewRecorder(recordWorkflows)
+			wfController.SetRecorder(recorder)
 
 			leaderElectionOff := os.Getenv("LEADER_ELECTION_DISABLE")
 			if leaderElectionOff == "true" {
@@ -163,9 +180,11 @@ func NewRootCommand() *cobra.Command {
 			}
 
 			http.HandleFunc("/healthz", wfController.Healthz)
+			http.HandleFunc(diagnostics.Path, wfController.Diagnostics)
+			http.Handle(recording.Path, recorder)
 
 			go func() {
-				log.Println(http.ListenAndServe(":6060", nil))
+				log.Println(http.ListenAndServe(fmt.Sprintf(":%d", diagnostics.Port), nil))
 			}()
 
 			<-ctx.Done()
@@ -190,6 +209,8 @@ func NewRootCommand() *cobra.Command {
 	command.Flags().BoolVar(&namespaced, "namespaced", false, "run workflow-controller as namespaced mode")
 	command.Flags().StringVar(&managedNamespace, "managed-namespace", "", "namespace that workflow-controller watches, default to the installation namespace")
 	command.Flags().BoolVar(&executorPlugins, "executor-plugins", false, "enable executor plugins")
+	command.Flags().BoolVar(&faultInjection, "fault-injection", false, "inject faults into workflows as configured by faultInjection in the configmap, for testing only")
+	command.Flags().StringSliceVar(&recordWorkflows, "record-workflows", []string{}, "namespace/name of workflows to record the reconciliations of, to replay them with argo admin replay, in addition to those with the workflows.argoproj.io/record-reconciliations annotation")
 
 	viper.AutomaticEnv()
 	viper.SetEnvPrefix("ARGO")
@@ -0,0 +1,9 @@
+package config
+
+// Approvals configures how the Argo Server approves the suspend nodes that need approvals.
+type Approvals struct {
+	// AllowUnknownCreator allows the nodes of workflows whose creator the Argo Server did not record, e.g. because they
+	// were created with kubectl or by a cron workflow, to be approved by any user. By default they cannot be approved,
+	// as the user who submitted the workflow could otherwise approve it.
+	AllowUnknownCreator bool `json:"allowUnknownCreator,omitempty"`
+}
@@ -0,0 +1,36 @@
+package config
+
+import (
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// DefaultArtifactCacheHostPath is the node directory the artifact cache is kept in when no volume is configured
+const DefaultArtifactCacheHostPath = "/var/cache/argo/artifacts"
+
+var defaultArtifactCacheMaxSize = resource.MustParse("10Gi")
+
+// ArtifactCache is a cache of input artifacts shared by the pods on each node, keyed by the artifacts' checksums, so
+// that pods loading an artifact that was already loaded on their node copy it instead of downloading it again
+type ArtifactCache struct {
+	// Volume is where the cache is kept. It must be shared by the pods on a node, e.g. a hostPath volume or a local
+	// persistent volume. Defaults to the hostPath /var/cache/argo/artifacts.
+	Volume *apiv1.VolumeSource `json:"volume,omitempty"`
+	// MaxSize is the size the cache is evicted down to, least recently used artifacts first. Defaults to 10Gi.
+	MaxSize *resource.Quantity `json:"maxSize,omitempty"`
+}
+
+func (c *ArtifactCache) GetVolume() apiv1.VolumeSource {
+	if c.Volume != nil {
+		return *c.Volume
+	}
+	hostPathType := apiv1.HostPathDirectoryOrCreate
+	return apiv1.VolumeSource{HostPath: &apiv1.HostPathVolumeSource{Path: DefaultArtifactCacheHostPath, Type: &hostPathType}}
+}
+
+func (c *ArtifactCache) GetMaxSize() int64 {
+	if c.MaxSize != nil {
+		return c.MaxSize.Value()
+	}
+	return defaultArtifactCacheMaxSize.Value()
+}
@@ -0,0 +1,28 @@
+package config
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var defaultArtifactCredentialsDuration = time.Hour
+
+// ArtifactCredentials configures how the controller gets the short-lived credentials of templates with
+// `artifactCredentials: true`, from an STS service using the S3 artifact repository's credentials
+type ArtifactCredentials struct {
+	// STSEndpoint is the URL of the STS service, e.g. "https://sts.amazonaws.com" for AWS, or the URL of a MinIO server
+	STSEndpoint string `json:"stsEndpoint"`
+	// RoleARN is the role that is assumed, which AWS requires. Defaults to the artifact repository's roleARN.
+	RoleARN string `json:"roleARN,omitempty"`
+	// Duration is how long the credentials are valid for, defaults to 1h. AWS requires at least 15m. The controller
+	// renews them once half of it has passed.
+	Duration *metav1.Duration `json:"duration,omitempty"`
+}
+
+func (c *ArtifactCredentials) GetDuration() time.Duration {
+	if c.Duration != nil {
+		return c.Duration.Duration
+	}
+	return defaultArtifactCredentialsDuration
+}
@@ -0,0 +1,16 @@
+package config
+
+import apiv1 "k8s.io/api/core/v1"
+
+// ArtifactMountDriver is a CSI driver that mounts a bucket, e.g. using FUSE, so that artifacts in it are read on demand
+type ArtifactMountDriver struct {
+	// Driver is the name of the CSI driver, e.g. "gcsfuse.csi.storage.gke.io"
+	Driver string `json:"driver"`
+	// VolumeAttributes are passed to the driver. "{{bucket}}", "{{endpoint}}" and "{{region}}" are replaced with those of
+	// the artifact.
+	VolumeAttributes map[string]string `json:"volumeAttributes,omitempty"`
+	// NodePublishSecretRef is the secret with the credentials the driver uses, if it needs one
+	NodePublishSecretRef *apiv1.LocalObjectReference `json:"nodePublishSecretRef,omitempty"`
+	// PodAnnotations are added to the pods that mount artifacts, e.g. to enable the driver's sidecar
+	PodAnnotations map[string]string `json:"podAnnotations,omitempty"`
+}
@@ -16,6 +16,16 @@ import (
 type ResourceRateLimit struct {
 	Limit float64 `json:"limit"`
 	Burst int     `json:"burst"`
+	// PerNamespace limits the rate at which pods are created in each namespace
+	PerNamespace *RateLimit `json:"perNamespace,omitempty"`
+	// PerWorkflow limits the rate at which pods are created for each workflow
+	PerWorkflow *RateLimit `json:"perWorkflow,omitempty"`
+}
+
+// RateLimit is a token bucket that allows bursts of up to Burst pods, refilled at Limit pods per second
+type RateLimit struct {
+	Limit float64 `json:"limit"`
+	Burst int     `json:"burst"`
 }
 
 // Config contains the configuration settings for the workflow controller
@@ -62,6 +72,11 @@ type Config struct {
 	// NamespaceParallelism limits the max workflows that can execute at the same time in a namespace
 	NamespaceParallelism int `json:"namespaceParallelism,omitempty"`
 
+	// SemaphoreAging is the time a workflow waits for a semaphore or mutex before its priority is raised by one.
+	// Waiting workflows acquire locks in priority order, then first-in-first-out; aging stops a steady stream of
+	// higher priority workflows from starving lower priority ones. Disabled by default.
+	SemaphoreAging *metav1.Duration `json:"semaphoreAging,omitempty"`
+
 	// ResourceRateLimit limits the rate at which pods are created
 	ResourceRateLimit *ResourceRateLimit `json:"resourceRateLimit,omitempty"`
 
@@ -93,9 +108,35 @@ type Config struct {
 	// WorkflowRestrictions restricts the controller to executing Workflows that meet certain restrictions
 	WorkflowRestrictions *WorkflowRestrictions `json:"workflowRestrictions,omitempty"`
 
+	// Guardrails limit the duration and size of workflows, and are enforced by the Argo Server when workflows are
+	// submitted and by the controller when they are expanded
+	Guardrails *Guardrails `json:"guardrails,omitempty"`
+
+	// DeprecatedParameters is what the Argo Server does when workflows are submitted with parameters that their
+	// workflow template deprecates, either "Warn" (the default) or "Reject"
+	DeprecatedParameters DeprecatedParameters `json:"deprecatedParameters,omitempty"`
+
+	// CheckArtifactRepository is whether the Argo Server checks that the artifact repository of a workflow can be
+	// reached, with the secrets of its namespace, when it is submitted or linted, so that it fails fast rather than
+	// when its first step saves an artifact
+	CheckArtifactRepository bool `json:"checkArtifactRepository,omitempty"`
+
+	// IdempotencyKeyWindow is how long the Argo Server returns the original result when a workflow is created,
+	// resubmitted or retried again with the same Idempotency-Key header, defaults to 24h, 0s disables idempotency keys
+	IdempotencyKeyWindow *metav1.Duration `json:"idempotencyKeyWindow,omitempty"`
+
+	// Approvals configures how the Argo Server approves the suspend nodes that need approvals
+	Approvals *Approvals `json:"approvals,omitempty"`
+
 	// Adds configurable initial delay (for K8S clusters with mutating webhooks) to prevent workflow getting modified by MWC.
 	InitialDelay metav1.Duration `json:"initialDelay,omitempty"`
 
+	// StatusUpdateWindow, if set, is the least time between the controller's updates of a workflow, e.g. 5s. Updates
+	// that only report the progress of running nodes are coalesced within the window, while changes of phase, new
+	// nodes and completed nodes are updated immediately. This reduces the updates that large fan-outs make to the
+	// Kubernetes API.
+	StatusUpdateWindow *metav1.Duration `json:"statusUpdateWindow,omitempty"`
+
 	// The command/args for each image, needed when the command is not specified and the emissary executor is used.
 	// https://argoproj.github.io/argo-workflows/workflow-executors/#emissary-emissary
 	Images map[string]Image `json:"images,omitempty"`
@@ -108,6 +149,45 @@ type Config struct {
 
 	// SSO in settings for single-sign on
 	SSO SSOConfig `json:"sso,omitempty"`
+
+	// WorkflowStore configures where the Argo Server reads workflows from for list and get requests
+	WorkflowStore *WorkflowStoreConfig `json:"workflowStore,omitempty"`
+
+	// Redis is where replicas of the Argo Server share their SSO logins, rate limits and event queue, so that they
+	// can run behind a load balancer
+	Redis *RedisConfig `json:"redis,omitempty"`
+
+	// SecurityProfiles are named seccomp, AppArmor and capability settings that templates can apply to their pods
+	SecurityProfiles map[string]SecurityProfile `json:"securityProfiles,omitempty"`
+
+	// ArtifactMountDrivers configure, by repository type (e.g. "s3" or "gcs"), the CSI driver that mounts input artifacts
+	// with `mount: true`
+	ArtifactMountDrivers map[string]ArtifactMountDriver `json:"artifactMountDrivers,omitempty"`
+
+	// RegistryPullSecrets map registry prefixes to image pull secrets, which are added to the pods that use images from
+	// those registries
+	RegistryPullSecrets RegistryPullSecrets `json:"registryPullSecrets,omitempty"`
+
+	// ArtifactCache, if set, caches input artifacts on each node, so that they are only downloaded once per node
+	ArtifactCache *ArtifactCache `json:"artifactCache,omitempty"`
+
+	// ArtifactCredentials, if set, allows templates to get short-lived credentials for their workflow's key prefix in
+	// its S3 artifact repository
+	ArtifactCredentials *ArtifactCredentials `json:"artifactCredentials,omitempty"`
+
+	// FaultInjection, if set and the controller is started with --fault-injection, injects faults into workflows for
+	// testing
+	FaultInjection *FaultInjection `json:"faultInjection,omitempty"`
+
+	// Kueue, if set, labels the pods of workflows with a Kueue queue, so that Kueue admits them
+	Kueue *Kueue `json:"kueue,omitempty"`
+
+	// Redaction, if set, redacts the values that may be secret from the workflows that the Argo Server returns to users
+	// who cannot get the secrets of their namespaces
+	Redaction *Redaction `json:"redaction,omitempty"`
+
+	// WorkflowExport, if set, exports a summary record of each completed workflow to data warehouses
+	WorkflowExport *WorkflowExport `json:"workflowExport,omitempty"`
 }
 
 func (c Config) GetExecutor() *apiv1.Container {
@@ -119,7 +199,13 @@ func (c Config) GetExecutor() *apiv1.Container {
 
 func (c Config) GetResourceRateLimit() ResourceRateLimit {
 	if c.ResourceRateLimit != nil {
-		return *c.ResourceRateLimit
+		limit := *c.ResourceRateLimit
+		// only per-namespace or per-workflow limits are configured
+		if limit.Limit == 0 && limit.Burst == 0 && (limit.PerNamespace != nil || limit.PerWorkflow != nil) {
+			limit.Limit = math.MaxFloat32
+			limit.Burst = math.MaxInt32
+		}
+		return limit
 	}
 	return ResourceRateLimit{
 		Limit: math.MaxFloat32,
@@ -135,6 +221,30 @@ func (c Config) GetPodGCDeleteDelayDuration() time.Duration {
 	return c.PodGCDeleteDelayDuration.Duration
 }
 
+func (c Config) GetSemaphoreAging() time.Duration {
+	if c.SemaphoreAging == nil {
+		return 0
+	}
+
+	return c.SemaphoreAging.Duration
+}
+
+func (c Config) GetIdempotencyKeyWindow() time.Duration {
+	if c.IdempotencyKeyWindow == nil {
+		return 24 * time.Hour
+	}
+
+	return c.IdempotencyKeyWindow.Duration
+}
+
+func (c Config) GetStatusUpdateWindow() time.Duration {
+	if c.StatusUpdateWindow == nil {
+		return 0
+	}
+
+	return c.StatusUpdateWindow.Duration
+}
+
 func (c Config) ValidateProtocol(inputProtocol string, allowedProtocol []string) error {
 	for _, protocol := range allowedProtocol {
 		if inputProtocol == protocol {
@@ -262,6 +372,8 @@ type MetricsConfig struct {
 	IgnoreErrors bool `json:"ignoreErrors,omitempty"`
 	// Secure is a flag that starts the metrics servers using TLS
 	Secure *bool `json:"secure,omitempty"`
+	// Tenants, if set, adds a metric that counts workflows by namespace and workflow template
+	Tenants *TenantMetrics `json:"tenants,omitempty"`
 }
 
 func (mc MetricsConfig) GetSecure(defaultValue bool) bool {
@@ -282,6 +394,13 @@ const (
 	TemplateReferencingSecure TemplateReferencing = "Secure"
 )
 
+type DeprecatedParameters string
+
+const (
+	DeprecatedParametersWarn   DeprecatedParameters = "Warn"
+	DeprecatedParametersReject DeprecatedParameters = "Reject"
+)
+
 func (req *WorkflowRestrictions) MustUseReference() bool {
 	if req == nil {
 		return false
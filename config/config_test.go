@@ -31,3 +31,19 @@ func TestSanitize(t *testing.T) {
 		}
 	}
 }
+
+func TestWorkflowStoreConfig(t *testing.T) {
+	var nilConfig *WorkflowStoreConfig
+	assert.Equal(t, WorkflowStoreKubernetes, nilConfig.GetStore("my-ns"))
+	assert.NoError(t, nilConfig.Validate())
+
+	c := &WorkflowStoreConfig{Default: WorkflowStoreInformer, Namespaces: map[string]WorkflowStoreType{"my-ns": WorkflowStoreArchive}}
+	assert.Equal(t, WorkflowStoreArchive, c.GetStore("my-ns"))
+	assert.Equal(t, WorkflowStoreInformer, c.GetStore("other-ns"))
+	assert.True(t, c.Uses(WorkflowStoreArchive))
+	assert.False(t, c.Uses(WorkflowStoreKubernetes))
+	assert.NoError(t, c.Validate())
+
+	c.Namespaces["bad-ns"] = "etcd"
+	assert.EqualError(t, c.Validate(), `invalid workflow store "etcd" for namespace "bad-ns"`)
+}
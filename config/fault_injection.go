@@ -0,0 +1,28 @@
+package config
+
+// FaultInjection randomly injects faults into the workflows of some namespaces, so that their retry strategies and
+// exit handlers can be tested. It only takes effect when the controller is started with --fault-injection, and must
+// never be used in production. Each rate is the fraction, from 0 to 1, of the operations that fail.
+type FaultInjection struct {
+	// Namespaces are the namespaces of the workflows that faults are injected into
+	Namespaces []string `json:"namespaces,omitempty"`
+	// PodFailureRate is the rate at which pods that succeed are failed
+	PodFailureRate float64 `json:"podFailureRate,omitempty"`
+	// ArtifactErrorRate is the rate at which the executor fails to load and save artifacts
+	ArtifactErrorRate float64 `json:"artifactErrorRate,omitempty"`
+	// APITimeoutRate is the rate at which the controller's Kubernetes API requests time out
+	APITimeoutRate float64 `json:"apiTimeoutRate,omitempty"`
+}
+
+// SelectsNamespace returns true if faults are injected into the workflows in the namespace
+func (f *FaultInjection) SelectsNamespace(namespace string) bool {
+	if f == nil {
+		return false
+	}
+	for _, n := range f.Namespaces {
+		if n == namespace {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,50 @@
+package config
+
+import (
+	"fmt"
+)
+
+// Guardrails limit the duration and size of workflows, to protect shared clusters from workflows that run forever or
+// expand to millions of nodes by accident. Zero values are unlimited.
+type Guardrails struct {
+	// MaxActiveDeadlineSeconds is the maximum activeDeadlineSeconds of a workflow. It is also the deadline of
+	// workflows that do not set one.
+	MaxActiveDeadlineSeconds int64 `json:"maxActiveDeadlineSeconds,omitempty"`
+	// MaxNodes is the maximum number of nodes of a workflow
+	MaxNodes int `json:"maxNodes,omitempty"`
+	// MaxFanOut is the maximum number of items a withItems, withParam or withSequence loop may expand to
+	MaxFanOut int `json:"maxFanOut,omitempty"`
+}
+
+// CheckActiveDeadlineSeconds returns an error if the activeDeadlineSeconds exceeds the maximum
+func (g *Guardrails) CheckActiveDeadlineSeconds(activeDeadlineSeconds *int64) error {
+	if g == nil || g.MaxActiveDeadlineSeconds <= 0 || activeDeadlineSeconds == nil || *activeDeadlineSeconds <= g.MaxActiveDeadlineSeconds {
+		return nil
+	}
+	return fmt.Errorf("activeDeadlineSeconds %d exceeds the maximum of %d set by the controller's guardrails", *activeDeadlineSeconds, g.MaxActiveDeadlineSeconds)
+}
+
+// GetActiveDeadlineSeconds returns the activeDeadlineSeconds, or the maximum if it is not set
+func (g *Guardrails) GetActiveDeadlineSeconds(activeDeadlineSeconds *int64) *int64 {
+	if activeDeadlineSeconds != nil || g == nil || g.MaxActiveDeadlineSeconds <= 0 {
+		return activeDeadlineSeconds
+	}
+	maxActiveDeadlineSeconds := g.MaxActiveDeadlineSeconds
+	return &maxActiveDeadlineSeconds
+}
+
+// CheckNodes returns an error if the number of nodes exceeds the maximum
+func (g *Guardrails) CheckNodes(nodes int) error {
+	if g == nil || g.MaxNodes <= 0 || nodes <= g.MaxNodes {
+		return nil
+	}
+	return fmt.Errorf("workflow exceeds the maximum of %d nodes set by the controller's guardrails", g.MaxNodes)
+}
+
+// CheckFanOut returns an error if the number of items of a loop exceeds the maximum
+func (g *Guardrails) CheckFanOut(items int) error {
+	if g == nil || g.MaxFanOut <= 0 || items <= g.MaxFanOut {
+		return nil
+	}
+	return fmt.Errorf("loop of %d items exceeds the maximum fan-out of %d set by the controller's guardrails", items, g.MaxFanOut)
+}
@@ -0,0 +1,34 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/pointer"
+)
+
+func TestGuardrails(t *testing.T) {
+	t.Run("Unlimited", func(t *testing.T) {
+		var g *Guardrails
+		assert.NoError(t, g.CheckActiveDeadlineSeconds(pointer.Int64(1000000)))
+		assert.Nil(t, g.GetActiveDeadlineSeconds(nil))
+		assert.NoError(t, g.CheckNodes(1000000))
+		assert.NoError(t, g.CheckFanOut(1000000))
+	})
+	g := &Guardrails{MaxActiveDeadlineSeconds: 3600, MaxNodes: 100, MaxFanOut: 10}
+	t.Run("ActiveDeadlineSeconds", func(t *testing.T) {
+		assert.NoError(t, g.CheckActiveDeadlineSeconds(nil))
+		assert.NoError(t, g.CheckActiveDeadlineSeconds(pointer.Int64(3600)))
+		assert.EqualError(t, g.CheckActiveDeadlineSeconds(pointer.Int64(3601)), "activeDeadlineSeconds 3601 exceeds the maximum of 3600 set by the controller's guardrails")
+		assert.Equal(t, pointer.Int64(3600), g.GetActiveDeadlineSeconds(nil))
+		assert.Equal(t, pointer.Int64(60), g.GetActiveDeadlineSeconds(pointer.Int64(60)))
+	})
+	t.Run("Nodes", func(t *testing.T) {
+		assert.NoError(t, g.CheckNodes(100))
+		assert.EqualError(t, g.CheckNodes(101), "workflow exceeds the maximum of 100 nodes set by the controller's guardrails")
+	})
+	t.Run("FanOut", func(t *testing.T) {
+		assert.NoError(t, g.CheckFanOut(10))
+		assert.EqualError(t, g.CheckFanOut(11), "loop of 11 items exceeds the maximum fan-out of 10 set by the controller's guardrails")
+	})
+}
@@ -0,0 +1,27 @@
+package config
+
+// Kueue has the workflow controller label the pods it creates with a Kueue local queue, so that Kueue admits them
+// rather than the controller's own parallelism limits. Kueue must manage pods in the workflows' namespaces: it gates
+// each labelled pod until its queue has the capacity for it.
+type Kueue struct {
+	// QueueName is the local queue of the pods whose workflow, template or pod metadata do not name one with the
+	// kueue.x-k8s.io/queue-name label
+	QueueName string `json:"queueName,omitempty"`
+	// WorkloadPriorityClassName is the Kueue workload priority class of the pods that do not name one with the
+	// kueue.x-k8s.io/priority-class label
+	WorkloadPriorityClassName string `json:"workloadPriorityClassName,omitempty"`
+}
+
+func (k *Kueue) GetQueueName() string {
+	if k == nil {
+		return ""
+	}
+	return k.QueueName
+}
+
+func (k *Kueue) GetWorkloadPriorityClassName() string {
+	if k == nil {
+		return ""
+	}
+	return k.WorkloadPriorityClassName
+}
@@ -0,0 +1,11 @@
+package config
+
+// Redaction has the Argo Server redact the values that may be secret from the workflows it returns to users who cannot
+// get the secrets of the workflows' namespaces, so that they can view workflows without seeing their secrets. The
+// values of environment variables, the credentials of HTTP and Git artifacts, and the values of the parameters whose
+// names match a pattern are redacted.
+type Redaction struct {
+	// ParameterNamePatterns are regular expressions of the names of the parameters whose values are redacted,
+	// e.g. "(?i)password|token"
+	ParameterNamePatterns []string `json:"parameterNamePatterns,omitempty"`
+}
@@ -0,0 +1,26 @@
+package config
+
+import apiv1 "k8s.io/api/core/v1"
+
+// RedisConfig configures the Redis server that replicas of the Argo Server share state in
+type RedisConfig struct {
+	// Address is the host:port of the Redis server
+	Address string `json:"address"`
+	// UsernameSecret is the secret key of the username, if Redis ACLs are used
+	UsernameSecret *apiv1.SecretKeySelector `json:"usernameSecret,omitempty"`
+	// PasswordSecret is the secret key of the password
+	PasswordSecret *apiv1.SecretKeySelector `json:"passwordSecret,omitempty"`
+	// DB is the number of the database, defaults to 0
+	DB int `json:"db,omitempty"`
+	// TLS connects to Redis using TLS
+	TLS bool `json:"tls,omitempty"`
+	// KeyPrefix is prefixed to all keys, so several installations can share a Redis server, defaults to "argo-server:"
+	KeyPrefix string `json:"keyPrefix,omitempty"`
+}
+
+func (c *RedisConfig) GetKeyPrefix() string {
+	if c.KeyPrefix != "" {
+		return c.KeyPrefix
+	}
+	return "argo-server:"
+}
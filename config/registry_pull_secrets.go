@@ -0,0 +1,42 @@
+package config
+
+import "strings"
+
+// RegistryPullSecrets maps registry prefixes, e.g. "ghcr.io/my-org" or "my-registry.example.com", to the name of the
+// image pull secret that covers them. Images without a registry are on "docker.io".
+type RegistryPullSecrets map[string]string
+
+// GetPullSecret returns the secret of the longest prefix that matches the image, or the empty string if none does
+func (r RegistryPullSecrets) GetPullSecret(image string) string {
+	image = normalizeImage(image)
+	secret, longest := "", -1
+	for prefix, s := range r {
+		prefix = strings.TrimSuffix(prefix, "/")
+		if len(prefix) > longest && hasImagePrefix(image, prefix) {
+			secret, longest = s, len(prefix)
+		}
+	}
+	return secret
+}
+
+// hasImagePrefix returns true if the prefix is the image's registry, or a path of its repository
+func hasImagePrefix(image, prefix string) bool {
+	if !strings.HasPrefix(image, prefix) {
+		return false
+	}
+	rest := image[len(prefix):]
+	return rest == "" || strings.ContainsAny(rest[:1], "/:@")
+}
+
+// normalizeImage prefixes images on Docker Hub with their registry, e.g. "argoproj/argosay:v2" becomes
+// "docker.io/argoproj/argosay:v2" and "alpine" becomes "docker.io/library/alpine"
+func normalizeImage(image string) string {
+	parts := strings.SplitN(image, "/", 2)
+	if len(parts) == 1 {
+		return "docker.io/library/" + image
+	}
+	if !strings.ContainsAny(parts[0], ".:") && parts[0] != "localhost" {
+		return "docker.io/" + image
+	}
+	return image
+}
@@ -0,0 +1,24 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegistryPullSecrets_GetPullSecret(t *testing.T) {
+	r := RegistryPullSecrets{
+		"docker.io":               "docker-hub",
+		"ghcr.io/my-org":          "ghcr-my-org",
+		"ghcr.io/my-org/private/": "ghcr-private",
+		"localhost:5000":          "local",
+	}
+	assert.Equal(t, "docker-hub", r.GetPullSecret("alpine"))
+	assert.Equal(t, "docker-hub", r.GetPullSecret("argoproj/argosay:v2"))
+	assert.Equal(t, "ghcr-my-org", r.GetPullSecret("ghcr.io/my-org/app:v1"))
+	assert.Equal(t, "ghcr-private", r.GetPullSecret("ghcr.io/my-org/private/app@sha256:abc"), "longest prefix")
+	assert.Equal(t, "", r.GetPullSecret("ghcr.io/my-organization/app"), "prefixes match whole path segments")
+	assert.Equal(t, "local", r.GetPullSecret("localhost:5000/app"))
+	assert.Equal(t, "", r.GetPullSecret("quay.io/app"))
+	assert.Equal(t, "", RegistryPullSecrets(nil).GetPullSecret("alpine"))
+}
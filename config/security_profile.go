@@ -0,0 +1,13 @@
+package config
+
+import apiv1 "k8s.io/api/core/v1"
+
+// SecurityProfile is a named set of sandboxing settings, which templates apply to their pods with securityProfile
+type SecurityProfile struct {
+	// SeccompProfile is set on the pod and on every container, overriding any seccomp profile they specify
+	SeccompProfile *apiv1.SeccompProfile `json:"seccompProfile,omitempty"`
+	// AppArmorProfile is set on every container, e.g. "runtime/default" or "localhost/my-profile"
+	AppArmorProfile string `json:"appArmorProfile,omitempty"`
+	// DropCapabilities are dropped by every container, and removed from the capabilities they add
+	DropCapabilities []apiv1.Capability `json:"dropCapabilities,omitempty"`
+}
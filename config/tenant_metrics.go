@@ -0,0 +1,34 @@
+package config
+
+const defaultMaxTenants = 100
+
+// TenantMetrics configures the argo_workflows_tenant_workflows_count metric, which counts workflows by namespace and
+// workflow template, so that platform teams can have a dashboard for each tenant without a series for every
+// namespace and template in the cluster
+type TenantMetrics struct {
+	// Labels are the tenant labels of the metric, "namespace" and/or "workflow_template". Defaults to both.
+	Labels []string `json:"labels,omitempty"`
+	// Namespaces, if set, are the only namespaces that have their own series, e.g. "team-a" or "team-*". The workflows
+	// of other namespaces are counted in the "other" namespace.
+	Namespaces []string `json:"namespaces,omitempty"`
+	// WorkflowTemplates, if set, are the only workflow templates that have their own series. The workflows of other
+	// templates are counted in the "other" template.
+	WorkflowTemplates []string `json:"workflowTemplates,omitempty"`
+	// MaxTenants is the most tenants that have their own series. The tenants with the most workflows have them, and
+	// the workflows of the rest are counted in the "other" tenant. Defaults to 100.
+	MaxTenants int `json:"maxTenants,omitempty"`
+}
+
+func (t *TenantMetrics) GetLabels() []string {
+	if len(t.Labels) > 0 {
+		return t.Labels
+	}
+	return []string{"namespace", "workflow_template"}
+}
+
+func (t *TenantMetrics) GetMaxTenants() int {
+	if t.MaxTenants > 0 {
+		return t.MaxTenants
+	}
+	return defaultMaxTenants
+}
@@ -0,0 +1,112 @@
+package config
+
+import (
+	"fmt"
+	"time"
+
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+// WorkflowExport has the controller export a summary record of each completed workflow to data warehouses, for
+// long-term analytics beyond the workflow archive
+type WorkflowExport struct {
+	// LabelSelector selects the workflows that are exported, by default all of them
+	LabelSelector *metav1.LabelSelector `json:"labelSelector,omitempty"`
+	// BatchSize is the most records that are exported together, defaults to 100
+	BatchSize int `json:"batchSize,omitempty"`
+	// FlushInterval is the longest that a record waits to be exported with others, defaults to 1m
+	FlushInterval *metav1.Duration `json:"flushInterval,omitempty"`
+	// Sinks are the data warehouses the records are exported to
+	Sinks []WorkflowExportSink `json:"sinks"`
+}
+
+// WorkflowExportSink is a data warehouse the records are exported to, one of BigQuery, Snowflake, or Parquet files in S3
+type WorkflowExportSink struct {
+	// Name of the sink, for logging
+	Name      string               `json:"name"`
+	BigQuery  *BigQueryExportSink  `json:"bigQuery,omitempty"`
+	Snowflake *SnowflakeExportSink `json:"snowflake,omitempty"`
+	S3        *S3ExportSink        `json:"s3,omitempty"`
+}
+
+// BigQueryExportSink streams the records into a BigQuery table
+type BigQueryExportSink struct {
+	Project string `json:"project"`
+	Dataset string `json:"dataset"`
+	Table   string `json:"table"`
+	// CredentialsSecret is the secret key of a service account key, by default the controller's application default
+	// credentials are used
+	CredentialsSecret *apiv1.SecretKeySelector `json:"credentialsSecret,omitempty"`
+}
+
+// SnowflakeExportSink inserts the records into a Snowflake table with the Snowflake SQL API
+type SnowflakeExportSink struct {
+	// Account is the account identifier, e.g. myorg-myaccount
+	Account   string `json:"account"`
+	Database  string `json:"database"`
+	Schema    string `json:"schema"`
+	Table     string `json:"table"`
+	Warehouse string `json:"warehouse,omitempty"`
+	Role      string `json:"role,omitempty"`
+	// TokenSecret is the secret key of an OAuth token or a programmatic access token
+	TokenSecret apiv1.SecretKeySelector `json:"tokenSecret"`
+	// TokenType is OAUTH (default) or PROGRAMMATIC_ACCESS_TOKEN
+	TokenType string `json:"tokenType,omitempty"`
+}
+
+// S3ExportSink writes each batch of records to a Parquet file in an S3 bucket, under a date=YYYY-MM-DD partition
+type S3ExportSink struct {
+	wfv1.S3Bucket `json:",inline"`
+	// KeyPrefix is the prefix of the keys of the files, e.g. analytics/workflows/
+	KeyPrefix string `json:"keyPrefix,omitempty"`
+}
+
+func (e *WorkflowExport) IsEnabled() bool {
+	return e != nil && len(e.Sinks) > 0
+}
+
+func (e *WorkflowExport) GetLabelSelector() (labels.Selector, error) {
+	if e == nil || e.LabelSelector == nil {
+		return labels.Everything(), nil
+	}
+	return metav1.LabelSelectorAsSelector(e.LabelSelector)
+}
+
+func (e *WorkflowExport) GetBatchSize() int {
+	if e == nil || e.BatchSize <= 0 {
+		return 100
+	}
+	return e.BatchSize
+}
+
+func (e *WorkflowExport) GetFlushInterval() time.Duration {
+	if e == nil || e.FlushInterval == nil {
+		return time.Minute
+	}
+	return e.FlushInterval.Duration
+}
+
+func (e *WorkflowExport) Validate() error {
+	if e == nil {
+		return nil
+	}
+	if _, err := e.GetLabelSelector(); err != nil {
+		return fmt.Errorf("invalid workflow export label selector: %w", err)
+	}
+	for _, s := range e.Sinks {
+		n := 0
+		for _, set := range []bool{s.BigQuery != nil, s.Snowflake != nil, s.S3 != nil} {
+			if set {
+				n++
+			}
+		}
+		if n != 1 {
+			return fmt.Errorf("workflow export sink %q must have exactly one of bigQuery, snowflake or s3", s.Name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+func TestWorkflowExport(t *testing.T) {
+	t.Run("Nil", func(t *testing.T) {
+		var e *WorkflowExport
+		assert.False(t, e.IsEnabled())
+		assert.Equal(t, 100, e.GetBatchSize())
+		assert.Equal(t, time.Minute, e.GetFlushInterval())
+		selector, err := e.GetLabelSelector()
+		if assert.NoError(t, err) {
+			assert.True(t, selector.Matches(labels.Set{}))
+		}
+		assert.NoError(t, e.Validate())
+	})
+	t.Run("Configured", func(t *testing.T) {
+		e := &WorkflowExport{
+			LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "data"}},
+			BatchSize:     10,
+			FlushInterval: &metav1.Duration{Duration: time.Second},
+			Sinks:         []WorkflowExportSink{{Name: "bq", BigQuery: &BigQueryExportSink{Project: "p", Dataset: "d", Table: "t"}}},
+		}
+		assert.True(t, e.IsEnabled())
+		assert.Equal(t, 10, e.GetBatchSize())
+		assert.Equal(t, time.Second, e.GetFlushInterval())
+		selector, err := e.GetLabelSelector()
+		if assert.NoError(t, err) {
+			assert.True(t, selector.Matches(labels.Set{"team": "data"}))
+			assert.False(t, selector.Matches(labels.Set{"team": "web"}))
+		}
+		assert.NoError(t, e.Validate())
+	})
+	t.Run("NoSinkType", func(t *testing.T) {
+		e := &WorkflowExport{Sinks: []WorkflowExportSink{{Name: "empty"}}}
+		assert.EqualError(t, e.Validate(), `workflow export sink "empty" must have exactly one of bigQuery, snowflake or s3`)
+	})
+	t.Run("TwoSinkTypes", func(t *testing.T) {
+		e := &WorkflowExport{Sinks: []WorkflowExportSink{{Name: "both", BigQuery: &BigQueryExportSink{}, S3: &S3ExportSink{}}}}
+		assert.Error(t, e.Validate())
+	})
+}
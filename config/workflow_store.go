@@ -0,0 +1,75 @@
+package config
+
+import "fmt"
+
+type WorkflowStoreType string
+
+const (
+	// WorkflowStoreKubernetes reads workflows from the Kubernetes API on every request
+	WorkflowStoreKubernetes WorkflowStoreType = "kubernetes"
+	// WorkflowStoreInformer reads workflows from an informer cache kept by the Argo Server
+	WorkflowStoreInformer WorkflowStoreType = "informer"
+	// WorkflowStoreArchive reads workflows from the workflow archive database
+	WorkflowStoreArchive WorkflowStoreType = "archive"
+)
+
+// WorkflowStoreConfig configures where the Argo Server reads workflows from when serving list and get requests
+type WorkflowStoreConfig struct {
+	// Default is the store used for namespaces without an entry in Namespaces, defaults to "kubernetes"
+	Default WorkflowStoreType `json:"default,omitempty"`
+	// Namespaces overrides the store for specific namespaces
+	Namespaces map[string]WorkflowStoreType `json:"namespaces,omitempty"`
+}
+
+func (c *WorkflowStoreConfig) GetDefault() WorkflowStoreType {
+	if c != nil && c.Default != "" {
+		return c.Default
+	}
+	return WorkflowStoreKubernetes
+}
+
+// GetStore returns the store type for the namespace
+func (c *WorkflowStoreConfig) GetStore(namespace string) WorkflowStoreType {
+	if c != nil {
+		if t, ok := c.Namespaces[namespace]; ok && t != "" {
+			return t
+		}
+	}
+	return c.GetDefault()
+}
+
+// Uses returns true if the default or any of the namespaces use the store type
+func (c *WorkflowStoreConfig) Uses(t WorkflowStoreType) bool {
+	if c.GetDefault() == t {
+		return true
+	}
+	if c != nil {
+		for _, v := range c.Namespaces {
+			if v == t {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (c *WorkflowStoreConfig) Validate() error {
+	if c == nil {
+		return nil
+	}
+	types := map[string]WorkflowStoreType{"": c.Default}
+	for ns, t := range c.Namespaces {
+		types[ns] = t
+	}
+	for ns, t := range types {
+		switch t {
+		case "", WorkflowStoreKubernetes, WorkflowStoreInformer, WorkflowStoreArchive:
+		default:
+			if ns == "" {
+				return fmt.Errorf("invalid default workflow store %q", t)
+			}
+			return fmt.Errorf("invalid workflow store %q for namespace %q", t, ns)
+		}
+	}
+	return nil
+}
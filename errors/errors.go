@@ -9,13 +9,18 @@ import (
 
 // Externally visible error codes
 const (
-	CodeUnauthorized   = "ERR_UNAUTHORIZED"
-	CodeBadRequest     = "ERR_BAD_REQUEST"
-	CodeForbidden      = "ERR_FORBIDDEN"
-	CodeNotFound       = "ERR_NOT_FOUND"
-	CodeNotImplemented = "ERR_NOT_IMPLEMENTED"
-	CodeTimeout        = "ERR_TIMEOUT"
-	CodeInternal       = "ERR_INTERNAL"
+	CodeUnauthorized     = "ERR_UNAUTHORIZED"
+	CodeBadRequest       = "ERR_BAD_REQUEST"
+	CodeForbidden        = "ERR_FORBIDDEN"
+	CodeNotFound         = "ERR_NOT_FOUND"
+	CodeNotImplemented   = "ERR_NOT_IMPLEMENTED"
+	CodeTimeout          = "ERR_TIMEOUT"
+	CodeInternal         = "ERR_INTERNAL"
+	CodeTemplateNotFound = "ERR_TEMPLATE_NOT_FOUND"
+	CodeSemaphoreConfig  = "ERR_SEMAPHORE_CONFIG"
+	CodeArtifactUpload   = "ERR_ARTIFACT_UPLOAD"
+	// CodeIdempotencyKeyReused is the code of a request with an idempotency key that was used for a different request
+	CodeIdempotencyKeyReused = "ERR_IDEMPOTENCY_KEY_REUSED"
 )
 
 // ArgoError is an error interface that additionally adds support for
@@ -146,13 +151,15 @@ func (e argoerr) HTTPCode() int {
 		return http.StatusUnauthorized
 	case CodeForbidden:
 		return http.StatusForbidden
-	case CodeNotFound:
+	case CodeNotFound, CodeTemplateNotFound:
 		return http.StatusNotFound
-	case CodeBadRequest:
+	case CodeBadRequest, CodeSemaphoreConfig:
 		return http.StatusBadRequest
 	case CodeNotImplemented:
 		return http.StatusNotImplemented
-	case CodeTimeout, CodeInternal:
+	case CodeIdempotencyKeyReused:
+		return http.StatusUnprocessableEntity
+	case CodeTimeout, CodeInternal, CodeArtifactUpload:
 		return http.StatusInternalServerError
 	default:
 		return http.StatusInternalServerError
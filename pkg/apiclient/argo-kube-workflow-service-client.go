@@ -121,3 +121,27 @@ func (c *argoKubeWorkflowServiceClient) WorkflowLogs(ctx context.Context, req *w
 func (c *argoKubeWorkflowServiceClient) SubmitWorkflow(ctx context.Context, req *workflowpkg.WorkflowSubmitRequest, _ ...grpc.CallOption) (*v1alpha1.Workflow, error) {
 	return c.delegate.SubmitWorkflow(ctx, req)
 }
+
+func (c *argoKubeWorkflowServiceClient) SignalWorkflow(ctx context.Context, req *workflowpkg.WorkflowSignalRequest, _ ...grpc.CallOption) (*v1alpha1.Workflow, error) {
+	return c.delegate.SignalWorkflow(ctx, req)
+}
+
+func (c *argoKubeWorkflowServiceClient) GetWorkflowDataflow(ctx context.Context, req *workflowpkg.WorkflowDataflowRequest, _ ...grpc.CallOption) (*v1alpha1.WorkflowDataflow, error) {
+	return c.delegate.GetWorkflowDataflow(ctx, req)
+}
+
+func (c *argoKubeWorkflowServiceClient) GetWorkflowOutputs(ctx context.Context, req *workflowpkg.WorkflowOutputsRequest, _ ...grpc.CallOption) (*v1alpha1.WorkflowOutputs, error) {
+	return c.delegate.GetWorkflowOutputs(ctx, req)
+}
+
+func (c *argoKubeWorkflowServiceClient) GetWorkflowArtifactGC(ctx context.Context, req *workflowpkg.WorkflowArtifactGCRequest, _ ...grpc.CallOption) (*v1alpha1.ArtifactGCReport, error) {
+	return c.delegate.GetWorkflowArtifactGC(ctx, req)
+}
+
+func (c *argoKubeWorkflowServiceClient) RetryWorkflowArtifactGC(ctx context.Context, req *workflowpkg.WorkflowArtifactGCRequest, _ ...grpc.CallOption) (*v1alpha1.Workflow, error) {
+	return c.delegate.RetryWorkflowArtifactGC(ctx, req)
+}
+
+func (c *argoKubeWorkflowServiceClient) AnnotateWorkflowNode(ctx context.Context, req *workflowpkg.WorkflowNodeAnnotateRequest, _ ...grpc.CallOption) (*v1alpha1.Workflow, error) {
+	return c.delegate.AnnotateWorkflowNode(ctx, req)
+}
@@ -38,3 +38,11 @@ func (a *argoKubeWorkflowTemplateServiceClient) DeleteWorkflowTemplate(ctx conte
 func (a *argoKubeWorkflowTemplateServiceClient) LintWorkflowTemplate(ctx context.Context, req *workflowtemplatepkg.WorkflowTemplateLintRequest, _ ...grpc.CallOption) (*v1alpha1.WorkflowTemplate, error) {
 	return a.delegate.LintWorkflowTemplate(ctx, req)
 }
+
+func (a *argoKubeWorkflowTemplateServiceClient) PromoteWorkflowTemplateCanary(ctx context.Context, req *workflowtemplatepkg.WorkflowTemplateCanaryRequest, _ ...grpc.CallOption) (*v1alpha1.WorkflowTemplate, error) {
+	return a.delegate.PromoteWorkflowTemplateCanary(ctx, req)
+}
+
+func (a *argoKubeWorkflowTemplateServiceClient) RollbackWorkflowTemplateCanary(ctx context.Context, req *workflowtemplatepkg.WorkflowTemplateCanaryRequest, _ ...grpc.CallOption) (*v1alpha1.WorkflowTemplate, error) {
+	return a.delegate.RollbackWorkflowTemplateCanary(ctx, req)
+}
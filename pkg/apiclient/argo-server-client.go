@@ -3,11 +3,14 @@ package apiclient
 import (
 	"context"
 	"crypto/tls"
+	"net"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/resolver"
+	"google.golang.org/grpc/resolver/manual"
 
 	clusterworkflowtmplpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/clusterworkflowtemplate"
 	cronworkflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/cronworkflow"
@@ -65,13 +68,40 @@ func newClientConn(opts ArgoServerOpts) (*grpc.ClientConn, error) {
 	if opts.Secure {
 		creds = grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{InsecureSkipVerify: opts.InsecureSkipVerify}))
 	}
-	conn, err := grpc.Dial(opts.URL, grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(MaxClientGRPCMessageSize)), creds)
+	dialOpts := []grpc.DialOption{grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(MaxClientGRPCMessageSize)), creds}
+	target := opts.URL
+	if opts.IsMultiEndpoint() {
+		endpoints, err := opts.GetEndpoints()
+		if err != nil {
+			return nil, err
+		}
+		// the default pick_first balancer connects to the first endpoint that accepts connections, and to the next if
+		// it fails
+		r := manual.NewBuilderWithScheme("argo-server")
+		r.InitialState(resolver.State{Addresses: newResolverAddresses(endpoints)})
+		dialOpts = append(dialOpts, grpc.WithResolvers(r))
+		target = r.Scheme() + ":///argo-server"
+	}
+	conn, err := grpc.Dial(target, dialOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return conn, nil
 }
 
+// newResolverAddresses returns the addresses of the endpoints, each verified as its own host when using TLS
+func newResolverAddresses(endpoints []string) []resolver.Address {
+	addresses := make([]resolver.Address, len(endpoints))
+	for i, e := range endpoints {
+		host, _, err := net.SplitHostPort(e)
+		if err != nil {
+			host = e
+		}
+		addresses[i] = resolver.Address{Addr: e, ServerName: host}
+	}
+	return addresses
+}
+
 func newContext(auth string) context.Context {
 	if auth == "" {
 		return context.Background()
@@ -0,0 +1,103 @@
+package apiclient
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// SRVPrefix is the prefix of an Argo Server URL that is the DNS SRV name of its endpoints, e.g.
+// dns+srv://_web._tcp.argo-server-headless.argo.svc.cluster.local
+const SRVPrefix = "dns+srv://"
+
+// healthCheckTimeout is how long to wait to connect to an endpoint before it is considered unhealthy
+const healthCheckTimeout = 2 * time.Second
+
+var (
+	lookupSRV = net.LookupSRV
+	dial      = func(endpoint string) error {
+		conn, err := net.DialTimeout("tcp", endpoint, healthCheckTimeout)
+		if err != nil {
+			return err
+		}
+		return conn.Close()
+	}
+)
+
+// IsMultiEndpoint returns whether the URL is a list or DNS SRV name of several endpoints to fail over between
+func (o ArgoServerOpts) IsMultiEndpoint() bool {
+	return strings.HasPrefix(o.URL, SRVPrefix) || strings.Contains(o.URL, ",")
+}
+
+// GetEndpoints returns the `host:port` endpoints of the Argo Server: those of a comma separated list, or the targets
+// of a DNS SRV name in order of priority and weight. If there are several, the healthy endpoints, those that accept
+// connections, are returned first, so that clients prefer them and fail over to the others.
+func (o ArgoServerOpts) GetEndpoints() ([]string, error) {
+	if !o.IsMultiEndpoint() {
+		return []string{o.URL}, nil
+	}
+	var endpoints []string
+	if name := strings.TrimPrefix(o.URL, SRVPrefix); name != o.URL {
+		_, records, err := lookupSRV("", "", name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to look up the Argo Server endpoints of %s: %w", name, err)
+		}
+		for _, r := range records {
+			endpoints = append(endpoints, net.JoinHostPort(strings.TrimSuffix(r.Target, "."), strconv.Itoa(int(r.Port))))
+		}
+	} else {
+		for _, e := range strings.Split(o.URL, ",") {
+			if e = strings.TrimSpace(e); e != "" {
+				endpoints = append(endpoints, e)
+			}
+		}
+	}
+	if len(endpoints) == 0 {
+		return nil, fmt.Errorf("no Argo Server endpoints found in %q", o.URL)
+	}
+	return sortHealthy(endpoints), nil
+}
+
+// GetEndpointURL returns the URL of the endpoint, with the scheme and base path of the Argo Server
+func (o ArgoServerOpts) GetEndpointURL(endpoint string) string {
+	o.URL = endpoint
+	return o.GetURL()
+}
+
+// ResolveURL returns the URL of the preferred endpoint of the Argo Server
+func (o ArgoServerOpts) ResolveURL() (string, error) {
+	endpoints, err := o.GetEndpoints()
+	if err != nil {
+		return "", err
+	}
+	return o.GetEndpointURL(endpoints[0]), nil
+}
+
+// sortHealthy health checks the endpoints concurrently and returns the healthy ones first, keeping their order
+func sortHealthy(endpoints []string) []string {
+	errs := make([]error, len(endpoints))
+	var wg sync.WaitGroup
+	for i, e := range endpoints {
+		wg.Add(1)
+		go func(i int, e string) {
+			defer wg.Done()
+			errs[i] = dial(e)
+		}(i, e)
+	}
+	wg.Wait()
+	var healthy, unhealthy []string
+	for i, e := range endpoints {
+		if errs[i] != nil {
+			log.WithError(errs[i]).WithField("endpoint", e).Warn("Argo Server endpoint is unhealthy")
+			unhealthy = append(unhealthy, e)
+		} else {
+			healthy = append(healthy, e)
+		}
+	}
+	return append(healthy, unhealthy...)
+}
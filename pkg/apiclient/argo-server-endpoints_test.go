@@ -0,0 +1,65 @@
+package apiclient
+
+import (
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/resolver"
+)
+
+func TestArgoServerOpts_GetEndpoints(t *testing.T) {
+	defer func(d func(string) error, l func(string, string, string) (string, []*net.SRV, error)) {
+		dial, lookupSRV = d, l
+	}(dial, lookupSRV)
+	dial = func(endpoint string) error {
+		if endpoint == "down:2746" {
+			return errors.New("connection refused")
+		}
+		return nil
+	}
+	lookupSRV = func(service, proto, name string) (string, []*net.SRV, error) {
+		if name != "_web._tcp.argo-server" {
+			return "", nil, errors.New("no such host")
+		}
+		return name, []*net.SRV{{Target: "down.", Port: 2746}, {Target: "up.", Port: 2746}}, nil
+	}
+	t.Run("Single", func(t *testing.T) {
+		endpoints, err := ArgoServerOpts{URL: "down:2746"}.GetEndpoints()
+		if assert.NoError(t, err) {
+			assert.Equal(t, []string{"down:2746"}, endpoints)
+		}
+	})
+	t.Run("List", func(t *testing.T) {
+		endpoints, err := ArgoServerOpts{URL: "down:2746, a:2746,,b:2746"}.GetEndpoints()
+		if assert.NoError(t, err) {
+			assert.Equal(t, []string{"a:2746", "b:2746", "down:2746"}, endpoints)
+		}
+	})
+	t.Run("EmptyList", func(t *testing.T) {
+		_, err := ArgoServerOpts{URL: ","}.GetEndpoints()
+		assert.Error(t, err)
+	})
+	t.Run("SRV", func(t *testing.T) {
+		endpoints, err := ArgoServerOpts{URL: "dns+srv://_web._tcp.argo-server"}.GetEndpoints()
+		if assert.NoError(t, err) {
+			assert.Equal(t, []string{"up:2746", "down:2746"}, endpoints)
+		}
+		url, err := ArgoServerOpts{URL: "dns+srv://_web._tcp.argo-server", Path: "/argo", Secure: true}.ResolveURL()
+		if assert.NoError(t, err) {
+			assert.Equal(t, "https://up:2746/argo", url)
+		}
+	})
+	t.Run("SRVNotFound", func(t *testing.T) {
+		_, err := ArgoServerOpts{URL: "dns+srv://unknown"}.GetEndpoints()
+		assert.EqualError(t, err, "failed to look up the Argo Server endpoints of unknown: no such host")
+	})
+}
+
+func Test_newResolverAddresses(t *testing.T) {
+	assert.Equal(t, []resolver.Address{
+		{Addr: "a.argo:2746", ServerName: "a.argo"},
+		{Addr: "b.argo", ServerName: "b.argo"},
+	}, newResolverAddresses([]string{"a.argo:2746", "b.argo"}))
+}
@@ -100,3 +100,33 @@ func (c *errorTranslatingWorkflowServiceClient) SubmitWorkflow(ctx context.Conte
 	workflow, err := c.delegate.SubmitWorkflow(ctx, req)
 	return workflow, grpcutil.TranslateError(err)
 }
+
+func (c *errorTranslatingWorkflowServiceClient) SignalWorkflow(ctx context.Context, req *workflowpkg.WorkflowSignalRequest, _ ...grpc.CallOption) (*v1alpha1.Workflow, error) {
+	workflow, err := c.delegate.SignalWorkflow(ctx, req)
+	return workflow, grpcutil.TranslateError(err)
+}
+
+func (c *errorTranslatingWorkflowServiceClient) GetWorkflowDataflow(ctx context.Context, req *workflowpkg.WorkflowDataflowRequest, _ ...grpc.CallOption) (*v1alpha1.WorkflowDataflow, error) {
+	dataflow, err := c.delegate.GetWorkflowDataflow(ctx, req)
+	return dataflow, grpcutil.TranslateError(err)
+}
+
+func (c *errorTranslatingWorkflowServiceClient) GetWorkflowOutputs(ctx context.Context, req *workflowpkg.WorkflowOutputsRequest, _ ...grpc.CallOption) (*v1alpha1.WorkflowOutputs, error) {
+	outputs, err := c.delegate.GetWorkflowOutputs(ctx, req)
+	return outputs, grpcutil.TranslateError(err)
+}
+
+func (c *errorTranslatingWorkflowServiceClient) GetWorkflowArtifactGC(ctx context.Context, req *workflowpkg.WorkflowArtifactGCRequest, _ ...grpc.CallOption) (*v1alpha1.ArtifactGCReport, error) {
+	report, err := c.delegate.GetWorkflowArtifactGC(ctx, req)
+	return report, grpcutil.TranslateError(err)
+}
+
+func (c *errorTranslatingWorkflowServiceClient) RetryWorkflowArtifactGC(ctx context.Context, req *workflowpkg.WorkflowArtifactGCRequest, _ ...grpc.CallOption) (*v1alpha1.Workflow, error) {
+	workflow, err := c.delegate.RetryWorkflowArtifactGC(ctx, req)
+	return workflow, grpcutil.TranslateError(err)
+}
+
+func (c *errorTranslatingWorkflowServiceClient) AnnotateWorkflowNode(ctx context.Context, req *workflowpkg.WorkflowNodeAnnotateRequest, _ ...grpc.CallOption) (*v1alpha1.Workflow, error) {
+	workflow, err := c.delegate.AnnotateWorkflowNode(ctx, req)
+	return workflow, grpcutil.TranslateError(err)
+}
@@ -45,3 +45,13 @@ func (a *errorTranslatingWorkflowTemplateServiceClient) LintWorkflowTemplate(ctx
 	template, err := a.delegate.LintWorkflowTemplate(ctx, req)
 	return template, grpcutil.TranslateError(err)
 }
+
+func (a *errorTranslatingWorkflowTemplateServiceClient) PromoteWorkflowTemplateCanary(ctx context.Context, req *workflowtemplatepkg.WorkflowTemplateCanaryRequest, _ ...grpc.CallOption) (*v1alpha1.WorkflowTemplate, error) {
+	template, err := a.delegate.PromoteWorkflowTemplateCanary(ctx, req)
+	return template, grpcutil.TranslateError(err)
+}
+
+func (a *errorTranslatingWorkflowTemplateServiceClient) RollbackWorkflowTemplateCanary(ctx context.Context, req *workflowtemplatepkg.WorkflowTemplateCanaryRequest, _ ...grpc.CallOption) (*v1alpha1.WorkflowTemplate, error) {
+	template, err := a.delegate.RollbackWorkflowTemplateCanary(ctx, req)
+	return template, grpcutil.TranslateError(err)
+}
@@ -40,6 +40,15 @@ func (h httpClient) NewInfoServiceClient() (infopkg.InfoServiceClient, error) {
 	return http1.InfoServiceClient(h), nil
 }
 
-func newHTTP1Client(baseUrl string, auth string, insecureSkipVerify bool, headers []string) (context.Context, Client, error) {
-	return context.Background(), httpClient(http1.NewFacade(baseUrl, auth, insecureSkipVerify, headers)), nil
+func newHTTP1Client(opts ArgoServerOpts, auth string) (context.Context, Client, error) {
+	endpoints, err := opts.GetEndpoints()
+	if err != nil {
+		return nil, nil, err
+	}
+	var baseUrls []string
+	for _, e := range endpoints {
+		baseUrls = append(baseUrls, opts.GetEndpointURL(e))
+	}
+	facade := http1.NewFacade(baseUrls[0], auth, opts.InsecureSkipVerify, opts.Headers).WithFailover(baseUrls[1:]...)
+	return context.Background(), httpClient(facade), nil
 }
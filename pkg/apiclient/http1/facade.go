@@ -5,13 +5,16 @@ import (
 	"bytes"
 	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net"
 	"net/http"
 	"net/url"
 	"regexp"
 	"strings"
 
 	log "github.com/sirupsen/logrus"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
@@ -25,10 +28,40 @@ type Facade struct {
 	authorization      string
 	insecureSkipVerify bool
 	headers            []string
+	// failoverBaseUrls are the base URLs of other endpoints of the server, tried in turn if the previous one does not
+	// accept connections
+	failoverBaseUrls []string
 }
 
 func NewFacade(baseUrl, authorization string, insecureSkipVerify bool, headers []string) Facade {
-	return Facade{baseUrl, authorization, insecureSkipVerify, headers}
+	return Facade{baseUrl: baseUrl, authorization: authorization, insecureSkipVerify: insecureSkipVerify, headers: headers}
+}
+
+// WithFailover returns the facade failing over to the base URLs, in order, if it cannot connect to its own
+func (h Facade) WithFailover(baseUrls ...string) Facade {
+	h.failoverBaseUrls = baseUrls
+	return h
+}
+
+// withFailover calls f with the facade of each base URL in turn, until one accepts connections
+func (h Facade) withFailover(f func(h Facade) error) error {
+	err := f(h)
+	for _, baseUrl := range h.failoverBaseUrls {
+		if !isDialError(err) {
+			return err
+		}
+		log.WithError(err).Warnf("Failed to connect to %s, failing over to %s", h.baseUrl, baseUrl)
+		h.baseUrl = baseUrl
+		err = f(h)
+	}
+	return err
+}
+
+// isDialError returns whether the error is a failure to connect, so the request was not sent and can be sent to another
+// endpoint whatever its method
+func isDialError(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
 }
 
 func (h Facade) Get(in, out interface{}, path string) error {
@@ -48,6 +81,16 @@ func (h Facade) Delete(in, out interface{}, path string) error {
 }
 
 func (h Facade) EventStreamReader(in interface{}, path string) (*bufio.Reader, error) {
+	var reader *bufio.Reader
+	err := h.withFailover(func(h Facade) error {
+		var err error
+		reader, err = h.eventStreamReader(in, path)
+		return err
+	})
+	return reader, err
+}
+
+func (h Facade) eventStreamReader(in interface{}, path string) (*bufio.Reader, error) {
 	method := "GET"
 	u, err := h.url(method, path, in)
 	if err != nil {
@@ -85,6 +128,10 @@ func (h Facade) EventStreamReader(in interface{}, path string) (*bufio.Reader, e
 }
 
 func (h Facade) do(in interface{}, out interface{}, method string, path string) error {
+	return h.withFailover(func(h Facade) error { return h.doOnce(in, out, method, path) })
+}
+
+func (h Facade) doOnce(in interface{}, out interface{}, method string, path string) error {
 	var data []byte
 	if method != "GET" {
 		var err error
@@ -154,9 +201,22 @@ func errFromResponse(r *http.Response) error {
 	x := &struct {
 		Code    codes.Code `json:"code"`
 		Message string     `json:"message"`
+		Details []struct {
+			Type   string `json:"@type"`
+			Reason string `json:"reason"`
+			Domain string `json:"domain"`
+		} `json:"details"`
 	}{}
 	if err := json.NewDecoder(r.Body).Decode(x); err == nil {
-		return status.Errorf(x.Code, x.Message)
+		st := status.New(x.Code, x.Message)
+		for _, detail := range x.Details {
+			if detail.Type == "type.googleapis.com/google.rpc.ErrorInfo" {
+				if withDetails, err := st.WithDetails(&errdetails.ErrorInfo{Reason: detail.Reason, Domain: detail.Domain}); err == nil {
+					st = withDetails
+				}
+			}
+		}
+		return st.Err()
 	}
 	return status.Error(codes.Internal, fmt.Sprintf(": %v", r))
 }
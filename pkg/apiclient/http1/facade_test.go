@@ -1,10 +1,19 @@
 package http1
 
 import (
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	grpcutil "github.com/argoproj/argo-workflows/v3/util/grpc"
 )
 
 func TestFacade_do(t *testing.T) {
@@ -14,3 +23,46 @@ func TestFacade_do(t *testing.T) {
 		assert.Equal(t, "http://my-url/my-ns/?labels.foo=1", u.String())
 	}
 }
+
+func TestFacade_withFailover(t *testing.T) {
+	dialErr := &net.OpError{Op: "dial", Err: errors.New("connection refused")}
+	f := NewFacade("http://a", "", false, nil).WithFailover("http://b", "http://c")
+	t.Run("FailOver", func(t *testing.T) {
+		var tried []string
+		err := f.withFailover(func(h Facade) error {
+			tried = append(tried, h.baseUrl)
+			if h.baseUrl == "http://c" {
+				return nil
+			}
+			return dialErr
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"http://a", "http://b", "http://c"}, tried)
+	})
+	t.Run("AllDown", func(t *testing.T) {
+		err := f.withFailover(func(h Facade) error { return dialErr })
+		assert.Equal(t, dialErr, err)
+	})
+	t.Run("NotDialError", func(t *testing.T) {
+		var tried []string
+		err := f.withFailover(func(h Facade) error {
+			tried = append(tried, h.baseUrl)
+			return errors.New("unavailable")
+		})
+		assert.EqualError(t, err, "unavailable")
+		assert.Equal(t, []string{"http://a"}, tried)
+	})
+}
+
+func Test_errFromResponse(t *testing.T) {
+	t.Run("OK", func(t *testing.T) {
+		assert.NoError(t, errFromResponse(&http.Response{StatusCode: http.StatusOK}))
+	})
+	t.Run("ErrorCode", func(t *testing.T) {
+		body := `{"code":5,"message":"template my-tmpl not found","details":[{"@type":"type.googleapis.com/google.rpc.ErrorInfo","reason":"ERR_TEMPLATE_NOT_FOUND","domain":"argoproj.io"}]}`
+		err := errFromResponse(&http.Response{StatusCode: http.StatusNotFound, Body: io.NopCloser(strings.NewReader(body))})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+		assert.Equal(t, "template my-tmpl not found", status.Convert(err).Message())
+		assert.Equal(t, "ERR_TEMPLATE_NOT_FOUND", grpcutil.ErrorCode(err))
+	})
+}
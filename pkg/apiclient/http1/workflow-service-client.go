@@ -107,3 +107,33 @@ func (h WorkflowServiceClient) SubmitWorkflow(_ context.Context, in *workflowpkg
 	out := &wfv1.Workflow{}
 	return out, h.Post(in, out, "/api/v1/workflows/{namespace}/submit")
 }
+
+func (h WorkflowServiceClient) SignalWorkflow(_ context.Context, in *workflowpkg.WorkflowSignalRequest, _ ...grpc.CallOption) (*wfv1.Workflow, error) {
+	out := &wfv1.Workflow{}
+	return out, h.Put(in, out, "/api/v1/workflows/{namespace}/{name}/signal")
+}
+
+func (h WorkflowServiceClient) GetWorkflowDataflow(_ context.Context, in *workflowpkg.WorkflowDataflowRequest, _ ...grpc.CallOption) (*wfv1.WorkflowDataflow, error) {
+	out := &wfv1.WorkflowDataflow{}
+	return out, h.Get(in, out, "/api/v1/workflows/{namespace}/{name}/dataflow")
+}
+
+func (h WorkflowServiceClient) GetWorkflowOutputs(_ context.Context, in *workflowpkg.WorkflowOutputsRequest, _ ...grpc.CallOption) (*wfv1.WorkflowOutputs, error) {
+	out := &wfv1.WorkflowOutputs{}
+	return out, h.Get(in, out, "/api/v1/workflows/{namespace}/{name}/outputs")
+}
+
+func (h WorkflowServiceClient) GetWorkflowArtifactGC(_ context.Context, in *workflowpkg.WorkflowArtifactGCRequest, _ ...grpc.CallOption) (*wfv1.ArtifactGCReport, error) {
+	out := &wfv1.ArtifactGCReport{}
+	return out, h.Get(in, out, "/api/v1/workflows/{namespace}/{name}/artifact-gc")
+}
+
+func (h WorkflowServiceClient) RetryWorkflowArtifactGC(_ context.Context, in *workflowpkg.WorkflowArtifactGCRequest, _ ...grpc.CallOption) (*wfv1.Workflow, error) {
+	out := &wfv1.Workflow{}
+	return out, h.Put(in, out, "/api/v1/workflows/{namespace}/{name}/artifact-gc/retry")
+}
+
+func (h WorkflowServiceClient) AnnotateWorkflowNode(_ context.Context, in *workflowpkg.WorkflowNodeAnnotateRequest, _ ...grpc.CallOption) (*wfv1.Workflow, error) {
+	out := &wfv1.Workflow{}
+	return out, h.Put(in, out, "/api/v1/workflows/{namespace}/{name}/annotate")
+}
@@ -40,3 +40,13 @@ func (h WorkflowTemplateServiceClient) LintWorkflowTemplate(_ context.Context, i
 	out := &wfv1.WorkflowTemplate{}
 	return out, h.Post(in, out, "/api/v1/workflow-templates/{namespace}/lint")
 }
+
+func (h WorkflowTemplateServiceClient) PromoteWorkflowTemplateCanary(_ context.Context, in *workflowtemplatepkg.WorkflowTemplateCanaryRequest, _ ...grpc.CallOption) (*wfv1.WorkflowTemplate, error) {
+	out := &wfv1.WorkflowTemplate{}
+	return out, h.Put(in, out, "/api/v1/workflow-templates/{namespace}/{name}/canary/promote")
+}
+
+func (h WorkflowTemplateServiceClient) RollbackWorkflowTemplateCanary(_ context.Context, in *workflowtemplatepkg.WorkflowTemplateCanaryRequest, _ ...grpc.CallOption) (*wfv1.WorkflowTemplate, error) {
+	out := &wfv1.WorkflowTemplate{}
+	return out, h.Put(in, out, "/api/v1/workflow-templates/{namespace}/{name}/canary/rollback")
+}
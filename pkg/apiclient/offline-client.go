@@ -12,9 +12,8 @@ import (
 	"github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflowtemplate"
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 	"github.com/argoproj/argo-workflows/v3/util/file"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
 	"github.com/argoproj/argo-workflows/v3/workflow/templateresolution"
-
-	"sigs.k8s.io/yaml"
 )
 
 type offlineWorkflowTemplateGetterMap map[string]templateresolution.WorkflowTemplateNamespacedGetter
@@ -50,40 +49,33 @@ func newOfflineClient(paths []string) (context.Context, Client, error) {
 
 	for _, basePath := range paths {
 		err := file.WalkManifests(basePath, func(path string, bytes []byte) error {
-			var generic map[string]interface{}
-			if err := yaml.Unmarshal(bytes, &generic); err != nil {
-				return fmt.Errorf("failed to parse YAML from file %s: %w", path, err)
-			}
-			switch generic["kind"] {
-			case "ClusterWorkflowTemplate":
-				cwftmpl := new(wfv1.ClusterWorkflowTemplate)
-				if err := yaml.Unmarshal(bytes, &cwftmpl); err != nil {
-					return fmt.Errorf("failed to unmarshal file %s as a ClusterWorkflowTemplate: %w", path, err)
+			// a file may have several documents, and the objects that fail to parse are reported when the file is linted
+			for _, pr := range common.ParseObjects(bytes, false) {
+				if pr.Err != nil {
+					continue
 				}
-
-				if _, ok := clusterWorkflowTemplateGetter.clusterWorkflowTemplates[cwftmpl.Name]; ok {
-					return fmt.Errorf("duplicate ClusterWorkflowTemplate found: %q", cwftmpl.Name)
-				}
-				clusterWorkflowTemplateGetter.clusterWorkflowTemplates[cwftmpl.Name] = cwftmpl
-
-			case "WorkflowTemplate":
-				wftmpl := new(wfv1.WorkflowTemplate)
-				if err := yaml.Unmarshal(bytes, &wftmpl); err != nil {
-					return fmt.Errorf("failed to unmarshal file %s as a WorkflowTemplate: %w", path, err)
-				}
-				getter, ok := workflowTemplateGetters[wftmpl.Namespace]
-				if !ok {
-					getter = &offlineWorkflowTemplateNamespacedGetter{
-						namespace:         wftmpl.Namespace,
-						workflowTemplates: map[string]*wfv1.WorkflowTemplate{},
+				switch v := pr.Object.(type) {
+				case *wfv1.ClusterWorkflowTemplate:
+					if _, ok := clusterWorkflowTemplateGetter.clusterWorkflowTemplates[v.Name]; ok {
+						return fmt.Errorf("duplicate ClusterWorkflowTemplate found: %q", v.Name)
+					}
+					clusterWorkflowTemplateGetter.clusterWorkflowTemplates[v.Name] = v
+
+				case *wfv1.WorkflowTemplate:
+					getter, ok := workflowTemplateGetters[v.Namespace]
+					if !ok {
+						getter = &offlineWorkflowTemplateNamespacedGetter{
+							namespace:         v.Namespace,
+							workflowTemplates: map[string]*wfv1.WorkflowTemplate{},
+						}
+						workflowTemplateGetters[v.Namespace] = getter
 					}
-					workflowTemplateGetters[wftmpl.Namespace] = getter
-				}
 
-				if _, ok := getter.(*offlineWorkflowTemplateNamespacedGetter).workflowTemplates[wftmpl.Name]; ok {
-					return fmt.Errorf("duplicate WorkflowTemplate found: %q", wftmpl.Name)
+					if _, ok := getter.(*offlineWorkflowTemplateNamespacedGetter).workflowTemplates[v.Name]; ok {
+						return fmt.Errorf("duplicate WorkflowTemplate found: %q", v.Name)
+					}
+					getter.(*offlineWorkflowTemplateNamespacedGetter).workflowTemplates[v.Name] = v
 				}
-				getter.(*offlineWorkflowTemplateNamespacedGetter).workflowTemplates[wftmpl.Name] = wftmpl
 			}
 
 			return nil
@@ -23,7 +23,7 @@ func (o OfflineClusterWorkflowTemplateServiceClient) CreateClusterWorkflowTempla
 }
 
 func (o OfflineClusterWorkflowTemplateServiceClient) GetClusterWorkflowTemplate(ctx context.Context, req *clusterworkflowtmplpkg.ClusterWorkflowTemplateGetRequest, opts ...grpc.CallOption) (*v1alpha1.ClusterWorkflowTemplate, error) {
-	return nil, OfflineErr
+	return o.clusterWorkflowTemplateGetter.Get(req.Name)
 }
 
 func (o OfflineClusterWorkflowTemplateServiceClient) ListClusterWorkflowTemplates(ctx context.Context, req *clusterworkflowtmplpkg.ClusterWorkflowTemplateListRequest, opts ...grpc.CallOption) (*v1alpha1.ClusterWorkflowTemplateList, error) {
@@ -89,3 +89,27 @@ func (o OfflineWorkflowServiceClient) WorkflowLogs(context.Context, *workflowpkg
 func (o OfflineWorkflowServiceClient) SubmitWorkflow(context.Context, *workflowpkg.WorkflowSubmitRequest, ...grpc.CallOption) (*wfv1.Workflow, error) {
 	return nil, OfflineErr
 }
+
+func (o OfflineWorkflowServiceClient) SignalWorkflow(context.Context, *workflowpkg.WorkflowSignalRequest, ...grpc.CallOption) (*wfv1.Workflow, error) {
+	return nil, OfflineErr
+}
+
+func (o OfflineWorkflowServiceClient) GetWorkflowDataflow(context.Context, *workflowpkg.WorkflowDataflowRequest, ...grpc.CallOption) (*wfv1.WorkflowDataflow, error) {
+	return nil, OfflineErr
+}
+
+func (o OfflineWorkflowServiceClient) GetWorkflowOutputs(context.Context, *workflowpkg.WorkflowOutputsRequest, ...grpc.CallOption) (*wfv1.WorkflowOutputs, error) {
+	return nil, OfflineErr
+}
+
+func (o OfflineWorkflowServiceClient) GetWorkflowArtifactGC(context.Context, *workflowpkg.WorkflowArtifactGCRequest, ...grpc.CallOption) (*wfv1.ArtifactGCReport, error) {
+	return nil, OfflineErr
+}
+
+func (o OfflineWorkflowServiceClient) RetryWorkflowArtifactGC(context.Context, *workflowpkg.WorkflowArtifactGCRequest, ...grpc.CallOption) (*wfv1.Workflow, error) {
+	return nil, OfflineErr
+}
+
+func (o OfflineWorkflowServiceClient) AnnotateWorkflowNode(context.Context, *workflowpkg.WorkflowNodeAnnotateRequest, ...grpc.CallOption) (*wfv1.Workflow, error) {
+	return nil, OfflineErr
+}
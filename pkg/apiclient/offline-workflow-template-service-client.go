@@ -23,7 +23,7 @@ func (o OfflineWorkflowTemplateServiceClient) CreateWorkflowTemplate(ctx context
 }
 
 func (o OfflineWorkflowTemplateServiceClient) GetWorkflowTemplate(ctx context.Context, req *workflowtemplatepkg.WorkflowTemplateGetRequest, _ ...grpc.CallOption) (*v1alpha1.WorkflowTemplate, error) {
-	return nil, OfflineErr
+	return o.namespacedWorkflowTemplateGetterMap.GetNamespaceGetter(req.Namespace).Get(req.Name)
 }
 
 func (o OfflineWorkflowTemplateServiceClient) ListWorkflowTemplates(ctx context.Context, req *workflowtemplatepkg.WorkflowTemplateListRequest, _ ...grpc.CallOption) (*v1alpha1.WorkflowTemplateList, error) {
@@ -45,3 +45,11 @@ func (o OfflineWorkflowTemplateServiceClient) LintWorkflowTemplate(ctx context.C
 	}
 	return req.Template, nil
 }
+
+func (o OfflineWorkflowTemplateServiceClient) PromoteWorkflowTemplateCanary(context.Context, *workflowtemplatepkg.WorkflowTemplateCanaryRequest, ...grpc.CallOption) (*v1alpha1.WorkflowTemplate, error) {
+	return nil, OfflineErr
+}
+
+func (o OfflineWorkflowTemplateServiceClient) RollbackWorkflowTemplateCanary(context.Context, *workflowtemplatepkg.WorkflowTemplateCanaryRequest, ...grpc.CallOption) (*v1alpha1.WorkflowTemplate, error) {
+	return nil, OfflineErr
+}
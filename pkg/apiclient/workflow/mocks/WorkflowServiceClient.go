@@ -19,6 +19,39 @@ type WorkflowServiceClient struct {
 	mock.Mock
 }
 
+// AnnotateWorkflowNode provides a mock function with given fields: ctx, in, opts
+func (_m *WorkflowServiceClient) AnnotateWorkflowNode(ctx context.Context, in *workflow.WorkflowNodeAnnotateRequest, opts ...grpc.CallOption) (*v1alpha1.Workflow, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *v1alpha1.Workflow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *workflow.WorkflowNodeAnnotateRequest, ...grpc.CallOption) (*v1alpha1.Workflow, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *workflow.WorkflowNodeAnnotateRequest, ...grpc.CallOption) *v1alpha1.Workflow); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1alpha1.Workflow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *workflow.WorkflowNodeAnnotateRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateWorkflow provides a mock function with given fields: ctx, in, opts
 func (_m *WorkflowServiceClient) CreateWorkflow(ctx context.Context, in *workflow.WorkflowCreateRequest, opts ...grpc.CallOption) (*v1alpha1.Workflow, error) {
 	_va := make([]interface{}, len(opts))
@@ -118,6 +151,105 @@ func (_m *WorkflowServiceClient) GetWorkflow(ctx context.Context, in *workflow.W
 	return r0, r1
 }
 
+// GetWorkflowArtifactGC provides a mock function with given fields: ctx, in, opts
+func (_m *WorkflowServiceClient) GetWorkflowArtifactGC(ctx context.Context, in *workflow.WorkflowArtifactGCRequest, opts ...grpc.CallOption) (*v1alpha1.ArtifactGCReport, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *v1alpha1.ArtifactGCReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *workflow.WorkflowArtifactGCRequest, ...grpc.CallOption) (*v1alpha1.ArtifactGCReport, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *workflow.WorkflowArtifactGCRequest, ...grpc.CallOption) *v1alpha1.ArtifactGCReport); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1alpha1.ArtifactGCReport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *workflow.WorkflowArtifactGCRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetWorkflowDataflow provides a mock function with given fields: ctx, in, opts
+func (_m *WorkflowServiceClient) GetWorkflowDataflow(ctx context.Context, in *workflow.WorkflowDataflowRequest, opts ...grpc.CallOption) (*v1alpha1.WorkflowDataflow, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *v1alpha1.WorkflowDataflow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *workflow.WorkflowDataflowRequest, ...grpc.CallOption) (*v1alpha1.WorkflowDataflow, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *workflow.WorkflowDataflowRequest, ...grpc.CallOption) *v1alpha1.WorkflowDataflow); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1alpha1.WorkflowDataflow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *workflow.WorkflowDataflowRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetWorkflowOutputs provides a mock function with given fields: ctx, in, opts
+func (_m *WorkflowServiceClient) GetWorkflowOutputs(ctx context.Context, in *workflow.WorkflowOutputsRequest, opts ...grpc.CallOption) (*v1alpha1.WorkflowOutputs, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *v1alpha1.WorkflowOutputs
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *workflow.WorkflowOutputsRequest, ...grpc.CallOption) (*v1alpha1.WorkflowOutputs, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *workflow.WorkflowOutputsRequest, ...grpc.CallOption) *v1alpha1.WorkflowOutputs); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1alpha1.WorkflowOutputs)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *workflow.WorkflowOutputsRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // LintWorkflow provides a mock function with given fields: ctx, in, opts
 func (_m *WorkflowServiceClient) LintWorkflow(ctx context.Context, in *workflow.WorkflowLintRequest, opts ...grpc.CallOption) (*v1alpha1.Workflow, error) {
 	_va := make([]interface{}, len(opts))
@@ -316,6 +448,39 @@ func (_m *WorkflowServiceClient) RetryWorkflow(ctx context.Context, in *workflow
 	return r0, r1
 }
 
+// RetryWorkflowArtifactGC provides a mock function with given fields: ctx, in, opts
+func (_m *WorkflowServiceClient) RetryWorkflowArtifactGC(ctx context.Context, in *workflow.WorkflowArtifactGCRequest, opts ...grpc.CallOption) (*v1alpha1.Workflow, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *v1alpha1.Workflow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *workflow.WorkflowArtifactGCRequest, ...grpc.CallOption) (*v1alpha1.Workflow, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *workflow.WorkflowArtifactGCRequest, ...grpc.CallOption) *v1alpha1.Workflow); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1alpha1.Workflow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *workflow.WorkflowArtifactGCRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SetWorkflow provides a mock function with given fields: ctx, in, opts
 func (_m *WorkflowServiceClient) SetWorkflow(ctx context.Context, in *workflow.WorkflowSetRequest, opts ...grpc.CallOption) (*v1alpha1.Workflow, error) {
 	_va := make([]interface{}, len(opts))
@@ -382,6 +547,39 @@ func (_m *WorkflowServiceClient) StopWorkflow(ctx context.Context, in *workflow.
 	return r0, r1
 }
 
+// SignalWorkflow provides a mock function with given fields: ctx, in, opts
+func (_m *WorkflowServiceClient) SignalWorkflow(ctx context.Context, in *workflow.WorkflowSignalRequest, opts ...grpc.CallOption) (*v1alpha1.Workflow, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *v1alpha1.Workflow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *workflow.WorkflowSignalRequest, ...grpc.CallOption) (*v1alpha1.Workflow, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *workflow.WorkflowSignalRequest, ...grpc.CallOption) *v1alpha1.Workflow); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1alpha1.Workflow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *workflow.WorkflowSignalRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SubmitWorkflow provides a mock function with given fields: ctx, in, opts
 func (_m *WorkflowServiceClient) SubmitWorkflow(ctx context.Context, in *workflow.WorkflowSubmitRequest, opts ...grpc.CallOption) (*v1alpha1.Workflow, error) {
 	_va := make([]interface{}, len(opts))
@@ -1,3 +1,11 @@
 package workflow
 
 //go:generate mockery --name=WorkflowServiceClient
+
+// WarningHeader is the header of the warnings about a workflow that is created or submitted, e.g. that it uses
+// deprecated parameters
+const WarningHeader = "warning"
+
+// IdempotencyKeyHeader is the header of the key of a request to create, resubmit or retry a workflow. The Argo Server
+// returns the original workflow when a request is repeated with the same key.
+const IdempotencyKeyHeader = "idempotency-key"
@@ -184,6 +184,72 @@ func (_m *WorkflowTemplateServiceClient) ListWorkflowTemplates(ctx context.Conte
 	return r0, r1
 }
 
+// PromoteWorkflowTemplateCanary provides a mock function with given fields: ctx, in, opts
+func (_m *WorkflowTemplateServiceClient) PromoteWorkflowTemplateCanary(ctx context.Context, in *workflowtemplate.WorkflowTemplateCanaryRequest, opts ...grpc.CallOption) (*v1alpha1.WorkflowTemplate, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *v1alpha1.WorkflowTemplate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *workflowtemplate.WorkflowTemplateCanaryRequest, ...grpc.CallOption) (*v1alpha1.WorkflowTemplate, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *workflowtemplate.WorkflowTemplateCanaryRequest, ...grpc.CallOption) *v1alpha1.WorkflowTemplate); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1alpha1.WorkflowTemplate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *workflowtemplate.WorkflowTemplateCanaryRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RollbackWorkflowTemplateCanary provides a mock function with given fields: ctx, in, opts
+func (_m *WorkflowTemplateServiceClient) RollbackWorkflowTemplateCanary(ctx context.Context, in *workflowtemplate.WorkflowTemplateCanaryRequest, opts ...grpc.CallOption) (*v1alpha1.WorkflowTemplate, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *v1alpha1.WorkflowTemplate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *workflowtemplate.WorkflowTemplateCanaryRequest, ...grpc.CallOption) (*v1alpha1.WorkflowTemplate, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *workflowtemplate.WorkflowTemplateCanaryRequest, ...grpc.CallOption) *v1alpha1.WorkflowTemplate); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1alpha1.WorkflowTemplate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *workflowtemplate.WorkflowTemplateCanaryRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateWorkflowTemplate provides a mock function with given fields: ctx, in, opts
 func (_m *WorkflowTemplateServiceClient) UpdateWorkflowTemplate(ctx context.Context, in *workflowtemplate.WorkflowTemplateUpdateRequest, opts ...grpc.CallOption) (*v1alpha1.WorkflowTemplate, error) {
 	_va := make([]interface{}, len(opts))
@@ -452,6 +452,62 @@ func (m *WorkflowTemplateLintRequest) GetCreateOptions() *v1.CreateOptions {
 	return nil
 }
 
+type WorkflowTemplateCanaryRequest struct {
+	// Name of the stable workflow template.
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Namespace            string   `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WorkflowTemplateCanaryRequest) Reset()         { *m = WorkflowTemplateCanaryRequest{} }
+func (m *WorkflowTemplateCanaryRequest) String() string { return proto.CompactTextString(m) }
+func (*WorkflowTemplateCanaryRequest) ProtoMessage()    {}
+func (*WorkflowTemplateCanaryRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_215375a0ab97a62a, []int{7}
+}
+func (m *WorkflowTemplateCanaryRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *WorkflowTemplateCanaryRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_WorkflowTemplateCanaryRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *WorkflowTemplateCanaryRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WorkflowTemplateCanaryRequest.Merge(m, src)
+}
+func (m *WorkflowTemplateCanaryRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *WorkflowTemplateCanaryRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WorkflowTemplateCanaryRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WorkflowTemplateCanaryRequest proto.InternalMessageInfo
+
+func (m *WorkflowTemplateCanaryRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *WorkflowTemplateCanaryRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*WorkflowTemplateCreateRequest)(nil), "workflowtemplate.WorkflowTemplateCreateRequest")
 	proto.RegisterType((*WorkflowTemplateGetRequest)(nil), "workflowtemplate.WorkflowTemplateGetRequest")
@@ -460,6 +516,7 @@ func init() {
 	proto.RegisterType((*WorkflowTemplateDeleteRequest)(nil), "workflowtemplate.WorkflowTemplateDeleteRequest")
 	proto.RegisterType((*WorkflowTemplateDeleteResponse)(nil), "workflowtemplate.WorkflowTemplateDeleteResponse")
 	proto.RegisterType((*WorkflowTemplateLintRequest)(nil), "workflowtemplate.WorkflowTemplateLintRequest")
+	proto.RegisterType((*WorkflowTemplateCanaryRequest)(nil), "workflowtemplate.WorkflowTemplateCanaryRequest")
 }
 
 func init() {
@@ -532,6 +589,8 @@ type WorkflowTemplateServiceClient interface {
 	UpdateWorkflowTemplate(ctx context.Context, in *WorkflowTemplateUpdateRequest, opts ...grpc.CallOption) (*v1alpha1.WorkflowTemplate, error)
 	DeleteWorkflowTemplate(ctx context.Context, in *WorkflowTemplateDeleteRequest, opts ...grpc.CallOption) (*WorkflowTemplateDeleteResponse, error)
 	LintWorkflowTemplate(ctx context.Context, in *WorkflowTemplateLintRequest, opts ...grpc.CallOption) (*v1alpha1.WorkflowTemplate, error)
+	PromoteWorkflowTemplateCanary(ctx context.Context, in *WorkflowTemplateCanaryRequest, opts ...grpc.CallOption) (*v1alpha1.WorkflowTemplate, error)
+	RollbackWorkflowTemplateCanary(ctx context.Context, in *WorkflowTemplateCanaryRequest, opts ...grpc.CallOption) (*v1alpha1.WorkflowTemplate, error)
 }
 
 type workflowTemplateServiceClient struct {
@@ -596,6 +655,24 @@ func (c *workflowTemplateServiceClient) LintWorkflowTemplate(ctx context.Context
 	return out, nil
 }
 
+func (c *workflowTemplateServiceClient) PromoteWorkflowTemplateCanary(ctx context.Context, in *WorkflowTemplateCanaryRequest, opts ...grpc.CallOption) (*v1alpha1.WorkflowTemplate, error) {
+	out := new(v1alpha1.WorkflowTemplate)
+	err := c.cc.Invoke(ctx, "/workflowtemplate.WorkflowTemplateService/PromoteWorkflowTemplateCanary", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *workflowTemplateServiceClient) RollbackWorkflowTemplateCanary(ctx context.Context, in *WorkflowTemplateCanaryRequest, opts ...grpc.CallOption) (*v1alpha1.WorkflowTemplate, error) {
+	out := new(v1alpha1.WorkflowTemplate)
+	err := c.cc.Invoke(ctx, "/workflowtemplate.WorkflowTemplateService/RollbackWorkflowTemplateCanary", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // WorkflowTemplateServiceServer is the server API for WorkflowTemplateService service.
 type WorkflowTemplateServiceServer interface {
 	CreateWorkflowTemplate(context.Context, *WorkflowTemplateCreateRequest) (*v1alpha1.WorkflowTemplate, error)
@@ -604,6 +681,8 @@ type WorkflowTemplateServiceServer interface {
 	UpdateWorkflowTemplate(context.Context, *WorkflowTemplateUpdateRequest) (*v1alpha1.WorkflowTemplate, error)
 	DeleteWorkflowTemplate(context.Context, *WorkflowTemplateDeleteRequest) (*WorkflowTemplateDeleteResponse, error)
 	LintWorkflowTemplate(context.Context, *WorkflowTemplateLintRequest) (*v1alpha1.WorkflowTemplate, error)
+	PromoteWorkflowTemplateCanary(context.Context, *WorkflowTemplateCanaryRequest) (*v1alpha1.WorkflowTemplate, error)
+	RollbackWorkflowTemplateCanary(context.Context, *WorkflowTemplateCanaryRequest) (*v1alpha1.WorkflowTemplate, error)
 }
 
 // UnimplementedWorkflowTemplateServiceServer can be embedded to have forward compatible implementations.
@@ -629,6 +708,14 @@ func (*UnimplementedWorkflowTemplateServiceServer) LintWorkflowTemplate(ctx cont
 	return nil, status.Errorf(codes.Unimplemented, "method LintWorkflowTemplate not implemented")
 }
 
+func (*UnimplementedWorkflowTemplateServiceServer) PromoteWorkflowTemplateCanary(ctx context.Context, req *WorkflowTemplateCanaryRequest) (*v1alpha1.WorkflowTemplate, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PromoteWorkflowTemplateCanary not implemented")
+}
+
+func (*UnimplementedWorkflowTemplateServiceServer) RollbackWorkflowTemplateCanary(ctx context.Context, req *WorkflowTemplateCanaryRequest) (*v1alpha1.WorkflowTemplate, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RollbackWorkflowTemplateCanary not implemented")
+}
+
 func RegisterWorkflowTemplateServiceServer(s *grpc.Server, srv WorkflowTemplateServiceServer) {
 	s.RegisterService(&_WorkflowTemplateService_serviceDesc, srv)
 }
@@ -741,6 +828,42 @@ func _WorkflowTemplateService_LintWorkflowTemplate_Handler(srv interface{}, ctx
 	return interceptor(ctx, in, info, handler)
 }
 
+func _WorkflowTemplateService_PromoteWorkflowTemplateCanary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WorkflowTemplateCanaryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkflowTemplateServiceServer).PromoteWorkflowTemplateCanary(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/workflowtemplate.WorkflowTemplateService/PromoteWorkflowTemplateCanary",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkflowTemplateServiceServer).PromoteWorkflowTemplateCanary(ctx, req.(*WorkflowTemplateCanaryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WorkflowTemplateService_RollbackWorkflowTemplateCanary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WorkflowTemplateCanaryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkflowTemplateServiceServer).RollbackWorkflowTemplateCanary(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/workflowtemplate.WorkflowTemplateService/RollbackWorkflowTemplateCanary",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkflowTemplateServiceServer).RollbackWorkflowTemplateCanary(ctx, req.(*WorkflowTemplateCanaryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _WorkflowTemplateService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "workflowtemplate.WorkflowTemplateService",
 	HandlerType: (*WorkflowTemplateServiceServer)(nil),
@@ -769,6 +892,14 @@ var _WorkflowTemplateService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "LintWorkflowTemplate",
 			Handler:    _WorkflowTemplateService_LintWorkflowTemplate_Handler,
 		},
+		{
+			MethodName: "PromoteWorkflowTemplateCanary",
+			Handler:    _WorkflowTemplateService_PromoteWorkflowTemplateCanary_Handler,
+		},
+		{
+			MethodName: "RollbackWorkflowTemplateCanary",
+			Handler:    _WorkflowTemplateService_RollbackWorkflowTemplateCanary_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "pkg/apiclient/workflowtemplate/workflow-template.proto",
@@ -1129,6 +1260,47 @@ func (m *WorkflowTemplateLintRequest) MarshalToSizedBuffer(dAtA []byte) (int, er
 	return len(dAtA) - i, nil
 }
 
+func (m *WorkflowTemplateCanaryRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *WorkflowTemplateCanaryRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *WorkflowTemplateCanaryRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Namespace) > 0 {
+		i -= len(m.Namespace)
+		copy(dAtA[i:], m.Namespace)
+		i = encodeVarintWorkflowTemplate(dAtA, i, uint64(len(m.Namespace)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintWorkflowTemplate(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func encodeVarintWorkflowTemplate(dAtA []byte, offset int, v uint64) int {
 	offset -= sovWorkflowTemplate(v)
 	base := offset
@@ -1296,6 +1468,26 @@ func (m *WorkflowTemplateLintRequest) Size() (n int) {
 	return n
 }
 
+func (m *WorkflowTemplateCanaryRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovWorkflowTemplate(uint64(l))
+	}
+	l = len(m.Namespace)
+	if l > 0 {
+		n += 1 + l + sovWorkflowTemplate(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
 func sovWorkflowTemplate(x uint64) (n int) {
 	return (math_bits.Len64(x|1) + 6) / 7
 }
@@ -2267,6 +2459,122 @@ func (m *WorkflowTemplateLintRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *WorkflowTemplateCanaryRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowWorkflowTemplate
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: WorkflowTemplateCanaryRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: WorkflowTemplateCanaryRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWorkflowTemplate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWorkflowTemplate
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWorkflowTemplate
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Namespace", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWorkflowTemplate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWorkflowTemplate
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWorkflowTemplate
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Namespace = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipWorkflowTemplate(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthWorkflowTemplate
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+
 func skipWorkflowTemplate(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
@@ -0,0 +1,31 @@
+package v1alpha1
+
+import (
+	"strings"
+
+	"golang.org/x/exp/slices"
+)
+
+// ArtifactBundle collects the output artifacts of the workflow's nodes into a single archive when the workflow
+// completes, and exposes it as a workflow output artifact, rather than a hand-written step that gathers them
+type ArtifactBundle struct {
+	// Name of the workflow output artifact the bundle is saved as, e.g. `workflow.outputs.artifacts.<name>`
+	Name string `json:"name" protobuf:"bytes,1,opt,name=name"`
+	// Artifacts are the names of the output artifacts to collect, all output artifacts except logs if empty
+	Artifacts []string `json:"artifacts,omitempty" protobuf:"bytes,2,rep,name=artifacts"`
+	// Templates are the names of the templates to collect the output artifacts of the nodes of, all templates if empty
+	Templates []string `json:"templates,omitempty" protobuf:"bytes,3,rep,name=templates"`
+}
+
+// SelectsTemplate returns whether the output artifacts of the nodes of the template are collected
+func (b *ArtifactBundle) SelectsTemplate(name string) bool {
+	return len(b.Templates) == 0 || slices.Contains(b.Templates, name)
+}
+
+// SelectsArtifact returns whether the output artifact of the name is collected
+func (b *ArtifactBundle) SelectsArtifact(name string) bool {
+	if len(b.Artifacts) == 0 {
+		return !strings.HasSuffix(name, LogsSuffix)
+	}
+	return slices.Contains(b.Artifacts, name)
+}
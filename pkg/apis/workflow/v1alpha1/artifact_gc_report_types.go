@@ -0,0 +1,43 @@
+package v1alpha1
+
+// ArtifactGCReport lists the output artifacts of a workflow that artifact garbage collection will delete, and the
+// artifacts it failed to delete
+type ArtifactGCReport struct {
+	// Candidates are the artifacts that have not been deleted yet, with the strategy that will delete them
+	Candidates []ArtifactGCCandidate `json:"candidates,omitempty" protobuf:"bytes,1,rep,name=candidates"`
+	// Failures are the artifacts that garbage collection failed to delete, and the garbage collection pods that failed
+	Failures []ArtifactGCFailure `json:"failures,omitempty" protobuf:"bytes,2,rep,name=failures"`
+}
+
+// ArtifactGCCandidate is an output artifact that artifact garbage collection will delete
+type ArtifactGCCandidate struct {
+	// NodeID is the ID of the node that output the artifact
+	NodeID string `json:"nodeID" protobuf:"bytes,1,opt,name=nodeID"`
+	// NodeName is the display name of the node that output the artifact
+	NodeName string `json:"nodeName,omitempty" protobuf:"bytes,2,opt,name=nodeName"`
+	// ArtifactName is the name of the artifact
+	ArtifactName string `json:"artifactName" protobuf:"bytes,3,opt,name=artifactName"`
+	// Strategy is the artifact garbage collection strategy that will delete the artifact
+	Strategy ArtifactGCStrategy `json:"strategy" protobuf:"bytes,4,opt,name=strategy,casttype=ArtifactGCStrategy"`
+	// Key is the key of the artifact in its repository
+	Key string `json:"key,omitempty" protobuf:"bytes,5,opt,name=key"`
+	// SizeBytes is the size of the artifact, or zero if it is not known
+	SizeBytes int64 `json:"sizeBytes,omitempty" protobuf:"varint,6,opt,name=sizeBytes"`
+	// Ready is whether the strategy applies yet, e.g. OnWorkflowCompletion once the workflow has completed
+	Ready bool `json:"ready,omitempty" protobuf:"varint,7,opt,name=ready"`
+}
+
+// ArtifactGCFailure is an artifact that artifact garbage collection failed to delete, or a garbage collection pod
+// that failed
+type ArtifactGCFailure struct {
+	// NodeID is the ID of the node that output the artifact
+	NodeID string `json:"nodeID,omitempty" protobuf:"bytes,1,opt,name=nodeID"`
+	// ArtifactName is the name of the artifact
+	ArtifactName string `json:"artifactName,omitempty" protobuf:"bytes,2,opt,name=artifactName"`
+	// Message is why the deletion failed
+	Message string `json:"message" protobuf:"bytes,3,opt,name=message"`
+	// TaskName is the name of the WorkflowArtifactGCTask with the result of the deletion
+	TaskName string `json:"taskName,omitempty" protobuf:"bytes,4,opt,name=taskName"`
+	// PodName is the name of the pod that failed, if it did not report results for its artifacts
+	PodName string `json:"podName,omitempty" protobuf:"bytes,5,opt,name=podName"`
+}
@@ -27,6 +27,8 @@ type ArtifactRepository struct {
 	GCS *GCSArtifactRepository `json:"gcs,omitempty" protobuf:"bytes,6,opt,name=gcs"`
 	// Azure stores artifact in an Azure Storage account
 	Azure *AzureArtifactRepository `json:"azure,omitempty" protobuf:"bytes,7,opt,name=azure"`
+	// CompressLogs stores the archived logs gzip-compressed, as <container>.log.gz
+	CompressLogs *bool `json:"compressLogs,omitempty" protobuf:"varint,8,opt,name=compressLogs"`
 }
 
 func (a *ArtifactRepository) IsArchiveLogs() bool {
@@ -62,7 +64,7 @@ func (a *ArtifactRepository) ToArtifactLocation() *ArtifactLocation {
 	if a == nil {
 		return nil
 	}
-	l := &ArtifactLocation{ArchiveLogs: a.ArchiveLogs}
+	l := &ArtifactLocation{ArchiveLogs: a.ArchiveLogs, CompressLogs: a.CompressLogs}
 	v := a.Get()
 	if v != nil {
 		v.IntoArtifactLocation(l)
@@ -1,6 +1,8 @@
 package v1alpha1
 
 import (
+	"strings"
+
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -43,7 +45,7 @@ type CronWorkflowSpec struct {
 	// WorkflowSpec is the spec of the workflow to be run
 	WorkflowSpec WorkflowSpec `json:"workflowSpec" protobuf:"bytes,1,opt,name=workflowSpec,casttype=WorkflowSpec"`
 	// Schedule is a schedule to run the Workflow in Cron format
-	Schedule string `json:"schedule" protobuf:"bytes,2,opt,name=schedule"`
+	Schedule string `json:"schedule,omitempty" protobuf:"bytes,2,opt,name=schedule"`
 	// ConcurrencyPolicy is the K8s-style concurrency policy that will be used
 	ConcurrencyPolicy ConcurrencyPolicy `json:"concurrencyPolicy,omitempty" protobuf:"bytes,3,opt,name=concurrencyPolicy,casttype=ConcurrencyPolicy"`
 	// Suspend is a flag that will stop new CronWorkflows from running if set to true
@@ -59,6 +61,19 @@ type CronWorkflowSpec struct {
 	Timezone string `json:"timezone,omitempty" protobuf:"bytes,8,opt,name=timezone"`
 	// WorkflowMetadata contains some metadata of the workflow to be run
 	WorkflowMetadata *metav1.ObjectMeta `json:"workflowMetadata,omitempty" protobuf:"bytes,9,opt,name=workflowMeta"`
+	// Schedules are named schedules to run the Workflow on, each with its own parameters, instead of Schedule, e.g. an
+	// hourly light run and a nightly full run
+	Schedules []CronSchedule `json:"schedules,omitempty" protobuf:"bytes,10,rep,name=schedules"`
+}
+
+// CronSchedule is a named schedule of a CronWorkflow
+type CronSchedule struct {
+	// Name of the schedule, which is added to the names of the workflows it runs
+	Name string `json:"name" protobuf:"bytes,1,opt,name=name"`
+	// Schedule to run the Workflow on in Cron format
+	Schedule string `json:"schedule" protobuf:"bytes,2,opt,name=schedule"`
+	// Parameters override the arguments of the workflows the schedule runs
+	Parameters []Parameter `json:"parameters,omitempty" protobuf:"bytes,3,rep,name=parameters"`
 }
 
 // CronWorkflowStatus is the status of a CronWorkflow
@@ -69,6 +84,21 @@ type CronWorkflowStatus struct {
 	LastScheduledTime *metav1.Time `json:"lastScheduledTime" protobuf:"bytes,2,opt,name=lastScheduledTime"`
 	// Conditions is a list of conditions the CronWorkflow may have
 	Conditions Conditions `json:"conditions" protobuf:"bytes,3,rep,name=conditions"`
+	// LastSuccessfulRunOutputs are the output parameters of the most recently completed successful Workflow. They are
+	// available to the next Workflow as `workflow.lastSuccessfulRunOutputs.parameters.<NAME>`
+	LastSuccessfulRunOutputs *Outputs `json:"lastSuccessfulRunOutputs,omitempty" protobuf:"bytes,4,opt,name=lastSuccessfulRunOutputs"`
+	// Schedules are the statuses of the CronWorkflow's named schedules
+	Schedules []CronScheduleStatus `json:"schedules,omitempty" protobuf:"bytes,5,rep,name=schedules"`
+}
+
+// CronScheduleStatus is the status of a named schedule of a CronWorkflow
+type CronScheduleStatus struct {
+	// Name of the schedule
+	Name string `json:"name" protobuf:"bytes,1,opt,name=name"`
+	// Active is a list of the active workflows the schedule ran
+	Active []v1.ObjectReference `json:"active,omitempty" protobuf:"bytes,2,rep,name=active"`
+	// LastScheduledTime is the last time the schedule ran a workflow
+	LastScheduledTime *metav1.Time `json:"lastScheduledTime,omitempty" protobuf:"bytes,3,opt,name=lastScheduledTime"`
 }
 
 func (c *CronWorkflow) IsUsingNewSchedule() bool {
@@ -89,12 +119,49 @@ func (c *CronWorkflow) GetLatestSchedule() string {
 	return c.Annotations[annotationKeyLatestSchedule]
 }
 
+// GetScheduleString returns the schedule, or the named schedules separated by commas, with the timezone
 func (c *CronWorkflowSpec) GetScheduleString() string {
-	scheduleString := c.Schedule
+	return strings.Join(c.GetScheduleStrings(), ",")
+}
+
+// GetScheduleStrings returns the schedule, or each of the named schedules, with the timezone
+func (c *CronWorkflowSpec) GetScheduleStrings() []string {
+	if len(c.Schedules) == 0 {
+		return []string{c.withTimezone(c.Schedule)}
+	}
+	var schedules []string
+	for _, s := range c.Schedules {
+		schedules = append(schedules, c.withTimezone(s.Schedule))
+	}
+	return schedules
+}
+
+func (c *CronWorkflowSpec) withTimezone(schedule string) string {
 	if c.Timezone != "" {
-		scheduleString = "CRON_TZ=" + c.Timezone + " " + scheduleString
+		return "CRON_TZ=" + c.Timezone + " " + schedule
+	}
+	return schedule
+}
+
+// GetSchedule returns the named schedule, or nil if there is none of the name
+func (c *CronWorkflowSpec) GetSchedule(name string) *CronSchedule {
+	for i := range c.Schedules {
+		if c.Schedules[i].Name == name {
+			return &c.Schedules[i]
+		}
+	}
+	return nil
+}
+
+// GetSchedule returns the status of the named schedule, adding it if there is none
+func (c *CronWorkflowStatus) GetSchedule(name string) *CronScheduleStatus {
+	for i := range c.Schedules {
+		if c.Schedules[i].Name == name {
+			return &c.Schedules[i]
+		}
 	}
-	return scheduleString
+	c.Schedules = append(c.Schedules, CronScheduleStatus{Name: name})
+	return &c.Schedules[len(c.Schedules)-1]
 }
 
 func (c *CronWorkflowStatus) HasActiveUID(uid types.UID) bool {
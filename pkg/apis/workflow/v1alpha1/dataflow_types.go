@@ -0,0 +1,25 @@
+package v1alpha1
+
+const (
+	DataflowParameter = "parameter"
+	DataflowArtifact  = "artifact"
+)
+
+// DataflowEdge is a parameter or artifact that one node outputs and another node takes as an input
+type DataflowEdge struct {
+	// From is the ID of the node that outputs the parameter or artifact
+	From string `json:"from" protobuf:"bytes,1,opt,name=from"`
+	// To is the ID of the node that takes it as an input
+	To string `json:"to" protobuf:"bytes,2,opt,name=to"`
+	// Type is "parameter" or "artifact"
+	Type string `json:"type" protobuf:"bytes,3,opt,name=type"`
+	// Output is the name of the output parameter or artifact, "result" for the result of a script, or "exitCode"
+	Output string `json:"output" protobuf:"bytes,4,opt,name=output"`
+	// Input is the name of the argument it is passed to the node as
+	Input string `json:"input" protobuf:"bytes,5,opt,name=input"`
+}
+
+// WorkflowDataflow is the lineage of a workflow's data: the parameters and artifacts that flow between its nodes
+type WorkflowDataflow struct {
+	Edges []DataflowEdge `json:"edges,omitempty" protobuf:"bytes,1,rep,name=edges"`
+}
@@ -0,0 +1,80 @@
+package v1alpha1
+
+import (
+	"fmt"
+
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// NUMA configures a template's pod for latency-sensitive work: exclusive CPUs, huge pages, and NUMA topology hints
+type NUMA struct {
+	// ExclusiveCPUs is the number of whole CPUs to give each of the template's containers, with requests equal to
+	// limits. Every container in the pod is given equal requests and limits too, so that the pod is Guaranteed and a
+	// kubelet with the static CPU manager policy pins the containers to CPUs that no other container uses.
+	ExclusiveCPUs int32 `json:"exclusiveCPUs,omitempty" protobuf:"varint,1,opt,name=exclusiveCPUs"`
+	// HugePages to allocate to the template's containers, and mount into them
+	HugePages []HugePages `json:"hugePages,omitempty" protobuf:"bytes,2,rep,name=hugePages"`
+	// TopologyPolicy is the NUMA topology policy the pod needs: "none", "best-effort", "restricted" or
+	// "single-numa-node". It is set as the pod's workflows.argoproj.io/topology-policy annotation, for topology-aware
+	// schedulers and admission webhooks.
+	TopologyPolicy string `json:"topologyPolicy,omitempty" protobuf:"bytes,3,opt,name=topologyPolicy"`
+	// DisableCPULoadBalancing asks the container runtime to disable CPU load balancing for the pod's exclusive CPUs.
+	// This is a CRI-O annotation, and usually requires a runtime class that allows it.
+	DisableCPULoadBalancing bool `json:"disableCPULoadBalancing,omitempty" protobuf:"varint,4,opt,name=disableCPULoadBalancing"`
+	// DisableCPUQuota asks the container runtime to disable the CFS quota for the pod's exclusive CPUs.
+	// This is a CRI-O annotation, and usually requires a runtime class that allows it.
+	DisableCPUQuota bool `json:"disableCPUQuota,omitempty" protobuf:"varint,5,opt,name=disableCPUQuota"`
+	// DisableIRQLoadBalancing asks the container runtime to keep device interrupts off the pod's exclusive CPUs.
+	// This is a CRI-O annotation, and usually requires a runtime class that allows it.
+	DisableIRQLoadBalancing bool `json:"disableIRQLoadBalancing,omitempty" protobuf:"varint,6,opt,name=disableIRQLoadBalancing"`
+}
+
+// HugePages is an amount of memory backed by huge pages of one size
+type HugePages struct {
+	// PageSize is the size of each huge page, "2Mi" or "1Gi"
+	PageSize string `json:"pageSize" protobuf:"bytes,1,opt,name=pageSize"`
+	// Quantity is the amount of huge page memory, e.g. "512Mi"
+	Quantity string `json:"quantity" protobuf:"bytes,2,opt,name=quantity"`
+	// MountPath is where the huge pages are mounted in the containers. Defaults to /dev/hugepages, or
+	// /dev/hugepages-<pageSize> if there is more than one page size.
+	MountPath string `json:"mountPath,omitempty" protobuf:"bytes,3,opt,name=mountPath"`
+}
+
+// ResourceName returns the name of the resource for huge pages of this size, e.g. hugepages-2Mi
+func (h HugePages) ResourceName() apiv1.ResourceName {
+	return apiv1.ResourceName(apiv1.ResourceHugePagesPrefix + h.PageSize)
+}
+
+// Validate returns an error if the NUMA configuration is invalid
+func (n *NUMA) Validate() error {
+	if n.ExclusiveCPUs < 0 {
+		return fmt.Errorf("exclusiveCPUs must be a positive integer")
+	}
+	switch n.TopologyPolicy {
+	case "", "none", "best-effort", "restricted", "single-numa-node":
+	default:
+		return fmt.Errorf("topologyPolicy must be one of: none, best-effort, restricted, single-numa-node")
+	}
+	pageSizes := make(map[string]bool)
+	for i, h := range n.HugePages {
+		switch h.PageSize {
+		case "2Mi", "1Gi":
+		default:
+			return fmt.Errorf("hugePages[%d].pageSize must be one of: 2Mi, 1Gi", i)
+		}
+		if pageSizes[h.PageSize] {
+			return fmt.Errorf("hugePages[%d].pageSize %s is not unique", i, h.PageSize)
+		}
+		pageSizes[h.PageSize] = true
+		quantity, err := resource.ParseQuantity(h.Quantity)
+		if err != nil || quantity.Sign() <= 0 {
+			return fmt.Errorf("hugePages[%d].quantity must be a positive quantity, e.g. 512Mi", i)
+		}
+		pageSize := resource.MustParse(h.PageSize)
+		if quantity.Value()%pageSize.Value() != 0 {
+			return fmt.Errorf("hugePages[%d].quantity must be a multiple of the page size %s", i, h.PageSize)
+		}
+	}
+	return nil
+}
@@ -5,12 +5,16 @@ import (
 	"fmt"
 )
 
-// Plugin is an Object with exactly one key
+// PluginVersionKey is the key of the optional version constraint of a plugin template, e.g. `version: ">=1.2"`
+const PluginVersionKey = "version"
+
+// Plugin is an Object with exactly one key, the name of the plugin, and optionally the version constraint of the plugin
 type Plugin struct {
 	Object `json:",inline" protobuf:"bytes,1,opt,name=object"`
 }
 
-// UnmarshalJSON unmarshalls the Plugin from JSON, and also validates that it is a map exactly one key
+// UnmarshalJSON unmarshalls the Plugin from JSON, and also validates that it is a map exactly one key, other than the
+// version
 func (p *Plugin) UnmarshalJSON(value []byte) error {
 	if err := p.Object.UnmarshalJSON(value); err != nil {
 		return err
@@ -22,8 +26,34 @@ func (p *Plugin) UnmarshalJSON(value []byte) error {
 		return err
 	}
 	numKeys := len(m)
+	if v, ok := m[PluginVersionKey]; ok {
+		if _, ok := v.(string); !ok {
+			return fmt.Errorf("expected %s to be a string, got %v", PluginVersionKey, v)
+		}
+		numKeys--
+	}
 	if numKeys != 1 {
 		return fmt.Errorf("expected exactly one key, got %d", numKeys)
 	}
 	return nil
 }
+
+// GetName returns the name of the plugin, its key other than the version
+func (p *Plugin) GetName() string {
+	m := map[string]interface{}{}
+	_ = json.Unmarshal(p.Object.Value, &m)
+	for k := range m {
+		if k != PluginVersionKey {
+			return k
+		}
+	}
+	return ""
+}
+
+// GetVersion returns the version constraint of the plugin, e.g. ">=1.2", or an empty string if any version will do
+func (p *Plugin) GetVersion() string {
+	m := map[string]interface{}{}
+	_ = json.Unmarshal(p.Object.Value, &m)
+	v, _ := m[PluginVersionKey].(string)
+	return v
+}
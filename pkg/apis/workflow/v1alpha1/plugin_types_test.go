@@ -19,4 +19,19 @@ func TestPlugin_UnmarshalJSON(t *testing.T) {
 		p := Plugin{}
 		assert.NoError(t, p.UnmarshalJSON([]byte(`{"foo":1}`)))
 	})
+	t.Run("Version", func(t *testing.T) {
+		p := Plugin{}
+		if assert.NoError(t, p.UnmarshalJSON([]byte(`{"foo":1,"version":">=1.2"}`))) {
+			assert.Equal(t, "foo", p.GetName())
+			assert.Equal(t, ">=1.2", p.GetVersion())
+		}
+	})
+	t.Run("OnlyVersion", func(t *testing.T) {
+		p := Plugin{}
+		assert.EqualError(t, p.UnmarshalJSON([]byte(`{"version":">=1.2"}`)), "expected exactly one key, got 0")
+	})
+	t.Run("InvalidVersion", func(t *testing.T) {
+		p := Plugin{}
+		assert.EqualError(t, p.UnmarshalJSON([]byte(`{"foo":1,"version":1}`)), "expected version to be a string, got 1")
+	})
 }
@@ -0,0 +1,96 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// WorkflowOutputType is the type of the value of a workflow output
+type WorkflowOutputType string
+
+const (
+	WorkflowOutputTypeString   WorkflowOutputType = "string"
+	WorkflowOutputTypeNumber   WorkflowOutputType = "number"
+	WorkflowOutputTypeBoolean  WorkflowOutputType = "boolean"
+	WorkflowOutputTypeJSON     WorkflowOutputType = "json"
+	WorkflowOutputTypeArtifact WorkflowOutputType = "artifact"
+)
+
+// WorkflowOutput declares a named output of the workflow, taken from an output of one of its nodes
+type WorkflowOutput struct {
+	// Name of the output
+	Name string `json:"name" protobuf:"bytes,1,opt,name=name"`
+	// Description of the output, for its consumers
+	Description string `json:"description,omitempty" protobuf:"bytes,2,opt,name=description"`
+	// Type of the output's value: "string" (default), "number", "boolean", "json" or "artifact"
+	Type WorkflowOutputType `json:"type,omitempty" protobuf:"bytes,3,opt,name=type,casttype=WorkflowOutputType"`
+	// From is the output of a step or task of the entrypoint template to take the value from, e.g.
+	// `tasks.train.outputs.parameters.accuracy`, `steps.build.outputs.result` or `tasks.train.outputs.artifacts.model`,
+	// or an output of the entrypoint template itself, e.g. `outputs.parameters.accuracy`
+	From string `json:"from" protobuf:"bytes,4,opt,name=from"`
+}
+
+// GetType returns the type of the output, defaulting to string
+func (o WorkflowOutput) GetType() WorkflowOutputType {
+	if o.Type == "" {
+		return WorkflowOutputTypeString
+	}
+	return o.Type
+}
+
+var workflowOutputFromRegex = regexp.MustCompile(`^(?:(tasks|steps)\.([A-Za-z0-9_-]+)\.)?outputs\.(?:(result)|(parameters|artifacts)\.([A-Za-z0-9_-]+))$`)
+
+// ParseFrom returns whether the output is taken from one of the "tasks" or "steps" of the entrypoint template, or from
+// the entrypoint template itself if empty, and the name of the task or step. It also returns whether the output is
+// the "result", or one of the "parameters" or "artifacts", and the name of the parameter or artifact.
+func (o WorkflowOutput) ParseFrom() (nodeKind, nodeName, outputKind, outputName string, err error) {
+	m := workflowOutputFromRegex.FindStringSubmatch(o.From)
+	if m == nil {
+		return "", "", "", "", fmt.Errorf("from '%s' must be an output of the entrypoint template or of one of its steps or tasks, e.g. tasks.train.outputs.parameters.accuracy", o.From)
+	}
+	if m[3] != "" {
+		return m[1], m[2], m[3], "", nil
+	}
+	return m[1], m[2], m[4], m[5], nil
+}
+
+// WorkflowOutputValue is the value of a workflow output
+type WorkflowOutputValue struct {
+	// Name of the output
+	Name string `json:"name" protobuf:"bytes,1,opt,name=name"`
+	// Description of the output
+	Description string `json:"description,omitempty" protobuf:"bytes,2,opt,name=description"`
+	// Type of the output's value
+	Type WorkflowOutputType `json:"type" protobuf:"bytes,3,opt,name=type,casttype=WorkflowOutputType"`
+	// Value of the output, unless it is an artifact
+	Value string `json:"value,omitempty" protobuf:"bytes,4,opt,name=value"`
+	// Artifact is the output's artifact, if it is an artifact
+	Artifact *Artifact `json:"artifact,omitempty" protobuf:"bytes,5,opt,name=artifact"`
+	// Message is why the output has no value, e.g. because the node it is taken from has not completed yet, or why
+	// its value is not of its type
+	Message string `json:"message,omitempty" protobuf:"bytes,6,opt,name=message"`
+}
+
+// WorkflowOutputs are the values of the outputs a workflow declares
+type WorkflowOutputs struct {
+	Outputs []WorkflowOutputValue `json:"outputs,omitempty" protobuf:"bytes,1,rep,name=outputs"`
+}
+
+// WorkflowOutputsValueFrom takes the value of a parameter from an output of the latest of the workflows selected by a
+// label selector, so that a workflow can consume the newest result of an upstream workflow
+type WorkflowOutputsValueFrom struct {
+	// Selector is the label selector of the workflows, e.g. `workflows.argoproj.io/workflow-template=nightly-build`
+	Selector string `json:"selector" protobuf:"bytes,1,opt,name=selector"`
+	// Output is the name of one of the outputs the workflow declares, or of one of its global output parameters
+	Output string `json:"output" protobuf:"bytes,2,opt,name=output"`
+	// Latest is the phase of the workflows to take the latest finished of: Succeeded (default), Failed or Error
+	Latest WorkflowPhase `json:"latest,omitempty" protobuf:"bytes,3,opt,name=latest,casttype=WorkflowPhase"`
+}
+
+// GetLatest returns the phase of the workflows to take the latest of, defaulting to Succeeded
+func (v WorkflowOutputsValueFrom) GetLatest() WorkflowPhase {
+	if v.Latest == "" {
+		return WorkflowSucceeded
+	}
+	return v.Latest
+}
@@ -11,6 +11,7 @@ import (
 	"reflect"
 	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -43,6 +44,7 @@ const (
 	TemplateTypeData         TemplateType = "Data"
 	TemplateTypeHTTP         TemplateType = "HTTP"
 	TemplateTypePlugin       TemplateType = "Plugin"
+	TemplateTypeManual       TemplateType = "Manual"
 	TemplateTypeUnknown      TemplateType = "Unknown"
 )
 
@@ -431,6 +433,31 @@ type WorkflowSpec struct {
 	// ArtifactGC describes the strategy to use when deleting artifacts from completed or deleted workflows (applies to all output Artifacts
 	// unless Artifact.ArtifactGC is specified, which overrides this)
 	ArtifactGC *WorkflowLevelArtifactGC `json:"artifactGC,omitempty" protobuf:"bytes,43,opt,name=artifactGC"`
+
+	// ImagePreflight checks that the workflow's images exist and can be pulled before it starts, and records their digests
+	ImagePreflight *ImagePreflight `json:"imagePreflight,omitempty" protobuf:"bytes,44,opt,name=imagePreflight"`
+
+	// ExitHooksDeadlineSeconds guarantees the workflow's exit handler and the exit hooks of its steps and tasks are run
+	// even if the workflow is terminated or exceeds activeDeadlineSeconds, and gives them this many seconds to complete
+	// from then. Exit hooks that do not complete in time are failed and the ExitHooksNotRun condition is set.
+	ExitHooksDeadlineSeconds *int64 `json:"exitHooksDeadlineSeconds,omitempty" protobuf:"bytes,45,opt,name=exitHooksDeadlineSeconds"`
+
+	// Outputs declares the outputs of the workflow, so that consumers can get them with `argo outputs`, rather than
+	// from the outputs of its nodes
+	Outputs []WorkflowOutput `json:"outputs,omitempty" protobuf:"bytes,46,rep,name=outputs"`
+
+	// StrictVariables fails validation on any variable reference that cannot be resolved, such as a misspelled
+	// {{inputs.parameters.imge}}, rather than leaving references it does not recognise as they are
+	StrictVariables bool `json:"strictVariables,omitempty" protobuf:"varint,47,opt,name=strictVariables"`
+
+	// ArtifactParallelism limits the number of pods that load input artifacts, or save output artifacts or logs, that
+	// can run at the same time in the workflow, so that a large fan-out stays within the request rate limits of the
+	// artifact repository
+	ArtifactParallelism *int64 `json:"artifactParallelism,omitempty" protobuf:"bytes,48,opt,name=artifactParallelism"`
+
+	// ArtifactBundle collects the output artifacts of the workflow's nodes into a single archive when the workflow
+	// completes, and exposes it as a workflow output artifact
+	ArtifactBundle *ArtifactBundle `json:"artifactBundle,omitempty" protobuf:"bytes,49,opt,name=artifactBundle"`
 }
 
 type LabelValueFrom struct {
@@ -659,6 +686,35 @@ type Template struct {
 	// Plugin is a plugin template
 	Plugin *Plugin `json:"plugin,omitempty" protobuf:"bytes,43,opt,name=plugin"`
 
+	// Heartbeat requires the main container to show signs of life, by writing to the progress file or its logs,
+	// within a timeout. A step that stops doing so is considered hung: its containers are killed and the node fails,
+	// so that the retry strategy can be applied.
+	Heartbeat *Heartbeat `json:"heartbeat,omitempty" protobuf:"bytes,44,opt,name=heartbeat"`
+
+	// Manual is a task done outside the cluster, which the workflow waits for someone to complete
+	Manual *ManualTemplate `json:"manual,omitempty" protobuf:"bytes,45,opt,name=manual"`
+
+	// ArtifactBandwidth limits the rate at which the executor uploads and downloads this template's artifacts
+	ArtifactBandwidth *ArtifactBandwidth `json:"artifactBandwidth,omitempty" protobuf:"bytes,46,opt,name=artifactBandwidth"`
+
+	// Critical protects this template's pods from voluntary disruptions, such as node drains, with a pod disruption
+	// budget. If any template is critical, the workflow's budget only covers critical pods, and one that allows no
+	// disruptions is created if the workflow does not specify one.
+	Critical bool `json:"critical,omitempty" protobuf:"varint,47,opt,name=critical"`
+
+	// SecurityProfile is the name of a security profile in the controller's ConfigMap, such as a seccomp profile,
+	// AppArmor profile and dropped capabilities, which is applied to every container in this template's pod
+	SecurityProfile string `json:"securityProfile,omitempty" protobuf:"bytes,48,opt,name=securityProfile"`
+
+	// NUMA configures this template's pod for latency-sensitive work, with exclusive CPUs, huge pages and NUMA
+	// topology hints, without the need for a pod spec patch
+	NUMA *NUMA `json:"numa,omitempty" protobuf:"bytes,49,opt,name=numa"`
+
+	// ArtifactCredentials mounts short-lived credentials, that only give access to the workflow's key prefix in its S3
+	// artifact repository, into the main containers, so that they can read and write their workflow's artifacts
+	// without the artifact repository's own credentials
+	ArtifactCredentials bool `json:"artifactCredentials,omitempty" protobuf:"varint,50,opt,name=artifactCredentials"`
+
 	// Volumes is a list of volumes that can be mounted by containers in a template.
 	// +patchStrategy=merge
 	// +patchMergeKey=name
@@ -875,6 +931,18 @@ type Parameter struct {
 
 	// Description is the parameter description
 	Description *AnyString `json:"description,omitempty" protobuf:"bytes,7,opt,name=description"`
+
+	// Deprecated marks the parameter as deprecated, so that the Argo Server warns when workflows are submitted with it
+	Deprecated *ParameterDeprecation `json:"deprecated,omitempty" protobuf:"bytes,8,opt,name=deprecated"`
+}
+
+// ParameterDeprecation describes why a parameter is deprecated and what to use instead
+type ParameterDeprecation struct {
+	// Message explains why the parameter is deprecated
+	Message string `json:"message,omitempty" protobuf:"bytes,1,opt,name=message"`
+
+	// ReplacedBy is the name of the parameter to use instead
+	ReplacedBy string `json:"replacedBy,omitempty" protobuf:"bytes,2,opt,name=replacedBy"`
 }
 
 // ValueFrom describes a location in which to obtain the value to a parameter
@@ -906,6 +974,10 @@ type ValueFrom struct {
 
 	// Expression, if defined, is evaluated to specify the value for the parameter
 	Expression string `json:"expression,omitempty" protobuf:"bytes,8,rep,name=expression"`
+
+	// WorkflowOutputs takes the value of a workflow argument from an output of the latest of the workflows a label
+	// selector selects, when the workflow is submitted to the Argo Server
+	WorkflowOutputs *WorkflowOutputsValueFrom `json:"workflowOutputs,omitempty" protobuf:"bytes,10,opt,name=workflowOutputs"`
 }
 
 func (p *Parameter) HasValue() bool {
@@ -922,6 +994,21 @@ func (p *Parameter) GetValue() string {
 	return ""
 }
 
+// GetDeprecationMessage returns the message to show when the parameter is used, or "" if it is not deprecated
+func (p *Parameter) GetDeprecationMessage() string {
+	if p.Deprecated == nil {
+		return ""
+	}
+	message := fmt.Sprintf("parameter %q is deprecated", p.Name)
+	if p.Deprecated.Message != "" {
+		message += ": " + p.Deprecated.Message
+	}
+	if p.Deprecated.ReplacedBy != "" {
+		message += fmt.Sprintf(", use %q instead", p.Deprecated.ReplacedBy)
+	}
+	return message
+}
+
 // SuppliedValueFrom is a placeholder for a value to be filled in directly, either through the CLI, API, etc.
 type SuppliedValueFrom struct{}
 
@@ -933,6 +1020,10 @@ type Artifact struct {
 	// Path is the container path to the artifact
 	Path string `json:"path,omitempty" protobuf:"bytes,2,opt,name=path"`
 
+	// Paths are glob patterns, relative to path, of the files to include in an output artifact, e.g. `reports/**/*.xml`.
+	// `**` matches any number of directories. The matching files are packaged into one archive.
+	Paths []string `json:"paths,omitempty" protobuf:"bytes,16,rep,name=paths"`
+
 	// mode bits to use on this file, must be a value between 0 and 0777
 	// set when loading input artifacts.
 	Mode *int32 `json:"mode,omitempty" protobuf:"varint,3,opt,name=mode"`
@@ -967,6 +1058,35 @@ type Artifact struct {
 
 	// Has this been deleted?
 	Deleted bool `json:"deleted,omitempty" protobuf:"varint,13,opt,name=deleted"`
+
+	// IfNotPresent skips uploading an output artifact if an object with the same key already exists
+	IfNotPresent *ArtifactIfNotPresent `json:"ifNotPresent,omitempty" protobuf:"bytes,14,opt,name=ifNotPresent"`
+
+	// UploadSkipped is set if the upload was skipped because the artifact was already present
+	UploadSkipped bool `json:"uploadSkipped,omitempty" protobuf:"varint,15,opt,name=uploadSkipped"`
+
+	// Mount, for an input artifact, mounts it into the main container with the CSI driver configured for its
+	// repository instead of downloading it, so that it is read on demand. The artifact must not be archived.
+	Mount bool `json:"mount,omitempty" protobuf:"varint,17,opt,name=mount"`
+
+	// SizeBytes is the size of the output artifact that was saved, after it was archived
+	SizeBytes int64 `json:"sizeBytes,omitempty" protobuf:"varint,18,opt,name=sizeBytes"`
+
+	// Checksum is the SHA-256 checksum of the output artifact that was saved, after it was archived. An input artifact
+	// with a checksum is verified against it when it is loaded, and the node artifact cache looks artifacts up by it.
+	Checksum string `json:"checksum,omitempty" protobuf:"bytes,19,opt,name=checksum"`
+
+	// ObjectMetadata is saved with the objects of an output artifact, so that the lifecycle rules and cost allocation
+	// of the storage can use it. It is saved as the tags of S3 objects and the custom metadata of GCS objects.
+	// It can use workflow and template variables, e.g. `{{workflow.name}}`.
+	ObjectMetadata map[string]string `json:"objectMetadata,omitempty" protobuf:"bytes,20,rep,name=objectMetadata"`
+}
+
+// ArtifactIfNotPresent configures when an output artifact already in the repository is not uploaded again
+type ArtifactIfNotPresent struct {
+	// Checksum also requires the existing object to have the same SHA-256 checksum as the artifact. The object is
+	// downloaded to calculate it.
+	Checksum bool `json:"checksum,omitempty" protobuf:"varint,1,opt,name=checksum"`
 }
 
 // ArtifactGC returns the ArtifactGC that was defined by the artifact.  If none was provided, a default value is returned.
@@ -1147,6 +1267,9 @@ type ArtifactLocation struct {
 
 	// Azure contains Azure Storage artifact location details
 	Azure *AzureArtifact `json:"azure,omitempty" protobuf:"bytes,10,opt,name=azure"`
+
+	// CompressLogs indicates if the archived container logs should be stored gzip-compressed, as <container>.log.gz
+	CompressLogs *bool `json:"compressLogs,omitempty" protobuf:"varint,11,opt,name=compressLogs"`
 }
 
 func (a *ArtifactLocation) Get() (ArtifactLocationType, error) {
@@ -1255,6 +1378,10 @@ func (a *ArtifactLocation) IsArchiveLogs() bool {
 	return a != nil && a.ArchiveLogs != nil && *a.ArchiveLogs
 }
 
+func (a *ArtifactLocation) IsCompressLogs() bool {
+	return a != nil && a.CompressLogs != nil && *a.CompressLogs
+}
+
 func (a *ArtifactLocation) GetKey() (string, error) {
 	v, err := a.Get()
 	if err != nil {
@@ -1793,6 +1920,37 @@ func (n Nodes) GetName(key string) (string, error) {
 	}
 	return val.Name, nil
 }
+
+// GetAttempt returns the retry attempt, starting from one, of the node with the name. A node is in the attempt of the
+// closest retry node its name is nested in, e.g. "my-wf[0].step(1)[0].inner" is in the second attempt of
+// "my-wf[0].step", and in attempt one if it is not nested in a retry node.
+func (n Nodes) GetAttempt(nodeName string) int {
+	attempt, _ := n.GetAttempts(nodeName)
+	return attempt
+}
+
+// GetAttempts returns the retry attempt of the node with the name, as GetAttempt does, and the number of attempts of the
+// closest retry node its name is nested in so far, which is one if it is not nested in a retry node.
+func (n Nodes) GetAttempts(nodeName string) (int, int) {
+	attempt, attempts, closest := 1, 1, 0
+	for _, retryNode := range n {
+		if retryNode.Type != NodeTypeRetry || len(retryNode.Name) <= closest || !strings.HasPrefix(nodeName, retryNode.Name+"(") {
+			continue
+		}
+		index, _, ok := strings.Cut(strings.TrimPrefix(nodeName, retryNode.Name+"("), ")")
+		if !ok {
+			continue
+		}
+		// other nodes nested in a retry node, e.g. the items of a loop "step(0:foo)", are not attempts
+		i, err := strconv.Atoi(index)
+		if err != nil {
+			continue
+		}
+		attempt, attempts, closest = i+1, len(retryNode.Children), len(retryNode.Name)
+	}
+	return attempt, attempts
+}
+
 func NodeWithName(name string) func(n NodeStatus) bool {
 	return func(n NodeStatus) bool { return n.Name == name }
 }
@@ -1898,6 +2056,10 @@ type WorkflowStatus struct {
 	// EstimatedDuration in seconds.
 	EstimatedDuration EstimatedDuration `json:"estimatedDuration,omitempty" protobuf:"varint,16,opt,name=estimatedDuration,casttype=EstimatedDuration"`
 
+	// EstimatedFinishedAt is when the workflow is estimated to finish while it runs, from how long previous runs of the
+	// same workflow template, cluster workflow template or cron workflow ran for after its latest node started
+	EstimatedFinishedAt *metav1.Time `json:"estimatedFinishedAt,omitempty" protobuf:"bytes,22,opt,name=estimatedFinishedAt"`
+
 	// Progress to completion
 	Progress Progress `json:"progress,omitempty" protobuf:"bytes,17,opt,name=progress,casttype=Progress"`
 
@@ -1941,6 +2103,51 @@ type WorkflowStatus struct {
 
 	// ArtifactGCStatus maintains the status of Artifact Garbage Collection
 	ArtifactGCStatus *ArtGCStatus `json:"artifactGCStatus,omitempty" protobuf:"bytes,19,opt,name=artifactGCStatus"`
+
+	// ResolvedImages are the digests the workflow's images resolved to, when image preflight is enabled
+	ResolvedImages []ResolvedImage `json:"resolvedImages,omitempty" protobuf:"bytes,20,rep,name=resolvedImages"`
+
+	// ExitHooksDeadline is when the exit handler and exit hooks must complete by. It is set when a workflow with
+	// exitHooksDeadlineSeconds is shut down or exceeds its deadline.
+	ExitHooksDeadline *metav1.Time `json:"exitHooksDeadline,omitempty" protobuf:"bytes,21,opt,name=exitHooksDeadline"`
+}
+
+// ImagePreflight resolves the images of the workflow's templates to digests when it is submitted, so a missing image
+// or a pull secret without access fails the workflow straight away, rather than when the pod is scheduled.
+// Images that use template variables are resolved when their pod is created.
+type ImagePreflight struct {
+	// PinDigests runs pods with the digest their image resolved to, so the workflow keeps using the same images
+	// even if their tags are pushed to while it runs
+	PinDigests bool `json:"pinDigests,omitempty" protobuf:"varint,1,opt,name=pinDigests"`
+}
+
+// ResolvedImage is the digest an image resolved to
+type ResolvedImage struct {
+	// Image is the image as specified in the template
+	Image string `json:"image" protobuf:"bytes,1,opt,name=image"`
+	// Digest is the digest the image resolved to, e.g. "sha256:..."
+	Digest string `json:"digest" protobuf:"bytes,2,opt,name=digest"`
+}
+
+// GetResolvedImage returns the digest the image resolved to, or "" if it has not been resolved
+func (ws *WorkflowStatus) GetResolvedImage(image string) string {
+	for _, i := range ws.ResolvedImages {
+		if i.Image == image {
+			return i.Digest
+		}
+	}
+	return ""
+}
+
+// SetResolvedImage records the digest an image resolved to
+func (ws *WorkflowStatus) SetResolvedImage(image, digest string) {
+	for i := range ws.ResolvedImages {
+		if ws.ResolvedImages[i].Image == image {
+			ws.ResolvedImages[i].Digest = digest
+			return
+		}
+	}
+	ws.ResolvedImages = append(ws.ResolvedImages, ResolvedImage{Image: image, Digest: digest})
 }
 
 func (ws *WorkflowStatus) IsOffloadNodeStatus() bool {
@@ -1972,6 +2179,32 @@ const (
 	RetryPolicyOnTransientError RetryPolicy = "OnTransientError"
 )
 
+// NodeFailureClass is why the pod of a node failed
+type NodeFailureClass string
+
+const (
+	// NodeFailureClassEvicted is a pod that was evicted or preempted
+	NodeFailureClassEvicted NodeFailureClass = "Evicted"
+	// NodeFailureClassNodeLost is a pod that was lost with the node it ran on, or deleted
+	NodeFailureClassNodeLost NodeFailureClass = "NodeLost"
+	// NodeFailureClassImagePull is a pod whose image could not be pulled
+	NodeFailureClassImagePull NodeFailureClass = "ImagePull"
+	// NodeFailureClassOOMKilled is a pod with a container that was killed because it ran out of memory
+	NodeFailureClassOOMKilled NodeFailureClass = "OOMKilled"
+	// NodeFailureClassApplication is a pod whose main container exited with a non-zero exit code
+	NodeFailureClassApplication NodeFailureClass = "Application"
+)
+
+// Infrastructure returns whether the class is a failure of the infrastructure the pod ran on, rather than of the
+// application
+func (c NodeFailureClass) Infrastructure() bool {
+	switch c {
+	case NodeFailureClassEvicted, NodeFailureClassNodeLost, NodeFailureClassImagePull, NodeFailureClassOOMKilled:
+		return true
+	}
+	return false
+}
+
 // Backoff is a backoff strategy to use within retryStrategy
 type Backoff struct {
 	// Duration is the amount to back off. Default unit is seconds, but could also be a duration (e.g. "2m", "1h")
@@ -2009,6 +2242,23 @@ type RetryStrategy struct {
 	// Expression is a condition expression for when a node will be retried. If it evaluates to false, the node will not
 	// be retried and the retry strategy will be ignored
 	Expression string `json:"expression,omitempty" protobuf:"bytes,5,opt,name=expression"`
+
+	// FailureClasses are the failure classes of the nodes that are retried with the OnTransientError policy. Defaults to
+	// the infrastructure classes: Evicted, NodeLost, ImagePull and OOMKilled.
+	FailureClasses []NodeFailureClass `json:"failureClasses,omitempty" protobuf:"bytes,6,rep,name=failureClasses,casttype=NodeFailureClass"`
+}
+
+// RetriesFailureClass returns whether a node that failed with the class is retried with the OnTransientError policy
+func (s RetryStrategy) RetriesFailureClass(class NodeFailureClass) bool {
+	if len(s.FailureClasses) == 0 {
+		return class.Infrastructure()
+	}
+	for _, c := range s.FailureClasses {
+		if c == class {
+			return true
+		}
+	}
+	return false
 }
 
 // RetryPolicyActual gets the active retry policy for a strategy.
@@ -2085,14 +2335,19 @@ func ResourceQuantityDenominator(r apiv1.ResourceName) *resource.Quantity {
 
 type Conditions []Condition
 
+// UpsertCondition adds the condition, or replaces the condition of the same type. Unless the condition has a
+// LastTransitionTime, it is kept from the replaced condition if the status has not changed, and is now otherwise.
 func (cs *Conditions) UpsertCondition(condition Condition) {
 	for index, wfCondition := range *cs {
 		if wfCondition.Type == condition.Type {
-			(*cs)[index] = condition
+			if condition.LastTransitionTime == nil && wfCondition.Status == condition.Status {
+				condition.LastTransitionTime = wfCondition.LastTransitionTime
+			}
+			(*cs)[index] = condition.withLastTransitionTime()
 			return
 		}
 	}
-	*cs = append(*cs, condition)
+	*cs = append(*cs, condition.withLastTransitionTime())
 }
 
 func (cs *Conditions) UpsertConditionMessage(condition Condition) {
@@ -2102,7 +2357,17 @@ func (cs *Conditions) UpsertConditionMessage(condition Condition) {
 			return
 		}
 	}
-	*cs = append(*cs, condition)
+	*cs = append(*cs, condition.withLastTransitionTime())
+}
+
+// Get returns the condition of the type, or nil if there is none
+func (cs Conditions) Get(conditionType ConditionType) *Condition {
+	for i := range cs {
+		if cs[i].Type == conditionType {
+			return &cs[i]
+		}
+	}
+	return nil
 }
 
 func (cs *Conditions) JoinConditions(conditions *Conditions) {
@@ -2130,12 +2395,23 @@ func (cs *Conditions) DisplayString(fmtStr string, iconMap map[ConditionType]str
 		if conditionMessage == "" {
 			conditionMessage = string(condition.Status)
 		}
+		if condition.Reason != "" {
+			conditionMessage = fmt.Sprintf("%s: %s", condition.Reason, conditionMessage)
+		}
 		conditionPrefix := fmt.Sprintf("%s %s", iconMap[condition.Type], string(condition.Type))
 		out += fmt.Sprintf(fmtStr, conditionPrefix, conditionMessage)
 	}
 	return out
 }
 
+func (c Condition) withLastTransitionTime() Condition {
+	if c.LastTransitionTime == nil {
+		now := metav1.Now()
+		c.LastTransitionTime = &now
+	}
+	return c
+}
+
 type ConditionType string
 
 const (
@@ -2143,6 +2419,9 @@ const (
 	ConditionTypeCompleted ConditionType = "Completed"
 	// ConditionTypePodRunning any workflow pods are currently running
 	ConditionTypePodRunning ConditionType = "PodRunning"
+	// ConditionTypeSubmissionPending is a workflow whose processing has been postponed, e.g. because too many
+	// workflows are already running
+	ConditionTypeSubmissionPending ConditionType = "SubmissionPending"
 	// ConditionTypeSpecWarning is a warning on the current application spec
 	ConditionTypeSpecWarning ConditionType = "SpecWarning"
 	// ConditionTypeSpecWarning is an error on the current application spec
@@ -2151,6 +2430,54 @@ const (
 	ConditionTypeMetricsError ConditionType = "MetricsError"
 	//ConditionTypeArtifactGCError is an error on artifact garbage collection
 	ConditionTypeArtifactGCError ConditionType = "ArtifactGCError"
+	// ConditionTypeSynchronizationWaiting is the position of the workflow in the queue of a lock it is waiting for
+	ConditionTypeSynchronizationWaiting ConditionType = "SynchronizationWaiting"
+	// ConditionTypeExitHooksNotRun is exit hooks that were skipped, or did not complete within the exit hooks deadline
+	ConditionTypeExitHooksNotRun ConditionType = "ExitHooksNotRun"
+	// ConditionTypeStoppedByPolicy is a workflow that was declined or stopped by a policy, rather than failing by itself.
+	// Its reason is one of the PolicyReason codes.
+	ConditionTypeStoppedByPolicy ConditionType = "StoppedByPolicy"
+)
+
+// The reasons of conditions that are not caused by a policy
+const (
+	// ConditionReasonPodsRunning is the reason of the PodRunning condition when any of the workflow's pods are running
+	ConditionReasonPodsRunning = "PodsRunning"
+	// ConditionReasonNoPodsRunning is the reason of the PodRunning condition when none of the workflow's pods are running
+	ConditionReasonNoPodsRunning = "NoPodsRunning"
+	// ConditionReasonRetried is the reason of the Completed condition of a workflow that was retried
+	ConditionReasonRetried = "Retried"
+	// ConditionReasonResubmitted is the reason of the Completed condition of a workflow that was resubmitted
+	ConditionReasonResubmitted = "Resubmitted"
+	// ConditionReasonParallelismLimit is the reason of the SubmissionPending condition of a workflow postponed
+	// because too many workflows are already running
+	ConditionReasonParallelismLimit = "ParallelismLimit"
+	// ConditionReasonAdmitted is the reason of the SubmissionPending condition of a workflow that was postponed, and
+	// is now being processed
+	ConditionReasonAdmitted = "Admitted"
+	// ConditionReasonArtifactGCFailed is the reason of the ArtifactGCError condition
+	ConditionReasonArtifactGCFailed = "ArtifactGCFailed"
+)
+
+// PolicyReason is a machine-readable code for the policy that declined or stopped a workflow
+type PolicyReason string
+
+const (
+	// PolicyReasonWorkflowRestricted is a workflow declined by the controller's workflow restrictions
+	PolicyReasonWorkflowRestricted PolicyReason = "WorkflowRestricted"
+	// PolicyReasonActiveDeadlineExceeded is a workflow stopped because it ran out of its activeDeadlineSeconds
+	PolicyReasonActiveDeadlineExceeded PolicyReason = "ActiveDeadlineExceeded"
+	// PolicyReasonResourceQuotaExceeded is a workflow whose pods could not be created within its deadline because of a
+	// resource quota
+	PolicyReasonResourceQuotaExceeded PolicyReason = "ResourceQuotaExceeded"
+	// PolicyReasonPreempted is a workflow that failed because a pod was preempted by a pod with a higher priority
+	PolicyReasonPreempted PolicyReason = "Preempted"
+	// PolicyReasonEvicted is a workflow that failed because a pod was evicted, e.g. because its node was low on
+	// resources or was drained
+	PolicyReasonEvicted PolicyReason = "Evicted"
+	// PolicyReasonGuardrailExceeded is a workflow that exceeded the controller's guardrails, e.g. its maximum number of
+	// nodes
+	PolicyReasonGuardrailExceeded PolicyReason = "GuardrailExceeded"
 )
 
 type Condition struct {
@@ -2162,6 +2489,12 @@ type Condition struct {
 
 	// Message is the condition message
 	Message string `json:"message,omitempty" protobuf:"bytes,3,opt,name=message"`
+
+	// Reason is a machine-readable code for the condition, e.g. the PolicyReason of the StoppedByPolicy condition
+	Reason string `json:"reason,omitempty" protobuf:"bytes,4,opt,name=reason"`
+
+	// LastTransitionTime is the last time the status of the condition changed
+	LastTransitionTime *metav1.Time `json:"lastTransitionTime,omitempty" protobuf:"bytes,5,opt,name=lastTransitionTime"`
 }
 
 // NodeStatus contains status information about an individual node in the workflow
@@ -2257,6 +2590,35 @@ type NodeStatus struct {
 
 	// SynchronizationStatus is the synchronization status of the node
 	SynchronizationStatus *NodeSynchronizationStatus `json:"synchronizationStatus,omitempty" protobuf:"bytes,25,opt,name=synchronizationStatus"`
+
+	// ManualTask holds the instructions, assignees and due date of a manual task node
+	ManualTask *ManualTaskStatus `json:"manualTask,omitempty" protobuf:"bytes,28,opt,name=manualTask"`
+
+	// Paused is true if the branch of a DAG that starts at this node is paused: none of the tasks of the branch that
+	// have not started yet are started until it is resumed
+	Paused bool `json:"paused,omitempty" protobuf:"varint,29,opt,name=paused"`
+
+	// FailureClass is why the pod of a failed or errored pod node failed: because of the infrastructure it ran on, or
+	// because of the application
+	FailureClass NodeFailureClass `json:"failureClass,omitempty" protobuf:"bytes,30,opt,name=failureClass,casttype=NodeFailureClass"`
+
+	// Approval holds the approvals of a suspend node that needs approvals to resume
+	Approval *ApprovalStatus `json:"approval,omitempty" protobuf:"bytes,31,opt,name=approval"`
+
+	// Notes are the notes users annotated the node with, in the order they were added
+	Notes []NodeNote `json:"notes,omitempty" protobuf:"bytes,32,rep,name=notes"`
+}
+
+// NodeNote is a note a user annotated a node with, e.g. to keep the context of an operational action with the run
+type NodeNote struct {
+	// Note is the text of the note
+	Note string `json:"note" protobuf:"bytes,1,opt,name=note"`
+
+	// Author is the subject of the user who added the note, if the Argo Server authenticated them
+	Author string `json:"author,omitempty" protobuf:"bytes,2,opt,name=author"`
+
+	// AddedAt is when the note was added
+	AddedAt metav1.Time `json:"addedAt" protobuf:"bytes,3,opt,name=addedAt"`
 }
 
 func (n *NodeStatus) GetName() string {
@@ -2300,6 +2662,16 @@ func (ws WorkflowStatus) Failed() bool {
 	return ws.Phase == WorkflowFailed
 }
 
+// GetPolicyReason returns the reason the workflow was declined or stopped by a policy, or an empty string if it was not
+func (ws WorkflowStatus) GetPolicyReason() PolicyReason {
+	for _, c := range ws.Conditions {
+		if c.Type == ConditionTypeStoppedByPolicy {
+			return PolicyReason(c.Reason)
+		}
+	}
+	return ""
+}
+
 func (ws WorkflowStatus) StartTime() *metav1.Time {
 	return &ws.StartedAt
 }
@@ -2726,7 +3098,10 @@ type Header struct {
 	Name string `json:"name" protobuf:"bytes,1,opt,name=name"`
 
 	// Value is the literal value to use for the header
-	Value string `json:"value" protobuf:"bytes,2,opt,name=value"`
+	Value string `json:"value,omitempty" protobuf:"bytes,2,opt,name=value"`
+
+	// ValueFrom is the source of the header value, e.g. a secret holding an API token
+	ValueFrom *HTTPHeaderSource `json:"valueFrom,omitempty" protobuf:"bytes,3,opt,name=valueFrom"`
 }
 
 // BasicAuth describes the secret selectors required for basic authentication
@@ -2936,8 +3311,23 @@ type ResourceTemplate struct {
 	// 	"--validate=false"  # disable resource validation
 	// ]
 	Flags []string `json:"flags,omitempty" protobuf:"varint,7,opt,name=flags"`
+
+	// Operator is the operator that runs the resource: spark for a SparkApplication, ray for a RayJob, or flink for a
+	// FlinkDeployment. It defaults the success and failure conditions to the operator's status, streams the logs of
+	// the operator's pods to the main container, and allows output parameters named after the operator's status fields
+	// without a valueFrom.
+	Operator ResourceOperator `json:"operator,omitempty" protobuf:"bytes,9,opt,name=operator,casttype=ResourceOperator"`
 }
 
+// ResourceOperator is an operator that resource templates have built-in support for
+type ResourceOperator string
+
+const (
+	ResourceOperatorSpark ResourceOperator = "spark"
+	ResourceOperatorRay   ResourceOperator = "ray"
+	ResourceOperatorFlink ResourceOperator = "flink"
+)
+
 type ManifestFrom struct {
 	// Artifact contains the artifact to use
 	Artifact *Artifact `json:"artifact" protobuf:"bytes,1,opt,name=artifact"`
@@ -2975,6 +3365,9 @@ func (tmpl *Template) GetType() TemplateType {
 	if tmpl.Plugin != nil {
 		return TemplateTypePlugin
 	}
+	if tmpl.Manual != nil {
+		return TemplateTypeManual
+	}
 	return TemplateTypeUnknown
 }
 
@@ -2989,7 +3382,8 @@ func (tmpl *Template) GetNodeType() NodeType {
 		return NodeTypeDAG
 	case TemplateTypeSteps:
 		return NodeTypeSteps
-	case TemplateTypeSuspend:
+	case TemplateTypeSuspend, TemplateTypeManual:
+		// manual tasks are suspend nodes, so they can be completed the same way
 		return NodeTypeSuspend
 	case TemplateTypePlugin:
 		return NodeTypePlugin
@@ -3193,6 +3587,123 @@ type SuspendTemplate struct {
 	// Duration is the seconds to wait before automatically resuming a template. Must be a string. Default unit is seconds.
 	// Could also be a Duration, e.g.: "2m", "6h"
 	Duration string `json:"duration,omitempty" protobuf:"bytes,1,opt,name=duration"`
+
+	// Approvals is the number of approvals from distinct users needed to resume the node. Each resume by a user,
+	// other than the one who submitted the workflow, through the Argo Server is an approval.
+	Approvals int32 `json:"approvals,omitempty" protobuf:"varint,2,opt,name=approvals"`
+}
+
+// ManualTemplate is a template subtype for work done outside the cluster. The node waits until it is completed,
+// e.g. with `argo node complete`, which may also supply its output parameters.
+type ManualTemplate struct {
+	// Instructions for whoever completes the task, as markdown. Template variables are substituted.
+	Instructions string `json:"instructions,omitempty" protobuf:"bytes,1,opt,name=instructions"`
+
+	// Assignees are the users or groups expected to complete the task
+	Assignees []string `json:"assignees,omitempty" protobuf:"bytes,2,rep,name=assignees"`
+
+	// Due is how long after the task starts it should be completed. Must be a string. Default unit is seconds.
+	// Could also be a Duration, e.g.: "2h", "48h"
+	Due string `json:"due,omitempty" protobuf:"bytes,3,opt,name=due"`
+}
+
+// GetDue returns the parsed due duration
+func (m *ManualTemplate) GetDue() (time.Duration, error) {
+	var due time.Duration
+	// if no units are attached, the duration is in seconds
+	if seconds, err := strconv.Atoi(m.Due); err == nil {
+		due = time.Duration(seconds) * time.Second
+	} else if due, err = time.ParseDuration(m.Due); err != nil {
+		return 0, err
+	}
+	if due <= 0 {
+		return 0, fmt.Errorf("must be a positive duration")
+	}
+	return due, nil
+}
+
+// ArtifactBandwidth is the maximum rate at which artifacts are transferred, as a quantity of bytes per second,
+// e.g. "50Mi". All the artifacts of a step share this rate. Only the S3, HTTP and Artifactory drivers enforce it.
+type ArtifactBandwidth struct {
+	// Upload is the maximum rate for saving output artifacts
+	Upload string `json:"upload,omitempty" protobuf:"bytes,1,opt,name=upload"`
+
+	// Download is the maximum rate for loading input artifacts
+	Download string `json:"download,omitempty" protobuf:"bytes,2,opt,name=download"`
+}
+
+// GetUpload returns the upload limit in bytes per second, or zero if unlimited
+func (b *ArtifactBandwidth) GetUpload() (int64, error) {
+	return parseBandwidth(b.Upload)
+}
+
+// GetDownload returns the download limit in bytes per second, or zero if unlimited
+func (b *ArtifactBandwidth) GetDownload() (int64, error) {
+	return parseBandwidth(b.Download)
+}
+
+func parseBandwidth(s string) (int64, error) {
+	if s == "" {
+		return 0, nil
+	}
+	q, err := resource.ParseQuantity(s)
+	if err != nil {
+		return 0, err
+	}
+	if q.Sign() <= 0 {
+		return 0, fmt.Errorf("must be a positive quantity")
+	}
+	return q.Value(), nil
+}
+
+// ManualTaskStatus is the status of a manual task node
+type ManualTaskStatus struct {
+	// Instructions for whoever completes the task, with template variables substituted
+	Instructions string `json:"instructions,omitempty" protobuf:"bytes,1,opt,name=instructions"`
+
+	// Assignees are the users or groups expected to complete the task
+	Assignees []string `json:"assignees,omitempty" protobuf:"bytes,2,rep,name=assignees"`
+
+	// DueAt is when the task should be completed by
+	DueAt *metav1.Time `json:"dueAt,omitempty" protobuf:"bytes,3,opt,name=dueAt"`
+}
+
+// IsOverdue returns true if the task has a due date which has passed
+func (s *ManualTaskStatus) IsOverdue(now time.Time) bool {
+	return s != nil && s.DueAt != nil && now.After(s.DueAt.Time)
+}
+
+// ApprovalStatus is the status of the approvals of a suspend node
+type ApprovalStatus struct {
+	// Required is the number of approvals from distinct users needed to resume the node
+	Required int32 `json:"required,omitempty" protobuf:"varint,1,opt,name=required"`
+
+	// Approvals are the approvals given so far, in the order they were given
+	Approvals []Approval `json:"approvals,omitempty" protobuf:"bytes,2,rep,name=approvals"`
+}
+
+// Approval is who approved a suspend node, and when
+type Approval struct {
+	// Approver is the subject of the user who approved
+	Approver string `json:"approver" protobuf:"bytes,1,opt,name=approver"`
+
+	// ApprovedAt is when they approved
+	ApprovedAt metav1.Time `json:"approvedAt" protobuf:"bytes,2,opt,name=approvedAt"`
+}
+
+// IsApprovedBy returns true if the user with the subject has approved
+func (s *ApprovalStatus) IsApprovedBy(subject string) bool {
+	for _, a := range s.Approvals {
+		if a.Approver == subject {
+			return true
+		}
+	}
+	return false
+}
+
+// IsApproved returns true if there are enough approvals
+func (s *ApprovalStatus) IsApproved() bool {
+	return int32(len(s.Approvals)) >= s.Required
 }
 
 // GetArtifactByName returns an input artifact by its name
@@ -3251,6 +3762,19 @@ func (out *Outputs) GetArtifactByName(name string) *Artifact {
 	return out.Artifacts.GetArtifactByName(name)
 }
 
+// GetParameterByName returns an output parameter by its name
+func (out *Outputs) GetParameterByName(name string) *Parameter {
+	if out == nil {
+		return nil
+	}
+	for _, param := range out.Parameters {
+		if param.Name == name {
+			return &param
+		}
+	}
+	return nil
+}
+
 func (out *Outputs) HasResult() bool {
 	return out != nil && out.Result != nil
 }
@@ -3603,6 +4127,25 @@ type Memoize struct {
 	MaxAge string `json:"maxAge" protobuf:"bytes,3,opt,name=maxAge"`
 }
 
+// Heartbeat is a liveness check for long-running steps
+type Heartbeat struct {
+	// Timeout is the longest time (e.g. "10m") the main container may go without writing to the progress file
+	// or its logs before it is considered hung.
+	Timeout string `json:"timeout" protobuf:"bytes,1,opt,name=timeout"`
+}
+
+// GetTimeout returns the parsed heartbeat timeout
+func (h *Heartbeat) GetTimeout() (time.Duration, error) {
+	timeout, err := time.ParseDuration(h.Timeout)
+	if err != nil {
+		return 0, err
+	}
+	if timeout <= 0 {
+		return 0, fmt.Errorf("must be a positive duration")
+	}
+	return timeout, nil
+}
+
 // MemoizationStatus is the status of this memoized node
 type MemoizationStatus struct {
 	// Hit indicates whether this node was created from a cache entry
@@ -727,6 +727,44 @@ func TestNodes_Children(t *testing.T) {
 	})
 }
 
+func TestNodes_GetAttempt(t *testing.T) {
+	nodes := Nodes{
+		"wf":    NodeStatus{Name: "wf", Type: NodeTypeSteps},
+		"a":     NodeStatus{Name: "wf[0].a", Type: NodeTypeRetry},
+		"a-1-b": NodeStatus{Name: "wf[0].a(1)[0].b", Type: NodeTypeRetry},
+		"d":     NodeStatus{Name: "wf[1].d", Type: NodeTypeRetry},
+	}
+	for name, attempt := range map[string]int{
+		"wf":                 1,
+		"wf[0].a":            1,
+		"wf[0].a(0)":         1,
+		"wf[0].a(1)":         2,
+		"wf[0].a(1)[0].b":    2,
+		"wf[0].a(1)[0].b(0)": 1,
+		"wf[0].a(1)[0].b(2)": 3,
+		"wf[0].a(1)[0].c":    2,
+		"wf[1].d(0:foo)":     1,
+	} {
+		assert.Equal(t, attempt, nodes.GetAttempt(name), name)
+	}
+}
+
+func TestNodes_GetAttempts(t *testing.T) {
+	nodes := Nodes{
+		"a":     NodeStatus{Name: "wf[0].a", Type: NodeTypeRetry, Children: []string{"a-0", "a-1"}},
+		"a-1-b": NodeStatus{Name: "wf[0].a(1)[0].b", Type: NodeTypeRetry, Children: []string{"b-0", "b-1", "b-2"}},
+	}
+	for name, want := range map[string][2]int{
+		"wf":                 {1, 1},
+		"wf[0].a(0)":         {1, 2},
+		"wf[0].a(1)[0].c":    {2, 2},
+		"wf[0].a(1)[0].b(1)": {2, 3},
+	} {
+		attempt, attempts := nodes.GetAttempts(name)
+		assert.Equal(t, want, [2]int{attempt, attempts}, name)
+	}
+}
+
 func TestNestedChildren(t *testing.T) {
 	nodes := Nodes{
 		"node_0": NodeStatus{Name: "node_0", Phase: NodeFailed, Children: []string{"node_1", "node_2"}},
@@ -839,6 +877,24 @@ func TestWorkflowConditions_UpsertConditionMessage(t *testing.T) {
 	assert.Equal(t, "Hello, world!", wfCond[0].Message)
 }
 
+func TestWorkflowConditions_UpsertCondition(t *testing.T) {
+	cs := Conditions{}
+	cs.UpsertCondition(Condition{Type: ConditionTypePodRunning, Status: metav1.ConditionFalse})
+	c := cs.Get(ConditionTypePodRunning)
+	if assert.NotNil(t, c) && assert.NotNil(t, c.LastTransitionTime) {
+		// the time is kept while the status is unchanged
+		transitioned := metav1.NewTime(c.LastTransitionTime.Add(-time.Hour))
+		c.LastTransitionTime = &transitioned
+		cs.UpsertCondition(Condition{Type: ConditionTypePodRunning, Status: metav1.ConditionFalse, Message: "foo"})
+		assert.Equal(t, transitioned, *cs.Get(ConditionTypePodRunning).LastTransitionTime)
+		assert.Equal(t, "foo", cs.Get(ConditionTypePodRunning).Message)
+		cs.UpsertCondition(Condition{Type: ConditionTypePodRunning, Status: metav1.ConditionTrue})
+		assert.True(t, cs.Get(ConditionTypePodRunning).LastTransitionTime.After(transitioned.Time))
+	}
+	assert.Len(t, cs, 1)
+	assert.Nil(t, cs.Get(ConditionTypeCompleted))
+}
+
 func TestShutdownStrategy_ShouldExecute(t *testing.T) {
 	assert.False(t, ShutdownStrategyTerminate.ShouldExecute(true))
 	assert.False(t, ShutdownStrategyTerminate.ShouldExecute(false))
@@ -1662,3 +1718,41 @@ func TestInlineStore(t *testing.T) {
 		})
 	}
 }
+
+func TestManualTemplateGetDue(t *testing.T) {
+	due, err := (&ManualTemplate{Due: "60"}).GetDue()
+	assert.NoError(t, err)
+	assert.Equal(t, time.Minute, due)
+	due, err = (&ManualTemplate{Due: "2h"}).GetDue()
+	assert.NoError(t, err)
+	assert.Equal(t, 2*time.Hour, due)
+	for _, value := range []string{"0", "-5", "0s", "-1h"} {
+		_, err = (&ManualTemplate{Due: value}).GetDue()
+		assert.EqualError(t, err, "must be a positive duration", value)
+	}
+	_, err = (&ManualTemplate{Due: "soon"}).GetDue()
+	assert.Error(t, err)
+}
+
+func TestManualTaskStatusIsOverdue(t *testing.T) {
+	now := time.Now()
+	assert.False(t, (*ManualTaskStatus)(nil).IsOverdue(now))
+	assert.False(t, (&ManualTaskStatus{}).IsOverdue(now))
+	assert.False(t, (&ManualTaskStatus{DueAt: &metav1.Time{Time: now.Add(time.Minute)}}).IsOverdue(now))
+	assert.True(t, (&ManualTaskStatus{DueAt: &metav1.Time{Time: now.Add(-time.Minute)}}).IsOverdue(now))
+}
+
+func TestArtifactBandwidth(t *testing.T) {
+	bw := &ArtifactBandwidth{Upload: "50Mi", Download: ""}
+	upload, err := bw.GetUpload()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(50*1024*1024), upload)
+	download, err := bw.GetDownload()
+	assert.NoError(t, err)
+	assert.Zero(t, download)
+
+	_, err = (&ArtifactBandwidth{Upload: "fast"}).GetUpload()
+	assert.Error(t, err)
+	_, err = (&ArtifactBandwidth{Download: "0"}).GetDownload()
+	assert.EqualError(t, err, "must be a positive quantity")
+}
@@ -31,6 +31,46 @@ func (in *Amount) DeepCopy() *Amount {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Approval) DeepCopyInto(out *Approval) {
+	*out = *in
+	in.ApprovedAt.DeepCopyInto(&out.ApprovedAt)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Approval.
+func (in *Approval) DeepCopy() *Approval {
+	if in == nil {
+		return nil
+	}
+	out := new(Approval)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApprovalStatus) DeepCopyInto(out *ApprovalStatus) {
+	*out = *in
+	if in.Approvals != nil {
+		in, out := &in.Approvals, &out.Approvals
+		*out = make([]Approval, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApprovalStatus.
+func (in *ApprovalStatus) DeepCopy() *ApprovalStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ApprovalStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ArchiveStrategy) DeepCopyInto(out *ArchiveStrategy) {
 	*out = *in
@@ -125,6 +165,11 @@ func (in *ArtGCStatus) DeepCopy() *ArtGCStatus {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Artifact) DeepCopyInto(out *Artifact) {
 	*out = *in
+	if in.Paths != nil {
+		in, out := &in.Paths, &out.Paths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.Mode != nil {
 		in, out := &in.Mode, &out.Mode
 		*out = new(int32)
@@ -141,6 +186,18 @@ func (in *Artifact) DeepCopyInto(out *Artifact) {
 		*out = new(ArtifactGC)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.IfNotPresent != nil {
+		in, out := &in.IfNotPresent, &out.IfNotPresent
+		*out = new(ArtifactIfNotPresent)
+		**out = **in
+	}
+	if in.ObjectMetadata != nil {
+		in, out := &in.ObjectMetadata, &out.ObjectMetadata
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	return
 }
 
@@ -154,6 +211,48 @@ func (in *Artifact) DeepCopy() *Artifact {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArtifactBandwidth) DeepCopyInto(out *ArtifactBandwidth) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArtifactBandwidth.
+func (in *ArtifactBandwidth) DeepCopy() *ArtifactBandwidth {
+	if in == nil {
+		return nil
+	}
+	out := new(ArtifactBandwidth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArtifactBundle) DeepCopyInto(out *ArtifactBundle) {
+	*out = *in
+	if in.Artifacts != nil {
+		in, out := &in.Artifacts, &out.Artifacts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Templates != nil {
+		in, out := &in.Templates, &out.Templates
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArtifactBundle.
+func (in *ArtifactBundle) DeepCopy() *ArtifactBundle {
+	if in == nil {
+		return nil
+	}
+	out := new(ArtifactBundle)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ArtifactGC) DeepCopyInto(out *ArtifactGC) {
 	*out = *in
@@ -175,6 +274,64 @@ func (in *ArtifactGC) DeepCopy() *ArtifactGC {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArtifactGCCandidate) DeepCopyInto(out *ArtifactGCCandidate) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArtifactGCCandidate.
+func (in *ArtifactGCCandidate) DeepCopy() *ArtifactGCCandidate {
+	if in == nil {
+		return nil
+	}
+	out := new(ArtifactGCCandidate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArtifactGCFailure) DeepCopyInto(out *ArtifactGCFailure) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArtifactGCFailure.
+func (in *ArtifactGCFailure) DeepCopy() *ArtifactGCFailure {
+	if in == nil {
+		return nil
+	}
+	out := new(ArtifactGCFailure)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArtifactGCReport) DeepCopyInto(out *ArtifactGCReport) {
+	*out = *in
+	if in.Candidates != nil {
+		in, out := &in.Candidates, &out.Candidates
+		*out = make([]ArtifactGCCandidate, len(*in))
+		copy(*out, *in)
+	}
+	if in.Failures != nil {
+		in, out := &in.Failures, &out.Failures
+		*out = make([]ArtifactGCFailure, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArtifactGCReport.
+func (in *ArtifactGCReport) DeepCopy() *ArtifactGCReport {
+	if in == nil {
+		return nil
+	}
+	out := new(ArtifactGCReport)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ArtifactGCSpec) DeepCopyInto(out *ArtifactGCSpec) {
 	*out = *in
@@ -221,6 +378,22 @@ func (in *ArtifactGCStatus) DeepCopy() *ArtifactGCStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArtifactIfNotPresent) DeepCopyInto(out *ArtifactIfNotPresent) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArtifactIfNotPresent.
+func (in *ArtifactIfNotPresent) DeepCopy() *ArtifactIfNotPresent {
+	if in == nil {
+		return nil
+	}
+	out := new(ArtifactIfNotPresent)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ArtifactLocation) DeepCopyInto(out *ArtifactLocation) {
 	*out = *in
@@ -274,6 +447,11 @@ func (in *ArtifactLocation) DeepCopyInto(out *ArtifactLocation) {
 		*out = new(AzureArtifact)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.CompressLogs != nil {
+		in, out := &in.CompressLogs, &out.CompressLogs
+		*out = new(bool)
+		**out = **in
+	}
 	return
 }
 
@@ -370,6 +548,11 @@ func (in *ArtifactRepository) DeepCopyInto(out *ArtifactRepository) {
 		*out = new(AzureArtifactRepository)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.CompressLogs != nil {
+		in, out := &in.CompressLogs, &out.CompressLogs
+		*out = new(bool)
+		**out = **in
+	}
 	return
 }
 
@@ -871,6 +1054,10 @@ func (in *Column) DeepCopy() *Column {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Condition) DeepCopyInto(out *Condition) {
 	*out = *in
+	if in.LastTransitionTime != nil {
+		in, out := &in.LastTransitionTime, &out.LastTransitionTime
+		*out = (*in).DeepCopy()
+	}
 	return
 }
 
@@ -889,7 +1076,9 @@ func (in Conditions) DeepCopyInto(out *Conditions) {
 	{
 		in := &in
 		*out = make(Conditions, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 		return
 	}
 }
@@ -1030,6 +1219,54 @@ func (in *CreateS3BucketOptions) DeepCopy() *CreateS3BucketOptions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CronSchedule) DeepCopyInto(out *CronSchedule) {
+	*out = *in
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make([]Parameter, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CronSchedule.
+func (in *CronSchedule) DeepCopy() *CronSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(CronSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CronScheduleStatus) DeepCopyInto(out *CronScheduleStatus) {
+	*out = *in
+	if in.Active != nil {
+		in, out := &in.Active, &out.Active
+		*out = make([]v1.ObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastScheduledTime != nil {
+		in, out := &in.LastScheduledTime, &out.LastScheduledTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CronScheduleStatus.
+func (in *CronScheduleStatus) DeepCopy() *CronScheduleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CronScheduleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CronWorkflow) DeepCopyInto(out *CronWorkflow) {
 	*out = *in
@@ -1115,6 +1352,13 @@ func (in *CronWorkflowSpec) DeepCopyInto(out *CronWorkflowSpec) {
 		*out = new(metav1.ObjectMeta)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Schedules != nil {
+		in, out := &in.Schedules, &out.Schedules
+		*out = make([]CronSchedule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -1143,7 +1387,21 @@ func (in *CronWorkflowStatus) DeepCopyInto(out *CronWorkflowStatus) {
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make(Conditions, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastSuccessfulRunOutputs != nil {
+		in, out := &in.LastSuccessfulRunOutputs, &out.LastSuccessfulRunOutputs
+		*out = new(Outputs)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Schedules != nil {
+		in, out := &in.Schedules, &out.Schedules
+		*out = make([]CronScheduleStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	return
 }
@@ -1285,6 +1543,22 @@ func (in *DataSource) DeepCopy() *DataSource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataflowEdge) DeepCopyInto(out *DataflowEdge) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataflowEdge.
+func (in *DataflowEdge) DeepCopy() *DataflowEdge {
+	if in == nil {
+		return nil
+	}
+	out := new(DataflowEdge)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Event) DeepCopyInto(out *Event) {
 	*out = *in
@@ -1560,7 +1834,9 @@ func (in *HTTPArtifact) DeepCopyInto(out *HTTPArtifact) {
 	if in.Headers != nil {
 		in, out := &in.Headers, &out.Headers
 		*out = make([]Header, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.Auth != nil {
 		in, out := &in.Auth, &out.Auth
@@ -1687,6 +1963,11 @@ func (in HTTPHeaders) DeepCopy() HTTPHeaders {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Header) DeepCopyInto(out *Header) {
 	*out = *in
+	if in.ValueFrom != nil {
+		in, out := &in.ValueFrom, &out.ValueFrom
+		*out = new(HTTPHeaderSource)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1700,6 +1981,22 @@ func (in *Header) DeepCopy() *Header {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Heartbeat) DeepCopyInto(out *Heartbeat) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Heartbeat.
+func (in *Heartbeat) DeepCopy() *Heartbeat {
+	if in == nil {
+		return nil
+	}
+	out := new(Heartbeat)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Histogram) DeepCopyInto(out *Histogram) {
 	*out = *in
@@ -1721,6 +2018,38 @@ func (in *Histogram) DeepCopy() *Histogram {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HugePages) DeepCopyInto(out *HugePages) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HugePages.
+func (in *HugePages) DeepCopy() *HugePages {
+	if in == nil {
+		return nil
+	}
+	out := new(HugePages)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImagePreflight) DeepCopyInto(out *ImagePreflight) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImagePreflight.
+func (in *ImagePreflight) DeepCopy() *ImagePreflight {
+	if in == nil {
+		return nil
+	}
+	out := new(ImagePreflight)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Inputs) DeepCopyInto(out *Inputs) {
 	*out = *in
@@ -1900,6 +2229,52 @@ func (in *ManifestFrom) DeepCopy() *ManifestFrom {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManualTaskStatus) DeepCopyInto(out *ManualTaskStatus) {
+	*out = *in
+	if in.Assignees != nil {
+		in, out := &in.Assignees, &out.Assignees
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DueAt != nil {
+		in, out := &in.DueAt, &out.DueAt
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManualTaskStatus.
+func (in *ManualTaskStatus) DeepCopy() *ManualTaskStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ManualTaskStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManualTemplate) DeepCopyInto(out *ManualTemplate) {
+	*out = *in
+	if in.Assignees != nil {
+		in, out := &in.Assignees, &out.Assignees
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManualTemplate.
+func (in *ManualTemplate) DeepCopy() *ManualTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(ManualTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MemoizationStatus) DeepCopyInto(out *MemoizationStatus) {
 	*out = *in
@@ -2068,6 +2443,27 @@ func (in *MutexStatus) DeepCopy() *MutexStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NUMA) DeepCopyInto(out *NUMA) {
+	*out = *in
+	if in.HugePages != nil {
+		in, out := &in.HugePages, &out.HugePages
+		*out = make([]HugePages, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NUMA.
+func (in *NUMA) DeepCopy() *NUMA {
+	if in == nil {
+		return nil
+	}
+	out := new(NUMA)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodeFlag) DeepCopyInto(out *NodeFlag) {
 	*out = *in
@@ -2084,6 +2480,23 @@ func (in *NodeFlag) DeepCopy() *NodeFlag {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeNote) DeepCopyInto(out *NodeNote) {
+	*out = *in
+	in.AddedAt.DeepCopyInto(&out.AddedAt)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeNote.
+func (in *NodeNote) DeepCopy() *NodeNote {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeNote)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodeResult) DeepCopyInto(out *NodeResult) {
 	*out = *in
@@ -2162,6 +2575,23 @@ func (in *NodeStatus) DeepCopyInto(out *NodeStatus) {
 		*out = new(NodeSynchronizationStatus)
 		**out = **in
 	}
+	if in.ManualTask != nil {
+		in, out := &in.ManualTask, &out.ManualTask
+		*out = new(ManualTaskStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Approval != nil {
+		in, out := &in.Approval, &out.Approval
+		*out = new(ApprovalStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Notes != nil {
+		in, out := &in.Notes, &out.Notes
+		*out = make([]NodeNote, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -2479,6 +2909,11 @@ func (in *Parameter) DeepCopyInto(out *Parameter) {
 		*out = new(AnyString)
 		**out = **in
 	}
+	if in.Deprecated != nil {
+		in, out := &in.Deprecated, &out.Deprecated
+		*out = new(ParameterDeprecation)
+		**out = **in
+	}
 	return
 }
 
@@ -2492,6 +2927,22 @@ func (in *Parameter) DeepCopy() *Parameter {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ParameterDeprecation) DeepCopyInto(out *ParameterDeprecation) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ParameterDeprecation.
+func (in *ParameterDeprecation) DeepCopy() *ParameterDeprecation {
+	if in == nil {
+		return nil
+	}
+	out := new(ParameterDeprecation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Plugin) DeepCopyInto(out *Plugin) {
 	*out = *in
@@ -2593,6 +3044,22 @@ func (in *RawArtifact) DeepCopy() *RawArtifact {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResolvedImage) DeepCopyInto(out *ResolvedImage) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResolvedImage.
+func (in *ResolvedImage) DeepCopy() *ResolvedImage {
+	if in == nil {
+		return nil
+	}
+	out := new(ResolvedImage)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResourceTemplate) DeepCopyInto(out *ResourceTemplate) {
 	*out = *in
@@ -2696,6 +3163,11 @@ func (in *RetryStrategy) DeepCopyInto(out *RetryStrategy) {
 		*out = new(RetryAffinity)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.FailureClasses != nil {
+		in, out := &in.FailureClasses, &out.FailureClasses
+		*out = make([]NodeFailureClass, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -3195,6 +3667,26 @@ func (in *Template) DeepCopyInto(out *Template) {
 		*out = new(Plugin)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Heartbeat != nil {
+		in, out := &in.Heartbeat, &out.Heartbeat
+		*out = new(Heartbeat)
+		**out = **in
+	}
+	if in.Manual != nil {
+		in, out := &in.Manual, &out.Manual
+		*out = new(ManualTemplate)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ArtifactBandwidth != nil {
+		in, out := &in.ArtifactBandwidth, &out.ArtifactBandwidth
+		*out = new(ArtifactBandwidth)
+		**out = **in
+	}
+	if in.NUMA != nil {
+		in, out := &in.NUMA, &out.NUMA
+		*out = new(NUMA)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Volumes != nil {
 		in, out := &in.Volumes, &out.Volumes
 		*out = make([]v1.Volume, len(*in))
@@ -3395,6 +3887,11 @@ func (in *ValueFrom) DeepCopyInto(out *ValueFrom) {
 		*out = new(AnyString)
 		**out = **in
 	}
+	if in.WorkflowOutputs != nil {
+		in, out := &in.WorkflowOutputs, &out.WorkflowOutputs
+		*out = new(WorkflowOutputsValueFrom)
+		**out = **in
+	}
 	return
 }
 
@@ -3529,6 +4026,27 @@ func (in *WorkflowArtifactGCTaskList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkflowDataflow) DeepCopyInto(out *WorkflowDataflow) {
+	*out = *in
+	if in.Edges != nil {
+		in, out := &in.Edges, &out.Edges
+		*out = make([]DataflowEdge, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkflowDataflow.
+func (in *WorkflowDataflow) DeepCopy() *WorkflowDataflow {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkflowDataflow)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WorkflowEventBinding) DeepCopyInto(out *WorkflowEventBinding) {
 	*out = *in
@@ -3698,6 +4216,82 @@ func (in *WorkflowMetadata) DeepCopy() *WorkflowMetadata {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkflowOutput) DeepCopyInto(out *WorkflowOutput) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkflowOutput.
+func (in *WorkflowOutput) DeepCopy() *WorkflowOutput {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkflowOutput)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkflowOutputValue) DeepCopyInto(out *WorkflowOutputValue) {
+	*out = *in
+	if in.Artifact != nil {
+		in, out := &in.Artifact, &out.Artifact
+		*out = new(Artifact)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkflowOutputValue.
+func (in *WorkflowOutputValue) DeepCopy() *WorkflowOutputValue {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkflowOutputValue)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkflowOutputs) DeepCopyInto(out *WorkflowOutputs) {
+	*out = *in
+	if in.Outputs != nil {
+		in, out := &in.Outputs, &out.Outputs
+		*out = make([]WorkflowOutputValue, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkflowOutputs.
+func (in *WorkflowOutputs) DeepCopy() *WorkflowOutputs {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkflowOutputs)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkflowOutputsValueFrom) DeepCopyInto(out *WorkflowOutputsValueFrom) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkflowOutputsValueFrom.
+func (in *WorkflowOutputsValueFrom) DeepCopy() *WorkflowOutputsValueFrom {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkflowOutputsValueFrom)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WorkflowSpec) DeepCopyInto(out *WorkflowSpec) {
 	*out = *in
@@ -3886,6 +4480,31 @@ func (in *WorkflowSpec) DeepCopyInto(out *WorkflowSpec) {
 		*out = new(WorkflowLevelArtifactGC)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ImagePreflight != nil {
+		in, out := &in.ImagePreflight, &out.ImagePreflight
+		*out = new(ImagePreflight)
+		**out = **in
+	}
+	if in.ExitHooksDeadlineSeconds != nil {
+		in, out := &in.ExitHooksDeadlineSeconds, &out.ExitHooksDeadlineSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Outputs != nil {
+		in, out := &in.Outputs, &out.Outputs
+		*out = make([]WorkflowOutput, len(*in))
+		copy(*out, *in)
+	}
+	if in.ArtifactParallelism != nil {
+		in, out := &in.ArtifactParallelism, &out.ArtifactParallelism
+		*out = new(int64)
+		**out = **in
+	}
+	if in.ArtifactBundle != nil {
+		in, out := &in.ArtifactBundle, &out.ArtifactBundle
+		*out = new(ArtifactBundle)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -3904,6 +4523,10 @@ func (in *WorkflowStatus) DeepCopyInto(out *WorkflowStatus) {
 	*out = *in
 	in.StartedAt.DeepCopyInto(&out.StartedAt)
 	in.FinishedAt.DeepCopyInto(&out.FinishedAt)
+	if in.EstimatedFinishedAt != nil {
+		in, out := &in.EstimatedFinishedAt, &out.EstimatedFinishedAt
+		*out = (*in).DeepCopy()
+	}
 	if in.Nodes != nil {
 		in, out := &in.Nodes, &out.Nodes
 		*out = make(Nodes, len(*in))
@@ -3933,7 +4556,9 @@ func (in *WorkflowStatus) DeepCopyInto(out *WorkflowStatus) {
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make(Conditions, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.ResourcesDuration != nil {
 		in, out := &in.ResourcesDuration, &out.ResourcesDuration
@@ -3962,6 +4587,15 @@ func (in *WorkflowStatus) DeepCopyInto(out *WorkflowStatus) {
 		*out = new(ArtGCStatus)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ResolvedImages != nil {
+		in, out := &in.ResolvedImages, &out.ResolvedImages
+		*out = make([]ResolvedImage, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExitHooksDeadline != nil {
+		in, out := &in.ExitHooksDeadline, &out.ExitHooksDeadline
+		*out = (*in).DeepCopy()
+	}
 	return
 }
 
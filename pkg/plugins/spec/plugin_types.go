@@ -3,6 +3,7 @@ package spec
 import (
 	"fmt"
 
+	"github.com/Masterminds/semver/v3"
 	apiv1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -14,6 +15,11 @@ type Plugin struct {
 }
 
 func (p Plugin) Validate() error {
+	if p.Spec.Version != "" {
+		if _, err := semver.NewVersion(p.Spec.Version); err != nil {
+			return fmt.Errorf("version %q is not a semantic version: %w", p.Spec.Version, err)
+		}
+	}
 	if err := p.Spec.Sidecar.Validate(); err != nil {
 		return fmt.Errorf("sidecar is invalid: %w", err)
 	}
@@ -21,6 +27,8 @@ func (p Plugin) Validate() error {
 }
 
 type PluginSpec struct {
+	// Version is the semantic version of the plugin, which templates may constrain, e.g. `version: ">=1.2"`
+	Version string  `json:"version,omitempty"`
 	Sidecar Sidecar `json:"sidecar"`
 }
 
@@ -11,6 +11,8 @@ import (
 func TestPlugin_Validate(t *testing.T) {
 	err := Plugin{}.Validate()
 	assert.EqualError(t, err, "sidecar is invalid: at least one port is mandatory")
+	err = Plugin{Spec: PluginSpec{Version: "latest"}}.Validate()
+	assert.EqualError(t, err, `version "latest" is not a semantic version: Invalid Semantic Version`)
 }
 
 func TestSidecar_Validate(t *testing.T) {
@@ -28,6 +28,7 @@ import (
 
 	"github.com/argoproj/argo-workflows/v3"
 	"github.com/argoproj/argo-workflows/v3/config"
+	argoerrs "github.com/argoproj/argo-workflows/v3/errors"
 	"github.com/argoproj/argo-workflows/v3/persist/sqldb"
 	clusterwftemplatepkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/clusterworkflowtemplate"
 	cronworkflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/cronworkflow"
@@ -45,23 +46,32 @@ import (
 	"github.com/argoproj/argo-workflows/v3/server/auth/sso"
 	"github.com/argoproj/argo-workflows/v3/server/auth/webhook"
 	"github.com/argoproj/argo-workflows/v3/server/cache"
+	"github.com/argoproj/argo-workflows/v3/server/clusters"
 	"github.com/argoproj/argo-workflows/v3/server/clusterworkflowtemplate"
 	"github.com/argoproj/argo-workflows/v3/server/cronworkflow"
 	"github.com/argoproj/argo-workflows/v3/server/event"
+	eventqueue "github.com/argoproj/argo-workflows/v3/server/event/queue"
 	"github.com/argoproj/argo-workflows/v3/server/eventsource"
+	"github.com/argoproj/argo-workflows/v3/server/grpcweb"
 	"github.com/argoproj/argo-workflows/v3/server/info"
+	"github.com/argoproj/argo-workflows/v3/server/redaction"
 	"github.com/argoproj/argo-workflows/v3/server/sensor"
+	"github.com/argoproj/argo-workflows/v3/server/state"
 	"github.com/argoproj/argo-workflows/v3/server/static"
 	"github.com/argoproj/argo-workflows/v3/server/types"
 	"github.com/argoproj/argo-workflows/v3/server/workflow"
+	"github.com/argoproj/argo-workflows/v3/server/workflow/store"
 	"github.com/argoproj/argo-workflows/v3/server/workflowarchive"
 	"github.com/argoproj/argo-workflows/v3/server/workflowtemplate"
 	grpcutil "github.com/argoproj/argo-workflows/v3/util/grpc"
 	"github.com/argoproj/argo-workflows/v3/util/instanceid"
 	"github.com/argoproj/argo-workflows/v3/util/json"
+	"github.com/argoproj/argo-workflows/v3/util/logs"
 	"github.com/argoproj/argo-workflows/v3/workflow/artifactrepositories"
+	"github.com/argoproj/argo-workflows/v3/workflow/creator"
 	"github.com/argoproj/argo-workflows/v3/workflow/events"
 	"github.com/argoproj/argo-workflows/v3/workflow/hydrator"
+	wfutil "github.com/argoproj/argo-workflows/v3/workflow/util"
 
 	"github.com/sethvargo/go-limiter"
 	"github.com/sethvargo/go-limiter/httplimit"
@@ -85,11 +95,16 @@ type argoServer struct {
 	eventQueueSize           int
 	eventWorkerCount         int
 	eventAsyncDispatch       bool
+	eventQueue               string
+	eventQueueDir            string
+	eventDiskQueueSize       int
 	xframeOptions            string
 	accessControlAllowOrigin string
 	apiRateLimiter           limiter.Store
+	stateStore               state.Store
 	allowedLinkProtocol      []string
 	cache                    *cache.ResourceCache
+	clusters                 clusters.Registry
 }
 
 type ArgoServerOpts struct {
@@ -101,17 +116,27 @@ type ArgoServerOpts struct {
 	RestConfig *rest.Config
 	AuthModes  auth.Modes
 	// config map name
-	ConfigName               string
-	ManagedNamespace         string
-	SSONamespace             string
-	HSTS                     bool
-	EventOperationQueueSize  int
-	EventWorkerCount         int
-	EventAsyncDispatch       bool
+	ConfigName              string
+	ManagedNamespace        string
+	SSONamespace            string
+	HSTS                    bool
+	EventOperationQueueSize int
+	EventWorkerCount        int
+	EventAsyncDispatch      bool
+	// EventQueue is where async events are queued, one of "memory" or "disk"
+	EventQueue string
+	// EventQueueDir is the directory of the disk event queue
+	EventQueueDir string
+	// EventDiskQueueSize is how many events the disk event queue holds
+	EventDiskQueueSize       int
 	XFrameOptions            string
 	AccessControlAllowOrigin string
 	APIRateLimit             uint64
 	AllowedLinkProtocol      []string
+	// MultiCluster enables aggregation of the clusters registered via secrets in the namespace
+	MultiCluster bool
+	// ClusterName is the name of this cluster in aggregated responses
+	ClusterName string
 }
 
 func init() {
@@ -132,13 +157,21 @@ func getResourceCacheNamespace(opts ArgoServerOpts) string {
 func NewArgoServer(ctx context.Context, opts ArgoServerOpts) (*argoServer, error) {
 	configController := config.NewController(opts.Namespace, opts.ConfigName, opts.Clients.Kubernetes)
 	var resourceCache *cache.ResourceCache = nil
-	ssoIf := sso.NullSSO
-	if opts.AuthModes[auth.SSO] {
-		c, err := configController.Get(ctx)
+	c, err := configController.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var stateStore state.Store
+	if c.Redis != nil {
+		stateStore, err = state.NewRedis(ctx, c.Redis, opts.Clients.Kubernetes.CoreV1().Secrets(opts.Namespace))
 		if err != nil {
 			return nil, err
 		}
-		ssoIf, err = sso.New(c.SSO, opts.Clients.Kubernetes.CoreV1().Secrets(opts.Namespace), opts.BaseHRef, opts.TLSConfig != nil)
+		log.WithField("address", c.Redis.Address).Info("Sharing state with other replicas in Redis")
+	}
+	ssoIf := sso.NullSSO
+	if opts.AuthModes[auth.SSO] {
+		ssoIf, err = sso.New(c.SSO, opts.Clients.Kubernetes.CoreV1().Secrets(opts.Namespace), opts.BaseHRef, opts.TLSConfig != nil, stateStore)
 		if err != nil {
 			return nil, err
 		}
@@ -155,12 +188,25 @@ func NewArgoServer(ctx context.Context, opts ArgoServerOpts) (*argoServer, error
 	if err != nil {
 		return nil, err
 	}
-	store, err := memorystore.New(&memorystore.Config{
-		Tokens:   opts.APIRateLimit,
-		Interval: time.Second,
-	})
-	if err != nil {
-		log.Fatal(err)
+	clusterRegistry := clusters.NullRegistry
+	if opts.MultiCluster {
+		clusterRegistry, err = clusters.NewRegistry(ctx, opts.Clients.Kubernetes, opts.Namespace, opts.ClusterName)
+		if err != nil {
+			return nil, err
+		}
+		log.WithFields(log.Fields{"cluster": opts.ClusterName, "remotes": clusterRegistry.Remotes()}).Info("Multi-cluster aggregation enabled")
+	}
+	var store limiter.Store
+	if stateStore != nil {
+		store = state.NewRateLimiter(stateStore, opts.APIRateLimit, time.Second)
+	} else {
+		store, err = memorystore.New(&memorystore.Config{
+			Tokens:   opts.APIRateLimit,
+			Interval: time.Second,
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
 	}
 
 	return &argoServer{
@@ -177,11 +223,16 @@ func NewArgoServer(ctx context.Context, opts ArgoServerOpts) (*argoServer, error
 		eventQueueSize:           opts.EventOperationQueueSize,
 		eventWorkerCount:         opts.EventWorkerCount,
 		eventAsyncDispatch:       opts.EventAsyncDispatch,
+		eventQueue:               opts.EventQueue,
+		eventQueueDir:            opts.EventQueueDir,
+		eventDiskQueueSize:       opts.EventDiskQueueSize,
 		xframeOptions:            opts.XFrameOptions,
 		accessControlAllowOrigin: opts.AccessControlAllowOrigin,
 		apiRateLimiter:           store,
+		stateStore:               stateStore,
 		allowedLinkProtocol:      opts.AllowedLinkProtocol,
 		cache:                    resourceCache,
+		clusters:                 clusterRegistry,
 	}, nil
 }
 
@@ -229,8 +280,53 @@ func (as *argoServer) Run(ctx context.Context, port int, browserOpenFunc func(st
 	artifactRepositories := artifactrepositories.New(as.clients.Kubernetes, as.managedNamespace, &config.ArtifactRepository)
 	artifactServer := artifacts.NewArtifactServer(as.gatekeeper, hydrator.New(offloadRepo), wfArchive, instanceIDService, artifactRepositories)
 	eventServer := event.NewController(instanceIDService, eventRecorderManager, as.eventQueueSize, as.eventWorkerCount, as.eventAsyncDispatch)
-	grpcServer := as.newGRPCServer(instanceIDService, offloadRepo, wfArchive, eventServer, config.Links, config.Columns, config.NavColor)
-	httpServer := as.newHTTPServer(ctx, port, artifactServer)
+	switch as.eventQueue {
+	case "", eventqueue.Memory:
+	case eventqueue.Disk:
+		q, err := eventqueue.NewDisk(as.eventQueueDir, as.eventDiskQueueSize)
+		if err != nil {
+			log.Fatal(err)
+		}
+		eventServer.UseQueue(q, as.gatekeeper.Context)
+	case eventqueue.Redis:
+		if as.stateStore == nil {
+			log.Fatalf("the %s event queue requires redis to be configured", eventqueue.Redis)
+		}
+		replica, err := os.Hostname()
+		if err != nil {
+			log.Fatal(err)
+		}
+		q, err := eventqueue.NewStore(as.stateStore, replica, as.eventDiskQueueSize)
+		if err != nil {
+			log.Fatal(err)
+		}
+		eventServer.UseQueue(q, as.gatekeeper.Context)
+	default:
+		log.Fatalf("unknown event queue %q, must be one of %s, %s or %s", as.eventQueue, eventqueue.Memory, eventqueue.Disk, eventqueue.Redis)
+	}
+	redactor, err := redaction.New(config.Redaction)
+	if err != nil {
+		log.Fatal(err)
+	}
+	wfArchiveServer := workflowarchive.NewWorkflowArchiveServer(wfArchive, redactor)
+	workflowStores, err := store.NewRegistry(ctx, config.WorkflowStore, as.clients.Workflow, as.managedNamespace, instanceIDService, wfArchiveServer)
+	if err != nil {
+		log.Fatal(err)
+	}
+	var checkedArtifactRepositories artifactrepositories.Interface
+	if config.CheckArtifactRepository {
+		checkedArtifactRepositories = artifactRepositories
+	}
+	creatorKey, err := creator.Key(ctx, as.clients.Kubernetes.CoreV1().Secrets(as.namespace))
+	if err != nil {
+		log.Fatal(err)
+	}
+	approvals := wfutil.ApprovalOpts{CreatorKey: creatorKey}
+	if config.Approvals != nil {
+		approvals.AllowUnknownCreator = config.Approvals.AllowUnknownCreator
+	}
+	grpcServer := as.newGRPCServer(instanceIDService, offloadRepo, wfArchiveServer, workflowStores, eventServer, config.Links, config.Columns, config.NavColor, config.Guardrails, config.DeprecatedParameters, config.GetIdempotencyKeyWindow(), approvals, redactor, artifactServer.OpenArchivedLog, checkedArtifactRepositories)
+	httpServer := as.newHTTPServer(ctx, port, artifactServer, grpcServer)
 
 	// Start listener
 	var conn net.Listener
@@ -275,7 +371,7 @@ func (as *argoServer) Run(ctx context.Context, port int, browserOpenFunc func(st
 	<-as.stopCh
 }
 
-func (as *argoServer) newGRPCServer(instanceIDService instanceid.Service, offloadNodeStatusRepo sqldb.OffloadNodeStatusRepo, wfArchive sqldb.WorkflowArchive, eventServer *event.Controller, links []*v1alpha1.Link, columns []*v1alpha1.Column, navColor string) *grpc.Server {
+func (as *argoServer) newGRPCServer(instanceIDService instanceid.Service, offloadNodeStatusRepo sqldb.OffloadNodeStatusRepo, wfArchiveServer workflowarchivepkg.ArchivedWorkflowServiceServer, workflowStores store.Registry, eventServer *event.Controller, links []*v1alpha1.Link, columns []*v1alpha1.Column, navColor string, guardrails *config.Guardrails, deprecatedParameters config.DeprecatedParameters, idempotencyKeyWindow time.Duration, approvals wfutil.ApprovalOpts, redactor *redaction.Redactor, archivedLogs logs.ArchivedLogOpener, artifactRepositories artifactrepositories.Interface) *grpc.Server {
 	serverLog := log.NewEntry(log.StandardLogger())
 
 	// "Prometheus histograms are a great way to measure latency distributions of your RPCs. However, since it is bad practice to have metrics of high cardinality the latency monitoring metrics are disabled by default. To enable them please call the following in your server initialization code:"
@@ -307,12 +403,11 @@ func (as *argoServer) newGRPCServer(instanceIDService instanceid.Service, offloa
 	}
 
 	grpcServer := grpc.NewServer(sOpts...)
-	wfArchiveServer := workflowarchive.NewWorkflowArchiveServer(wfArchive)
 	infopkg.RegisterInfoServiceServer(grpcServer, info.NewInfoServer(as.managedNamespace, links, columns, navColor))
 	eventpkg.RegisterEventServiceServer(grpcServer, eventServer)
 	eventsourcepkg.RegisterEventSourceServiceServer(grpcServer, eventsource.NewEventSourceServer())
 	sensorpkg.RegisterSensorServiceServer(grpcServer, sensor.NewSensorServer())
-	workflowpkg.RegisterWorkflowServiceServer(grpcServer, workflow.NewWorkflowServer(instanceIDService, offloadNodeStatusRepo, wfArchiveServer))
+	workflowpkg.RegisterWorkflowServiceServer(grpcServer, workflow.NewWorkflowServer(instanceIDService, offloadNodeStatusRepo, wfArchiveServer, as.clusters, workflowStores, guardrails, deprecatedParameters, idempotencyKeyWindow, approvals, redactor, archivedLogs, artifactRepositories))
 	workflowtemplatepkg.RegisterWorkflowTemplateServiceServer(grpcServer, workflowtemplate.NewWorkflowTemplateServer(instanceIDService))
 	cronworkflowpkg.RegisterCronWorkflowServiceServer(grpcServer, cronworkflow.NewCronWorkflowServer(instanceIDService))
 	workflowarchivepkg.RegisterArchivedWorkflowServiceServer(grpcServer, wfArchiveServer)
@@ -322,8 +417,9 @@ func (as *argoServer) newGRPCServer(instanceIDService instanceid.Service, offloa
 }
 
 // newHTTPServer returns the HTTP server to serve HTTP/HTTPS requests. This is implemented
-// using grpc-gateway as a proxy to the gRPC server.
-func (as *argoServer) newHTTPServer(ctx context.Context, port int, artifactServer *artifacts.ArtifactServer) *http.Server {
+// using grpc-gateway as a proxy to the gRPC server. gRPC-Web and Connect requests are served
+// by the gRPC server directly.
+func (as *argoServer) newHTTPServer(ctx context.Context, port int, artifactServer *artifacts.ArtifactServer, grpcServer *grpc.Server) *http.Server {
 	endpoint := fmt.Sprintf("localhost:%d", port)
 
 	ratelimit_middleware, err := httplimit.NewMiddleware(as.apiRateLimiter, httplimit.IPKeyFunc())
@@ -334,7 +430,7 @@ func (as *argoServer) newHTTPServer(ctx context.Context, port int, artifactServe
 	mux := http.NewServeMux()
 	httpServer := http.Server{
 		Addr:      endpoint,
-		Handler:   ratelimit_middleware.Handle(accesslog.Interceptor(mux)),
+		Handler:   ratelimit_middleware.Handle(accesslog.Interceptor(grpcweb.NewHandler(grpcServer, mux, as.accessControlAllowOrigin))),
 		TLSConfig: as.tlsConfig,
 	}
 	dialOpts := []grpc.DialOption{
@@ -360,7 +456,7 @@ func (as *argoServer) newHTTPServer(ctx context.Context, port int, artifactServe
 	gwMuxOpts := runtime.WithMarshalerOption(runtime.MIMEWildcard, new(json.JSONMarshaler))
 	gwmux := runtime.NewServeMux(gwMuxOpts,
 		runtime.WithIncomingHeaderMatcher(func(key string) (string, bool) { return key, true }),
-		runtime.WithProtoErrorHandler(runtime.DefaultHTTPProtoErrorHandler),
+		runtime.WithProtoErrorHandler(protoErrorHandler),
 	)
 	mustRegisterGWHandler(infopkg.RegisterInfoServiceHandlerFromEndpoint, ctx, gwmux, endpoint, dialOpts)
 	mustRegisterGWHandler(eventpkg.RegisterEventServiceHandlerFromEndpoint, ctx, gwmux, endpoint, dialOpts)
@@ -388,6 +484,8 @@ func (as *argoServer) newHTTPServer(ctx context.Context, port int, artifactServe
 	}
 	mux.Handle("/oauth2/redirect", handlers.ProxyHeaders(http.HandlerFunc(as.oAuth2Service.HandleRedirect)))
 	mux.Handle("/oauth2/callback", handlers.ProxyHeaders(http.HandlerFunc(as.oAuth2Service.HandleCallback)))
+	mux.Handle("/oauth2/device", handlers.ProxyHeaders(http.HandlerFunc(as.oAuth2Service.HandleDeviceAuth)))
+	mux.Handle("/oauth2/token", handlers.ProxyHeaders(http.HandlerFunc(as.oAuth2Service.HandleToken)))
 	mux.HandleFunc("/metrics", func(w http.ResponseWriter, r *http.Request) {
 		if os.Getenv("ARGO_SERVER_METRICS_AUTH") != "false" {
 			md := metadata.New(map[string]string{"authorization": r.Header.Get("Authorization")})
@@ -422,6 +520,25 @@ func mustRegisterGWHandler(register registerFunc, ctx context.Context, mux *runt
 }
 
 // checkServeErr checks the error from a .Serve() call to decide if it was a graceful shutdown
+// protoErrorHandler responds to errors as grpc-gateway does, except that a reused idempotency key is a 422, as no gRPC
+// code maps to it
+func protoErrorHandler(ctx context.Context, mux *runtime.ServeMux, marshaler runtime.Marshaler, w http.ResponseWriter, r *http.Request, err error) {
+	if grpcutil.ErrorCode(err) == argoerrs.CodeIdempotencyKeyReused {
+		w = &statusCodeResponseWriter{ResponseWriter: w, code: http.StatusUnprocessableEntity}
+	}
+	runtime.DefaultHTTPProtoErrorHandler(ctx, mux, marshaler, w, r, err)
+}
+
+// statusCodeResponseWriter writes its status code, whichever status code it is given
+type statusCodeResponseWriter struct {
+	http.ResponseWriter
+	code int
+}
+
+func (w *statusCodeResponseWriter) WriteHeader(int) {
+	w.ResponseWriter.WriteHeader(w.code)
+}
+
 func (as *argoServer) checkServeErr(name string, err error) {
 	nameField := log.Fields{"name": name}
 	if err != nil {
@@ -1,6 +1,7 @@
 package artifacts
 
 import (
+	"compress/gzip"
 	"context"
 	"errors"
 	"fmt"
@@ -445,7 +446,7 @@ func (a *ArtifactServer) getArtifactAndDriver(ctx context.Context, nodeId, artif
 }
 
 func (a *ArtifactServer) returnArtifact(w http.ResponseWriter, art *wfv1.Artifact, driver common.ArtifactDriver) error {
-	stream, err := driver.OpenStream(art)
+	stream, key, err := openStream(art, driver)
 	if err != nil {
 		return err
 	}
@@ -456,7 +457,6 @@ func (a *ArtifactServer) returnArtifact(w http.ResponseWriter, art *wfv1.Artifac
 		}
 	}()
 
-	key, _ := art.GetKey()
 	w.Header().Add("Content-Disposition", fmt.Sprintf(`filename="%s"`, path.Base(key)))
 	w.Header().Add("Content-Type", mime.TypeByExtension(path.Ext(key)))
 	w.Header().Add("Content-Security-Policy", env.GetString("ARGO_ARTIFACT_CONTENT_SECURITY_POLICY", "sandbox; base-uri 'none'; default-src 'none'; img-src 'self'; style-src 'self' 'unsafe-inline'"))
@@ -474,6 +474,50 @@ func (a *ArtifactServer) returnArtifact(w http.ResponseWriter, art *wfv1.Artifac
 	return nil
 }
 
+// OpenArchivedLog opens the archived log of a container, the output artifact of the workflow's node with the name,
+// decompressing it if it was stored compressed
+func (a *ArtifactServer) OpenArchivedLog(ctx context.Context, wf *wfv1.Workflow, nodeID, artifactName string) (io.ReadCloser, error) {
+	art, driver, err := a.getArtifactAndDriver(ctx, nodeID, artifactName, false, wf, nil)
+	if err != nil {
+		return nil, err
+	}
+	stream, _, err := openStream(art, driver)
+	return stream, err
+}
+
+// openStream opens the artifact, and returns the key of its content. The logs that were stored compressed are
+// decompressed, so that they are read like those that were not.
+func openStream(art *wfv1.Artifact, driver common.ArtifactDriver) (io.ReadCloser, string, error) {
+	stream, err := driver.OpenStream(art)
+	if err != nil {
+		return nil, "", err
+	}
+	key, _ := art.GetKey()
+	if !strings.HasSuffix(art.Name, wfv1.LogsSuffix) || !strings.HasSuffix(key, ".log.gz") {
+		return stream, key, nil
+	}
+	reader, err := gzip.NewReader(stream)
+	if err != nil {
+		_ = stream.Close()
+		return nil, "", fmt.Errorf("failed to decompress the logs: %w", err)
+	}
+	return &gzipReadCloser{reader, stream}, strings.TrimSuffix(key, ".gz"), nil
+}
+
+// gzipReadCloser reads a gzip stream, and closes both the reader and the stream
+type gzipReadCloser struct {
+	*gzip.Reader
+	stream io.Closer
+}
+
+func (r *gzipReadCloser) Close() error {
+	err := r.Reader.Close()
+	if closeErr := r.stream.Close(); closeErr != nil {
+		return closeErr
+	}
+	return err
+}
+
 func (a *ArtifactServer) getWorkflowAndValidate(ctx context.Context, namespace string, workflowName string) (*wfv1.Workflow, error) {
 	wfClient := auth.GetWfClient(ctx)
 	wf, err := wfClient.ArgoprojV1alpha1().Workflows(namespace).Get(ctx, workflowName, metav1.GetOptions{})
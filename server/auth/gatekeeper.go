@@ -44,6 +44,7 @@ const (
 	EventSourceKey ContextKey = "eventsource.Interface"
 	KubeKey        ContextKey = "kubernetes.Interface"
 	ClaimsKey      ContextKey = "types.Claims"
+	RestConfigKey  ContextKey = "rest.Config"
 )
 
 //go:generate mockery --name=Gatekeeper
@@ -116,6 +117,7 @@ func (s *gatekeeper) ContextWithRequest(ctx context.Context, req interface{}) (c
 	ctx = context.WithValue(ctx, SensorKey, clients.Sensor)
 	ctx = context.WithValue(ctx, KubeKey, clients.Kubernetes)
 	ctx = context.WithValue(ctx, ClaimsKey, claims)
+	ctx = context.WithValue(ctx, RestConfigKey, clients.RestConfig)
 	return ctx, nil
 }
 
@@ -143,6 +145,11 @@ func GetKubeClient(ctx context.Context) kubernetes.Interface {
 	return ctx.Value(KubeKey).(kubernetes.Interface)
 }
 
+func GetRestConfig(ctx context.Context) *rest.Config {
+	config, _ := ctx.Value(RestConfigKey).(*rest.Config)
+	return config
+}
+
 func GetClaims(ctx context.Context) *types.Claims {
 	config, _ := ctx.Value(ClaimsKey).(*types.Claims)
 	return config
@@ -368,5 +375,6 @@ func DefaultClientForAuthorization(authorization string) (*rest.Config, *servert
 		Sensor:      sensorClient,
 		EventSource: eventSourceClient,
 		Kubernetes:  kubeClient,
+		RestConfig:  restConfig,
 	}, nil
 }
@@ -0,0 +1,104 @@
+package sso
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/oauth2"
+)
+
+const (
+	// GrantTypeDeviceCode is the grant type of a token request for a device code, once the user has authorized it
+	GrantTypeDeviceCode = "urn:ietf:params:oauth:grant-type:device_code"
+	// GrantTypeRefreshToken is the grant type of a token request for the refresh token of the SSO provider
+	GrantTypeRefreshToken = "refresh_token"
+	// deviceCodeTTL is how long to wait for the user to authorize a device, if the device code does not say
+	deviceCodeTTL = 15 * time.Minute
+)
+
+// TokenResponse is the response to a token request, with which the CLI logs in and refreshes its token
+type TokenResponse struct {
+	// Token is the bearer token of the Argo Server, e.g. "Bearer v2:..."
+	Token string `json:"token"`
+	// Expiry is when the token expires
+	Expiry time.Time `json:"expiry"`
+	// RefreshToken is the refresh token of the SSO provider, to get a new token with once it expires
+	RefreshToken string `json:"refreshToken,omitempty"`
+}
+
+// HandleDeviceAuth starts the device authorization grant with the SSO provider, and responds with the user code to
+// enter at its verification URI, and the device code to request the token with
+func (s *sso) HandleDeviceAuth(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	if s.config.Endpoint.DeviceAuthURL == "" {
+		http.Error(w, "the SSO provider does not support the device authorization grant", http.StatusNotImplemented)
+		return
+	}
+	// Use sso.httpClient in order to respect TLSOptions
+	ctx := context.WithValue(r.Context(), oauth2.HTTPClient, s.httpClient)
+	da, err := s.config.DeviceAuth(ctx, oauth2.SetAuthURLParam("client_secret", s.config.ClientSecret))
+	if err != nil {
+		log.WithError(err).Error("failed to start the device authorization grant")
+		w.WriteHeader(http.StatusBadGateway)
+		return
+	}
+	writeJSON(w, da)
+}
+
+// HandleToken responds with a token for the device code, waiting for the user to authorize the device, or for the
+// refresh token
+func (s *sso) HandleToken(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	ctx := context.WithValue(r.Context(), oauth2.HTTPClient, s.httpClient)
+	var (
+		oauth2Token *oauth2.Token
+		err         error
+	)
+	switch grantType := r.PostForm.Get("grant_type"); grantType {
+	case GrantTypeDeviceCode:
+		da := &oauth2.DeviceAuthResponse{DeviceCode: r.PostForm.Get("device_code"), Expiry: time.Now().Add(deviceCodeTTL)}
+		da.Interval, _ = strconv.ParseInt(r.PostForm.Get("interval"), 10, 64)
+		if expiresIn, _ := strconv.ParseInt(r.PostForm.Get("expires_in"), 10, 64); expiresIn > 0 && time.Duration(expiresIn)*time.Second < deviceCodeTTL {
+			da.Expiry = time.Now().Add(time.Duration(expiresIn) * time.Second)
+		}
+		oauth2Token, err = s.config.DeviceAccessToken(ctx, da)
+	case GrantTypeRefreshToken:
+		oauth2Token, err = s.config.TokenSource(ctx, &oauth2.Token{RefreshToken: r.PostForm.Get("refresh_token")}).Token()
+	default:
+		http.Error(w, "unsupported grant_type "+strconv.Quote(grantType), http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		log.WithError(err).Error("failed to get oauth2Token from the oauth2 server")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	value, err := s.newToken(ctx, oauth2Token)
+	if err != nil {
+		log.WithError(err).Error("failed to create the token")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	writeJSON(w, TokenResponse{Token: value, Expiry: time.Now().Add(s.expiry), RefreshToken: oauth2Token.RefreshToken})
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.WithError(err).Error("failed to write the response")
+	}
+}
@@ -46,11 +46,21 @@ func (_m *Interface) HandleCallback(writer http.ResponseWriter, request *http.Re
 	_m.Called(writer, request)
 }
 
+// HandleDeviceAuth provides a mock function with given fields: writer, request
+func (_m *Interface) HandleDeviceAuth(writer http.ResponseWriter, request *http.Request) {
+	_m.Called(writer, request)
+}
+
 // HandleRedirect provides a mock function with given fields: writer, request
 func (_m *Interface) HandleRedirect(writer http.ResponseWriter, request *http.Request) {
 	_m.Called(writer, request)
 }
 
+// HandleToken provides a mock function with given fields: writer, request
+func (_m *Interface) HandleToken(writer http.ResponseWriter, request *http.Request) {
+	_m.Called(writer, request)
+}
+
 // IsRBACEnabled provides a mock function with given fields:
 func (_m *Interface) IsRBACEnabled() bool {
 	ret := _m.Called()
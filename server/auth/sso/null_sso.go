@@ -26,3 +26,11 @@ func (n nullService) HandleRedirect(w http.ResponseWriter, _ *http.Request) {
 func (n nullService) HandleCallback(w http.ResponseWriter, _ *http.Request) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
+
+func (n nullService) HandleDeviceAuth(w http.ResponseWriter, _ *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+func (n nullService) HandleToken(w http.ResponseWriter, _ *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
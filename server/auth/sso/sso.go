@@ -5,8 +5,12 @@ import (
 	"crypto"
 	"crypto/rand"
 	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/subtle"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"regexp"
@@ -27,6 +31,7 @@ import (
 	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 
 	"github.com/argoproj/argo-workflows/v3/server/auth/types"
+	"github.com/argoproj/argo-workflows/v3/server/state"
 )
 
 const (
@@ -34,6 +39,7 @@ const (
 	issuer                              = "argo-server"                // the JWT issuer
 	secretName                          = "sso"                        // where we store SSO secret
 	cookieEncryptionPrivateKeySecretKey = "cookieEncryptionPrivateKey" // the key name for the private key in the secret
+	stateTTL                            = 3 * time.Minute              // how long a login can take
 )
 
 //go:generate mockery --name=Interface
@@ -42,6 +48,8 @@ type Interface interface {
 	Authorize(authorization string) (*types.Claims, error)
 	HandleRedirect(writer http.ResponseWriter, request *http.Request)
 	HandleCallback(writer http.ResponseWriter, request *http.Request)
+	HandleDeviceAuth(writer http.ResponseWriter, request *http.Request)
+	HandleToken(writer http.ResponseWriter, request *http.Request)
 	IsRBACEnabled() bool
 }
 
@@ -63,6 +71,8 @@ type sso struct {
 	customClaimName   string
 	userInfoPath      string
 	filterGroupsRegex []*regexp.Regexp
+	// states keeps the OAuth2 state of logins in progress, if nil it is kept in a cookie
+	states state.Store
 }
 
 func (s *sso) IsRBACEnabled() bool {
@@ -76,6 +86,7 @@ func (s *sso) IsRBACEnabled() bool {
 type providerInterface interface {
 	Endpoint() oauth2.Endpoint
 	Verifier(config *oidc.Config) *oidc.IDTokenVerifier
+	Claims(v interface{}) error
 }
 
 type providerFactory func(ctx context.Context, issuer string) (providerInterface, error)
@@ -84,8 +95,8 @@ func providerFactoryOIDC(ctx context.Context, issuer string) (providerInterface,
 	return oidc.NewProvider(ctx, issuer)
 }
 
-func New(c Config, secretsIf corev1.SecretInterface, baseHRef string, secure bool) (Interface, error) {
-	return newSso(providerFactoryOIDC, c, secretsIf, baseHRef, secure)
+func New(c Config, secretsIf corev1.SecretInterface, baseHRef string, secure bool, states state.Store) (Interface, error) {
+	return newSso(providerFactoryOIDC, c, secretsIf, baseHRef, secure, states)
 }
 
 func newSso(
@@ -94,6 +105,7 @@ func newSso(
 	secretsIf corev1.SecretInterface,
 	baseHRef string,
 	secure bool,
+	states state.Store,
 ) (Interface, error) {
 	if c.Issuer == "" {
 		return nil, fmt.Errorf("issuer empty")
@@ -178,6 +190,15 @@ func newSso(
 		Endpoint:     provider.Endpoint(),
 		Scopes:       append(c.Scopes, oidc.ScopeOpenID),
 	}
+	// The device authorization endpoint is only in the discovery document, and only if the provider supports the
+	// device authorization grant, which the CLI uses to log in
+	var discovery struct {
+		DeviceAuthURL string `json:"device_authorization_endpoint"`
+	}
+	if err := provider.Claims(&discovery); err != nil {
+		log.WithError(err).Warn("failed to read the device authorization endpoint from the discovery document")
+	}
+	config.Endpoint.DeviceAuthURL = discovery.DeviceAuthURL
 	idTokenVerifier := provider.Verifier(&oidc.Config{ClientID: config.ClientID})
 	encrypter, err := jose.NewEncrypter(jose.A256GCM, jose.Recipient{Algorithm: jose.RSA_OAEP_256, Key: privateKey.Public()}, &jose.EncrypterOptions{Compression: jose.DEFLATE})
 	if err != nil {
@@ -215,6 +236,7 @@ func newSso(
 		userInfoPath:      c.UserInfoPath,
 		issuer:            c.Issuer,
 		filterGroupsRegex: filterGroupsRegex,
+		states:            states,
 	}, nil
 }
 
@@ -226,10 +248,33 @@ func (s *sso) HandleRedirect(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(500)
 		return
 	}
+	cookieValue := redirectUrl
+	if s.states != nil {
+		// the callback can then be handled by any replica, and only once, but only from the browser given the nonce,
+		// so that a login started by someone else cannot be completed in this browser
+		nonce, err := pkgrand.RandString(32)
+		if err != nil {
+			log.WithError(err).Error("failed to create nonce")
+			w.WriteHeader(500)
+			return
+		}
+		value, err := json.Marshal(storedState{NonceHash: hashNonce(nonce), RedirectURL: redirectUrl})
+		if err != nil {
+			log.WithError(err).Error("failed to marshal state")
+			w.WriteHeader(500)
+			return
+		}
+		if err := s.states.Set(r.Context(), stateKey(state), value, stateTTL); err != nil {
+			log.WithError(err).Error("failed to save state")
+			w.WriteHeader(500)
+			return
+		}
+		cookieValue = nonce
+	}
 	http.SetCookie(w, &http.Cookie{
 		Name:     state,
-		Value:    redirectUrl,
-		Expires:  time.Now().Add(3 * time.Minute),
+		Value:    cookieValue,
+		Expires:  time.Now().Add(stateTTL),
 		HttpOnly: true,
 		SameSite: http.SameSiteLaxMode,
 		Secure:   s.secure,
@@ -242,10 +287,9 @@ func (s *sso) HandleRedirect(w http.ResponseWriter, r *http.Request) {
 func (s *sso) HandleCallback(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	state := r.URL.Query().Get("state")
-	cookie, err := r.Cookie(state)
-	http.SetCookie(w, &http.Cookie{Name: state, MaxAge: 0})
+	redirectUrl, err := s.getState(w, r, state)
 	if err != nil {
-		log.WithError(err).Error("failed to get cookie")
+		log.WithError(err).Error("failed to get state")
 		w.WriteHeader(400)
 		return
 	}
@@ -258,23 +302,48 @@ func (s *sso) HandleCallback(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(401)
 		return
 	}
-	rawIDToken, ok := oauth2Token.Extra("id_token").(string)
-	if !ok {
-		log.Error("failed to extract id_token from the response")
+	value, err := s.newToken(ctx, oauth2Token)
+	if err != nil {
+		log.WithError(err).Error("failed to create the token")
 		w.WriteHeader(401)
 		return
 	}
+	log.Debugf("handing oauth2 callback %v", value)
+	http.SetCookie(w, &http.Cookie{
+		Value:    value,
+		Name:     "authorization",
+		Path:     s.baseHRef,
+		Expires:  time.Now().Add(s.expiry),
+		SameSite: http.SameSiteStrictMode,
+		Secure:   s.secure,
+	})
+	redirect := s.baseHRef
+
+	proto := "http"
+	if s.secure {
+		proto = "https"
+	}
+	prefix := fmt.Sprintf("%s://%s%s", proto, r.Host, s.baseHRef)
+
+	if strings.HasPrefix(redirectUrl, prefix) {
+		redirect = redirectUrl
+	}
+	http.Redirect(w, r, redirect, 302)
+}
+
+// newToken returns the bearer token of the Argo Server for the user of the OAuth2 token, with the claims of its ID token
+func (s *sso) newToken(ctx context.Context, oauth2Token *oauth2.Token) (string, error) {
+	rawIDToken, ok := oauth2Token.Extra("id_token").(string)
+	if !ok {
+		return "", fmt.Errorf("failed to extract id_token from the response")
+	}
 	idToken, err := s.idTokenVerifier.Verify(ctx, rawIDToken)
 	if err != nil {
-		log.WithError(err).Error("failed to verify the id token issued")
-		w.WriteHeader(401)
-		return
+		return "", fmt.Errorf("failed to verify the id token issued: %w", err)
 	}
 	c := &types.Claims{}
 	if err := idToken.Claims(c); err != nil {
-		log.WithError(err).Error("failed to get claims from the id token")
-		w.WriteHeader(401)
-		return
+		return "", fmt.Errorf("failed to get claims from the id token: %w", err)
 	}
 	// Default to groups claim but if customClaimName is set
 	// extract groups based on that claim key
@@ -290,9 +359,7 @@ func (s *sso) HandleCallback(w http.ResponseWriter, r *http.Request) {
 	if s.userInfoPath != "" {
 		groups, err = c.GetUserInfoGroups(oauth2Token.AccessToken, s.issuer, s.userInfoPath)
 		if err != nil {
-			log.WithError(err).Errorf("failed to get groups claim from the given userInfoPath(%s)", s.userInfoPath)
-			w.WriteHeader(401)
-			return
+			return "", fmt.Errorf("failed to get groups claim from the given userInfoPath(%s): %w", s.userInfoPath, err)
 		}
 	}
 
@@ -326,32 +393,50 @@ func (s *sso) HandleCallback(w http.ResponseWriter, r *http.Request) {
 	}
 	raw, err := jwt.Encrypted(s.encrypter).Claims(argoClaims).CompactSerialize()
 	if err != nil {
-		log.WithError(err).Errorf("failed to encrypt and serialize the jwt token")
-		w.WriteHeader(401)
-		return
+		return "", fmt.Errorf("failed to encrypt and serialize the jwt token: %w", err)
 	}
-	value := Prefix + raw
-	log.Debugf("handing oauth2 callback %v", value)
-	http.SetCookie(w, &http.Cookie{
-		Value:    value,
-		Name:     "authorization",
-		Path:     s.baseHRef,
-		Expires:  time.Now().Add(s.expiry),
-		SameSite: http.SameSiteStrictMode,
-		Secure:   s.secure,
-	})
-	redirect := s.baseHRef
+	return Prefix + raw, nil
+}
 
-	proto := "http"
-	if s.secure {
-		proto = "https"
-	}
-	prefix := fmt.Sprintf("%s://%s%s", proto, r.Host, s.baseHRef)
+func stateKey(state string) string {
+	return "sso:state:" + state
+}
+
+// storedState is a login in progress, kept in the state store
+type storedState struct {
+	// NonceHash is the hash of the nonce in the cookie of the browser the login was started in
+	NonceHash   string `json:"nonceHash"`
+	RedirectURL string `json:"redirectUrl"`
+}
+
+func hashNonce(nonce string) string {
+	h := sha256.Sum256([]byte(nonce))
+	return hex.EncodeToString(h[:])
+}
 
-	if strings.HasPrefix(cookie.Value, prefix) {
-		redirect = cookie.Value
+// getState returns the URL to redirect to after the login with the state, which can only be used once, and only in the
+// browser the login was started in
+func (s *sso) getState(w http.ResponseWriter, r *http.Request, state string) (string, error) {
+	cookie, err := r.Cookie(state)
+	http.SetCookie(w, &http.Cookie{Name: state, MaxAge: 0})
+	if err != nil {
+		return "", err
 	}
-	http.Redirect(w, r, redirect, 302)
+	if s.states == nil {
+		return cookie.Value, nil
+	}
+	value, err := s.states.GetAndDelete(r.Context(), stateKey(state))
+	if err != nil {
+		return "", err
+	}
+	stored := &storedState{}
+	if err := json.Unmarshal(value, stored); err != nil {
+		return "", err
+	}
+	if subtle.ConstantTimeCompare([]byte(stored.NonceHash), []byte(hashNonce(cookie.Value))) != 1 {
+		return "", fmt.Errorf("the login was not started in this browser")
+	}
+	return stored.RedirectURL, nil
 }
 
 // authorize verifies a bearer token and pulls user information form the claims.
@@ -2,6 +2,11 @@ package sso
 
 import (
 	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
 	"testing"
 	"time"
 
@@ -11,6 +16,8 @@ import (
 	apiv1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/argoproj/argo-workflows/v3/server/state"
 )
 
 const testNamespace = "argo"
@@ -28,6 +35,10 @@ func (fakeOidcProvider) Verifier(config *oidc.Config) *oidc.IDTokenVerifier {
 	return nil
 }
 
+func (fakeOidcProvider) Claims(v interface{}) error {
+	return json.Unmarshal([]byte(`{"device_authorization_endpoint": "https://idp.example.com/device"}`), v)
+}
+
 func fakeOidcFactory(ctx context.Context, issuer string) (providerInterface, error) {
 	return fakeOidcProvider{ctx, issuer}, nil
 }
@@ -63,11 +74,12 @@ func TestLoadSsoClientIdFromSecret(t *testing.T) {
 		RedirectURL:          "https://dummy",
 		CustomGroupClaimName: "argo_groups",
 	}
-	ssoInterface, err := newSso(fakeOidcFactory, config, fakeClient, "/", false)
+	ssoInterface, err := newSso(fakeOidcFactory, config, fakeClient, "/", false, nil)
 	assert.NoError(t, err)
 	ssoObject := ssoInterface.(*sso)
 	assert.Equal(t, "sso-client-id-value", ssoObject.config.ClientID)
 	assert.Equal(t, "sso-client-secret-value", ssoObject.config.ClientSecret)
+	assert.Equal(t, "https://idp.example.com/device", ssoObject.config.Endpoint.DeviceAuthURL)
 	assert.Equal(t, "argo_groups", ssoObject.customClaimName)
 	assert.Equal(t, "", config.IssuerAlias)
 	assert.Equal(t, 10*time.Hour, ssoObject.expiry)
@@ -84,7 +96,7 @@ func TestNewSsoWithIssuerAlias(t *testing.T) {
 		RedirectURL:          "https://dummy",
 		CustomGroupClaimName: "argo_groups",
 	}
-	_, err := newSso(fakeOidcFactory, config, fakeClient, "/", false)
+	_, err := newSso(fakeOidcFactory, config, fakeClient, "/", false, nil)
 	assert.NoError(t, err)
 
 }
@@ -107,7 +119,7 @@ func TestLoadSsoClientIdFromDifferentSecret(t *testing.T) {
 		ClientSecret: getSecretKeySelector("argo-sso-secret", "client-secret"),
 		RedirectURL:  "https://dummy",
 	}
-	ssoInterface, err := newSso(fakeOidcFactory, config, fakeClient, "/", false)
+	ssoInterface, err := newSso(fakeOidcFactory, config, fakeClient, "/", false, nil)
 	assert.NoError(t, err)
 	ssoObject := ssoInterface.(*sso)
 	assert.Equal(t, "sso-client-id-value", ssoObject.config.ClientID)
@@ -121,7 +133,7 @@ func TestLoadSsoClientIdFromSecretNoKeyFails(t *testing.T) {
 		ClientSecret: getSecretKeySelector("argo-sso-secret", "client-secret"),
 		RedirectURL:  "https://dummy",
 	}
-	_, err := newSso(fakeOidcFactory, config, fakeClient, "/", false)
+	_, err := newSso(fakeOidcFactory, config, fakeClient, "/", false, nil)
 	assert.Error(t, err)
 	assert.Regexp(t, "key nonexistent missing in secret argo-sso-secret", err.Error())
 }
@@ -142,7 +154,7 @@ func TestLoadSsoClientIdFromExistingSsoSecretFails(t *testing.T) {
 		ClientSecret: getSecretKeySelector("argo-sso-secret", "client-secret"),
 		RedirectURL:  "https://dummy",
 	}
-	_, err = newSso(fakeOidcFactory, config, fakeClient, "/", false)
+	_, err = newSso(fakeOidcFactory, config, fakeClient, "/", false, nil)
 	assert.Error(t, err)
 	assert.Regexp(t, "If you have already defined a Secret named sso, delete it and retry", err.Error())
 }
@@ -153,3 +165,116 @@ func TestGetSessionExpiry(t *testing.T) {
 	}
 	assert.Equal(t, config.GetSessionExpiry(), 5*time.Hour)
 }
+
+func TestStateStore(t *testing.T) {
+	fakeClient := fake.NewSimpleClientset(ssoConfigSecret).CoreV1().Secrets(testNamespace)
+	config := Config{
+		Issuer:       "https://test-issuer",
+		ClientID:     getSecretKeySelector("argo-sso-secret", "client-id"),
+		ClientSecret: getSecretKeySelector("argo-sso-secret", "client-secret"),
+		RedirectURL:  "https://dummy",
+	}
+	states := state.NewMemory()
+	ssoInterface, err := newSso(fakeOidcFactory, config, fakeClient, "/", false, states)
+	assert.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	ssoInterface.HandleRedirect(w, httptest.NewRequest("GET", "/oauth2/redirect?redirect=http://localhost/workflows", nil))
+	assert.Equal(t, 302, w.Code)
+	location, err := url.Parse(w.Header().Get("Location"))
+	assert.NoError(t, err)
+	s := location.Query().Get("state")
+	cookies := w.Result().Cookies()
+	if assert.Len(t, cookies, 1) {
+		assert.Equal(t, s, cookies[0].Name)
+		assert.True(t, cookies[0].HttpOnly)
+		assert.NotContains(t, cookies[0].Value, "localhost", "only a nonce is kept in the cookie")
+	}
+	callback := func(cookies ...*http.Cookie) *http.Request {
+		r := httptest.NewRequest("GET", "/oauth2/callback?state="+s, nil)
+		for _, c := range cookies {
+			r.AddCookie(c)
+		}
+		return r
+	}
+
+	// the callback may be handled by another replica
+	other := &sso{states: states}
+	t.Run("OtherBrowser", func(t *testing.T) {
+		_, err := other.getState(httptest.NewRecorder(), callback(), s)
+		assert.Error(t, err)
+		_, err = other.getState(httptest.NewRecorder(), callback(&http.Cookie{Name: s, Value: "not-the-nonce"}), s)
+		assert.EqualError(t, err, "the login was not started in this browser")
+	})
+
+	w = httptest.NewRecorder()
+	ssoInterface.HandleRedirect(w, httptest.NewRequest("GET", "/oauth2/redirect?redirect=http://localhost/workflows", nil))
+	location, err = url.Parse(w.Header().Get("Location"))
+	assert.NoError(t, err)
+	s = location.Query().Get("state")
+	cookies = w.Result().Cookies()
+	t.Run("SameBrowser", func(t *testing.T) {
+		redirectUrl, err := other.getState(httptest.NewRecorder(), callback(cookies...), s)
+		assert.NoError(t, err)
+		assert.Equal(t, "http://localhost/workflows", redirectUrl)
+		_, err = other.getState(httptest.NewRecorder(), callback(cookies...), s)
+		assert.Equal(t, state.ErrNotFound, err, "state can only be used once")
+	})
+}
+
+func TestDeviceAuthorization(t *testing.T) {
+	idp := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/device":
+			assert.Equal(t, "my-client", r.PostForm.Get("client_id"))
+			assert.Equal(t, "my-secret", r.PostForm.Get("client_secret"))
+			_, _ = w.Write([]byte(`{"device_code": "my-device-code", "user_code": "ABCD-EFGH", "verification_uri": "https://idp.example.com/activate", "expires_in": 600, "interval": 1}`))
+		case "/token":
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error": "access_denied"}`))
+		}
+	}))
+	defer idp.Close()
+	s := &sso{
+		config: &oauth2.Config{
+			ClientID:     "my-client",
+			ClientSecret: "my-secret",
+			Endpoint:     oauth2.Endpoint{DeviceAuthURL: idp.URL + "/device", TokenURL: idp.URL + "/token"},
+		},
+		httpClient: idp.Client(),
+	}
+	t.Run("DeviceAuth", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		s.HandleDeviceAuth(w, httptest.NewRequest("POST", "/oauth2/device", nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+		da := &oauth2.DeviceAuthResponse{}
+		if assert.NoError(t, json.Unmarshal(w.Body.Bytes(), da)) {
+			assert.Equal(t, "my-device-code", da.DeviceCode)
+			assert.Equal(t, "ABCD-EFGH", da.UserCode)
+			assert.Equal(t, "https://idp.example.com/activate", da.VerificationURI)
+			assert.Equal(t, int64(1), da.Interval)
+		}
+	})
+	t.Run("NotSupported", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		(&sso{config: &oauth2.Config{}}).HandleDeviceAuth(w, httptest.NewRequest("POST", "/oauth2/device", nil))
+		assert.Equal(t, http.StatusNotImplemented, w.Code)
+	})
+	t.Run("AccessDenied", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		form := url.Values{"grant_type": {GrantTypeDeviceCode}, "device_code": {"my-device-code"}, "interval": {"1"}}
+		r := httptest.NewRequest("POST", "/oauth2/token", strings.NewReader(form.Encode()))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		s.HandleToken(w, r)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+	t.Run("UnsupportedGrantType", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest("POST", "/oauth2/token", strings.NewReader("grant_type=password"))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		s.HandleToken(w, r)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
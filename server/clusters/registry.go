@@ -0,0 +1,178 @@
+package clusters
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/argoproj/argo-workflows/v3/pkg/client/clientset/versioned"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+// KubeconfigKey is the key within a cluster secret that holds the kubeconfig for the cluster.
+const KubeconfigKey = "kubeconfig"
+
+// User is the identity requests to remote clusters are made as, so that the RBAC of the remote cluster applies to them.
+type User struct {
+	Name   string
+	Groups []string
+}
+
+// Registry holds the workflow clients for the remote clusters an Argo Server aggregates.
+type Registry interface {
+	// Name returns the name of the cluster the Argo Server itself runs in.
+	Name() string
+	// Remotes returns the sorted names of the registered remote clusters.
+	Remotes() []string
+	// WfClient returns a workflow client for the named remote cluster that impersonates the user.
+	WfClient(name string, user User) (versioned.Interface, error)
+}
+
+type registry struct {
+	name    string
+	lock    sync.RWMutex
+	configs map[string]*rest.Config
+}
+
+// NullRegistry is used when aggregation is disabled, it knows of no remote clusters.
+var NullRegistry Registry = NewStaticRegistry("", map[string]versioned.Interface{})
+
+// NewRegistry creates a registry from the secrets in the namespace labelled with `workflows.argoproj.io/cluster`.
+// The label value is the cluster name, and the secret must contain a kubeconfig under the `kubeconfig` key.
+// The secrets are watched until the context is done, so clusters can be added, changed and removed without a restart.
+func NewRegistry(ctx context.Context, kubeClient kubernetes.Interface, namespace, name string) (Registry, error) {
+	r := &registry{name: name, configs: map[string]*rest.Config{}}
+	informerFactory := informers.NewSharedInformerFactoryWithOptions(kubeClient, 20*time.Minute,
+		informers.WithNamespace(namespace),
+		informers.WithTweakListOptions(func(options *metav1.ListOptions) {
+			options.LabelSelector = common.LabelKeyCluster
+		}))
+	informer := informerFactory.Core().V1().Secrets()
+	lister := informer.Lister().Secrets(namespace)
+	sync := func() {
+		secrets, err := lister.List(labels.Everything())
+		if err != nil {
+			log.WithError(err).Error("unable to list cluster secrets")
+			return
+		}
+		r.sync(secrets)
+	}
+	informer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(interface{}) { sync() },
+		UpdateFunc: func(interface{}, interface{}) { sync() },
+		DeleteFunc: func(interface{}) { sync() },
+	})
+	informerFactory.Start(ctx.Done())
+	for _, ok := range informerFactory.WaitForCacheSync(ctx.Done()) {
+		if !ok {
+			return nil, fmt.Errorf("timed out waiting for the cluster secrets to sync")
+		}
+	}
+	sync()
+	return r, nil
+}
+
+// sync replaces the registered clusters with those of the secrets. A secret with an invalid kubeconfig is logged and
+// skipped, so that it does not remove the other clusters.
+func (r *registry) sync(secrets []*apiv1.Secret) {
+	configs := map[string]*rest.Config{}
+	for _, secret := range secrets {
+		clusterName := secret.Labels[common.LabelKeyCluster]
+		logCtx := log.WithFields(log.Fields{"secret": secret.Name, "cluster": clusterName})
+		if clusterName == "" || clusterName == r.name {
+			logCtx.Warn("ignoring cluster secret with an empty or reserved cluster name")
+			continue
+		}
+		restConfig, err := clientcmd.RESTConfigFromKubeConfig(secret.Data[KubeconfigKey])
+		if err != nil {
+			logCtx.WithError(err).Error("ignoring cluster secret with an invalid kubeconfig")
+			continue
+		}
+		configs[clusterName] = restConfig
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.configs = configs
+	log.WithField("remotes", sortedKeys(configs)).Info("registered clusters")
+}
+
+func (r *registry) Name() string {
+	return r.name
+}
+
+func (r *registry) Remotes() []string {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	return sortedKeys(r.configs)
+}
+
+func (r *registry) WfClient(name string, user User) (versioned.Interface, error) {
+	if user.Name == "" {
+		return nil, fmt.Errorf("cannot access cluster %q without a user to impersonate", name)
+	}
+	r.lock.RLock()
+	restConfig, ok := r.configs[name]
+	r.lock.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("cluster %q is not registered", name)
+	}
+	return versioned.NewForConfig(impersonate(restConfig, user))
+}
+
+// impersonate returns a copy of the config that impersonates the user.
+func impersonate(restConfig *rest.Config, user User) *rest.Config {
+	restConfig = rest.CopyConfig(restConfig)
+	restConfig.Impersonate = rest.ImpersonationConfig{UserName: user.Name, Groups: user.Groups}
+	return restConfig
+}
+
+type staticRegistry struct {
+	name    string
+	clients map[string]versioned.Interface
+}
+
+// NewStaticRegistry creates a registry from already constructed clients, keyed by cluster name. The clients are
+// returned as they are, so they are expected to impersonate the user themselves, if they need to.
+func NewStaticRegistry(name string, clients map[string]versioned.Interface) Registry {
+	return &staticRegistry{name: name, clients: clients}
+}
+
+func (r *staticRegistry) Name() string {
+	return r.name
+}
+
+func (r *staticRegistry) Remotes() []string {
+	return sortedKeys(r.clients)
+}
+
+func (r *staticRegistry) WfClient(name string, user User) (versioned.Interface, error) {
+	if user.Name == "" {
+		return nil, fmt.Errorf("cannot access cluster %q without a user to impersonate", name)
+	}
+	wfClient, ok := r.clients[name]
+	if !ok {
+		return nil, fmt.Errorf("cluster %q is not registered", name)
+	}
+	return wfClient, nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,90 @@
+package clusters
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+const kubeconfig = `
+apiVersion: v1
+kind: Config
+clusters:
+- name: east
+  cluster:
+    server: https://east.example.com
+contexts:
+- name: east
+  context:
+    cluster: east
+    user: east
+current-context: east
+users:
+- name: east
+  user:
+    token: my-token
+`
+
+func clusterSecret(name, cluster string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "argo", Labels: map[string]string{common.LabelKeyCluster: cluster}},
+		Data:       map[string][]byte{KubeconfigKey: []byte(kubeconfig)},
+	}
+}
+
+func TestNewRegistry(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	kubeClient := fake.NewSimpleClientset(
+		clusterSecret("east", "east"),
+		clusterSecret("self", "local"),
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "invalid", Namespace: "argo", Labels: map[string]string{common.LabelKeyCluster: "invalid"}},
+			Data:       map[string][]byte{KubeconfigKey: []byte("not a kubeconfig")},
+		},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "argo"}},
+	)
+	r, err := NewRegistry(ctx, kubeClient, "argo", "local")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "local", r.Name())
+		assert.Equal(t, []string{"east"}, r.Remotes())
+		_, err := r.WfClient("east", User{Name: "my-user"})
+		assert.NoError(t, err)
+		_, err = r.WfClient("east", User{})
+		assert.EqualError(t, err, `cannot access cluster "east" without a user to impersonate`)
+		_, err = r.WfClient("west", User{Name: "my-user"})
+		assert.EqualError(t, err, `cluster "west" is not registered`)
+	}
+	t.Run("Reload", func(t *testing.T) {
+		_, err := kubeClient.CoreV1().Secrets("argo").Create(ctx, clusterSecret("west", "west"), metav1.CreateOptions{})
+		if assert.NoError(t, err) {
+			assert.Eventually(t, func() bool { return len(r.Remotes()) == 2 }, 5*time.Second, 10*time.Millisecond)
+		}
+		err = kubeClient.CoreV1().Secrets("argo").Delete(ctx, "east", metav1.DeleteOptions{})
+		if assert.NoError(t, err) {
+			assert.Eventually(t, func() bool { return reflect.DeepEqual([]string{"west"}, r.Remotes()) }, 5*time.Second, 10*time.Millisecond)
+		}
+	})
+}
+
+func TestImpersonate(t *testing.T) {
+	restConfig := &rest.Config{Host: "https://east.example.com", BearerToken: "my-token"}
+	impersonating := impersonate(restConfig, User{Name: "my-user", Groups: []string{"my-group"}})
+	assert.Equal(t, rest.ImpersonationConfig{UserName: "my-user", Groups: []string{"my-group"}}, impersonating.Impersonate)
+	assert.Empty(t, restConfig.Impersonate.UserName)
+}
+
+func TestNullRegistry(t *testing.T) {
+	assert.Empty(t, NullRegistry.Remotes())
+	_, err := NullRegistry.WfClient("east", User{Name: "my-user"})
+	assert.Error(t, err)
+}
@@ -2,10 +2,12 @@ package event
 
 import (
 	"context"
+	"fmt"
 	"sync"
 
 	log "github.com/sirupsen/logrus"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -13,6 +15,7 @@ import (
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 	"github.com/argoproj/argo-workflows/v3/server/auth"
 	"github.com/argoproj/argo-workflows/v3/server/event/dispatch"
+	"github.com/argoproj/argo-workflows/v3/server/event/queue"
 	"github.com/argoproj/argo-workflows/v3/util/instanceid"
 	"github.com/argoproj/argo-workflows/v3/workflow/events"
 
@@ -24,8 +27,12 @@ type Controller struct {
 	eventRecorderManager events.EventRecorderManager
 	// a channel for operations to be executed async on
 	operationQueue chan dispatch.Operation
-	workerCount    int
-	asyncDispatch  bool
+	// if set, async events are queued here instead of on the operation queue
+	eventQueue queue.Interface
+	// authorize gives a queued event's request the clients of its sender
+	authorize     func(ctx context.Context) (context.Context, error)
+	workerCount   int
+	asyncDispatch bool
 }
 
 var _ eventpkg.EventServiceServer = &Controller{}
@@ -43,6 +50,14 @@ func NewController(instanceIDService instanceid.Service, eventRecorderManager ev
 	}
 }
 
+// UseQueue queues async events on the queue, rather than in memory, authorizing them with the gatekeeper when they are
+// dispatched
+func (s *Controller) UseQueue(eventQueue queue.Interface, authorize func(ctx context.Context) (context.Context, error)) {
+	s.eventQueue = eventQueue
+	s.authorize = authorize
+	s.asyncDispatch = true
+}
+
 func (s *Controller) Run(stopCh <-chan struct{}) {
 	// this `WaitGroup` allows us to wait for all events to dispatch before exiting
 	wg := sync.WaitGroup{}
@@ -50,6 +65,10 @@ func (s *Controller) Run(stopCh <-chan struct{}) {
 	for w := 0; w < s.workerCount; w++ {
 		go func() {
 			defer wg.Done()
+			if s.eventQueue != nil {
+				s.processQueue()
+				return
+			}
 			for operation := range s.operationQueue {
 				_ = operation.Dispatch(context.Background())
 			}
@@ -61,6 +80,10 @@ func (s *Controller) Run(stopCh <-chan struct{}) {
 
 	// stop accepting new events
 	close(s.operationQueue)
+	if s.eventQueue != nil {
+		// events that have not been dispatched stay in the queue, and are replayed when the server restarts
+		s.eventQueue.Close()
+	}
 
 	log.WithFields(log.Fields{"operations": len(s.operationQueue)}).Info("Waiting until all remaining events are processed")
 
@@ -68,16 +91,52 @@ func (s *Controller) Run(stopCh <-chan struct{}) {
 	wg.Wait()
 }
 
-func (s *Controller) ReceiveEvent(ctx context.Context, req *eventpkg.EventRequest) (*eventpkg.EventResponse, error) {
+func (s *Controller) processQueue() {
+	for {
+		event, ok := s.eventQueue.Get()
+		if !ok {
+			return
+		}
+		logCtx := log.WithFields(log.Fields{"namespace": event.Namespace, "discriminator": event.Discriminator})
+		if err := s.dispatchQueued(event); err != nil {
+			logCtx.WithError(err).Error("Failed to dispatch queued event, moving it to the dead letters")
+			if err := s.eventQueue.DeadLetter(event, err); err != nil {
+				logCtx.WithError(err).Error("Failed to move event to the dead letters")
+			}
+			continue
+		}
+		if err := s.eventQueue.Done(event); err != nil {
+			logCtx.WithError(err).Error("Failed to remove dispatched event from the queue")
+		}
+	}
+}
+
+func (s *Controller) dispatchQueued(event *queue.Event) error {
+	ctx, err := s.authorize(metadata.NewIncomingContext(context.Background(), event.Metadata))
+	if err != nil {
+		return fmt.Errorf("failed to authorize event: %w", err)
+	}
+	operation, err := s.newOperation(ctx, event.Namespace, event.Discriminator, event.Payload)
+	if err != nil {
+		return err
+	}
+	return operation.Dispatch(ctx)
+}
+
+func (s *Controller) newOperation(ctx context.Context, namespace, discriminator string, payload *wfv1.Item) (*dispatch.Operation, error) {
 	options := metav1.ListOptions{}
 	s.instanceIDService.With(&options)
 
-	list, err := auth.GetWfClient(ctx).ArgoprojV1alpha1().WorkflowEventBindings(req.Namespace).List(ctx, options)
+	list, err := auth.GetWfClient(ctx).ArgoprojV1alpha1().WorkflowEventBindings(namespace).List(ctx, options)
 	if err != nil {
-		return nil, sutils.ToStatusError(err, codes.Internal)
+		return nil, err
 	}
 
-	operation, err := dispatch.NewOperation(ctx, s.instanceIDService, s.eventRecorderManager.Get(req.Namespace), list.Items, req.Namespace, req.Discriminator, req.Payload)
+	return dispatch.NewOperation(ctx, s.instanceIDService, s.eventRecorderManager.Get(namespace), list.Items, namespace, discriminator, payload)
+}
+
+func (s *Controller) ReceiveEvent(ctx context.Context, req *eventpkg.EventRequest) (*eventpkg.EventResponse, error) {
+	operation, err := s.newOperation(ctx, req.Namespace, req.Discriminator, req.Payload)
 	if err != nil {
 		return nil, sutils.ToStatusError(err, codes.Internal)
 	}
@@ -89,6 +148,21 @@ func (s *Controller) ReceiveEvent(ctx context.Context, req *eventpkg.EventReques
 		return &eventpkg.EventResponse{}, nil
 	}
 
+	if s.eventQueue != nil {
+		err := s.eventQueue.Add(queue.Event{
+			Namespace:     req.Namespace,
+			Discriminator: req.Discriminator,
+			Payload:       req.Payload,
+			Metadata:      authorizationMetadata(ctx),
+		})
+		if err == queue.ErrFull {
+			return nil, sutils.ToStatusError(apierrors.NewServiceUnavailable(err.Error()), codes.ResourceExhausted)
+		} else if err != nil {
+			return nil, sutils.ToStatusError(err, codes.Internal)
+		}
+		return &eventpkg.EventResponse{}, nil
+	}
+
 	select {
 	case s.operationQueue <- *operation:
 		return &eventpkg.EventResponse{}, nil
@@ -97,6 +171,18 @@ func (s *Controller) ReceiveEvent(ctx context.Context, req *eventpkg.EventReques
 	}
 }
 
+// authorizationMetadata returns only the request metadata the gatekeeper needs to authorize a queued event
+func authorizationMetadata(ctx context.Context) metadata.MD {
+	md, _ := metadata.FromIncomingContext(ctx)
+	authorization := metadata.MD{}
+	for _, k := range []string{"authorization", "cookie"} {
+		if v := md.Get(k); len(v) > 0 {
+			authorization[k] = v
+		}
+	}
+	return authorization
+}
+
 func (s *Controller) ListWorkflowEventBindings(ctx context.Context, in *eventpkg.ListWorkflowEventBindingsRequest) (*wfv1.WorkflowEventBindingList, error) {
 	listOptions := metav1.ListOptions{}
 	if in.ListOptions != nil {
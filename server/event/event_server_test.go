@@ -4,14 +4,17 @@ import (
 	"context"
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/metadata"
 	fakekube "k8s.io/client-go/kubernetes/fake"
 
 	eventpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/event"
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 	"github.com/argoproj/argo-workflows/v3/pkg/client/clientset/versioned/fake"
 	"github.com/argoproj/argo-workflows/v3/server/auth"
+	"github.com/argoproj/argo-workflows/v3/server/event/queue"
 	"github.com/argoproj/argo-workflows/v3/util/instanceid"
 	"github.com/argoproj/argo-workflows/v3/workflow/events"
 )
@@ -43,6 +46,35 @@ func TestController(t *testing.T) {
 
 		assert.Len(t, s.operationQueue, 0, "all events were processed")
 	})
+	t.Run("Queue", func(t *testing.T) {
+		s := newController(false)
+		q, err := queue.NewDisk(t.TempDir(), 1)
+		assert.NoError(t, err)
+		s.UseQueue(q, func(ctx context.Context) (context.Context, error) {
+			md, _ := metadata.FromIncomingContext(ctx)
+			assert.Equal(t, []string{"Bearer my-token"}, md.Get("authorization"))
+			return context.WithValue(ctx, auth.WfKey, clientset), nil
+		})
+
+		_, err = s.ReceiveEvent(metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", "Bearer my-token", "other", "x")), e1)
+		assert.NoError(t, err)
+
+		assert.Equal(t, 1, q.Len(), "one event to be processed")
+
+		_, err = s.ReceiveEvent(ctx, e2)
+		assert.EqualError(t, err, "rpc error: code = Unavailable desc = event queue full", "backpressure when queue is full")
+
+		stopCh := make(chan struct{})
+		go func() {
+			for q.Len() > 0 {
+				time.Sleep(10 * time.Millisecond)
+			}
+			close(stopCh)
+		}()
+		s.Run(stopCh)
+
+		assert.Equal(t, 0, q.Len(), "all events were processed")
+	})
 	t.Run("Sync", func(t *testing.T) {
 		s := newController(false)
 
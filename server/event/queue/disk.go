@@ -0,0 +1,182 @@
+package queue
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// disk keeps each event in a file in the pending directory until it is dispatched. Files are named by a sequence
+// number, so events are replayed in the order they were received.
+type disk struct {
+	pendingDir string
+	deadDir    string
+	maxSize    int
+	mu         sync.Mutex
+	cond       *sync.Cond
+	pending    []string
+	inFlight   int
+	seq        uint64
+	closed     bool
+}
+
+// NewDisk returns a queue which keeps up to maxSize events in files in the directory. Events already in the directory
+// are replayed. The files contain the senders' authorization, so the directory must only be readable by the server.
+func NewDisk(dir string, maxSize int) (Interface, error) {
+	q := &disk{
+		pendingDir: filepath.Join(dir, "pending"),
+		deadDir:    filepath.Join(dir, "dead"),
+		maxSize:    maxSize,
+	}
+	q.cond = sync.NewCond(&q.mu)
+	for _, d := range []string{q.pendingDir, q.deadDir} {
+		if err := os.MkdirAll(d, 0o700); err != nil {
+			return nil, fmt.Errorf("failed to create event queue directory: %w", err)
+		}
+	}
+	entries, err := os.ReadDir(q.pendingDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read event queue directory: %w", err)
+	}
+	for _, e := range entries {
+		name := e.Name()
+		if !strings.HasSuffix(name, ".json") {
+			// a partially written event, it was never acknowledged to its sender
+			_ = os.Remove(filepath.Join(q.pendingDir, name))
+			continue
+		}
+		seq, err := strconv.ParseUint(strings.TrimSuffix(name, ".json"), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("unexpected file %q in event queue directory", name)
+		}
+		if seq > q.seq {
+			q.seq = seq
+		}
+		q.pending = append(q.pending, name)
+	}
+	if len(q.pending) > 0 {
+		log.WithField("events", len(q.pending)).Info("Replaying queued events")
+		replayedMetric.Add(float64(len(q.pending)))
+	}
+	depthMetric.Set(float64(len(q.pending)))
+	return q, nil
+}
+
+func (q *disk) Add(event Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed {
+		return fmt.Errorf("event queue closed")
+	}
+	if len(q.pending)+q.inFlight >= q.maxSize {
+		return ErrFull
+	}
+	q.seq++
+	name := fmt.Sprintf("%020d.json", q.seq)
+	if err := writeFile(filepath.Join(q.pendingDir, name), data); err != nil {
+		return fmt.Errorf("failed to write event: %w", err)
+	}
+	q.pending = append(q.pending, name)
+	depthMetric.Set(float64(len(q.pending) + q.inFlight))
+	q.cond.Signal()
+	return nil
+}
+
+func (q *disk) Get() (*Event, bool) {
+	for {
+		q.mu.Lock()
+		for len(q.pending) == 0 && !q.closed {
+			q.cond.Wait()
+		}
+		if q.closed {
+			q.mu.Unlock()
+			return nil, false
+		}
+		name := q.pending[0]
+		q.pending = q.pending[1:]
+		q.inFlight++
+		q.mu.Unlock()
+
+		event := &Event{id: name}
+		data, err := os.ReadFile(filepath.Join(q.pendingDir, name))
+		if err == nil {
+			err = json.Unmarshal(data, event)
+		}
+		if err != nil {
+			log.WithError(err).WithField("event", name).Error("Failed to read queued event")
+			_ = q.DeadLetter(event, err)
+			continue
+		}
+		return event, true
+	}
+}
+
+func (q *disk) Done(event *Event) error {
+	defer q.release()
+	return os.Remove(filepath.Join(q.pendingDir, event.id))
+}
+
+func (q *disk) DeadLetter(event *Event, reason error) error {
+	defer q.release()
+	deadLettersMetric.Inc()
+	event.Error = reason.Error()
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	if err := writeFile(filepath.Join(q.deadDir, event.id), data); err != nil {
+		return fmt.Errorf("failed to write dead letter: %w", err)
+	}
+	return os.Remove(filepath.Join(q.pendingDir, event.id))
+}
+
+func (q *disk) release() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.inFlight--
+	depthMetric.Set(float64(len(q.pending) + q.inFlight))
+}
+
+func (q *disk) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.pending) + q.inFlight
+}
+
+func (q *disk) Close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.closed = true
+	q.cond.Broadcast()
+}
+
+// writeFile writes the file atomically, so a crash never leaves a partially written event
+func writeFile(path string, data []byte) error {
+	tmp := path + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
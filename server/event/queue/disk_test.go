@@ -0,0 +1,59 @@
+package queue
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDisk(t *testing.T) {
+	dir := t.TempDir()
+	q, err := NewDisk(dir, 2)
+	assert.NoError(t, err)
+
+	assert.NoError(t, q.Add(Event{Namespace: "ns-1", Metadata: map[string][]string{"authorization": {"Bearer my-token"}}}))
+	assert.NoError(t, q.Add(Event{Namespace: "ns-2"}))
+	assert.Equal(t, ErrFull, q.Add(Event{Namespace: "ns-3"}), "backpressure when queue is full")
+	assert.Equal(t, 2, q.Len())
+
+	event, ok := q.Get()
+	if assert.True(t, ok) {
+		assert.Equal(t, "ns-1", event.Namespace)
+		assert.Equal(t, []string{"Bearer my-token"}, event.Metadata["authorization"])
+		assert.NoError(t, q.Done(event))
+	}
+	event, ok = q.Get()
+	if assert.True(t, ok) {
+		assert.Equal(t, "ns-2", event.Namespace)
+		assert.NoError(t, q.DeadLetter(event, fmt.Errorf("my-error")))
+	}
+	assert.Equal(t, 0, q.Len())
+	dead, err := os.ReadDir(filepath.Join(dir, "dead"))
+	if assert.NoError(t, err) {
+		assert.Len(t, dead, 1)
+	}
+
+	t.Run("Replay", func(t *testing.T) {
+		assert.NoError(t, q.Add(Event{Namespace: "ns-4"}))
+		q.Close()
+		_, ok := q.Get()
+		assert.False(t, ok, "no events after close")
+
+		q, err := NewDisk(dir, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, q.Len())
+		event, ok := q.Get()
+		if assert.True(t, ok) {
+			assert.Equal(t, "ns-4", event.Namespace)
+			assert.NoError(t, q.Done(event))
+		}
+		assert.NoError(t, q.Add(Event{Namespace: "ns-5"}))
+		event, ok = q.Get()
+		if assert.True(t, ok) {
+			assert.Equal(t, "ns-5", event.Namespace, "sequence continues after replay")
+		}
+	})
+}
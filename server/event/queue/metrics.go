@@ -0,0 +1,25 @@
+package queue
+
+import "github.com/prometheus/client_golang/prometheus"
+
+var (
+	depthMetric = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "argo_server",
+		Name:      "event_queue_depth",
+		Help:      "Number of events waiting to be dispatched, including those being dispatched",
+	})
+	replayedMetric = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "argo_server",
+		Name:      "event_queue_replayed_total",
+		Help:      "Number of events found in the queue when the server started, which are dispatched again",
+	})
+	deadLettersMetric = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "argo_server",
+		Name:      "event_queue_dead_letters_total",
+		Help:      "Number of events that could not be dispatched and were moved to the dead letters",
+	})
+)
+
+func init() {
+	prometheus.MustRegister(depthMetric, replayedMetric, deadLettersMetric)
+}
@@ -0,0 +1,49 @@
+package queue
+
+import (
+	"errors"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+const (
+	// Memory keeps the operations of async events in memory, they are lost if the server restarts
+	Memory = "memory"
+	// Disk keeps async events in files, which are replayed when the server restarts
+	Disk = "disk"
+	// Redis keeps async events in Redis, where they are dispatched by any replica of the server, and replayed if the
+	// replica dispatching them stops
+	Redis = "redis"
+)
+
+var ErrFull = errors.New("event queue full")
+
+// Event is an event request waiting to be dispatched
+type Event struct {
+	Namespace     string     `json:"namespace"`
+	Discriminator string     `json:"discriminator,omitempty"`
+	Payload       *wfv1.Item `json:"payload,omitempty"`
+	// Metadata are the request's gRPC metadata which carry its authorization, so that it can be dispatched with the
+	// sender's permissions
+	Metadata map[string][]string `json:"metadata,omitempty"`
+	// Error is why the event could not be dispatched, only set on dead letters
+	Error string `json:"error,omitempty"`
+	id    string
+}
+
+// Interface is a queue of events, which are removed once they are dispatched, or moved to the dead letters if they
+// cannot be
+type Interface interface {
+	// Add queues the event, returning ErrFull if the queue is full
+	Add(event Event) error
+	// Get blocks until there is an event to dispatch, it returns false once the queue is closed
+	Get() (*Event, bool)
+	// Done removes a dispatched event from the queue
+	Done(event *Event) error
+	// DeadLetter removes an event that could not be dispatched from the queue, keeping it for inspection
+	DeadLetter(event *Event, reason error) error
+	// Len is the number of events in the queue, including those being dispatched
+	Len() int
+	// Close stops Get returning events, those not yet dispatched remain in the queue
+	Close()
+}
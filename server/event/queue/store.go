@@ -0,0 +1,247 @@
+package queue
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/argoproj/argo-workflows/v3/server/state"
+)
+
+const (
+	pendingList  = "events:pending"
+	deadList     = "events:dead"
+	replicasList = "events:replicas"
+)
+
+// leaseTTL is how long a replica can stop renewing its lease before the events it is dispatching are queued again
+var leaseTTL = 30 * time.Second
+
+// store keeps events in a list in the shared state store, so they are dispatched by whichever replica is free. Each
+// replica moves the events it is dispatching to its own processing list, and holds a lease while it is running. When a
+// replica's lease expires, another replica moves the events it was dispatching back to the pending list.
+type store struct {
+	store   state.Store
+	replica string
+	maxSize int
+	// ctx is cancelled when the queue is closed, events being dispatched can still be completed afterwards
+	ctx    context.Context
+	cancel context.CancelFunc
+	mu     sync.Mutex
+	closed bool
+}
+
+// NewStore returns a queue which keeps up to maxSize events in the store, shared by all the replicas using it. Events
+// that the replica was dispatching when it last stopped are replayed. The events contain the senders' authorization,
+// so the store must only be accessible by the server.
+func NewStore(s state.Store, replica string, maxSize int) (Interface, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	q := &store{store: s, replica: replica, maxSize: maxSize, ctx: ctx, cancel: cancel}
+	if err := s.Set(ctx, q.leaseKey(replica), []byte(replica), leaseTTL); err != nil {
+		cancel()
+		return nil, fmt.Errorf("failed to take event queue lease: %w", err)
+	}
+	replicas, err := s.Range(ctx, replicasList)
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("failed to list event queue replicas: %w", err)
+	}
+	if !containsValue(replicas, []byte(replica)) {
+		if err := s.Push(ctx, replicasList, []byte(replica)); err != nil {
+			cancel()
+			return nil, fmt.Errorf("failed to register event queue replica: %w", err)
+		}
+	}
+	if err := q.requeue(replica); err != nil {
+		cancel()
+		return nil, err
+	}
+	go q.run()
+	return q, nil
+}
+
+func containsValue(values [][]byte, value []byte) bool {
+	for _, v := range values {
+		if bytes.Equal(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func (q *store) leaseKey(replica string) string {
+	return "events:lease:" + replica
+}
+
+func (q *store) processingList(replica string) string {
+	return "events:processing:" + replica
+}
+
+// run renews the replica's lease, and requeues the events of replicas whose lease has expired
+func (q *store) run() {
+	ticker := time.NewTicker(leaseTTL / 3)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-q.ctx.Done():
+			return
+		case <-ticker.C:
+			if err := q.store.Set(q.ctx, q.leaseKey(q.replica), []byte(q.replica), leaseTTL); err != nil {
+				log.WithError(err).Warn("Failed to renew event queue lease")
+			}
+			if err := q.recover(); err != nil {
+				log.WithError(err).Warn("Failed to requeue the events of stopped replicas")
+			}
+		}
+	}
+}
+
+// recover requeues the events of the replicas whose lease has expired
+func (q *store) recover() error {
+	replicas, err := q.store.Range(q.ctx, replicasList)
+	if err != nil {
+		return err
+	}
+	for _, r := range replicas {
+		replica := string(r)
+		if replica == q.replica {
+			continue
+		}
+		// taking the lease of a stopped replica ensures only one replica requeues its events
+		ok, err := q.store.SetIfAbsent(q.ctx, q.leaseKey(replica), []byte(q.replica), leaseTTL)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+		if err := q.requeue(replica); err != nil {
+			return err
+		}
+		if err := q.store.Remove(q.ctx, replicasList, r); err != nil {
+			return err
+		}
+		if err := q.store.Delete(q.ctx, q.leaseKey(replica)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// requeue moves the events the replica was dispatching back to the pending list
+func (q *store) requeue(replica string) error {
+	n := 0
+	for {
+		_, err := q.store.Move(q.ctx, q.processingList(replica), pendingList, 0)
+		if errors.Is(err, state.ErrNotFound) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to requeue events: %w", err)
+		}
+		n++
+	}
+	if n > 0 {
+		log.WithFields(log.Fields{"events": n, "replica": replica}).Info("Replaying queued events")
+		replayedMetric.Add(float64(n))
+	}
+	return nil
+}
+
+func (q *store) Add(event Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	q.mu.Lock()
+	closed := q.closed
+	q.mu.Unlock()
+	if closed {
+		return fmt.Errorf("event queue closed")
+	}
+	n, err := q.store.Len(context.Background(), pendingList)
+	if err != nil {
+		return err
+	}
+	if n >= q.maxSize {
+		return ErrFull
+	}
+	if err := q.store.Push(context.Background(), pendingList, data); err != nil {
+		return fmt.Errorf("failed to queue event: %w", err)
+	}
+	q.updateDepth()
+	return nil
+}
+
+func (q *store) Get() (*Event, bool) {
+	for {
+		data, err := q.store.Move(q.ctx, pendingList, q.processingList(q.replica), time.Second)
+		if q.ctx.Err() != nil {
+			return nil, false
+		}
+		if errors.Is(err, state.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			log.WithError(err).Error("Failed to get queued event")
+			time.Sleep(time.Second)
+			continue
+		}
+		event := &Event{id: string(data)}
+		if err := json.Unmarshal(data, event); err != nil {
+			log.WithError(err).Error("Failed to read queued event")
+			_ = q.DeadLetter(event, err)
+			continue
+		}
+		return event, true
+	}
+}
+
+func (q *store) Done(event *Event) error {
+	defer q.updateDepth()
+	return q.store.Remove(context.Background(), q.processingList(q.replica), []byte(event.id))
+}
+
+func (q *store) DeadLetter(event *Event, reason error) error {
+	defer q.updateDepth()
+	deadLettersMetric.Inc()
+	event.Error = reason.Error()
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	if err := q.store.Push(context.Background(), deadList, data); err != nil {
+		return fmt.Errorf("failed to write dead letter: %w", err)
+	}
+	return q.store.Remove(context.Background(), q.processingList(q.replica), []byte(event.id))
+}
+
+func (q *store) updateDepth() {
+	depthMetric.Set(float64(q.Len()))
+}
+
+// Len is the number of events waiting to be dispatched by any replica, and those being dispatched by this one
+func (q *store) Len() int {
+	pending, err := q.store.Len(context.Background(), pendingList)
+	if err != nil {
+		log.WithError(err).Warn("Failed to get event queue length")
+	}
+	processing, err := q.store.Len(context.Background(), q.processingList(q.replica))
+	if err != nil {
+		log.WithError(err).Warn("Failed to get event queue length")
+	}
+	return pending + processing
+}
+
+func (q *store) Close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.closed = true
+	q.cancel()
+}
@@ -0,0 +1,72 @@
+package queue
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/argoproj/argo-workflows/v3/server/state"
+)
+
+func TestStore(t *testing.T) {
+	s := state.NewMemory()
+	q, err := NewStore(s, "replica-1", 2)
+	assert.NoError(t, err)
+
+	assert.NoError(t, q.Add(Event{Namespace: "ns-1", Metadata: map[string][]string{"authorization": {"Bearer my-token"}}}))
+	assert.NoError(t, q.Add(Event{Namespace: "ns-2"}))
+	assert.Equal(t, ErrFull, q.Add(Event{Namespace: "ns-3"}), "backpressure when queue is full")
+	assert.Equal(t, 2, q.Len())
+
+	event, ok := q.Get()
+	if assert.True(t, ok) {
+		assert.Equal(t, "ns-1", event.Namespace)
+		assert.Equal(t, []string{"Bearer my-token"}, event.Metadata["authorization"])
+		assert.NoError(t, q.Done(event))
+	}
+	event, ok = q.Get()
+	if assert.True(t, ok) {
+		assert.Equal(t, "ns-2", event.Namespace)
+		assert.NoError(t, q.DeadLetter(event, fmt.Errorf("my-error")))
+	}
+	assert.Equal(t, 0, q.Len())
+	n, err := s.Len(context.Background(), deadList)
+	if assert.NoError(t, err) {
+		assert.Equal(t, 1, n)
+	}
+
+	t.Run("SharedByReplicas", func(t *testing.T) {
+		q2, err := NewStore(s, "replica-2", 2)
+		assert.NoError(t, err)
+		defer q2.Close()
+		assert.NoError(t, q.Add(Event{Namespace: "ns-4"}))
+		event, ok := q2.Get()
+		if assert.True(t, ok) {
+			assert.Equal(t, "ns-4", event.Namespace)
+			assert.NoError(t, q2.Done(event))
+		}
+	})
+
+	t.Run("Replay", func(t *testing.T) {
+		assert.NoError(t, q.Add(Event{Namespace: "ns-5"}))
+		_, ok := q.Get()
+		assert.True(t, ok)
+		// the replica stops while dispatching the event
+		q.Close()
+		_, ok = q.Get()
+		assert.False(t, ok, "no events after close")
+
+		q3, err := NewStore(s, "replica-3", 2)
+		assert.NoError(t, err)
+		defer q3.Close()
+		// the lease of the stopped replica expires
+		assert.NoError(t, s.Delete(context.Background(), "events:lease:replica-1"))
+		assert.NoError(t, q3.(*store).recover())
+		event, ok := q3.Get()
+		if assert.True(t, ok) {
+			assert.Equal(t, "ns-5", event.Namespace, "events of stopped replicas are replayed")
+		}
+	})
+}
@@ -0,0 +1,213 @@
+package grpcweb
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+)
+
+// connectCodes are the names of the codes in the Connect protocol, and the HTTP status of unary responses with them
+var connectCodes = map[codes.Code]struct {
+	name   string
+	status int
+}{
+	codes.Canceled:           {"canceled", 499},
+	codes.Unknown:            {"unknown", http.StatusInternalServerError},
+	codes.InvalidArgument:    {"invalid_argument", http.StatusBadRequest},
+	codes.DeadlineExceeded:   {"deadline_exceeded", http.StatusGatewayTimeout},
+	codes.NotFound:           {"not_found", http.StatusNotFound},
+	codes.AlreadyExists:      {"already_exists", http.StatusConflict},
+	codes.PermissionDenied:   {"permission_denied", http.StatusForbidden},
+	codes.ResourceExhausted:  {"resource_exhausted", http.StatusTooManyRequests},
+	codes.FailedPrecondition: {"failed_precondition", http.StatusBadRequest},
+	codes.Aborted:            {"aborted", http.StatusConflict},
+	codes.OutOfRange:         {"out_of_range", http.StatusBadRequest},
+	codes.Unimplemented:      {"unimplemented", http.StatusNotImplemented},
+	codes.Internal:           {"internal", http.StatusInternalServerError},
+	codes.Unavailable:        {"unavailable", http.StatusServiceUnavailable},
+	codes.DataLoss:           {"data_loss", http.StatusInternalServerError},
+	codes.Unauthenticated:    {"unauthenticated", http.StatusUnauthorized},
+}
+
+type connectError struct {
+	Code    string `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+type connectEndStream struct {
+	Error    *connectError       `json:"error,omitempty"`
+	Metadata map[string][]string `json:"metadata,omitempty"`
+}
+
+// getConnectError returns the error of the gRPC status in the trailers, or nil if the call succeeded
+func getConnectError(trailers http.Header) *connectError {
+	code, err := strconv.Atoi(trailers.Get("Grpc-Status"))
+	if err != nil {
+		return &connectError{Code: connectCodes[codes.Unknown].name, Message: "missing gRPC status"}
+	}
+	if codes.Code(code) == codes.OK {
+		return nil
+	}
+	c, ok := connectCodes[codes.Code(code)]
+	if !ok {
+		c = connectCodes[codes.Unknown]
+	}
+	// gRPC percent-encodes the message
+	message, err := url.PathUnescape(trailers.Get("Grpc-Message"))
+	if err != nil {
+		message = trailers.Get("Grpc-Message")
+	}
+	return &connectError{Code: c.name, Message: message}
+}
+
+func getConnectStatus(e *connectError) int {
+	for _, c := range connectCodes {
+		if c.name == e.Code {
+			return c.status
+		}
+	}
+	return http.StatusInternalServerError
+}
+
+// getMetadata returns the trailers other than the gRPC status
+func getMetadata(trailers http.Header) http.Header {
+	metadata := make(http.Header)
+	for k, v := range trailers {
+		if !strings.HasPrefix(k, "Grpc-") {
+			metadata[k] = v
+		}
+	}
+	return metadata
+}
+
+// newConnectRequest returns the gRPC request for a Connect request. Compression is not supported, so requests that are
+// compressed are rejected.
+func newConnectRequest(r *http.Request, body io.Reader, encodingHeader string) (*http.Request, error) {
+	if encoding := r.Header.Get(encodingHeader); encoding != "" && encoding != "identity" {
+		return nil, fmt.Errorf("unsupported %s %q", encodingHeader, encoding)
+	}
+	req := newGRPCRequest(r, body, contentTypeGRPC+"+proto")
+	if v := r.Header.Get("Connect-Timeout-Ms"); v != "" {
+		ms, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || ms < 0 {
+			return nil, fmt.Errorf("invalid Connect-Timeout-Ms %q", v)
+		}
+		// gRPC timeouts have at most 8 digits
+		if ms < 1e8 {
+			req.Header.Set("Grpc-Timeout", fmt.Sprintf("%dm", ms))
+		} else {
+			req.Header.Set("Grpc-Timeout", fmt.Sprintf("%dS", ms/1000))
+		}
+	}
+	return req, nil
+}
+
+func (h *handler) serveConnectStreaming(w http.ResponseWriter, r *http.Request) {
+	rw := newResponseWriter(w, contentTypeConnectStreaming, false)
+	req, err := newConnectRequest(r, r.Body, "Connect-Content-Encoding")
+	if err != nil {
+		writeConnectEndStream(rw, &connectEndStream{Error: &connectError{Code: connectCodes[codes.Unimplemented].name, Message: err.Error()}})
+		return
+	}
+	h.grpcServer.ServeHTTP(rw, req)
+	if !rw.ok() {
+		return
+	}
+	// Connect sends the status and trailers in the body, as the last frame
+	trailers := rw.trailers()
+	endStream := &connectEndStream{Error: getConnectError(trailers), Metadata: make(map[string][]string)}
+	for k, v := range getMetadata(trailers) {
+		endStream.Metadata[strings.ToLower(k)] = v
+	}
+	writeConnectEndStream(rw, endStream)
+}
+
+func writeConnectEndStream(rw *responseWriter, endStream *connectEndStream) {
+	data, err := json.Marshal(endStream)
+	if err != nil {
+		data = []byte(`{"error":{"code":"internal"}}`)
+	}
+	_, _ = rw.Write(newFrame(flagEndStream, data))
+	rw.Flush()
+}
+
+// bufferedResponse keeps the response of a unary call, as Connect sends the status of unary calls in the headers
+type bufferedResponse struct {
+	header http.Header
+	code   int
+	body   bytes.Buffer
+}
+
+func (b *bufferedResponse) Header() http.Header {
+	return b.header
+}
+
+func (b *bufferedResponse) WriteHeader(code int) {
+	b.code = code
+}
+
+func (b *bufferedResponse) Write(data []byte) (int, error) {
+	return b.body.Write(data)
+}
+
+func (h *handler) serveConnectUnary(w http.ResponseWriter, r *http.Request) {
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeConnectError(w, &connectError{Code: connectCodes[codes.InvalidArgument].name, Message: err.Error()})
+		return
+	}
+	// the message of a unary call is the whole body, gRPC needs it in a frame
+	req, err := newConnectRequest(r, bytes.NewReader(newFrame(0, data)), "Content-Encoding")
+	if err != nil {
+		writeConnectError(w, &connectError{Code: connectCodes[codes.Unimplemented].name, Message: err.Error()})
+		return
+	}
+	res := &bufferedResponse{header: make(http.Header)}
+	rw := newResponseWriter(res, contentTypeConnectUnary, false)
+	h.grpcServer.ServeHTTP(rw, req)
+	if !rw.ok() {
+		for k, v := range res.header {
+			w.Header()[k] = v
+		}
+		w.WriteHeader(res.code)
+		_, _ = w.Write(res.body.Bytes())
+		return
+	}
+	for k, v := range res.header {
+		if k != "Content-Type" {
+			w.Header()[k] = v
+		}
+	}
+	trailers := rw.trailers()
+	for k, v := range getMetadata(trailers) {
+		w.Header()["Trailer-"+k] = v
+	}
+	if e := getConnectError(trailers); e != nil {
+		writeConnectError(w, e)
+		return
+	}
+	message := res.body.Bytes()
+	if len(message) >= frameHeaderBytes {
+		message = message[frameHeaderBytes:]
+	}
+	w.Header().Set("Content-Type", contentTypeConnectUnary)
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(message)
+}
+
+func writeConnectError(w http.ResponseWriter, e *connectError) {
+	data, err := json.Marshal(e)
+	if err != nil {
+		data = []byte(`{"code":"internal"}`)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(getConnectStatus(e))
+	_, _ = w.Write(data)
+}
@@ -0,0 +1,241 @@
+package grpcweb
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	"google.golang.org/grpc"
+)
+
+const (
+	contentTypeGRPC             = "application/grpc"
+	contentTypeGRPCWeb          = "application/grpc-web"
+	contentTypeGRPCWebText      = "application/grpc-web-text"
+	contentTypeConnectUnary     = "application/proto"
+	contentTypeConnectStreaming = "application/connect+proto"
+
+	// the flags of the frames of a response
+	flagTrailer      = 0x80
+	flagEndStream    = 0x02
+	frameHeaderBytes = 5
+)
+
+type protocol int
+
+const (
+	protocolNone protocol = iota
+	protocolGRPCWeb
+	protocolGRPCWebText
+	protocolConnectUnary
+	protocolConnectStreaming
+)
+
+type handler struct {
+	grpcServer  http.Handler
+	services    map[string]bool
+	next        http.Handler
+	allowOrigin string
+}
+
+// NewHandler returns a handler that serves the gRPC-Web and Connect requests for the methods of the gRPC server, so
+// that browsers and clients that cannot use HTTP/2 can use the gRPC API, and passes other requests to the next handler.
+// The requests are translated to gRPC requests and served by the gRPC server, so they go through its interceptors.
+func NewHandler(grpcServer *grpc.Server, next http.Handler, allowOrigin string) http.Handler {
+	services := make(map[string]bool)
+	for name := range grpcServer.GetServiceInfo() {
+		services[name] = true
+	}
+	return &handler{grpcServer: grpcServer, services: services, next: next, allowOrigin: allowOrigin}
+}
+
+// isMethod returns true if the path is "/<service>/<method>" for one of the gRPC server's services
+func (h *handler) isMethod(path string) bool {
+	parts := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	return len(parts) == 2 && h.services[parts[0]] && parts[1] != ""
+}
+
+func getProtocol(r *http.Request) protocol {
+	contentType := r.Header.Get("Content-Type")
+	switch {
+	case strings.HasPrefix(contentType, contentTypeGRPCWebText):
+		return protocolGRPCWebText
+	case strings.HasPrefix(contentType, contentTypeGRPCWeb):
+		return protocolGRPCWeb
+	case contentType == contentTypeConnectStreaming:
+		return protocolConnectStreaming
+	// Connect clients send the protocol version, which tells their unary requests apart from other protobuf requests
+	case contentType == contentTypeConnectUnary && r.Header.Get("Connect-Protocol-Version") == "1":
+		return protocolConnectUnary
+	}
+	return protocolNone
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !h.isMethod(r.URL.Path) {
+		h.next.ServeHTTP(w, r)
+		return
+	}
+	if h.allowOrigin != "" {
+		w.Header().Set("Access-Control-Allow-Origin", h.allowOrigin)
+		// browsers only let the page read the response to a request with credentials if the response allows them too
+		w.Header().Set("Access-Control-Allow-Credentials", "true")
+		if r.Method == http.MethodOptions {
+			w.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-Grpc-Web, X-User-Agent, Grpc-Timeout, Connect-Protocol-Version, Connect-Timeout-Ms")
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.Header().Set("Access-Control-Expose-Headers", "Grpc-Status, Grpc-Message, Grpc-Status-Details-Bin")
+	}
+	p := getProtocol(r)
+	if p == protocolNone || r.Method != http.MethodPost {
+		h.next.ServeHTTP(w, r)
+		return
+	}
+	switch p {
+	case protocolGRPCWeb, protocolGRPCWebText:
+		h.serveGRPCWeb(w, r, p == protocolGRPCWebText)
+	case protocolConnectStreaming:
+		h.serveConnectStreaming(w, r)
+	case protocolConnectUnary:
+		h.serveConnectUnary(w, r)
+	}
+}
+
+// newGRPCRequest returns the gRPC request for a request of another protocol, with the body and content type
+func newGRPCRequest(r *http.Request, body io.Reader, contentType string) *http.Request {
+	req := r.Clone(r.Context())
+	req.ProtoMajor, req.ProtoMinor, req.Proto = 2, 0, "HTTP/2"
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Del("Content-Length")
+	req.ContentLength = -1
+	req.Body = io.NopCloser(body)
+	return req
+}
+
+func (h *handler) serveGRPCWeb(w http.ResponseWriter, r *http.Request, text bool) {
+	contentType := r.Header.Get("Content-Type")
+	var body io.Reader = r.Body
+	subtype := strings.TrimPrefix(contentType, contentTypeGRPCWeb)
+	if text {
+		body = base64.NewDecoder(base64.StdEncoding, r.Body)
+		subtype = strings.TrimPrefix(contentType, contentTypeGRPCWebText)
+	}
+	rw := newResponseWriter(w, contentType, text)
+	h.grpcServer.ServeHTTP(rw, newGRPCRequest(r, body, contentTypeGRPC+subtype))
+	if !rw.ok() {
+		return
+	}
+	// gRPC-Web sends the trailers in the body, as the last frame
+	var trailers bytes.Buffer
+	t := rw.trailers()
+	for _, k := range sortedKeys(t) {
+		for _, v := range t[k] {
+			_, _ = fmt.Fprintf(&trailers, "%s: %s\r\n", strings.ToLower(k), v)
+		}
+	}
+	_, _ = rw.Write(newFrame(flagTrailer, trailers.Bytes()))
+	rw.Flush()
+}
+
+// responseWriter is given to the gRPC server. The gRPC server sets its trailers in the header after it has written the
+// body, as HTTP/2 does, so they are told apart from the headers by whether they were set before the body was written.
+type responseWriter struct {
+	w           http.ResponseWriter
+	header      http.Header
+	sent        map[string]bool
+	code        int
+	contentType string
+	text        bool
+	// text is buffered until it is flushed, so that each message is encoded as a whole
+	buf bytes.Buffer
+}
+
+func newResponseWriter(w http.ResponseWriter, contentType string, text bool) *responseWriter {
+	return &responseWriter{w: w, header: make(http.Header), sent: make(map[string]bool), contentType: contentType, text: text}
+}
+
+func (rw *responseWriter) Header() http.Header {
+	return rw.header
+}
+
+func (rw *responseWriter) WriteHeader(code int) {
+	if rw.code != 0 {
+		return
+	}
+	rw.code = code
+	for k, v := range rw.header {
+		rw.sent[k] = true
+		if k == "Trailer" || k == "Content-Type" || strings.HasPrefix(k, http.TrailerPrefix) {
+			continue
+		}
+		rw.w.Header()[k] = v
+	}
+	if code == http.StatusOK {
+		rw.w.Header().Set("Content-Type", rw.contentType)
+	} else {
+		rw.w.Header().Set("Content-Type", rw.header.Get("Content-Type"))
+	}
+	rw.w.WriteHeader(code)
+}
+
+func (rw *responseWriter) Write(b []byte) (int, error) {
+	rw.WriteHeader(http.StatusOK)
+	if rw.text && rw.code == http.StatusOK {
+		return rw.buf.Write(b)
+	}
+	return rw.w.Write(b)
+}
+
+func (rw *responseWriter) Flush() {
+	rw.WriteHeader(http.StatusOK)
+	if rw.buf.Len() > 0 {
+		_, _ = rw.w.Write([]byte(base64.StdEncoding.EncodeToString(rw.buf.Bytes())))
+		rw.buf.Reset()
+	}
+	if f, ok := rw.w.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// ok returns false if the gRPC server rejected the request, rather than responding with a status
+func (rw *responseWriter) ok() bool {
+	return rw.code == 0 || rw.code == http.StatusOK
+}
+
+// trailers returns the fields that were set after the headers were written
+func (rw *responseWriter) trailers() http.Header {
+	t := make(http.Header)
+	for k, v := range rw.header {
+		switch {
+		case strings.HasPrefix(k, http.TrailerPrefix):
+			t[http.CanonicalHeaderKey(strings.TrimPrefix(k, http.TrailerPrefix))] = v
+		case k != "Trailer" && !rw.sent[k]:
+			t[k] = v
+		}
+	}
+	return t
+}
+
+func newFrame(flags byte, data []byte) []byte {
+	frame := make([]byte, frameHeaderBytes+len(data))
+	frame[0] = flags
+	binary.BigEndian.PutUint32(frame[1:frameHeaderBytes], uint32(len(data)))
+	copy(frame[frameHeaderBytes:], data)
+	return frame
+}
+
+func sortedKeys(h http.Header) []string {
+	keys := make([]string, 0, len(h))
+	for k := range h {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,205 @@
+package grpcweb
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+const checkPath = "/grpc.health.v1.Health/Check"
+
+func newTestServer(t *testing.T) *httptest.Server {
+	return newTestServerWithOrigin(t, "*")
+}
+
+func newTestServerWithOrigin(t *testing.T, allowOrigin string) *httptest.Server {
+	grpcServer := grpc.NewServer()
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus("my-service", healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(grpcServer, healthServer)
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("next"))
+	})
+	s := httptest.NewServer(NewHandler(grpcServer, next, allowOrigin))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func marshalRequest(t *testing.T, service string) []byte {
+	data, err := proto.Marshal(&healthpb.HealthCheckRequest{Service: service})
+	require.NoError(t, err)
+	return data
+}
+
+type frame struct {
+	flags byte
+	data  []byte
+}
+
+func readFrames(t *testing.T, data []byte) []frame {
+	var frames []frame
+	for len(data) > 0 {
+		require.GreaterOrEqual(t, len(data), frameHeaderBytes)
+		n := binary.BigEndian.Uint32(data[1:frameHeaderBytes])
+		frames = append(frames, frame{data[0], data[frameHeaderBytes : frameHeaderBytes+int(n)]})
+		data = data[frameHeaderBytes+int(n):]
+	}
+	return frames
+}
+
+func post(t *testing.T, url, contentType string, body []byte, header map[string]string) (*http.Response, []byte) {
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", contentType)
+	for k, v := range header {
+		req.Header.Set(k, v)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp, data
+}
+
+func TestGRPCWeb(t *testing.T) {
+	s := newTestServer(t)
+	t.Run("OK", func(t *testing.T) {
+		resp, data := post(t, s.URL+checkPath, "application/grpc-web+proto", newFrame(0, marshalRequest(t, "my-service")), nil)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/grpc-web+proto", resp.Header.Get("Content-Type"))
+		assert.Equal(t, "*", resp.Header.Get("Access-Control-Allow-Origin"))
+		frames := readFrames(t, data)
+		require.Len(t, frames, 2)
+		res := &healthpb.HealthCheckResponse{}
+		require.NoError(t, proto.Unmarshal(frames[0].data, res))
+		assert.Equal(t, healthpb.HealthCheckResponse_SERVING, res.Status)
+		assert.Equal(t, byte(flagTrailer), frames[1].flags)
+		assert.Contains(t, string(frames[1].data), "grpc-status: 0\r\n")
+	})
+	t.Run("Error", func(t *testing.T) {
+		resp, data := post(t, s.URL+checkPath, "application/grpc-web+proto", newFrame(0, marshalRequest(t, "unknown")), nil)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		frames := readFrames(t, data)
+		require.Len(t, frames, 1)
+		assert.Equal(t, byte(flagTrailer), frames[0].flags)
+		assert.Contains(t, string(frames[0].data), "grpc-status: 5\r\n")
+		assert.Contains(t, string(frames[0].data), "grpc-message: unknown service\r\n")
+	})
+	t.Run("Text", func(t *testing.T) {
+		body := base64.StdEncoding.EncodeToString(newFrame(0, marshalRequest(t, "my-service")))
+		resp, data := post(t, s.URL+checkPath, "application/grpc-web-text", []byte(body), nil)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/grpc-web-text", resp.Header.Get("Content-Type"))
+		// each flush is encoded separately, so the body is decoded in groups of four characters
+		var decoded []byte
+		for i := 0; i+4 <= len(data); i += 4 {
+			b, err := base64.StdEncoding.DecodeString(string(data[i : i+4]))
+			require.NoError(t, err)
+			decoded = append(decoded, b...)
+		}
+		frames := readFrames(t, decoded)
+		require.Len(t, frames, 2)
+		assert.Contains(t, string(frames[1].data), "grpc-status: 0\r\n")
+	})
+}
+
+func TestCrossOrigin(t *testing.T) {
+	s := newTestServerWithOrigin(t, "https://ui.example.com")
+	header := map[string]string{"Origin": "https://ui.example.com", "Authorization": "Bearer my-token"}
+	t.Run("Preflight", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodOptions, s.URL+checkPath, nil)
+		require.NoError(t, err)
+		for k, v := range header {
+			req.Header.Set(k, v)
+		}
+		req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		assert.Equal(t, "https://ui.example.com", resp.Header.Get("Access-Control-Allow-Origin"))
+		assert.Equal(t, "true", resp.Header.Get("Access-Control-Allow-Credentials"))
+	})
+	t.Run("Request", func(t *testing.T) {
+		resp, _ := post(t, s.URL+checkPath, "application/grpc-web+proto", newFrame(0, marshalRequest(t, "my-service")), header)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "https://ui.example.com", resp.Header.Get("Access-Control-Allow-Origin"))
+		assert.Equal(t, "true", resp.Header.Get("Access-Control-Allow-Credentials"))
+		assert.Contains(t, resp.Header.Get("Access-Control-Expose-Headers"), "Grpc-Status")
+	})
+	t.Run("ConnectRequest", func(t *testing.T) {
+		header := map[string]string{"Origin": "https://ui.example.com", "Connect-Protocol-Version": "1"}
+		resp, _ := post(t, s.URL+checkPath, "application/proto", marshalRequest(t, "my-service"), header)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "true", resp.Header.Get("Access-Control-Allow-Credentials"))
+	})
+}
+
+func TestConnect(t *testing.T) {
+	s := newTestServer(t)
+	header := map[string]string{"Connect-Protocol-Version": "1"}
+	t.Run("Unary", func(t *testing.T) {
+		resp, data := post(t, s.URL+checkPath, "application/proto", marshalRequest(t, "my-service"), header)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/proto", resp.Header.Get("Content-Type"))
+		res := &healthpb.HealthCheckResponse{}
+		require.NoError(t, proto.Unmarshal(data, res))
+		assert.Equal(t, healthpb.HealthCheckResponse_SERVING, res.Status)
+	})
+	t.Run("UnaryError", func(t *testing.T) {
+		resp, data := post(t, s.URL+checkPath, "application/proto", marshalRequest(t, "unknown"), header)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+		assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+		assert.JSONEq(t, `{"code":"not_found","message":"unknown service"}`, string(data))
+	})
+	t.Run("UnaryCompressed", func(t *testing.T) {
+		resp, _ := post(t, s.URL+checkPath, "application/proto", marshalRequest(t, "my-service"), map[string]string{"Connect-Protocol-Version": "1", "Content-Encoding": "gzip"})
+		assert.Equal(t, http.StatusNotImplemented, resp.StatusCode)
+	})
+	t.Run("Streaming", func(t *testing.T) {
+		resp, data := post(t, s.URL+checkPath, "application/connect+proto", newFrame(0, marshalRequest(t, "unknown")), nil)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/connect+proto", resp.Header.Get("Content-Type"))
+		frames := readFrames(t, data)
+		require.Len(t, frames, 1)
+		assert.Equal(t, byte(flagEndStream), frames[0].flags)
+		endStream := &connectEndStream{}
+		require.NoError(t, json.Unmarshal(frames[0].data, endStream))
+		require.NotNil(t, endStream.Error)
+		assert.Equal(t, "not_found", endStream.Error.Code)
+	})
+}
+
+func TestNext(t *testing.T) {
+	s := newTestServer(t)
+	t.Run("OtherPath", func(t *testing.T) {
+		_, data := post(t, s.URL+"/api/v1/info", "application/grpc-web+proto", nil, nil)
+		assert.Equal(t, "next", string(data))
+	})
+	t.Run("OtherContentType", func(t *testing.T) {
+		_, data := post(t, s.URL+checkPath, "application/json", nil, nil)
+		assert.Equal(t, "next", string(data))
+	})
+	t.Run("Preflight", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodOptions, s.URL+checkPath, nil)
+		require.NoError(t, err)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		assert.Equal(t, "*", resp.Header.Get("Access-Control-Allow-Origin"))
+	})
+}
@@ -2,14 +2,22 @@ package info
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"os"
+	"time"
 
 	log "github.com/sirupsen/logrus"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/argoproj/argo-workflows/v3"
 	infopkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/info"
+	"github.com/argoproj/argo-workflows/v3/pkg/apis/workflow"
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 	"github.com/argoproj/argo-workflows/v3/server/auth"
+	"github.com/argoproj/argo-workflows/v3/server/cache"
 )
 
 type infoServer struct {
@@ -17,12 +25,15 @@ type infoServer struct {
 	links            []*wfv1.Link
 	columns          []*wfv1.Column
 	navColor         string
+	// namespaces caches the accessible namespaces of each caller
+	namespaces cache.Interface
 }
 
-func (i *infoServer) GetUserInfo(ctx context.Context, _ *infopkg.GetUserInfoRequest) (*infopkg.GetUserInfoResponse, error) {
+func (i *infoServer) GetUserInfo(ctx context.Context, req *infopkg.GetUserInfoRequest) (*infopkg.GetUserInfoResponse, error) {
+	resp := &infopkg.GetUserInfoResponse{}
 	claims := auth.GetClaims(ctx)
 	if claims != nil {
-		return &infopkg.GetUserInfoResponse{
+		resp = &infopkg.GetUserInfoResponse{
 			Subject:                 claims.Subject,
 			Issuer:                  claims.Issuer,
 			Groups:                  claims.Groups,
@@ -31,9 +42,160 @@ func (i *infoServer) GetUserInfo(ctx context.Context, _ *infopkg.GetUserInfoRequ
 			EmailVerified:           claims.EmailVerified,
 			ServiceAccountName:      claims.ServiceAccountName,
 			ServiceAccountNamespace: claims.ServiceAccountNamespace,
-		}, nil
+		}
 	}
-	return &infopkg.GetUserInfoResponse{}, nil
+	if namespace := req.GetNamespace(); namespace != "" {
+		if i.managedNamespace != "" {
+			namespace = i.managedNamespace
+		}
+		namespaces, err := i.accessibleNamespaces(ctx)
+		if err != nil {
+			return nil, err
+		}
+		permissions, err := permissions(ctx, namespace)
+		if err != nil {
+			return nil, err
+		}
+		resp.Namespaces = namespaces
+		resp.Permissions = permissions
+	}
+	return resp, nil
+}
+
+// maxAccessibleNamespaces is the most namespaces accessibleNamespaces checks one by one. Beyond it, the namespaces are
+// not reported, as checking them would take too long.
+const maxAccessibleNamespaces = 100
+
+// accessibleNamespaces returns the namespaces the caller can list workflows in, or "*" if they can list them in all
+// namespaces. Checking each namespace takes a request per namespace, so the result is cached for each caller.
+func (i *infoServer) accessibleNamespaces(ctx context.Context) ([]string, error) {
+	key := callerKey(ctx)
+	if i.namespaces != nil && key != "" {
+		if namespaces, ok := i.namespaces.Get(key); ok {
+			return namespaces.([]string), nil
+		}
+	}
+	namespaces, err := i.listAccessibleNamespaces(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if i.namespaces != nil && key != "" {
+		i.namespaces.Add(key, namespaces)
+	}
+	return namespaces, nil
+}
+
+func (i *infoServer) listAccessibleNamespaces(ctx context.Context) ([]string, error) {
+	if i.managedNamespace != "" {
+		allowed, err := auth.CanI(ctx, "list", workflow.WorkflowPlural, i.managedNamespace, "")
+		if err != nil || !allowed {
+			return nil, err
+		}
+		return []string{i.managedNamespace}, nil
+	}
+	allowed, err := auth.CanI(ctx, "list", workflow.WorkflowPlural, "", "")
+	if err != nil {
+		return nil, err
+	}
+	if allowed {
+		return []string{"*"}, nil
+	}
+	list, err := auth.GetKubeClient(ctx).CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+	if apierr.IsForbidden(err) {
+		// we cannot enumerate namespaces, so we cannot tell which ones are accessible
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(list.Items) > maxAccessibleNamespaces {
+		log.WithField("namespaces", len(list.Items)).Debug("too many namespaces to check which are accessible")
+		return nil, nil
+	}
+	var namespaces []string
+	for _, ns := range list.Items {
+		allowed, err := auth.CanI(ctx, "list", workflow.WorkflowPlural, ns.Name, "")
+		if err != nil {
+			return nil, err
+		}
+		if allowed {
+			namespaces = append(namespaces, ns.Name)
+		}
+	}
+	return namespaces, nil
+}
+
+// callerKey identifies the caller by the credentials of their Kubernetes client, or returns "" if it has none
+func callerKey(ctx context.Context) string {
+	restConfig := auth.GetRestConfig(ctx)
+	if restConfig == nil {
+		return ""
+	}
+	h := sha256.New()
+	for _, v := range []string{restConfig.Host, restConfig.Username, restConfig.Password, restConfig.BearerToken, restConfig.BearerTokenFile} {
+		_, _ = h.Write([]byte(v))
+		_, _ = h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+var (
+	permissionResources = []string{workflow.WorkflowPlural, workflow.WorkflowTemplatePlural, workflow.CronWorkflowPlural, workflow.ClusterWorkflowTemplatePlural}
+	permissionVerbs     = []string{"get", "list", "watch", "create", "update", "patch", "delete"}
+)
+
+// permissions returns the "<verb> <resource>" pairs the caller is permitted for workflow resources in the namespace.
+// They are read from a single rules review, unless it is incomplete, e.g. because a webhook authorizer is used, in which
+// case each pair is checked with an access review.
+func permissions(ctx context.Context, namespace string) ([]string, error) {
+	review, err := auth.GetKubeClient(ctx).AuthorizationV1().SelfSubjectRulesReviews().Create(ctx, &authorizationv1.SelfSubjectRulesReview{
+		Spec: authorizationv1.SelfSubjectRulesReviewSpec{Namespace: namespace},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return nil, err
+	}
+	var permissions []string
+	for _, resource := range permissionResources {
+		ns := namespace
+		if resource == workflow.ClusterWorkflowTemplatePlural {
+			ns = ""
+		}
+		for _, verb := range permissionVerbs {
+			allowed := rulesAllow(review.Status.ResourceRules, verb, resource)
+			if !allowed && review.Status.Incomplete {
+				allowed, err = auth.CanI(ctx, verb, resource, ns, "")
+				if err != nil {
+					return nil, err
+				}
+			}
+			if allowed {
+				permissions = append(permissions, verb+" "+resource)
+			}
+		}
+	}
+	return permissions, nil
+}
+
+// rulesAllow returns whether the rules allow the verb on all resources of the workflow resource type
+func rulesAllow(rules []authorizationv1.ResourceRule, verb, resource string) bool {
+	for _, rule := range rules {
+		if len(rule.ResourceNames) == 0 &&
+			matchesRule(rule.APIGroups, workflow.Group) &&
+			matchesRule(rule.Resources, resource) &&
+			matchesRule(rule.Verbs, verb) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesRule(values []string, value string) bool {
+	for _, v := range values {
+		if v == "*" || v == value {
+			return true
+		}
+	}
+	return false
 }
 
 func (i *infoServer) GetInfo(context.Context, *infopkg.GetInfoRequest) (*infopkg.InfoResponse, error) {
@@ -73,5 +235,5 @@ func (i *infoServer) CollectEvent(ctx context.Context, req *infopkg.CollectEvent
 }
 
 func NewInfoServer(managedNamespace string, links []*wfv1.Link, columns []*wfv1.Column, navColor string) infopkg.InfoServiceServer {
-	return &infoServer{managedNamespace, links, columns, navColor}
+	return &infoServer{managedNamespace, links, columns, navColor, cache.NewLRUTtlCache(time.Minute, 1000)}
 }
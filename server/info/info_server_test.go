@@ -6,7 +6,15 @@ import (
 
 	"github.com/go-jose/go-jose/v3/jwt"
 	"github.com/stretchr/testify/assert"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	k8stesting "k8s.io/client-go/testing"
 
+	infopkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/info"
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 	"github.com/argoproj/argo-workflows/v3/server/auth"
 	"github.com/argoproj/argo-workflows/v3/server/auth/types"
@@ -27,6 +35,63 @@ func Test_infoServer_GetUserInfo(t *testing.T) {
 	}
 }
 
+func Test_infoServer_GetUserInfo_Permissions(t *testing.T) {
+	kubeClient := &kubefake.Clientset{}
+	kubeClient.AddReactor("create", "selfsubjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		attrs := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectAccessReview).Spec.ResourceAttributes
+		allowed := attrs.Namespace == "my-ns" && attrs.Resource == "workflows" && (attrs.Verb == "list" || attrs.Verb == "create")
+		return true, &authorizationv1.SelfSubjectAccessReview{Status: authorizationv1.SubjectAccessReviewStatus{Allowed: allowed}}, nil
+	})
+	incomplete := false
+	kubeClient.AddReactor("create", "selfsubjectrulesreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := &authorizationv1.SelfSubjectRulesReview{Status: authorizationv1.SubjectRulesReviewStatus{Incomplete: incomplete}}
+		if !incomplete && action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectRulesReview).Spec.Namespace == "my-ns" {
+			review.Status.ResourceRules = []authorizationv1.ResourceRule{
+				{APIGroups: []string{"argoproj.io"}, Resources: []string{"workflows"}, Verbs: []string{"list", "create"}},
+				{APIGroups: []string{"argoproj.io"}, Resources: []string{"workflowtemplates"}, Verbs: []string{"get"}, ResourceNames: []string{"my-wftmpl"}},
+			}
+		}
+		return true, review, nil
+	})
+	namespaceLists := 0
+	kubeClient.AddReactor("list", "namespaces", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		namespaceLists++
+		return true, &corev1.NamespaceList{Items: []corev1.Namespace{{ObjectMeta: metav1.ObjectMeta{Name: "my-ns"}}, {ObjectMeta: metav1.ObjectMeta{Name: "other-ns"}}}}, nil
+	})
+	ctx := context.WithValue(context.WithValue(context.TODO(), auth.KubeKey, kubeClient), auth.RestConfigKey, &rest.Config{BearerToken: "my-token"})
+	i := NewInfoServer("", nil, nil, "").(*infoServer)
+	t.Run("NotRequested", func(t *testing.T) {
+		info, err := i.GetUserInfo(ctx, &infopkg.GetUserInfoRequest{})
+		if assert.NoError(t, err) {
+			assert.Empty(t, info.Namespaces)
+			assert.Empty(t, info.Permissions)
+		}
+	})
+	t.Run("Requested", func(t *testing.T) {
+		info, err := i.GetUserInfo(ctx, &infopkg.GetUserInfoRequest{Namespace: "my-ns"})
+		if assert.NoError(t, err) {
+			assert.Equal(t, []string{"my-ns"}, info.Namespaces)
+			assert.Equal(t, []string{"list workflows", "create workflows"}, info.Permissions)
+		}
+	})
+	t.Run("Cached", func(t *testing.T) {
+		lists := namespaceLists
+		info, err := i.GetUserInfo(ctx, &infopkg.GetUserInfoRequest{Namespace: "my-ns"})
+		if assert.NoError(t, err) {
+			assert.Equal(t, []string{"my-ns"}, info.Namespaces)
+			assert.Equal(t, lists, namespaceLists)
+		}
+	})
+	t.Run("IncompleteRules", func(t *testing.T) {
+		incomplete = true
+		defer func() { incomplete = false }()
+		info, err := i.GetUserInfo(ctx, &infopkg.GetUserInfoRequest{Namespace: "my-ns"})
+		if assert.NoError(t, err) {
+			assert.Equal(t, []string{"list workflows", "create workflows"}, info.Permissions)
+		}
+	})
+}
+
 func Test_infoServer_GetInfo(t *testing.T) {
 	t.Run("Ful Fields", func(t *testing.T) {
 		i := &infoServer{
@@ -0,0 +1,229 @@
+package redaction
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+
+	apiv1 "k8s.io/api/core/v1"
+
+	"github.com/argoproj/argo-workflows/v3/config"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/server/auth"
+)
+
+// Redacted replaces the redacted values
+const Redacted = "[redacted]"
+
+// Redactor redacts the values that may be secret from the workflows that are returned to users who cannot get the
+// secrets of the workflows' namespaces. A nil Redactor redacts nothing.
+type Redactor struct {
+	parameterNames []*regexp.Regexp
+	canGetSecrets  func(ctx context.Context, namespace string) (bool, error)
+}
+
+// New returns the redactor of the configuration, or nil if redaction is not configured
+func New(c *config.Redaction) (*Redactor, error) {
+	if c == nil {
+		return nil, nil
+	}
+	r := &Redactor{canGetSecrets: func(ctx context.Context, namespace string) (bool, error) {
+		return auth.CanI(ctx, "get", "secrets", namespace, "")
+	}}
+	for _, pattern := range c.ParameterNamePatterns {
+		x, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redaction parameter name pattern %q: %w", pattern, err)
+		}
+		r.parameterNames = append(r.parameterNames, x)
+	}
+	return r, nil
+}
+
+// RedactWorkflow returns a redacted copy of the workflow if the user cannot get the secrets of its namespace, or else
+// the workflow
+func (r *Redactor) RedactWorkflow(ctx context.Context, wf *wfv1.Workflow) (*wfv1.Workflow, error) {
+	return r.WorkflowRedactor(ctx)(wf)
+}
+
+// RedactWorkflows replaces the workflows whose namespace the user cannot get the secrets of with redacted copies
+func (r *Redactor) RedactWorkflows(ctx context.Context, wfs []wfv1.Workflow) error {
+	if r == nil {
+		return nil
+	}
+	redact := r.WorkflowRedactor(ctx)
+	for i := range wfs {
+		wf, err := redact(&wfs[i])
+		if err != nil {
+			return err
+		}
+		wfs[i] = *wf
+	}
+	return nil
+}
+
+// WorkflowRedactor returns a function like RedactWorkflow that remembers whether the user can get the secrets of each
+// namespace, for the workflows of a list or watch
+func (r *Redactor) WorkflowRedactor(ctx context.Context) func(wf *wfv1.Workflow) (*wfv1.Workflow, error) {
+	canGetSecrets := map[string]bool{}
+	return func(wf *wfv1.Workflow) (*wfv1.Workflow, error) {
+		if r == nil || wf == nil {
+			return wf, nil
+		}
+		allowed, ok := canGetSecrets[wf.Namespace]
+		if !ok {
+			var err error
+			allowed, err = r.canGetSecrets(ctx, wf.Namespace)
+			if err != nil {
+				return nil, err
+			}
+			canGetSecrets[wf.Namespace] = allowed
+		}
+		if allowed {
+			return wf, nil
+		}
+		// the workflow may share its fields with a cache, so it is copied before it is redacted
+		wf = wf.DeepCopy()
+		r.redactWorkflow(wf)
+		return wf, nil
+	}
+}
+
+func (r *Redactor) redactWorkflow(wf *wfv1.Workflow) {
+	r.redactSpec(&wf.Spec)
+	if wf.Status.StoredWorkflowSpec != nil {
+		r.redactSpec(wf.Status.StoredWorkflowSpec)
+	}
+	for name, tmpl := range wf.Status.StoredTemplates {
+		r.redactTemplate(&tmpl)
+		wf.Status.StoredTemplates[name] = tmpl
+	}
+	for id, node := range wf.Status.Nodes {
+		if node.Inputs != nil {
+			r.redactParameters(node.Inputs.Parameters)
+			redactArtifacts(node.Inputs.Artifacts)
+		}
+		r.redactOutputs(node.Outputs)
+		wf.Status.Nodes[id] = node
+	}
+	r.redactOutputs(wf.Status.Outputs)
+}
+
+func (r *Redactor) redactSpec(spec *wfv1.WorkflowSpec) {
+	r.redactArguments(&spec.Arguments)
+	for i := range spec.Templates {
+		r.redactTemplate(&spec.Templates[i])
+	}
+	if spec.TemplateDefaults != nil {
+		r.redactTemplate(spec.TemplateDefaults)
+	}
+}
+
+func (r *Redactor) redactTemplate(tmpl *wfv1.Template) {
+	r.redactParameters(tmpl.Inputs.Parameters)
+	redactArtifacts(tmpl.Inputs.Artifacts)
+	r.redactOutputs(&tmpl.Outputs)
+	redactContainer(tmpl.Container)
+	if tmpl.Script != nil {
+		redactContainer(&tmpl.Script.Container)
+	}
+	if tmpl.ContainerSet != nil {
+		for i := range tmpl.ContainerSet.Containers {
+			redactContainer(&tmpl.ContainerSet.Containers[i].Container)
+		}
+	}
+	for i := range tmpl.InitContainers {
+		redactContainer(&tmpl.InitContainers[i].Container)
+	}
+	for i := range tmpl.Sidecars {
+		redactContainer(&tmpl.Sidecars[i].Container)
+	}
+	for _, parallelSteps := range tmpl.Steps {
+		for i := range parallelSteps.Steps {
+			r.redactArguments(&parallelSteps.Steps[i].Arguments)
+		}
+	}
+	if tmpl.DAG != nil {
+		for i := range tmpl.DAG.Tasks {
+			r.redactArguments(&tmpl.DAG.Tasks[i].Arguments)
+		}
+	}
+}
+
+func (r *Redactor) redactArguments(args *wfv1.Arguments) {
+	r.redactParameters(args.Parameters)
+	redactArtifacts(args.Artifacts)
+}
+
+func (r *Redactor) redactOutputs(outputs *wfv1.Outputs) {
+	if outputs == nil {
+		return
+	}
+	r.redactParameters(outputs.Parameters)
+	redactArtifacts(outputs.Artifacts)
+}
+
+func (r *Redactor) redactParameters(parameters []wfv1.Parameter) {
+	for i, p := range parameters {
+		if !r.redactsParameter(p.Name) {
+			continue
+		}
+		if p.Value != nil {
+			parameters[i].Value = wfv1.AnyStringPtr(Redacted)
+		}
+		if p.Default != nil {
+			parameters[i].Default = wfv1.AnyStringPtr(Redacted)
+		}
+	}
+}
+
+func (r *Redactor) redactsParameter(name string) bool {
+	for _, x := range r.parameterNames {
+		if x.MatchString(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactContainer redacts the values of the container's environment variables, but not where they come from
+func redactContainer(c *apiv1.Container) {
+	if c == nil {
+		return
+	}
+	for i, env := range c.Env {
+		if env.Value != "" {
+			c.Env[i].Value = Redacted
+		}
+	}
+}
+
+// redactArtifacts redacts the credentials of artifacts that may be inline, rather than in a secret
+func redactArtifacts(artifacts wfv1.Artifacts) {
+	for _, a := range artifacts {
+		if a.HTTP != nil {
+			a.HTTP.URL = redactURL(a.HTTP.URL)
+			for i, h := range a.HTTP.Headers {
+				if h.Value != "" {
+					a.HTTP.Headers[i].Value = Redacted
+				}
+			}
+		}
+		if a.Git != nil {
+			a.Git.Repo = redactURL(a.Git.Repo)
+		}
+	}
+}
+
+// redactURL redacts the password of the URL
+func redactURL(s string) string {
+	u, err := url.Parse(s)
+	if err != nil || u.User == nil {
+		return s
+	}
+	if _, ok := u.User.Password(); !ok {
+		return s
+	}
+	return u.Redacted()
+}
@@ -0,0 +1,149 @@
+package state
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"time"
+)
+
+type entry struct {
+	value   []byte
+	count   int64
+	expires time.Time
+}
+
+// memory is a store for a single replica
+type memory struct {
+	mu      sync.Mutex
+	entries map[string]*entry
+	lists   map[string][][]byte
+	// pushed is closed, and replaced, when a value is pushed to a list
+	pushed chan struct{}
+}
+
+// NewMemory returns a store that keeps state in memory, which cannot be shared by replicas
+func NewMemory() Store {
+	return &memory{entries: make(map[string]*entry), lists: make(map[string][][]byte), pushed: make(chan struct{})}
+}
+
+// get returns the entry of the key, deleting it if it has expired
+func (m *memory) get(key string) *entry {
+	e, ok := m.entries[key]
+	if ok && time.Now().After(e.expires) {
+		delete(m.entries, key)
+		return nil
+	}
+	return e
+}
+
+func (m *memory) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.entries[key] = &entry{value: value, expires: time.Now().Add(ttl)}
+	return nil
+}
+
+func (m *memory) SetIfAbsent(_ context.Context, key string, value []byte, ttl time.Duration) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.get(key) != nil {
+		return false, nil
+	}
+	m.entries[key] = &entry{value: value, expires: time.Now().Add(ttl)}
+	return true, nil
+}
+
+func (m *memory) GetAndDelete(_ context.Context, key string) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e := m.get(key)
+	if e == nil {
+		return nil, ErrNotFound
+	}
+	delete(m.entries, key)
+	return e.value, nil
+}
+
+func (m *memory) Delete(_ context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.entries, key)
+	return nil
+}
+
+func (m *memory) Increment(_ context.Context, key string, by int64, ttl time.Duration) (int64, time.Duration, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e := m.get(key)
+	if e == nil {
+		e = &entry{expires: time.Now().Add(ttl)}
+		m.entries[key] = e
+	}
+	e.count += by
+	return e.count, time.Until(e.expires), nil
+}
+
+func (m *memory) Push(_ context.Context, list string, value []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.lists[list] = append(m.lists[list], value)
+	close(m.pushed)
+	m.pushed = make(chan struct{})
+	return nil
+}
+
+func (m *memory) Move(ctx context.Context, list, destination string, timeout time.Duration) ([]byte, error) {
+	deadline := time.After(timeout)
+	for {
+		m.mu.Lock()
+		if values := m.lists[list]; len(values) > 0 {
+			value := values[0]
+			m.lists[list] = values[1:]
+			m.lists[destination] = append(m.lists[destination], value)
+			m.mu.Unlock()
+			return value, nil
+		}
+		pushed := m.pushed
+		m.mu.Unlock()
+		if timeout == 0 {
+			return nil, ErrNotFound
+		}
+		select {
+		case <-pushed:
+		case <-deadline:
+			return nil, ErrNotFound
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+func (m *memory) Remove(_ context.Context, list string, value []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	values := m.lists[list]
+	for i, v := range values {
+		if bytes.Equal(v, value) {
+			m.lists[list] = append(values[:i:i], values[i+1:]...)
+			return nil
+		}
+	}
+	return nil
+}
+
+func (m *memory) Range(_ context.Context, list string) ([][]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([][]byte{}, m.lists[list]...), nil
+}
+
+func (m *memory) Len(_ context.Context, list string) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.lists[list]), nil
+}
+
+func (m *memory) Close() error {
+	return nil
+}
@@ -0,0 +1,70 @@
+package state
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemory(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemory()
+	t.Run("GetAndDelete", func(t *testing.T) {
+		assert.NoError(t, s.Set(ctx, "my-key", []byte("my-value"), time.Minute))
+		value, err := s.GetAndDelete(ctx, "my-key")
+		assert.NoError(t, err)
+		assert.Equal(t, "my-value", string(value))
+		_, err = s.GetAndDelete(ctx, "my-key")
+		assert.Equal(t, ErrNotFound, err, "can only be got once")
+	})
+	t.Run("Expired", func(t *testing.T) {
+		assert.NoError(t, s.Set(ctx, "my-key", []byte("my-value"), -time.Second))
+		_, err := s.GetAndDelete(ctx, "my-key")
+		assert.Equal(t, ErrNotFound, err)
+	})
+	t.Run("SetIfAbsent", func(t *testing.T) {
+		ok, err := s.SetIfAbsent(ctx, "my-lease", nil, time.Minute)
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		ok, err = s.SetIfAbsent(ctx, "my-lease", nil, time.Minute)
+		assert.NoError(t, err)
+		assert.False(t, ok)
+		assert.NoError(t, s.Delete(ctx, "my-lease"))
+		ok, err = s.SetIfAbsent(ctx, "my-lease", nil, time.Minute)
+		assert.NoError(t, err)
+		assert.True(t, ok)
+	})
+	t.Run("Increment", func(t *testing.T) {
+		count, ttl, err := s.Increment(ctx, "my-counter", 1, time.Minute)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+		assert.Greater(t, ttl, 59*time.Second)
+		count, _, err = s.Increment(ctx, "my-counter", 2, time.Hour)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), count)
+	})
+	t.Run("Lists", func(t *testing.T) {
+		_, err := s.Move(ctx, "pending", "processing", 0)
+		assert.Equal(t, ErrNotFound, err)
+		go func() {
+			time.Sleep(10 * time.Millisecond)
+			_ = s.Push(ctx, "pending", []byte("a"))
+		}()
+		value, err := s.Move(ctx, "pending", "processing", time.Minute)
+		assert.NoError(t, err)
+		assert.Equal(t, "a", string(value), "waits for a value")
+		assert.NoError(t, s.Push(ctx, "pending", []byte("b")))
+		n, err := s.Len(ctx, "pending")
+		assert.NoError(t, err)
+		assert.Equal(t, 1, n)
+		values, err := s.Range(ctx, "processing")
+		assert.NoError(t, err)
+		assert.Equal(t, [][]byte{[]byte("a")}, values)
+		assert.NoError(t, s.Remove(ctx, "processing", []byte("a")))
+		n, err = s.Len(ctx, "processing")
+		assert.NoError(t, err)
+		assert.Equal(t, 0, n)
+	})
+}
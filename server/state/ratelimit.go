@@ -0,0 +1,61 @@
+package state
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sethvargo/go-limiter"
+)
+
+type rateLimiter struct {
+	store    Store
+	tokens   uint64
+	interval time.Duration
+}
+
+// NewRateLimiter returns a rate limiter that allows each key the number of tokens per interval, counted in the store
+// so that the limit applies to the sum of the requests served by all replicas
+func NewRateLimiter(store Store, tokens uint64, interval time.Duration) limiter.Store {
+	return &rateLimiter{store: store, tokens: tokens, interval: interval}
+}
+
+func (l *rateLimiter) key(key string) string {
+	return "ratelimit:" + key
+}
+
+func (l *rateLimiter) Take(ctx context.Context, key string) (uint64, uint64, uint64, bool, error) {
+	count, ttl, err := l.store.Increment(ctx, l.key(key), 1, l.interval)
+	if err != nil {
+		return 0, 0, 0, false, err
+	}
+	reset := uint64(time.Now().Add(ttl).UnixNano())
+	if count > int64(l.tokens) {
+		return l.tokens, 0, reset, false, nil
+	}
+	return l.tokens, l.tokens - uint64(count), reset, true, nil
+}
+
+func (l *rateLimiter) Get(ctx context.Context, key string) (uint64, uint64, error) {
+	count, _, err := l.store.Increment(ctx, l.key(key), 0, l.interval)
+	if err != nil {
+		return 0, 0, err
+	}
+	if count > int64(l.tokens) {
+		return l.tokens, 0, nil
+	}
+	return l.tokens, l.tokens - uint64(count), nil
+}
+
+func (l *rateLimiter) Set(context.Context, string, uint64, time.Duration) error {
+	return fmt.Errorf("the tokens of a key cannot be changed")
+}
+
+func (l *rateLimiter) Burst(ctx context.Context, key string, tokens uint64) error {
+	_, _, err := l.store.Increment(ctx, l.key(key), -int64(tokens), l.interval)
+	return err
+}
+
+func (l *rateLimiter) Close(context.Context) error {
+	return nil
+}
@@ -0,0 +1,37 @@
+package state
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimiter(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemory()
+	// two replicas sharing a store
+	l1 := NewRateLimiter(store, 2, time.Minute)
+	l2 := NewRateLimiter(store, 2, time.Minute)
+
+	_, remaining, _, ok, err := l1.Take(ctx, "1.2.3.4")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(1), remaining)
+	_, remaining, _, ok, err = l2.Take(ctx, "1.2.3.4")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(0), remaining)
+	_, _, _, ok, err = l1.Take(ctx, "1.2.3.4")
+	assert.NoError(t, err)
+	assert.False(t, ok, "limit applies across replicas")
+	_, _, _, ok, err = l1.Take(ctx, "5.6.7.8")
+	assert.NoError(t, err)
+	assert.True(t, ok, "limit is per key")
+
+	assert.NoError(t, l1.Burst(ctx, "5.6.7.8", 2))
+	_, remaining, err = l2.Get(ctx, "5.6.7.8")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(3), remaining)
+}
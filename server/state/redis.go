@@ -0,0 +1,150 @@
+package state
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+
+	"github.com/argoproj/argo-workflows/v3/config"
+)
+
+// increment increments the counter, and sets its expiry if it is new, in one round trip
+var increment = redis.NewScript(`
+local count = redis.call("INCRBY", KEYS[1], ARGV[1])
+if redis.call("PTTL", KEYS[1]) < 0 then
+	redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return {count, redis.call("PTTL", KEYS[1])}
+`)
+
+type redisStore struct {
+	client    *redis.Client
+	keyPrefix string
+}
+
+// NewRedis returns a store that keeps state in Redis, so that it is shared by all replicas using the same server
+func NewRedis(ctx context.Context, c *config.RedisConfig, secretsIf corev1.SecretInterface) (Store, error) {
+	if c.Address == "" {
+		return nil, fmt.Errorf("redis address empty")
+	}
+	username, err := getSecret(ctx, secretsIf, c.UsernameSecret)
+	if err != nil {
+		return nil, err
+	}
+	password, err := getSecret(ctx, secretsIf, c.PasswordSecret)
+	if err != nil {
+		return nil, err
+	}
+	opts := &redis.Options{
+		Addr:     c.Address,
+		Username: username,
+		Password: password,
+		DB:       c.DB,
+	}
+	if c.TLS {
+		opts.TLSConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+	client := redis.NewClient(opts)
+	if err := client.Ping(ctx).Err(); err != nil {
+		_ = client.Close()
+		return nil, fmt.Errorf("failed to connect to redis at %s: %w", c.Address, err)
+	}
+	return &redisStore{client: client, keyPrefix: c.GetKeyPrefix()}, nil
+}
+
+func getSecret(ctx context.Context, secretsIf corev1.SecretInterface, selector *apiv1.SecretKeySelector) (string, error) {
+	if selector == nil {
+		return "", nil
+	}
+	secret, err := secretsIf.Get(ctx, selector.Name, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+	value, ok := secret.Data[selector.Key]
+	if !ok {
+		return "", fmt.Errorf("secret %q does not have the key %q", selector.Name, selector.Key)
+	}
+	return string(value), nil
+}
+
+func (s *redisStore) key(key string) string {
+	return s.keyPrefix + key
+}
+
+func (s *redisStore) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return s.client.Set(ctx, s.key(key), value, ttl).Err()
+}
+
+func (s *redisStore) SetIfAbsent(ctx context.Context, key string, value []byte, ttl time.Duration) (bool, error) {
+	return s.client.SetNX(ctx, s.key(key), value, ttl).Result()
+}
+
+func (s *redisStore) GetAndDelete(ctx context.Context, key string) ([]byte, error) {
+	value, err := s.client.GetDel(ctx, s.key(key)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrNotFound
+	}
+	return value, err
+}
+
+func (s *redisStore) Delete(ctx context.Context, key string) error {
+	return s.client.Del(ctx, s.key(key)).Err()
+}
+
+func (s *redisStore) Increment(ctx context.Context, key string, by int64, ttl time.Duration) (int64, time.Duration, error) {
+	result, err := increment.Run(ctx, s.client, []string{s.key(key)}, by, ttl.Milliseconds()).Int64Slice()
+	if err != nil {
+		return 0, 0, err
+	}
+	return result[0], time.Duration(result[1]) * time.Millisecond, nil
+}
+
+func (s *redisStore) Push(ctx context.Context, list string, value []byte) error {
+	return s.client.RPush(ctx, s.key(list), value).Err()
+}
+
+func (s *redisStore) Move(ctx context.Context, list, destination string, timeout time.Duration) ([]byte, error) {
+	var cmd *redis.StringCmd
+	if timeout == 0 {
+		cmd = s.client.LMove(ctx, s.key(list), s.key(destination), "LEFT", "RIGHT")
+	} else {
+		cmd = s.client.BLMove(ctx, s.key(list), s.key(destination), "LEFT", "RIGHT", timeout)
+	}
+	value, err := cmd.Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrNotFound
+	}
+	return value, err
+}
+
+func (s *redisStore) Remove(ctx context.Context, list string, value []byte) error {
+	return s.client.LRem(ctx, s.key(list), 1, value).Err()
+}
+
+func (s *redisStore) Range(ctx context.Context, list string) ([][]byte, error) {
+	values, err := s.client.LRange(ctx, s.key(list), 0, -1).Result()
+	if err != nil {
+		return nil, err
+	}
+	result := make([][]byte, len(values))
+	for i, v := range values {
+		result[i] = []byte(v)
+	}
+	return result, nil
+}
+
+func (s *redisStore) Len(ctx context.Context, list string) (int, error) {
+	n, err := s.client.LLen(ctx, s.key(list)).Result()
+	return int(n), err
+}
+
+func (s *redisStore) Close() error {
+	return s.client.Close()
+}
@@ -0,0 +1,38 @@
+package state
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrNotFound is returned when a key does not exist, or when a list is still empty after waiting for a value
+var ErrNotFound = errors.New("not found")
+
+// Store keeps the state of the Argo Server that must be shared by its replicas, so that requests can be served by any
+// of them, e.g. the OAuth2 state of an SSO login started on one replica and completed on another.
+type Store interface {
+	// Set sets the value of the key, which expires after the TTL
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	// SetIfAbsent sets the value of the key if it does not exist, returning false if it does
+	SetIfAbsent(ctx context.Context, key string, value []byte, ttl time.Duration) (bool, error)
+	// GetAndDelete gets the value of the key and deletes it, so only one replica can get it
+	GetAndDelete(ctx context.Context, key string) ([]byte, error)
+	// Delete deletes the key, if it exists
+	Delete(ctx context.Context, key string) error
+	// Increment increments the counter of the key by the amount, returning its count and the time until it expires.
+	// The counter starts at zero and expires after the TTL of its first increment.
+	Increment(ctx context.Context, key string, by int64, ttl time.Duration) (int64, time.Duration, error)
+	// Push appends the value to the list
+	Push(ctx context.Context, list string, value []byte) error
+	// Move atomically moves the first value of the list to the end of the destination list. It waits for up to the
+	// timeout for the list to have a value, or not at all if the timeout is zero.
+	Move(ctx context.Context, list, destination string, timeout time.Duration) ([]byte, error)
+	// Remove removes the value from the list
+	Remove(ctx context.Context, list string, value []byte) error
+	// Range returns the values of the list
+	Range(ctx context.Context, list string) ([][]byte, error)
+	// Len returns the length of the list
+	Len(ctx context.Context, list string) (int, error)
+	Close() error
+}
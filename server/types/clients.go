@@ -5,6 +5,7 @@ import (
 	sensor "github.com/argoproj/argo-events/pkg/client/sensor/clientset/versioned"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
 
 	workflow "github.com/argoproj/argo-workflows/v3/pkg/client/clientset/versioned"
 )
@@ -15,4 +16,6 @@ type Clients struct {
 	Sensor      sensor.Interface
 	EventSource eventsource.Interface
 	Kubernetes  kubernetes.Interface
+	// RestConfig the clients were created with, which identifies the credentials they use
+	RestConfig *rest.Config
 }
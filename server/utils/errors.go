@@ -8,6 +8,7 @@ import (
 	"google.golang.org/grpc/status"
 
 	argoerrors "github.com/argoproj/argo-workflows/v3/errors"
+	grpcutil "github.com/argoproj/argo-workflows/v3/util/grpc"
 
 	apierr "k8s.io/apimachinery/pkg/api/errors"
 )
@@ -77,7 +78,8 @@ func ToStatusError(err error, code codes.Code) error {
 	if errors.As(err, &argoerr) {
 		newErr, converted := httpToStatusError(argoerr.HTTPCode(), err.Error())
 		if converted {
-			return newErr
+			// the code of the error lets clients branch on its class, rather than on its message
+			return grpcutil.WithErrorCode(status.Convert(newErr), err).Err()
 		}
 	}
 
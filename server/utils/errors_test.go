@@ -10,6 +10,7 @@ import (
 	"google.golang.org/grpc/status"
 
 	argoerrors "github.com/argoproj/argo-workflows/v3/errors"
+	grpcutil "github.com/argoproj/argo-workflows/v3/util/grpc"
 )
 
 type testArgoError struct {
@@ -88,6 +89,14 @@ func TestArgoError(t *testing.T) {
 		assert.Equal(t, codes.Internal, stat.Code())
 	})
 
+	t.Run("ErrorCode", func(t *testing.T) {
+		argoErr := argoerrors.Errorf(argoerrors.CodeTemplateNotFound, "template %s not found", "my-tmpl")
+		newErr := ToStatusError(argoErr, codes.Internal)
+		stat := status.Convert(newErr)
+		assert.Equal(t, codes.NotFound, stat.Code())
+		assert.Equal(t, "template my-tmpl not found", stat.Message())
+		assert.Equal(t, argoerrors.CodeTemplateNotFound, grpcutil.ErrorCode(newErr))
+	})
 }
 
 func TestHTTPToStatusError(t *testing.T) {
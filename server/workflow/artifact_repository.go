@@ -0,0 +1,91 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/server/auth"
+	artifactcommon "github.com/argoproj/argo-workflows/v3/workflow/artifacts/common"
+	"github.com/argoproj/argo-workflows/v3/workflow/templateresolution"
+)
+
+// checkArtifactRepository checks that the artifact repository that the workflow resolves to can be reached with the
+// secrets of the namespace, so that a workflow whose repository is misconfigured fails when it is submitted,
+// rather than when its first step saves an artifact. It does nothing unless the server is configured to check.
+func (s *workflowServer) checkArtifactRepository(ctx context.Context, wftmplGetter templateresolution.WorkflowTemplateNamespacedGetter, cwftmplGetter templateresolution.ClusterWorkflowTemplateGetter, namespace string, wf *wfv1.Workflow) error {
+	if s.artifactRepositories == nil {
+		return nil
+	}
+	ref, err := getArtifactRepositoryRef(wftmplGetter, cwftmplGetter, wf)
+	if err != nil {
+		return err
+	}
+	refStatus, err := s.artifactRepositories.Resolve(ctx, ref, namespace)
+	if err != nil {
+		return fmt.Errorf("failed to resolve the artifact repository: %w", err)
+	}
+	repo, err := s.artifactRepositories.Get(ctx, refStatus)
+	if err != nil {
+		return fmt.Errorf("failed to get artifact repository %s: %w", refStatus, err)
+	}
+	location := repo.ToArtifactLocation()
+	if location == nil || !location.HasLocation() {
+		return nil
+	}
+	art := &wfv1.Artifact{ArtifactLocation: *location}
+	driver, err := s.artDriverFactory(ctx, art, resources{auth.GetKubeClient(ctx), namespace})
+	if err == nil {
+		if checker, ok := driver.(artifactcommon.RepositoryChecker); ok {
+			err = checker.CheckRepository(art)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("artifact repository %s is not reachable: %w", refStatus, err)
+	}
+	return nil
+}
+
+// getArtifactRepositoryRef returns the workflow's artifact repository ref, or that of its workflow template
+func getArtifactRepositoryRef(wftmplGetter templateresolution.WorkflowTemplateNamespacedGetter, cwftmplGetter templateresolution.ClusterWorkflowTemplateGetter, wf *wfv1.Workflow) (*wfv1.ArtifactRepositoryRef, error) {
+	if wf.Spec.ArtifactRepositoryRef != nil || wf.Spec.WorkflowTemplateRef == nil {
+		return wf.Spec.ArtifactRepositoryRef, nil
+	}
+	if wf.Spec.WorkflowTemplateRef.ClusterScope {
+		cwftmpl, err := cwftmplGetter.Get(wf.Spec.WorkflowTemplateRef.Name)
+		if err != nil {
+			return nil, err
+		}
+		return cwftmpl.Spec.ArtifactRepositoryRef, nil
+	}
+	wftmpl, err := wftmplGetter.Get(wf.Spec.WorkflowTemplateRef.Name)
+	if err != nil {
+		return nil, err
+	}
+	return wftmpl.Spec.ArtifactRepositoryRef, nil
+}
+
+// resources reads the secrets and config maps of the artifact repository from the workflow's namespace
+type resources struct {
+	kubeClient kubernetes.Interface
+	namespace  string
+}
+
+func (r resources) GetSecret(ctx context.Context, name, key string) (string, error) {
+	secret, err := r.kubeClient.CoreV1().Secrets(r.namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+	return string(secret.Data[key]), nil
+}
+
+func (r resources) GetConfigMapKey(ctx context.Context, name, key string) (string, error) {
+	configMap, err := r.kubeClient.CoreV1().ConfigMaps(r.namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+	return configMap.Data[key], nil
+}
@@ -0,0 +1,85 @@
+package workflow
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/server/auth"
+	armocks "github.com/argoproj/argo-workflows/v3/workflow/artifactrepositories/mocks"
+	artifactscommon "github.com/argoproj/argo-workflows/v3/workflow/artifacts/common"
+	"github.com/argoproj/argo-workflows/v3/workflow/artifacts/resource"
+)
+
+type checkedArtifactDriver struct {
+	artifactscommon.ArtifactDriver
+	err error
+}
+
+func (d *checkedArtifactDriver) CheckRepository(*wfv1.Artifact) error {
+	return d.err
+}
+
+type fakeWorkflowTemplateGetter struct {
+	wftmpl *wfv1.WorkflowTemplate
+}
+
+func (g fakeWorkflowTemplateGetter) Get(string) (*wfv1.WorkflowTemplate, error) {
+	return g.wftmpl, nil
+}
+
+func TestCheckArtifactRepository(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.KubeKey, fake.NewSimpleClientset())
+	repo := &wfv1.ArtifactRepository{S3: &wfv1.S3ArtifactRepository{S3Bucket: wfv1.S3Bucket{Endpoint: "my-endpoint", Bucket: "my-bucket"}}}
+	wf := &wfv1.Workflow{}
+	defaultRepositories := func(repo *wfv1.ArtifactRepository) *armocks.Interface {
+		repositories := &armocks.Interface{}
+		repositories.On("Resolve", mock.Anything, (*wfv1.ArtifactRepositoryRef)(nil), "my-ns").Return(&wfv1.ArtifactRepositoryRefStatus{Default: true}, nil)
+		repositories.On("Get", mock.Anything, mock.Anything).Return(repo, nil)
+		return repositories
+	}
+	newServer := func(repositories *armocks.Interface, err error) *workflowServer {
+		return &workflowServer{
+			artifactRepositories: repositories,
+			artDriverFactory: func(_ context.Context, art *wfv1.Artifact, _ resource.Interface) (artifactscommon.ArtifactDriver, error) {
+				assert.Equal(t, "my-bucket", art.S3.Bucket)
+				return &checkedArtifactDriver{err: err}, nil
+			},
+		}
+	}
+
+	t.Run("Disabled", func(t *testing.T) {
+		s := &workflowServer{}
+		assert.NoError(t, s.checkArtifactRepository(ctx, nil, nil, "my-ns", wf))
+	})
+	t.Run("Reachable", func(t *testing.T) {
+		s := newServer(defaultRepositories(repo), nil)
+		assert.NoError(t, s.checkArtifactRepository(ctx, nil, nil, "my-ns", wf))
+	})
+	t.Run("NotReachable", func(t *testing.T) {
+		s := newServer(defaultRepositories(repo), errors.New("bucket my-bucket does not exist"))
+		err := s.checkArtifactRepository(ctx, nil, nil, "my-ns", wf)
+		assert.EqualError(t, err, "artifact repository default-artifact-repository is not reachable: bucket my-bucket does not exist")
+	})
+	t.Run("NoRepository", func(t *testing.T) {
+		s := newServer(defaultRepositories(nil), errors.New("not checked"))
+		assert.NoError(t, s.checkArtifactRepository(ctx, nil, nil, "my-ns", wf))
+	})
+	t.Run("WorkflowTemplateRef", func(t *testing.T) {
+		ref := &wfv1.ArtifactRepositoryRef{ConfigMap: "my-cm", Key: "my-key"}
+		repositories := &armocks.Interface{}
+		repositories.On("Resolve", mock.Anything, ref, "my-ns").Return(&wfv1.ArtifactRepositoryRefStatus{Namespace: "my-ns", ArtifactRepositoryRef: *ref}, nil)
+		repositories.On("Get", mock.Anything, mock.Anything).Return(repo, nil)
+		s := newServer(repositories, errors.New("Access Denied"))
+		wftmpl := &wfv1.WorkflowTemplate{ObjectMeta: metav1.ObjectMeta{Name: "my-wftmpl"}, Spec: wfv1.WorkflowSpec{ArtifactRepositoryRef: ref}}
+		wf := &wfv1.Workflow{Spec: wfv1.WorkflowSpec{WorkflowTemplateRef: &wfv1.WorkflowTemplateRef{Name: "my-wftmpl"}}}
+		err := s.checkArtifactRepository(ctx, fakeWorkflowTemplateGetter{wftmpl}, nil, "my-ns", wf)
+		assert.EqualError(t, err, "artifact repository my-ns/my-cm#my-key is not reachable: Access Denied")
+	})
+}
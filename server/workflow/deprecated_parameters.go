@@ -0,0 +1,37 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/argoproj/argo-workflows/v3/config"
+	workflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/templateresolution"
+	"github.com/argoproj/argo-workflows/v3/workflow/validate"
+)
+
+// checkDeprecatedParameters warns the client when the workflow is submitted with parameters that its workflow template
+// deprecates, or returns an error if the server rejects them
+func (s *workflowServer) checkDeprecatedParameters(ctx context.Context, wftmplGetter templateresolution.WorkflowTemplateNamespacedGetter, cwftmplGetter templateresolution.ClusterWorkflowTemplateGetter, wf *wfv1.Workflow) error {
+	warnings, err := validate.GetDeprecatedParameterWarnings(wftmplGetter, cwftmplGetter, wf)
+	if err != nil {
+		return err
+	}
+	if len(warnings) == 0 {
+		return nil
+	}
+	if s.deprecatedParameters == config.DeprecatedParametersReject {
+		return fmt.Errorf("workflow uses deprecated parameters: %s", strings.Join(warnings, "; "))
+	}
+	log.WithFields(log.Fields{"namespace": wf.Namespace, "workflowTemplate": wf.Spec.WorkflowTemplateRef.Name, "warnings": warnings}).
+		Info("Workflow submitted with deprecated parameters")
+	// there is no header when the server is used in-process, e.g. by the CLI without an Argo Server
+	_ = grpc.SetHeader(ctx, metadata.MD{workflowpkg.WarningHeader: warnings})
+	return nil
+}
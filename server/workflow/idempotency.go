@@ -0,0 +1,115 @@
+package workflow
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	argoerrs "github.com/argoproj/argo-workflows/v3/errors"
+	workflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/pkg/client/clientset/versioned"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+// getIdempotencyKey returns the hash of the Idempotency-Key header of the request, or "" if the request does not have
+// one or idempotency keys are disabled. The key is hashed so that it is a valid label value.
+func (s *workflowServer) getIdempotencyKey(ctx context.Context) string {
+	if s.idempotencyKeyWindow <= 0 {
+		return ""
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	keys := md.Get(workflowpkg.IdempotencyKeyHeader)
+	if len(keys) == 0 || keys[0] == "" {
+		return ""
+	}
+	return fmt.Sprintf("%x", sha256.Sum224([]byte(keys[0])))
+}
+
+// idempotentNameSuffixLength is how much of the hash of the idempotency key names the workflow
+const idempotentNameSuffixLength = 10
+
+// idempotentNameSuffix returns the suffix of the name of the workflow created or resubmitted with the key. The name is
+// derived from the key, so that the API server rejects creating the workflow again, even while the first request is in
+// flight.
+func idempotentNameSuffix(key string) string {
+	if key == "" {
+		return ""
+	}
+	return key[:idempotentNameSuffixLength]
+}
+
+// hashRequest returns the hash of the request, so a repeat of the request can be told apart from another request with
+// the same key
+func hashRequest(req interface{}) (string, error) {
+	data, err := json.Marshal(req)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(data)), nil
+}
+
+// getIdempotentWorkflow returns the named workflow, which already existed when it was created with the key, if it was
+// created or resubmitted by a repeat of the request within the window. It fails with ERR_IDEMPOTENCY_KEY_REUSED if
+// the key was used for another request.
+func (s *workflowServer) getIdempotentWorkflow(ctx context.Context, wfClient versioned.Interface, namespace, name, key, requestHash string) (*wfv1.Workflow, error) {
+	existing, err := wfClient.ArgoprojV1alpha1().Workflows(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if existing.Labels[common.LabelKeyIdempotencyKey] != key || time.Since(existing.CreationTimestamp.Time) >= s.idempotencyKeyWindow {
+		return nil, status.Errorf(codes.AlreadyExists, "workflow %q already exists, and was not created with the idempotency key within the window", name)
+	}
+	if existing.Annotations[common.AnnotationKeyIdempotencyRequestHash] != requestHash {
+		return nil, argoerrs.New(argoerrs.CodeIdempotencyKeyReused, "the idempotency key was used for a different request")
+	}
+	log.WithFields(log.Fields{"namespace": existing.Namespace, "name": existing.Name}).Info("Returning workflow of repeated request with the same idempotency key")
+	return existing, nil
+}
+
+// isIdempotentRetry returns true if the workflow was retried with the idempotency key within the window
+func (s *workflowServer) isIdempotentRetry(wf *wfv1.Workflow, key string) bool {
+	if key == "" || wf.Annotations[common.AnnotationKeyRetryIdempotencyKey] != key {
+		return false
+	}
+	retriedAt, err := time.Parse(time.RFC3339, wf.Annotations[common.AnnotationKeyRetriedAt])
+	return err == nil && time.Since(retriedAt) < s.idempotencyKeyWindow
+}
+
+// setIdempotencyKey labels the workflow with the key and annotates it with the hash of the request. Unless the workflow
+// has a name, it is named after the key.
+func setIdempotencyKey(wf *wfv1.Workflow, key, requestHash string) {
+	if key == "" {
+		return
+	}
+	if wf.Labels == nil {
+		wf.Labels = make(map[string]string)
+	}
+	wf.Labels[common.LabelKeyIdempotencyKey] = key
+	if wf.Annotations == nil {
+		wf.Annotations = make(map[string]string)
+	}
+	wf.Annotations[common.AnnotationKeyIdempotencyRequestHash] = requestHash
+	if wf.Name == "" {
+		wf.Name = wf.GenerateName + idempotentNameSuffix(key)
+	}
+}
+
+func setRetryIdempotencyKey(wf *wfv1.Workflow, key string) {
+	if key == "" {
+		return
+	}
+	if wf.Annotations == nil {
+		wf.Annotations = make(map[string]string)
+	}
+	wf.Annotations[common.AnnotationKeyRetryIdempotencyKey] = key
+	wf.Annotations[common.AnnotationKeyRetriedAt] = time.Now().UTC().Format(time.RFC3339)
+}
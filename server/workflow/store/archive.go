@@ -0,0 +1,40 @@
+package store
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	workflowarchivepkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflowarchive"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+type archiveStore struct {
+	wfArchiveServer workflowarchivepkg.ArchivedWorkflowServiceServer
+}
+
+// NewArchiveStore returns a store that reads workflows from the workflow archive. Only archived workflows are
+// visible, so it suits namespaces where completed workflows are the main interest.
+func NewArchiveStore(wfArchiveServer workflowarchivepkg.ArchivedWorkflowServiceServer) WorkflowStore {
+	return &archiveStore{wfArchiveServer}
+}
+
+// ListWorkflows lists the archived workflows with the selectors, limit and offset of the options applied by the archive
+// query, so that only a page is loaded. The continue token is the offset of the next page.
+func (s *archiveStore) ListWorkflows(ctx context.Context, namespace string, options metav1.ListOptions) (*wfv1.WorkflowList, error) {
+	return s.wfArchiveServer.ListArchivedWorkflows(ctx, &workflowarchivepkg.ListArchivedWorkflowsRequest{
+		ListOptions: &options,
+		Namespace:   namespace,
+	})
+}
+
+func (s *archiveStore) GetWorkflow(ctx context.Context, namespace, name string, _ metav1.GetOptions) (*wfv1.Workflow, error) {
+	return s.wfArchiveServer.GetArchivedWorkflow(ctx, &workflowarchivepkg.GetArchivedWorkflowRequest{
+		Namespace: namespace,
+		Name:      name,
+	})
+}
+
+func (s *archiveStore) IsArchive() bool {
+	return true
+}
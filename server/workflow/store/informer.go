@@ -0,0 +1,116 @@
+package store
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/argoproj/argo-workflows/v3/pkg/apis/workflow"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/pkg/client/clientset/versioned"
+	wfextvv1alpha1 "github.com/argoproj/argo-workflows/v3/pkg/client/informers/externalversions/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/server/auth"
+	"github.com/argoproj/argo-workflows/v3/util/instanceid"
+)
+
+type informerStore struct {
+	informer cache.SharedIndexInformer
+}
+
+// NewInformerStore returns a store that serves workflows from an informer cache. The informer uses the server's own
+// service account, so it must be allowed to list and watch workflows. Users' access is checked on each request.
+func NewInformerStore(ctx context.Context, wfClient versioned.Interface, namespace string, instanceIDService instanceid.Service) (WorkflowStore, error) {
+	informer := wfextvv1alpha1.NewFilteredWorkflowInformer(wfClient, namespace, 0, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, func(options *metav1.ListOptions) {
+		instanceIDService.With(options)
+	})
+	go informer.Run(ctx.Done())
+	if !cache.WaitForCacheSync(ctx.Done(), informer.HasSynced) {
+		return nil, fmt.Errorf("timed out waiting for workflow informer to sync")
+	}
+	return newInformerStore(informer), nil
+}
+
+func newInformerStore(informer cache.SharedIndexInformer) *informerStore {
+	return &informerStore{informer}
+}
+
+func (s *informerStore) ListWorkflows(ctx context.Context, namespace string, options metav1.ListOptions) (*wfv1.WorkflowList, error) {
+	if err := canI(ctx, "list", namespace, ""); err != nil {
+		return nil, err
+	}
+	labelSelector, err := labels.Parse(options.LabelSelector)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	fieldSelector, err := fields.ParseSelector(options.FieldSelector)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	for _, r := range fieldSelector.Requirements() {
+		if r.Field != "metadata.name" && r.Field != "metadata.namespace" {
+			return nil, status.Errorf(codes.InvalidArgument, "field selector %q is not supported, only metadata.name and metadata.namespace are", r.Field)
+		}
+	}
+	var objs []interface{}
+	if namespace == "" {
+		objs = s.informer.GetStore().List()
+	} else {
+		objs, err = s.informer.GetIndexer().ByIndex(cache.NamespaceIndex, namespace)
+		if err != nil {
+			return nil, err
+		}
+	}
+	list := &wfv1.WorkflowList{Items: make(wfv1.Workflows, 0, len(objs))}
+	for _, obj := range objs {
+		wf, ok := obj.(*wfv1.Workflow)
+		if !ok {
+			continue
+		}
+		if !labelSelector.Matches(labels.Set(wf.Labels)) || !fieldSelector.Matches(fields.Set{"metadata.name": wf.Name, "metadata.namespace": wf.Namespace}) {
+			continue
+		}
+		list.Items = append(list.Items, *wf.DeepCopy())
+	}
+	list.ResourceVersion = s.informer.LastSyncResourceVersion()
+	return list, nil
+}
+
+func (s *informerStore) GetWorkflow(ctx context.Context, namespace, name string, _ metav1.GetOptions) (*wfv1.Workflow, error) {
+	if err := canI(ctx, "get", namespace, name); err != nil {
+		return nil, err
+	}
+	obj, exists, err := s.informer.GetStore().GetByKey(namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, apierr.NewNotFound(wfv1.Resource(workflow.WorkflowPlural), name)
+	}
+	wf, ok := obj.(*wfv1.Workflow)
+	if !ok {
+		return nil, fmt.Errorf("unexpected object %T in workflow informer", obj)
+	}
+	return wf.DeepCopy(), nil
+}
+
+func (s *informerStore) IsArchive() bool {
+	return false
+}
+
+func canI(ctx context.Context, verb, namespace, name string) error {
+	allowed, err := auth.CanI(ctx, verb, workflow.WorkflowPlural, namespace, name)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return status.Error(codes.PermissionDenied, "permission denied")
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/pkg/client/clientset/versioned/fake"
+	wfextvv1alpha1 "github.com/argoproj/argo-workflows/v3/pkg/client/informers/externalversions/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/server/auth"
+)
+
+func Test_informerStore(t *testing.T) {
+	kubeClient := &kubefake.Clientset{}
+	kubeClient.AddReactor("create", "selfsubjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		attrs := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectAccessReview).Spec.ResourceAttributes
+		return true, &authorizationv1.SelfSubjectAccessReview{Status: authorizationv1.SubjectAccessReviewStatus{Allowed: attrs.Namespace == "my-ns"}}, nil
+	})
+	ctx := context.WithValue(context.TODO(), auth.KubeKey, kubeClient)
+
+	informer := wfextvv1alpha1.NewWorkflowInformer(fake.NewSimpleClientset(), "", 0, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	for _, wf := range []*wfv1.Workflow{
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "my-ns", Name: "my-wf", Labels: map[string]string{"foo": "bar"}}},
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "my-ns", Name: "other-wf"}},
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "other-ns", Name: "my-wf"}},
+	} {
+		assert.NoError(t, informer.GetIndexer().Add(wf))
+	}
+	s := newInformerStore(informer)
+
+	t.Run("List", func(t *testing.T) {
+		list, err := s.ListWorkflows(ctx, "my-ns", metav1.ListOptions{})
+		if assert.NoError(t, err) {
+			assert.Len(t, list.Items, 2)
+		}
+	})
+	t.Run("ListSelectors", func(t *testing.T) {
+		list, err := s.ListWorkflows(ctx, "my-ns", metav1.ListOptions{LabelSelector: "foo=bar"})
+		if assert.NoError(t, err) && assert.Len(t, list.Items, 1) {
+			assert.Equal(t, "my-wf", list.Items[0].Name)
+		}
+		list, err = s.ListWorkflows(ctx, "my-ns", metav1.ListOptions{FieldSelector: "metadata.name=other-wf"})
+		if assert.NoError(t, err) && assert.Len(t, list.Items, 1) {
+			assert.Equal(t, "other-wf", list.Items[0].Name)
+		}
+	})
+	t.Run("ListUnsupportedFieldSelector", func(t *testing.T) {
+		_, err := s.ListWorkflows(ctx, "my-ns", metav1.ListOptions{FieldSelector: "status.phase=Running"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+	t.Run("ListForbidden", func(t *testing.T) {
+		_, err := s.ListWorkflows(ctx, "other-ns", metav1.ListOptions{})
+		assert.EqualError(t, err, "rpc error: code = PermissionDenied desc = permission denied")
+	})
+	t.Run("Get", func(t *testing.T) {
+		wf, err := s.GetWorkflow(ctx, "my-ns", "my-wf", metav1.GetOptions{})
+		if assert.NoError(t, err) {
+			assert.Equal(t, "bar", wf.Labels["foo"])
+			// callers must not be able to change the cached workflow
+			wf.Labels["foo"] = "baz"
+			wf, _ = s.GetWorkflow(ctx, "my-ns", "my-wf", metav1.GetOptions{})
+			assert.Equal(t, "bar", wf.Labels["foo"])
+		}
+	})
+	t.Run("GetNotFound", func(t *testing.T) {
+		_, err := s.GetWorkflow(ctx, "my-ns", "missing-wf", metav1.GetOptions{})
+		assert.True(t, apierr.IsNotFound(err))
+	})
+}
@@ -0,0 +1,29 @@
+package store
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/server/auth"
+)
+
+type kubeStore struct{}
+
+// NewKubeStore returns a store that reads workflows from the Kubernetes API using the user's client.
+func NewKubeStore() WorkflowStore {
+	return &kubeStore{}
+}
+
+func (s *kubeStore) ListWorkflows(ctx context.Context, namespace string, options metav1.ListOptions) (*wfv1.WorkflowList, error) {
+	return auth.GetWfClient(ctx).ArgoprojV1alpha1().Workflows(namespace).List(ctx, options)
+}
+
+func (s *kubeStore) GetWorkflow(ctx context.Context, namespace, name string, options metav1.GetOptions) (*wfv1.Workflow, error) {
+	return auth.GetWfClient(ctx).ArgoprojV1alpha1().Workflows(namespace).Get(ctx, name, options)
+}
+
+func (s *kubeStore) IsArchive() bool {
+	return false
+}
@@ -0,0 +1,68 @@
+package store
+
+import (
+	"context"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/argoproj/argo-workflows/v3/config"
+	workflowarchivepkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflowarchive"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/pkg/client/clientset/versioned"
+	"github.com/argoproj/argo-workflows/v3/util/instanceid"
+)
+
+// WorkflowStore is where the Argo Server reads workflows from when serving list and get requests.
+// Implementations must check that the user is allowed to read the workflows.
+type WorkflowStore interface {
+	ListWorkflows(ctx context.Context, namespace string, options metav1.ListOptions) (*wfv1.WorkflowList, error)
+	GetWorkflow(ctx context.Context, namespace, name string, options metav1.GetOptions) (*wfv1.Workflow, error)
+	// IsArchive returns true if the store reads from the workflow archive, so callers need not query it again. The
+	// archive paginates itself, so callers must pass the limit and continue token through, rather than paginate the list.
+	IsArchive() bool
+}
+
+// Registry selects the workflow store for a namespace.
+type Registry interface {
+	For(namespace string) WorkflowStore
+}
+
+type registry struct {
+	storeConfig *config.WorkflowStoreConfig
+	stores      map[config.WorkflowStoreType]WorkflowStore
+}
+
+// NewKubeRegistry returns a registry that always reads from the Kubernetes API.
+func NewKubeRegistry() Registry {
+	return &registry{stores: map[config.WorkflowStoreType]WorkflowStore{config.WorkflowStoreKubernetes: NewKubeStore()}}
+}
+
+// NewRegistry creates the stores used by the configuration. The informer is only started if a namespace uses it,
+// and is scoped to the managed namespace, if any.
+func NewRegistry(ctx context.Context, storeConfig *config.WorkflowStoreConfig, wfClient versioned.Interface, managedNamespace string, instanceIDService instanceid.Service, wfArchiveServer workflowarchivepkg.ArchivedWorkflowServiceServer) (Registry, error) {
+	if err := storeConfig.Validate(); err != nil {
+		return nil, err
+	}
+	stores := map[config.WorkflowStoreType]WorkflowStore{
+		config.WorkflowStoreKubernetes: NewKubeStore(),
+		config.WorkflowStoreArchive:    NewArchiveStore(wfArchiveServer),
+	}
+	if storeConfig.Uses(config.WorkflowStoreInformer) {
+		informerStore, err := NewInformerStore(ctx, wfClient, managedNamespace, instanceIDService)
+		if err != nil {
+			return nil, fmt.Errorf("failed to start workflow informer store: %w", err)
+		}
+		stores[config.WorkflowStoreInformer] = informerStore
+	}
+	log.WithField("default", storeConfig.GetDefault()).Info("Workflow store configured")
+	return &registry{storeConfig, stores}, nil
+}
+
+func (r *registry) For(namespace string) WorkflowStore {
+	if s, ok := r.stores[r.storeConfig.GetStore(namespace)]; ok {
+		return s
+	}
+	return r.stores[config.WorkflowStoreKubernetes]
+}
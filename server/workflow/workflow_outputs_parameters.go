@@ -0,0 +1,94 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/pkg/client/clientset/versioned"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+	"github.com/argoproj/argo-workflows/v3/workflow/templateresolution"
+	"github.com/argoproj/argo-workflows/v3/workflow/util"
+)
+
+// resolveWorkflowOutputsParameters sets the values of the arguments of the workflow, and of its workflow template, that
+// are taken from the outputs of the latest of the workflows a label selector selects
+func (s *workflowServer) resolveWorkflowOutputsParameters(ctx context.Context, wfClient versioned.Interface, wftmplGetter templateresolution.WorkflowTemplateNamespacedGetter, cwftmplGetter templateresolution.ClusterWorkflowTemplateGetter, namespace string, wf *wfv1.Workflow) error {
+	for i, p := range wf.Spec.Arguments.Parameters {
+		if p.ValueFrom == nil || p.ValueFrom.WorkflowOutputs == nil {
+			continue
+		}
+		value, err := s.getWorkflowOutputsParameterValue(ctx, wfClient, namespace, p)
+		if err != nil {
+			return err
+		}
+		wf.Spec.Arguments.Parameters[i].Value = wfv1.AnyStringPtr(value)
+		wf.Spec.Arguments.Parameters[i].ValueFrom = nil
+	}
+	ref := wf.Spec.WorkflowTemplateRef
+	if ref == nil {
+		return nil
+	}
+	var wfSpecHolder wfv1.WorkflowSpecHolder
+	var err error
+	if ref.ClusterScope {
+		wfSpecHolder, err = cwftmplGetter.Get(ref.Name)
+	} else {
+		wfSpecHolder, err = wftmplGetter.Get(ref.Name)
+	}
+	if err != nil {
+		return err
+	}
+	for _, p := range wfSpecHolder.GetWorkflowSpec().Arguments.Parameters {
+		if p.ValueFrom == nil || p.ValueFrom.WorkflowOutputs == nil || wf.Spec.Arguments.GetParameterByName(p.Name) != nil {
+			continue
+		}
+		value, err := s.getWorkflowOutputsParameterValue(ctx, wfClient, namespace, p)
+		if err != nil {
+			return err
+		}
+		wf.Spec.Arguments.Parameters = append(wf.Spec.Arguments.Parameters, wfv1.Parameter{Name: p.Name, Value: wfv1.AnyStringPtr(value)})
+	}
+	return nil
+}
+
+// getWorkflowOutputsParameterValue returns the value of the parameter from the output of the latest workflow, or its
+// default if it cannot be got
+func (s *workflowServer) getWorkflowOutputsParameterValue(ctx context.Context, wfClient versioned.Interface, namespace string, p wfv1.Parameter) (string, error) {
+	from := p.ValueFrom.WorkflowOutputs
+	value, err := s.getLatestWorkflowOutput(ctx, wfClient, namespace, *from)
+	if err != nil {
+		if p.ValueFrom.Default != nil {
+			return p.ValueFrom.Default.String(), nil
+		}
+		return "", fmt.Errorf("failed to get the value of parameter %q from the workflows %q: %w", p.Name, from.Selector, err)
+	}
+	return value, nil
+}
+
+func (s *workflowServer) getLatestWorkflowOutput(ctx context.Context, wfClient versioned.Interface, namespace string, from wfv1.WorkflowOutputsValueFrom) (string, error) {
+	selector, err := labels.Parse(from.Selector)
+	if err != nil {
+		return "", err
+	}
+	phase, err := labels.NewRequirement(common.LabelKeyPhase, selection.Equals, []string{string(from.GetLatest())})
+	if err != nil {
+		return "", err
+	}
+	wfList, err := wfClient.ArgoprojV1alpha1().Workflows(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.Add(*phase).String()})
+	if err != nil {
+		return "", err
+	}
+	latest := util.GetLatestFinishedWorkflow(wfList.Items)
+	if latest == nil {
+		return "", fmt.Errorf("no %s workflows found", from.GetLatest())
+	}
+	if err := s.hydrator.Hydrate(latest); err != nil {
+		return "", err
+	}
+	return util.GetWorkflowOutputValue(latest, from.Output)
+}
@@ -7,6 +7,8 @@ import (
 	"io"
 	"sort"
 	"strconv"
+	"sync"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 	"google.golang.org/grpc/codes"
@@ -15,7 +17,9 @@ import (
 	apierr "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
 
+	"github.com/argoproj/argo-workflows/v3/config"
 	"github.com/argoproj/argo-workflows/v3/errors"
 	"github.com/argoproj/argo-workflows/v3/persist/sqldb"
 	workflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
@@ -24,14 +28,20 @@ import (
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 	"github.com/argoproj/argo-workflows/v3/pkg/client/clientset/versioned"
 	"github.com/argoproj/argo-workflows/v3/server/auth"
+	"github.com/argoproj/argo-workflows/v3/server/clusters"
+	"github.com/argoproj/argo-workflows/v3/server/redaction"
 	sutils "github.com/argoproj/argo-workflows/v3/server/utils"
+	"github.com/argoproj/argo-workflows/v3/server/workflow/store"
 	argoutil "github.com/argoproj/argo-workflows/v3/util"
 	"github.com/argoproj/argo-workflows/v3/util/fields"
 	"github.com/argoproj/argo-workflows/v3/util/instanceid"
 	"github.com/argoproj/argo-workflows/v3/util/logs"
+	"github.com/argoproj/argo-workflows/v3/workflow/artifactrepositories"
+	artifact "github.com/argoproj/argo-workflows/v3/workflow/artifacts"
 	"github.com/argoproj/argo-workflows/v3/workflow/common"
 	"github.com/argoproj/argo-workflows/v3/workflow/creator"
 	"github.com/argoproj/argo-workflows/v3/workflow/hydrator"
+	"github.com/argoproj/argo-workflows/v3/workflow/signal"
 	"github.com/argoproj/argo-workflows/v3/workflow/templateresolution"
 	"github.com/argoproj/argo-workflows/v3/workflow/util"
 	"github.com/argoproj/argo-workflows/v3/workflow/validate"
@@ -42,13 +52,24 @@ type workflowServer struct {
 	offloadNodeStatusRepo sqldb.OffloadNodeStatusRepo
 	hydrator              hydrator.Interface
 	wfArchiveServer       workflowarchivepkg.ArchivedWorkflowServiceServer
+	clusters              clusters.Registry
+	workflowStores        store.Registry
+	guardrails            *config.Guardrails
+	deprecatedParameters  config.DeprecatedParameters
+	idempotencyKeyWindow  time.Duration
+	approvals             util.ApprovalOpts
+	redactor              *redaction.Redactor
+	archivedLogs          logs.ArchivedLogOpener
+	// artifactRepositories resolves the artifact repositories of workflows to check them, if it is set
+	artifactRepositories artifactrepositories.Interface
+	artDriverFactory     artifact.NewDriverFunc
 }
 
 const latestAlias = "@latest"
 
 // NewWorkflowServer returns a new workflowServer
-func NewWorkflowServer(instanceIDService instanceid.Service, offloadNodeStatusRepo sqldb.OffloadNodeStatusRepo, wfArchiveServer workflowarchivepkg.ArchivedWorkflowServiceServer) workflowpkg.WorkflowServiceServer {
-	return &workflowServer{instanceIDService, offloadNodeStatusRepo, hydrator.New(offloadNodeStatusRepo), wfArchiveServer}
+func NewWorkflowServer(instanceIDService instanceid.Service, offloadNodeStatusRepo sqldb.OffloadNodeStatusRepo, wfArchiveServer workflowarchivepkg.ArchivedWorkflowServiceServer, clusterRegistry clusters.Registry, workflowStores store.Registry, guardrails *config.Guardrails, deprecatedParameters config.DeprecatedParameters, idempotencyKeyWindow time.Duration, approvals util.ApprovalOpts, redactor *redaction.Redactor, archivedLogs logs.ArchivedLogOpener, artifactRepositories artifactrepositories.Interface) workflowpkg.WorkflowServiceServer {
+	return &workflowServer{instanceIDService, offloadNodeStatusRepo, hydrator.New(offloadNodeStatusRepo), wfArchiveServer, clusterRegistry, workflowStores, guardrails, deprecatedParameters, idempotencyKeyWindow, approvals, redactor, archivedLogs, artifactRepositories, artifact.NewDriver}
 }
 
 func (s *workflowServer) CreateWorkflow(ctx context.Context, req *workflowpkg.WorkflowCreateRequest) (*wfv1.Workflow, error) {
@@ -62,30 +83,58 @@ func (s *workflowServer) CreateWorkflow(ctx context.Context, req *workflowpkg.Wo
 		req.Workflow.Namespace = req.Namespace
 	}
 
+	// the request is hashed as it was sent, before the server changes the workflow
+	key := s.getIdempotencyKey(ctx)
+	requestHash, err := hashRequest(req)
+	if err != nil {
+		return nil, sutils.ToStatusError(err, codes.Internal)
+	}
+
 	s.instanceIDService.Label(req.Workflow)
 	creator.Label(ctx, req.Workflow)
 
 	wftmplGetter := templateresolution.WrapWorkflowTemplateInterface(wfClient.ArgoprojV1alpha1().WorkflowTemplates(req.Namespace))
 	cwftmplGetter := templateresolution.WrapClusterWorkflowTemplateInterface(wfClient.ArgoprojV1alpha1().ClusterWorkflowTemplates())
 
-	err := validate.ValidateWorkflow(wftmplGetter, cwftmplGetter, req.Workflow, validate.ValidateOpts{})
+	err = validate.ValidateWorkflow(wftmplGetter, cwftmplGetter, req.Workflow, validate.ValidateOpts{Guardrails: s.guardrails})
 	if err != nil {
 		return nil, sutils.ToStatusError(err, codes.InvalidArgument)
 	}
+	err = s.checkDeprecatedParameters(ctx, wftmplGetter, cwftmplGetter, req.Workflow)
+	if err != nil {
+		return nil, sutils.ToStatusError(err, codes.InvalidArgument)
+	}
+	err = s.resolveWorkflowOutputsParameters(ctx, wfClient, wftmplGetter, cwftmplGetter, req.Namespace, req.Workflow)
+	if err != nil {
+		return nil, sutils.ToStatusError(err, codes.InvalidArgument)
+	}
+	err = s.checkArtifactRepository(ctx, wftmplGetter, cwftmplGetter, req.Namespace, req.Workflow)
+	if err != nil {
+		return nil, sutils.ToStatusError(err, codes.FailedPrecondition)
+	}
 
 	// if we are doing a normal dryRun, just return the workflow un-altered
 	if req.CreateOptions != nil && len(req.CreateOptions.DryRun) > 0 {
-		return req.Workflow, nil
+		return s.redactWorkflow(ctx, req.Workflow)
 	}
 	if req.ServerDryRun {
 		workflow, err := util.CreateServerDryRun(ctx, req.Workflow, wfClient)
 		if err != nil {
 			return nil, sutils.ToStatusError(err, codes.InvalidArgument)
 		}
-		return workflow, nil
+		return s.redactWorkflow(ctx, workflow)
 	}
 
+	setIdempotencyKey(req.Workflow, key, requestHash)
+
 	wf, err := wfClient.ArgoprojV1alpha1().Workflows(req.Namespace).Create(ctx, req.Workflow, metav1.CreateOptions{})
+	if apierr.IsAlreadyExists(err) && key != "" {
+		wf, err = s.getIdempotentWorkflow(ctx, wfClient, req.Namespace, req.Workflow.Name, key, requestHash)
+		if err != nil {
+			return nil, sutils.ToStatusError(err, codes.Internal)
+		}
+		return s.redactWorkflow(ctx, wf)
+	}
 	if err != nil {
 		if apierr.IsServerTimeout(err) && req.Workflow.GenerateName != "" && req.Workflow.Name != "" {
 			errWithHint := fmt.Errorf(`create request failed due to timeout, but it's possible that workflow "%s" already exists. Original error: %w`, req.Workflow.Name, err)
@@ -95,8 +144,28 @@ func (s *workflowServer) CreateWorkflow(ctx context.Context, req *workflowpkg.Wo
 		log.WithError(err).Error("Create request failed")
 		return nil, sutils.ToStatusError(err, codes.Internal)
 	}
+	wf, err = s.signCreator(ctx, wfClient, wf)
+	if err != nil {
+		return nil, sutils.ToStatusError(err, codes.Internal)
+	}
 
-	return wf, nil
+	return s.redactWorkflow(ctx, wf)
+}
+
+// signCreator signs the creator subject of a workflow the server has just created, so that approvals can tell that the
+// server recorded it, see creator.VerifiedSubject. It is signed once the workflow is created, as the signature covers
+// the workflow's UID.
+func (s *workflowServer) signCreator(ctx context.Context, wfClient versioned.Interface, wf *wfv1.Workflow) (*wfv1.Workflow, error) {
+	if len(s.approvals.CreatorKey) == 0 || creator.Subject(wf) == "" {
+		return wf, nil
+	}
+	data, err := json.Marshal(map[string]interface{}{"metadata": map[string]interface{}{"annotations": map[string]string{
+		common.AnnotationKeyCreatorSignature: creator.Signature(wf, s.approvals.CreatorKey),
+	}}})
+	if err != nil {
+		return nil, err
+	}
+	return wfClient.ArgoprojV1alpha1().Workflows(wf.Namespace).Patch(ctx, wf.Name, types.MergePatchType, data, metav1.PatchOptions{})
 }
 
 func (s *workflowServer) GetWorkflow(ctx context.Context, req *workflowpkg.WorkflowGetRequest) (*wfv1.Workflow, error) {
@@ -104,8 +173,14 @@ func (s *workflowServer) GetWorkflow(ctx context.Context, req *workflowpkg.Workf
 	if req.GetOptions != nil {
 		wfGetOption = *req.GetOptions
 	}
+	if req.Cluster != "" && req.Cluster != s.clusters.Name() {
+		return s.getRemoteWorkflow(ctx, req, wfGetOption)
+	}
 	wfClient := auth.GetWfClient(ctx)
-	wf, err := s.getWorkflow(ctx, wfClient, req.Namespace, req.Name, wfGetOption)
+	workflowStore := s.workflowStores.For(req.Namespace)
+	wf, err := s.lookupWorkflow(ctx, wfClient, req.Namespace, req.Name, func() (*wfv1.Workflow, error) {
+		return workflowStore.GetWorkflow(ctx, req.Namespace, req.Name, wfGetOption)
+	})
 	if err != nil {
 		return nil, sutils.ToStatusError(err, codes.Internal)
 	}
@@ -113,12 +188,19 @@ func (s *workflowServer) GetWorkflow(ctx context.Context, req *workflowpkg.Workf
 	if err != nil {
 		return nil, sutils.ToStatusError(err, codes.InvalidArgument)
 	}
+	if req.Cluster != "" {
+		setCluster(wf, req.Cluster)
+	}
 	cleaner := fields.NewCleaner(req.Fields)
 	if !cleaner.WillExclude("status.nodes") {
 		if err := s.hydrator.Hydrate(wf); err != nil {
 			return nil, sutils.ToStatusError(err, codes.Internal)
 		}
 	}
+	wf, err = s.redactWorkflow(ctx, wf)
+	if err != nil {
+		return nil, err
+	}
 	newWf := &wfv1.Workflow{}
 	if ok, err := cleaner.Clean(wf, &newWf); err != nil {
 		// should this be InvalidArgument?
@@ -202,48 +284,190 @@ func mergeWithArchivedWorkflows(liveWfs wfv1.WorkflowList, archivedWfs wfv1.Work
 	return &wfv1.WorkflowList{Items: mergedWfs, ListMeta: liveWfs.ListMeta}
 }
 
-func (s *workflowServer) ListWorkflows(ctx context.Context, req *workflowpkg.WorkflowListRequest) (*wfv1.WorkflowList, error) {
-	wfClient := auth.GetWfClient(ctx)
+// getRemoteWorkflow gets a workflow from a remote cluster. Remote workflows are neither archived nor hydrated by this server.
+func (s *workflowServer) getRemoteWorkflow(ctx context.Context, req *workflowpkg.WorkflowGetRequest, options metav1.GetOptions) (*wfv1.Workflow, error) {
+	user, err := remoteUser(ctx, req.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	wfClient, err := s.clusters.WfClient(req.Cluster, user)
+	if err != nil {
+		return nil, sutils.ToStatusError(err, codes.InvalidArgument)
+	}
+	wf, err := wfClient.ArgoprojV1alpha1().Workflows(req.Namespace).Get(ctx, req.Name, options)
+	if err != nil {
+		return nil, sutils.ToStatusError(err, codes.Internal)
+	}
+	setCluster(wf, req.Cluster)
+	wf, err = s.redactWorkflow(ctx, wf)
+	if err != nil {
+		return nil, err
+	}
+	newWf := &wfv1.Workflow{}
+	if ok, err := fields.NewCleaner(req.Fields).Clean(wf, &newWf); err != nil {
+		return nil, sutils.ToStatusError(fmt.Errorf("unable to CleanFields in request: %w", err), codes.Internal)
+	} else if ok {
+		return newWf, nil
+	}
+	return wf, nil
+}
 
-	options := &metav1.ListOptions{}
-	if req.ListOptions != nil {
-		options = req.ListOptions
+// mergeWatches merges the events of the watches, keyed by cluster name, into a single channel, annotating each
+// workflow with its cluster. The channel is closed when any of the watches ends, or the context is done.
+func mergeWatches(ctx context.Context, watches map[string]watch.Interface) <-chan watch.Event {
+	results := make(chan watch.Event)
+	done := make(chan struct{})
+	var once sync.Once
+	var wg sync.WaitGroup
+	for cluster, w := range watches {
+		wg.Add(1)
+		go func(cluster string, w watch.Interface) {
+			defer wg.Done()
+			defer once.Do(func() { close(done) })
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-done:
+					return
+				case event, open := <-w.ResultChan():
+					if !open {
+						return
+					}
+					if wf, ok := event.Object.(*wfv1.Workflow); ok {
+						setCluster(wf, cluster)
+					}
+					select {
+					case results <- event:
+					case <-done:
+						return
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+		}(cluster, w)
 	}
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+	return results
+}
 
-	// Save the original Continue and Limit.
-	resourceVersion := options.Continue
-	limit := options.Limit
+// remoteUser returns the caller, to be impersonated in remote clusters. A user authenticated with SSO RBAC is
+// impersonated as the service account selected for them. The claims of a client's bearer token are not verified when
+// the request is authenticated, so the token is first used to check that the caller may list the workflows of the
+// namespace in this cluster, which fails if it is not valid.
+func remoteUser(ctx context.Context, namespace string) (clusters.User, error) {
+	claims := auth.GetClaims(ctx)
+	if claims == nil || claims.Subject == "" {
+		return clusters.User{}, sutils.ToStatusError(fmt.Errorf("unable to determine the user to access remote clusters as"), codes.PermissionDenied)
+	}
+	allowed, err := auth.CanI(ctx, "list", "workflows", namespace, "")
+	if err != nil {
+		return clusters.User{}, sutils.ToStatusError(err, codes.Unauthenticated)
+	}
+	if !allowed {
+		return clusters.User{}, sutils.ToStatusError(fmt.Errorf("not allowed to list workflows in namespace %q", namespace), codes.PermissionDenied)
+	}
+	if claims.ServiceAccountName != "" {
+		return clusters.User{Name: fmt.Sprintf("system:serviceaccount:%s:%s", claims.ServiceAccountNamespace, claims.ServiceAccountName)}, nil
+	}
+	return clusters.User{Name: claims.Subject, Groups: claims.Groups}, nil
+}
 
-	// Search whole with Limit 0.
-	// Reset the Continue "" to prevent Kubernetes native pagination.
-	options.Continue = ""
-	options.Limit = 0
+// setCluster annotates the workflow with the name of the cluster it was read from.
+func setCluster(wf *wfv1.Workflow, cluster string) {
+	if wf.Annotations == nil {
+		wf.Annotations = map[string]string{}
+	}
+	wf.Annotations[common.AnnotationKeyCluster] = cluster
+}
 
-	s.instanceIDService.With(options)
-	wfList, err := wfClient.ArgoprojV1alpha1().Workflows(req.Namespace).List(ctx, *options)
+// listRemoteWorkflows lists the workflows in each of the remote clusters. A cluster that cannot be listed is
+// logged and skipped, so that one unavailable cluster does not break the aggregated view.
+func (s *workflowServer) listRemoteWorkflows(ctx context.Context, namespace string, options metav1.ListOptions) (wfv1.Workflows, error) {
+	user, err := remoteUser(ctx, namespace)
 	if err != nil {
-		return nil, sutils.ToStatusError(err, codes.Internal)
+		return nil, err
 	}
+	var items wfv1.Workflows
+	for _, cluster := range s.clusters.Remotes() {
+		logCtx := log.WithField("cluster", cluster)
+		wfClient, err := s.clusters.WfClient(cluster, user)
+		if err != nil {
+			logCtx.WithError(err).Warn("unable to get client for cluster")
+			continue
+		}
+		wfList, err := wfClient.ArgoprojV1alpha1().Workflows(namespace).List(ctx, options)
+		if err != nil {
+			logCtx.WithError(err).Warn("unable to list workflows in cluster")
+			continue
+		}
+		for i := range wfList.Items {
+			setCluster(&wfList.Items[i], cluster)
+		}
+		items = append(items, wfList.Items...)
+	}
+	return items, nil
+}
 
-	// Search whole with Limit 0.
-	// Reset the Continue "0" to prevent archive workflow pagination.
-	options.Continue = "0"
-	options.Limit = 0
-	archivedWfList, err := s.wfArchiveServer.ListArchivedWorkflows(ctx, &workflowarchivepkg.ListArchivedWorkflowsRequest{
-		ListOptions: options,
-		NamePrefix:  "",
-		Namespace:   req.Namespace,
-	})
-	if err != nil {
-		log.Warnf("unable to list archived workflows:%v", err)
+func (s *workflowServer) ListWorkflows(ctx context.Context, req *workflowpkg.WorkflowListRequest) (*wfv1.WorkflowList, error) {
+	options := &metav1.ListOptions{}
+	if req.ListOptions != nil {
+		options = req.ListOptions
+	}
+	if req.AllClusters && (options.Limit > 0 || options.Continue != "") {
+		return nil, sutils.ToStatusError(fmt.Errorf("pagination is not supported when listing workflows from all clusters"), codes.InvalidArgument)
+	}
+
+	s.instanceIDService.With(options)
+	workflowStore := s.workflowStores.For(req.Namespace)
+	var wfList *wfv1.WorkflowList
+	var err error
+	if workflowStore.IsArchive() {
+		// the archive store only has archived workflows, and paginates them in its query
+		wfList, err = workflowStore.ListWorkflows(ctx, req.Namespace, *options)
+		if err != nil {
+			return nil, sutils.ToStatusError(err, codes.Internal)
+		}
 	} else {
-		if archivedWfList != nil {
-			wfList = mergeWithArchivedWorkflows(*wfList, *archivedWfList)
+		// Save the original Continue and Limit.
+		resourceVersion := options.Continue
+		limit := options.Limit
+
+		// Search whole with Limit 0.
+		// Reset the Continue "" to prevent Kubernetes native pagination.
+		options.Continue = ""
+		options.Limit = 0
+
+		wfList, err = workflowStore.ListWorkflows(ctx, req.Namespace, *options)
+		if err != nil {
+			return nil, sutils.ToStatusError(err, codes.Internal)
+		}
+
+		// Search whole with Limit 0.
+		// Reset the Continue "0" to prevent archive workflow pagination.
+		options.Continue = "0"
+		options.Limit = 0
+		archivedWfList, err := s.wfArchiveServer.ListArchivedWorkflows(ctx, &workflowarchivepkg.ListArchivedWorkflowsRequest{
+			ListOptions: options,
+			NamePrefix:  "",
+			Namespace:   req.Namespace,
+		})
+		if err != nil {
+			log.Warnf("unable to list archived workflows:%v", err)
+		} else {
+			if archivedWfList != nil {
+				wfList = mergeWithArchivedWorkflows(*wfList, *archivedWfList)
+			}
 		}
+		cursorPaginationByResourceVersion(wfList.Items, resourceVersion, limit, wfList)
 	}
-	cursorPaginationByResourceVersion(wfList.Items, resourceVersion, limit, wfList)
 
 	cleaner := fields.NewCleaner(req.Fields)
+
 	if s.offloadNodeStatusRepo.IsEnabled() && !cleaner.WillExclude("items.status.nodes") {
 		offloadedNodes, err := s.offloadNodeStatusRepo.List(req.Namespace)
 		if err != nil {
@@ -260,6 +484,23 @@ func (s *workflowServer) ListWorkflows(ctx context.Context, req *workflowpkg.Wor
 		}
 	}
 
+	if req.AllClusters {
+		for i := range wfList.Items {
+			setCluster(&wfList.Items[i], s.clusters.Name())
+		}
+		// offloaded nodes are only hydrated for the local cluster, so remote workflows are added afterwards
+		options.Continue = ""
+		remoteWfs, err := s.listRemoteWorkflows(ctx, req.Namespace, *options)
+		if err != nil {
+			return nil, err
+		}
+		wfList.Items = append(wfList.Items, remoteWfs...)
+	}
+
+	if err := s.redactor.RedactWorkflows(ctx, wfList.Items); err != nil {
+		return nil, sutils.ToStatusError(err, codes.Internal)
+	}
+
 	// we make no promises about the overall list sorting, we just sort each page
 	sort.Sort(wfList.Items)
 
@@ -292,13 +533,35 @@ func (s *workflowServer) WatchWorkflows(req *workflowpkg.WatchWorkflowsRequest,
 	}
 	s.instanceIDService.With(opts)
 	wfIf := wfClient.ArgoprojV1alpha1().Workflows(req.Namespace)
-	watch, err := wfIf.Watch(ctx, *opts)
+	wfWatch, err := wfIf.Watch(ctx, *opts)
 	if err != nil {
 		return sutils.ToStatusError(err, codes.Internal)
 	}
-	defer watch.Stop()
+	defer wfWatch.Stop()
+	results := wfWatch.ResultChan()
+	if req.AllClusters {
+		user, err := remoteUser(ctx, req.Namespace)
+		if err != nil {
+			return err
+		}
+		watches := map[string]watch.Interface{s.clusters.Name(): wfWatch}
+		for _, cluster := range s.clusters.Remotes() {
+			remoteClient, err := s.clusters.WfClient(cluster, user)
+			if err != nil {
+				return sutils.ToStatusError(err, codes.Internal)
+			}
+			remoteWatch, err := remoteClient.ArgoprojV1alpha1().Workflows(req.Namespace).Watch(ctx, *opts)
+			if err != nil {
+				return sutils.ToStatusError(fmt.Errorf("failed to watch workflows in cluster %q: %w", cluster, err), codes.Internal)
+			}
+			defer remoteWatch.Stop()
+			watches[cluster] = remoteWatch
+		}
+		results = mergeWatches(ctx, watches)
+	}
 	cleaner := fields.NewCleaner(req.Fields).WithoutPrefix("result.object.")
 
+	redact := s.redactor.WorkflowRedactor(ctx)
 	clean := func(x *wfv1.Workflow) (*wfv1.Workflow, error) {
 		y := &wfv1.Workflow{}
 		if clean, err := cleaner.Clean(x, y); err != nil {
@@ -325,7 +588,7 @@ func (s *workflowServer) WatchWorkflows(req *workflowpkg.WatchWorkflowsRequest,
 		select {
 		case <-ctx.Done():
 			return nil
-		case event, open := <-watch.ResultChan():
+		case event, open := <-results:
 			if !open {
 				return sutils.ToStatusError(io.EOF, codes.ResourceExhausted)
 			}
@@ -336,11 +599,17 @@ func (s *workflowServer) WatchWorkflows(req *workflowpkg.WatchWorkflowsRequest,
 				return sutils.ToStatusError(apierr.FromObject(event.Object), codes.Internal)
 			}
 			logCtx := log.WithFields(log.Fields{"workflow": wf.Name, "type": event.Type, "phase": wf.Status.Phase})
-			if !cleaner.WillExclude("status.nodes") {
+			if cluster := wf.Annotations[common.AnnotationKeyCluster]; req.AllClusters && cluster != s.clusters.Name() {
+				logCtx = logCtx.WithField("cluster", cluster)
+			} else if !cleaner.WillExclude("status.nodes") {
 				if err := s.hydrator.Hydrate(wf); err != nil {
 					return sutils.ToStatusError(err, codes.Internal)
 				}
 			}
+			wf, err = redact(wf)
+			if err != nil {
+				return sutils.ToStatusError(err, codes.Internal)
+			}
 			newWf, err := clean(wf)
 			if err != nil {
 				return sutils.ToStatusError(fmt.Errorf("unable to CleanFields in request: %w", err), codes.Internal)
@@ -438,6 +707,12 @@ func (s *workflowServer) RetryWorkflow(ctx context.Context, req *workflowpkg.Wor
 		return nil, sutils.ToStatusError(err, codes.InvalidArgument)
 	}
 
+	key := s.getIdempotencyKey(ctx)
+	if s.isIdempotentRetry(wf, key) {
+		log.WithFields(log.Fields{"namespace": wf.Namespace, "name": wf.Name}).Info("Returning workflow of repeated retry with the same idempotency key")
+		return s.redactWorkflow(ctx, wf)
+	}
+
 	err = s.hydrator.Hydrate(wf)
 	if err != nil {
 		return nil, sutils.ToStatusError(err, codes.Internal)
@@ -447,6 +722,7 @@ func (s *workflowServer) RetryWorkflow(ctx context.Context, req *workflowpkg.Wor
 	if err != nil {
 		return nil, sutils.ToStatusError(err, codes.Internal)
 	}
+	setRetryIdempotencyKey(wf, key)
 
 	for _, podName := range podsToDelete {
 		log.WithFields(log.Fields{"podDeleted": podName}).Info("Deleting pod")
@@ -466,7 +742,7 @@ func (s *workflowServer) RetryWorkflow(ctx context.Context, req *workflowpkg.Wor
 		return nil, sutils.ToStatusError(err, codes.Internal)
 	}
 
-	return wf, nil
+	return s.redactWorkflow(ctx, wf)
 }
 
 func (s *workflowServer) ResubmitWorkflow(ctx context.Context, req *workflowpkg.WorkflowResubmitRequest) (*wfv1.Workflow, error) {
@@ -481,16 +757,28 @@ func (s *workflowServer) ResubmitWorkflow(ctx context.Context, req *workflowpkg.
 		return nil, sutils.ToStatusError(err, codes.InvalidArgument)
 	}
 
-	newWF, err := util.FormulateResubmitWorkflow(ctx, wf, req.Memoized, req.Parameters)
+	key := s.getIdempotencyKey(ctx)
+	requestHash, err := hashRequest(req)
+	if err != nil {
+		return nil, sutils.ToStatusError(err, codes.Internal)
+	}
+
+	newWF, err := util.FormulateResubmitWorkflowWithNameSuffix(ctx, wf, req.Memoized, req.Parameters, idempotentNameSuffix(key))
 	if err != nil {
 		return nil, sutils.ToStatusError(err, codes.Internal)
 	}
+	setIdempotencyKey(newWF, key, requestHash)
 
 	created, err := util.SubmitWorkflow(ctx, wfClient.ArgoprojV1alpha1().Workflows(req.Namespace), wfClient, req.Namespace, newWF, &wfv1.SubmitOpts{})
+	if err == nil {
+		created, err = s.signCreator(ctx, wfClient, created)
+	} else if apierr.IsAlreadyExists(err) && key != "" {
+		created, err = s.getIdempotentWorkflow(ctx, wfClient, req.Namespace, newWF.Name, key, requestHash)
+	}
 	if err != nil {
 		return nil, sutils.ToStatusError(err, codes.Internal)
 	}
-	return created, nil
+	return s.redactWorkflow(ctx, created)
 }
 
 func (s *workflowServer) ResumeWorkflow(ctx context.Context, req *workflowpkg.WorkflowResumeRequest) (*wfv1.Workflow, error) {
@@ -505,7 +793,7 @@ func (s *workflowServer) ResumeWorkflow(ctx context.Context, req *workflowpkg.Wo
 		return nil, sutils.ToStatusError(err, codes.InvalidArgument)
 	}
 
-	err = util.ResumeWorkflow(ctx, wfClient.ArgoprojV1alpha1().Workflows(req.Namespace), s.hydrator, wf.Name, req.NodeFieldSelector)
+	err = util.ResumeWorkflow(ctx, wfClient.ArgoprojV1alpha1().Workflows(req.Namespace), s.hydrator, wf.Name, req.NodeFieldSelector, wfv1.NodePhase(req.OthersPhase), s.approvals)
 	if err != nil {
 		log.WithFields(log.Fields{"name": wf.Name}).WithError(err).Warn("Failed to resume")
 		return nil, sutils.ToStatusError(err, codes.Internal)
@@ -517,7 +805,7 @@ func (s *workflowServer) ResumeWorkflow(ctx context.Context, req *workflowpkg.Wo
 		return nil, sutils.ToStatusError(err, codes.Internal)
 	}
 
-	return wf, nil
+	return s.redactWorkflow(ctx, wf)
 }
 
 func (s *workflowServer) SuspendWorkflow(ctx context.Context, req *workflowpkg.WorkflowSuspendRequest) (*wfv1.Workflow, error) {
@@ -533,7 +821,11 @@ func (s *workflowServer) SuspendWorkflow(ctx context.Context, req *workflowpkg.W
 		return nil, sutils.ToStatusError(err, codes.InvalidArgument)
 	}
 
-	err = util.SuspendWorkflow(ctx, wfClient.ArgoprojV1alpha1().Workflows(wf.Namespace), wf.Name)
+	if req.NodeFieldSelector != "" {
+		err = util.PauseWorkflowBranches(ctx, wfClient.ArgoprojV1alpha1().Workflows(wf.Namespace), s.hydrator, wf.Name, req.NodeFieldSelector)
+	} else {
+		err = util.SuspendWorkflow(ctx, wfClient.ArgoprojV1alpha1().Workflows(wf.Namespace), wf.Name)
+	}
 	if err != nil {
 		return nil, sutils.ToStatusError(err, codes.Internal)
 	}
@@ -543,7 +835,7 @@ func (s *workflowServer) SuspendWorkflow(ctx context.Context, req *workflowpkg.W
 		return nil, sutils.ToStatusError(err, codes.Internal)
 	}
 
-	return wf, nil
+	return s.redactWorkflow(ctx, wf)
 }
 
 func (s *workflowServer) TerminateWorkflow(ctx context.Context, req *workflowpkg.WorkflowTerminateRequest) (*wfv1.Workflow, error) {
@@ -568,7 +860,7 @@ func (s *workflowServer) TerminateWorkflow(ctx context.Context, req *workflowpkg
 	if err != nil {
 		return nil, sutils.ToStatusError(err, codes.Internal)
 	}
-	return wf, nil
+	return s.redactWorkflow(ctx, wf)
 }
 
 func (s *workflowServer) StopWorkflow(ctx context.Context, req *workflowpkg.WorkflowStopRequest) (*wfv1.Workflow, error) {
@@ -590,7 +882,7 @@ func (s *workflowServer) StopWorkflow(ctx context.Context, req *workflowpkg.Work
 	if err != nil {
 		return nil, sutils.ToStatusError(err, codes.Internal)
 	}
-	return wf, nil
+	return s.redactWorkflow(ctx, wf)
 }
 
 func (s *workflowServer) SetWorkflow(ctx context.Context, req *workflowpkg.WorkflowSetRequest) (*wfv1.Workflow, error) {
@@ -620,10 +912,20 @@ func (s *workflowServer) SetWorkflow(ctx context.Context, req *workflowpkg.Workf
 		}
 	}
 
+	outputArtifacts := make(map[string]string)
+	if req.OutputArtifacts != "" {
+		err = json.Unmarshal([]byte(req.OutputArtifacts), &outputArtifacts)
+		if err != nil {
+			return nil, sutils.ToStatusError(fmt.Errorf("unable to parse output artifact set request: %s", err), codes.InvalidArgument)
+		}
+	}
+
 	operation := util.SetOperationValues{
 		Phase:            phaseToSet,
 		Message:          req.Message,
 		OutputParameters: outputParams,
+		OutputArtifacts:  outputArtifacts,
+		Approvals:        s.approvals,
 	}
 
 	err = util.SetWorkflow(ctx, wfClient.ArgoprojV1alpha1().Workflows(req.Namespace), s.hydrator, wf.Name, req.NodeFieldSelector, operation)
@@ -635,7 +937,214 @@ func (s *workflowServer) SetWorkflow(ctx context.Context, req *workflowpkg.Workf
 	if err != nil {
 		return nil, sutils.ToStatusError(err, codes.Internal)
 	}
-	return wf, nil
+	return s.redactWorkflow(ctx, wf)
+}
+
+// SignalWorkflow sends a signal to the main containers of a running pod node, via the executor, e.g. so that a job
+// that checkpoints or flushes on SIGUSR1 can be told to do so
+func (s *workflowServer) SignalWorkflow(ctx context.Context, req *workflowpkg.WorkflowSignalRequest) (*wfv1.Workflow, error) {
+	sig, err := signal.ParseSignal(req.Signal)
+	if err != nil {
+		return nil, sutils.ToStatusError(err, codes.InvalidArgument)
+	}
+	wfClient := auth.GetWfClient(ctx)
+	wf, err := s.getWorkflow(ctx, wfClient, req.Namespace, req.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, sutils.ToStatusError(err, codes.Internal)
+	}
+	err = s.validateWorkflow(wf)
+	if err != nil {
+		return nil, sutils.ToStatusError(err, codes.InvalidArgument)
+	}
+	err = s.hydrator.Hydrate(wf)
+	if err != nil {
+		return nil, sutils.ToStatusError(err, codes.Internal)
+	}
+	node := wf.Status.Nodes.Find(func(n wfv1.NodeStatus) bool {
+		return n.ID == req.Node || n.Name == req.Node || n.DisplayName == req.Node
+	})
+	if node == nil {
+		return nil, sutils.ToStatusError(fmt.Errorf("node %q not found in workflow %q", req.Node, wf.Name), codes.NotFound)
+	}
+	if node.Type != wfv1.NodeTypePod || node.Phase != wfv1.NodeRunning {
+		return nil, sutils.ToStatusError(fmt.Errorf("node %q is not a running pod", req.Node), codes.FailedPrecondition)
+	}
+	wf, err = util.SignalNode(ctx, wfClient.ArgoprojV1alpha1().Workflows(req.Namespace), s.hydrator, wf.Name, node.ID, sig)
+	if err != nil {
+		return nil, sutils.ToStatusError(err, codes.Internal)
+	}
+	err = s.hydrator.Hydrate(wf)
+	if err != nil {
+		return nil, sutils.ToStatusError(err, codes.Internal)
+	}
+	return s.redactWorkflow(ctx, wf)
+}
+
+// GetWorkflowDataflow returns the parameters and artifacts that flow between the nodes of a workflow, e.g. so that the
+// UI can label the edges of the workflow's graph with them
+func (s *workflowServer) GetWorkflowDataflow(ctx context.Context, req *workflowpkg.WorkflowDataflowRequest) (*wfv1.WorkflowDataflow, error) {
+	wfClient := auth.GetWfClient(ctx)
+	wf, err := s.getWorkflow(ctx, wfClient, req.Namespace, req.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, sutils.ToStatusError(err, codes.Internal)
+	}
+	err = s.validateWorkflow(wf)
+	if err != nil {
+		return nil, sutils.ToStatusError(err, codes.InvalidArgument)
+	}
+	err = s.hydrator.Hydrate(wf)
+	if err != nil {
+		return nil, sutils.ToStatusError(err, codes.Internal)
+	}
+	wf, err = s.redactWorkflow(ctx, wf)
+	if err != nil {
+		return nil, err
+	}
+	return util.GetWorkflowDataflow(wf), nil
+}
+
+// GetWorkflowOutputs returns the values of the outputs a workflow declares, so that consumers do not need to get the
+// whole workflow for them
+func (s *workflowServer) GetWorkflowOutputs(ctx context.Context, req *workflowpkg.WorkflowOutputsRequest) (*wfv1.WorkflowOutputs, error) {
+	wfClient := auth.GetWfClient(ctx)
+	wf, err := s.getWorkflow(ctx, wfClient, req.Namespace, req.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, sutils.ToStatusError(err, codes.Internal)
+	}
+	err = s.validateWorkflow(wf)
+	if err != nil {
+		return nil, sutils.ToStatusError(err, codes.InvalidArgument)
+	}
+	err = s.hydrator.Hydrate(wf)
+	if err != nil {
+		return nil, sutils.ToStatusError(err, codes.Internal)
+	}
+	wf, err = s.redactWorkflow(ctx, wf)
+	if err != nil {
+		return nil, err
+	}
+	return util.GetWorkflowOutputs(wf), nil
+}
+
+func (s *workflowServer) GetWorkflowArtifactGC(ctx context.Context, req *workflowpkg.WorkflowArtifactGCRequest) (*wfv1.ArtifactGCReport, error) {
+	wfClient := auth.GetWfClient(ctx)
+	wf, err := s.getWorkflow(ctx, wfClient, req.Namespace, req.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, sutils.ToStatusError(err, codes.Internal)
+	}
+	err = s.validateWorkflow(wf)
+	if err != nil {
+		return nil, sutils.ToStatusError(err, codes.InvalidArgument)
+	}
+	err = s.hydrator.Hydrate(wf)
+	if err != nil {
+		return nil, sutils.ToStatusError(err, codes.Internal)
+	}
+	tasks, pods, err := s.getArtifactGCTasksAndPods(ctx, wf)
+	if err != nil {
+		return nil, sutils.ToStatusError(err, codes.Internal)
+	}
+	return util.GetArtifactGCReport(wf, tasks, pods), nil
+}
+
+func (s *workflowServer) RetryWorkflowArtifactGC(ctx context.Context, req *workflowpkg.WorkflowArtifactGCRequest) (*wfv1.Workflow, error) {
+	wfClient := auth.GetWfClient(ctx)
+	kubeClient := auth.GetKubeClient(ctx)
+
+	wf, err := s.getWorkflow(ctx, wfClient, req.Namespace, req.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, sutils.ToStatusError(err, codes.Internal)
+	}
+
+	err = s.validateWorkflow(wf)
+	if err != nil {
+		return nil, sutils.ToStatusError(err, codes.InvalidArgument)
+	}
+
+	err = s.hydrator.Hydrate(wf)
+	if err != nil {
+		return nil, sutils.ToStatusError(err, codes.Internal)
+	}
+
+	wf, err = util.FormulateRetryArtifactGC(wf)
+	if err != nil {
+		return nil, sutils.ToStatusError(err, codes.FailedPrecondition)
+	}
+
+	// the controller re-uses existing tasks and pods, so they must be deleted for new ones to be created
+	tasks, pods, err := s.getArtifactGCTasksAndPods(ctx, wf)
+	if err != nil {
+		return nil, sutils.ToStatusError(err, codes.Internal)
+	}
+	for _, task := range tasks {
+		log.WithFields(log.Fields{"artifactGCTaskDeleted": task.Name}).Info("Deleting artifact GC task")
+		err := wfClient.ArgoprojV1alpha1().WorkflowArtifactGCTasks(wf.Namespace).Delete(ctx, task.Name, metav1.DeleteOptions{})
+		if err != nil && !apierr.IsNotFound(err) {
+			return nil, sutils.ToStatusError(err, codes.Internal)
+		}
+	}
+	for _, pod := range pods {
+		log.WithFields(log.Fields{"podDeleted": pod.Name}).Info("Deleting pod")
+		err := kubeClient.CoreV1().Pods(wf.Namespace).Delete(ctx, pod.Name, metav1.DeleteOptions{})
+		if err != nil && !apierr.IsNotFound(err) {
+			return nil, sutils.ToStatusError(err, codes.Internal)
+		}
+	}
+
+	err = s.hydrator.Dehydrate(wf)
+	if err != nil {
+		return nil, sutils.ToStatusError(err, codes.Internal)
+	}
+
+	wf, err = wfClient.ArgoprojV1alpha1().Workflows(req.Namespace).Update(ctx, wf, metav1.UpdateOptions{})
+	if err != nil {
+		return nil, sutils.ToStatusError(err, codes.Internal)
+	}
+
+	return s.redactWorkflow(ctx, wf)
+}
+
+// getArtifactGCTasksAndPods returns the artifact garbage collection tasks and pods the controller created for the workflow
+func (s *workflowServer) getArtifactGCTasksAndPods(ctx context.Context, wf *wfv1.Workflow) ([]wfv1.WorkflowArtifactGCTask, []corev1.Pod, error) {
+	taskList, err := auth.GetWfClient(ctx).ArgoprojV1alpha1().WorkflowArtifactGCTasks(wf.Namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, nil, err
+	}
+	var tasks []wfv1.WorkflowArtifactGCTask
+	for _, task := range taskList.Items {
+		if metav1.IsControlledBy(&task, wf) {
+			tasks = append(tasks, task)
+		}
+	}
+	podList, err := auth.GetKubeClient(ctx).CoreV1().Pods(wf.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s,%s=%s", common.LabelKeyWorkflow, wf.Name, common.LabelKeyComponent, common.ArtifactGCComponent),
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return tasks, podList.Items, nil
+}
+
+// AnnotateWorkflowNode adds a note to a node of a workflow, e.g. so that the context of a manual rerun stays with the run
+func (s *workflowServer) AnnotateWorkflowNode(ctx context.Context, req *workflowpkg.WorkflowNodeAnnotateRequest) (*wfv1.Workflow, error) {
+	wfClient := auth.GetWfClient(ctx)
+	wf, err := wfClient.ArgoprojV1alpha1().Workflows(req.Namespace).Get(ctx, req.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, sutils.ToStatusError(err, codes.Internal)
+	}
+	err = s.validateWorkflow(wf)
+	if err != nil {
+		return nil, sutils.ToStatusError(err, codes.InvalidArgument)
+	}
+	wf, err = util.AnnotateNode(ctx, wfClient.ArgoprojV1alpha1().Workflows(req.Namespace), s.hydrator, req.Name, req.Node, req.Note)
+	if err != nil {
+		return nil, sutils.ToStatusError(err, codes.Internal)
+	}
+	err = s.hydrator.Hydrate(wf)
+	if err != nil {
+		return nil, sutils.ToStatusError(err, codes.Internal)
+	}
+	return s.redactWorkflow(ctx, wf)
 }
 
 func (s *workflowServer) LintWorkflow(ctx context.Context, req *workflowpkg.WorkflowLintRequest) (*wfv1.Workflow, error) {
@@ -648,12 +1157,16 @@ func (s *workflowServer) LintWorkflow(ctx context.Context, req *workflowpkg.Work
 	s.instanceIDService.Label(req.Workflow)
 	creator.Label(ctx, req.Workflow)
 
-	err := validate.ValidateWorkflow(wftmplGetter, cwftmplGetter, req.Workflow, validate.ValidateOpts{Lint: true})
+	err := validate.ValidateWorkflow(wftmplGetter, cwftmplGetter, req.Workflow, validate.ValidateOpts{Lint: true, Guardrails: s.guardrails})
+	if err != nil {
+		return nil, err
+	}
+	err = s.checkArtifactRepository(ctx, wftmplGetter, cwftmplGetter, req.Namespace, req.Workflow)
 	if err != nil {
 		return nil, err
 	}
 
-	return req.Workflow, nil
+	return s.redactWorkflow(ctx, req.Workflow)
 }
 
 func (s *workflowServer) PodLogs(req *workflowpkg.WorkflowLogRequest, ws workflowpkg.WorkflowService_PodLogsServer) error {
@@ -676,7 +1189,16 @@ func (s *workflowServer) PodLogs(req *workflowpkg.WorkflowLogRequest, ws workflo
 		return sutils.ToStatusError(err, codes.Internal)
 	}
 
-	err = logs.WorkflowLogs(ctx, wfClient, kubeClient, req, ws)
+	// a workflow that was deleted once it was archived only has archived logs
+	if req.Archived && s.archivedLogs != nil {
+		_, err := wfClient.ArgoprojV1alpha1().Workflows(req.Namespace).Get(ctx, wf.Name, metav1.GetOptions{})
+		if apierr.IsNotFound(err) {
+			err = logs.ArchivedWorkflowLogs(ctx, wf, req, nil, s.archivedLogs, ws)
+			return sutils.ToStatusError(err, codes.Internal)
+		}
+	}
+
+	err = logs.WorkflowLogs(ctx, wfClient, kubeClient, s.archivedLogs, req, ws)
 	return sutils.ToStatusError(err, codes.Internal)
 }
 
@@ -684,7 +1206,14 @@ func (s *workflowServer) WorkflowLogs(req *workflowpkg.WorkflowLogRequest, ws wo
 	return sutils.ToStatusError(s.PodLogs(req, ws), codes.Internal)
 }
 
+// getWorkflow reads the workflow directly from Kubernetes, falling back to the archive, as needed before changing it
 func (s *workflowServer) getWorkflow(ctx context.Context, wfClient versioned.Interface, namespace string, name string, options metav1.GetOptions) (*wfv1.Workflow, error) {
+	return s.lookupWorkflow(ctx, wfClient, namespace, name, func() (*wfv1.Workflow, error) {
+		return wfClient.ArgoprojV1alpha1().Workflows(namespace).Get(ctx, name, options)
+	})
+}
+
+func (s *workflowServer) lookupWorkflow(ctx context.Context, wfClient versioned.Interface, namespace string, name string, get func() (*wfv1.Workflow, error)) (*wfv1.Workflow, error) {
 	if name == latestAlias {
 		latest, err := getLatestWorkflow(ctx, wfClient, namespace)
 		if err != nil {
@@ -694,7 +1223,7 @@ func (s *workflowServer) getWorkflow(ctx context.Context, wfClient versioned.Int
 		return latest, nil
 	}
 	var err error
-	wf, origErr := wfClient.ArgoprojV1alpha1().Workflows(namespace).Get(ctx, name, options)
+	wf, origErr := get()
 	if wf == nil || origErr != nil {
 		wf, err = s.wfArchiveServer.GetArchivedWorkflow(ctx, &workflowarchivepkg.GetArchivedWorkflowRequest{
 			Namespace: namespace,
@@ -713,6 +1242,16 @@ func (s *workflowServer) validateWorkflow(wf *wfv1.Workflow) error {
 	return sutils.ToStatusError(s.instanceIDService.Validate(wf), codes.InvalidArgument)
 }
 
+// redactWorkflow redacts the workflow returned to the user, unless they can get the secrets of its namespace, see
+// redaction.Redactor
+func (s *workflowServer) redactWorkflow(ctx context.Context, wf *wfv1.Workflow) (*wfv1.Workflow, error) {
+	wf, err := s.redactor.RedactWorkflow(ctx, wf)
+	if err != nil {
+		return nil, sutils.ToStatusError(err, codes.Internal)
+	}
+	return wf, nil
+}
+
 func getLatestWorkflow(ctx context.Context, wfClient versioned.Interface, namespace string) (*wfv1.Workflow, error) {
 	wfList, err := wfClient.ArgoprojV1alpha1().Workflows(namespace).List(ctx, metav1.ListOptions{})
 	if err != nil {
@@ -760,13 +1299,29 @@ func (s *workflowServer) SubmitWorkflow(ctx context.Context, req *workflowpkg.Wo
 	wftmplGetter := templateresolution.WrapWorkflowTemplateInterface(wfClient.ArgoprojV1alpha1().WorkflowTemplates(req.Namespace))
 	cwftmplGetter := templateresolution.WrapClusterWorkflowTemplateInterface(wfClient.ArgoprojV1alpha1().ClusterWorkflowTemplates())
 
-	err = validate.ValidateWorkflow(wftmplGetter, cwftmplGetter, wf, validate.ValidateOpts{Submit: true})
+	err = validate.ValidateWorkflow(wftmplGetter, cwftmplGetter, wf, validate.ValidateOpts{Submit: true, Guardrails: s.guardrails})
+	if err != nil {
+		return nil, sutils.ToStatusError(err, codes.InvalidArgument)
+	}
+	err = s.checkDeprecatedParameters(ctx, wftmplGetter, cwftmplGetter, wf)
 	if err != nil {
 		return nil, sutils.ToStatusError(err, codes.InvalidArgument)
 	}
+	err = s.resolveWorkflowOutputsParameters(ctx, wfClient, wftmplGetter, cwftmplGetter, req.Namespace, wf)
+	if err != nil {
+		return nil, sutils.ToStatusError(err, codes.InvalidArgument)
+	}
+	err = s.checkArtifactRepository(ctx, wftmplGetter, cwftmplGetter, req.Namespace, wf)
+	if err != nil {
+		return nil, sutils.ToStatusError(err, codes.FailedPrecondition)
+	}
 	wf, err = wfClient.ArgoprojV1alpha1().Workflows(req.Namespace).Create(ctx, wf, metav1.CreateOptions{})
 	if err != nil {
 		return nil, sutils.ToStatusError(err, codes.InvalidArgument)
 	}
-	return wf, nil
+	wf, err = s.signCreator(ctx, wfClient, wf)
+	if err != nil {
+		return nil, sutils.ToStatusError(err, codes.Internal)
+	}
+	return s.redactWorkflow(ctx, wf)
 }
@@ -2,16 +2,23 @@ package workflow
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	"sort"
 
+	"syscall"
 	"testing"
 	"time"
 
 	"github.com/go-jose/go-jose/v3/jwt"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	authorizationv1 "k8s.io/api/authorization/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -19,6 +26,8 @@ import (
 	"k8s.io/client-go/kubernetes/fake"
 	ktesting "k8s.io/client-go/testing"
 
+	"github.com/argoproj/argo-workflows/v3/config"
+	argoerrs "github.com/argoproj/argo-workflows/v3/errors"
 	"github.com/argoproj/argo-workflows/v3/persist/sqldb"
 	"github.com/argoproj/argo-workflows/v3/persist/sqldb/mocks"
 	workflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
@@ -27,10 +36,17 @@ import (
 	v1alpha "github.com/argoproj/argo-workflows/v3/pkg/client/clientset/versioned/fake"
 	"github.com/argoproj/argo-workflows/v3/server/auth"
 	"github.com/argoproj/argo-workflows/v3/server/auth/types"
+	"github.com/argoproj/argo-workflows/v3/server/clusters"
+	"github.com/argoproj/argo-workflows/v3/server/redaction"
+	"github.com/argoproj/argo-workflows/v3/server/workflow/store"
 	"github.com/argoproj/argo-workflows/v3/server/workflowarchive"
 	"github.com/argoproj/argo-workflows/v3/util"
+	grpcutil "github.com/argoproj/argo-workflows/v3/util/grpc"
 	"github.com/argoproj/argo-workflows/v3/util/instanceid"
 	"github.com/argoproj/argo-workflows/v3/workflow/common"
+	"github.com/argoproj/argo-workflows/v3/workflow/creator"
+	"github.com/argoproj/argo-workflows/v3/workflow/signal"
+	wfutil "github.com/argoproj/argo-workflows/v3/workflow/util"
 )
 
 const unlabelled = `{
@@ -592,7 +608,7 @@ func getWorkflowServer() (workflowpkg.WorkflowServiceServer, context.Context) {
 
 	archivedRepo := &mocks.WorkflowArchive{}
 
-	wfaServer := workflowarchive.NewWorkflowArchiveServer(archivedRepo)
+	wfaServer := workflowarchive.NewWorkflowArchiveServer(archivedRepo, nil)
 	archivedRepo.On("GetWorkflow", "", "test", "hello-world-9tql2-test").Return(&v1alpha1.Workflow{
 		ObjectMeta: metav1.ObjectMeta{Name: "hello-world-9tql2-test", Namespace: "test"},
 		Spec: v1alpha1.WorkflowSpec{
@@ -606,8 +622,17 @@ func getWorkflowServer() (workflowpkg.WorkflowServiceServer, context.Context) {
 	archivedRepo.On("GetWorkflow", "", "test", "unlabelled").Return(nil, nil)
 	archivedRepo.On("GetWorkflow", "", "workflows", "latest").Return(nil, nil)
 	archivedRepo.On("GetWorkflow", "", "workflows", "hello-world-9tql2-not").Return(nil, nil)
-	server := NewWorkflowServer(instanceid.NewService("my-instanceid"), offloadNodeStatusRepo, wfaServer)
+	// callers allowed to list workflows also list the archive, which is unavailable, so that only live workflows are listed
+	archivedRepo.On("ListWorkflows", "workflows", "", "", time.Time{}, time.Time{}, mock.Anything, 0, 0).Return(v1alpha1.Workflows{}, fmt.Errorf("archive unavailable"))
+	remoteWfClientset := v1alpha.NewSimpleClientset(&v1alpha1.Workflow{
+		ObjectMeta: metav1.ObjectMeta{Name: "remote-wf", Namespace: "workflows", Labels: map[string]string{common.LabelKeyControllerInstanceID: "my-instanceid"}},
+	})
+	clusterRegistry := clusters.NewStaticRegistry("local", map[string]versioned.Interface{"east": remoteWfClientset})
+	server := NewWorkflowServer(instanceid.NewService("my-instanceid"), offloadNodeStatusRepo, wfaServer, clusterRegistry, store.NewKubeRegistry(), nil, "", time.Hour, wfutil.ApprovalOpts{}, nil, nil, nil)
 	kubeClientSet := fake.NewSimpleClientset()
+	kubeClientSet.PrependReactor("create", "selfsubjectaccessreviews", func(action ktesting.Action) (bool, runtime.Object, error) {
+		return true, &authorizationv1.SelfSubjectAccessReview{}, nil
+	})
 	wfClientset := v1alpha.NewSimpleClientset(&unlabelledObj, &wfObj1, &wfObj2, &wfObj3, &wfObj4, &wfObj5, &failedWfObj, &wftmpl, &cronwfObj, &cwfTmpl)
 	wfClientset.PrependReactor("create", "workflows", generateNameReactor)
 	ctx := context.WithValue(context.WithValue(context.WithValue(context.TODO(), auth.WfKey, wfClientset), auth.KubeKey, kubeClientSet), auth.ClaimsKey, &types.Claims{Claims: jwt.Claims{Subject: "my-sub"}})
@@ -644,6 +669,64 @@ func TestCreateWorkflow(t *testing.T) {
 	}
 }
 
+func TestIdempotencyKey(t *testing.T) {
+	server, ctx := getWorkflowServer()
+	// the fake client does not set the creation timestamp
+	auth.GetWfClient(ctx).(*v1alpha.Clientset).PrependReactor("create", "workflows", func(action ktesting.Action) (bool, runtime.Object, error) {
+		action.(ktesting.CreateAction).GetObject().(*v1alpha1.Workflow).CreationTimestamp = metav1.Now()
+		return false, nil, nil
+	})
+	withKey := func(key string) context.Context {
+		return metadata.NewIncomingContext(ctx, metadata.Pairs(workflowpkg.IdempotencyKeyHeader, key))
+	}
+	create := func(key string) *v1alpha1.Workflow {
+		var req workflowpkg.WorkflowCreateRequest
+		v1alpha1.MustUnmarshal(workflow1, &req)
+		wf, err := server.CreateWorkflow(withKey(key), &req)
+		require.NoError(t, err)
+		return wf
+	}
+	t.Run("Create", func(t *testing.T) {
+		wf := create("create")
+		assert.Contains(t, wf.Labels, common.LabelKeyIdempotencyKey)
+		assert.Contains(t, wf.Annotations, common.AnnotationKeyIdempotencyRequestHash)
+		// the name is derived from the key, so the API server rejects a duplicate even if the requests are concurrent
+		assert.Equal(t, "hello-world-"+idempotentNameSuffix(wf.Labels[common.LabelKeyIdempotencyKey]), wf.Name)
+		assert.Equal(t, wf.Name, create("create").Name)
+		assert.NotEqual(t, wf.Name, create("other").Name)
+	})
+	t.Run("CreateOtherRequest", func(t *testing.T) {
+		create("reused")
+		var req workflowpkg.WorkflowCreateRequest
+		v1alpha1.MustUnmarshal(workflow1, &req)
+		req.Workflow.Annotations = map[string]string{"other": "request"}
+		_, err := server.CreateWorkflow(withKey("reused"), &req)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.Equal(t, argoerrs.CodeIdempotencyKeyReused, grpcutil.ErrorCode(err))
+	})
+	t.Run("Resubmit", func(t *testing.T) {
+		req := &workflowpkg.WorkflowResubmitRequest{Name: "hello-world-9tql2", Namespace: "workflows"}
+		wf, err := server.ResubmitWorkflow(withKey("resubmit"), req)
+		require.NoError(t, err)
+		replayed, err := server.ResubmitWorkflow(withKey("resubmit"), req)
+		require.NoError(t, err)
+		assert.Equal(t, wf.Name, replayed.Name)
+		_, err = server.ResubmitWorkflow(withKey("resubmit"), &workflowpkg.WorkflowResubmitRequest{Name: "hello-world-9tql2", Namespace: "workflows", Parameters: []string{"message=other"}})
+		assert.Equal(t, argoerrs.CodeIdempotencyKeyReused, grpcutil.ErrorCode(err))
+	})
+	t.Run("Retry", func(t *testing.T) {
+		req := &workflowpkg.WorkflowRetryRequest{Name: "failed", Namespace: "workflows"}
+		wf, err := server.RetryWorkflow(withKey("retry"), req)
+		require.NoError(t, err)
+		assert.Contains(t, wf.Annotations, common.AnnotationKeyRetriedAt)
+		_, err = server.RetryWorkflow(withKey("retry"), req)
+		require.NoError(t, err)
+		// the workflow is running, so it cannot be retried with another key
+		_, err = server.RetryWorkflow(withKey("other"), req)
+		assert.Error(t, err)
+	})
+}
+
 type testWatchWorkflowServer struct {
 	testServerStream
 }
@@ -798,6 +881,89 @@ func TestListWorkflow(t *testing.T) {
 	}
 }
 
+type archiveStoreRegistry struct{ store.WorkflowStore }
+
+func (r archiveStoreRegistry) For(string) store.WorkflowStore { return r.WorkflowStore }
+
+func TestListWorkflowFromArchiveStore(t *testing.T) {
+	archivedRepo := &mocks.WorkflowArchive{}
+	archivedRepo.On("ListWorkflows", "workflows", "", "", time.Time{}, time.Time{}, mock.Anything, 3, 4).Return(v1alpha1.Workflows{
+		{ObjectMeta: metav1.ObjectMeta{Name: "my-wf", Namespace: "workflows"}},
+	}, nil)
+	wfaServer := workflowarchive.NewWorkflowArchiveServer(archivedRepo, nil)
+	offloadNodeStatusRepo := &mocks.OffloadNodeStatusRepo{}
+	offloadNodeStatusRepo.On("IsEnabled", mock.Anything).Return(false)
+	server := NewWorkflowServer(instanceid.NewService(""), offloadNodeStatusRepo, wfaServer, clusters.NullRegistry, archiveStoreRegistry{store.NewArchiveStore(wfaServer)}, nil, "", time.Hour, wfutil.ApprovalOpts{}, nil, nil, nil)
+	kubeClientSet := fake.NewSimpleClientset()
+	kubeClientSet.PrependReactor("create", "selfsubjectaccessreviews", func(action ktesting.Action) (bool, runtime.Object, error) {
+		return true, &authorizationv1.SelfSubjectAccessReview{Status: authorizationv1.SubjectAccessReviewStatus{Allowed: true}}, nil
+	})
+	ctx := context.WithValue(context.TODO(), auth.KubeKey, kubeClientSet)
+
+	// the limit and offset are applied by the archive query, which loads one more record to know if there is a next page
+	wfl, err := server.ListWorkflows(ctx, &workflowpkg.WorkflowListRequest{Namespace: "workflows", ListOptions: &metav1.ListOptions{Limit: 2, Continue: "4"}})
+	if assert.NoError(t, err) {
+		assert.Len(t, wfl.Items, 1)
+	}
+	archivedRepo.AssertExpectations(t)
+
+	_, err = server.ListWorkflows(ctx, &workflowpkg.WorkflowListRequest{Namespace: "workflows", ListOptions: &metav1.ListOptions{Continue: "not-an-offset"}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+// withListAllowed returns a context in which the caller is allowed to list workflows, and nothing else
+func withListAllowed(ctx context.Context) context.Context {
+	kubeClientSet := fake.NewSimpleClientset()
+	kubeClientSet.PrependReactor("create", "selfsubjectaccessreviews", func(action ktesting.Action) (bool, runtime.Object, error) {
+		attrs := action.(ktesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectAccessReview).Spec.ResourceAttributes
+		allowed := attrs != nil && attrs.Verb == "list" && attrs.Resource == "workflows"
+		return true, &authorizationv1.SelfSubjectAccessReview{Status: authorizationv1.SubjectAccessReviewStatus{Allowed: allowed}}, nil
+	})
+	return context.WithValue(ctx, auth.KubeKey, kubeClientSet)
+}
+
+func TestListWorkflowAllClusters(t *testing.T) {
+	server, deniedCtx := getWorkflowServer()
+	ctx := withListAllowed(deniedCtx)
+	t.Run("AllClusters", func(t *testing.T) {
+		wfl, err := server.ListWorkflows(ctx, &workflowpkg.WorkflowListRequest{Namespace: "workflows", AllClusters: true})
+		if assert.NoError(t, err) {
+			assert.Len(t, wfl.Items, 5)
+			clusterCounts := map[string]int{}
+			for _, wf := range wfl.Items {
+				clusterCounts[wf.Annotations[common.AnnotationKeyCluster]]++
+			}
+			assert.Equal(t, map[string]int{"local": 4, "east": 1}, clusterCounts)
+		}
+	})
+	t.Run("Pagination", func(t *testing.T) {
+		_, err := server.ListWorkflows(ctx, &workflowpkg.WorkflowListRequest{Namespace: "workflows", AllClusters: true, ListOptions: &metav1.ListOptions{Limit: 1}})
+		assert.Error(t, err)
+	})
+	t.Run("GetRemote", func(t *testing.T) {
+		wf, err := server.GetWorkflow(ctx, &workflowpkg.WorkflowGetRequest{Namespace: "workflows", Name: "remote-wf", Cluster: "east"})
+		if assert.NoError(t, err) {
+			assert.Equal(t, "east", wf.Annotations[common.AnnotationKeyCluster])
+		}
+	})
+	t.Run("GetRemoteWithoutUser", func(t *testing.T) {
+		_, err := server.GetWorkflow(context.WithValue(ctx, auth.ClaimsKey, nil), &workflowpkg.WorkflowGetRequest{Namespace: "workflows", Name: "remote-wf", Cluster: "east"})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+		_, err = server.ListWorkflows(context.WithValue(ctx, auth.ClaimsKey, nil), &workflowpkg.WorkflowListRequest{Namespace: "workflows", AllClusters: true})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+	t.Run("GetUnknownCluster", func(t *testing.T) {
+		_, err := server.GetWorkflow(ctx, &workflowpkg.WorkflowGetRequest{Namespace: "workflows", Name: "remote-wf", Cluster: "west"})
+		assert.Error(t, err)
+	})
+	t.Run("GetRemoteNotAllowed", func(t *testing.T) {
+		_, err := server.GetWorkflow(deniedCtx, &workflowpkg.WorkflowGetRequest{Namespace: "workflows", Name: "remote-wf", Cluster: "east"})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+		_, err = server.ListWorkflows(deniedCtx, &workflowpkg.WorkflowListRequest{Namespace: "workflows", AllClusters: true})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+}
+
 func TestDeleteWorkflow(t *testing.T) {
 	server, ctx := getWorkflowServer()
 	t.Run("Labelled", func(t *testing.T) {
@@ -867,6 +1033,50 @@ func TestSuspendResumeWorkflowWithNotFound(t *testing.T) {
 	assert.NotNil(t, err)
 }
 
+func TestResumeWorkflowWithApprovals(t *testing.T) {
+	server, ctx := getWorkflowServer()
+	wfClient := auth.GetWfClient(ctx)
+	wf, err := wfClient.ArgoprojV1alpha1().Workflows("workflows").Get(ctx, "hello-world-9tql2-run", metav1.GetOptions{})
+	require.NoError(t, err)
+	wf.Status.Nodes["approve"] = v1alpha1.NodeStatus{ID: "approve", Name: "approve", DisplayName: "approve", Type: v1alpha1.NodeTypeSuspend, Phase: v1alpha1.NodeRunning, Approval: &v1alpha1.ApprovalStatus{Required: 1}}
+	_, err = wfClient.ArgoprojV1alpha1().Workflows("workflows").Update(ctx, wf, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	req := &workflowpkg.WorkflowResumeRequest{Name: "hello-world-9tql2-run", Namespace: "workflows", NodeFieldSelector: "displayName=approve"}
+
+	_, err = server.ResumeWorkflow(ctx, req)
+	assert.ErrorContains(t, err, "the creator of the workflow is not known, so node approve cannot be approved")
+
+	server.(*workflowServer).approvals = wfutil.ApprovalOpts{AllowUnknownCreator: true}
+	wf, err = server.ResumeWorkflow(ctx, req)
+	if assert.NoError(t, err) {
+		assert.Equal(t, v1alpha1.NodeSucceeded, wf.Status.Nodes["approve"].Phase)
+	}
+}
+
+func TestCreateWorkflowSignsCreator(t *testing.T) {
+	server, ctx := getWorkflowServer()
+	key := []byte("my-key")
+	server.(*workflowServer).approvals = wfutil.ApprovalOpts{CreatorKey: key}
+	// the fake client does not set the UID
+	auth.GetWfClient(ctx).(*v1alpha.Clientset).PrependReactor("create", "workflows", func(action ktesting.Action) (bool, runtime.Object, error) {
+		action.(ktesting.CreateAction).GetObject().(*v1alpha1.Workflow).UID = "my-uid"
+		return false, nil, nil
+	})
+	var req workflowpkg.WorkflowCreateRequest
+	v1alpha1.MustUnmarshal(workflow1, &req)
+	// a creator the user supplied is not trusted
+	req.Workflow.Annotations = map[string]string{common.AnnotationKeyCreatorSubject: "other-sub", common.AnnotationKeyCreatorSignature: "forged"}
+	wf, err := server.CreateWorkflow(ctx, &req)
+	require.NoError(t, err)
+	assert.Equal(t, "my-sub", creator.VerifiedSubject(wf, key))
+
+	wf.Status.Nodes = v1alpha1.Nodes{"approve": v1alpha1.NodeStatus{ID: "approve", Name: "approve", DisplayName: "approve", Type: v1alpha1.NodeTypeSuspend, Phase: v1alpha1.NodeRunning, Approval: &v1alpha1.ApprovalStatus{Required: 1}}}
+	_, err = auth.GetWfClient(ctx).ArgoprojV1alpha1().Workflows(wf.Namespace).Update(ctx, wf, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	_, err = server.ResumeWorkflow(ctx, &workflowpkg.WorkflowResumeRequest{Name: wf.Name, Namespace: wf.Namespace, NodeFieldSelector: "displayName=approve"})
+	assert.ErrorContains(t, err, "my-sub submitted the workflow and cannot approve node approve")
+}
+
 func TestTerminateWorkflow(t *testing.T) {
 	server, ctx := getWorkflowServer()
 
@@ -902,6 +1112,87 @@ func TestStopWorkflow(t *testing.T) {
 	}
 }
 
+func TestSignalWorkflow(t *testing.T) {
+	server, ctx := getWorkflowServer()
+	t.Run("UnsupportedSignal", func(t *testing.T) {
+		_, err := server.SignalWorkflow(ctx, &workflowpkg.WorkflowSignalRequest{Name: "hello-world-9tql2", Namespace: "workflows", Node: "hello-world-9tql2", Signal: "SIGKILL"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+	t.Run("NodeNotFound", func(t *testing.T) {
+		_, err := server.SignalWorkflow(ctx, &workflowpkg.WorkflowSignalRequest{Name: "hello-world-9tql2", Namespace: "workflows", Node: "not-found", Signal: "SIGUSR1"})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+	t.Run("NodeNotRunning", func(t *testing.T) {
+		_, err := server.SignalWorkflow(ctx, &workflowpkg.WorkflowSignalRequest{Name: "hello-world-9tql2", Namespace: "workflows", Node: "hello-world-9tql2", Signal: "SIGUSR1"})
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+	t.Run("Signalled", func(t *testing.T) {
+		wf, err := server.SignalWorkflow(ctx, &workflowpkg.WorkflowSignalRequest{Name: "hello-world-9tql2-run", Namespace: "workflows", Node: "hello-world-9tql2-run", Signal: "SIGUSR1"})
+		require.NoError(t, err)
+		requests, err := signal.Requests(wf.Annotations)
+		require.NoError(t, err)
+		s, err := signal.ParseRequest(requests["hello-world-9tql2"])
+		require.NoError(t, err)
+		assert.Equal(t, syscall.Signal(10), s)
+	})
+}
+
+func TestGetWorkflowDataflow(t *testing.T) {
+	server, ctx := getWorkflowServer()
+	dataflow, err := server.GetWorkflowDataflow(ctx, &workflowpkg.WorkflowDataflowRequest{Name: "hello-world-9tql2", Namespace: "workflows"})
+	if assert.NoError(t, err) {
+		assert.Empty(t, dataflow.Edges)
+	}
+}
+
+func TestGetWorkflowOutputs(t *testing.T) {
+	server, ctx := getWorkflowServer()
+	outputs, err := server.GetWorkflowOutputs(ctx, &workflowpkg.WorkflowOutputsRequest{Name: "hello-world-9tql2", Namespace: "workflows"})
+	if assert.NoError(t, err) {
+		assert.Empty(t, outputs.Outputs)
+	}
+}
+
+func TestGetWorkflowArtifactGC(t *testing.T) {
+	server, ctx := getWorkflowServer()
+	report, err := server.GetWorkflowArtifactGC(ctx, &workflowpkg.WorkflowArtifactGCRequest{Name: "hello-world-9tql2", Namespace: "workflows"})
+	if assert.NoError(t, err) {
+		assert.Empty(t, report.Candidates)
+		assert.Empty(t, report.Failures)
+	}
+}
+
+func TestRetryWorkflowArtifactGC(t *testing.T) {
+	server, ctx := getWorkflowServer()
+	_, err := server.RetryWorkflowArtifactGC(ctx, &workflowpkg.WorkflowArtifactGCRequest{Name: "hello-world-9tql2", Namespace: "workflows"})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
+
+func TestAnnotateWorkflowNode(t *testing.T) {
+	server, ctx := getWorkflowServer()
+	t.Run("EmptyNote", func(t *testing.T) {
+		_, err := server.AnnotateWorkflowNode(ctx, &workflowpkg.WorkflowNodeAnnotateRequest{Name: "hello-world-9tql2", Namespace: "workflows", Node: "hello-world-9tql2"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+	t.Run("NodeNotFound", func(t *testing.T) {
+		_, err := server.AnnotateWorkflowNode(ctx, &workflowpkg.WorkflowNodeAnnotateRequest{Name: "hello-world-9tql2", Namespace: "workflows", Node: "not-found", Note: "my note"})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+	t.Run("WorkflowNotFound", func(t *testing.T) {
+		_, err := server.AnnotateWorkflowNode(ctx, &workflowpkg.WorkflowNodeAnnotateRequest{Name: "not-found", Namespace: "workflows", Node: "not-found", Note: "my note"})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+	t.Run("Annotated", func(t *testing.T) {
+		wf, err := server.AnnotateWorkflowNode(ctx, &workflowpkg.WorkflowNodeAnnotateRequest{Name: "hello-world-9tql2", Namespace: "workflows", Node: "hello-world-9tql2", Note: "my note"})
+		if assert.NoError(t, err) {
+			notes := wf.Status.Nodes["hello-world-9tql2"].Notes
+			if assert.Len(t, notes, 1) {
+				assert.Equal(t, "my note", notes[0].Note)
+			}
+		}
+	})
+}
+
 func TestResubmitWorkflow(t *testing.T) {
 	server, ctx := getWorkflowServer()
 	t.Run("Labelled", func(t *testing.T) {
@@ -934,6 +1225,125 @@ func TestLintWorkflow(t *testing.T) {
 	}
 }
 
+// getRedactingWorkflowServer returns a server that redacts the workflows it returns, as the user of the context cannot
+// get secrets, and adds a secret to the workflow with the name
+func getRedactingWorkflowServer(t *testing.T, namespace, name string) (workflowpkg.WorkflowServiceServer, context.Context) {
+	server, ctx := getWorkflowServer()
+	redactor, err := redaction.New(&config.Redaction{ParameterNamePatterns: []string{"token"}})
+	require.NoError(t, err)
+	server.(*workflowServer).redactor = redactor
+	addSecret(ctx, t, auth.GetWfClient(ctx), namespace, name)
+	return server, ctx
+}
+
+// addSecret adds an argument, environment variables and output parameters with a secret value to the workflow, and an
+// output of the workflow that is taken from the parameter of its entrypoint node
+func addSecret(ctx context.Context, t *testing.T, wfClient versioned.Interface, namespace, name string) {
+	wf, err := wfClient.ArgoprojV1alpha1().Workflows(namespace).Get(ctx, name, metav1.GetOptions{})
+	require.NoError(t, err)
+	wf.Spec.Arguments.Parameters = append(wf.Spec.Arguments.Parameters, v1alpha1.Parameter{Name: "api-token", Value: v1alpha1.AnyStringPtr("my-secret")})
+	for i := range wf.Spec.Templates {
+		if c := wf.Spec.Templates[i].Container; c != nil {
+			c.Env = append(c.Env, corev1.EnvVar{Name: "API_TOKEN", Value: "my-secret"})
+		}
+	}
+	wf.Spec.Outputs = []v1alpha1.WorkflowOutput{{Name: "token", From: "outputs.parameters.api-token"}}
+	for id, node := range wf.Status.Nodes {
+		node.Outputs = &v1alpha1.Outputs{Parameters: []v1alpha1.Parameter{{Name: "api-token", Value: v1alpha1.AnyStringPtr("my-secret")}}}
+		wf.Status.Nodes[id] = node
+	}
+	_, err = wfClient.ArgoprojV1alpha1().Workflows(namespace).Update(ctx, wf, metav1.UpdateOptions{})
+	require.NoError(t, err)
+}
+
+// assertRedacted asserts that the response does not contain the secret of addSecret
+func assertRedacted(t *testing.T, response interface{}, err error) {
+	if assert.NoError(t, err) {
+		data, err := json.Marshal(response)
+		require.NoError(t, err)
+		assert.NotContains(t, string(data), "my-secret")
+		assert.Contains(t, string(data), redaction.Redacted)
+	}
+}
+
+func TestRedaction(t *testing.T) {
+	t.Run("Get", func(t *testing.T) {
+		server, ctx := getRedactingWorkflowServer(t, "workflows", "hello-world-9tql2")
+		wf, err := server.GetWorkflow(ctx, &workflowpkg.WorkflowGetRequest{Name: "hello-world-9tql2", Namespace: "workflows"})
+		assertRedacted(t, wf, err)
+	})
+	t.Run("GetRemote", func(t *testing.T) {
+		server, ctx := getRedactingWorkflowServer(t, "workflows", "hello-world-9tql2")
+		wfClient, err := server.(*workflowServer).clusters.WfClient("east", clusters.User{Name: "my-sub"})
+		require.NoError(t, err)
+		addSecret(ctx, t, wfClient, "workflows", "remote-wf")
+		wf, err := server.GetWorkflow(withListAllowed(ctx), &workflowpkg.WorkflowGetRequest{Name: "remote-wf", Namespace: "workflows", Cluster: "east"})
+		assertRedacted(t, wf, err)
+	})
+	t.Run("Dataflow", func(t *testing.T) {
+		server, ctx := getRedactingWorkflowServer(t, "workflows", "hello-world-9tql2")
+		dataflow, err := server.GetWorkflowDataflow(ctx, &workflowpkg.WorkflowDataflowRequest{Name: "hello-world-9tql2", Namespace: "workflows"})
+		if assert.NoError(t, err) {
+			data, err := json.Marshal(dataflow)
+			require.NoError(t, err)
+			assert.NotContains(t, string(data), "my-secret")
+		}
+	})
+	t.Run("Outputs", func(t *testing.T) {
+		server, ctx := getRedactingWorkflowServer(t, "workflows", "hello-world-9tql2")
+		outputs, err := server.GetWorkflowOutputs(ctx, &workflowpkg.WorkflowOutputsRequest{Name: "hello-world-9tql2", Namespace: "workflows"})
+		if assertRedacted(t, outputs, err); err == nil && assert.Len(t, outputs.Outputs, 1) {
+			assert.Equal(t, redaction.Redacted, outputs.Outputs[0].Value)
+		}
+	})
+	t.Run("Annotate", func(t *testing.T) {
+		server, ctx := getRedactingWorkflowServer(t, "workflows", "hello-world-9tql2")
+		wf, err := server.AnnotateWorkflowNode(ctx, &workflowpkg.WorkflowNodeAnnotateRequest{Name: "hello-world-9tql2", Namespace: "workflows", Node: "hello-world-9tql2", Note: "my note"})
+		assertRedacted(t, wf, err)
+	})
+	t.Run("Retry", func(t *testing.T) {
+		server, ctx := getRedactingWorkflowServer(t, "workflows", "failed")
+		wf, err := server.RetryWorkflow(ctx, &workflowpkg.WorkflowRetryRequest{Name: "failed", Namespace: "workflows"})
+		assertRedacted(t, wf, err)
+	})
+	t.Run("Resubmit", func(t *testing.T) {
+		server, ctx := getRedactingWorkflowServer(t, "workflows", "hello-world-9tql2")
+		wf, err := server.ResubmitWorkflow(ctx, &workflowpkg.WorkflowResubmitRequest{Name: "hello-world-9tql2", Namespace: "workflows"})
+		assertRedacted(t, wf, err)
+	})
+	t.Run("Suspend", func(t *testing.T) {
+		server, ctx := getRedactingWorkflowServer(t, "workflows", "hello-world-9tql2-run")
+		wf, err := server.SuspendWorkflow(ctx, &workflowpkg.WorkflowSuspendRequest{Name: "hello-world-9tql2-run", Namespace: "workflows"})
+		assertRedacted(t, wf, err)
+	})
+	t.Run("Resume", func(t *testing.T) {
+		server, ctx := getRedactingWorkflowServer(t, "workflows", "hello-world-9tql2-run")
+		wf, err := server.ResumeWorkflow(ctx, &workflowpkg.WorkflowResumeRequest{Name: "hello-world-9tql2-run", Namespace: "workflows"})
+		assertRedacted(t, wf, err)
+	})
+	t.Run("Terminate", func(t *testing.T) {
+		server, ctx := getRedactingWorkflowServer(t, "workflows", "hello-world-9tql2-run")
+		wf, err := server.TerminateWorkflow(ctx, &workflowpkg.WorkflowTerminateRequest{Name: "hello-world-9tql2-run", Namespace: "workflows"})
+		assertRedacted(t, wf, err)
+	})
+	t.Run("Stop", func(t *testing.T) {
+		server, ctx := getRedactingWorkflowServer(t, "workflows", "hello-world-9tql2-run")
+		wf, err := server.StopWorkflow(ctx, &workflowpkg.WorkflowStopRequest{Name: "hello-world-9tql2-run", Namespace: "workflows"})
+		assertRedacted(t, wf, err)
+	})
+	t.Run("Set", func(t *testing.T) {
+		server, ctx := getRedactingWorkflowServer(t, "workflows", "hello-world-9tql2-run")
+		wfClient := auth.GetWfClient(ctx)
+		wf, err := wfClient.ArgoprojV1alpha1().Workflows("workflows").Get(ctx, "hello-world-9tql2-run", metav1.GetOptions{})
+		require.NoError(t, err)
+		wf.Status.Nodes["approve"] = v1alpha1.NodeStatus{ID: "approve", Name: "approve", DisplayName: "approve", Type: v1alpha1.NodeTypeSuspend, Phase: v1alpha1.NodeRunning}
+		_, err = wfClient.ArgoprojV1alpha1().Workflows("workflows").Update(ctx, wf, metav1.UpdateOptions{})
+		require.NoError(t, err)
+		wf, err = server.SetWorkflow(ctx, &workflowpkg.WorkflowSetRequest{Name: "hello-world-9tql2-run", Namespace: "workflows", NodeFieldSelector: "displayName=approve", Message: "my message"})
+		assertRedacted(t, wf, err)
+	})
+}
+
 type testPodLogsServer struct {
 	testServerStream
 }
@@ -1009,3 +1419,109 @@ func TestSubmitWorkflowFromResource(t *testing.T) {
 		}
 	})
 }
+
+func TestSubmitWorkflowWithDeprecatedParameters(t *testing.T) {
+	server, ctx := getWorkflowServer()
+	_, err := auth.GetWfClient(ctx).ArgoprojV1alpha1().WorkflowTemplates("workflows").Create(ctx, &v1alpha1.WorkflowTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: "deprecated", Namespace: "workflows"},
+		Spec: v1alpha1.WorkflowSpec{
+			Entrypoint: "main",
+			Arguments: v1alpha1.Arguments{Parameters: []v1alpha1.Parameter{
+				{Name: "msg", Value: v1alpha1.AnyStringPtr(""), Deprecated: &v1alpha1.ParameterDeprecation{ReplacedBy: "message"}},
+				{Name: "message", Value: v1alpha1.AnyStringPtr("")},
+			}},
+			Templates: []v1alpha1.Template{{Name: "main", Container: &corev1.Container{Image: "argoproj/argosay:v2"}}},
+		},
+	}, metav1.CreateOptions{})
+	if !assert.NoError(t, err) {
+		return
+	}
+	submit := func() error {
+		_, err := server.SubmitWorkflow(ctx, &workflowpkg.WorkflowSubmitRequest{
+			Namespace:     "workflows",
+			ResourceKind:  "workflowtemplate",
+			ResourceName:  "deprecated",
+			SubmitOptions: &v1alpha1.SubmitOpts{Parameters: []string{"msg=hello"}},
+		})
+		return err
+	}
+	t.Run("Warn", func(t *testing.T) {
+		assert.NoError(t, submit())
+	})
+	t.Run("Reject", func(t *testing.T) {
+		server.(*workflowServer).deprecatedParameters = config.DeprecatedParametersReject
+		assert.EqualError(t, submit(), `rpc error: code = InvalidArgument desc = workflow uses deprecated parameters: parameter "msg" is deprecated, use "message" instead`)
+	})
+}
+
+func TestSubmitWorkflowWithWorkflowOutputsParameters(t *testing.T) {
+	server, ctx := getWorkflowServer()
+	wfClient := auth.GetWfClient(ctx)
+	now := time.Now()
+	for i, digest := range []string{"sha256:older", "sha256:latest"} {
+		_, err := wfClient.ArgoprojV1alpha1().Workflows("workflows").Create(ctx, &v1alpha1.Workflow{
+			ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("nightly-build-%d", i), Namespace: "workflows", Labels: map[string]string{
+				common.LabelKeyWorkflowTemplate: "nightly-build",
+				common.LabelKeyPhase:            string(v1alpha1.WorkflowSucceeded),
+			}},
+			Status: v1alpha1.WorkflowStatus{
+				Phase:      v1alpha1.WorkflowSucceeded,
+				FinishedAt: metav1.NewTime(now.Add(time.Duration(i) * time.Minute)),
+				Outputs:    &v1alpha1.Outputs{Parameters: []v1alpha1.Parameter{{Name: "imageDigest", Value: v1alpha1.AnyStringPtr(digest)}}},
+			},
+		}, metav1.CreateOptions{})
+		if !assert.NoError(t, err) {
+			return
+		}
+	}
+	valueFrom := func(selector string) *v1alpha1.ValueFrom {
+		return &v1alpha1.ValueFrom{WorkflowOutputs: &v1alpha1.WorkflowOutputsValueFrom{Selector: selector, Output: "imageDigest"}}
+	}
+	_, err := wfClient.ArgoprojV1alpha1().WorkflowTemplates("workflows").Create(ctx, &v1alpha1.WorkflowTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: "deploy", Namespace: "workflows"},
+		Spec: v1alpha1.WorkflowSpec{
+			Entrypoint: "main",
+			Arguments: v1alpha1.Arguments{Parameters: []v1alpha1.Parameter{
+				{Name: "image-digest", ValueFrom: valueFrom(common.LabelKeyWorkflowTemplate + "=nightly-build")},
+			}},
+			Templates: []v1alpha1.Template{{Name: "main", Container: &corev1.Container{Image: "argoproj/argosay:v2"}}},
+		},
+	}, metav1.CreateOptions{})
+	if !assert.NoError(t, err) {
+		return
+	}
+	t.Run("WorkflowTemplate", func(t *testing.T) {
+		wf, err := server.SubmitWorkflow(ctx, &workflowpkg.WorkflowSubmitRequest{Namespace: "workflows", ResourceKind: "workflowtemplate", ResourceName: "deploy"})
+		if assert.NoError(t, err) {
+			assert.Equal(t, []v1alpha1.Parameter{{Name: "image-digest", Value: v1alpha1.AnyStringPtr("sha256:latest")}}, wf.Spec.Arguments.Parameters)
+		}
+	})
+	t.Run("Overridden", func(t *testing.T) {
+		wf, err := server.SubmitWorkflow(ctx, &workflowpkg.WorkflowSubmitRequest{Namespace: "workflows", ResourceKind: "workflowtemplate", ResourceName: "deploy", SubmitOptions: &v1alpha1.SubmitOpts{Parameters: []string{"image-digest=sha256:pinned"}}})
+		if assert.NoError(t, err) {
+			assert.Equal(t, "sha256:pinned", wf.Spec.Arguments.GetParameterByName("image-digest").Value.String())
+		}
+	})
+	create := func(valueFrom *v1alpha1.ValueFrom) (*v1alpha1.Workflow, error) {
+		return server.CreateWorkflow(ctx, &workflowpkg.WorkflowCreateRequest{Namespace: "workflows", Workflow: &v1alpha1.Workflow{
+			ObjectMeta: metav1.ObjectMeta{GenerateName: "deploy-"},
+			Spec: v1alpha1.WorkflowSpec{
+				Entrypoint: "main",
+				Arguments:  v1alpha1.Arguments{Parameters: []v1alpha1.Parameter{{Name: "image-digest", ValueFrom: valueFrom}}},
+				Templates:  []v1alpha1.Template{{Name: "main", Container: &corev1.Container{Image: "argoproj/argosay:v2"}}},
+			},
+		}})
+	}
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := create(valueFrom(common.LabelKeyWorkflowTemplate + "=other"))
+		assert.EqualError(t, err, `rpc error: code = InvalidArgument desc = failed to get the value of parameter "image-digest" from the workflows "workflows.argoproj.io/workflow-template=other": no Succeeded workflows found`)
+	})
+	t.Run("Default", func(t *testing.T) {
+		v := valueFrom(common.LabelKeyWorkflowTemplate + "=other")
+		v.Default = v1alpha1.AnyStringPtr("sha256:default")
+		wf, err := create(v)
+		if assert.NoError(t, err) {
+			assert.Equal(t, []v1alpha1.Parameter{{Name: "image-digest", Value: v1alpha1.AnyStringPtr("sha256:default")}}, wf.Spec.Arguments.Parameters)
+		}
+	})
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/argoproj/argo-workflows/v3/pkg/apis/workflow"
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 	"github.com/argoproj/argo-workflows/v3/server/auth"
+	"github.com/argoproj/argo-workflows/v3/server/redaction"
 	"github.com/argoproj/argo-workflows/v3/workflow/util"
 
 	sutils "github.com/argoproj/argo-workflows/v3/server/utils"
@@ -32,11 +33,12 @@ const disableValueListRetrievalKeyPattern = "DISABLE_VALUE_LIST_RETRIEVAL_KEY_PA
 
 type archivedWorkflowServer struct {
 	wfArchive sqldb.WorkflowArchive
+	redactor  *redaction.Redactor
 }
 
 // NewWorkflowArchiveServer returns a new archivedWorkflowServer
-func NewWorkflowArchiveServer(wfArchive sqldb.WorkflowArchive) workflowarchivepkg.ArchivedWorkflowServiceServer {
-	return &archivedWorkflowServer{wfArchive: wfArchive}
+func NewWorkflowArchiveServer(wfArchive sqldb.WorkflowArchive, redactor *redaction.Redactor) workflowarchivepkg.ArchivedWorkflowServiceServer {
+	return &archivedWorkflowServer{wfArchive: wfArchive, redactor: redactor}
 }
 
 func (w *archivedWorkflowServer) ListArchivedWorkflows(ctx context.Context, req *workflowarchivepkg.ListArchivedWorkflowsRequest) (*wfv1.WorkflowList, error) {
@@ -160,6 +162,10 @@ func (w *archivedWorkflowServer) ListArchivedWorkflows(ctx context.Context, req
 		meta.Continue = fmt.Sprintf("%v", offset+limit)
 	}
 
+	if err := w.redactor.RedactWorkflows(ctx, items); err != nil {
+		return nil, sutils.ToStatusError(err, codes.Internal)
+	}
+
 	sort.Sort(items)
 	return &wfv1.WorkflowList{ListMeta: meta, Items: items}, nil
 }
@@ -180,6 +186,10 @@ func (w *archivedWorkflowServer) GetArchivedWorkflow(ctx context.Context, req *w
 	if !allowed {
 		return nil, status.Error(codes.PermissionDenied, "permission denied")
 	}
+	wf, err = w.redactor.RedactWorkflow(ctx, wf)
+	if err != nil {
+		return nil, sutils.ToStatusError(err, codes.Internal)
+	}
 	return wf, nil
 }
 
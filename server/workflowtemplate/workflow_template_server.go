@@ -15,8 +15,10 @@ import (
 	"github.com/argoproj/argo-workflows/v3/server/auth"
 	sutils "github.com/argoproj/argo-workflows/v3/server/utils"
 	"github.com/argoproj/argo-workflows/v3/util/instanceid"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
 	"github.com/argoproj/argo-workflows/v3/workflow/creator"
 	"github.com/argoproj/argo-workflows/v3/workflow/templateresolution"
+	wfutil "github.com/argoproj/argo-workflows/v3/workflow/util"
 	"github.com/argoproj/argo-workflows/v3/workflow/validate"
 )
 
@@ -201,3 +203,59 @@ func (wts *WorkflowTemplateServer) UpdateWorkflowTemplate(ctx context.Context, r
 	}
 	return res, nil
 }
+
+// getCanary returns the stable workflow template, and its canary
+func (wts *WorkflowTemplateServer) getCanary(ctx context.Context, namespace, name string) (*v1alpha1.WorkflowTemplate, *v1alpha1.WorkflowTemplate, error) {
+	stable, err := wts.getTemplateAndValidate(ctx, namespace, name)
+	if err != nil {
+		return nil, nil, err
+	}
+	wfClient := auth.GetWfClient(ctx)
+	list, err := wfClient.ArgoprojV1alpha1().WorkflowTemplates(namespace).List(ctx, v1.ListOptions{LabelSelector: common.LabelKeyCanaryOf + "=" + name})
+	if err != nil {
+		return nil, nil, sutils.ToStatusError(err, codes.Internal)
+	}
+	canaries := make([]*v1alpha1.WorkflowTemplate, len(list.Items))
+	for i := range list.Items {
+		canaries[i] = &list.Items[i]
+	}
+	canary, err := wfutil.GetCanary(name, canaries)
+	if err != nil {
+		return nil, nil, sutils.ToStatusError(err, codes.FailedPrecondition)
+	}
+	if canary == nil {
+		return nil, nil, sutils.ToStatusError(fmt.Errorf("workflow template %q does not have a canary", name), codes.NotFound)
+	}
+	return stable, canary, nil
+}
+
+func (wts *WorkflowTemplateServer) PromoteWorkflowTemplateCanary(ctx context.Context, req *workflowtemplatepkg.WorkflowTemplateCanaryRequest) (*v1alpha1.WorkflowTemplate, error) {
+	stable, canary, err := wts.getCanary(ctx, req.Namespace, req.Name)
+	if err != nil {
+		return nil, err
+	}
+	wfClient := auth.GetWfClient(ctx)
+	stable.Spec = canary.Spec
+	res, err := wfClient.ArgoprojV1alpha1().WorkflowTemplates(req.Namespace).Update(ctx, stable, v1.UpdateOptions{})
+	if err != nil {
+		return nil, sutils.ToStatusError(err, codes.Internal)
+	}
+	err = wfClient.ArgoprojV1alpha1().WorkflowTemplates(req.Namespace).Delete(ctx, canary.Name, v1.DeleteOptions{})
+	if err != nil {
+		return nil, sutils.ToStatusError(err, codes.Internal)
+	}
+	return res, nil
+}
+
+func (wts *WorkflowTemplateServer) RollbackWorkflowTemplateCanary(ctx context.Context, req *workflowtemplatepkg.WorkflowTemplateCanaryRequest) (*v1alpha1.WorkflowTemplate, error) {
+	stable, canary, err := wts.getCanary(ctx, req.Namespace, req.Name)
+	if err != nil {
+		return nil, err
+	}
+	wfClient := auth.GetWfClient(ctx)
+	err = wfClient.ArgoprojV1alpha1().WorkflowTemplates(req.Namespace).Delete(ctx, canary.Name, v1.DeleteOptions{})
+	if err != nil {
+		return nil, sutils.ToStatusError(err, codes.Internal)
+	}
+	return stable, nil
+}
@@ -281,3 +281,55 @@ func TestWorkflowTemplateServer_UpdateWorkflowTemplate(t *testing.T) {
 		assert.Error(t, err)
 	})
 }
+
+func createCanary(t *testing.T, ctx context.Context) {
+	var canary v1alpha1.WorkflowTemplate
+	v1alpha1.MustUnmarshal(wftStr2, &canary)
+	canary.Name = "workflow-template-whalesay-template2-canary"
+	canary.Labels[common.LabelKeyCanaryOf] = "workflow-template-whalesay-template2"
+	canary.Annotations = map[string]string{common.AnnotationKeyCanaryWeight: "10"}
+	canary.Spec.Templates[0].Container.Image = "alpine:latest"
+	_, err := auth.GetWfClient(ctx).ArgoprojV1alpha1().WorkflowTemplates("default").Create(ctx, &canary, metav1.CreateOptions{})
+	assert.NoError(t, err)
+}
+
+func TestWorkflowTemplateServer_PromoteWorkflowTemplateCanary(t *testing.T) {
+	server, ctx := getWorkflowTemplateServer()
+	req := &workflowtemplatepkg.WorkflowTemplateCanaryRequest{Namespace: "default", Name: "workflow-template-whalesay-template2"}
+	t.Run("NoCanary", func(t *testing.T) {
+		_, err := server.PromoteWorkflowTemplateCanary(ctx, req)
+		assert.Error(t, err)
+	})
+	t.Run("Canary", func(t *testing.T) {
+		createCanary(t, ctx)
+		wftRsp, err := server.PromoteWorkflowTemplateCanary(ctx, req)
+		if assert.NoError(t, err) {
+			assert.Equal(t, "workflow-template-whalesay-template2", wftRsp.Name)
+			assert.Equal(t, "alpine:latest", wftRsp.Spec.Templates[0].Container.Image)
+		}
+		_, err = auth.GetWfClient(ctx).ArgoprojV1alpha1().WorkflowTemplates("default").Get(ctx, "workflow-template-whalesay-template2-canary", metav1.GetOptions{})
+		assert.Error(t, err)
+	})
+	t.Run("Unlabelled", func(t *testing.T) {
+		_, err := server.PromoteWorkflowTemplateCanary(ctx, &workflowtemplatepkg.WorkflowTemplateCanaryRequest{Namespace: "default", Name: "unlabelled"})
+		assert.Error(t, err)
+	})
+}
+
+func TestWorkflowTemplateServer_RollbackWorkflowTemplateCanary(t *testing.T) {
+	server, ctx := getWorkflowTemplateServer()
+	req := &workflowtemplatepkg.WorkflowTemplateCanaryRequest{Namespace: "default", Name: "workflow-template-whalesay-template2"}
+	t.Run("NoCanary", func(t *testing.T) {
+		_, err := server.RollbackWorkflowTemplateCanary(ctx, req)
+		assert.Error(t, err)
+	})
+	t.Run("Canary", func(t *testing.T) {
+		createCanary(t, ctx)
+		wftRsp, err := server.RollbackWorkflowTemplateCanary(ctx, req)
+		if assert.NoError(t, err) {
+			assert.Equal(t, "docker/whalesay", wftRsp.Spec.Templates[0].Container.Image)
+		}
+		_, err = auth.GetWfClient(ctx).ArgoprojV1alpha1().WorkflowTemplates("default").Get(ctx, "workflow-template-whalesay-template2-canary", metav1.GetOptions{})
+		assert.Error(t, err)
+	})
+}
@@ -12,7 +12,7 @@ import {Timestamp} from '../../../shared/components/timestamp';
 import {useCollectEvent} from '../../../shared/components/use-collect-event';
 import {ZeroState} from '../../../shared/components/zero-state';
 import {Context} from '../../../shared/context';
-import {getNextScheduledTime} from '../../../shared/cron';
+import {getNextScheduledTimeOf, getSchedules} from '../../../shared/cron';
 import {Footnote} from '../../../shared/footnote';
 import {historyUrl} from '../../../shared/history';
 import {services} from '../../../shared/services';
@@ -148,9 +148,11 @@ export function CronWorkflowList({match, location, history}: RouteComponentProps
                                         <div className='columns small-3'>{w.metadata.name}</div>
                                         <div className='columns small-2'>{w.metadata.namespace}</div>
                                         <div className='columns small-1'>{w.spec.timezone}</div>
-                                        <div className='columns small-1'>{w.spec.schedule}</div>
+                                        <div className='columns small-1'>{getSchedules(w.spec).join(', ')}</div>
                                         <div className='columns small-2'>
-                                            <PrettySchedule schedule={w.spec.schedule} />
+                                            {getSchedules(w.spec).map(schedule => (
+                                                <PrettySchedule key={schedule} schedule={schedule} />
+                                            ))}
                                         </div>
                                         <div className='columns small-1'>
                                             <Timestamp date={w.metadata.creationTimestamp} />
@@ -159,7 +161,7 @@ export function CronWorkflowList({match, location, history}: RouteComponentProps
                                             {w.spec.suspend ? (
                                                 ''
                                             ) : (
-                                                <Ticker intervalMs={1000}>{() => <Timestamp date={getNextScheduledTime(w.spec.schedule, w.spec.timezone)} />}</Ticker>
+                                                <Ticker intervalMs={1000}>{() => <Timestamp date={getNextScheduledTimeOf(getSchedules(w.spec), w.spec.timezone)} />}</Ticker>
                                             )}
                                         </div>
                                     </Link>
@@ -15,14 +15,23 @@ export const CronWorkflowStatusViewer = ({spec, status}: {spec: CronWorkflowSpec
             <div className='white-box__details'>
                 {[
                     {title: 'Active', value: status.active ? getCronWorkflowActiveWorkflowList(status.active) : <i>No Workflows Active</i>},
-                    {
-                        title: 'Schedule',
-                        value: (
-                            <>
-                                <code>{spec.schedule}</code> <PrettySchedule schedule={spec.schedule} />
-                            </>
-                        )
-                    },
+                    ...(spec.schedules || [{name: '', schedule: spec.schedule}]).map(schedule => {
+                        const scheduleStatus = (status.schedules || []).find(s => s.name === schedule.name);
+                        return {
+                            title: schedule.name ? `Schedule ${schedule.name}` : 'Schedule',
+                            value: (
+                                <>
+                                    <code>{schedule.schedule}</code> <PrettySchedule schedule={schedule.schedule} />
+                                    {scheduleStatus && scheduleStatus.lastScheduledTime && (
+                                        <>
+                                            {' '}
+                                            last scheduled <Timestamp date={scheduleStatus.lastScheduledTime} />
+                                        </>
+                                    )}
+                                </>
+                            )
+                        };
+                    }),
                     {title: 'Last Scheduled Time', value: <Timestamp date={status.lastScheduledTime} />},
                     {title: 'Conditions', value: <ConditionsPanel conditions={status.conditions} />}
                 ].map(attr => (
@@ -1,4 +1,5 @@
 import parser = require('cron-parser');
+import {CronWorkflowSpec} from '../../models';
 
 export function getNextScheduledTime(schedule: string, tz: string): Date {
     let out: Date;
@@ -12,3 +13,16 @@ export function getNextScheduledTime(schedule: string, tz: string): Date {
     }
     return out;
 }
+
+// getSchedules returns the schedule, or each of the named schedules, of the cron workflow
+export function getSchedules(spec: CronWorkflowSpec): string[] {
+    return spec.schedules ? spec.schedules.map(s => s.schedule) : [spec.schedule];
+}
+
+// getNextScheduledTimeOf returns the earliest next scheduled time of the schedules
+export function getNextScheduledTimeOf(schedules: string[], tz: string): Date {
+    return schedules
+        .map(schedule => getNextScheduledTime(schedule, tz))
+        .filter(date => !!date)
+        .reduce((earliest, date) => (!earliest || date < earliest ? date : earliest), undefined);
+}
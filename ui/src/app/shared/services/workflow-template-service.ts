@@ -30,5 +30,19 @@ export const WorkflowTemplateService = {
 
     delete(name: string, namespace: string) {
         return requests.delete(`api/v1/workflow-templates/${namespace}/${name}`);
+    },
+
+    promoteCanary(name: string, namespace: string) {
+        return requests
+            .put(`api/v1/workflow-templates/${namespace}/${name}/canary/promote`)
+            .send({})
+            .then(res => res.body as models.WorkflowTemplate);
+    },
+
+    rollbackCanary(name: string, namespace: string) {
+        return requests
+            .put(`api/v1/workflow-templates/${namespace}/${name}/canary/rollback`)
+            .send({})
+            .then(res => res.body as models.WorkflowTemplate);
     }
 };
@@ -67,6 +67,25 @@ export const WorkflowsService = {
         return requests.get(`api/v1/workflows/${namespace}/${name}`).then(res => res.body as Workflow);
     },
 
+    getDataflow(namespace: string, name: string) {
+        return requests.get(`api/v1/workflows/${namespace}/${name}/dataflow`).then(res => res.body as models.WorkflowDataflow);
+    },
+
+    getOutputs(namespace: string, name: string) {
+        return requests.get(`api/v1/workflows/${namespace}/${name}/outputs`).then(res => res.body as models.WorkflowOutputs);
+    },
+
+    getArtifactGC(namespace: string, name: string) {
+        return requests.get(`api/v1/workflows/${namespace}/${name}/artifact-gc`).then(res => res.body as models.ArtifactGCReport);
+    },
+
+    retryArtifactGC(namespace: string, name: string) {
+        return requests
+            .put(`api/v1/workflows/${namespace}/${name}/artifact-gc/retry`)
+            .send({})
+            .then(res => res.body as Workflow);
+    },
+
     getArchived(namespace: string, uid: string) {
         return requests.get(`api/v1/archived-workflows/${uid}?namespace=${namespace}`).then(res => res.body as models.Workflow);
     },
@@ -1,5 +1,5 @@
 import * as kubernetes from 'argo-ui/src/models/kubernetes';
-import {Condition, WorkflowSpec} from './workflows';
+import {Condition, Outputs, Parameter, WorkflowSpec} from './workflows';
 
 export interface CronWorkflow {
     apiVersion?: string;
@@ -14,7 +14,8 @@ export type ConcurrencyPolicy = 'Allow' | 'Forbid' | 'Replace';
 export interface CronWorkflowSpec {
     workflowSpec: WorkflowSpec;
     workflowMetadata?: kubernetes.ObjectMeta;
-    schedule: string;
+    schedule?: string;
+    schedules?: CronSchedule[];
     concurrencyPolicy?: ConcurrencyPolicy;
     suspend?: boolean;
     startingDeadlineSeconds?: number;
@@ -23,10 +24,24 @@ export interface CronWorkflowSpec {
     timezone?: string;
 }
 
+export interface CronSchedule {
+    name: string;
+    schedule: string;
+    parameters?: Parameter[];
+}
+
 export interface CronWorkflowStatus {
     active: kubernetes.ObjectReference[];
     lastScheduledTime: kubernetes.Time;
     conditions?: Condition[];
+    lastSuccessfulRunOutputs?: Outputs;
+    schedules?: CronScheduleStatus[];
+}
+
+export interface CronScheduleStatus {
+    name: string;
+    active?: kubernetes.ObjectReference[];
+    lastScheduledTime?: kubernetes.Time;
 }
 
 export interface CronWorkflowList {
@@ -30,4 +30,6 @@ export interface GetUserInfoResponse {
     emailVerified?: boolean;
     serviceAccountName?: string;
     serviceAccountNamespace?: string;
+    namespaces?: string[];
+    permissions?: string[];
 }
@@ -131,6 +131,10 @@ export interface Artifact {
      * Path is the container path to the artifact
      */
     path?: string;
+    /**
+     * Paths are glob patterns, relative to path, of the files to include in an output artifact
+     */
+    paths?: string[];
     gcs?: GCSArtifact;
     git?: GitArtifact;
     http?: HTTPArtifact;
@@ -145,6 +149,26 @@ export interface Artifact {
         strategy?: 'OnWorkflowCompletion' | 'OnWorkflowDeletion';
     };
     deleted?: boolean;
+    ifNotPresent?: {
+        checksum?: boolean;
+    };
+    uploadSkipped?: boolean;
+    /**
+     * Mount, for an input artifact, mounts it into the main container with the CSI driver configured for its repository instead of downloading it
+     */
+    mount?: boolean;
+    /**
+     * SizeBytes is the size of the output artifact that was saved, after it was archived
+     */
+    sizeBytes?: number;
+    /**
+     * Checksum is the SHA-256 checksum of the output artifact that was saved, after it was archived
+     */
+    checksum?: string;
+    /**
+     * ObjectMetadata is saved with the objects of an output artifact, as S3 object tags or GCS custom metadata
+     */
+    objectMetadata?: {[key: string]: string};
 }
 
 /**
@@ -211,6 +235,24 @@ export interface Parameter {
      * Description is the parameter description
      */
     description?: string;
+    /**
+     * Deprecated marks the parameter as deprecated, so that the Argo Server warns when workflows are submitted with it
+     */
+    deprecated?: ParameterDeprecation;
+}
+
+/**
+ * ParameterDeprecation describes why a parameter is deprecated and what to use instead
+ */
+export interface ParameterDeprecation {
+    /**
+     * Message explains why the parameter is deprecated
+     */
+    message?: string;
+    /**
+     * ReplacedBy is the name of the parameter to use instead
+     */
+    replacedBy?: string;
 }
 
 /**
@@ -233,6 +275,10 @@ export interface ResourceTemplate {
      * SuccessCondition is a label selector expression which describes the conditions of the k8s resource in which it is acceptable to proceed to the following step
      */
     successCondition?: string;
+    /**
+     * Operator is the operator that runs the resource, which defaults the conditions, streams the logs of its pods and outputs its status
+     */
+    operator?: 'spark' | 'ray' | 'flink';
 }
 
 /**
@@ -485,6 +531,31 @@ export interface Template {
      */
     suspend?: {};
 
+    /**
+     * Manual is a task done outside the cluster, which the workflow waits for someone to complete
+     */
+    manual?: ManualTemplate;
+    /**
+     * ArtifactBandwidth limits the rate at which the executor uploads and downloads this template's artifacts
+     */
+    artifactBandwidth?: ArtifactBandwidth;
+    /**
+     * Critical protects this template's pods from voluntary disruptions, such as node drains, with a pod disruption budget
+     */
+    critical?: boolean;
+    /**
+     * SecurityProfile is the name of a security profile in the controller's ConfigMap which is applied to every container in this template's pod
+     */
+    securityProfile?: string;
+    /**
+     * NUMA configures this template's pod for latency-sensitive work, with exclusive CPUs, huge pages and NUMA topology hints
+     */
+    numa?: NUMA;
+    /**
+     * ArtifactCredentials mounts short-lived credentials, that only give access to the workflow's key prefix in its S3 artifact repository, into the main containers
+     */
+    artifactCredentials?: boolean;
+
     /**
      * Template is the name of the template which is used as the base of this template.
      */
@@ -516,6 +587,28 @@ export interface ValueFrom {
      * Path in the container to retrieve an output parameter value from in container templates
      */
     path?: string;
+    /**
+     * WorkflowOutputs takes the value of a workflow argument from an output of the latest of the workflows a label selector selects, when the workflow is submitted to the Argo Server
+     */
+    workflowOutputs?: WorkflowOutputsValueFrom;
+}
+
+/**
+ * WorkflowOutputsValueFrom takes the value of a parameter from an output of the latest of the workflows selected by a label selector
+ */
+export interface WorkflowOutputsValueFrom {
+    /**
+     * Selector is the label selector of the workflows, e.g. `workflows.argoproj.io/workflow-template=nightly-build`
+     */
+    selector: string;
+    /**
+     * Output is the name of one of the outputs the workflow declares, or of one of its global output parameters
+     */
+    output: string;
+    /**
+     * Latest is the phase of the workflows to take the latest finished of: Succeeded (default), Failed or Error
+     */
+    latest?: WorkflowPhase;
 }
 
 /**
@@ -686,6 +779,107 @@ export interface NodeStatus {
      * Memoization
      */
     memoizationStatus: MemoizationStatus;
+
+    /**
+     * ManualTask holds the instructions, assignees and due date of a manual task node
+     */
+    manualTask?: ManualTaskStatus;
+
+    /**
+     * Paused is true if the branch of a DAG that starts at this node is paused
+     */
+    paused?: boolean;
+
+    /**
+     * FailureClass is why the pod of a failed or errored pod node failed
+     */
+    failureClass?: string;
+
+    /**
+     * Approval holds the approvals of a suspend node that needs approvals to resume
+     */
+    approval?: ApprovalStatus;
+
+    /**
+     * Notes are the notes users annotated the node with, in the order they were added
+     */
+    notes?: NodeNote[];
+}
+
+export interface ManualTemplate {
+    /**
+     * Instructions for whoever completes the task, as markdown
+     */
+    instructions?: string;
+    /**
+     * Assignees are the users or groups expected to complete the task
+     */
+    assignees?: string[];
+    /**
+     * Due is how long after the task starts it should be completed, e.g. "2h"
+     */
+    due?: string;
+}
+
+export interface ArtifactBandwidth {
+    /**
+     * Upload is the maximum rate for saving output artifacts, e.g. "50Mi"
+     */
+    upload?: string;
+    /**
+     * Download is the maximum rate for loading input artifacts, e.g. "50Mi"
+     */
+    download?: string;
+}
+
+export interface HugePages {
+    /**
+     * PageSize is the size of each huge page, "2Mi" or "1Gi"
+     */
+    pageSize: string;
+    /**
+     * Quantity is the amount of huge page memory, e.g. "512Mi"
+     */
+    quantity: string;
+    mountPath?: string;
+}
+
+export interface NUMA {
+    /**
+     * ExclusiveCPUs is the number of whole CPUs to give each of the template's containers, with requests equal to limits
+     */
+    exclusiveCPUs?: number;
+    hugePages?: HugePages[];
+    topologyPolicy?: 'none' | 'best-effort' | 'restricted' | 'single-numa-node';
+    disableCPULoadBalancing?: boolean;
+    disableCPUQuota?: boolean;
+    disableIRQLoadBalancing?: boolean;
+}
+
+export interface ManualTaskStatus {
+    instructions?: string;
+    assignees?: string[];
+    /**
+     * DueAt is when the task should be completed by
+     */
+    dueAt?: kubernetes.Time;
+}
+
+export interface ApprovalStatus {
+    required?: number;
+    /**
+     * Approvals are the approvals given so far, in the order they were given
+     */
+    approvals?: {approver: string; approvedAt: kubernetes.Time}[];
+}
+
+export interface NodeNote {
+    note: string;
+    /**
+     * Author is the subject of the user who added the note, if the Argo Server authenticated them
+     */
+    author?: string;
+    addedAt: kubernetes.Time;
 }
 
 export interface TemplateRef {
@@ -719,6 +913,10 @@ export interface WorkflowStatus {
      * Estimated duration in seconds.
      */
     estimatedDuration?: number;
+    /**
+     * When the workflow is estimated to finish while it runs.
+     */
+    estimatedFinishedAt?: kubernetes.Time;
 
     /**
      * Progress as numerator/denominator.
@@ -763,17 +961,154 @@ export interface WorkflowStatus {
     storedWorkflowTemplateSpec?: WorkflowSpec;
 
     artifactRepositoryRef?: ArtifactRepositoryRefStatus;
+
+    /**
+     * ExitHooksDeadline is when the exit handler and exit hooks must complete by.
+     */
+    exitHooksDeadline?: kubernetes.Time;
 }
 
 export interface Condition {
     type: ConditionType;
     status: ConditionStatus;
+    reason?: string;
     message: string;
+    lastTransitionTime?: kubernetes.Time;
 }
 
-export type ConditionType = 'Completed' | 'SpecWarning' | 'MetricsError' | 'SubmissionError' | 'SpecError' | 'ArtifactGCError';
+export type ConditionType =
+    | 'Completed'
+    | 'PodRunning'
+    | 'SubmissionPending'
+    | 'SpecWarning'
+    | 'MetricsError'
+    | 'SubmissionError'
+    | 'SpecError'
+    | 'ArtifactGCError'
+    | 'SynchronizationWaiting'
+    | 'StoppedByPolicy';
 export type ConditionStatus = 'True' | 'False' | 'Unknown';
 
+/**
+ * DataflowEdge is a parameter or artifact that one node outputs and another node takes as an input
+ */
+export interface DataflowEdge {
+    /**
+     * From is the ID of the node that outputs the parameter or artifact
+     */
+    from: string;
+    /**
+     * To is the ID of the node that takes it as an input
+     */
+    to: string;
+    type: 'parameter' | 'artifact';
+    /**
+     * Output is the name of the output parameter or artifact, "result" for the result of a script, or "exitCode"
+     */
+    output: string;
+    /**
+     * Input is the name of the argument it is passed to the node as
+     */
+    input: string;
+}
+
+/**
+ * WorkflowDataflow is the lineage of a workflow's data: the parameters and artifacts that flow between its nodes
+ */
+export interface WorkflowDataflow {
+    edges?: DataflowEdge[];
+}
+
+/**
+ * ArtifactBundle collects the output artifacts of the workflow's nodes into a single archive when the workflow completes
+ */
+export interface ArtifactBundle {
+    /**
+     * Name of the workflow output artifact the bundle is saved as
+     */
+    name: string;
+    /**
+     * Artifacts are the names of the output artifacts to collect, all output artifacts except logs if empty
+     */
+    artifacts?: string[];
+    /**
+     * Templates are the names of the templates to collect the output artifacts of the nodes of, all templates if empty
+     */
+    templates?: string[];
+}
+
+export type WorkflowOutputType = 'string' | 'number' | 'boolean' | 'json' | 'artifact';
+
+/**
+ * WorkflowOutput declares a named output of the workflow, taken from an output of one of its nodes
+ */
+export interface WorkflowOutput {
+    name: string;
+    description?: string;
+    type?: WorkflowOutputType;
+    /**
+     * From is the output of a step or task of the entrypoint template to take the value from, e.g. `tasks.train.outputs.parameters.accuracy`
+     */
+    from: string;
+}
+
+/**
+ * WorkflowOutputValue is the value of a workflow output
+ */
+export interface WorkflowOutputValue {
+    name: string;
+    description?: string;
+    type: WorkflowOutputType;
+    value?: string;
+    artifact?: Artifact;
+    /**
+     * Message is why the output has no value, or why its value is not of its type
+     */
+    message?: string;
+}
+
+/**
+ * WorkflowOutputs are the values of the outputs a workflow declares
+ */
+export interface WorkflowOutputs {
+    outputs?: WorkflowOutputValue[];
+}
+
+/**
+ * ArtifactGCCandidate is an output artifact that artifact garbage collection will delete
+ */
+export interface ArtifactGCCandidate {
+    nodeID: string;
+    nodeName?: string;
+    artifactName: string;
+    strategy: 'OnWorkflowCompletion' | 'OnWorkflowDeletion';
+    key?: string;
+    sizeBytes?: number;
+    /**
+     * Ready is whether the strategy applies yet, e.g. OnWorkflowCompletion once the workflow has completed
+     */
+    ready?: boolean;
+}
+
+/**
+ * ArtifactGCFailure is a failed attempt to delete an artifact, or a garbage collection pod that failed
+ */
+export interface ArtifactGCFailure {
+    nodeID?: string;
+    artifactName?: string;
+    message: string;
+    taskName?: string;
+    podName?: string;
+}
+
+/**
+ * ArtifactGCReport lists the artifacts artifact garbage collection would delete, and its previous failures
+ */
+export interface ArtifactGCReport {
+    candidates?: ArtifactGCCandidate[];
+    failures?: ArtifactGCFailure[];
+}
+
 /**
  * WorkflowList is list of Workflow resources
  */
@@ -856,6 +1191,27 @@ export interface WorkflowSpec {
      * OnExit is a template reference which is invoked at the end of the workflow, irrespective of the success, failure, or error of the primary workflow.
      */
     onExit?: string;
+    /**
+     * ExitHooksDeadlineSeconds guarantees the exit handler and exit hooks are run even if the workflow is terminated or exceeds activeDeadlineSeconds,
+     * and gives them this many seconds to complete from then.
+     */
+    exitHooksDeadlineSeconds?: number;
+    /**
+     * Outputs declares the outputs of the workflow, so that consumers can get them with `argo outputs`, rather than from the outputs of its nodes
+     */
+    outputs?: WorkflowOutput[];
+    /**
+     * StrictVariables fails validation on any variable reference that cannot be resolved, rather than leaving references it does not recognise as they are
+     */
+    strictVariables?: boolean;
+    /**
+     * ArtifactParallelism limits the number of pods that load input artifacts, or save output artifacts or logs, that can run at the same time in the workflow
+     */
+    artifactParallelism?: number;
+    /**
+     * ArtifactBundle collects the output artifacts of the workflow's nodes into a single archive when the workflow completes, and exposes it as a workflow output artifact
+     */
+    artifactBundle?: ArtifactBundle;
     /**
      * ServiceAccountName is the name of the ServiceAccount to run all pods of the workflow as.
      */
@@ -1031,4 +1387,5 @@ export type ResourceScope = 'local' | 'namespaced' | 'cluster';
 export interface LogEntry {
     content?: string;
     podName?: string;
+    container?: string;
 }
@@ -18,8 +18,9 @@ type flusher interface {
 	Flush() error
 }
 
-// TarGzToWriter tar.gz's the source path to the supplied writer
-func TarGzToWriter(sourcePath string, level int, w io.Writer) error {
+// TarGzToWriter tar.gz's the source path to the supplied writer. If patterns are given, the source path must be a
+// directory, and only the files in it which match one of the patterns are included.
+func TarGzToWriter(sourcePath string, level int, w io.Writer, patterns ...string) error {
 	sourcePath, err := filepath.Abs(sourcePath)
 	if err != nil {
 		return errors.InternalErrorf("getting absolute path: %v", err)
@@ -35,6 +36,9 @@ func TarGzToWriter(sourcePath string, level int, w io.Writer) error {
 	if !sourceFi.Mode().IsRegular() && !sourceFi.IsDir() {
 		return errors.InternalErrorf("%s is not a regular file or directory", sourcePath)
 	}
+	if len(patterns) > 0 && !sourceFi.IsDir() {
+		return errors.InternalErrorf("%s must be a directory to select files from it", sourcePath)
+	}
 	if flush, ok := w.(flusher); ok {
 		defer func() { _ = flush.Flush() }()
 	}
@@ -47,13 +51,14 @@ func TarGzToWriter(sourcePath string, level int, w io.Writer) error {
 	defer util.Close(tw)
 
 	if sourceFi.IsDir() {
-		return tarDir(sourcePath, tw)
+		return tarDir(sourcePath, tw, patterns)
 	}
 	return tarFile(sourcePath, tw)
 }
 
-// ZipToWriter zip the source path to the supplied writer
-func ZipToWriter(sourcePath string, zw *zip.Writer) error {
+// ZipToWriter zip the source path to the supplied writer. If patterns are given, the source path must be a directory,
+// and only the files in it which match one of the patterns are included.
+func ZipToWriter(sourcePath string, zw *zip.Writer, patterns ...string) error {
 	sourcePath, err := filepath.Abs(sourcePath)
 	if err != nil {
 		return errors.InternalErrorf("getting absolute path: %v", err)
@@ -69,14 +74,17 @@ func ZipToWriter(sourcePath string, zw *zip.Writer) error {
 	if !sourceFi.Mode().IsRegular() && !sourceFi.IsDir() {
 		return errors.InternalErrorf("%s is not a regular file or directory", sourcePath)
 	}
+	if len(patterns) > 0 && !sourceFi.IsDir() {
+		return errors.InternalErrorf("%s must be a directory to select files from it", sourcePath)
+	}
 
 	if sourceFi.IsDir() {
-		return zipDir(sourcePath, zw)
+		return zipDir(sourcePath, zw, patterns)
 	}
 	return zipFile(sourcePath, zw)
 }
 
-func tarDir(sourcePath string, tw *tar.Writer) error {
+func tarDir(sourcePath string, tw *tar.Writer, patterns []string) error {
 	baseName := filepath.Base(sourcePath)
 	count := 0
 	err := filepath.Walk(sourcePath, func(fpath string, info os.FileInfo, err error) error {
@@ -88,6 +96,10 @@ func tarDir(sourcePath string, tw *tar.Writer) error {
 		if err != nil {
 			return errors.InternalWrapError(err)
 		}
+		// when selecting files, directories other than the root are created implicitly by their files
+		if len(patterns) > 0 && nameInArchive != "." && (info.IsDir() || !MatchAny(patterns, filepath.ToSlash(nameInArchive))) {
+			return nil
+		}
 		nameInArchive = filepath.ToSlash(filepath.Join(baseName, nameInArchive))
 		log.Debugf("writing %s", nameInArchive)
 		count++
@@ -160,7 +172,7 @@ func tarFile(sourcePath string, tw *tar.Writer) error {
 	return err
 }
 
-func zipDir(sourcePath string, zw *zip.Writer) error {
+func zipDir(sourcePath string, zw *zip.Writer, patterns []string) error {
 	baseName := filepath.Base(sourcePath)
 	count := 0
 	err := filepath.Walk(sourcePath, func(fpath string, info os.FileInfo, err error) error {
@@ -175,6 +187,9 @@ func zipDir(sourcePath string, zw *zip.Writer) error {
 		if err != nil {
 			return errors.InternalWrapError(err)
 		}
+		if len(patterns) > 0 && !MatchAny(patterns, filepath.ToSlash(nameInArchive)) {
+			return nil
+		}
 		nameInArchive = filepath.Join(baseName, nameInArchive)
 		log.Infof("writing %s", nameInArchive)
 		count++
@@ -1,11 +1,14 @@
 package archive
 
 import (
+	"archive/tar"
 	"archive/zip"
 	"bufio"
+	"bytes"
 	"compress/gzip"
 	"crypto/rand"
 	"encoding/hex"
+	"io"
 	"os"
 	"path/filepath"
 	"testing"
@@ -87,6 +90,42 @@ func TestTarDirectory(t *testing.T) {
 	}
 }
 
+func TestTarDirectoryWithPatterns(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "src")
+	for _, name := range []string{"d.yaml", "a/b.yaml", "a/c.txt", "e/f/g.yaml", "e/h.yml"} {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0o700))
+		assert.NoError(t, os.WriteFile(path, []byte(name), 0o600))
+	}
+
+	var buf bytes.Buffer
+	err := TarGzToWriter(dir, gzip.DefaultCompression, &buf, "**/*.yaml")
+	assert.NoError(t, err)
+
+	gzr, err := gzip.NewReader(&buf)
+	assert.NoError(t, err)
+	tr := tar.NewReader(gzr)
+	var names []string
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if !assert.NoError(t, err) {
+			break
+		}
+		names = append(names, header.Name)
+	}
+	// directories other than the root are created implicitly by the files that match
+	assert.ElementsMatch(t, []string{"src", "src/d.yaml", "src/a/b.yaml", "src/e/f/g.yaml"}, names)
+	assert.NotContains(t, names, "src/a/c.txt")
+	assert.NotContains(t, names, "src/e/h.yml")
+	assert.NotContains(t, names, "src/a")
+
+	err = TarGzToWriter(filepath.Join(dir, "d.yaml"), gzip.DefaultCompression, io.Discard, "*.yaml")
+	assert.Error(t, err)
+}
+
 func TestTarFile(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -0,0 +1,57 @@
+package archive
+
+import (
+	"path"
+	"strings"
+)
+
+// ValidatePattern returns path.ErrBadPattern if the glob pattern is malformed, e.g. it has an unclosed "[". Each
+// slash separated segment is checked with the syntax of path.Match, so "**" segments are allowed.
+func ValidatePattern(pattern string) error {
+	for _, segment := range strings.Split(pattern, "/") {
+		if _, err := path.Match(segment, ""); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MatchAny returns true if the slash separated name matches any of the glob patterns, see Match. It returns false
+// if there are no patterns, so callers must treat an empty list as "select everything" themselves.
+func MatchAny(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if Match(pattern, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// Match returns true if the whole slash separated name, relative to the directory being archived, matches the glob
+// pattern. Each segment of the pattern matches one segment of the name with the syntax of path.Match, so "*" does not
+// match across slashes, and a "**" segment matches zero or more directories, e.g. "**/*.yaml" matches "a.yaml" and
+// "a/b/c.yaml". A malformed pattern matches nothing, so patterns should be checked with ValidatePattern first.
+func Match(pattern, name string) bool {
+	return matchSegments(strings.Split(pattern, "/"), strings.Split(name, "/"))
+}
+
+func matchSegments(patterns, names []string) bool {
+	for len(patterns) > 0 {
+		if patterns[0] == "**" {
+			for i := 0; i <= len(names); i++ {
+				if matchSegments(patterns[1:], names[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(names) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(patterns[0], names[0]); !ok {
+			return false
+		}
+		patterns, names = patterns[1:], names[1:]
+	}
+	return len(names) == 0
+}
@@ -0,0 +1,36 @@
+package archive
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		pattern string
+		name    string
+		want    bool
+	}{
+		{"*.txt", "a.txt", true},
+		{"*.txt", "dir/a.txt", false},
+		{"dir/*.txt", "dir/a.txt", true},
+		{"**/*.txt", "a.txt", true},
+		{"**/*.txt", "dir/sub/a.txt", true},
+		{"**/*.txt", "dir/sub/a.json", false},
+		{"dir/**", "dir/sub/a.json", true},
+		{"dir/**", "other/a.json", false},
+		{"dir/**/a.?son", "dir/a.json", true},
+		{"report.json", "report.json", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pattern+" "+tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Match(tt.pattern, tt.name))
+		})
+	}
+}
+
+func TestValidatePattern(t *testing.T) {
+	assert.NoError(t, ValidatePattern("**/*.txt"))
+	assert.Error(t, ValidatePattern("dir/[*.txt"))
+}
@@ -0,0 +1,19 @@
+package errors
+
+import (
+	log "github.com/sirupsen/logrus"
+
+	grpcutil "github.com/argoproj/argo-workflows/v3/util/grpc"
+)
+
+// CheckError logs a fatal error and exits if err is not nil, with the code of the error, e.g. ERR_TEMPLATE_NOT_FOUND,
+// if it has one, so that scripts can branch on the code rather than the message
+func CheckError(err error) {
+	if err == nil {
+		return
+	}
+	if code := grpcutil.ErrorCode(err); code != "" {
+		log.WithField("errorCode", code).Fatal(err)
+	}
+	log.Fatal(err)
+}
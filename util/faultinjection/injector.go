@@ -0,0 +1,112 @@
+// Package faultinjection randomly injects faults into workflows, so that their retry strategies and exit handlers can
+// be tested. It is only enabled by the controller's --fault-injection flag, and must never be used in production.
+package faultinjection
+
+import (
+	"io"
+	"math/rand"
+	"net/http"
+	"strings"
+	"sync/atomic"
+
+	log "github.com/sirupsen/logrus"
+	"k8s.io/client-go/rest"
+
+	"github.com/argoproj/argo-workflows/v3/config"
+	"github.com/argoproj/argo-workflows/v3/util/k8s"
+)
+
+// Injector decides which faults to inject, by the rates of the namespaces in its config. A nil Injector never injects
+// faults.
+type Injector struct {
+	config atomic.Pointer[config.FaultInjection]
+	// random returns a number in [0,1), it is replaced in tests
+	random func() float64
+}
+
+func NewInjector() *Injector {
+	return &Injector{random: rand.Float64}
+}
+
+// SetConfig sets the config of the faults to inject, nil stops injecting faults
+func (i *Injector) SetConfig(c *config.FaultInjection) {
+	i.config.Store(c)
+}
+
+func (i *Injector) inject(namespace string, rate func(c *config.FaultInjection) float64) bool {
+	if i == nil {
+		return false
+	}
+	c := i.config.Load()
+	return c.SelectsNamespace(namespace) && i.random() < rate(c)
+}
+
+// InjectPodFailure returns true if a pod of a workflow in the namespace that succeeded is to be failed
+func (i *Injector) InjectPodFailure(namespace string) bool {
+	return i.inject(namespace, func(c *config.FaultInjection) float64 { return c.PodFailureRate })
+}
+
+// InjectAPITimeout returns true if a Kubernetes API request for the namespace is to time out
+func (i *Injector) InjectAPITimeout(namespace string) bool {
+	return i.inject(namespace, func(c *config.FaultInjection) float64 { return c.APITimeoutRate })
+}
+
+// GetArtifactErrorRate returns the rate at which the executor fails to load and save the artifacts of workflows in the
+// namespace, or 0 if no artifact errors are injected into them
+func (i *Injector) GetArtifactErrorRate(namespace string) float64 {
+	if i == nil {
+		return 0
+	}
+	c := i.config.Load()
+	if !c.SelectsNamespace(namespace) {
+		return 0
+	}
+	return c.ArtifactErrorRate
+}
+
+type apiTimeoutRoundTripper struct {
+	injector     *Injector
+	roundTripper http.RoundTripper
+}
+
+func (m apiTimeoutRoundTripper) RoundTrip(r *http.Request) (*http.Response, error) {
+	verb, kind := k8s.ParseRequest(r)
+	// watches are left alone, informers would only restart them
+	if verb != "Watch" && m.injector.InjectAPITimeout(getRequestNamespace(r)) {
+		log.WithFields(log.Fields{"verb": verb, "kind": kind}).Warn("Injecting fault: Kubernetes API request timeout")
+		return &http.Response{
+			Status:     "504 Gateway Timeout",
+			StatusCode: http.StatusGatewayTimeout,
+			Proto:      r.Proto,
+			ProtoMajor: r.ProtoMajor,
+			ProtoMinor: r.ProtoMinor,
+			Header:     http.Header{},
+			Body:       io.NopCloser(strings.NewReader("")),
+			Request:    r,
+		}, nil
+	}
+	return m.roundTripper.RoundTrip(r)
+}
+
+// getRequestNamespace returns the namespace of the Kubernetes API request, or "" if it is not namespaced
+func getRequestNamespace(r *http.Request) string {
+	path := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	for i := 0; i < len(path)-1; i++ {
+		if path[i] == "namespaces" {
+			return path[i+1]
+		}
+	}
+	return ""
+}
+
+// AddTransportWrapper makes the Kubernetes API requests of the config time out at the API timeout rate
+func (i *Injector) AddTransportWrapper(config *rest.Config) *rest.Config {
+	wrap := config.WrapTransport
+	config.WrapTransport = func(rt http.RoundTripper) http.RoundTripper {
+		if wrap != nil {
+			rt = wrap(rt)
+		}
+		return &apiTimeoutRoundTripper{injector: i, roundTripper: rt}
+	}
+	return config
+}
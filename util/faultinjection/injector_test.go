@@ -0,0 +1,54 @@
+package faultinjection
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/argoproj/argo-workflows/v3/config"
+)
+
+func TestInjector(t *testing.T) {
+	var nilInjector *Injector
+	assert.False(t, nilInjector.InjectPodFailure("my-ns"))
+	assert.Zero(t, nilInjector.GetArtifactErrorRate("my-ns"))
+
+	i := NewInjector()
+	i.random = func() float64 { return 0.5 }
+	assert.False(t, i.InjectPodFailure("my-ns"), "no config")
+
+	i.SetConfig(&config.FaultInjection{Namespaces: []string{"my-ns"}, PodFailureRate: 0.6, APITimeoutRate: 0.4, ArtifactErrorRate: 0.1})
+	assert.True(t, i.InjectPodFailure("my-ns"))
+	assert.False(t, i.InjectAPITimeout("my-ns"))
+	assert.Equal(t, 0.1, i.GetArtifactErrorRate("my-ns"))
+	assert.False(t, i.InjectPodFailure("other-ns"))
+	assert.Zero(t, i.GetArtifactErrorRate("other-ns"))
+}
+
+type okRoundTripper struct{}
+
+func (okRoundTripper) RoundTrip(r *http.Request) (*http.Response, error) {
+	return &http.Response{StatusCode: http.StatusOK, Request: r}, nil
+}
+
+func TestAPITimeoutRoundTripper(t *testing.T) {
+	i := NewInjector()
+	i.random = func() float64 { return 0 }
+	i.SetConfig(&config.FaultInjection{Namespaces: []string{"my-ns"}, APITimeoutRate: 0.5})
+	rt := &apiTimeoutRoundTripper{injector: i, roundTripper: okRoundTripper{}}
+	for url, code := range map[string]int{
+		"https://k8s/api/v1/namespaces/my-ns/pods/my-pod":                             http.StatusGatewayTimeout,
+		"https://k8s/apis/argoproj.io/v1alpha1/namespaces/my-ns/workflows":            http.StatusGatewayTimeout,
+		"https://k8s/apis/argoproj.io/v1alpha1/namespaces/my-ns/workflows?watch=true": http.StatusOK,
+		"https://k8s/api/v1/namespaces/other-ns/pods/my-pod":                          http.StatusOK,
+		"https://k8s/apis/argoproj.io/v1alpha1/clusterworkflowtemplates/my-cwft":      http.StatusOK,
+	} {
+		r, err := http.NewRequest(http.MethodGet, url, nil)
+		require.NoError(t, err)
+		resp, err := rt.RoundTrip(r)
+		require.NoError(t, err)
+		assert.Equal(t, code, resp.StatusCode, url)
+	}
+}
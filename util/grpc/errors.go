@@ -1,11 +1,20 @@
 package grpc
 
 import (
+	"errors"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	apierr "k8s.io/apimachinery/pkg/api/errors"
+
+	argoerrs "github.com/argoproj/argo-workflows/v3/errors"
 )
 
+// ErrorDomain is the domain of the error info that carries the code of an error, e.g. ERR_TEMPLATE_NOT_FOUND, in
+// the details of gRPC errors and HTTP error bodies
+const ErrorDomain = "argoproj.io"
+
 // translate a K8S errors into gRPC error - assume that we want to surface this - which we may not
 func TranslateError(err error) error {
 	switch {
@@ -32,5 +41,63 @@ func TranslateError(err error) error {
 	case apierr.IsInternalError(err):
 		return status.Error(codes.Internal, err.Error())
 	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	var argoErr argoerrs.ArgoError
+	if errors.As(err, &argoErr) && argoErr.Code() != "" {
+		return WithErrorCode(status.New(statusCode(argoErr.Code()), err.Error()), err).Err()
+	}
 	return err
 }
+
+// statusCode returns the gRPC code for the code of an Argo error
+func statusCode(code string) codes.Code {
+	switch code {
+	case argoerrs.CodeUnauthorized:
+		return codes.Unauthenticated
+	case argoerrs.CodeForbidden:
+		return codes.PermissionDenied
+	case argoerrs.CodeNotFound, argoerrs.CodeTemplateNotFound:
+		return codes.NotFound
+	case argoerrs.CodeBadRequest, argoerrs.CodeSemaphoreConfig, argoerrs.CodeIdempotencyKeyReused:
+		return codes.InvalidArgument
+	case argoerrs.CodeNotImplemented:
+		return codes.Unimplemented
+	case argoerrs.CodeTimeout:
+		return codes.DeadlineExceeded
+	default:
+		return codes.Internal
+	}
+}
+
+// WithErrorCode adds the code of the Argo error in the chain of err, if there is one, to the details of the status
+func WithErrorCode(st *status.Status, err error) *status.Status {
+	var argoErr argoerrs.ArgoError
+	if !errors.As(err, &argoErr) || argoErr.Code() == "" {
+		return st
+	}
+	withDetails, detailsErr := st.WithDetails(&errdetails.ErrorInfo{Reason: argoErr.Code(), Domain: ErrorDomain})
+	if detailsErr != nil {
+		return st
+	}
+	return withDetails
+}
+
+// ErrorCode returns the code of the error, from the details of a gRPC error, or from the Argo error in its chain,
+// or an empty string if it has no code
+func ErrorCode(err error) string {
+	if st, ok := status.FromError(err); ok {
+		for _, detail := range st.Details() {
+			if info, ok := detail.(*errdetails.ErrorInfo); ok && info.Domain == ErrorDomain {
+				return info.Reason
+			}
+		}
+		return ""
+	}
+	var argoErr argoerrs.ArgoError
+	if errors.As(err, &argoErr) {
+		return argoErr.Code()
+	}
+	return ""
+}
@@ -0,0 +1,43 @@
+package grpc
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	argoerrs "github.com/argoproj/argo-workflows/v3/errors"
+)
+
+func TestTranslateError(t *testing.T) {
+	t.Run("Nil", func(t *testing.T) {
+		assert.NoError(t, TranslateError(nil))
+	})
+	t.Run("Kubernetes", func(t *testing.T) {
+		err := TranslateError(apierr.NewNotFound(schema.GroupResource{Resource: "workflows"}, "my-wf"))
+		assert.Equal(t, codes.NotFound, status.Code(err))
+		assert.Empty(t, ErrorCode(err))
+	})
+	t.Run("Argo", func(t *testing.T) {
+		err := TranslateError(argoerrs.New(argoerrs.CodeSemaphoreConfig, "Sync configuration key 'my-key' not found in ConfigMap"))
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.Equal(t, argoerrs.CodeSemaphoreConfig, ErrorCode(err))
+	})
+	t.Run("Other", func(t *testing.T) {
+		err := errors.New("my-error")
+		assert.Equal(t, err, TranslateError(err))
+		assert.Empty(t, ErrorCode(err))
+	})
+}
+
+func TestErrorCode(t *testing.T) {
+	assert.Empty(t, ErrorCode(nil))
+	assert.Empty(t, ErrorCode(status.Error(codes.Internal, "my-error")))
+	assert.Equal(t, argoerrs.CodeArtifactUpload, ErrorCode(argoerrs.New(argoerrs.CodeArtifactUpload, "my-error")))
+	st := WithErrorCode(status.New(codes.NotFound, "my-error"), argoerrs.New(argoerrs.CodeTemplateNotFound, "my-error"))
+	assert.Equal(t, argoerrs.CodeTemplateNotFound, ErrorCode(st.Err()))
+}
@@ -0,0 +1,128 @@
+package logs
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+
+	log "github.com/sirupsen/logrus"
+
+	workflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/util"
+)
+
+// ArchivedLogOpener opens the archived log of a container, the output artifact of the node with the name, decompressing
+// it if it was stored compressed
+type ArchivedLogOpener func(ctx context.Context, wf *wfv1.Workflow, nodeID, artifactName string) (io.ReadCloser, error)
+
+// lineFilter selects the lines of a log that match a regular expression, or do not if it is inverted, and the lines
+// within contextLines of them
+type lineFilter struct {
+	rx           *regexp.Regexp
+	invert       bool
+	contextLines int
+	// before are the last lines, up to contextLines, that were not selected
+	before []logEntry
+	// after is the number of lines still to select after the last selected line
+	after int
+}
+
+func newLineFilter(rx *regexp.Regexp, req request) *lineFilter {
+	return &lineFilter{rx: rx, invert: req.GetInvert(), contextLines: int(req.GetContextLines())}
+}
+
+// filter returns the entries to send for the next line of the log: none, the line, or the line after its context
+func (f *lineFilter) filter(e logEntry) []logEntry {
+	if f.rx.MatchString(e.content) != f.invert {
+		entries := append(f.before, e)
+		f.before = nil
+		f.after = f.contextLines
+		return entries
+	}
+	if f.after > 0 {
+		f.after--
+		return []logEntry{e}
+	}
+	if f.contextLines > 0 {
+		f.before = append(f.before, e)
+		if len(f.before) > f.contextLines {
+			f.before = f.before[1:]
+		}
+	}
+	return nil
+}
+
+// ArchivedWorkflowLogs sends the archived logs of the requested containers of the workflow's pods, in the attempt if one
+// is requested, that are not in skipPods, e.g. because their logs can be streamed. The lines are filtered by the
+// request's grep, with its context lines, and each pod's logs are sent in turn, in the order the pods started.
+func ArchivedWorkflowLogs(ctx context.Context, wf *wfv1.Workflow, req request, skipPods map[string]bool, open ArchivedLogOpener, sender sender) error {
+	rx, err := regexp.Compile(req.GetGrep())
+	if err != nil {
+		return fmt.Errorf("failed to compile %q: %w", req.GetGrep(), err)
+	}
+	containers := req.GetContainers()
+	if len(containers) == 0 {
+		containers = []string{"main"}
+		if o := req.GetLogOptions(); o != nil && o.Container != "" {
+			containers = []string{o.Container}
+		}
+	}
+	previous := req.GetLogOptions() != nil && req.GetLogOptions().Previous
+	var nodes []wfv1.NodeStatus
+	for _, node := range wf.Status.Nodes {
+		if node.Type == wfv1.NodeTypePod {
+			nodes = append(nodes, node)
+		}
+	}
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].StartedAt.Before(&nodes[j].StartedAt) })
+	podNameVersion := util.GetWorkflowPodNameVersion(wf)
+	for _, node := range nodes {
+		podName := util.GeneratePodName(wf.Name, node.Name, util.GetTemplateFromNode(node), node.ID, podNameVersion)
+		if skipPods[podName] || (req.GetPodName() != "" && podName != req.GetPodName()) {
+			continue
+		}
+		// only the logs of the last container of each pod are archived, not those of its previous container
+		if selected, previousContainer := SelectAttempt(wf.Status.Nodes, node.Name, req.GetAttempt(), previous); !selected || previousContainer {
+			continue
+		}
+		for _, container := range containers {
+			artifactName := container + wfv1.LogsSuffix
+			if node.Outputs.GetArtifactByName(artifactName) == nil {
+				continue
+			}
+			err := sendArchivedLog(ctx, wf, node.ID, artifactName, podName, container, newLineFilter(rx, req), open, sender)
+			if err != nil {
+				return fmt.Errorf("failed to get the archived logs of %s: %w", podName, err)
+			}
+		}
+	}
+	return nil
+}
+
+func sendArchivedLog(ctx context.Context, wf *wfv1.Workflow, nodeID, artifactName, podName, container string, f *lineFilter, open ArchivedLogOpener, sender sender) error {
+	log.WithFields(log.Fields{"podName": podName, "container": container}).Debug("Reading archived logs")
+	r, err := open(ctx, wf, nodeID, artifactName)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = r.Close() }()
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, startBufSize), maxTokenLength)
+	scanner.Split(scanLinesOrGiveLong)
+	for scanner.Scan() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		for _, e := range f.filter(logEntry{podName: podName, container: container, content: scanner.Text()}) {
+			err := sender.Send(&workflowpkg.LogEntry{Content: e.content, PodName: e.podName, Container: e.container})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return scanner.Err()
+}
@@ -0,0 +1,162 @@
+package logs
+
+import (
+	"context"
+	"io"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	workflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+type testSender struct {
+	entries []*workflowpkg.LogEntry
+}
+
+func (s *testSender) Send(entry *workflowpkg.LogEntry) error {
+	s.entries = append(s.entries, entry)
+	return nil
+}
+
+func (s *testSender) lines() []string {
+	var lines []string
+	for _, e := range s.entries {
+		lines = append(lines, e.PodName+": "+e.Content)
+	}
+	return lines
+}
+
+func Test_lineFilter(t *testing.T) {
+	filter := func(req *workflowpkg.WorkflowLogRequest, lines ...string) []string {
+		f := newLineFilter(regexp.MustCompile(req.Grep), req)
+		var selected []string
+		for _, l := range lines {
+			for _, e := range f.filter(logEntry{content: l}) {
+				selected = append(selected, e.content)
+			}
+		}
+		return selected
+	}
+	lines := []string{"a", "b", "error 1", "c", "d", "e", "f", "g", "error 2", "h"}
+	t.Run("Grep", func(t *testing.T) {
+		assert.Equal(t, []string{"error 1", "error 2"}, filter(&workflowpkg.WorkflowLogRequest{Grep: "error"}, lines...))
+	})
+	t.Run("Invert", func(t *testing.T) {
+		assert.Equal(t, []string{"a", "b", "c", "d", "e", "f", "g", "h"}, filter(&workflowpkg.WorkflowLogRequest{Grep: "error", Invert: true}, lines...))
+	})
+	t.Run("ContextLines", func(t *testing.T) {
+		assert.Equal(t, []string{"a", "b", "error 1", "c", "d", "f", "g", "error 2", "h"}, filter(&workflowpkg.WorkflowLogRequest{Grep: "error", ContextLines: 2}, lines...))
+		assert.Equal(t, []string{"b", "error 1", "c", "g", "error 2", "h"}, filter(&workflowpkg.WorkflowLogRequest{Grep: "error", ContextLines: 1}, lines...))
+	})
+	t.Run("OverlappingContextLines", func(t *testing.T) {
+		assert.Equal(t, []string{"a", "error 1", "b", "error 2", "c"}, filter(&workflowpkg.WorkflowLogRequest{Grep: "error", ContextLines: 1}, "a", "error 1", "b", "error 2", "c", "d"))
+	})
+}
+
+func TestArchivedWorkflowLogs(t *testing.T) {
+	t0 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	logs := wfv1.Outputs{Artifacts: []wfv1.Artifact{{Name: "main-logs"}}}
+	wf := &wfv1.Workflow{
+		// the pods are named after the nodes' IDs
+		ObjectMeta: metav1.ObjectMeta{Name: "my-wf", Annotations: map[string]string{common.AnnotationKeyPodNameVersion: "v1"}},
+		Status: wfv1.WorkflowStatus{Nodes: wfv1.Nodes{
+			"my-wf":   {ID: "my-wf", Name: "my-wf", Type: wfv1.NodeTypeSteps},
+			"my-wf-1": {ID: "my-wf-1", Name: "my-wf[0].b", TemplateName: "b", Type: wfv1.NodeTypePod, StartedAt: metav1.NewTime(t0.Add(time.Minute)), Outputs: &logs},
+			"my-wf-2": {ID: "my-wf-2", Name: "my-wf[0].a", TemplateName: "a", Type: wfv1.NodeTypePod, StartedAt: metav1.NewTime(t0), Outputs: &logs},
+			"my-wf-3": {ID: "my-wf-3", Name: "my-wf[0].c", TemplateName: "c", Type: wfv1.NodeTypePod, StartedAt: metav1.NewTime(t0.Add(2 * time.Minute))},
+		}},
+	}
+	archived := map[string]string{
+		"my-wf-1/main-logs": "b 1\nb error\nb 2\n",
+		"my-wf-2/main-logs": "a error\na 1\n",
+	}
+	open := func(_ context.Context, _ *wfv1.Workflow, nodeID, artifactName string) (io.ReadCloser, error) {
+		return io.NopCloser(strings.NewReader(archived[nodeID+"/"+artifactName])), nil
+	}
+	t.Run("All", func(t *testing.T) {
+		s := &testSender{}
+		err := ArchivedWorkflowLogs(context.Background(), wf, &workflowpkg.WorkflowLogRequest{}, nil, open, s)
+		if assert.NoError(t, err) {
+			assert.Equal(t, []string{"my-wf-2: a error", "my-wf-2: a 1", "my-wf-1: b 1", "my-wf-1: b error", "my-wf-1: b 2"}, s.lines())
+		}
+	})
+	t.Run("Grep", func(t *testing.T) {
+		s := &testSender{}
+		err := ArchivedWorkflowLogs(context.Background(), wf, &workflowpkg.WorkflowLogRequest{Grep: "error", ContextLines: 1}, map[string]bool{"my-wf-2": true}, open, s)
+		if assert.NoError(t, err) {
+			assert.Equal(t, []string{"my-wf-1: b 1", "my-wf-1: b error", "my-wf-1: b 2"}, s.lines())
+		}
+	})
+	t.Run("InvalidGrep", func(t *testing.T) {
+		err := ArchivedWorkflowLogs(context.Background(), wf, &workflowpkg.WorkflowLogRequest{Grep: "("}, nil, open, &testSender{})
+		assert.Error(t, err)
+	})
+}
+
+func TestArchivedWorkflowLogsPrevious(t *testing.T) {
+	logs := wfv1.Outputs{Artifacts: []wfv1.Artifact{{Name: "main-logs"}}}
+	wf := &wfv1.Workflow{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-wf", Annotations: map[string]string{common.AnnotationKeyPodNameVersion: "v1"}},
+		Status: wfv1.WorkflowStatus{Nodes: wfv1.Nodes{
+			"my-wf":   {ID: "my-wf", Name: "my-wf[0].a", Type: wfv1.NodeTypeRetry, Children: []string{"my-wf-1", "my-wf-2", "my-wf-3"}},
+			"my-wf-1": {ID: "my-wf-1", Name: "my-wf[0].a(0)", TemplateName: "a", Type: wfv1.NodeTypePod, Outputs: &logs},
+			"my-wf-2": {ID: "my-wf-2", Name: "my-wf[0].a(1)", TemplateName: "a", Type: wfv1.NodeTypePod, Outputs: &logs},
+			"my-wf-3": {ID: "my-wf-3", Name: "my-wf[0].a(2)", TemplateName: "a", Type: wfv1.NodeTypePod, Outputs: &logs},
+			"my-wf-4": {ID: "my-wf-4", Name: "my-wf[1].b", TemplateName: "b", Type: wfv1.NodeTypePod, Outputs: &logs},
+		}},
+	}
+	open := func(_ context.Context, _ *wfv1.Workflow, nodeID, _ string) (io.ReadCloser, error) {
+		return io.NopCloser(strings.NewReader(nodeID + " log\n")), nil
+	}
+	for name, tt := range map[string]struct {
+		req  *workflowpkg.WorkflowLogRequest
+		want []string
+	}{
+		// the previous container of the pod that was not retried is not archived
+		"Previous":                {&workflowpkg.WorkflowLogRequest{LogOptions: &corev1.PodLogOptions{Previous: true}}, []string{"my-wf-2: my-wf-2 log"}},
+		"RetryAttempt":            {&workflowpkg.WorkflowLogRequest{Attempt: 1}, []string{"my-wf-1: my-wf-1 log", "my-wf-4: my-wf-4 log"}},
+		"RetryAttemptAndPrevious": {&workflowpkg.WorkflowLogRequest{Attempt: 1, LogOptions: &corev1.PodLogOptions{Previous: true}}, nil},
+	} {
+		t.Run(name, func(t *testing.T) {
+			s := &testSender{}
+			err := ArchivedWorkflowLogs(context.Background(), wf, tt.req, nil, open, s)
+			if assert.NoError(t, err) {
+				assert.ElementsMatch(t, tt.want, s.lines())
+			}
+		})
+	}
+}
+
+func TestSelectAttempt(t *testing.T) {
+	nodes := wfv1.Nodes{
+		"a": {Name: "wf[0].a", Type: wfv1.NodeTypeRetry, Children: []string{"a-0", "a-1", "a-2"}},
+	}
+	for name, tt := range map[string]struct {
+		nodeName                    string
+		attempt                     int32
+		previous                    bool
+		selected, previousContainer bool
+	}{
+		"All":                         {"wf[0].a(0)", 0, false, true, false},
+		"Attempt":                     {"wf[0].a(1)", 2, false, true, false},
+		"OtherAttempt":                {"wf[0].a(1)", 1, false, false, false},
+		"PreviousAttempt":             {"wf[0].a(1)", 0, true, true, false},
+		"NotPreviousAttempt":          {"wf[0].a(2)", 0, true, false, false},
+		"PreviousContainer":           {"wf[1].b", 0, true, true, true},
+		"AttemptAndPreviousContainer": {"wf[0].a(0)", 1, true, true, true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			selected, previousContainer := SelectAttempt(nodes, tt.nodeName, tt.attempt, tt.previous)
+			assert.Equal(t, tt.selected, selected)
+			assert.Equal(t, tt.previousContainer, previousContainer)
+		})
+	}
+}
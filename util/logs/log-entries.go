@@ -7,6 +7,7 @@ import (
 type logEntry struct {
 	timestamp time.Time
 	podName   string
+	container string
 	content   string
 }
 
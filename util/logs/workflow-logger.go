@@ -35,12 +35,32 @@ type request interface {
 	GetLogOptions() *corev1.PodLogOptions
 	GetGrep() string
 	GetSelector() string
+	GetAttempt() int32
+	GetInvert() bool
+	GetContainers() []string
+	GetContextLines() int32
+	GetArchived() bool
 }
 
 type sender interface {
 	Send(entry *workflowpkg.LogEntry) error
 }
 
+// SelectAttempt returns whether the logs of the pod of the node are requested, given the requested retry attempt, and
+// whether the logs of its previous container are. The previous logs of a node that was retried are those of its
+// previous attempt rather than of its previous container, as the pod of each attempt only runs once.
+func SelectAttempt(nodes wfv1.Nodes, nodeName string, attempt int32, previous bool) (bool, bool) {
+	nodeAttempt, attempts := nodes.GetAttempts(nodeName)
+	switch {
+	case attempt > 0:
+		return nodeAttempt == int(attempt), previous
+	case previous && attempts > 1:
+		return nodeAttempt == attempts-1, false
+	default:
+		return true, previous
+	}
+}
+
 const maxTokenLength = 1024 * 1024
 const startBufSize = 16 * 1024
 
@@ -62,9 +82,11 @@ func scanLinesOrGiveLong(data []byte, atEOF bool) (advance int, token []byte, er
 	return maxTokenLength, data[0:maxTokenLength], nil
 }
 
-func WorkflowLogs(ctx context.Context, wfClient versioned.Interface, kubeClient kubernetes.Interface, req request, sender sender) error {
+// WorkflowLogs sends the logs of the workflow's pods, and the archived logs of its deleted pods if the request asks for
+// them and archived is not nil.
+func WorkflowLogs(ctx context.Context, wfClient versioned.Interface, kubeClient kubernetes.Interface, archived ArchivedLogOpener, req request, sender sender) error {
 	wfInterface := wfClient.ArgoprojV1alpha1().Workflows(req.GetNamespace())
-	_, err := wfInterface.Get(ctx, req.GetName(), metav1.GetOptions{})
+	wf, err := wfInterface.Get(ctx, req.GetName(), metav1.GetOptions{})
 	if err != nil {
 		return err
 	}
@@ -110,9 +132,69 @@ func WorkflowLogs(ctx context.Context, wfClient versioned.Interface, kubeClient
 	}
 	logCtx.WithField("options", logOptions).Debug("Log options")
 
-	// make a copy of requested log options and set timestamps to true, so they can be parsed out later
-	podLogStreamOptions := *logOptions
-	podLogStreamOptions.Timestamps = true
+	// the requested containers replace the container of the log options, so that several can be logged
+	containers := req.GetContainers()
+	if len(containers) == 0 {
+		containers = []string{logOptions.Container}
+	}
+
+	// this func streams the logs of one container of a pod
+	streamContainer := func(logCtx *log.Entry, podName, container string, previous bool) {
+		defer wg.Done()
+		logCtx.Debug("Streaming pod logs")
+		defer logCtx.Debug("Pod logs stream done")
+		// make a copy of requested log options and set timestamps to true, so they can be parsed out later
+		podLogStreamOptions := *logOptions
+		podLogStreamOptions.Timestamps = true
+		podLogStreamOptions.Container = container
+		podLogStreamOptions.Previous = previous
+		stream, err := podInterface.GetLogs(podName, &podLogStreamOptions).Stream(ctx)
+		if err != nil {
+			logCtx.Error(err)
+			return
+		}
+
+		f := newLineFilter(rx, req)
+		scanner := bufio.NewScanner(stream)
+		//give it more space for long line
+		scanner.Buffer(make([]byte, startBufSize), maxTokenLength)
+		//avoid bufio.ErrTooLong error when encounters a very very long line
+		scanner.Split(scanLinesOrGiveLong)
+		for scanner.Scan() {
+			select {
+			case <-ctx.Done():
+				return
+			default:
+				line := scanner.Text()
+				parts := strings.SplitN(line, " ", 2)
+				//on old version k8s, the line may contains no space, hence len(parts) would equal to 1
+				content := ""
+				if len(parts) > 1 {
+					content = parts[1]
+				}
+				timestamp, err := time.Parse(time.RFC3339, parts[0])
+				if err != nil {
+					logCtx.Errorf("unable to decode or infer timestamp from log line: %s", err)
+					// The current timestamp is the next best substitute. This won't be shown, but will be used
+					// for sorting
+					timestamp = time.Now()
+					content = line
+				}
+				// You might ask - why don't we let the client do this? Well, it is because
+				// this is the same as how this works for `kubectl logs`
+				if logOptions.Timestamps {
+					content = line
+				}
+				// this means we filter the lines in the server, but will still incur the cost of retrieving them from Kubernetes
+				for _, e := range f.filter(logEntry{podName: podName, container: container, content: content, timestamp: timestamp}) {
+					logCtx.WithFields(log.Fields{"timestamp": e.timestamp, "content": e.content}).Debug("Log line")
+					unsortedEntries <- e
+				}
+			}
+		}
+		logCtx.Debug("No more log lines to stream")
+		// out of data, we do not want to start watching again
+	}
 
 	// this func start a stream if one is not already running
 	ensureWeAreStreaming := func(pod *corev1.Pod) {
@@ -120,58 +202,23 @@ func WorkflowLogs(ctx context.Context, wfClient versioned.Interface, kubeClient
 		defer streamedPodsGuard.Unlock()
 		logCtx := logCtx.WithField("podName", pod.GetName())
 		logCtx.WithFields(log.Fields{"podPhase": pod.Status.Phase, "alreadyStreaming": streamedPods[pod.UID]}).Debug("Ensuring pod logs stream")
+		// if an attempt, or the previous one, was requested, we only stream the pods of the nodes in that attempt
+		selected, previous := SelectAttempt(wf.Status.Nodes, pod.GetAnnotations()[common.AnnotationKeyNodeName], req.GetAttempt(), logOptions.Previous)
+		if !selected {
+			logCtx.Debug("Pod is not in the requested attempt")
+			return
+		}
 		if pod.Status.Phase != corev1.PodPending && !streamedPods[pod.UID] {
 			streamedPods[pod.UID] = true
-			wg.Add(1)
-			go func(podName string) {
-				defer wg.Done()
-				logCtx.Debug("Streaming pod logs")
-				defer logCtx.Debug("Pod logs stream done")
-				stream, err := podInterface.GetLogs(podName, &podLogStreamOptions).Stream(ctx)
-				if err != nil {
-					logCtx.Error(err)
-					return
+			for _, container := range containers {
+				// when containers are requested, the pods that do not have them, such as those of other templates,
+				// are skipped rather than failing
+				if len(req.GetContainers()) > 0 && !hasContainer(pod, container) {
+					continue
 				}
-
-				scanner := bufio.NewScanner(stream)
-				//give it more space for long line
-				scanner.Buffer(make([]byte, startBufSize), maxTokenLength)
-				//avoid bufio.ErrTooLong error when encounters a very very long line
-				scanner.Split(scanLinesOrGiveLong)
-				for scanner.Scan() {
-					select {
-					case <-ctx.Done():
-						return
-					default:
-						line := scanner.Text()
-						parts := strings.SplitN(line, " ", 2)
-						//on old version k8s, the line may contains no space, hence len(parts) would equal to 1
-						content := ""
-						if len(parts) > 1 {
-							content = parts[1]
-						}
-						timestamp, err := time.Parse(time.RFC3339, parts[0])
-						if err != nil {
-							logCtx.Errorf("unable to decode or infer timestamp from log line: %s", err)
-							// The current timestamp is the next best substitute. This won't be shown, but will be used
-							// for sorting
-							timestamp = time.Now()
-							content = line
-						}
-						// You might ask - why don't we let the client do this? Well, it is because
-						// this is the same as how this works for `kubectl logs`
-						if req.GetLogOptions().Timestamps {
-							content = line
-						}
-						if rx.MatchString(content) { // this means we filter the lines in the server, but will still incur the cost of retrieving them from Kubernetes
-							logCtx.WithFields(log.Fields{"timestamp": timestamp, "content": content}).Debug("Log line")
-							unsortedEntries <- logEntry{podName: podName, content: content, timestamp: timestamp}
-						}
-					}
-				}
-				logCtx.Debug("No more log lines to stream")
-				// out of data, we do not want to start watching again
-			}(pod.GetName())
+				wg.Add(1)
+				go streamContainer(logCtx.WithField("container", container), pod.GetName(), container, previous)
+			}
 		}
 	}
 
@@ -193,6 +240,19 @@ func WorkflowLogs(ctx context.Context, wfClient versioned.Interface, kubeClient
 		return list.Items[i].Status.StartTime.Before(list.Items[j].Status.StartTime)
 	})
 
+	// the archived logs of the pods that were deleted, e.g. by pod GC, are sent first, as they cannot be streamed. They
+	// cannot be selected by labels, so are only sent without a selector.
+	if archived != nil && req.GetArchived() && req.GetSelector() == "" {
+		livePods := make(map[string]bool, len(list.Items))
+		for _, pod := range list.Items {
+			livePods[pod.Name] = true
+		}
+		err := ArchivedWorkflowLogs(ctx, wf, req, livePods, archived, sender)
+		if err != nil {
+			return err
+		}
+	}
+
 	for _, pod := range list.Items {
 		ensureWeAreStreaming(&pod)
 	}
@@ -229,14 +289,17 @@ func WorkflowLogs(ctx context.Context, wfClient versioned.Interface, kubeClient
 						}
 						continue
 					}
-					wf, ok := event.Object.(*wfv1.Workflow)
+					newWf, ok := event.Object.(*wfv1.Workflow)
 					if !ok {
 						// object is probably probably metav1.Status
 						logCtx.WithError(apierr.FromObject(event.Object)).Warn("watch object was not a workflow")
 						return
 					}
-					logCtx.WithFields(log.Fields{"eventType": event.Type, "completed": wf.Status.Fulfilled()}).Debug("Workflow event")
-					if event.Type == watch.Deleted || wf.Status.Fulfilled() {
+					streamedPodsGuard.Lock()
+					wf = newWf
+					streamedPodsGuard.Unlock()
+					logCtx.WithFields(log.Fields{"eventType": event.Type, "completed": newWf.Status.Fulfilled()}).Debug("Workflow event")
+					if event.Type == watch.Deleted || newWf.Status.Fulfilled() {
 						return
 					}
 				}
@@ -298,7 +361,7 @@ func WorkflowLogs(ctx context.Context, wfClient versioned.Interface, kubeClient
 				var e logEntry
 				e, entries = entries[0], entries[1:]
 				logCtx.WithFields(log.Fields{"timestamp": e.timestamp, "content": e.content}).Debug("Sending entry")
-				err := sender.Send(&workflowpkg.LogEntry{Content: e.content, PodName: e.podName})
+				err := sender.Send(&workflowpkg.LogEntry{Content: e.content, PodName: e.podName, Container: e.container})
 				if err != nil {
 					return err
 				}
@@ -339,3 +402,18 @@ func WorkflowLogs(ctx context.Context, wfClient versioned.Interface, kubeClient
 	logCtx.Debug("Done-done")
 	return nil
 }
+
+// hasContainer returns true if the pod has a container or init container with the name
+func hasContainer(pod *corev1.Pod, name string) bool {
+	for _, c := range pod.Spec.InitContainers {
+		if c.Name == name {
+			return true
+		}
+	}
+	for _, c := range pod.Spec.Containers {
+		if c.Name == name {
+			return true
+		}
+	}
+	return false
+}
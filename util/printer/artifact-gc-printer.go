@@ -0,0 +1,80 @@
+package printer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	"sigs.k8s.io/yaml"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+// PrintArtifactGCReports prints the artifacts that garbage collection would delete for each workflow, and the
+// garbage collection attempts that failed, as tables, or as a map of each workflow's name to its report
+func PrintArtifactGCReports(reports map[string]*wfv1.ArtifactGCReport, out io.Writer, opts PrintOpts) error {
+	switch opts.Output {
+	case "":
+		names := make([]string, 0, len(reports))
+		for name := range reports {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		w := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+		if !opts.NoHeaders {
+			_, _ = fmt.Fprintln(w, "WORKFLOW\tNODE\tARTIFACT\tSTRATEGY\tREADY\tSIZE\tKEY")
+		}
+		var failures bool
+		for _, name := range names {
+			for _, c := range reports[name].Candidates {
+				_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%t\t%s\t%s\n", name, c.NodeName, c.ArtifactName, c.Strategy, c.Ready, artifactSizeText(c.SizeBytes), c.Key)
+			}
+			failures = failures || len(reports[name].Failures) > 0
+		}
+		_ = w.Flush()
+		if !failures {
+			return nil
+		}
+		_, _ = fmt.Fprintln(out)
+		w = tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+		if !opts.NoHeaders {
+			_, _ = fmt.Fprintln(w, "WORKFLOW\tTASK/POD\tNODE\tARTIFACT\tMESSAGE")
+		}
+		for _, name := range names {
+			for _, f := range reports[name].Failures {
+				source := f.TaskName
+				if f.PodName != "" {
+					source = f.PodName
+				}
+				_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", name, source, f.NodeID, f.ArtifactName, f.Message)
+			}
+		}
+		_ = w.Flush()
+	case "json":
+		output, err := json.MarshalIndent(reports, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintln(out, string(output))
+	case "yaml":
+		output, err := yaml.Marshal(reports)
+		if err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintln(out, string(output))
+	default:
+		return fmt.Errorf("unknown output mode: %s", opts.Output)
+	}
+	return nil
+}
+
+// artifactSizeText returns the size of the artifact, or "-" if it was not recorded
+func artifactSizeText(sizeBytes int64) string {
+	if sizeBytes == 0 {
+		return "-"
+	}
+	return resource.NewQuantity(sizeBytes, resource.BinarySI).String()
+}
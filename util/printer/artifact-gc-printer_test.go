@@ -0,0 +1,41 @@
+package printer
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+func TestPrintArtifactGCReports(t *testing.T) {
+	reports := map[string]*wfv1.ArtifactGCReport{
+		"my-wf": {
+			Candidates: []wfv1.ArtifactGCCandidate{
+				{NodeID: "my-wf-1", NodeName: "main", ArtifactName: "model", Strategy: wfv1.ArtifactGCOnWorkflowCompletion, Key: "my-wf/model.tgz", SizeBytes: 2048, Ready: true},
+				{NodeID: "my-wf-1", NodeName: "main", ArtifactName: "logs", Strategy: wfv1.ArtifactGCOnWorkflowDeletion, Key: "my-wf/logs.tgz"},
+			},
+			Failures: []wfv1.ArtifactGCFailure{{NodeID: "my-wf-1", ArtifactName: "model", Message: "access denied", TaskName: "my-wf-1234-0"}},
+		},
+	}
+
+	t.Run("Table", func(t *testing.T) {
+		var buf bytes.Buffer
+		assert.NoError(t, PrintArtifactGCReports(reports, &buf, PrintOpts{}))
+		assert.Contains(t, buf.String(), "WORKFLOW")
+		assert.Contains(t, buf.String(), "my-wf/model.tgz")
+		assert.Contains(t, buf.String(), "2Ki")
+		assert.Contains(t, buf.String(), "access denied")
+	})
+	t.Run("NoFailures", func(t *testing.T) {
+		var buf bytes.Buffer
+		assert.NoError(t, PrintArtifactGCReports(map[string]*wfv1.ArtifactGCReport{"my-wf": {Candidates: reports["my-wf"].Candidates}}, &buf, PrintOpts{}))
+		assert.NotContains(t, buf.String(), "MESSAGE")
+	})
+	t.Run("JSON", func(t *testing.T) {
+		var buf bytes.Buffer
+		assert.NoError(t, PrintArtifactGCReports(reports, &buf, PrintOpts{Output: "json"}))
+		assert.Contains(t, buf.String(), `"sizeBytes": 2048`)
+	})
+}
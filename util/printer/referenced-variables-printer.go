@@ -0,0 +1,55 @@
+package printer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/argoproj/argo-workflows/v3/workflow/util"
+)
+
+// PrintReferencedVariables prints the variables referenced by each object, e.g. workflowtemplate/my-template, as a
+// table, or as a map of each object to its variables for json and yaml
+func PrintReferencedVariables(variables map[string][]util.ReferencedVariable, out io.Writer, opts PrintOpts) error {
+	switch opts.Output {
+	case "":
+		objects := make([]string, 0, len(variables))
+		for object := range variables {
+			objects = append(objects, object)
+		}
+		sort.Strings(objects)
+		w := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+		if !opts.NoHeaders {
+			_, _ = fmt.Fprintln(w, "OBJECT\tTEMPLATE\tVARIABLE")
+		}
+		for _, object := range objects {
+			for _, v := range variables[object] {
+				template := v.Template
+				if template == "" {
+					template = "-"
+				}
+				_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n", object, template, v.Name)
+			}
+		}
+		_ = w.Flush()
+	case "json":
+		output, err := json.MarshalIndent(variables, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintln(out, string(output))
+	case "yaml":
+		output, err := yaml.Marshal(variables)
+		if err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintln(out, string(output))
+	default:
+		return fmt.Errorf("unknown output mode: %s", opts.Output)
+	}
+	return nil
+}
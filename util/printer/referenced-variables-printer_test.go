@@ -0,0 +1,36 @@
+package printer
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/argoproj/argo-workflows/v3/workflow/util"
+)
+
+func TestPrintReferencedVariables(t *testing.T) {
+	variables := map[string][]util.ReferencedVariable{
+		"workflow/my-wf": {
+			{Name: "workflow.name"},
+			{Name: "inputs.parameters.imge", Template: "say"},
+		},
+	}
+
+	t.Run("Table", func(t *testing.T) {
+		var buf bytes.Buffer
+		assert.NoError(t, PrintReferencedVariables(variables, &buf, PrintOpts{}))
+		assert.Equal(t, `OBJECT           TEMPLATE   VARIABLE
+workflow/my-wf   -          workflow.name
+workflow/my-wf   say        inputs.parameters.imge
+`, buf.String())
+	})
+	t.Run("JSON", func(t *testing.T) {
+		var buf bytes.Buffer
+		assert.NoError(t, PrintReferencedVariables(variables, &buf, PrintOpts{Output: "json"}))
+		assert.JSONEq(t, `{"workflow/my-wf": [{"name": "workflow.name"}, {"name": "inputs.parameters.imge", "template": "say"}]}`, buf.String())
+	})
+	t.Run("Unknown", func(t *testing.T) {
+		assert.EqualError(t, PrintReferencedVariables(variables, &bytes.Buffer{}, PrintOpts{Output: "wide"}), "unknown output mode: wide")
+	})
+}
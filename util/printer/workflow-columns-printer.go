@@ -0,0 +1,143 @@
+package printer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"k8s.io/client-go/util/jsonpath"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+const (
+	customColumnsPrefix = "custom-columns="
+	jsonPathPrefix      = "jsonpath="
+)
+
+// IsTemplateOutput returns whether the output is custom columns or a JSONPath template, which may refer to any field of
+// the workflows
+func IsTemplateOutput(output string) bool {
+	return strings.HasPrefix(output, customColumnsPrefix) || strings.HasPrefix(output, jsonPathPrefix)
+}
+
+// relaxedJSONPath returns the expression as a JSONPath template, adding the braces and leading dot it may omit, as
+// kubectl does
+func relaxedJSONPath(expr string) (string, error) {
+	expr = strings.TrimSpace(expr)
+	if expr == "" {
+		return "", fmt.Errorf("empty JSONPath expression")
+	}
+	if strings.HasPrefix(expr, "{") != strings.HasSuffix(expr, "}") {
+		return "", fmt.Errorf("unbalanced braces in JSONPath expression %q", expr)
+	}
+	expr = strings.TrimSuffix(strings.TrimPrefix(expr, "{"), "}")
+	if !strings.HasPrefix(expr, ".") && !strings.HasPrefix(expr, "[") {
+		expr = "." + expr
+	}
+	return "{" + expr + "}", nil
+}
+
+type column struct {
+	header string
+	path   *jsonpath.JSONPath
+}
+
+// parseCustomColumns parses a comma separated list of HEADER:JSONPATH columns
+func parseCustomColumns(spec string) ([]column, error) {
+	if spec == "" {
+		return nil, fmt.Errorf("custom-columns format specified but no custom columns given")
+	}
+	var columns []column
+	for _, part := range strings.Split(spec, ",") {
+		header, expr, ok := strings.Cut(part, ":")
+		if !ok || header == "" {
+			return nil, fmt.Errorf("unexpected custom-columns spec: %s, expected <header>:<json-path-expr>", part)
+		}
+		template, err := relaxedJSONPath(expr)
+		if err != nil {
+			return nil, err
+		}
+		path := jsonpath.New(header).AllowMissingKeys(true)
+		if err := path.Parse(template); err != nil {
+			return nil, fmt.Errorf("failed to parse the JSONPath of column %s: %w", header, err)
+		}
+		columns = append(columns, column{header: header, path: path})
+	}
+	return columns, nil
+}
+
+// toUnstructured returns the value as decoded JSON, so that JSONPaths refer to its JSON field names
+func toUnstructured(v interface{}) (interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var obj interface{}
+	return obj, json.Unmarshal(data, &obj)
+}
+
+// printCustomColumns prints a table of the workflows with a column for each spec, "<none>" where its path is missing,
+// as `kubectl get -o custom-columns` does
+func printCustomColumns(workflows wfv1.Workflows, out io.Writer, spec string, opts PrintOpts) error {
+	columns, err := parseCustomColumns(spec)
+	if err != nil {
+		return err
+	}
+	w := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+	if !opts.NoHeaders {
+		var headers []string
+		for _, c := range columns {
+			headers = append(headers, c.header)
+		}
+		_, _ = fmt.Fprintln(w, strings.Join(headers, "\t"))
+	}
+	for _, wf := range workflows {
+		obj, err := toUnstructured(wf)
+		if err != nil {
+			return err
+		}
+		var values []string
+		for _, c := range columns {
+			results, err := c.path.FindResults(obj)
+			if err != nil {
+				return fmt.Errorf("failed to get column %s of %s: %w", c.header, wf.Name, err)
+			}
+			var found []string
+			for _, r := range results {
+				for _, v := range r {
+					found = append(found, fmt.Sprintf("%v", v.Interface()))
+				}
+			}
+			if len(found) == 0 {
+				values = append(values, "<none>")
+			} else {
+				values = append(values, strings.Join(found, ","))
+			}
+		}
+		_, _ = fmt.Fprintln(w, strings.Join(values, "\t"))
+	}
+	return w.Flush()
+}
+
+// printJSONPath prints the template executed on the list of workflows, e.g. {.items[*].metadata.name}, without a
+// trailing new line, as `kubectl get -o jsonpath` does
+func printJSONPath(workflows wfv1.Workflows, out io.Writer, template string) error {
+	path := jsonpath.New("output")
+	if err := path.Parse(template); err != nil {
+		return fmt.Errorf("failed to parse the JSONPath template %q: %w", template, err)
+	}
+	if workflows == nil {
+		workflows = wfv1.Workflows{}
+	}
+	obj, err := toUnstructured(map[string]interface{}{"items": workflows})
+	if err != nil {
+		return err
+	}
+	if err := path.Execute(out, obj); err != nil {
+		return fmt.Errorf("failed to execute the JSONPath template %q: %w", template, err)
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package printer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"text/tabwriter"
+
+	"sigs.k8s.io/yaml"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+// PrintWorkflowOutputs prints the outputs of a workflow as a table, or as a map of each output's name to its typed
+// value for json and yaml, so that scripts can consume them directly
+func PrintWorkflowOutputs(outputs *wfv1.WorkflowOutputs, out io.Writer, opts PrintOpts) error {
+	switch opts.Output {
+	case "":
+		w := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+		if !opts.NoHeaders {
+			_, _ = fmt.Fprintln(w, "NAME\tTYPE\tVALUE\tDESCRIPTION")
+		}
+		for _, o := range outputs.Outputs {
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", o.Name, o.Type, workflowOutputText(o), o.Description)
+		}
+		_ = w.Flush()
+	case "json":
+		output, err := json.MarshalIndent(workflowOutputValues(outputs), "", "  ")
+		if err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintln(out, string(output))
+	case "yaml":
+		output, err := yaml.Marshal(workflowOutputValues(outputs))
+		if err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintln(out, string(output))
+	default:
+		return fmt.Errorf("unknown output mode: %s", opts.Output)
+	}
+	return nil
+}
+
+func workflowOutputText(o wfv1.WorkflowOutputValue) string {
+	switch {
+	case o.Message != "":
+		return fmt.Sprintf("<%s>", o.Message)
+	case o.Artifact != nil:
+		if key, err := o.Artifact.GetKey(); err == nil {
+			return key
+		}
+		return o.Artifact.Name
+	default:
+		return o.Value
+	}
+}
+
+// workflowOutputValues returns the value of each output as its type, or nil if it has no value
+func workflowOutputValues(outputs *wfv1.WorkflowOutputs) map[string]interface{} {
+	values := make(map[string]interface{}, len(outputs.Outputs))
+	for _, o := range outputs.Outputs {
+		values[o.Name] = workflowOutputValue(o)
+	}
+	return values
+}
+
+func workflowOutputValue(o wfv1.WorkflowOutputValue) interface{} {
+	if o.Message != "" {
+		return nil
+	}
+	switch o.Type {
+	case wfv1.WorkflowOutputTypeArtifact:
+		return o.Artifact
+	case wfv1.WorkflowOutputTypeNumber:
+		if v, err := strconv.ParseFloat(o.Value, 64); err == nil {
+			return v
+		}
+	case wfv1.WorkflowOutputTypeBoolean:
+		if v, err := strconv.ParseBool(o.Value); err == nil {
+			return v
+		}
+	case wfv1.WorkflowOutputTypeJSON:
+		return json.RawMessage(o.Value)
+	}
+	return o.Value
+}
@@ -0,0 +1,38 @@
+package printer
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+func TestPrintWorkflowOutputs(t *testing.T) {
+	outputs := &wfv1.WorkflowOutputs{Outputs: []wfv1.WorkflowOutputValue{
+		{Name: "accuracy", Description: "Accuracy of the model", Type: wfv1.WorkflowOutputTypeNumber, Value: "0.93"},
+		{Name: "passed", Type: wfv1.WorkflowOutputTypeBoolean, Value: "true"},
+		{Name: "metrics", Type: wfv1.WorkflowOutputTypeJSON, Value: `{"loss":0.1}`},
+		{Name: "model", Type: wfv1.WorkflowOutputTypeArtifact, Artifact: &wfv1.Artifact{Name: "model", ArtifactLocation: wfv1.ArtifactLocation{S3: &wfv1.S3Artifact{Key: "model.tgz"}}}},
+		{Name: "report", Type: wfv1.WorkflowOutputTypeString, Message: "report has not completed"},
+	}}
+
+	t.Run("Table", func(t *testing.T) {
+		var buf bytes.Buffer
+		assert.NoError(t, PrintWorkflowOutputs(outputs, &buf, PrintOpts{}))
+		assert.Contains(t, buf.String(), "NAME")
+		assert.Contains(t, buf.String(), "Accuracy of the model")
+		assert.Contains(t, buf.String(), "model.tgz")
+		assert.Contains(t, buf.String(), "<report has not completed>")
+	})
+	t.Run("JSON", func(t *testing.T) {
+		var buf bytes.Buffer
+		assert.NoError(t, PrintWorkflowOutputs(outputs, &buf, PrintOpts{Output: "json"}))
+		assert.Contains(t, buf.String(), `"accuracy": 0.93`)
+		assert.Contains(t, buf.String(), `"passed": true`)
+		assert.Contains(t, buf.String(), `"loss": 0.1`)
+		assert.Contains(t, buf.String(), `"key": "model.tgz"`)
+		assert.Contains(t, buf.String(), `"report": null`)
+	})
+}
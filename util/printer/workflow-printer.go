@@ -12,10 +12,14 @@ import (
 	"sigs.k8s.io/yaml"
 
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
 	"github.com/argoproj/argo-workflows/v3/workflow/util"
 )
 
 func PrintWorkflows(workflows wfv1.Workflows, out io.Writer, opts PrintOpts) error {
+	if strings.HasPrefix(opts.Output, jsonPathPrefix) {
+		return printJSONPath(workflows, out, strings.TrimPrefix(opts.Output, jsonPathPrefix))
+	}
 	if len(workflows) == 0 {
 		if opts.Output == "json" || opts.Output == "yaml" {
 			_, _ = fmt.Fprintln(out, "[]")
@@ -25,6 +29,10 @@ func PrintWorkflows(workflows wfv1.Workflows, out io.Writer, opts PrintOpts) err
 		return nil
 	}
 
+	if strings.HasPrefix(opts.Output, customColumnsPrefix) {
+		return printCustomColumns(workflows, out, strings.TrimPrefix(opts.Output, customColumnsPrefix), opts)
+	}
+
 	switch opts.Output {
 	case "", "wide":
 		printTable(workflows, out, opts)
@@ -53,6 +61,8 @@ func PrintWorkflows(workflows wfv1.Workflows, out io.Writer, opts PrintOpts) err
 
 type PrintOpts struct {
 	NoHeaders bool
+	Context   bool
+	Cluster   bool
 	Namespace bool
 	Output    string
 	UID       bool
@@ -61,12 +71,18 @@ type PrintOpts struct {
 func printTable(wfList []wfv1.Workflow, out io.Writer, opts PrintOpts) {
 	w := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
 	if !opts.NoHeaders {
+		if opts.Context {
+			_, _ = fmt.Fprint(w, "CONTEXT\t")
+		}
+		if opts.Cluster {
+			_, _ = fmt.Fprint(w, "CLUSTER\t")
+		}
 		if opts.Namespace {
 			_, _ = fmt.Fprint(w, "NAMESPACE\t")
 		}
 		_, _ = fmt.Fprint(w, "NAME\tSTATUS\tAGE\tDURATION\tPRIORITY\tMESSAGE")
 		if opts.Output == "wide" {
-			_, _ = fmt.Fprint(w, "\tP/R/C\tPARAMETERS")
+			_, _ = fmt.Fprint(w, "\tP/R/C\tETA\tPARAMETERS")
 		}
 		if opts.UID {
 			_, _ = fmt.Fprint(w, "\tUID")
@@ -77,6 +93,12 @@ func printTable(wfList []wfv1.Workflow, out io.Writer, opts PrintOpts) {
 		ageStr := humanize.RelativeDurationShort(wf.ObjectMeta.CreationTimestamp.Time, time.Now())
 		durationStr := humanize.RelativeDurationShort(wf.Status.StartedAt.Time, wf.Status.FinishedAt.Time)
 		messageStr := wf.Status.Message
+		if opts.Context {
+			_, _ = fmt.Fprintf(w, "%s\t", wf.ObjectMeta.Annotations[common.AnnotationKeyContext])
+		}
+		if opts.Cluster {
+			_, _ = fmt.Fprintf(w, "%s\t", wf.ObjectMeta.Annotations[common.AnnotationKeyCluster])
+		}
 		if opts.Namespace {
 			_, _ = fmt.Fprintf(w, "%s\t", wf.ObjectMeta.Namespace)
 		}
@@ -88,6 +110,7 @@ func printTable(wfList []wfv1.Workflow, out io.Writer, opts PrintOpts) {
 		if opts.Output == "wide" {
 			pending, running, completed := countPendingRunningCompletedNodes(&wf)
 			_, _ = fmt.Fprintf(w, "\t%d/%d/%d", pending, running, completed)
+			_, _ = fmt.Fprintf(w, "\t%s", etaString(&wf))
 			_, _ = fmt.Fprintf(w, "\t%s", parameterString(wf.Spec.Arguments.Parameters))
 		}
 		if opts.UID {
@@ -98,6 +121,20 @@ func printTable(wfList []wfv1.Workflow, out io.Writer, opts PrintOpts) {
 	_ = w.Flush()
 }
 
+// etaString returns how long until a running workflow is estimated to finish, "overdue" if it was estimated to have
+// finished already, or "-" if it is not running or there is no estimate
+func etaString(wf *wfv1.Workflow) string {
+	eta := wf.Status.EstimatedFinishedAt
+	if wf.Status.Phase != wfv1.WorkflowRunning || eta == nil {
+		return "-"
+	}
+	now := time.Now()
+	if eta.Time.Before(now) {
+		return "overdue"
+	}
+	return humanize.RelativeDurationShort(now, eta.Time)
+}
+
 // printCostOptimizationNudges prints cost optimization nudges for workflows
 func printCostOptimizationNudges(wfList []wfv1.Workflow, out io.Writer) {
 	completed, incomplete := countCompletedWorkflows(wfList)
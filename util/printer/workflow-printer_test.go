@@ -11,13 +11,14 @@ import (
 	"k8s.io/utils/pointer"
 
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
 )
 
 func TestPrintWorkflows(t *testing.T) {
 	now := time.Now()
 	workflows := wfv1.Workflows{
 		{
-			ObjectMeta: metav1.ObjectMeta{Name: "my-wf", Namespace: "my-ns", CreationTimestamp: metav1.Time{Time: now}},
+			ObjectMeta: metav1.ObjectMeta{Name: "my-wf", Namespace: "my-ns", CreationTimestamp: metav1.Time{Time: now}, Annotations: map[string]string{common.AnnotationKeyCluster: "east", common.AnnotationKeyContext: "prod"}},
 			Spec: wfv1.WorkflowSpec{
 				Arguments: wfv1.Arguments{Parameters: []wfv1.Parameter{
 					{Name: "my-param", Value: wfv1.AnyStringPtr("my-value")},
@@ -82,14 +83,38 @@ my-wf   Running   0s    3s         2          test-message
 		assert.NoError(t, PrintWorkflows(workflows, &b, PrintOpts{Namespace: true}))
 		assert.Equal(t, `NAMESPACE   NAME    STATUS    AGE   DURATION   PRIORITY   MESSAGE
 my-ns       my-wf   Running   0s    3s         2          test-message
+`, b.String())
+	})
+	t.Run("Cluster", func(t *testing.T) {
+		var b bytes.Buffer
+		assert.NoError(t, PrintWorkflows(workflows, &b, PrintOpts{Cluster: true, Namespace: true}))
+		assert.Equal(t, `CLUSTER   NAMESPACE   NAME    STATUS    AGE   DURATION   PRIORITY   MESSAGE
+east      my-ns       my-wf   Running   0s    3s         2          test-message
+`, b.String())
+	})
+	t.Run("Context", func(t *testing.T) {
+		var b bytes.Buffer
+		assert.NoError(t, PrintWorkflows(workflows, &b, PrintOpts{Context: true, Namespace: true}))
+		assert.Equal(t, `CONTEXT   NAMESPACE   NAME    STATUS    AGE   DURATION   PRIORITY   MESSAGE
+prod      my-ns       my-wf   Running   0s    3s         2          test-message
 `, b.String())
 	})
 	t.Run("Wide", func(t *testing.T) {
 		var b bytes.Buffer
 		assert.NoError(t, PrintWorkflows(workflows, &b, PrintOpts{Output: "wide"}))
-		assert.Equal(t, `NAME    STATUS    AGE   DURATION   PRIORITY   MESSAGE        P/R/C   PARAMETERS
-my-wf   Running   0s    3s         2          test-message   1/2/3   my-param=my-value
+		assert.Equal(t, `NAME    STATUS    AGE   DURATION   PRIORITY   MESSAGE        P/R/C   ETA   PARAMETERS
+my-wf   Running   0s    3s         2          test-message   1/2/3   -     my-param=my-value
+`, b.String())
+	})
+	t.Run("WideETA", func(t *testing.T) {
+		wf := workflows[0].DeepCopy()
+		wf.Status.EstimatedFinishedAt = &metav1.Time{Time: now.Add(10*time.Minute + 30*time.Second)}
+		var b bytes.Buffer
+		assert.NoError(t, PrintWorkflows(wfv1.Workflows{*wf}, &b, PrintOpts{Output: "wide", NoHeaders: true}))
+		assert.Equal(t, `my-wf   Running   0s   3s   2   test-message   1/2/3   10m   my-param=my-value
 `, b.String())
+		wf.Status.EstimatedFinishedAt = &metav1.Time{Time: now.Add(-time.Minute)}
+		assert.Equal(t, "overdue", etaString(wf))
 	})
 	t.Run("Name", func(t *testing.T) {
 		var b bytes.Buffer
@@ -107,6 +132,27 @@ my-wf   Running   0s    3s         2          test-message   1/2/3   my-param=my
 		assert.NoError(t, PrintWorkflows(workflows, &b, PrintOpts{Output: "yaml"}))
 		assert.NotEmpty(t, b.String())
 	})
+	t.Run("CustomColumns", func(t *testing.T) {
+		var b bytes.Buffer
+		assert.NoError(t, PrintWorkflows(workflows, &b, PrintOpts{Output: "custom-columns=NAME:.metadata.name,PHASE:{.status.phase},COST:metadata.labels.cost-center"}))
+		assert.Equal(t, `NAME    PHASE     COST
+my-wf   Running   <none>
+`, b.String())
+	})
+	t.Run("InvalidCustomColumns", func(t *testing.T) {
+		assert.Error(t, PrintWorkflows(workflows, &bytes.Buffer{}, PrintOpts{Output: "custom-columns=NAME"}))
+		assert.Error(t, PrintWorkflows(workflows, &bytes.Buffer{}, PrintOpts{Output: "custom-columns="}))
+	})
+	t.Run("JSONPath", func(t *testing.T) {
+		var b bytes.Buffer
+		assert.NoError(t, PrintWorkflows(workflows, &b, PrintOpts{Output: "jsonpath={range .items[*]}{.metadata.name}={.status.phase}{\"\\n\"}{end}"}))
+		assert.Equal(t, "my-wf=Running\n", b.String())
+	})
+	t.Run("EmptyJSONPath", func(t *testing.T) {
+		var b bytes.Buffer
+		assert.NoError(t, PrintWorkflows(emptyWorkflows, &b, PrintOpts{Output: "jsonpath={.items[*].metadata.name}"}))
+		assert.Empty(t, b.String())
+	})
 }
 
 func TestPrintWorkflowCostOptimizationNudges(t *testing.T) {
@@ -0,0 +1,196 @@
+package printer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"sigs.k8s.io/yaml"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+// the messages the controller sets on a retry node when it stops retrying
+const (
+	retryLimitReachedMessage   = "No more retries left"
+	maxDurationExceededMessage = "Max duration limit exceeded"
+	backoffExceedsMaxMessage   = "Backoff would exceed max duration limit"
+)
+
+// RetryInsight summarizes the attempts of one retried node
+type RetryInsight struct {
+	NodeID      string         `json:"nodeId"`
+	DisplayName string         `json:"displayName"`
+	Phase       wfv1.NodePhase `json:"phase"`
+	Attempts    int            `json:"attempts"`
+	Failures    int            `json:"failures"`
+	// Reasons are the messages of the failed attempts, most frequent first
+	Reasons []RetryFailureReason `json:"reasons,omitempty"`
+	// FailedAttemptsSeconds is the time spent running attempts that failed
+	FailedAttemptsSeconds int64 `json:"failedAttemptsSeconds"`
+	// BackoffSeconds is the time spent waiting between attempts
+	BackoffSeconds int64 `json:"backoffSeconds"`
+	// LimitReached is true if the node failed because it ran out of retries
+	LimitReached bool `json:"limitReached,omitempty"`
+	// BackoffCapped is true if the node failed because its backoff reached the retry strategy's max duration
+	BackoffCapped bool `json:"backoffCapped,omitempty"`
+}
+
+type RetryFailureReason struct {
+	Message string `json:"message"`
+	Count   int    `json:"count"`
+}
+
+// TimeLost is the time spent on failed attempts and waiting between attempts
+func (r RetryInsight) TimeLost() time.Duration {
+	return time.Duration(r.FailedAttemptsSeconds+r.BackoffSeconds) * time.Second
+}
+
+// AnalyzeRetries returns insights into each retry node of the workflow that made more than one attempt, in the order
+// they started
+func AnalyzeRetries(wf *wfv1.Workflow) []RetryInsight {
+	var nodes []wfv1.NodeStatus
+	for _, node := range wf.Status.Nodes {
+		if node.Type == wfv1.NodeTypeRetry {
+			nodes = append(nodes, node)
+		}
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		if !nodes[i].StartedAt.Equal(&nodes[j].StartedAt) {
+			return nodes[i].StartedAt.Before(&nodes[j].StartedAt)
+		}
+		return nodes[i].Name < nodes[j].Name
+	})
+	var insights []RetryInsight
+	for _, node := range nodes {
+		attempts := retryAttempts(node, wf.Status.Nodes)
+		if len(attempts) < 2 {
+			continue
+		}
+		insight := RetryInsight{
+			NodeID:        node.ID,
+			DisplayName:   node.DisplayName,
+			Phase:         node.Phase,
+			Attempts:      len(attempts),
+			LimitReached:  node.Phase.FailedOrError() && node.Message == retryLimitReachedMessage,
+			BackoffCapped: node.Phase.FailedOrError() && (node.Message == maxDurationExceededMessage || node.Message == backoffExceedsMaxMessage),
+		}
+		var failed, backoff time.Duration
+		reasons := map[string]int{}
+		for i, attempt := range attempts {
+			if attempt.FailedOrError() {
+				insight.Failures++
+				reasons[attempt.Message]++
+				if !attempt.StartedAt.IsZero() && !attempt.FinishedAt.IsZero() {
+					failed += attempt.FinishedAt.Sub(attempt.StartedAt.Time)
+				}
+			}
+			if i > 0 {
+				previous := attempts[i-1]
+				if !previous.FinishedAt.IsZero() && attempt.StartedAt.After(previous.FinishedAt.Time) {
+					backoff += attempt.StartedAt.Sub(previous.FinishedAt.Time)
+				}
+			}
+		}
+		for message, count := range reasons {
+			insight.Reasons = append(insight.Reasons, RetryFailureReason{Message: message, Count: count})
+		}
+		sort.Slice(insight.Reasons, func(i, j int) bool {
+			if insight.Reasons[i].Count != insight.Reasons[j].Count {
+				return insight.Reasons[i].Count > insight.Reasons[j].Count
+			}
+			return insight.Reasons[i].Message < insight.Reasons[j].Message
+		})
+		insight.FailedAttemptsSeconds = int64(failed.Round(time.Second).Seconds())
+		insight.BackoffSeconds = int64(backoff.Round(time.Second).Seconds())
+		insights = append(insights, insight)
+	}
+	return insights
+}
+
+// retryAttempts returns the attempts of a retry node, in order. Children that are not attempts, such as hooks, are
+// flagged as such in workflows created since node flags were introduced.
+func retryAttempts(node wfv1.NodeStatus, nodes wfv1.Nodes) []wfv1.NodeStatus {
+	var attempts, children []wfv1.NodeStatus
+	for _, id := range node.Children {
+		child, ok := nodes[id]
+		if !ok {
+			continue
+		}
+		children = append(children, child)
+		if child.NodeFlag != nil && child.NodeFlag.Retried {
+			attempts = append(attempts, child)
+		}
+	}
+	if len(attempts) == 0 {
+		return children
+	}
+	return attempts
+}
+
+func PrintRetryInsights(insights []RetryInsight, out io.Writer, opts PrintOpts) error {
+	switch opts.Output {
+	case "", "wide":
+		w := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+		if !opts.NoHeaders {
+			_, _ = fmt.Fprint(w, "NODE\tPHASE\tATTEMPTS\tFAILURES\tTIME LOST\tBACKOFF\tOUTCOME\tREASON")
+			if opts.Output == "wide" {
+				_, _ = fmt.Fprint(w, "\tID")
+			}
+			_, _ = fmt.Fprintln(w)
+		}
+		for _, r := range insights {
+			reason := "-"
+			if len(r.Reasons) > 0 {
+				reason = fmt.Sprintf("%s (x%d)", r.Reasons[0].Message, r.Reasons[0].Count)
+				if opts.Output == "wide" {
+					var reasons []string
+					for _, x := range r.Reasons {
+						reasons = append(reasons, fmt.Sprintf("%s (x%d)", x.Message, x.Count))
+					}
+					reason = strings.Join(reasons, ", ")
+				}
+			}
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%s\t%s\t%s\t%s", r.DisplayName, r.Phase, r.Attempts, r.Failures, r.TimeLost(), time.Duration(r.BackoffSeconds)*time.Second, retryOutcome(r), reason)
+			if opts.Output == "wide" {
+				_, _ = fmt.Fprintf(w, "\t%s", r.NodeID)
+			}
+			_, _ = fmt.Fprintln(w)
+		}
+		_ = w.Flush()
+	case "json":
+		output, err := json.MarshalIndent(insights, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintln(out, string(output))
+	case "yaml":
+		output, err := yaml.Marshal(insights)
+		if err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintln(out, string(output))
+	default:
+		return fmt.Errorf("unknown output mode: %s", opts.Output)
+	}
+	return nil
+}
+
+func retryOutcome(r RetryInsight) string {
+	switch {
+	case r.LimitReached:
+		return "retry limit reached"
+	case r.BackoffCapped:
+		return "backoff max duration reached"
+	case r.Phase == wfv1.NodeSucceeded:
+		return "succeeded after retries"
+	case r.Phase.Fulfilled():
+		return "stopped retrying"
+	default:
+		return "retrying"
+	}
+}
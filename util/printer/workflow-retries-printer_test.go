@@ -0,0 +1,60 @@
+package printer
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+func TestAnalyzeRetries(t *testing.T) {
+	now := time.Now()
+	attempt := func(id string, phase wfv1.NodePhase, message string, start, end time.Duration) wfv1.NodeStatus {
+		return wfv1.NodeStatus{
+			ID: id, Name: id, Type: wfv1.NodeTypePod, Phase: phase, Message: message,
+			StartedAt: metav1.Time{Time: now.Add(start)}, FinishedAt: metav1.Time{Time: now.Add(end)},
+			NodeFlag: &wfv1.NodeFlag{Retried: true},
+		}
+	}
+	wf := &wfv1.Workflow{Status: wfv1.WorkflowStatus{Nodes: wfv1.Nodes{
+		"flaky":    {ID: "flaky", DisplayName: "flaky", Type: wfv1.NodeTypeRetry, Phase: wfv1.NodeSucceeded, StartedAt: metav1.Time{Time: now}, Children: []string{"flaky-0", "flaky-1", "flaky-2"}},
+		"flaky-0":  attempt("flaky-0", wfv1.NodeFailed, "OOMKilled (exit code 137)", 0, time.Minute),
+		"flaky-1":  attempt("flaky-1", wfv1.NodeFailed, "OOMKilled (exit code 137)", 2*time.Minute, 3*time.Minute),
+		"flaky-2":  attempt("flaky-2", wfv1.NodeSucceeded, "", 5*time.Minute, 6*time.Minute),
+		"broken":   {ID: "broken", DisplayName: "broken", Type: wfv1.NodeTypeRetry, Phase: wfv1.NodeFailed, Message: "No more retries left", StartedAt: metav1.Time{Time: now.Add(time.Second)}, Children: []string{"broken-0", "broken-1"}},
+		"broken-0": attempt("broken-0", wfv1.NodeFailed, "Error (exit code 1)", time.Second, 11*time.Second),
+		"broken-1": attempt("broken-1", wfv1.NodeError, "pod deleted", 11*time.Second, 21*time.Second),
+		"once":     {ID: "once", DisplayName: "once", Type: wfv1.NodeTypeRetry, Phase: wfv1.NodeSucceeded, Children: []string{"once-0"}},
+		"once-0":   attempt("once-0", wfv1.NodeSucceeded, "", 0, time.Minute),
+	}}}
+
+	insights := AnalyzeRetries(wf)
+	if assert.Len(t, insights, 2) {
+		flaky := insights[0]
+		assert.Equal(t, "flaky", flaky.DisplayName)
+		assert.Equal(t, 3, flaky.Attempts)
+		assert.Equal(t, 2, flaky.Failures)
+		assert.Equal(t, []RetryFailureReason{{Message: "OOMKilled (exit code 137)", Count: 2}}, flaky.Reasons)
+		assert.Equal(t, int64(120), flaky.FailedAttemptsSeconds)
+		assert.Equal(t, int64(180), flaky.BackoffSeconds)
+		assert.Equal(t, 5*time.Minute, flaky.TimeLost())
+		assert.False(t, flaky.LimitReached)
+
+		broken := insights[1]
+		assert.Equal(t, "broken", broken.DisplayName)
+		assert.Equal(t, 2, broken.Failures)
+		assert.Len(t, broken.Reasons, 2)
+		assert.Equal(t, int64(0), broken.BackoffSeconds)
+		assert.True(t, broken.LimitReached)
+		assert.False(t, broken.BackoffCapped)
+	}
+
+	var buf bytes.Buffer
+	assert.NoError(t, PrintRetryInsights(insights, &buf, PrintOpts{}))
+	assert.Contains(t, buf.String(), "OOMKilled (exit code 137) (x2)")
+	assert.Contains(t, buf.String(), "retry limit reached")
+}
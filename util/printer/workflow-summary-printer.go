@@ -0,0 +1,148 @@
+package printer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"sigs.k8s.io/yaml"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+const (
+	GroupByWorkflowTemplate = "workflowTemplate"
+	GroupByCronWorkflow     = "cronWorkflow"
+	GroupByNamespace        = "namespace"
+	groupByLabelPrefix      = "label:"
+
+	noGroup = "<none>"
+)
+
+// WorkflowSummary aggregates the workflows in one group
+type WorkflowSummary struct {
+	Group     string `json:"group"`
+	Total     int    `json:"total"`
+	Running   int    `json:"running"`
+	Succeeded int    `json:"succeeded"`
+	Failed    int    `json:"failed"`
+	// SuccessRate is the percentage of completed workflows that succeeded, nil if none completed
+	SuccessRate *float64 `json:"successRate,omitempty"`
+	// AverageDurationSeconds is the average duration of completed workflows
+	AverageDurationSeconds int64 `json:"averageDurationSeconds,omitempty"`
+	totalDuration          time.Duration
+}
+
+// WorkflowGroupFunc returns the func that gives the group of a workflow for the --group-by value.
+// An empty value puts all workflows in one group.
+func WorkflowGroupFunc(groupBy string) (func(wf wfv1.Workflow) string, error) {
+	switch {
+	case groupBy == "":
+		return func(wfv1.Workflow) string { return "<all>" }, nil
+	case groupBy == GroupByWorkflowTemplate:
+		return func(wf wfv1.Workflow) string {
+			if name, ok := wf.Labels[common.LabelKeyWorkflowTemplate]; ok {
+				return name
+			}
+			if name, ok := wf.Labels[common.LabelKeyClusterWorkflowTemplate]; ok {
+				return name
+			}
+			if wf.Spec.WorkflowTemplateRef != nil {
+				return wf.Spec.WorkflowTemplateRef.Name
+			}
+			return noGroup
+		}, nil
+	case groupBy == GroupByCronWorkflow:
+		return labelGroup(common.LabelKeyCronWorkflow), nil
+	case groupBy == GroupByNamespace:
+		return func(wf wfv1.Workflow) string { return wf.Namespace }, nil
+	case strings.HasPrefix(groupBy, groupByLabelPrefix) && len(groupBy) > len(groupByLabelPrefix):
+		return labelGroup(strings.TrimPrefix(groupBy, groupByLabelPrefix)), nil
+	default:
+		return nil, fmt.Errorf("unknown group by %q, must be one of %s, %s, %s or %s<KEY>", groupBy, GroupByWorkflowTemplate, GroupByCronWorkflow, GroupByNamespace, groupByLabelPrefix)
+	}
+}
+
+func labelGroup(key string) func(wf wfv1.Workflow) string {
+	return func(wf wfv1.Workflow) string {
+		if value, ok := wf.Labels[key]; ok {
+			return value
+		}
+		return noGroup
+	}
+}
+
+// SummarizeWorkflows aggregates the workflows by group, sorted by group
+func SummarizeWorkflows(workflows wfv1.Workflows, groupFunc func(wf wfv1.Workflow) string) []WorkflowSummary {
+	byGroup := map[string]*WorkflowSummary{}
+	for _, wf := range workflows {
+		group := groupFunc(wf)
+		s, ok := byGroup[group]
+		if !ok {
+			s = &WorkflowSummary{Group: group}
+			byGroup[group] = s
+		}
+		s.Total++
+		switch wf.Status.Phase {
+		case wfv1.WorkflowSucceeded:
+			s.Succeeded++
+		case wfv1.WorkflowFailed, wfv1.WorkflowError:
+			s.Failed++
+		default:
+			s.Running++
+		}
+		if wf.Status.Phase.Completed() && !wf.Status.StartedAt.IsZero() && !wf.Status.FinishedAt.IsZero() {
+			s.totalDuration += wf.Status.FinishedAt.Sub(wf.Status.StartedAt.Time)
+		}
+	}
+	summaries := make([]WorkflowSummary, 0, len(byGroup))
+	for _, s := range byGroup {
+		if completed := s.Succeeded + s.Failed; completed > 0 {
+			rate := float64(s.Succeeded) * 100 / float64(completed)
+			s.SuccessRate = &rate
+			s.AverageDurationSeconds = int64((s.totalDuration / time.Duration(completed)).Round(time.Second).Seconds())
+		}
+		summaries = append(summaries, *s)
+	}
+	sort.Slice(summaries, func(i, j int) bool { return summaries[i].Group < summaries[j].Group })
+	return summaries
+}
+
+func PrintWorkflowSummaries(summaries []WorkflowSummary, out io.Writer, opts PrintOpts) error {
+	switch opts.Output {
+	case "", "wide":
+		w := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+		if !opts.NoHeaders {
+			_, _ = fmt.Fprintln(w, "GROUP\tTOTAL\tRUNNING\tSUCCEEDED\tFAILED\tSUCCESS RATE\tAVG DURATION")
+		}
+		for _, s := range summaries {
+			rate, duration := "-", "-"
+			if s.SuccessRate != nil {
+				rate = fmt.Sprintf("%.0f%%", *s.SuccessRate)
+				duration = (time.Duration(s.AverageDurationSeconds) * time.Second).String()
+			}
+			_, _ = fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%d\t%s\t%s\n", s.Group, s.Total, s.Running, s.Succeeded, s.Failed, rate, duration)
+		}
+		_ = w.Flush()
+	case "json":
+		output, err := json.MarshalIndent(summaries, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintln(out, string(output))
+	case "yaml":
+		output, err := yaml.Marshal(summaries)
+		if err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintln(out, string(output))
+	default:
+		return fmt.Errorf("unknown output mode: %s", opts.Output)
+	}
+	return nil
+}
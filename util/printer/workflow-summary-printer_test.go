@@ -0,0 +1,69 @@
+package printer
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+func TestSummarizeWorkflows(t *testing.T) {
+	now := time.Now()
+	completed := func(phase wfv1.WorkflowPhase, d time.Duration) wfv1.WorkflowStatus {
+		return wfv1.WorkflowStatus{Phase: phase, StartedAt: metav1.Time{Time: now}, FinishedAt: metav1.Time{Time: now.Add(d)}}
+	}
+	workflows := wfv1.Workflows{
+		{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{common.LabelKeyWorkflowTemplate: "etl", "team": "data"}}, Status: completed(wfv1.WorkflowSucceeded, time.Minute)},
+		{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{common.LabelKeyWorkflowTemplate: "etl", "team": "data"}}, Status: completed(wfv1.WorkflowFailed, 3*time.Minute)},
+		{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{common.LabelKeyWorkflowTemplate: "etl"}}, Status: wfv1.WorkflowStatus{Phase: wfv1.WorkflowRunning}},
+		{Spec: wfv1.WorkflowSpec{WorkflowTemplateRef: &wfv1.WorkflowTemplateRef{Name: "train"}}, Status: completed(wfv1.WorkflowSucceeded, time.Hour)},
+		{Status: wfv1.WorkflowStatus{Phase: wfv1.WorkflowPending}},
+	}
+
+	t.Run("WorkflowTemplate", func(t *testing.T) {
+		groupFunc, err := WorkflowGroupFunc(GroupByWorkflowTemplate)
+		assert.NoError(t, err)
+		summaries := SummarizeWorkflows(workflows, groupFunc)
+		if assert.Len(t, summaries, 3) {
+			assert.Equal(t, "<none>", summaries[0].Group)
+			assert.Nil(t, summaries[0].SuccessRate)
+			etl := summaries[1]
+			assert.Equal(t, "etl", etl.Group)
+			assert.Equal(t, 3, etl.Total)
+			assert.Equal(t, 1, etl.Running)
+			assert.Equal(t, 1, etl.Succeeded)
+			assert.Equal(t, 1, etl.Failed)
+			assert.Equal(t, 50.0, *etl.SuccessRate)
+			assert.Equal(t, int64(120), etl.AverageDurationSeconds)
+			assert.Equal(t, "train", summaries[2].Group)
+		}
+	})
+	t.Run("Label", func(t *testing.T) {
+		groupFunc, err := WorkflowGroupFunc("label:team")
+		assert.NoError(t, err)
+		summaries := SummarizeWorkflows(workflows, groupFunc)
+		if assert.Len(t, summaries, 2) {
+			assert.Equal(t, "<none>", summaries[0].Group)
+			assert.Equal(t, 3, summaries[0].Total)
+			assert.Equal(t, "data", summaries[1].Group)
+			assert.Equal(t, 2, summaries[1].Total)
+		}
+	})
+	t.Run("Unknown", func(t *testing.T) {
+		_, err := WorkflowGroupFunc("label:")
+		assert.Error(t, err)
+	})
+	t.Run("Print", func(t *testing.T) {
+		groupFunc, _ := WorkflowGroupFunc("")
+		b := &bytes.Buffer{}
+		assert.NoError(t, PrintWorkflowSummaries(SummarizeWorkflows(workflows, groupFunc), b, PrintOpts{}))
+		assert.Equal(t, `GROUP   TOTAL   RUNNING   SUCCEEDED   FAILED   SUCCESS RATE   AVG DURATION
+<all>   5       2         2           1        67%            21m20s
+`, b.String())
+	})
+}
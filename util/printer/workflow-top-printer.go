@@ -0,0 +1,149 @@
+package printer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"sigs.k8s.io/yaml"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+// NodeUsage is the live resource usage of the pod of a running node, and the resources its containers request
+type NodeUsage struct {
+	NodeID      string `json:"nodeId"`
+	DisplayName string `json:"displayName"`
+	PodName     string `json:"podName"`
+	// HasMetrics is false if the metrics server has no metrics of the pod yet, e.g. because it just started
+	HasMetrics    bool              `json:"hasMetrics"`
+	CPU           resource.Quantity `json:"cpu"`
+	Memory        resource.Quantity `json:"memory"`
+	CPURequest    resource.Quantity `json:"cpuRequest"`
+	MemoryRequest resource.Quantity `json:"memoryRequest"`
+	CPULimit      resource.Quantity `json:"cpuLimit"`
+	MemoryLimit   resource.Quantity `json:"memoryLimit"`
+}
+
+// GetNodeUsages joins the running pod nodes of the workflow with their pods and the usage of each pod, keyed by pod
+// name, in the order the nodes started
+func GetNodeUsages(wf *wfv1.Workflow, pods []apiv1.Pod, usages map[string]apiv1.ResourceList) []NodeUsage {
+	podsByNodeID := make(map[string]apiv1.Pod, len(pods))
+	for _, pod := range pods {
+		podsByNodeID[pod.Annotations[common.AnnotationKeyNodeID]] = pod
+	}
+	var nodes []wfv1.NodeStatus
+	for _, node := range wf.Status.Nodes {
+		if _, ok := podsByNodeID[node.ID]; ok && node.Type == wfv1.NodeTypePod && node.Phase == wfv1.NodeRunning {
+			nodes = append(nodes, node)
+		}
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		if !nodes[i].StartedAt.Equal(&nodes[j].StartedAt) {
+			return nodes[i].StartedAt.Before(&nodes[j].StartedAt)
+		}
+		return nodes[i].ID < nodes[j].ID
+	})
+	var result []NodeUsage
+	for _, node := range nodes {
+		pod := podsByNodeID[node.ID]
+		u := NodeUsage{NodeID: node.ID, DisplayName: node.DisplayName, PodName: pod.Name}
+		if usage, ok := usages[pod.Name]; ok {
+			u.HasMetrics = true
+			u.CPU = usage[apiv1.ResourceCPU]
+			u.Memory = usage[apiv1.ResourceMemory]
+		}
+		for _, c := range pod.Spec.Containers {
+			u.CPURequest.Add(c.Resources.Requests[apiv1.ResourceCPU])
+			u.MemoryRequest.Add(c.Resources.Requests[apiv1.ResourceMemory])
+			u.CPULimit.Add(c.Resources.Limits[apiv1.ResourceCPU])
+			u.MemoryLimit.Add(c.Resources.Limits[apiv1.ResourceMemory])
+		}
+		result = append(result, u)
+	}
+	return result
+}
+
+// SortNodeUsages sorts the usages by "cpu" or "memory", highest first, or by "name", keeping the order they started
+// if sortBy is empty
+func SortNodeUsages(usages []NodeUsage, sortBy string) error {
+	var less func(a, b NodeUsage) bool
+	switch sortBy {
+	case "":
+		return nil
+	case "cpu":
+		less = func(a, b NodeUsage) bool { return a.CPU.Cmp(b.CPU) > 0 }
+	case "memory":
+		less = func(a, b NodeUsage) bool { return a.Memory.Cmp(b.Memory) > 0 }
+	case "name":
+		less = func(a, b NodeUsage) bool { return a.DisplayName < b.DisplayName }
+	default:
+		return fmt.Errorf("unknown sort: %s, must be one of: cpu|memory|name", sortBy)
+	}
+	sort.SliceStable(usages, func(i, j int) bool { return less(usages[i], usages[j]) })
+	return nil
+}
+
+func PrintNodeUsages(usages []NodeUsage, out io.Writer, opts PrintOpts) error {
+	switch opts.Output {
+	case "", "wide":
+		w := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+		if !opts.NoHeaders {
+			_, _ = fmt.Fprint(w, "NODE\tPOD\tCPU(cores)\tCPU REQUEST\tMEMORY(bytes)\tMEMORY REQUEST")
+			if opts.Output == "wide" {
+				_, _ = fmt.Fprint(w, "\tCPU LIMIT\tMEMORY LIMIT\tID")
+			}
+			_, _ = fmt.Fprintln(w)
+		}
+		for _, u := range usages {
+			cpu, memory := "-", "-"
+			if u.HasMetrics {
+				cpu, memory = formatCPU(u.CPU), formatMemory(u.Memory)
+			}
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s", u.DisplayName, u.PodName, cpu, formatResource(u.CPURequest, formatCPU), memory, formatResource(u.MemoryRequest, formatMemory))
+			if opts.Output == "wide" {
+				_, _ = fmt.Fprintf(w, "\t%s\t%s\t%s", formatResource(u.CPULimit, formatCPU), formatResource(u.MemoryLimit, formatMemory), u.NodeID)
+			}
+			_, _ = fmt.Fprintln(w)
+		}
+		_ = w.Flush()
+	case "json":
+		output, err := json.MarshalIndent(usages, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintln(out, string(output))
+	case "yaml":
+		output, err := yaml.Marshal(usages)
+		if err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintln(out, string(output))
+	default:
+		return fmt.Errorf("unknown output mode: %s", opts.Output)
+	}
+	return nil
+}
+
+// formatCPU returns the CPU in millicores, as `kubectl top` does
+func formatCPU(q resource.Quantity) string {
+	return fmt.Sprintf("%dm", q.MilliValue())
+}
+
+// formatMemory returns the memory in mebibytes, as `kubectl top` does
+func formatMemory(q resource.Quantity) string {
+	return fmt.Sprintf("%dMi", q.Value()/(1024*1024))
+}
+
+// formatResource returns the formatted request or limit, or "-" if none is set
+func formatResource(q resource.Quantity, format func(resource.Quantity) string) string {
+	if q.IsZero() {
+		return "-"
+	}
+	return format(q)
+}
@@ -0,0 +1,65 @@
+package printer
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+func TestNodeUsages(t *testing.T) {
+	now := time.Now()
+	node := func(id string, phase wfv1.NodePhase, start time.Duration) wfv1.NodeStatus {
+		return wfv1.NodeStatus{ID: id, DisplayName: id, Type: wfv1.NodeTypePod, Phase: phase, StartedAt: metav1.Time{Time: now.Add(start)}}
+	}
+	wf := &wfv1.Workflow{Status: wfv1.WorkflowStatus{Nodes: wfv1.Nodes{
+		"train":    node("train", wfv1.NodeRunning, time.Minute),
+		"prepare":  node("prepare", wfv1.NodeRunning, 0),
+		"starting": node("starting", wfv1.NodeRunning, 2*time.Minute),
+		"done":     node("done", wfv1.NodeSucceeded, 0),
+	}}}
+	pod := func(nodeID, cpu, memory string) apiv1.Pod {
+		return apiv1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-wf-" + nodeID, Annotations: map[string]string{common.AnnotationKeyNodeID: nodeID}},
+			Spec: apiv1.PodSpec{Containers: []apiv1.Container{
+				{Name: common.WaitContainerName},
+				{Name: common.MainContainerName, Resources: apiv1.ResourceRequirements{Requests: apiv1.ResourceList{
+					apiv1.ResourceCPU:    resource.MustParse(cpu),
+					apiv1.ResourceMemory: resource.MustParse(memory),
+				}}},
+			}},
+		}
+	}
+	pods := []apiv1.Pod{pod("train", "2", "4Gi"), pod("prepare", "500m", "1Gi"), pod("starting", "1", "1Gi"), pod("done", "1", "1Gi")}
+	usages := map[string]apiv1.ResourceList{
+		"my-wf-train":   {apiv1.ResourceCPU: resource.MustParse("1500m"), apiv1.ResourceMemory: resource.MustParse("3Gi")},
+		"my-wf-prepare": {apiv1.ResourceCPU: resource.MustParse("1800m"), apiv1.ResourceMemory: resource.MustParse("512Mi")},
+	}
+
+	nodeUsages := GetNodeUsages(wf, pods, usages)
+	require.Len(t, nodeUsages, 3)
+	assert.Equal(t, []string{"prepare", "train", "starting"}, []string{nodeUsages[0].DisplayName, nodeUsages[1].DisplayName, nodeUsages[2].DisplayName})
+	assert.False(t, nodeUsages[2].HasMetrics)
+
+	require.NoError(t, SortNodeUsages(nodeUsages, "memory"))
+	assert.Equal(t, "train", nodeUsages[0].DisplayName)
+	require.NoError(t, SortNodeUsages(nodeUsages, "cpu"))
+	assert.Equal(t, "prepare", nodeUsages[0].DisplayName)
+	assert.EqualError(t, SortNodeUsages(nodeUsages, "disk"), "unknown sort: disk, must be one of: cpu|memory|name")
+
+	var out bytes.Buffer
+	require.NoError(t, PrintNodeUsages(nodeUsages, &out, PrintOpts{}))
+	assert.Equal(t, `NODE       POD              CPU(cores)   CPU REQUEST   MEMORY(bytes)   MEMORY REQUEST
+prepare    my-wf-prepare    1800m        500m          512Mi           1024Mi
+train      my-wf-train      1500m        2000m         3072Mi          4096Mi
+starting   my-wf-starting   -            1000m         -               1024Mi
+`, out.String())
+}
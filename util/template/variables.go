@@ -0,0 +1,87 @@
+package template
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/antonmedv/expr/file"
+	"github.com/antonmedv/expr/parser/lexer"
+	"github.com/valyala/fasttemplate"
+)
+
+// Variables returns the variables referenced by the template, in the order they first appear. The variables of an
+// expression tag are the variables it uses, e.g. inputs.parameters.message for {{=inputs.parameters.message}}.
+func Variables(s string) ([]string, error) {
+	t, err := fasttemplate.NewTemplate(s, prefix, suffix)
+	if err != nil {
+		return nil, err
+	}
+	var variables []string
+	seen := make(map[string]bool)
+	add := func(variable string) {
+		if variable != "" && !seen[variable] {
+			seen[variable] = true
+			variables = append(variables, variable)
+		}
+	}
+	_, err = t.ExecuteFunc(io.Discard, func(w io.Writer, tag string) (int, error) {
+		kind, expression := parseTag(tag)
+		switch kind {
+		case kindExpression:
+			for _, variable := range expressionVariables(expression) {
+				add(variable)
+			}
+		default:
+			add(strings.TrimSpace(tag))
+		}
+		return 0, nil
+	})
+	return variables, err
+}
+
+// expressionVariables returns the variables an expression uses, i.e. the identifiers it does not call, joined with
+// the fields and string indexes they are accessed with
+func expressionVariables(expression string) []string {
+	// The template is usually JSON-marshaled. This JSON-unmarshals the expression to undo any character escapes.
+	var unmarshalledExpression string
+	if err := json.Unmarshal([]byte(fmt.Sprintf(`"%s"`, expression)), &unmarshalledExpression); err != nil {
+		unmarshalledExpression = expression
+	}
+	tokens, err := lexer.Lex(file.NewSource(unmarshalledExpression))
+	if err != nil {
+		return nil
+	}
+	is := func(i int, kind lexer.Kind, value string) bool {
+		return i >= 0 && i < len(tokens) && tokens[i].Kind == kind && (value == "" || tokens[i].Value == value)
+	}
+	var variables []string
+	for i := 0; i < len(tokens); i++ {
+		if !is(i, lexer.Identifier, "") || is(i-1, lexer.Operator, ".") {
+			continue
+		}
+		variable := tokens[i].Value
+		j := i + 1
+		for {
+			if is(j, lexer.Operator, ".") && is(j+1, lexer.Identifier, "") {
+				variable += "." + tokens[j+1].Value
+				j += 2
+			} else if is(j, lexer.Bracket, "[") && is(j+1, lexer.String, "") && is(j+2, lexer.Bracket, "]") {
+				variable += "." + tokens[j+1].Value
+				j += 3
+			} else {
+				break
+			}
+		}
+		switch {
+		case is(j, lexer.Bracket, "("):
+			// a function, e.g. sprig.trim
+		case variable == "true" || variable == "false" || variable == "nil":
+		default:
+			variables = append(variables, variable)
+		}
+		i = j - 1
+	}
+	return variables
+}
@@ -0,0 +1,29 @@
+package template
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Variables(t *testing.T) {
+	t.Run("InvalidTemplate", func(t *testing.T) {
+		_, err := Variables("{{")
+		assert.Error(t, err)
+	})
+	t.Run("Simple", func(t *testing.T) {
+		variables, err := Variables(`{"args": ["{{inputs.parameters.message}}", "{{ workflow.name }}", "{{inputs.parameters.message}}"]}`)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"inputs.parameters.message", "workflow.name"}, variables)
+	})
+	t.Run("Expression", func(t *testing.T) {
+		variables, err := Variables(`{"args": ["{{=sprig.trim(inputs.parameters['my-param']) + item.name == \"x\" ? true : retries}}"]}`)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"inputs.parameters.my-param", "item.name", "retries"}, variables)
+	})
+	t.Run("None", func(t *testing.T) {
+		variables, err := Variables(`{"args": ["hello"]}`)
+		assert.NoError(t, err)
+		assert.Empty(t, variables)
+	})
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	gohttp "net/http"
 
+	log "github.com/sirupsen/logrus"
+
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 	"github.com/argoproj/argo-workflows/v3/workflow/artifacts/azure"
 	"github.com/argoproj/argo-workflows/v3/workflow/artifacts/common"
@@ -29,6 +31,9 @@ func NewDriver(ctx context.Context, art *wfv1.Artifact, ri resource.Interface) (
 	if err != nil {
 		return nil, err
 	}
+	if limit := common.BandwidthLimitFromContext(ctx); !limit.IsZero() && art.S3 == nil && art.HTTP == nil && art.Artifactory == nil {
+		log.Warnf("Bandwidth limits are only enforced for S3, HTTP and Artifactory artifacts, artifact %s will not be throttled", art.Name)
+	}
 	return logging.New(drv), nil
 
 }
@@ -94,6 +99,7 @@ func newDriver(ctx context.Context, art *wfv1.Artifact, ri resource.Interface) (
 			KmsEncryptionContext:  kmsEncryptionContext,
 			EnableEncryption:      enableEncryption,
 			ServerSideCustomerKey: serverSideCustomerKey,
+			Bandwidth:             common.BandwidthLimitFromContext(ctx),
 		}
 
 		return &driver, nil
@@ -144,10 +150,25 @@ func newDriver(ctx context.Context, art *wfv1.Artifact, ri resource.Interface) (
 				return nil, err
 			}
 		}
+		for _, h := range art.HTTP.Headers {
+			if h.ValueFrom == nil || h.ValueFrom.SecretKeyRef == nil {
+				continue
+			}
+			value, err := ri.GetSecret(ctx, h.ValueFrom.SecretKeyRef.Name, h.ValueFrom.SecretKeyRef.Key)
+			if err != nil {
+				return nil, err
+			}
+			if driver.SecretHeaders == nil {
+				driver.SecretHeaders = map[string]string{}
+			}
+			driver.SecretHeaders[h.Name] = value
+		}
 		if client == nil {
 			client = &gohttp.Client{}
 		}
+		http.ThrottleClient(client, common.BandwidthLimitFromContext(ctx))
 		driver.Client = client
+		driver.CacheDir = common.CacheDirFromContext(ctx)
 		return &driver, nil
 	}
 	if art.Git != nil {
@@ -193,6 +214,7 @@ func newDriver(ctx context.Context, art *wfv1.Artifact, ri resource.Interface) (
 			Password: passwordBytes,
 			Client:   &gohttp.Client{},
 		}
+		http.ThrottleClient(driver.Client, common.BandwidthLimitFromContext(ctx))
 		return &driver, nil
 
 	}
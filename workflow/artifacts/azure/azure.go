@@ -425,6 +425,20 @@ func (azblobDriver *ArtifactDriver) IsDirectory(artifact *wfv1.Artifact) (bool,
 	return false, nil
 }
 
+// CheckRepository checks that the artifact's container exists and its blobs can be listed, by listing at most one
+func (azblobDriver *ArtifactDriver) CheckRepository(artifact *wfv1.Artifact) error {
+	containerClient, err := azblobDriver.newAzureContainerClient()
+	if err != nil {
+		return fmt.Errorf("unable to create Azure Blob Container client: %s", err)
+	}
+	maxResults := int32(1)
+	pager := containerClient.NewListBlobsFlatPager(&azblob.ListBlobsFlatOptions{MaxResults: &maxResults})
+	if _, err := pager.NextPage(context.TODO()); err != nil {
+		return fmt.Errorf("error listing blobs in Azure Blob Storage container %s: %s", artifact.Azure.Container, err)
+	}
+	return nil
+}
+
 type uploadTask struct {
 	blobName string
 	path     string
@@ -0,0 +1,130 @@
+package common
+
+import (
+	"context"
+	"io"
+	"net/http"
+
+	"golang.org/x/time/rate"
+)
+
+// maxBurst caps how many bytes a throttled connection may move in one go
+const maxBurst = 1024 * 1024
+
+// BandwidthLimit is the maximum rate, in bytes per second, at which drivers transfer artifacts.
+// Zero means unlimited.
+type BandwidthLimit struct {
+	Upload   int64
+	Download int64
+	// the limiters shared by the transports throttled to a limit made with NewBandwidthLimit
+	upload   *rate.Limiter
+	download *rate.Limiter
+}
+
+// NewBandwidthLimit returns a limit that all the transports throttled to it share, so that together they transfer
+// at no more than the limit
+func NewBandwidthLimit(upload, download int64) BandwidthLimit {
+	return BandwidthLimit{Upload: upload, Download: download, upload: newLimiter(upload), download: newLimiter(download)}
+}
+
+// IsZero returns true if there is no limit in either direction
+func (l BandwidthLimit) IsZero() bool {
+	return l.Upload <= 0 && l.Download <= 0
+}
+
+type bandwidthLimitKey struct{}
+
+// WithBandwidthLimit returns a context which drivers created with it will throttle their transfers to
+func WithBandwidthLimit(ctx context.Context, limit BandwidthLimit) context.Context {
+	return context.WithValue(ctx, bandwidthLimitKey{}, limit)
+}
+
+// BandwidthLimitFromContext returns the limit set with WithBandwidthLimit, if any
+func BandwidthLimitFromContext(ctx context.Context) BandwidthLimit {
+	limit, _ := ctx.Value(bandwidthLimitKey{}).(BandwidthLimit)
+	return limit
+}
+
+// ThrottleTransport wraps the transport so that, across all its requests, request bodies are sent at no more than the
+// upload limit and response bodies are received at no more than the download limit. A limit made with
+// NewBandwidthLimit is shared with the other transports throttled to it. Waiting for the limit stops as soon as the
+// request's context is done, so a cancelled transfer is not held up.
+func (l BandwidthLimit) ThrottleTransport(tr http.RoundTripper) http.RoundTripper {
+	if l.IsZero() {
+		return tr
+	}
+	upload, download := l.upload, l.download
+	if upload == nil {
+		upload = newLimiter(l.Upload)
+	}
+	if download == nil {
+		download = newLimiter(l.Download)
+	}
+	return &throttledTransport{base: tr, upload: upload, download: download}
+}
+
+func newLimiter(bytesPerSecond int64) *rate.Limiter {
+	if bytesPerSecond <= 0 {
+		return nil
+	}
+	burst := maxBurst
+	if bytesPerSecond < maxBurst {
+		burst = int(bytesPerSecond)
+	}
+	return rate.NewLimiter(rate.Limit(bytesPerSecond), burst)
+}
+
+type throttledTransport struct {
+	base     http.RoundTripper
+	upload   *rate.Limiter
+	download *rate.Limiter
+}
+
+func (t *throttledTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	if t.upload != nil && req.Body != nil && req.Body != http.NoBody {
+		// a round tripper must not modify the request it is given
+		req = req.Clone(ctx)
+		req.Body = throttle(ctx, req.Body, t.upload)
+		if getBody := req.GetBody; getBody != nil {
+			req.GetBody = func() (io.ReadCloser, error) {
+				body, err := getBody()
+				if err != nil {
+					return nil, err
+				}
+				return throttle(ctx, body, t.upload), nil
+			}
+		}
+	}
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	if t.download != nil && resp.Body != nil {
+		resp.Body = throttle(ctx, resp.Body, t.download)
+	}
+	return resp, nil
+}
+
+func throttle(ctx context.Context, body io.ReadCloser, limiter *rate.Limiter) io.ReadCloser {
+	return &throttledReader{ReadCloser: body, ctx: ctx, limiter: limiter}
+}
+
+type throttledReader struct {
+	io.ReadCloser
+	ctx     context.Context
+	limiter *rate.Limiter
+}
+
+func (r *throttledReader) Read(b []byte) (int, error) {
+	if len(b) > r.limiter.Burst() {
+		b = b[:r.limiter.Burst()]
+	}
+	n, err := r.ReadCloser.Read(b)
+	if n > 0 {
+		if werr := r.limiter.WaitN(r.ctx, n); werr != nil && err == nil {
+			err = werr
+		}
+	}
+	return n, err
+}
@@ -0,0 +1,80 @@
+package common
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBandwidthLimitFromContext(t *testing.T) {
+	ctx := context.Background()
+	assert.True(t, BandwidthLimitFromContext(ctx).IsZero())
+	ctx = WithBandwidthLimit(ctx, BandwidthLimit{Upload: 10})
+	assert.Equal(t, BandwidthLimit{Upload: 10}, BandwidthLimitFromContext(ctx))
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestThrottleTransport(t *testing.T) {
+	t.Run("NoLimit", func(t *testing.T) {
+		tr := &http.Transport{}
+		assert.Same(t, tr, BandwidthLimit{}.ThrottleTransport(tr))
+	})
+	t.Run("Upload", func(t *testing.T) {
+		tr := BandwidthLimit{Upload: 100 * 1024}.ThrottleTransport(roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			n, err := io.Copy(io.Discard, req.Body)
+			assert.NoError(t, err)
+			assert.Equal(t, int64(250*1024), n)
+			return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+		}))
+		req, err := http.NewRequest(http.MethodPut, "http://example.com", bytes.NewReader(make([]byte, 250*1024)))
+		assert.NoError(t, err)
+		start := time.Now()
+		_, err = tr.RoundTrip(req)
+		assert.NoError(t, err)
+		// the first 100Ki is the burst, the remaining 150Ki take 1.5s
+		assert.GreaterOrEqual(t, time.Since(start), time.Second)
+	})
+	t.Run("Shared", func(t *testing.T) {
+		limit := NewBandwidthLimit(100*1024, 0)
+		start := time.Now()
+		for i := 0; i < 2; i++ {
+			tr := limit.ThrottleTransport(roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				_, err := io.Copy(io.Discard, req.Body)
+				assert.NoError(t, err)
+				return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+			}))
+			req, err := http.NewRequest(http.MethodPut, "http://example.com", bytes.NewReader(make([]byte, 100*1024)))
+			assert.NoError(t, err)
+			_, err = tr.RoundTrip(req)
+			assert.NoError(t, err)
+		}
+		// the first transport uses up the burst, so the second one waits for it to refill
+		assert.GreaterOrEqual(t, time.Since(start), 900*time.Millisecond)
+	})
+	t.Run("DownloadCancelled", func(t *testing.T) {
+		tr := BandwidthLimit{Download: 1024}.ThrottleTransport(roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewReader(make([]byte, 100*1024)))}, nil
+		}))
+		ctx, cancel := context.WithCancel(context.Background())
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://example.com", nil)
+		assert.NoError(t, err)
+		resp, err := tr.RoundTrip(req)
+		assert.NoError(t, err)
+		time.AfterFunc(100*time.Millisecond, cancel)
+		start := time.Now()
+		// at 1Ki/s this would take over a minute, but the transfer stops waiting once it is cancelled
+		_, err = io.Copy(io.Discard, resp.Body)
+		assert.Error(t, err)
+		assert.Less(t, time.Since(start), 10*time.Second)
+	})
+}
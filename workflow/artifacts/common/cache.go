@@ -0,0 +1,17 @@
+package common
+
+import "context"
+
+type cacheDirKey struct{}
+
+// WithCacheDir returns a context which drivers created with it will keep copies of the artifacts they load in the
+// directory, if they can revalidate them rather than download them again
+func WithCacheDir(ctx context.Context, dir string) context.Context {
+	return context.WithValue(ctx, cacheDirKey{}, dir)
+}
+
+// CacheDirFromContext returns the directory set with WithCacheDir, if any
+func CacheDirFromContext(ctx context.Context) string {
+	dir, _ := ctx.Value(cacheDirKey{}).(string)
+	return dir
+}
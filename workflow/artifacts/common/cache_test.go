@@ -0,0 +1,14 @@
+package common
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCacheDirFromContext(t *testing.T) {
+	ctx := context.Background()
+	assert.Empty(t, CacheDirFromContext(ctx))
+	assert.Equal(t, "/my-dir", CacheDirFromContext(WithCacheDir(ctx, "/my-dir")))
+}
@@ -30,6 +30,12 @@ type ArtifactDriver interface {
 	IsDirectory(artifact *v1alpha1.Artifact) (bool, error)
 }
 
+// RepositoryChecker is implemented by drivers that can cheaply check that the repository of an artifact, e.g. its
+// bucket, exists and can be reached with the driver's credentials, without reading or writing any artifacts
+type RepositoryChecker interface {
+	CheckRepository(artifact *v1alpha1.Artifact) error
+}
+
 // ErrDeleteNotSupported Sentinel error definition for artifact deletion
 var ErrDeleteNotSupported = errors.New("delete not supported for this artifact storage, please check" +
 	" the following issue for details: https://github.com/argoproj/argo-workflows/issues/3102")
@@ -0,0 +1,54 @@
+package faultinjection
+
+import (
+	"fmt"
+	"io"
+	"math/rand"
+
+	log "github.com/sirupsen/logrus"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/artifacts/common"
+)
+
+// driver fails to load and save artifacts at a rate, to test the retry strategies and exit handlers of workflows. It
+// must never be used in production.
+type driver struct {
+	common.ArtifactDriver
+	rate float64
+	// random returns a number in [0,1), it is replaced in tests
+	random func() float64
+}
+
+func New(d common.ArtifactDriver, rate float64) common.ArtifactDriver {
+	return &driver{ArtifactDriver: d, rate: rate, random: rand.Float64}
+}
+
+func (d driver) inject(a *wfv1.Artifact, op string) error {
+	if d.random() >= d.rate {
+		return nil
+	}
+	log.WithField("artifactName", a.Name).Warnf("Injecting fault: artifact %s error", op)
+	return fmt.Errorf("injected fault: failed to %s artifact %s", op, a.Name)
+}
+
+func (d driver) Load(a *wfv1.Artifact, path string) error {
+	if err := d.inject(a, "load"); err != nil {
+		return err
+	}
+	return d.ArtifactDriver.Load(a, path)
+}
+
+func (d driver) OpenStream(a *wfv1.Artifact) (io.ReadCloser, error) {
+	if err := d.inject(a, "load"); err != nil {
+		return nil, err
+	}
+	return d.ArtifactDriver.OpenStream(a)
+}
+
+func (d driver) Save(path string, a *wfv1.Artifact) error {
+	if err := d.inject(a, "save"); err != nil {
+		return err
+	}
+	return d.ArtifactDriver.Save(path, a)
+}
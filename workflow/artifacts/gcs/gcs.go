@@ -223,7 +223,7 @@ func (g *ArtifactDriver) Save(path string, outputArtifact *wfv1.Artifact) error
 				return !isTransientGCSErr(err), err
 			}
 			defer client.Close()
-			err = uploadObjects(client, outputArtifact.GCS.Bucket, key, path)
+			err = uploadObjects(client, outputArtifact.GCS.Bucket, key, path, outputArtifact.ObjectMetadata)
 			if err != nil {
 				return !isTransientGCSErr(err), err
 			}
@@ -255,8 +255,8 @@ func listFileRelPaths(path string, relPath string) ([]string, error) {
 	return results, nil
 }
 
-// upload a local file or dir to GCS
-func uploadObjects(client *storage.Client, bucket, key, path string) error {
+// upload a local file or dir to GCS, with the custom metadata
+func uploadObjects(client *storage.Client, bucket, key, path string, metadata map[string]string) error {
 	isDir, err := file.IsDirectory(path)
 	if err != nil {
 		return fmt.Errorf("test if %s is a dir: %w", path, err)
@@ -274,7 +274,7 @@ func uploadObjects(client *storage.Client, bucket, key, path string) error {
 				fullKey = strings.ReplaceAll(fullKey, "\\", "/")
 			}
 
-			err = uploadObject(client, bucket, fullKey, dirName+relPath)
+			err = uploadObject(client, bucket, fullKey, dirName+relPath, metadata)
 			if err != nil {
 				return fmt.Errorf("upload %s: %w", dirName+relPath, err)
 			}
@@ -284,7 +284,7 @@ func uploadObjects(client *storage.Client, bucket, key, path string) error {
 		if os.PathSeparator == '\\' {
 			objectKey = strings.ReplaceAll(objectKey, "\\", "/")
 		}
-		err = uploadObject(client, bucket, objectKey, path)
+		err = uploadObject(client, bucket, objectKey, path, metadata)
 		if err != nil {
 			return fmt.Errorf("upload %s: %w", path, err)
 		}
@@ -292,8 +292,8 @@ func uploadObjects(client *storage.Client, bucket, key, path string) error {
 	return nil
 }
 
-// upload an object to GCS
-func uploadObject(client *storage.Client, bucket, key, localPath string) error {
+// upload an object to GCS, with the custom metadata
+func uploadObject(client *storage.Client, bucket, key, localPath string, metadata map[string]string) error {
 	f, err := os.Open(filepath.Clean(localPath))
 	if err != nil {
 		return fmt.Errorf("os open: %w", err)
@@ -305,6 +305,7 @@ func uploadObject(client *storage.Client, bucket, key, localPath string) error {
 	}()
 	ctx := context.Background()
 	wc := client.Bucket(bucket).Object(key).NewWriter(ctx)
+	wc.Metadata = metadata
 	if _, err = io.Copy(wc, f); err != nil {
 		return fmt.Errorf("io copy: %w", err)
 	}
@@ -363,6 +364,23 @@ func (g *ArtifactDriver) ListObjects(artifact *wfv1.Artifact) ([]string, error)
 	return files, err
 }
 
+// CheckRepository checks that the artifact's bucket exists and its objects can be listed, by listing at most one
+func (g *ArtifactDriver) CheckRepository(artifact *wfv1.Artifact) error {
+	client, err := g.newGCSClient()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
+	defer cancel()
+	it := client.Bucket(artifact.GCS.Bucket).Objects(ctx, nil)
+	it.PageInfo().MaxSize = 1
+	if _, err := it.Next(); err != nil && err != iterator.Done {
+		return fmt.Errorf("failed to list objects in bucket %s: %w", artifact.GCS.Bucket, err)
+	}
+	return nil
+}
+
 func (g *ArtifactDriver) IsDirectory(artifact *wfv1.Artifact) (bool, error) {
 	return false, errors.New(errors.CodeNotImplemented, "IsDirectory currently unimplemented for GCS")
 }
@@ -0,0 +1,157 @@
+package http
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// cacheEntryPrefix is the prefix of the names of the entries in the node artifact cache, which tells them from the
+// artifacts cached by their checksum
+const cacheEntryPrefix = "http-"
+
+// cache is a node-local copy of an HTTP artifact, keyed by its URL and the headers and user it is requested with, so
+// that it is only shared by requests for the same content. The content is stored next to a metadata file holding the
+// validators (ETag and Last-Modified) used to revalidate it.
+type cache struct {
+	dir      string
+	dataPath string
+	metaPath string
+}
+
+type cacheMetadata struct {
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+}
+
+func newCache(dir, url, username string, header http.Header) *cache {
+	h := sha256.New()
+	_, _ = h.Write([]byte(url))
+	_, _ = h.Write([]byte{0})
+	_, _ = h.Write([]byte(username))
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for _, value := range header[name] {
+			_, _ = h.Write([]byte{0})
+			_, _ = h.Write([]byte(name + ": " + value))
+		}
+	}
+	key := cacheEntryPrefix + hex.EncodeToString(h.Sum(nil))
+	return &cache{
+		dir:      dir,
+		dataPath: filepath.Join(dir, key),
+		metaPath: filepath.Join(dir, key+".json"),
+	}
+}
+
+// conditionalHeaders returns the headers to revalidate the cached copy with, or nil if there is none
+func (c *cache) conditionalHeaders() http.Header {
+	if c == nil {
+		return nil
+	}
+	data, err := os.ReadFile(c.metaPath)
+	if err != nil {
+		return nil
+	}
+	meta := cacheMetadata{}
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return nil
+	}
+	if _, err := os.Stat(c.dataPath); err != nil {
+		return nil
+	}
+	header := http.Header{}
+	if meta.ETag != "" {
+		header.Set("If-None-Match", meta.ETag)
+	}
+	if meta.LastModified != "" {
+		header.Set("If-Modified-Since", meta.LastModified)
+	}
+	if len(header) == 0 {
+		return nil
+	}
+	return header
+}
+
+// restore copies the cached content to path, and marks it as used, so that it is evicted from the cache last
+func (c *cache) restore(path string) error {
+	src, err := os.Open(c.dataPath)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = src.Close()
+	}()
+	dst, err := os.Create(filepath.Clean(path))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = dst.Close()
+	}()
+	if _, err := io.Copy(dst, src); err != nil {
+		return err
+	}
+	now := time.Now()
+	_ = os.Chtimes(c.dataPath, now, now)
+	return nil
+}
+
+// store caches the content at path, if the response carries validators to revalidate it with later.
+// Files are written to a temporary file and renamed, so concurrent pods on the node never read a partial entry.
+func (c *cache) store(path string, header http.Header) error {
+	meta := cacheMetadata{ETag: header.Get("ETag"), LastModified: header.Get("Last-Modified")}
+	if meta.ETag == "" && meta.LastModified == "" {
+		return nil
+	}
+	src, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = src.Close()
+	}()
+	if err := c.writeFile(c.dataPath, src); err != nil {
+		return err
+	}
+	data, err := json.Marshal(meta)
+	if err != nil {
+		return err
+	}
+	return c.writeFile(c.metaPath, bytes.NewReader(data))
+}
+
+func (c *cache) writeFile(path string, r io.Reader) error {
+	tmp, err := os.CreateTemp(c.dir, ".tmp-")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = os.Remove(tmp.Name())
+	}()
+	_, err = io.Copy(tmp, r)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// invalidate removes the cache entry
+func (c *cache) invalidate() {
+	_ = os.Remove(c.metaPath)
+	_ = os.Remove(c.dataPath)
+}
@@ -9,6 +9,7 @@ import (
 	cc "golang.org/x/oauth2/clientcredentials"
 
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/artifacts/common"
 )
 
 func CreateClientWithCertificate(clientCert, clientKey []byte) (*http.Client, error) {
@@ -39,3 +40,15 @@ func CreateOauth2Client(clientID, clientSecret, tokenURL string, scopes []string
 	}
 	return conf.Client(ctx)
 }
+
+// ThrottleClient limits the bandwidth used by the client's transport.
+func ThrottleClient(client *http.Client, limit common.BandwidthLimit) {
+	if limit.IsZero() {
+		return
+	}
+	tr := client.Transport
+	if tr == nil {
+		tr = http.DefaultTransport
+	}
+	client.Transport = limit.ThrottleTransport(tr)
+}
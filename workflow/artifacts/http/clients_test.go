@@ -1,11 +1,13 @@
 package http
 
 import (
+	"net/http"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/artifacts/common"
 )
 
 func TestCreateOauth2Client(t *testing.T) {
@@ -30,6 +32,27 @@ func TestCreateClientWithCertificateValidCert(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestThrottleClient(t *testing.T) {
+	limit := common.BandwidthLimit{Download: 1024}
+	t.Run("NoLimit", func(t *testing.T) {
+		client := &http.Client{}
+		ThrottleClient(client, common.BandwidthLimit{})
+		assert.Nil(t, client.Transport)
+	})
+	t.Run("DefaultTransport", func(t *testing.T) {
+		client := &http.Client{}
+		ThrottleClient(client, limit)
+		assert.NotNil(t, client.Transport)
+		assert.NotSame(t, http.DefaultTransport, client.Transport)
+	})
+	t.Run("OAuth2", func(t *testing.T) {
+		client := CreateOauth2Client("clientID", "clientSecret", "tokenURL", nil, nil)
+		tr := client.Transport
+		ThrottleClient(client, limit)
+		assert.NotEqual(t, tr, client.Transport)
+	})
+}
+
 // test certificate pair
 const (
 	CERT_PEM = `-----BEGIN CERTIFICATE-----
@@ -1,14 +1,22 @@
 package http
 
 import (
+	goerrors "errors"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
 	"path/filepath"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/wait"
 
 	"github.com/argoproj/argo-workflows/v3/errors"
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	errutil "github.com/argoproj/argo-workflows/v3/util/errors"
+	waitutil "github.com/argoproj/argo-workflows/v3/util/wait"
 	"github.com/argoproj/argo-workflows/v3/workflow/artifacts/common"
 )
 
@@ -17,11 +25,39 @@ type ArtifactDriver struct {
 	Username string
 	Password string
 	Client   *http.Client
+	// SecretHeaders are the resolved values of headers sourced from secrets, keyed by header name
+	SecretHeaders map[string]string
+	// CacheDir is the node artifact cache to keep copies of HTTP artifacts in, which are revalidated using their ETag
+	// and Last-Modified headers rather than downloaded again, empty if caching is disabled
+	CacheDir string
+}
+
+var (
+	_            common.ArtifactDriver = &ArtifactDriver{}
+	defaultRetry                       = wait.Backoff{Duration: time.Second * 2, Factor: 2.0, Steps: 5, Jitter: 0.1}
+)
+
+// transientStatusError is returned for responses that are worth retrying, i.e. 429 and 5xx
+type transientStatusError struct {
+	error
+}
+
+func isTransientHTTPErr(err error) bool {
+	var statusErr transientStatusError
+	return goerrors.As(err, &statusErr) || goerrors.Is(err, io.ErrUnexpectedEOF) || errutil.IsTransientErr(err)
 }
 
-var _ common.ArtifactDriver = &ArtifactDriver{}
+func (h *ArtifactDriver) addHeaders(req *http.Request, headers []wfv1.Header) {
+	for _, header := range headers {
+		value := header.Value
+		if header.ValueFrom != nil {
+			value = h.SecretHeaders[header.Name]
+		}
+		req.Header.Add(header.Name, value)
+	}
+}
 
-func (h *ArtifactDriver) retrieveContent(inputArtifact *wfv1.Artifact) (http.Response, error) {
+func (h *ArtifactDriver) retrieveContent(inputArtifact *wfv1.Artifact, extraHeaders http.Header) (http.Response, error) {
 	var req *http.Request
 	var url string
 	var err error
@@ -38,8 +74,11 @@ func (h *ArtifactDriver) retrieveContent(inputArtifact *wfv1.Artifact) (http.Res
 		if err != nil {
 			return http.Response{}, err
 		}
-		for _, h := range inputArtifact.HTTP.Headers {
-			req.Header.Add(h.Name, h.Value)
+		h.addHeaders(req, inputArtifact.HTTP.Headers)
+		for name, values := range extraHeaders {
+			for _, value := range values {
+				req.Header.Add(name, value)
+			}
 		}
 		if h.Username != "" && h.Password != "" {
 			req.SetBasicAuth(h.Username, h.Password)
@@ -55,36 +94,132 @@ func (h *ArtifactDriver) retrieveContent(inputArtifact *wfv1.Artifact) (http.Res
 		return http.Response{}, err
 	}
 	if res.StatusCode == 404 {
+		_ = res.Body.Close()
 		return http.Response{}, errors.New(errors.CodeNotFound, res.Status)
 	}
+	if res.StatusCode == http.StatusNotModified && extraHeaders.Get("If-None-Match")+extraHeaders.Get("If-Modified-Since") != "" {
+		return *res, nil
+	}
+	if res.StatusCode == http.StatusTooManyRequests || res.StatusCode >= 500 {
+		_ = res.Body.Close()
+		return http.Response{}, transientStatusError{errors.InternalErrorf("loading content from %s failed with reason: %s", url, res.Status)}
+	}
 	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		_ = res.Body.Close()
 		return http.Response{}, errors.InternalErrorf("loading content from %s failed with reason: %s", url, res.Status)
 	}
 	return *res, nil
 }
 
-// Load reads the artifact from the HTTP URL
+// download is the progress of loading an artifact, kept between attempts so that a download that fails part way
+// through is resumed from the last byte written, rather than restarted
+type download struct {
+	// written is the number of bytes written to the file
+	written int64
+	// validator is the ETag, or else the Last-Modified time, of the content being downloaded, so that it is only
+	// resumed if the content has not changed. It is empty if the download cannot be resumed.
+	validator string
+}
+
+// rangeHeaders returns the headers to resume the download with, or nil if it cannot be resumed
+func (d *download) rangeHeaders() http.Header {
+	if d.written == 0 || d.validator == "" {
+		return nil
+	}
+	return http.Header{
+		"Range":    {fmt.Sprintf("bytes=%d-", d.written)},
+		"If-Range": {d.validator},
+	}
+}
+
+// resumes returns whether the response continues the download from the last byte written
+func (d *download) resumes(res http.Response) bool {
+	return res.StatusCode == http.StatusPartialContent &&
+		strings.HasPrefix(res.Header.Get("Content-Range"), fmt.Sprintf("bytes %d-", d.written))
+}
+
+// Load reads the artifact from the HTTP URL, retrying transient failures. A download that fails part way through is
+// resumed from the last byte written, if the server supports range requests.
 func (h *ArtifactDriver) Load(inputArtifact *wfv1.Artifact, path string) error {
-	lf, err := os.Create(path)
+	d := &download{}
+	return waitutil.Backoff(defaultRetry, func() (bool, error) {
+		err := h.load(inputArtifact, path, d)
+		if err != nil {
+			log.Warnf("Failed to load HTTP artifact: %v", err)
+			return !isTransientHTTPErr(err), err
+		}
+		return true, nil
+	})
+}
+
+// cacheEntry returns the cache entry of the artifact, or nil if caching is disabled
+func (h *ArtifactDriver) cacheEntry(inputArtifact *wfv1.Artifact) *cache {
+	if h.CacheDir == "" || inputArtifact.HTTP == nil {
+		return nil
+	}
+	req := &http.Request{Header: http.Header{}}
+	h.addHeaders(req, inputArtifact.HTTP.Headers)
+	return newCache(h.CacheDir, inputArtifact.HTTP.URL, h.Username, req.Header)
+}
+
+func (h *ArtifactDriver) load(inputArtifact *wfv1.Artifact, path string, d *download) error {
+	c := h.cacheEntry(inputArtifact)
+	headers := d.rangeHeaders()
+	if headers == nil {
+		headers = c.conditionalHeaders()
+	}
+	res, err := h.retrieveContent(inputArtifact, headers)
 	if err != nil {
 		return err
 	}
 	defer func() {
-		_ = lf.Close()
+		_ = res.Body.Close()
 	}()
-	res, err := h.retrieveContent(inputArtifact)
+	if res.StatusCode == http.StatusNotModified {
+		log.Infof("HTTP artifact %s not modified, using cached copy", inputArtifact.HTTP.URL)
+		err := c.restore(path)
+		if err != nil {
+			// the cached copy went missing, so drop the entry and download it again
+			c.invalidate()
+			return transientStatusError{err}
+		}
+		return nil
+	}
+	flag := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	if d.resumes(res) {
+		log.Infof("Resuming the download of the HTTP artifact at byte %d", d.written)
+		flag = os.O_WRONLY | os.O_APPEND
+	} else {
+		// the whole content, as the download is new, or the content changed, or the server does not support ranges
+		d.written = 0
+		d.validator = res.Header.Get("ETag")
+		if d.validator == "" {
+			d.validator = res.Header.Get("Last-Modified")
+		}
+	}
+	lf, err := os.OpenFile(path, flag, 0o666)
 	if err != nil {
 		return err
 	}
 	defer func() {
-		_ = res.Body.Close()
+		_ = lf.Close()
 	}()
-	_, err = io.Copy(lf, res.Body)
-	return err
+	// a response shorter than its Content-Length fails with io.ErrUnexpectedEOF, so it is resumed
+	n, err := io.Copy(lf, res.Body)
+	d.written += n
+	if err != nil {
+		return err
+	}
+	if c != nil {
+		if err := c.store(path, res.Header); err != nil {
+			log.Warnf("Failed to cache HTTP artifact %s: %v", inputArtifact.HTTP.URL, err)
+		}
+	}
+	return nil
 }
 
 func (h *ArtifactDriver) OpenStream(inputArtifact *wfv1.Artifact) (io.ReadCloser, error) {
-	res, err := h.retrieveContent(inputArtifact)
+	res, err := h.retrieveContent(inputArtifact, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -113,9 +248,7 @@ func (h *ArtifactDriver) Save(path string, outputArtifact *wfv1.Artifact) error
 		if err != nil {
 			return err
 		}
-		for _, h := range outputArtifact.HTTP.Headers {
-			req.Header.Add(h.Name, h.Value)
-		}
+		h.addHeaders(req, outputArtifact.HTTP.Headers)
 		if h.Username != "" && h.Password != "" {
 			req.SetBasicAuth(h.Username, h.Password)
 		}
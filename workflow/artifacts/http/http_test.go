@@ -6,9 +6,13 @@ import (
 	"net/http/httptest"
 	"os"
 	"path"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	apiv1 "k8s.io/api/core/v1"
 
 	"github.com/argoproj/argo-workflows/v3/errors"
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
@@ -133,3 +137,164 @@ func TestSaveHTTPArtifactRedirect(t *testing.T) {
 	})
 
 }
+
+func TestLoadHTTPArtifactSecretHeaders(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer my-token", r.Header.Get("Authorization"))
+		assert.Equal(t, "application/json", r.Header.Get("Accept"))
+		_, _ = w.Write([]byte("data"))
+	}))
+	defer svr.Close()
+
+	driver := ArtifactDriver{
+		Client:        &http.Client{},
+		SecretHeaders: map[string]string{"Authorization": "Bearer my-token"},
+	}
+	art := &wfv1.Artifact{
+		ArtifactLocation: wfv1.ArtifactLocation{
+			HTTP: &wfv1.HTTPArtifact{
+				URL: svr.URL,
+				Headers: []wfv1.Header{
+					{Name: "Accept", Value: "application/json"},
+					{Name: "Authorization", ValueFrom: &wfv1.HTTPHeaderSource{SecretKeyRef: &apiv1.SecretKeySelector{Key: "token"}}},
+				},
+			},
+		},
+	}
+	dest := path.Join(t.TempDir(), "out")
+	assert.NoError(t, driver.Load(art, dest))
+	assert.FileExists(t, dest)
+}
+
+func TestLoadHTTPArtifactCache(t *testing.T) {
+	downloads := 0
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		downloads++
+		w.Header().Set("ETag", `"v1"`)
+		_, _ = w.Write([]byte("reference data"))
+	}))
+	defer svr.Close()
+
+	driver := ArtifactDriver{Client: &http.Client{}, CacheDir: t.TempDir()}
+	load := func(art *wfv1.Artifact) {
+		dest := path.Join(t.TempDir(), "out")
+		if assert.NoError(t, driver.Load(art, dest)) {
+			data, err := os.ReadFile(dest)
+			assert.NoError(t, err)
+			assert.Equal(t, "reference data", string(data))
+		}
+	}
+	art := &wfv1.Artifact{ArtifactLocation: wfv1.ArtifactLocation{HTTP: &wfv1.HTTPArtifact{URL: svr.URL}}}
+	load(art)
+	load(art)
+	assert.Equal(t, 1, downloads)
+	// requested with other headers, e.g. another user's token, the artifact is not shared
+	load(&wfv1.Artifact{ArtifactLocation: wfv1.ArtifactLocation{HTTP: &wfv1.HTTPArtifact{URL: svr.URL, Headers: []wfv1.Header{{Name: "Authorization", Value: "Bearer other"}}}}})
+	assert.Equal(t, 2, downloads)
+
+	t.Run("Evicted", func(t *testing.T) {
+		entries, err := os.ReadDir(driver.CacheDir)
+		assert.NoError(t, err)
+		for _, e := range entries {
+			if !strings.HasSuffix(e.Name(), ".json") {
+				assert.NoError(t, os.Remove(path.Join(driver.CacheDir, e.Name())))
+			}
+		}
+		load(art)
+		assert.Equal(t, 3, downloads)
+	})
+}
+
+// closeTrackingBody records whether the response body was closed
+type closeTrackingBody struct {
+	*strings.Reader
+	closed bool
+}
+
+func (b *closeTrackingBody) Close() error {
+	b.closed = true
+	return nil
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestRetrieveContentClosesBody(t *testing.T) {
+	for _, status := range []int{http.StatusNotFound, http.StatusForbidden, http.StatusNotModified, http.StatusServiceUnavailable} {
+		t.Run(strconv.Itoa(status), func(t *testing.T) {
+			body := &closeTrackingBody{Reader: strings.NewReader("error")}
+			driver := &ArtifactDriver{Client: &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				return &http.Response{StatusCode: status, Status: http.StatusText(status), Body: body, Header: http.Header{}}, nil
+			})}}
+			_, err := driver.retrieveContent(&wfv1.Artifact{ArtifactLocation: wfv1.ArtifactLocation{HTTP: &wfv1.HTTPArtifact{URL: "https://example.com"}}}, nil)
+			assert.Error(t, err)
+			assert.True(t, body.closed)
+		})
+	}
+}
+
+func TestLoadHTTPArtifactRetry(t *testing.T) {
+	requests := 0
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte("data"))
+	}))
+	defer svr.Close()
+
+	driver := ArtifactDriver{Client: &http.Client{}}
+	art := &wfv1.Artifact{ArtifactLocation: wfv1.ArtifactLocation{HTTP: &wfv1.HTTPArtifact{URL: svr.URL}}}
+	assert.NoError(t, driver.Load(art, path.Join(t.TempDir(), "out")))
+	assert.Equal(t, 2, requests)
+}
+
+func TestLoadHTTPArtifactResume(t *testing.T) {
+	for name, changed := range map[string]bool{"Resumed": false, "Changed": true} {
+		t.Run(name, func(t *testing.T) {
+			var ranges []string
+			svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				ranges = append(ranges, r.Header.Get("Range"))
+				content, etag := "0123456789", `"v1"`
+				if len(ranges) > 1 && changed {
+					content, etag = "abcdefghij", `"v2"`
+				}
+				w.Header().Set("ETag", etag)
+				if len(ranges) == 1 {
+					// the connection is lost half way through
+					w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+					_, _ = w.Write([]byte(content[:5]))
+					w.(http.Flusher).Flush()
+					conn, _, _ := w.(http.Hijacker).Hijack()
+					_ = conn.Close()
+					return
+				}
+				http.ServeContent(w, r, "", time.Time{}, strings.NewReader(content))
+			}))
+			defer svr.Close()
+
+			driver := ArtifactDriver{Client: &http.Client{}}
+			art := &wfv1.Artifact{ArtifactLocation: wfv1.ArtifactLocation{HTTP: &wfv1.HTTPArtifact{URL: svr.URL}}}
+			dest := path.Join(t.TempDir(), "out")
+			if assert.NoError(t, driver.Load(art, dest)) {
+				data, err := os.ReadFile(dest)
+				assert.NoError(t, err)
+				if changed {
+					assert.Equal(t, "abcdefghij", string(data))
+				} else {
+					assert.Equal(t, "0123456789", string(data))
+				}
+			}
+			assert.Equal(t, []string{"", "bytes=5-"}, ranges)
+		})
+	}
+}
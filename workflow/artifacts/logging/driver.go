@@ -78,3 +78,17 @@ func (d driver) IsDirectory(a *wfv1.Artifact) (bool, error) {
 		Info("Check if directory")
 	return isDir, err
 }
+
+// CheckRepository checks the repository if the driver can, and does nothing otherwise
+func (d driver) CheckRepository(a *wfv1.Artifact) error {
+	checker, ok := d.ArtifactDriver.(common.RepositoryChecker)
+	if !ok {
+		return nil
+	}
+	t := time.Now()
+	err := checker.CheckRepository(a)
+	log.WithField("duration", time.Since(t)).
+		WithError(err).
+		Info("Check repository")
+	return err
+}
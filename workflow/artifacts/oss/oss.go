@@ -248,6 +248,22 @@ func (ossDriver *ArtifactDriver) ListObjects(artifact *wfv1.Artifact) ([]string,
 	return files, err
 }
 
+// CheckRepository checks that the artifact's bucket exists and its objects can be listed, by listing at most one
+func (ossDriver *ArtifactDriver) CheckRepository(artifact *wfv1.Artifact) error {
+	osscli, err := ossDriver.newOSSClient()
+	if err != nil {
+		return err
+	}
+	bucket, err := osscli.Bucket(artifact.OSS.Bucket)
+	if err != nil {
+		return err
+	}
+	if _, err := bucket.ListObjectsV2(oss.MaxKeys(1)); err != nil {
+		return fmt.Errorf("failed to list objects in bucket %s: %w", artifact.OSS.Bucket, err)
+	}
+	return nil
+}
+
 func setBucketLogging(client *oss.Client, bucketName string) error {
 	if os.Getenv(wfcommon.EnvVarArgoTrace) == "1" {
 		err := client.SetBucketLogging(bucketName, bucketName, bucketLogFilePrefix, true)
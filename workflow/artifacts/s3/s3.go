@@ -11,6 +11,7 @@ import (
 	"github.com/argoproj/pkg/file"
 	argos3 "github.com/argoproj/pkg/s3"
 	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/tags"
 	log "github.com/sirupsen/logrus"
 	"k8s.io/client-go/util/retry"
 
@@ -37,12 +38,13 @@ type ArtifactDriver struct {
 	KmsEncryptionContext  string
 	EnableEncryption      bool
 	ServerSideCustomerKey string
+	Bandwidth             artifactscommon.BandwidthLimit
 }
 
 var _ artifactscommon.ArtifactDriver = &ArtifactDriver{}
 
-// newS3Client instantiates a new S3 client object.
-func (s3Driver *ArtifactDriver) newS3Client(ctx context.Context) (argos3.S3Client, error) {
+// newS3ClientOpts returns the options of the S3 clients
+func (s3Driver *ArtifactDriver) newS3ClientOpts() argos3.S3ClientOpts {
 	opts := argos3.S3ClientOpts{
 		Endpoint:    s3Driver.Endpoint,
 		Region:      s3Driver.Region,
@@ -67,10 +69,29 @@ func (s3Driver *ArtifactDriver) newS3Client(ctx context.Context) (argos3.S3Clien
 			pool.AppendCertsFromPEM([]byte(s3Driver.TrustedCA))
 			tr.TLSClientConfig.RootCAs = pool
 		}
-		opts.Transport = tr
+		opts.Transport = s3Driver.Bandwidth.ThrottleTransport(tr)
 	}
+	return opts
+}
+
+// newS3Client instantiates a new S3 client object.
+func (s3Driver *ArtifactDriver) newS3Client(ctx context.Context) (argos3.S3Client, error) {
+	return argos3.NewS3Client(ctx, s3Driver.newS3ClientOpts())
+}
+
+// newObjectTagger instantiates a minio client to tag objects with, as argos3.S3Client cannot tag objects
+func (s3Driver *ArtifactDriver) newObjectTagger() (objectTagger, error) {
+	opts := s3Driver.newS3ClientOpts()
+	creds, err := argos3.GetCredentials(opts)
+	if err != nil {
+		return nil, err
+	}
+	return minio.New(opts.Endpoint, &minio.Options{Creds: creds, Secure: opts.Secure, Transport: opts.Transport, Region: opts.Region})
+}
 
-	return argos3.NewS3Client(ctx, opts)
+// objectTagger tags S3 objects
+type objectTagger interface {
+	PutObjectTagging(ctx context.Context, bucketName, objectName string, otags *tags.Tags, opts minio.PutObjectTaggingOptions) error
 }
 
 // Load downloads artifacts from S3 compliant storage
@@ -164,11 +185,47 @@ func (s3Driver *ArtifactDriver) Save(path string, outputArtifact *wfv1.Artifact)
 			if err != nil {
 				return !isTransientS3Err(err), fmt.Errorf("failed to create new S3 client: %v", err)
 			}
-			return saveS3Artifact(s3cli, path, outputArtifact)
+			done, err := saveS3Artifact(s3cli, path, outputArtifact)
+			if err != nil || len(outputArtifact.ObjectMetadata) == 0 {
+				return done, err
+			}
+			tagger, err := s3Driver.newObjectTagger()
+			if err != nil {
+				return !isTransientS3Err(err), fmt.Errorf("failed to create new S3 client: %v", err)
+			}
+			return tagS3Artifact(ctx, s3cli, tagger, path, outputArtifact)
 		})
 	return err
 }
 
+// tagS3Artifact tags the uploaded objects of an artifact with its object metadata
+// returns true if the tagging is completed or can't be retried (non-transient error)
+// returns false if it can be retried (transient error)
+func tagS3Artifact(ctx context.Context, s3cli argos3.S3Client, tagger objectTagger, path string, outputArtifact *wfv1.Artifact) (bool, error) {
+	objectTags, err := tags.NewTags(outputArtifact.ObjectMetadata, true)
+	if err != nil {
+		return true, fmt.Errorf("invalid object metadata for S3 object tags: %v", err)
+	}
+	keys := []string{outputArtifact.S3.Key}
+	isDir, err := file.IsDirectory(path)
+	if err != nil {
+		return true, fmt.Errorf("failed to test if %s is a directory: %v", path, err)
+	}
+	if isDir {
+		keys, err = s3cli.ListDirectory(outputArtifact.S3.Bucket, outputArtifact.S3.Key)
+		if err != nil {
+			return !isTransientS3Err(err), fmt.Errorf("failed to list directory: %v", err)
+		}
+	}
+	for _, key := range keys {
+		log.WithField("key", key).Info("Tagging S3 object")
+		if err := tagger.PutObjectTagging(ctx, outputArtifact.S3.Bucket, key, objectTags, minio.PutObjectTaggingOptions{}); err != nil {
+			return !isTransientS3Err(err), fmt.Errorf("failed to tag object %s: %v", key, err)
+		}
+	}
+	return true, nil
+}
+
 // Delete deletes an artifact from an S3 compliant storage
 func (s3Driver *ArtifactDriver) Delete(artifact *wfv1.Artifact) error {
 	ctx, cancel := context.WithCancel(context.Background())
@@ -274,6 +331,26 @@ func listObjects(s3cli argos3.S3Client, artifact *wfv1.Artifact) (bool, []string
 	return true, files, nil
 }
 
+// CheckRepository checks that the artifact's bucket exists and can be reached
+func (s3Driver *ArtifactDriver) CheckRepository(artifact *wfv1.Artifact) error {
+	s3cli, err := s3Driver.newS3Client(context.TODO())
+	if err != nil {
+		return fmt.Errorf("failed to create new S3 client: %w", err)
+	}
+	return checkBucket(s3cli, artifact.S3.Bucket)
+}
+
+func checkBucket(s3cli argos3.S3Client, bucket string) error {
+	exists, err := s3cli.BucketExists(bucket)
+	if err != nil {
+		return fmt.Errorf("failed to check if bucket %s exists: %w", bucket, err)
+	}
+	if !exists {
+		return fmt.Errorf("bucket %s does not exist", bucket)
+	}
+	return nil
+}
+
 func (s3Driver *ArtifactDriver) IsDirectory(artifact *wfv1.Artifact) (bool, error) {
 	s3cli, err := s3Driver.newS3Client(context.TODO())
 	if err != nil {
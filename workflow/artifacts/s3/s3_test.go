@@ -2,6 +2,8 @@ package s3
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"io"
 	"os"
 	"path/filepath"
@@ -10,7 +12,9 @@ import (
 
 	argos3 "github.com/argoproj/pkg/s3"
 	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/tags"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 )
@@ -539,6 +543,59 @@ func TestSaveS3Artifact(t *testing.T) {
 	}
 }
 
+type mockObjectTagger struct {
+	tags map[string]map[string]string
+}
+
+func (m *mockObjectTagger) PutObjectTagging(_ context.Context, bucketName, objectName string, otags *tags.Tags, _ minio.PutObjectTaggingOptions) error {
+	m.tags[bucketName+"/"+objectName] = otags.ToMap()
+	return nil
+}
+
+func TestTagS3Artifact(t *testing.T) {
+	ctx := context.Background()
+	tempDir := t.TempDir()
+	tempFile := filepath.Join(tempDir, "tmpfile")
+	require.NoError(t, os.WriteFile(tempFile, []byte("temporary file's content"), 0o600))
+	objectMetadata := map[string]string{"workflow": "my-wf", "team": "data"}
+
+	t.Run("File", func(t *testing.T) {
+		tagger := &mockObjectTagger{tags: map[string]map[string]string{}}
+		art := &wfv1.Artifact{
+			ArtifactLocation: wfv1.ArtifactLocation{S3: &wfv1.S3Artifact{S3Bucket: wfv1.S3Bucket{Bucket: "my-bucket"}, Key: "my-wf/hello.tgz"}},
+			ObjectMetadata:   objectMetadata,
+		}
+		done, err := tagS3Artifact(ctx, newMockS3Client(map[string][]string{}, map[string]error{}), tagger, tempFile, art)
+		require.NoError(t, err)
+		assert.True(t, done)
+		assert.Equal(t, map[string]map[string]string{"my-bucket/my-wf/hello.tgz": objectMetadata}, tagger.tags)
+	})
+	t.Run("Directory", func(t *testing.T) {
+		tagger := &mockObjectTagger{tags: map[string]map[string]string{}}
+		s3client := newMockS3Client(map[string][]string{"my-bucket": {"my-wf/dir/a", "my-wf/dir/b", "other/c"}}, map[string]error{})
+		art := &wfv1.Artifact{
+			ArtifactLocation: wfv1.ArtifactLocation{S3: &wfv1.S3Artifact{S3Bucket: wfv1.S3Bucket{Bucket: "my-bucket"}, Key: "my-wf/dir"}},
+			ObjectMetadata:   objectMetadata,
+		}
+		done, err := tagS3Artifact(ctx, s3client, tagger, tempDir, art)
+		require.NoError(t, err)
+		assert.True(t, done)
+		assert.Len(t, tagger.tags, 2)
+		assert.Equal(t, objectMetadata, tagger.tags["my-bucket/my-wf/dir/b"])
+	})
+	t.Run("InvalidTags", func(t *testing.T) {
+		tagger := &mockObjectTagger{tags: map[string]map[string]string{}}
+		art := &wfv1.Artifact{
+			ArtifactLocation: wfv1.ArtifactLocation{S3: &wfv1.S3Artifact{S3Bucket: wfv1.S3Bucket{Bucket: "my-bucket"}, Key: "my-wf/hello.tgz"}},
+			ObjectMetadata:   map[string]string{"workflow": strings.Repeat("x", 300)},
+		}
+		done, err := tagS3Artifact(ctx, newMockS3Client(map[string][]string{}, map[string]error{}), tagger, tempFile, art)
+		assert.ErrorContains(t, err, "invalid object metadata for S3 object tags")
+		assert.True(t, done)
+		assert.Empty(t, tagger.tags)
+	})
+}
+
 func TestListObjects(t *testing.T) {
 
 	tests := map[string]struct {
@@ -619,3 +676,11 @@ func TestListObjects(t *testing.T) {
 	}
 	_ = os.Unsetenv(transientEnvVarKey)
 }
+
+func TestCheckBucket(t *testing.T) {
+	s3cli := newMockS3Client(map[string][]string{"my-bucket": {}}, map[string]error{})
+	assert.NoError(t, checkBucket(s3cli, "my-bucket"))
+	assert.EqualError(t, checkBucket(s3cli, "other-bucket"), "bucket other-bucket does not exist")
+	s3cli = newMockS3Client(map[string][]string{}, map[string]error{"BucketExists": errors.New("Access Denied")})
+	assert.EqualError(t, checkBucket(s3cli, "my-bucket"), "failed to check if bucket my-bucket exists: Access Denied")
+}
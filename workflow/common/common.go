@@ -39,6 +39,9 @@ const (
 	// AnnotationKeyCronWfScheduledTime is the workflow metadata annotation key containing the time when the workflow
 	// was scheduled to run by CronWorkflow.
 	AnnotationKeyCronWfScheduledTime = workflow.WorkflowFullName + "/scheduled-time"
+	// AnnotationKeyCronWfLastSuccessfulRunOutputs is the workflow metadata annotation key containing the JSON encoded
+	// output parameters of the CronWorkflow's last successful run.
+	AnnotationKeyCronWfLastSuccessfulRunOutputs = workflow.WorkflowFullName + "/last-successful-run-outputs"
 
 	// AnnotationKeyWorkflowName is the name of the workflow
 	AnnotationKeyWorkflowName = workflow.WorkflowFullName + "/workflow-name"
@@ -48,6 +51,19 @@ const (
 	// AnnotationKeyPodNameVersion stores the pod naming convention version
 	AnnotationKeyPodNameVersion = workflow.WorkflowFullName + "/pod-name-format"
 
+	// AnnotationKeyCluster is added by the Argo Server to workflows in aggregated responses to indicate the cluster they were read from
+	AnnotationKeyCluster = workflow.WorkflowFullName + "/cluster"
+
+	// AnnotationKeyContext is added by the CLI to workflows listed with --all-contexts to indicate the context they were read from
+	AnnotationKeyContext = workflow.WorkflowFullName + "/context"
+
+	// AnnotationKeyDeadline is the deadline of the pod, which is mounted into its main containers
+	AnnotationKeyDeadline = workflow.WorkflowFullName + "/deadline"
+	// AnnotationKeySignal is the last signal requested for the main containers of the pod, which is mounted into its
+	// wait container
+	AnnotationKeySignal = workflow.WorkflowFullName + "/signal"
+	// AnnotationKeySignals are the signals requested for the running pods of a workflow, by node ID
+	AnnotationKeySignals = workflow.WorkflowFullName + "/signals"
 	// AnnotationKeyProgress is N/M progress for the node
 	AnnotationKeyProgress = workflow.WorkflowFullName + "/progress"
 
@@ -55,6 +71,30 @@ const (
 	// the strategy whose artifacts are being deleted
 	AnnotationKeyArtifactGCStrategy = workflow.WorkflowFullName + "/artifact-gc-strategy"
 
+	// AnnotationKeyTopologyPolicy is the NUMA topology policy a pod needs, for topology-aware schedulers
+	AnnotationKeyTopologyPolicy = workflow.WorkflowFullName + "/topology-policy"
+
+	// AnnotationKeyCanaryWeight is the percentage of the workflows referencing the stable workflow template that a
+	// canary workflow template runs, from 0 to 100
+	AnnotationKeyCanaryWeight = workflow.WorkflowFullName + "/canary-weight"
+
+	// AnnotationKeyIdempotencyRequestHash is the hash of the request that created or resubmitted a workflow with an
+	// idempotency key, so that the key being reused for another request can be detected
+	AnnotationKeyIdempotencyRequestHash = workflow.WorkflowFullName + "/idempotency-request-hash"
+	// AnnotationKeyRetryIdempotencyKey is the hash of the idempotency key of the last retry of a workflow
+	AnnotationKeyRetryIdempotencyKey = workflow.WorkflowFullName + "/retry-idempotency-key"
+	// AnnotationKeyRetriedAt is the time of the last retry of a workflow with an idempotency key
+	AnnotationKeyRetriedAt = workflow.WorkflowFullName + "/retried-at"
+	// AnnotationKeyRecordReconciliations makes the controller record the reconciliations of a workflow, so that they can
+	// be replayed with `argo admin replay`
+	AnnotationKeyRecordReconciliations = workflow.WorkflowFullName + "/record-reconciliations"
+	// AnnotationKeyCreatorSubject is the subject of the user who created the workflow in full, unlike the creator label,
+	// which is truncated and has the characters labels cannot have replaced, so that the creator is known exactly
+	AnnotationKeyCreatorSubject = workflow.WorkflowFullName + "/creator-subject"
+	// AnnotationKeyCreatorSignature is the signature the Argo Server adds to the workflows it creates, so that it can be
+	// told that the Argo Server recorded their creator subject, rather than the user who created them
+	AnnotationKeyCreatorSignature = workflow.WorkflowFullName + "/creator-signature"
+
 	// LabelKeyControllerInstanceID is the label the controller will carry forward to workflows/pod labels
 	// for the purposes of workflow segregation
 	LabelKeyControllerInstanceID = workflow.WorkflowFullName + "/controller-instanceid"
@@ -73,27 +113,57 @@ const (
 	// * `Persisted` - has been archived and retrieved from db
 	// See also `LabelKeyCompleted`.
 	LabelKeyWorkflowArchivingStatus = workflow.WorkflowFullName + "/workflow-archiving-status"
+	// LabelKeyWorkflowExportStatus indicates if the record of a completed workflow is pending export to the data
+	// warehouses of the workflow export, `Pending`, or has been exported, `Exported`
+	LabelKeyWorkflowExportStatus = workflow.WorkflowFullName + "/workflow-export-status"
 	// LabelKeyWorkflow is the pod metadata label to indicate the associated workflow name
 	LabelKeyWorkflow = workflow.WorkflowFullName + "/workflow"
+	// LabelKeyWorkflowUID is the metadata label applied to resources created by the controller to indicate the UID of the owning workflow
+	LabelKeyWorkflowUID = workflow.WorkflowFullName + "/workflow-uid"
+	// LabelKeyCritical is the pod metadata label applied to the pods of critical templates, which the workflow's pod
+	// disruption budget selects
+	LabelKeyCritical = workflow.WorkflowFullName + "/critical"
+	// LabelKeyArtifacts is the pod metadata label applied to pods that load input artifacts, or save output artifacts or
+	// logs, which count towards the workflow's artifactParallelism
+	LabelKeyArtifacts = workflow.WorkflowFullName + "/artifacts"
+	// LabelKeyCluster is a label applied to secrets holding the kubeconfig of a cluster aggregated by the Argo Server, its value is the cluster name
+	LabelKeyCluster = workflow.WorkflowFullName + "/cluster"
 	// LabelKeyComponent determines what component within a workflow, intentionally similar to app.kubernetes.io/component.
 	// See https://kubernetes.io/docs/concepts/overview/working-with-objects/common-labels/
 	LabelKeyComponent = workflow.WorkflowFullName + "/component"
+	// ArtifactGCComponent is the component label of the pods that delete artifacts for artifact garbage collection
+	ArtifactGCComponent = "artifact-gc"
 	// LabelKeyPhase is a label applied to workflows to indicate the current phase of the workflow (for filtering purposes)
 	LabelKeyPhase = workflow.WorkflowFullName + "/phase"
+	// LabelKeyPolicyReason is a label applied to workflows declined or stopped by a policy, with the reason (for filtering purposes)
+	LabelKeyPolicyReason = workflow.WorkflowFullName + "/policy-reason"
+	// LabelKeyIdempotencyKey is a label applied to workflows created or resubmitted with an idempotency key, with the hash of the key
+	LabelKeyIdempotencyKey = workflow.WorkflowFullName + "/idempotency-key"
 	// LabelKeyPreviousWorkflowName is a label applied to resubmitted workflows
 	LabelKeyPreviousWorkflowName = workflow.WorkflowFullName + "/resubmitted-from-workflow"
 	// LabelKeyCronWorkflow is a label applied to Workflows that are started by a CronWorkflow
 	LabelKeyCronWorkflow = workflow.WorkflowFullName + "/cron-workflow"
+	// LabelKeyCronWorkflowSchedule is a label applied to Workflows that are started by a named schedule of a CronWorkflow
+	LabelKeyCronWorkflowSchedule = workflow.WorkflowFullName + "/cron-workflow-schedule"
 	// LabelKeyWorkflowTemplate is a label applied to Workflows that are submitted from Workflowtemplate
 	LabelKeyWorkflowTemplate = workflow.WorkflowFullName + "/workflow-template"
 	// LabelKeyWorkflowEventBinding is a label applied to Workflows that are submitted from a WorkflowEventBinding
 	LabelKeyWorkflowEventBinding = workflow.WorkflowFullName + "/workflow-event-binding"
 	// LabelKeyWorkflowTemplate is a label applied to Workflows that are submitted from ClusterWorkflowtemplate
 	LabelKeyClusterWorkflowTemplate = workflow.WorkflowFullName + "/cluster-workflow-template"
+	// LabelKeyCanaryOf is a label applied to a WorkflowTemplate to make it the canary of the WorkflowTemplate it names
+	LabelKeyCanaryOf = workflow.WorkflowFullName + "/canary-of"
+	// LabelKeyWorkflowTemplateVersion is a label applied to Workflows that reference a WorkflowTemplate with a canary,
+	// with the version of the template they run, "stable" or "canary"
+	LabelKeyWorkflowTemplateVersion = workflow.WorkflowFullName + "/workflow-template-version"
 	// LabelKeyOnExit is a label applied to Pods that are run from onExit nodes, so that they are not shut down when stopping a Workflow
 	LabelKeyOnExit = workflow.WorkflowFullName + "/on-exit"
 	// LabelKeyArtifactGCPodHash is a label applied to WorkflowTaskSets used by the Artifact Garbage Collection Pod
 	LabelKeyArtifactGCPodHash = workflow.WorkflowFullName + "/artifact-gc-pod"
+	// LabelKeyKueueQueueName is Kueue's label of the local queue that admits a pod
+	LabelKeyKueueQueueName = "kueue.x-k8s.io/queue-name"
+	// LabelKeyKueuePriorityClass is Kueue's label of the workload priority class of a pod
+	LabelKeyKueuePriorityClass = "kueue.x-k8s.io/priority-class"
 
 	// ExecutorArtifactBaseDir is the base directory in the init container in which artifacts will be copied to.
 	// Each artifact will be named according to its input name (e.g: /argo/inputs/artifacts/CODE)
@@ -105,6 +175,9 @@ const (
 	// as well as artifact collection by the wait container.
 	ExecutorMainFilesystemDir = "/mainctrfs"
 
+	// ExecutorArtifactCacheDir is the directory in the init container at which the node artifact cache is mounted
+	ExecutorArtifactCacheDir = "/argo/artifact-cache"
+
 	// ExecutorStagingEmptyDir is the path of the emptydir which is used as a staging area to transfer a file between init/main container for script/resource templates
 	ExecutorStagingEmptyDir = "/argo/staging"
 	// ExecutorScriptSourcePath is the path which init will write the script source file to for script templates
@@ -132,10 +205,14 @@ const (
 	EnvVarPluginAddresses = "ARGO_PLUGIN_ADDRESSES"
 	// EnvVarPluginNames is a list of plugin names
 	EnvVarPluginNames = "ARGO_PLUGIN_NAMES"
+	// EnvVarPluginVersions is a map of the names of the plugins to their versions
+	EnvVarPluginVersions = "ARGO_PLUGIN_VERSIONS"
 	// EnvVarContainerName container the container's name for the current pod
 	EnvVarContainerName = "ARGO_CONTAINER_NAME"
 	// EnvVarDeadline is the deadline for the pod
 	EnvVarDeadline = "ARGO_DEADLINE"
+	// EnvVarDeadlineFile is the file containing the pod's deadline, which is updated if the deadline is brought forward
+	EnvVarDeadlineFile = "ARGO_DEADLINE_FILE"
 	// EnvVarTerminationGracePeriodSeconds is pod.spec.terminationGracePeriodSeconds
 	EnvVarTerminationGracePeriodSeconds = "ARGO_TERMINATION_GRACE_PERIOD_SECONDS"
 	// EnvVarIncludeScriptOutput capture the stdout and stderr
@@ -152,6 +229,17 @@ const (
 	EnvVarProgressFileTickDuration = "ARGO_PROGRESS_FILE_TICK_DURATION"
 	// EnvVarProgressFile is the file watched for reporting progress
 	EnvVarProgressFile = "ARGO_PROGRESS_FILE"
+	// EnvVarArtifactCacheMaxSize is the size in bytes the node artifact cache is evicted down to, it is only set when
+	// the cache is enabled
+	EnvVarArtifactCacheMaxSize = "ARGO_ARTIFACT_CACHE_MAX_SIZE"
+	// EnvVarArtifactBucket, EnvVarArtifactEndpoint and EnvVarArtifactKeyPrefix are where the short-lived artifact
+	// credentials of templates with artifactCredentials give access to
+	EnvVarArtifactBucket    = "ARGO_ARTIFACT_BUCKET"
+	EnvVarArtifactEndpoint  = "ARGO_ARTIFACT_ENDPOINT"
+	EnvVarArtifactKeyPrefix = "ARGO_ARTIFACT_KEY_PREFIX"
+	// EnvVarFaultInjectionArtifactErrorRate is the rate at which the executor fails to load and save artifacts, it is
+	// only set when the controller injects faults for testing
+	EnvVarFaultInjectionArtifactErrorRate = "ARGO_FAULT_INJECTION_ARTIFACT_ERROR_RATE"
 	// EnvVarDefaultRequeueTime is the default requeue time for Workflow Informers. For more info, see rate_limiters.go
 	EnvVarDefaultRequeueTime = "DEFAULT_REQUEUE_TIME"
 	// EnvAgentTaskWorkers is the number of task workers for the agent pod
@@ -198,6 +286,9 @@ const (
 	GlobalVarWorkflowParametersJSON = "workflow.parameters.json"
 	// GlobalVarWorkflowCronScheduleTime is the scheduled timestamp of a Workflow started by a CronWorkflow
 	GlobalVarWorkflowCronScheduleTime = "workflow.scheduledTime"
+	// GlobalVarWorkflowLastSuccessfulRunOutputs is the prefix of the output parameters of the last successful run of
+	// the CronWorkflow that started a Workflow
+	GlobalVarWorkflowLastSuccessfulRunOutputs = "workflow.lastSuccessfulRunOutputs"
 
 	// LabelKeyConfigMapType is the label key for the type of configmap.
 	LabelKeyConfigMapType = "workflows.argoproj.io/configmap-type"
@@ -207,6 +298,10 @@ const (
 	LabelValueTypeConfigMapParameter = "Parameter"
 	// LabelValueTypeConfigMapExecutorPlugin is a key for configmaps that contains an executor plugin.
 	LabelValueTypeConfigMapExecutorPlugin = "ExecutorPlugin"
+	// LabelValueTypeConfigMapWorkflowDefaults is a key for configmaps that contain the workflow defaults for their namespace.
+	LabelValueTypeConfigMapWorkflowDefaults = "WorkflowDefaults"
+	// ConfigMapKeyWorkflowDefaults is the key of the workflow in a workflow defaults configmap.
+	ConfigMapKeyWorkflowDefaults = "workflowDefaults"
 
 	// LocalVarPodName is a step level variable that references the name of the pod
 	LocalVarPodName = "pod.name"
@@ -242,6 +337,21 @@ const (
 	// VarRunArgoPath is the standard path for the shared volume
 	VarRunArgoPath = "/var/run/argo"
 
+	// PodInfoVolumeName is the name of the downward API volume mounted into the main containers
+	PodInfoVolumeName = "argo-podinfo"
+	// PodInfoMountPath is where the downward API volume is mounted
+	PodInfoMountPath = "/etc/argo/podinfo"
+	// ArgoDeadlinePath is the file containing the pod's deadline
+	ArgoDeadlinePath = PodInfoMountPath + "/deadline"
+	// SignalVolumeName is the name of the downward API volume mounted into the wait container
+	SignalVolumeName = "argo-signal"
+	// SignalMountPath is where the signal volume is mounted
+	SignalMountPath = "/etc/argo/signal"
+	// ArgoSignalPath is the file containing the last signal requested for the pod's main containers
+	ArgoSignalPath = SignalMountPath + "/signal"
+	// ArtifactCredentialsMountPath is where the short-lived artifact credentials are mounted
+	ArtifactCredentialsMountPath = "/etc/argo/artifact-credentials"
+
 	// ArgoProgressPath defines the path to a file used for self reporting progress
 	ArgoProgressPath = VarRunArgoPath + "/progress"
 
@@ -1,6 +1,7 @@
 package common
 
 import (
+	"encoding/json"
 	"time"
 
 	log "github.com/sirupsen/logrus"
@@ -90,6 +91,14 @@ func toWorkflow(cronWf wfv1.CronWorkflow, objectMeta metav1.ObjectMeta) *wfv1.Wo
 	}
 
 	wf.Labels[LabelKeyCronWorkflow] = cronWf.Name
+	if outputs := cronWf.Status.LastSuccessfulRunOutputs; outputs != nil && len(outputs.Parameters) > 0 {
+		data, err := json.Marshal(outputs.Parameters)
+		if err != nil {
+			log.WithError(err).Warn("unable to marshal the last successful run outputs of the cron workflow")
+		} else {
+			wf.Annotations[AnnotationKeyCronWfLastSuccessfulRunOutputs] = string(data)
+		}
+	}
 	if cronWf.Spec.WorkflowMetadata != nil {
 		for key, label := range cronWf.Spec.WorkflowMetadata.Labels {
 			wf.Labels[key] = label
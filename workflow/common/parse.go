@@ -19,6 +19,8 @@ var yamlSeparator = regexp.MustCompile(`\n---`)
 type ParseResult struct {
 	Object metav1.Object
 	Err    error
+	// Manifest is the YAML or JSON document the object was parsed from
+	Manifest []byte
 }
 
 func ParseObjects(body []byte, strict bool) []ParseResult {
@@ -28,10 +30,10 @@ func ParseObjects(body []byte, strict bool) []ParseResult {
 		err := jsonpkg.Unmarshal(body, un)
 		if un.GetKind() != "" && err != nil {
 			// only return an error if this is a kubernetes object, otherwise, ignore
-			return append(res, ParseResult{nil, err})
+			return append(res, ParseResult{Err: err, Manifest: body})
 		}
 		v, err := toWorkflowTypeJSON(body, un.GetKind(), strict)
-		return append(res, ParseResult{v, err})
+		return append(res, ParseResult{Object: v, Err: err, Manifest: body})
 	}
 
 	for i, text := range yamlSeparator.Split(string(body), -1) {
@@ -43,7 +45,7 @@ func ParseObjects(body []byte, strict bool) []ParseResult {
 		if err != nil {
 			// Only return an error if this is a kubernetes object, otherwise, print the error
 			if un.GetKind() != "" {
-				res = append(res, ParseResult{nil, err})
+				res = append(res, ParseResult{Err: err, Manifest: []byte(text)})
 			} else {
 				log.Errorf("yaml file at index %d is not valid: %s", i, err)
 			}
@@ -52,7 +54,7 @@ func ParseObjects(body []byte, strict bool) []ParseResult {
 		v, err := toWorkflowTypeYAML([]byte(text), un.GetKind(), strict)
 		if v != nil {
 			// only append when this is a Kubernetes object
-			res = append(res, ParseResult{v, err})
+			res = append(res, ParseResult{Object: v, Err: err, Manifest: []byte(text)})
 		}
 	}
 	return res
@@ -0,0 +1,79 @@
+package common
+
+import (
+	"fmt"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+// ResourceOperator describes how a resource template runs a resource of an operator it has built-in support for
+type ResourceOperator struct {
+	// Kind is the kind of the operator's resource
+	Kind string
+	// SuccessCondition is the default success condition of the resource
+	SuccessCondition string
+	// FailureCondition is the default failure condition of the resource
+	FailureCondition string
+	// LogSelector is the label selector of the operator's pods to stream the logs of, formatted with the name of the
+	// resource
+	LogSelector string
+	// Outputs are the JSON paths of the status fields that output parameters without a valueFrom get their value from
+	Outputs map[string]string
+}
+
+// ResourceOperators are the operators resource templates have built-in support for
+var ResourceOperators = map[wfv1.ResourceOperator]ResourceOperator{
+	wfv1.ResourceOperatorSpark: {
+		Kind:             "SparkApplication",
+		SuccessCondition: "status.applicationState.state == COMPLETED",
+		FailureCondition: "status.applicationState.state in (FAILED, SUBMISSION_FAILED)",
+		LogSelector:      "spark-role=driver,sparkoperator.k8s.io/app-name=%s",
+		Outputs: map[string]string{
+			"applicationId": "{.status.sparkApplicationId}",
+			"state":         "{.status.applicationState.state}",
+			"driverPodName": "{.status.driverInfo.podName}",
+			"webUIAddress":  "{.status.driverInfo.webUIAddress}",
+		},
+	},
+	wfv1.ResourceOperatorRay: {
+		Kind:             "RayJob",
+		SuccessCondition: "status.jobStatus == SUCCEEDED",
+		FailureCondition: "status.jobStatus in (FAILED, STOPPED),status.jobDeploymentStatus == Failed",
+		// the pods of the job that submits the RayJob to its cluster
+		LogSelector: "job-name=%s",
+		Outputs: map[string]string{
+			"jobId":          "{.status.jobId}",
+			"jobStatus":      "{.status.jobStatus}",
+			"rayClusterName": "{.status.rayClusterName}",
+			"dashboardURL":   "{.status.dashboardURL}",
+		},
+	},
+	wfv1.ResourceOperatorFlink: {
+		Kind:             "FlinkDeployment",
+		SuccessCondition: "status.jobStatus.state == FINISHED",
+		FailureCondition: "status.jobStatus.state in (FAILED, CANCELED),status.lifecycleState == FAILED",
+		LogSelector:      "app=%s,component=jobmanager",
+		Outputs: map[string]string{
+			"jobId":          "{.status.jobStatus.jobId}",
+			"jobState":       "{.status.jobStatus.state}",
+			"lifecycleState": "{.status.lifecycleState}",
+		},
+	},
+}
+
+// GetResourceOperator returns the operator of the resource template, or nil if it does not have one
+func GetResourceOperator(tmpl *wfv1.ResourceTemplate) (*ResourceOperator, error) {
+	if tmpl == nil || tmpl.Operator == "" {
+		return nil, nil
+	}
+	operator, ok := ResourceOperators[tmpl.Operator]
+	if !ok {
+		return nil, fmt.Errorf("unknown operator %q, must be one of: spark, ray, flink", tmpl.Operator)
+	}
+	return &operator, nil
+}
+
+// GetLogSelector returns the label selector of the operator's pods for the resource
+func (o *ResourceOperator) GetLogSelector(resourceName string) string {
+	return fmt.Sprintf(o.LogSelector, resourceName)
+}
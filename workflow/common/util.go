@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"path/filepath"
+	"regexp"
 	"sort"
 	"strings"
 
@@ -43,6 +45,21 @@ func isSubPath(path string, normalizedMountPath string) bool {
 	return strings.HasPrefix(path, normalizedMountPath+"/")
 }
 
+// GetOutputStagingPath returns the path under dir that the emissary copies the output parameter or artifact (kind
+// is "parameters" or "artifacts") at the path to, for the wait container to read. The volume name of a Windows path,
+// such as "C:", is removed as it cannot be part of another path.
+func GetOutputStagingPath(dir, kind, path string) string {
+	return filepath.Join(dir, "outputs", kind, strings.TrimPrefix(path, filepath.VolumeName(path)))
+}
+
+var checksumRegex = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+// IsValidChecksum returns true if the checksum is a SHA-256 checksum of 64 lowercase hex characters. Only such
+// checksums are safe to use as file names.
+func IsValidChecksum(checksum string) bool {
+	return checksumRegex.MatchString(checksum)
+}
+
 type RoundTripCallback func(conn *websocket.Conn, resp *http.Response, err error) error
 
 type WebsocketRoundTripper struct {
@@ -317,6 +334,10 @@ func GenerateOnExitNodeName(parentNodeName string) string {
 	return fmt.Sprintf("%s.onExit", parentNodeName)
 }
 
+func GenerateArtifactBundleNodeName(workflowName string) string {
+	return fmt.Sprintf("%s.artifactBundle", workflowName)
+}
+
 func IsDone(un *unstructured.Unstructured) bool {
 	return un.GetDeletionTimestamp() == nil &&
 		un.GetLabels()[LabelKeyCompleted] == "true" &&
@@ -380,3 +380,8 @@ func TestOverridableTemplateInputParamsValueFrom(t *testing.T) {
 	assert.NotNil(t, newTmpl)
 	assert.Equal(t, newTmpl.Inputs.Parameters[0].Value.String(), overrideConfigMapValue)
 }
+
+func TestGetOutputStagingPath(t *testing.T) {
+	assert.Equal(t, "/var/run/argo/outputs/parameters/tmp/message", GetOutputStagingPath(VarRunArgoPath, "parameters", "/tmp/message"))
+	assert.Equal(t, "/var/run/argo/outputs/artifacts/tmp/message.tgz", GetOutputStagingPath(VarRunArgoPath, "artifacts", "/tmp/message.tgz"))
+}
@@ -128,7 +128,7 @@ func (woc *wfOperationCtx) createAgentPod(ctx context.Context) (*apiv1.Pod, erro
 		return nil, err
 	}
 
-	pluginSidecars, pluginVolumes, err := woc.getExecutorPlugins(ctx)
+	pluginSidecars, pluginVolumes, pluginVersions, err := woc.getExecutorPlugins(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -139,6 +139,7 @@ func (woc *wfOperationCtx) createAgentPod(ctx context.Context) (*apiv1.Pod, erro
 		{Name: common.EnvAgentPatchRate, Value: env.LookupEnvStringOr(common.EnvAgentPatchRate, GetRequeueTime().String())},
 		{Name: common.EnvVarPluginAddresses, Value: wfv1.MustMarshallJSON(addresses(pluginSidecars))},
 		{Name: common.EnvVarPluginNames, Value: wfv1.MustMarshallJSON(names(pluginSidecars))},
+		{Name: common.EnvVarPluginVersions, Value: wfv1.MustMarshallJSON(pluginVersions)},
 	}
 
 	// If the default number of task workers is overridden, then pass it to the agent pod.
@@ -208,9 +209,10 @@ func (woc *wfOperationCtx) createAgentPod(ctx context.Context) (*apiv1.Pod, erro
 			Name:      podName,
 			Namespace: woc.wf.ObjectMeta.Namespace,
 			Labels: map[string]string{
-				common.LabelKeyWorkflow:  woc.wf.Name, // Allows filtering by pods related to specific workflow
-				common.LabelKeyCompleted: "false",     // Allows filtering by incomplete workflow pods
-				common.LabelKeyComponent: "agent",     // Allows you to identify agent pods and use a different NetworkPolicy on them
+				common.LabelKeyWorkflow:    woc.wf.Name,        // Allows filtering by pods related to specific workflow
+				common.LabelKeyWorkflowUID: string(woc.wf.UID), // Allows finding pods whose workflow no longer exists
+				common.LabelKeyCompleted:   "false",            // Allows filtering by incomplete workflow pods
+				common.LabelKeyComponent:   "agent",            // Allows you to identify agent pods and use a different NetworkPolicy on them
 			},
 			Annotations: map[string]string{
 				common.AnnotationKeyDefaultContainer: common.MainContainerName,
@@ -261,14 +263,19 @@ func (woc *wfOperationCtx) createAgentPod(ctx context.Context) (*apiv1.Pod, erro
 	return created, nil
 }
 
-func (woc *wfOperationCtx) getExecutorPlugins(ctx context.Context) ([]apiv1.Container, []apiv1.Volume, error) {
+// getExecutorPlugins returns the sidecars and volumes of the plugins in the workflow's namespace and the controller's
+// namespace, and the versions of the plugins by name. A plugin in the workflow's namespace shadows a plugin of the same
+// name in the controller's namespace.
+func (woc *wfOperationCtx) getExecutorPlugins(ctx context.Context) ([]apiv1.Container, []apiv1.Volume, map[string]string, error) {
 	var sidecars []apiv1.Container
 	var volumes []apiv1.Volume
-	namespaces := map[string]bool{} // de-dupes executorPlugins when their namespaces are the same
-	namespaces[woc.controller.namespace] = true
-	namespaces[woc.wf.Namespace] = true
-	for namespace := range namespaces {
+	versions := map[string]string{}
+	for _, namespace := range []string{woc.wf.Namespace, woc.controller.namespace} {
 		for _, plug := range woc.controller.executorPlugins[namespace] {
+			if _, ok := versions[plug.Name]; ok {
+				continue
+			}
+			versions[plug.Name] = plug.Spec.Version
 			s := plug.Spec.Sidecar
 			c := s.Container.DeepCopy()
 			c.VolumeMounts = append(c.VolumeMounts, apiv1.VolumeMount{
@@ -281,7 +288,7 @@ func (woc *wfOperationCtx) getExecutorPlugins(ctx context.Context) ([]apiv1.Cont
 			if s.AutomountServiceAccountToken {
 				volume, volumeMount, err := woc.getServiceAccountTokenVolume(ctx, plug.Name+"-executor-plugin")
 				if err != nil {
-					return nil, nil, err
+					return nil, nil, nil, err
 				}
 				volumes = append(volumes, *volume)
 				c.VolumeMounts = append(c.VolumeMounts, *volumeMount)
@@ -289,7 +296,7 @@ func (woc *wfOperationCtx) getExecutorPlugins(ctx context.Context) ([]apiv1.Cont
 			sidecars = append(sidecars, *c)
 		}
 	}
-	return sidecars, volumes, nil
+	return sidecars, volumes, versions, nil
 }
 
 func addresses(containers []apiv1.Container) []string {
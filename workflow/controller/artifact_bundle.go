@@ -0,0 +1,77 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	apiv1 "k8s.io/api/core/v1"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+	"github.com/argoproj/argo-workflows/v3/workflow/templateresolution"
+)
+
+// artifactBundlePath is the directory the bundle pod loads the collected artifacts to, each to
+// <node ID>/<artifact name>, and saves as the bundle
+const artifactBundlePath = "/argo/artifact-bundle"
+
+// artifactBundleTemplate returns the template of the pod that loads the output artifacts the bundle collects, and
+// saves them as a single output artifact of the workflow, or nil if there are no artifacts to collect
+func (woc *wfOperationCtx) artifactBundleTemplate() *wfv1.Template {
+	bundle := woc.execWf.Spec.ArtifactBundle
+	bundleNodeID := woc.wf.NodeID(common.GenerateArtifactBundleNodeName(woc.wf.Name))
+	var nodes []wfv1.NodeStatus
+	for _, node := range woc.wf.Status.Nodes {
+		if node.Type != wfv1.NodeTypePod || node.ID == bundleNodeID || (node.NodeFlag != nil && node.NodeFlag.Hooked) {
+			continue
+		}
+		if node.Outputs == nil || !bundle.SelectsTemplate(node.TemplateName) {
+			continue
+		}
+		nodes = append(nodes, node)
+	}
+	// sorted, so that the template is the same each time the workflow is reconciled
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].ID < nodes[j].ID })
+
+	var inputs []wfv1.Artifact
+	for _, node := range nodes {
+		for _, art := range node.Outputs.Artifacts {
+			if !bundle.SelectsArtifact(art.Name) || !art.HasLocationOrKey() {
+				continue
+			}
+			inputs = append(inputs, wfv1.Artifact{
+				Name:             fmt.Sprintf("artifact-%d", len(inputs)),
+				Path:             filepath.Join(artifactBundlePath, node.ID, art.Name),
+				ArtifactLocation: art.ArtifactLocation,
+			})
+		}
+	}
+	if len(inputs) == 0 {
+		return nil
+	}
+	return &wfv1.Template{
+		Inputs: wfv1.Inputs{Artifacts: inputs},
+		Outputs: wfv1.Outputs{Artifacts: []wfv1.Artifact{
+			{Name: bundle.Name, Path: artifactBundlePath, GlobalName: bundle.Name},
+		}},
+		Container: &apiv1.Container{
+			Image:           woc.controller.executorImage(),
+			ImagePullPolicy: woc.controller.executorImagePullPolicy(),
+			Command:         []string{"argoexec", "version"},
+		},
+	}
+}
+
+// executeArtifactBundle runs the pod that bundles the output artifacts of the workflow's nodes, once all of them have
+// completed, and returns its node, or nil if there are no artifacts to bundle
+func (woc *wfOperationCtx) executeArtifactBundle(ctx context.Context, tmplCtx *templateresolution.Context) (*wfv1.NodeStatus, error) {
+	nodeName := common.GenerateArtifactBundleNodeName(woc.wf.Name)
+	tmpl := woc.artifactBundleTemplate()
+	if tmpl == nil {
+		return nil, nil
+	}
+	woc.log.WithField("artifacts", len(tmpl.Inputs.Artifacts)).Info("Bundling artifacts")
+	return woc.executeTemplate(ctx, nodeName, &wfv1.WorkflowStep{Inline: tmpl}, tmplCtx, wfv1.Arguments{}, &executeTemplateOpts{onExitTemplate: true})
+}
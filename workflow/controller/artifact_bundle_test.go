@@ -0,0 +1,142 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+var artifactBundleWorkflow = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  name: artifact-bundle
+spec:
+  entrypoint: main
+  artifactBundle:
+    name: reports
+    templates: [report]
+  templates:
+  - name: main
+    steps:
+    - - name: report
+        template: report
+        withItems: [1, 2]
+      - name: other
+        template: other
+  - name: report
+    outputs:
+      artifacts:
+      - name: report
+        path: /tmp/report
+    container:
+      image: argoproj/argosay:v2
+  - name: other
+    outputs:
+      artifacts:
+      - name: report
+        path: /tmp/report
+    container:
+      image: argoproj/argosay:v2
+`
+
+// setArtifactBundleOutputs sets the outputs of the workflow's pod nodes, as their pods would
+func setArtifactBundleOutputs(woc *wfOperationCtx) {
+	for id, node := range woc.wf.Status.Nodes {
+		if node.Type != wfv1.NodeTypePod {
+			continue
+		}
+		node.Outputs = &wfv1.Outputs{Artifacts: wfv1.Artifacts{
+			{Name: "report", ArtifactLocation: wfv1.ArtifactLocation{S3: &wfv1.S3Artifact{Key: node.ID + "/report.tgz"}}},
+			{Name: "main-logs", ArtifactLocation: wfv1.ArtifactLocation{S3: &wfv1.S3Artifact{Key: node.ID + "/main.log"}}},
+		}}
+		woc.wf.Status.Nodes[id] = node
+	}
+}
+
+func TestArtifactBundle(t *testing.T) {
+	ctx := context.Background()
+	wf := wfv1.MustUnmarshalWorkflow(artifactBundleWorkflow)
+	cancel, controller := newController(wf)
+	defer cancel()
+
+	woc := newWorkflowOperationCtx(wf, controller)
+	woc.operate(ctx)
+	makePodsPhase(ctx, woc, apiv1.PodSucceeded)
+	setArtifactBundleOutputs(woc)
+
+	woc = newWorkflowOperationCtx(woc.wf, controller)
+	woc.operate(ctx)
+	assert.Equal(t, wfv1.WorkflowRunning, woc.wf.Status.Phase)
+	node, err := woc.wf.GetNodeByName(common.GenerateArtifactBundleNodeName(wf.Name))
+	require.NoError(t, err)
+	assert.Equal(t, wfv1.NodeTypePod, node.Type)
+
+	pods, err := listPods(woc)
+	require.NoError(t, err)
+	assert.Len(t, pods.Items, 4)
+
+	tmpl := woc.artifactBundleTemplate()
+	require.NotNil(t, tmpl)
+	report, err := woc.wf.GetNodeByName(wf.Name + "[0].report(0:1)")
+	require.NoError(t, err)
+	var keys []string
+	for _, art := range tmpl.Inputs.Artifacts {
+		keys = append(keys, art.S3.Key)
+		if art.S3.Key == report.ID+"/report.tgz" {
+			assert.Equal(t, artifactBundlePath+"/"+report.ID+"/report", art.Path)
+		}
+	}
+	// only the "report" artifacts of the nodes of the "report" template, not their logs
+	assert.Len(t, keys, 2)
+	assert.Contains(t, keys, report.ID+"/report.tgz")
+	assert.Equal(t, wfv1.Artifacts{{Name: "reports", Path: artifactBundlePath, GlobalName: "reports"}}, tmpl.Outputs.Artifacts)
+
+	makePodsPhase(ctx, woc, apiv1.PodSucceeded)
+	woc = newWorkflowOperationCtx(woc.wf, controller)
+	woc.operate(ctx)
+	assert.Equal(t, wfv1.WorkflowSucceeded, woc.wf.Status.Phase)
+}
+
+func TestArtifactBundleFailed(t *testing.T) {
+	ctx := context.Background()
+	wf := wfv1.MustUnmarshalWorkflow(artifactBundleWorkflow)
+	cancel, controller := newController(wf)
+	defer cancel()
+
+	woc := newWorkflowOperationCtx(wf, controller)
+	woc.operate(ctx)
+	makePodsPhase(ctx, woc, apiv1.PodSucceeded)
+	setArtifactBundleOutputs(woc)
+
+	woc = newWorkflowOperationCtx(woc.wf, controller)
+	woc.operate(ctx)
+	makePodsPhase(ctx, woc, apiv1.PodFailed)
+	woc = newWorkflowOperationCtx(woc.wf, controller)
+	woc.operate(ctx)
+	assert.Equal(t, wfv1.WorkflowFailed, woc.wf.Status.Phase)
+	assert.Contains(t, woc.wf.Status.Message, "failed to bundle artifacts")
+}
+
+func TestArtifactBundleNoArtifacts(t *testing.T) {
+	ctx := context.Background()
+	wf := wfv1.MustUnmarshalWorkflow(artifactBundleWorkflow)
+	cancel, controller := newController(wf)
+	defer cancel()
+
+	woc := newWorkflowOperationCtx(wf, controller)
+	woc.operate(ctx)
+	makePodsPhase(ctx, woc, apiv1.PodSucceeded)
+
+	woc = newWorkflowOperationCtx(woc.wf, controller)
+	woc.operate(ctx)
+	assert.Equal(t, wfv1.WorkflowSucceeded, woc.wf.Status.Phase)
+	_, err := woc.wf.GetNodeByName(common.GenerateArtifactBundleNodeName(wf.Name))
+	assert.Error(t, err)
+}
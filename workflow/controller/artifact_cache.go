@@ -0,0 +1,36 @@
+package controller
+
+import (
+	apiv1 "k8s.io/api/core/v1"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+const artifactCacheVolumeName = "artifact-cache"
+
+// addArtifactCacheVolume mounts the node artifact cache into the init container of pods that load input artifacts
+func (woc *wfOperationCtx) addArtifactCacheVolume(pod *apiv1.Pod, tmpl *wfv1.Template) {
+	c := woc.controller.Config.ArtifactCache
+	if c == nil || !hasLoadedInputArtifacts(tmpl) {
+		return
+	}
+	pod.Spec.Volumes = append(pod.Spec.Volumes, apiv1.Volume{Name: artifactCacheVolumeName, VolumeSource: c.GetVolume()})
+	for i, initCtr := range pod.Spec.InitContainers {
+		if initCtr.Name == common.InitContainerName {
+			initCtr.VolumeMounts = append(initCtr.VolumeMounts, apiv1.VolumeMount{Name: artifactCacheVolumeName, MountPath: common.ExecutorArtifactCacheDir})
+			pod.Spec.InitContainers[i] = initCtr
+			break
+		}
+	}
+}
+
+// hasLoadedInputArtifacts returns true if the init container downloads any of the template's input artifacts
+func hasLoadedInputArtifacts(tmpl *wfv1.Template) bool {
+	for _, art := range tmpl.Inputs.Artifacts {
+		if !art.Mount {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,49 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/argoproj/argo-workflows/v3/config"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+func TestArtifactCacheVolume(t *testing.T) {
+	ctx := context.Background()
+	t.Run("Disabled", func(t *testing.T) {
+		tmpl := unmarshalTemplate(scriptTemplateWithInputArtifact)
+		woc := newWoc()
+		pod, err := woc.createWorkflowPod(ctx, tmpl.Name, []apiv1.Container{tmpl.Script.Container}, tmpl, &createWorkflowPodOpts{})
+		assert.NoError(t, err)
+		for _, v := range pod.Spec.Volumes {
+			assert.NotEqual(t, artifactCacheVolumeName, v.Name)
+		}
+		for _, e := range pod.Spec.InitContainers[0].Env {
+			assert.NotEqual(t, common.EnvVarArtifactCacheMaxSize, e.Name)
+		}
+	})
+	t.Run("Enabled", func(t *testing.T) {
+		tmpl := unmarshalTemplate(scriptTemplateWithInputArtifact)
+		woc := newWoc()
+		maxSize := resource.MustParse("1Gi")
+		woc.controller.Config.ArtifactCache = &config.ArtifactCache{MaxSize: &maxSize}
+		pod, err := woc.createWorkflowPod(ctx, tmpl.Name, []apiv1.Container{tmpl.Script.Container}, tmpl, &createWorkflowPodOpts{})
+		assert.NoError(t, err)
+		hostPathType := apiv1.HostPathDirectoryOrCreate
+		assert.Contains(t, pod.Spec.Volumes, apiv1.Volume{
+			Name: artifactCacheVolumeName,
+			VolumeSource: apiv1.VolumeSource{
+				HostPath: &apiv1.HostPathVolumeSource{Path: config.DefaultArtifactCacheHostPath, Type: &hostPathType},
+			},
+		})
+		assert.Contains(t, pod.Spec.InitContainers[0].VolumeMounts, apiv1.VolumeMount{Name: artifactCacheVolumeName, MountPath: common.ExecutorArtifactCacheDir})
+		assert.Contains(t, pod.Spec.InitContainers[0].Env, apiv1.EnvVar{Name: common.EnvVarArtifactCacheMaxSize, Value: "1073741824"})
+		for _, c := range pod.Spec.Containers {
+			assert.NotContains(t, c.VolumeMounts, apiv1.VolumeMount{Name: artifactCacheVolumeName, MountPath: common.ExecutorArtifactCacheDir})
+		}
+	})
+}
@@ -0,0 +1,292 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	apiv1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/argoproj/argo-workflows/v3/pkg/apis/workflow"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/util"
+	"github.com/argoproj/argo-workflows/v3/util/template"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+const (
+	artifactCredentialsVolumeName = "artifact-credentials"
+	// artifactCredentialsExpirationKey is the key of the secret with the time the credentials expire
+	artifactCredentialsExpirationKey = "expiration"
+	// maxRoleSessionNameLength is the longest role session name that STS accepts
+	maxRoleSessionNameLength = 64
+)
+
+// assumeRole gets temporary credentials from the STS service, it is a variable so that tests can replace it
+var assumeRole = func(endpoint string, opts credentials.STSAssumeRoleOptions) (credentials.Value, error) {
+	c, err := credentials.NewSTSAssumeRole(endpoint, opts)
+	if err != nil {
+		return credentials.Value{}, err
+	}
+	return c.Get()
+}
+
+func artifactCredentialsSecretName(workflowName string) string {
+	return workflowName + "-artifact-credentials"
+}
+
+// usesArtifactCredentials returns true if any of the workflow's templates has artifactCredentials
+func (woc *wfOperationCtx) usesArtifactCredentials() bool {
+	for _, tmpl := range woc.execWf.Spec.Templates {
+		if tmpl.ArtifactCredentials {
+			return true
+		}
+	}
+	for _, tmpl := range woc.wf.Status.StoredTemplates {
+		if tmpl.ArtifactCredentials {
+			return true
+		}
+	}
+	return false
+}
+
+// workflowVariableRegex matches the workflow variables of a key format, e.g. "{{ workflow.uid }}"
+var workflowVariableRegex = regexp.MustCompile(`{{\s*(workflow\.[a-zA-Z.]+)\s*}}`)
+
+// getArtifactCredentialsScope returns the workflow's S3 artifact repository, and the key prefix of the workflow's
+// artifacts, which is the part of the repository's key format before the first variable that is not a workflow variable,
+// e.g. of the pod. The prefix must contain the workflow's UID, or its namespace and name, so that it is not shared with
+// other workflows, such as one of the same name in another namespace, or one created after the workflow was deleted.
+func (woc *wfOperationCtx) getArtifactCredentialsScope() (*wfv1.S3ArtifactRepository, string, error) {
+	repo := woc.artifactRepository
+	if repo == nil || repo.S3 == nil {
+		return nil, "", fmt.Errorf("artifactCredentials is only supported for S3 artifact repositories")
+	}
+	keyFormat := repo.S3.KeyFormat
+	if keyFormat == "" {
+		keyFormat = path.Join(repo.S3.KeyPrefix, wfv1.DefaultArchivePattern)
+	}
+	head := getWorkflowKeyFormat(keyFormat)
+	head = head[:strings.LastIndex(head, "/")+1]
+	variables := map[string]bool{}
+	for _, m := range workflowVariableRegex.FindAllStringSubmatch(head, -1) {
+		variables[m[1]] = true
+	}
+	if !variables[common.GlobalVarWorkflowUID] && !(variables[common.GlobalVarWorkflowNamespace] && variables[common.GlobalVarWorkflowName]) {
+		return nil, "", fmt.Errorf("artifactCredentials requires the key format of the artifact repository to start with a prefix containing the workflow's UID, or its namespace and name, e.g. \"{{workflow.uid}}/{{pod.name}}\"")
+	}
+	t, err := template.NewTemplate(head)
+	if err != nil {
+		return nil, "", err
+	}
+	prefix, err := t.Replace(woc.globalParams, false)
+	if err != nil {
+		return nil, "", err
+	}
+	return repo.S3, prefix, nil
+}
+
+// getWorkflowKeyFormat returns the part of the key format before the first variable that is not a workflow variable
+func getWorkflowKeyFormat(keyFormat string) string {
+	n := 0
+	for {
+		i := strings.Index(keyFormat[n:], "{{")
+		if i < 0 {
+			return keyFormat
+		}
+		j := strings.Index(keyFormat[n+i:], "}}")
+		if j < 0 {
+			return keyFormat
+		}
+		tag := strings.TrimSpace(keyFormat[n+i+2 : n+i+j])
+		if !strings.HasPrefix(tag, "workflow.") {
+			return keyFormat[:n+i]
+		}
+		n += i + j + 2
+	}
+}
+
+// getArtifactCredentialsPolicy returns the session policy that limits the credentials to the objects under the prefix
+func getArtifactCredentialsPolicy(bucket, prefix string) (string, error) {
+	policy := map[string]interface{}{
+		"Version": "2012-10-17",
+		"Statement": []map[string]interface{}{
+			{
+				"Effect":   "Allow",
+				"Action":   []string{"s3:GetObject", "s3:PutObject", "s3:DeleteObject"},
+				"Resource": []string{fmt.Sprintf("arn:aws:s3:::%s/%s*", bucket, prefix)},
+			},
+			{
+				"Effect":    "Allow",
+				"Action":    []string{"s3:ListBucket"},
+				"Resource":  []string{fmt.Sprintf("arn:aws:s3:::%s", bucket)},
+				"Condition": map[string]interface{}{"StringLike": map[string]interface{}{"s3:prefix": []string{prefix + "*"}}},
+			},
+		},
+	}
+	data, err := json.Marshal(policy)
+	return string(data), err
+}
+
+// ensureArtifactCredentials creates the secret with the workflow's short-lived artifact credentials, or renews them
+// once half of their duration has passed. The secret is only checked once per operation.
+func (woc *wfOperationCtx) ensureArtifactCredentials(ctx context.Context, create bool) error {
+	if woc.artifactCredentialsChecked {
+		return nil
+	}
+	c := woc.controller.Config.ArtifactCredentials
+	if c == nil {
+		return fmt.Errorf("artifactCredentials is not configured in the controller's ConfigMap")
+	}
+	secrets := woc.controller.kubeclientset.CoreV1().Secrets(woc.wf.Namespace)
+	secret, err := secrets.Get(ctx, artifactCredentialsSecretName(woc.wf.Name), metav1.GetOptions{})
+	if apierr.IsNotFound(err) {
+		if !create {
+			return nil
+		}
+		secret = nil
+	} else if err != nil {
+		return err
+	}
+	duration := c.GetDuration()
+	if secret != nil {
+		expiration, err := time.Parse(time.RFC3339, string(secret.Data[artifactCredentialsExpirationKey]))
+		if err == nil && time.Until(expiration) > duration/2 {
+			woc.artifactCredentialsChecked = true
+			woc.requeueAfter(time.Until(expiration) - duration/2)
+			return nil
+		}
+	}
+	data, err := woc.getArtifactCredentials(ctx, c.STSEndpoint, c.RoleARN, duration)
+	if err != nil {
+		return fmt.Errorf("failed to get artifact credentials: %w", err)
+	}
+	if secret == nil {
+		_, err = secrets.Create(ctx, &apiv1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   artifactCredentialsSecretName(woc.wf.Name),
+				Labels: map[string]string{common.LabelKeyWorkflow: woc.wf.Name},
+				OwnerReferences: []metav1.OwnerReference{
+					*metav1.NewControllerRef(woc.wf, wfv1.SchemeGroupVersion.WithKind(workflow.WorkflowKind)),
+				},
+			},
+			Data: data,
+		}, metav1.CreateOptions{})
+	} else {
+		secret.Data = data
+		_, err = secrets.Update(ctx, secret, metav1.UpdateOptions{})
+	}
+	if err != nil {
+		return err
+	}
+	woc.log.Info("Issued artifact credentials")
+	woc.artifactCredentialsChecked = true
+	woc.requeueAfter(duration / 2)
+	return nil
+}
+
+// getArtifactCredentials gets credentials for the workflow's key prefix from the STS service, using the credentials of
+// the artifact repository, and returns them as the data of the secret. The "credentials" key is an AWS shared
+// credentials file, which most S3 clients read.
+func (woc *wfOperationCtx) getArtifactCredentials(ctx context.Context, endpoint, roleARN string, duration time.Duration) (map[string][]byte, error) {
+	repo, prefix, err := woc.getArtifactCredentialsScope()
+	if err != nil {
+		return nil, err
+	}
+	if repo.AccessKeySecret == nil || repo.SecretKeySecret == nil {
+		return nil, fmt.Errorf("artifactCredentials requires the accessKeySecret and secretKeySecret of the artifact repository")
+	}
+	accessKey, err := util.GetSecrets(ctx, woc.controller.kubeclientset, woc.wf.Namespace, repo.AccessKeySecret.Name, repo.AccessKeySecret.Key)
+	if err != nil {
+		return nil, err
+	}
+	secretKey, err := util.GetSecrets(ctx, woc.controller.kubeclientset, woc.wf.Namespace, repo.SecretKeySecret.Name, repo.SecretKeySecret.Key)
+	if err != nil {
+		return nil, err
+	}
+	policy, err := getArtifactCredentialsPolicy(repo.Bucket, prefix)
+	if err != nil {
+		return nil, err
+	}
+	if roleARN == "" {
+		roleARN = repo.RoleARN
+	}
+	sessionName := woc.wf.Name
+	if len(sessionName) > maxRoleSessionNameLength {
+		sessionName = sessionName[:maxRoleSessionNameLength]
+	}
+	expiration := time.Now().Add(duration)
+	value, err := assumeRole(endpoint, credentials.STSAssumeRoleOptions{
+		AccessKey:       strings.TrimSpace(string(accessKey)),
+		SecretKey:       strings.TrimSpace(string(secretKey)),
+		Policy:          policy,
+		Location:        repo.Region,
+		DurationSeconds: int(duration.Seconds()),
+		RoleARN:         roleARN,
+		RoleSessionName: sessionName,
+	})
+	if err != nil {
+		return nil, err
+	}
+	file := fmt.Sprintf("[default]\naws_access_key_id = %s\naws_secret_access_key = %s\naws_session_token = %s\n", value.AccessKeyID, value.SecretAccessKey, value.SessionToken)
+	return map[string][]byte{
+		"accessKey":                      []byte(value.AccessKeyID),
+		"secretKey":                      []byte(value.SecretAccessKey),
+		"sessionToken":                   []byte(value.SessionToken),
+		"credentials":                    []byte(file),
+		artifactCredentialsExpirationKey: []byte(expiration.UTC().Format(time.RFC3339)),
+	}, nil
+}
+
+// addArtifactCredentials makes sure the workflow's artifact credentials have been issued, and mounts them into the pod
+func (woc *wfOperationCtx) addArtifactCredentials(ctx context.Context, pod *apiv1.Pod) error {
+	if err := woc.ensureArtifactCredentials(ctx, true); err != nil {
+		return err
+	}
+	repo, prefix, err := woc.getArtifactCredentialsScope()
+	if err != nil {
+		return err
+	}
+	addArtifactCredentialsVolume(pod, artifactCredentialsSecretName(woc.wf.Name), repo, prefix)
+	return nil
+}
+
+// addArtifactCredentialsVolume mounts the workflow's artifact credentials into the main containers, with the location
+// they give access to. The secret is mounted as a volume, rather than as environment variables, so that the containers
+// see the credentials being renewed.
+func addArtifactCredentialsVolume(pod *apiv1.Pod, secretName string, repo *wfv1.S3ArtifactRepository, prefix string) {
+	pod.Spec.Volumes = append(pod.Spec.Volumes, apiv1.Volume{
+		Name: artifactCredentialsVolumeName,
+		VolumeSource: apiv1.VolumeSource{
+			Secret: &apiv1.SecretVolumeSource{SecretName: secretName},
+		},
+	})
+	env := []apiv1.EnvVar{
+		{Name: "AWS_SHARED_CREDENTIALS_FILE", Value: path.Join(common.ArtifactCredentialsMountPath, "credentials")},
+		{Name: common.EnvVarArtifactEndpoint, Value: repo.Endpoint},
+		{Name: common.EnvVarArtifactBucket, Value: repo.Bucket},
+		{Name: common.EnvVarArtifactKeyPrefix, Value: prefix},
+	}
+	if repo.Region != "" {
+		env = append(env, apiv1.EnvVar{Name: "AWS_REGION", Value: repo.Region})
+	}
+	for i, c := range pod.Spec.Containers {
+		if c.Name == common.WaitContainerName {
+			continue
+		}
+		c.VolumeMounts = append(c.VolumeMounts, apiv1.VolumeMount{
+			Name:      artifactCredentialsVolumeName,
+			MountPath: common.ArtifactCredentialsMountPath,
+			ReadOnly:  true,
+		})
+		c.Env = append(c.Env, env...)
+		pod.Spec.Containers[i] = c
+	}
+}
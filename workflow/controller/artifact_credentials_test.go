@@ -0,0 +1,150 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/argoproj/argo-workflows/v3/config"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	armocks "github.com/argoproj/argo-workflows/v3/workflow/artifactrepositories/mocks"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+func Test_getWorkflowKeyFormat(t *testing.T) {
+	assert.Equal(t, "{{workflow.name}}/", getWorkflowKeyFormat("{{workflow.name}}/{{pod.name}}"))
+	assert.Equal(t, "artifacts/{{ workflow.uid }}/", getWorkflowKeyFormat("artifacts/{{ workflow.uid }}/{{pod.name}}"))
+	assert.Equal(t, "{{workflow.name}}/logs", getWorkflowKeyFormat("{{workflow.name}}/logs"))
+	assert.Equal(t, "", getWorkflowKeyFormat("{{pod.name}}/{{workflow.name}}"))
+}
+
+func TestGetArtifactCredentialsScope(t *testing.T) {
+	cancel, controller := newController()
+	defer cancel()
+	woc := newWorkflowOperationCtx(wfv1.MustUnmarshalWorkflow(artifactCredentialsWorkflow), controller)
+	woc.globalParams[common.GlobalVarWorkflowName] = "my-wf"
+	woc.globalParams[common.GlobalVarWorkflowNamespace] = "my-ns"
+	woc.globalParams[common.GlobalVarWorkflowUID] = "my-uid"
+
+	t.Run("UID", func(t *testing.T) {
+		woc.artifactRepository = &wfv1.ArtifactRepository{S3: &wfv1.S3ArtifactRepository{S3Bucket: wfv1.S3Bucket{Bucket: "my-bucket"}, KeyFormat: "{{workflow.uid}}/{{pod.name}}"}}
+		repo, prefix, err := woc.getArtifactCredentialsScope()
+		require.NoError(t, err)
+		assert.Equal(t, "my-bucket", repo.Bucket)
+		assert.Equal(t, "my-uid/", prefix)
+	})
+	t.Run("NamespaceAndName", func(t *testing.T) {
+		woc.artifactRepository = &wfv1.ArtifactRepository{S3: &wfv1.S3ArtifactRepository{KeyFormat: "artifacts/{{ workflow.namespace }}/{{workflow.name}}/{{pod.name}}"}}
+		_, prefix, err := woc.getArtifactCredentialsScope()
+		require.NoError(t, err)
+		assert.Equal(t, "artifacts/my-ns/my-wf/", prefix)
+	})
+	t.Run("Default", func(t *testing.T) {
+		// the default key format only has the workflow's name, which another workflow can have too
+		woc.artifactRepository = &wfv1.ArtifactRepository{S3: &wfv1.S3ArtifactRepository{S3Bucket: wfv1.S3Bucket{Bucket: "my-bucket"}}}
+		_, _, err := woc.getArtifactCredentialsScope()
+		assert.ErrorContains(t, err, "workflow's UID, or its namespace and name")
+	})
+	t.Run("Name", func(t *testing.T) {
+		woc.artifactRepository = &wfv1.ArtifactRepository{S3: &wfv1.S3ArtifactRepository{KeyFormat: "artifacts/{{workflow.name}}/{{pod.name}}"}}
+		_, _, err := woc.getArtifactCredentialsScope()
+		assert.ErrorContains(t, err, "workflow's UID, or its namespace and name")
+	})
+	t.Run("SharedPrefix", func(t *testing.T) {
+		woc.artifactRepository = &wfv1.ArtifactRepository{S3: &wfv1.S3ArtifactRepository{KeyFormat: "artifacts/{{pod.name}}/{{workflow.uid}}"}}
+		_, _, err := woc.getArtifactCredentialsScope()
+		assert.ErrorContains(t, err, "workflow's UID, or its namespace and name")
+	})
+	t.Run("NotS3", func(t *testing.T) {
+		woc.artifactRepository = &wfv1.ArtifactRepository{GCS: &wfv1.GCSArtifactRepository{}}
+		_, _, err := woc.getArtifactCredentialsScope()
+		assert.ErrorContains(t, err, "only supported for S3")
+	})
+}
+
+var artifactCredentialsWorkflow = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  name: artifact-credentials
+  namespace: default
+spec:
+  entrypoint: main
+  templates:
+  - name: main
+    artifactCredentials: true
+    container:
+      image: argoproj/argosay:v2
+`
+
+func TestArtifactCredentials(t *testing.T) {
+	var opts credentials.STSAssumeRoleOptions
+	defer func(f func(string, credentials.STSAssumeRoleOptions) (credentials.Value, error)) { assumeRole = f }(assumeRole)
+	assumeRole = func(endpoint string, o credentials.STSAssumeRoleOptions) (credentials.Value, error) {
+		assert.Equal(t, "https://sts.example.com", endpoint)
+		opts = o
+		return credentials.Value{AccessKeyID: "my-access-key-id", SecretAccessKey: "my-secret-access-key", SessionToken: "my-session-token"}, nil
+	}
+
+	ctx := context.Background()
+	wf := wfv1.MustUnmarshalWorkflow(artifactCredentialsWorkflow)
+	cancel, controller := newController(wf, func(c *WorkflowController) {
+		c.Config.ArtifactCredentials = &config.ArtifactCredentials{STSEndpoint: "https://sts.example.com"}
+		c.artifactRepositories = armocks.DummyArtifactRepositories(&wfv1.ArtifactRepository{
+			S3: &wfv1.S3ArtifactRepository{
+				S3Bucket: wfv1.S3Bucket{
+					Bucket:          "my-bucket",
+					Endpoint:        "my-endpoint",
+					AccessKeySecret: &apiv1.SecretKeySelector{LocalObjectReference: apiv1.LocalObjectReference{Name: "my-s3-credentials"}, Key: "accessKey"},
+					SecretKeySecret: &apiv1.SecretKeySelector{LocalObjectReference: apiv1.LocalObjectReference{Name: "my-s3-credentials"}, Key: "secretKey"},
+				},
+				KeyFormat: "{{workflow.namespace}}/{{workflow.name}}/{{pod.name}}",
+			},
+		})
+	})
+	defer cancel()
+	_, err := controller.kubeclientset.CoreV1().Secrets("default").Create(ctx, &apiv1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-s3-credentials"},
+		Data:       map[string][]byte{"accessKey": []byte("my-access-key"), "secretKey": []byte("my-secret-key")},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	woc := newWorkflowOperationCtx(wf, controller)
+	woc.operate(ctx)
+
+	assert.Equal(t, "my-access-key", opts.AccessKey)
+	assert.Equal(t, "my-secret-key", opts.SecretKey)
+	assert.Equal(t, 3600, opts.DurationSeconds)
+	assert.Contains(t, opts.Policy, `"arn:aws:s3:::my-bucket/default/artifact-credentials/*"`)
+
+	secret, err := controller.kubeclientset.CoreV1().Secrets("default").Get(ctx, "artifact-credentials-artifact-credentials", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "my-session-token", string(secret.Data["sessionToken"]))
+	assert.Contains(t, string(secret.Data["credentials"]), "aws_session_token = my-session-token")
+	expiration, err := time.Parse(time.RFC3339, string(secret.Data["expiration"]))
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), expiration, time.Minute)
+
+	pods, err := listPods(woc)
+	require.NoError(t, err)
+	require.Len(t, pods.Items, 1)
+	pod := pods.Items[0]
+	assert.Contains(t, pod.Spec.Volumes, apiv1.Volume{
+		Name:         artifactCredentialsVolumeName,
+		VolumeSource: apiv1.VolumeSource{Secret: &apiv1.SecretVolumeSource{SecretName: "artifact-credentials-artifact-credentials"}},
+	})
+	for _, c := range pod.Spec.Containers {
+		if c.Name == common.MainContainerName {
+			assert.Contains(t, c.Env, apiv1.EnvVar{Name: common.EnvVarArtifactKeyPrefix, Value: "default/artifact-credentials/"})
+			assert.Contains(t, c.Env, apiv1.EnvVar{Name: "AWS_SHARED_CREDENTIALS_FILE", Value: "/etc/argo/artifact-credentials/credentials"})
+		} else {
+			assert.NotContains(t, c.Env, apiv1.EnvVar{Name: common.EnvVarArtifactBucket, Value: "my-bucket"})
+		}
+	}
+}
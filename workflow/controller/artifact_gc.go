@@ -22,8 +22,6 @@ import (
 	"github.com/argoproj/argo-workflows/v3/workflow/util"
 )
 
-const artifactGCComponent = "artifact-gc"
-
 // artifactGCEnabled is a feature flag to globally disabled artifact GC in case of emergency
 var artifactGCEnabled, _ = env.GetBool("ARGO_ARTIFACT_GC_ENABLED", true)
 
@@ -419,7 +417,7 @@ func (woc *wfOperationCtx) createArtifactGCPod(ctx context.Context, strategy wfv
 			Name: podName,
 			Labels: map[string]string{
 				common.LabelKeyWorkflow:  woc.wf.Name,
-				common.LabelKeyComponent: artifactGCComponent,
+				common.LabelKeyComponent: common.ArtifactGCComponent,
 				common.LabelKeyCompleted: "false",
 			},
 			Annotations: map[string]string{
@@ -515,7 +513,7 @@ func (woc *wfOperationCtx) processArtifactGCCompletion(ctx context.Context) erro
 	anyPodSuccess := false
 	for _, obj := range pods {
 		pod := obj.(*corev1.Pod)
-		if pod.Labels[common.LabelKeyComponent] != artifactGCComponent { // make sure it's an Artifact GC Pod
+		if pod.Labels[common.LabelKeyComponent] != common.ArtifactGCComponent { // make sure it's an Artifact GC Pod
 			continue
 		}
 
@@ -685,6 +683,7 @@ func (woc *wfOperationCtx) addArtGCCondition(msg string) {
 	woc.wf.Status.Conditions.UpsertCondition(wfv1.Condition{
 		Type:    wfv1.ConditionTypeArtifactGCError,
 		Status:  metav1.ConditionTrue,
+		Reason:  wfv1.ConditionReasonArtifactGCFailed,
 		Message: msg,
 	})
 }
@@ -0,0 +1,99 @@
+package controller
+
+import (
+	"fmt"
+	"strings"
+
+	apiv1 "k8s.io/api/core/v1"
+
+	"github.com/argoproj/argo-workflows/v3/errors"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+// mountLocation is where in a bucket a mounted artifact is
+type mountLocation struct {
+	repoType string
+	bucket   string
+	key      string
+	endpoint string
+	region   string
+}
+
+func newMountLocation(art *wfv1.Artifact) (*mountLocation, error) {
+	switch {
+	case art.S3 != nil:
+		return &mountLocation{repoType: "s3", bucket: art.S3.Bucket, key: art.S3.Key, endpoint: art.S3.Endpoint, region: art.S3.Region}, nil
+	case art.GCS != nil:
+		return &mountLocation{repoType: "gcs", bucket: art.GCS.Bucket, key: art.GCS.Key}, nil
+	case art.Azure != nil:
+		return &mountLocation{repoType: "azure", bucket: art.Azure.Container, key: art.Azure.Blob, endpoint: art.Azure.Endpoint}, nil
+	case art.OSS != nil:
+		return &mountLocation{repoType: "oss", bucket: art.OSS.Bucket, key: art.OSS.Key, endpoint: art.OSS.Endpoint}, nil
+	default:
+		return nil, fmt.Errorf("only s3, gcs, azure and oss artifacts can be mounted")
+	}
+}
+
+// addArtifactMounts mounts the input artifacts with mount: true into the main container, using the CSI driver
+// configured for their repository type. The executor does not download these artifacts.
+func (woc *wfOperationCtx) addArtifactMounts(pod *apiv1.Pod, tmpl *wfv1.Template) error {
+	for i, art := range tmpl.Inputs.Artifacts {
+		if !art.Mount {
+			continue
+		}
+		if !art.HasLocationOrKey() && art.Optional {
+			continue
+		}
+		if overlap := common.FindOverlappingVolume(tmpl, art.Path); overlap != nil {
+			return errors.Errorf(errors.CodeBadRequest, "inputs.artifacts.%s.mount: path overlaps with volume mount %s", art.Name, overlap.Name)
+		}
+		driverArt := art.DeepCopy()
+		if err := driverArt.Relocate(tmpl.ArchiveLocation); err != nil {
+			return err
+		}
+		loc, err := newMountLocation(driverArt)
+		if err != nil {
+			return errors.Errorf(errors.CodeBadRequest, "inputs.artifacts.%s.mount: %s", art.Name, err.Error())
+		}
+		driver, ok := woc.controller.Config.ArtifactMountDrivers[loc.repoType]
+		if !ok {
+			return errors.Errorf(errors.CodeBadRequest, "inputs.artifacts.%s.mount: no artifact mount driver is configured for %s artifacts", art.Name, loc.repoType)
+		}
+		replacer := strings.NewReplacer("{{bucket}}", loc.bucket, "{{endpoint}}", loc.endpoint, "{{region}}", loc.region)
+		attributes := make(map[string]string, len(driver.VolumeAttributes))
+		for k, v := range driver.VolumeAttributes {
+			attributes[k] = replacer.Replace(v)
+		}
+		readOnly := true
+		volume := apiv1.Volume{
+			Name: fmt.Sprintf("artifact-mount-%d", i),
+			VolumeSource: apiv1.VolumeSource{
+				CSI: &apiv1.CSIVolumeSource{
+					Driver:               driver.Driver,
+					ReadOnly:             &readOnly,
+					VolumeAttributes:     attributes,
+					NodePublishSecretRef: driver.NodePublishSecretRef,
+				},
+			},
+		}
+		pod.Spec.Volumes = append(pod.Spec.Volumes, volume)
+		for j, c := range pod.Spec.Containers {
+			if c.Name == common.MainContainerName {
+				pod.Spec.Containers[j].VolumeMounts = append(c.VolumeMounts, apiv1.VolumeMount{
+					Name:      volume.Name,
+					MountPath: art.Path,
+					SubPath:   strings.Trim(loc.key, "/"),
+					ReadOnly:  true,
+				})
+			}
+		}
+		for k, v := range driver.PodAnnotations {
+			if pod.ObjectMeta.Annotations == nil {
+				pod.ObjectMeta.Annotations = map[string]string{}
+			}
+			pod.ObjectMeta.Annotations[k] = v
+		}
+	}
+	return nil
+}
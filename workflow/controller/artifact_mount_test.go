@@ -0,0 +1,87 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	apiv1 "k8s.io/api/core/v1"
+
+	"github.com/argoproj/argo-workflows/v3/config"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+var artifactMountWf = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  name: artifact-mount
+spec:
+  entrypoint: main
+  templates:
+  - name: main
+    inputs:
+      artifacts:
+      - name: dataset
+        path: /data
+        mount: true
+        s3:
+          endpoint: minio:9000
+          bucket: datasets
+          key: imagenet/
+    container:
+      image: argoproj/argosay:v2
+`
+
+func TestArtifactMount(t *testing.T) {
+	t.Run("Mounted", func(t *testing.T) {
+		wf := wfv1.MustUnmarshalWorkflow(artifactMountWf)
+		cancel, controller := newController(wf)
+		defer cancel()
+		controller.Config.ArtifactMountDrivers = map[string]config.ArtifactMountDriver{
+			"s3": {
+				Driver:           "s3.csi.example.com",
+				VolumeAttributes: map[string]string{"bucketName": "{{bucket}}", "endpoint": "http://{{endpoint}}"},
+				PodAnnotations:   map[string]string{"example.com/fuse": "true"},
+			},
+		}
+		ctx := context.Background()
+		woc := newWorkflowOperationCtx(wf, controller)
+		woc.operate(ctx)
+		pods, err := listPods(woc)
+		if assert.NoError(t, err) && assert.Len(t, pods.Items, 1) {
+			pod := pods.Items[0]
+			assert.Equal(t, "true", pod.Annotations["example.com/fuse"])
+			var csi *apiv1.CSIVolumeSource
+			for _, v := range pod.Spec.Volumes {
+				if v.Name == "artifact-mount-0" {
+					csi = v.CSI
+				}
+			}
+			if assert.NotNil(t, csi) {
+				assert.Equal(t, "s3.csi.example.com", csi.Driver)
+				assert.Equal(t, map[string]string{"bucketName": "datasets", "endpoint": "http://minio:9000"}, csi.VolumeAttributes)
+				assert.True(t, *csi.ReadOnly)
+			}
+			for _, c := range pod.Spec.Containers {
+				if c.Name == common.MainContainerName {
+					assert.Contains(t, c.VolumeMounts, apiv1.VolumeMount{Name: "artifact-mount-0", MountPath: "/data", SubPath: "imagenet", ReadOnly: true})
+					for _, m := range c.VolumeMounts {
+						assert.NotEqual(t, "input-artifacts", m.Name)
+					}
+				}
+			}
+		}
+	})
+	t.Run("NoDriver", func(t *testing.T) {
+		wf := wfv1.MustUnmarshalWorkflow(artifactMountWf)
+		cancel, controller := newController(wf)
+		defer cancel()
+		ctx := context.Background()
+		woc := newWorkflowOperationCtx(wf, controller)
+		woc.operate(ctx)
+		assert.Equal(t, wfv1.WorkflowError, woc.wf.Status.Phase)
+		assert.Contains(t, woc.wf.Status.Message, "no artifact mount driver is configured for s3 artifacts")
+	})
+}
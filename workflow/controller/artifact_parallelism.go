@@ -0,0 +1,27 @@
+package controller
+
+import (
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+// hasArtifactTransfers returns true if the pods of the template load input artifacts, or save output artifacts or logs
+func (woc *wfOperationCtx) hasArtifactTransfers(tmpl *wfv1.Template) bool {
+	return hasLoadedInputArtifacts(tmpl) || len(tmpl.Outputs.Artifacts) > 0 || woc.IsArchiveLogs(tmpl)
+}
+
+// checkArtifactParallelism checks if a pod that loads or saves artifacts can be created for the given template,
+// considering the workflow's artifact parallelism. Pods that have already been created are not held back.
+func (woc *wfOperationCtx) checkArtifactParallelism(tmpl *wfv1.Template, node *wfv1.NodeStatus) error {
+	limit := woc.execWf.Spec.ArtifactParallelism
+	if limit == nil || woc.activeArtifactPods < *limit || !tmpl.IsPodType() {
+		return nil
+	}
+	if node != nil && woc.nodePodExist(*node) {
+		return nil
+	}
+	if !woc.hasArtifactTransfers(tmpl) {
+		return nil
+	}
+	woc.log.Infof("workflow active artifact pod parallelism reached %d/%d", woc.activeArtifactPods, *limit)
+	return ErrParallelismReached
+}
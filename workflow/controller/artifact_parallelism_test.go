@@ -0,0 +1,80 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	apiv1 "k8s.io/api/core/v1"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+var artifactParallelismWorkflow = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  name: artifact-parallelism
+spec:
+  entrypoint: main
+  artifactParallelism: 1
+  templates:
+  - name: main
+    steps:
+    - - name: load
+        template: load
+        withItems: [1, 2, 3]
+      - name: print
+        template: print
+        withItems: [1, 2]
+  - name: load
+    inputs:
+      artifacts:
+      - name: data
+        path: /tmp/data
+        raw:
+          data: hello
+    container:
+      image: argoproj/argosay:v2
+  - name: print
+    container:
+      image: argoproj/argosay:v2
+`
+
+func TestArtifactParallelism(t *testing.T) {
+	ctx := context.Background()
+	wf := wfv1.MustUnmarshalWorkflow(artifactParallelismWorkflow)
+	cancel, controller := newController(wf)
+	defer cancel()
+
+	woc := newWorkflowOperationCtx(wf, controller)
+	woc.operate(ctx)
+	pods, err := listPods(woc)
+	assert.NoError(t, err)
+	assert.Len(t, pods.Items, 3)
+	artifactPods := 0
+	for _, pod := range pods.Items {
+		if pod.Labels[common.LabelKeyArtifacts] == "true" {
+			artifactPods++
+		}
+	}
+	assert.Equal(t, 1, artifactPods)
+
+	makePodsPhase(ctx, woc, apiv1.PodRunning)
+
+	// the pod that loads artifacts is still running, so no more are created
+	woc = newWorkflowOperationCtx(woc.wf, controller)
+	woc.operate(ctx)
+	pods, err = listPods(woc)
+	assert.NoError(t, err)
+	assert.Len(t, pods.Items, 3)
+
+	makePodsPhase(ctx, woc, apiv1.PodSucceeded)
+
+	woc = newWorkflowOperationCtx(woc.wf, controller)
+	woc.operate(ctx)
+	pods, err = listPods(woc)
+	assert.NoError(t, err)
+	assert.Len(t, pods.Items, 4)
+}
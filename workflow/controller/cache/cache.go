@@ -15,7 +15,7 @@ var cacheKeyRegex = regexp.MustCompile("^[a-zA-Z0-9][-a-zA-Z0-9]*$")
 
 type MemoizationCache interface {
 	Load(ctx context.Context, key string) (*Entry, error)
-	Save(ctx context.Context, key string, nodeId string, value *wfv1.Outputs) error
+	Save(ctx context.Context, key string, nodeId string, value *wfv1.Outputs, wf *wfv1.Workflow) error
 }
 
 type Entry struct {
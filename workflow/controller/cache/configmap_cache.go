@@ -112,7 +112,7 @@ func (c *configMapCache) Load(ctx context.Context, key string) (*Entry, error) {
 	return &entry, nil
 }
 
-func (c *configMapCache) Save(ctx context.Context, key string, nodeId string, value *wfv1.Outputs) error {
+func (c *configMapCache) Save(ctx context.Context, key string, nodeId string, value *wfv1.Outputs, wf *wfv1.Workflow) error {
 	if !cacheKeyRegex.MatchString(key) {
 		errString := fmt.Sprintf("invalid cache key: %s", key)
 		err := errors.New(errString)
@@ -144,7 +144,16 @@ func (c *configMapCache) Save(ctx context.Context, key string, nodeId string, va
 	}
 
 	creationTime := time.Now()
-	cache.SetLabels(map[string]string{common.LabelKeyConfigMapType: common.LabelValueTypeConfigMapCache})
+	if cache.Labels == nil {
+		cache.Labels = make(map[string]string)
+	}
+	cache.Labels[common.LabelKeyConfigMapType] = common.LabelValueTypeConfigMapCache
+	// record the workflow that last saved to the cache, so it can be audited later. The cache is shared by workflows,
+	// so its type label stops it being taken for a resource of this workflow once the workflow is deleted.
+	if wf != nil {
+		cache.Labels[common.LabelKeyWorkflow] = wf.Name
+		cache.Labels[common.LabelKeyWorkflowUID] = string(wf.UID)
+	}
 
 	newEntry := Entry{
 		NodeID:            nodeId,
@@ -94,12 +94,20 @@ func TestConfigMapCacheSave(t *testing.T) {
 	ctx := context.Background()
 	outputs := wfv1.Outputs{}
 	outputs.Parameters = append(outputs.Parameters, MockParam)
-	err := c.Save(ctx, "hi-there-world", "", &outputs)
+	err := c.Save(ctx, "hi-there-world", "", &outputs, &wfv1.Workflow{ObjectMeta: metav1.ObjectMeta{Name: "my-wf", UID: "my-wf-uid"}})
+	assert.NoError(t, err)
+	err = c.Save(ctx, "hi-there-world", "", &outputs, &wfv1.Workflow{ObjectMeta: metav1.ObjectMeta{Name: "other-wf", UID: "other-wf-uid"}})
 	assert.NoError(t, err)
 
 	cm, err := controller.kubeclientset.CoreV1().ConfigMaps("default").Get(ctx, "whalesay-cache", metav1.GetOptions{})
 	assert.NoError(t, err)
 	assert.NotNil(t, cm)
+	// the cache is labelled with the workflow that last saved to it
+	assert.Equal(t, map[string]string{
+		common.LabelKeyConfigMapType: common.LabelValueTypeConfigMapCache,
+		common.LabelKeyWorkflow:      "other-wf",
+		common.LabelKeyWorkflowUID:   "other-wf-uid",
+	}, cm.Labels)
 	var entry cache.Entry
 	wfv1.MustUnmarshal([]byte(cm.Data["hi-there-world"]), &entry)
 	assert.Equal(t, entry.LastHitTimestamp.Time, entry.CreationTimestamp.Time)
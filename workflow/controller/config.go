@@ -5,7 +5,6 @@ import (
 	"fmt"
 
 	log "github.com/sirupsen/logrus"
-	"golang.org/x/time/rate"
 	apiv1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"sigs.k8s.io/yaml"
@@ -14,6 +13,7 @@ import (
 	"github.com/argoproj/argo-workflows/v3/persist/sqldb"
 	"github.com/argoproj/argo-workflows/v3/util/instanceid"
 	"github.com/argoproj/argo-workflows/v3/workflow/artifactrepositories"
+	"github.com/argoproj/argo-workflows/v3/workflow/export"
 	"github.com/argoproj/argo-workflows/v3/workflow/hydrator"
 )
 
@@ -70,9 +70,18 @@ func (wfc *WorkflowController) updateConfig() error {
 	}
 
 	wfc.hydrator = hydrator.New(wfc.offloadNodeStatusRepo)
+	if err := wfc.updateExportConfig(); err != nil {
+		return err
+	}
 	wfc.updateEstimatorFactory()
 	wfc.rateLimiter = wfc.newRateLimiter()
 	wfc.maxStackDepth = wfc.getMaxStackDepth()
+	if wfc.syncManager != nil {
+		wfc.syncManager.SetAging(wfc.Config.GetSemaphoreAging())
+	}
+	if wfc.faultInjector != nil {
+		wfc.faultInjector.SetConfig(wfc.Config.FaultInjection)
+	}
 
 	log.WithField("executorImage", wfc.executorImage()).
 		WithField("executorImagePullPolicy", wfc.executorImagePullPolicy()).
@@ -81,6 +90,34 @@ func (wfc *WorkflowController) updateConfig() error {
 	return nil
 }
 
+func (wfc *WorkflowController) updateExportConfig() error {
+	wfc.exportLabelSelector = labels.Nothing()
+	if wfc.exportQueue == nil {
+		return nil
+	}
+	workflowExport := wfc.Config.WorkflowExport
+	if !workflowExport.IsEnabled() {
+		wfc.exportQueue.Configure(nil, 0, 0)
+		log.Info("Workflow export is disabled")
+		return nil
+	}
+	if err := workflowExport.Validate(); err != nil {
+		return err
+	}
+	selector, err := workflowExport.GetLabelSelector()
+	if err != nil {
+		return err
+	}
+	exporters, err := export.NewExporters(wfc.kubeclientset, wfc.namespace, workflowExport)
+	if err != nil {
+		return err
+	}
+	wfc.exportLabelSelector = selector
+	wfc.exportQueue.Configure(exporters, workflowExport.GetBatchSize(), workflowExport.GetFlushInterval())
+	log.WithField("sinks", len(exporters)).Info("Workflow export is enabled")
+	return nil
+}
+
 // initDB inits argo DB tables
 func (wfc *WorkflowController) initDB() error {
 	persistence := wfc.Config.Persistence
@@ -96,9 +133,8 @@ func (wfc *WorkflowController) initDB() error {
 	return sqldb.NewMigrate(wfc.session, persistence.GetClusterName(), tableName).Exec(context.Background())
 }
 
-func (wfc *WorkflowController) newRateLimiter() *rate.Limiter {
-	rateLimiter := wfc.Config.GetResourceRateLimit()
-	return rate.NewLimiter(rate.Limit(rateLimiter.Limit), rateLimiter.Burst)
+func (wfc *WorkflowController) newRateLimiter() *podRateLimiter {
+	return newPodRateLimiter(wfc.Config.GetResourceRateLimit())
 }
 
 // executorImage returns the image to use for the workflow executor
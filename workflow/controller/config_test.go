@@ -13,6 +13,7 @@ func TestUpdateConfig(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, controller.Config)
 	assert.NotNil(t, controller.archiveLabelSelector)
+	assert.NotNil(t, controller.exportLabelSelector)
 	assert.NotNil(t, controller.wfArchive)
 	assert.NotNil(t, controller.offloadNodeStatusRepo)
 }
@@ -46,6 +46,7 @@ spec:
 		{Name: "tmp-dir-argo", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}},
 		{Name: "var-run-argo", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}},
 		{Name: "workspace", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}},
+		{Name: "argo-signal", VolumeSource: corev1.VolumeSource{DownwardAPI: &corev1.DownwardAPIVolumeSource{Items: []corev1.DownwardAPIVolumeFile{{Path: "signal", FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.annotations['workflows.argoproj.io/signal']"}}}}}},
 	}, pod.Spec.Volumes)
 
 	assert.NotEmpty(t, pod.Spec.InitContainers)
@@ -55,6 +56,7 @@ spec:
 		switch c.Name {
 		case common.WaitContainerName:
 			assert.ElementsMatch(t, []corev1.VolumeMount{
+				{Name: "argo-signal", MountPath: common.SignalMountPath, ReadOnly: true},
 				{Name: "tmp-dir-argo", MountPath: "/tmp", SubPath: "0"},
 				{Name: "var-run-argo", MountPath: common.VarRunArgoPath},
 			}, c.VolumeMounts)
@@ -115,6 +117,7 @@ spec:
 		{Name: "var-run-argo", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}},
 		{Name: "workspace", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}},
 		{Name: "input-artifacts", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}},
+		{Name: "argo-signal", VolumeSource: corev1.VolumeSource{DownwardAPI: &corev1.DownwardAPIVolumeSource{Items: []corev1.DownwardAPIVolumeFile{{Path: "signal", FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.annotations['workflows.argoproj.io/signal']"}}}}}},
 	}, pod.Spec.Volumes)
 
 	if assert.Len(t, pod.Spec.InitContainers, 1) {
@@ -131,6 +134,7 @@ spec:
 		switch c.Name {
 		case common.WaitContainerName:
 			assert.ElementsMatch(t, []corev1.VolumeMount{
+				{Name: "argo-signal", MountPath: common.SignalMountPath, ReadOnly: true},
 				{Name: "workspace", MountPath: "/mainctrfs/workspace"},
 				{Name: "input-artifacts", MountPath: "/mainctrfs/in/in-0", SubPath: "in-0"},
 				{Name: "tmp-dir-argo", MountPath: "/tmp", SubPath: "0"},
@@ -194,6 +198,7 @@ spec:
 		{Name: "tmp-dir-argo", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}},
 		{Name: "var-run-argo", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}},
 		{Name: "workspace", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}},
+		{Name: "argo-signal", VolumeSource: corev1.VolumeSource{DownwardAPI: &corev1.DownwardAPIVolumeSource{Items: []corev1.DownwardAPIVolumeFile{{Path: "signal", FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.annotations['workflows.argoproj.io/signal']"}}}}}},
 	}, pod.Spec.Volumes)
 
 	assert.NotEmpty(t, pod.Spec.InitContainers)
@@ -203,6 +208,7 @@ spec:
 		switch c.Name {
 		case common.WaitContainerName:
 			assert.ElementsMatch(t, []corev1.VolumeMount{
+				{Name: "argo-signal", MountPath: common.SignalMountPath, ReadOnly: true},
 				{Name: "workspace", MountPath: "/mainctrfs/workspace"},
 				{Name: "tmp-dir-argo", MountPath: "/tmp", SubPath: "0"},
 				{Name: "var-run-argo", MountPath: common.VarRunArgoPath},
@@ -14,7 +14,6 @@ import (
 	syncpkg "github.com/argoproj/pkg/sync"
 	log "github.com/sirupsen/logrus"
 	"golang.org/x/exp/slices"
-	"golang.org/x/time/rate"
 	apiv1 "k8s.io/api/core/v1"
 	apierr "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
@@ -34,6 +33,7 @@ import (
 	"k8s.io/client-go/tools/cache"
 	apiwatch "k8s.io/client-go/tools/watch"
 	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/yaml"
 
 	"github.com/argoproj/argo-workflows/v3"
 	"github.com/argoproj/argo-workflows/v3/config"
@@ -48,6 +48,7 @@ import (
 	"github.com/argoproj/argo-workflows/v3/util/diff"
 	"github.com/argoproj/argo-workflows/v3/util/env"
 	errorsutil "github.com/argoproj/argo-workflows/v3/util/errors"
+	"github.com/argoproj/argo-workflows/v3/util/faultinjection"
 	"github.com/argoproj/argo-workflows/v3/workflow/artifactrepositories"
 	"github.com/argoproj/argo-workflows/v3/workflow/common"
 	controllercache "github.com/argoproj/argo-workflows/v3/workflow/controller/cache"
@@ -56,11 +57,14 @@ import (
 	"github.com/argoproj/argo-workflows/v3/workflow/controller/indexes"
 	"github.com/argoproj/argo-workflows/v3/workflow/controller/informer"
 	"github.com/argoproj/argo-workflows/v3/workflow/controller/pod"
+	"github.com/argoproj/argo-workflows/v3/workflow/controller/preflight"
 	"github.com/argoproj/argo-workflows/v3/workflow/cron"
 	"github.com/argoproj/argo-workflows/v3/workflow/events"
+	"github.com/argoproj/argo-workflows/v3/workflow/export"
 	"github.com/argoproj/argo-workflows/v3/workflow/gccontroller"
 	"github.com/argoproj/argo-workflows/v3/workflow/hydrator"
 	"github.com/argoproj/argo-workflows/v3/workflow/metrics"
+	"github.com/argoproj/argo-workflows/v3/workflow/recording"
 	"github.com/argoproj/argo-workflows/v3/workflow/signal"
 	"github.com/argoproj/argo-workflows/v3/workflow/sync"
 	"github.com/argoproj/argo-workflows/v3/workflow/util"
@@ -82,6 +86,8 @@ type WorkflowController struct {
 	artifactRepositories artifactrepositories.Interface
 	// get images
 	entrypoint entrypoint.Interface
+	// resolve image digests for image preflight
+	imageResolver preflight.ImageResolver
 
 	// cliExecutorImage is the executor image as specified from the command line
 	cliExecutorImage string
@@ -96,7 +102,7 @@ type WorkflowController struct {
 	// restConfig is used by controller to send a SIGUSR1 to the wait sidecar using remotecommand.NewSPDYExecutor().
 	restConfig       *rest.Config
 	kubeclientset    kubernetes.Interface
-	rateLimiter      *rate.Limiter
+	rateLimiter      *podRateLimiter
 	dynamicInterface dynamic.Interface
 	wfclientset      wfclientset.Interface
 
@@ -123,6 +129,9 @@ type WorkflowController struct {
 	metrics               *metrics.Metrics
 	eventRecorderManager  events.EventRecorderManager
 	archiveLabelSelector  labels.Selector
+	exportQueue           *export.Queue
+	statusUpdates         *statusUpdates
+	exportLabelSelector   labels.Selector
 	cacheFactory          controllercache.Factory
 	wfTaskSetInformer     wfextvv1alpha1.WorkflowTaskSetInformer
 	artGCTaskInformer     wfextvv1alpha1.WorkflowArtifactGCTaskInformer
@@ -135,6 +144,10 @@ type WorkflowController struct {
 	// Default is 3s and can be configured using the env var ARGO_PROGRESS_FILE_TICK_DURATION
 	progressFileTickDuration time.Duration
 	executorPlugins          map[string]map[string]*spec.Plugin // namespace -> name -> plugin
+	// faultInjector injects faults into workflows for testing, nil unless the controller is started with --fault-injection
+	faultInjector *faultinjection.Injector
+	// recorder records the reconciliations of workflows for `argo admin replay`, nil if none are recorded
+	recorder *recording.Recorder
 }
 
 const (
@@ -186,6 +199,7 @@ func NewWorkflowController(ctx context.Context, restConfig *rest.Config, kubecli
 		workflowKeyLock:            syncpkg.NewKeyLock(),
 		cacheFactory:               controllercache.NewCacheFactory(kubeclientset, namespace),
 		eventRecorderManager:       events.NewEventRecorderManager(kubeclientset),
+		statusUpdates:              newStatusUpdates(),
 		progressPatchTickDuration:  env.LookupEnvDurationOr(common.EnvVarProgressPatchTickDuration, 1*time.Minute),
 		progressFileTickDuration:   env.LookupEnvDurationOr(common.EnvVarProgressFileTickDuration, 3*time.Second),
 	}
@@ -194,10 +208,12 @@ func NewWorkflowController(ctx context.Context, restConfig *rest.Config, kubecli
 		wfc.executorPlugins = map[string]map[string]*spec.Plugin{}
 	}
 
+	wfc.exportQueue = export.NewQueue(wfc.markWorkflowsExported)
 	wfc.UpdateConfig(ctx)
 	wfc.maxStackDepth = wfc.getMaxStackDepth()
 	wfc.metrics = metrics.New(wfc.getMetricsServerConfig())
 	wfc.entrypoint = entrypoint.New(kubeclientset, wfc.Config.Images)
+	wfc.imageResolver = preflight.NewAsyncImageResolver(preflight.NewImageResolver(kubeclientset))
 
 	workqueue.SetProvider(wfc.metrics) // must execute SetProvider before we created the queues
 	wfc.wfQueue = wfc.metrics.RateLimiterWithBusyWorkers(&fixedItemIntervalRateLimiter{}, "workflow_queue")
@@ -314,6 +330,7 @@ func (wfc *WorkflowController) Run(ctx context.Context, wfWorkers, workflowTTLWo
 	}
 	go wfc.workflowGarbageCollector(ctx.Done())
 	go wfc.archivedWorkflowGarbageCollector(ctx.Done())
+	go wfc.exportQueue.Run(ctx)
 
 	go wfc.runGCcontroller(ctx, workflowTTLWorkers)
 	go wfc.runCronController(ctx, cronWorkflowWorkers)
@@ -328,6 +345,9 @@ func (wfc *WorkflowController) Run(ctx context.Context, wfWorkers, workflowTTLWo
 	if cacheGCPeriod != 0 {
 		go wait.JitterUntilWithContext(ctx, wfc.syncAllCacheForGC, cacheGCPeriod, 0.0, true)
 	}
+	if orphanGCPeriod != 0 {
+		go wait.JitterUntilWithContext(ctx, wfc.gcOrphans, orphanGCPeriod, 0.0, true)
+	}
 	<-ctx.Done()
 }
 
@@ -349,7 +369,7 @@ func (wfc *WorkflowController) createSynchronizationManager(ctx context.Context)
 
 		value, found := configMap.Data[lockName.Key]
 		if !found {
-			return 0, argoErr.New(argoErr.CodeBadRequest, fmt.Sprintf("Sync configuration key '%s' not found in ConfigMap", lockName.Key))
+			return 0, argoErr.New(argoErr.CodeSemaphoreConfig, fmt.Sprintf("Sync configuration key '%s' not found in ConfigMap", lockName.Key))
 		}
 		return strconv.Atoi(value)
 	}
@@ -368,6 +388,7 @@ func (wfc *WorkflowController) createSynchronizationManager(ctx context.Context)
 	}
 
 	wfc.syncManager = sync.NewLockManager(getSyncLimit, nextWorkflow, isWFDeleted)
+	wfc.syncManager.SetAging(wfc.Config.GetSemaphoreAging())
 }
 
 // list all running workflows to initialize throttler and syncManager
@@ -755,12 +776,15 @@ func (wfc *WorkflowController) processNextItem(ctx context.Context) bool {
 
 	if !wfc.throttler.Admit(key.(string)) {
 		log.WithField("key", key).Info("Workflow processing has been postponed due to max parallelism limit")
+		message := "Workflow processing has been postponed because too many workflows are already running"
 		if woc.wf.Status.Phase == wfv1.WorkflowUnknown {
-			woc.markWorkflowPhase(ctx, wfv1.WorkflowPending, "Workflow processing has been postponed because too many workflows are already running")
-			woc.persistUpdates(ctx)
+			woc.markWorkflowPhase(ctx, wfv1.WorkflowPending, message)
 		}
+		woc.markSubmissionPending(message)
+		woc.persistUpdates(ctx)
 		return true
 	}
+	woc.markSubmissionAdmitted()
 
 	// make sure this is removed from the throttler is complete
 	defer func() {
@@ -777,9 +801,17 @@ func (wfc *WorkflowController) processNextItem(ctx context.Context) bool {
 		woc.persistUpdates(ctx)
 		return true
 	}
+	var reconciliation *recording.Reconciliation
+	if wfc.recorder.Enabled(woc.wf) {
+		reconciliation = wfc.newReconciliation(woc)
+	}
 	startTime := time.Now()
 	woc.operate(ctx)
 	wfc.metrics.OperationCompleted(time.Since(startTime).Seconds())
+	if reconciliation != nil {
+		reconciliation.Result = woc.wf.DeepCopy()
+		wfc.recorder.Record(key.(string), *reconciliation)
+	}
 	if woc.wf.Status.Fulfilled() {
 		err := woc.completeTaskSet(ctx)
 		if err != nil {
@@ -905,11 +937,26 @@ func (wfc *WorkflowController) addWorkflowInformerHandlers(ctx context.Context)
 		},
 	},
 	)
+	wfc.wfInformer.AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: func(obj interface{}) bool {
+			un, ok := obj.(*unstructured.Unstructured)
+			return ok && un.GetLabels()[common.LabelKeyWorkflowExportStatus] == "Pending"
+		},
+		Handler: cache.ResourceEventHandlerFuncs{
+			AddFunc: func(obj interface{}) {
+				wfc.exportWorkflow(obj)
+			},
+			UpdateFunc: func(_, obj interface{}) {
+				wfc.exportWorkflow(obj)
+			},
+		},
+	})
 	wfc.wfInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
 		DeleteFunc: func(obj interface{}) {
 			wf, ok := obj.(*unstructured.Unstructured)
 			if ok { // maybe cache.DeletedFinalStateUnknown
 				wfc.metrics.StopRealtimeMetricsForKey(string(wf.GetUID()))
+				wfc.statusUpdates.forget(wf.GetUID())
 			}
 		},
 	})
@@ -975,6 +1022,54 @@ func (wfc *WorkflowController) archiveWorkflowAux(ctx context.Context, obj inter
 	return nil
 }
 
+func (wfc *WorkflowController) exportWorkflow(obj interface{}) {
+	un, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return
+	}
+	logCtx := log.WithFields(log.Fields{"namespace": un.GetNamespace(), "workflow": un.GetName()})
+	wf, err := util.FromUnstructured(un)
+	if err != nil {
+		logCtx.WithError(err).Error("failed to convert to workflow from unstructured")
+		return
+	}
+	err = wfc.hydrator.Hydrate(wf)
+	if err != nil {
+		logCtx.WithError(err).Error("failed to hydrate workflow")
+		return
+	}
+	record, err := export.NewRecord(wf)
+	if err != nil {
+		logCtx.WithError(err).Error("failed to create the export record of the workflow")
+		return
+	}
+	if wfc.exportQueue.Add(record) {
+		logCtx.Info("queued workflow for export")
+	}
+}
+
+// markWorkflowsExported labels the workflows of the records as exported, so they are not exported again
+func (wfc *WorkflowController) markWorkflowsExported(ctx context.Context, records []export.Record) {
+	data, err := json.Marshal(map[string]interface{}{
+		"metadata": metav1.ObjectMeta{
+			Labels: map[string]string{
+				common.LabelKeyWorkflowExportStatus: "Exported",
+			},
+		},
+	})
+	if err != nil {
+		log.WithError(err).Error("failed to marshal patch")
+		return
+	}
+	for _, r := range records {
+		_, err := wfc.wfclientset.ArgoprojV1alpha1().Workflows(r.Namespace).Patch(ctx, r.Name, types.MergePatchType, data, metav1.PatchOptions{})
+		// the workflow may have been deleted since it was exported
+		if err != nil && !apierr.IsNotFound(err) {
+			log.WithFields(log.Fields{"namespace": r.Namespace, "workflow": r.Name}).WithError(err).Error("failed to mark workflow as exported")
+		}
+	}
+}
+
 var (
 	incompleteReq, _ = labels.NewRequirement(common.LabelKeyCompleted, selection.Equals, []string{"false"})
 	workflowReq, _   = labels.NewRequirement(common.LabelKeyWorkflow, selection.Exists, nil)
@@ -1123,10 +1218,15 @@ func (wfc *WorkflowController) updateEstimatorFactory() {
 
 // setWorkflowDefaults sets values in the workflow.Spec with defaults from the
 // workflowController. Values in the workflow will be given the upper hand over the defaults.
-// The defaults for the workflow controller are set in the workflow-controller config map
+// The defaults for the workflow controller are set in the workflow-controller config map, and may be overridden
+// per namespace by a config map labelled as WorkflowDefaults
 func (wfc *WorkflowController) setWorkflowDefaults(wf *wfv1.Workflow) error {
-	if wfc.Config.WorkflowDefaults != nil {
-		err := util.MergeTo(wfc.Config.WorkflowDefaults, wf)
+	wfDefaults, err := wfc.getWorkflowDefaults(wf.Namespace)
+	if err != nil {
+		return err
+	}
+	if wfDefaults != nil {
+		err := util.MergeTo(wfDefaults, wf)
 		if err != nil {
 			return err
 		}
@@ -1134,6 +1234,49 @@ func (wfc *WorkflowController) setWorkflowDefaults(wf *wfv1.Workflow) error {
 	return nil
 }
 
+// getWorkflowDefaults returns the controller's workflow defaults, overridden by the namespace's, or nil if neither are set
+func (wfc *WorkflowController) getWorkflowDefaults(namespace string) (*wfv1.Workflow, error) {
+	if wfc.configMapInformer == nil {
+		return wfc.Config.WorkflowDefaults, nil
+	}
+	objs, err := wfc.configMapInformer.GetIndexer().ByIndex(indexes.ConfigMapLabelsIndex, common.LabelValueTypeConfigMapWorkflowDefaults)
+	if err != nil {
+		return nil, err
+	}
+	var cm *apiv1.ConfigMap
+	for _, obj := range objs {
+		c, ok := obj.(*apiv1.ConfigMap)
+		// if there is more than one in the namespace, use the first by name so that the choice is stable
+		if ok && c.Namespace == namespace && (cm == nil || c.Name < cm.Name) {
+			cm = c
+		}
+	}
+	if cm == nil {
+		return wfc.Config.WorkflowDefaults, nil
+	}
+	nsDefaults := &wfv1.Workflow{}
+	if err := yaml.Unmarshal([]byte(cm.Data[common.ConfigMapKeyWorkflowDefaults]), nsDefaults); err != nil {
+		return nil, fmt.Errorf("invalid workflow defaults in config map %s/%s: %w", cm.Namespace, cm.Name, err)
+	}
+	if wfc.Config.WorkflowDefaults != nil {
+		if err := util.MergeTo(wfc.Config.WorkflowDefaults.DeepCopy(), nsDefaults); err != nil {
+			return nil, err
+		}
+	}
+	return nsDefaults, nil
+}
+
+// SetFaultInjector makes the controller inject faults into workflows as configured by the faultInjection config
+func (wfc *WorkflowController) SetFaultInjector(i *faultinjection.Injector) {
+	wfc.faultInjector = i
+	i.SetConfig(wfc.Config.FaultInjection)
+}
+
+// SetRecorder makes the controller record the reconciliations of the workflows the recorder is enabled for
+func (wfc *WorkflowController) SetRecorder(r *recording.Recorder) {
+	wfc.recorder = r
+}
+
 func (wfc *WorkflowController) GetManagedNamespace() string {
 	if wfc.managedNamespace != "" {
 		return wfc.managedNamespace
@@ -1207,6 +1350,10 @@ func (wfc *WorkflowController) isArchivable(wf *wfv1.Workflow) bool {
 	return wfc.archiveLabelSelector.Matches(labels.Set(wf.Labels))
 }
 
+func (wfc *WorkflowController) isExportable(wf *wfv1.Workflow) bool {
+	return wfc.exportLabelSelector.Matches(labels.Set(wf.Labels))
+}
+
 func (wfc *WorkflowController) syncWorkflowPhaseMetrics() {
 	defer runtimeutil.HandleCrash(runtimeutil.PanicHandlers...)
 
@@ -1223,6 +1370,38 @@ func (wfc *WorkflowController) syncWorkflowPhaseMetrics() {
 		errors.CheckError(err)
 		metrics.WorkflowConditionMetric.WithLabelValues(string(x.Type), string(x.Status)).Set(float64(len(keys)))
 	}
+	if tenants := wfc.Config.MetricsConfig.Tenants; tenants != nil {
+		wfc.syncTenantWorkflowMetrics(tenants)
+	}
+}
+
+// syncTenantWorkflowMetrics counts the workflows by namespace, workflow template and phase
+func (wfc *WorkflowController) syncTenantWorkflowMetrics(tenants *config.TenantMetrics) {
+	counts := make(metrics.TenantCounts)
+	for _, phase := range []wfv1.NodePhase{wfv1.NodePending, wfv1.NodeRunning, wfv1.NodeSucceeded, wfv1.NodeFailed, wfv1.NodeError} {
+		objs, err := wfc.wfInformer.GetIndexer().ByIndex(indexes.WorkflowPhaseIndex, string(phase))
+		errors.CheckError(err)
+		for _, obj := range objs {
+			un, ok := obj.(*unstructured.Unstructured)
+			if !ok {
+				continue
+			}
+			counts.Add(tenants, un.GetNamespace(), getWorkflowTemplateName(un), string(phase))
+		}
+	}
+	metrics.SetTenantWorkflowCounts(counts.Limit(tenants.GetMaxTenants()))
+}
+
+// getWorkflowTemplateName returns the name of the workflow template or cluster workflow template the workflow was
+// submitted from or references, or the empty string if there is none
+func getWorkflowTemplateName(un *unstructured.Unstructured) string {
+	if name, _, _ := unstructured.NestedString(un.Object, "spec", "workflowTemplateRef", "name"); name != "" {
+		return name
+	}
+	if name := un.GetLabels()[common.LabelKeyWorkflowTemplate]; name != "" {
+		return name
+	}
+	return un.GetLabels()[common.LabelKeyClusterWorkflowTemplate]
 }
 
 func (wfc *WorkflowController) syncPodPhaseMetrics() {
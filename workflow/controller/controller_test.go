@@ -7,6 +7,7 @@ import (
 
 	"github.com/argoproj/pkg/sync"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	authorizationv1 "k8s.io/api/authorization/v1"
 	apiv1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -34,7 +35,9 @@ import (
 	controllercache "github.com/argoproj/argo-workflows/v3/workflow/controller/cache"
 	"github.com/argoproj/argo-workflows/v3/workflow/controller/entrypoint"
 	"github.com/argoproj/argo-workflows/v3/workflow/controller/estimation"
+	"github.com/argoproj/argo-workflows/v3/workflow/controller/preflight"
 	"github.com/argoproj/argo-workflows/v3/workflow/events"
+	"github.com/argoproj/argo-workflows/v3/workflow/export"
 	hydratorfake "github.com/argoproj/argo-workflows/v3/workflow/hydrator/fake"
 	"github.com/argoproj/argo-workflows/v3/workflow/metrics"
 	"github.com/argoproj/argo-workflows/v3/workflow/util"
@@ -196,6 +199,7 @@ func newController(options ...interface{}) (context.CancelFunc, *WorkflowControl
 		estimatorFactory:          estimation.DummyEstimatorFactory,
 		eventRecorderManager:      &testEventRecorderManager{eventRecorder: record.NewFakeRecorder(64)},
 		archiveLabelSelector:      labels.Everything(),
+		statusUpdates:             newStatusUpdates(),
 		cacheFactory:              controllercache.NewCacheFactory(kube, "default"),
 		progressPatchTickDuration: envutil.LookupEnvDurationOr(common.EnvVarProgressPatchTickDuration, 1*time.Minute),
 		progressFileTickDuration:  envutil.LookupEnvDurationOr(common.EnvVarProgressFileTickDuration, 3*time.Second),
@@ -214,10 +218,12 @@ func newController(options ...interface{}) (context.CancelFunc, *WorkflowControl
 	{
 		wfc.metrics = metrics.New(metrics.ServerConfig{}, metrics.ServerConfig{})
 		wfc.entrypoint = entrypoint.New(kube, wfc.Config.Images)
+		wfc.imageResolver = preflight.NewImageResolver(kube)
 		wfc.wfQueue = workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
 		wfc.throttler = wfc.newThrottler()
 		wfc.podCleanupQueue = workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
 		wfc.rateLimiter = wfc.newRateLimiter()
+		wfc.exportQueue = export.NewQueue(wfc.markWorkflowsExported)
 	}
 
 	// always compare to WorkflowController.Run to see what this block of code should be doing
@@ -325,6 +331,18 @@ func listPods(woc *wfOperationCtx) (*apiv1.PodList, error) {
 	return woc.controller.kubeclientset.CoreV1().Pods(woc.wf.Namespace).List(context.Background(), metav1.ListOptions{})
 }
 
+// withoutLastTransitionTimes returns the conditions without the times they were last upserted with a new status, so
+// they can be compared
+func withoutLastTransitionTimes(t *testing.T, conditions wfv1.Conditions) wfv1.Conditions {
+	var out wfv1.Conditions
+	for _, c := range conditions {
+		assert.NotNil(t, c.LastTransitionTime, c.Type)
+		c.LastTransitionTime = nil
+		out = append(out, c)
+	}
+	return out
+}
+
 type with func(pod *apiv1.Pod)
 
 func withOutputs(v interface{}) with {
@@ -460,6 +478,38 @@ func TestAddingWorkflowDefaultValueIfValueNotExist(t *testing.T) {
 	})
 }
 
+func TestNamespaceWorkflowDefaults(t *testing.T) {
+	cancel, controller := newControllerWithDefaults()
+	defer cancel()
+	err := controller.configMapInformer.GetIndexer().Add(&apiv1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "tenant",
+			Name:      "workflow-defaults",
+			Labels:    map[string]string{common.LabelKeyConfigMapType: common.LabelValueTypeConfigMapWorkflowDefaults},
+		},
+		Data: map[string]string{common.ConfigMapKeyWorkflowDefaults: "spec:\n  serviceAccountName: tenant\n  hostNetwork: false\n"},
+	})
+	assert.NoError(t, err)
+	t.Run("Namespace", func(t *testing.T) {
+		wf := wfv1.MustUnmarshalWorkflow(helloWorldWf)
+		wf.Namespace = "tenant"
+		err := controller.setWorkflowDefaults(wf)
+		if assert.NoError(t, err) {
+			assert.Equal(t, "tenant", wf.Spec.ServiceAccountName)
+			assert.False(t, *wf.Spec.HostNetwork)
+		}
+	})
+	t.Run("OtherNamespace", func(t *testing.T) {
+		wf := wfv1.MustUnmarshalWorkflow(helloWorldWf)
+		wf.Namespace = "other"
+		err := controller.setWorkflowDefaults(wf)
+		if assert.NoError(t, err) {
+			assert.Empty(t, wf.Spec.ServiceAccountName)
+			assert.True(t, *wf.Spec.HostNetwork)
+		}
+	})
+}
+
 func TestAddingWorkflowDefaultComplex(t *testing.T) {
 	cancel, controller := newControllerWithComplexDefaults()
 	defer cancel()
@@ -729,6 +779,41 @@ func TestIsArchivable(t *testing.T) {
 	})
 }
 
+func TestWorkflowExport(t *testing.T) {
+	cancel, controller := newController()
+	defer cancel()
+	ctx := context.Background()
+	controller.Config.WorkflowExport = &config.WorkflowExport{
+		LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"export": "true"}},
+		Sinks:         []config.WorkflowExportSink{{Name: "bq", BigQuery: &config.BigQueryExportSink{Project: "p", Dataset: "d", Table: "t"}}},
+	}
+	require.NoError(t, controller.updateConfig())
+
+	wf := wfv1.MustUnmarshalWorkflow(helloWorldWf)
+	assert.False(t, controller.isExportable(wf))
+	wf.Labels = map[string]string{"export": "true"}
+	assert.True(t, controller.isExportable(wf))
+
+	woc := newWorkflowOperationCtx(wf, controller)
+	woc.markWorkflowPhase(ctx, wfv1.WorkflowSucceeded, "")
+	assert.Equal(t, "Pending", woc.wf.Labels[common.LabelKeyWorkflowExportStatus])
+
+	t.Run("Exported", func(t *testing.T) {
+		wf, err := controller.wfclientset.ArgoprojV1alpha1().Workflows("default").Create(ctx, woc.wf, metav1.CreateOptions{})
+		require.NoError(t, err)
+		controller.markWorkflowsExported(ctx, []export.Record{{Namespace: "default", Name: wf.Name}, {Namespace: "default", Name: "deleted"}})
+		wf, err = controller.wfclientset.ArgoprojV1alpha1().Workflows("default").Get(ctx, wf.Name, metav1.GetOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, "Exported", wf.Labels[common.LabelKeyWorkflowExportStatus])
+	})
+	t.Run("Disabled", func(t *testing.T) {
+		controller.Config.WorkflowExport = nil
+		require.NoError(t, controller.updateConfig())
+		assert.False(t, controller.isExportable(wf))
+		assert.False(t, controller.exportQueue.Add(export.Record{UID: "1"}))
+	})
+}
+
 func TestReleaseAllWorkflowLocks(t *testing.T) {
 	cancel, controller := newController()
 	defer cancel()
@@ -17,6 +17,7 @@ import (
 	"github.com/argoproj/argo-workflows/v3/workflow/common"
 	controllercache "github.com/argoproj/argo-workflows/v3/workflow/controller/cache"
 	"github.com/argoproj/argo-workflows/v3/workflow/templateresolution"
+	wfutil "github.com/argoproj/argo-workflows/v3/workflow/util"
 )
 
 // dagContext holds context information about this context's DAG
@@ -360,7 +361,7 @@ func (woc *wfOperationCtx) executeDAG(ctx context.Context, nodeName string, tmpl
 		woc.wf.Status.Nodes.Set(node.ID, *node)
 		if node.MemoizationStatus != nil {
 			c := woc.controller.cacheFactory.GetCache(controllercache.ConfigMapCache, node.MemoizationStatus.CacheName)
-			err := c.Save(ctx, node.MemoizationStatus.Key, node.ID, node.Outputs)
+			err := c.Save(ctx, node.MemoizationStatus.Key, node.ID, node.Outputs, woc.wf)
 			if err != nil {
 				woc.log.WithFields(log.Fields{"nodeID": node.ID}).WithError(err).Error("Failed to save node outputs to cache")
 				node.Phase = wfv1.NodeError
@@ -527,6 +528,21 @@ func (woc *wfOperationCtx) executeDAGTask(ctx context.Context, dagCtx *dagContex
 		}
 	}
 
+	// Tasks of a paused branch are not started until the branch is resumed. The DAG itself and the dependencies of the
+	// task are its ancestors.
+	if node == nil {
+		ancestorIDs := []string{dagCtx.boundaryID}
+		for _, depName := range taskDependencies {
+			if depNode := dagCtx.getTaskNode(depName); depNode != nil {
+				ancestorIDs = append(ancestorIDs, depNode.ID)
+			}
+		}
+		if woc.inPausedBranch(ancestorIDs...) {
+			log.Info("Task is in a paused branch, not starting it")
+			return
+		}
+	}
+
 	// All our dependencies were satisfied and successful. It's our turn to run
 	// First resolve/substitute params/artifacts from our dependencies
 	newTask, err := woc.resolveDependencyReferences(dagCtx, task)
@@ -539,6 +555,9 @@ func (woc *wfOperationCtx) executeDAGTask(ctx context.Context, dagCtx *dagContex
 	// Next, expand the DAG's withItems/withParams/withSequence (if any). If there was none, then
 	// expandedTasks will be a single element list of the same task
 	expandedTasks, err := expandTask(*newTask)
+	if err == nil {
+		err = woc.checkFanOutGuardrail(task.Name, len(expandedTasks))
+	}
 	if err != nil {
 		woc.initializeNode(nodeName, wfv1.NodeTypeSkipped, dagTemplateScope, task, dagCtx.boundaryID, wfv1.NodeError, &wfv1.NodeFlag{}, err.Error())
 		connectDependencies(nodeName)
@@ -569,7 +588,7 @@ func (woc *wfOperationCtx) executeDAGTask(ctx context.Context, dagCtx *dagContex
 			connectDependencies(taskNodeName)
 
 			// Check the task's when clause to decide if it should execute
-			proceed, err := shouldExecute(t.When)
+			proceed, err := wfutil.ShouldExecute(t.When)
 			if err != nil {
 				woc.initializeNode(taskNodeName, wfv1.NodeTypeSkipped, dagTemplateScope, task, dagCtx.boundaryID, wfv1.NodeError, &wfv1.NodeFlag{}, err.Error())
 				continue
@@ -703,7 +722,7 @@ func (woc *wfOperationCtx) resolveDependencyReferences(dagCtx *dagContext, task
 
 	// If we are not executing, don't attempt to resolve any artifact references. We only check if we are executing after
 	// the initial parameter resolution, since it's likely that the "when" clause will contain parameter references.
-	proceed, err := shouldExecute(newTask.When)
+	proceed, err := wfutil.ShouldExecute(newTask.When)
 	if err != nil {
 		// If we got an error, it might be because our "when" clause contains a task-expansion parameter (e.g. {{item}}).
 		// Since we don't perform task-expansion until later and task-expansion parameters won't get resolved here,
@@ -772,15 +791,15 @@ func expandTask(task wfv1.DAGTask) ([]wfv1.DAGTask, error) {
 	} else if task.WithParam != "" {
 		err = json.Unmarshal([]byte(task.WithParam), &items)
 		if err != nil {
-			mustExec, mustExecErr := shouldExecute(task.When)
+			mustExec, mustExecErr := wfutil.ShouldExecute(task.When)
 			if mustExecErr != nil || mustExec {
 				return nil, errors.Errorf(errors.CodeBadRequest, "withParam value could not be parsed as a JSON list: %s: %v", strings.TrimSpace(task.WithParam), err)
 			}
 		}
 	} else if task.WithSequence != nil {
-		items, err = expandSequence(task.WithSequence)
+		items, err = wfutil.ExpandSequence(task.WithSequence)
 		if err != nil {
-			mustExec, mustExecErr := shouldExecute(task.When)
+			mustExec, mustExecErr := wfutil.ShouldExecute(task.When)
 			if mustExecErr != nil || mustExec {
 				return nil, err
 			}
@@ -900,3 +919,40 @@ func (d *dagContext) evaluateDependsLogic(taskName string) (bool, bool, error) {
 	}
 	return execute, true, nil
 }
+
+// inPausedBranch returns whether any of the nodes is in a paused branch, that is whether the node or one of its
+// ancestors is paused
+func (woc *wfOperationCtx) inPausedBranch(nodeIDs ...string) bool {
+	var queue []string
+	for nodeID, node := range woc.wf.Status.Nodes {
+		if node.Paused {
+			queue = append(queue, nodeID)
+		}
+	}
+	if len(queue) == 0 {
+		return false
+	}
+	wanted := make(map[string]bool)
+	for _, nodeID := range nodeIDs {
+		wanted[nodeID] = true
+	}
+	// BFS over the descendants of the paused nodes
+	visited := make(map[string]bool)
+	for len(queue) > 0 {
+		nodeID := queue[0]
+		queue = queue[1:]
+		if visited[nodeID] {
+			continue
+		}
+		visited[nodeID] = true
+		if wanted[nodeID] {
+			return true
+		}
+		node, err := woc.wf.Status.Nodes.Get(nodeID)
+		if err != nil {
+			continue
+		}
+		queue = append(queue, node.Children...)
+	}
+	return false
+}
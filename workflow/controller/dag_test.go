@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -3655,3 +3656,54 @@ spec:
 	woc1.operate(ctx)
 	assert.Equal(t, wfv1.WorkflowRunning, woc.wf.Status.Phase)
 }
+
+func TestDAGPausedBranch(t *testing.T) {
+	wf := wfv1.MustUnmarshalWorkflow(`apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  name: paused-branch
+  namespace: argo
+spec:
+  entrypoint: main
+  templates:
+    - name: main
+      dag:
+        tasks:
+          - name: a
+            template: whalesay
+          - name: b
+            template: whalesay
+          - name: c
+            template: whalesay
+            dependencies: [a]
+          - name: d
+            template: whalesay
+            dependencies: [b]
+    - name: whalesay
+      container:
+        image: docker/whalesay:latest`)
+	cancel, controller := newController(wf)
+	defer cancel()
+
+	ctx := context.Background()
+	woc := newWorkflowOperationCtx(wf, controller)
+	woc.operate(ctx)
+	nodeA := woc.wf.Status.Nodes.FindByDisplayName("a")
+	require.NotNil(t, nodeA)
+	nodeA.Paused = true
+	woc.wf.Status.Nodes.Set(nodeA.ID, *nodeA)
+
+	makePodsPhase(ctx, woc, v1.PodSucceeded)
+	woc = newWorkflowOperationCtx(woc.wf, controller)
+	woc.operate(ctx)
+	assert.Nil(t, woc.wf.Status.Nodes.FindByDisplayName("c"), "the paused branch is not started")
+	assert.NotNil(t, woc.wf.Status.Nodes.FindByDisplayName("d"), "the other branch is started")
+	assert.Equal(t, wfv1.WorkflowRunning, woc.wf.Status.Phase)
+
+	nodeA = woc.wf.Status.Nodes.FindByDisplayName("a")
+	nodeA.Paused = false
+	woc.wf.Status.Nodes.Set(nodeA.ID, *nodeA)
+	woc = newWorkflowOperationCtx(woc.wf, controller)
+	woc.operate(ctx)
+	assert.NotNil(t, woc.wf.Status.Nodes.FindByDisplayName("c"), "the resumed branch is started")
+}
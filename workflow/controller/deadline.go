@@ -0,0 +1,78 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+// addDeadlineVolume annotates the pod with its deadline, and mounts the annotation into the main containers using the
+// downward API, so that they can see the deadline being brought forward while they are running
+func addDeadlineVolume(pod *apiv1.Pod, deadline time.Time) {
+	if deadline.IsZero() {
+		return
+	}
+	pod.ObjectMeta.Annotations[common.AnnotationKeyDeadline] = deadline.Format(time.RFC3339)
+	pod.Spec.Volumes = append(pod.Spec.Volumes, apiv1.Volume{
+		Name: common.PodInfoVolumeName,
+		VolumeSource: apiv1.VolumeSource{
+			DownwardAPI: &apiv1.DownwardAPIVolumeSource{
+				Items: []apiv1.DownwardAPIVolumeFile{{
+					Path:     "deadline",
+					FieldRef: &apiv1.ObjectFieldSelector{FieldPath: fmt.Sprintf("metadata.annotations['%s']", common.AnnotationKeyDeadline)},
+				}},
+			},
+		},
+	})
+	for i, c := range pod.Spec.Containers {
+		if c.Name == common.WaitContainerName {
+			continue
+		}
+		c.VolumeMounts = append(c.VolumeMounts, apiv1.VolumeMount{
+			Name:      common.PodInfoVolumeName,
+			MountPath: common.PodInfoMountPath,
+			ReadOnly:  true,
+		})
+		c.Env = append(c.Env, apiv1.EnvVar{Name: common.EnvVarDeadlineFile, Value: common.ArgoDeadlinePath})
+		pod.Spec.Containers[i] = c
+	}
+}
+
+// refreshPodDeadline brings the deadline annotation of the pod forward when the workflow deadline is earlier, e.g.
+// because the workflow's activeDeadlineSeconds was reduced after the pod was created. Pods without the annotation do
+// not have the deadline file.
+func (woc *wfOperationCtx) refreshPodDeadline(ctx context.Context, pod *apiv1.Pod) {
+	if woc.workflowDeadline == nil {
+		return
+	}
+	value, ok := pod.Annotations[common.AnnotationKeyDeadline]
+	if !ok {
+		return
+	}
+	deadline := woc.workflowDeadline.Truncate(time.Second)
+	if current, err := time.Parse(time.RFC3339, value); err == nil && !deadline.Before(current) {
+		return
+	}
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]string{common.AnnotationKeyDeadline: deadline.Format(time.RFC3339)},
+		},
+	})
+	if err != nil {
+		woc.log.WithError(err).Error("failed to marshal pod deadline patch")
+		return
+	}
+	_, err = woc.controller.kubeclientset.CoreV1().Pods(pod.Namespace).Patch(ctx, pod.Name, types.MergePatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		woc.log.WithError(err).WithField("podName", pod.Name).Warn("failed to update pod deadline")
+		return
+	}
+	woc.log.WithField("podName", pod.Name).WithField("deadline", deadline).Info("Brought pod deadline forward")
+}
@@ -0,0 +1,78 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+func newDeadlinePod() *apiv1.Pod {
+	return &apiv1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-pod", Namespace: "my-ns", Annotations: map[string]string{}},
+		Spec: apiv1.PodSpec{
+			Containers: []apiv1.Container{{Name: common.WaitContainerName}, {Name: common.MainContainerName}},
+		},
+	}
+}
+
+func TestAddDeadlineVolume(t *testing.T) {
+	t.Run("NoDeadline", func(t *testing.T) {
+		pod := newDeadlinePod()
+		addDeadlineVolume(pod, time.Time{})
+		assert.NotContains(t, pod.Annotations, common.AnnotationKeyDeadline)
+		assert.Empty(t, pod.Spec.Volumes)
+	})
+	t.Run("Deadline", func(t *testing.T) {
+		pod := newDeadlinePod()
+		addDeadlineVolume(pod, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+		assert.Equal(t, "2024-01-01T00:00:00Z", pod.Annotations[common.AnnotationKeyDeadline])
+		require.Len(t, pod.Spec.Volumes, 1)
+		assert.Equal(t, "metadata.annotations['workflows.argoproj.io/deadline']", pod.Spec.Volumes[0].DownwardAPI.Items[0].FieldRef.FieldPath)
+		assert.Empty(t, pod.Spec.Containers[0].VolumeMounts)
+		assert.Empty(t, pod.Spec.Containers[0].Env)
+		main := pod.Spec.Containers[1]
+		require.Len(t, main.VolumeMounts, 1)
+		assert.Equal(t, common.PodInfoMountPath, main.VolumeMounts[0].MountPath)
+		assert.Contains(t, main.Env, apiv1.EnvVar{Name: common.EnvVarDeadlineFile, Value: "/etc/argo/podinfo/deadline"})
+	})
+}
+
+func TestRefreshPodDeadline(t *testing.T) {
+	ctx := context.Background()
+	run := func(t *testing.T, annotation string, workflowDeadline *time.Time) string {
+		woc := newWoc()
+		woc.workflowDeadline = workflowDeadline
+		pod := newDeadlinePod()
+		if annotation != "" {
+			pod.Annotations[common.AnnotationKeyDeadline] = annotation
+		}
+		pods := woc.controller.kubeclientset.CoreV1().Pods(pod.Namespace)
+		_, err := pods.Create(ctx, pod, metav1.CreateOptions{})
+		require.NoError(t, err)
+		woc.refreshPodDeadline(ctx, pod)
+		pod, err = pods.Get(ctx, pod.Name, metav1.GetOptions{})
+		require.NoError(t, err)
+		return pod.Annotations[common.AnnotationKeyDeadline]
+	}
+	earlier := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	later := earlier.Add(time.Hour)
+	t.Run("NoWorkflowDeadline", func(t *testing.T) {
+		assert.Equal(t, "2024-01-01T01:00:00Z", run(t, "2024-01-01T01:00:00Z", nil))
+	})
+	t.Run("NoAnnotation", func(t *testing.T) {
+		assert.Empty(t, run(t, "", &earlier))
+	})
+	t.Run("Later", func(t *testing.T) {
+		assert.Equal(t, "2024-01-01T00:00:00Z", run(t, "2024-01-01T00:00:00Z", &later))
+	})
+	t.Run("Earlier", func(t *testing.T) {
+		assert.Equal(t, "2024-01-01T00:00:00Z", run(t, "2024-01-01T01:00:00Z", &earlier))
+	})
+}
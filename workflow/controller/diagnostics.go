@@ -0,0 +1,95 @@
+package controller
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+
+	log "github.com/sirupsen/logrus"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/argoproj/argo-workflows/v3"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/controller/indexes"
+	"github.com/argoproj/argo-workflows/v3/workflow/diagnostics"
+)
+
+// Diagnostics writes the status of the controller as JSON, for `argo admin controller status`
+func (wfc *WorkflowController) Diagnostics(w http.ResponseWriter, r *http.Request) {
+	data, err := json.Marshal(wfc.getDiagnostics())
+	if err != nil {
+		log.WithError(err).Error("failed to marshal diagnostics")
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(data)
+}
+
+func (wfc *WorkflowController) getDiagnostics() *diagnostics.Status {
+	status := &diagnostics.Status{
+		Version:          argo.GetVersion().Version,
+		InstanceID:       wfc.Config.InstanceID,
+		ManagedNamespace: wfc.managedNamespace,
+		ConfigHash:       wfc.getConfigHash(),
+	}
+	if wfc.metrics != nil {
+		status.RecentErrors = wfc.metrics.RecentErrors()
+	}
+	// the wfc.wfInformer is nil if it is not the leader
+	if wfc.wfInformer == nil {
+		return status
+	}
+	status.Leading = true
+	status.Queues = map[string]int{
+		"workflow":   wfc.wfQueue.Len(),
+		"podCleanup": wfc.podCleanupQueue.Len(),
+	}
+	status.Workflows = make(map[string]int)
+	for _, phase := range []wfv1.NodePhase{wfv1.NodePending, wfv1.NodeRunning, wfv1.NodeSucceeded, wfv1.NodeFailed, wfv1.NodeError} {
+		keys, err := wfc.wfInformer.GetIndexer().IndexKeys(indexes.WorkflowPhaseIndex, string(phase))
+		if err != nil {
+			log.WithError(err).WithField("phase", phase).Error("failed to count workflows")
+			continue
+		}
+		status.Workflows[string(phase)] = len(keys)
+	}
+	informers := map[string]cache.SharedIndexInformer{
+		"workflows":           wfc.wfInformer,
+		"pods":                wfc.podInformer,
+		"configMaps":          wfc.configMapInformer,
+		"workflowTaskResults": wfc.taskResultInformer,
+	}
+	if wfc.wftmplInformer != nil {
+		informers["workflowTemplates"] = wfc.wftmplInformer.Informer()
+	}
+	if wfc.cwftmplInformer != nil {
+		informers["clusterWorkflowTemplates"] = wfc.cwftmplInformer.Informer()
+	}
+	if wfc.wfTaskSetInformer != nil {
+		informers["workflowTaskSets"] = wfc.wfTaskSetInformer.Informer()
+	}
+	if wfc.artGCTaskInformer != nil {
+		informers["workflowArtifactGCTasks"] = wfc.artGCTaskInformer.Informer()
+	}
+	status.Informers = make(map[string]bool)
+	for name, informer := range informers {
+		if informer != nil {
+			status.Informers[name] = informer.HasSynced()
+		}
+	}
+	return status
+}
+
+// getConfigHash returns a short hash of the configuration, so operators can tell whether controllers run with the same
+// configuration
+func (wfc *WorkflowController) getConfigHash() string {
+	data, err := json.Marshal(wfc.Config)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])[:12]
+}
@@ -15,3 +15,7 @@ func (e *dummyEstimator) EstimateWorkflowDuration() wfv1.EstimatedDuration {
 func (e *dummyEstimator) EstimateNodeDuration(string) wfv1.EstimatedDuration {
 	return wfv1.NewEstimatedDuration(time.Second)
 }
+
+func (e *dummyEstimator) EstimateRemainingDuration(string) wfv1.EstimatedDuration {
+	return wfv1.NewEstimatedDuration(time.Second)
+}
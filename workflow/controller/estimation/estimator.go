@@ -1,7 +1,9 @@
 package estimation
 
 import (
+	"sort"
 	"strings"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 
@@ -12,30 +14,68 @@ import (
 type Estimator interface {
 	EstimateWorkflowDuration() wfv1.EstimatedDuration
 	EstimateNodeDuration(nodeName string) wfv1.EstimatedDuration
+	// EstimateRemainingDuration returns how long the workflow will run for after the node starts
+	EstimateRemainingDuration(nodeName string) wfv1.EstimatedDuration
 }
 
 type estimator struct {
-	wf         *wfv1.Workflow
-	baselineWF *wfv1.Workflow
+	wf *wfv1.Workflow
+	// baselineWFs are previous successful runs of the same workflow template, cluster workflow template or cron
+	// workflow, newest first
+	baselineWFs []*wfv1.Workflow
 }
 
 func (e *estimator) EstimateWorkflowDuration() wfv1.EstimatedDuration {
-	if e.baselineWF == nil {
-		return 0
+	var durations []time.Duration
+	for _, baselineWF := range e.baselineWFs {
+		durations = append(durations, baselineWF.Status.GetDuration())
 	}
-	return wfv1.NewEstimatedDuration(e.baselineWF.Status.GetDuration())
+	return wfv1.NewEstimatedDuration(median(durations))
 }
 
 func (e *estimator) EstimateNodeDuration(nodeName string) wfv1.EstimatedDuration {
-	if e.baselineWF == nil {
-		return 0
+	var durations []time.Duration
+	for _, baselineWF := range e.baselineWFs {
+		if node := e.getBaselineNode(baselineWF, nodeName); node != nil {
+			durations = append(durations, node.GetDuration())
+		}
+	}
+	return wfv1.NewEstimatedDuration(median(durations))
+}
+
+func (e *estimator) EstimateRemainingDuration(nodeName string) wfv1.EstimatedDuration {
+	var durations []time.Duration
+	for _, baselineWF := range e.baselineWFs {
+		if node := e.getBaselineNode(baselineWF, nodeName); node != nil {
+			durations = append(durations, baselineWF.Status.FinishedAt.Sub(node.StartedAt.Time))
+		}
 	}
-	oldNodeID := e.baselineWF.NodeID(strings.Replace(nodeName, e.wf.Name, e.baselineWF.Name, 1))
-	node, err := e.baselineWF.Status.Nodes.Get(oldNodeID)
+	return wfv1.NewEstimatedDuration(median(durations))
+}
+
+// getBaselineNode returns the node of the baseline workflow with the same name as the node of the workflow, or nil if
+// it did not run in the baseline, e.g. because it was skipped
+func (e *estimator) getBaselineNode(baselineWF *wfv1.Workflow, nodeName string) *wfv1.NodeStatus {
+	oldNodeID := baselineWF.NodeID(strings.Replace(nodeName, e.wf.Name, baselineWF.Name, 1))
+	node, err := baselineWF.Status.Nodes.Get(oldNodeID)
 	if err != nil {
-		log.Errorf("was unable to obtain node for %s", oldNodeID)
+		log.Debugf("was unable to obtain node for %s", oldNodeID)
 		// inacurate but not going to break anything
+		return nil
+	}
+	return node
+}
+
+// median returns the median of the durations, so that an unusually slow or fast run does not skew the estimate, or
+// zero if there are none
+func median(durations []time.Duration) time.Duration {
+	if len(durations) == 0 {
 		return 0
 	}
-	return wfv1.NewEstimatedDuration(node.GetDuration())
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+	m := len(durations) / 2
+	if len(durations)%2 == 0 {
+		return (durations[m-1] + durations[m]) / 2
+	}
+	return durations[m]
 }
@@ -2,6 +2,7 @@ package estimation
 
 import (
 	"fmt"
+	"sort"
 	"time"
 
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -16,6 +17,9 @@ import (
 	"github.com/argoproj/argo-workflows/v3/workflow/util"
 )
 
+// maxBaselines is the maximum number of previous runs of a workflow's template the estimates are made from
+const maxBaselines = 5
+
 type EstimatorFactory interface {
 	// ALWAYS return as estimator, even if it also returns an error.
 	NewEstimator(wf *wfv1.Workflow) (Estimator, error)
@@ -46,7 +50,7 @@ func (f *estimatorFactory) NewEstimator(wf *wfv1.Workflow) (Estimator, error) {
 			if err != nil {
 				return defaultEstimator, fmt.Errorf("failed to list workflows by index: %v", err)
 			}
-			var newestUn *unstructured.Unstructured
+			var succeeded []*unstructured.Unstructured
 			for _, obj := range objs {
 				un, ok := obj.(*unstructured.Unstructured)
 				if !ok {
@@ -55,33 +59,45 @@ func (f *estimatorFactory) NewEstimator(wf *wfv1.Workflow) (Estimator, error) {
 				if un.GetLabels()[common.LabelKeyPhase] != string(wfv1.NodeSucceeded) {
 					continue
 				}
-				// we use `creationTimestamp` because it's fast
-				if newestUn == nil || un.GetCreationTimestamp().After(newestUn.GetCreationTimestamp().Time) {
-					newestUn = un
-				}
+				succeeded = append(succeeded, un)
 			}
-			if newestUn != nil {
-				newestWf, err := util.FromUnstructured(newestUn)
-				if err != nil {
-					return defaultEstimator, fmt.Errorf("failed convert unstructured to workflow: %w", err)
+			if len(succeeded) > 0 {
+				// we use `creationTimestamp` because it's fast
+				sort.Slice(succeeded, func(i, j int) bool {
+					return succeeded[i].GetCreationTimestamp().After(succeeded[j].GetCreationTimestamp().Time)
+				})
+				if len(succeeded) > maxBaselines {
+					succeeded = succeeded[:maxBaselines]
 				}
-				err = f.hydrator.Hydrate(newestWf)
-				if err != nil {
-					return defaultEstimator, fmt.Errorf("failed hydrate last workflow: %w", err)
+				var baselineWFs []*wfv1.Workflow
+				for _, un := range succeeded {
+					baselineWF, err := util.FromUnstructured(un)
+					if err != nil {
+						return defaultEstimator, fmt.Errorf("failed convert unstructured to workflow: %w", err)
+					}
+					err = f.hydrator.Hydrate(baselineWF)
+					if err != nil {
+						return defaultEstimator, fmt.Errorf("failed hydrate baseline workflow: %w", err)
+					}
+					baselineWFs = append(baselineWFs, baselineWF)
 				}
-				return &estimator{wf, newestWf}, nil
+				return &estimator{wf, baselineWFs}, nil
 			}
 			// we failed to find a base-line in the live set, so we now look in the archive
 			requirements, err := labels.ParseToRequirements(common.LabelKeyPhase + "=" + string(wfv1.NodeSucceeded) + "," + labelName + "=" + labelValue)
 			if err != nil {
 				return defaultEstimator, fmt.Errorf("failed to parse selector to requirements: %v", err)
 			}
-			workflows, err := f.wfArchive.ListWorkflows(wf.Namespace, "", "", time.Time{}, time.Time{}, requirements, 1, 0)
+			workflows, err := f.wfArchive.ListWorkflows(wf.Namespace, "", "", time.Time{}, time.Time{}, requirements, maxBaselines, 0)
 			if err != nil {
 				return defaultEstimator, fmt.Errorf("failed to list archived workflows: %v", err)
 			}
 			if len(workflows) > 0 {
-				return &estimator{wf, &workflows[0]}, nil
+				var baselineWFs []*wfv1.Workflow
+				for i := range workflows {
+					baselineWFs = append(baselineWFs, &workflows[i])
+				}
+				return &estimator{wf, baselineWFs}, nil
 			}
 		}
 	}
@@ -1,6 +1,7 @@
 package estimation
 
 import (
+	"fmt"
 	"testing"
 	"time"
 
@@ -50,10 +51,23 @@ metadata:
   labels:
     workflows.argoproj.io/phase: Succeeded
 `), wfFailed)
+	var many []interface{}
+	for i := 0; i < maxBaselines+2; i++ {
+		many = append(many, testutil.MustUnmarshalUnstructured(fmt.Sprintf(`
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  name: my-many-baseline-%d
+  creationTimestamp: "2024-01-01T00:0%d:00Z"
+  labels:
+    workflows.argoproj.io/phase: Succeeded
+`, i, i)))
+	}
+	informer.Indexer.SetByIndex(indexes.WorkflowTemplateIndex, "my-ns/my-many", many...)
 	wfArchive := &sqldbmocks.WorkflowArchive{}
 	r, err := labels.ParseToRequirements("workflows.argoproj.io/phase=Succeeded,workflows.argoproj.io/workflow-template=my-archived-wftmpl")
 	assert.NoError(t, err)
-	wfArchive.On("ListWorkflows", "my-ns", "", "", time.Time{}, time.Time{}, labels.Requirements(r), 1, 0).Return(wfv1.Workflows{
+	wfArchive.On("ListWorkflows", "my-ns", "", "", time.Time{}, time.Time{}, labels.Requirements(r), maxBaselines, 0).Return(wfv1.Workflows{
 		*testutil.MustUnmarshalWorkflow(`
 metadata:
   name: my-archived-wftmpl-baseline`),
@@ -63,7 +77,7 @@ metadata:
 		p, err := f.NewEstimator(&wfv1.Workflow{})
 		if assert.NoError(t, err) && assert.NotNil(t, p) {
 			e := p.(*estimator)
-			assert.Nil(t, e.baselineWF)
+			assert.Empty(t, e.baselineWFs)
 		}
 	})
 	t.Run("WorkflowTemplate", func(t *testing.T) {
@@ -72,8 +86,20 @@ metadata:
 		})
 		if assert.NoError(t, err) && assert.NotNil(t, p) {
 			e := p.(*estimator)
-			if assert.NotNil(t, e) && assert.NotNil(t, e.baselineWF) {
-				assert.Equal(t, "my-wftmpl-baseline", e.baselineWF.Name)
+			if assert.NotNil(t, e) && assert.Len(t, e.baselineWFs, 1) {
+				assert.Equal(t, "my-wftmpl-baseline", e.baselineWFs[0].Name)
+			}
+		}
+	})
+	t.Run("NewestBaselines", func(t *testing.T) {
+		p, err := f.NewEstimator(&wfv1.Workflow{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "my-ns", Labels: map[string]string{common.LabelKeyWorkflowTemplate: "my-many"}},
+		})
+		if assert.NoError(t, err) && assert.NotNil(t, p) {
+			e := p.(*estimator)
+			if assert.Len(t, e.baselineWFs, maxBaselines) {
+				assert.Equal(t, "my-many-baseline-6", e.baselineWFs[0].Name)
+				assert.Equal(t, "my-many-baseline-2", e.baselineWFs[maxBaselines-1].Name)
 			}
 		}
 	})
@@ -83,8 +109,8 @@ metadata:
 		})
 		if assert.NoError(t, err) && assert.NotNil(t, p) {
 			e := p.(*estimator)
-			if assert.NotNil(t, e) && assert.NotNil(t, e.baselineWF) {
-				assert.Equal(t, "my-cwft-baseline", e.baselineWF.Name)
+			if assert.NotNil(t, e) && assert.Len(t, e.baselineWFs, 1) {
+				assert.Equal(t, "my-cwft-baseline", e.baselineWFs[0].Name)
 			}
 		}
 	})
@@ -94,8 +120,8 @@ metadata:
 		})
 		if assert.NoError(t, err) && assert.NotNil(t, p) {
 			e := p.(*estimator)
-			if assert.NotNil(t, e) && assert.NotNil(t, e.baselineWF) {
-				assert.Equal(t, "my-cwf-baseline", e.baselineWF.Name)
+			if assert.NotNil(t, e) && assert.Len(t, e.baselineWFs, 1) {
+				assert.Equal(t, "my-cwf-baseline", e.baselineWFs[0].Name)
 			}
 		}
 	})
@@ -105,8 +131,8 @@ metadata:
 		})
 		if assert.NoError(t, err) && assert.NotNil(t, p) {
 			e := p.(*estimator)
-			if assert.NotNil(t, e) && assert.NotNil(t, e.baselineWF) {
-				assert.Equal(t, "my-archived-wftmpl-baseline", e.baselineWF.Name)
+			if assert.NotNil(t, e) && assert.Len(t, e.baselineWFs, 1) {
+				assert.Equal(t, "my-archived-wftmpl-baseline", e.baselineWFs[0].Name)
 			}
 		}
 	})
@@ -24,7 +24,7 @@ func Test_estimator(t *testing.T) {
 				},
 			},
 		},
-		&wfv1.Workflow{
+		[]*wfv1.Workflow{{
 			ObjectMeta: metav1.ObjectMeta{Name: "my-baseline"},
 			Status: wfv1.WorkflowStatus{
 				StartedAt:  a,
@@ -35,9 +35,46 @@ func Test_estimator(t *testing.T) {
 					"my-baseline-873244444.x": {StartedAt: a, FinishedAt: b},
 				},
 			},
-		},
+		}},
 	}
 	assert.Equal(t, wfv1.EstimatedDuration(1), p.EstimateWorkflowDuration())
 	assert.Equal(t, wfv1.EstimatedDuration(1), p.EstimateNodeDuration("my-wf"))
 	assert.Equal(t, wfv1.EstimatedDuration(1), p.EstimateNodeDuration("1"))
+	assert.Equal(t, wfv1.EstimatedDuration(1), p.EstimateRemainingDuration("my-wf"))
+}
+
+func Test_estimatorWithBaselines(t *testing.T) {
+	t0 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(minutes int) metav1.Time { return metav1.NewTime(t0.Add(time.Duration(minutes) * time.Minute)) }
+	wf := &wfv1.Workflow{ObjectMeta: metav1.ObjectMeta{Name: "my-wf"}}
+	// newBaseline returns a run that took the minutes, in which the node "my-wf.b" ran for its minutes, starting a
+	// minute in, unless they are zero
+	newBaseline := func(name string, minutes, nodeMinutes int) *wfv1.Workflow {
+		baselineWF := &wfv1.Workflow{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status:     wfv1.WorkflowStatus{StartedAt: at(0), FinishedAt: at(minutes), Nodes: wfv1.Nodes{}},
+		}
+		if nodeMinutes > 0 {
+			baselineWF.Status.Nodes[baselineWF.NodeID(name+".b")] = wfv1.NodeStatus{StartedAt: at(1), FinishedAt: at(1 + nodeMinutes)}
+		}
+		return baselineWF
+	}
+	t.Run("Median", func(t *testing.T) {
+		p := &estimator{wf, []*wfv1.Workflow{newBaseline("a", 10, 2), newBaseline("b", 60, 30), newBaseline("c", 12, 4)}}
+		assert.Equal(t, wfv1.NewEstimatedDuration(12*time.Minute), p.EstimateWorkflowDuration())
+		assert.Equal(t, wfv1.NewEstimatedDuration(4*time.Minute), p.EstimateNodeDuration("my-wf.b"))
+		assert.Equal(t, wfv1.NewEstimatedDuration(11*time.Minute), p.EstimateRemainingDuration("my-wf.b"))
+	})
+	t.Run("NodeNotInEveryBaseline", func(t *testing.T) {
+		p := &estimator{wf, []*wfv1.Workflow{newBaseline("a", 10, 2), newBaseline("b", 20, 0), newBaseline("c", 12, 4)}}
+		assert.Equal(t, wfv1.NewEstimatedDuration(12*time.Minute), p.EstimateWorkflowDuration())
+		assert.Equal(t, wfv1.NewEstimatedDuration(3*time.Minute), p.EstimateNodeDuration("my-wf.b"))
+		assert.Equal(t, wfv1.NewEstimatedDuration(10*time.Minute), p.EstimateRemainingDuration("my-wf.b"))
+	})
+	t.Run("NoBaselines", func(t *testing.T) {
+		p := &estimator{wf: wf}
+		assert.Equal(t, wfv1.EstimatedDuration(0), p.EstimateWorkflowDuration())
+		assert.Equal(t, wfv1.EstimatedDuration(0), p.EstimateNodeDuration("my-wf.b"))
+		assert.Equal(t, wfv1.EstimatedDuration(0), p.EstimateRemainingDuration("my-wf.b"))
+	})
 }
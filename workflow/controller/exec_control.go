@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"context"
 	"fmt"
 	"sync"
 	"time"
@@ -13,8 +14,9 @@ import (
 )
 
 // applyExecutionControl will ensure a pod's execution control annotation is up-to-date
-// kills any pending and running pods when workflow has reached it's deadline
-func (woc *wfOperationCtx) applyExecutionControl(pod *apiv1.Pod, wfNodesLock *sync.RWMutex) {
+// kills any pending and running pods when workflow has reached it's deadline, brings forward the
+// deadline of the others if the workflow deadline has been brought forward, and passes on requested signals
+func (woc *wfOperationCtx) applyExecutionControl(ctx context.Context, pod *apiv1.Pod, wfNodesLock *sync.RWMutex) {
 	if pod == nil {
 		return
 	}
@@ -36,12 +38,21 @@ func (woc *wfOperationCtx) applyExecutionControl(pod *apiv1.Pod, wfNodesLock *sy
 		// Skip any pod which are already completed
 		return
 	case apiv1.PodPending, apiv1.PodRunning:
+		// Check if the exit hooks have run out of time
+		if _, onExitPod := pod.Labels[common.LabelKeyOnExit]; onExitPod && woc.exitHooksDeadlineExceeded() {
+			woc.log.WithField("podName", pod.Name).
+				WithField("exitHooksDeadline", woc.wf.Status.ExitHooksDeadline).
+				Info("Terminating on-exit pod which has exceeded exit hooks deadline")
+			woc.controller.queuePodForCleanup(pod.Namespace, pod.Name, terminateContainers)
+			woc.handleExecutionControlError(nodeID, wfNodesLock, exitHooksDeadlineExceededMessage)
+			return
+		}
 		// Check if we are currently shutting down
 		if woc.GetShutdownStrategy().Enabled() {
 			// Only delete pods that are not part of an onExit handler if we are "Stopping" or all pods if we are "Terminating"
 			_, onExitPod := pod.Labels[common.LabelKeyOnExit]
 
-			if !woc.GetShutdownStrategy().ShouldExecute(onExitPod) {
+			if !woc.shouldExecute(onExitPod) {
 				woc.log.WithField("podName", pod.Name).
 					WithField("shutdownStrategy", woc.GetShutdownStrategy()).
 					Info("Terminating pod as part of workflow shutdown")
@@ -65,9 +76,13 @@ func (woc *wfOperationCtx) applyExecutionControl(pod *apiv1.Pod, wfNodesLock *sy
 				return
 			}
 		}
+		if _, onExitPod := pod.Labels[common.LabelKeyOnExit]; !onExitPod {
+			woc.refreshPodDeadline(ctx, pod)
+		}
+		woc.deliverPodSignal(ctx, pod, nodeID)
 	}
 	if woc.GetShutdownStrategy().Enabled() {
-		if _, onExitPod := pod.Labels[common.LabelKeyOnExit]; !woc.GetShutdownStrategy().ShouldExecute(onExitPod) {
+		if _, onExitPod := pod.Labels[common.LabelKeyOnExit]; !woc.shouldExecute(onExitPod) {
 			woc.log.WithField("podName", pod.Name).
 				Info("Terminating on-exit pod")
 			woc.controller.queuePodForCleanup(woc.wf.Namespace, pod.Name, terminateContainers)
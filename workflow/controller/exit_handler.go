@@ -20,7 +20,7 @@ func (woc *wfOperationCtx) runOnExitNode(ctx context.Context, exitHook *wfv1.Lif
 		outputs = lastChildNode.Outputs
 	}
 
-	if exitHook != nil && woc.GetShutdownStrategy().ShouldExecute(true) {
+	if exitHook != nil && woc.shouldExecute(true) {
 		execute := true
 		var err error
 		if exitHook.Expression != "" {
@@ -48,6 +48,8 @@ func (woc *wfOperationCtx) runOnExitNode(ctx context.Context, exitHook *wfv1.Lif
 			woc.addChildNode(parentNode.Name, onExitNodeName)
 			return true, onExitNode, err
 		}
+	} else if exitHook != nil {
+		woc.markExitHooksSkipped()
 	}
 	return false, nil, nil
 }
@@ -0,0 +1,92 @@
+package controller
+
+import (
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+const exitHooksDeadlineExceededMessage = "exit hook exceeded the exit hooks deadline"
+
+// shouldExecute returns whether a pod, or an exit handler if onExit is true, should be run given the workflow's
+// shutdown strategy. Exit handlers are always run if the workflow has exitHooksDeadlineSeconds.
+func (woc *wfOperationCtx) shouldExecute(onExit bool) bool {
+	if onExit && woc.execWf.Spec.ExitHooksDeadlineSeconds != nil {
+		return true
+	}
+	return woc.GetShutdownStrategy().ShouldExecute(onExit)
+}
+
+// reconcileExitHooksDeadline sets the exit hooks deadline when a workflow with exitHooksDeadlineSeconds is first
+// shut down or exceeds its deadline, and requeues the workflow for when it passes
+func (woc *wfOperationCtx) reconcileExitHooksDeadline() {
+	seconds := woc.execWf.Spec.ExitHooksDeadlineSeconds
+	if seconds == nil {
+		return
+	}
+	if woc.wf.Status.ExitHooksDeadline == nil {
+		now := time.Now().UTC()
+		var start time.Time
+		switch {
+		case woc.workflowDeadline != nil && now.After(*woc.workflowDeadline):
+			start = *woc.workflowDeadline
+		case woc.GetShutdownStrategy().Enabled():
+			start = now
+		default:
+			return
+		}
+		deadline := metav1.NewTime(start.Add(time.Duration(*seconds) * time.Second))
+		woc.log.WithField("exitHooksDeadline", deadline).Info("Exit hooks deadline set")
+		woc.wf.Status.ExitHooksDeadline = &deadline
+		woc.updated = true
+	}
+	if !woc.exitHooksDeadlineExceeded() {
+		woc.requeueAfter(time.Until(woc.wf.Status.ExitHooksDeadline.Time))
+	}
+}
+
+// exitHooksDeadlineExceeded returns true if the workflow has an exit hooks deadline which has passed
+func (woc *wfOperationCtx) exitHooksDeadlineExceeded() bool {
+	deadline := woc.wf.Status.ExitHooksDeadline
+	return deadline != nil && time.Now().UTC().After(deadline.Time)
+}
+
+// exitHookNodeIDs returns the IDs of the exit handler nodes and all of their children
+func (woc *wfOperationCtx) exitHookNodeIDs() map[string]bool {
+	ids := map[string]bool{}
+	for _, node := range woc.wf.Status.Nodes {
+		if node.NodeFlag == nil || !node.NodeFlag.Hooked || !node.IsExitNode() {
+			continue
+		}
+		ids[node.ID] = true
+		children, err := woc.wf.Status.Nodes.NestedChildrenStatus(node.ID)
+		if err != nil {
+			woc.log.WithError(err).Warn("failed to get exit handler children")
+			continue
+		}
+		for _, child := range children {
+			ids[child.ID] = true
+		}
+	}
+	return ids
+}
+
+// markExitHooksNotRun sets the ExitHooksNotRun condition
+func (woc *wfOperationCtx) markExitHooksNotRun(message string) {
+	for _, c := range woc.wf.Status.Conditions {
+		if c.Type == wfv1.ConditionTypeExitHooksNotRun && c.Message == message {
+			return
+		}
+	}
+	woc.wf.Status.Conditions.UpsertCondition(wfv1.Condition{Type: wfv1.ConditionTypeExitHooksNotRun, Status: metav1.ConditionTrue, Message: message})
+	woc.updated = true
+}
+
+// markExitHooksSkipped sets the ExitHooksNotRun condition for an exit handler that is not run because of the
+// workflow's shutdown strategy
+func (woc *wfOperationCtx) markExitHooksSkipped() {
+	woc.markExitHooksNotRun(fmt.Sprintf("exit hooks were not run because the workflow was shut down with strategy: %s", woc.GetShutdownStrategy()))
+}
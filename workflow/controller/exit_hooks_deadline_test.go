@@ -0,0 +1,108 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+var exitHooksDeadlineWf = `
+metadata:
+  name: exit-hooks-deadline
+  namespace: default
+spec:
+  entrypoint: main
+  onExit: exit
+  exitHooksDeadlineSeconds: 60
+  templates:
+  - name: main
+    container:
+      image: argoproj/argosay:v2
+  - name: exit
+    container:
+      image: argoproj/argosay:v2
+`
+
+func TestExitHooksDeadline(t *testing.T) {
+	ctx := context.Background()
+	terminate := func(t *testing.T, wf *wfv1.Workflow) *wfOperationCtx {
+		cancel, controller := newController(wf)
+		t.Cleanup(cancel)
+		woc := newWorkflowOperationCtx(wf, controller)
+		woc.operate(ctx)
+		makePodsPhase(ctx, woc, apiv1.PodRunning)
+
+		wf = woc.wf
+		wf.Spec.Shutdown = wfv1.ShutdownStrategyTerminate
+		woc = newWorkflowOperationCtx(wf, controller)
+		woc.operate(ctx)
+		return woc
+	}
+
+	t.Run("Terminate", func(t *testing.T) {
+		woc := terminate(t, wfv1.MustUnmarshalWorkflow(exitHooksDeadlineWf))
+		if assert.NotNil(t, woc.wf.Status.ExitHooksDeadline) {
+			assert.WithinDuration(t, time.Now().Add(time.Minute), woc.wf.Status.ExitHooksDeadline.Time, 5*time.Second)
+		}
+		onExitNode, err := woc.wf.GetNodeByName("exit-hooks-deadline.onExit")
+		if assert.NoError(t, err) {
+			assert.Equal(t, wfv1.NodePending, onExitNode.Phase)
+		}
+		assert.Equal(t, wfv1.WorkflowRunning, woc.wf.Status.Phase)
+
+		t.Run("DeadlineExceeded", func(t *testing.T) {
+			makePodsPhase(ctx, woc, apiv1.PodRunning)
+			woc.wf.Status.ExitHooksDeadline = &metav1.Time{Time: time.Now().Add(-time.Second)}
+			woc := newWorkflowOperationCtx(woc.wf, woc.controller)
+			woc.operate(ctx)
+			onExitNode, err := woc.wf.GetNodeByName("exit-hooks-deadline.onExit")
+			if assert.NoError(t, err) {
+				assert.Equal(t, wfv1.NodeFailed, onExitNode.Phase)
+				assert.Equal(t, exitHooksDeadlineExceededMessage, onExitNode.Message)
+			}
+			assert.Contains(t, withoutLastTransitionTimes(t, woc.wf.Status.Conditions), wfv1.Condition{
+				Type:    wfv1.ConditionTypeExitHooksNotRun,
+				Status:  metav1.ConditionTrue,
+				Message: "exit hooks did not complete within the exit hooks deadline",
+			})
+		})
+	})
+
+	t.Run("TerminateWithoutExitHooksDeadline", func(t *testing.T) {
+		wf := wfv1.MustUnmarshalWorkflow(exitHooksDeadlineWf)
+		wf.Spec.ExitHooksDeadlineSeconds = nil
+		woc := terminate(t, wf)
+		assert.Nil(t, woc.wf.Status.ExitHooksDeadline)
+		_, err := woc.wf.GetNodeByName("exit-hooks-deadline.onExit")
+		assert.Error(t, err)
+		assert.Equal(t, wfv1.WorkflowFailed, woc.wf.Status.Phase)
+		assert.Contains(t, withoutLastTransitionTimes(t, woc.wf.Status.Conditions), wfv1.Condition{
+			Type:    wfv1.ConditionTypeExitHooksNotRun,
+			Status:  metav1.ConditionTrue,
+			Message: fmt.Sprintf("exit hooks were not run because the workflow was shut down with strategy: %s", wfv1.ShutdownStrategyTerminate),
+		})
+	})
+
+	t.Run("ActiveDeadlineSeconds", func(t *testing.T) {
+		wf := wfv1.MustUnmarshalWorkflow(exitHooksDeadlineWf)
+		wf.Spec.ActiveDeadlineSeconds = pointer.Int64(10)
+		wf.Status.StartedAt = metav1.Time{Time: time.Now().Add(-time.Minute)}
+		wf.Status.Phase = wfv1.WorkflowRunning
+		cancel, controller := newController(wf)
+		defer cancel()
+		woc := newWorkflowOperationCtx(wf, controller)
+		woc.operate(ctx)
+		if assert.NotNil(t, woc.wf.Status.ExitHooksDeadline) {
+			workflowDeadline := woc.getWorkflowDeadline()
+			assert.True(t, workflowDeadline.Add(time.Minute).Equal(woc.wf.Status.ExitHooksDeadline.Time))
+		}
+	})
+}
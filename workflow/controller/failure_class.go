@@ -0,0 +1,43 @@
+package controller
+
+import (
+	apiv1 "k8s.io/api/core/v1"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+// podFailureClass returns why the failed pod failed: because of the infrastructure it ran on, or because its main
+// container exited with a non-zero exit code. It is empty if the pod failed for another reason, e.g. an error of the
+// executor.
+func podFailureClass(pod *apiv1.Pod, tmpl *wfv1.Template) wfv1.NodeFailureClass {
+	if reason, _ := podPolicyReason(pod); reason != "" {
+		return wfv1.NodeFailureClassEvicted
+	}
+	switch pod.Status.Reason {
+	case "NodeLost", "NodeShutdown", "Shutdown", "Terminated":
+		return wfv1.NodeFailureClassNodeLost
+	}
+	for _, c := range pod.Status.Conditions {
+		if c.Type == podDisruptionTarget && c.Status == apiv1.ConditionTrue && c.Reason == "DeletionByPodGC" {
+			return wfv1.NodeFailureClassNodeLost
+		}
+	}
+	ctrs := append(append([]apiv1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+	for _, ctr := range ctrs {
+		if w := ctr.State.Waiting; w != nil {
+			switch w.Reason {
+			case "ErrImagePull", "ImagePullBackOff":
+				return wfv1.NodeFailureClassImagePull
+			}
+		}
+		if t := ctr.State.Terminated; t != nil && t.Reason == "OOMKilled" {
+			return wfv1.NodeFailureClassOOMKilled
+		}
+	}
+	for _, ctr := range pod.Status.ContainerStatuses {
+		if t := ctr.State.Terminated; t != nil && t.ExitCode != 0 && tmpl.IsMainContainerName(ctr.Name) {
+			return wfv1.NodeFailureClassApplication
+		}
+	}
+	return ""
+}
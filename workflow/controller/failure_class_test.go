@@ -0,0 +1,76 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	apiv1 "k8s.io/api/core/v1"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	intstrutil "github.com/argoproj/argo-workflows/v3/util/intstr"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+func TestPodFailureClass(t *testing.T) {
+	terminated := func(name, reason string, exitCode int32) apiv1.ContainerStatus {
+		return apiv1.ContainerStatus{Name: name, State: apiv1.ContainerState{Terminated: &apiv1.ContainerStateTerminated{Reason: reason, ExitCode: exitCode}}}
+	}
+	tests := []struct {
+		name   string
+		status apiv1.PodStatus
+		class  wfv1.NodeFailureClass
+	}{
+		{"Evicted", apiv1.PodStatus{Reason: "Evicted"}, wfv1.NodeFailureClassEvicted},
+		{"Preempted", apiv1.PodStatus{Conditions: []apiv1.PodCondition{{Type: podDisruptionTarget, Status: apiv1.ConditionTrue, Reason: "PreemptionByScheduler"}}}, wfv1.NodeFailureClassEvicted},
+		{"NodeShutdown", apiv1.PodStatus{Reason: "NodeShutdown"}, wfv1.NodeFailureClassNodeLost},
+		{"DeletionByPodGC", apiv1.PodStatus{Conditions: []apiv1.PodCondition{{Type: podDisruptionTarget, Status: apiv1.ConditionTrue, Reason: "DeletionByPodGC"}}}, wfv1.NodeFailureClassNodeLost},
+		{"ImagePull", apiv1.PodStatus{ContainerStatuses: []apiv1.ContainerStatus{{Name: common.MainContainerName, State: apiv1.ContainerState{Waiting: &apiv1.ContainerStateWaiting{Reason: "ImagePullBackOff"}}}}}, wfv1.NodeFailureClassImagePull},
+		{"OOMKilled", apiv1.PodStatus{ContainerStatuses: []apiv1.ContainerStatus{terminated(common.MainContainerName, "OOMKilled", 137)}}, wfv1.NodeFailureClassOOMKilled},
+		{"Application", apiv1.PodStatus{ContainerStatuses: []apiv1.ContainerStatus{terminated(common.WaitContainerName, "Completed", 0), terminated(common.MainContainerName, "Error", 1)}}, wfv1.NodeFailureClassApplication},
+		{"WaitContainer", apiv1.PodStatus{ContainerStatuses: []apiv1.ContainerStatus{terminated(common.WaitContainerName, "Error", 1), terminated(common.MainContainerName, "Completed", 0)}}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.class, podFailureClass(&apiv1.Pod{Status: tt.status}, nil))
+		})
+	}
+}
+
+func TestProcessNodeRetriesFailureClasses(t *testing.T) {
+	tests := []struct {
+		name    string
+		classes []wfv1.NodeFailureClass
+		class   wfv1.NodeFailureClass
+		retried bool
+	}{
+		{"DefaultInfrastructure", nil, wfv1.NodeFailureClassEvicted, true},
+		{"DefaultApplication", nil, wfv1.NodeFailureClassApplication, false},
+		{"Selected", []wfv1.NodeFailureClass{wfv1.NodeFailureClassApplication}, wfv1.NodeFailureClassApplication, true},
+		{"NotSelected", []wfv1.NodeFailureClass{wfv1.NodeFailureClassNodeLost}, wfv1.NodeFailureClassOOMKilled, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cancel, controller := newController()
+			defer cancel()
+			woc := newWorkflowOperationCtx(wfv1.MustUnmarshalWorkflow(helloWorldWf), controller)
+			nodeName := "test-node"
+			node := woc.initializeNode(nodeName, wfv1.NodeTypeRetry, "", &wfv1.WorkflowStep{}, "", wfv1.NodeRunning, &wfv1.NodeFlag{})
+			childName := nodeName + "(0)"
+			child := woc.initializeNode(childName, wfv1.NodeTypePod, "", &wfv1.WorkflowStep{}, "", wfv1.NodeFailed, &wfv1.NodeFlag{Retried: true})
+			child.FailureClass = tt.class
+			woc.wf.Status.Nodes.Set(child.ID, *child)
+			woc.addChildNode(nodeName, childName)
+			node, err := woc.wf.GetNodeByName(node.Name)
+			assert.NoError(t, err)
+
+			retries := wfv1.RetryStrategy{Limit: intstrutil.ParsePtr("2"), RetryPolicy: wfv1.RetryPolicyOnTransientError, FailureClasses: tt.classes}
+			n, _, err := woc.processNodeRetries(node, retries, &executeTemplateOpts{})
+			assert.NoError(t, err)
+			if tt.retried {
+				assert.Equal(t, wfv1.NodeRunning, n.Phase)
+			} else {
+				assert.Equal(t, wfv1.NodeFailed, n.Phase)
+			}
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package controller
+
+import (
+	"fmt"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+// checkNodesGuardrail returns an error, and stops the workflow by policy, if creating another node would exceed the
+// guardrails' maximum number of nodes
+func (woc *wfOperationCtx) checkNodesGuardrail() error {
+	if err := woc.controller.Config.Guardrails.CheckNodes(len(woc.wf.Status.Nodes) + 1); err != nil {
+		woc.markStoppedByPolicy(wfv1.PolicyReasonGuardrailExceeded, err.Error())
+		return err
+	}
+	return nil
+}
+
+// checkFanOutGuardrail returns an error, and stops the workflow by policy, if a loop expanded to more items than the
+// guardrails' maximum fan-out
+func (woc *wfOperationCtx) checkFanOutGuardrail(name string, items int) error {
+	if err := woc.controller.Config.Guardrails.CheckFanOut(items); err != nil {
+		err = fmt.Errorf("%s: %w", name, err)
+		woc.markStoppedByPolicy(wfv1.PolicyReasonGuardrailExceeded, err.Error())
+		return err
+	}
+	return nil
+}
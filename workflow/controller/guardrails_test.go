@@ -0,0 +1,84 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/pointer"
+
+	"github.com/argoproj/argo-workflows/v3/config"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+var guardrailsWf = `
+metadata:
+  name: guardrails
+  namespace: default
+spec:
+  entrypoint: main
+  templates:
+  - name: main
+    steps:
+    - - name: echo
+        template: echo
+        withItems: [a, b, c]
+  - name: echo
+    container:
+      image: argoproj/argosay:v2
+`
+
+func TestGuardrails(t *testing.T) {
+	ctx := context.Background()
+	operate := func(wf *wfv1.Workflow, guardrails *config.Guardrails) *wfOperationCtx {
+		cancel, controller := newController(wf)
+		defer cancel()
+		controller.Config.Guardrails = guardrails
+		woc := newWorkflowOperationCtx(wf, controller)
+		woc.operate(ctx)
+		return woc
+	}
+
+	t.Run("Unlimited", func(t *testing.T) {
+		woc := operate(wfv1.MustUnmarshalWorkflow(guardrailsWf), &config.Guardrails{})
+		assert.Equal(t, wfv1.WorkflowRunning, woc.wf.Status.Phase)
+		assert.Empty(t, woc.wf.Status.GetPolicyReason())
+		assert.Nil(t, woc.getActiveDeadlineSeconds())
+	})
+	t.Run("ActiveDeadlineSeconds", func(t *testing.T) {
+		wf := wfv1.MustUnmarshalWorkflow(guardrailsWf)
+		wf.Spec.ActiveDeadlineSeconds = pointer.Int64(7200)
+		woc := operate(wf, &config.Guardrails{MaxActiveDeadlineSeconds: 3600})
+		assert.Equal(t, wfv1.WorkflowFailed, woc.wf.Status.Phase)
+		assert.Equal(t, wfv1.PolicyReasonGuardrailExceeded, woc.wf.Status.GetPolicyReason())
+		assert.Equal(t, "activeDeadlineSeconds 7200 exceeds the maximum of 3600 set by the controller's guardrails", woc.wf.Status.Message)
+	})
+	t.Run("DefaultActiveDeadlineSeconds", func(t *testing.T) {
+		woc := operate(wfv1.MustUnmarshalWorkflow(guardrailsWf), &config.Guardrails{MaxActiveDeadlineSeconds: 3600})
+		assert.Equal(t, wfv1.WorkflowRunning, woc.wf.Status.Phase)
+		assert.Equal(t, pointer.Int64(3600), woc.getActiveDeadlineSeconds())
+		if assert.NotNil(t, woc.getWorkflowDeadline()) {
+			assert.Equal(t, woc.wf.Status.StartedAt.Truncate(time.Second).Add(time.Hour).UTC(), *woc.getWorkflowDeadline())
+		}
+	})
+	t.Run("FanOut", func(t *testing.T) {
+		woc := operate(wfv1.MustUnmarshalWorkflow(guardrailsWf), &config.Guardrails{MaxFanOut: 2})
+		assert.Equal(t, wfv1.PolicyReasonGuardrailExceeded, woc.wf.Status.GetPolicyReason())
+		node := woc.wf.Status.Nodes.FindByDisplayName("[0]")
+		if assert.NotNil(t, node) {
+			assert.Equal(t, wfv1.NodeError, node.Phase)
+			assert.Equal(t, "echo: loop of 3 items exceeds the maximum fan-out of 2 set by the controller's guardrails", node.Message)
+		}
+		pods, err := listPods(woc)
+		assert.NoError(t, err)
+		assert.Empty(t, pods.Items)
+	})
+	t.Run("Nodes", func(t *testing.T) {
+		woc := operate(wfv1.MustUnmarshalWorkflow(guardrailsWf), &config.Guardrails{MaxNodes: 3})
+		assert.Equal(t, wfv1.PolicyReasonGuardrailExceeded, woc.wf.Status.GetPolicyReason())
+		pods, err := listPods(woc)
+		assert.NoError(t, err)
+		assert.Len(t, pods.Items, 1)
+	})
+}
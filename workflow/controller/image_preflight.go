@@ -0,0 +1,106 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	apiv1 "k8s.io/api/core/v1"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/controller/entrypoint"
+	"github.com/argoproj/argo-workflows/v3/workflow/controller/preflight"
+)
+
+// templateImages returns the images of the workflow's templates, except those that use template variables, which
+// cannot be known until the pod is created.
+func templateImages(wf *wfv1.Workflow) []string {
+	images := map[string]bool{}
+	add := func(image string) {
+		if image != "" && !strings.Contains(image, "{{") {
+			images[image] = true
+		}
+	}
+	addAll := func(tmpl *wfv1.Template) {
+		if tmpl == nil {
+			return
+		}
+		if tmpl.Container != nil {
+			add(tmpl.Container.Image)
+		}
+		if tmpl.Script != nil {
+			add(tmpl.Script.Image)
+		}
+		if tmpl.ContainerSet != nil {
+			for _, c := range tmpl.ContainerSet.Containers {
+				add(c.Image)
+			}
+		}
+		for _, c := range tmpl.InitContainers {
+			add(c.Image)
+		}
+		for _, c := range tmpl.Sidecars {
+			add(c.Image)
+		}
+	}
+	for i := range wf.Spec.Templates {
+		addAll(&wf.Spec.Templates[i])
+	}
+	addAll(wf.Spec.TemplateDefaults)
+	var v []string
+	for image := range images {
+		v = append(v, image)
+	}
+	sort.Strings(v)
+	return v
+}
+
+// preflightImages resolves the workflow's images to digests, failing if any of them cannot be pulled.
+// Images resolved by a previous attempt are not resolved again.
+func (woc *wfOperationCtx) preflightImages(ctx context.Context) error {
+	for _, image := range templateImages(woc.execWf) {
+		if _, err := woc.resolveImage(ctx, image); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resolveImage returns the digest of the image, resolving it and recording it in the status the first time.
+func (woc *wfOperationCtx) resolveImage(ctx context.Context, image string) (string, error) {
+	if digest := woc.wf.Status.GetResolvedImage(image); digest != "" {
+		return digest, nil
+	}
+	digest, err := woc.controller.imageResolver.Resolve(ctx, image, entrypoint.Options{
+		Namespace: woc.wf.Namespace, ServiceAccountName: woc.execWf.Spec.ServiceAccountName, ImagePullSecrets: woc.getImagePullSecrets(image),
+	})
+	if err != nil {
+		return "", fmt.Errorf("image %q: %w", image, err)
+	}
+	woc.log.WithField("image", image).WithField("digest", digest).Info("Resolved image")
+	woc.wf.Status.SetResolvedImage(image, digest)
+	woc.updated = true
+	return digest, nil
+}
+
+// resolvePodImages resolves the images of the pod's user containers, such as those with template variables that
+// were not known at submission, and pins them to their digests if requested.
+func (woc *wfOperationCtx) resolvePodImages(ctx context.Context, pod *apiv1.Pod) error {
+	executorImage := woc.controller.executorImage()
+	for _, containers := range [][]apiv1.Container{pod.Spec.InitContainers, pod.Spec.Containers} {
+		for i, c := range containers {
+			if c.Image == executorImage {
+				continue
+			}
+			digest, err := woc.resolveImage(ctx, c.Image)
+			if err != nil {
+				return err
+			}
+			if woc.execWf.Spec.ImagePreflight.PinDigests {
+				containers[i].Image = preflight.PinnedImage(c.Image, digest)
+			}
+		}
+	}
+	return nil
+}
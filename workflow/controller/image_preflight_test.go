@@ -0,0 +1,115 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/controller/entrypoint"
+	"github.com/argoproj/argo-workflows/v3/workflow/controller/preflight"
+)
+
+const testDigest = "sha256:0123456789012345678901234567890123456789012345678901234567890123"
+
+type fakeImageResolver map[string]string
+
+func (f fakeImageResolver) Resolve(_ context.Context, image string, _ entrypoint.Options) (string, error) {
+	if digest, ok := f[image]; ok {
+		return digest, nil
+	}
+	return "", fmt.Errorf("MANIFEST_UNKNOWN: manifest unknown")
+}
+
+var imagePreflightWf = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  name: image-preflight
+spec:
+  entrypoint: main
+  imagePreflight:
+    pinDigests: true
+  templates:
+  - name: main
+    container:
+      image: argoproj/argosay:v2
+`
+
+func TestImagePreflight(t *testing.T) {
+	t.Run("Pinned", func(t *testing.T) {
+		wf := wfv1.MustUnmarshalWorkflow(imagePreflightWf)
+		cancel, controller := newController(wf)
+		defer cancel()
+		controller.imageResolver = fakeImageResolver{"argoproj/argosay:v2": testDigest}
+		ctx := context.Background()
+		woc := newWorkflowOperationCtx(wf, controller)
+		woc.operate(ctx)
+		assert.Equal(t, wfv1.WorkflowRunning, woc.wf.Status.Phase)
+		assert.Equal(t, []wfv1.ResolvedImage{{Image: "argoproj/argosay:v2", Digest: testDigest}}, woc.wf.Status.ResolvedImages)
+		pods, err := listPods(woc)
+		if assert.NoError(t, err) && assert.Len(t, pods.Items, 1) {
+			main := pods.Items[0].Spec.Containers[1]
+			assert.Equal(t, "argoproj/argosay:v2@"+testDigest, main.Image)
+		}
+	})
+	t.Run("Missing", func(t *testing.T) {
+		wf := wfv1.MustUnmarshalWorkflow(imagePreflightWf)
+		cancel, controller := newController(wf)
+		defer cancel()
+		controller.imageResolver = fakeImageResolver{}
+		ctx := context.Background()
+		woc := newWorkflowOperationCtx(wf, controller)
+		woc.operate(ctx)
+		assert.Equal(t, wfv1.WorkflowFailed, woc.wf.Status.Phase)
+		assert.Contains(t, woc.wf.Status.Message, `Image preflight check failed: image "argoproj/argosay:v2"`)
+		pods, err := listPods(woc)
+		if assert.NoError(t, err) {
+			assert.Empty(t, pods.Items)
+		}
+	})
+	t.Run("Pending", func(t *testing.T) {
+		wf := wfv1.MustUnmarshalWorkflow(imagePreflightWf)
+		cancel, controller := newController(wf)
+		defer cancel()
+		controller.imageResolver = preflight.NewAsyncImageResolver(fakeImageResolver{"argoproj/argosay:v2": testDigest})
+		ctx := context.Background()
+		woc := newWorkflowOperationCtx(wf, controller)
+		woc.operate(ctx)
+		// the registry is not waited for, the workflow is requeued until the image is resolved
+		assert.Equal(t, wfv1.WorkflowUnknown, woc.wf.Status.Phase)
+		assert.Eventually(t, func() bool {
+			woc = newWorkflowOperationCtx(woc.wf, controller)
+			woc.operate(ctx)
+			return woc.wf.Status.Phase == wfv1.WorkflowRunning
+		}, time.Second, 10*time.Millisecond)
+		assert.Equal(t, []wfv1.ResolvedImage{{Image: "argoproj/argosay:v2", Digest: testDigest}}, woc.wf.Status.ResolvedImages)
+	})
+}
+
+func TestTemplateImages(t *testing.T) {
+	wf := wfv1.MustUnmarshalWorkflow(`
+metadata:
+  name: my-wf
+spec:
+  templates:
+  - name: a
+    container:
+      image: my-image
+    sidecars:
+    - name: sidecar
+      image: my-sidecar
+  - name: b
+    script:
+      image: "{{inputs.parameters.image}}"
+  - name: c
+    containerSet:
+      containers:
+      - name: main
+        image: my-image
+`)
+	assert.Equal(t, []string{"my-image", "my-sidecar"}, templateImages(wf))
+}
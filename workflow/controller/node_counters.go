@@ -2,6 +2,7 @@ package controller
 
 import (
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
 )
 
 type counter func(wfv1.NodeStatus) bool
@@ -20,6 +21,18 @@ func (woc *wfOperationCtx) getActivePodsCounter(boundaryID string) counter {
 	}
 }
 
+func (woc *wfOperationCtx) getActiveArtifactPodsCounter() counter {
+	activePods := woc.getActivePodsCounter("")
+	return func(node wfv1.NodeStatus) bool {
+		if !activePods(node) {
+			return false
+		}
+		pod, _, _ := woc.podExists(node.ID)
+		// Only count pods that load or save artifacts
+		return pod != nil && pod.Labels[common.LabelKeyArtifacts] == "true"
+	}
+}
+
 func getActiveChildrenCounter(boundaryID string) counter {
 	return func(node wfv1.NodeStatus) bool {
 		return node.BoundaryID == boundaryID &&
@@ -44,6 +57,10 @@ func (woc *wfOperationCtx) getActivePods(boundaryID string) int64 {
 	return woc.countNodes(woc.getActivePodsCounter(boundaryID))
 }
 
+func (woc *wfOperationCtx) getActiveArtifactPods() int64 {
+	return woc.countNodes(woc.getActiveArtifactPodsCounter())
+}
+
 func (woc *wfOperationCtx) getActiveChildren(boundaryID string) int64 {
 	return woc.countNodes(getActiveChildrenCounter(boundaryID))
 }
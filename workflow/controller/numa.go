@@ -0,0 +1,160 @@
+package controller
+
+import (
+	"strings"
+
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/argoproj/argo-workflows/v3/errors"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+// the CRI-O annotations that tune the pod's exclusive CPUs
+const (
+	cpuLoadBalancingAnnotation = "cpu-load-balancing.crio.io"
+	cpuQuotaAnnotation         = "cpu-quota.crio.io"
+	irqLoadBalancingAnnotation = "irq-load-balancing.crio.io"
+)
+
+// the resources given to the executor's containers when the pod must be Guaranteed and they are not configured
+var (
+	guaranteedExecutorCPU    = resource.MustParse("100m")
+	guaranteedExecutorMemory = resource.MustParse("64Mi")
+)
+
+// applyNUMA gives the template's containers exclusive CPUs and huge pages, and sets the NUMA annotations on the pod.
+// It is applied before any pod spec patch, so that templates can still fine-tune the pod.
+func applyNUMA(pod *apiv1.Pod, numa *wfv1.NUMA, mainCtrs []apiv1.Container) error {
+	if err := numa.Validate(); err != nil {
+		return errors.Errorf(errors.CodeBadRequest, "numa.%s", err.Error())
+	}
+	isMain := make(map[string]bool)
+	for _, c := range mainCtrs {
+		isMain[c.Name] = true
+	}
+	// the executor's containers share their resources with the controller's config, so they must be copied first
+	for i := range pod.Spec.InitContainers {
+		pod.Spec.InitContainers[i].Resources = *pod.Spec.InitContainers[i].Resources.DeepCopy()
+	}
+	for i := range pod.Spec.Containers {
+		pod.Spec.Containers[i].Resources = *pod.Spec.Containers[i].Resources.DeepCopy()
+	}
+	addNUMAAnnotations(pod, numa)
+	addHugePages(pod, numa.HugePages, isMain)
+	if numa.ExclusiveCPUs == 0 {
+		return nil
+	}
+	cpus := *resource.NewQuantity(int64(numa.ExclusiveCPUs), resource.DecimalSI)
+	for i := range pod.Spec.InitContainers {
+		c := &pod.Spec.InitContainers[i]
+		if err := makeGuaranteed(c, c.Name == common.InitContainerName); err != nil {
+			return err
+		}
+	}
+	for i := range pod.Spec.Containers {
+		c := &pod.Spec.Containers[i]
+		if isMain[c.Name] {
+			setResource(c, apiv1.ResourceCPU, cpus)
+		}
+		if err := makeGuaranteed(c, c.Name == common.WaitContainerName); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func addNUMAAnnotations(pod *apiv1.Pod, numa *wfv1.NUMA) {
+	annotations := make(map[string]string)
+	if numa.TopologyPolicy != "" {
+		annotations[common.AnnotationKeyTopologyPolicy] = numa.TopologyPolicy
+	}
+	if numa.DisableCPULoadBalancing {
+		annotations[cpuLoadBalancingAnnotation] = "disable"
+	}
+	if numa.DisableCPUQuota {
+		annotations[cpuQuotaAnnotation] = "disable"
+	}
+	if numa.DisableIRQLoadBalancing {
+		annotations[irqLoadBalancingAnnotation] = "disable"
+	}
+	if len(annotations) == 0 {
+		return
+	}
+	if pod.Annotations == nil {
+		pod.Annotations = map[string]string{}
+	}
+	for k, v := range annotations {
+		pod.Annotations[k] = v
+	}
+}
+
+// addHugePages requests the huge pages for the template's containers, and mounts them using volumes backed by huge
+// pages of each size
+func addHugePages(pod *apiv1.Pod, hugePages []wfv1.HugePages, isMain map[string]bool) {
+	for _, h := range hugePages {
+		name := "hugepages-" + strings.ToLower(h.PageSize)
+		mountPath := h.MountPath
+		if mountPath == "" {
+			mountPath = "/dev/hugepages"
+			if len(hugePages) > 1 {
+				mountPath += "-" + h.PageSize
+			}
+		}
+		pod.Spec.Volumes = append(pod.Spec.Volumes, apiv1.Volume{
+			Name: name,
+			VolumeSource: apiv1.VolumeSource{
+				EmptyDir: &apiv1.EmptyDirVolumeSource{Medium: apiv1.StorageMediumHugePagesPrefix + apiv1.StorageMedium(h.PageSize)},
+			},
+		})
+		quantity := resource.MustParse(h.Quantity)
+		for i := range pod.Spec.Containers {
+			c := &pod.Spec.Containers[i]
+			if !isMain[c.Name] {
+				continue
+			}
+			setResource(c, h.ResourceName(), quantity)
+			c.VolumeMounts = append(c.VolumeMounts, apiv1.VolumeMount{Name: name, MountPath: mountPath})
+		}
+	}
+}
+
+// setResource sets the request and limit of the resource, which must be equal for CPUs to be exclusive and for huge
+// pages
+func setResource(c *apiv1.Container, name apiv1.ResourceName, quantity resource.Quantity) {
+	if c.Resources.Requests == nil {
+		c.Resources.Requests = apiv1.ResourceList{}
+	}
+	if c.Resources.Limits == nil {
+		c.Resources.Limits = apiv1.ResourceList{}
+	}
+	c.Resources.Requests[name] = quantity
+	c.Resources.Limits[name] = quantity
+}
+
+// makeGuaranteed makes the container's CPU and memory requests equal to their limits, or the limits equal to the
+// requests if there are none. A container with neither is an error, unless it is the executor's, which is given
+// defaults.
+func makeGuaranteed(c *apiv1.Container, executor bool) error {
+	for _, x := range []struct {
+		name     apiv1.ResourceName
+		fallback resource.Quantity
+	}{
+		{apiv1.ResourceCPU, guaranteedExecutorCPU},
+		{apiv1.ResourceMemory, guaranteedExecutorMemory},
+	} {
+		quantity, ok := c.Resources.Limits[x.name]
+		if !ok {
+			quantity, ok = c.Resources.Requests[x.name]
+		}
+		if !ok {
+			if !executor {
+				return errors.Errorf(errors.CodeBadRequest, "numa.exclusiveCPUs requires container %s to have a %s request or limit, so that the pod is Guaranteed", c.Name, x.name)
+			}
+			quantity = x.fallback
+		}
+		setResource(c, x.name, quantity)
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	apiv1 "k8s.io/api/core/v1"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+var numaWf = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  name: numa
+spec:
+  entrypoint: main
+  templates:
+  - name: main
+    numa:
+      exclusiveCPUs: 4
+      topologyPolicy: single-numa-node
+      disableCPUQuota: true
+      hugePages:
+      - pageSize: 2Mi
+        quantity: 512Mi
+    container:
+      image: argoproj/argosay:v2
+      resources:
+        requests:
+          memory: 1Gi
+`
+
+func TestNUMA(t *testing.T) {
+	t.Run("Applied", func(t *testing.T) {
+		wf := wfv1.MustUnmarshalWorkflow(numaWf)
+		cancel, controller := newController(wf)
+		defer cancel()
+		ctx := context.Background()
+		woc := newWorkflowOperationCtx(wf, controller)
+		woc.operate(ctx)
+		pods, err := listPods(woc)
+		if assert.NoError(t, err) && assert.Len(t, pods.Items, 1) {
+			pod := pods.Items[0]
+			assert.Equal(t, "single-numa-node", pod.Annotations[common.AnnotationKeyTopologyPolicy])
+			assert.Equal(t, "disable", pod.Annotations[cpuQuotaAnnotation])
+			assert.NotContains(t, pod.Annotations, cpuLoadBalancingAnnotation)
+			for _, c := range append(pod.Spec.InitContainers, pod.Spec.Containers...) {
+				assert.Equal(t, c.Resources.Limits.Cpu().String(), c.Resources.Requests.Cpu().String(), c.Name)
+				assert.Equal(t, c.Resources.Limits.Memory().String(), c.Resources.Requests.Memory().String(), c.Name)
+			}
+			for _, c := range pod.Spec.Containers {
+				if c.Name != common.MainContainerName {
+					continue
+				}
+				assert.Equal(t, "4", c.Resources.Limits.Cpu().String())
+				assert.Equal(t, "1Gi", c.Resources.Limits.Memory().String())
+				hugePages := c.Resources.Limits[apiv1.ResourceName("hugepages-2Mi")]
+				assert.Equal(t, "512Mi", hugePages.String())
+				assert.Contains(t, c.VolumeMounts, apiv1.VolumeMount{Name: "hugepages-2mi", MountPath: "/dev/hugepages"})
+			}
+			assert.Contains(t, pod.Spec.Volumes, apiv1.Volume{
+				Name:         "hugepages-2mi",
+				VolumeSource: apiv1.VolumeSource{EmptyDir: &apiv1.EmptyDirVolumeSource{Medium: "HugePages-2Mi"}},
+			})
+		}
+	})
+	t.Run("NoMemory", func(t *testing.T) {
+		wf := wfv1.MustUnmarshalWorkflow(numaWf)
+		wf.Spec.Templates[0].Container.Resources = apiv1.ResourceRequirements{}
+		cancel, controller := newController(wf)
+		defer cancel()
+		ctx := context.Background()
+		woc := newWorkflowOperationCtx(wf, controller)
+		woc.operate(ctx)
+		assert.Equal(t, wfv1.WorkflowError, woc.wf.Status.Phase)
+		assert.Contains(t, woc.wf.Status.Message, "numa.exclusiveCPUs requires container main to have a memory request or limit")
+	})
+}
@@ -29,6 +29,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	k8sintstr "k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/strategicpatch"
 	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/client-go/tools/cache"
@@ -40,7 +41,6 @@ import (
 	"github.com/argoproj/argo-workflows/v3/pkg/apis/workflow"
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 	"github.com/argoproj/argo-workflows/v3/pkg/client/clientset/versioned/typed/workflow/v1alpha1"
-	"github.com/argoproj/argo-workflows/v3/util"
 	"github.com/argoproj/argo-workflows/v3/util/diff"
 	envutil "github.com/argoproj/argo-workflows/v3/util/env"
 	errorsutil "github.com/argoproj/argo-workflows/v3/util/errors"
@@ -58,6 +58,7 @@ import (
 	controllercache "github.com/argoproj/argo-workflows/v3/workflow/controller/cache"
 	"github.com/argoproj/argo-workflows/v3/workflow/controller/estimation"
 	"github.com/argoproj/argo-workflows/v3/workflow/controller/indexes"
+	"github.com/argoproj/argo-workflows/v3/workflow/controller/preflight"
 	"github.com/argoproj/argo-workflows/v3/workflow/metrics"
 	"github.com/argoproj/argo-workflows/v3/workflow/progress"
 	argosync "github.com/argoproj/argo-workflows/v3/workflow/sync"
@@ -97,6 +98,11 @@ type wfOperationCtx struct {
 	// activePods tracks the number of active (Running/Pending) pods for controlling
 	// parallelism
 	activePods int64
+	// activeArtifactPods tracks the number of active (Running/Pending) pods that load or save artifacts, for
+	// controlling artifact parallelism
+	activeArtifactPods int64
+	// artifactCredentialsChecked is set once the workflow's artifact credentials have been checked in this operation
+	artifactCredentialsChecked bool
 	// workflowDeadline is the deadline which the workflow is expected to complete before we
 	// terminate the workflow.
 	workflowDeadline *time.Time
@@ -276,6 +282,23 @@ func (woc *wfOperationCtx) operate(ctx context.Context) {
 		woc.computeMetrics(woc.execWf.Spec.Metrics.Prometheus, localScope, realTimeScope, true)
 	}
 
+	if woc.wf.Status.Phase == wfv1.WorkflowUnknown && woc.execWf.Spec.ImagePreflight != nil {
+		if err := woc.preflightImages(ctx); err != nil {
+			if preflight.IsPending(err) {
+				woc.log.Debug("Waiting for the image preflight check")
+				woc.requeue()
+				return
+			}
+			if preflight.IsTransientErr(err) {
+				woc.log.WithError(err).Warn("Image preflight check could not complete, will retry")
+				woc.requeue()
+				return
+			}
+			woc.markWorkflowFailed(ctx, fmt.Sprintf("Image preflight check failed: %v", err))
+			return
+		}
+	}
+
 	if woc.wf.Status.Phase == wfv1.WorkflowUnknown {
 		woc.markWorkflowRunning(ctx)
 		setWfPodNamesAnnotation(woc.wf)
@@ -298,11 +321,17 @@ func (woc *wfOperationCtx) operate(ctx context.Context) {
 		woc.wf.Status.EstimatedDuration = woc.estimateWorkflowDuration()
 	} else {
 		woc.workflowDeadline = woc.getWorkflowDeadline()
+		woc.reconcileExitHooksDeadline()
 		woc.taskResultReconciliation()
 		err = woc.podReconciliation(ctx)
 		if err == nil {
 			woc.failSuspendedAndPendingNodesAfterDeadlineOrShutdown()
 		}
+		if woc.usesArtifactCredentials() {
+			if err := woc.ensureArtifactCredentials(ctx, false); err != nil {
+				woc.log.WithError(err).Warn("failed to renew artifact credentials")
+			}
+		}
 
 		if err != nil {
 			woc.log.WithError(err).WithField("workflow", woc.wf.ObjectMeta.Name).Error("workflow timeout")
@@ -319,6 +348,9 @@ func (woc *wfOperationCtx) operate(ctx context.Context) {
 	if woc.execWf.Spec.Parallelism != nil {
 		woc.activePods = woc.getActivePods("")
 	}
+	if woc.execWf.Spec.ArtifactParallelism != nil {
+		woc.activeArtifactPods = woc.getActiveArtifactPods()
+	}
 
 	// Create a starting template context.
 	tmplCtx, err := woc.createTemplateContext(wfv1.ResourceScopeLocal, "")
@@ -426,8 +458,22 @@ func (woc *wfOperationCtx) operate(ctx context.Context) {
 		return
 	}
 
+	var artifactBundleNode *wfv1.NodeStatus
+	if woc.execWf.Spec.ArtifactBundle != nil && woc.shouldExecute(true) {
+		artifactBundleNode, err = woc.executeArtifactBundle(ctx, tmplCtx)
+		if err != nil {
+			if !errorsutil.IsTransientErr(err) && err != ErrParallelismReached && !woc.wf.Status.Phase.Completed() {
+				woc.markWorkflowError(ctx, fmt.Errorf("error in artifact bundle execution : %w", err))
+			}
+			return
+		}
+		if artifactBundleNode != nil && !artifactBundleNode.Fulfilled() {
+			return
+		}
+	}
+
 	var onExitNode *wfv1.NodeStatus
-	if woc.execWf.Spec.HasExitHook() && woc.GetShutdownStrategy().ShouldExecute(true) {
+	if woc.execWf.Spec.HasExitHook() && woc.shouldExecute(true) {
 		woc.log.Infof("Running OnExit handler: %s", woc.execWf.Spec.OnExit)
 		onExitNodeName := common.GenerateOnExitNodeName(woc.wf.ObjectMeta.Name)
 		exitHook := woc.execWf.Spec.GetExitHook(woc.execWf.Spec.Arguments)
@@ -460,6 +506,8 @@ func (woc *wfOperationCtx) operate(ctx context.Context) {
 		if onExitNode == nil || !onExitNode.Fulfilled() {
 			return
 		}
+	} else if woc.execWf.Spec.HasExitHook() {
+		woc.markExitHooksSkipped()
 	}
 
 	var workflowMessage string
@@ -474,7 +522,9 @@ func (woc *wfOperationCtx) operate(ctx context.Context) {
 	// node phase.
 	switch workflowStatus {
 	case wfv1.WorkflowSucceeded:
-		if onExitNode != nil && onExitNode.FailedOrError() {
+		if artifactBundleNode != nil && artifactBundleNode.FailedOrError() {
+			woc.markWorkflowFailed(ctx, fmt.Sprintf("failed to bundle artifacts: %s", artifactBundleNode.Message))
+		} else if onExitNode != nil && onExitNode.FailedOrError() {
 			// if main workflow succeeded, but the exit node was unsuccessful
 			// the workflow is now considered unsuccessful.
 			switch onExitNode.Phase {
@@ -575,15 +625,22 @@ func (woc *wfOperationCtx) updateWorkflowMetadata() error {
 	return nil
 }
 
+// getActiveDeadlineSeconds returns the activeDeadlineSeconds of the workflow, or the guardrails' maximum if it does not
+// have one
+func (woc *wfOperationCtx) getActiveDeadlineSeconds() *int64 {
+	return woc.controller.Config.Guardrails.GetActiveDeadlineSeconds(woc.execWf.Spec.ActiveDeadlineSeconds)
+}
+
 func (woc *wfOperationCtx) getWorkflowDeadline() *time.Time {
-	if woc.execWf.Spec.ActiveDeadlineSeconds == nil {
+	activeDeadlineSeconds := woc.getActiveDeadlineSeconds()
+	if activeDeadlineSeconds == nil {
 		return nil
 	}
 	if woc.wf.Status.StartedAt.IsZero() {
 		return nil
 	}
 	startedAt := woc.wf.Status.StartedAt.Truncate(time.Second)
-	deadline := startedAt.Add(time.Duration(*woc.execWf.Spec.ActiveDeadlineSeconds) * time.Second).UTC()
+	deadline := startedAt.Add(time.Duration(*activeDeadlineSeconds) * time.Second).UTC()
 	return &deadline
 }
 
@@ -600,6 +657,17 @@ func (woc *wfOperationCtx) setGlobalParameters(executionParameters wfv1.Argument
 		if ok {
 			woc.globalParams[common.GlobalVarWorkflowCronScheduleTime] = val
 		}
+		if val, ok := annotation[common.AnnotationKeyCronWfLastSuccessfulRunOutputs]; ok {
+			var params []wfv1.Parameter
+			if err := json.Unmarshal([]byte(val), &params); err != nil {
+				return fmt.Errorf("failed to unmarshal the last successful run outputs: %w", err)
+			}
+			for _, param := range params {
+				if param.HasValue() {
+					woc.globalParams[common.GlobalVarWorkflowLastSuccessfulRunOutputs+".parameters."+param.Name] = param.GetValue()
+				}
+			}
+		}
 	}
 
 	if woc.execWf.Spec.Priority != nil {
@@ -631,6 +699,8 @@ func (woc *wfOperationCtx) setGlobalParameters(executionParameters wfv1.Argument
 			}
 		} else if param.Value != nil {
 			woc.globalParams["workflow.parameters."+param.Name] = param.Value.String()
+		} else if param.ValueFrom != nil && param.ValueFrom.WorkflowOutputs != nil {
+			return fmt.Errorf("global parameter %s is taken from the outputs of other workflows, which is only resolved when the workflow is submitted to the Argo Server", param.Name)
 		} else {
 			return fmt.Errorf("either value or valueFrom must be specified in order to set global parameter %s", param.Name)
 		}
@@ -699,6 +769,11 @@ func (woc *wfOperationCtx) persistUpdates(ctx context.Context) {
 	if !woc.updated {
 		return
 	}
+	if delay := woc.statusUpdateDelay(); delay > 0 {
+		woc.log.WithField("delay", delay).Info("Coalescing workflow update with the updates that follow")
+		woc.requeueAfter(delay)
+		return
+	}
 
 	diff.LogChanges(woc.orig, woc.wf)
 
@@ -760,6 +835,11 @@ func (woc *wfOperationCtx) persistUpdates(ctx context.Context) {
 	}
 
 	woc.log.WithFields(log.Fields{"resourceVersion": woc.wf.ResourceVersion, "phase": woc.wf.Status.Phase}).Info("Workflow update successful")
+	if woc.wf.Status.Fulfilled() {
+		woc.controller.statusUpdates.forget(woc.wf.UID)
+	} else {
+		woc.controller.statusUpdates.record(woc.wf.UID, time.Now())
+	}
 
 	switch os.Getenv("INFORMER_WRITE_BACK") {
 	// By default we write back (as per v2.11), this does not reduce errors, but does reduce
@@ -1035,7 +1115,10 @@ func (woc *wfOperationCtx) processNodeRetries(node *wfv1.NodeStatus, retryStrate
 		retryOnFailed = false
 		retryOnError = true
 	case wfv1.RetryPolicyOnTransientError:
-		if (lastChildNode.Phase == wfv1.NodeFailed || lastChildNode.Phase == wfv1.NodeError) && errorsutil.IsTransientErr(errors.InternalError(lastChildNode.Message)) {
+		// nodes whose failure was not classified, e.g. because they failed to create their pod, are retried if their
+		// message matches a transient error
+		if lastChildNode.FailedOrError() && (retryStrategy.RetriesFailureClass(lastChildNode.FailureClass) ||
+			lastChildNode.FailureClass == "" && errorsutil.IsTransientErr(errors.InternalError(lastChildNode.Message))) {
 			retryOnFailed = true
 			retryOnError = true
 		}
@@ -1082,7 +1165,7 @@ func (woc *wfOperationCtx) podReconciliation(ctx context.Context) error {
 	seenPods := make(map[string]*apiv1.Pod)
 	seenPodLock := &sync.Mutex{}
 	wfNodesLock := &sync.RWMutex{}
-	podRunningCondition := wfv1.Condition{Type: wfv1.ConditionTypePodRunning, Status: metav1.ConditionFalse}
+	podRunningCondition := wfv1.Condition{Type: wfv1.ConditionTypePodRunning, Status: metav1.ConditionFalse, Reason: wfv1.ConditionReasonNoPodsRunning}
 	performAssessment := func(pod *apiv1.Pod) {
 		if pod == nil {
 			return
@@ -1101,11 +1184,16 @@ func (woc *wfOperationCtx) podReconciliation(ctx context.Context) error {
 		node, err := woc.wf.Status.Nodes.Get(nodeID)
 		if err == nil {
 			if newState := woc.assessNodeStatus(pod, node); newState != nil {
+				if !node.Fulfilled() && newState.Succeeded() && woc.controller.faultInjector.InjectPodFailure(woc.wf.Namespace) {
+					woc.log.WithField("nodeID", nodeID).Warn("Injecting fault: pod failure")
+					newState.Phase = wfv1.NodeFailed
+					newState.Message = "injected fault: pod failure"
+				}
 				woc.addOutputsToGlobalScope(newState.Outputs)
 				if newState.MemoizationStatus != nil {
 					if newState.Succeeded() {
 						c := woc.controller.cacheFactory.GetCache(controllercache.ConfigMapCache, newState.MemoizationStatus.CacheName)
-						err := c.Save(ctx, newState.MemoizationStatus.Key, newState.ID, newState.Outputs)
+						err := c.Save(ctx, newState.MemoizationStatus.Key, newState.ID, newState.Outputs, woc.wf)
 						if err != nil {
 							woc.log.WithFields(log.Fields{"nodeID": newState.ID}).WithError(err).Error("Failed to save node outputs to cache")
 							newState.Phase = wfv1.NodeError
@@ -1115,6 +1203,7 @@ func (woc *wfOperationCtx) podReconciliation(ctx context.Context) error {
 				}
 				if newState.Phase == wfv1.NodeRunning {
 					podRunningCondition.Status = metav1.ConditionTrue
+					podRunningCondition.Reason = wfv1.ConditionReasonPodsRunning
 				}
 				woc.wf.Status.Nodes.Set(nodeID, *newState)
 				woc.updated = true
@@ -1137,7 +1226,7 @@ func (woc *wfOperationCtx) podReconciliation(ctx context.Context) error {
 		go func(pod *apiv1.Pod) {
 			defer wg.Done()
 			performAssessment(pod)
-			woc.applyExecutionControl(pod, wfNodesLock)
+			woc.applyExecutionControl(ctx, pod, wfNodesLock)
 			<-parallelPodNum
 		}(pod)
 	}
@@ -1180,7 +1269,9 @@ func (woc *wfOperationCtx) podReconciliation(ctx context.Context) error {
 				node.Daemoned = nil
 				woc.updated = true
 			}
-			woc.markNodePhase(node.Name, wfv1.NodeError, "pod deleted")
+			deleted := woc.markNodePhase(node.Name, wfv1.NodeError, "pod deleted")
+			deleted.FailureClass = wfv1.NodeFailureClassNodeLost
+			woc.wf.Status.Nodes.Set(nodeID, *deleted)
 		}
 	}
 	return nil
@@ -1205,7 +1296,22 @@ func (woc *wfOperationCtx) shouldPrintPodSpec(node *wfv1.NodeStatus) bool {
 }
 
 func (woc *wfOperationCtx) failSuspendedAndPendingNodesAfterDeadlineOrShutdown() {
+	var exitHookNodeIDs map[string]bool
+	if woc.execWf.Spec.ExitHooksDeadlineSeconds != nil {
+		exitHookNodeIDs = woc.exitHookNodeIDs()
+	}
 	for _, node := range woc.wf.Status.Nodes {
+		// exit hooks are only subject to the exit hooks deadline
+		if exitHookNodeIDs[node.ID] {
+			if woc.exitHooksDeadlineExceeded() && (node.Phase == wfv1.NodePending || node.IsActiveSuspendNode()) {
+				woc.markNodePhase(node.Name, wfv1.NodeFailed, exitHooksDeadlineExceededMessage)
+			}
+			if node.Message == exitHooksDeadlineExceededMessage || (woc.exitHooksDeadlineExceeded() && !node.Fulfilled()) {
+				woc.markExitHooksNotRun("exit hooks did not complete within the exit hooks deadline")
+			}
+			continue
+		}
+
 		// fail suspended nodes when shuting down
 		if woc.GetShutdownStrategy().Enabled() && node.IsActiveSuspendNode() {
 			message := fmt.Sprintf("Stopped with strategy '%s'", woc.GetShutdownStrategy())
@@ -1216,6 +1322,9 @@ func (woc *wfOperationCtx) failSuspendedAndPendingNodesAfterDeadlineOrShutdown()
 		// fail all pending and suspended nodes when exceeding deadline
 		deadlineExceeded := woc.workflowDeadline != nil && time.Now().UTC().After(*woc.workflowDeadline)
 		if deadlineExceeded && (node.Phase == wfv1.NodePending || node.IsActiveSuspendNode()) {
+			if node.Phase == wfv1.NodePending && isExceededQuotaMessage(node.Message) {
+				woc.markStoppedByPolicy(wfv1.PolicyReasonResourceQuotaExceeded, fmt.Sprintf("%s: %s", node.DisplayName, node.Message))
+			}
 			message := "Step exceeded its deadline"
 			woc.markNodePhase(node.Name, wfv1.NodeFailed, message)
 			continue
@@ -1280,6 +1389,9 @@ func (woc *wfOperationCtx) assessNodeStatus(pod *apiv1.Pod, old *wfv1.NodeStatus
 			new.Phase = wfv1.NodeSucceeded
 		} else {
 			new.Phase, new.Message = woc.inferFailedReason(pod, tmpl)
+			if new.FailedOrError() {
+				new.FailureClass = podFailureClass(pod, tmpl)
+			}
 			woc.log.WithField("displayName", old.DisplayName).WithField("templateName", old.TemplateName).
 				WithField("pod", pod.Name).Infof("Pod failed: %s", new.Message)
 		}
@@ -1472,6 +1584,9 @@ func getLatestFinishedAt(pod *apiv1.Pod) metav1.Time {
 	return latest
 }
 
+// podReasonSchedulingGated is the reason of the PodScheduled condition of a pod with scheduling gates
+const podReasonSchedulingGated = "SchedulingGated"
+
 func getPendingReason(pod *apiv1.Pod) string {
 	for _, ctrStatus := range pod.Status.ContainerStatuses {
 		if ctrStatus.State.Waiting != nil {
@@ -1489,6 +1604,10 @@ func getPendingReason(pod *apiv1.Pod) string {
 	//   status: "False"
 	//   type: PodScheduled
 	for _, cond := range pod.Status.Conditions {
+		// a pod of a Kueue queue is gated from scheduling until Kueue admits it
+		if queue := pod.Labels[common.LabelKeyKueueQueueName]; cond.Reason == podReasonSchedulingGated && queue != "" {
+			return fmt.Sprintf("%s: waiting for admission by Kueue queue %s", cond.Reason, queue)
+		}
 		if cond.Reason == apiv1.PodReasonUnschedulable {
 			if cond.Message != "" {
 				return fmt.Sprintf("%s: %s", cond.Reason, cond.Message)
@@ -1502,6 +1621,10 @@ func getPendingReason(pod *apiv1.Pod) string {
 // inferFailedReason returns metadata about a Failed pod to be used in its NodeStatus
 // Returns a tuple of the new phase and message
 func (woc *wfOperationCtx) inferFailedReason(pod *apiv1.Pod, tmpl *wfv1.Template) (wfv1.NodePhase, string) {
+	if reason, message := podPolicyReason(pod); reason != "" {
+		// Pod was stopped by a policy, record which so that the workflow can report it.
+		return wfv1.NodeFailed, fmt.Sprintf("%s: %s", reason, message)
+	}
 	if pod.Status.Message != "" {
 		// Pod has a nice error message. Use that.
 		return wfv1.NodeFailed, pod.Status.Message
@@ -1601,6 +1724,7 @@ func (woc *wfOperationCtx) createPVCs(ctx context.Context) error {
 			pvcTmpl.ObjectMeta.Labels = make(map[string]string)
 		}
 		pvcTmpl.ObjectMeta.Labels[common.LabelKeyWorkflow] = woc.wf.ObjectMeta.Name
+		pvcTmpl.ObjectMeta.Labels[common.LabelKeyWorkflowUID] = string(woc.wf.ObjectMeta.UID)
 		pvcTmpl.OwnerReferences = []metav1.OwnerReference{
 			*metav1.NewControllerRef(woc.wf, wfv1.SchemeGroupVersion.WithKind(workflow.WorkflowKind)),
 		}
@@ -1802,6 +1926,12 @@ func (woc *wfOperationCtx) executeTemplate(ctx context.Context, nodeName string,
 		return woc.initializeNodeOrMarkError(node, nodeName, tmplCtx.GetTemplateScope(), orgTmpl, opts.boundaryID, opts.nodeFlag, ErrMaxDepthExceeded), ErrMaxDepthExceeded
 	}
 
+	if node == nil {
+		if err := woc.checkNodesGuardrail(); err != nil {
+			return woc.initializeNodeOrMarkError(node, nodeName, templateScope, orgTmpl, opts.boundaryID, opts.nodeFlag, err), err
+		}
+	}
+
 	newTmplCtx, resolvedTmpl, templateStored, err := tmplCtx.ResolveTemplate(orgTmpl)
 	if err != nil {
 		return woc.initializeNodeOrMarkError(node, nodeName, templateScope, orgTmpl, opts.boundaryID, opts.nodeFlag, err), err
@@ -1977,6 +2107,7 @@ func (woc *wfOperationCtx) executeTemplate(ctx context.Context, nodeName string,
 			node.EstimatedDuration = woc.estimateNodeDuration(node.Name)
 			woc.wf.Status.Nodes.Set(node.ID, *node)
 			woc.updated = true
+			woc.updateEstimatedFinishedAt(node.Name, node.StartedAt.Time)
 		}
 	}
 
@@ -2084,6 +2215,8 @@ func (woc *wfOperationCtx) executeTemplate(ctx context.Context, nodeName string,
 		node, err = woc.executeDAG(ctx, nodeName, newTmplCtx, templateScope, processedTmpl, orgTmpl, opts)
 	case wfv1.TemplateTypeSuspend:
 		node, err = woc.executeSuspend(nodeName, templateScope, processedTmpl, orgTmpl, opts)
+	case wfv1.TemplateTypeManual:
+		node, err = woc.executeManual(nodeName, templateScope, processedTmpl, orgTmpl, opts)
 	case wfv1.TemplateTypeData:
 		node, err = woc.executeData(ctx, nodeName, templateScope, processedTmpl, orgTmpl, opts)
 	case wfv1.TemplateTypeHTTP:
@@ -2243,11 +2376,17 @@ func (woc *wfOperationCtx) markWorkflowPhase(ctx context.Context, phase wfv1.Wor
 			woc.eventRecorder.Event(woc.wf, apiv1.EventTypeWarning, "WorkflowFailed", message)
 		}
 		markCompleted = phase.Completed()
+		if phase == wfv1.WorkflowFailed || phase == wfv1.WorkflowError {
+			woc.reconcileStoppedByPolicy()
+		}
 	}
 	if woc.wf.Status.StartedAt.IsZero() && phase != wfv1.WorkflowPending {
 		woc.updated = true
 		woc.wf.Status.StartedAt = metav1.Time{Time: time.Now().UTC()}
 		woc.wf.Status.EstimatedDuration = woc.estimateWorkflowDuration()
+		if d := woc.wf.Status.EstimatedDuration; d > 0 {
+			woc.setEstimatedFinishedAt(woc.wf.Status.StartedAt.Add(d.ToDuration()))
+		}
 	}
 	if woc.wf.Status.Message != message {
 		woc.log.Infof("Updated message %s -> %s", woc.wf.Status.Message, message)
@@ -2279,7 +2418,7 @@ func (woc *wfOperationCtx) markWorkflowPhase(ctx context.Context, phase wfv1.Wor
 				woc.wf.ObjectMeta.Labels = make(map[string]string)
 			}
 			woc.wf.ObjectMeta.Labels[common.LabelKeyCompleted] = "true"
-			woc.wf.Status.Conditions.UpsertCondition(wfv1.Condition{Status: metav1.ConditionTrue, Type: wfv1.ConditionTypeCompleted})
+			woc.wf.Status.Conditions.UpsertCondition(wfv1.Condition{Status: metav1.ConditionTrue, Type: wfv1.ConditionTypeCompleted, Reason: string(phase)})
 			err := woc.deletePDBResource(ctx)
 			if err != nil {
 				woc.wf.Status.Phase = wfv1.WorkflowError
@@ -2295,6 +2434,13 @@ func (woc *wfOperationCtx) markWorkflowPhase(ctx context.Context, phase wfv1.Wor
 					woc.log.Info("Doesn't match with archive label selector. Skipping Archive")
 				}
 			}
+			if woc.controller.Config.WorkflowExport.IsEnabled() && woc.controller.isExportable(woc.wf) {
+				woc.log.Info("Marking workflow as pending export")
+				woc.wf.Labels[common.LabelKeyWorkflowExportStatus] = "Pending"
+			}
+			if version, ok := woc.wf.Labels[common.LabelKeyWorkflowTemplateVersion]; ok && woc.wf.Spec.WorkflowTemplateRef != nil { // not-woc-misuse
+				metrics.WorkflowTemplateVersionMetric.WithLabelValues(woc.wf.Namespace, woc.wf.Spec.WorkflowTemplateRef.Name, version, string(woc.wf.Status.Phase)).Inc() // not-woc-misuse
+			}
 			woc.updated = true
 		}
 		woc.controller.queuePodForCleanup(woc.wf.Namespace, woc.getAgentPodName(), deletePod)
@@ -2317,6 +2463,23 @@ func (woc *wfOperationCtx) estimateNodeDuration(nodeName string) wfv1.EstimatedD
 	return woc.getEstimator().EstimateNodeDuration(nodeName)
 }
 
+// updateEstimatedFinishedAt estimates when the workflow will finish from how long its previous runs ran for after the
+// node started, unless none of them ran the node
+func (woc *wfOperationCtx) updateEstimatedFinishedAt(nodeName string, startedAt time.Time) {
+	if remaining := woc.getEstimator().EstimateRemainingDuration(nodeName); remaining > 0 {
+		woc.setEstimatedFinishedAt(startedAt.Add(remaining.ToDuration()))
+	}
+}
+
+func (woc *wfOperationCtx) setEstimatedFinishedAt(finishedAt time.Time) {
+	// the time is stored to the second, so only a change of a second or more is an update
+	t := metav1.NewTime(finishedAt.Truncate(time.Second))
+	if woc.wf.Status.EstimatedFinishedAt == nil || !woc.wf.Status.EstimatedFinishedAt.Equal(&t) {
+		woc.wf.Status.EstimatedFinishedAt = &t
+		woc.updated = true
+	}
+}
+
 func (woc *wfOperationCtx) hasDaemonNodes() bool {
 	for _, node := range woc.wf.Status.Nodes {
 		if node.IsDaemoned() {
@@ -2463,6 +2626,7 @@ func (woc *wfOperationCtx) initializeNode(nodeName string, nodeType wfv1.NodeTyp
 	woc.wf.Status.Nodes.Set(nodeID, node)
 	woc.log.Infof("%s node %v initialized %s%s", node.Type, node.ID, node.Phase, message)
 	woc.updated = true
+	woc.updateEstimatedFinishedAt(nodeName, node.StartedAt.Time)
 	return &node
 }
 
@@ -2641,6 +2805,10 @@ func (woc *wfOperationCtx) checkParallelism(tmpl *wfv1.Template, node *wfv1.Node
 		return ErrParallelismReached
 	}
 
+	if err := woc.checkArtifactParallelism(tmpl, node); err != nil {
+		return err
+	}
+
 	// If we are a DAG or Steps template, check if we have active pods or unsuccessful children
 	if node != nil && (tmpl.GetType() == wfv1.TemplateTypeDAG || tmpl.GetType() == wfv1.TemplateTypeSteps) {
 		// Check failFast
@@ -2928,7 +3096,7 @@ func (woc *wfOperationCtx) executeScript(ctx context.Context, nodeName string, t
 }
 
 func (woc *wfOperationCtx) requeueIfTransientErr(err error, nodeName string) (*wfv1.NodeStatus, error) {
-	if errorsutil.IsTransientErr(err) || err == ErrResourceRateLimitReached {
+	if errorsutil.IsTransientErr(err) || err == ErrResourceRateLimitReached || preflight.IsPending(err) {
 		// Our error was most likely caused by a lack of resources.
 		woc.requeue()
 		return woc.markNodePending(nodeName, err), nil
@@ -3231,6 +3399,10 @@ func (woc *wfOperationCtx) executeSuspend(nodeName string, templateScope string,
 	if err != nil {
 		node = woc.initializeExecutableNode(nodeName, wfv1.NodeTypeSuspend, templateScope, tmpl, orgTmpl, opts.boundaryID, wfv1.NodePending, opts.nodeFlag)
 		woc.resolveInputFieldsForSuspendNode(node)
+		if tmpl.Suspend.Approvals > 0 {
+			node.Approval = &wfv1.ApprovalStatus{Required: tmpl.Suspend.Approvals}
+			woc.wf.Status.Nodes.Set(node.ID, *node)
+		}
 	}
 	woc.log.Infof("node %s suspended", nodeName)
 
@@ -3274,6 +3446,50 @@ func (woc *wfOperationCtx) executeSuspend(nodeName string, templateScope string,
 	return node, nil
 }
 
+// manualTaskOverdueMessage is the message of a manual task node that has not been completed by its due date
+const manualTaskOverdueMessage = "manual task is overdue"
+
+// executeManual waits for a manual task to be completed. The node is a suspend node, so it is completed like one,
+// e.g. by `argo node complete`, but it is not resumed with the rest of the workflow.
+func (woc *wfOperationCtx) executeManual(nodeName string, templateScope string, tmpl *wfv1.Template, orgTmpl wfv1.TemplateReferenceHolder, opts *executeTemplateOpts) (*wfv1.NodeStatus, error) {
+	node, err := woc.wf.GetNodeByName(nodeName)
+	if err != nil {
+		node = woc.initializeExecutableNode(nodeName, wfv1.NodeTypeSuspend, templateScope, tmpl, orgTmpl, opts.boundaryID, wfv1.NodePending, opts.nodeFlag)
+		woc.resolveInputFieldsForSuspendNode(node)
+		node.ManualTask = &wfv1.ManualTaskStatus{
+			Instructions: tmpl.Manual.Instructions,
+			Assignees:    tmpl.Manual.Assignees,
+		}
+		if tmpl.Manual.Due != "" {
+			due, err := tmpl.Manual.GetDue()
+			if err != nil {
+				return node, err
+			}
+			node.ManualTask.DueAt = &metav1.Time{Time: node.StartedAt.Add(due)}
+		}
+		woc.wf.Status.Nodes.Set(node.ID, *node)
+	}
+	woc.log.WithField("assignees", tmpl.Manual.Assignees).Infof("node %s waiting for manual task", nodeName)
+
+	if workflowDeadline := woc.getWorkflowDeadline(); workflowDeadline != nil {
+		woc.requeueAfter(time.Until(*workflowDeadline))
+	}
+
+	// an overdue task is not failed, but it is reported in the node message and with an event, so that it can be chased
+	var message []string
+	if node.ManualTask.IsOverdue(time.Now()) {
+		message = []string{manualTaskOverdueMessage}
+		if node.Message != manualTaskOverdueMessage {
+			woc.eventRecorder.Event(woc.wf, apiv1.EventTypeWarning, "ManualTaskOverdue", fmt.Sprintf("Manual task %s is overdue", nodeName))
+		}
+	} else if node.ManualTask != nil && node.ManualTask.DueAt != nil {
+		woc.requeueAfter(time.Until(node.ManualTask.DueAt.Time))
+	}
+
+	_ = woc.markNodePhase(nodeName, wfv1.NodeRunning, message...)
+	return node, nil
+}
+
 func (woc *wfOperationCtx) resolveInputFieldsForSuspendNode(node *wfv1.NodeStatus) {
 	if node.Inputs == nil {
 		return
@@ -3302,7 +3518,7 @@ func (woc *wfOperationCtx) resolveInputFieldsForSuspendNode(node *wfv1.NodeStatu
 }
 
 func addRawOutputFields(node *wfv1.NodeStatus, tmpl *wfv1.Template) *wfv1.NodeStatus {
-	if tmpl.GetType() != wfv1.TemplateTypeSuspend || node.Type != wfv1.NodeTypeSuspend {
+	if (tmpl.GetType() != wfv1.TemplateTypeSuspend && tmpl.GetType() != wfv1.TemplateTypeManual) || node.Type != wfv1.NodeTypeSuspend {
 		panic("addRawOutputFields should only be used for nodes and templates of type suspend")
 	}
 	for _, param := range tmpl.Outputs.Parameters {
@@ -3313,6 +3529,15 @@ func addRawOutputFields(node *wfv1.NodeStatus, tmpl *wfv1.Template) *wfv1.NodeSt
 			node.Outputs.Parameters = append(node.Outputs.Parameters, param)
 		}
 	}
+	// artifacts with neither a location nor a source are supplied by setting their keys, e.g. `argo node set`
+	for _, art := range tmpl.Outputs.Artifacts {
+		if art.From == "" && art.FromExpression == "" && !art.HasLocation() {
+			if node.Outputs == nil {
+				node.Outputs = &wfv1.Outputs{}
+			}
+			node.Outputs.Artifacts = append(node.Outputs.Artifacts, art)
+		}
+	}
 	return node
 }
 
@@ -3330,51 +3555,15 @@ func parseStringToDuration(durationString string) (time.Duration, error) {
 }
 
 func processItem(tmpl template.Template, name string, index int, item wfv1.Item, obj interface{}, whenCondition string) (string, error) {
-	replaceMap := make(map[string]string)
-	var newName string
-
-	switch item.GetType() {
-	case wfv1.String, wfv1.Number, wfv1.Bool:
-		replaceMap["item"] = fmt.Sprintf("%v", item)
-		newName = generateNodeName(name, index, item)
-	case wfv1.Map:
-		// Handle the case when withItems is a list of maps.
-		// vals holds stringified versions of the map items which are incorporated as part of the step name.
-		// For example if the item is: {"name": "jesse","group":"developer"}
-		// the vals would be: ["name:jesse", "group:developer"]
-		// This would eventually be part of the step name (group:developer,name:jesse)
-		vals := make([]string, 0)
-		mapVal := item.GetMapVal()
-		for itemKey, itemVal := range mapVal {
-			replaceMap[fmt.Sprintf("item.%s", itemKey)] = fmt.Sprintf("%v", itemVal)
-			vals = append(vals, fmt.Sprintf("%s:%v", itemKey, itemVal))
-
-		}
-		jsonByteVal, err := json.Marshal(mapVal)
-		if err != nil {
-			return "", errors.InternalWrapError(err)
-		}
-		replaceMap["item"] = string(jsonByteVal)
-
-		// sort the values so that the name is deterministic
-		sort.Strings(vals)
-		newName = generateNodeName(name, index, strings.Join(vals, ","))
-	case wfv1.List:
-		listVal := item.GetListVal()
-		byteVal, err := json.Marshal(listVal)
-		if err != nil {
-			return "", errors.InternalWrapError(err)
-		}
-		replaceMap["item"] = string(byteVal)
-		newName = generateNodeName(name, index, listVal)
-	default:
-		return "", errors.Errorf(errors.CodeBadRequest, "withItems[%d] expected string, number, list, or map. received: %v", index, item)
+	newName, replaceMap, err := wfutil.GetItemReplaceMap(name, index, item)
+	if err != nil {
+		return "", err
 	}
 	var newStepStr string
 	// If when is not parameterised and evaluated to false, we are not executing nor resolving artifact,
 	// we allow parameter substitution to be Unresolved
 	// The parameterised when will get handle by the task-expansion
-	proceed, err := shouldExecute(whenCondition)
+	proceed, err := wfutil.ShouldExecute(whenCondition)
 	if err == nil && !proceed {
 		newStepStr, err = tmpl.Replace(replaceMap, true)
 	} else {
@@ -3390,68 +3579,6 @@ func processItem(tmpl template.Template, name string, index int, item wfv1.Item,
 	return newName, nil
 }
 
-func generateNodeName(name string, index int, desc interface{}) string {
-	// Do not display parentheses in node name. Nodes are still guaranteed to be unique due to the index number
-	replacer := strings.NewReplacer("(", "", ")", "")
-	cleanName := replacer.Replace(fmt.Sprint(desc))
-	newName := fmt.Sprintf("%s(%d:%v)", name, index, cleanName)
-	if out := util.RecoverIndexFromNodeName(newName); out != index {
-		panic(fmt.Sprintf("unrecoverable digit in generateName; wanted '%d' and got '%d'", index, out))
-	}
-	return newName
-}
-
-func expandSequence(seq *wfv1.Sequence) ([]wfv1.Item, error) {
-	var start, end int
-	var err error
-	if seq.Start != nil {
-		start, err = strconv.Atoi(seq.Start.String())
-		if err != nil {
-			return nil, err
-		}
-	}
-	if seq.End != nil {
-		end, err = strconv.Atoi(seq.End.String())
-		if err != nil {
-			return nil, err
-		}
-	} else if seq.Count != nil {
-		count, err := strconv.Atoi(seq.Count.String())
-		if err != nil {
-			return nil, err
-		}
-		if count == 0 {
-			return []wfv1.Item{}, nil
-		}
-		end = start + count - 1
-	} else {
-		return nil, errors.InternalError("neither end nor count was specified in withSequence")
-	}
-	items := make([]wfv1.Item, 0)
-	format := "%d"
-	if seq.Format != "" {
-		format = seq.Format
-	}
-	if start <= end {
-		for i := start; i <= end; i++ {
-			item, err := wfv1.ParseItem(`"` + fmt.Sprintf(format, i) + `"`)
-			if err != nil {
-				return nil, err
-			}
-			items = append(items, item)
-		}
-	} else {
-		for i := start; i >= end; i-- {
-			item, err := wfv1.ParseItem(`"` + fmt.Sprintf(format, i) + `"`)
-			if err != nil {
-				return nil, err
-			}
-			items = append(items, item)
-		}
-	}
-	return items, nil
-}
-
 func (woc *wfOperationCtx) substituteParamsInVolumes(params map[string]string) error {
 	if woc.volumes == nil {
 		return nil
@@ -3534,7 +3661,7 @@ func (woc *wfOperationCtx) computeMetrics(metricList []*wfv1.Prometheus, localSc
 		metricTmpl.Labels = metricTmplSubstituted.Labels
 		metricTmpl.When = metricTmplSubstituted.When
 
-		proceed, err := shouldExecute(metricTmpl.When)
+		proceed, err := wfutil.ShouldExecute(metricTmpl.When)
 		if err != nil {
 			woc.reportMetricEmissionError(fmt.Sprintf("unable to compute 'when' clause for metric '%s': %s", woc.wf.ObjectMeta.Name, err))
 			continue
@@ -3622,8 +3749,42 @@ func (woc *wfOperationCtx) reportMetricEmissionError(errorString string) {
 	woc.log.Error(errorString)
 }
 
+// podDisruptionBudget returns the spec of the workflow's pod disruption budget, or nil if it does not need one
+func (woc *wfOperationCtx) podDisruptionBudget() *policyv1.PodDisruptionBudgetSpec {
+	critical := woc.hasCriticalTemplates()
+	if woc.execWf.Spec.PodDisruptionBudget == nil && !critical {
+		return nil
+	}
+	pdbSpec := policyv1.PodDisruptionBudgetSpec{}
+	if woc.execWf.Spec.PodDisruptionBudget != nil {
+		pdbSpec = *woc.execWf.Spec.PodDisruptionBudget.DeepCopy()
+	} else {
+		maxUnavailable := k8sintstr.FromInt(0)
+		pdbSpec.MaxUnavailable = &maxUnavailable
+	}
+	if pdbSpec.Selector == nil {
+		pdbSpec.Selector = &metav1.LabelSelector{
+			MatchLabels: map[string]string{common.LabelKeyWorkflow: woc.wf.Name},
+		}
+		if critical {
+			pdbSpec.Selector.MatchLabels[common.LabelKeyCritical] = "true"
+		}
+	}
+	return &pdbSpec
+}
+
+func (woc *wfOperationCtx) hasCriticalTemplates() bool {
+	for _, tmpl := range woc.execWf.Spec.Templates {
+		if tmpl.Critical {
+			return true
+		}
+	}
+	return false
+}
+
 func (woc *wfOperationCtx) createPDBResource(ctx context.Context) error {
-	if woc.execWf.Spec.PodDisruptionBudget == nil {
+	pdbSpec := woc.podDisruptionBudget()
+	if pdbSpec == nil {
 		return nil
 	}
 
@@ -3640,13 +3801,6 @@ func (woc *wfOperationCtx) createPDBResource(ctx context.Context) error {
 		return nil
 	}
 
-	pdbSpec := *woc.execWf.Spec.PodDisruptionBudget
-	if pdbSpec.Selector == nil {
-		pdbSpec.Selector = &metav1.LabelSelector{
-			MatchLabels: map[string]string{common.LabelKeyWorkflow: woc.wf.Name},
-		}
-	}
-
 	newPDB := policyv1.PodDisruptionBudget{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:   woc.wf.Name,
@@ -3655,7 +3809,7 @@ func (woc *wfOperationCtx) createPDBResource(ctx context.Context) error {
 				*metav1.NewControllerRef(woc.wf, wfv1.SchemeGroupVersion.WithKind(workflow.WorkflowKind)),
 			},
 		},
-		Spec: pdbSpec,
+		Spec: *pdbSpec,
 	}
 	_, err = woc.controller.kubeclientset.PolicyV1().PodDisruptionBudgets(woc.wf.Namespace).Create(ctx, &newPDB, metav1.CreateOptions{})
 	if err != nil {
@@ -3667,7 +3821,7 @@ func (woc *wfOperationCtx) createPDBResource(ctx context.Context) error {
 }
 
 func (woc *wfOperationCtx) deletePDBResource(ctx context.Context) error {
-	if woc.execWf.Spec.PodDisruptionBudget == nil {
+	if woc.podDisruptionBudget() == nil {
 		return nil
 	}
 	err := waitutil.Backoff(retry.DefaultRetry, func() (bool, error) {
@@ -3725,7 +3879,7 @@ func (woc *wfOperationCtx) fetchWorkflowSpec() (wfv1.WorkflowSpecHolder, error)
 		}
 		specHolder, err = woc.controller.cwftmplInformer.Lister().Get(woc.wf.Spec.WorkflowTemplateRef.Name) // not-woc-misuse
 	} else {
-		specHolder, err = woc.controller.wftmplInformer.Lister().WorkflowTemplates(woc.wf.Namespace).Get(woc.wf.Spec.WorkflowTemplateRef.Name) // not-woc-misuse
+		specHolder, err = woc.getWorkflowTemplate(woc.wf.Spec.WorkflowTemplateRef.Name) // not-woc-misuse
 	}
 	if err != nil {
 		return nil, err
@@ -3751,6 +3905,7 @@ func (woc *wfOperationCtx) setExecWorkflow(ctx context.Context) error {
 		woc.volumes = woc.execWf.Spec.DeepCopy().Volumes
 	} else if woc.controller.Config.WorkflowRestrictions.MustUseReference() {
 		err := fmt.Errorf("workflows must use workflowTemplateRef to be executed when the controller is in reference mode")
+		woc.markStoppedByPolicy(wfv1.PolicyReasonWorkflowRestricted, err.Error())
 		woc.markWorkflowError(ctx, err)
 		return err
 	} else {
@@ -3782,6 +3937,11 @@ func (woc *wfOperationCtx) setExecWorkflow(ctx context.Context) error {
 			woc.markWorkflowFailed(ctx, msg)
 			return err
 		}
+		if err := woc.controller.Config.Guardrails.CheckActiveDeadlineSeconds(woc.execWf.Spec.ActiveDeadlineSeconds); err != nil {
+			woc.markStoppedByPolicy(wfv1.PolicyReasonGuardrailExceeded, err.Error())
+			woc.markWorkflowFailed(ctx, err.Error())
+			return err
+		}
 	}
 	err := woc.setGlobalParameters(woc.execWf.Spec.Arguments)
 	if err != nil {
@@ -3835,7 +3995,10 @@ func (woc *wfOperationCtx) needsStoredWfSpecUpdate() bool {
 }
 
 func (woc *wfOperationCtx) setStoredWfSpec() error {
-	wfDefault := woc.controller.Config.WorkflowDefaults
+	wfDefault, err := woc.controller.getWorkflowDefaults(woc.wf.Namespace)
+	if err != nil {
+		return err
+	}
 	if wfDefault == nil {
 		wfDefault = &wfv1.Workflow{}
 	}
@@ -3871,7 +4034,9 @@ func (woc *wfOperationCtx) setStoredWfSpec() error {
 			return err
 		}
 		if mergedWf.Spec.String() != woc.wf.Status.StoredWorkflowSpec.String() {
-			return fmt.Errorf("WorkflowSpec may not change during execution when the controller is set `templateReferencing: Secure`")
+			err := fmt.Errorf("WorkflowSpec may not change during execution when the controller is set `templateReferencing: Secure`")
+			woc.markStoppedByPolicy(wfv1.PolicyReasonWorkflowRestricted, err.Error())
+			return err
 		}
 	}
 	return nil
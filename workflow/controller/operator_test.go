@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"github.com/argoproj/pkg/strftime"
+	"github.com/go-jose/go-jose/v3/jwt"
 	"github.com/prometheus/client_golang/prometheus"
 	dto "github.com/prometheus/client_model/go"
 	"github.com/stretchr/testify/assert"
@@ -34,6 +35,9 @@ import (
 	"github.com/argoproj/argo-workflows/v3/config"
 	"github.com/argoproj/argo-workflows/v3/pkg/apis/workflow"
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/server/auth"
+	"github.com/argoproj/argo-workflows/v3/server/auth/types"
+	"github.com/argoproj/argo-workflows/v3/util/faultinjection"
 	intstrutil "github.com/argoproj/argo-workflows/v3/util/intstr"
 	"github.com/argoproj/argo-workflows/v3/util/template"
 	"github.com/argoproj/argo-workflows/v3/workflow/common"
@@ -227,6 +231,9 @@ status:
 	assert.Equal(t, wfv1.EstimatedDuration(1), woc.wf.Status.EstimatedDuration)
 	assert.Equal(t, wfv1.EstimatedDuration(1), woc.wf.Status.Nodes[woc.wf.Name].EstimatedDuration)
 	assert.Equal(t, wfv1.EstimatedDuration(1), woc.wf.Status.Nodes.FindByDisplayName("pod").EstimatedDuration)
+	if assert.NotNil(t, woc.wf.Status.EstimatedFinishedAt) {
+		assert.False(t, woc.wf.Status.EstimatedFinishedAt.Before(&woc.wf.Status.StartedAt))
+	}
 }
 
 func TestDefaultProgress(t *testing.T) {
@@ -2014,7 +2021,7 @@ func TestSuspendResume(t *testing.T) {
 	assert.Equal(t, 0, len(pods.Items))
 
 	// resume the workflow and operate again. two pods should be able to be scheduled
-	err = util.ResumeWorkflow(ctx, wfcset, controller.hydrator, wf.ObjectMeta.Name, "")
+	err = util.ResumeWorkflow(ctx, wfcset, controller.hydrator, wf.ObjectMeta.Name, "", "", util.ApprovalOpts{})
 	assert.NoError(t, err)
 	wf, err = wfcset.Get(ctx, wf.ObjectMeta.Name, metav1.GetOptions{})
 	assert.NoError(t, err)
@@ -2387,7 +2394,7 @@ func TestSuspendTemplate(t *testing.T) {
 	assert.Equal(t, 0, len(pods.Items))
 
 	// resume the workflow. verify resume workflow edits nodestatus correctly
-	err = util.ResumeWorkflow(ctx, wfcset, controller.hydrator, wf.ObjectMeta.Name, "")
+	err = util.ResumeWorkflow(ctx, wfcset, controller.hydrator, wf.ObjectMeta.Name, "", "", util.ApprovalOpts{})
 	assert.NoError(t, err)
 	wf, err = wfcset.Get(ctx, wf.ObjectMeta.Name, metav1.GetOptions{})
 	assert.NoError(t, err)
@@ -2401,6 +2408,168 @@ func TestSuspendTemplate(t *testing.T) {
 	assert.Equal(t, 1, len(pods.Items))
 }
 
+var manualTemplate = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  name: manual-template
+spec:
+  entrypoint: main
+  templates:
+  - name: main
+    steps:
+    - - name: review
+        template: review
+        arguments:
+          parameters:
+          - name: change
+            value: CHG-1
+    - - name: release
+        template: whalesay
+
+  - name: review
+    inputs:
+      parameters:
+      - name: change
+    manual:
+      instructions: Review {{inputs.parameters.change}}
+      assignees: [release-managers]
+      due: 1h
+
+  - name: whalesay
+    container:
+      image: docker/whalesay:latest
+`
+
+func TestManualTemplate(t *testing.T) {
+	cancel, controller := newController()
+	defer cancel()
+	wfcset := controller.wfclientset.ArgoprojV1alpha1().Workflows("")
+
+	ctx := context.Background()
+	wf := wfv1.MustUnmarshalWorkflow(manualTemplate)
+	wf, err := wfcset.Create(ctx, wf, metav1.CreateOptions{})
+	assert.NoError(t, err)
+	woc := newWorkflowOperationCtx(wf, controller)
+	woc.operate(ctx)
+	wf, err = wfcset.Get(ctx, wf.ObjectMeta.Name, metav1.GetOptions{})
+	assert.NoError(t, err)
+	node := wf.Status.Nodes.FindByDisplayName("review")
+	if assert.NotNil(t, node) && assert.NotNil(t, node.ManualTask) {
+		assert.Equal(t, wfv1.NodeTypeSuspend, node.Type)
+		assert.Equal(t, wfv1.NodeRunning, node.Phase)
+		assert.Equal(t, "Review CHG-1", node.ManualTask.Instructions)
+		assert.Equal(t, []string{"release-managers"}, node.ManualTask.Assignees)
+		if assert.NotNil(t, node.ManualTask.DueAt) {
+			assert.Equal(t, node.StartedAt.Add(time.Hour), node.ManualTask.DueAt.Time)
+		}
+		assert.Empty(t, node.Message)
+	}
+
+	// an overdue task keeps running, but is reported
+	node.ManualTask.DueAt = &metav1.Time{Time: time.Now().Add(-time.Minute)}
+	wf.Status.Nodes.Set(node.ID, *node)
+	wf, err = wfcset.Update(ctx, wf, metav1.UpdateOptions{})
+	assert.NoError(t, err)
+	events := controller.eventRecorderManager.(*testEventRecorderManager).eventRecorder.Events
+	for len(events) > 0 {
+		<-events
+	}
+	woc = newWorkflowOperationCtx(wf, controller)
+	woc.operate(ctx)
+	wf, err = wfcset.Get(ctx, wf.ObjectMeta.Name, metav1.GetOptions{})
+	assert.NoError(t, err)
+	node = wf.Status.Nodes.FindByDisplayName("review")
+	assert.Equal(t, wfv1.NodeRunning, node.Phase)
+	assert.Equal(t, manualTaskOverdueMessage, node.Message)
+	var recorded []string
+	for len(events) > 0 {
+		recorded = append(recorded, <-events)
+	}
+	assert.Contains(t, recorded, "Warning ManualTaskOverdue Manual task "+node.Name+" is overdue")
+
+	// resuming the whole workflow does not complete manual tasks
+	err = util.ResumeWorkflow(ctx, wfcset, controller.hydrator, wf.ObjectMeta.Name, "", "", util.ApprovalOpts{})
+	assert.NoError(t, err)
+	wf, err = wfcset.Get(ctx, wf.ObjectMeta.Name, metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, wfv1.NodeRunning, wf.Status.Nodes.FindByDisplayName("review").Phase)
+
+	err = util.SetWorkflow(ctx, wfcset, controller.hydrator, wf.ObjectMeta.Name, "displayName=review", util.SetOperationValues{Phase: wfv1.NodeSucceeded})
+	assert.NoError(t, err)
+	wf, err = wfcset.Get(ctx, wf.ObjectMeta.Name, metav1.GetOptions{})
+	assert.NoError(t, err)
+	woc = newWorkflowOperationCtx(wf, controller)
+	woc.operate(ctx)
+	pods, err := listPods(woc)
+	assert.NoError(t, err)
+	assert.Len(t, pods.Items, 1)
+}
+
+var suspendTemplateWithApprovals = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  name: suspend-template-approvals
+  labels:
+    workflows.argoproj.io/creator: submitter
+spec:
+  entrypoint: main
+  templates:
+  - name: main
+    steps:
+    - - name: approve
+        template: approve
+    - - name: release
+        template: whalesay
+
+  - name: approve
+    suspend:
+      approvals: 2
+
+  - name: whalesay
+    container:
+      image: docker/whalesay:latest
+`
+
+func TestSuspendTemplateWithApprovals(t *testing.T) {
+	cancel, controller := newController()
+	defer cancel()
+	wfcset := controller.wfclientset.ArgoprojV1alpha1().Workflows("")
+
+	ctx := context.Background()
+	wf := wfv1.MustUnmarshalWorkflow(suspendTemplateWithApprovals)
+	wf, err := wfcset.Create(ctx, wf, metav1.CreateOptions{})
+	assert.NoError(t, err)
+	woc := newWorkflowOperationCtx(wf, controller)
+	woc.operate(ctx)
+	wf, err = wfcset.Get(ctx, wf.ObjectMeta.Name, metav1.GetOptions{})
+	assert.NoError(t, err)
+	node := wf.Status.Nodes.FindByDisplayName("approve")
+	if assert.NotNil(t, node) && assert.NotNil(t, node.Approval) {
+		assert.Equal(t, wfv1.NodeRunning, node.Phase)
+		assert.Equal(t, int32(2), node.Approval.Required)
+		assert.Empty(t, node.Approval.Approvals)
+	}
+
+	for _, subject := range []string{"alice", "bob"} {
+		userCtx := context.WithValue(ctx, auth.ClaimsKey, &types.Claims{Claims: jwt.Claims{Subject: subject}})
+		err = util.ResumeWorkflow(userCtx, wfcset, controller.hydrator, wf.ObjectMeta.Name, "displayName=approve", "", util.ApprovalOpts{AllowUnknownCreator: true})
+		assert.NoError(t, err)
+		wf, err = wfcset.Get(ctx, wf.ObjectMeta.Name, metav1.GetOptions{})
+		assert.NoError(t, err)
+		woc = newWorkflowOperationCtx(wf, controller)
+		woc.operate(ctx)
+		pods, err := listPods(woc)
+		assert.NoError(t, err)
+		if subject == "alice" {
+			assert.Empty(t, pods.Items)
+		} else {
+			assert.Len(t, pods.Items, 1)
+		}
+	}
+}
+
 func TestSuspendTemplateWithFailedResume(t *testing.T) {
 	cancel, controller := newController()
 	defer cancel()
@@ -2464,7 +2633,7 @@ func TestSuspendTemplateWithFilteredResume(t *testing.T) {
 	assert.Equal(t, 0, len(pods.Items))
 
 	// resume the workflow, but with non-matching selector
-	err = util.ResumeWorkflow(ctx, wfcset, controller.hydrator, wf.ObjectMeta.Name, "inputs.paramaters.param1.value=value2")
+	err = util.ResumeWorkflow(ctx, wfcset, controller.hydrator, wf.ObjectMeta.Name, "inputs.paramaters.param1.value=value2", "", util.ApprovalOpts{})
 	assert.Error(t, err)
 
 	// operate the workflow. nothing should have happened
@@ -2476,7 +2645,7 @@ func TestSuspendTemplateWithFilteredResume(t *testing.T) {
 	assert.True(t, util.IsWorkflowSuspended(wf))
 
 	// resume the workflow, but with matching selector
-	err = util.ResumeWorkflow(ctx, wfcset, controller.hydrator, wf.ObjectMeta.Name, "inputs.parameters.param1.value=value1")
+	err = util.ResumeWorkflow(ctx, wfcset, controller.hydrator, wf.ObjectMeta.Name, "inputs.parameters.param1.value=value1", "", util.ApprovalOpts{})
 	assert.NoError(t, err)
 	wf, err = wfcset.Get(ctx, wf.ObjectMeta.Name, metav1.GetOptions{})
 	assert.NoError(t, err)
@@ -2747,78 +2916,6 @@ func TestGlobalParamSubstitutionWithArtifact(t *testing.T) {
 	assert.Equal(t, len(pods.Items), 1)
 }
 
-func TestExpandWithSequence(t *testing.T) {
-	var seq wfv1.Sequence
-	var items []wfv1.Item
-	var err error
-
-	seq = wfv1.Sequence{
-		Count: intstrutil.ParsePtr("10"),
-	}
-	items, err = expandSequence(&seq)
-	assert.NoError(t, err)
-	assert.Equal(t, 10, len(items))
-	assert.Equal(t, "0", items[0].GetStrVal())
-	assert.Equal(t, "9", items[9].GetStrVal())
-
-	seq = wfv1.Sequence{
-		Start: intstrutil.ParsePtr("101"),
-		Count: intstrutil.ParsePtr("10"),
-	}
-	items, err = expandSequence(&seq)
-	assert.NoError(t, err)
-	assert.Equal(t, 10, len(items))
-	assert.Equal(t, "101", items[0].GetStrVal())
-	assert.Equal(t, "110", items[9].GetStrVal())
-
-	seq = wfv1.Sequence{
-		Start: intstrutil.ParsePtr("50"),
-		End:   intstrutil.ParsePtr("60"),
-	}
-	items, err = expandSequence(&seq)
-	assert.NoError(t, err)
-	assert.Equal(t, 11, len(items))
-	assert.Equal(t, "50", items[0].GetStrVal())
-	assert.Equal(t, "60", items[10].GetStrVal())
-
-	seq = wfv1.Sequence{
-		Start: intstrutil.ParsePtr("60"),
-		End:   intstrutil.ParsePtr("50"),
-	}
-	items, err = expandSequence(&seq)
-	assert.NoError(t, err)
-	assert.Equal(t, 11, len(items))
-	assert.Equal(t, "60", items[0].GetStrVal())
-	assert.Equal(t, "50", items[10].GetStrVal())
-
-	seq = wfv1.Sequence{
-		Count: intstrutil.ParsePtr("0"),
-	}
-	items, err = expandSequence(&seq)
-	assert.NoError(t, err)
-	assert.Equal(t, 0, len(items))
-
-	seq = wfv1.Sequence{
-		Start: intstrutil.ParsePtr("8"),
-		End:   intstrutil.ParsePtr("8"),
-	}
-	items, err = expandSequence(&seq)
-	assert.NoError(t, err)
-	assert.Equal(t, 1, len(items))
-	assert.Equal(t, "8", items[0].GetStrVal())
-
-	seq = wfv1.Sequence{
-		Format: "testuser%02X",
-		Count:  intstrutil.ParsePtr("10"),
-		Start:  intstrutil.ParsePtr("1"),
-	}
-	items, err = expandSequence(&seq)
-	assert.NoError(t, err)
-	assert.Equal(t, 10, len(items))
-	assert.Equal(t, "testuser01", items[0].GetStrVal())
-	assert.Equal(t, "testuser0A", items[9].GetStrVal())
-}
-
 var metadataTemplate = `
 apiVersion: argoproj.io/v1alpha1
 kind: Workflow
@@ -3829,6 +3926,43 @@ func TestPDBCreation(t *testing.T) {
 	assert.Equal(t, pdb.Name, wf.Name)
 }
 
+var pdbCriticalWf = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  name: my-pdb-wf
+spec:
+  entrypoint: main
+  templates:
+  - name: main
+    critical: true
+    container:
+      image: docker/whalesay:latest
+`
+
+func TestPDBCriticalTemplates(t *testing.T) {
+	wf := wfv1.MustUnmarshalWorkflow(pdbCriticalWf)
+	cancel, controller := newController(wf)
+	defer cancel()
+	ctx := context.Background()
+
+	woc := newWorkflowOperationCtx(wf, controller)
+	woc.operate(ctx)
+	pdb, err := controller.kubeclientset.PolicyV1().PodDisruptionBudgets("").Get(ctx, woc.wf.Name, metav1.GetOptions{})
+	if assert.NoError(t, err) {
+		assert.Equal(t, 0, pdb.Spec.MaxUnavailable.IntValue())
+		assert.Equal(t, map[string]string{common.LabelKeyWorkflow: wf.Name, common.LabelKeyCritical: "true"}, pdb.Spec.Selector.MatchLabels)
+	}
+	pods, err := listPods(woc)
+	assert.NoError(t, err)
+	if assert.Len(t, pods.Items, 1) {
+		assert.Equal(t, "true", pods.Items[0].Labels[common.LabelKeyCritical])
+	}
+	woc.markWorkflowSuccess(ctx)
+	_, err = controller.kubeclientset.PolicyV1().PodDisruptionBudgets("").Get(ctx, woc.wf.Name, metav1.GetOptions{})
+	assert.True(t, apierr.IsNotFound(err))
+}
+
 func TestPDBCreationRaceDelete(t *testing.T) {
 	wf := wfv1.MustUnmarshalWorkflow(pdbwf)
 	cancel, controller := newController(wf)
@@ -4838,17 +4972,6 @@ func TestNoOnExitWhenSkipped(t *testing.T) {
 	assert.Error(t, err)
 }
 
-func TestGenerateNodeName(t *testing.T) {
-	assert.Equal(t, "sleep(10:ten)", generateNodeName("sleep", 10, "ten"))
-	item, err := wfv1.ParseItem(`[{"foo": "bar"}]`)
-	assert.NoError(t, err)
-	assert.Equal(t, `sleep(10:[{"foo":"bar"}])`, generateNodeName("sleep", 10, item))
-	assert.NoError(t, err)
-	item, err = wfv1.ParseItem("[10]")
-	assert.NoError(t, err)
-	assert.Equal(t, `sleep(10:[10])`, generateNodeName("sleep", 10, item))
-}
-
 // This tests that we don't wait a backoff if it would exceed the maxDuration anyway.
 func TestPanicMetric(t *testing.T) {
 	wf := wfv1.MustUnmarshalWorkflow(noOnExitWhenSkipped)
@@ -5076,14 +5199,14 @@ spec:
 	woc.operate(ctx)
 
 	assert.Equal(t, wfv1.WorkflowRunning, woc.wf.Status.Phase)
-	assert.Equal(t, wfv1.Conditions{{Type: wfv1.ConditionTypePodRunning, Status: metav1.ConditionFalse}}, woc.wf.Status.Conditions)
+	assert.Equal(t, wfv1.Conditions{{Type: wfv1.ConditionTypePodRunning, Status: metav1.ConditionFalse, Reason: wfv1.ConditionReasonNoPodsRunning}}, withoutLastTransitionTimes(t, woc.wf.Status.Conditions))
 
 	makePodsPhase(ctx, woc, apiv1.PodRunning)
 	woc = newWorkflowOperationCtx(woc.wf, controller)
 	woc.operate(ctx)
 
 	assert.Equal(t, wfv1.WorkflowRunning, woc.wf.Status.Phase)
-	assert.Equal(t, wfv1.Conditions{{Type: wfv1.ConditionTypePodRunning, Status: metav1.ConditionTrue}}, woc.wf.Status.Conditions)
+	assert.Equal(t, wfv1.Conditions{{Type: wfv1.ConditionTypePodRunning, Status: metav1.ConditionTrue, Reason: wfv1.ConditionReasonPodsRunning}}, withoutLastTransitionTimes(t, woc.wf.Status.Conditions))
 
 	makePodsPhase(ctx, woc, apiv1.PodSucceeded)
 	woc = newWorkflowOperationCtx(woc.wf, controller)
@@ -5091,9 +5214,9 @@ spec:
 
 	assert.Equal(t, wfv1.WorkflowSucceeded, woc.wf.Status.Phase)
 	assert.Equal(t, wfv1.Conditions{
-		{Type: wfv1.ConditionTypePodRunning, Status: metav1.ConditionFalse},
-		{Type: wfv1.ConditionTypeCompleted, Status: metav1.ConditionTrue},
-	}, woc.wf.Status.Conditions)
+		{Type: wfv1.ConditionTypePodRunning, Status: metav1.ConditionFalse, Reason: wfv1.ConditionReasonNoPodsRunning},
+		{Type: wfv1.ConditionTypeCompleted, Status: metav1.ConditionTrue, Reason: string(wfv1.WorkflowSucceeded)},
+	}, withoutLastTransitionTimes(t, woc.wf.Status.Conditions))
 }
 
 var workflowCached = `
@@ -6892,6 +7015,38 @@ func TestWorkflowScheduledTimeVariable(t *testing.T) {
 	assert.Equal(t, "2006-01-02T15:04:05-07:00", woc.globalParams[common.GlobalVarWorkflowCronScheduleTime])
 }
 
+var wfLastSuccessfulRunOutputsVariable = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  name: hello-world
+  annotations:
+    workflows.argoproj.io/last-successful-run-outputs: '[{"name":"watermark","value":"2023-01-01"}]'
+spec:
+  entrypoint: whalesay
+  templates:
+  - name: whalesay
+    container:
+      image: docker/whalesay:latest
+      command: [cowsay]
+      args: ["{{workflow.lastSuccessfulRunOutputs.parameters.watermark}}"]
+`
+
+func TestWorkflowLastSuccessfulRunOutputsVariable(t *testing.T) {
+	wf := wfv1.MustUnmarshalWorkflow(wfLastSuccessfulRunOutputsVariable)
+	cancel, controller := newController(wf)
+	defer cancel()
+
+	ctx := context.Background()
+	woc := newWorkflowOperationCtx(wf, controller)
+	woc.operate(ctx)
+	pods, err := listPods(woc)
+	assert.NoError(t, err)
+	if assert.Len(t, pods.Items, 1) {
+		assert.Equal(t, []string{"2023-01-01"}, pods.Items[0].Spec.Containers[1].Args)
+	}
+}
+
 var wfMainEntrypointVariable = `
 apiVersion: argoproj.io/v1alpha1
 kind: Workflow
@@ -9742,3 +9897,77 @@ func TestGetChildNodeIdsAndLastRetriedNode(t *testing.T) {
 		assert.Equal(t, childNodes[1].ID, lastChildNode.ID)
 	})
 }
+
+func TestFaultInjectionPodFailure(t *testing.T) {
+	wf := wfv1.MustUnmarshalWorkflow(`
+metadata:
+  name: my-wf
+  namespace: my-ns
+spec:
+  entrypoint: main
+  templates:
+   - name: main
+     container:
+       image: my-image
+`)
+	cancel, controller := newController(wf)
+	defer cancel()
+	controller.Config.FaultInjection = &config.FaultInjection{Namespaces: []string{"my-ns"}, PodFailureRate: 1, ArtifactErrorRate: 0.5}
+	controller.SetFaultInjector(faultinjection.NewInjector())
+
+	ctx := context.Background()
+	woc := newWorkflowOperationCtx(wf, controller)
+	woc.operate(ctx)
+
+	pods, err := listPods(woc)
+	require.NoError(t, err)
+	require.Len(t, pods.Items, 1)
+	for _, c := range pods.Items[0].Spec.Containers {
+		if c.Name == common.WaitContainerName {
+			assert.Contains(t, c.Env, apiv1.EnvVar{Name: common.EnvVarFaultInjectionArtifactErrorRate, Value: "0.5"})
+		}
+	}
+
+	makePodsPhase(ctx, woc, apiv1.PodSucceeded)
+	woc = newWorkflowOperationCtx(woc.wf, controller)
+	woc.operate(ctx)
+
+	assert.Equal(t, wfv1.WorkflowFailed, woc.wf.Status.Phase)
+	node := woc.wf.Status.Nodes.FindByDisplayName("my-wf")
+	require.NotNil(t, node)
+	assert.Equal(t, wfv1.NodeFailed, node.Phase)
+	assert.Equal(t, "injected fault: pod failure", node.Message)
+}
+
+func TestGetPendingReasonKueue(t *testing.T) {
+	pod := &apiv1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{common.LabelKeyKueueQueueName: "my-queue"}},
+		Status: apiv1.PodStatus{
+			Phase:      apiv1.PodPending,
+			Conditions: []apiv1.PodCondition{{Type: apiv1.PodScheduled, Status: apiv1.ConditionFalse, Reason: "SchedulingGated"}},
+		},
+	}
+	assert.Equal(t, "SchedulingGated: waiting for admission by Kueue queue my-queue", getPendingReason(pod))
+
+	delete(pod.Labels, common.LabelKeyKueueQueueName)
+	assert.Empty(t, getPendingReason(pod))
+}
+
+func TestAddRawOutputFields(t *testing.T) {
+	tmpl := &wfv1.Template{
+		Name:    "review",
+		Suspend: &wfv1.SuspendTemplate{},
+		Outputs: wfv1.Outputs{
+			Parameters: []wfv1.Parameter{{Name: "approved", ValueFrom: &wfv1.ValueFrom{Supplied: &wfv1.SuppliedValueFrom{}}}},
+			Artifacts: wfv1.Artifacts{
+				{Name: "report"},
+				{Name: "from", From: "{{workflow.outputs.artifacts.x}}"},
+			},
+		},
+	}
+	node := addRawOutputFields(&wfv1.NodeStatus{Type: wfv1.NodeTypeSuspend}, tmpl)
+	if assert.NotNil(t, node.Outputs) {
+		assert.Len(t, node.Outputs.Parameters, 1)
+		assert.Equal(t, wfv1.Artifacts{{Name: "report"}}, node.Outputs.Artifacts)
+	}
+}
@@ -11,6 +11,7 @@ import (
 
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 	"github.com/argoproj/argo-workflows/v3/util"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
 )
 
 func TestWorkflowTemplateRef(t *testing.T) {
@@ -560,3 +561,61 @@ func TestSubmitWorkflowTemplateRefWithoutRBAC(t *testing.T) {
 	woc.operate(ctx)
 	assert.Equal(t, wfv1.WorkflowError, woc.wf.Status.Phase)
 }
+
+func TestWorkflowTemplateRefPrecedenceOverWorkflowDefaults(t *testing.T) {
+	ctx := context.Background()
+	newWorkflowDefaultsController := func(wf *wfv1.Workflow, wftmpl *wfv1.WorkflowTemplate) (context.CancelFunc, *WorkflowController) {
+		cancel, controller := newController(wf, wftmpl)
+		controller.Config.WorkflowDefaults = &wfv1.Workflow{
+			Spec: wfv1.WorkflowSpec{
+				ServiceAccountName: "controller",
+				TTLStrategy:        &wfv1.TTLStrategy{SecondsAfterCompletion: pointer.Int32Ptr(600)},
+				PodGC:              &wfv1.PodGC{Strategy: wfv1.PodGCOnWorkflowCompletion},
+			},
+		}
+		err := controller.configMapInformer.GetIndexer().Add(&apiv1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "workflow-defaults",
+				Labels:    map[string]string{common.LabelKeyConfigMapType: common.LabelValueTypeConfigMapWorkflowDefaults},
+			},
+			Data: map[string]string{common.ConfigMapKeyWorkflowDefaults: "spec:\n  serviceAccountName: namespace\n  ttlStrategy:\n    secondsAfterCompletion: 300\n"},
+		})
+		assert.NoError(t, err)
+		return cancel, controller
+	}
+	t.Run("WorkflowTemplate", func(t *testing.T) {
+		wf := wfv1.MustUnmarshalWorkflow(wfWithTmplRef)
+		wftmpl := wfv1.MustUnmarshalWorkflowTemplate(wfTmpl)
+		wftmpl.Spec.TTLStrategy = &wfv1.TTLStrategy{SecondsAfterCompletion: pointer.Int32Ptr(60)}
+		wftmpl.Spec.PodGC = &wfv1.PodGC{Strategy: wfv1.PodGCOnPodSuccess}
+		cancel, controller := newWorkflowDefaultsController(wf, wftmpl)
+		defer cancel()
+		woc := newWorkflowOperationCtx(wf, controller)
+		woc.operate(ctx)
+		assert.Equal(t, "my-sa", woc.execWf.Spec.ServiceAccountName)
+		assert.Equal(t, int32(60), *woc.wf.GetTTLStrategy().SecondsAfterCompletion)
+		assert.Equal(t, wfv1.PodGCOnPodSuccess, woc.execWf.Spec.PodGC.Strategy)
+	})
+	t.Run("Workflow", func(t *testing.T) {
+		wf := wfv1.MustUnmarshalWorkflow(wfWithTmplRef)
+		wf.Spec.ServiceAccountName = "workflow"
+		wftmpl := wfv1.MustUnmarshalWorkflowTemplate(wfTmpl)
+		cancel, controller := newWorkflowDefaultsController(wf, wftmpl)
+		defer cancel()
+		woc := newWorkflowOperationCtx(wf, controller)
+		woc.operate(ctx)
+		assert.Equal(t, "workflow", woc.execWf.Spec.ServiceAccountName)
+	})
+	t.Run("Defaults", func(t *testing.T) {
+		wf := wfv1.MustUnmarshalWorkflow(wfWithTmplRef)
+		wftmpl := wfv1.MustUnmarshalWorkflowTemplate(wfTmpl)
+		cancel, controller := newWorkflowDefaultsController(wf, wftmpl)
+		defer cancel()
+		woc := newWorkflowOperationCtx(wf, controller)
+		woc.operate(ctx)
+		// the namespace's defaults take precedence over the controller's
+		assert.Equal(t, int32(300), *woc.wf.GetTTLStrategy().SecondsAfterCompletion)
+		assert.Equal(t, wfv1.PodGCOnWorkflowCompletion, woc.execWf.Spec.PodGC.Strategy)
+	})
+}
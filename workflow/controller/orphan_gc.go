@@ -0,0 +1,105 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	apiv1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/argoproj/argo-workflows/v3/pkg/apis/workflow"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/util/env"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+	"github.com/argoproj/argo-workflows/v3/workflow/metrics"
+)
+
+var orphanGCPeriod = env.LookupEnvDurationOr("ORPHAN_GC_PERIOD", 5*time.Minute)
+
+// orphanGCMinAge is how old a resource must be before it is deleted as an orphan, so that a resource created for a
+// workflow the informer has not yet seen is not deleted
+const orphanGCMinAge = time.Minute
+
+// gcOrphans deletes the task sets, task results and agent pods whose workflow no longer exists, which are left behind
+// when the controller crashes before they are cleaned up, or their owner references are not honoured
+func (wfc *WorkflowController) gcOrphans(ctx context.Context) {
+	for _, obj := range wfc.wfTaskSetInformer.Informer().GetStore().List() {
+		taskSet := obj.(*wfv1.WorkflowTaskSet)
+		// a task set has the name of its workflow
+		if wfc.isOrphan(taskSet.ObjectMeta, taskSet.Name, workflowOwnerUID(taskSet.OwnerReferences)) {
+			wfc.deleteOrphan(ctx, workflow.WorkflowTaskSetKind, taskSet.ObjectMeta, func() error {
+				return wfc.wfclientset.ArgoprojV1alpha1().WorkflowTaskSets(taskSet.Namespace).Delete(ctx, taskSet.Name, metav1.DeleteOptions{})
+			})
+		}
+	}
+	for _, obj := range wfc.taskResultInformer.GetStore().List() {
+		result := obj.(*wfv1.WorkflowTaskResult)
+		// a task result is owned by its pod, so its workflow is only known by name
+		if wfc.isOrphan(result.ObjectMeta, result.Labels[common.LabelKeyWorkflow], "") {
+			wfc.deleteOrphan(ctx, workflow.WorkflowTaskResultKind, result.ObjectMeta, func() error {
+				return wfc.wfclientset.ArgoprojV1alpha1().WorkflowTaskResults(result.Namespace).Delete(ctx, result.Name, metav1.DeleteOptions{})
+			})
+		}
+	}
+	for _, obj := range wfc.podInformer.GetStore().List() {
+		pod := obj.(*apiv1.Pod)
+		if pod.Labels[common.LabelKeyComponent] != "agent" {
+			continue
+		}
+		if wfc.isOrphan(pod.ObjectMeta, pod.Labels[common.LabelKeyWorkflow], types.UID(pod.Labels[common.LabelKeyWorkflowUID])) {
+			wfc.deleteOrphan(ctx, "Pod", pod.ObjectMeta, func() error {
+				return wfc.kubeclientset.CoreV1().Pods(pod.Namespace).Delete(ctx, pod.Name, metav1.DeleteOptions{})
+			})
+		}
+	}
+}
+
+// isOrphan returns whether the resource's workflow, of the name, and of the UID unless it is empty, no longer exists
+func (wfc *WorkflowController) isOrphan(m metav1.ObjectMeta, workflowName string, workflowUID types.UID) bool {
+	if workflowName == "" || time.Since(m.CreationTimestamp.Time) < orphanGCMinAge {
+		return false
+	}
+	obj, exists, err := wfc.wfInformer.GetIndexer().GetByKey(m.Namespace + "/" + workflowName)
+	if err != nil {
+		log.WithFields(log.Fields{"namespace": m.Namespace, "workflow": workflowName}).WithError(err).Error("Failed to get workflow from informer")
+		return false
+	}
+	if !exists {
+		return true
+	}
+	if workflowUID == "" {
+		return false
+	}
+	wf, err := meta.Accessor(obj)
+	if err != nil {
+		return false
+	}
+	// a workflow of the same name that was created after the resource's workflow was deleted
+	return wf.GetUID() != workflowUID
+}
+
+func (wfc *WorkflowController) deleteOrphan(ctx context.Context, kind string, m metav1.ObjectMeta, delete func() error) {
+	logCtx := log.WithFields(log.Fields{"kind": kind, "namespace": m.Namespace, "name": m.Name})
+	if err := delete(); err != nil {
+		if !apierr.IsNotFound(err) {
+			logCtx.WithError(err).Error("Failed to delete orphan")
+		}
+		return
+	}
+	logCtx.Info("Deleted orphan, as its workflow no longer exists")
+	metrics.OrphansDeletedMetric.WithLabelValues(kind, m.Namespace).Inc()
+}
+
+// workflowOwnerUID returns the UID of the workflow of the owner references, or the empty string if there is none
+func workflowOwnerUID(refs []metav1.OwnerReference) types.UID {
+	for _, ref := range refs {
+		if ref.Kind == workflow.WorkflowKind {
+			return ref.UID
+		}
+	}
+	return ""
+}
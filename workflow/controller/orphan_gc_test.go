@@ -0,0 +1,96 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+
+	"github.com/argoproj/argo-workflows/v3/pkg/apis/workflow"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+	"github.com/argoproj/argo-workflows/v3/workflow/metrics"
+)
+
+func TestGCOrphans(t *testing.T) {
+	ctx := context.Background()
+	wf := wfv1.MustUnmarshalWorkflow(helloWorldWf)
+	wf.Namespace = "my-ns"
+	wf.UID = "my-uid"
+	old := metav1.NewTime(time.Now().Add(-time.Hour))
+	meta := func(name, workflowName string) metav1.ObjectMeta {
+		return metav1.ObjectMeta{
+			Namespace:         wf.Namespace,
+			Name:              name,
+			Labels:            map[string]string{common.LabelKeyWorkflow: workflowName},
+			CreationTimestamp: old,
+		}
+	}
+	taskSet := func(name string, uid string) *wfv1.WorkflowTaskSet {
+		m := meta(name, name)
+		m.OwnerReferences = []metav1.OwnerReference{{Kind: workflow.WorkflowKind, Name: name, UID: k8stypes.UID(uid)}}
+		return &wfv1.WorkflowTaskSet{ObjectMeta: m}
+	}
+	// of an earlier workflow of the same name
+	earlierTaskSet := taskSet(wf.Name, "earlier-uid")
+	earlierTaskSet.Name = "earlier"
+	newResult := meta("new-result", "gone")
+	newResult.CreationTimestamp = metav1.Now()
+	cancel, controller := newController(
+		wf,
+		taskSet(wf.Name, "my-uid"),
+		taskSet("gone", "gone-uid"),
+		earlierTaskSet,
+		&wfv1.WorkflowTaskResult{ObjectMeta: meta("result", wf.Name)},
+		&wfv1.WorkflowTaskResult{ObjectMeta: meta("gone-result", "gone")},
+		&wfv1.WorkflowTaskResult{ObjectMeta: newResult},
+	)
+	defer cancel()
+
+	agentPod := func(name, workflowName, uid string) {
+		m := meta(name, workflowName)
+		m.Labels[common.LabelKeyComponent] = "agent"
+		m.Labels[common.LabelKeyWorkflowUID] = uid
+		_, err := controller.kubeclientset.CoreV1().Pods(wf.Namespace).Create(ctx, &apiv1.Pod{ObjectMeta: m}, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+	agentPod("agent", wf.Name, "my-uid")
+	agentPod("gone-agent", "gone", "gone-uid")
+	agentPod("earlier-agent", wf.Name, "earlier-uid")
+	for len(controller.podInformer.GetStore().List()) < 3 {
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	before := orphansDeleted(t, "Pod", wf.Namespace)
+	controller.gcOrphans(ctx)
+
+	taskSets := controller.wfclientset.ArgoprojV1alpha1().WorkflowTaskSets(wf.Namespace)
+	for name, exists := range map[string]bool{wf.Name: true, "gone": false, "earlier": false} {
+		_, err := taskSets.Get(ctx, name, metav1.GetOptions{})
+		assert.Equal(t, !exists, apierr.IsNotFound(err), name)
+	}
+	taskResults := controller.wfclientset.ArgoprojV1alpha1().WorkflowTaskResults(wf.Namespace)
+	for name, exists := range map[string]bool{"result": true, "gone-result": false, "new-result": true} {
+		_, err := taskResults.Get(ctx, name, metav1.GetOptions{})
+		assert.Equal(t, !exists, apierr.IsNotFound(err), name)
+	}
+	pods := controller.kubeclientset.CoreV1().Pods(wf.Namespace)
+	for name, exists := range map[string]bool{"agent": true, "gone-agent": false, "earlier-agent": false} {
+		_, err := pods.Get(ctx, name, metav1.GetOptions{})
+		assert.Equal(t, !exists, apierr.IsNotFound(err), name)
+	}
+	assert.Equal(t, before+2, orphansDeleted(t, "Pod", wf.Namespace))
+}
+
+func orphansDeleted(t *testing.T, kind, namespace string) float64 {
+	var m dto.Metric
+	require.NoError(t, metrics.OrphansDeletedMetric.WithLabelValues(kind, namespace).Write(&m))
+	return m.Counter.GetValue()
+}
@@ -0,0 +1,97 @@
+package controller
+
+import (
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"github.com/argoproj/argo-workflows/v3/config"
+)
+
+const (
+	podRateLimitScopeGlobal    = "global"
+	podRateLimitScopeNamespace = "namespace"
+	podRateLimitScopeWorkflow  = "workflow"
+)
+
+// podRateLimiter limits the rate at which pods are created, globally, per namespace and per workflow.
+// Each limit is a token bucket and a pod is only created if all of them have a token available,
+// so that a single large workflow cannot starve the Kubernetes API server for everyone else.
+type podRateLimiter struct {
+	global       *rate.Limiter
+	perNamespace *config.RateLimit
+	perWorkflow  *config.RateLimit
+	// Ensures mutual exclusion in the namespaces and workflows maps
+	mutex      sync.Mutex
+	namespaces map[string]*rate.Limiter
+	workflows  map[string]*rate.Limiter
+	lastPruned time.Time
+}
+
+func newPodRateLimiter(limit config.ResourceRateLimit) *podRateLimiter {
+	return &podRateLimiter{
+		global:       rate.NewLimiter(rate.Limit(limit.Limit), limit.Burst),
+		perNamespace: limit.PerNamespace,
+		perWorkflow:  limit.PerWorkflow,
+		namespaces:   make(map[string]*rate.Limiter),
+		workflows:    make(map[string]*rate.Limiter),
+	}
+}
+
+// Allow reports whether a pod can be created for the workflow now, taking a token from each limit if so.
+// Otherwise, it returns the scope of the limit that was reached and no tokens are taken.
+func (l *podRateLimiter) Allow(namespace, workflowKey string) (bool, string) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	now := time.Now()
+	l.prune(now)
+	scopes := []string{podRateLimitScopeGlobal}
+	limiters := []*rate.Limiter{l.global}
+	if l.perNamespace != nil {
+		scopes = append(scopes, podRateLimitScopeNamespace)
+		limiters = append(limiters, getOrCreateLimiter(l.namespaces, namespace, l.perNamespace))
+	}
+	if l.perWorkflow != nil {
+		scopes = append(scopes, podRateLimitScopeWorkflow)
+		limiters = append(limiters, getOrCreateLimiter(l.workflows, workflowKey, l.perWorkflow))
+	}
+	var reservations []*rate.Reservation
+	for i, limiter := range limiters {
+		r := limiter.ReserveN(now, 1)
+		if !r.OK() || r.DelayFrom(now) > 0 {
+			r.CancelAt(now)
+			// give back the tokens taken from the other limits
+			for _, taken := range reservations {
+				taken.CancelAt(now)
+			}
+			return false, scopes[i]
+		}
+		reservations = append(reservations, r)
+	}
+	return true, ""
+}
+
+func getOrCreateLimiter(limiters map[string]*rate.Limiter, key string, limit *config.RateLimit) *rate.Limiter {
+	limiter, ok := limiters[key]
+	if !ok {
+		limiter = rate.NewLimiter(rate.Limit(limit.Limit), limit.Burst)
+		limiters[key] = limiter
+	}
+	return limiter
+}
+
+// prune forgets limiters whose bucket has refilled, as they behave the same as new ones
+func (l *podRateLimiter) prune(now time.Time) {
+	if now.Sub(l.lastPruned) < time.Minute {
+		return
+	}
+	l.lastPruned = now
+	for _, limiters := range []map[string]*rate.Limiter{l.namespaces, l.workflows} {
+		for key, limiter := range limiters {
+			if limiter.TokensAt(now) >= float64(limiter.Burst()) {
+				delete(limiters, key)
+			}
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package controller
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/argoproj/argo-workflows/v3/config"
+)
+
+func TestPodRateLimiter(t *testing.T) {
+	t.Run("Global", func(t *testing.T) {
+		l := newPodRateLimiter(config.ResourceRateLimit{Limit: 0.001, Burst: 2})
+		for i := 0; i < 2; i++ {
+			allowed, _ := l.Allow("my-ns", "wf-1")
+			assert.True(t, allowed)
+		}
+		allowed, scope := l.Allow("my-ns", "wf-2")
+		assert.False(t, allowed)
+		assert.Equal(t, "global", scope)
+	})
+	t.Run("PerNamespace", func(t *testing.T) {
+		l := newPodRateLimiter(config.ResourceRateLimit{Limit: math.MaxFloat32, Burst: math.MaxInt32, PerNamespace: &config.RateLimit{Limit: 0.001, Burst: 1}})
+		allowed, _ := l.Allow("my-ns", "wf-1")
+		assert.True(t, allowed)
+		allowed, scope := l.Allow("my-ns", "wf-2")
+		assert.False(t, allowed)
+		assert.Equal(t, "namespace", scope)
+		allowed, _ = l.Allow("other-ns", "wf-3")
+		assert.True(t, allowed)
+	})
+	t.Run("PerWorkflow", func(t *testing.T) {
+		l := newPodRateLimiter(config.ResourceRateLimit{Limit: 0.001, Burst: 2, PerWorkflow: &config.RateLimit{Limit: 0.001, Burst: 1}})
+		allowed, _ := l.Allow("my-ns", "wf-1")
+		assert.True(t, allowed)
+		allowed, scope := l.Allow("my-ns", "wf-1")
+		assert.False(t, allowed)
+		assert.Equal(t, "workflow", scope)
+		// the global token was given back, so another workflow can still create a pod
+		allowed, _ = l.Allow("my-ns", "wf-2")
+		assert.True(t, allowed)
+	})
+	t.Run("Prune", func(t *testing.T) {
+		l := newPodRateLimiter(config.ResourceRateLimit{Limit: math.MaxFloat32, Burst: math.MaxInt32, PerWorkflow: &config.RateLimit{Limit: 1000, Burst: 1}})
+		allowed, _ := l.Allow("my-ns", "wf-1")
+		assert.True(t, allowed)
+		assert.Len(t, l.workflows, 1)
+		l.prune(time.Now().Add(time.Minute))
+		assert.Empty(t, l.workflows)
+	})
+}
@@ -0,0 +1,104 @@
+package controller
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+// podDisruptionTarget is the type of the pod condition Kubernetes sets when it is about to delete a pod
+const podDisruptionTarget apiv1.PodConditionType = "DisruptionTarget"
+
+// markStoppedByPolicy sets the StoppedByPolicy condition, and the policy reason label so workflows can be filtered
+// by it. The first policy to stop the workflow is kept.
+func (woc *wfOperationCtx) markStoppedByPolicy(reason wfv1.PolicyReason, message string) {
+	if woc.wf.Status.GetPolicyReason() != "" {
+		return
+	}
+	woc.log.WithField("reason", reason).Info("Workflow stopped by policy")
+	woc.wf.Status.Conditions.UpsertCondition(wfv1.Condition{Type: wfv1.ConditionTypeStoppedByPolicy, Status: metav1.ConditionTrue, Reason: string(reason), Message: message})
+	if woc.wf.Labels == nil {
+		woc.wf.Labels = map[string]string{}
+	}
+	woc.wf.Labels[common.LabelKeyPolicyReason] = string(reason)
+	woc.updated = true
+}
+
+// reconcileStoppedByPolicy sets the StoppedByPolicy condition for a workflow that is completing unsuccessfully,
+// if it is because one of its pods was preempted or evicted, or because it exceeded its deadline
+func (woc *wfOperationCtx) reconcileStoppedByPolicy() {
+	if woc.wf.Status.GetPolicyReason() != "" || woc.GetShutdownStrategy().Enabled() {
+		return
+	}
+	if reason, message := woc.failedPodsPolicyReason(); reason != "" {
+		woc.markStoppedByPolicy(reason, message)
+		return
+	}
+	if deadline := woc.getWorkflowDeadline(); deadline != nil && time.Now().After(*deadline) {
+		woc.markStoppedByPolicy(wfv1.PolicyReasonActiveDeadlineExceeded, fmt.Sprintf("workflow exceeded its activeDeadlineSeconds of %d", *woc.getActiveDeadlineSeconds()))
+	}
+}
+
+// failedPodsPolicyReason returns the reason of the first pod that a policy stopped and that was not retried
+// successfully
+func (woc *wfOperationCtx) failedPodsPolicyReason() (wfv1.PolicyReason, string) {
+	retryNodes := make(map[string]wfv1.NodeStatus)
+	for _, node := range woc.wf.Status.Nodes {
+		if node.Type == wfv1.NodeTypeRetry {
+			for _, child := range node.Children {
+				retryNodes[child] = node
+			}
+		}
+	}
+	var nodes []wfv1.NodeStatus
+	for _, node := range woc.wf.Status.Nodes {
+		if node.Type != wfv1.NodeTypePod || !node.FailedOrError() {
+			continue
+		}
+		if retryNode, ok := retryNodes[node.ID]; ok && !retryNode.FailedOrError() {
+			continue
+		}
+		nodes = append(nodes, node)
+	}
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].FinishedAt.Before(&nodes[j].FinishedAt) })
+	for _, node := range nodes {
+		for _, reason := range []wfv1.PolicyReason{wfv1.PolicyReasonPreempted, wfv1.PolicyReasonEvicted} {
+			if strings.HasPrefix(node.Message, string(reason)+": ") {
+				return reason, fmt.Sprintf("%s: %s", node.DisplayName, strings.TrimPrefix(node.Message, string(reason)+": "))
+			}
+		}
+	}
+	return "", ""
+}
+
+// podPolicyReason returns whether the pod was preempted or evicted, and why
+func podPolicyReason(pod *apiv1.Pod) (wfv1.PolicyReason, string) {
+	for _, c := range pod.Status.Conditions {
+		if c.Type != podDisruptionTarget || c.Status != apiv1.ConditionTrue {
+			continue
+		}
+		switch c.Reason {
+		case "PreemptionByScheduler", "PreemptionByKubeScheduler":
+			return wfv1.PolicyReasonPreempted, c.Message
+		case "EvictionByEvictionAPI", "TerminationByKubelet", "DeletionByTaintManager":
+			return wfv1.PolicyReasonEvicted, c.Message
+		}
+	}
+	if pod.Status.Reason == "Evicted" {
+		return wfv1.PolicyReasonEvicted, pod.Status.Message
+	}
+	return "", ""
+}
+
+// isExceededQuotaMessage returns whether the node is pending because its pod could not be created within the namespace's
+// resource quota
+func isExceededQuotaMessage(message string) bool {
+	return strings.Contains(message, "exceeded quota")
+}
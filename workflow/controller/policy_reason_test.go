@@ -0,0 +1,116 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	"github.com/argoproj/argo-workflows/v3/config"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+var policyReasonWf = `
+metadata:
+  name: policy-reason
+  namespace: default
+spec:
+  entrypoint: main
+  templates:
+  - name: main
+    container:
+      image: argoproj/argosay:v2
+`
+
+func TestPodPolicyReason(t *testing.T) {
+	t.Run("Preempted", func(t *testing.T) {
+		reason, message := podPolicyReason(&apiv1.Pod{Status: apiv1.PodStatus{Conditions: []apiv1.PodCondition{
+			{Type: podDisruptionTarget, Status: apiv1.ConditionTrue, Reason: "PreemptionByScheduler", Message: "preempting to accommodate a higher priority pod"},
+		}}})
+		assert.Equal(t, wfv1.PolicyReasonPreempted, reason)
+		assert.Equal(t, "preempting to accommodate a higher priority pod", message)
+	})
+	t.Run("Evicted", func(t *testing.T) {
+		reason, message := podPolicyReason(&apiv1.Pod{Status: apiv1.PodStatus{Reason: "Evicted", Message: "The node was low on resource: memory."}})
+		assert.Equal(t, wfv1.PolicyReasonEvicted, reason)
+		assert.Equal(t, "The node was low on resource: memory.", message)
+	})
+	t.Run("Failed", func(t *testing.T) {
+		reason, _ := podPolicyReason(&apiv1.Pod{Status: apiv1.PodStatus{Phase: apiv1.PodFailed, Message: "Pod failed"}})
+		assert.Empty(t, reason)
+	})
+}
+
+func TestStoppedByPolicy(t *testing.T) {
+	ctx := context.Background()
+	assertStoppedByPolicy := func(t *testing.T, wf *wfv1.Workflow, reason wfv1.PolicyReason) {
+		assert.Equal(t, reason, wf.Status.GetPolicyReason())
+		assert.Equal(t, string(reason), wf.Labels[common.LabelKeyPolicyReason])
+	}
+
+	t.Run("Evicted", func(t *testing.T) {
+		wf := wfv1.MustUnmarshalWorkflow(policyReasonWf)
+		cancel, controller := newController(wf)
+		defer cancel()
+		woc := newWorkflowOperationCtx(wf, controller)
+		woc.operate(ctx)
+		makePodsPhase(ctx, woc, apiv1.PodFailed, func(pod *apiv1.Pod) {
+			pod.Status.Reason = "Evicted"
+			pod.Status.Message = "The node was low on resource: memory."
+		})
+		woc = newWorkflowOperationCtx(woc.wf, controller)
+		woc.operate(ctx)
+		assert.Equal(t, wfv1.WorkflowFailed, woc.wf.Status.Phase)
+		assertStoppedByPolicy(t, woc.wf, wfv1.PolicyReasonEvicted)
+		assert.Contains(t, withoutLastTransitionTimes(t, woc.wf.Status.Conditions), wfv1.Condition{
+			Type:    wfv1.ConditionTypeStoppedByPolicy,
+			Status:  metav1.ConditionTrue,
+			Reason:  string(wfv1.PolicyReasonEvicted),
+			Message: "policy-reason: The node was low on resource: memory.",
+		})
+	})
+
+	t.Run("Failed", func(t *testing.T) {
+		wf := wfv1.MustUnmarshalWorkflow(policyReasonWf)
+		cancel, controller := newController(wf)
+		defer cancel()
+		woc := newWorkflowOperationCtx(wf, controller)
+		woc.operate(ctx)
+		makePodsPhase(ctx, woc, apiv1.PodFailed)
+		woc = newWorkflowOperationCtx(woc.wf, controller)
+		woc.operate(ctx)
+		assert.Equal(t, wfv1.WorkflowFailed, woc.wf.Status.Phase)
+		assertStoppedByPolicy(t, woc.wf, "")
+	})
+
+	t.Run("ActiveDeadlineExceeded", func(t *testing.T) {
+		wf := wfv1.MustUnmarshalWorkflow(policyReasonWf)
+		wf.Spec.ActiveDeadlineSeconds = pointer.Int64(10)
+		cancel, controller := newController(wf)
+		defer cancel()
+		woc := newWorkflowOperationCtx(wf, controller)
+		woc.operate(ctx)
+		woc.wf.Status.StartedAt = metav1.Time{Time: time.Now().Add(-time.Minute)}
+		makePodsPhase(ctx, woc, apiv1.PodFailed)
+		woc = newWorkflowOperationCtx(woc.wf, controller)
+		woc.operate(ctx)
+		assert.Equal(t, wfv1.WorkflowFailed, woc.wf.Status.Phase)
+		assertStoppedByPolicy(t, woc.wf, wfv1.PolicyReasonActiveDeadlineExceeded)
+	})
+
+	t.Run("WorkflowRestricted", func(t *testing.T) {
+		wf := wfv1.MustUnmarshalWorkflow(policyReasonWf)
+		cancel, controller := newController(wf)
+		defer cancel()
+		controller.Config.WorkflowRestrictions = &config.WorkflowRestrictions{TemplateReferencing: config.TemplateReferencingStrict}
+		woc := newWorkflowOperationCtx(wf, controller)
+		woc.operate(ctx)
+		assert.Equal(t, wfv1.WorkflowError, woc.wf.Status.Phase)
+		assertStoppedByPolicy(t, woc.wf, wfv1.PolicyReasonWorkflowRestricted)
+	})
+}
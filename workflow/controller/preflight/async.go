@@ -0,0 +1,85 @@
+package preflight
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"k8s.io/utils/lru"
+
+	"github.com/argoproj/argo-workflows/v3/workflow/controller/entrypoint"
+)
+
+// ErrPending is returned while an image is resolved in the background, so the caller should try again later.
+var ErrPending = errors.New("image is being resolved")
+
+var (
+	// resolveTimeout bounds how long a registry may take to answer
+	resolveTimeout = 30 * time.Second
+	// digests are cached for a while, as a tag may be pushed to
+	digestTTL = 5 * time.Minute
+	// errors are cached briefly, so the workflow that is waiting for them sees them, but a fix is picked up soon
+	errorTTL = 30 * time.Second
+)
+
+// IsPending returns true if the image is still being resolved.
+func IsPending(err error) bool {
+	return errors.Is(err, ErrPending)
+}
+
+type result struct {
+	digest  string
+	err     error
+	expires time.Time
+}
+
+type asyncResolver struct {
+	delegate ImageResolver
+	results  *lru.Cache
+	lock     sync.Mutex
+	pending  map[string]bool
+}
+
+// NewAsyncImageResolver returns a resolver that never waits for a registry. The first time an image is asked for,
+// it is resolved in the background with a timeout and ErrPending is returned. The result is cached, so asking again
+// later returns it.
+func NewAsyncImageResolver(delegate ImageResolver) ImageResolver {
+	return &asyncResolver{delegate: delegate, results: lru.New(1024), pending: map[string]bool{}}
+}
+
+func (r *asyncResolver) Resolve(_ context.Context, image string, options entrypoint.Options) (string, error) {
+	// access depends on the credentials, so they are part of the key
+	key := strings.Join(append([]string{image, options.Namespace, options.ServiceAccountName}, imagePullSecretNames(options)...), "/")
+	if v, ok := r.results.Get(key); ok {
+		if x := v.(*result); time.Now().Before(x.expires) {
+			return x.digest, x.err
+		}
+		r.results.Remove(key)
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if !r.pending[key] {
+		r.pending[key] = true
+		go r.resolve(key, image, options)
+	}
+	return "", ErrPending
+}
+
+func (r *asyncResolver) resolve(key, image string, options entrypoint.Options) {
+	// the request must not be cancelled when the workflow's operation is done
+	ctx, cancel := context.WithTimeout(context.Background(), resolveTimeout)
+	defer cancel()
+	digest, err := r.delegate.Resolve(ctx, image, options)
+	x := &result{digest: digest, err: err, expires: time.Now().Add(digestTTL)}
+	if err != nil {
+		log.WithField("image", image).WithError(err).Info("Could not resolve image")
+		x.expires = time.Now().Add(errorTTL)
+	}
+	r.results.Add(key, x)
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	delete(r.pending, key)
+}
@@ -0,0 +1,56 @@
+package preflight
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/argoproj/argo-workflows/v3/workflow/controller/entrypoint"
+)
+
+type countingResolver struct {
+	calls  int32
+	digest string
+	err    error
+}
+
+func (r *countingResolver) Resolve(ctx context.Context, _ string, _ entrypoint.Options) (string, error) {
+	atomic.AddInt32(&r.calls, 1)
+	if _, ok := ctx.Deadline(); !ok {
+		return "", fmt.Errorf("no timeout")
+	}
+	return r.digest, r.err
+}
+
+func TestAsyncImageResolver(t *testing.T) {
+	ctx := context.Background()
+	options := entrypoint.Options{Namespace: "my-ns"}
+	t.Run("Resolved", func(t *testing.T) {
+		delegate := &countingResolver{digest: "sha256:my-digest"}
+		r := NewAsyncImageResolver(delegate)
+		_, err := r.Resolve(ctx, "my-image", options)
+		assert.True(t, IsPending(err))
+		assert.Eventually(t, func() bool {
+			digest, err := r.Resolve(ctx, "my-image", options)
+			return err == nil && digest == "sha256:my-digest"
+		}, time.Second, 10*time.Millisecond)
+		// the digest is cached
+		assert.Equal(t, int32(1), atomic.LoadInt32(&delegate.calls))
+		// but not for other credentials
+		_, err = r.Resolve(ctx, "my-image", entrypoint.Options{Namespace: "other-ns"})
+		assert.True(t, IsPending(err))
+	})
+	t.Run("Failed", func(t *testing.T) {
+		r := NewAsyncImageResolver(&countingResolver{err: fmt.Errorf("MANIFEST_UNKNOWN")})
+		_, err := r.Resolve(ctx, "my-image", options)
+		assert.True(t, IsPending(err))
+		assert.Eventually(t, func() bool {
+			_, err := r.Resolve(ctx, "my-image", options)
+			return err != nil && err.Error() == "MANIFEST_UNKNOWN"
+		}, time.Second, 10*time.Millisecond)
+	})
+}
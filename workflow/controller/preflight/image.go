@@ -0,0 +1,82 @@
+package preflight
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/google/go-containerregistry/pkg/authn/k8schain"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	"k8s.io/client-go/kubernetes"
+
+	errorsutil "github.com/argoproj/argo-workflows/v3/util/errors"
+	"github.com/argoproj/argo-workflows/v3/workflow/controller/entrypoint"
+)
+
+// ImageResolver resolves images to the digest they currently refer to.
+type ImageResolver interface {
+	// Resolve returns the digest of the image, e.g. "sha256:...". It fails if the image does not exist, or cannot be
+	// pulled with the service account's or the given image pull secrets.
+	Resolve(ctx context.Context, image string, options entrypoint.Options) (string, error)
+}
+
+type registryResolver struct {
+	kubernetesClient kubernetes.Interface
+}
+
+func NewImageResolver(kubernetesClient kubernetes.Interface) ImageResolver {
+	return &registryResolver{kubernetesClient}
+}
+
+func (r *registryResolver) Resolve(ctx context.Context, image string, options entrypoint.Options) (string, error) {
+	kc, err := k8schain.New(ctx, r.kubernetesClient, k8schain.Options{
+		Namespace:          options.Namespace,
+		ServiceAccountName: options.ServiceAccountName,
+		ImagePullSecrets:   imagePullSecretNames(options),
+	})
+	if err != nil {
+		return "", err
+	}
+	ref, err := name.ParseReference(image)
+	if err != nil {
+		return "", err
+	}
+	// a HEAD request is enough to check access and get the digest, without counting as a pull
+	desc, err := remote.Head(ref, remote.WithAuthFromKeychain(kc), remote.WithContext(ctx))
+	if err != nil {
+		return "", err
+	}
+	return desc.Digest.String(), nil
+}
+
+func imagePullSecretNames(options entrypoint.Options) []string {
+	var v []string
+	for _, s := range options.ImagePullSecrets {
+		v = append(v, s.Name)
+	}
+	return v
+}
+
+// IsTransientErr returns true if the registry could not answer, rather than saying the image is missing or denied,
+// so the check should be tried again later.
+func IsTransientErr(err error) bool {
+	var terr *transport.Error
+	if errors.As(err, &terr) {
+		return terr.StatusCode == http.StatusTooManyRequests || terr.StatusCode >= http.StatusInternalServerError
+	}
+	return errorsutil.IsTransientErr(err)
+}
+
+// PinnedImage returns the image reference with the digest appended, so the same image is used even if the tag is
+// pushed to. Images that already have a digest are returned unchanged.
+func PinnedImage(image, digest string) string {
+	if digest == "" {
+		return image
+	}
+	if _, err := name.NewDigest(image); err == nil {
+		return image
+	}
+	return image + "@" + digest
+}
@@ -0,0 +1,24 @@
+package preflight
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPinnedImage(t *testing.T) {
+	const digest = "sha256:0123456789012345678901234567890123456789012345678901234567890123"
+	assert.Equal(t, "docker/whalesay:latest@"+digest, PinnedImage("docker/whalesay:latest", digest))
+	assert.Equal(t, "argoproj/argosay:v2", PinnedImage("argoproj/argosay:v2", ""))
+	assert.Equal(t, "argoproj/argosay@"+digest, PinnedImage("argoproj/argosay@"+digest, "sha256:other"))
+}
+
+func TestIsTransientErr(t *testing.T) {
+	assert.True(t, IsTransientErr(&transport.Error{StatusCode: http.StatusTooManyRequests}))
+	assert.True(t, IsTransientErr(fmt.Errorf("head: %w", &transport.Error{StatusCode: http.StatusBadGateway})))
+	assert.False(t, IsTransientErr(&transport.Error{StatusCode: http.StatusNotFound}))
+	assert.False(t, IsTransientErr(&transport.Error{StatusCode: http.StatusUnauthorized}))
+}
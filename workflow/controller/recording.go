@@ -0,0 +1,171 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/argoproj/pkg/sync"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+
+	"github.com/argoproj/argo-workflows/v3/persist/sqldb"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	fakewfclientset "github.com/argoproj/argo-workflows/v3/pkg/client/clientset/versioned/fake"
+	"github.com/argoproj/argo-workflows/v3/pkg/client/clientset/versioned/scheme"
+	wfextv "github.com/argoproj/argo-workflows/v3/pkg/client/informers/externalversions"
+	"github.com/argoproj/argo-workflows/v3/workflow/artifactrepositories"
+	controllercache "github.com/argoproj/argo-workflows/v3/workflow/controller/cache"
+	"github.com/argoproj/argo-workflows/v3/workflow/controller/entrypoint"
+	"github.com/argoproj/argo-workflows/v3/workflow/controller/estimation"
+	"github.com/argoproj/argo-workflows/v3/workflow/controller/indexes"
+	"github.com/argoproj/argo-workflows/v3/workflow/controller/preflight"
+	hydratorfake "github.com/argoproj/argo-workflows/v3/workflow/hydrator/fake"
+	"github.com/argoproj/argo-workflows/v3/workflow/metrics"
+	"github.com/argoproj/argo-workflows/v3/workflow/recording"
+	"github.com/argoproj/argo-workflows/v3/workflow/util"
+)
+
+// newReconciliation returns the record of what the reconciliation of the workflow reads, before it is operated on
+func (wfc *WorkflowController) newReconciliation(woc *wfOperationCtx) *recording.Reconciliation {
+	reconciliation := &recording.Reconciliation{
+		Time: metav1.Now(),
+		// the configuration is replaced rather than changed when it is reloaded, so it does not need copying
+		Config:                  wfc.Config,
+		ExecutorImage:           wfc.executorImage(),
+		ExecutorImagePullPolicy: wfc.executorImagePullPolicy(),
+		Workflow:                woc.wf.DeepCopy(),
+	}
+	key := indexes.WorkflowIndexValue(woc.wf.Namespace, woc.wf.Name)
+	pods, err := wfc.podInformer.GetIndexer().ByIndex(indexes.WorkflowIndex, key)
+	if err != nil {
+		woc.log.WithError(err).Warn("failed to record the workflow's pods")
+	}
+	for _, obj := range pods {
+		if pod, ok := obj.(*apiv1.Pod); ok {
+			reconciliation.Pods = append(reconciliation.Pods, pod.DeepCopy())
+		}
+	}
+	results, err := wfc.taskResultInformer.GetIndexer().ByIndex(indexes.WorkflowIndex, key)
+	if err != nil {
+		woc.log.WithError(err).Warn("failed to record the workflow's task results")
+	}
+	for _, obj := range results {
+		if result, ok := obj.(*wfv1.WorkflowTaskResult); ok {
+			reconciliation.TaskResults = append(reconciliation.TaskResults, result.DeepCopy())
+		}
+	}
+	return reconciliation
+}
+
+// Replay operates on the recorded workflow with a controller that has the recorded configuration, pods and task results
+// and fake clients, and returns the workflow at the end of the reconciliation, to compare with the recorded result.
+// Nothing outside of the process is read or changed.
+func Replay(ctx context.Context, reconciliation recording.Reconciliation) (*wfv1.Workflow, error) {
+	if reconciliation.Workflow == nil {
+		return nil, fmt.Errorf("the reconciliation has no workflow")
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	wfc := newReplayController(ctx, reconciliation)
+	for _, c := range []cache.SharedIndexInformer{
+		wfc.wfInformer,
+		wfc.wftmplInformer.Informer(),
+		wfc.cwftmplInformer.Informer(),
+		wfc.podInformer,
+		wfc.wfTaskSetInformer.Informer(),
+		wfc.artGCTaskInformer.Informer(),
+		wfc.taskResultInformer,
+	} {
+		if !cache.WaitForCacheSync(ctx.Done(), c.HasSynced) {
+			return nil, fmt.Errorf("timed out waiting for the replay caches to sync")
+		}
+	}
+	woc := newWorkflowOperationCtx(reconciliation.Workflow, wfc)
+	woc.operate(ctx)
+	return woc.wf, nil
+}
+
+// newReplayController returns a controller like NewWorkflowController and Run create, with fake clients holding the
+// recorded objects, that does not connect to a database
+func newReplayController(ctx context.Context, reconciliation recording.Reconciliation) *WorkflowController {
+	objects := []runtime.Object{reconciliation.Workflow.DeepCopy()}
+	for _, result := range reconciliation.TaskResults {
+		objects = append(objects, result.DeepCopy())
+	}
+	var coreObjects []runtime.Object
+	for _, pod := range reconciliation.Pods {
+		coreObjects = append(coreObjects, pod.DeepCopy())
+	}
+	wfclientset := fakewfclientset.NewSimpleClientset(objects...)
+	dynamicClient := dynamicfake.NewSimpleDynamicClient(scheme.Scheme, reconciliation.Workflow.DeepCopy())
+	informerFactory := wfextv.NewSharedInformerFactory(wfclientset, 0)
+	kube := fake.NewSimpleClientset(coreObjects...)
+	config := reconciliation.Config
+	config.Persistence = nil
+	wfc := &WorkflowController{
+		Config:                     config,
+		namespace:                  reconciliation.Workflow.Namespace,
+		managedNamespace:           reconciliation.Workflow.Namespace,
+		artifactRepositories:       artifactrepositories.New(kube, reconciliation.Workflow.Namespace, &config.ArtifactRepository),
+		cliExecutorImage:           reconciliation.ExecutorImage,
+		cliExecutorImagePullPolicy: string(reconciliation.ExecutorImagePullPolicy),
+		cliExecutorLogFormat:       "text",
+		kubeclientset:              kube,
+		dynamicInterface:           dynamicClient,
+		wfclientset:                wfclientset,
+		workflowKeyLock:            sync.NewKeyLock(),
+		wfArchive:                  sqldb.NullWorkflowArchive,
+		offloadNodeStatusRepo:      sqldb.ExplosiveOffloadNodeStatusRepo,
+		hydrator:                   hydratorfake.Noop,
+		estimatorFactory:           estimation.DummyEstimatorFactory,
+		eventRecorderManager:       &replayEventRecorderManager{},
+		archiveLabelSelector:       labels.Everything(),
+		cacheFactory:               controllercache.NewCacheFactory(kube, reconciliation.Workflow.Namespace),
+		progressPatchTickDuration:  time.Minute,
+		progressFileTickDuration:   3 * time.Second,
+	}
+	wfc.maxStackDepth = wfc.getMaxStackDepth()
+	wfc.metrics = metrics.New(metrics.ServerConfig{}, metrics.ServerConfig{})
+	wfc.entrypoint = entrypoint.New(kube, wfc.Config.Images)
+	wfc.imageResolver = preflight.NewImageResolver(kube)
+	wfc.wfQueue = workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+	wfc.throttler = wfc.newThrottler()
+	wfc.podCleanupQueue = workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+	wfc.rateLimiter = wfc.newRateLimiter()
+
+	wfc.wfInformer = util.NewWorkflowInformer(dynamicClient, "", 0, wfc.tweakListOptions, indexers)
+	wfc.wfTaskSetInformer = informerFactory.Argoproj().V1alpha1().WorkflowTaskSets()
+	wfc.artGCTaskInformer = informerFactory.Argoproj().V1alpha1().WorkflowArtifactGCTasks()
+	wfc.taskResultInformer = wfc.newWorkflowTaskResultInformer()
+	wfc.wftmplInformer = informerFactory.Argoproj().V1alpha1().WorkflowTemplates()
+	wfc.cwftmplInformer = informerFactory.Argoproj().V1alpha1().ClusterWorkflowTemplates()
+	wfc.podInformer = wfc.newPodInformer(ctx)
+	wfc.configMapInformer = wfc.newConfigMapInformer()
+	wfc.createSynchronizationManager(ctx)
+	_ = wfc.initManagers(ctx)
+
+	go wfc.wfInformer.Run(ctx.Done())
+	go wfc.wftmplInformer.Informer().Run(ctx.Done())
+	go wfc.cwftmplInformer.Informer().Run(ctx.Done())
+	go wfc.podInformer.Run(ctx.Done())
+	go wfc.wfTaskSetInformer.Informer().Run(ctx.Done())
+	go wfc.artGCTaskInformer.Informer().Run(ctx.Done())
+	go wfc.taskResultInformer.Run(ctx.Done())
+	go wfc.configMapInformer.Run(ctx.Done())
+	return wfc
+}
+
+// replayEventRecorderManager drops the events of replayed reconciliations
+type replayEventRecorderManager struct{}
+
+func (m *replayEventRecorderManager) Get(string) record.EventRecorder {
+	return &record.FakeRecorder{}
+}
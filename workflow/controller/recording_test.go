@@ -0,0 +1,34 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+func TestReplay(t *testing.T) {
+	wf := wfv1.MustUnmarshalWorkflow(helloWorldWf)
+	cancel, controller := newController(wf)
+	defer cancel()
+
+	ctx := context.Background()
+	woc := newWorkflowOperationCtx(wf, controller)
+	reconciliation := controller.newReconciliation(woc)
+	woc.operate(ctx)
+	reconciliation.Result = woc.wf.DeepCopy()
+
+	assert.Equal(t, wf.Name, reconciliation.Workflow.Name)
+	assert.Equal(t, controller.executorImage(), reconciliation.ExecutorImage)
+
+	replayed, err := Replay(ctx, *reconciliation)
+	require.NoError(t, err)
+	assert.Equal(t, reconciliation.Result.Status.Phase, replayed.Status.Phase)
+	require.Len(t, replayed.Status.Nodes, len(reconciliation.Result.Status.Nodes))
+	for id, n := range reconciliation.Result.Status.Nodes {
+		assert.Equal(t, n.Phase, replayed.Status.Nodes[id].Phase)
+	}
+}
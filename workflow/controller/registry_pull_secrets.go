@@ -0,0 +1,33 @@
+package controller
+
+import (
+	apiv1 "k8s.io/api/core/v1"
+)
+
+// addRegistryPullSecrets adds the pull secrets of the registries of the pod's images from the controller's config, so
+// that workflow authors do not need to know which secret covers which registry
+func (woc *wfOperationCtx) addRegistryPullSecrets(pod *apiv1.Pod) {
+	for _, containers := range [][]apiv1.Container{pod.Spec.InitContainers, pod.Spec.Containers} {
+		for _, c := range containers {
+			pod.Spec.ImagePullSecrets = addImagePullSecret(pod.Spec.ImagePullSecrets, woc.controller.Config.RegistryPullSecrets.GetPullSecret(c.Image))
+		}
+	}
+}
+
+// getImagePullSecrets returns the workflow's image pull secrets, and the pull secret of the image's registry
+func (woc *wfOperationCtx) getImagePullSecrets(image string) []apiv1.LocalObjectReference {
+	return addImagePullSecret(woc.execWf.Spec.ImagePullSecrets, woc.controller.Config.RegistryPullSecrets.GetPullSecret(image))
+}
+
+func addImagePullSecret(secrets []apiv1.LocalObjectReference, name string) []apiv1.LocalObjectReference {
+	if name == "" {
+		return secrets
+	}
+	for _, s := range secrets {
+		if s.Name == name {
+			return secrets
+		}
+	}
+	// copy, so that the workflow's secrets are not modified
+	return append(secrets[:len(secrets):len(secrets)], apiv1.LocalObjectReference{Name: name})
+}
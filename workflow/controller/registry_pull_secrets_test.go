@@ -0,0 +1,51 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	apiv1 "k8s.io/api/core/v1"
+
+	"github.com/argoproj/argo-workflows/v3/config"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+var registryPullSecretsWf = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  name: registry-pull-secrets
+spec:
+  entrypoint: main
+  imagePullSecrets:
+  - name: my-secret
+  templates:
+  - name: main
+    container:
+      image: ghcr.io/my-org/app:v1
+      command: [app]
+    sidecars:
+    - name: proxy
+      image: my-registry.example.com/proxy:v1
+      command: [proxy]
+`
+
+func TestRegistryPullSecrets(t *testing.T) {
+	wf := wfv1.MustUnmarshalWorkflow(registryPullSecretsWf)
+	cancel, controller := newController(wf)
+	defer cancel()
+	controller.Config.RegistryPullSecrets = config.RegistryPullSecrets{
+		"ghcr.io/my-org":           "ghcr",
+		"my-registry.example.com/": "my-secret",
+		"quay.io":                  "quay",
+	}
+	ctx := context.Background()
+	woc := newWorkflowOperationCtx(wf, controller)
+	woc.operate(ctx)
+	pods, err := listPods(woc)
+	if assert.NoError(t, err) && assert.Len(t, pods.Items, 1) {
+		assert.Equal(t, []apiv1.LocalObjectReference{{Name: "my-secret"}, {Name: "quay"}, {Name: "ghcr"}}, pods.Items[0].Spec.ImagePullSecrets)
+	}
+	assert.Equal(t, []apiv1.LocalObjectReference{{Name: "my-secret"}}, woc.execWf.Spec.ImagePullSecrets, "workflow is not modified")
+}
@@ -0,0 +1,74 @@
+package controller
+
+import (
+	apiv1 "k8s.io/api/core/v1"
+
+	"github.com/argoproj/argo-workflows/v3/config"
+	"github.com/argoproj/argo-workflows/v3/errors"
+)
+
+// appArmorAnnotationPrefix is followed by the container name, Kubernetes < v1.30 only reads AppArmor profiles from
+// annotations
+const appArmorAnnotationPrefix = "container.apparmor.security.beta.kubernetes.io/"
+
+// applySecurityProfile applies the named security profile from the controller's config to every container in the pod,
+// after any pod spec patch, so that templates cannot loosen it
+func (woc *wfOperationCtx) applySecurityProfile(pod *apiv1.Pod, name string) error {
+	profile, ok := woc.controller.Config.SecurityProfiles[name]
+	if !ok {
+		return errors.Errorf(errors.CodeBadRequest, "security profile %q is not configured", name)
+	}
+	if profile.SeccompProfile != nil {
+		if pod.Spec.SecurityContext == nil {
+			pod.Spec.SecurityContext = &apiv1.PodSecurityContext{}
+		}
+		pod.Spec.SecurityContext.SeccompProfile = profile.SeccompProfile.DeepCopy()
+	}
+	for i := range pod.Spec.InitContainers {
+		applySecurityProfileToContainer(pod, &pod.Spec.InitContainers[i], profile)
+	}
+	for i := range pod.Spec.Containers {
+		applySecurityProfileToContainer(pod, &pod.Spec.Containers[i], profile)
+	}
+	return nil
+}
+
+func applySecurityProfileToContainer(pod *apiv1.Pod, c *apiv1.Container, profile config.SecurityProfile) {
+	if profile.SeccompProfile != nil || len(profile.DropCapabilities) > 0 {
+		if c.SecurityContext == nil {
+			c.SecurityContext = &apiv1.SecurityContext{}
+		}
+	}
+	if profile.SeccompProfile != nil {
+		c.SecurityContext.SeccompProfile = profile.SeccompProfile.DeepCopy()
+	}
+	if len(profile.DropCapabilities) > 0 {
+		if c.SecurityContext.Capabilities == nil {
+			c.SecurityContext.Capabilities = &apiv1.Capabilities{}
+		}
+		caps := c.SecurityContext.Capabilities
+		dropped := map[apiv1.Capability]bool{}
+		for _, x := range caps.Drop {
+			dropped[x] = true
+		}
+		for _, x := range profile.DropCapabilities {
+			if !dropped[x] {
+				caps.Drop = append(caps.Drop, x)
+				dropped[x] = true
+			}
+		}
+		var add []apiv1.Capability
+		for _, x := range caps.Add {
+			if !dropped["ALL"] && !dropped[x] {
+				add = append(add, x)
+			}
+		}
+		caps.Add = add
+	}
+	if profile.AppArmorProfile != "" {
+		if pod.Annotations == nil {
+			pod.Annotations = map[string]string{}
+		}
+		pod.Annotations[appArmorAnnotationPrefix+c.Name] = profile.AppArmorProfile
+	}
+}
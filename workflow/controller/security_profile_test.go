@@ -0,0 +1,72 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	apiv1 "k8s.io/api/core/v1"
+
+	"github.com/argoproj/argo-workflows/v3/config"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+var securityProfileWf = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  name: security-profile
+spec:
+  entrypoint: main
+  templates:
+  - name: main
+    securityProfile: strict
+    container:
+      image: argoproj/argosay:v2
+      securityContext:
+        capabilities:
+          add: [NET_ADMIN]
+`
+
+func TestSecurityProfile(t *testing.T) {
+	t.Run("Applied", func(t *testing.T) {
+		wf := wfv1.MustUnmarshalWorkflow(securityProfileWf)
+		cancel, controller := newController(wf)
+		defer cancel()
+		controller.Config.SecurityProfiles = map[string]config.SecurityProfile{
+			"strict": {
+				SeccompProfile:   &apiv1.SeccompProfile{Type: apiv1.SeccompProfileTypeRuntimeDefault},
+				AppArmorProfile:  "runtime/default",
+				DropCapabilities: []apiv1.Capability{"ALL"},
+			},
+		}
+		ctx := context.Background()
+		woc := newWorkflowOperationCtx(wf, controller)
+		woc.operate(ctx)
+		pods, err := listPods(woc)
+		if assert.NoError(t, err) && assert.Len(t, pods.Items, 1) {
+			pod := pods.Items[0]
+			assert.Equal(t, apiv1.SeccompProfileTypeRuntimeDefault, pod.Spec.SecurityContext.SeccompProfile.Type)
+			for _, c := range append(pod.Spec.InitContainers, pod.Spec.Containers...) {
+				assert.Equal(t, apiv1.SeccompProfileTypeRuntimeDefault, c.SecurityContext.SeccompProfile.Type, c.Name)
+				assert.Equal(t, []apiv1.Capability{"ALL"}, c.SecurityContext.Capabilities.Drop, c.Name)
+				assert.Empty(t, c.SecurityContext.Capabilities.Add, c.Name)
+				assert.Equal(t, "runtime/default", pod.Annotations[appArmorAnnotationPrefix+c.Name], c.Name)
+			}
+		}
+	})
+	t.Run("Missing", func(t *testing.T) {
+		wf := wfv1.MustUnmarshalWorkflow(securityProfileWf)
+		cancel, controller := newController(wf)
+		defer cancel()
+		ctx := context.Background()
+		woc := newWorkflowOperationCtx(wf, controller)
+		woc.operate(ctx)
+		assert.Equal(t, wfv1.WorkflowError, woc.wf.Status.Phase)
+		assert.Contains(t, woc.wf.Status.Message, `security profile "strict" is not configured`)
+		pods, err := listPods(woc)
+		if assert.NoError(t, err) {
+			assert.Empty(t, pods.Items)
+		}
+	})
+}
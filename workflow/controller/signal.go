@@ -0,0 +1,83 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+	"github.com/argoproj/argo-workflows/v3/workflow/signal"
+)
+
+// addSignalVolume mounts the signal annotation of the pod into its wait container using the downward API, so that the
+// executor can deliver the signals requested for the main containers without anyone needing to exec into the pod
+func addSignalVolume(pod *apiv1.Pod) {
+	for i, c := range pod.Spec.Containers {
+		if c.Name != common.WaitContainerName {
+			continue
+		}
+		pod.Spec.Volumes = append(pod.Spec.Volumes, apiv1.Volume{
+			Name: common.SignalVolumeName,
+			VolumeSource: apiv1.VolumeSource{
+				DownwardAPI: &apiv1.DownwardAPIVolumeSource{
+					Items: []apiv1.DownwardAPIVolumeFile{{
+						Path:     "signal",
+						FieldRef: &apiv1.ObjectFieldSelector{FieldPath: fmt.Sprintf("metadata.annotations['%s']", common.AnnotationKeySignal)},
+					}},
+				},
+			},
+		})
+		c.VolumeMounts = append(c.VolumeMounts, apiv1.VolumeMount{
+			Name:      common.SignalVolumeName,
+			MountPath: common.SignalMountPath,
+			ReadOnly:  true,
+		})
+		pod.Spec.Containers[i] = c
+		return
+	}
+}
+
+// deliverPodSignal passes the last signal requested for the node on to its pod, by updating the signal annotation of
+// the pod. Pods without the signal volume were created before signals were delivered this way, and are skipped.
+func (woc *wfOperationCtx) deliverPodSignal(ctx context.Context, pod *apiv1.Pod, nodeID string) {
+	requests, err := signal.Requests(woc.wf.Annotations)
+	if err != nil {
+		woc.log.WithError(err).Warn("failed to read signal requests")
+		return
+	}
+	request, ok := requests[nodeID]
+	if !ok || pod.Annotations[common.AnnotationKeySignal] == request {
+		return
+	}
+	if !hasVolume(pod, common.SignalVolumeName) {
+		return
+	}
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]string{common.AnnotationKeySignal: request},
+		},
+	})
+	if err != nil {
+		woc.log.WithError(err).Error("failed to marshal pod signal patch")
+		return
+	}
+	_, err = woc.controller.kubeclientset.CoreV1().Pods(pod.Namespace).Patch(ctx, pod.Name, types.MergePatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		woc.log.WithError(err).WithField("podName", pod.Name).Warn("failed to deliver pod signal")
+		return
+	}
+	woc.log.WithField("podName", pod.Name).WithField("request", request).Info("Delivered pod signal")
+}
+
+func hasVolume(pod *apiv1.Pod, name string) bool {
+	for _, v := range pod.Spec.Volumes {
+		if v.Name == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,51 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+func TestAddSignalVolume(t *testing.T) {
+	pod := newDeadlinePod()
+	addSignalVolume(pod)
+	require.Len(t, pod.Spec.Volumes, 1)
+	assert.Equal(t, "metadata.annotations['workflows.argoproj.io/signal']", pod.Spec.Volumes[0].DownwardAPI.Items[0].FieldRef.FieldPath)
+	wait := pod.Spec.Containers[0]
+	require.Len(t, wait.VolumeMounts, 1)
+	assert.Equal(t, common.SignalMountPath, wait.VolumeMounts[0].MountPath)
+	assert.Empty(t, pod.Spec.Containers[1].VolumeMounts)
+}
+
+func TestDeliverPodSignal(t *testing.T) {
+	ctx := context.Background()
+	run := func(t *testing.T, requests string, withVolume bool) string {
+		woc := newWoc()
+		woc.wf.Annotations = map[string]string{common.AnnotationKeySignals: requests}
+		pod := newDeadlinePod()
+		if withVolume {
+			addSignalVolume(pod)
+		}
+		pods := woc.controller.kubeclientset.CoreV1().Pods(pod.Namespace)
+		_, err := pods.Create(ctx, pod, metav1.CreateOptions{})
+		require.NoError(t, err)
+		woc.deliverPodSignal(ctx, pod, "my-node")
+		pod, err = pods.Get(ctx, pod.Name, metav1.GetOptions{})
+		require.NoError(t, err)
+		return pod.Annotations[common.AnnotationKeySignal]
+	}
+	t.Run("Requested", func(t *testing.T) {
+		assert.Equal(t, "1:10", run(t, `{"my-node":"1:10"}`, true))
+	})
+	t.Run("OtherNode", func(t *testing.T) {
+		assert.Empty(t, run(t, `{"other-node":"1:10"}`, true))
+	})
+	t.Run("NoVolume", func(t *testing.T) {
+		assert.Empty(t, run(t, `{"my-node":"1:10"}`, false))
+	})
+}
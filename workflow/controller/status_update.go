@@ -0,0 +1,79 @@
+package controller
+
+import (
+	"reflect"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// statusUpdates records when the controller last updated each running workflow, so that the updates that follow
+// within the status update window can be coalesced
+type statusUpdates struct {
+	mu      sync.Mutex
+	updated map[types.UID]time.Time
+}
+
+func newStatusUpdates() *statusUpdates {
+	return &statusUpdates{updated: make(map[types.UID]time.Time)}
+}
+
+// delay returns how long the next update of the workflow must wait to be a window after its last update, or zero if
+// it was not updated within the window
+func (s *statusUpdates) delay(uid types.UID, window time.Duration, now time.Time) time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	updated, ok := s.updated[uid]
+	if !ok {
+		return 0
+	}
+	if remaining := window - now.Sub(updated); remaining > 0 {
+		return remaining
+	}
+	return 0
+}
+
+func (s *statusUpdates) record(uid types.UID, now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.updated[uid] = now
+}
+
+func (s *statusUpdates) forget(uid types.UID) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.updated, uid)
+}
+
+// statusUpdateDelay returns how long to delay the update of the workflow, so that it is coalesced with the updates that
+// follow, or zero if it must be updated now
+func (woc *wfOperationCtx) statusUpdateDelay() time.Duration {
+	window := woc.controller.Config.GetStatusUpdateWindow()
+	if window <= 0 || woc.statusTransitioned() {
+		return 0
+	}
+	return woc.controller.statusUpdates.delay(woc.wf.UID, window, time.Now())
+}
+
+// statusTransitioned returns whether the workflow changed phase, metadata or synchronization, or has new or completed
+// nodes. The controller acts on these, e.g. by creating pods or emitting metrics, and would do so again if the update
+// was delayed and the workflow reconciled from its last update.
+func (woc *wfOperationCtx) statusTransitioned() bool {
+	if woc.wf.Status.Phase != woc.orig.Status.Phase || woc.wf.Status.Fulfilled() {
+		return true
+	}
+	if !reflect.DeepEqual(woc.wf.Labels, woc.orig.Labels) ||
+		!reflect.DeepEqual(woc.wf.Annotations, woc.orig.Annotations) ||
+		!reflect.DeepEqual(woc.wf.Finalizers, woc.orig.Finalizers) ||
+		!reflect.DeepEqual(woc.wf.Status.Synchronization, woc.orig.Status.Synchronization) {
+		return true
+	}
+	for id, node := range woc.wf.Status.Nodes {
+		phase, ok := woc.preExecutionNodePhases[id]
+		if !ok || (node.Fulfilled() && !phase.Fulfilled()) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,102 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+func TestStatusUpdates(t *testing.T) {
+	s := newStatusUpdates()
+	now := time.Now()
+	assert.Zero(t, s.delay("my-uid", 5*time.Second, now))
+	s.record("my-uid", now)
+	assert.Equal(t, 3*time.Second, s.delay("my-uid", 5*time.Second, now.Add(2*time.Second)))
+	assert.Zero(t, s.delay("my-uid", 5*time.Second, now.Add(5*time.Second)))
+	s.forget("my-uid")
+	assert.Zero(t, s.delay("my-uid", 5*time.Second, now.Add(2*time.Second)))
+}
+
+func TestStatusTransitioned(t *testing.T) {
+	cancel, controller := newController()
+	defer cancel()
+	wf := wfv1.MustUnmarshalWorkflow(helloWorldWfPersist)
+	wf.Status.Phase = wfv1.WorkflowRunning
+	wf.Status.Nodes = wfv1.Nodes{"my-node": {ID: "my-node", Phase: wfv1.NodePending}}
+	newWoc := func() *wfOperationCtx {
+		woc := newWorkflowOperationCtx(wf, controller)
+		woc.preExecutionNodePhases["my-node"] = wfv1.NodePending
+		return woc
+	}
+	t.Run("NodeRunning", func(t *testing.T) {
+		woc := newWoc()
+		woc.wf.Status.Nodes.Set("my-node", wfv1.NodeStatus{ID: "my-node", Phase: wfv1.NodeRunning, Message: "running"})
+		woc.wf.Status.Progress = "0/1"
+		assert.False(t, woc.statusTransitioned())
+	})
+	t.Run("NodeCompleted", func(t *testing.T) {
+		woc := newWoc()
+		woc.wf.Status.Nodes.Set("my-node", wfv1.NodeStatus{ID: "my-node", Phase: wfv1.NodeSucceeded})
+		assert.True(t, woc.statusTransitioned())
+	})
+	t.Run("NodeAdded", func(t *testing.T) {
+		woc := newWoc()
+		woc.wf.Status.Nodes.Set("other-node", wfv1.NodeStatus{ID: "other-node", Phase: wfv1.NodePending})
+		assert.True(t, woc.statusTransitioned())
+	})
+	t.Run("PhaseChanged", func(t *testing.T) {
+		woc := newWoc()
+		woc.wf.Status.Phase = wfv1.WorkflowFailed
+		assert.True(t, woc.statusTransitioned())
+	})
+	t.Run("LabelsChanged", func(t *testing.T) {
+		woc := newWoc()
+		woc.wf.Labels = map[string]string{"my-label": "my-value"}
+		assert.True(t, woc.statusTransitioned())
+	})
+}
+
+func TestPersistUpdatesCoalesced(t *testing.T) {
+	cancel, controller := newController()
+	defer cancel()
+	controller.Config.StatusUpdateWindow = &metav1.Duration{Duration: time.Minute}
+	ctx := context.Background()
+	wfcset := controller.wfclientset.ArgoprojV1alpha1().Workflows("")
+	wf := wfv1.MustUnmarshalWorkflow(helloWorldWfPersist)
+	wf.Status.Phase = wfv1.WorkflowRunning
+	wf.Status.Nodes = wfv1.Nodes{"my-node": {ID: "my-node", Phase: wfv1.NodePending}}
+	wf, err := wfcset.Create(ctx, wf, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	persist := func(phase wfv1.NodePhase) *wfv1.Workflow {
+		latest, err := wfcset.Get(ctx, wf.Name, metav1.GetOptions{})
+		require.NoError(t, err)
+		woc := newWorkflowOperationCtx(latest, controller)
+		woc.preExecutionNodePhases["my-node"] = latest.Status.Nodes["my-node"].Phase
+		woc.wf.Status.Nodes.Set("my-node", wfv1.NodeStatus{ID: "my-node", Phase: phase})
+		woc.updated = true
+		woc.persistUpdates(ctx)
+		latest, err = wfcset.Get(ctx, wf.Name, metav1.GetOptions{})
+		require.NoError(t, err)
+		return latest
+	}
+
+	// not updated within the window, so updated now
+	assert.Equal(t, wfv1.NodeRunning, persist(wfv1.NodeRunning).Status.Nodes["my-node"].Phase)
+	// updated within the window, so coalesced
+	controller.statusUpdates.record(wf.UID, time.Now())
+	latest, err := wfcset.Get(ctx, wf.Name, metav1.GetOptions{})
+	require.NoError(t, err)
+	latest.Status.Nodes.Set("my-node", wfv1.NodeStatus{ID: "my-node", Phase: wfv1.NodePending})
+	_, err = wfcset.Update(ctx, latest, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, wfv1.NodePending, persist(wfv1.NodeRunning).Status.Nodes["my-node"].Phase)
+	// completed, so updated now
+	assert.Equal(t, wfv1.NodeSucceeded, persist(wfv1.NodeSucceeded).Status.Nodes["my-node"].Phase)
+}
@@ -7,7 +7,6 @@ import (
 	"strings"
 	"time"
 
-	"github.com/Knetic/govaluate"
 	log "github.com/sirupsen/logrus"
 	v1 "k8s.io/api/core/v1"
 
@@ -17,6 +16,7 @@ import (
 	"github.com/argoproj/argo-workflows/v3/workflow/common"
 	controllercache "github.com/argoproj/argo-workflows/v3/workflow/controller/cache"
 	"github.com/argoproj/argo-workflows/v3/workflow/templateresolution"
+	wfutil "github.com/argoproj/argo-workflows/v3/workflow/util"
 )
 
 // stepsContext holds context information about this context's steps
@@ -180,7 +180,7 @@ func (woc *wfOperationCtx) executeSteps(ctx context.Context, nodeName string, tm
 		woc.wf.Status.Nodes.Set(node.ID, *node)
 		if node.MemoizationStatus != nil {
 			c := woc.controller.cacheFactory.GetCache(controllercache.ConfigMapCache, node.MemoizationStatus.CacheName)
-			err := c.Save(ctx, node.MemoizationStatus.Key, node.ID, node.Outputs)
+			err := c.Save(ctx, node.MemoizationStatus.Key, node.ID, node.Outputs, woc.wf)
 			if err != nil {
 				woc.log.WithFields(log.Fields{"nodeID": node.ID}).WithError(err).Error("Failed to save node outputs to cache")
 				node.Phase = wfv1.NodeError
@@ -258,7 +258,7 @@ func (woc *wfOperationCtx) executeStepGroup(ctx context.Context, stepGroup []wfv
 		childNodeName := fmt.Sprintf("%s.%s", sgNodeName, step.Name)
 
 		// Check the step's when clause to decide if it should execute
-		proceed, err := shouldExecute(step.When)
+		proceed, err := wfutil.ShouldExecute(step.When)
 		if err != nil {
 			woc.initializeNode(childNodeName, wfv1.NodeTypeSkipped, stepTemplateScope, &step, stepsCtx.boundaryID, wfv1.NodeError, &wfv1.NodeFlag{}, err.Error())
 			woc.addChildNode(sgNodeName, childNodeName)
@@ -357,44 +357,6 @@ func (woc *wfOperationCtx) executeStepGroup(ctx context.Context, stepGroup []wfv
 }
 
 // shouldExecute evaluates a already substituted when expression to decide whether or not a step should execute
-func shouldExecute(when string) (bool, error) {
-	if when == "" {
-		return true, nil
-	}
-	expression, err := govaluate.NewEvaluableExpression(when)
-	if err != nil {
-		if strings.Contains(err.Error(), "Invalid token") {
-			return false, errors.Errorf(errors.CodeBadRequest, "Invalid 'when' expression '%s': %v (hint: try wrapping the affected expression in quotes (\"))", when, err)
-		}
-		return false, errors.Errorf(errors.CodeBadRequest, "Invalid 'when' expression '%s': %v", when, err)
-	}
-	// The following loop converts govaluate variables (which we don't use), into strings. This
-	// allows us to have expressions like: "foo != bar" without requiring foo and bar to be quoted.
-	tokens := expression.Tokens()
-	for i, tok := range tokens {
-		switch tok.Kind {
-		case govaluate.VARIABLE:
-			tok.Kind = govaluate.STRING
-		default:
-			continue
-		}
-		tokens[i] = tok
-	}
-	expression, err = govaluate.NewEvaluableExpressionFromTokens(tokens)
-	if err != nil {
-		return false, errors.InternalWrapErrorf(err, "Failed to parse 'when' expression '%s': %v", when, err)
-	}
-	result, err := expression.Evaluate(nil)
-	if err != nil {
-		return false, errors.InternalWrapErrorf(err, "Failed to evaluate 'when' expresion '%s': %v", when, err)
-	}
-	boolRes, ok := result.(bool)
-	if !ok {
-		return false, errors.Errorf(errors.CodeBadRequest, "Expected boolean evaluation for '%s'. Got %v", when, result)
-	}
-	return boolRes, nil
-}
-
 // resolveReferences replaces any references to outputs of previous steps, or artifacts in the inputs
 // NOTE: by now, input parameters should have been substituted throughout the template, so we only
 // are concerned with:
@@ -431,7 +393,7 @@ func (woc *wfOperationCtx) resolveReferences(stepGroup []wfv1.WorkflowStep, scop
 
 		// If we are not executing, don't attempt to resolve any artifact references. We only check if we are executing after
 		// the initial parameter resolution, since it's likely that the "when" clause will contain parameter references.
-		proceed, err := shouldExecute(newStep.When)
+		proceed, err := wfutil.ShouldExecute(newStep.When)
 		if err != nil {
 			// If we got an error, it might be because our "when" clause contains a task-expansion parameter (e.g. {{item}}).
 			// Since we don't perform task-expansion until later and task-expansion parameters won't get resolved here,
@@ -511,15 +473,15 @@ func (woc *wfOperationCtx) expandStep(step wfv1.WorkflowStep) ([]wfv1.WorkflowSt
 	} else if step.WithParam != "" {
 		err = json.Unmarshal([]byte(step.WithParam), &items)
 		if err != nil {
-			mustExec, mustExecErr := shouldExecute(step.When)
+			mustExec, mustExecErr := wfutil.ShouldExecute(step.When)
 			if mustExecErr != nil || mustExec {
 				return nil, errors.Errorf(errors.CodeBadRequest, "withParam value could not be parsed as a JSON list: %s: %v", strings.TrimSpace(step.WithParam), err)
 			}
 		}
 	} else if step.WithSequence != nil {
-		items, err = expandSequence(step.WithSequence)
+		items, err = wfutil.ExpandSequence(step.WithSequence)
 		if err != nil {
-			mustExec, mustExecErr := shouldExecute(step.When)
+			mustExec, mustExecErr := wfutil.ShouldExecute(step.When)
 			if mustExecErr != nil || mustExec {
 				return nil, err
 			}
@@ -528,6 +490,9 @@ func (woc *wfOperationCtx) expandStep(step wfv1.WorkflowStep) ([]wfv1.WorkflowSt
 		// this should have been prevented in expandStepGroup()
 		return nil, errors.InternalError("expandStep() was called with withItems and withParam empty")
 	}
+	if err := woc.checkFanOutGuardrail(step.Name, len(items)); err != nil {
+		return nil, err
+	}
 
 	// these fields can be very large (>100m) and marshalling 10k x 100m = 6GB of memory used and
 	// very poor performance, so we just nil them out
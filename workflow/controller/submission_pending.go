@@ -0,0 +1,35 @@
+package controller
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+// markSubmissionPending sets the SubmissionPending condition of a workflow whose processing has been postponed
+// because too many workflows are already running
+func (woc *wfOperationCtx) markSubmissionPending(message string) {
+	if c := woc.wf.Status.Conditions.Get(wfv1.ConditionTypeSubmissionPending); c != nil && c.Status == metav1.ConditionTrue {
+		return
+	}
+	woc.wf.Status.Conditions.UpsertCondition(wfv1.Condition{
+		Type:    wfv1.ConditionTypeSubmissionPending,
+		Status:  metav1.ConditionTrue,
+		Reason:  wfv1.ConditionReasonParallelismLimit,
+		Message: message,
+	})
+	woc.updated = true
+}
+
+// markSubmissionAdmitted clears the SubmissionPending condition of a workflow once it is being processed
+func (woc *wfOperationCtx) markSubmissionAdmitted() {
+	if c := woc.wf.Status.Conditions.Get(wfv1.ConditionTypeSubmissionPending); c == nil || c.Status != metav1.ConditionTrue {
+		return
+	}
+	woc.wf.Status.Conditions.UpsertCondition(wfv1.Condition{
+		Type:   wfv1.ConditionTypeSubmissionPending,
+		Status: metav1.ConditionFalse,
+		Reason: wfv1.ConditionReasonAdmitted,
+	})
+	woc.updated = true
+}
@@ -149,7 +149,7 @@ func (woc *wfOperationCtx) reconcileTaskSet(ctx context.Context) error {
 			woc.wf.Status.Nodes.Set(nodeID, *node)
 			if node.MemoizationStatus != nil && node.Succeeded() {
 				c := woc.controller.cacheFactory.GetCache(controllercache.ConfigMapCache, node.MemoizationStatus.CacheName)
-				err := c.Save(ctx, node.MemoizationStatus.Key, node.ID, node.Outputs)
+				err := c.Save(ctx, node.MemoizationStatus.Key, node.ID, node.Outputs, woc.wf)
 				if err != nil {
 					woc.log.WithFields(log.Fields{"nodeID": node.ID}).WithError(err).Error("Failed to save node outputs to cache")
 				}
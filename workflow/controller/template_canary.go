@@ -0,0 +1,60 @@
+package controller
+
+import (
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+	wfutil "github.com/argoproj/argo-workflows/v3/workflow/util"
+)
+
+// getWorkflowTemplate returns the workflow template the workflow references, or its canary if the workflow was
+// selected to run it. The version the workflow runs is recorded in a label, so it is only selected once.
+func (woc *wfOperationCtx) getWorkflowTemplate(name string) (*wfv1.WorkflowTemplate, error) {
+	lister := woc.controller.wftmplInformer.Lister().WorkflowTemplates(woc.wf.Namespace)
+	stable, err := lister.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	canary, err := woc.getCanary(name)
+	if err != nil {
+		// a broken canary must not stop the workflows of the stable template from running
+		woc.log.WithError(err).Warn("Failed to get the canary of the workflow template, running the stable version")
+	}
+	version := woc.wf.Labels[common.LabelKeyWorkflowTemplateVersion]
+	if version == "" {
+		if canary == nil {
+			return stable, nil
+		}
+		version = wfutil.WorkflowTemplateVersionStable
+		if weight, err := wfutil.GetCanaryWeight(canary); err != nil {
+			woc.log.WithError(err).Warn("Failed to get the weight of the canary, running the stable version")
+		} else {
+			version = wfutil.SelectWorkflowTemplateVersion(woc.wf.UID, weight)
+		}
+		if woc.wf.Labels == nil {
+			woc.wf.Labels = map[string]string{}
+		}
+		woc.wf.Labels[common.LabelKeyWorkflowTemplateVersion] = version
+		woc.updated = true
+		woc.log.WithField("version", version).Info("Selected workflow template version")
+	}
+	// if the canary was promoted or rolled back since the version was selected, the workflow runs the stable version
+	if version == wfutil.WorkflowTemplateVersionCanary && canary != nil {
+		return canary, nil
+	}
+	return stable, nil
+}
+
+func (woc *wfOperationCtx) getCanary(name string) (*wfv1.WorkflowTemplate, error) {
+	requirement, err := labels.NewRequirement(common.LabelKeyCanaryOf, selection.Equals, []string{name})
+	if err != nil {
+		return nil, err
+	}
+	canaries, err := woc.controller.wftmplInformer.Lister().WorkflowTemplates(woc.wf.Namespace).List(labels.NewSelector().Add(*requirement))
+	if err != nil {
+		return nil, err
+	}
+	return wfutil.GetCanary(name, canaries)
+}
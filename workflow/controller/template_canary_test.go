@@ -0,0 +1,72 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/pointer"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+	wfutil "github.com/argoproj/argo-workflows/v3/workflow/util"
+)
+
+func canaryOf(weight string) *wfv1.WorkflowTemplate {
+	canary := wfv1.MustUnmarshalWorkflowTemplate(wfTmpl)
+	canary.Name = "workflow-template-whalesay-template-canary"
+	canary.Labels = map[string]string{common.LabelKeyCanaryOf: "workflow-template-whalesay-template"}
+	canary.Annotations = map[string]string{common.AnnotationKeyCanaryWeight: weight}
+	canary.Spec.Priority = pointer.Int32(88)
+	return canary
+}
+
+func TestWorkflowTemplateCanary(t *testing.T) {
+	ctx := context.Background()
+	t.Run("NoCanary", func(t *testing.T) {
+		wf := wfv1.MustUnmarshalWorkflow(wfWithTmplRef)
+		cancel, controller := newController(wf, wfv1.MustUnmarshalWorkflowTemplate(wfTmpl))
+		defer cancel()
+		woc := newWorkflowOperationCtx(wf, controller)
+		woc.operate(ctx)
+		assert.NotContains(t, woc.wf.Labels, common.LabelKeyWorkflowTemplateVersion)
+		assert.Equal(t, "77", woc.globalParams["workflow.priority"])
+	})
+	t.Run("Stable", func(t *testing.T) {
+		wf := wfv1.MustUnmarshalWorkflow(wfWithTmplRef)
+		cancel, controller := newController(wf, wfv1.MustUnmarshalWorkflowTemplate(wfTmpl), canaryOf("0"))
+		defer cancel()
+		woc := newWorkflowOperationCtx(wf, controller)
+		woc.operate(ctx)
+		assert.Equal(t, wfutil.WorkflowTemplateVersionStable, woc.wf.Labels[common.LabelKeyWorkflowTemplateVersion])
+		assert.Equal(t, "77", woc.globalParams["workflow.priority"])
+	})
+	t.Run("Canary", func(t *testing.T) {
+		wf := wfv1.MustUnmarshalWorkflow(wfWithTmplRef)
+		cancel, controller := newController(wf, wfv1.MustUnmarshalWorkflowTemplate(wfTmpl), canaryOf("100"))
+		defer cancel()
+		woc := newWorkflowOperationCtx(wf, controller)
+		woc.operate(ctx)
+		assert.Equal(t, wfutil.WorkflowTemplateVersionCanary, woc.wf.Labels[common.LabelKeyWorkflowTemplateVersion])
+		assert.Equal(t, "88", woc.globalParams["workflow.priority"])
+	})
+	t.Run("InvalidWeight", func(t *testing.T) {
+		wf := wfv1.MustUnmarshalWorkflow(wfWithTmplRef)
+		cancel, controller := newController(wf, wfv1.MustUnmarshalWorkflowTemplate(wfTmpl), canaryOf("all"))
+		defer cancel()
+		woc := newWorkflowOperationCtx(wf, controller)
+		woc.operate(ctx)
+		assert.Equal(t, wfutil.WorkflowTemplateVersionStable, woc.wf.Labels[common.LabelKeyWorkflowTemplateVersion])
+		assert.Equal(t, "77", woc.globalParams["workflow.priority"])
+	})
+	t.Run("CanaryGone", func(t *testing.T) {
+		wf := wfv1.MustUnmarshalWorkflow(wfWithTmplRef)
+		wf.Labels = map[string]string{common.LabelKeyWorkflowTemplateVersion: wfutil.WorkflowTemplateVersionCanary}
+		cancel, controller := newController(wf, wfv1.MustUnmarshalWorkflowTemplate(wfTmpl))
+		defer cancel()
+		woc := newWorkflowOperationCtx(wf, controller)
+		woc.operate(ctx)
+		assert.Equal(t, wfutil.WorkflowTemplateVersionCanary, woc.wf.Labels[common.LabelKeyWorkflowTemplateVersion])
+		assert.Equal(t, "77", woc.globalParams["workflow.priority"])
+	})
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/argoproj/argo-workflows/v3/workflow/common"
 	"github.com/argoproj/argo-workflows/v3/workflow/controller/entrypoint"
 	"github.com/argoproj/argo-workflows/v3/workflow/controller/indexes"
+	"github.com/argoproj/argo-workflows/v3/workflow/metrics"
 	"github.com/argoproj/argo-workflows/v3/workflow/util"
 )
 
@@ -93,12 +94,17 @@ func (woc *wfOperationCtx) createWorkflowPod(ctx context.Context, nodeName strin
 		return existing, nil
 	}
 
-	if !woc.GetShutdownStrategy().ShouldExecute(opts.onExitPod) {
+	if !woc.shouldExecute(opts.onExitPod) {
 		// Do not create pods if we are shutting down
 		woc.markNodePhase(nodeName, wfv1.NodeSkipped, fmt.Sprintf("workflow shutdown with strategy: %s", woc.GetShutdownStrategy()))
 		return nil, nil
 	}
 
+	if opts.onExitPod && woc.exitHooksDeadlineExceeded() {
+		woc.markNodePhase(nodeName, wfv1.NodeFailed, exitHooksDeadlineExceededMessage)
+		return nil, nil
+	}
+
 	tmpl = tmpl.DeepCopy()
 	wfSpec := woc.execWf.Spec.DeepCopy()
 
@@ -134,7 +140,18 @@ func (woc *wfOperationCtx) createWorkflowPod(ctx context.Context, nodeName strin
 	if err != nil {
 		return nil, err
 	}
-	if wfDeadline == nil || opts.onExitPod { // ignore the workflow deadline for exit handler so they still run if the deadline has passed
+	if opts.onExitPod { // ignore the workflow deadline for exit handler so they still run if the deadline has passed
+		activeDeadlineSeconds = tmplActiveDeadlineSeconds
+		if exitHooksDeadline := woc.wf.Status.ExitHooksDeadline; exitHooksDeadline != nil {
+			exitHooksActiveDeadlineSeconds := int64(time.Until(exitHooksDeadline.Time).Seconds())
+			if exitHooksActiveDeadlineSeconds <= 0 {
+				woc.markNodePhase(nodeName, wfv1.NodeFailed, exitHooksDeadlineExceededMessage)
+				return nil, nil
+			} else if tmplActiveDeadlineSeconds == nil || exitHooksActiveDeadlineSeconds < *tmplActiveDeadlineSeconds {
+				activeDeadlineSeconds = &exitHooksActiveDeadlineSeconds
+			}
+		}
+	} else if wfDeadline == nil {
 		activeDeadlineSeconds = tmplActiveDeadlineSeconds
 	} else {
 		wfActiveDeadlineSeconds := int64((*wfDeadline).Sub(time.Now().UTC()).Seconds())
@@ -161,8 +178,9 @@ func (woc *wfOperationCtx) createWorkflowPod(ctx context.Context, nodeName strin
 			Name:      util.GeneratePodName(woc.wf.Name, nodeName, tmpl.Name, nodeID, util.GetWorkflowPodNameVersion(woc.wf)),
 			Namespace: woc.wf.ObjectMeta.Namespace,
 			Labels: map[string]string{
-				common.LabelKeyWorkflow:  woc.wf.ObjectMeta.Name, // Allows filtering by pods related to specific workflow
-				common.LabelKeyCompleted: "false",                // Allows filtering by incomplete workflow pods
+				common.LabelKeyWorkflow:    woc.wf.ObjectMeta.Name,        // Allows filtering by pods related to specific workflow
+				common.LabelKeyWorkflowUID: string(woc.wf.ObjectMeta.UID), // Allows finding pods whose workflow no longer exists
+				common.LabelKeyCompleted:   "false",                       // Allows filtering by incomplete workflow pods
 			},
 			Annotations: map[string]string{
 				common.AnnotationKeyNodeName: nodeName,
@@ -180,6 +198,14 @@ func (woc *wfOperationCtx) createWorkflowPod(ctx context.Context, nodeName strin
 		},
 	}
 
+	if tmpl.Critical {
+		pod.ObjectMeta.Labels[common.LabelKeyCritical] = "true"
+	}
+
+	if woc.hasArtifactTransfers(tmpl) {
+		pod.ObjectMeta.Labels[common.LabelKeyArtifacts] = "true"
+	}
+
 	if opts.onExitPod {
 		// This pod is part of an onExit handler, label it so
 		pod.ObjectMeta.Labels[common.LabelKeyOnExit] = "true"
@@ -255,6 +281,7 @@ func (woc *wfOperationCtx) createWorkflowPod(ctx context.Context, nodeName strin
 
 	addSchedulingConstraints(pod, wfSpec, tmpl)
 	woc.addMetadata(pod, tmpl)
+	woc.addKueueLabels(pod)
 
 	err = addVolumeReferences(pod, woc.volumes, tmpl, woc.wf.Status.PersistentVolumeClaims)
 	if err != nil {
@@ -266,6 +293,23 @@ func (woc *wfOperationCtx) createWorkflowPod(ctx context.Context, nodeName strin
 		return nil, err
 	}
 
+	err = woc.addArtifactMounts(pod, tmpl)
+	if err != nil {
+		return nil, err
+	}
+
+	woc.addArtifactCacheVolume(pod, tmpl)
+
+	if tmpl.ArtifactCredentials {
+		if err := woc.addArtifactCredentials(ctx, pod); err != nil {
+			return nil, err
+		}
+	}
+
+	deadline := woc.getDeadline(opts)
+	addDeadlineVolume(pod, *deadline)
+	addSignalVolume(pod)
+
 	if tmpl.GetType() == wfv1.TemplateTypeScript {
 		addScriptStagingVolume(pod)
 	}
@@ -276,6 +320,12 @@ func (woc *wfOperationCtx) createWorkflowPod(ctx context.Context, nodeName strin
 	addSidecars(pod, tmpl)
 	addOutputArtifactsVolumes(pod, tmpl)
 
+	if tmpl.NUMA != nil {
+		if err := applyNUMA(pod, tmpl.NUMA, mainCtrs); err != nil {
+			return nil, err
+		}
+	}
+
 	for i, c := range pod.Spec.InitContainers {
 		c.VolumeMounts = append(c.VolumeMounts, volumeMountVarArgo)
 		pod.Spec.InitContainers[i] = c
@@ -288,7 +338,7 @@ func (woc *wfOperationCtx) createWorkflowPod(ctx context.Context, nodeName strin
 		{Name: common.EnvVarTemplate, Value: envVarTemplateValue},
 		{Name: common.EnvVarNodeID, Value: nodeID},
 		{Name: common.EnvVarIncludeScriptOutput, Value: strconv.FormatBool(opts.includeScriptOutput)},
-		{Name: common.EnvVarDeadline, Value: woc.getDeadline(opts).Format(time.RFC3339)},
+		{Name: common.EnvVarDeadline, Value: deadline.Format(time.RFC3339)},
 		{Name: common.EnvVarProgressFile, Value: common.ArgoProgressPath},
 	}
 
@@ -371,12 +421,14 @@ func (woc *wfOperationCtx) createWorkflowPod(ctx context.Context, nodeName strin
 		pod.Spec = *patchedPodSpec
 	}
 
+	woc.addRegistryPullSecrets(pod)
+
 	for i, c := range pod.Spec.Containers {
 		if c.Name != common.WaitContainerName {
 			// https://kubernetes.io/docs/tasks/inject-data-application/define-command-argument-container/#notes
 			if len(c.Command) == 0 {
 				x, err := woc.controller.entrypoint.Lookup(ctx, c.Image, entrypoint.Options{
-					Namespace: woc.wf.Namespace, ServiceAccountName: woc.execWf.Spec.ServiceAccountName, ImagePullSecrets: woc.execWf.Spec.ImagePullSecrets,
+					Namespace: woc.wf.Namespace, ServiceAccountName: woc.execWf.Spec.ServiceAccountName, ImagePullSecrets: pod.Spec.ImagePullSecrets,
 				})
 				if err != nil {
 					return nil, fmt.Errorf("failed to look-up entrypoint/cmd for image %q, you must either explicitly specify the command, or list the image's command in the index: https://argoproj.github.io/argo-workflows/workflow-executors/#emissary-emissary: %w", c.Image, err)
@@ -405,6 +457,18 @@ func (woc *wfOperationCtx) createWorkflowPod(ctx context.Context, nodeName strin
 		pod.Spec.Containers[i] = c
 	}
 
+	if tmpl.SecurityProfile != "" {
+		if err := woc.applySecurityProfile(pod, tmpl.SecurityProfile); err != nil {
+			return nil, err
+		}
+	}
+
+	if woc.execWf.Spec.ImagePreflight != nil {
+		if err := woc.resolvePodImages(ctx, pod); err != nil {
+			return nil, err
+		}
+	}
+
 	// Check if the template has exceeded its timeout duration. If it hasn't set the applicable activeDeadlineSeconds
 	node, err := woc.wf.GetNodeByName(nodeName)
 	if err != nil {
@@ -428,7 +492,8 @@ func (woc *wfOperationCtx) createWorkflowPod(ctx context.Context, nodeName strin
 		pod.Spec.ActiveDeadlineSeconds = &newActiveDeadlineSeconds
 	}
 
-	if !woc.controller.rateLimiter.Allow() {
+	if allowed, scope := woc.controller.rateLimiter.Allow(woc.wf.Namespace, string(woc.wf.UID)); !allowed {
+		metrics.PodCreationRateLimitedMetric.WithLabelValues(scope, woc.wf.Namespace).Inc()
 		return nil, ErrResourceRateLimitReached
 	}
 
@@ -450,6 +515,9 @@ func (woc *wfOperationCtx) createWorkflowPod(ctx context.Context, nodeName strin
 	}
 	woc.log.Infof("Created pod: %s (%s)", nodeName, created.Name)
 	woc.activePods++
+	if pod.Labels[common.LabelKeyArtifacts] == "true" {
+		woc.activeArtifactPods++
+	}
 	return created, nil
 }
 
@@ -478,7 +546,12 @@ func (woc *wfOperationCtx) podExists(nodeID string) (existing *apiv1.Pod, exists
 
 func (woc *wfOperationCtx) getDeadline(opts *createWorkflowPodOpts) *time.Time {
 	deadline := time.Time{}
-	if woc.workflowDeadline != nil {
+	if opts.onExitPod {
+		// exit handlers are not subject to the workflow deadline, only the exit hooks deadline
+		if woc.wf.Status.ExitHooksDeadline != nil {
+			deadline = woc.wf.Status.ExitHooksDeadline.Time
+		}
+	} else if woc.workflowDeadline != nil {
 		deadline = *woc.workflowDeadline
 	}
 	if !opts.executionDeadline.IsZero() && (deadline.IsZero() || opts.executionDeadline.Before(deadline)) {
@@ -564,6 +637,16 @@ func (woc *wfOperationCtx) createEnvVars() []apiv1.EnvVar {
 			apiv1.EnvVar{Name: common.EnvVarInstanceID, Value: v},
 		)
 	}
+	if c := woc.controller.Config.ArtifactCache; c != nil {
+		execEnvVars = append(execEnvVars,
+			apiv1.EnvVar{Name: common.EnvVarArtifactCacheMaxSize, Value: strconv.FormatInt(c.GetMaxSize(), 10)},
+		)
+	}
+	if rate := woc.controller.faultInjector.GetArtifactErrorRate(woc.wf.Namespace); rate > 0 {
+		execEnvVars = append(execEnvVars,
+			apiv1.EnvVar{Name: common.EnvVarFaultInjectionArtifactErrorRate, Value: strconv.FormatFloat(rate, 'f', -1, 64)},
+		)
+	}
 	if woc.controller.Config.Executor != nil {
 		execEnvVars = append(execEnvVars, woc.controller.Config.Executor.Env...)
 	}
@@ -669,6 +752,25 @@ func (woc *wfOperationCtx) addMetadata(pod *apiv1.Pod, tmpl *wfv1.Template) {
 	}
 }
 
+// addKueueLabels labels the pod with the Kueue queue and workload priority class of its workflow, or of the
+// configuration, unless its metadata already names them
+func (woc *wfOperationCtx) addKueueLabels(pod *apiv1.Pod) {
+	kueue := woc.controller.Config.Kueue
+	for key, defaultValue := range map[string]string{
+		common.LabelKeyKueueQueueName:     kueue.GetQueueName(),
+		common.LabelKeyKueuePriorityClass: kueue.GetWorkloadPriorityClassName(),
+	} {
+		if _, ok := pod.Labels[key]; ok {
+			continue
+		}
+		if v, ok := woc.wf.Labels[key]; ok {
+			pod.Labels[key] = v
+		} else if defaultValue != "" {
+			pod.Labels[key] = defaultValue
+		}
+	}
+}
+
 // addSchedulingConstraints applies any node selectors or affinity rules to the pod, either set in the workflow or the template
 func addSchedulingConstraints(pod *apiv1.Pod, wfSpec *wfv1.WorkflowSpec, tmpl *wfv1.Template) {
 	// Set nodeSelector (if specified)
@@ -887,6 +989,10 @@ func (woc *wfOperationCtx) addInputArtifactsVolumes(pod *apiv1.Pod, tmpl *wfv1.T
 					art.Name, art.Path)
 				continue
 			}
+			if art.Mount {
+				// mounted by addArtifactMounts instead
+				continue
+			}
 			overlap := common.FindOverlappingVolume(tmpl, art.Path)
 			if overlap != nil {
 				// artifact path overlaps with a mounted volume. do not mount the
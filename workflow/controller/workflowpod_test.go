@@ -732,10 +732,11 @@ func TestVolumeAndVolumeMounts(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Len(t, pods.Items, 1)
 		pod := pods.Items[0]
-		if assert.Len(t, pod.Spec.Volumes, 3) {
+		if assert.Len(t, pod.Spec.Volumes, 4) {
 			assert.Equal(t, "var-run-argo", pod.Spec.Volumes[0].Name)
 			assert.Equal(t, "tmp-dir-argo", pod.Spec.Volumes[1].Name)
 			assert.Equal(t, "volume-name", pod.Spec.Volumes[2].Name)
+			assert.Equal(t, "argo-signal", pod.Spec.Volumes[3].Name)
 		}
 		if assert.Len(t, pod.Spec.InitContainers, 1) {
 			init := pod.Spec.InitContainers[0]
@@ -746,10 +747,11 @@ func TestVolumeAndVolumeMounts(t *testing.T) {
 		containers := pod.Spec.Containers
 		if assert.Len(t, containers, 2) {
 			wait := containers[0]
-			if assert.Len(t, wait.VolumeMounts, 3) {
-				assert.Equal(t, "volume-name", wait.VolumeMounts[0].Name)
-				assert.Equal(t, "tmp-dir-argo", wait.VolumeMounts[1].Name)
-				assert.Equal(t, "var-run-argo", wait.VolumeMounts[2].Name)
+			if assert.Len(t, wait.VolumeMounts, 4) {
+				assert.Equal(t, "argo-signal", wait.VolumeMounts[0].Name)
+				assert.Equal(t, "volume-name", wait.VolumeMounts[1].Name)
+				assert.Equal(t, "tmp-dir-argo", wait.VolumeMounts[2].Name)
+				assert.Equal(t, "var-run-argo", wait.VolumeMounts[3].Name)
 			}
 			main := containers[1]
 			assert.Equal(t, []string{"/var/run/argo/argoexec", "emissary",
@@ -801,11 +803,11 @@ func TestVolumesPodSubstitution(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Len(t, pods.Items, 1)
 	pod := pods.Items[0]
-	assert.Equal(t, 3, len(pod.Spec.Volumes))
+	assert.Equal(t, 4, len(pod.Spec.Volumes))
 	assert.Equal(t, "volume-name", pod.Spec.Volumes[2].Name)
 	assert.Equal(t, "test-name", pod.Spec.Volumes[2].PersistentVolumeClaim.ClaimName)
 	assert.Equal(t, 2, len(pod.Spec.Containers[1].VolumeMounts))
-	assert.Equal(t, "volume-name", pod.Spec.Containers[0].VolumeMounts[0].Name)
+	assert.Equal(t, "volume-name", pod.Spec.Containers[0].VolumeMounts[1].Name)
 }
 
 func TestOutOfCluster(t *testing.T) {
@@ -1649,6 +1651,41 @@ func TestPodMetadata(t *testing.T) {
 	assert.Equal(t, "world", pod.ObjectMeta.Labels["template-level-pod-label"])
 }
 
+func TestPodKueueLabels(t *testing.T) {
+	ctx := context.Background()
+	t.Run("NotConfigured", func(t *testing.T) {
+		wf := wfv1.MustUnmarshalWorkflow(helloWorldWf)
+		woc := newWoc(*wf)
+		mainCtr := woc.execWf.Spec.Templates[0].Container
+		pod, err := woc.createWorkflowPod(ctx, wf.Name, []apiv1.Container{*mainCtr}, &wf.Spec.Templates[0], &createWorkflowPodOpts{})
+		require.NoError(t, err)
+		assert.NotContains(t, pod.Labels, common.LabelKeyKueueQueueName)
+		assert.NotContains(t, pod.Labels, common.LabelKeyKueuePriorityClass)
+	})
+	t.Run("Config", func(t *testing.T) {
+		wf := wfv1.MustUnmarshalWorkflow(helloWorldWf)
+		woc := newWoc(*wf)
+		woc.controller.Config.Kueue = &config.Kueue{QueueName: "default-queue", WorkloadPriorityClassName: "low"}
+		mainCtr := woc.execWf.Spec.Templates[0].Container
+		pod, err := woc.createWorkflowPod(ctx, wf.Name, []apiv1.Container{*mainCtr}, &wf.Spec.Templates[0], &createWorkflowPodOpts{})
+		require.NoError(t, err)
+		assert.Equal(t, "default-queue", pod.Labels[common.LabelKeyKueueQueueName])
+		assert.Equal(t, "low", pod.Labels[common.LabelKeyKueuePriorityClass])
+	})
+	t.Run("WorkflowAndTemplateLabels", func(t *testing.T) {
+		wf := wfv1.MustUnmarshalWorkflow(helloWorldWf)
+		wf.Labels = map[string]string{common.LabelKeyKueueQueueName: "workflow-queue", common.LabelKeyKueuePriorityClass: "high"}
+		wf.Spec.Templates[0].Metadata.Labels = map[string]string{common.LabelKeyKueueQueueName: "template-queue"}
+		woc := newWoc(*wf)
+		woc.controller.Config.Kueue = &config.Kueue{QueueName: "default-queue", WorkloadPriorityClassName: "low"}
+		mainCtr := woc.execWf.Spec.Templates[0].Container
+		pod, err := woc.createWorkflowPod(ctx, wf.Name, []apiv1.Container{*mainCtr}, &wf.Spec.Templates[0], &createWorkflowPodOpts{})
+		require.NoError(t, err)
+		assert.Equal(t, "template-queue", pod.Labels[common.LabelKeyKueueQueueName])
+		assert.Equal(t, "high", pod.Labels[common.LabelKeyKueuePriorityClass])
+	})
+}
+
 var wfWithContainerSet = `
 apiVersion: argoproj.io/v1alpha1
 kind: Workflow
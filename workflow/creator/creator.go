@@ -2,6 +2,9 @@ package creator
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
 	"regexp"
 	"strings"
 
@@ -13,12 +16,16 @@ import (
 )
 
 func Label(ctx context.Context, obj metav1.Object) {
+	// a signature is only valid for the object the Argo Server signed, so one the user supplied is always removed
+	unannotate(obj, common.AnnotationKeyCreatorSignature)
 	claims := auth.GetClaims(ctx)
 	if claims != nil {
 		if claims.Subject != "" {
 			labels.Label(obj, common.LabelKeyCreator, dnsFriendly(claims.Subject))
+			annotate(obj, common.AnnotationKeyCreatorSubject, claims.Subject)
 		} else {
 			labels.UnLabel(obj, common.LabelKeyCreator)
+			unannotate(obj, common.AnnotationKeyCreatorSubject)
 		}
 		if claims.Email != "" {
 			labels.Label(obj, common.LabelKeyCreatorEmail, dnsFriendly(strings.Replace(claims.Email, "@", ".at.", 1)))
@@ -36,9 +43,28 @@ func Label(ctx context.Context, obj metav1.Object) {
 		labels.UnLabel(obj, common.LabelKeyCreator)
 		labels.UnLabel(obj, common.LabelKeyCreatorEmail)
 		labels.UnLabel(obj, common.LabelKeyCreatorPreferredUsername)
+		unannotate(obj, common.AnnotationKeyCreatorSubject)
 	}
 }
 
+func annotate(obj metav1.Object, name, value string) {
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[name] = value
+	obj.SetAnnotations(annotations)
+}
+
+func unannotate(obj metav1.Object, name string) {
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		return
+	}
+	delete(annotations, name)
+	obj.SetAnnotations(annotations)
+}
+
 // https://kubernetes.io/docs/concepts/overview/working-with-objects/labels/#syntax-and-character-set
 func dnsFriendly(s string) string {
 	value := regexp.MustCompile("[^-_.a-z0-9A-Z]").ReplaceAllString(s, "-")
@@ -67,3 +93,33 @@ func UserInfoMap(ctx context.Context) map[string]string {
 	}
 	return res
 }
+
+// Subject returns the subject of the user who created the object, as recorded by Label, or "" if it is not known, e.g.
+// because the object was not created through the Argo Server. As anyone who can create the object can set it, it
+// cannot be trusted unless VerifiedSubject verifies it.
+func Subject(obj metav1.Object) string {
+	return obj.GetAnnotations()[common.AnnotationKeyCreatorSubject]
+}
+
+// Signature returns the signature of the creator subject of the object that the Argo Server annotates the object with
+// once it is created, so that it can be told that the Argo Server recorded the subject. It is made with the key, and
+// covers the object's UID, so that it cannot be copied to another object.
+func Signature(obj metav1.Object, key []byte) string {
+	mac := hmac.New(sha256.New, key)
+	_, _ = mac.Write([]byte(string(obj.GetUID()) + "/" + Subject(obj)))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// VerifiedSubject returns the subject of the user who created the object if the Argo Server recorded it, according to
+// its signature made with the key, or "" if it did not
+func VerifiedSubject(obj metav1.Object, key []byte) string {
+	subject := Subject(obj)
+	signature := obj.GetAnnotations()[common.AnnotationKeyCreatorSignature]
+	if len(key) == 0 || subject == "" || obj.GetUID() == "" || signature == "" {
+		return ""
+	}
+	if !hmac.Equal([]byte(signature), []byte(Signature(obj, key))) {
+		return ""
+	}
+	return subject
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/go-jose/go-jose/v3/jwt"
 	"github.com/stretchr/testify/assert"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
 
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 	"github.com/argoproj/argo-workflows/v3/server/auth"
@@ -20,6 +21,7 @@ func TestLabel(t *testing.T) {
 		wf := &wfv1.Workflow{}
 		Label(context.TODO(), wf)
 		assert.Empty(t, wf.Labels)
+		assert.Empty(t, wf.Annotations)
 	})
 	t.Run("NotEmpty", func(t *testing.T) {
 		wf := &wfv1.Workflow{}
@@ -29,6 +31,7 @@ func TestLabel(t *testing.T) {
 			assert.Equal(t, "my.at.email", wf.Labels[common.LabelKeyCreatorEmail], "'@' is replaced by '.at.'")
 			assert.Equal(t, "username", wf.Labels[common.LabelKeyCreatorPreferredUsername], "username is matching")
 		}
+		assert.Equal(t, strings.Repeat("x", 63)+"y", wf.Annotations[common.AnnotationKeyCreatorSubject], "subject is not truncated")
 	})
 	t.Run("TooLongHyphen", func(t *testing.T) {
 		wf := &wfv1.Workflow{}
@@ -44,6 +47,7 @@ func TestLabel(t *testing.T) {
 			assert.Equal(t, strings.Repeat("y", 35), wf.Labels[common.LabelKeyCreator])
 			assert.Equal(t, "us-er-name", wf.Labels[common.LabelKeyCreatorPreferredUsername], "username is truncated")
 		}
+		assert.Equal(t, "!@#$%^&*()--__"+strings.Repeat("y", 35)+"__--!@#$%^&*()", wf.Annotations[common.AnnotationKeyCreatorSubject], "subject is not replaced")
 	})
 	t.Run("InvalidDNSNamesWithMidDashes", func(t *testing.T) {
 		wf := &wfv1.Workflow{}
@@ -61,6 +65,7 @@ func TestLabel(t *testing.T) {
 		type output struct {
 			creatorLabelsToHave    map[string]string
 			creatorLabelsNotToHave []string
+			creatorSubject         string
 		}
 		for _, testCase := range []struct {
 			name   string
@@ -75,7 +80,7 @@ func TestLabel(t *testing.T) {
 						common.LabelKeyCreator:                  "xxxx-xxxx-xxxx-xxxx",
 						common.LabelKeyCreatorEmail:             "foo.at.example.com",
 						common.LabelKeyCreatorPreferredUsername: "foo",
-					}}},
+					}, Annotations: map[string]string{common.AnnotationKeyCreatorSubject: "xxxx-xxxx-xxxx-xxxx"}}},
 				},
 				output: &output{
 					creatorLabelsToHave:    nil,
@@ -89,7 +94,7 @@ func TestLabel(t *testing.T) {
 						common.LabelKeyCreator:                  "xxxx-xxxx-xxxx-xxxx",
 						common.LabelKeyCreatorEmail:             "foo.at.example.com",
 						common.LabelKeyCreatorPreferredUsername: "foo",
-					}}},
+					}, Annotations: map[string]string{common.AnnotationKeyCreatorSubject: "xxxx-xxxx-xxxx-xxxx"}}},
 				},
 				output: &output{
 					creatorLabelsToHave:    nil,
@@ -103,7 +108,7 @@ func TestLabel(t *testing.T) {
 						common.LabelKeyCreator:                  "xxxx-xxxx-xxxx-xxxx",
 						common.LabelKeyCreatorEmail:             "foo.at.example.com",
 						common.LabelKeyCreatorPreferredUsername: "foo",
-					}}},
+					}, Annotations: map[string]string{common.AnnotationKeyCreatorSubject: "xxxx-xxxx-xxxx-xxxx"}}},
 				},
 				output: &output{
 					creatorLabelsToHave: map[string]string{
@@ -112,6 +117,7 @@ func TestLabel(t *testing.T) {
 						common.LabelKeyCreatorPreferredUsername: "bar",
 					},
 					creatorLabelsNotToHave: nil,
+					creatorSubject:         "yyyy-yyyy-yyyy-yyyy",
 				},
 			},
 		} {
@@ -125,6 +131,7 @@ func TestLabel(t *testing.T) {
 					_, ok := labels[creatorLabelKey]
 					assert.Falsef(t, ok, "should not have the creator label, \"%s\"", creatorLabelKey)
 				}
+				assert.Equal(t, testCase.output.creatorSubject, Subject(testCase.input.wf))
 			})
 
 		}
@@ -147,3 +154,40 @@ func TestUserInfoMap(t *testing.T) {
 		assert.Nil(t, uim)
 	})
 }
+
+func TestVerifiedSubject(t *testing.T) {
+	key := []byte("my-key")
+	signed := func(uid k8stypes.UID, subject string, key []byte) *metav1.ObjectMeta {
+		obj := &metav1.ObjectMeta{UID: uid, Annotations: map[string]string{common.AnnotationKeyCreatorSubject: subject}}
+		obj.Annotations[common.AnnotationKeyCreatorSignature] = Signature(obj, key)
+		return obj
+	}
+	t.Run("Signed", func(t *testing.T) {
+		assert.Equal(t, "my@sub", VerifiedSubject(signed("my-uid", "my@sub", key), key))
+	})
+	t.Run("Unsigned", func(t *testing.T) {
+		obj := &metav1.ObjectMeta{UID: "my-uid", Annotations: map[string]string{common.AnnotationKeyCreatorSubject: "my@sub"}}
+		assert.Empty(t, VerifiedSubject(obj, key))
+	})
+	t.Run("OtherKey", func(t *testing.T) {
+		assert.Empty(t, VerifiedSubject(signed("my-uid", "my@sub", []byte("other-key")), key))
+	})
+	t.Run("NoKey", func(t *testing.T) {
+		assert.Empty(t, VerifiedSubject(signed("my-uid", "my@sub", nil), nil))
+	})
+	t.Run("ChangedSubject", func(t *testing.T) {
+		obj := signed("my-uid", "my@sub", key)
+		obj.Annotations[common.AnnotationKeyCreatorSubject] = "other-sub"
+		assert.Empty(t, VerifiedSubject(obj, key))
+	})
+	t.Run("CopiedToOtherObject", func(t *testing.T) {
+		obj := signed("my-uid", "my@sub", key)
+		obj.UID = "other-uid"
+		assert.Empty(t, VerifiedSubject(obj, key))
+	})
+	t.Run("LabelRemovesSignature", func(t *testing.T) {
+		obj := signed("my-uid", "my@sub", key)
+		Label(context.WithValue(context.TODO(), auth.ClaimsKey, &types.Claims{Claims: jwt.Claims{Subject: "my@sub"}}), obj)
+		assert.NotContains(t, obj.Annotations, common.AnnotationKeyCreatorSignature)
+	})
+}
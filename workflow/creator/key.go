@@ -0,0 +1,43 @@
+package creator
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+
+	apiv1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+)
+
+const (
+	keySecretName = "argo-server-creator-key" // where the Argo Server stores the key it signs creators with
+	keySecretKey  = "key"                     // the key name for the key in the secret
+)
+
+// Key returns the key the Argo Server signs the creators of the workflows it creates with, generating it and storing it
+// in a secret the first time, so that every replica of the Argo Server uses the same key
+func Key(ctx context.Context, secretsIf corev1.SecretInterface) ([]byte, error) {
+	generatedKey := make([]byte, 32)
+	if _, err := rand.Read(generatedKey); err != nil {
+		return nil, fmt.Errorf("failed to generate key: %w", err)
+	}
+	// creating the secret fails if another replica already created it, in which case its key is used
+	_, err := secretsIf.Create(ctx, &apiv1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: keySecretName},
+		Data:       map[string][]byte{keySecretKey: generatedKey},
+	}, metav1.CreateOptions{})
+	if err != nil && !apierr.IsAlreadyExists(err) {
+		return nil, fmt.Errorf("failed to create secret: %w", err)
+	}
+	secret, err := secretsIf.Get(ctx, keySecretName, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read secret: %w", err)
+	}
+	key := secret.Data[keySecretKey]
+	if len(key) < 32 {
+		return nil, fmt.Errorf("key %s of secret %s must be at least 32 bytes, delete the secret and retry", keySecretKey, keySecretName)
+	}
+	return key, nil
+}
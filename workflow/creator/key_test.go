@@ -0,0 +1,32 @@
+package creator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestKey(t *testing.T) {
+	t.Run("Generated", func(t *testing.T) {
+		secretsIf := fake.NewSimpleClientset().CoreV1().Secrets("argo")
+		key, err := Key(context.TODO(), secretsIf)
+		require.NoError(t, err)
+		assert.Len(t, key, 32)
+		again, err := Key(context.TODO(), secretsIf)
+		require.NoError(t, err)
+		assert.Equal(t, key, again, "the stored key is used once it exists")
+	})
+	t.Run("TooShort", func(t *testing.T) {
+		secretsIf := fake.NewSimpleClientset(&apiv1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "argo-server-creator-key", Namespace: "argo"},
+			Data:       map[string][]byte{"key": []byte("short")},
+		}).CoreV1().Secrets("argo")
+		_, err := Key(context.TODO(), secretsIf)
+		assert.EqualError(t, err, "key key of secret argo-server-creator-key must be at least 32 bytes, delete the secret and retry")
+	})
+}
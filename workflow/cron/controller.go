@@ -189,13 +189,28 @@ func (cc *Controller) processNextCronItem(ctx context.Context) bool {
 	// The job is currently scheduled, remove it and re add it.
 	cc.cron.Delete(key.(string))
 
-	lastScheduledTimeFunc, err := cc.cron.AddJob(key.(string), cronWf.Spec.GetScheduleString(), cronWorkflowOperationCtx)
-	if err != nil {
-		logCtx.WithError(err).Error("could not schedule CronWorkflow")
-		return true
-	}
+	if len(cronWf.Spec.Schedules) > 0 {
+		// Each named schedule is a job of its own, that runs the workflow with the schedule's parameters
+		schedules := cronWf.Spec.GetScheduleStrings()
+		for i, schedule := range cronWf.Spec.Schedules {
+			job := &scheduleJob{woc: cronWorkflowOperationCtx, name: schedule.Name, scheduledTimeFunc: inferScheduledTime}
+			lastScheduledTimeFunc, err := cc.cron.AddJob(key.(string), schedules[i], job)
+			if err != nil {
+				cc.cron.Delete(key.(string))
+				logCtx.WithError(err).WithField("schedule", schedule.Name).Error("could not schedule CronWorkflow")
+				return true
+			}
+			job.scheduledTimeFunc = lastScheduledTimeFunc
+		}
+	} else {
+		lastScheduledTimeFunc, err := cc.cron.AddJob(key.(string), cronWf.Spec.GetScheduleString(), cronWorkflowOperationCtx)
+		if err != nil {
+			logCtx.WithError(err).Error("could not schedule CronWorkflow")
+			return true
+		}
 
-	cronWorkflowOperationCtx.scheduledTimeFunc = lastScheduledTimeFunc
+		cronWorkflowOperationCtx.scheduledTimeFunc = lastScheduledTimeFunc
+	}
 
 	logCtx.Infof("CronWorkflow %s added", key.(string))
 
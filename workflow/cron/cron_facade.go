@@ -14,7 +14,7 @@ import (
 type cronFacade struct {
 	mu       sync.Mutex
 	cron     *cron.Cron
-	entryIDs map[string]cron.EntryID
+	entryIDs map[string][]cron.EntryID
 }
 
 type ScheduledTimeFunc func() time.Time
@@ -22,7 +22,7 @@ type ScheduledTimeFunc func() time.Time
 func newCronFacade() *cronFacade {
 	return &cronFacade{
 		cron:     cron.New(),
-		entryIDs: make(map[string]cron.EntryID),
+		entryIDs: make(map[string][]cron.EntryID),
 	}
 }
 
@@ -34,25 +34,29 @@ func (f *cronFacade) Stop() {
 	f.cron.Stop()
 }
 
+// Delete removes all the jobs of the key
 func (f *cronFacade) Delete(key string) {
 	f.mu.Lock()
 	defer f.mu.Unlock()
-	entryID, ok := f.entryIDs[key]
+	entryIDs, ok := f.entryIDs[key]
 	if !ok {
 		return
 	}
-	f.cron.Remove(entryID)
+	for _, entryID := range entryIDs {
+		f.cron.Remove(entryID)
+	}
 	delete(f.entryIDs, key)
 }
 
-func (f *cronFacade) AddJob(key, schedule string, cwoc *cronWfOperationCtx) (ScheduledTimeFunc, error) {
+// AddJob adds a job of the key, in addition to any it already has, e.g. one for each named schedule of a cron workflow
+func (f *cronFacade) AddJob(key, schedule string, job cron.Job) (ScheduledTimeFunc, error) {
 	f.mu.Lock()
 	defer f.mu.Unlock()
-	entryID, err := f.cron.AddJob(schedule, cwoc)
+	entryID, err := f.cron.AddJob(schedule, job)
 	if err != nil {
 		return nil, err
 	}
-	f.entryIDs[key] = entryID
+	f.entryIDs[key] = append(f.entryIDs[key], entryID)
 
 	// Return a function to return the last scheduled time
 	return func() time.Time {
@@ -63,14 +67,17 @@ func (f *cronFacade) AddJob(key, schedule string, cwoc *cronWfOperationCtx) (Sch
 func (f *cronFacade) Load(key string) (*cronWfOperationCtx, error) {
 	f.mu.Lock()
 	defer f.mu.Unlock()
-	entryID, ok := f.entryIDs[key]
-	if !ok {
+	entryIDs, ok := f.entryIDs[key]
+	if !ok || len(entryIDs) == 0 {
 		return nil, fmt.Errorf("entry ID for %s not found", key)
 	}
-	entry := f.cron.Entry(entryID).Job
-	cwoc, ok := entry.(*cronWfOperationCtx)
-	if !ok {
+	entry := f.cron.Entry(entryIDs[0]).Job
+	switch job := entry.(type) {
+	case *cronWfOperationCtx:
+		return job, nil
+	case *scheduleJob:
+		return job.woc, nil
+	default:
 		return nil, fmt.Errorf("job entry ID for %s was not a *cronWfOperationCtx, was %v", key, reflect.TypeOf(entry))
 	}
-	return cwoc, nil
 }
@@ -5,10 +5,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"sort"
+	"sync"
 	"time"
 
 	"github.com/robfig/cron/v3"
 	log "github.com/sirupsen/logrus"
+	"golang.org/x/exp/slices"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -30,6 +32,8 @@ import (
 )
 
 type cronWfOperationCtx struct {
+	// mu serializes the runs of the named schedules, which the cron engine may start at the same time
+	mu sync.Mutex
 	// CronWorkflow is the CronWorkflow to be run
 	name            string
 	cronWf          *v1alpha1.CronWorkflow
@@ -72,13 +76,31 @@ func newCronWfOperationCtx(cronWorkflow *v1alpha1.CronWorkflow, wfClientset vers
 // It fits the github.com/robfig/cron.Job interface
 func (woc *cronWfOperationCtx) Run() {
 	ctx := context.Background()
-	woc.run(ctx, woc.scheduledTimeFunc())
+	woc.run(ctx, "", woc.scheduledTimeFunc())
 }
 
-func (woc *cronWfOperationCtx) run(ctx context.Context, scheduledRuntime time.Time) {
+// scheduleJob runs the workflow of a named schedule of a cron workflow
+// It fits the github.com/robfig/cron.Job interface
+type scheduleJob struct {
+	woc  *cronWfOperationCtx
+	name string
+	// scheduledTimeFunc returns the last scheduled time of the schedule when it is called
+	scheduledTimeFunc ScheduledTimeFunc
+}
+
+func (j *scheduleJob) Run() {
+	ctx := context.Background()
+	j.woc.run(ctx, j.name, j.scheduledTimeFunc())
+}
+
+// run submits a workflow for the named schedule, or for the schedule if the name is empty
+func (woc *cronWfOperationCtx) run(ctx context.Context, scheduleName string, scheduledRuntime time.Time) {
+	woc.mu.Lock()
+	defer woc.mu.Unlock()
 	defer woc.persistUpdate(ctx)
 
-	woc.log.Infof("Running %s", woc.name)
+	woc.log.WithField("schedule", scheduleName).Infof("Running %s", woc.name)
+	woc.pruneScheduleStatuses()
 
 	// If the cron workflow has a schedule that was just updated, update its annotation
 	if woc.cronWf.IsUsingNewSchedule() {
@@ -90,7 +112,7 @@ func (woc *cronWfOperationCtx) run(ctx context.Context, scheduledRuntime time.Ti
 		return
 	}
 
-	proceed, err := woc.enforceRuntimePolicy(ctx)
+	proceed, err := woc.enforceRuntimePolicy(ctx, woc.activeWorkflows(scheduleName))
 	if err != nil {
 		woc.reportCronWorkflowError(v1alpha1.ConditionTypeSubmissionError, fmt.Sprintf("Concurrency policy error: %s", err))
 		return
@@ -98,7 +120,10 @@ func (woc *cronWfOperationCtx) run(ctx context.Context, scheduledRuntime time.Ti
 		return
 	}
 
-	wf := common.ConvertCronWorkflowToWorkflowWithProperties(woc.cronWf, getChildWorkflowName(woc.cronWf.Name, scheduledRuntime), scheduledRuntime)
+	wf := common.ConvertCronWorkflowToWorkflowWithProperties(woc.cronWf, getChildWorkflowName(woc.cronWf.Name, scheduleName, scheduledRuntime), scheduledRuntime)
+	if schedule := woc.cronWf.Spec.GetSchedule(scheduleName); schedule != nil {
+		applySchedule(wf, schedule)
+	}
 
 	runWf, err := util.SubmitWorkflow(ctx, woc.wfClient, woc.wfClientset, woc.cronWf.Namespace, wf, &v1alpha1.SubmitOpts{})
 	if err != nil {
@@ -112,9 +137,55 @@ func (woc *cronWfOperationCtx) run(ctx context.Context, scheduledRuntime time.Ti
 
 	woc.cronWf.Status.Active = append(woc.cronWf.Status.Active, getWorkflowObjectReference(wf, runWf))
 	woc.cronWf.Status.LastScheduledTime = &v1.Time{Time: scheduledRuntime}
+	if scheduleName != "" {
+		status := woc.cronWf.Status.GetSchedule(scheduleName)
+		status.Active = append(status.Active, getWorkflowObjectReference(wf, runWf))
+		status.LastScheduledTime = &v1.Time{Time: scheduledRuntime}
+	}
 	woc.cronWf.Status.Conditions.RemoveCondition(v1alpha1.ConditionTypeSubmissionError)
 }
 
+// applySchedule labels the workflow with the name of the schedule, and overrides its arguments with the schedule's
+// parameters
+func applySchedule(wf *v1alpha1.Workflow, schedule *v1alpha1.CronSchedule) {
+	wf.Labels[common.LabelKeyCronWorkflowSchedule] = schedule.Name
+	// copied, so that the cron workflow's arguments are not changed
+	parameters := append([]v1alpha1.Parameter{}, wf.Spec.Arguments.Parameters...)
+	for _, p := range schedule.Parameters {
+		i := slices.IndexFunc(parameters, func(q v1alpha1.Parameter) bool { return q.Name == p.Name })
+		if i < 0 {
+			parameters = append(parameters, p)
+		} else {
+			parameters[i] = p
+		}
+	}
+	wf.Spec.Arguments.Parameters = parameters
+}
+
+// activeWorkflows returns the active workflows of the named schedule, or of the cron workflow if the name is empty
+func (woc *cronWfOperationCtx) activeWorkflows(scheduleName string) []corev1.ObjectReference {
+	if scheduleName == "" {
+		return woc.cronWf.Status.Active
+	}
+	for _, status := range woc.cronWf.Status.Schedules {
+		if status.Name == scheduleName {
+			return status.Active
+		}
+	}
+	return nil
+}
+
+// pruneScheduleStatuses removes the statuses of the named schedules that were removed from the spec
+func (woc *cronWfOperationCtx) pruneScheduleStatuses() {
+	var statuses []v1alpha1.CronScheduleStatus
+	for _, status := range woc.cronWf.Status.Schedules {
+		if woc.cronWf.Spec.GetSchedule(status.Name) != nil {
+			statuses = append(statuses, status)
+		}
+	}
+	woc.cronWf.Status.Schedules = statuses
+}
+
 func (woc *cronWfOperationCtx) validateCronWorkflow() error {
 	wftmplGetter := informer.NewWorkflowTemplateFromInformerGetter(woc.wftmplInformer, woc.cronWf.ObjectMeta.Namespace)
 	cwftmplGetter := informer.NewClusterWorkflowTemplateFromInformerGetter(woc.cwftmplInformer)
@@ -145,7 +216,7 @@ func (woc *cronWfOperationCtx) persistUpdate(ctx context.Context) {
 }
 
 func (woc *cronWfOperationCtx) persistUpdateActiveWorkflows(ctx context.Context) {
-	woc.patch(ctx, map[string]interface{}{"status": map[string]interface{}{"active": woc.cronWf.Status.Active}})
+	woc.patch(ctx, map[string]interface{}{"status": map[string]interface{}{"active": woc.cronWf.Status.Active, "lastSuccessfulRunOutputs": woc.cronWf.Status.LastSuccessfulRunOutputs, "schedules": woc.cronWf.Status.Schedules}})
 }
 
 func (woc *cronWfOperationCtx) patch(ctx context.Context, patch map[string]interface{}) {
@@ -168,7 +239,8 @@ func (woc *cronWfOperationCtx) patch(ctx context.Context, patch map[string]inter
 	}
 }
 
-func (woc *cronWfOperationCtx) enforceRuntimePolicy(ctx context.Context) (bool, error) {
+// enforceRuntimePolicy returns whether a workflow may be run, given the active workflows of its schedule
+func (woc *cronWfOperationCtx) enforceRuntimePolicy(ctx context.Context, active []corev1.ObjectReference) (bool, error) {
 	if woc.cronWf.Spec.Suspend {
 		woc.log.Infof("%s is suspended, skipping execution", woc.name)
 		return false, nil
@@ -179,14 +251,14 @@ func (woc *cronWfOperationCtx) enforceRuntimePolicy(ctx context.Context) (bool,
 		case v1alpha1.AllowConcurrent, "":
 			// Do nothing
 		case v1alpha1.ForbidConcurrent:
-			if len(woc.cronWf.Status.Active) > 0 {
+			if len(active) > 0 {
 				woc.log.Infof("%s has 'ConcurrencyPolicy: Forbid' and has an active Workflow so it was not run", woc.name)
 				return false, nil
 			}
 		case v1alpha1.ReplaceConcurrent:
-			if len(woc.cronWf.Status.Active) > 0 {
+			if len(active) > 0 {
 				woc.log.Infof("%s has 'ConcurrencyPolicy: Replace' and has active Workflows", woc.name)
-				err := woc.terminateOutstandingWorkflows(ctx)
+				err := woc.terminateOutstandingWorkflows(ctx, active)
 				if err != nil {
 					return false, err
 				}
@@ -198,8 +270,8 @@ func (woc *cronWfOperationCtx) enforceRuntimePolicy(ctx context.Context) (bool,
 	return true, nil
 }
 
-func (woc *cronWfOperationCtx) terminateOutstandingWorkflows(ctx context.Context) error {
-	for _, wfObjectRef := range woc.cronWf.Status.Active {
+func (woc *cronWfOperationCtx) terminateOutstandingWorkflows(ctx context.Context, active []corev1.ObjectReference) error {
+	for _, wfObjectRef := range active {
 		woc.log.Infof("stopping '%s'", wfObjectRef.Name)
 		err := util.TerminateWorkflow(ctx, woc.wfClient, wfObjectRef.Name)
 		if err != nil {
@@ -219,17 +291,34 @@ func (woc *cronWfOperationCtx) terminateOutstandingWorkflows(ctx context.Context
 }
 
 func (woc *cronWfOperationCtx) runOutstandingWorkflows(ctx context.Context) (bool, error) {
+	if len(woc.cronWf.Spec.Schedules) > 0 {
+		return woc.runOutstandingScheduleWorkflows(ctx)
+	}
 	missedExecutionTime, err := woc.shouldOutstandingWorkflowsBeRun()
 	if err != nil {
 		return false, err
 	}
 	if !missedExecutionTime.IsZero() {
-		woc.run(ctx, missedExecutionTime)
+		woc.run(ctx, "", missedExecutionTime)
 		return true, nil
 	}
 	return false, nil
 }
 
+// runOutstandingScheduleWorkflows runs a workflow for each of the named schedules that missed an execution
+func (woc *cronWfOperationCtx) runOutstandingScheduleWorkflows(ctx context.Context) (bool, error) {
+	missedExecutionTimes, err := woc.shouldOutstandingScheduleWorkflowsBeRun()
+	if err != nil {
+		return false, err
+	}
+	for _, schedule := range woc.cronWf.Spec.Schedules {
+		if missedExecutionTime, ok := missedExecutionTimes[schedule.Name]; ok {
+			woc.run(ctx, schedule.Name, missedExecutionTime)
+		}
+	}
+	return len(missedExecutionTimes) > 0, nil
+}
+
 func (woc *cronWfOperationCtx) shouldOutstandingWorkflowsBeRun() (time.Time, error) {
 	// If the CronWorkflow schedule was just updated, then do not run any outstanding workflows.
 	if woc.cronWf.IsUsingNewSchedule() {
@@ -237,44 +326,75 @@ func (woc *cronWfOperationCtx) shouldOutstandingWorkflowsBeRun() (time.Time, err
 	}
 	// If this CronWorkflow has been run before, check if we have missed any scheduled executions
 	if woc.cronWf.Status.LastScheduledTime != nil {
-		var now time.Time
-		var cronSchedule cron.Schedule
-		if woc.cronWf.Spec.Timezone != "" {
-			loc, err := time.LoadLocation(woc.cronWf.Spec.Timezone)
-			if err != nil {
-				return time.Time{}, fmt.Errorf("invalid timezone '%s': %s", woc.cronWf.Spec.Timezone, err)
-			}
-			now = time.Now().In(loc)
+		return woc.missedExecutionTime(woc.cronWf.Spec.GetScheduleString(), woc.cronWf.Status.LastScheduledTime.Time)
+	}
+	return time.Time{}, nil
+}
 
-			cronScheduleString := woc.cronWf.Spec.GetScheduleString()
-			cronSchedule, err = cron.ParseStandard(cronScheduleString)
-			if err != nil {
-				return time.Time{}, fmt.Errorf("unable to form timezone schedule '%s': %s", cronScheduleString, err)
-			}
-		} else {
-			var err error
-			now = time.Now()
-			cronSchedule, err = cron.ParseStandard(woc.cronWf.Spec.Schedule)
-			if err != nil {
-				return time.Time{}, err
+// shouldOutstandingScheduleWorkflowsBeRun returns the missed execution times of the named schedules that should be run,
+// by name
+func (woc *cronWfOperationCtx) shouldOutstandingScheduleWorkflowsBeRun() (map[string]time.Time, error) {
+	missedExecutionTimes := make(map[string]time.Time)
+	// If the CronWorkflow schedules were just updated, then do not run any outstanding workflows.
+	if woc.cronWf.IsUsingNewSchedule() {
+		return missedExecutionTimes, nil
+	}
+	schedules := woc.cronWf.Spec.GetScheduleStrings()
+	for i, schedule := range woc.cronWf.Spec.Schedules {
+		// If this schedule has been run before, check if we have missed any scheduled executions
+		var lastScheduledTime *v1.Time
+		for _, status := range woc.cronWf.Status.Schedules {
+			if status.Name == schedule.Name {
+				lastScheduledTime = status.LastScheduledTime
 			}
 		}
+		if lastScheduledTime == nil {
+			continue
+		}
+		missedExecutionTime, err := woc.missedExecutionTime(schedules[i], lastScheduledTime.Time)
+		if err != nil {
+			return nil, err
+		}
+		if !missedExecutionTime.IsZero() {
+			missedExecutionTimes[schedule.Name] = missedExecutionTime
+		}
+	}
+	return missedExecutionTimes, nil
+}
 
-		var missedExecutionTime time.Time
-		nextScheduledRunTime := cronSchedule.Next(woc.cronWf.Status.LastScheduledTime.Time)
-		// Workflow should have ran
-		for nextScheduledRunTime.Before(now) {
-			missedExecutionTime = nextScheduledRunTime
-			nextScheduledRunTime = cronSchedule.Next(missedExecutionTime)
+// missedExecutionTime returns the latest execution of the schedule missed since the last scheduled time, if it is within
+// the starting deadline, or the zero time
+func (woc *cronWfOperationCtx) missedExecutionTime(cronScheduleString string, lastScheduledTime time.Time) (time.Time, error) {
+	now := time.Now()
+	if woc.cronWf.Spec.Timezone != "" {
+		loc, err := time.LoadLocation(woc.cronWf.Spec.Timezone)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid timezone '%s': %s", woc.cronWf.Spec.Timezone, err)
 		}
+		now = now.In(loc)
+	}
+	cronSchedule, err := cron.ParseStandard(cronScheduleString)
+	if err != nil {
+		if woc.cronWf.Spec.Timezone != "" {
+			return time.Time{}, fmt.Errorf("unable to form timezone schedule '%s': %s", cronScheduleString, err)
+		}
+		return time.Time{}, err
+	}
 
-		// We missed the latest execution time
-		if !missedExecutionTime.IsZero() {
-			// if missedExecutionTime is within StartDeadlineSeconds, We are still within the deadline window, run the Workflow
-			if woc.cronWf.Spec.StartingDeadlineSeconds != nil && now.Before(missedExecutionTime.Add(time.Duration(*woc.cronWf.Spec.StartingDeadlineSeconds)*time.Second)) {
-				woc.log.Infof("%s missed an execution at %s and is within StartingDeadline", woc.cronWf.Name, missedExecutionTime.Format("Mon Jan _2 15:04:05 2006"))
-				return missedExecutionTime, nil
-			}
+	var missedExecutionTime time.Time
+	nextScheduledRunTime := cronSchedule.Next(lastScheduledTime)
+	// Workflow should have ran
+	for nextScheduledRunTime.Before(now) {
+		missedExecutionTime = nextScheduledRunTime
+		nextScheduledRunTime = cronSchedule.Next(missedExecutionTime)
+	}
+
+	// We missed the latest execution time
+	if !missedExecutionTime.IsZero() {
+		// if missedExecutionTime is within StartDeadlineSeconds, We are still within the deadline window, run the Workflow
+		if woc.cronWf.Spec.StartingDeadlineSeconds != nil && now.Before(missedExecutionTime.Add(time.Duration(*woc.cronWf.Spec.StartingDeadlineSeconds)*time.Second)) {
+			woc.log.Infof("%s missed an execution at %s and is within StartingDeadline", woc.cronWf.Name, missedExecutionTime.Format("Mon Jan _2 15:04:05 2006"))
+			return missedExecutionTime, nil
 		}
 	}
 	return time.Time{}, nil
@@ -282,20 +402,28 @@ func (woc *cronWfOperationCtx) shouldOutstandingWorkflowsBeRun() (time.Time, err
 
 func (woc *cronWfOperationCtx) reconcileActiveWfs(ctx context.Context, workflows []v1alpha1.Workflow) error {
 	updated := false
-	currentWfsFulfilled := make(map[types.UID]bool)
+	currentWfs := make(map[types.UID]v1alpha1.Workflow)
 	for _, wf := range workflows {
-		currentWfsFulfilled[wf.UID] = wf.Status.Fulfilled()
+		currentWfs[wf.UID] = wf
 
 		if !woc.cronWf.Status.HasActiveUID(wf.UID) && !wf.Status.Fulfilled() {
 			updated = true
 			woc.cronWf.Status.Active = append(woc.cronWf.Status.Active, getWorkflowObjectReference(&wf, &wf))
+			if name := wf.Labels[common.LabelKeyCronWorkflowSchedule]; name != "" && woc.cronWf.Spec.GetSchedule(name) != nil {
+				status := woc.cronWf.Status.GetSchedule(name)
+				status.Active = append(status.Active, getWorkflowObjectReference(&wf, &wf))
+			}
 		}
 	}
 
 	for _, objectRef := range woc.cronWf.Status.Active {
-		if fulfilled, found := currentWfsFulfilled[objectRef.UID]; !found || fulfilled {
+		wf, found := currentWfs[objectRef.UID]
+		if !found || wf.Status.Fulfilled() {
 			updated = true
 			woc.removeFromActiveList(objectRef.UID)
+			if found && wf.Status.Successful() {
+				woc.cronWf.Status.LastSuccessfulRunOutputs = getOutputParameters(wf)
+			}
 		}
 	}
 
@@ -306,14 +434,29 @@ func (woc *cronWfOperationCtx) reconcileActiveWfs(ctx context.Context, workflows
 	return nil
 }
 
+// getOutputParameters returns the workflow's global output parameters, which are kept for its successor
+func getOutputParameters(wf v1alpha1.Workflow) *v1alpha1.Outputs {
+	if wf.Status.Outputs == nil || len(wf.Status.Outputs.Parameters) == 0 {
+		return nil
+	}
+	return &v1alpha1.Outputs{Parameters: wf.Status.Outputs.Parameters}
+}
+
 func (woc *cronWfOperationCtx) removeFromActiveList(uid types.UID) {
+	woc.cronWf.Status.Active = removeFromActive(woc.cronWf.Status.Active, uid)
+	for i := range woc.cronWf.Status.Schedules {
+		woc.cronWf.Status.Schedules[i].Active = removeFromActive(woc.cronWf.Status.Schedules[i].Active, uid)
+	}
+}
+
+func removeFromActive(active []corev1.ObjectReference, uid types.UID) []corev1.ObjectReference {
 	var newActive []corev1.ObjectReference
-	for _, ref := range woc.cronWf.Status.Active {
+	for _, ref := range active {
 		if ref.UID != uid {
 			newActive = append(newActive, ref)
 		}
 	}
-	woc.cronWf.Status.Active = newActive
+	return newActive
 }
 
 func (woc *cronWfOperationCtx) enforceHistoryLimit(ctx context.Context, workflows []v1alpha1.Workflow) error {
@@ -402,6 +545,9 @@ func inferScheduledTime() time.Time {
 	return scheduledTime
 }
 
-func getChildWorkflowName(cronWorkflowName string, scheduledRuntime time.Time) string {
+func getChildWorkflowName(cronWorkflowName, scheduleName string, scheduledRuntime time.Time) string {
+	if scheduleName != "" {
+		return fmt.Sprintf("%s-%s-%d", cronWorkflowName, scheduleName, scheduledRuntime.Unix())
+	}
 	return fmt.Sprintf("%s-%d", cronWorkflowName, scheduledRuntime.Unix())
 }
@@ -2,13 +2,17 @@ package cron
 
 import (
 	"context"
+	"fmt"
 	"testing"
 	"time"
 
 	"github.com/argoproj/pkg/humanize"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 
 	"github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 	"github.com/argoproj/argo-workflows/v3/pkg/client/clientset/versioned/fake"
@@ -396,3 +400,162 @@ func TestMissedScheduleAfterCronScheduleWithForbid(t *testing.T) {
 		assert.True(t, missedExecutionTime.IsZero())
 	})
 }
+
+func TestLastSuccessfulRunOutputs(t *testing.T) {
+	var cronWf v1alpha1.CronWorkflow
+	v1alpha1.MustUnmarshal([]byte(scheduledWf), &cronWf)
+	cronWf.Status.Active = []corev1.ObjectReference{{Name: "test-1", UID: "test-1"}}
+
+	cs := fake.NewSimpleClientset(&cronWf)
+	testMetrics := metrics.New(metrics.ServerConfig{}, metrics.ServerConfig{})
+	woc := &cronWfOperationCtx{
+		wfClientset:       cs,
+		wfClient:          cs.ArgoprojV1alpha1().Workflows(""),
+		cronWfIf:          cs.ArgoprojV1alpha1().CronWorkflows("argo"),
+		cronWf:            &cronWf,
+		log:               logrus.WithFields(logrus.Fields{}),
+		metrics:           testMetrics,
+		scheduledTimeFunc: inferScheduledTime,
+	}
+	wf := v1alpha1.Workflow{
+		ObjectMeta: v1.ObjectMeta{Name: "test-1", UID: "test-1"},
+		Status: v1alpha1.WorkflowStatus{
+			Phase:   v1alpha1.WorkflowSucceeded,
+			Outputs: &v1alpha1.Outputs{Parameters: []v1alpha1.Parameter{{Name: "watermark", Value: v1alpha1.AnyStringPtr("2023-01-01")}}},
+		},
+	}
+	err := woc.reconcileActiveWfs(context.Background(), []v1alpha1.Workflow{wf})
+	assert.NoError(t, err)
+	assert.Empty(t, woc.cronWf.Status.Active)
+	if assert.NotNil(t, woc.cronWf.Status.LastSuccessfulRunOutputs) {
+		assert.Equal(t, "2023-01-01", woc.cronWf.Status.LastSuccessfulRunOutputs.Parameters[0].GetValue())
+	}
+
+	woc.Run()
+	wsl, err := cs.ArgoprojV1alpha1().Workflows("").List(context.Background(), v1.ListOptions{})
+	assert.NoError(t, err)
+	if assert.Len(t, wsl.Items, 1) {
+		assert.JSONEq(t, `[{"name":"watermark","value":"2023-01-01"}]`, wsl.Items[0].Annotations[common.AnnotationKeyCronWfLastSuccessfulRunOutputs])
+	}
+}
+
+const cronWfWithSchedules = `apiVersion: argoproj.io/v1alpha1
+kind: CronWorkflow
+metadata:
+  name: test
+  namespace: argo
+spec:
+  concurrencyPolicy: Forbid
+  schedules:
+  - name: hourly
+    schedule: 0 * * * *
+  - name: nightly
+    schedule: 0 0 * * *
+    parameters:
+    - name: mode
+      value: full
+    - name: date
+      value: today
+  workflowSpec:
+    arguments:
+      parameters:
+      - name: mode
+        value: light
+    entrypoint: job
+    templates:
+    - container:
+        image: alpine
+      name: job
+`
+
+func TestSchedules(t *testing.T) {
+	ctx := context.Background()
+	var cronWf v1alpha1.CronWorkflow
+	v1alpha1.MustUnmarshal([]byte(cronWfWithSchedules), &cronWf)
+
+	cs := fake.NewSimpleClientset(&cronWf)
+	testMetrics := metrics.New(metrics.ServerConfig{}, metrics.ServerConfig{})
+	woc := &cronWfOperationCtx{
+		wfClientset: cs,
+		wfClient:    cs.ArgoprojV1alpha1().Workflows("argo"),
+		cronWfIf:    cs.ArgoprojV1alpha1().CronWorkflows("argo"),
+		cronWf:      &cronWf,
+		log:         logrus.WithFields(logrus.Fields{}),
+		metrics:     testMetrics,
+	}
+	scheduledTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	scheduledTimeFunc := func() time.Time { return scheduledTime }
+	(&scheduleJob{woc: woc, name: "nightly", scheduledTimeFunc: scheduledTimeFunc}).Run()
+
+	wf, err := woc.wfClient.Get(ctx, fmt.Sprintf("test-nightly-%d", scheduledTime.Unix()), v1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "nightly", wf.Labels[common.LabelKeyCronWorkflowSchedule])
+	assert.Equal(t, "full", wf.Spec.Arguments.GetParameterByName("mode").GetValue())
+	assert.Equal(t, "today", wf.Spec.Arguments.GetParameterByName("date").GetValue())
+	// the cron workflow's own arguments are unchanged
+	assert.Equal(t, "light", woc.cronWf.Spec.WorkflowSpec.Arguments.GetParameterByName("mode").GetValue())
+	assert.Len(t, woc.cronWf.Status.Active, 1)
+	if assert.Len(t, woc.cronWf.Status.Schedules, 1) {
+		status := woc.cronWf.Status.Schedules[0]
+		assert.Equal(t, "nightly", status.Name)
+		assert.Len(t, status.Active, 1)
+		assert.True(t, scheduledTime.Equal(status.LastScheduledTime.Time))
+	}
+
+	// the concurrency policy applies to each schedule's own workflows
+	scheduledTime = scheduledTime.Add(time.Hour)
+	(&scheduleJob{woc: woc, name: "nightly", scheduledTimeFunc: scheduledTimeFunc}).Run()
+	(&scheduleJob{woc: woc, name: "hourly", scheduledTimeFunc: scheduledTimeFunc}).Run()
+	wfs, err := woc.wfClient.List(ctx, v1.ListOptions{})
+	require.NoError(t, err)
+	assert.Len(t, wfs.Items, 2)
+	hourly, err := woc.wfClient.Get(ctx, fmt.Sprintf("test-hourly-%d", scheduledTime.Unix()), v1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "light", hourly.Spec.Arguments.GetParameterByName("mode").GetValue())
+	assert.Len(t, woc.cronWf.Status.Active, 2)
+	assert.Len(t, woc.cronWf.Status.Schedules, 2)
+
+	// completed workflows are removed from the active workflows of their schedule, by UID, which the fake client does
+	// not set
+	setUID := func(refs []corev1.ObjectReference) {
+		for i := range refs {
+			refs[i].UID = types.UID(refs[i].Name)
+		}
+	}
+	setUID(woc.cronWf.Status.Active)
+	for _, status := range woc.cronWf.Status.Schedules {
+		setUID(status.Active)
+	}
+	wf.UID = types.UID(wf.Name)
+	hourly.UID = types.UID(hourly.Name)
+	wf.Status.Phase = v1alpha1.WorkflowSucceeded
+	require.NoError(t, woc.reconcileActiveWfs(ctx, []v1alpha1.Workflow{*wf, *hourly}))
+	assert.Len(t, woc.cronWf.Status.Active, 1)
+	for _, status := range woc.cronWf.Status.Schedules {
+		if status.Name == "nightly" {
+			assert.Empty(t, status.Active)
+		} else {
+			assert.Len(t, status.Active, 1)
+		}
+	}
+}
+
+func TestShouldOutstandingScheduleWorkflowsBeRun(t *testing.T) {
+	var cronWf v1alpha1.CronWorkflow
+	v1alpha1.MustUnmarshal([]byte(cronWfWithSchedules), &cronWf)
+	startingDeadlineSeconds := int64(3600)
+	cronWf.Spec.StartingDeadlineSeconds = &startingDeadlineSeconds
+	cronWf.SetSchedule(cronWf.Spec.GetScheduleString())
+	// the hourly schedule last ran over an hour ago, the nightly schedule has not run
+	cronWf.Status.Schedules = []v1alpha1.CronScheduleStatus{
+		{Name: "hourly", LastScheduledTime: &v1.Time{Time: time.Now().Add(-61 * time.Minute)}},
+	}
+	woc := &cronWfOperationCtx{
+		cronWf: &cronWf,
+		log:    logrus.WithFields(logrus.Fields{}),
+	}
+	missedExecutionTimes, err := woc.shouldOutstandingScheduleWorkflowsBeRun()
+	require.NoError(t, err)
+	assert.Len(t, missedExecutionTimes, 1)
+	assert.Contains(t, missedExecutionTimes, "hourly")
+}
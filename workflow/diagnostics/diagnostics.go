@@ -0,0 +1,31 @@
+package diagnostics
+
+const (
+	// Port is the port of the workflow controller that serves the diagnostics, along with the health check
+	Port = 6060
+	// Path is the path of the diagnostics
+	Path = "/diagnostics"
+)
+
+// Status is the state of a workflow controller, as reported by its diagnostics
+type Status struct {
+	// Version is the version of the controller
+	Version string `json:"version"`
+	// InstanceID is the instance ID of the controller, if any
+	InstanceID string `json:"instanceID,omitempty"`
+	// ManagedNamespace is the namespace the controller is restricted to, if any
+	ManagedNamespace string `json:"managedNamespace,omitempty"`
+	// Leading is whether the controller is the leader. Only the leader runs the informers and queues, so the other
+	// controllers report none of them.
+	Leading bool `json:"leading"`
+	// ConfigHash is a hash of the controller's configuration, it changes when the configuration is reloaded
+	ConfigHash string `json:"configHash"`
+	// Queues is the number of items waiting in each queue
+	Queues map[string]int `json:"queues,omitempty"`
+	// Workflows is the number of workflows in each phase, workflows without a phase are counted as pending
+	Workflows map[string]int `json:"workflows,omitempty"`
+	// Informers is whether each informer cache has synced
+	Informers map[string]bool `json:"informers,omitempty"`
+	// RecentErrors is the number of errors of each cause in the last hour
+	RecentErrors map[string]int `json:"recentErrors"`
+}
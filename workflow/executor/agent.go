@@ -11,6 +11,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/Masterminds/semver/v3"
 	log "github.com/sirupsen/logrus"
 	apierr "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -44,11 +45,13 @@ type AgentExecutor struct {
 	Namespace         string
 	consideredTasks   *sync.Map
 	plugins           []executorplugins.TemplateExecutor
+	// pluginVersions are the versions of the plugins by name, or nil if they are unknown
+	pluginVersions map[string]string
 }
 
 type templateExecutor = func(ctx context.Context, tmpl wfv1.Template, result *wfv1.NodeResult) (time.Duration, error)
 
-func NewAgentExecutor(clientSet kubernetes.Interface, restClient rest.Interface, config *rest.Config, namespace, workflowName, workflowUID string, plugins []executorplugins.TemplateExecutor) *AgentExecutor {
+func NewAgentExecutor(clientSet kubernetes.Interface, restClient rest.Interface, config *rest.Config, namespace, workflowName, workflowUID string, plugins []executorplugins.TemplateExecutor, pluginVersions map[string]string) *AgentExecutor {
 	return &AgentExecutor{
 		log:               log.WithField("workflow", workflowName),
 		ClientSet:         clientSet,
@@ -59,6 +62,7 @@ func NewAgentExecutor(clientSet kubernetes.Interface, restClient rest.Interface,
 		WorkflowInterface: workflow.NewForConfigOrDie(config),
 		consideredTasks:   &sync.Map{},
 		plugins:           plugins,
+		pluginVersions:    pluginVersions,
 	}
 }
 
@@ -352,6 +356,9 @@ func (ae *AgentExecutor) executeHTTPTemplateRequest(ctx context.Context, httpTem
 }
 
 func (ae *AgentExecutor) executePluginTemplate(ctx context.Context, tmpl wfv1.Template, result *wfv1.NodeResult) (time.Duration, error) {
+	if err := ae.checkPluginVersion(tmpl.Plugin); err != nil {
+		return 0, err
+	}
 	args := executorplugins.ExecuteTemplateArgs{
 		Workflow: &executorplugins.Workflow{
 			ObjectMeta: executorplugins.ObjectMeta{
@@ -377,6 +384,35 @@ func (ae *AgentExecutor) executePluginTemplate(ctx context.Context, tmpl wfv1.Te
 	return 0, fmt.Errorf("no plugin executed the template")
 }
 
+// checkPluginVersion returns an error if the template constrains the version of its plugin, and the installed plugin of
+// that name does not satisfy the constraint
+func (ae *AgentExecutor) checkPluginVersion(plug *wfv1.Plugin) error {
+	constraint := plug.GetVersion()
+	if constraint == "" {
+		return nil
+	}
+	name := plug.GetName()
+	c, err := semver.NewConstraint(constraint)
+	if err != nil {
+		return fmt.Errorf("invalid version constraint %q of executor plugin %s: %w", constraint, name, err)
+	}
+	version, ok := ae.pluginVersions[name]
+	switch {
+	case !ok:
+		return fmt.Errorf("executor plugin %s is not installed in the workflow's namespace or the controller's namespace", name)
+	case version == "":
+		return fmt.Errorf("executor plugin %s has no version, so does not satisfy %q", name, constraint)
+	}
+	v, err := semver.NewVersion(version)
+	if err != nil {
+		return fmt.Errorf("executor plugin %s has version %q, which is not a semantic version: %w", name, version, err)
+	}
+	if !c.Check(v) {
+		return fmt.Errorf("executor plugin %s version %s does not satisfy %q", name, version, constraint)
+	}
+	return nil
+}
+
 func IsWorkflowCompleted(wts *wfv1.WorkflowTaskSet) bool {
 	return wts.Labels[common.LabelKeyCompleted] == "true"
 }
@@ -94,3 +94,39 @@ func (a alwaysSucceededPlugin) ExecuteTemplate(_ context.Context, _ executorplug
 	reply.Requeue = &metav1.Duration{Duration: a.requeue}
 	return nil
 }
+
+func TestAgentPluginVersion(t *testing.T) {
+	tmpl := v1alpha1.Template{
+		Plugin: &v1alpha1.Plugin{
+			Object: v1alpha1.Object{Value: json.RawMessage(`{"slack": {}, "version": ">=1.2"}`)},
+		},
+	}
+	process := func(versions map[string]string) *v1alpha1.NodeResult {
+		ae := &AgentExecutor{
+			consideredTasks: &sync.Map{},
+			plugins:         []executorplugins.TemplateExecutor{&alwaysSucceededPlugin{}},
+			pluginVersions:  versions,
+		}
+		result, _, err := ae.processTask(context.Background(), tmpl)
+		assert.NoError(t, err)
+		return result
+	}
+	t.Run("Satisfied", func(t *testing.T) {
+		assert.Equal(t, v1alpha1.NodeSucceeded, process(map[string]string{"slack": "1.3.0"}).Phase)
+	})
+	t.Run("NotSatisfied", func(t *testing.T) {
+		result := process(map[string]string{"slack": "1.1.0"})
+		assert.Equal(t, v1alpha1.NodeFailed, result.Phase)
+		assert.Equal(t, `executor plugin slack version 1.1.0 does not satisfy ">=1.2"`, result.Message)
+	})
+	t.Run("NoVersion", func(t *testing.T) {
+		result := process(map[string]string{"slack": ""})
+		assert.Equal(t, v1alpha1.NodeFailed, result.Phase)
+		assert.Equal(t, `executor plugin slack has no version, so does not satisfy ">=1.2"`, result.Message)
+	})
+	t.Run("NotInstalled", func(t *testing.T) {
+		result := process(map[string]string{"hello": "1.3.0"})
+		assert.Equal(t, v1alpha1.NodeFailed, result.Phase)
+		assert.Equal(t, "executor plugin slack is not installed in the workflow's namespace or the controller's namespace", result.Message)
+	})
+}
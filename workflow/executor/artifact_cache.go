@@ -0,0 +1,198 @@
+package executor
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	artifactcommon "github.com/argoproj/argo-workflows/v3/workflow/artifacts/common"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+const artifactCacheTempPrefix = ".tmp-"
+
+// artifactCache is a cache of input artifacts shared by the pods on a node, keyed by the SHA-256 checksum of the
+// artifact. The modification time of each entry is the last time it was used, so that the least recently used entries
+// are evicted first.
+type artifactCache struct {
+	dir     string
+	maxSize int64
+}
+
+// newArtifactCache returns the node artifact cache, or nil if it is not enabled
+func newArtifactCache() *artifactCache {
+	value := os.Getenv(common.EnvVarArtifactCacheMaxSize)
+	if value == "" {
+		return nil
+	}
+	maxSize, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		log.WithError(err).Warnf("Invalid %s, the artifact cache is disabled", common.EnvVarArtifactCacheMaxSize)
+		return nil
+	}
+	return &artifactCache{dir: common.ExecutorArtifactCacheDir, maxSize: maxSize}
+}
+
+// path returns the path of the cached artifact. The checksum may be set by the workflow's author, so anything other
+// than a SHA-256 checksum is rejected, rather than risk it being a path outside the cache.
+func (c *artifactCache) path(checksum string) (string, error) {
+	if !common.IsValidChecksum(checksum) {
+		return "", fmt.Errorf("%q is not a SHA-256 checksum", checksum)
+	}
+	return filepath.Join(c.dir, checksum), nil
+}
+
+// driverContext returns the context to create the driver of the artifact with. HTTP artifacts without a checksum
+// cannot be looked up by it, so their driver keeps a copy of them in the cache, which it revalidates using their ETag
+// and Last-Modified headers rather than download them again.
+func (c *artifactCache) driverContext(ctx context.Context, art *wfv1.Artifact) context.Context {
+	if c == nil || art.HTTP == nil || art.Checksum != "" {
+		return ctx
+	}
+	return artifactcommon.WithCacheDir(ctx, c.dir)
+}
+
+// restore copies the cached artifact to path, and returns false if it is not cached. The copy is verified against the
+// checksum, as another pod on the node may have changed the cached file, and an entry that does not match is removed.
+func (c *artifactCache) restore(checksum, path string) bool {
+	if c == nil || checksum == "" {
+		return false
+	}
+	src, err := c.path(checksum)
+	if err != nil {
+		log.WithError(err).Warn("Not restoring artifact from the cache")
+		return false
+	}
+	actual, err := copyFile(src, path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.WithError(err).Warnf("Failed to restore artifact %s from the cache", checksum)
+		}
+		_ = os.RemoveAll(path)
+		return false
+	}
+	if actual != checksum {
+		log.Warnf("Cached artifact %s has the checksum %s, removing it from the cache", checksum, actual)
+		_ = os.Remove(src)
+		_ = os.RemoveAll(path)
+		return false
+	}
+	now := time.Now()
+	_ = os.Chtimes(src, now, now)
+	return true
+}
+
+// store caches the artifact at path, if its checksum matches, and then evicts the least recently used artifacts. The
+// artifact is written to a temporary file and renamed, so pods on the node never read a partial entry.
+func (c *artifactCache) store(checksum, path string) error {
+	if c == nil || checksum == "" {
+		return nil
+	}
+	dst, err := c.path(checksum)
+	if err != nil {
+		return err
+	}
+	fi, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	if fi.IsDir() {
+		return nil
+	}
+	if fi.Size() > c.maxSize {
+		log.Infof("Not caching artifact %s, it is larger than the cache", checksum)
+		return nil
+	}
+	src, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return err
+	}
+	defer func() { _ = src.Close() }()
+	tmp, err := os.CreateTemp(c.dir, artifactCacheTempPrefix)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	h := sha256.New()
+	_, err = io.Copy(io.MultiWriter(tmp, h), src)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	if actual := hex.EncodeToString(h.Sum(nil)); actual != checksum {
+		return fmt.Errorf("the checksum of the artifact is %s, not %s", actual, checksum)
+	}
+	if err := os.Rename(tmp.Name(), dst); err != nil {
+		return err
+	}
+	return c.evict()
+}
+
+// evict removes the least recently used artifacts until the cache is no larger than its maximum size
+func (c *artifactCache) evict() error {
+	entries, err := os.ReadDir(c.dir)
+	if err != nil {
+		return err
+	}
+	var files []os.FileInfo
+	var size int64
+	for _, e := range entries {
+		if e.IsDir() || strings.HasPrefix(e.Name(), artifactCacheTempPrefix) {
+			continue
+		}
+		fi, err := e.Info()
+		if err != nil {
+			// removed by another pod
+			continue
+		}
+		files = append(files, fi)
+		size += fi.Size()
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].ModTime().Before(files[j].ModTime()) })
+	for _, fi := range files {
+		if size <= c.maxSize {
+			break
+		}
+		if err := os.Remove(filepath.Join(c.dir, fi.Name())); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		log.Infof("Evicted artifact %s from the cache", fi.Name())
+		size -= fi.Size()
+	}
+	return nil
+}
+
+// copyFile copies src to dst and returns the SHA-256 checksum of what was copied
+func copyFile(src, dst string) (string, error) {
+	in, err := os.Open(filepath.Clean(src))
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = in.Close() }()
+	out, err := os.Create(filepath.Clean(dst))
+	if err != nil {
+		return "", err
+	}
+	h := sha256.New()
+	_, err = io.Copy(io.MultiWriter(out, h), in)
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
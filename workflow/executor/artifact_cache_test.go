@@ -0,0 +1,122 @@
+package executor
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	artifactcommon "github.com/argoproj/argo-workflows/v3/workflow/artifacts/common"
+)
+
+func writeArtifact(t *testing.T, dir, name, content string) (string, string) {
+	path := filepath.Join(dir, name)
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	sum := sha256.Sum256([]byte(content))
+	return path, hex.EncodeToString(sum[:])
+}
+
+func TestArtifactCache(t *testing.T) {
+	t.Run("Disabled", func(t *testing.T) {
+		var c *artifactCache
+		assert.False(t, c.restore("checksum", filepath.Join(t.TempDir(), "art")))
+		assert.NoError(t, c.store("checksum", filepath.Join(t.TempDir(), "art")))
+	})
+	t.Run("StoreAndRestore", func(t *testing.T) {
+		c := &artifactCache{dir: t.TempDir(), maxSize: 1024}
+		work := t.TempDir()
+		path, checksum := writeArtifact(t, work, "art", "hello")
+		restored := filepath.Join(work, "restored")
+		assert.False(t, c.restore(checksum, restored))
+		assert.NoFileExists(t, restored)
+		assert.NoError(t, c.store(checksum, path))
+		if assert.True(t, c.restore(checksum, restored)) {
+			data, err := os.ReadFile(restored)
+			assert.NoError(t, err)
+			assert.Equal(t, "hello", string(data))
+		}
+	})
+	t.Run("NoChecksum", func(t *testing.T) {
+		c := &artifactCache{dir: t.TempDir(), maxSize: 1024}
+		path, _ := writeArtifact(t, t.TempDir(), "art", "hello")
+		assert.NoError(t, c.store("", path))
+		entries, err := os.ReadDir(c.dir)
+		assert.NoError(t, err)
+		assert.Empty(t, entries)
+	})
+	t.Run("ChecksumMismatch", func(t *testing.T) {
+		c := &artifactCache{dir: t.TempDir(), maxSize: 1024}
+		path, _ := writeArtifact(t, t.TempDir(), "art", "hello")
+		_, other := writeArtifact(t, t.TempDir(), "other", "world")
+		assert.Error(t, c.store(other, path))
+		assert.False(t, c.restore(other, filepath.Join(t.TempDir(), "restored")))
+	})
+	t.Run("InvalidChecksum", func(t *testing.T) {
+		c := &artifactCache{dir: filepath.Join(t.TempDir(), "cache"), maxSize: 1024}
+		assert.NoError(t, os.Mkdir(c.dir, 0o700))
+		work := t.TempDir()
+		path, checksum := writeArtifact(t, work, "art", "hello")
+		// the checksum of an input artifact may be set by the author of the workflow
+		assert.Error(t, c.store("../art", path))
+		assert.False(t, c.restore("../secret", filepath.Join(work, "restored")))
+		assert.NoFileExists(t, filepath.Join(work, "restored"))
+		assert.False(t, c.restore(strings.ToUpper(checksum), filepath.Join(work, "restored")))
+	})
+	t.Run("Corrupted", func(t *testing.T) {
+		c := &artifactCache{dir: t.TempDir(), maxSize: 1024}
+		work := t.TempDir()
+		path, checksum := writeArtifact(t, work, "art", "hello")
+		assert.NoError(t, c.store(checksum, path))
+		// another pod on the node changed the cached file
+		assert.NoError(t, os.WriteFile(filepath.Join(c.dir, checksum), []byte("evil"), 0o600))
+		restored := filepath.Join(work, "restored")
+		assert.False(t, c.restore(checksum, restored))
+		assert.NoFileExists(t, restored)
+		assert.NoFileExists(t, filepath.Join(c.dir, checksum))
+	})
+	t.Run("Evict", func(t *testing.T) {
+		c := &artifactCache{dir: t.TempDir(), maxSize: 10}
+		work := t.TempDir()
+		path1, checksum1 := writeArtifact(t, work, "art1", "aaaa")
+		path2, checksum2 := writeArtifact(t, work, "art2", "bbbb")
+		path3, checksum3 := writeArtifact(t, work, "art3", "cccc")
+		assert.NoError(t, c.store(checksum1, path1))
+		assert.NoError(t, c.store(checksum2, path2))
+		// using the first artifact makes the second the least recently used
+		old := time.Now().Add(-time.Hour)
+		assert.NoError(t, os.Chtimes(filepath.Join(c.dir, checksum2), old, old))
+		assert.True(t, c.restore(checksum1, filepath.Join(work, "restored")))
+		assert.NoError(t, c.store(checksum3, path3))
+		assert.FileExists(t, filepath.Join(c.dir, checksum1))
+		assert.NoFileExists(t, filepath.Join(c.dir, checksum2))
+		assert.FileExists(t, filepath.Join(c.dir, checksum3))
+	})
+	t.Run("LargerThanCache", func(t *testing.T) {
+		c := &artifactCache{dir: t.TempDir(), maxSize: 2}
+		path, checksum := writeArtifact(t, t.TempDir(), "art", "hello")
+		assert.NoError(t, c.store(checksum, path))
+		assert.NoFileExists(t, filepath.Join(c.dir, checksum))
+	})
+}
+
+func TestArtifactCacheDriverContext(t *testing.T) {
+	ctx := context.Background()
+	httpArt := &wfv1.Artifact{ArtifactLocation: wfv1.ArtifactLocation{HTTP: &wfv1.HTTPArtifact{URL: "https://example.com"}}}
+	var disabled *artifactCache
+	assert.Empty(t, artifactcommon.CacheDirFromContext(disabled.driverContext(ctx, httpArt)))
+	c := &artifactCache{dir: "/my-cache"}
+	assert.Equal(t, "/my-cache", artifactcommon.CacheDirFromContext(c.driverContext(ctx, httpArt)))
+	// artifacts with a checksum are cached by it instead
+	withChecksum := httpArt.DeepCopy()
+	withChecksum.Checksum = "my-checksum"
+	assert.Empty(t, artifactcommon.CacheDirFromContext(c.driverContext(ctx, withChecksum)))
+	s3Art := &wfv1.Artifact{ArtifactLocation: wfv1.ArtifactLocation{S3: &wfv1.S3Artifact{Key: "my-key"}}}
+	assert.Empty(t, artifactcommon.CacheDirFromContext(c.driverContext(ctx, s3Art)))
+}
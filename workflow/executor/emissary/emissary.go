@@ -69,7 +69,7 @@ func (e emissary) writeTemplate(t wfv1.Template) error {
 }
 
 func (e emissary) GetFileContents(_ string, sourcePath string) (string, error) {
-	data, err := os.ReadFile(filepath.Clean(filepath.Join(common.VarRunArgoPath, "outputs", "parameters", sourcePath)))
+	data, err := os.ReadFile(common.GetOutputStagingPath(common.VarRunArgoPath, "parameters", sourcePath))
 	return string(data), err
 }
 
@@ -77,7 +77,7 @@ func (e emissary) CopyFile(_ string, sourcePath string, destPath string, _ int)
 	// this implementation is very different, because we expect the emissary binary has already compressed the file
 	// so no compression can or needs to be implemented here
 	// TODO - warn the user we ignored compression?
-	sourceFile := filepath.Join(common.VarRunArgoPath, "outputs", "artifacts", strings.TrimSuffix(sourcePath, "/")+".tgz")
+	sourceFile := common.GetOutputStagingPath(common.VarRunArgoPath, "artifacts", strings.TrimSuffix(sourcePath, "/")+".tgz")
 	log.Infof("%s -> %s", sourceFile, destPath)
 	src, err := os.Open(filepath.Clean(sourceFile))
 	if err != nil {
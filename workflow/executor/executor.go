@@ -6,6 +6,8 @@ import (
 	"bufio"
 	"compress/gzip"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -42,8 +44,10 @@ import (
 	waitutil "github.com/argoproj/argo-workflows/v3/util/wait"
 	artifact "github.com/argoproj/argo-workflows/v3/workflow/artifacts"
 	artifactcommon "github.com/argoproj/argo-workflows/v3/workflow/artifacts/common"
+	"github.com/argoproj/argo-workflows/v3/workflow/artifacts/faultinjection"
 	"github.com/argoproj/argo-workflows/v3/workflow/common"
 	executorretry "github.com/argoproj/argo-workflows/v3/workflow/executor/retry"
+	"github.com/argoproj/argo-workflows/v3/workflow/signal"
 )
 
 const (
@@ -79,6 +83,16 @@ type WorkflowExecutor struct {
 
 	annotationPatchTickDuration  time.Duration
 	readProgressFileTickDuration time.Duration
+
+	// artifactCache is the node artifact cache, nil if it is not enabled
+	artifactCache *artifactCache
+
+	// artifactErrorRate is the rate at which loading and saving artifacts fail, when faults are injected for testing
+	artifactErrorRate float64
+
+	// bandwidthLimit is the template's artifact bandwidth limit, shared by all the artifacts of the step, nil until
+	// the first driver is initialized
+	bandwidthLimit *artifactcommon.BandwidthLimit
 }
 
 type Initializer interface {
@@ -139,7 +153,24 @@ func NewExecutor(
 		errors:                       []error{},
 		annotationPatchTickDuration:  annotationPatchTickDuration,
 		readProgressFileTickDuration: readProgressFileTickDuration,
+		artifactCache:                newArtifactCache(),
+		artifactErrorRate:            getArtifactErrorRate(),
+	}
+}
+
+// getArtifactErrorRate returns the rate at which loading and saving artifacts fail, 0 unless faults are injected
+func getArtifactErrorRate() float64 {
+	value := os.Getenv(common.EnvVarFaultInjectionArtifactErrorRate)
+	if value == "" {
+		return 0
+	}
+	rate, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		log.WithError(err).Warnf("Invalid %s, no artifact errors are injected", common.EnvVarFaultInjectionArtifactErrorRate)
+		return 0
 	}
+	log.WithField("rate", rate).Warn("Injecting faults: artifact errors")
+	return rate
 }
 
 // HandleError is a helper to annotate the pod with the error message upon a unexpected executor panic or error
@@ -158,6 +189,10 @@ func (we *WorkflowExecutor) HandleError(ctx context.Context) {
 func (we *WorkflowExecutor) LoadArtifacts(ctx context.Context) error {
 	log.Infof("Start loading input artifacts...")
 	for _, art := range we.Template.Inputs.Artifacts {
+		if art.Mount {
+			log.Infof("Not downloading artifact %s, which is mounted", art.Name)
+			continue
+		}
 
 		log.Infof("Downloading artifact: %s", art.Name)
 
@@ -177,7 +212,7 @@ func (we *WorkflowExecutor) LoadArtifacts(ctx context.Context) error {
 		if err != nil {
 			return fmt.Errorf("failed to load artifact '%s': %w", art.Name, err)
 		}
-		artDriver, err := we.InitDriver(ctx, driverArt)
+		artDriver, err := we.InitDriver(we.artifactCache.driverContext(ctx, driverArt), driverArt)
 		if err != nil {
 			return err
 		}
@@ -200,13 +235,26 @@ func (we *WorkflowExecutor) LoadArtifacts(ctx context.Context) error {
 		// the file is a tarball or not. If it is, it is first extracted then renamed to
 		// the desired location. If not, it is simply renamed to the location.
 		tempArtPath := artPath + ".tmp"
-		err = artDriver.Load(driverArt, tempArtPath)
-		if err != nil {
-			if art.Optional && argoerrs.IsCode(argoerrs.CodeNotFound, err) {
-				log.Infof("Skipping optional input artifact that was not found: %s", art.Name)
-				continue
+		if we.artifactCache.restore(art.Checksum, tempArtPath) {
+			log.Infof("Loaded artifact %s from the node artifact cache", art.Name)
+		} else {
+			err = loadArtifact(artDriver, driverArt, tempArtPath, art.Checksum)
+			if err != nil {
+				if art.Optional && argoerrs.IsCode(argoerrs.CodeNotFound, err) {
+					log.Infof("Skipping optional input artifact that was not found: %s", art.Name)
+					continue
+				}
+				return fmt.Errorf("artifact %s failed to load: %w", art.Name, err)
+			}
+			if err := we.artifactCache.store(art.Checksum, tempArtPath); err != nil {
+				log.WithError(err).Warnf("Failed to cache artifact %s", art.Name)
+			}
+			if we.artifactCache != nil && driverArt.HTTP != nil && driverArt.Checksum == "" {
+				// the driver may have kept a copy of the artifact in the cache
+				if err := we.artifactCache.evict(); err != nil {
+					log.WithError(err).Warn("Failed to evict artifacts from the cache")
+				}
 			}
-			return fmt.Errorf("artifact %s failed to load: %w", art.Name, err)
 		}
 
 		isTar := false
@@ -325,7 +373,21 @@ func (we *WorkflowExecutor) saveArtifact(ctx context.Context, containerName stri
 	if size == 0 {
 		log.Warnf("The file %q is empty. It may not be uploaded successfully depending on the artifact driver", localArtPath)
 	}
-	return we.saveArtifactFromFile(ctx, art, fileName, localArtPath)
+	// the checksum is calculated before saving, as saving may delete the file
+	var checksum string
+	if fi.Mode().IsRegular() {
+		checksum, err = fileSha256Sum(localArtPath)
+		if err != nil {
+			return err
+		}
+	}
+	err = we.saveArtifactFromFile(ctx, art, fileName, localArtPath)
+	if err != nil {
+		return err
+	}
+	art.SizeBytes = size
+	art.Checksum = checksum
+	return nil
 }
 
 // fileBase is probably path.Base(filePath), but can be something else
@@ -354,15 +416,122 @@ func (we *WorkflowExecutor) saveArtifactFromFile(ctx context.Context, art *wfv1.
 	if err != nil {
 		return err
 	}
+	if len(driverArt.ObjectMetadata) > 0 && driverArt.S3 == nil && driverArt.GCS == nil {
+		log.Warnf("Artifact %s is saved without its object metadata, which is only supported by S3 and GCS", art.Name)
+	}
+	if art.IfNotPresent != nil && isArtifactPresent(artDriver, driverArt, localArtPath, art.IfNotPresent.Checksum) {
+		art.UploadSkipped = true
+		we.maybeDeleteLocalArtPath(localArtPath)
+		log.Infof("Skipped saving file %s, artifact %s is already present", localArtPath, art.Name)
+		return nil
+	}
 	err = artDriver.Save(localArtPath, driverArt)
 	if err != nil {
-		return err
+		return argoerrs.Errorf(argoerrs.CodeArtifactUpload, "failed to upload artifact %s: %v", art.Name, err)
 	}
 	we.maybeDeleteLocalArtPath(localArtPath)
 	log.Infof("Successfully saved file: %s", localArtPath)
 	return nil
 }
 
+// isArtifactPresent returns true if the artifact already exists in the repository and, if checksum is set, has the
+// same SHA-256 checksum as the local file. Any error is taken to mean it is not present, so that it is uploaded.
+func isArtifactPresent(driver artifactcommon.ArtifactDriver, art *wfv1.Artifact, localArtPath string, checksum bool) bool {
+	fi, err := os.Stat(localArtPath)
+	if err != nil {
+		return false
+	}
+	if fi.IsDir() {
+		if checksum {
+			log.Infof("Cannot compare the checksum of directory %s, it will be uploaded", localArtPath)
+			return false
+		}
+		files, err := driver.ListObjects(art)
+		return err == nil && len(files) > 0
+	}
+	stream, err := driver.OpenStream(art)
+	if err != nil {
+		log.WithError(err).Debugf("Artifact %s is not present", art.Name)
+		return false
+	}
+	defer func() { _ = stream.Close() }()
+	if !checksum {
+		// some drivers only fail when the stream is first read
+		_, err := stream.Read(make([]byte, 1))
+		return err == nil || err == io.EOF
+	}
+	remote, err := sha256Sum(stream)
+	if err != nil {
+		log.WithError(err).Debugf("Artifact %s is not present", art.Name)
+		return false
+	}
+	local, err := fileSha256Sum(localArtPath)
+	if err != nil {
+		return false
+	}
+	if local != remote {
+		log.Infof("Artifact %s is present with a different checksum, it will be uploaded", art.Name)
+		return false
+	}
+	return true
+}
+
+// loadArtifact loads the artifact to path and verifies its checksum, unless it has none. An artifact that does not
+// match its checksum, e.g. as its download was truncated, is loaded once more before giving up.
+func loadArtifact(driver artifactcommon.ArtifactDriver, art *wfv1.Artifact, path, checksum string) error {
+	for attempt := 0; ; attempt++ {
+		if err := driver.Load(art, path); err != nil {
+			return err
+		}
+		err := verifyChecksum(path, checksum)
+		if err == nil || attempt > 0 {
+			return err
+		}
+		log.WithError(err).Warnf("Loading artifact %s again", art.Name)
+		_ = os.RemoveAll(path)
+	}
+}
+
+// verifyChecksum returns an error if the file at path does not have the SHA-256 checksum. Directories, and files
+// without a checksum, are not verified.
+func verifyChecksum(path, checksum string) error {
+	if checksum == "" {
+		return nil
+	}
+	fi, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	if !fi.Mode().IsRegular() {
+		return nil
+	}
+	actual, err := fileSha256Sum(path)
+	if err != nil {
+		return err
+	}
+	if actual != checksum {
+		return fmt.Errorf("checksum %s does not match the artifact's checksum %s, it may be truncated or corrupted", actual, checksum)
+	}
+	return nil
+}
+
+func sha256Sum(r io.Reader) (string, error) {
+	h := sha256.New()
+	if _, err := io.Copy(h, r); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func fileSha256Sum(path string) (string, error) {
+	f, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = f.Close() }()
+	return sha256Sum(f)
+}
+
 func (we *WorkflowExecutor) maybeDeleteLocalArtPath(localArtPath string) {
 	if os.Getenv("REMOVE_LOCAL_ART_PATH") == "true" {
 		log.WithField("localArtPath", localArtPath).Info("deleting local artifact")
@@ -423,7 +592,7 @@ func (we *WorkflowExecutor) stageArchiveFile(containerName string, art *wfv1.Art
 			}
 			zw := zip.NewWriter(f)
 			defer zw.Close()
-			err = archive.ZipToWriter(mountedArtPath, zw)
+			err = archive.ZipToWriter(mountedArtPath, zw, art.Paths...)
 			if err != nil {
 				return "", "", err
 			}
@@ -437,7 +606,7 @@ func (we *WorkflowExecutor) stageArchiveFile(containerName string, art *wfv1.Art
 			return "", "", argoerrs.InternalWrapError(err)
 		}
 		w := bufio.NewWriter(f)
-		err = archive.TarGzToWriter(mountedArtPath, compressionLevel, w)
+		err = archive.TarGzToWriter(mountedArtPath, compressionLevel, w, art.Paths...)
 		if err != nil {
 			return "", "", err
 		}
@@ -615,11 +784,15 @@ func (we *WorkflowExecutor) SaveLogs(ctx context.Context) {
 	}
 }
 
-// saveContainerLogs saves a single container's log into a file
+// saveContainerLogs saves a single container's log into a file, gzip-compressed if the archive location compresses logs
 func (we *WorkflowExecutor) saveContainerLogs(ctx context.Context, tempLogsDir, containerName string) (*wfv1.Artifact, error) {
 	fileName := containerName + ".log"
+	compress := we.Template.ArchiveLocation.IsCompressLogs()
+	if compress {
+		fileName += ".gz"
+	}
 	filePath := path.Join(tempLogsDir, fileName)
-	err := we.saveLogToFile(ctx, containerName, filePath)
+	err := we.saveLogToFile(ctx, containerName, filePath, compress)
 	if err != nil {
 		return nil, err
 	}
@@ -642,8 +815,8 @@ func (we *WorkflowExecutor) GetSecret(ctx context.Context, accessKeyName string,
 	return string(file), nil
 }
 
-// saveLogToFile saves the entire log output of a container to a local file
-func (we *WorkflowExecutor) saveLogToFile(ctx context.Context, containerName, path string) error {
+// saveLogToFile saves the entire log output of a container to a local file, gzip-compressed if compress is true
+func (we *WorkflowExecutor) saveLogToFile(ctx context.Context, containerName, path string, compress bool) error {
 	outFile, err := os.Create(path)
 	if err != nil {
 		return argoerrs.InternalWrapError(err)
@@ -654,7 +827,20 @@ func (we *WorkflowExecutor) saveLogToFile(ctx context.Context, containerName, pa
 		return err
 	}
 	defer func() { _ = reader.Close() }()
-	_, err = io.Copy(outFile, reader)
+	if !compress {
+		_, err = io.Copy(outFile, reader)
+		if err != nil {
+			return argoerrs.InternalWrapError(err)
+		}
+		return nil
+	}
+	gzipWriter := gzip.NewWriter(outFile)
+	_, err = io.Copy(gzipWriter, reader)
+	if err != nil {
+		return argoerrs.InternalWrapError(err)
+	}
+	// closing the writer writes the end of the gzip stream
+	err = gzipWriter.Close()
 	if err != nil {
 		return argoerrs.InternalWrapError(err)
 	}
@@ -669,13 +855,44 @@ func (we *WorkflowExecutor) newDriverArt(art *wfv1.Artifact) (*wfv1.Artifact, er
 
 // InitDriver initializes an instance of an artifact driver
 func (we *WorkflowExecutor) InitDriver(ctx context.Context, art *wfv1.Artifact) (artifactcommon.ArtifactDriver, error) {
+	limit, err := we.getBandwidthLimit()
+	if err != nil {
+		return nil, err
+	}
+	if !limit.IsZero() {
+		ctx = artifactcommon.WithBandwidthLimit(ctx, limit)
+	}
 	driver, err := artifact.NewDriver(ctx, art, we)
 	if err == artifact.ErrUnsupportedDriver {
 		return nil, argoerrs.Errorf(argoerrs.CodeBadRequest, "Unsupported artifact driver for %s", art.Name)
 	}
+	if err == nil && we.artifactErrorRate > 0 {
+		driver = faultinjection.New(driver, we.artifactErrorRate)
+	}
 	return driver, err
 }
 
+// getBandwidthLimit returns the template's artifact bandwidth limit. It is only made once, so that the drivers of all
+// the artifacts of the step share it, rather than each artifact being transferred at up to the limit.
+func (we *WorkflowExecutor) getBandwidthLimit() (artifactcommon.BandwidthLimit, error) {
+	if we.bandwidthLimit == nil {
+		limit := artifactcommon.BandwidthLimit{}
+		if bw := we.Template.ArtifactBandwidth; bw != nil {
+			upload, err := bw.GetUpload()
+			if err != nil {
+				return limit, argoerrs.Errorf(argoerrs.CodeBadRequest, "Invalid artifactBandwidth.upload: %v", err)
+			}
+			download, err := bw.GetDownload()
+			if err != nil {
+				return limit, argoerrs.Errorf(argoerrs.CodeBadRequest, "Invalid artifactBandwidth.download: %v", err)
+			}
+			limit = artifactcommon.NewBandwidthLimit(upload, download)
+		}
+		we.bandwidthLimit = &limit
+	}
+	return *we.bandwidthLimit, nil
+}
+
 // GetConfigMapKey retrieves a configmap value and memoizes the result
 func (we *WorkflowExecutor) GetConfigMapKey(ctx context.Context, name, key string) (string, error) {
 	namespace := we.Namespace
@@ -1074,6 +1291,12 @@ func (we *WorkflowExecutor) Wait(ctx context.Context) error {
 
 	go we.monitorDeadline(ctx, containerNames)
 
+	if we.Template.Heartbeat != nil {
+		go we.monitorHeartbeat(ctx, containerNames)
+	}
+
+	go we.monitorSignals(ctx, common.ArgoSignalPath, common.VarRunArgoPath, containerNames)
+
 	err := retryutil.OnError(executorretry.ExecutorRetry, errorsutil.IsTransientErr, func() error {
 		return we.RuntimeExecutor.Wait(ctx, containerNames)
 	})
@@ -1164,6 +1387,122 @@ func (we *WorkflowExecutor) monitorDeadline(ctx context.Context, containerNames
 	we.killContainers(ctx, containerNames)
 }
 
+// monitorHeartbeat terminates the main containers if none of them has written to its logs or the progress file
+// within the template's heartbeat timeout, so that a hung step fails and its retry strategy is applied.
+// The timeout only starts once a main container has started.
+func (we *WorkflowExecutor) monitorHeartbeat(ctx context.Context, containerNames []string) {
+	timeout, err := we.Template.Heartbeat.GetTimeout()
+	if err != nil {
+		log.WithError(err).Warn("Invalid heartbeat timeout, heartbeat monitor disabled")
+		return
+	}
+	files := heartbeatFiles(os.Getenv(common.EnvVarProgressFile), containerNames)
+
+	ticker := time.NewTicker(heartbeatCheckInterval(timeout))
+	defer ticker.Stop()
+
+	log.WithField("timeout", timeout).Infof("Starting heartbeat monitor")
+	for {
+		select {
+		case <-ctx.Done():
+			log.Info("Heartbeat monitor stopped")
+			return
+		case <-ticker.C:
+			lastHeartbeat := lastModified(files)
+			if lastHeartbeat.IsZero() || time.Since(lastHeartbeat) <= timeout {
+				continue
+			}
+			message := fmt.Sprintf("Step did not report a heartbeat within %v", timeout)
+			log.Info(message)
+			util.WriteTerminateMessage(message)
+			we.killContainers(ctx, containerNames)
+			return
+		}
+	}
+}
+
+// monitorSignals delivers the signals requested for the main containers, which the controller passes on to the signal
+// file. A signal is delivered by leaving it for the emissary of each main container, which sends it to its process.
+func (we *WorkflowExecutor) monitorSignals(ctx context.Context, signalFile, varRunArgo string, containerNames []string) {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	lastRequest := ""
+	log.Info("Starting signal monitor")
+	for {
+		select {
+		case <-ctx.Done():
+			log.Info("Signal monitor stopped")
+			return
+		case <-ticker.C:
+			data, err := os.ReadFile(filepath.Clean(signalFile))
+			if err != nil {
+				continue
+			}
+			request := strings.TrimSpace(string(data))
+			if request == "" || request == lastRequest {
+				continue
+			}
+			lastRequest = request
+			deliverSignal(request, varRunArgo, containerNames)
+		}
+	}
+}
+
+// deliverSignal leaves the signal of the request for the emissary of each of the containers
+func deliverSignal(request, varRunArgo string, containerNames []string) {
+	s, err := signal.ParseRequest(request)
+	if err != nil {
+		log.WithError(err).Warn("Ignoring signal request")
+		return
+	}
+	for _, containerName := range containerNames {
+		if err := os.WriteFile(filepath.Join(varRunArgo, "ctr", containerName, "signal"), []byte(strconv.Itoa(int(s))), 0o666); err != nil { //nolint:gosec
+			log.WithError(err).WithField("containerName", containerName).Warn("failed to deliver signal")
+			continue
+		}
+		log.WithField("containerName", containerName).WithField("signal", s).Info("Delivered signal")
+	}
+}
+
+// heartbeatFiles returns the files the main containers write to, which are the progress file, if there is one, and
+// their logs
+func heartbeatFiles(progressFile string, containerNames []string) []string {
+	var files []string
+	if progressFile != "" {
+		files = append(files, filepath.Clean(progressFile))
+	}
+	for _, containerName := range containerNames {
+		files = append(files, filepath.Join(common.VarRunArgoPath, "ctr", containerName, "combined"))
+	}
+	return files
+}
+
+// heartbeatCheckInterval checks often enough to notice a missed heartbeat promptly, without polling short timeouts
+// more than once a second.
+func heartbeatCheckInterval(timeout time.Duration) time.Duration {
+	interval := timeout / 10
+	if interval < time.Second {
+		return time.Second
+	}
+	return interval
+}
+
+// lastModified returns the most recent modification time of the files which exist
+func lastModified(files []string) time.Time {
+	var latest time.Time
+	for _, file := range files {
+		info, err := os.Stat(file)
+		if err != nil {
+			continue
+		}
+		if info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+	}
+	return latest
+}
+
 func (we *WorkflowExecutor) killContainers(ctx context.Context, containerNames []string) {
 	log.Infof("Killing containers")
 	terminationGracePeriodDuration := getTerminationGracePeriodDuration()
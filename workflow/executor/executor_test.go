@@ -1,16 +1,19 @@
 package executor
 
 import (
+	"compress/gzip"
 	"context"
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes/fake"
@@ -18,6 +21,7 @@ import (
 
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 	argofake "github.com/argoproj/argo-workflows/v3/pkg/client/clientset/versioned/fake"
+	artifactcommon "github.com/argoproj/argo-workflows/v3/workflow/artifacts/common"
 	"github.com/argoproj/argo-workflows/v3/workflow/common"
 	"github.com/argoproj/argo-workflows/v3/workflow/executor/mocks"
 )
@@ -537,4 +541,169 @@ func TestSaveLogs(t *testing.T) {
 		we.SaveLogs(ctx)
 		assert.EqualError(t, we.errors[0], artStorageError)
 	})
+	t.Run("Compressed", func(t *testing.T) {
+		mockRuntimeExecutor := mocks.ContainerRuntimeExecutor{}
+		mockRuntimeExecutor.On("GetOutputStream", mock.Anything, "main", true).Return(io.NopCloser(strings.NewReader("hello world")), nil)
+		we := WorkflowExecutor{RuntimeExecutor: &mockRuntimeExecutor}
+		filePath := filepath.Join(t.TempDir(), "main.log.gz")
+		if assert.NoError(t, we.saveLogToFile(context.Background(), "main", filePath, true)) {
+			f, err := os.Open(filePath)
+			if assert.NoError(t, err) {
+				defer f.Close()
+				r, err := gzip.NewReader(f)
+				if assert.NoError(t, err) {
+					data, err := io.ReadAll(r)
+					assert.NoError(t, err)
+					assert.Equal(t, "hello world", string(data))
+				}
+			}
+		}
+	})
+}
+
+func TestLastModified(t *testing.T) {
+	dir := t.TempDir()
+	older := filepath.Join(dir, "older")
+	newer := filepath.Join(dir, "newer")
+	assert.True(t, lastModified([]string{older, newer}).IsZero())
+
+	assert.NoError(t, os.WriteFile(older, nil, 0o600))
+	assert.NoError(t, os.WriteFile(newer, nil, 0o600))
+	now := time.Now().Truncate(time.Second)
+	assert.NoError(t, os.Chtimes(older, now.Add(-time.Hour), now.Add(-time.Hour)))
+	assert.NoError(t, os.Chtimes(newer, now, now))
+	assert.True(t, now.Equal(lastModified([]string{older, newer, filepath.Join(dir, "missing")})))
+}
+
+func TestHeartbeatFiles(t *testing.T) {
+	assert.Equal(t, []string{"/var/run/argo/ctr/main/combined"}, heartbeatFiles("", []string{"main"}))
+	assert.Equal(t, []string{"/my/progress", "/var/run/argo/ctr/main/combined"}, heartbeatFiles("/my//progress", []string{"main"}))
+}
+
+func TestGetBandwidthLimit(t *testing.T) {
+	t.Run("Shared", func(t *testing.T) {
+		we := &WorkflowExecutor{Template: wfv1.Template{ArtifactBandwidth: &wfv1.ArtifactBandwidth{Upload: "1Mi"}}}
+		limit, err := we.getBandwidthLimit()
+		require.NoError(t, err)
+		assert.Equal(t, int64(1024*1024), limit.Upload)
+		shared := we.bandwidthLimit
+		_, err = we.getBandwidthLimit()
+		require.NoError(t, err)
+		// every driver gets the same limit, so that the artifacts of the step share it
+		assert.Same(t, shared, we.bandwidthLimit)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		we := &WorkflowExecutor{Template: wfv1.Template{ArtifactBandwidth: &wfv1.ArtifactBandwidth{Download: "fast"}}}
+		_, err := we.getBandwidthLimit()
+		assert.ErrorContains(t, err, "Invalid artifactBandwidth.download")
+	})
+}
+
+func TestHeartbeatCheckInterval(t *testing.T) {
+	assert.Equal(t, time.Second, heartbeatCheckInterval(5*time.Second))
+	assert.Equal(t, time.Minute, heartbeatCheckInterval(10*time.Minute))
+}
+
+func TestMonitorSignals(t *testing.T) {
+	dir := t.TempDir()
+	signalFile := filepath.Join(dir, "signal")
+	ctrDir := filepath.Join(dir, "ctr", "main")
+	require.NoError(t, os.MkdirAll(ctrDir, 0o700))
+	require.NoError(t, os.WriteFile(signalFile, []byte("1:10"), 0o600))
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	we := &WorkflowExecutor{}
+	go we.monitorSignals(ctx, signalFile, dir, []string{"main"})
+	assert.Eventually(t, func() bool {
+		data, err := os.ReadFile(filepath.Join(ctrDir, "signal"))
+		return err == nil && string(data) == "10"
+	}, 5*time.Second, 100*time.Millisecond)
+
+	// the emissary removes the signal once it has sent it, and the same request is not delivered again
+	require.NoError(t, os.Remove(filepath.Join(ctrDir, "signal")))
+	time.Sleep(1500 * time.Millisecond)
+	assert.NoFileExists(t, filepath.Join(ctrDir, "signal"))
+}
+
+func TestDeliverSignal(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"a", "b"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, "ctr", name), 0o700))
+	}
+	deliverSignal("1:12", dir, []string{"a", "b"})
+	for _, name := range []string{"a", "b"} {
+		data, err := os.ReadFile(filepath.Join(dir, "ctr", name, "signal"))
+		require.NoError(t, err)
+		assert.Equal(t, "12", string(data))
+	}
+	deliverSignal("invalid", dir, []string{"c"})
+	assert.NoFileExists(t, filepath.Join(dir, "ctr", "c", "signal"))
+}
+
+type fakeArtifactDriver struct {
+	artifactcommon.ArtifactDriver
+	data []byte
+}
+
+func (d *fakeArtifactDriver) OpenStream(*wfv1.Artifact) (io.ReadCloser, error) {
+	if d.data == nil {
+		return nil, fmt.Errorf("not found")
+	}
+	return io.NopCloser(strings.NewReader(string(d.data))), nil
+}
+
+func TestIsArtifactPresent(t *testing.T) {
+	localArtPath := filepath.Join(t.TempDir(), "out.txt")
+	assert.NoError(t, os.WriteFile(localArtPath, []byte("hello"), 0o600))
+	art := &wfv1.Artifact{Name: "out"}
+
+	assert.False(t, isArtifactPresent(&fakeArtifactDriver{}, art, localArtPath, false))
+	assert.True(t, isArtifactPresent(&fakeArtifactDriver{data: []byte("goodbye")}, art, localArtPath, false))
+	assert.False(t, isArtifactPresent(&fakeArtifactDriver{data: []byte("goodbye")}, art, localArtPath, true))
+	assert.True(t, isArtifactPresent(&fakeArtifactDriver{data: []byte("hello")}, art, localArtPath, true))
+}
+
+// loadingArtifactDriver loads each of its contents in turn, the last one from then on
+type loadingArtifactDriver struct {
+	artifactcommon.ArtifactDriver
+	contents []string
+	loads    int
+}
+
+func (d *loadingArtifactDriver) Load(_ *wfv1.Artifact, path string) error {
+	content := d.contents[min(d.loads, len(d.contents)-1)]
+	d.loads++
+	return os.WriteFile(path, []byte(content), 0o600)
+}
+
+func TestLoadArtifact(t *testing.T) {
+	checksum, err := sha256Sum(strings.NewReader("hello"))
+	require.NoError(t, err)
+	art := &wfv1.Artifact{Name: "in"}
+	path := filepath.Join(t.TempDir(), "in")
+
+	t.Run("NoChecksum", func(t *testing.T) {
+		driver := &loadingArtifactDriver{contents: []string{"hel"}}
+		assert.NoError(t, loadArtifact(driver, art, path, ""))
+		assert.Equal(t, 1, driver.loads)
+	})
+	t.Run("Verified", func(t *testing.T) {
+		driver := &loadingArtifactDriver{contents: []string{"hello"}}
+		assert.NoError(t, loadArtifact(driver, art, path, checksum))
+		assert.Equal(t, 1, driver.loads)
+	})
+	t.Run("Truncated", func(t *testing.T) {
+		driver := &loadingArtifactDriver{contents: []string{"hel", "hello"}}
+		assert.NoError(t, loadArtifact(driver, art, path, checksum))
+		assert.Equal(t, 2, driver.loads)
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "hello", string(data))
+	})
+	t.Run("Corrupted", func(t *testing.T) {
+		driver := &loadingArtifactDriver{contents: []string{"hallo"}}
+		err := loadArtifact(driver, art, path, checksum)
+		assert.ErrorContains(t, err, "does not match the artifact's checksum "+checksum)
+		assert.Equal(t, 2, driver.loads)
+	})
 }
@@ -15,15 +15,18 @@ func CanIgnoreSignal(s os.Signal) bool {
 	return false
 }
 
+// Kill terminates the process, as Windows processes cannot be sent signals. The process exits with the code that a
+// process killed by the signal has on Linux, e.g. 143 for SIGTERM, so that the node's exit code is the same on both.
 func Kill(pid int, s syscall.Signal) error {
 	if pid < 0 {
 		pid = -pid // // we cannot kill a negative process on windows
 	}
-	p, err := os.FindProcess(pid)
+	h, err := syscall.OpenProcess(syscall.PROCESS_TERMINATE, false, uint32(pid))
 	if err != nil {
-		return err
+		return os.NewSyscallError("OpenProcess", err)
 	}
-	return p.Signal(s)
+	defer func() { _ = syscall.CloseHandle(h) }()
+	return os.NewSyscallError("TerminateProcess", syscall.TerminateProcess(h, uint32(128+s)))
 }
 
 func Setpgid(a *syscall.SysProcAttr) {
@@ -32,8 +35,11 @@ func Setpgid(a *syscall.SysProcAttr) {
 
 func Wait(process *os.Process) error {
 	stat, err := process.Wait()
+	if err != nil {
+		return err
+	}
 	if stat.ExitCode() != 0 {
 		return errors.NewExitErr(stat.ExitCode())
 	}
-	return err
+	return nil
 }
@@ -0,0 +1,116 @@
+package executor
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	envutil "github.com/argoproj/argo-workflows/v3/util/env"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+// ApplyResourceOperator defaults the success and failure conditions of the resource template to the operator's, and
+// the output parameters without a valueFrom to the operator's status fields of the same name
+func (we *WorkflowExecutor) ApplyResourceOperator(operator *common.ResourceOperator) {
+	if operator == nil {
+		return
+	}
+	if we.Template.Resource.SuccessCondition == "" && we.Template.Resource.FailureCondition == "" {
+		we.Template.Resource.SuccessCondition = operator.SuccessCondition
+		we.Template.Resource.FailureCondition = operator.FailureCondition
+	}
+	for i, param := range we.Template.Outputs.Parameters {
+		if jsonPath, ok := operator.Outputs[param.Name]; ok && param.ValueFrom == nil && param.Value == nil {
+			we.Template.Outputs.Parameters[i].ValueFrom = &wfv1.ValueFrom{JSONPath: jsonPath}
+		}
+	}
+}
+
+// StreamResourceOperatorLogs prints the logs of the operator's pods for the resource, prefixed with the pod and
+// container name, so that they are in the logs of the main container. It stops looking for new pods when the
+// returned function is called, which then waits for the logs of the pods that have been found for up to
+// RESOURCE_OPERATOR_LOGS_TIMEOUT.
+func (we *WorkflowExecutor) StreamResourceOperatorLogs(ctx context.Context, operator *common.ResourceOperator, resourceNamespace, resourceName string) func() {
+	if operator == nil {
+		return func() {}
+	}
+	// the resource name is e.g. sparkapplication.sparkoperator.k8s.io/my-app
+	name := resourceName[strings.LastIndex(resourceName, "/")+1:]
+	selector := operator.GetLogSelector(name)
+	streamCtx, cancelStreams := context.WithCancel(ctx)
+	pollCtx, cancelPoll := context.WithCancel(ctx)
+	streamed := make(map[string]bool)
+	wg := &sync.WaitGroup{}
+	streamNewPods := func() {
+		pods, err := we.ClientSet.CoreV1().Pods(resourceNamespace).List(streamCtx, metav1.ListOptions{LabelSelector: selector})
+		if err != nil {
+			log.WithError(err).WithField("selector", selector).Warn("failed to list operator pods")
+			return
+		}
+		for _, pod := range pods.Items {
+			if streamed[pod.Name] || pod.Status.Phase == corev1.PodPending {
+				continue
+			}
+			streamed[pod.Name] = true
+			for _, c := range pod.Spec.Containers {
+				wg.Add(1)
+				go func(podName, containerName string) {
+					defer wg.Done()
+					we.streamContainerLogs(streamCtx, resourceNamespace, podName, containerName)
+				}(pod.Name, c.Name)
+			}
+		}
+	}
+	polled := make(chan struct{})
+	go func() {
+		defer close(polled)
+		ticker := time.NewTicker(envutil.LookupEnvDurationOr("RESOURCE_STATE_CHECK_INTERVAL", time.Second*5))
+		defer ticker.Stop()
+		for {
+			streamNewPods()
+			select {
+			case <-pollCtx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return func() {
+		cancelPoll()
+		<-polled
+		// pods may have started and completed since the last poll
+		streamNewPods()
+		done := make(chan struct{})
+		go func() {
+			wg.Wait()
+			close(done)
+		}()
+		select {
+		case <-done:
+		case <-time.After(envutil.LookupEnvDurationOr("RESOURCE_OPERATOR_LOGS_TIMEOUT", 30*time.Second)):
+			log.Warn("timed out waiting for the logs of the operator pods, e.g. because they are still running")
+		}
+		cancelStreams()
+	}
+}
+
+func (we *WorkflowExecutor) streamContainerLogs(ctx context.Context, namespace, podName, containerName string) {
+	stream, err := we.ClientSet.CoreV1().Pods(namespace).GetLogs(podName, &corev1.PodLogOptions{Container: containerName, Follow: true}).Stream(ctx)
+	if err != nil {
+		log.WithError(err).WithField("pod", podName).Warn("failed to stream operator pod logs")
+		return
+	}
+	defer func() { _ = stream.Close() }()
+	scanner := bufio.NewScanner(stream)
+	for scanner.Scan() {
+		fmt.Printf("%s/%s: %s\n", podName, containerName, scanner.Text())
+	}
+}
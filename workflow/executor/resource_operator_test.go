@@ -0,0 +1,36 @@
+package executor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+func TestApplyResourceOperator(t *testing.T) {
+	operator := common.ResourceOperators[wfv1.ResourceOperatorSpark]
+	t.Run("Defaults", func(t *testing.T) {
+		we := WorkflowExecutor{Template: wfv1.Template{
+			Resource: &wfv1.ResourceTemplate{Action: "create", Operator: wfv1.ResourceOperatorSpark},
+			Outputs: wfv1.Outputs{Parameters: []wfv1.Parameter{
+				{Name: "applicationId"},
+				{Name: "name", ValueFrom: &wfv1.ValueFrom{JSONPath: "{.metadata.name}"}},
+			}},
+		}}
+		we.ApplyResourceOperator(&operator)
+		assert.Equal(t, "status.applicationState.state == COMPLETED", we.Template.Resource.SuccessCondition)
+		assert.Equal(t, "status.applicationState.state in (FAILED, SUBMISSION_FAILED)", we.Template.Resource.FailureCondition)
+		assert.Equal(t, &wfv1.ValueFrom{JSONPath: "{.status.sparkApplicationId}"}, we.Template.Outputs.Parameters[0].ValueFrom)
+		assert.Equal(t, &wfv1.ValueFrom{JSONPath: "{.metadata.name}"}, we.Template.Outputs.Parameters[1].ValueFrom)
+	})
+	t.Run("Conditions", func(t *testing.T) {
+		we := WorkflowExecutor{Template: wfv1.Template{
+			Resource: &wfv1.ResourceTemplate{Action: "create", Operator: wfv1.ResourceOperatorSpark, SuccessCondition: "status.applicationState.state == RUNNING"},
+		}}
+		we.ApplyResourceOperator(&operator)
+		assert.Equal(t, "status.applicationState.state == RUNNING", we.Template.Resource.SuccessCondition)
+		assert.Empty(t, we.Template.Resource.FailureCondition)
+	})
+}
@@ -0,0 +1,75 @@
+package export
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	bigquery "google.golang.org/api/bigquery/v2"
+	"google.golang.org/api/option"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/argoproj/argo-workflows/v3/config"
+	"github.com/argoproj/argo-workflows/v3/util"
+)
+
+// bigQueryExporter streams the records into a BigQuery table, with the uid of each as its insert ID so BigQuery
+// deduplicates records that are exported again
+type bigQueryExporter struct {
+	name       string
+	sink       config.BigQueryExportSink
+	kubeClient kubernetes.Interface
+	namespace  string
+	// options are added to the options of the BigQuery client, for testing
+	options []option.ClientOption
+}
+
+func (e *bigQueryExporter) Name() string {
+	return e.name
+}
+
+func (e *bigQueryExporter) Export(ctx context.Context, records []Record) error {
+	opts := e.options
+	if s := e.sink.CredentialsSecret; s != nil {
+		credentials, err := util.GetSecrets(ctx, e.kubeClient, e.namespace, s.Name, s.Key)
+		if err != nil {
+			return err
+		}
+		opts = append([]option.ClientOption{option.WithCredentialsJSON(credentials)}, opts...)
+	}
+	service, err := bigquery.NewService(ctx, opts...)
+	if err != nil {
+		return err
+	}
+	req := &bigquery.TableDataInsertAllRequest{}
+	for _, r := range records {
+		row := make(map[string]bigquery.JsonValue, len(columns))
+		for _, c := range columns {
+			v := c.value(r)
+			switch {
+			case isNull(v):
+				row[c.name] = nil
+			case c.typ == columnTimestamp:
+				row[c.name] = v.(time.Time).Format(time.RFC3339Nano)
+			default:
+				row[c.name] = v
+			}
+		}
+		req.Rows = append(req.Rows, &bigquery.TableDataInsertAllRequestRows{InsertId: r.UID, Json: row})
+	}
+	resp, err := service.Tabledata.InsertAll(e.sink.Project, e.sink.Dataset, e.sink.Table, req).Context(ctx).Do()
+	if err != nil {
+		return err
+	}
+	if len(resp.InsertErrors) > 0 {
+		var messages []string
+		for _, insertErr := range resp.InsertErrors {
+			for _, e := range insertErr.Errors {
+				messages = append(messages, fmt.Sprintf("row %d: %s", insertErr.Index, e.Message))
+			}
+		}
+		return fmt.Errorf("failed to insert %d rows: %s", len(resp.InsertErrors), strings.Join(messages, "; "))
+	}
+	return nil
+}
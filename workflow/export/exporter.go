@@ -0,0 +1,172 @@
+package export
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/argoproj/argo-workflows/v3/config"
+)
+
+// Exporter exports records to a sink
+type Exporter interface {
+	// Name is the name of the sink
+	Name() string
+	// Export exports the records, at least once, so a sink may have duplicates of a record which are deduplicated by
+	// its uid
+	Export(ctx context.Context, records []Record) error
+}
+
+// NewExporters returns the exporters to the sinks, which read their secrets from the namespace each time they export
+func NewExporters(kubeClient kubernetes.Interface, namespace string, c *config.WorkflowExport) ([]Exporter, error) {
+	var exporters []Exporter
+	for _, s := range c.Sinks {
+		switch {
+		case s.BigQuery != nil:
+			exporters = append(exporters, &bigQueryExporter{name: s.Name, sink: *s.BigQuery, kubeClient: kubeClient, namespace: namespace})
+		case s.Snowflake != nil:
+			exporters = append(exporters, &snowflakeExporter{name: s.Name, sink: *s.Snowflake, kubeClient: kubeClient, namespace: namespace, client: http.DefaultClient})
+		case s.S3 != nil:
+			exporters = append(exporters, &s3Exporter{name: s.Name, sink: *s.S3, resources: resources{kubeClient, namespace}})
+		default:
+			return nil, fmt.Errorf("workflow export sink %q has no bigQuery, snowflake or s3", s.Name)
+		}
+	}
+	return exporters, nil
+}
+
+// exportedExpiry is how long the uid of an exported record is remembered, so a workflow is not exported again while it
+// is still labelled pending export in the informer's cache
+const exportedExpiry = 10 * time.Minute
+
+// Queue batches the records, and exports each batch to each of the exporters once it has the batch size of records,
+// or its oldest record has waited the flush interval
+type Queue struct {
+	mu            sync.Mutex
+	exporters     []Exporter
+	batchSize     int
+	flushInterval time.Duration
+	records       []Record
+	oldest        time.Time
+	// uids are the uids of the records that are queued, being exported, or were recently exported, and when
+	uids map[string]time.Time
+	// onExported is called with the records that were exported to all of the sinks
+	onExported func(ctx context.Context, records []Record)
+}
+
+func NewQueue(onExported func(ctx context.Context, records []Record)) *Queue {
+	return &Queue{uids: make(map[string]time.Time), onExported: onExported}
+}
+
+// Configure sets the exporters, batch size and flush interval of the queue, no exporters disabling it
+func (q *Queue) Configure(exporters []Exporter, batchSize int, flushInterval time.Duration) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.exporters = exporters
+	q.batchSize = batchSize
+	q.flushInterval = flushInterval
+}
+
+// Add queues the record, unless it is already queued or was recently exported, and returns whether it was queued
+func (q *Queue) Add(r Record) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if len(q.exporters) == 0 {
+		return false
+	}
+	if _, ok := q.uids[r.UID]; ok {
+		return false
+	}
+	if len(q.records) == 0 {
+		q.oldest = time.Now()
+	}
+	q.records = append(q.records, r)
+	q.uids[r.UID] = time.Now()
+	return true
+}
+
+// Run exports the batches until the context is done
+func (q *Queue) Run(ctx context.Context) {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			for q.flush(ctx) {
+			}
+		}
+	}
+}
+
+// flush exports the next batch, if it is due, and returns whether it did
+func (q *Queue) flush(ctx context.Context) bool {
+	q.mu.Lock()
+	q.forgetExported()
+	n := len(q.records)
+	if n == 0 || (n < q.batchSize && time.Since(q.oldest) < q.flushInterval) {
+		q.mu.Unlock()
+		return false
+	}
+	if n > q.batchSize {
+		n = q.batchSize
+	}
+	batch := q.records[:n:n]
+	q.records = q.records[n:]
+	q.oldest = time.Now()
+	exporters := q.exporters
+	q.mu.Unlock()
+
+	err := q.export(ctx, exporters, batch)
+
+	q.mu.Lock()
+	for _, r := range batch {
+		if err != nil {
+			// forget the failed records, so they are queued again when the informer next resyncs the workflows
+			delete(q.uids, r.UID)
+		} else {
+			q.uids[r.UID] = time.Now()
+		}
+	}
+	q.mu.Unlock()
+	if err == nil {
+		q.onExported(ctx, batch)
+	}
+	return true
+}
+
+func (q *Queue) export(ctx context.Context, exporters []Exporter, batch []Record) error {
+	var failed bool
+	for _, e := range exporters {
+		logCtx := log.WithFields(log.Fields{"sink": e.Name(), "records": len(batch)})
+		if err := e.Export(ctx, batch); err != nil {
+			logCtx.WithError(err).Error("failed to export workflow records")
+			failed = true
+			continue
+		}
+		logCtx.Info("exported workflow records")
+	}
+	if failed {
+		return fmt.Errorf("failed to export %d workflow records", len(batch))
+	}
+	return nil
+}
+
+// forgetExported forgets the uids of the records that were exported longer ago than the expiry
+func (q *Queue) forgetExported() {
+	queued := make(map[string]bool, len(q.records))
+	for _, r := range q.records {
+		queued[r.UID] = true
+	}
+	for uid, t := range q.uids {
+		if !queued[uid] && time.Since(t) > exportedExpiry {
+			delete(q.uids, uid)
+		}
+	}
+}
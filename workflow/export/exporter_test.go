@@ -0,0 +1,71 @@
+package export
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testExporter struct {
+	err     error
+	batches [][]Record
+}
+
+func (e *testExporter) Name() string {
+	return "test"
+}
+
+func (e *testExporter) Export(_ context.Context, records []Record) error {
+	e.batches = append(e.batches, records)
+	return e.err
+}
+
+func TestQueue(t *testing.T) {
+	ctx := context.Background()
+	t.Run("Disabled", func(t *testing.T) {
+		q := NewQueue(nil)
+		assert.False(t, q.Add(Record{UID: "1"}))
+	})
+	t.Run("BatchSize", func(t *testing.T) {
+		var exported []Record
+		e := &testExporter{}
+		q := NewQueue(func(_ context.Context, records []Record) { exported = append(exported, records...) })
+		q.Configure([]Exporter{e}, 2, time.Hour)
+		assert.True(t, q.Add(Record{UID: "1"}))
+		assert.False(t, q.Add(Record{UID: "1"}))
+		assert.False(t, q.flush(ctx))
+		assert.True(t, q.Add(Record{UID: "2"}))
+		assert.True(t, q.Add(Record{UID: "3"}))
+		assert.True(t, q.flush(ctx))
+		assert.False(t, q.flush(ctx))
+		assert.Equal(t, [][]Record{{{UID: "1"}, {UID: "2"}}}, e.batches)
+		assert.Equal(t, []Record{{UID: "1"}, {UID: "2"}}, exported)
+		// recently exported
+		assert.False(t, q.Add(Record{UID: "1"}))
+	})
+	t.Run("FlushInterval", func(t *testing.T) {
+		e := &testExporter{}
+		q := NewQueue(func(context.Context, []Record) {})
+		q.Configure([]Exporter{e}, 100, time.Millisecond)
+		assert.True(t, q.Add(Record{UID: "1"}))
+		time.Sleep(2 * time.Millisecond)
+		assert.True(t, q.flush(ctx))
+		assert.Len(t, e.batches, 1)
+	})
+	t.Run("Failed", func(t *testing.T) {
+		var exported []Record
+		ok, failed := &testExporter{}, &testExporter{err: errors.New("boom")}
+		q := NewQueue(func(_ context.Context, records []Record) { exported = append(exported, records...) })
+		q.Configure([]Exporter{ok, failed}, 1, time.Hour)
+		assert.True(t, q.Add(Record{UID: "1"}))
+		assert.True(t, q.flush(ctx))
+		assert.Len(t, ok.batches, 1)
+		assert.Len(t, failed.batches, 1)
+		assert.Empty(t, exported)
+		// the failed record can be queued again
+		assert.True(t, q.Add(Record{UID: "1"}))
+	})
+}
@@ -0,0 +1,266 @@
+package export
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"time"
+)
+
+// This is a minimal writer of Parquet files (https://parquet.apache.org/docs/file-format/) of the records: a single
+// row group of a flat schema, with one uncompressed, plain encoded data page per column. Timestamps are optional, as
+// they are null if zero, and the other columns are required.
+
+const parquetMagic = "PAR1"
+
+// Parquet's physical types, repetition types, converted types, encodings and page types
+const (
+	parquetInt64     = 2
+	parquetDouble    = 5
+	parquetByteArray = 6
+
+	parquetRequired = 0
+	parquetOptional = 1
+
+	parquetUTF8            = 0
+	parquetTimestampMillis = 9
+
+	parquetPlain = 0
+	parquetRLE   = 3
+
+	parquetDataPage = 0
+)
+
+// WriteParquet writes the records to a Parquet file
+func WriteParquet(w io.Writer, records []Record) error {
+	buf := &bytes.Buffer{}
+	buf.WriteString(parquetMagic)
+	var chunks []*thriftStruct
+	for _, c := range columns {
+		page := parquetPage(c, records)
+		header := newThriftStruct().
+			i32(1, parquetDataPage).
+			i32(2, int32(len(page))).
+			i32(3, int32(len(page))).
+			structure(5, newThriftStruct().
+				i32(1, int32(len(records))).
+				i32(2, parquetPlain).
+				i32(3, parquetRLE).
+				i32(4, parquetRLE))
+		offset := int64(buf.Len())
+		buf.Write(header.bytes())
+		buf.Write(page)
+		size := int64(buf.Len()) - offset
+		chunks = append(chunks, newThriftStruct().
+			i64(2, offset).
+			structure(3, newThriftStruct().
+				i32(1, parquetPhysicalType(c.typ)).
+				i32List(2, []int32{parquetPlain, parquetRLE}).
+				stringList(3, []string{c.name}).
+				i32(4, 0). // uncompressed
+				i64(5, int64(len(records))).
+				i64(6, size).
+				i64(7, size).
+				i64(9, offset)))
+	}
+	schema := []*thriftStruct{newThriftStruct().string(4, "workflow").i32(5, int32(len(columns)))}
+	for _, c := range columns {
+		e := newThriftStruct().i32(1, parquetPhysicalType(c.typ))
+		if c.typ == columnTimestamp {
+			e.i32(3, parquetOptional)
+		} else {
+			e.i32(3, parquetRequired)
+		}
+		e.string(4, c.name)
+		switch c.typ {
+		case columnString:
+			e.i32(6, parquetUTF8)
+		case columnTimestamp:
+			e.i32(6, parquetTimestampMillis)
+		}
+		schema = append(schema, e)
+	}
+	rowGroup := newThriftStruct().
+		structList(1, chunks).
+		i64(2, int64(buf.Len()-len(parquetMagic))).
+		i64(3, int64(len(records)))
+	metadata := newThriftStruct().
+		i32(1, 1).
+		structList(2, schema).
+		i64(3, int64(len(records))).
+		structList(4, []*thriftStruct{rowGroup}).
+		string(6, "argo-workflows").
+		bytes()
+	buf.Write(metadata)
+	_ = binary.Write(buf, binary.LittleEndian, uint32(len(metadata)))
+	buf.WriteString(parquetMagic)
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+func parquetPhysicalType(t columnType) int32 {
+	switch t {
+	case columnString:
+		return parquetByteArray
+	case columnDouble:
+		return parquetDouble
+	default:
+		return parquetInt64
+	}
+}
+
+// parquetPage returns the data of the column's page: the definition levels of an optional column, and then the plain
+// encoded values that are not null
+func parquetPage(c column, records []Record) []byte {
+	page := &bytes.Buffer{}
+	if c.typ == columnTimestamp {
+		levels := make([]bool, len(records))
+		for i, r := range records {
+			levels[i] = !isNull(c.value(r))
+		}
+		encoded := rleBooleans(levels)
+		_ = binary.Write(page, binary.LittleEndian, uint32(len(encoded)))
+		page.Write(encoded)
+	}
+	for _, r := range records {
+		v := c.value(r)
+		if isNull(v) {
+			continue
+		}
+		switch v := v.(type) {
+		case string:
+			_ = binary.Write(page, binary.LittleEndian, uint32(len(v)))
+			page.WriteString(v)
+		case int64:
+			_ = binary.Write(page, binary.LittleEndian, v)
+		case float64:
+			_ = binary.Write(page, binary.LittleEndian, math.Float64bits(v))
+		case time.Time:
+			_ = binary.Write(page, binary.LittleEndian, v.UnixMilli())
+		default:
+			panic(fmt.Sprintf("unexpected value %T of column %s", v, c.name))
+		}
+	}
+	return page.Bytes()
+}
+
+// rleBooleans encodes the values, of bit width 1, as runs of the RLE/bit-packing hybrid encoding
+func rleBooleans(values []bool) []byte {
+	var out []byte
+	for i := 0; i < len(values); {
+		j := i
+		for j < len(values) && values[j] == values[i] {
+			j++
+		}
+		out = binary.AppendUvarint(out, uint64(j-i)<<1)
+		if values[i] {
+			out = append(out, 1)
+		} else {
+			out = append(out, 0)
+		}
+		i = j
+	}
+	return out
+}
+
+// Thrift compact protocol types
+const (
+	thriftI32    = 5
+	thriftI64    = 6
+	thriftBinary = 8
+	thriftList   = 9
+	thriftStruc  = 12
+)
+
+// thriftStruct encodes a struct with the Thrift compact protocol, which Parquet's metadata is encoded with. Fields
+// must be added in order of their IDs.
+type thriftStruct struct {
+	buf     []byte
+	lastID  int16
+	encoded bool
+}
+
+func newThriftStruct() *thriftStruct {
+	return &thriftStruct{}
+}
+
+func (s *thriftStruct) field(id int16, typ byte) {
+	if delta := id - s.lastID; delta > 0 && delta <= 15 {
+		s.buf = append(s.buf, byte(delta)<<4|typ)
+	} else {
+		s.buf = append(s.buf, typ)
+		s.buf = binary.AppendVarint(s.buf, int64(id))
+	}
+	s.lastID = id
+}
+
+func (s *thriftStruct) i32(id int16, v int32) *thriftStruct {
+	s.field(id, thriftI32)
+	s.buf = binary.AppendVarint(s.buf, int64(v))
+	return s
+}
+
+func (s *thriftStruct) i64(id int16, v int64) *thriftStruct {
+	s.field(id, thriftI64)
+	s.buf = binary.AppendVarint(s.buf, v)
+	return s
+}
+
+func (s *thriftStruct) string(id int16, v string) *thriftStruct {
+	s.field(id, thriftBinary)
+	s.buf = binary.AppendUvarint(s.buf, uint64(len(v)))
+	s.buf = append(s.buf, v...)
+	return s
+}
+
+func (s *thriftStruct) structure(id int16, v *thriftStruct) *thriftStruct {
+	s.field(id, thriftStruc)
+	s.buf = append(s.buf, v.bytes()...)
+	return s
+}
+
+func (s *thriftStruct) listHeader(id int16, size int, elemType byte) {
+	s.field(id, thriftList)
+	if size < 15 {
+		s.buf = append(s.buf, byte(size)<<4|elemType)
+	} else {
+		s.buf = append(s.buf, 0xf0|elemType)
+		s.buf = binary.AppendUvarint(s.buf, uint64(size))
+	}
+}
+
+func (s *thriftStruct) i32List(id int16, v []int32) *thriftStruct {
+	s.listHeader(id, len(v), thriftI32)
+	for _, x := range v {
+		s.buf = binary.AppendVarint(s.buf, int64(x))
+	}
+	return s
+}
+
+func (s *thriftStruct) stringList(id int16, v []string) *thriftStruct {
+	s.listHeader(id, len(v), thriftBinary)
+	for _, x := range v {
+		s.buf = binary.AppendUvarint(s.buf, uint64(len(x)))
+		s.buf = append(s.buf, x...)
+	}
+	return s
+}
+
+func (s *thriftStruct) structList(id int16, v []*thriftStruct) *thriftStruct {
+	s.listHeader(id, len(v), thriftStruc)
+	for _, x := range v {
+		s.buf = append(s.buf, x.bytes()...)
+	}
+	return s
+}
+
+// bytes returns the encoded struct, terminated by a stop field
+func (s *thriftStruct) bytes() []byte {
+	if !s.encoded {
+		s.buf = append(s.buf, 0)
+		s.encoded = true
+	}
+	return s.buf
+}
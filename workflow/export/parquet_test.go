@@ -0,0 +1,146 @@
+package export
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// thriftReader decodes the Thrift compact protocol, into maps of field IDs to values
+type thriftReader struct {
+	*bytes.Reader
+}
+
+func (r thriftReader) varint(t *testing.T) int64 {
+	v, err := binary.ReadVarint(r)
+	require.NoError(t, err)
+	return v
+}
+
+func (r thriftReader) uvarint(t *testing.T) uint64 {
+	v, err := binary.ReadUvarint(r)
+	require.NoError(t, err)
+	return v
+}
+
+func (r thriftReader) value(t *testing.T, typ byte) interface{} {
+	switch typ {
+	case thriftI32, thriftI64:
+		return r.varint(t)
+	case thriftBinary:
+		b := make([]byte, r.uvarint(t))
+		_, err := r.Read(b)
+		require.NoError(t, err)
+		return string(b)
+	case thriftList:
+		header, err := r.ReadByte()
+		require.NoError(t, err)
+		size := uint64(header >> 4)
+		if size == 15 {
+			size = r.uvarint(t)
+		}
+		var list []interface{}
+		for i := uint64(0); i < size; i++ {
+			list = append(list, r.value(t, header&0x0f))
+		}
+		return list
+	case thriftStruc:
+		return r.structure(t)
+	}
+	t.Fatalf("unexpected type %d", typ)
+	return nil
+}
+
+func (r thriftReader) structure(t *testing.T) map[int16]interface{} {
+	s := map[int16]interface{}{}
+	var id int16
+	for {
+		header, err := r.ReadByte()
+		require.NoError(t, err)
+		if header == 0 {
+			return s
+		}
+		if delta := int16(header >> 4); delta != 0 {
+			id += delta
+		} else {
+			id = int16(r.varint(t))
+		}
+		s[id] = r.value(t, header&0x0f)
+	}
+}
+
+func TestWriteParquet(t *testing.T) {
+	r, err := NewRecord(testWorkflow())
+	require.NoError(t, err)
+	notStarted := r
+	notStarted.Name = "not-started"
+	notStarted.StartedAt = time.Time{}
+	records := []Record{r, notStarted}
+
+	buf := &bytes.Buffer{}
+	require.NoError(t, WriteParquet(buf, records))
+	data := buf.Bytes()
+	assert.Equal(t, parquetMagic, string(data[:4]))
+	assert.Equal(t, parquetMagic, string(data[len(data)-4:]))
+	length := int(binary.LittleEndian.Uint32(data[len(data)-8:]))
+	metadata := thriftReader{bytes.NewReader(data[len(data)-8-length : len(data)-8])}.structure(t)
+
+	assert.Equal(t, int64(2), metadata[3])
+	schema := metadata[2].([]interface{})
+	require.Len(t, schema, len(columns)+1)
+	assert.Equal(t, int64(len(columns)), schema[0].(map[int16]interface{})[5])
+	for i, c := range columns {
+		e := schema[i+1].(map[int16]interface{})
+		assert.Equal(t, c.name, e[4])
+		assert.Equal(t, int64(parquetPhysicalType(c.typ)), e[1])
+	}
+
+	rowGroup := metadata[4].([]interface{})[0].(map[int16]interface{})
+	assert.Equal(t, int64(2), rowGroup[3])
+	chunks := rowGroup[1].([]interface{})
+	require.Len(t, chunks, len(columns))
+
+	t.Run("Required", func(t *testing.T) {
+		chunk := chunks[1].(map[int16]interface{})
+		assert.Equal(t, []interface{}{"name"}, chunk[3].(map[int16]interface{})[3])
+		reader := thriftReader{bytes.NewReader(data[chunk[2].(int64):])}
+		header := reader.structure(t)
+		assert.Equal(t, int64(2), header[5].(map[int16]interface{})[1])
+		page := make([]byte, header[2].(int64))
+		_, err := reader.Read(page)
+		require.NoError(t, err)
+		assert.Equal(t, append([]byte{5, 0, 0, 0}, "my-wf\x0b\x00\x00\x00not-started"...), page)
+	})
+	t.Run("Optional", func(t *testing.T) {
+		chunk := chunks[9].(map[int16]interface{})
+		assert.Equal(t, []interface{}{"started_at"}, chunk[3].(map[int16]interface{})[3])
+		reader := thriftReader{bytes.NewReader(data[chunk[2].(int64):])}
+		reader.structure(t)
+		levels := make([]byte, 4+2+2)
+		_, err := reader.Read(levels)
+		require.NoError(t, err)
+		// one defined value, and then one null
+		assert.Equal(t, []byte{4, 0, 0, 0, 2, 1, 2, 0}, levels)
+		millis := make([]byte, 8)
+		_, err = reader.Read(millis)
+		require.NoError(t, err)
+		assert.Equal(t, r.StartedAt.UnixMilli(), int64(binary.LittleEndian.Uint64(millis)))
+	})
+}
+
+func TestRLEBooleans(t *testing.T) {
+	assert.Empty(t, rleBooleans(nil))
+	assert.Equal(t, []byte{6, 1}, rleBooleans([]bool{true, true, true}))
+	assert.Equal(t, []byte{2, 1, 4, 0, 2, 1}, rleBooleans([]bool{true, false, false, true}))
+	assert.Equal(t, []byte{0x90, 0x03, 0}, rleBooleans(make([]bool, 200)))
+}
+
+func TestThriftStruct(t *testing.T) {
+	s := newThriftStruct().i32(1, 7).string(3, "a").i64(20, -1).bytes()
+	assert.Equal(t, []byte{0x15, 14, 0x28, 1, 'a', 0x06, 40, 1, 0}, s)
+	assert.Equal(t, map[int16]interface{}{1: int64(7), 3: "a", 20: int64(-1)}, thriftReader{bytes.NewReader(s)}.structure(t))
+}
@@ -0,0 +1,129 @@
+package export
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+// Record is the summary of a completed workflow that is exported
+type Record struct {
+	Namespace        string
+	Name             string
+	UID              string
+	WorkflowTemplate string
+	CronWorkflow     string
+	// SpecHash is the SHA-256 of the workflow's spec, or its stored workflow template's spec, so that the runs of the
+	// same spec can be grouped
+	SpecHash   string
+	Phase      string
+	Message    string
+	CreatedAt  time.Time
+	StartedAt  time.Time
+	FinishedAt time.Time
+	// QueuedSeconds is how long the workflow waited to be started
+	QueuedSeconds float64
+	// DurationSeconds is how long the workflow ran
+	DurationSeconds float64
+	Nodes           int64
+	FailedNodes     int64
+	// ResourcesDuration is the JSON of the workflow's resources duration, e.g. {"cpu":12,"memory":30}
+	ResourcesDuration string
+	// Labels is the JSON of the workflow's labels
+	Labels string
+}
+
+// NewRecord returns the record of the completed workflow
+func NewRecord(wf *wfv1.Workflow) (Record, error) {
+	spec, err := json.Marshal(wf.GetExecSpec())
+	if err != nil {
+		return Record{}, err
+	}
+	specHash := sha256.Sum256(spec)
+	resourcesDuration, err := json.Marshal(wf.Status.ResourcesDuration)
+	if err != nil {
+		return Record{}, err
+	}
+	labels, err := json.Marshal(wf.Labels)
+	if err != nil {
+		return Record{}, err
+	}
+	r := Record{
+		Namespace:         wf.Namespace,
+		Name:              wf.Name,
+		UID:               string(wf.UID),
+		WorkflowTemplate:  wf.Labels[common.LabelKeyWorkflowTemplate],
+		CronWorkflow:      wf.Labels[common.LabelKeyCronWorkflow],
+		SpecHash:          hex.EncodeToString(specHash[:]),
+		Phase:             string(wf.Status.Phase),
+		Message:           wf.Status.Message,
+		CreatedAt:         wf.CreationTimestamp.UTC(),
+		StartedAt:         wf.Status.StartedAt.UTC(),
+		FinishedAt:        wf.Status.FinishedAt.UTC(),
+		ResourcesDuration: string(resourcesDuration),
+		Labels:            string(labels),
+	}
+	if r.WorkflowTemplate == "" {
+		r.WorkflowTemplate = wf.Labels[common.LabelKeyClusterWorkflowTemplate]
+	}
+	if !wf.Status.StartedAt.IsZero() {
+		r.QueuedSeconds = wf.Status.StartedAt.Sub(wf.CreationTimestamp.Time).Seconds()
+		if !wf.Status.FinishedAt.IsZero() {
+			r.DurationSeconds = wf.Status.FinishedAt.Sub(wf.Status.StartedAt.Time).Seconds()
+		}
+	}
+	for _, node := range wf.Status.Nodes {
+		r.Nodes++
+		if node.FailedOrError() {
+			r.FailedNodes++
+		}
+	}
+	return r, nil
+}
+
+type columnType int
+
+const (
+	columnString columnType = iota
+	columnInt64
+	columnDouble
+	columnTimestamp
+)
+
+// column is a column of the exported records, the same in each of the sinks
+type column struct {
+	name string
+	typ  columnType
+	// value returns the column's value of the record: a string, int64, float64, or time.Time, which is null if zero
+	value func(r Record) interface{}
+}
+
+var columns = []column{
+	{"namespace", columnString, func(r Record) interface{} { return r.Namespace }},
+	{"name", columnString, func(r Record) interface{} { return r.Name }},
+	{"uid", columnString, func(r Record) interface{} { return r.UID }},
+	{"workflow_template", columnString, func(r Record) interface{} { return r.WorkflowTemplate }},
+	{"cron_workflow", columnString, func(r Record) interface{} { return r.CronWorkflow }},
+	{"spec_hash", columnString, func(r Record) interface{} { return r.SpecHash }},
+	{"phase", columnString, func(r Record) interface{} { return r.Phase }},
+	{"message", columnString, func(r Record) interface{} { return r.Message }},
+	{"created_at", columnTimestamp, func(r Record) interface{} { return r.CreatedAt }},
+	{"started_at", columnTimestamp, func(r Record) interface{} { return r.StartedAt }},
+	{"finished_at", columnTimestamp, func(r Record) interface{} { return r.FinishedAt }},
+	{"queued_seconds", columnDouble, func(r Record) interface{} { return r.QueuedSeconds }},
+	{"duration_seconds", columnDouble, func(r Record) interface{} { return r.DurationSeconds }},
+	{"nodes", columnInt64, func(r Record) interface{} { return r.Nodes }},
+	{"failed_nodes", columnInt64, func(r Record) interface{} { return r.FailedNodes }},
+	{"resources_duration", columnString, func(r Record) interface{} { return r.ResourcesDuration }},
+	{"labels", columnString, func(r Record) interface{} { return r.Labels }},
+}
+
+// isNull returns whether the value is null, which only timestamps may be
+func isNull(v interface{}) bool {
+	t, ok := v.(time.Time)
+	return ok && t.IsZero()
+}
@@ -0,0 +1,77 @@
+package export
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+var created = time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+func testWorkflow() *wfv1.Workflow {
+	return &wfv1.Workflow{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         "argo",
+			Name:              "my-wf",
+			UID:               "my-uid",
+			CreationTimestamp: metav1.Time{Time: created},
+			Labels:            map[string]string{common.LabelKeyWorkflowTemplate: "my-wftmpl", "team": "data"},
+		},
+		Spec: wfv1.WorkflowSpec{Entrypoint: "main"},
+		Status: wfv1.WorkflowStatus{
+			Phase:             wfv1.WorkflowFailed,
+			Message:           "child failed",
+			StartedAt:         metav1.Time{Time: created.Add(5 * time.Second)},
+			FinishedAt:        metav1.Time{Time: created.Add(65 * time.Second)},
+			ResourcesDuration: wfv1.ResourcesDuration{"cpu": 12},
+			Nodes: wfv1.Nodes{
+				"my-wf":   {Phase: wfv1.NodeFailed},
+				"my-wf-1": {Phase: wfv1.NodeSucceeded},
+				"my-wf-2": {Phase: wfv1.NodeError},
+			},
+		},
+	}
+}
+
+func TestNewRecord(t *testing.T) {
+	r, err := NewRecord(testWorkflow())
+	require.NoError(t, err)
+	assert.Equal(t, "argo", r.Namespace)
+	assert.Equal(t, "my-wf", r.Name)
+	assert.Equal(t, "my-uid", r.UID)
+	assert.Equal(t, "my-wftmpl", r.WorkflowTemplate)
+	assert.Empty(t, r.CronWorkflow)
+	assert.Len(t, r.SpecHash, 64)
+	assert.Equal(t, "Failed", r.Phase)
+	assert.Equal(t, "child failed", r.Message)
+	assert.Equal(t, created, r.CreatedAt)
+	assert.InDelta(t, 5, r.QueuedSeconds, 0.001)
+	assert.InDelta(t, 60, r.DurationSeconds, 0.001)
+	assert.Equal(t, int64(3), r.Nodes)
+	assert.Equal(t, int64(2), r.FailedNodes)
+	assert.JSONEq(t, `{"cpu":12}`, r.ResourcesDuration)
+	assert.JSONEq(t, `{"team":"data","workflows.argoproj.io/workflow-template":"my-wftmpl"}`, r.Labels)
+
+	t.Run("SameSpec", func(t *testing.T) {
+		wf := testWorkflow()
+		wf.Name = "other-wf"
+		other, err := NewRecord(wf)
+		require.NoError(t, err)
+		assert.Equal(t, r.SpecHash, other.SpecHash)
+	})
+	t.Run("NotStarted", func(t *testing.T) {
+		wf := testWorkflow()
+		wf.Status.StartedAt = metav1.Time{}
+		r, err := NewRecord(wf)
+		require.NoError(t, err)
+		assert.Zero(t, r.QueuedSeconds)
+		assert.Zero(t, r.DurationSeconds)
+		assert.True(t, isNull(r.StartedAt))
+	})
+}
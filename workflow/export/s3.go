@@ -0,0 +1,78 @@
+package export
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/argoproj/argo-workflows/v3/config"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	artifact "github.com/argoproj/argo-workflows/v3/workflow/artifacts"
+)
+
+// s3Exporter writes each batch of records to a Parquet file in an S3 bucket, partitioned by the date it is exported
+type s3Exporter struct {
+	name      string
+	sink      config.S3ExportSink
+	resources resources
+}
+
+func (e *s3Exporter) Name() string {
+	return e.name
+}
+
+func (e *s3Exporter) Export(ctx context.Context, records []Record) error {
+	f, err := os.CreateTemp("", "workflows-*.parquet")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(f.Name()) }()
+	err = WriteParquet(f, records)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	art := &wfv1.Artifact{ArtifactLocation: wfv1.ArtifactLocation{S3: &wfv1.S3Artifact{
+		S3Bucket: e.sink.S3Bucket,
+		Key:      s3Key(e.sink.KeyPrefix, time.Now()),
+	}}}
+	driver, err := artifact.NewDriver(ctx, art, e.resources)
+	if err != nil {
+		return err
+	}
+	return driver.Save(f.Name(), art)
+}
+
+// s3Key returns the key of a file written at the time, in the partition of its date
+func s3Key(prefix string, t time.Time) string {
+	t = t.UTC()
+	return fmt.Sprintf("%sdate=%s/workflows-%d.parquet", prefix, t.Format("2006-01-02"), t.UnixNano())
+}
+
+// resources gets the secrets and config maps of the S3 driver from the namespace
+type resources struct {
+	kubeClient kubernetes.Interface
+	namespace  string
+}
+
+func (r resources) GetSecret(ctx context.Context, name, key string) (string, error) {
+	secret, err := r.kubeClient.CoreV1().Secrets(r.namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+	return string(secret.Data[key]), nil
+}
+
+func (r resources) GetConfigMapKey(ctx context.Context, name, key string) (string, error) {
+	configMap, err := r.kubeClient.CoreV1().ConfigMaps(r.namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+	return configMap.Data[key], nil
+}
@@ -0,0 +1,120 @@
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/option"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/argoproj/argo-workflows/v3/config"
+)
+
+func TestBigQueryExporter(t *testing.T) {
+	r, err := NewRecord(testWorkflow())
+	require.NoError(t, err)
+	var body map[string]interface{}
+	var path string
+	status := http.StatusOK
+	response := `{"kind":"bigquery#tableDataInsertAllResponse"}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		path = req.URL.Path
+		require.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(response))
+	}))
+	defer server.Close()
+	e := &bigQueryExporter{
+		name:    "bq",
+		sink:    config.BigQueryExportSink{Project: "my-project", Dataset: "my_dataset", Table: "workflows"},
+		options: []option.ClientOption{option.WithEndpoint(server.URL), option.WithoutAuthentication()},
+	}
+
+	require.NoError(t, e.Export(context.Background(), []Record{r}))
+	assert.Equal(t, "/projects/my-project/datasets/my_dataset/tables/workflows/insertAll", path)
+	rows := body["rows"].([]interface{})
+	require.Len(t, rows, 1)
+	row := rows[0].(map[string]interface{})
+	assert.Equal(t, "my-uid", row["insertId"])
+	values := row["json"].(map[string]interface{})
+	assert.Equal(t, "my-wf", values["name"])
+	assert.Equal(t, "2024-05-01T10:00:05Z", values["started_at"])
+	assert.Equal(t, float64(2), values["failed_nodes"])
+	assert.Equal(t, float64(60), values["duration_seconds"])
+
+	t.Run("InsertErrors", func(t *testing.T) {
+		response = `{"insertErrors":[{"index":0,"errors":[{"message":"no such field"}]}]}`
+		assert.EqualError(t, e.Export(context.Background(), []Record{r}), "failed to insert 1 rows: row 0: no such field")
+	})
+}
+
+func TestSnowflakeExporter(t *testing.T) {
+	r, err := NewRecord(testWorkflow())
+	require.NoError(t, err)
+	r.StartedAt = time.Time{}
+	var req *http.Request
+	var statement snowflakeStatement
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req = r
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&statement))
+		w.WriteHeader(status)
+		_, _ = io.WriteString(w, `{"message":"Statement executed successfully."}`)
+	}))
+	defer server.Close()
+	kubeClient := fake.NewSimpleClientset(&apiv1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "argo", Name: "snowflake"},
+		Data:       map[string][]byte{"token": []byte("my-token\n")},
+	})
+	e := &snowflakeExporter{
+		name: "snowflake",
+		sink: config.SnowflakeExportSink{
+			Account:     "myorg-myaccount",
+			Database:    "ANALYTICS",
+			Schema:      "ARGO",
+			Table:       "WORKFLOWS",
+			Warehouse:   "LOAD",
+			TokenSecret: apiv1.SecretKeySelector{LocalObjectReference: apiv1.LocalObjectReference{Name: "snowflake"}, Key: "token"},
+		},
+		kubeClient: kubeClient,
+		namespace:  "argo",
+		client:     server.Client(),
+		url:        server.URL,
+	}
+
+	require.NoError(t, e.Export(context.Background(), []Record{r, r}))
+	assert.Equal(t, "Bearer my-token", req.Header.Get("Authorization"))
+	assert.Equal(t, "OAUTH", req.Header.Get("X-Snowflake-Authorization-Token-Type"))
+	assert.Equal(t, "ANALYTICS", statement.Database)
+	assert.Equal(t, "LOAD", statement.Warehouse)
+	assert.Contains(t, statement.Statement, "INSERT INTO WORKFLOWS (namespace, name, uid,")
+	assert.Contains(t, statement.Statement, "VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?), (?, ")
+	assert.Len(t, statement.Bindings, 2*len(columns))
+	assert.Equal(t, "my-wf", *statement.Bindings["2"].Value)
+	assert.Equal(t, "2024-05-01T10:00:00.000000000+00:00", *statement.Bindings["9"].Value)
+	assert.Nil(t, statement.Bindings["10"].Value)
+	assert.Equal(t, snowflakeBinding{Type: "FIXED", Value: stringPtr("2")}, statement.Bindings["15"])
+	assert.Equal(t, snowflakeBinding{Type: "REAL", Value: stringPtr("60")}, statement.Bindings["13"])
+
+	t.Run("Failed", func(t *testing.T) {
+		status = http.StatusUnprocessableEntity
+		assert.Error(t, e.Export(context.Background(), []Record{r}))
+	})
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+func TestS3Key(t *testing.T) {
+	assert.Equal(t, "analytics/date=2024-05-01/workflows-1714557600000000000.parquet", s3Key("analytics/", created))
+}
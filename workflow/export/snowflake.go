@@ -0,0 +1,139 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/argoproj/argo-workflows/v3/config"
+	"github.com/argoproj/argo-workflows/v3/util"
+)
+
+// snowflakeExporter inserts the records into a Snowflake table with a statement of the Snowflake SQL API
+// (https://docs.snowflake.com/en/developer-guide/sql-api/index)
+type snowflakeExporter struct {
+	name       string
+	sink       config.SnowflakeExportSink
+	kubeClient kubernetes.Interface
+	namespace  string
+	client     *http.Client
+	// url is the URL of the SQL API, by default that of the account
+	url string
+}
+
+type snowflakeBinding struct {
+	Type  string  `json:"type"`
+	Value *string `json:"value"`
+}
+
+type snowflakeStatement struct {
+	Statement  string                      `json:"statement"`
+	Timeout    int                         `json:"timeout"`
+	Database   string                      `json:"database"`
+	Schema     string                      `json:"schema"`
+	Warehouse  string                      `json:"warehouse,omitempty"`
+	Role       string                      `json:"role,omitempty"`
+	Bindings   map[string]snowflakeBinding `json:"bindings"`
+	Parameters map[string]string           `json:"parameters,omitempty"`
+}
+
+func (e *snowflakeExporter) Name() string {
+	return e.name
+}
+
+func (e *snowflakeExporter) Export(ctx context.Context, records []Record) error {
+	token, err := util.GetSecrets(ctx, e.kubeClient, e.namespace, e.sink.TokenSecret.Name, e.sink.TokenSecret.Key)
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(e.statement(records))
+	if err != nil {
+		return err
+	}
+	url := e.url
+	if url == "" {
+		url = fmt.Sprintf("https://%s.snowflakecomputing.com/api/v2/statements", e.sink.Account)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	tokenType := e.sink.TokenType
+	if tokenType == "" {
+		tokenType = "OAUTH"
+	}
+	req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+	req.Header.Set("X-Snowflake-Authorization-Token-Type", tokenType)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	// 202 is returned if the statement is still running, after which it completes asynchronously
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusAccepted {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return fmt.Errorf("snowflake statement failed with %s: %s", resp.Status, strings.TrimSpace(string(message)))
+	}
+	return nil
+}
+
+// statement returns the statement that inserts the records, with a binding of each of their values
+func (e *snowflakeExporter) statement(records []Record) snowflakeStatement {
+	names := make([]string, len(columns))
+	for i, c := range columns {
+		names[i] = c.name
+	}
+	bindings := make(map[string]snowflakeBinding, len(records)*len(columns))
+	rows := make([]string, len(records))
+	for i, r := range records {
+		placeholders := make([]string, len(columns))
+		for j, c := range columns {
+			placeholders[j] = "?"
+			bindings[strconv.Itoa(i*len(columns)+j+1)] = snowflakeValue(c, r)
+		}
+		rows[i] = "(" + strings.Join(placeholders, ", ") + ")"
+	}
+	return snowflakeStatement{
+		Statement: fmt.Sprintf("INSERT INTO %s (%s) VALUES %s", e.sink.Table, strings.Join(names, ", "), strings.Join(rows, ", ")),
+		Timeout:   60,
+		Database:  e.sink.Database,
+		Schema:    e.sink.Schema,
+		Warehouse: e.sink.Warehouse,
+		Role:      e.sink.Role,
+		Bindings:  bindings,
+		// timestamps are bound as text, in the format they are formatted in
+		Parameters: map[string]string{"timestamp_input_format": "YYYY-MM-DDTHH24:MI:SS.FF9TZH:TZM"},
+	}
+}
+
+func snowflakeValue(c column, r Record) snowflakeBinding {
+	v := c.value(r)
+	if isNull(v) {
+		return snowflakeBinding{Type: "TEXT"}
+	}
+	var s string
+	typ := "TEXT"
+	switch v := v.(type) {
+	case string:
+		s = v
+	case int64:
+		typ = "FIXED"
+		s = strconv.FormatInt(v, 10)
+	case float64:
+		typ = "REAL"
+		s = strconv.FormatFloat(v, 'f', -1, 64)
+	case time.Time:
+		s = v.Format("2006-01-02T15:04:05.000000000-07:00")
+	}
+	return snowflakeBinding{Type: typ, Value: &s}
+}
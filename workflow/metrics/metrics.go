@@ -64,6 +64,7 @@ type Metrics struct {
 	defaultMetricDescs map[string]bool
 	metricNameHelps    map[string]string
 	logMetric          *prometheus.CounterVec
+	recentErrors       *recentErrors
 }
 
 func (m *Metrics) Levels() []log.Level {
@@ -72,6 +73,9 @@ func (m *Metrics) Levels() []log.Level {
 
 func (m *Metrics) Fire(entry *log.Entry) error {
 	m.logMetric.WithLabelValues(entry.Level.String()).Inc()
+	if entry.Level == log.ErrorLevel {
+		m.recentErrors.inc(ErrorCauseErrorLogMessage)
+	}
 	return nil
 }
 
@@ -101,6 +105,7 @@ func New(metricsConfig, telemetryConfig ServerConfig) *Metrics {
 			Name: "log_messages",
 			Help: "Total number of log messages.",
 		}, []string{"level"}),
+		recentErrors: newRecentErrors(),
 	}
 
 	for _, metric := range metrics.allMetrics() {
@@ -227,6 +232,7 @@ func (m *Metrics) OperationPanic() {
 	defer m.mutex.Unlock()
 
 	m.errors[ErrorCauseOperationPanic].Inc()
+	m.recentErrors.inc(ErrorCauseOperationPanic)
 }
 
 func (m *Metrics) CronWorkflowSubmissionError() {
@@ -234,6 +240,7 @@ func (m *Metrics) CronWorkflowSubmissionError() {
 	defer m.mutex.Unlock()
 
 	m.errors[ErrorCauseCronWorkflowSubmissionError].Inc()
+	m.recentErrors.inc(ErrorCauseCronWorkflowSubmissionError)
 }
 
 func (m *Metrics) CronWorkflowSpecError() {
@@ -241,6 +248,7 @@ func (m *Metrics) CronWorkflowSpecError() {
 	defer m.mutex.Unlock()
 
 	m.errors[ErrorCauseCronWorkflowSpecError].Inc()
+	m.recentErrors.inc(ErrorCauseCronWorkflowSpecError)
 }
 
 // Act as a metrics provider for a workflow queue
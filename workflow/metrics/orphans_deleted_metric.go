@@ -0,0 +1,13 @@
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+var OrphansDeletedMetric = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: argoNamespace,
+		Subsystem: workflowsSubsystem,
+		Name:      "orphans_deleted_total",
+		Help:      "Task sets, task results and agent pods deleted as their workflow no longer exists. https://argoproj.github.io/argo-workflows/metrics/#argo_workflows_orphans_deleted_total",
+	},
+	[]string{"kind", "namespace"},
+)
@@ -0,0 +1,13 @@
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+var PodCreationRateLimitedMetric = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: argoNamespace,
+		Subsystem: workflowsSubsystem,
+		Name:      "pod_creation_rate_limited_total",
+		Help:      "Pod creations delayed by a rate limit. https://argoproj.github.io/argo-workflows/metrics/#argo_workflows_pod_creation_rate_limited_total",
+	},
+	[]string{"scope", "namespace"},
+)
@@ -0,0 +1,69 @@
+package metrics
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	// ErrorCauseErrorLogMessage counts the messages logged at error level, it is only reported by RecentErrors
+	ErrorCauseErrorLogMessage ErrorCause = "ErrorLogMessage"
+
+	recentErrorsWindow = time.Hour
+	recentErrorsBucket = time.Minute
+)
+
+// recentErrors counts errors by cause in one minute buckets, keeping the buckets of the last hour
+type recentErrors struct {
+	mutex   sync.Mutex
+	buckets map[time.Time]map[ErrorCause]int
+	now     func() time.Time
+}
+
+func newRecentErrors() *recentErrors {
+	return &recentErrors{buckets: make(map[time.Time]map[ErrorCause]int), now: time.Now}
+}
+
+func (r *recentErrors) inc(cause ErrorCause) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	bucket := r.now().Truncate(recentErrorsBucket)
+	if r.buckets[bucket] == nil {
+		r.buckets[bucket] = make(map[ErrorCause]int)
+	}
+	r.buckets[bucket][cause]++
+	r.gc()
+}
+
+func (r *recentErrors) counts() map[string]int {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.gc()
+	counts := make(map[string]int)
+	for _, bucket := range r.buckets {
+		for cause, n := range bucket {
+			counts[string(cause)] += n
+		}
+	}
+	return counts
+}
+
+// gc deletes the buckets that are older than the window, it must be called with the mutex held
+func (r *recentErrors) gc() {
+	oldest := r.now().Add(-recentErrorsWindow)
+	for bucket := range r.buckets {
+		if !bucket.After(oldest) {
+			delete(r.buckets, bucket)
+		}
+	}
+}
+
+// RecentErrors returns the number of errors by cause in the last hour
+func (m *Metrics) RecentErrors() map[string]int {
+	counts := m.recentErrors.counts()
+	for cause := range m.errors {
+		counts[string(cause)] += 0
+	}
+	counts[string(ErrorCauseErrorLogMessage)] += 0
+	return counts
+}
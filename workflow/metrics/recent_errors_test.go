@@ -0,0 +1,37 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecentErrors(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	r := newRecentErrors()
+	r.now = func() time.Time { return now }
+
+	r.inc(ErrorCauseOperationPanic)
+	now = now.Add(30 * time.Minute)
+	r.inc(ErrorCauseOperationPanic)
+	r.inc(ErrorCauseCronWorkflowSpecError)
+	assert.Equal(t, map[string]int{"OperationPanic": 2, "CronWorkflowSpecError": 1}, r.counts())
+
+	now = now.Add(45 * time.Minute)
+	assert.Equal(t, map[string]int{"OperationPanic": 1, "CronWorkflowSpecError": 1}, r.counts())
+
+	now = now.Add(time.Hour)
+	assert.Empty(t, r.counts())
+}
+
+func TestMetrics_RecentErrors(t *testing.T) {
+	m := New(ServerConfig{}, ServerConfig{})
+	m.OperationPanic()
+	m.CronWorkflowSubmissionError()
+	errors := m.RecentErrors()
+	assert.Equal(t, 1, errors["OperationPanic"])
+	assert.Equal(t, 1, errors["CronWorkflowSubmissionError"])
+	assert.Equal(t, 0, errors["CronWorkflowSpecError"])
+	assert.Contains(t, errors, "ErrorLogMessage")
+}
@@ -112,7 +112,11 @@ func (m *Metrics) Describe(ch chan<- *prometheus.Desc) {
 	m.logMetric.Describe(ch)
 	K8sRequestTotalMetric.Describe(ch)
 	PodMissingMetric.Describe(ch)
+	PodCreationRateLimitedMetric.Describe(ch)
+	OrphansDeletedMetric.Describe(ch)
 	WorkflowConditionMetric.Describe(ch)
+	WorkflowTemplateVersionMetric.Describe(ch)
+	TenantWorkflowsMetric.Describe(ch)
 }
 
 func (m *Metrics) Collect(ch chan<- prometheus.Metric) {
@@ -122,7 +126,11 @@ func (m *Metrics) Collect(ch chan<- prometheus.Metric) {
 	m.logMetric.Collect(ch)
 	K8sRequestTotalMetric.Collect(ch)
 	PodMissingMetric.Collect(ch)
+	PodCreationRateLimitedMetric.Collect(ch)
+	OrphansDeletedMetric.Collect(ch)
 	WorkflowConditionMetric.Collect(ch)
+	WorkflowTemplateVersionMetric.Collect(ch)
+	TenantWorkflowsMetric.Collect(ch)
 }
 
 func (m *Metrics) garbageCollector(ctx context.Context) {
@@ -0,0 +1,144 @@
+package metrics
+
+import (
+	"path"
+	"sort"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/argoproj/argo-workflows/v3/config"
+)
+
+const (
+	TenantLabelNamespace        = "namespace"
+	TenantLabelWorkflowTemplate = "workflow_template"
+	// OtherTenant is the label value of the workflows of tenants that do not have their own series
+	OtherTenant = "other"
+)
+
+var TenantWorkflowsMetric = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Namespace: argoNamespace,
+		Subsystem: workflowsSubsystem,
+		Name:      "tenant_workflows_count",
+		Help:      "Number of workflows by tenant and phase (refreshed every 15s). https://argoproj.github.io/argo-workflows/metrics/#argo_workflows_tenant_workflows_count",
+	},
+	[]string{TenantLabelNamespace, TenantLabelWorkflowTemplate, "status"},
+)
+
+// Tenant is the namespace and workflow template of a workflow, those that are not labels of the metric are empty
+type Tenant struct {
+	Namespace        string
+	WorkflowTemplate string
+}
+
+// TenantCounts are the numbers of workflows of each tenant, by phase
+type TenantCounts map[Tenant]map[string]int
+
+// Add counts a workflow of the namespace and workflow template in the phase
+func (c TenantCounts) Add(tenants *config.TenantMetrics, namespace, workflowTemplate, phase string) {
+	t := newTenant(tenants, namespace, workflowTemplate)
+	if c[t] == nil {
+		c[t] = make(map[string]int)
+	}
+	c[t][phase]++
+}
+
+func newTenant(tenants *config.TenantMetrics, namespace, workflowTemplate string) Tenant {
+	var t Tenant
+	for _, label := range tenants.GetLabels() {
+		switch label {
+		case TenantLabelNamespace:
+			t.Namespace = allowedOrOther(tenants.Namespaces, namespace)
+		case TenantLabelWorkflowTemplate:
+			t.WorkflowTemplate = allowedOrOther(tenants.WorkflowTemplates, workflowTemplate)
+		}
+	}
+	return t
+}
+
+// allowedOrOther returns the value if there is no allowlist or it matches one of its patterns, and "other" otherwise
+func allowedOrOther(allowlist []string, value string) string {
+	if len(allowlist) == 0 || value == "" {
+		return value
+	}
+	for _, pattern := range allowlist {
+		if ok, _ := path.Match(pattern, value); ok {
+			return value
+		}
+	}
+	return OtherTenant
+}
+
+// other returns the tenant that the workflows of the tenant are counted in when it does not have its own series
+func (t Tenant) other() Tenant {
+	if t.Namespace != "" {
+		t.Namespace = OtherTenant
+	}
+	if t.WorkflowTemplate != "" {
+		t.WorkflowTemplate = OtherTenant
+	}
+	return t
+}
+
+func (t Tenant) isOther() bool {
+	return t == t.other()
+}
+
+// Limit keeps the series of the maxTenants tenants with the most workflows, and counts the workflows of the others
+// in the "other" tenant
+func (c TenantCounts) Limit(maxTenants int) TenantCounts {
+	type tenantTotal struct {
+		tenant Tenant
+		total  int
+	}
+	var totals []tenantTotal
+	for t, phases := range c {
+		if t.isOther() {
+			continue
+		}
+		total := 0
+		for _, n := range phases {
+			total += n
+		}
+		totals = append(totals, tenantTotal{t, total})
+	}
+	sort.Slice(totals, func(i, j int) bool {
+		if totals[i].total != totals[j].total {
+			return totals[i].total > totals[j].total
+		}
+		if totals[i].tenant.Namespace != totals[j].tenant.Namespace {
+			return totals[i].tenant.Namespace < totals[j].tenant.Namespace
+		}
+		return totals[i].tenant.WorkflowTemplate < totals[j].tenant.WorkflowTemplate
+	})
+	kept := make(map[Tenant]bool)
+	for i, t := range totals {
+		if i < maxTenants {
+			kept[t.tenant] = true
+		}
+	}
+	limited := make(TenantCounts)
+	for t, phases := range c {
+		if !kept[t] {
+			t = t.other()
+		}
+		if limited[t] == nil {
+			limited[t] = make(map[string]int)
+		}
+		for phase, n := range phases {
+			limited[t][phase] += n
+		}
+	}
+	return limited
+}
+
+// SetTenantWorkflowCounts replaces the series of the metric with the counts
+func SetTenantWorkflowCounts(counts TenantCounts) {
+	TenantWorkflowsMetric.Reset()
+	for t, phases := range counts {
+		for phase, n := range phases {
+			TenantWorkflowsMetric.WithLabelValues(t.Namespace, t.WorkflowTemplate, phase).Set(float64(n))
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/argoproj/argo-workflows/v3/config"
+)
+
+func TestTenantCounts(t *testing.T) {
+	t.Run("Labels", func(t *testing.T) {
+		counts := make(TenantCounts)
+		counts.Add(&config.TenantMetrics{Labels: []string{TenantLabelNamespace}}, "team-a", "my-template", "Running")
+		assert.Equal(t, TenantCounts{{Namespace: "team-a"}: {"Running": 1}}, counts)
+	})
+	t.Run("Allowlist", func(t *testing.T) {
+		tenants := &config.TenantMetrics{Namespaces: []string{"team-*"}, WorkflowTemplates: []string{"build"}}
+		counts := make(TenantCounts)
+		counts.Add(tenants, "team-a", "build", "Running")
+		counts.Add(tenants, "team-a", "deploy", "Running")
+		counts.Add(tenants, "sandbox", "build", "Succeeded")
+		counts.Add(tenants, "sandbox", "", "Succeeded")
+		assert.Equal(t, TenantCounts{
+			{Namespace: "team-a", WorkflowTemplate: "build"}:     {"Running": 1},
+			{Namespace: "team-a", WorkflowTemplate: OtherTenant}: {"Running": 1},
+			{Namespace: OtherTenant, WorkflowTemplate: "build"}:  {"Succeeded": 1},
+			{Namespace: OtherTenant}:                             {"Succeeded": 1},
+		}, counts)
+	})
+	t.Run("Limit", func(t *testing.T) {
+		tenants := &config.TenantMetrics{Labels: []string{TenantLabelNamespace}}
+		counts := make(TenantCounts)
+		counts.Add(tenants, "team-a", "", "Running")
+		counts.Add(tenants, "team-a", "", "Succeeded")
+		counts.Add(tenants, "team-b", "", "Running")
+		counts.Add(tenants, "team-c", "", "Running")
+		counts.Add(tenants, "team-c", "", "Failed")
+		assert.Equal(t, TenantCounts{
+			{Namespace: "team-a"}:    {"Running": 1, "Succeeded": 1},
+			{Namespace: OtherTenant}: {"Running": 2, "Failed": 1},
+		}, counts.Limit(1))
+	})
+}
+
+func TestSetTenantWorkflowCounts(t *testing.T) {
+	SetTenantWorkflowCounts(TenantCounts{{Namespace: "team-a", WorkflowTemplate: "build"}: {"Running": 2}})
+	m := write(TenantWorkflowsMetric.WithLabelValues("team-a", "build", "Running"))
+	assert.Equal(t, 2.0, m.GetGauge().GetValue())
+
+	SetTenantWorkflowCounts(TenantCounts{})
+	m = write(TenantWorkflowsMetric.WithLabelValues("team-a", "build", "Running"))
+	assert.Equal(t, 0.0, m.GetGauge().GetValue())
+}
@@ -0,0 +1,15 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var WorkflowTemplateVersionMetric = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: argoNamespace,
+		Subsystem: workflowsSubsystem,
+		Name:      "workflow_template_version_total",
+		Help:      "Number of completed workflows by the version of the workflow template they ran. https://argoproj.github.io/argo-workflows/metrics/#argo_workflows_workflow_template_version_total",
+	},
+	[]string{"namespace", "workflow_template", "version", "phase"},
+)
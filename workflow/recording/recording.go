@@ -0,0 +1,142 @@
+package recording
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/argoproj/argo-workflows/v3/config"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+const (
+	// Path is the path of the recordings, served on the diagnostics port, followed by the workflow's namespace and name
+	Path = "/recordings/"
+	// MaxReconciliations is the number of reconciliations that are kept of each workflow, older ones are dropped
+	MaxReconciliations = 100
+	// MaxWorkflows is the number of workflows that recordings are kept of, the recordings of the workflow that was
+	// recorded first are dropped
+	MaxWorkflows = 20
+)
+
+// Reconciliation is what the controller read and wrote in one reconciliation of a workflow
+type Reconciliation struct {
+	// Time is when the reconciliation started
+	Time metav1.Time `json:"time"`
+	// Config is the configuration of the controller
+	Config config.Config `json:"config"`
+	// ExecutorImage is the image of the executor, which may be set by a flag of the controller
+	ExecutorImage string `json:"executorImage"`
+	// ExecutorImagePullPolicy is the pull policy of the executor image
+	ExecutorImagePullPolicy apiv1.PullPolicy `json:"executorImagePullPolicy,omitempty"`
+	// Workflow is the workflow that was reconciled, with its offloaded node status
+	Workflow *wfv1.Workflow `json:"workflow"`
+	// Pods are the workflow's pods in the controller's cache
+	Pods []*apiv1.Pod `json:"pods,omitempty"`
+	// TaskResults are the workflow's task results in the controller's cache
+	TaskResults []*wfv1.WorkflowTaskResult `json:"taskResults,omitempty"`
+	// Result is the workflow at the end of the reconciliation
+	Result *wfv1.Workflow `json:"result"`
+}
+
+// Recorder keeps the reconciliations of the workflows with the record-reconciliations annotation, or that were named
+// when it was created, in memory
+type Recorder struct {
+	workflows    map[string]bool
+	mutex        sync.Mutex
+	recordings   map[string][]Reconciliation
+	recordedKeys []string
+}
+
+// NewRecorder returns a recorder that records the workflows with the annotation, and those with the keys, in the form
+// namespace/name
+func NewRecorder(keys []string) *Recorder {
+	workflows := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		workflows[key] = true
+	}
+	return &Recorder{workflows: workflows, recordings: make(map[string][]Reconciliation)}
+}
+
+// Enabled returns true if the reconciliations of the workflow are recorded
+func (r *Recorder) Enabled(wf metav1.Object) bool {
+	if r == nil {
+		return false
+	}
+	return wf.GetAnnotations()[common.AnnotationKeyRecordReconciliations] == "true" || r.workflows[wf.GetNamespace()+"/"+wf.GetName()]
+}
+
+// Record adds the reconciliation to the recording of the workflow with the key
+func (r *Recorder) Record(key string, reconciliation Reconciliation) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	recording, ok := r.recordings[key]
+	if !ok {
+		r.recordedKeys = append(r.recordedKeys, key)
+		if len(r.recordedKeys) > MaxWorkflows {
+			delete(r.recordings, r.recordedKeys[0])
+			r.recordedKeys = r.recordedKeys[1:]
+		}
+	}
+	recording = append(recording, reconciliation)
+	if len(recording) > MaxReconciliations {
+		recording = recording[len(recording)-MaxReconciliations:]
+	}
+	r.recordings[key] = recording
+}
+
+// Get returns the recorded reconciliations of the workflow with the key, oldest first
+func (r *Recorder) Get(key string) []Reconciliation {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return append([]Reconciliation(nil), r.recordings[key]...)
+}
+
+// ServeHTTP writes the recording of the workflow whose namespace and name follow the path, for
+// `argo admin controller recording`
+func (r *Recorder) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	key := strings.TrimPrefix(req.URL.Path, Path)
+	recording := r.Get(key)
+	if len(recording) == 0 {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte("no reconciliations of " + key + " were recorded"))
+		return
+	}
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	if err := Write(w, recording); err != nil {
+		log.WithError(err).WithField("key", key).Error("failed to write recording")
+	}
+}
+
+// Write writes the reconciliations as JSON, one per line
+func Write(w io.Writer, recording []Reconciliation) error {
+	encoder := json.NewEncoder(w)
+	for _, reconciliation := range recording {
+		if err := encoder.Encode(reconciliation); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Read reads the reconciliations written by Write
+func Read(r io.Reader) ([]Reconciliation, error) {
+	var recording []Reconciliation
+	decoder := json.NewDecoder(bufio.NewReader(r))
+	for decoder.More() {
+		var reconciliation Reconciliation
+		if err := decoder.Decode(&reconciliation); err != nil {
+			return nil, err
+		}
+		recording = append(recording, reconciliation)
+	}
+	return recording, nil
+}
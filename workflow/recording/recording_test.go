@@ -0,0 +1,53 @@
+package recording
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+func TestRecorder_Enabled(t *testing.T) {
+	wf := &wfv1.Workflow{ObjectMeta: metav1.ObjectMeta{Namespace: "my-ns", Name: "my-wf"}}
+	assert.False(t, (*Recorder)(nil).Enabled(wf))
+	assert.False(t, NewRecorder(nil).Enabled(wf))
+	assert.True(t, NewRecorder([]string{"my-ns/my-wf"}).Enabled(wf))
+	wf.Annotations = map[string]string{common.AnnotationKeyRecordReconciliations: "true"}
+	assert.True(t, NewRecorder(nil).Enabled(wf))
+}
+
+func TestRecorder_Record(t *testing.T) {
+	r := NewRecorder(nil)
+	for i := 0; i < MaxReconciliations+1; i++ {
+		r.Record("my-ns/my-wf", Reconciliation{ExecutorImage: fmt.Sprint(i)})
+	}
+	recording := r.Get("my-ns/my-wf")
+	require.Len(t, recording, MaxReconciliations)
+	assert.Equal(t, "1", recording[0].ExecutorImage)
+
+	for i := 0; i < MaxWorkflows; i++ {
+		r.Record(fmt.Sprintf("my-ns/my-wf-%d", i), Reconciliation{})
+	}
+	assert.Empty(t, r.Get("my-ns/my-wf"), "the recording of the first workflow is dropped")
+	assert.Len(t, r.Get("my-ns/my-wf-0"), 1)
+}
+
+func TestReadWrite(t *testing.T) {
+	recording := []Reconciliation{
+		{ExecutorImage: "my-image", Workflow: &wfv1.Workflow{ObjectMeta: metav1.ObjectMeta{Name: "my-wf"}}},
+		{ExecutorImage: "my-image", Result: &wfv1.Workflow{Status: wfv1.WorkflowStatus{Phase: wfv1.WorkflowRunning}}},
+	}
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, recording))
+	read, err := Read(&buf)
+	require.NoError(t, err)
+	require.Len(t, read, 2)
+	assert.Equal(t, "my-wf", read[0].Workflow.Name)
+	assert.Equal(t, wfv1.WorkflowRunning, read[1].Result.Status.Phase)
+}
@@ -0,0 +1,313 @@
+package render
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/util/template"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+	"github.com/argoproj/argo-workflows/v3/workflow/templateresolution"
+	wfutil "github.com/argoproj/argo-workflows/v3/workflow/util"
+)
+
+// Node is a step or task of a workflow rendered before it is submitted, with its loop expanded, and the
+// parameters and expressions that do not depend on the outputs of other nodes evaluated
+type Node struct {
+	// Name is the display name the node would have, e.g. "print(1:hello)" for the item of a loop
+	Name string `json:"name"`
+	// StepGroup is the index of the step group of a step, or -1
+	StepGroup int `json:"stepGroup"`
+	// Template is the name of the template the node runs, or "WORKFLOW_TEMPLATE/TEMPLATE" for a template reference
+	Template string `json:"template"`
+	// Type is the type of the template, empty if it could not be resolved
+	Type wfv1.TemplateType `json:"type,omitempty"`
+	// Parameters are the input parameters of the template
+	Parameters []wfv1.Parameter `json:"parameters,omitempty"`
+	// Dependencies are the names of the nodes of the same parent this node runs after
+	Dependencies []string `json:"dependencies,omitempty"`
+	// Notes are the conditions and loops that could not be evaluated, and why the node is skipped or not expanded
+	Notes []string `json:"notes,omitempty"`
+	// Children are the steps or tasks of the template
+	Children []*Node `json:"children,omitempty"`
+}
+
+// renderDependsTaskNameRegex matches the task names in a DAG task's depends expression
+var renderDependsTaskNameRegex = regexp.MustCompile(`[a-zA-Z0-9_-]+`)
+
+type workflowRenderer struct {
+	globalParams common.Parameters
+	namespace    string
+}
+
+// Workflow returns the nodes the entrypoint, and the exit handler if the workflow has one, would run, resolving
+// template references with the getters. Loops over items, sequences and JSON lists are expanded, and the workflow
+// parameters, input parameters, items and expressions are substituted. Values that are only known when the workflow
+// runs, such as the outputs of other nodes, are left as they are, and so are the loops and conditions that depend on
+// them. Templates that call themselves are not expanded.
+func Workflow(wf *wfv1.Workflow, wftmplGetter templateresolution.WorkflowTemplateNamespacedGetter, cwftmplGetter templateresolution.ClusterWorkflowTemplateGetter) ([]*Node, error) {
+	wf = wf.DeepCopy()
+	if wf.Spec.WorkflowTemplateRef != nil {
+		var wftmpl wfv1.WorkflowSpecHolder
+		var err error
+		if wf.Spec.WorkflowTemplateRef.ClusterScope {
+			wftmpl, err = cwftmplGetter.Get(wf.Spec.WorkflowTemplateRef.Name)
+		} else {
+			wftmpl, err = wftmplGetter.Get(wf.Spec.WorkflowTemplateRef.Name)
+		}
+		if err != nil {
+			return nil, err
+		}
+		joined, err := wfutil.JoinWorkflowSpec(&wf.Spec, wftmpl.GetWorkflowSpec(), nil)
+		if err != nil {
+			return nil, err
+		}
+		wf.Spec = joined.Spec
+		wf.Spec.WorkflowTemplateRef = nil
+	}
+	r := &workflowRenderer{globalParams: getRenderGlobalParams(wf), namespace: wf.Namespace}
+	tmplCtx := templateresolution.NewContext(wftmplGetter, cwftmplGetter, wf, nil)
+	var nodes []*Node
+	if wf.Spec.Entrypoint != "" {
+		n := &Node{Name: wf.Name, StepGroup: -1, Template: wf.Spec.Entrypoint}
+		if n.Name == "" {
+			n.Name = wf.GenerateName
+		}
+		r.render(tmplCtx, n, &wfv1.WorkflowStep{Template: wf.Spec.Entrypoint}, wf.Spec.Arguments, map[string]bool{})
+		nodes = append(nodes, n)
+	}
+	if wf.Spec.OnExit != "" {
+		n := &Node{Name: "onExit", StepGroup: -1, Template: wf.Spec.OnExit}
+		r.render(tmplCtx, n, &wfv1.WorkflowStep{Template: wf.Spec.OnExit}, wf.Spec.Arguments, map[string]bool{})
+		nodes = append(nodes, n)
+	}
+	return nodes, nil
+}
+
+// getRenderGlobalParams returns the global parameters of the workflow that are known before it is submitted
+func getRenderGlobalParams(wf *wfv1.Workflow) common.Parameters {
+	globalParams := make(common.Parameters)
+	if wf.Name != "" {
+		globalParams[common.GlobalVarWorkflowName] = wf.Name
+	}
+	if wf.Namespace != "" {
+		globalParams[common.GlobalVarWorkflowNamespace] = wf.Namespace
+	}
+	globalParams[common.GlobalVarWorkflowMainEntrypoint] = wf.Spec.Entrypoint
+	if wf.Spec.ServiceAccountName != "" {
+		globalParams[common.GlobalVarWorkflowServiceAccountName] = wf.Spec.ServiceAccountName
+	}
+	if workflowParameters, err := json.Marshal(wf.Spec.Arguments.Parameters); err == nil {
+		globalParams[common.GlobalVarWorkflowParameters] = string(workflowParameters)
+		globalParams[common.GlobalVarWorkflowParametersJSON] = string(workflowParameters)
+	}
+	for _, param := range wf.Spec.Arguments.Parameters {
+		if param.Value != nil {
+			globalParams["workflow.parameters."+param.Name] = param.Value.String()
+		} else if param.ValueFrom != nil && param.ValueFrom.Default != nil {
+			globalParams["workflow.parameters."+param.Name] = param.ValueFrom.Default.String()
+		}
+	}
+	for k, v := range wf.Annotations {
+		globalParams["workflow.annotations."+k] = v
+	}
+	for k, v := range wf.Labels {
+		globalParams["workflow.labels."+k] = v
+	}
+	return globalParams
+}
+
+// render resolves the template of the node, substitutes its inputs, and renders the steps or tasks of the template as
+// the children of the node. Errors are noted on the node rather than returned, so that the rest of the workflow is
+// still rendered.
+func (r *workflowRenderer) render(tmplCtx *templateresolution.Context, n *Node, holder wfv1.TemplateReferenceHolder, args wfv1.Arguments, stack map[string]bool) {
+	newTmplCtx, tmpl, _, err := tmplCtx.ResolveTemplate(holder)
+	if err != nil {
+		n.Notes = append(n.Notes, "error: "+err.Error())
+		return
+	}
+	n.Type = tmpl.GetType()
+	processedTmpl, err := common.ProcessArgs(tmpl, &args, r.globalParams, nil, true, r.namespace, nil)
+	if err != nil {
+		n.Notes = append(n.Notes, "error: "+err.Error())
+		return
+	}
+	n.Parameters = processedTmpl.Inputs.Parameters
+	key := newTmplCtx.GetTemplateScope() + "/" + n.Template
+	if stack[key] {
+		n.Notes = append(n.Notes, "recursive")
+		return
+	}
+	stack[key] = true
+	defer delete(stack, key)
+	switch {
+	case processedTmpl.Steps != nil:
+		var previous []string
+		for i, group := range processedTmpl.Steps {
+			var current []string
+			for _, step := range group.Steps {
+				for _, child := range r.renderStep(newTmplCtx, step, stack) {
+					child.StepGroup = i
+					child.Dependencies = previous
+					n.Children = append(n.Children, child)
+					current = append(current, child.Name)
+				}
+			}
+			previous = current
+		}
+	case processedTmpl.DAG != nil:
+		expandedNames := make(map[string][]string)
+		var tasks [][]*Node
+		for _, task := range processedTmpl.DAG.Tasks {
+			children := r.renderTask(newTmplCtx, task, stack)
+			for _, child := range children {
+				expandedNames[task.Name] = append(expandedNames[task.Name], child.Name)
+			}
+			tasks = append(tasks, children)
+		}
+		for i, task := range processedTmpl.DAG.Tasks {
+			dependencies := task.Dependencies
+			if task.Depends != "" {
+				dependencies = renderDependsTaskNameRegex.FindAllString(task.Depends, -1)
+			}
+			var names []string
+			seen := make(map[string]bool)
+			for _, d := range dependencies {
+				if !seen[d] {
+					seen[d] = true
+					names = append(names, expandedNames[d]...)
+				}
+			}
+			for _, child := range tasks[i] {
+				if task.Depends != "" {
+					child.Notes = append([]string{"depends: " + task.Depends}, child.Notes...)
+				}
+				child.Dependencies = names
+				n.Children = append(n.Children, child)
+			}
+		}
+	}
+}
+
+// getRenderedTemplateName returns the name of the template the holder refers to
+func getRenderedTemplateName(holder wfv1.TemplateReferenceHolder) string {
+	switch {
+	case holder.GetTemplate() != nil:
+		return "(inline)"
+	case holder.GetTemplateRef() != nil:
+		return holder.GetTemplateRef().Name + "/" + holder.GetTemplateRef().Template
+	default:
+		return holder.GetTemplateName()
+	}
+}
+
+// renderStep returns the nodes of a step, one for each item of its loop
+func (r *workflowRenderer) renderStep(tmplCtx *templateresolution.Context, step wfv1.WorkflowStep, stack map[string]bool) []*Node {
+	nodes, expanded := expandRendered(step.Name, step.WithItems, step.WithParam, step.WithSequence, step)
+	for i, n := range nodes {
+		if expanded[i] == "" {
+			continue
+		}
+		var s wfv1.WorkflowStep
+		if err := json.Unmarshal([]byte(expanded[i]), &s); err != nil {
+			n.Notes = append(n.Notes, "error: "+err.Error())
+			continue
+		}
+		n.Template = getRenderedTemplateName(&s)
+		if r.evaluateWhen(n, s.When) {
+			r.render(tmplCtx, n, &s, s.Arguments, stack)
+		}
+	}
+	return nodes
+}
+
+// renderTask returns the nodes of a task, one for each item of its loop
+func (r *workflowRenderer) renderTask(tmplCtx *templateresolution.Context, task wfv1.DAGTask, stack map[string]bool) []*Node {
+	nodes, expanded := expandRendered(task.Name, task.WithItems, task.WithParam, task.WithSequence, task)
+	for i, n := range nodes {
+		if expanded[i] == "" {
+			continue
+		}
+		var t wfv1.DAGTask
+		if err := json.Unmarshal([]byte(expanded[i]), &t); err != nil {
+			n.Notes = append(n.Notes, "error: "+err.Error())
+			continue
+		}
+		n.Template = getRenderedTemplateName(&t)
+		if r.evaluateWhen(n, t.When) {
+			r.render(tmplCtx, n, &t, t.Arguments, stack)
+		}
+	}
+	return nodes
+}
+
+// expandRendered returns a node for each item of the loop of the step or task, and the step or task with the item
+// substituted as JSON, as the controller does when it expands them. A loop that cannot be expanded before the workflow runs,
+// such as one over the outputs of another node, is noted on a single node. The JSON of a node that could not be
+// expanded is empty.
+func expandRendered(name string, withItems []wfv1.Item, withParam string, withSequence *wfv1.Sequence, obj interface{}) ([]*Node, []string) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return []*Node{{Name: name, Notes: []string{"error: " + err.Error()}}}, []string{""}
+	}
+	var items []wfv1.Item
+	switch {
+	case len(withItems) > 0:
+		items = withItems
+	case withParam != "":
+		if err := json.Unmarshal([]byte(withParam), &items); err != nil {
+			return []*Node{{Name: name, Notes: []string{"loop: withParam " + withParam + " (not expanded)"}}}, []string{string(data)}
+		}
+	case withSequence != nil:
+		items, err = wfutil.ExpandSequence(withSequence)
+		if err != nil {
+			return []*Node{{Name: name, Notes: []string{fmt.Sprintf("loop: withSequence (not expanded: %v)", err)}}}, []string{string(data)}
+		}
+	default:
+		return []*Node{{Name: name}}, []string{string(data)}
+	}
+	tmpl, err := template.NewTemplate(string(data))
+	if err != nil {
+		return []*Node{{Name: name, Notes: []string{"error: " + err.Error()}}}, []string{""}
+	}
+	nodes := make([]*Node, 0, len(items))
+	expanded := make([]string, 0, len(items))
+	for i, item := range items {
+		newName, replaceMap, err := wfutil.GetItemReplaceMap(name, i, item)
+		if err != nil {
+			return []*Node{{Name: name, Notes: []string{"error: " + err.Error()}}}, []string{""}
+		}
+		newStr, err := tmpl.Replace(replaceMap, true)
+		n := &Node{Name: newName}
+		if err != nil {
+			n.Notes = append(n.Notes, "error: "+err.Error())
+			newStr = ""
+		}
+		nodes = append(nodes, n)
+		expanded = append(expanded, newStr)
+	}
+	return nodes, expanded
+}
+
+// evaluateWhen returns whether the node would run, noting the condition on it if it cannot be evaluated before the
+// workflow runs, or if it is false
+func (r *workflowRenderer) evaluateWhen(n *Node, when string) bool {
+	if when == "" {
+		return true
+	}
+	if strings.Contains(when, "{{") {
+		n.Notes = append(n.Notes, "when: "+when)
+		return true
+	}
+	proceed, err := wfutil.ShouldExecute(when)
+	if err != nil {
+		n.Notes = append(n.Notes, "error: "+err.Error())
+		return false
+	}
+	if !proceed {
+		n.Notes = append(n.Notes, "skipped: when "+when)
+	}
+	return proceed
+}
@@ -0,0 +1,157 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	fakewfclientset "github.com/argoproj/argo-workflows/v3/pkg/client/clientset/versioned/fake"
+	"github.com/argoproj/argo-workflows/v3/workflow/templateresolution"
+)
+
+var renderWorkflowTemplate = `
+apiVersion: argoproj.io/v1alpha1
+kind: WorkflowTemplate
+metadata:
+  name: shared
+  namespace: default
+spec:
+  templates:
+  - name: print
+    inputs:
+      parameters:
+      - name: message
+    container:
+      image: argoproj/argosay:v2
+      args: [echo, "{{inputs.parameters.message}}"]
+`
+
+var renderWorkflow = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: render-
+  namespace: default
+spec:
+  entrypoint: main
+  arguments:
+    parameters:
+    - name: count
+      value: "2"
+  templates:
+  - name: main
+    dag:
+      tasks:
+      - name: A
+        template: gen
+        arguments:
+          parameters:
+          - name: message
+            value: "{{item}}"
+        withItems: [hello, goodbye]
+      - name: B
+        depends: A
+        templateRef:
+          name: shared
+          template: print
+        arguments:
+          parameters:
+          - name: message
+            value: "{{=asInt(item) * 10}}"
+        withSequence:
+          count: "{{workflow.parameters.count}}"
+      - name: C
+        depends: A
+        template: gen
+        arguments:
+          parameters:
+          - name: message
+            value: "{{item}}"
+        withParam: "{{tasks.A.outputs.result}}"
+      - name: D
+        template: gen
+        arguments:
+          parameters:
+          - name: message
+            value: skipped
+        when: "{{workflow.parameters.count}} > 5"
+      - name: E
+        template: loop
+  - name: gen
+    inputs:
+      parameters:
+      - name: message
+    container:
+      image: argoproj/argosay:v2
+      args: [echo, "{{inputs.parameters.message}}"]
+  - name: loop
+    steps:
+    - - name: again
+        template: loop
+`
+
+func TestWorkflow(t *testing.T) {
+	wfClientset := fakewfclientset.NewSimpleClientset(wfv1.MustUnmarshalWorkflowTemplate(renderWorkflowTemplate))
+	wftmplGetter := templateresolution.WrapWorkflowTemplateInterface(wfClientset.ArgoprojV1alpha1().WorkflowTemplates(metav1.NamespaceDefault))
+
+	nodes, err := Workflow(wfv1.MustUnmarshalWorkflow(renderWorkflow), wftmplGetter, &templateresolution.NullClusterWorkflowTemplateGetter{})
+	require.NoError(t, err)
+	require.Len(t, nodes, 1)
+	root := nodes[0]
+	assert.Equal(t, "render-", root.Name)
+	assert.Equal(t, wfv1.TemplateTypeDAG, root.Type)
+
+	var names []string
+	children := make(map[string]*Node)
+	for _, child := range root.Children {
+		names = append(names, child.Name)
+		children[child.Name] = child
+	}
+	assert.Equal(t, []string{"A(0:hello)", "A(1:goodbye)", "B(0:0)", "B(1:1)", "C", "D", "E"}, names)
+
+	t.Run("Items", func(t *testing.T) {
+		a := children["A(1:goodbye)"]
+		assert.Equal(t, "gen", a.Template)
+		assert.Equal(t, wfv1.TemplateTypeContainer, a.Type)
+		assert.Equal(t, "goodbye", a.Parameters[0].Value.String())
+	})
+	t.Run("SequenceOfTemplateRef", func(t *testing.T) {
+		b := children["B(1:1)"]
+		assert.Equal(t, "shared/print", b.Template)
+		assert.Equal(t, "10", b.Parameters[0].Value.String())
+		assert.Equal(t, []string{"A(0:hello)", "A(1:goodbye)"}, b.Dependencies)
+		assert.Equal(t, []string{"depends: A"}, b.Notes)
+	})
+	t.Run("ParamOfOutputs", func(t *testing.T) {
+		c := children["C"]
+		assert.Contains(t, c.Notes, "loop: withParam {{tasks.A.outputs.result}} (not expanded)")
+		assert.Equal(t, "{{item}}", c.Parameters[0].Value.String())
+	})
+	t.Run("WhenFalse", func(t *testing.T) {
+		d := children["D"]
+		assert.Equal(t, []string{"skipped: when 2 > 5"}, d.Notes)
+		assert.Empty(t, d.Type)
+	})
+	t.Run("Recursive", func(t *testing.T) {
+		e := children["E"]
+		require.Len(t, e.Children, 1)
+		assert.Equal(t, []string{"recursive"}, e.Children[0].Notes)
+	})
+}
+
+func TestWorkflowTemplateRefNotFound(t *testing.T) {
+	wfClientset := fakewfclientset.NewSimpleClientset()
+	wftmplGetter := templateresolution.WrapWorkflowTemplateInterface(wfClientset.ArgoprojV1alpha1().WorkflowTemplates(metav1.NamespaceDefault))
+
+	nodes, err := Workflow(wfv1.MustUnmarshalWorkflow(renderWorkflow), wftmplGetter, &templateresolution.NullClusterWorkflowTemplateGetter{})
+	require.NoError(t, err)
+	for _, child := range nodes[0].Children {
+		if child.Name == "B(0:0)" {
+			require.Len(t, child.Notes, 2)
+			assert.Contains(t, child.Notes[1], "error: ")
+		}
+	}
+}
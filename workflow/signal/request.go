@@ -0,0 +1,44 @@
+package signal
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+// Requests returns the signals requested for the running pods of a workflow, by node ID, from its annotations
+func Requests(annotations map[string]string) (map[string]string, error) {
+	requests := map[string]string{}
+	value, ok := annotations[common.AnnotationKeySignals]
+	if !ok {
+		return requests, nil
+	}
+	if err := json.Unmarshal([]byte(value), &requests); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal annotation %s: %w", common.AnnotationKeySignals, err)
+	}
+	return requests, nil
+}
+
+// NewRequest returns a request for the signal. Each request is different, so that the executor can tell the same
+// signal being requested again from the request it has already delivered.
+func NewRequest(s syscall.Signal, now time.Time) string {
+	return fmt.Sprintf("%d:%d", now.UnixNano(), s)
+}
+
+// ParseRequest returns the signal of a request
+func ParseRequest(request string) (syscall.Signal, error) {
+	_, value, ok := strings.Cut(request, ":")
+	if !ok {
+		return 0, fmt.Errorf("invalid signal request %q", request)
+	}
+	s, err := strconv.Atoi(value)
+	if err != nil || s <= 0 {
+		return 0, fmt.Errorf("invalid signal request %q", request)
+	}
+	return syscall.Signal(s), nil
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"path/filepath"
+	"sort"
 	"strings"
 	"syscall"
 
@@ -14,6 +15,34 @@ import (
 	"github.com/argoproj/argo-workflows/v3/workflow/common"
 )
 
+// signals are the signals that may be sent to a container on request, by name. Pods run on Linux, so these are Linux's
+// numbers, whichever platform this is built for.
+var signals = map[string]syscall.Signal{
+	"SIGHUP":  syscall.Signal(1),
+	"SIGINT":  syscall.Signal(2),
+	"SIGQUIT": syscall.Signal(3),
+	"SIGUSR1": syscall.Signal(10),
+	"SIGUSR2": syscall.Signal(12),
+	"SIGTERM": syscall.Signal(15),
+}
+
+// ParseSignal returns the signal with the name, e.g. "SIGUSR1" or "USR1"
+func ParseSignal(name string) (syscall.Signal, error) {
+	name = strings.ToUpper(name)
+	if !strings.HasPrefix(name, "SIG") {
+		name = "SIG" + name
+	}
+	if s, ok := signals[name]; ok {
+		return s, nil
+	}
+	names := make([]string, 0, len(signals))
+	for n := range signals {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return 0, fmt.Errorf("unsupported signal %q, must be one of %s", name, strings.Join(names, ", "))
+}
+
 func SignalContainer(restConfig *rest.Config, pod *corev1.Pod, container string, s syscall.Signal) error {
 	command := []string{"/bin/sh", "-c", "kill -%d 1"}
 
@@ -0,0 +1,52 @@
+package signal
+
+import (
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+func TestParseSignal(t *testing.T) {
+	for _, name := range []string{"SIGUSR1", "USR1", "sigusr1"} {
+		s, err := ParseSignal(name)
+		require.NoError(t, err)
+		assert.Equal(t, syscall.Signal(10), s)
+	}
+	_, err := ParseSignal("SIGKILL")
+	require.EqualError(t, err, `unsupported signal "SIGKILL", must be one of SIGHUP, SIGINT, SIGQUIT, SIGTERM, SIGUSR1, SIGUSR2`)
+}
+
+func TestRequests(t *testing.T) {
+	t.Run("None", func(t *testing.T) {
+		requests, err := Requests(nil)
+		require.NoError(t, err)
+		assert.Empty(t, requests)
+	})
+	t.Run("Some", func(t *testing.T) {
+		requests, err := Requests(map[string]string{common.AnnotationKeySignals: `{"my-node":"1:10"}`})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"my-node": "1:10"}, requests)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := Requests(map[string]string{common.AnnotationKeySignals: `[`})
+		require.Error(t, err)
+	})
+}
+
+func TestRequest(t *testing.T) {
+	now := time.Now()
+	request := NewRequest(syscall.Signal(10), now)
+	assert.NotEqual(t, request, NewRequest(syscall.Signal(10), now.Add(time.Nanosecond)))
+	s, err := ParseRequest(request)
+	require.NoError(t, err)
+	assert.Equal(t, syscall.Signal(10), s)
+	for _, request := range []string{"", "10", "1:", "1:USR1", "1:0", "1:-1"} {
+		_, err := ParseRequest(request)
+		require.Error(t, err, request)
+	}
+}
@@ -8,6 +8,8 @@ type Semaphore interface {
 	release(key string) bool
 	addToQueue(holderKey string, priority int32, creationTime time.Time)
 	removeFromQueue(holderKey string)
+	queuePosition(holderKey string) (int, int)
+	setAging(aging time.Duration)
 	getCurrentHolders() []string
 	getCurrentPending() []string
 	getName() string
@@ -41,7 +41,7 @@ func GetLockName(sync *v1alpha1.Synchronization, wfNamespace string) (*LockName,
 			}
 			return NewLockName(namespace, sync.Semaphore.ConfigMapKeyRef.Name, sync.Semaphore.ConfigMapKeyRef.Key, LockKindConfigMap), nil
 		}
-		return nil, fmt.Errorf("cannot get LockName for a Semaphore without a ConfigMapRef")
+		return nil, errors.New(errors.CodeSemaphoreConfig, "cannot get LockName for a Semaphore without a ConfigMapRef")
 	case v1alpha1.SynchronizationTypeMutex:
 		namespace := sync.Mutex.Namespace
 		if namespace == "" {
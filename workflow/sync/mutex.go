@@ -62,6 +62,18 @@ func (m *PriorityMutex) addToQueue(holderKey string, priority int32, creationTim
 	m.mutex.addToQueue(holderKey, priority, creationTime)
 }
 
+func (m *PriorityMutex) setAging(aging time.Duration) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.mutex.setAging(aging)
+}
+
+func (m *PriorityMutex) queuePosition(holderKey string) (int, int) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.mutex.queuePosition(holderKey)
+}
+
 func (m *PriorityMutex) removeFromQueue(holderKey string) {
 	m.lock.Lock()
 	defer m.lock.Unlock()
@@ -110,8 +110,9 @@ func (s *PrioritySemaphore) notifyWaiters() {
 	if s.pending.Len() < triggerCount {
 		triggerCount = s.pending.Len()
 	}
+	pending := s.pending.sorted()
 	for idx := 0; idx < triggerCount; idx++ {
-		item := s.pending.items[idx]
+		item := pending[idx]
 		wfKey := workflowKey(item)
 		s.log.Debugf("Enqueue the workflow %s", wfKey)
 		s.nextWorkflow(wfKey)
@@ -143,6 +144,21 @@ func (s *PrioritySemaphore) addToQueue(holderKey string, priority int32, creatio
 	s.log.Debugf("Added into queue: %s", holderKey)
 }
 
+// setAging configures how quickly waiting holders gain priority, see priorityQueue.aging.
+func (s *PrioritySemaphore) setAging(aging time.Duration) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.pending.aging = aging
+}
+
+// queuePosition returns the 1-based position of the holder in the queue and the length of the queue.
+// The position is 0 when the holder is not waiting.
+func (s *PrioritySemaphore) queuePosition(holderKey string) (int, int) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.pending.position(holderKey), s.pending.Len()
+}
+
 func (s *PrioritySemaphore) removeFromQueue(holderKey string) {
 	s.lock.Lock()
 	defer s.lock.Unlock()
@@ -178,16 +194,19 @@ func (s *PrioritySemaphore) tryAcquire(holderKey string) (bool, string) {
 		s.log.Debugf("%s is already holding a lock", holderKey)
 		return true, ""
 	}
-	var nextKey string
 
+	s.pending.reorder(time.Now())
 	waitingMsg := fmt.Sprintf("Waiting for %s lock. Lock status: %d/%d", s.name, s.limit-len(s.lockHolder), s.limit)
+	if position := s.pending.position(holderKey); position > 0 {
+		waitingMsg = fmt.Sprintf("%s. Queue position: %d/%d", waitingMsg, position, s.pending.Len())
+	}
 
 	// Check whether requested holdkey is in front of priority queue.
 	// If it is in front position, it will allow to acquire lock.
 	// If it is not a front key, it needs to wait for its turn.
 	if s.pending.Len() > 0 {
 		item := s.pending.peek()
-		if holderKey != nextKey && !isSameWorkflowNodeKeys(holderKey, item.key) {
+		if holderKey != item.key && !isSameWorkflowNodeKeys(holderKey, item.key) {
 			// Enqueue the front workflow if lock is available
 			if len(s.lockHolder) < s.limit {
 				s.nextWorkflow(workflowKey(item))
@@ -197,7 +216,7 @@ func (s *PrioritySemaphore) tryAcquire(holderKey string) (bool, string) {
 	}
 
 	if s.acquire(holderKey) {
-		s.pending.pop()
+		s.pending.remove(holderKey)
 		s.log.Infof("%s acquired by %s. Lock availability: %d/%d", s.name, holderKey, s.limit-len(s.lockHolder), s.limit)
 		s.notifyWaiters()
 		return true, ""
@@ -104,3 +104,52 @@ func TestNotifyWorkflowFromTemplateSemaphore(t *testing.T) {
 	assert.Len(t, notified, 1)
 	assert.True(t, notified["default/wf-02"])
 }
+
+func TestTryAcquirePriorityThenFIFO(t *testing.T) {
+	nextWorkflow := func(key string) {
+	}
+
+	s := NewSemaphore("foo", 1, nextWorkflow, "semaphore")
+	now := time.Now()
+	s.addToQueue("default/wf-01", 0, now)
+	s.addToQueue("default/wf-02", 0, now)
+	s.addToQueue("default/wf-03", 5, now.Add(time.Second))
+
+	acquired, msg := s.tryAcquire("default/wf-02")
+	assert.False(t, acquired)
+	assert.Contains(t, msg, "Queue position: 3/3")
+	acquired, _ = s.tryAcquire("default/wf-03")
+	assert.True(t, acquired)
+	assert.True(t, s.release("default/wf-03"))
+	// wf-01 and wf-02 were created at the same time, so the first one queued goes first
+	acquired, _ = s.tryAcquire("default/wf-02")
+	assert.False(t, acquired)
+	acquired, _ = s.tryAcquire("default/wf-01")
+	assert.True(t, acquired)
+}
+
+func TestTryAcquireAging(t *testing.T) {
+	nextWorkflow := func(key string) {
+	}
+
+	s := NewSemaphore("foo", 1, nextWorkflow, "semaphore")
+	s.setAging(time.Minute)
+	now := time.Now()
+	s.addToQueue("default/wf-low", 0, now)
+	s.addToQueue("default/wf-high", 2, now)
+	position, length := s.queuePosition("default/wf-low")
+	assert.Equal(t, 2, position)
+	assert.Equal(t, 2, length)
+
+	// the low priority workflow has been waiting long enough to overtake the high priority one
+	s.pending.itemByKey["default/wf-low"].enqueueTime = now.Add(-3 * time.Minute)
+	acquired, _ := s.tryAcquire("default/wf-high")
+	assert.False(t, acquired)
+	position, _ = s.queuePosition("default/wf-low")
+	assert.Equal(t, 1, position)
+	acquired, _ = s.tryAcquire("default/wf-low")
+	assert.True(t, acquired)
+	position, length = s.queuePosition("default/wf-low")
+	assert.Equal(t, 0, position)
+	assert.Equal(t, 1, length)
+}
@@ -4,8 +4,10 @@ import (
 	"fmt"
 	"strings"
 	"sync"
+	"time"
 
 	log "github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtimeutil "k8s.io/apimachinery/pkg/util/runtime"
 
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
@@ -23,6 +25,7 @@ type Manager struct {
 	nextWorkflow NextWorkflow
 	getSyncLimit GetSyncLimit
 	isWFDeleted  IsWorkflowDeleted
+	aging        time.Duration
 }
 
 func NewLockManager(getSyncLimit GetSyncLimit, nextWorkflow NextWorkflow, isWFDeleted IsWorkflowDeleted) *Manager {
@@ -35,6 +38,18 @@ func NewLockManager(getSyncLimit GetSyncLimit, nextWorkflow NextWorkflow, isWFDe
 	}
 }
 
+// SetAging sets the interval after which the priority of a waiting workflow is raised by one, so that low priority
+// workflows are eventually admitted. Zero disables aging.
+func (cm *Manager) SetAging(aging time.Duration) {
+	cm.lock.Lock()
+	defer cm.lock.Unlock()
+
+	cm.aging = aging
+	for _, lock := range cm.syncLockMap {
+		lock.setAging(aging)
+	}
+}
+
 func (cm *Manager) getWorkflowKey(key string) (string, error) {
 	if key == "" {
 		return "", fmt.Errorf("holderkey is empty")
@@ -166,13 +181,51 @@ func (cm *Manager) TryAcquire(wf *wfv1.Workflow, nodeName string, syncLockRef *w
 	acquired, msg := lock.tryAcquire(holderKey)
 	if acquired {
 		updated := wf.Status.Synchronization.GetStatus(syncLockRef.GetType()).LockAcquired(holderKey, lockKey, currentHolders)
+		if nodeName == "" {
+			updated = updateWaitingCondition(wf, lock, holderKey, lockKey, true) || updated
+		}
 		return true, updated, "", nil
 	}
 
 	updated := wf.Status.Synchronization.GetStatus(syncLockRef.GetType()).LockWaiting(holderKey, lockKey, currentHolders)
+	if nodeName == "" {
+		// the position is recomputed on every attempt, but it is also in the waiting message, which the controller
+		// saves whenever it changes, so the condition is saved with it rather than reported as an update of its own
+		updateWaitingCondition(wf, lock, holderKey, lockKey, false)
+	}
 	return false, updated, msg, nil
 }
 
+// updateWaitingCondition reports the queue position of a workflow waiting for a workflow-level lock as a workflow
+// condition, and clears the condition once the lock has been acquired. It returns whether the condition changed,
+// which it only does when the position has changed, not when other waiters join or leave the queue behind it.
+// Template-level waiters report their position in the node message instead.
+func updateWaitingCondition(wf *wfv1.Workflow, lock Semaphore, holderKey, lockKey string, acquired bool) bool {
+	existing := wf.Status.Conditions.Get(wfv1.ConditionTypeSynchronizationWaiting)
+	if acquired {
+		if existing == nil {
+			return false
+		}
+		wf.Status.Conditions.RemoveCondition(wfv1.ConditionTypeSynchronizationWaiting)
+		return true
+	}
+	position, _ := lock.queuePosition(holderKey)
+	if position == 0 {
+		return false
+	}
+	message := fmt.Sprintf("Position %d in queue for %s lock", position, lockKey)
+	// the existing condition has a transition time, so only the fields that carry the position are compared
+	if existing != nil && existing.Status == metav1.ConditionTrue && existing.Message == message {
+		return false
+	}
+	wf.Status.Conditions.UpsertCondition(wfv1.Condition{
+		Type:    wfv1.ConditionTypeSynchronizationWaiting,
+		Status:  metav1.ConditionTrue,
+		Message: message,
+	})
+	return true
+}
+
 func (cm *Manager) Release(wf *wfv1.Workflow, nodeName string, syncRef *wfv1.Synchronization) {
 	if syncRef == nil {
 		return
@@ -270,6 +323,7 @@ func (cm *Manager) ReleaseAll(wf *wfv1.Workflow) bool {
 	}
 
 	wf.Status.Synchronization = nil
+	wf.Status.Conditions.RemoveCondition(wfv1.ConditionTypeSynchronizationWaiting)
 	return true
 }
 
@@ -316,11 +370,15 @@ func (cm *Manager) initializeSemaphore(semaphoreName string) (Semaphore, error)
 	if err != nil {
 		return nil, err
 	}
-	return NewSemaphore(semaphoreName, limit, cm.nextWorkflow, "semaphore"), nil
+	semaphore := NewSemaphore(semaphoreName, limit, cm.nextWorkflow, "semaphore")
+	semaphore.setAging(cm.aging)
+	return semaphore, nil
 }
 
 func (cm *Manager) initializeMutex(mutexName string) Semaphore {
-	return NewMutex(mutexName, cm.nextWorkflow)
+	mutex := NewMutex(mutexName, cm.nextWorkflow)
+	mutex.setAging(cm.aging)
+	return mutex
 }
 
 func (cm *Manager) isSemaphoreSizeChanged(semaphore Semaphore) (bool, int, error) {
@@ -427,6 +427,13 @@ func TestSemaphoreWfLevel(t *testing.T) {
 		assert.NotEmpty(t, msg)
 		assert.False(t, status)
 		assert.True(t, wfUpdate)
+		if assert.Len(t, wf1.Status.Conditions, 1) {
+			condition := wf1.Status.Conditions[0]
+			assert.Equal(t, wfv1.ConditionTypeSynchronizationWaiting, condition.Type)
+			assert.Equal(t, metav1.ConditionTrue, condition.Status)
+			assert.Equal(t, "Position 2 in queue for default/ConfigMap/my-config/workflow lock", condition.Message)
+			assert.NotNil(t, condition.LastTransitionTime)
+		}
 
 		// High Priority workflow acquires the lock
 		status, wfUpdate, msg, err = concurrenyMgr.TryAcquire(wf2, "", wf2.Spec.Synchronization)
@@ -437,6 +444,7 @@ func TestSemaphoreWfLevel(t *testing.T) {
 		assert.NotNil(t, wf2.Status.Synchronization)
 		assert.NotNil(t, wf2.Status.Synchronization.Semaphore)
 		assert.Equal(t, wf2.Name, wf2.Status.Synchronization.Semaphore.Holding[0].Holders[0])
+		assert.Empty(t, wf2.Status.Conditions)
 
 		concurrenyMgr.ReleaseAll(wf2)
 		assert.Nil(t, wf2.Status.Synchronization)
@@ -871,3 +879,26 @@ status:
 	})
 
 }
+
+func TestUpdateWaitingCondition(t *testing.T) {
+	wf := &wfv1.Workflow{}
+	s := NewSemaphore("foo", 1, func(string) {}, "semaphore")
+	now := time.Now()
+	s.addToQueue("default/wf-01", 0, now)
+	assert.True(t, updateWaitingCondition(wf, s, "default/wf-01", "foo", false))
+	assert.Equal(t, "Position 1 in queue for foo lock", wf.Status.Conditions[0].Message)
+	assert.False(t, updateWaitingCondition(wf, s, "default/wf-01", "foo", false))
+
+	// a waiter queued behind it does not change its position
+	s.addToQueue("default/wf-02", 0, now.Add(time.Second))
+	assert.False(t, updateWaitingCondition(wf, s, "default/wf-01", "foo", false))
+
+	// a higher priority waiter queued ahead of it does
+	s.addToQueue("default/wf-03", 1, now.Add(time.Second))
+	assert.True(t, updateWaitingCondition(wf, s, "default/wf-01", "foo", false))
+	assert.Equal(t, "Position 2 in queue for foo lock", wf.Status.Conditions[0].Message)
+
+	assert.True(t, updateWaitingCondition(wf, s, "default/wf-01", "foo", true))
+	assert.Empty(t, wf.Status.Conditions)
+	assert.False(t, updateWaitingCondition(wf, s, "default/wf-01", "foo", true))
+}
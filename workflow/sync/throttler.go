@@ -2,6 +2,7 @@ package sync
 
 import (
 	"container/heap"
+	"sort"
 	"sync"
 	"time"
 
@@ -142,6 +143,7 @@ func (t *throttler) queueThrottled(bucketKey BucketKey) {
 type item struct {
 	key          string
 	creationTime time.Time
+	enqueueTime  time.Time
 	priority     int32
 	index        int
 }
@@ -149,6 +151,11 @@ type item struct {
 type priorityQueue struct {
 	items     []*item
 	itemByKey map[string]*item
+	// aging raises the effective priority of an item by one for every interval it has been waiting, so that
+	// low priority items cannot be starved by a steady stream of higher priority ones. Zero disables aging.
+	aging time.Duration
+	// now is the time effective priorities are evaluated at, it is only advanced by reorder
+	now time.Time
 }
 
 func (pq *priorityQueue) pop() *item {
@@ -166,7 +173,7 @@ func (pq *priorityQueue) add(key Key, priority int32, creationTime time.Time) {
 			heap.Fix(pq, res.index)
 		}
 	} else {
-		heap.Push(pq, &item{key: key, priority: priority, creationTime: creationTime})
+		heap.Push(pq, &item{key: key, priority: priority, creationTime: creationTime, enqueueTime: time.Now()})
 	}
 }
 
@@ -177,13 +184,63 @@ func (pq *priorityQueue) remove(key Key) {
 	}
 }
 
+// reorder re-evaluates the aged priorities at the given time and restores the heap order.
+func (pq *priorityQueue) reorder(now time.Time) {
+	if pq.aging <= 0 {
+		return
+	}
+	pq.now = now
+	heap.Init(pq)
+}
+
+// sorted returns the items in the order they are expected to leave the queue.
+func (pq *priorityQueue) sorted() []*item {
+	items := make([]*item, len(pq.items))
+	copy(items, pq.items)
+	sort.Slice(items, func(i, j int) bool { return pq.less(items[i], items[j]) })
+	return items
+}
+
+// position returns the 1-based position of the key in the queue, or 0 if the key is not queued.
+func (pq *priorityQueue) position(key Key) int {
+	if _, ok := pq.itemByKey[key]; !ok {
+		return 0
+	}
+	for i, item := range pq.sorted() {
+		if item.key == key {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+func (pq *priorityQueue) effectivePriority(i *item) int64 {
+	priority := int64(i.priority)
+	if pq.aging > 0 && pq.now.After(i.enqueueTime) {
+		priority += int64(pq.now.Sub(i.enqueueTime) / pq.aging)
+	}
+	return priority
+}
+
+// less orders items by effective priority, then by creation time and finally by the time they were queued,
+// so that items of equal priority are served first-in-first-out.
+func (pq *priorityQueue) less(a, b *item) bool {
+	if pa, pb := pq.effectivePriority(a), pq.effectivePriority(b); pa != pb {
+		return pa > pb
+	}
+	if !a.creationTime.Equal(b.creationTime) {
+		return a.creationTime.Before(b.creationTime)
+	}
+	if !a.enqueueTime.Equal(b.enqueueTime) {
+		return a.enqueueTime.Before(b.enqueueTime)
+	}
+	return a.key < b.key
+}
+
 func (pq priorityQueue) Len() int { return len(pq.items) }
 
 func (pq priorityQueue) Less(i, j int) bool {
-	if pq.items[i].priority == pq.items[j].priority {
-		return pq.items[i].creationTime.Before(pq.items[j].creationTime)
-	}
-	return pq.items[i].priority > pq.items[j].priority
+	return pq.less(pq.items[i], pq.items[j])
 }
 
 func (pq priorityQueue) Swap(i, j int) {
@@ -105,7 +105,7 @@ func (ctx *Context) GetTemplateByName(name string) (*wfv1.Template, error) {
 
 	tmpl := ctx.tmplBase.GetTemplateByName(name)
 	if tmpl == nil {
-		return nil, errors.Errorf(errors.CodeNotFound, "template %s not found", name)
+		return nil, errors.Errorf(errors.CodeTemplateNotFound, "template %s not found", name)
 	}
 	return tmpl.DeepCopy(), nil
 }
@@ -131,7 +131,7 @@ func (ctx *Context) GetTemplateFromRef(tmplRef *wfv1.TemplateRef) (*wfv1.Templat
 
 	if err != nil {
 		if apierr.IsNotFound(err) {
-			return nil, errors.Errorf(errors.CodeNotFound, "workflow template %s not found", tmplRef.Name)
+			return nil, errors.Errorf(errors.CodeTemplateNotFound, "workflow template %s not found", tmplRef.Name)
 		}
 		return nil, err
 	}
@@ -139,7 +139,7 @@ func (ctx *Context) GetTemplateFromRef(tmplRef *wfv1.TemplateRef) (*wfv1.Templat
 	template = wftmpl.GetTemplateByName(tmplRef.Template)
 
 	if template == nil {
-		return nil, errors.Errorf(errors.CodeNotFound, "template %s not found in workflow template %s", tmplRef.Template, tmplRef.Name)
+		return nil, errors.Errorf(errors.CodeTemplateNotFound, "template %s not found in workflow template %s", tmplRef.Template, tmplRef.Name)
 	}
 	return template.DeepCopy(), nil
 }
@@ -0,0 +1,71 @@
+package util
+
+import (
+	"context"
+	"strings"
+
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/argoproj/argo-workflows/v3/errors"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/pkg/client/clientset/versioned/typed/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/server/auth"
+	errorsutil "github.com/argoproj/argo-workflows/v3/util/errors"
+	"github.com/argoproj/argo-workflows/v3/util/retry"
+	waitutil "github.com/argoproj/argo-workflows/v3/util/wait"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+	"github.com/argoproj/argo-workflows/v3/workflow/hydrator"
+)
+
+// AnnotateNode adds a note to the node of the workflow with the ID, name or display name, authored by the user of the
+// request if the Argo Server authenticated them. A workflow that was already archived is marked to be archived again,
+// so that the archived workflow has the note too.
+func AnnotateNode(ctx context.Context, wfIf v1alpha1.WorkflowInterface, hydrator hydrator.Interface, workflowName, nodeName, note string) (*wfv1.Workflow, error) {
+	note = strings.TrimSpace(note)
+	if note == "" {
+		return nil, errors.New(errors.CodeBadRequest, "note must not be empty")
+	}
+	author := ""
+	if claims := auth.GetClaims(ctx); claims != nil {
+		author = claims.Subject
+	}
+	var updated *wfv1.Workflow
+	err := waitutil.Backoff(retry.DefaultRetry, func() (bool, error) {
+		wf, err := wfIf.Get(ctx, workflowName, metav1.GetOptions{})
+		if err != nil {
+			return !errorsutil.IsTransientErr(err), err
+		}
+		err = hydrator.Hydrate(wf)
+		if err != nil {
+			return false, err
+		}
+		node := wf.Status.Nodes.Find(func(n wfv1.NodeStatus) bool {
+			return n.ID == nodeName || n.Name == nodeName || n.DisplayName == nodeName
+		})
+		if node == nil {
+			return true, errors.Errorf(errors.CodeNotFound, "node %q not found in workflow %q", nodeName, workflowName)
+		}
+		node.Notes = append(node.Notes, wfv1.NodeNote{Note: note, Author: author, AddedAt: metav1.Now()})
+		wf.Status.Nodes.Set(node.ID, *node)
+		if wf.Labels[common.LabelKeyWorkflowArchivingStatus] == "Archived" {
+			wf.Labels[common.LabelKeyWorkflowArchivingStatus] = "Pending"
+		}
+		err = hydrator.Dehydrate(wf)
+		if err != nil {
+			return true, err
+		}
+		updated, err = wfIf.Update(ctx, wf, metav1.UpdateOptions{})
+		if err != nil {
+			if apierr.IsConflict(err) {
+				return false, nil
+			}
+			return true, err
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
@@ -0,0 +1,53 @@
+package util
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-jose/go-jose/v3/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/argoproj/argo-workflows/v3/errors"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	argofake "github.com/argoproj/argo-workflows/v3/pkg/client/clientset/versioned/fake"
+	"github.com/argoproj/argo-workflows/v3/server/auth"
+	"github.com/argoproj/argo-workflows/v3/server/auth/types"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+	hydratorfake "github.com/argoproj/argo-workflows/v3/workflow/hydrator/fake"
+)
+
+func TestAnnotateNode(t *testing.T) {
+	wfIf := argofake.NewSimpleClientset().ArgoprojV1alpha1().Workflows("")
+	wf := wfv1.MustUnmarshalWorkflow(susWorkflow)
+	wf.Labels = map[string]string{common.LabelKeyWorkflowArchivingStatus: "Archived"}
+	ctx := context.Background()
+	_, err := wfIf.Create(ctx, wf, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	t.Run("EmptyNote", func(t *testing.T) {
+		_, err := AnnotateNode(ctx, wfIf, hydratorfake.Noop, "suspend-template", "approve", " ")
+		assert.True(t, errors.IsCode(errors.CodeBadRequest, err))
+	})
+	t.Run("NodeNotFound", func(t *testing.T) {
+		_, err := AnnotateNode(ctx, wfIf, hydratorfake.Noop, "suspend-template", "not-found", "my note")
+		assert.True(t, errors.IsCode(errors.CodeNotFound, err))
+	})
+	t.Run("Annotated", func(t *testing.T) {
+		ctx := context.WithValue(ctx, auth.ClaimsKey, &types.Claims{Claims: jwt.Claims{Subject: "jane"}})
+		_, err := AnnotateNode(ctx, wfIf, hydratorfake.Noop, "suspend-template", "approve", "reran manually, see INC-1234")
+		require.NoError(t, err)
+		updated, err := AnnotateNode(context.Background(), wfIf, hydratorfake.Noop, "suspend-template", "suspend-template-kgfn7-2667278707", "second")
+		require.NoError(t, err)
+		notes := updated.Status.Nodes["suspend-template-kgfn7-2667278707"].Notes
+		require.Len(t, notes, 2)
+		assert.Equal(t, "reran manually, see INC-1234", notes[0].Note)
+		assert.Equal(t, "jane", notes[0].Author)
+		assert.False(t, notes[0].AddedAt.IsZero())
+		assert.Equal(t, "second", notes[1].Note)
+		assert.Empty(t, notes[1].Author)
+		// archived again, so that the archive has the notes too
+		assert.Equal(t, "Pending", updated.Labels[common.LabelKeyWorkflowArchivingStatus])
+	})
+}
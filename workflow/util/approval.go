@@ -0,0 +1,53 @@
+package util
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/server/auth"
+	"github.com/argoproj/argo-workflows/v3/workflow/creator"
+)
+
+// ApprovalOpts are how the Argo Server approves the suspend nodes that need approvals
+type ApprovalOpts struct {
+	// CreatorKey is the key the Argo Server signs the creators of the workflows it creates with, only a creator signed
+	// with it is trusted, see creator.VerifiedSubject
+	CreatorKey []byte
+	// AllowUnknownCreator allows the nodes of workflows whose creator is not known to be approved by any user
+	AllowUnknownCreator bool
+}
+
+// approveNode records the approval of a suspend node by the user of the request, and returns true if the node can be
+// resumed: it does not need approvals, or it now has enough of them. Approvals are only given by users the Argo Server
+// authenticated, so that it is known who approved, and a user cannot approve a node twice, nor approve a node of a
+// workflow they submitted. The creator is only known if the Argo Server signed it, as anyone who creates a workflow
+// could set any creator, and the nodes of workflows whose creator is not known cannot be approved, unless opts allow
+// it, as it cannot be told whether the user submitted them.
+func approveNode(ctx context.Context, wf *wfv1.Workflow, node *wfv1.NodeStatus, opts ApprovalOpts) (bool, error) {
+	if node.Approval == nil {
+		return true, nil
+	}
+	claims := auth.GetClaims(ctx)
+	if claims == nil || claims.Subject == "" {
+		return false, fmt.Errorf("node %s needs approvals, which can only be given by users authenticated by the Argo Server", node.DisplayName)
+	}
+	subject := creator.VerifiedSubject(wf, opts.CreatorKey)
+	if subject == "" && !opts.AllowUnknownCreator {
+		return false, fmt.Errorf("the creator of the workflow is not known, so node %s cannot be approved", node.DisplayName)
+	}
+	if subject == claims.Subject {
+		return false, fmt.Errorf("%s submitted the workflow and cannot approve node %s", claims.Subject, node.DisplayName)
+	}
+	if node.Approval.IsApprovedBy(claims.Subject) {
+		return false, fmt.Errorf("%s has already approved node %s", claims.Subject, node.DisplayName)
+	}
+	node.Approval.Approvals = append(node.Approval.Approvals, wfv1.Approval{Approver: claims.Subject, ApprovedAt: metav1.Now()})
+	if !node.Approval.IsApproved() {
+		node.Message = fmt.Sprintf("%d of %d approvals", len(node.Approval.Approvals), node.Approval.Required)
+		return false, nil
+	}
+	return true, nil
+}
@@ -0,0 +1,107 @@
+package util
+
+import (
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/util/slice"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+// GetArtifactGCReport returns the output artifacts of the workflow that artifact garbage collection will delete,
+// without deleting them, and the failures recorded by the workflow's garbage collection tasks and pods
+func GetArtifactGCReport(wf *wfv1.Workflow, tasks []wfv1.WorkflowArtifactGCTask, pods []corev1.Pod) *wfv1.ArtifactGCReport {
+	report := &wfv1.ArtifactGCReport{Candidates: artifactGCCandidates(wf)}
+	for _, task := range tasks {
+		for nodeID, nodeResult := range task.Status.ArtifactResultsByNode {
+			for artifactName, result := range nodeResult.ArtifactResults {
+				if result.Success || result.Error == nil {
+					continue
+				}
+				report.Failures = append(report.Failures, wfv1.ArtifactGCFailure{
+					NodeID:       nodeID,
+					ArtifactName: artifactName,
+					Message:      *result.Error,
+					TaskName:     task.Name,
+				})
+			}
+		}
+	}
+	for _, pod := range pods {
+		if pod.Status.Phase != corev1.PodFailed {
+			continue
+		}
+		message := pod.Status.Message
+		if message == "" {
+			message = "pod exited with non-zero exit code: check pod logs for more information"
+		}
+		report.Failures = append(report.Failures, wfv1.ArtifactGCFailure{Message: message, PodName: pod.Name})
+	}
+	sort.Slice(report.Failures, func(i, j int) bool {
+		a, b := report.Failures[i], report.Failures[j]
+		if a.TaskName+a.PodName != b.TaskName+b.PodName {
+			return a.TaskName+a.PodName < b.TaskName+b.PodName
+		}
+		if a.NodeID != b.NodeID {
+			return a.NodeID < b.NodeID
+		}
+		return a.ArtifactName < b.ArtifactName
+	})
+	return report
+}
+
+// artifactGCCandidates returns the output artifacts of the workflow's pods that have not been deleted yet, and that
+// have an artifact garbage collection strategy
+func artifactGCCandidates(wf *wfv1.Workflow) []wfv1.ArtifactGCCandidate {
+	execWf := &wfv1.Workflow{Spec: *wf.GetExecSpec()}
+	completed := wf.Labels[common.LabelKeyCompleted] == "true"
+	deleted := wf.DeletionTimestamp != nil
+	var candidates []wfv1.ArtifactGCCandidate
+	for _, n := range wf.Status.Nodes {
+		if n.Type != wfv1.NodeTypePod {
+			continue
+		}
+		for _, a := range n.GetOutputs().GetArtifacts() {
+			strategy := execWf.GetArtifactGCStrategy(&a)
+			if a.Deleted || strategy == wfv1.ArtifactGCNever || strategy == wfv1.ArtifactGCStrategyUndefined {
+				continue
+			}
+			key, _ := a.GetKey()
+			candidates = append(candidates, wfv1.ArtifactGCCandidate{
+				NodeID:       n.ID,
+				NodeName:     n.DisplayName,
+				ArtifactName: a.Name,
+				Strategy:     strategy,
+				Key:          key,
+				SizeBytes:    a.SizeBytes,
+				Ready:        deleted || (completed && strategy == wfv1.ArtifactGCOnWorkflowCompletion),
+			})
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].NodeName != candidates[j].NodeName {
+			return candidates[i].NodeName < candidates[j].NodeName
+		}
+		return candidates[i].ArtifactName < candidates[j].ArtifactName
+	})
+	return candidates
+}
+
+// FormulateRetryArtifactGC resets the artifact garbage collection of the workflow, so that the controller tries to
+// delete the artifacts it has not deleted yet again. The workflow's garbage collection tasks and pods must be deleted
+// too, so that new ones are created.
+func FormulateRetryArtifactGC(wf *wfv1.Workflow) (*wfv1.Workflow, error) {
+	if len(artifactGCCandidates(wf)) == 0 {
+		return nil, fmt.Errorf("workflow %s has no artifacts left to garbage collect", wf.Name)
+	}
+	newWF := wf.DeepCopy()
+	newWF.Status.ArtifactGCStatus = &wfv1.ArtGCStatus{}
+	newWF.Status.Conditions.RemoveCondition(wfv1.ConditionTypeArtifactGCError)
+	if !slice.ContainsString(newWF.Finalizers, common.FinalizerArtifactGC) && newWF.DeletionTimestamp == nil {
+		newWF.Finalizers = append(newWF.Finalizers, common.FinalizerArtifactGC)
+	}
+	return newWF, nil
+}
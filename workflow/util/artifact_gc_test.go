@@ -0,0 +1,122 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+var artifactGCWf = `
+metadata:
+  name: artifact-gc
+  labels:
+    workflows.argoproj.io/completed: "true"
+spec:
+  entrypoint: main
+  artifactGC:
+    strategy: OnWorkflowDeletion
+  templates:
+    - name: main
+      container:
+        image: argoproj/argosay:v2
+status:
+  conditions:
+    - type: ArtifactGCError
+      status: "True"
+      message: "access denied"
+  artifactGCStatus:
+    strategiesProcessed:
+      OnWorkflowCompletion: true
+    podsRecouped:
+      artifact-gc-wfcomp-1: true
+  nodes:
+    artifact-gc:
+      id: artifact-gc
+      name: artifact-gc
+      displayName: artifact-gc
+      type: Pod
+      phase: Succeeded
+      outputs:
+        artifacts:
+          - name: on-deletion
+            sizeBytes: 1024
+            s3:
+              key: on-deletion.tgz
+          - name: on-completion
+            s3:
+              key: on-completion.tgz
+            artifactGC:
+              strategy: OnWorkflowCompletion
+          - name: never
+            s3:
+              key: never.tgz
+            artifactGC:
+              strategy: Never
+          - name: deleted
+            deleted: true
+            s3:
+              key: deleted.tgz
+`
+
+func TestGetArtifactGCReport(t *testing.T) {
+	wf := wfv1.MustUnmarshalWorkflow(artifactGCWf)
+	tasks := []wfv1.WorkflowArtifactGCTask{{
+		ObjectMeta: metav1.ObjectMeta{Name: "artifact-gc-1"},
+		Status: wfv1.ArtifactGCStatus{ArtifactResultsByNode: map[string]wfv1.ArtifactResultNodeStatus{
+			"artifact-gc": {ArtifactResults: map[string]wfv1.ArtifactResult{
+				"on-completion": {Name: "on-completion", Error: pointer.String("access denied")},
+				"deleted":       {Name: "deleted", Success: true},
+			}},
+		}},
+	}}
+	pods := []corev1.Pod{
+		{ObjectMeta: metav1.ObjectMeta{Name: "artifact-gc-wfcomp-1"}, Status: corev1.PodStatus{Phase: corev1.PodFailed}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "artifact-gc-wfcomp-2"}, Status: corev1.PodStatus{Phase: corev1.PodSucceeded}},
+	}
+
+	report := GetArtifactGCReport(wf, tasks, pods)
+	assert.Equal(t, []wfv1.ArtifactGCCandidate{
+		{NodeID: "artifact-gc", NodeName: "artifact-gc", ArtifactName: "on-completion", Strategy: wfv1.ArtifactGCOnWorkflowCompletion, Key: "on-completion.tgz", Ready: true},
+		{NodeID: "artifact-gc", NodeName: "artifact-gc", ArtifactName: "on-deletion", Strategy: wfv1.ArtifactGCOnWorkflowDeletion, Key: "on-deletion.tgz", SizeBytes: 1024},
+	}, report.Candidates)
+	assert.Equal(t, []wfv1.ArtifactGCFailure{
+		{NodeID: "artifact-gc", ArtifactName: "on-completion", Message: "access denied", TaskName: "artifact-gc-1"},
+		{Message: "pod exited with non-zero exit code: check pod logs for more information", PodName: "artifact-gc-wfcomp-1"},
+	}, report.Failures)
+
+	t.Run("Deleting", func(t *testing.T) {
+		wf := wf.DeepCopy()
+		wf.DeletionTimestamp = &metav1.Time{}
+		report := GetArtifactGCReport(wf, nil, nil)
+		if assert.Len(t, report.Candidates, 2) {
+			assert.True(t, report.Candidates[1].Ready)
+		}
+		assert.Empty(t, report.Failures)
+	})
+}
+
+func TestFormulateRetryArtifactGC(t *testing.T) {
+	t.Run("Retry", func(t *testing.T) {
+		wf := wfv1.MustUnmarshalWorkflow(artifactGCWf)
+		newWf, err := FormulateRetryArtifactGC(wf)
+		if assert.NoError(t, err) {
+			assert.Equal(t, &wfv1.ArtGCStatus{}, newWf.Status.ArtifactGCStatus)
+			assert.Empty(t, newWf.Status.Conditions)
+			assert.Contains(t, newWf.Finalizers, common.FinalizerArtifactGC)
+			assert.NotEmpty(t, wf.Status.Conditions, "the workflow is not modified")
+		}
+	})
+	t.Run("NothingToCollect", func(t *testing.T) {
+		wf := wfv1.MustUnmarshalWorkflow(artifactGCWf)
+		wf.Spec.ArtifactGC = nil
+		wf.Status.Nodes = nil
+		_, err := FormulateRetryArtifactGC(wf)
+		assert.EqualError(t, err, "workflow artifact-gc has no artifacts left to garbage collect")
+	})
+}
@@ -0,0 +1,61 @@
+package util
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strconv"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+const (
+	// WorkflowTemplateVersionStable is the version of a workflow that runs the workflow template it references
+	WorkflowTemplateVersionStable = "stable"
+	// WorkflowTemplateVersionCanary is the version of a workflow that runs the canary of the workflow template it references
+	WorkflowTemplateVersionCanary = "canary"
+)
+
+// GetCanary returns the canary of the workflow template from the workflow templates labelled as its canary, or nil if
+// it has none. A workflow template can only have one canary.
+func GetCanary(name string, canaries []*v1alpha1.WorkflowTemplate) (*v1alpha1.WorkflowTemplate, error) {
+	switch len(canaries) {
+	case 0:
+		return nil, nil
+	case 1:
+		return canaries[0], nil
+	}
+	names := make([]string, len(canaries))
+	for i, c := range canaries {
+		names[i] = c.Name
+	}
+	sort.Strings(names)
+	return nil, fmt.Errorf("workflow template %q has more than one canary: %v", name, names)
+}
+
+// GetCanaryWeight returns the percentage of the workflows that the canary runs
+func GetCanaryWeight(canary *v1alpha1.WorkflowTemplate) (int, error) {
+	value, ok := canary.Annotations[common.AnnotationKeyCanaryWeight]
+	if !ok {
+		return 0, fmt.Errorf("canary workflow template %q does not have the %s annotation", canary.Name, common.AnnotationKeyCanaryWeight)
+	}
+	weight, err := strconv.Atoi(value)
+	if err != nil || weight < 0 || weight > 100 {
+		return 0, fmt.Errorf("canary workflow template %q has an invalid weight %q, it must be a percentage from 0 to 100", canary.Name, value)
+	}
+	return weight, nil
+}
+
+// SelectWorkflowTemplateVersion returns the version of the workflow template that the workflow runs, so that weight
+// percent of workflows run the canary. The same workflow always gets the same version.
+func SelectWorkflowTemplateVersion(uid types.UID, weight int) string {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(uid))
+	if int(h.Sum32()%100) < weight {
+		return WorkflowTemplateVersionCanary
+	}
+	return WorkflowTemplateVersionStable
+}
@@ -0,0 +1,78 @@
+package util
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+func canaryTemplate(name, weight string) *v1alpha1.WorkflowTemplate {
+	return &v1alpha1.WorkflowTemplate{ObjectMeta: metav1.ObjectMeta{
+		Name:        name,
+		Labels:      map[string]string{common.LabelKeyCanaryOf: "my-wftmpl"},
+		Annotations: map[string]string{common.AnnotationKeyCanaryWeight: weight},
+	}}
+}
+
+func TestGetCanary(t *testing.T) {
+	t.Run("None", func(t *testing.T) {
+		canary, err := GetCanary("my-wftmpl", nil)
+		assert.NoError(t, err)
+		assert.Nil(t, canary)
+	})
+	t.Run("One", func(t *testing.T) {
+		canary, err := GetCanary("my-wftmpl", []*v1alpha1.WorkflowTemplate{canaryTemplate("my-canary", "10")})
+		assert.NoError(t, err)
+		assert.Equal(t, "my-canary", canary.Name)
+	})
+	t.Run("MoreThanOne", func(t *testing.T) {
+		_, err := GetCanary("my-wftmpl", []*v1alpha1.WorkflowTemplate{canaryTemplate("b", "10"), canaryTemplate("a", "10")})
+		assert.EqualError(t, err, `workflow template "my-wftmpl" has more than one canary: [a b]`)
+	})
+}
+
+func TestGetCanaryWeight(t *testing.T) {
+	for _, weight := range []string{"0", "25", "100"} {
+		t.Run(weight, func(t *testing.T) {
+			w, err := GetCanaryWeight(canaryTemplate("my-canary", weight))
+			assert.NoError(t, err)
+			assert.Equal(t, weight, fmt.Sprint(w))
+		})
+	}
+	for _, weight := range []string{"-1", "101", "ten"} {
+		t.Run(weight, func(t *testing.T) {
+			_, err := GetCanaryWeight(canaryTemplate("my-canary", weight))
+			assert.Error(t, err)
+		})
+	}
+	t.Run("Missing", func(t *testing.T) {
+		_, err := GetCanaryWeight(&v1alpha1.WorkflowTemplate{ObjectMeta: metav1.ObjectMeta{Name: "my-canary"}})
+		assert.Error(t, err)
+	})
+}
+
+func TestSelectWorkflowTemplateVersion(t *testing.T) {
+	count := func(weight int) int {
+		n := 0
+		for i := 0; i < 1000; i++ {
+			if SelectWorkflowTemplateVersion(types.UID(fmt.Sprintf("uid-%d", i)), weight) == WorkflowTemplateVersionCanary {
+				n++
+			}
+		}
+		return n
+	}
+	assert.Equal(t, 0, count(0))
+	assert.Equal(t, 1000, count(100))
+	assert.InDelta(t, 250, count(25), 50)
+	t.Run("Deterministic", func(t *testing.T) {
+		for i := 0; i < 10; i++ {
+			assert.Equal(t, SelectWorkflowTemplateVersion("my-uid", 50), SelectWorkflowTemplateVersion("my-uid", 50))
+		}
+	})
+}
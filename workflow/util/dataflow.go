@@ -0,0 +1,130 @@
+package util
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+// outputRefRegex matches references to the outputs of a sibling task or step, e.g. `tasks.gen.outputs.parameters.x`,
+// `steps.gen.outputs.result` or `tasks.gen.exitCode`
+var outputRefRegex = regexp.MustCompile(`(tasks|steps)\.([A-Za-z0-9_-]+)\.(outputs\.(result|parameters\.([A-Za-z0-9_-]+)|artifacts\.([A-Za-z0-9_-]+))|exitCode)`)
+
+// GetWorkflowDataflow derives the parameters and artifacts that flow between the nodes of a workflow from the
+// arguments of the tasks of its DAG nodes and the steps of its steps nodes. Only nodes that have been created are
+// included, so a running workflow's dataflow grows as it progresses.
+func GetWorkflowDataflow(wf *wfv1.Workflow) *wfv1.WorkflowDataflow {
+	edges := map[wfv1.DataflowEdge]bool{}
+	for _, node := range wf.Status.Nodes {
+		if node.Type != wfv1.NodeTypeDAG && node.Type != wfv1.NodeTypeSteps {
+			continue
+		}
+		tmpl := nodeTemplate(wf, node)
+		if tmpl == nil {
+			continue
+		}
+		switch {
+		case tmpl.DAG != nil:
+			for _, task := range tmpl.DAG.Tasks {
+				addArgumentEdges(wf, edges, node.Name+"."+task.Name, task.Arguments, func(name string) string {
+					return node.Name + "." + name
+				})
+			}
+		case tmpl.Steps != nil:
+			groups := map[string]int{}
+			for i, group := range tmpl.Steps {
+				for _, step := range group.Steps {
+					groups[step.Name] = i
+				}
+			}
+			stepNodeName := func(name string) string {
+				i, ok := groups[name]
+				if !ok {
+					return ""
+				}
+				return stepsGroupNodeName(node.Name, i) + "." + name
+			}
+			for i, group := range tmpl.Steps {
+				for _, step := range group.Steps {
+					addArgumentEdges(wf, edges, stepsGroupNodeName(node.Name, i)+"."+step.Name, step.Arguments, stepNodeName)
+				}
+			}
+		}
+	}
+	dataflow := &wfv1.WorkflowDataflow{}
+	for edge := range edges {
+		dataflow.Edges = append(dataflow.Edges, edge)
+	}
+	sort.Slice(dataflow.Edges, func(i, j int) bool {
+		a, b := dataflow.Edges[i], dataflow.Edges[j]
+		if a.From != b.From {
+			return a.From < b.From
+		}
+		if a.To != b.To {
+			return a.To < b.To
+		}
+		if a.Input != b.Input {
+			return a.Input < b.Input
+		}
+		return a.Output < b.Output
+	})
+	return dataflow
+}
+
+func stepsGroupNodeName(stepsNodeName string, i int) string {
+	return fmt.Sprintf("%s[%d]", stepsNodeName, i)
+}
+
+// nodeTemplate returns the template a DAG or steps node was created from
+func nodeTemplate(wf *wfv1.Workflow, node wfv1.NodeStatus) *wfv1.Template {
+	scope, resourceName := node.GetTemplateScope()
+	if tmpl := wf.GetStoredTemplate(scope, resourceName, &node); tmpl != nil {
+		return tmpl
+	}
+	return wf.GetTemplateByName(node.TemplateName)
+}
+
+// addArgumentEdges adds an edge for every reference to a sibling's outputs in the arguments of the task or step
+// with the node name to. siblingNodeName maps the name of a sibling task or step to its node name.
+func addArgumentEdges(wf *wfv1.Workflow, edges map[wfv1.DataflowEdge]bool, to string, args wfv1.Arguments, siblingNodeName func(name string) string) {
+	toNode, err := wf.GetNodeByName(to)
+	if err != nil {
+		return
+	}
+	add := func(input, value string) {
+		for _, m := range outputRefRegex.FindAllStringSubmatch(value, -1) {
+			name := siblingNodeName(m[2])
+			if name == "" {
+				continue
+			}
+			fromNode, err := wf.GetNodeByName(name)
+			if err != nil {
+				continue
+			}
+			edge := wfv1.DataflowEdge{From: fromNode.ID, To: toNode.ID, Type: wfv1.DataflowParameter, Input: input}
+			switch {
+			case m[3] == "exitCode":
+				edge.Output = "exitCode"
+			case m[4] == "result":
+				edge.Output = "result"
+			case m[5] != "":
+				edge.Output = m[5]
+			default:
+				edge.Type = wfv1.DataflowArtifact
+				edge.Output = m[6]
+			}
+			edges[edge] = true
+		}
+	}
+	for _, p := range args.Parameters {
+		if p.Value != nil {
+			add(p.Name, p.Value.String())
+		}
+	}
+	for _, a := range args.Artifacts {
+		add(a.Name, a.From)
+		add(a.Name, a.FromExpression)
+	}
+}
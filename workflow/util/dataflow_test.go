@@ -0,0 +1,101 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+var dataflowWf = `
+metadata:
+  name: dataflow
+spec:
+  entrypoint: main
+  templates:
+    - name: main
+      dag:
+        tasks:
+          - name: gen
+            template: gen
+          - name: consume
+            template: consume
+            dependencies: [gen]
+            arguments:
+              parameters:
+                - name: x
+                  value: "{{tasks.gen.outputs.parameters.x}}-{{tasks.gen.outputs.result}}"
+              artifacts:
+                - name: data
+                  from: "{{tasks.gen.outputs.artifacts.data}}"
+          - name: loop
+            template: loop
+            dependencies: [gen]
+            arguments:
+              parameters:
+                - name: code
+                  value: "{{tasks.gen.exitCode}}"
+    - name: loop
+      inputs:
+        parameters:
+          - name: code
+      steps:
+        - - name: a
+            template: gen
+        - - name: b
+            template: consume
+            arguments:
+              parameters:
+                - name: x
+                  value: "{{steps.a.outputs.parameters.x}}"
+              artifacts:
+                - name: data
+                  from: "{{steps.missing.outputs.artifacts.data}}"
+    - name: gen
+      container:
+        image: argoproj/argosay:v2
+    - name: consume
+      inputs:
+        parameters:
+          - name: x
+        artifacts:
+          - name: data
+      container:
+        image: argoproj/argosay:v2
+`
+
+func TestGetWorkflowDataflow(t *testing.T) {
+	wf := wfv1.MustUnmarshalWorkflow(dataflowWf)
+	wf.Status.Nodes = wfv1.Nodes{}
+	addNode := func(name string, nodeType wfv1.NodeType, templateName string) string {
+		id := wf.NodeID(name)
+		wf.Status.Nodes[id] = wfv1.NodeStatus{ID: id, Name: name, Type: nodeType, TemplateName: templateName}
+		return id
+	}
+	addNode("dataflow", wfv1.NodeTypeDAG, "main")
+	gen := addNode("dataflow.gen", wfv1.NodeTypePod, "gen")
+	consume := addNode("dataflow.consume", wfv1.NodeTypePod, "consume")
+	loop := addNode("dataflow.loop", wfv1.NodeTypeSteps, "loop")
+	addNode("dataflow.loop[0]", wfv1.NodeTypeStepGroup, "")
+	a := addNode("dataflow.loop[0].a", wfv1.NodeTypePod, "gen")
+	addNode("dataflow.loop[1]", wfv1.NodeTypeStepGroup, "")
+	b := addNode("dataflow.loop[1].b", wfv1.NodeTypePod, "consume")
+
+	dataflow := GetWorkflowDataflow(wf)
+
+	expected := []wfv1.DataflowEdge{
+		{From: gen, To: consume, Type: wfv1.DataflowArtifact, Output: "data", Input: "data"},
+		{From: gen, To: consume, Type: wfv1.DataflowParameter, Output: "result", Input: "x"},
+		{From: gen, To: consume, Type: wfv1.DataflowParameter, Output: "x", Input: "x"},
+		{From: gen, To: loop, Type: wfv1.DataflowParameter, Output: "exitCode", Input: "code"},
+		{From: a, To: b, Type: wfv1.DataflowParameter, Output: "x", Input: "x"},
+	}
+	assert.ElementsMatch(t, expected, dataflow.Edges)
+
+	t.Run("NotStarted", func(t *testing.T) {
+		delete(wf.Status.Nodes, consume)
+		dataflow := GetWorkflowDataflow(wf)
+		assert.Len(t, dataflow.Edges, 2)
+	})
+}
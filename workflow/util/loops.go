@@ -0,0 +1,163 @@
+package util
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Knetic/govaluate"
+
+	"github.com/argoproj/argo-workflows/v3/errors"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	commonutil "github.com/argoproj/argo-workflows/v3/util"
+)
+
+// GetItemReplaceMap returns the name of the node of the item of a loop, and the item variables to replace in the step
+// or task of the node
+func GetItemReplaceMap(name string, index int, item wfv1.Item) (string, map[string]string, error) {
+	replaceMap := make(map[string]string)
+	var newName string
+
+	switch item.GetType() {
+	case wfv1.String, wfv1.Number, wfv1.Bool:
+		replaceMap["item"] = fmt.Sprintf("%v", item)
+		newName = generateNodeName(name, index, item)
+	case wfv1.Map:
+		// Handle the case when withItems is a list of maps.
+		// vals holds stringified versions of the map items which are incorporated as part of the step name.
+		// For example if the item is: {"name": "jesse","group":"developer"}
+		// the vals would be: ["name:jesse", "group:developer"]
+		// This would eventually be part of the step name (group:developer,name:jesse)
+		vals := make([]string, 0)
+		mapVal := item.GetMapVal()
+		for itemKey, itemVal := range mapVal {
+			replaceMap[fmt.Sprintf("item.%s", itemKey)] = fmt.Sprintf("%v", itemVal)
+			vals = append(vals, fmt.Sprintf("%s:%v", itemKey, itemVal))
+
+		}
+		jsonByteVal, err := json.Marshal(mapVal)
+		if err != nil {
+			return "", nil, errors.InternalWrapError(err)
+		}
+		replaceMap["item"] = string(jsonByteVal)
+
+		// sort the values so that the name is deterministic
+		sort.Strings(vals)
+		newName = generateNodeName(name, index, strings.Join(vals, ","))
+	case wfv1.List:
+		listVal := item.GetListVal()
+		byteVal, err := json.Marshal(listVal)
+		if err != nil {
+			return "", nil, errors.InternalWrapError(err)
+		}
+		replaceMap["item"] = string(byteVal)
+		newName = generateNodeName(name, index, listVal)
+	default:
+		return "", nil, errors.Errorf(errors.CodeBadRequest, "withItems[%d] expected string, number, list, or map. received: %v", index, item)
+	}
+	return newName, replaceMap, nil
+}
+
+func generateNodeName(name string, index int, desc interface{}) string {
+	// Do not display parentheses in node name. Nodes are still guaranteed to be unique due to the index number
+	replacer := strings.NewReplacer("(", "", ")", "")
+	cleanName := replacer.Replace(fmt.Sprint(desc))
+	newName := fmt.Sprintf("%s(%d:%v)", name, index, cleanName)
+	if out := commonutil.RecoverIndexFromNodeName(newName); out != index {
+		panic(fmt.Sprintf("unrecoverable digit in generateName; wanted '%d' and got '%d'", index, out))
+	}
+	return newName
+}
+
+// ExpandSequence returns the items of a withSequence loop
+func ExpandSequence(seq *wfv1.Sequence) ([]wfv1.Item, error) {
+	var start, end int
+	var err error
+	if seq.Start != nil {
+		start, err = strconv.Atoi(seq.Start.String())
+		if err != nil {
+			return nil, err
+		}
+	}
+	if seq.End != nil {
+		end, err = strconv.Atoi(seq.End.String())
+		if err != nil {
+			return nil, err
+		}
+	} else if seq.Count != nil {
+		count, err := strconv.Atoi(seq.Count.String())
+		if err != nil {
+			return nil, err
+		}
+		if count == 0 {
+			return []wfv1.Item{}, nil
+		}
+		end = start + count - 1
+	} else {
+		return nil, errors.InternalError("neither end nor count was specified in withSequence")
+	}
+	items := make([]wfv1.Item, 0)
+	format := "%d"
+	if seq.Format != "" {
+		format = seq.Format
+	}
+	if start <= end {
+		for i := start; i <= end; i++ {
+			item, err := wfv1.ParseItem(`"` + fmt.Sprintf(format, i) + `"`)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+	} else {
+		for i := start; i >= end; i-- {
+			item, err := wfv1.ParseItem(`"` + fmt.Sprintf(format, i) + `"`)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+	}
+	return items, nil
+}
+
+// ShouldExecute evaluates a when expression that has had its variables substituted
+func ShouldExecute(when string) (bool, error) {
+	if when == "" {
+		return true, nil
+	}
+	expression, err := govaluate.NewEvaluableExpression(when)
+	if err != nil {
+		if strings.Contains(err.Error(), "Invalid token") {
+			return false, errors.Errorf(errors.CodeBadRequest, "Invalid 'when' expression '%s': %v (hint: try wrapping the affected expression in quotes (\"))", when, err)
+		}
+		return false, errors.Errorf(errors.CodeBadRequest, "Invalid 'when' expression '%s': %v", when, err)
+	}
+	// The following loop converts govaluate variables (which we don't use), into strings. This
+	// allows us to have expressions like: "foo != bar" without requiring foo and bar to be quoted.
+	tokens := expression.Tokens()
+	for i, tok := range tokens {
+		switch tok.Kind {
+		case govaluate.VARIABLE:
+			tok.Kind = govaluate.STRING
+		default:
+			continue
+		}
+		tokens[i] = tok
+	}
+	expression, err = govaluate.NewEvaluableExpressionFromTokens(tokens)
+	if err != nil {
+		return false, errors.InternalWrapErrorf(err, "Failed to parse 'when' expression '%s': %v", when, err)
+	}
+	result, err := expression.Evaluate(nil)
+	if err != nil {
+		return false, errors.InternalWrapErrorf(err, "Failed to evaluate 'when' expresion '%s': %v", when, err)
+	}
+	boolRes, ok := result.(bool)
+	if !ok {
+		return false, errors.Errorf(errors.CodeBadRequest, "Expected boolean evaluation for '%s'. Got %v", when, result)
+	}
+	return boolRes, nil
+}
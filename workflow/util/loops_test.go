@@ -0,0 +1,165 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	intstrutil "github.com/argoproj/argo-workflows/v3/util/intstr"
+)
+
+func TestGenerateNodeName(t *testing.T) {
+	assert.Equal(t, "sleep(10:ten)", generateNodeName("sleep", 10, "ten"))
+	item, err := wfv1.ParseItem(`[{"foo": "bar"}]`)
+	assert.NoError(t, err)
+	assert.Equal(t, `sleep(10:[{"foo":"bar"}])`, generateNodeName("sleep", 10, item))
+	assert.NoError(t, err)
+	item, err = wfv1.ParseItem("[10]")
+	assert.NoError(t, err)
+	assert.Equal(t, `sleep(10:[10])`, generateNodeName("sleep", 10, item))
+}
+
+// This tests that we don't wait a backoff if it would exceed the maxDuration anyway.
+func TestExpandWithSequence(t *testing.T) {
+	var seq wfv1.Sequence
+	var items []wfv1.Item
+	var err error
+
+	seq = wfv1.Sequence{
+		Count: intstrutil.ParsePtr("10"),
+	}
+	items, err = ExpandSequence(&seq)
+	assert.NoError(t, err)
+	assert.Equal(t, 10, len(items))
+	assert.Equal(t, "0", items[0].GetStrVal())
+	assert.Equal(t, "9", items[9].GetStrVal())
+
+	seq = wfv1.Sequence{
+		Start: intstrutil.ParsePtr("101"),
+		Count: intstrutil.ParsePtr("10"),
+	}
+	items, err = ExpandSequence(&seq)
+	assert.NoError(t, err)
+	assert.Equal(t, 10, len(items))
+	assert.Equal(t, "101", items[0].GetStrVal())
+	assert.Equal(t, "110", items[9].GetStrVal())
+
+	seq = wfv1.Sequence{
+		Start: intstrutil.ParsePtr("50"),
+		End:   intstrutil.ParsePtr("60"),
+	}
+	items, err = ExpandSequence(&seq)
+	assert.NoError(t, err)
+	assert.Equal(t, 11, len(items))
+	assert.Equal(t, "50", items[0].GetStrVal())
+	assert.Equal(t, "60", items[10].GetStrVal())
+
+	seq = wfv1.Sequence{
+		Start: intstrutil.ParsePtr("60"),
+		End:   intstrutil.ParsePtr("50"),
+	}
+	items, err = ExpandSequence(&seq)
+	assert.NoError(t, err)
+	assert.Equal(t, 11, len(items))
+	assert.Equal(t, "60", items[0].GetStrVal())
+	assert.Equal(t, "50", items[10].GetStrVal())
+
+	seq = wfv1.Sequence{
+		Count: intstrutil.ParsePtr("0"),
+	}
+	items, err = ExpandSequence(&seq)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(items))
+
+	seq = wfv1.Sequence{
+		Start: intstrutil.ParsePtr("8"),
+		End:   intstrutil.ParsePtr("8"),
+	}
+	items, err = ExpandSequence(&seq)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(items))
+	assert.Equal(t, "8", items[0].GetStrVal())
+
+	seq = wfv1.Sequence{
+		Format: "testuser%02X",
+		Count:  intstrutil.ParsePtr("10"),
+		Start:  intstrutil.ParsePtr("1"),
+	}
+	items, err = ExpandSequence(&seq)
+	assert.NoError(t, err)
+	assert.Equal(t, 10, len(items))
+	assert.Equal(t, "testuser01", items[0].GetStrVal())
+	assert.Equal(t, "testuser0A", items[9].GetStrVal())
+}
+
+var metadataTemplate = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  name: metadata-template
+  labels:
+    image: foo:bar
+  annotations:
+    k8s-webhook-handler.io/repo: "git@github.com:argoproj/argo.git"
+    k8s-webhook-handler.io/revision: 1e111caa1d2cc672b3b53c202b96a5f660a7e9b2
+spec:
+  entrypoint: foo
+  templates:
+    - name: foo
+      container:
+        image: "{{workflow.labels.image}}"
+        env:
+          - name: REPO
+            value: "{{workflow.annotations.k8s-webhook-handler.io/repo}}"
+          - name: REVISION
+            value: "{{workflow.annotations.k8s-webhook-handler.io/revision}}"
+        command: [sh, -c]
+        args: ["echo hello world"]
+`
+
+func TestShouldExecute(t *testing.T) {
+	trueExpressions := []string{
+		"foo == foo",
+		"'ref/branch/master' == 'ref/branch/master'",
+		"foo != bar",
+		"1 == 1",
+		"1 != 2",
+		"1 < 2",
+		"1 <= 1",
+		"1/2 == 0.5",
+		"a < b",
+		"(foo == bar) || (foo == foo)",
+		"(1 > 0) && (1 < 2)",
+		"Error in (Failed, Error)",
+		"!(Succeeded in (Failed, Error))",
+		"true == true",
+	}
+	for _, trueExp := range trueExpressions {
+		res, err := ShouldExecute(trueExp)
+		assert.NoError(t, err)
+		assert.True(t, res)
+	}
+
+	falseExpressions := []string{
+		"foo != foo",
+		"'ref/branch/master' != 'ref/branch/master'",
+		"foo == bar",
+		"1 != 1",
+		"1 == 2",
+		"1 > 2",
+		"1 <= 0",
+		"1/2 != 0.5",
+		"a > b",
+		"(foo == bar) || (bar == foo)",
+		"(1 > 0) && (11 < 2)",
+		"Succeeded in (Failed, Error)",
+		"!(Error in (Failed, Error))",
+		"false == true",
+	}
+	for _, falseExp := range falseExpressions {
+		res, err := ShouldExecute(falseExp)
+		assert.NoError(t, err)
+		assert.False(t, res)
+	}
+}
@@ -0,0 +1,144 @@
+package util
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+// GetWorkflowOutputs resolves the outputs the workflow declares from the outputs of its nodes. Outputs of nodes that
+// have not completed yet have no value, and a message saying so.
+func GetWorkflowOutputs(wf *wfv1.Workflow) *wfv1.WorkflowOutputs {
+	outputs := &wfv1.WorkflowOutputs{}
+	for _, o := range wf.GetExecSpec().Outputs {
+		value := wfv1.WorkflowOutputValue{Name: o.Name, Description: o.Description, Type: o.GetType()}
+		if err := resolveWorkflowOutput(wf, o, &value); err != nil {
+			value.Message = err.Error()
+		}
+		outputs.Outputs = append(outputs.Outputs, value)
+	}
+	return outputs
+}
+
+// GetWorkflowOutputValue returns the value of the output the workflow declares with the name, or else of its global
+// output parameter with the name
+func GetWorkflowOutputValue(wf *wfv1.Workflow, name string) (string, error) {
+	for _, o := range wf.GetExecSpec().Outputs {
+		if o.Name != name {
+			continue
+		}
+		value := wfv1.WorkflowOutputValue{Name: o.Name, Type: o.GetType()}
+		if err := resolveWorkflowOutput(wf, o, &value); err != nil {
+			return "", err
+		}
+		if value.Artifact != nil {
+			return "", fmt.Errorf("output %q is an artifact", name)
+		}
+		return value.Value, nil
+	}
+	if wf.Status.Outputs != nil {
+		if p := wf.Status.Outputs.GetParameterByName(name); p != nil && p.Value != nil {
+			return p.Value.String(), nil
+		}
+	}
+	return "", fmt.Errorf("workflow %s has no output %q", wf.Name, name)
+}
+
+// GetLatestFinishedWorkflow returns the workflow that finished last, or nil if none of them have finished
+func GetLatestFinishedWorkflow(wfs []wfv1.Workflow) *wfv1.Workflow {
+	var latest *wfv1.Workflow
+	for i, wf := range wfs {
+		if wf.Status.FinishedAt.IsZero() {
+			continue
+		}
+		if latest == nil || wf.Status.FinishedAt.After(latest.Status.FinishedAt.Time) {
+			latest = &wfs[i]
+		}
+	}
+	return latest
+}
+
+func resolveWorkflowOutput(wf *wfv1.Workflow, o wfv1.WorkflowOutput, value *wfv1.WorkflowOutputValue) error {
+	nodeKind, nodeName, outputKind, outputName, err := o.ParseFrom()
+	if err != nil {
+		return err
+	}
+	node, err := workflowOutputNode(wf, nodeKind, nodeName)
+	if err != nil {
+		return err
+	}
+	if !node.Fulfilled() {
+		return fmt.Errorf("%s has not completed", node.DisplayName)
+	}
+	if node.Outputs == nil {
+		return fmt.Errorf("%s has no outputs", node.DisplayName)
+	}
+	switch outputKind {
+	case "result":
+		if node.Outputs.Result == nil {
+			return fmt.Errorf("%s has no result", node.DisplayName)
+		}
+		value.Value = *node.Outputs.Result
+	case "parameters":
+		p := node.Outputs.GetParameterByName(outputName)
+		if p == nil || p.Value == nil {
+			return fmt.Errorf("%s has no output parameter %q", node.DisplayName, outputName)
+		}
+		value.Value = p.Value.String()
+	default:
+		a := node.Outputs.GetArtifactByName(outputName)
+		if a == nil {
+			return fmt.Errorf("%s has no output artifact %q", node.DisplayName, outputName)
+		}
+		value.Artifact = a
+		return nil
+	}
+	return checkWorkflowOutputType(value.Type, value.Value)
+}
+
+// workflowOutputNode returns the node of the step or task of the entrypoint template, or the entrypoint node itself
+func workflowOutputNode(wf *wfv1.Workflow, kind, name string) (*wfv1.NodeStatus, error) {
+	entrypoint, err := wf.GetNodeByName(wf.Name)
+	if err != nil {
+		return nil, fmt.Errorf("workflow has not started")
+	}
+	switch kind {
+	case "tasks":
+		return wf.GetNodeByName(entrypoint.Name + "." + name)
+	case "steps":
+		tmpl := nodeTemplate(wf, *entrypoint)
+		if tmpl != nil {
+			for i, group := range tmpl.Steps {
+				for _, step := range group.Steps {
+					if step.Name == name {
+						return wf.GetNodeByName(stepsGroupNodeName(entrypoint.Name, i) + "." + name)
+					}
+				}
+			}
+		}
+		return nil, fmt.Errorf("step %q not found", name)
+	default:
+		return entrypoint, nil
+	}
+}
+
+// checkWorkflowOutputType returns an error if the value is not of the type
+func checkWorkflowOutputType(t wfv1.WorkflowOutputType, value string) error {
+	switch t {
+	case wfv1.WorkflowOutputTypeNumber:
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return fmt.Errorf("value %q is not a number", value)
+		}
+	case wfv1.WorkflowOutputTypeBoolean:
+		if _, err := strconv.ParseBool(value); err != nil {
+			return fmt.Errorf("value %q is not a boolean", value)
+		}
+	case wfv1.WorkflowOutputTypeJSON:
+		if !json.Valid([]byte(value)) {
+			return fmt.Errorf("value is not valid JSON")
+		}
+	}
+	return nil
+}
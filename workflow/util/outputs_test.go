@@ -0,0 +1,111 @@
+package util
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+var outputsWf = `
+metadata:
+  name: outputs
+spec:
+  entrypoint: main
+  outputs:
+    - name: accuracy
+      description: Accuracy of the model on the test set
+      type: number
+      from: steps.train.outputs.parameters.accuracy
+    - name: passed
+      type: boolean
+      from: steps.train.outputs.result
+    - name: model
+      type: artifact
+      from: steps.train.outputs.artifacts.model
+    - name: report
+      from: steps.report.outputs.result
+    - name: summary
+      from: outputs.parameters.summary
+  templates:
+    - name: main
+      steps:
+        - - name: train
+            template: train
+        - - name: report
+            template: train
+    - name: train
+      container:
+        image: argoproj/argosay:v2
+`
+
+func TestGetWorkflowOutputs(t *testing.T) {
+	wf := wfv1.MustUnmarshalWorkflow(outputsWf)
+	wf.Status.Nodes = wfv1.Nodes{}
+	addNode := func(name string, nodeType wfv1.NodeType, phase wfv1.NodePhase, outputs *wfv1.Outputs) {
+		id := wf.NodeID(name)
+		wf.Status.Nodes[id] = wfv1.NodeStatus{ID: id, Name: name, DisplayName: name, Type: nodeType, TemplateName: "main", Phase: phase, Outputs: outputs}
+	}
+	result := "yes"
+	addNode("outputs", wfv1.NodeTypeSteps, wfv1.NodeRunning, nil)
+	addNode("outputs[0].train", wfv1.NodeTypePod, wfv1.NodeSucceeded, &wfv1.Outputs{
+		Parameters: []wfv1.Parameter{{Name: "accuracy", Value: wfv1.AnyStringPtr("0.93")}},
+		Artifacts:  wfv1.Artifacts{{Name: "model", ArtifactLocation: wfv1.ArtifactLocation{S3: &wfv1.S3Artifact{Key: "model.tgz"}}}},
+		Result:     &result,
+	})
+	addNode("outputs[1].report", wfv1.NodeTypePod, wfv1.NodeRunning, nil)
+
+	outputs := GetWorkflowOutputs(wf)
+	if assert.Len(t, outputs.Outputs, 5) {
+		assert.Equal(t, wfv1.WorkflowOutputValue{Name: "accuracy", Description: "Accuracy of the model on the test set", Type: wfv1.WorkflowOutputTypeNumber, Value: "0.93"}, outputs.Outputs[0])
+		assert.Equal(t, `value "yes" is not a boolean`, outputs.Outputs[1].Message)
+		if assert.NotNil(t, outputs.Outputs[2].Artifact) {
+			assert.Equal(t, "model.tgz", outputs.Outputs[2].Artifact.S3.Key)
+		}
+		assert.Equal(t, wfv1.WorkflowOutputTypeString, outputs.Outputs[3].Type)
+		assert.Equal(t, "outputs[1].report has not completed", outputs.Outputs[3].Message)
+		assert.Equal(t, "outputs has not completed", outputs.Outputs[4].Message)
+	}
+}
+
+func TestGetWorkflowOutputValue(t *testing.T) {
+	wf := wfv1.MustUnmarshalWorkflow(outputsWf)
+	wf.Status.Nodes = wfv1.Nodes{}
+	id := wf.NodeID("outputs")
+	wf.Status.Nodes[id] = wfv1.NodeStatus{ID: id, Name: "outputs", DisplayName: "outputs", Type: wfv1.NodeTypeSteps, TemplateName: "main", Phase: wfv1.NodeSucceeded}
+	id = wf.NodeID("outputs[0].train")
+	wf.Status.Nodes[id] = wfv1.NodeStatus{ID: id, Name: "outputs[0].train", DisplayName: "train", Type: wfv1.NodeTypePod, TemplateName: "train", Phase: wfv1.NodeSucceeded, Outputs: &wfv1.Outputs{
+		Parameters: []wfv1.Parameter{{Name: "accuracy", Value: wfv1.AnyStringPtr("0.93")}},
+		Artifacts:  wfv1.Artifacts{{Name: "model", ArtifactLocation: wfv1.ArtifactLocation{S3: &wfv1.S3Artifact{Key: "model.tgz"}}}},
+	}}
+	wf.Status.Outputs = &wfv1.Outputs{Parameters: []wfv1.Parameter{{Name: "image-digest", Value: wfv1.AnyStringPtr("sha256:abc")}}}
+
+	value, err := GetWorkflowOutputValue(wf, "accuracy")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "0.93", value)
+	}
+	value, err = GetWorkflowOutputValue(wf, "image-digest")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "sha256:abc", value)
+	}
+	_, err = GetWorkflowOutputValue(wf, "model")
+	assert.EqualError(t, err, `output "model" is an artifact`)
+	_, err = GetWorkflowOutputValue(wf, "missing")
+	assert.EqualError(t, err, `workflow outputs has no output "missing"`)
+}
+
+func TestGetLatestFinishedWorkflow(t *testing.T) {
+	now := time.Now()
+	wfs := []wfv1.Workflow{
+		{ObjectMeta: metav1.ObjectMeta{Name: "older"}, Status: wfv1.WorkflowStatus{FinishedAt: metav1.NewTime(now.Add(-time.Hour))}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "latest"}, Status: wfv1.WorkflowStatus{FinishedAt: metav1.NewTime(now)}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "running"}},
+	}
+	if latest := GetLatestFinishedWorkflow(wfs); assert.NotNil(t, latest) {
+		assert.Equal(t, "latest", latest.Name)
+	}
+	assert.Nil(t, GetLatestFinishedWorkflow(wfs[2:]))
+}
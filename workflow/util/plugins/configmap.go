@@ -38,6 +38,9 @@ func ToConfigMap(p *spec.Plugin) (*apiv1.ConfigMap, error) {
 			"sidecar.container":                    string(data),
 		},
 	}
+	if p.Spec.Version != "" {
+		cm.Data["version"] = p.Spec.Version
+	}
 	for k, v := range p.Annotations {
 		cm.Annotations[k] = v
 	}
@@ -65,6 +68,7 @@ func FromConfigMap(cm *apiv1.ConfigMap) (*spec.Plugin, error) {
 		p.Labels[k] = v
 	}
 	delete(p.Labels, common.LabelKeyConfigMapType)
+	p.Spec.Version = cm.Data["version"]
 	p.Spec.Sidecar.AutomountServiceAccountToken = cm.Data["sidecar.automountServiceAccountToken"] == "true"
 	if err := yaml.UnmarshalStrict([]byte(cm.Data["sidecar.container"]), &p.Spec.Sidecar.Container); err != nil {
 		return nil, err
@@ -32,6 +32,7 @@ func TestToConfigMap(t *testing.T) {
 				},
 			},
 			Spec: spec.PluginSpec{
+				Version: "1.2.0",
 				Sidecar: spec.Sidecar{
 					AutomountServiceAccountToken: true,
 					Container: apiv1.Container{
@@ -55,6 +56,7 @@ func TestToConfigMap(t *testing.T) {
 			assert.Equal(t, map[string]string{
 				"sidecar.automountServiceAccountToken": "true",
 				"sidecar.container":                    "name: \"\"\nports:\n- containerPort: 1234\nresources: {}\nsecurityContext: {}\n",
+				"version":                              "1.2.0",
 			}, cm.Data)
 		}
 	})
@@ -80,6 +82,7 @@ func TestFromConfigMap(t *testing.T) {
 			Data: map[string]string{
 				"sidecar.automountServiceAccountToken": "true",
 				"sidecar.container":                    "{'name': 'my-name', 'ports': [{}], 'resources': {'requests': {}, 'limits': {}}, 'securityContext': {}}",
+				"version":                              "1.2.0",
 			},
 		})
 		if assert.NoError(t, err) {
@@ -87,6 +90,7 @@ func TestFromConfigMap(t *testing.T) {
 			assert.Equal(t, "my-plug", p.Name)
 			assert.Len(t, p.Annotations, 1)
 			assert.Len(t, p.Labels, 1)
+			assert.Equal(t, "1.2.0", p.Spec.Version)
 			assert.True(t, p.Spec.Sidecar.AutomountServiceAccountToken)
 			assert.Equal(t, apiv1.Container{
 				Name:  "my-name",
@@ -0,0 +1,77 @@
+package util
+
+import (
+	"context"
+	"encoding/json"
+	"syscall"
+	"time"
+
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/argoproj/argo-workflows/v3/errors"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/pkg/client/clientset/versioned/typed/workflow/v1alpha1"
+	errorsutil "github.com/argoproj/argo-workflows/v3/util/errors"
+	"github.com/argoproj/argo-workflows/v3/util/retry"
+	waitutil "github.com/argoproj/argo-workflows/v3/util/wait"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+	"github.com/argoproj/argo-workflows/v3/workflow/hydrator"
+	"github.com/argoproj/argo-workflows/v3/workflow/signal"
+)
+
+// SignalNode requests that the signal is sent to the main containers of the running pod node with the ID. The request
+// is recorded on the workflow, the controller passes it on to the pod, and the executor delivers it, so that requesting
+// a signal needs no more permission than updating the workflow. Requests for nodes which are no longer running are
+// dropped.
+func SignalNode(ctx context.Context, wfIf v1alpha1.WorkflowInterface, hydrator hydrator.Interface, workflowName, nodeID string, s syscall.Signal) (*wfv1.Workflow, error) {
+	var updated *wfv1.Workflow
+	err := waitutil.Backoff(retry.DefaultRetry, func() (bool, error) {
+		wf, err := wfIf.Get(ctx, workflowName, metav1.GetOptions{})
+		if err != nil {
+			return !errorsutil.IsTransientErr(err), err
+		}
+		err = hydrator.Hydrate(wf)
+		if err != nil {
+			return false, err
+		}
+		requests, err := signal.Requests(wf.Annotations)
+		if err != nil {
+			return true, err
+		}
+		for id := range requests {
+			if node, err := wf.Status.Nodes.Get(id); err != nil || node.Phase != wfv1.NodeRunning {
+				delete(requests, id)
+			}
+		}
+		node, err := wf.Status.Nodes.Get(nodeID)
+		if err != nil || node.Type != wfv1.NodeTypePod || node.Phase != wfv1.NodeRunning {
+			return true, errors.Errorf(errors.CodeBadRequest, "node %q is not a running pod", nodeID)
+		}
+		requests[nodeID] = signal.NewRequest(s, time.Now())
+		value, err := json.Marshal(requests)
+		if err != nil {
+			return true, err
+		}
+		if wf.Annotations == nil {
+			wf.Annotations = map[string]string{}
+		}
+		wf.Annotations[common.AnnotationKeySignals] = string(value)
+		err = hydrator.Dehydrate(wf)
+		if err != nil {
+			return true, err
+		}
+		updated, err = wfIf.Update(ctx, wf, metav1.UpdateOptions{})
+		if err != nil {
+			if apierr.IsConflict(err) {
+				return false, nil
+			}
+			return true, err
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
@@ -0,0 +1,73 @@
+package util
+
+import (
+	"context"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/argoproj/argo-workflows/v3/errors"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	argofake "github.com/argoproj/argo-workflows/v3/pkg/client/clientset/versioned/fake"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+	hydratorfake "github.com/argoproj/argo-workflows/v3/workflow/hydrator/fake"
+	"github.com/argoproj/argo-workflows/v3/workflow/signal"
+)
+
+func TestSignalNode(t *testing.T) {
+	wfIf := argofake.NewSimpleClientset().ArgoprojV1alpha1().Workflows("")
+	wf := &wfv1.Workflow{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-wf"},
+		Status: wfv1.WorkflowStatus{Nodes: wfv1.Nodes{
+			"my-wf":   {ID: "my-wf", Type: wfv1.NodeTypeSteps, Phase: wfv1.NodeRunning},
+			"running": {ID: "running", Type: wfv1.NodeTypePod, Phase: wfv1.NodeRunning},
+			"done":    {ID: "done", Type: wfv1.NodeTypePod, Phase: wfv1.NodeSucceeded},
+		}},
+	}
+	ctx := context.Background()
+	_, err := wfIf.Create(ctx, wf, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	t.Run("NotAPod", func(t *testing.T) {
+		_, err := SignalNode(ctx, wfIf, hydratorfake.Noop, "my-wf", "my-wf", syscall.Signal(10))
+		assert.True(t, errors.IsCode(errors.CodeBadRequest, err))
+	})
+	t.Run("NotRunning", func(t *testing.T) {
+		_, err := SignalNode(ctx, wfIf, hydratorfake.Noop, "my-wf", "done", syscall.Signal(10))
+		assert.True(t, errors.IsCode(errors.CodeBadRequest, err))
+	})
+	t.Run("Running", func(t *testing.T) {
+		updated, err := SignalNode(ctx, wfIf, hydratorfake.Noop, "my-wf", "running", syscall.Signal(10))
+		require.NoError(t, err)
+		requests, err := signal.Requests(updated.Annotations)
+		require.NoError(t, err)
+		require.Contains(t, requests, "running")
+		first := requests["running"]
+		s, err := signal.ParseRequest(first)
+		require.NoError(t, err)
+		assert.Equal(t, syscall.Signal(10), s)
+
+		updated, err = SignalNode(ctx, wfIf, hydratorfake.Noop, "my-wf", "running", syscall.Signal(10))
+		require.NoError(t, err)
+		requests, err = signal.Requests(updated.Annotations)
+		require.NoError(t, err)
+		// the same signal is requested again
+		assert.NotEqual(t, first, requests["running"])
+	})
+	t.Run("DropsFinished", func(t *testing.T) {
+		wf, err := wfIf.Get(ctx, "my-wf", metav1.GetOptions{})
+		require.NoError(t, err)
+		wf.Annotations[common.AnnotationKeySignals] = `{"done":"1:10"}`
+		_, err = wfIf.Update(ctx, wf, metav1.UpdateOptions{})
+		require.NoError(t, err)
+		updated, err := SignalNode(ctx, wfIf, hydratorfake.Noop, "my-wf", "running", syscall.Signal(12))
+		require.NoError(t, err)
+		requests, err := signal.Requests(updated.Annotations)
+		require.NoError(t, err)
+		assert.Len(t, requests, 1)
+		assert.Contains(t, requests, "running")
+	})
+}
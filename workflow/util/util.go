@@ -376,16 +376,105 @@ func SuspendWorkflow(ctx context.Context, wfIf v1alpha1.WorkflowInterface, workf
 	return err
 }
 
-// ResumeWorkflow resumes a workflow by setting spec.suspend to nil and any suspended nodes to Successful.
+// PauseWorkflowBranches pauses the DAG branches that start at the nodes matching the selector, so that none of their
+// tasks that have not started yet are started until they are resumed. Tasks that are running are not affected.
 // Retries conflict errors
-func ResumeWorkflow(ctx context.Context, wfIf v1alpha1.WorkflowInterface, hydrator hydrator.Interface, workflowName string, nodeFieldSelector string) error {
+func PauseWorkflowBranches(ctx context.Context, wfIf v1alpha1.WorkflowInterface, hydrator hydrator.Interface, workflowName string, nodeFieldSelector string) error {
+	_, err := setBranchesPaused(ctx, wfIf, hydrator, workflowName, nodeFieldSelector, true)
+	return err
+}
+
+// setBranchesPaused pauses or resumes the DAG branches that start at the nodes matching the selector, and returns
+// whether any branch was changed
+func setBranchesPaused(ctx context.Context, wfIf v1alpha1.WorkflowInterface, hydrator hydrator.Interface, workflowName string, nodeFieldSelector string, paused bool) (bool, error) {
+	selector, err := fields.ParseSelector(nodeFieldSelector)
+	if err != nil {
+		return false, err
+	}
+	updated := false
+	err = waitutil.Backoff(retry.DefaultRetry, func() (bool, error) {
+		updated = false
+		wf, err := wfIf.Get(ctx, workflowName, metav1.GetOptions{})
+		if err != nil {
+			return !errorsutil.IsTransientErr(err), err
+		}
+		if paused && IsWorkflowCompleted(wf) {
+			return true, errPausedCompletedWorkflow
+		}
+
+		err = hydrator.Hydrate(wf)
+		if err != nil {
+			return true, err
+		}
+
+		matched := false
+		for nodeID, node := range wf.Status.Nodes {
+			if !isDAGNode(wf, node) || !SelectorMatchesNode(selector, node) {
+				continue
+			}
+			matched = true
+			if node.Paused != paused {
+				node.Paused = paused
+				wf.Status.Nodes.Set(nodeID, node)
+				updated = true
+			}
+		}
+		if paused && !matched {
+			return true, errors.Errorf(errors.CodeNotFound, "no DAG or DAG task nodes matching nodeFieldSelector: %s", nodeFieldSelector)
+		}
+		if !updated {
+			return true, nil
+		}
+
+		err = hydrator.Dehydrate(wf)
+		if err != nil {
+			return true, fmt.Errorf("unable to compress or offload workflow nodes: %s", err)
+		}
+
+		_, err = wfIf.Update(ctx, wf, metav1.UpdateOptions{})
+		if apierr.IsConflict(err) {
+			return false, nil
+		}
+		return true, err
+	})
+	return updated, err
+}
+
+// isDAGNode returns whether the node is a DAG or a task of a DAG, and so can be the start of a paused branch
+func isDAGNode(wf *wfv1.Workflow, node wfv1.NodeStatus) bool {
+	if node.Type == wfv1.NodeTypeDAG {
+		return true
+	}
+	boundaryNode, err := wf.Status.Nodes.Get(node.BoundaryID)
+	return err == nil && boundaryNode.Type == wfv1.NodeTypeDAG
+}
+
+// ResumeWorkflow resumes a workflow by setting spec.suspend to nil, any suspended nodes to Successful, and resuming any
+// paused branches. With a node field selector, it resumes the paused branches that match the selector or, if there are
+// none, the suspended nodes that match it. Suspended nodes that need approvals are approved by the user of the request,
+// and only resumed once they have enough approvals, as configured by approvals. Without a node field selector, the
+// suspended nodes the user cannot approve are left suspended, and the rest of the workflow is resumed.
+// Retries conflict errors
+func ResumeWorkflow(ctx context.Context, wfIf v1alpha1.WorkflowInterface, hydrator hydrator.Interface, workflowName string, nodeFieldSelector string, othersPhase wfv1.NodePhase, approvals ApprovalOpts) error {
 	uiMsg := ""
 	uim := creator.UserInfoMap(ctx)
 	if uim != nil {
 		uiMsg = fmt.Sprintf("Resumed by: %v", uim)
 	}
+	switch othersPhase {
+	case "", wfv1.NodeFailed, wfv1.NodeSkipped:
+	default:
+		return fmt.Errorf("the other suspended nodes can only be Failed or Skipped, not %s", othersPhase)
+	}
+	if othersPhase != "" && nodeFieldSelector == "" {
+		return fmt.Errorf("a node field selector is needed to resume only some of the suspended nodes")
+	}
 	if len(nodeFieldSelector) > 0 {
-		return updateSuspendedNode(ctx, wfIf, hydrator, workflowName, nodeFieldSelector, SetOperationValues{Phase: wfv1.NodeSucceeded, Message: uiMsg})
+		resumed, err := setBranchesPaused(ctx, wfIf, hydrator, workflowName, nodeFieldSelector, false)
+		if err != nil || resumed {
+			return err
+		}
+		return updateSuspendedNode(ctx, wfIf, hydrator, workflowName, nodeFieldSelector, SetOperationValues{Phase: wfv1.NodeSucceeded, Message: uiMsg, OthersPhase: othersPhase, Approvals: approvals})
 	} else {
 		err := waitutil.Backoff(retry.DefaultRetry, func() (bool, error) {
 			wf, err := wfIf.Get(ctx, workflowName, metav1.GetOptions{})
@@ -399,14 +488,34 @@ func ResumeWorkflow(ctx context.Context, wfIf v1alpha1.WorkflowInterface, hydrat
 			}
 
 			workflowUpdated := false
+			// the first approval that was refused, which is only an error if nothing else is resumed
+			var refused error
 			if wf.Spec.Suspend != nil && *wf.Spec.Suspend {
 				wf.Spec.Suspend = nil
 				workflowUpdated = true
 			}
 
-			// To resume a workflow with a suspended node we simply mark the node as Successful
+			// To resume a workflow with a suspended node we simply mark the node as Successful.
+			// Manual tasks are only completed when selected explicitly.
 			for nodeID, node := range wf.Status.Nodes {
-				if node.IsActiveSuspendNode() {
+				if node.Paused {
+					node.Paused = false
+					wf.Status.Nodes.Set(nodeID, node)
+					workflowUpdated = true
+				}
+				if node.IsActiveSuspendNode() && node.ManualTask == nil {
+					approved, err := approveNode(ctx, wf, &node, approvals)
+					if err != nil {
+						if refused == nil {
+							refused = err
+						}
+						continue
+					}
+					if !approved {
+						wf.Status.Nodes.Set(nodeID, node)
+						workflowUpdated = true
+						continue
+					}
 					if node.Outputs != nil {
 						for i, param := range node.Outputs.Parameters {
 							if param.ValueFrom != nil && param.ValueFrom.Supplied != nil {
@@ -418,6 +527,11 @@ func ResumeWorkflow(ctx context.Context, wfIf v1alpha1.WorkflowInterface, hydrat
 								}
 							}
 						}
+						for _, art := range node.Outputs.Artifacts {
+							if !art.HasLocation() && !art.Optional {
+								return true, fmt.Errorf("output artifact '%s' has not been set and is not optional", art.Name)
+							}
+						}
 					}
 					node.Phase = wfv1.NodeSucceeded
 					if node.Message != "" {
@@ -429,6 +543,9 @@ func ResumeWorkflow(ctx context.Context, wfIf v1alpha1.WorkflowInterface, hydrat
 					workflowUpdated = true
 				}
 			}
+			if !workflowUpdated && refused != nil {
+				return true, refused
+			}
 
 			if workflowUpdated {
 				err := hydrator.Dehydrate(wf)
@@ -475,6 +592,13 @@ type SetOperationValues struct {
 	Phase            wfv1.NodePhase
 	Message          string
 	OutputParameters map[string]string
+	// OutputArtifacts are the keys of output artifacts in the workflow's artifact repository, by name
+	OutputArtifacts map[string]string
+	// OthersPhase is the phase to set the active suspend nodes that were not selected to, if they are children of the
+	// same node as one that was, e.g. the rest of a fan-out of which only some children are resumed
+	OthersPhase wfv1.NodePhase
+	// Approvals configures how the suspend nodes that need approvals are approved when they are resumed
+	Approvals ApprovalOpts
 }
 
 func AddParamToGlobalScope(wf *wfv1.Workflow, log *log.Entry, param wfv1.Parameter) bool {
@@ -527,10 +651,26 @@ func updateSuspendedNode(ctx context.Context, wfIf v1alpha1.WorkflowInterface, h
 		}
 
 		nodeUpdated := false
+		selected := make(map[string]bool)
 		for nodeID, node := range wf.Status.Nodes {
 			if node.IsActiveSuspendNode() {
 				if SelectorMatchesNode(selector, node) {
 
+					// A node that needs approvals is only resumed by the last of them
+					if values.Phase == wfv1.NodeSucceeded {
+						approved, err := approveNode(ctx, wf, &node, values.Approvals)
+						if err != nil {
+							return true, err
+						}
+						if !approved {
+							wf.Status.Nodes.Set(nodeID, node)
+							nodeUpdated = true
+							continue
+						}
+					}
+
+					selected[nodeID] = true
+
 					// Update phase
 					if values.Phase != "" {
 						node.Phase = values.Phase
@@ -571,6 +711,29 @@ func updateSuspendedNode(ctx context.Context, wfIf v1alpha1.WorkflowInterface, h
 							}
 						}
 					}
+
+					// Update output artifacts
+					if len(values.OutputArtifacts) > 0 {
+						if node.Outputs == nil {
+							return true, fmt.Errorf("cannot set output artifacts because node is not expecting any")
+						}
+						for name, key := range values.OutputArtifacts {
+							hit := false
+							for i, art := range node.Outputs.Artifacts {
+								if art.Name == name {
+									if err := setOutputArtifactKey(wf, &node.Outputs.Artifacts[i], key); err != nil {
+										return true, err
+									}
+									nodeUpdated = true
+									hit = true
+									break
+								}
+							}
+							if !hit {
+								return true, fmt.Errorf("node is not expecting output artifact '%s'", name)
+							}
+						}
+					}
 					wf.Status.Nodes.Set(nodeID, node)
 				}
 			}
@@ -580,6 +743,16 @@ func updateSuspendedNode(ctx context.Context, wfIf v1alpha1.WorkflowInterface, h
 			return true, fmt.Errorf("currently, set only targets suspend nodes: no suspend nodes matching nodeFieldSelector: %s", nodeFieldSelector)
 		}
 
+		if values.OthersPhase != "" {
+			for _, nodeID := range unselectedSiblingSuspendNodes(wf, selected) {
+				node := wf.Status.Nodes[nodeID]
+				node.Phase = values.OthersPhase
+				node.Message = "not selected when the other suspended nodes were resumed"
+				node.FinishedAt = metav1.Time{Time: time.Now().UTC()}
+				wf.Status.Nodes.Set(nodeID, node)
+			}
+		}
+
 		err = hydrator.Dehydrate(wf)
 		if err != nil {
 			return true, fmt.Errorf("unable to compress or offload workflow nodes: %s", err)
@@ -599,6 +772,53 @@ func updateSuspendedNode(ctx context.Context, wfIf v1alpha1.WorkflowInterface, h
 	return err
 }
 
+// unselectedSiblingSuspendNodes returns the IDs of the active suspend nodes that are not selected, but are children of
+// the same node as a selected node, e.g. the other children of a step group or task group that fans out
+func unselectedSiblingSuspendNodes(wf *wfv1.Workflow, selected map[string]bool) []string {
+	var siblings []string
+	for _, parent := range wf.Status.Nodes {
+		hasSelected := false
+		for _, childID := range parent.Children {
+			if selected[childID] {
+				hasSelected = true
+				break
+			}
+		}
+		if !hasSelected {
+			continue
+		}
+		for _, childID := range parent.Children {
+			if child, ok := wf.Status.Nodes[childID]; ok && !selected[childID] && child.IsActiveSuspendNode() {
+				siblings = append(siblings, childID)
+			}
+		}
+	}
+	return siblings
+}
+
+// setOutputArtifactKey sets the location of a supplied output artifact of a suspended node to the key in the workflow's
+// artifact repository
+func setOutputArtifactKey(wf *wfv1.Workflow, art *wfv1.Artifact, key string) error {
+	if art.HasLocation() {
+		return fmt.Errorf("cannot set output artifact '%s' because it was already set", art.Name)
+	}
+	if key == "" {
+		return fmt.Errorf("cannot set output artifact '%s' to an empty key", art.Name)
+	}
+	ref := wf.Status.ArtifactRepositoryRef
+	if ref == nil || ref.ArtifactRepository == nil {
+		return fmt.Errorf("cannot set output artifact '%s' because the workflow has no artifact repository", art.Name)
+	}
+	l := ref.ArtifactRepository.ToArtifactLocation()
+	l.ArchiveLogs = nil
+	l.CompressLogs = nil
+	if err := l.SetKey(key); err != nil {
+		return fmt.Errorf("cannot set output artifact '%s': %w", art.Name, err)
+	}
+	art.ArtifactLocation = *l
+	return nil
+}
+
 const letters = "abcdefghijklmnopqrstuvwxyz0123456789"
 
 func init() {
@@ -621,6 +841,12 @@ func RandSuffix() string {
 
 // FormulateResubmitWorkflow formulate a new workflow from a previous workflow, optionally re-using successful nodes
 func FormulateResubmitWorkflow(ctx context.Context, wf *wfv1.Workflow, memoized bool, parameters []string) (*wfv1.Workflow, error) {
+	return FormulateResubmitWorkflowWithNameSuffix(ctx, wf, memoized, parameters, "")
+}
+
+// FormulateResubmitWorkflowWithNameSuffix formulates the new workflow like FormulateResubmitWorkflow, but if the suffix
+// is not empty, the new workflow is named with it rather than a generated one, so that creating it twice fails
+func FormulateResubmitWorkflowWithNameSuffix(ctx context.Context, wf *wfv1.Workflow, memoized bool, parameters []string, nameSuffix string) (*wfv1.Workflow, error) {
 	newWF := wfv1.Workflow{}
 	newWF.TypeMeta = wf.TypeMeta
 
@@ -641,6 +867,9 @@ func FormulateResubmitWorkflow(ctx context.Context, wf *wfv1.Workflow, memoized
 		}
 		newWF.ObjectMeta.Name = newWF.ObjectMeta.GenerateName + RandSuffix()
 	}
+	if nameSuffix != "" {
+		newWF.ObjectMeta.Name = newWF.ObjectMeta.GenerateName + nameSuffix
+	}
 
 	// carry over the unmodified spec
 	newWF.Spec = wf.Spec
@@ -659,7 +888,8 @@ func FormulateResubmitWorkflow(ctx context.Context, wf *wfv1.Workflow, memoized
 	for key, val := range wf.ObjectMeta.Labels {
 		switch key {
 		case common.LabelKeyCreator, common.LabelKeyCreatorEmail, common.LabelKeyCreatorPreferredUsername,
-			common.LabelKeyPhase, common.LabelKeyCompleted, common.LabelKeyWorkflowArchivingStatus:
+			common.LabelKeyPhase, common.LabelKeyCompleted, common.LabelKeyWorkflowArchivingStatus, common.LabelKeyWorkflowExportStatus, common.LabelKeyPolicyReason,
+			common.LabelKeyIdempotencyKey:
 			// ignore
 		default:
 			newWF.ObjectMeta.Labels[key] = val
@@ -675,7 +905,13 @@ func FormulateResubmitWorkflow(ctx context.Context, wf *wfv1.Workflow, memoized
 		newWF.ObjectMeta.Annotations = make(map[string]string)
 	}
 	for key, val := range wf.ObjectMeta.Annotations {
-		newWF.ObjectMeta.Annotations[key] = val
+		switch key {
+		case common.AnnotationKeyRetryIdempotencyKey, common.AnnotationKeyRetriedAt, common.AnnotationKeyIdempotencyRequestHash,
+			common.AnnotationKeyCreatorSubject, common.AnnotationKeyCreatorSignature:
+			// ignore
+		default:
+			newWF.ObjectMeta.Annotations[key] = val
+		}
 	}
 
 	// Setting OwnerReference from original Workflow
@@ -748,7 +984,7 @@ func FormulateResubmitWorkflow(ctx context.Context, wf *wfv1.Workflow, memoized
 		newWF.Status.StoredTemplates[id] = tmpl
 	}
 
-	newWF.Status.Conditions = wfv1.Conditions{{Status: metav1.ConditionFalse, Type: wfv1.ConditionTypeCompleted}}
+	newWF.Status.Conditions.UpsertCondition(wfv1.Condition{Status: metav1.ConditionFalse, Type: wfv1.ConditionTypeCompleted, Reason: wfv1.ConditionReasonResubmitted})
 	newWF.Status.Phase = wfv1.WorkflowUnknown
 
 	return &newWF, nil
@@ -847,13 +1083,17 @@ func FormulateRetryWorkflow(ctx context.Context, wf *wfv1.Workflow, restartSucce
 	// Delete/reset fields which indicate workflow completed
 	delete(newWF.Labels, common.LabelKeyCompleted)
 	delete(newWF.Labels, common.LabelKeyWorkflowArchivingStatus)
-	newWF.Status.Conditions.UpsertCondition(wfv1.Condition{Status: metav1.ConditionFalse, Type: wfv1.ConditionTypeCompleted})
+	delete(newWF.Labels, common.LabelKeyPolicyReason)
+	newWF.Status.Conditions.UpsertCondition(wfv1.Condition{Status: metav1.ConditionFalse, Type: wfv1.ConditionTypeCompleted, Reason: wfv1.ConditionReasonRetried})
 	newWF.ObjectMeta.Labels[common.LabelKeyPhase] = string(wfv1.NodeRunning)
 	newWF.Status.Phase = wfv1.WorkflowRunning
 	newWF.Status.Nodes = make(wfv1.Nodes)
 	newWF.Status.Message = ""
 	newWF.Status.StartedAt = metav1.Time{Time: time.Now().UTC()}
 	newWF.Status.FinishedAt = metav1.Time{}
+	newWF.Status.ExitHooksDeadline = nil
+	newWF.Status.Conditions.RemoveCondition(wfv1.ConditionTypeExitHooksNotRun)
+	newWF.Status.Conditions.RemoveCondition(wfv1.ConditionTypeStoppedByPolicy)
 	if newWF.Status.StoredWorkflowSpec != nil {
 		newWF.Status.StoredWorkflowSpec.Shutdown = ""
 	}
@@ -1083,6 +1323,8 @@ func getNodeIDsToReset(restartSuccessful bool, nodeFieldSelector string, nodes w
 
 var errSuspendedCompletedWorkflow = errors.Errorf(errors.CodeBadRequest, "cannot suspend completed workflows")
 
+var errPausedCompletedWorkflow = errors.Errorf(errors.CodeBadRequest, "cannot pause branches of completed workflows")
+
 // IsWorkflowSuspended returns whether or not a workflow is considered suspended
 func IsWorkflowSuspended(wf *wfv1.Workflow) bool {
 	if wf.Spec.Suspend != nil && *wf.Spec.Suspend {
@@ -11,10 +11,12 @@ import (
 
 	"github.com/go-jose/go-jose/v3/jwt"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/utils/pointer"
 	"sigs.k8s.io/yaml"
 
 	"github.com/argoproj/argo-workflows/v3/server/auth"
@@ -278,7 +280,7 @@ func TestResumeWorkflowByNodeName(t *testing.T) {
 		assert.NoError(t, err)
 
 		// will return error as displayName does not match any nodes
-		err = ResumeWorkflow(ctx, wfIf, hydratorfake.Noop, "suspend", "displayName=nonexistant")
+		err = ResumeWorkflow(ctx, wfIf, hydratorfake.Noop, "suspend", "displayName=nonexistant", "", ApprovalOpts{})
 		assert.Error(t, err)
 
 		// displayName didn't match suspend node so should still be running
@@ -286,7 +288,7 @@ func TestResumeWorkflowByNodeName(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, wfv1.NodeRunning, wf.Status.Nodes.FindByDisplayName("approve").Phase)
 
-		err = ResumeWorkflow(ctx, wfIf, hydratorfake.Noop, "suspend", "displayName=approve")
+		err = ResumeWorkflow(ctx, wfIf, hydratorfake.Noop, "suspend", "displayName=approve", "", ApprovalOpts{})
 		assert.NoError(t, err)
 
 		// displayName matched node so has succeeded
@@ -309,7 +311,7 @@ func TestResumeWorkflowByNodeName(t *testing.T) {
 		assert.NoError(t, err)
 
 		// will return error as displayName does not match any nodes
-		err = ResumeWorkflow(ctx, wfIf, hydratorfake.Noop, "suspend", "displayName=nonexistant")
+		err = ResumeWorkflow(ctx, wfIf, hydratorfake.Noop, "suspend", "displayName=nonexistant", "", ApprovalOpts{})
 		assert.Error(t, err)
 
 		// displayName didn't match suspend node so should still be running
@@ -317,7 +319,7 @@ func TestResumeWorkflowByNodeName(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, wfv1.NodeRunning, wf.Status.Nodes.FindByDisplayName("approve").Phase)
 
-		err = ResumeWorkflow(ctx, wfIf, hydratorfake.Noop, "suspend", "displayName=approve")
+		err = ResumeWorkflow(ctx, wfIf, hydratorfake.Noop, "suspend", "displayName=approve", "", ApprovalOpts{})
 		assert.NoError(t, err)
 
 		// displayName matched node so has succeeded
@@ -329,6 +331,124 @@ func TestResumeWorkflowByNodeName(t *testing.T) {
 	})
 }
 
+// approvalsWf returns a suspended workflow with a node that needs approvals, whose creator is signed with the key, if
+// there is a subject
+func approvalsWf(t *testing.T, subject string, key []byte, required int32) *wfv1.Workflow {
+	t.Helper()
+	wf := wfv1.MustUnmarshalWorkflow(suspendedWf)
+	wf.UID = "my-uid"
+	if subject != "" {
+		wf.Annotations = map[string]string{common.AnnotationKeyCreatorSubject: subject}
+		wf.Annotations[common.AnnotationKeyCreatorSignature] = creator.Signature(wf, key)
+	}
+	node := wf.Status.Nodes["suspend-template-xjsg2-1771269240"]
+	node.Approval = &wfv1.ApprovalStatus{Required: required}
+	wf.Status.Nodes["suspend-template-xjsg2-1771269240"] = node
+	return wf
+}
+
+func TestResumeWorkflowWithApprovals(t *testing.T) {
+	wfIf := argofake.NewSimpleClientset().ArgoprojV1alpha1().Workflows("")
+	opts := ApprovalOpts{CreatorKey: []byte("my-key")}
+	_, err := wfIf.Create(context.Background(), approvalsWf(t, "submitter", opts.CreatorKey, 2), metav1.CreateOptions{})
+	assert.NoError(t, err)
+	userCtx := func(subject string) context.Context {
+		return context.WithValue(context.Background(), auth.ClaimsKey, &types.Claims{Claims: jwt.Claims{Subject: subject}})
+	}
+	getNode := func() *wfv1.NodeStatus {
+		wf, err := wfIf.Get(context.Background(), "suspend", metav1.GetOptions{})
+		assert.NoError(t, err)
+		return wf.Status.Nodes.FindByDisplayName("approve")
+	}
+
+	err = ResumeWorkflow(context.Background(), wfIf, hydratorfake.Noop, "suspend", "displayName=approve", "", opts)
+	assert.ErrorContains(t, err, "node approve needs approvals, which can only be given by users authenticated by the Argo Server")
+
+	err = ResumeWorkflow(userCtx("submitter"), wfIf, hydratorfake.Noop, "suspend", "displayName=approve", "", opts)
+	assert.EqualError(t, err, "submitter submitted the workflow and cannot approve node approve")
+
+	err = ResumeWorkflow(userCtx("alice"), wfIf, hydratorfake.Noop, "suspend", "displayName=approve", "", opts)
+	if assert.NoError(t, err) {
+		n := getNode()
+		assert.Equal(t, wfv1.NodeRunning, n.Phase)
+		assert.Equal(t, "1 of 2 approvals", n.Message)
+		if assert.Len(t, n.Approval.Approvals, 1) {
+			assert.Equal(t, "alice", n.Approval.Approvals[0].Approver)
+		}
+	}
+
+	err = ResumeWorkflow(userCtx("alice"), wfIf, hydratorfake.Noop, "suspend", "", "", opts)
+	assert.EqualError(t, err, "alice has already approved node approve")
+
+	err = ResumeWorkflow(userCtx("bob"), wfIf, hydratorfake.Noop, "suspend", "", "", opts)
+	if assert.NoError(t, err) {
+		n := getNode()
+		assert.Equal(t, wfv1.NodeSucceeded, n.Phase)
+		if assert.Len(t, n.Approval.Approvals, 2) {
+			assert.Equal(t, "bob", n.Approval.Approvals[1].Approver)
+		}
+	}
+}
+
+func TestResumeWorkflowWithApprovalsOfUnknownCreator(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.ClaimsKey, &types.Claims{Claims: jwt.Claims{Subject: "alice"}})
+	opts := ApprovalOpts{CreatorKey: []byte("my-key")}
+	for name, wf := range map[string]*wfv1.Workflow{
+		"Unsigned": approvalsWf(t, "", nil, 1),
+		"Forged":   approvalsWf(t, "bob", []byte("other-key"), 1),
+	} {
+		t.Run(name, func(t *testing.T) {
+			wfIf := argofake.NewSimpleClientset().ArgoprojV1alpha1().Workflows("")
+			_, err := wfIf.Create(context.Background(), wf, metav1.CreateOptions{})
+			require.NoError(t, err)
+
+			err = ResumeWorkflow(ctx, wfIf, hydratorfake.Noop, "suspend", "displayName=approve", "", opts)
+			assert.EqualError(t, err, "the creator of the workflow is not known, so node approve cannot be approved")
+
+			opts.AllowUnknownCreator = true
+			err = ResumeWorkflow(ctx, wfIf, hydratorfake.Noop, "suspend", "displayName=approve", "", opts)
+			if assert.NoError(t, err) {
+				wf, err := wfIf.Get(context.Background(), "suspend", metav1.GetOptions{})
+				require.NoError(t, err)
+				assert.Equal(t, wfv1.NodeSucceeded, wf.Status.Nodes.FindByDisplayName("approve").Phase)
+			}
+			opts.AllowUnknownCreator = false
+		})
+	}
+}
+
+func TestResumeWorkflowSkipsRefusedApprovals(t *testing.T) {
+	wfIf := argofake.NewSimpleClientset().ArgoprojV1alpha1().Workflows("")
+	opts := ApprovalOpts{CreatorKey: []byte("my-key")}
+	wf := approvalsWf(t, "alice", opts.CreatorKey, 1)
+	wf.Spec.Suspend = pointer.BoolPtr(true)
+	_, err := wfIf.Create(context.Background(), wf, metav1.CreateOptions{})
+	require.NoError(t, err)
+	ctx := context.WithValue(context.Background(), auth.ClaimsKey, &types.Claims{Claims: jwt.Claims{Subject: "alice"}})
+
+	err = ResumeWorkflow(ctx, wfIf, hydratorfake.Noop, "suspend", "", "", opts)
+	if assert.NoError(t, err) {
+		wf, err := wfIf.Get(context.Background(), "suspend", metav1.GetOptions{})
+		require.NoError(t, err)
+		assert.Nil(t, wf.Spec.Suspend)
+		n := wf.Status.Nodes.FindByDisplayName("approve")
+		assert.Equal(t, wfv1.NodeRunning, n.Phase)
+		assert.Empty(t, n.Approval.Approvals)
+	}
+}
+
+func TestResumeWorkflowWithUnsetOutputArtifact(t *testing.T) {
+	wfIf := argofake.NewSimpleClientset().ArgoprojV1alpha1().Workflows("")
+	origWf := wfv1.MustUnmarshalWorkflow(suspendedWf)
+	node := origWf.Status.Nodes["suspend-template-xjsg2-1771269240"]
+	node.Outputs = &wfv1.Outputs{Artifacts: wfv1.Artifacts{{Name: "report"}}}
+	origWf.Status.Nodes["suspend-template-xjsg2-1771269240"] = node
+	_, err := wfIf.Create(context.Background(), origWf, metav1.CreateOptions{})
+	assert.NoError(t, err)
+	err = ResumeWorkflow(context.Background(), wfIf, hydratorfake.Noop, "suspend", "", "", ApprovalOpts{})
+	assert.EqualError(t, err, "output artifact 'report' has not been set and is not optional")
+}
+
 func TestStopWorkflowByNodeName(t *testing.T) {
 	wfIf := argofake.NewSimpleClientset().ArgoprojV1alpha1().Workflows("")
 	origWf := wfv1.MustUnmarshalWorkflow(suspendedWf)
@@ -524,6 +644,41 @@ func TestUpdateSuspendedNode(t *testing.T) {
 	}
 }
 
+func TestUpdateSuspendedNodeOutputArtifacts(t *testing.T) {
+	wfIf := argofake.NewSimpleClientset().ArgoprojV1alpha1().Workflows("")
+	ctx := context.Background()
+	create := func(name string, repo *wfv1.ArtifactRepository) {
+		wf := wfv1.MustUnmarshalWorkflow(susWorkflow)
+		wf.Name = name
+		node := wf.Status.Nodes["suspend-template-kgfn7-2667278707"]
+		node.Outputs = &wfv1.Outputs{Artifacts: wfv1.Artifacts{{Name: "report"}}}
+		wf.Status.Nodes["suspend-template-kgfn7-2667278707"] = node
+		wf.Status.ArtifactRepositoryRef = &wfv1.ArtifactRepositoryRefStatus{ArtifactRepository: repo}
+		_, err := wfIf.Create(ctx, wf, metav1.CreateOptions{})
+		assert.NoError(t, err)
+	}
+	create("suspend-template", &wfv1.ArtifactRepository{S3: &wfv1.S3ArtifactRepository{S3Bucket: wfv1.S3Bucket{Endpoint: "my-endpoint", Bucket: "my-bucket"}}})
+	create("suspend-template-no-repository", nil)
+
+	err := updateSuspendedNode(ctx, wfIf, hydratorfake.Noop, "suspend-template", "displayName=approve", SetOperationValues{OutputArtifacts: map[string]string{"does-not-exist": "my-key"}})
+	assert.EqualError(t, err, "node is not expecting output artifact 'does-not-exist'")
+	err = updateSuspendedNode(ctx, wfIf, hydratorfake.Noop, "suspend-template-no-repository", "displayName=approve", SetOperationValues{OutputArtifacts: map[string]string{"report": "my-key"}})
+	assert.EqualError(t, err, "cannot set output artifact 'report' because the workflow has no artifact repository")
+	err = updateSuspendedNode(ctx, wfIf, hydratorfake.Noop, "suspend-template", "displayName=approve", SetOperationValues{OutputArtifacts: map[string]string{"report": "reviews/report.pdf"}})
+	if assert.NoError(t, err) {
+		wf, err := wfIf.Get(ctx, "suspend-template", metav1.GetOptions{})
+		if assert.NoError(t, err) {
+			art := wf.Status.Nodes["suspend-template-kgfn7-2667278707"].Outputs.GetArtifactByName("report")
+			if assert.NotNil(t, art) && assert.NotNil(t, art.S3) {
+				assert.Equal(t, "my-bucket", art.S3.Bucket)
+				assert.Equal(t, "reviews/report.pdf", art.S3.Key)
+			}
+		}
+	}
+	err = updateSuspendedNode(ctx, wfIf, hydratorfake.Noop, "suspend-template", "displayName=approve", SetOperationValues{OutputArtifacts: map[string]string{"report": "other.pdf"}})
+	assert.EqualError(t, err, "cannot set output artifact 'report' because it was already set")
+}
+
 func TestSelectorMatchesNode(t *testing.T) {
 	tests := map[string]struct {
 		selector string
@@ -705,6 +860,7 @@ func TestFormulateResubmitWorkflow(t *testing.T) {
 					common.LabelKeyCreatorEmail:             "foo.at.example.com",
 					common.LabelKeyCreatorPreferredUsername: "foo",
 				},
+				Annotations: map[string]string{common.AnnotationKeyCreatorSubject: "xxxx-xxxx-xxxx", common.AnnotationKeyCreatorSignature: "xxxx"},
 				OwnerReferences: []metav1.OwnerReference{
 					{
 						APIVersion: "test",
@@ -723,6 +879,8 @@ func TestFormulateResubmitWorkflow(t *testing.T) {
 			assert.Equal(t, "yyyy-yyyy-yyyy-yyyy", wf.Labels[common.LabelKeyCreator])
 			assert.Equal(t, "bar.at.example.com", wf.Labels[common.LabelKeyCreatorEmail])
 			assert.Equal(t, "bar", wf.Labels[common.LabelKeyCreatorPreferredUsername])
+			assert.Equal(t, "yyyy-yyyy-yyyy-yyyy", wf.Annotations[common.AnnotationKeyCreatorSubject])
+			assert.NotContains(t, wf.Annotations, common.AnnotationKeyCreatorSignature, "the signature of the resubmitted workflow is not valid for the new one")
 		}
 	})
 	t.Run("UnlabelCreatorLabels", func(t *testing.T) {
@@ -733,6 +891,7 @@ func TestFormulateResubmitWorkflow(t *testing.T) {
 					common.LabelKeyCreatorEmail:             "foo.at.example.com",
 					common.LabelKeyCreatorPreferredUsername: "foo",
 				},
+				Annotations: map[string]string{common.AnnotationKeyCreatorSubject: "xxxx-xxxx-xxxx", common.AnnotationKeyCreatorSignature: "xxxx"},
 				OwnerReferences: []metav1.OwnerReference{
 					{
 						APIVersion: "test",
@@ -746,6 +905,7 @@ func TestFormulateResubmitWorkflow(t *testing.T) {
 			assert.Emptyf(t, wf.Labels[common.LabelKeyCreator], "should not %s label when a workflow is resubmitted by an unauthenticated request", common.LabelKeyCreator)
 			assert.Emptyf(t, wf.Labels[common.LabelKeyCreatorEmail], "should not %s label when a workflow is resubmitted by an unauthenticated request", common.LabelKeyCreatorEmail)
 			assert.Emptyf(t, wf.Labels[common.LabelKeyCreatorPreferredUsername], "should not %s label when a workflow is resubmitted by an unauthenticated request", common.LabelKeyCreatorPreferredUsername)
+			assert.Emptyf(t, wf.Annotations[common.AnnotationKeyCreatorSubject], "should not %s annotation when a workflow is resubmitted by an unauthenticated request", common.AnnotationKeyCreatorSubject)
 		}
 	})
 	t.Run("OverrideParams", func(t *testing.T) {
@@ -2102,3 +2262,133 @@ func TestRetryWorkflowWithNestedDAGsWithSuspendNodes(t *testing.T) {
 	assert.Equal(t, wfv1.NodeSucceeded, wf.Status.Nodes.FindByName("fail-two-nested-dag-suspend.dag1-step4").Phase)
 	assert.Equal(t, 1, len(podsToDelete))
 }
+
+var fanOutSuspendedWf = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  name: fan-out
+spec:
+  entrypoint: main
+status:
+  nodes:
+    fan-out:
+      displayName: fan-out
+      id: fan-out
+      name: fan-out
+      phase: Running
+      type: Steps
+      children:
+      - fan-out-0
+      - fan-out-4
+    fan-out-0:
+      displayName: "[0]"
+      id: fan-out-0
+      name: fan-out[0]
+      phase: Running
+      type: StepGroup
+      children:
+      - fan-out-1
+      - fan-out-2
+      - fan-out-3
+    fan-out-1:
+      displayName: approve(0:eu)
+      id: fan-out-1
+      name: fan-out[0].approve(0:eu)
+      phase: Running
+      type: Suspend
+    fan-out-2:
+      displayName: approve(1:us)
+      id: fan-out-2
+      name: fan-out[0].approve(1:us)
+      phase: Running
+      type: Suspend
+    fan-out-3:
+      displayName: approve(2:ap)
+      id: fan-out-3
+      name: fan-out[0].approve(2:ap)
+      phase: Running
+      type: Suspend
+    fan-out-4:
+      displayName: other
+      id: fan-out-4
+      name: fan-out[1].other
+      phase: Running
+      type: Suspend
+  phase: Running
+`
+
+func TestResumeWorkflowOthersPhase(t *testing.T) {
+	ctx := context.Background()
+	wfIf := argofake.NewSimpleClientset().ArgoprojV1alpha1().Workflows("")
+	_, err := wfIf.Create(ctx, wfv1.MustUnmarshalWorkflow(fanOutSuspendedWf), metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	err = ResumeWorkflow(ctx, wfIf, hydratorfake.Noop, "fan-out", "", wfv1.NodeSkipped, ApprovalOpts{})
+	assert.EqualError(t, err, "a node field selector is needed to resume only some of the suspended nodes")
+	err = ResumeWorkflow(ctx, wfIf, hydratorfake.Noop, "fan-out", "displayName=approve(0:eu)", wfv1.NodeSucceeded, ApprovalOpts{})
+	assert.EqualError(t, err, "the other suspended nodes can only be Failed or Skipped, not Succeeded")
+
+	err = ResumeWorkflow(ctx, wfIf, hydratorfake.Noop, "fan-out", "displayName=approve(0:eu)", wfv1.NodeSkipped, ApprovalOpts{})
+	require.NoError(t, err)
+	wf, err := wfIf.Get(ctx, "fan-out", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, wfv1.NodeSucceeded, wf.Status.Nodes["fan-out-1"].Phase)
+	for _, id := range []string{"fan-out-2", "fan-out-3"} {
+		assert.Equal(t, wfv1.NodeSkipped, wf.Status.Nodes[id].Phase)
+		assert.Equal(t, "not selected when the other suspended nodes were resumed", wf.Status.Nodes[id].Message)
+		assert.False(t, wf.Status.Nodes[id].FinishedAt.Time.IsZero())
+	}
+	// suspend nodes in other groups are not affected
+	assert.Equal(t, wfv1.NodeRunning, wf.Status.Nodes["fan-out-4"].Phase)
+}
+
+var pausedBranchWf = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  name: dag
+spec:
+  entrypoint: main
+status:
+  nodes:
+    dag:
+      displayName: dag
+      id: dag
+      name: dag
+      phase: Running
+      type: DAG
+      children:
+      - dag-1
+    dag-1:
+      boundaryID: dag
+      displayName: a
+      id: dag-1
+      name: dag.a
+      phase: Running
+      type: Pod
+  phase: Running
+`
+
+func TestPauseWorkflowBranches(t *testing.T) {
+	ctx := context.Background()
+	wfIf := argofake.NewSimpleClientset().ArgoprojV1alpha1().Workflows("")
+	_, err := wfIf.Create(ctx, wfv1.MustUnmarshalWorkflow(pausedBranchWf), metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	err = PauseWorkflowBranches(ctx, wfIf, hydratorfake.Noop, "dag", "displayName=nonexistent")
+	assert.EqualError(t, err, "no DAG or DAG task nodes matching nodeFieldSelector: displayName=nonexistent")
+
+	err = PauseWorkflowBranches(ctx, wfIf, hydratorfake.Noop, "dag", "displayName=a")
+	require.NoError(t, err)
+	wf, err := wfIf.Get(ctx, "dag", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.True(t, wf.Status.Nodes.FindByDisplayName("a").Paused)
+	assert.False(t, wf.Status.Nodes.FindByDisplayName("dag").Paused)
+
+	err = ResumeWorkflow(ctx, wfIf, hydratorfake.Noop, "dag", "displayName=a", "", ApprovalOpts{})
+	require.NoError(t, err)
+	wf, err = wfIf.Get(ctx, "dag", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.False(t, wf.Status.Nodes.FindByDisplayName("a").Paused)
+}
@@ -0,0 +1,55 @@
+package util
+
+import (
+	"encoding/json"
+	"sort"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/util/template"
+)
+
+// ReferencedVariable is a variable that a workflow spec references
+type ReferencedVariable struct {
+	// Name is the name of the variable, e.g. inputs.parameters.message
+	Name string `json:"name"`
+	// Template is the name of the template that references the variable, or empty if a field outside of the
+	// templates references it
+	Template string `json:"template,omitempty"`
+}
+
+// ReferencedVariables returns the variables that the spec references, including the variables used by expressions,
+// sorted by template and name. It does not resolve them, so that typos can be found before the workflow runs.
+func ReferencedVariables(spec *wfv1.WorkflowSpec) ([]ReferencedVariable, error) {
+	var variables []ReferencedVariable
+	add := func(tmplName string, v interface{}) error {
+		data, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		names, err := template.Variables(string(data))
+		if err != nil {
+			return err
+		}
+		for _, name := range names {
+			variables = append(variables, ReferencedVariable{Name: name, Template: tmplName})
+		}
+		return nil
+	}
+	specWithoutTemplates := spec.DeepCopy()
+	specWithoutTemplates.Templates = nil
+	if err := add("", specWithoutTemplates); err != nil {
+		return nil, err
+	}
+	for _, tmpl := range spec.Templates {
+		if err := add(tmpl.Name, tmpl); err != nil {
+			return nil, err
+		}
+	}
+	sort.SliceStable(variables, func(i, j int) bool {
+		if variables[i].Template != variables[j].Template {
+			return variables[i].Template < variables[j].Template
+		}
+		return variables[i].Name < variables[j].Name
+	})
+	return variables, nil
+}
@@ -0,0 +1,46 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+func TestReferencedVariables(t *testing.T) {
+	wf := wfv1.MustUnmarshalWorkflow(`
+metadata:
+  name: my-wf
+spec:
+  entrypoint: main
+  arguments:
+    parameters:
+    - name: image
+      value: "{{workflow.name}}"
+  templates:
+  - name: main
+    steps:
+    - - name: say
+        template: say
+        arguments:
+          parameters:
+          - name: message
+            value: "{{=sprig.upper(workflow.parameters.image)}}"
+  - name: say
+    inputs:
+      parameters:
+      - name: message
+    container:
+      image: "{{inputs.parameters.imge}}"
+      args: ["{{inputs.parameters.message}}"]
+`)
+	variables, err := ReferencedVariables(&wf.Spec)
+	assert.NoError(t, err)
+	assert.Equal(t, []ReferencedVariable{
+		{Name: "workflow.name"},
+		{Name: "workflow.parameters.image", Template: "main"},
+		{Name: "inputs.parameters.imge", Template: "say"},
+		{Name: "inputs.parameters.message", Template: "say"},
+	}, variables)
+}
@@ -0,0 +1,60 @@
+package validate
+
+import (
+	"github.com/argoproj/argo-workflows/v3/errors"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/templateresolution"
+)
+
+// GetDeprecatedParameterWarnings returns a warning for each argument of the workflow that is deprecated by the workflow
+// template it references
+func GetDeprecatedParameterWarnings(wftmplGetter templateresolution.WorkflowTemplateNamespacedGetter, cwftmplGetter templateresolution.ClusterWorkflowTemplateGetter, wf *wfv1.Workflow) ([]string, error) {
+	ref := wf.Spec.WorkflowTemplateRef
+	if ref == nil {
+		return nil, nil
+	}
+	var wfSpecHolder wfv1.WorkflowSpecHolder
+	var err error
+	if ref.ClusterScope {
+		wfSpecHolder, err = cwftmplGetter.Get(ref.Name)
+	} else {
+		wfSpecHolder, err = wftmplGetter.Get(ref.Name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return GetDeprecatedArguments(wfSpecHolder.GetWorkflowSpec().Arguments.Parameters, wf.Spec.Arguments.Parameters), nil
+}
+
+// GetDeprecatedArguments returns a warning for each argument that is deprecated by the parameters
+func GetDeprecatedArguments(parameters []wfv1.Parameter, arguments []wfv1.Parameter) []string {
+	var warnings []string
+	for _, argument := range arguments {
+		for _, p := range parameters {
+			if p.Name == argument.Name && p.Deprecated != nil {
+				warnings = append(warnings, p.GetDeprecationMessage())
+			}
+		}
+	}
+	return warnings
+}
+
+// validateParameterDeprecations ensures that deprecated parameters are replaced by other parameters
+func validateParameterDeprecations(prefix string, parameters []wfv1.Parameter) error {
+	names := make(map[string]bool)
+	for _, p := range parameters {
+		names[p.Name] = true
+	}
+	for _, p := range parameters {
+		if p.Deprecated == nil || p.Deprecated.ReplacedBy == "" {
+			continue
+		}
+		if p.Deprecated.ReplacedBy == p.Name {
+			return errors.Errorf(errors.CodeBadRequest, "%s%s.deprecated.replacedBy cannot be the parameter itself", prefix, p.Name)
+		}
+		if !names[p.Deprecated.ReplacedBy] {
+			return errors.Errorf(errors.CodeBadRequest, "%s%s.deprecated.replacedBy %q is not a parameter", prefix, p.Name, p.Deprecated.ReplacedBy)
+		}
+	}
+	return nil
+}
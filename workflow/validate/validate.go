@@ -11,15 +11,19 @@ import (
 
 	"golang.org/x/exp/maps"
 
+	"github.com/Masterminds/semver/v3"
 	"github.com/robfig/cron/v3"
 	"github.com/sirupsen/logrus"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	apivalidation "k8s.io/apimachinery/pkg/util/validation"
 	"sigs.k8s.io/yaml"
 
+	"github.com/argoproj/argo-workflows/v3/config"
 	"github.com/argoproj/argo-workflows/v3/errors"
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 	"github.com/argoproj/argo-workflows/v3/util"
+	"github.com/argoproj/argo-workflows/v3/util/archive"
 	"github.com/argoproj/argo-workflows/v3/util/intstr"
 	"github.com/argoproj/argo-workflows/v3/util/sorting"
 	"github.com/argoproj/argo-workflows/v3/util/template"
@@ -46,6 +50,13 @@ type ValidateOpts struct {
 	// Submit indicates that the current operation is a workflow submission. This will impose
 	// more stringent requirements (e.g. require input values for all spec arguments)
 	Submit bool
+
+	// StrictVariables fails validation on any variable reference that cannot be resolved, including those that are
+	// not workflow variables, e.g. {{input.parameters.message}}. It is also enabled by the spec's strictVariables field.
+	StrictVariables bool
+
+	// Guardrails are the controller's limits on the duration and size of workflows
+	Guardrails *config.Guardrails
 }
 
 // templateValidationCtx is the context for validating a workflow spec
@@ -172,6 +183,8 @@ func ValidateWorkflow(wftmplGetter templateresolution.WorkflowTemplateNamespaced
 	}
 	err = validateWorkflowFieldNames(wf.Spec.Templates)
 
+	ctx.StrictVariables = ctx.StrictVariables || wf.Spec.StrictVariables || (hasWorkflowTemplateRef && wfSpecHolder.GetWorkflowSpec().StrictVariables)
+
 	wfArgs := wf.Spec.Arguments
 
 	if hasWorkflowTemplateRef {
@@ -190,6 +203,10 @@ func ValidateWorkflow(wftmplGetter templateresolution.WorkflowTemplateNamespaced
 	if err != nil {
 		return err
 	}
+	err = validateParameterDeprecations("spec.arguments.parameters.", wfArgs.Parameters)
+	if err != nil {
+		return err
+	}
 	if len(wfArgs.Parameters) > 0 {
 		ctx.globalParams[common.GlobalVarWorkflowParameters] = placeholderGenerator.NextPlaceholder()
 		ctx.globalParams[common.GlobalVarWorkflowParametersJSON] = placeholderGenerator.NextPlaceholder()
@@ -205,6 +222,23 @@ func ValidateWorkflow(wftmplGetter templateresolution.WorkflowTemplateNamespaced
 		}
 	}
 
+	if wf.Spec.ExitHooksDeadlineSeconds != nil && *wf.Spec.ExitHooksDeadlineSeconds <= 0 {
+		return errors.Errorf(errors.CodeBadRequest, "spec.exitHooksDeadlineSeconds must be a positive integer")
+	}
+
+	if wf.Spec.ArtifactParallelism != nil && *wf.Spec.ArtifactParallelism <= 0 {
+		return errors.Errorf(errors.CodeBadRequest, "spec.artifactParallelism must be a positive integer")
+	}
+
+	if err := ctx.Guardrails.CheckActiveDeadlineSeconds(wf.Spec.ActiveDeadlineSeconds); err != nil {
+		return errors.Errorf(errors.CodeBadRequest, "spec.%s", err.Error())
+	}
+	if hasWorkflowTemplateRef && wf.Spec.ActiveDeadlineSeconds == nil {
+		if err := ctx.Guardrails.CheckActiveDeadlineSeconds(wfSpecHolder.GetWorkflowSpec().ActiveDeadlineSeconds); err != nil {
+			return errors.Errorf(errors.CodeBadRequest, "spec.%s", err.Error())
+		}
+	}
+
 	annotationSources := [][]string{maps.Keys(wf.ObjectMeta.Annotations)}
 	labelSources := [][]string{maps.Keys(wf.ObjectMeta.Labels)}
 	if wf.Spec.WorkflowMetadata != nil {
@@ -235,6 +269,17 @@ func ValidateWorkflow(wftmplGetter templateresolution.WorkflowTemplateNamespaced
 	}
 	ctx.globalParams[common.GlobalVarWorkflowStatus] = placeholderGenerator.NextPlaceholder()
 
+	artifactBundle := wf.Spec.ArtifactBundle
+	if artifactBundle == nil && hasWorkflowTemplateRef {
+		artifactBundle = wfSpecHolder.GetWorkflowSpec().ArtifactBundle
+	}
+	if artifactBundle != nil {
+		if err := validateArtifactBundle(artifactBundle, wf, hasWorkflowTemplateRef); err != nil {
+			return err
+		}
+		ctx.globalParams["workflow.outputs.artifacts."+artifactBundle.Name] = placeholderGenerator.NextPlaceholder()
+	}
+
 	if !opts.IgnoreEntrypoint && entrypoint == "" {
 		return errors.New(errors.CodeBadRequest, "spec.entrypoint is required")
 	}
@@ -282,6 +327,10 @@ func ValidateWorkflow(wftmplGetter templateresolution.WorkflowTemplateNamespaced
 	if err != nil {
 		return err
 	}
+	err = validateWorkflowOutputs(wf.Spec.Outputs, wf.GetTemplateByName(entrypoint))
+	if err != nil {
+		return err
+	}
 
 	if !wf.Spec.PodGC.GetStrategy().IsValid() {
 		return errors.Errorf(errors.CodeBadRequest, "podGC.strategy unknown strategy '%s'", wf.Spec.PodGC.Strategy)
@@ -374,8 +423,12 @@ func ValidateCronWorkflow(wftmplGetter templateresolution.WorkflowTemplateNamesp
 		return fmt.Errorf("cron workflow name %q must not be more than 52 characters long (currently %d)", cronWf.Name, len(cronWf.Name))
 	}
 
-	if _, err := cron.ParseStandard(cronWf.Spec.Schedule); err != nil {
-		return errors.Errorf(errors.CodeBadRequest, "cron schedule is malformed: %s", err)
+	if len(cronWf.Spec.Schedules) == 0 {
+		if _, err := cron.ParseStandard(cronWf.Spec.Schedule); err != nil {
+			return errors.Errorf(errors.CodeBadRequest, "cron schedule is malformed: %s", err)
+		}
+	} else if err := validateCronSchedules(cronWf); err != nil {
+		return err
 	}
 
 	switch cronWf.Spec.ConcurrencyPolicy {
@@ -398,6 +451,39 @@ func ValidateCronWorkflow(wftmplGetter templateresolution.WorkflowTemplateNamesp
 	return nil
 }
 
+// validateCronSchedules validates the named schedules of a cron workflow, which are used instead of its schedule
+func validateCronSchedules(cronWf *wfv1.CronWorkflow) error {
+	if cronWf.Spec.Schedule != "" {
+		return errors.Errorf(errors.CodeBadRequest, "only one of schedule or schedules may be specified")
+	}
+	names := make(map[string]bool)
+	for _, schedule := range cronWf.Spec.Schedules {
+		if errs := apivalidation.IsDNS1123Label(schedule.Name); len(errs) > 0 {
+			return errors.Errorf(errors.CodeBadRequest, "schedule name %q is invalid: %s", schedule.Name, strings.Join(errs, ";"))
+		}
+		if names[schedule.Name] {
+			return errors.Errorf(errors.CodeBadRequest, "schedule name %q is not unique", schedule.Name)
+		}
+		names[schedule.Name] = true
+		// the schedule's name is added to the names of the workflows it runs, before the unix timestamp
+		if len(cronWf.Name)+1+len(schedule.Name) > maxCharsInCronWorkflowName {
+			return errors.Errorf(errors.CodeBadRequest, "cron workflow name and schedule name %q must not be more than %d characters long together", schedule.Name, maxCharsInCronWorkflowName-1)
+		}
+		if _, err := cron.ParseStandard(schedule.Schedule); err != nil {
+			return errors.Errorf(errors.CodeBadRequest, "cron schedule %q is malformed: %s", schedule.Name, err)
+		}
+		for _, p := range schedule.Parameters {
+			if p.Name == "" {
+				return errors.Errorf(errors.CodeBadRequest, "parameters of schedule %q must have names", schedule.Name)
+			}
+			if p.Value == nil {
+				return errors.Errorf(errors.CodeBadRequest, "parameter %q of schedule %q must have a value", p.Name, schedule.Name)
+			}
+		}
+	}
+	return nil
+}
+
 func (ctx *templateValidationCtx) validateInitContainers(containers []wfv1.UserContainer) error {
 	for _, container := range containers {
 		if len(container.Container.Name) == 0 {
@@ -491,7 +577,7 @@ func (ctx *templateValidationCtx) validateTemplate(tmpl *wfv1.Template, tmplCtx
 	if err != nil {
 		return err
 	}
-	err = validateOutputs(scope, ctx.globalParams, newTmpl, workflowTemplateValidation)
+	err = validateOutputs(scope, ctx.globalParams, newTmpl, workflowTemplateValidation, ctx.StrictVariables)
 	if err != nil {
 		return err
 	}
@@ -538,7 +624,7 @@ func (ctx *templateValidationCtx) validateTemplateHolder(tmplHolder wfv1.Templat
 	} else if tmplName != "" {
 		_, err := tmplCtx.GetTemplateByName(tmplName)
 		if err != nil {
-			if argoerr, ok := err.(errors.ArgoError); ok && argoerr.Code() == errors.CodeNotFound {
+			if argoerr, ok := err.(errors.ArgoError); ok && argoerr.Code() == errors.CodeTemplateNotFound {
 				return nil, errors.Errorf(errors.CodeBadRequest, "template name '%s' undefined", tmplName)
 			}
 			return nil, err
@@ -547,7 +633,7 @@ func (ctx *templateValidationCtx) validateTemplateHolder(tmplHolder wfv1.Templat
 
 	tmplCtx, resolvedTmpl, _, err := tmplCtx.ResolveTemplate(tmplHolder)
 	if err != nil {
-		if argoerr, ok := err.(errors.ArgoError); ok && argoerr.Code() == errors.CodeNotFound {
+		if argoerr, ok := err.(errors.ArgoError); ok && argoerr.Code() == errors.CodeTemplateNotFound {
 			if tmplRef != nil {
 				return nil, errors.Errorf(errors.CodeBadRequest, "template reference %s.%s not found", tmplRef.Name, tmplRef.Template)
 			}
@@ -565,6 +651,16 @@ func (ctx *templateValidationCtx) validateTemplateHolder(tmplHolder wfv1.Templat
 		default:
 			return nil, fmt.Errorf("%s is not a valid RetryPolicy", resolvedTmpl.RetryStrategy.RetryPolicy)
 		}
+		for _, class := range resolvedTmpl.RetryStrategy.FailureClasses {
+			switch class {
+			case wfv1.NodeFailureClassEvicted, wfv1.NodeFailureClassNodeLost, wfv1.NodeFailureClassImagePull, wfv1.NodeFailureClassOOMKilled, wfv1.NodeFailureClassApplication:
+			default:
+				return nil, fmt.Errorf("%s is not a valid failure class", class)
+			}
+		}
+		if len(resolvedTmpl.RetryStrategy.FailureClasses) > 0 && resolvedTmpl.RetryStrategy.RetryPolicy != wfv1.RetryPolicyOnTransientError {
+			return nil, fmt.Errorf("retryStrategy.failureClasses can only be used with the %s retryPolicy", wfv1.RetryPolicyOnTransientError)
+		}
 	}
 
 	return resolvedTmpl, ctx.validateTemplate(resolvedTmpl, tmplCtx, args, workflowTemplateValidation)
@@ -573,18 +669,18 @@ func (ctx *templateValidationCtx) validateTemplateHolder(tmplHolder wfv1.Templat
 // validateTemplateType validates that only one template type is defined
 func validateTemplateType(tmpl *wfv1.Template) error {
 	numTypes := 0
-	for _, tmplType := range []interface{}{tmpl.Container, tmpl.ContainerSet, tmpl.Steps, tmpl.Script, tmpl.Resource, tmpl.DAG, tmpl.Suspend, tmpl.Data, tmpl.HTTP, tmpl.Plugin} {
+	for _, tmplType := range []interface{}{tmpl.Container, tmpl.ContainerSet, tmpl.Steps, tmpl.Script, tmpl.Resource, tmpl.DAG, tmpl.Suspend, tmpl.Data, tmpl.HTTP, tmpl.Plugin, tmpl.Manual} {
 		if !reflect.ValueOf(tmplType).IsNil() {
 			numTypes++
 		}
 	}
 	switch numTypes {
 	case 0:
-		return errors.Errorf(errors.CodeBadRequest, "templates.%s template type unspecified. choose one of: container, containerSet, steps, script, resource, dag, suspend, template, template ref", tmpl.Name)
+		return errors.Errorf(errors.CodeBadRequest, "templates.%s template type unspecified. choose one of: container, containerSet, steps, script, resource, dag, suspend, manual, template, template ref", tmpl.Name)
 	case 1:
 		// Do nothing
 	default:
-		return errors.Errorf(errors.CodeBadRequest, "templates.%s multiple template types specified. choose one of: container, containerSet, steps, script, resource, dag, suspend, template, template ref", tmpl.Name)
+		return errors.Errorf(errors.CodeBadRequest, "templates.%s multiple template types specified. choose one of: container, containerSet, steps, script, resource, dag, suspend, manual, template, template ref", tmpl.Name)
 	}
 	return nil
 }
@@ -623,6 +719,21 @@ func validateInputs(tmpl *wfv1.Template) (map[string]interface{}, error) {
 		if art.From != "" {
 			return nil, errors.Errorf(errors.CodeBadRequest, "templates.%s.%s.from not valid in inputs", tmpl.Name, artRef)
 		}
+		if art.IfNotPresent != nil {
+			return nil, errors.Errorf(errors.CodeBadRequest, "templates.%s.%s.ifNotPresent not valid in inputs", tmpl.Name, artRef)
+		}
+		if len(art.ObjectMetadata) > 0 {
+			return nil, errors.Errorf(errors.CodeBadRequest, "templates.%s.%s.objectMetadata not valid in inputs", tmpl.Name, artRef)
+		}
+		if len(art.Paths) > 0 {
+			return nil, errors.Errorf(errors.CodeBadRequest, "templates.%s.%s.paths not valid in inputs", tmpl.Name, artRef)
+		}
+		if art.Mount && art.Archive != nil && art.Archive.None == nil {
+			return nil, errors.Errorf(errors.CodeBadRequest, "templates.%s.%s.mount cannot be used with a tar or zip archive", tmpl.Name, artRef)
+		}
+		if art.Checksum != "" && !common.IsValidChecksum(art.Checksum) {
+			return nil, errors.Errorf(errors.CodeBadRequest, "templates.%s.%s.checksum must be a SHA-256 checksum of 64 lowercase hex characters", tmpl.Name, artRef)
+		}
 		errPrefix := fmt.Sprintf("templates.%s.%s", tmpl.Name, artRef)
 		err = validateArtifactLocation(errPrefix, art.ArtifactLocation)
 		if err != nil {
@@ -632,6 +743,30 @@ func validateInputs(tmpl *wfv1.Template) (map[string]interface{}, error) {
 	return scope, nil
 }
 
+// validateArtifactPaths checks the glob patterns that select which files under an output artifact's path are saved
+func validateArtifactPaths(art wfv1.Artifact) error {
+	if art.Path == "" {
+		return fmt.Errorf("requires path to be specified")
+	}
+	if art.Archive != nil && art.Archive.None != nil {
+		return fmt.Errorf("cannot be used with archive strategy none")
+	}
+	for _, pattern := range art.Paths {
+		if pattern == "" || strings.HasPrefix(pattern, "/") {
+			return fmt.Errorf("%q must be a relative pattern", pattern)
+		}
+		for _, segment := range strings.Split(pattern, "/") {
+			if segment == ".." {
+				return fmt.Errorf("%q must not refer to a parent directory", pattern)
+			}
+		}
+		if err := archive.ValidatePattern(pattern); err != nil {
+			return fmt.Errorf("%q is not a valid pattern: %v", pattern, err)
+		}
+	}
+	return nil
+}
+
 func validateArtifactLocation(errPrefix string, art wfv1.ArtifactLocation) error {
 	if art.Git != nil {
 		if art.Git.Repo == "" {
@@ -649,15 +784,15 @@ func validateArtifactLocation(errPrefix string, art wfv1.ArtifactLocation) error
 }
 
 // resolveAllVariables is a helper to ensure all {{variables}} are resolvable from current scope
-func resolveAllVariables(scope map[string]interface{}, globalParams map[string]string, tmplStr string, workflowTemplateValidation bool) error {
+func resolveAllVariables(scope map[string]interface{}, globalParams map[string]string, tmplStr string, workflowTemplateValidation, strictVariables bool) error {
 	_, allowAllItemRefs := scope[anyItemMagicValue] // 'item.*' is a magic placeholder value set by addItemsToScope
 	_, allowAllWorkflowOutputParameterRefs := scope[anyWorkflowOutputParameterMagicValue]
 	_, allowAllWorkflowOutputArtifactRefs := scope[anyWorkflowOutputArtifactMagicValue]
 	return template.Validate(tmplStr, func(tag string) error {
 		// Trim the tag to check the validations
 		trimmedTag := strings.TrimSpace(tag)
-		// Skip the custom variable references
-		if !checkValidWorkflowVariablePrefix(trimmedTag) {
+		// Skip the custom variable references, unless variables are strict
+		if !checkValidWorkflowVariablePrefix(trimmedTag) && (!strictVariables || isMetricVariable(trimmedTag)) {
 			return nil
 		}
 		_, ok := scope[trimmedTag]
@@ -675,6 +810,8 @@ func resolveAllVariables(scope map[string]interface{}, globalParams map[string]s
 			} else if strings.HasPrefix(trimmedTag, common.GlobalVarWorkflowCreationTimestamp) {
 			} else if strings.HasPrefix(trimmedTag, common.GlobalVarWorkflowCronScheduleTime) {
 				// Allow runtime resolution for "scheduledTime" which will pass from CronWorkflow
+			} else if strings.HasPrefix(trimmedTag, common.GlobalVarWorkflowLastSuccessfulRunOutputs+".") {
+				// Allow runtime resolution for the outputs of the previous run, which will pass from CronWorkflow
 			} else if strings.HasPrefix(trimmedTag, common.GlobalVarWorkflowDuration) {
 			} else if strings.HasPrefix(trimmedTag, "tasks.name") {
 			} else if strings.HasPrefix(trimmedTag, "steps.name") {
@@ -689,6 +826,15 @@ func resolveAllVariables(scope map[string]interface{}, globalParams map[string]s
 	})
 }
 
+// isMetricVariable returns whether the tag is one of the variables only available when emitting metrics
+func isMetricVariable(tag string) bool {
+	switch tag {
+	case common.LocalVarDuration, common.LocalVarStatus, common.LocalVarExitCode:
+		return true
+	}
+	return strings.HasPrefix(tag, common.LocalVarResourcesDuration+".")
+}
+
 // checkValidWorkflowVariablePrefix is a helper methood check variable starts workflow root elements
 func checkValidWorkflowVariablePrefix(tag string) bool {
 	for _, rootTag := range common.GlobalVarValidWorkflowVariablePrefix {
@@ -699,6 +845,41 @@ func checkValidWorkflowVariablePrefix(tag string) bool {
 	return false
 }
 
+// validateArtifactBandwidthDrivers checks that the template's artifacts are transferred by drivers that enforce its
+// artifact bandwidth. Artifacts in the default artifact repository are only known at runtime, so they are not checked.
+func validateArtifactBandwidthDrivers(tmpl *wfv1.Template) error {
+	if name := unthrottledDriver(tmpl.ArchiveLocation); name != "" {
+		return fmt.Errorf("is not enforced for the %s archive location, only for S3, HTTP and Artifactory", name)
+	}
+	for _, arts := range []wfv1.Artifacts{tmpl.Inputs.Artifacts, tmpl.Outputs.Artifacts} {
+		for _, art := range arts {
+			if name := unthrottledDriver(&art.ArtifactLocation); name != "" {
+				return fmt.Errorf("is not enforced for the %s artifact %s, only for S3, HTTP and Artifactory artifacts", name, art.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// unthrottledDriver returns the name of the location's driver if it transfers artifacts without a bandwidth limit
+func unthrottledDriver(l *wfv1.ArtifactLocation) string {
+	switch {
+	case l == nil:
+		return ""
+	case l.Git != nil:
+		return "git"
+	case l.HDFS != nil:
+		return "hdfs"
+	case l.OSS != nil:
+		return "oss"
+	case l.GCS != nil:
+		return "gcs"
+	case l.Azure != nil:
+		return "azure"
+	}
+	return ""
+}
+
 func validateNonLeaf(tmpl *wfv1.Template) error {
 	if tmpl.ActiveDeadlineSeconds != nil {
 		return errors.Errorf(errors.CodeBadRequest, "templates.%s.activeDeadlineSeconds is only valid for leaf templates", tmpl.Name)
@@ -711,7 +892,7 @@ func (ctx *templateValidationCtx) validateLeaf(scope map[string]interface{}, tmp
 	if err != nil {
 		return errors.InternalWrapError(err)
 	}
-	err = resolveAllVariables(scope, ctx.globalParams, string(tmplBytes), workflowTemplateValidation)
+	err = resolveAllVariables(scope, ctx.globalParams, string(tmplBytes), workflowTemplateValidation, ctx.StrictVariables)
 	if err != nil {
 		return errors.Errorf(errors.CodeBadRequest, "templates.%s: %s", tmpl.Name, err.Error())
 	}
@@ -799,6 +980,18 @@ func (ctx *templateValidationCtx) validateLeaf(scope map[string]interface{}, tmp
 				}
 			}
 		}
+		operator, err := common.GetResourceOperator(tmpl.Resource)
+		if err != nil {
+			return errors.Errorf(errors.CodeBadRequest, "templates.%s.resource.operator %s", tmpl.Name, err.Error())
+		}
+		if operator != nil && tmpl.Resource.Manifest != "" && !placeholderGenerator.IsPlaceholder(tmpl.Resource.Manifest) {
+			var obj struct {
+				Kind string `json:"kind"`
+			}
+			if yaml.Unmarshal([]byte(SubstituteResourceManifestExpressions(tmpl.Resource.Manifest)), &obj) == nil && obj.Kind != "" && obj.Kind != operator.Kind {
+				return errors.Errorf(errors.CodeBadRequest, "templates.%s.resource.manifest must be a %s for the %s operator", tmpl.Name, operator.Kind, tmpl.Resource.Operator)
+			}
+		}
 	}
 	if tmpl.Script != nil {
 		if tmpl.Script.Image == "" {
@@ -816,7 +1009,58 @@ func (ctx *templateValidationCtx) validateLeaf(scope map[string]interface{}, tmp
 			}
 		}
 	}
-	// we don't validate tmpl.Plugin, because this is done by Plugin.UnmarshallJSON
+	// we don't validate the structure of tmpl.Plugin, because this is done by Plugin.UnmarshallJSON
+	if tmpl.Plugin != nil {
+		if v := tmpl.Plugin.GetVersion(); v != "" && !strings.Contains(v, "{{") {
+			if _, err := semver.NewConstraint(v); err != nil {
+				return errors.Errorf(errors.CodeBadRequest, "templates.%s.plugin.version %q is not a valid version constraint: %v", tmpl.Name, v, err)
+			}
+		}
+	}
+	if tmpl.Heartbeat != nil {
+		switch tmpl.GetType() {
+		case wfv1.TemplateTypeContainer, wfv1.TemplateTypeContainerSet, wfv1.TemplateTypeScript:
+		default:
+			return errors.Errorf(errors.CodeBadRequest, "templates.%s.heartbeat is only valid for container, containerSet and script templates", tmpl.Name)
+		}
+		if _, err := tmpl.Heartbeat.GetTimeout(); err != nil && !placeholderGenerator.IsPlaceholder(tmpl.Heartbeat.Timeout) {
+			return errors.Errorf(errors.CodeBadRequest, "templates.%s.heartbeat.timeout %s", tmpl.Name, err.Error())
+		}
+	}
+	if tmpl.Manual != nil && tmpl.Manual.Due != "" {
+		if _, err := tmpl.Manual.GetDue(); err != nil && !placeholderGenerator.IsPlaceholder(tmpl.Manual.Due) {
+			return errors.Errorf(errors.CodeBadRequest, "templates.%s.manual.due %s", tmpl.Name, err.Error())
+		}
+	}
+	if tmpl.Suspend != nil && tmpl.Suspend.Approvals != 0 {
+		if tmpl.Suspend.Approvals < 0 {
+			return errors.Errorf(errors.CodeBadRequest, "templates.%s.suspend.approvals must not be negative", tmpl.Name)
+		}
+		if tmpl.Suspend.Duration != "" {
+			return errors.Errorf(errors.CodeBadRequest, "templates.%s.suspend.approvals cannot be combined with a duration", tmpl.Name)
+		}
+	}
+	if bw := tmpl.ArtifactBandwidth; bw != nil {
+		if _, err := bw.GetUpload(); err != nil && !placeholderGenerator.IsPlaceholder(bw.Upload) {
+			return errors.Errorf(errors.CodeBadRequest, "templates.%s.artifactBandwidth.upload %s", tmpl.Name, err.Error())
+		}
+		if _, err := bw.GetDownload(); err != nil && !placeholderGenerator.IsPlaceholder(bw.Download) {
+			return errors.Errorf(errors.CodeBadRequest, "templates.%s.artifactBandwidth.download %s", tmpl.Name, err.Error())
+		}
+		if err := validateArtifactBandwidthDrivers(tmpl); err != nil {
+			return errors.Errorf(errors.CodeBadRequest, "templates.%s.artifactBandwidth %s", tmpl.Name, err.Error())
+		}
+	}
+	if tmpl.NUMA != nil {
+		switch tmpl.GetType() {
+		case wfv1.TemplateTypeContainer, wfv1.TemplateTypeContainerSet, wfv1.TemplateTypeScript:
+		default:
+			return errors.Errorf(errors.CodeBadRequest, "templates.%s.numa is only valid for container, containerSet and script templates", tmpl.Name)
+		}
+		if err := tmpl.NUMA.Validate(); err != nil {
+			return errors.Errorf(errors.CodeBadRequest, "templates.%s.numa.%s", tmpl.Name, err.Error())
+		}
+	}
 	if tmpl.ActiveDeadlineSeconds != nil {
 		if !intstr.IsValidIntOrArgoVariable(tmpl.ActiveDeadlineSeconds) && !placeholderGenerator.IsPlaceholder(tmpl.ActiveDeadlineSeconds.StrVal) {
 			return errors.Errorf(errors.CodeBadRequest, "templates.%s.activeDeadlineSeconds must be a positive integer > 0 or an argo variable", tmpl.Name)
@@ -828,6 +1072,11 @@ func (ctx *templateValidationCtx) validateLeaf(scope map[string]interface{}, tmp
 	if tmpl.Parallelism != nil {
 		return errors.Errorf(errors.CodeBadRequest, "templates.%s.parallelism is only valid for steps and dag templates", tmpl.Name)
 	}
+	if isWindowsTemplate(tmplCtx, tmpl) {
+		if err := validateWindowsTemplate(tmpl); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -861,6 +1110,11 @@ func validateArgumentsValues(prefix string, arguments wfv1.Arguments, allowEmpty
 				return errors.Errorf(errors.CodeBadRequest, "%s%s.value is required", prefix, param.Name)
 			}
 		}
+		if param.ValueFrom != nil && param.ValueFrom.WorkflowOutputs != nil {
+			if err := validateWorkflowOutputsValueFrom(prefix+param.Name+".valueFrom.workflowOutputs", *param.ValueFrom.WorkflowOutputs); err != nil {
+				return err
+			}
+		}
 		if param.Enum != nil {
 			if len(param.Enum) == 0 {
 				return errors.Errorf(errors.CodeBadRequest, "%s%s.enum should contain at least one value", prefix, param.Name)
@@ -890,6 +1144,9 @@ func validateArgumentsValues(prefix string, arguments wfv1.Arguments, allowEmpty
 		if art.From != "" && art.FromExpression != "" {
 			return errors.Errorf(errors.CodeBadRequest, "%s%s shouldn't have both `from` and `fromExpression` in Artifact", prefix, art.Name)
 		}
+		if art.Checksum != "" && !common.IsValidChecksum(art.Checksum) {
+			return errors.Errorf(errors.CodeBadRequest, "%s%s.checksum must be a SHA-256 checksum of 64 lowercase hex characters", prefix, art.Name)
+		}
 	}
 	return nil
 }
@@ -920,6 +1177,9 @@ func (ctx *templateValidationCtx) validateSteps(scope map[string]interface{}, tm
 			if err != nil {
 				return errors.Errorf(errors.CodeBadRequest, "templates.%s.steps[%d].%s %s", tmpl.Name, i, step.Name, err.Error())
 			}
+			if err := ctx.Guardrails.CheckFanOut(getFanOut(step.WithItems, step.WithSequence)); err != nil {
+				return errors.Errorf(errors.CodeBadRequest, "templates.%s.steps[%d].%s %s", tmpl.Name, i, step.Name, err.Error())
+			}
 			err = validateArguments(fmt.Sprintf("templates.%s.steps[%d].%s.arguments.", tmpl.Name, i, step.Name), step.Arguments, false)
 			if err != nil {
 				return err
@@ -955,7 +1215,7 @@ func (ctx *templateValidationCtx) validateSteps(scope map[string]interface{}, tm
 				}
 			}
 
-			err = resolveAllVariables(stepScope, ctx.globalParams, string(stepBytes), workflowTemplateValidation)
+			err = resolveAllVariables(stepScope, ctx.globalParams, string(stepBytes), workflowTemplateValidation, ctx.StrictVariables)
 			if err != nil {
 				return errors.Errorf(errors.CodeBadRequest, "templates.%s.steps %s", tmpl.Name, err.Error())
 			}
@@ -974,6 +1234,43 @@ func (ctx *templateValidationCtx) validateSteps(scope map[string]interface{}, tm
 	return nil
 }
 
+// getFanOut returns the number of items of a withItems or withSequence loop. It is zero for withParam loops, and
+// sequences that use variables, as their number of items is only known at runtime.
+func getFanOut(withItems []wfv1.Item, withSequence *wfv1.Sequence) int {
+	if len(withItems) > 0 {
+		return len(withItems)
+	}
+	if withSequence == nil {
+		return 0
+	}
+	start := 0
+	if withSequence.Start != nil {
+		i, err := strconv.Atoi(withSequence.Start.String())
+		if err != nil {
+			return 0
+		}
+		start = i
+	}
+	if withSequence.Count != nil {
+		count, err := strconv.Atoi(withSequence.Count.String())
+		if err != nil {
+			return 0
+		}
+		return count
+	}
+	if withSequence.End != nil {
+		end, err := strconv.Atoi(withSequence.End.String())
+		if err != nil {
+			return 0
+		}
+		if end < start {
+			return start - end + 1
+		}
+		return end - start + 1
+	}
+	return 0
+}
+
 func addItemsToScope(withItems []wfv1.Item, withParam string, withSequence *wfv1.Sequence, scope map[string]interface{}) error {
 	defined := 0
 	if len(withItems) > 0 {
@@ -1075,7 +1372,7 @@ func (ctx *templateValidationCtx) addOutputsToScope(tmpl *wfv1.Template, prefix
 	}
 }
 
-func validateOutputs(scope map[string]interface{}, globalParams map[string]string, tmpl *wfv1.Template, workflowTemplateValidation bool) error {
+func validateOutputs(scope map[string]interface{}, globalParams map[string]string, tmpl *wfv1.Template, workflowTemplateValidation, strictVariables bool) error {
 	err := validateWorkflowFieldNames(tmpl.Outputs.Parameters)
 	if err != nil {
 		return errors.Errorf(errors.CodeBadRequest, "templates.%s.outputs.parameters %s", tmpl.Name, err.Error())
@@ -1088,7 +1385,7 @@ func validateOutputs(scope map[string]interface{}, globalParams map[string]strin
 	if err != nil {
 		return errors.InternalWrapError(err)
 	}
-	err = resolveAllVariables(scope, globalParams, string(outputBytes), workflowTemplateValidation)
+	err = resolveAllVariables(scope, globalParams, string(outputBytes), workflowTemplateValidation, strictVariables)
 	if err != nil {
 		return errors.Errorf(errors.CodeBadRequest, "templates.%s.outputs %s", tmpl.Name, err.Error())
 	}
@@ -1105,6 +1402,14 @@ func validateOutputs(scope map[string]interface{}, globalParams map[string]strin
 				return errors.Errorf(errors.CodeBadRequest, "templates.%s.%s.path only valid in container/script templates", tmpl.Name, artRef)
 			}
 		}
+		if len(art.Paths) > 0 {
+			if err = validateArtifactPaths(art); err != nil {
+				return errors.Errorf(errors.CodeBadRequest, "templates.%s.%s.paths %s", tmpl.Name, artRef, err.Error())
+			}
+		}
+		if art.Mount {
+			return errors.Errorf(errors.CodeBadRequest, "templates.%s.%s.mount not valid in outputs", tmpl.Name, artRef)
+		}
 		if art.GlobalName != "" && !isParameter(art.GlobalName) {
 			errs := isValidParamOrArtifactName(art.GlobalName)
 			if len(errs) > 0 {
@@ -1112,11 +1417,16 @@ func validateOutputs(scope map[string]interface{}, globalParams map[string]strin
 			}
 		}
 	}
+	operator, _ := common.GetResourceOperator(tmpl.Resource)
 	for _, param := range tmpl.Outputs.Parameters {
 		paramRef := fmt.Sprintf("templates.%s.outputs.parameters.%s", tmpl.Name, param.Name)
-		err = validateOutputParameter(paramRef, &param)
-		if err != nil {
-			return err
+		// the value of an operator's output is the operator's status field of the same name
+		isOperatorOutput := operator != nil && param.ValueFrom == nil && param.Value == nil && operator.Outputs[param.Name] != ""
+		if !isOperatorOutput {
+			err = validateOutputParameter(paramRef, &param)
+			if err != nil {
+				return err
+			}
 		}
 		if param.ValueFrom != nil {
 			tmplType := tmpl.GetType()
@@ -1178,6 +1488,96 @@ func validateOutputParameter(paramRef string, param *wfv1.Parameter) error {
 	return nil
 }
 
+// validateWorkflowOutputsValueFrom checks that the argument is taken from an output of the latest workflow of a
+// completed phase that a valid label selector selects
+func validateWorkflowOutputsValueFrom(prefix string, from wfv1.WorkflowOutputsValueFrom) error {
+	if from.Selector == "" {
+		return errors.Errorf(errors.CodeBadRequest, "%s.selector is required", prefix)
+	}
+	if _, err := labels.Parse(from.Selector); err != nil {
+		return errors.Errorf(errors.CodeBadRequest, "%s.selector is invalid: %v", prefix, err)
+	}
+	if from.Output == "" {
+		return errors.Errorf(errors.CodeBadRequest, "%s.output is required", prefix)
+	}
+	switch from.GetLatest() {
+	case wfv1.WorkflowSucceeded, wfv1.WorkflowFailed, wfv1.WorkflowError:
+	default:
+		return errors.Errorf(errors.CodeBadRequest, "%s.latest must be one of: Succeeded|Failed|Error", prefix)
+	}
+	return nil
+}
+
+// validateArtifactBundle checks the artifact bundle of the workflow. If the workflow does not reference a workflow
+// template, the templates it collects the artifacts of must be in it.
+func validateArtifactBundle(bundle *wfv1.ArtifactBundle, wf *wfv1.Workflow, hasWorkflowTemplateRef bool) error {
+	if bundle.Name == "" {
+		return errors.New(errors.CodeBadRequest, "spec.artifactBundle.name is required")
+	}
+	if errs := isValidParamOrArtifactName(bundle.Name); len(errs) > 0 {
+		return errors.Errorf(errors.CodeBadRequest, "spec.artifactBundle.name: %s", errs[0])
+	}
+	if hasWorkflowTemplateRef {
+		return nil
+	}
+	for i, name := range bundle.Templates {
+		if wf.GetTemplateByName(name) == nil {
+			return errors.Errorf(errors.CodeBadRequest, "spec.artifactBundle.templates[%d] template '%s' not found", i, name)
+		}
+	}
+	return nil
+}
+
+// validateWorkflowOutputs checks the outputs the workflow declares. If the entrypoint template is in the workflow, the
+// steps or tasks they are taken from must be in it.
+func validateWorkflowOutputs(outputs []wfv1.WorkflowOutput, entrypoint *wfv1.Template) error {
+	names := make(map[string]bool)
+	for i, o := range outputs {
+		prefix := fmt.Sprintf("spec.outputs[%d]", i)
+		if o.Name == "" {
+			return errors.Errorf(errors.CodeBadRequest, "%s.name is required", prefix)
+		}
+		if names[o.Name] {
+			return errors.Errorf(errors.CodeBadRequest, "%s.name '%s' is not unique", prefix, o.Name)
+		}
+		names[o.Name] = true
+		switch o.GetType() {
+		case wfv1.WorkflowOutputTypeString, wfv1.WorkflowOutputTypeNumber, wfv1.WorkflowOutputTypeBoolean, wfv1.WorkflowOutputTypeJSON, wfv1.WorkflowOutputTypeArtifact:
+		default:
+			return errors.Errorf(errors.CodeBadRequest, "%s.type must be one of string, number, boolean, json or artifact", prefix)
+		}
+		nodeKind, nodeName, outputKind, _, err := o.ParseFrom()
+		if err != nil {
+			return errors.Errorf(errors.CodeBadRequest, "%s.%s", prefix, err.Error())
+		}
+		if (outputKind == "artifacts") != (o.GetType() == wfv1.WorkflowOutputTypeArtifact) {
+			return errors.Errorf(errors.CodeBadRequest, "%s.from must be an output artifact if, and only if, the type is artifact", prefix)
+		}
+		if entrypoint == nil || nodeKind == "" {
+			continue
+		}
+		found := false
+		switch nodeKind {
+		case "tasks":
+			if entrypoint.DAG != nil {
+				for _, task := range entrypoint.DAG.Tasks {
+					found = found || task.Name == nodeName
+				}
+			}
+		case "steps":
+			for _, group := range entrypoint.Steps {
+				for _, step := range group.Steps {
+					found = found || step.Name == nodeName
+				}
+			}
+		}
+		if !found {
+			return errors.Errorf(errors.CodeBadRequest, "%s.from: %s.%s not found in the entrypoint template", prefix, nodeKind, nodeName)
+		}
+	}
+	return nil
+}
+
 // validateWorkflowFieldNames accepts a slice of structs and
 // verifies that the Name field of the structs are:
 // * unique
@@ -1348,7 +1748,7 @@ func (ctx *templateValidationCtx) validateDAG(scope map[string]interface{}, tmpl
 	if err = verifyNoCycles(tmpl, dagValidationCtx); err != nil {
 		return err
 	}
-	err = resolveAllVariables(scope, ctx.globalParams, tmpl.DAG.Target, workflowTemplateValidation)
+	err = resolveAllVariables(scope, ctx.globalParams, tmpl.DAG.Target, workflowTemplateValidation, ctx.StrictVariables)
 	if err != nil {
 		return errors.Errorf(errors.CodeBadRequest, "templates.%s.targets %s", tmpl.Name, err.Error())
 	}
@@ -1394,7 +1794,10 @@ func (ctx *templateValidationCtx) validateDAG(scope map[string]interface{}, tmpl
 		if err != nil {
 			return errors.Errorf(errors.CodeBadRequest, "templates.%s.tasks.%s %s", tmpl.Name, task.Name, err.Error())
 		}
-		err = resolveAllVariables(taskScope, ctx.globalParams, string(taskBytes), workflowTemplateValidation)
+		if err := ctx.Guardrails.CheckFanOut(getFanOut(task.WithItems, task.WithSequence)); err != nil {
+			return errors.Errorf(errors.CodeBadRequest, "templates.%s.tasks.%s %s", tmpl.Name, task.Name, err.Error())
+		}
+		err = resolveAllVariables(taskScope, ctx.globalParams, string(taskBytes), workflowTemplateValidation, ctx.StrictVariables)
 		if err != nil {
 			return errors.Errorf(errors.CodeBadRequest, "templates.%s.tasks.%s %s", tmpl.Name, task.Name, err.Error())
 		}
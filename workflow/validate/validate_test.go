@@ -9,7 +9,9 @@ import (
 	"github.com/stretchr/testify/assert"
 	apierr "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
 
+	"github.com/argoproj/argo-workflows/v3/config"
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 	fakewfclientset "github.com/argoproj/argo-workflows/v3/pkg/client/clientset/versioned/fake"
 	"github.com/argoproj/argo-workflows/v3/workflow/common"
@@ -876,6 +878,377 @@ func TestInvalidOutputArtName(t *testing.T) {
 	}
 }
 
+var outputArtPaths = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: output-artifact-
+spec:
+  entrypoint: whalesay
+  templates:
+  - name: whalesay
+    container:
+      image: docker/whalesay:latest
+      command: [sh, -c]
+      args: ["cowsay hello world | tee /tmp/reports/hello_world.txt"]
+    outputs:
+      artifacts:
+      - name: reports
+        path: /tmp/reports
+        paths:
+        - "**/*.txt"
+        - summary.json
+`
+
+func TestOutputArtPaths(t *testing.T) {
+	err := validate(outputArtPaths)
+	assert.NoError(t, err)
+
+	err = validate(strings.Replace(outputArtPaths, `"**/*.txt"`, `../*.txt`, 1))
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "must not refer to a parent directory")
+	}
+
+	err = validate(strings.Replace(outputArtPaths, `"**/*.txt"`, `"/tmp/*.txt"`, 1))
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "must be a relative pattern")
+	}
+
+	err = validate(strings.Replace(outputArtPaths, `"**/*.txt"`, `"[*.txt"`, 1))
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "is not a valid pattern")
+	}
+
+	err = validate(outputArtPaths + "        archive:\n          none: {}\n")
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "cannot be used with archive strategy none")
+	}
+}
+
+var mountedInputArt = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: mount-
+spec:
+  entrypoint: main
+  templates:
+  - name: main
+    inputs:
+      artifacts:
+      - name: dataset
+        path: /data
+        mount: true
+        s3:
+          key: datasets/large
+    container:
+      image: argoproj/argosay:v2
+`
+
+func TestMountedInputArt(t *testing.T) {
+	err := validate(mountedInputArt)
+	assert.NoError(t, err)
+
+	err = validate(strings.Replace(mountedInputArt, "        mount: true\n", "        mount: true\n        archive:\n          tar: {}\n", 1))
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "mount cannot be used with a tar or zip archive")
+	}
+
+	err = validate(strings.Replace(mountedInputArt, "    inputs:\n", "    outputs:\n", 1))
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "mount not valid in outputs")
+	}
+}
+
+var artObjectMetadata = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: object-metadata-
+spec:
+  entrypoint: main
+  templates:
+  - name: main
+    outputs:
+      artifacts:
+      - name: report
+        path: /tmp/report.txt
+        objectMetadata:
+          workflow: "{{workflow.name}}"
+          environment: production
+    container:
+      image: argoproj/argosay:v2
+`
+
+func TestArtObjectMetadata(t *testing.T) {
+	err := validate(artObjectMetadata)
+	assert.NoError(t, err)
+
+	err = validate(strings.Replace(artObjectMetadata, "    outputs:\n", "    inputs:\n", 1))
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "objectMetadata not valid in inputs")
+	}
+}
+
+var inputArtChecksum = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: checksum-
+spec:
+  entrypoint: main
+  templates:
+  - name: main
+    inputs:
+      artifacts:
+      - name: dataset
+        path: /data
+        checksum: 2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824
+        http:
+          url: https://example.com/dataset
+    container:
+      image: argoproj/argosay:v2
+`
+
+func TestInputArtChecksum(t *testing.T) {
+	err := validate(inputArtChecksum)
+	assert.NoError(t, err)
+
+	for _, checksum := range []string{"../../etc/passwd", "2CF24DBA5FB0A30E26E83B2AC5B9E29E1B161E5C1FA7425E73043362938B9824", "2cf24dba"} {
+		err = validate(strings.Replace(inputArtChecksum, "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824", checksum, 1))
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), "templates.main.inputs.artifacts.dataset.checksum must be a SHA-256 checksum")
+		}
+	}
+}
+
+var exitHooksDeadlineSeconds = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: exit-hooks-deadline-
+spec:
+  entrypoint: main
+  onExit: main
+  exitHooksDeadlineSeconds: 60
+  templates:
+  - name: main
+    container:
+      image: argoproj/argosay:v2
+`
+
+func TestExitHooksDeadlineSeconds(t *testing.T) {
+	err := validate(exitHooksDeadlineSeconds)
+	assert.NoError(t, err)
+
+	err = validate(strings.Replace(exitHooksDeadlineSeconds, "exitHooksDeadlineSeconds: 60", "exitHooksDeadlineSeconds: 0", 1))
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "spec.exitHooksDeadlineSeconds must be a positive integer")
+	}
+}
+
+var artifactParallelism = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: artifact-parallelism-
+spec:
+  entrypoint: main
+  artifactParallelism: 10
+  templates:
+  - name: main
+    container:
+      image: argoproj/argosay:v2
+`
+
+func TestArtifactParallelism(t *testing.T) {
+	err := validate(artifactParallelism)
+	assert.NoError(t, err)
+
+	err = validate(strings.Replace(artifactParallelism, "artifactParallelism: 10", "artifactParallelism: 0", 1))
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "spec.artifactParallelism must be a positive integer")
+	}
+}
+
+var artifactBundle = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: artifact-bundle-
+spec:
+  entrypoint: main
+  onExit: exit
+  artifactBundle:
+    name: reports
+    templates: [main]
+  templates:
+  - name: main
+    outputs:
+      artifacts:
+      - name: report
+        path: /tmp/report
+    container:
+      image: argoproj/argosay:v2
+  - name: exit
+    steps:
+    - - name: print
+        template: print
+        arguments:
+          artifacts:
+          - name: reports
+            from: "{{workflow.outputs.artifacts.reports}}"
+  - name: print
+    inputs:
+      artifacts:
+      - name: reports
+        path: /tmp/reports
+    container:
+      image: argoproj/argosay:v2
+`
+
+func TestArtifactBundle(t *testing.T) {
+	err := validate(artifactBundle)
+	assert.NoError(t, err)
+
+	err = validate(strings.Replace(artifactBundle, "name: reports\n", "name: \"\"\n", 1))
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "spec.artifactBundle.name is required")
+	}
+
+	err = validate(strings.Replace(artifactBundle, "templates: [main]", "templates: [report]", 1))
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "spec.artifactBundle.templates[0] template 'report' not found")
+	}
+}
+
+var deprecatedParameters = `
+apiVersion: argoproj.io/v1alpha1
+kind: WorkflowTemplate
+metadata:
+  name: deprecated-parameters
+spec:
+  entrypoint: main
+  arguments:
+    parameters:
+      - name: msg
+        deprecated:
+          message: it is ambiguous
+          replacedBy: message
+      - name: message
+  templates:
+  - name: main
+    container:
+      image: argoproj/argosay:v2
+`
+
+func TestDeprecatedParameters(t *testing.T) {
+	err := validateWorkflowTemplate(deprecatedParameters, ValidateOpts{})
+	assert.NoError(t, err)
+
+	err = validateWorkflowTemplate(strings.Replace(deprecatedParameters, "replacedBy: message", "replacedBy: text", 1), ValidateOpts{})
+	assert.EqualError(t, err, `spec.arguments.parameters.msg.deprecated.replacedBy "text" is not a parameter`)
+
+	err = createWorkflowTemplateFromSpec(deprecatedParameters)
+	assert.NoError(t, err)
+	wf := &wfv1.Workflow{Spec: wfv1.WorkflowSpec{
+		WorkflowTemplateRef: &wfv1.WorkflowTemplateRef{Name: "deprecated-parameters"},
+		Arguments:           wfv1.Arguments{Parameters: []wfv1.Parameter{{Name: "msg"}, {Name: "message"}}},
+	}}
+	warnings, err := GetDeprecatedParameterWarnings(wftmplGetter, cwftmplGetter, wf)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{`parameter "msg" is deprecated: it is ambiguous, use "message" instead`}, warnings)
+}
+
+var workflowOutputs = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: workflow-outputs-
+spec:
+  entrypoint: main
+  outputs:
+    - name: accuracy
+      type: number
+      from: tasks.train.outputs.parameters.accuracy
+    - name: model
+      type: artifact
+      from: tasks.train.outputs.artifacts.model
+  templates:
+  - name: main
+    dag:
+      tasks:
+        - name: train
+          template: train
+  - name: train
+    container:
+      image: argoproj/argosay:v2
+    outputs:
+      parameters:
+        - name: accuracy
+          valueFrom:
+            path: /tmp/accuracy
+      artifacts:
+        - name: model
+          path: /tmp/model
+`
+
+func TestWorkflowOutputs(t *testing.T) {
+	err := validate(workflowOutputs)
+	assert.NoError(t, err)
+
+	for _, tt := range []struct{ old, new, err string }{
+		{"name: model", "name: accuracy", "spec.outputs[1].name 'accuracy' is not unique"},
+		{"type: number", "type: float", "spec.outputs[0].type must be one of string, number, boolean, json or artifact"},
+		{"from: tasks.train.outputs.parameters.accuracy", "from: train.accuracy", "spec.outputs[0].from 'train.accuracy' must be an output"},
+		{"type: artifact", "type: string", "spec.outputs[1].from must be an output artifact if, and only if, the type is artifact"},
+		{"from: tasks.train.outputs.parameters.accuracy", "from: tasks.test.outputs.parameters.accuracy", "spec.outputs[0].from: tasks.test not found in the entrypoint template"},
+	} {
+		err := validate(strings.Replace(workflowOutputs, tt.old, tt.new, 1))
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), tt.err)
+		}
+	}
+}
+
+var workflowOutputsArgument = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: deploy-
+spec:
+  entrypoint: main
+  arguments:
+    parameters:
+      - name: image-digest
+        valueFrom:
+          workflowOutputs:
+            selector: workflows.argoproj.io/workflow-template=nightly-build
+            output: imageDigest
+            latest: Succeeded
+  templates:
+    - name: main
+      container:
+        image: argoproj/argosay:v2
+        args: [echo, "{{workflow.parameters.image-digest}}"]
+`
+
+func TestWorkflowOutputsArgument(t *testing.T) {
+	err := validate(workflowOutputsArgument)
+	assert.NoError(t, err)
+
+	for _, tt := range []struct{ old, new, err string }{
+		{"selector: workflows.argoproj.io/workflow-template=nightly-build", "selector: a=b=c", "spec.arguments.image-digest.valueFrom.workflowOutputs.selector is invalid"},
+		{"output: imageDigest", "output: ''", "spec.arguments.image-digest.valueFrom.workflowOutputs.output is required"},
+		{"latest: Succeeded", "latest: Running", "spec.arguments.image-digest.valueFrom.workflowOutputs.latest must be one of: Succeeded|Failed|Error"},
+	} {
+		err := validate(strings.Replace(workflowOutputsArgument, tt.old, tt.new, 1))
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), tt.err)
+		}
+	}
+}
+
 var invalidOutputParamNames = `
 apiVersion: argoproj.io/v1alpha1
 kind: Workflow
@@ -1036,6 +1409,71 @@ func TestMultipleTemplateTypes(t *testing.T) {
 	}
 }
 
+var manualTemplateInvalidDue = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: manual-
+spec:
+  entrypoint: review
+  templates:
+  - name: review
+    manual:
+      instructions: Review the change
+      due: tomorrow
+`
+
+func TestManualTemplateDue(t *testing.T) {
+	err := validate(manualTemplateInvalidDue)
+	assert.ErrorContains(t, err, "templates.review.manual.due")
+	err = validate(strings.Replace(manualTemplateInvalidDue, "tomorrow", "24h", 1))
+	assert.NoError(t, err)
+}
+
+var suspendTemplateApprovals = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: approval-
+spec:
+  entrypoint: approve
+  templates:
+  - name: approve
+    suspend:
+      approvals: 2
+`
+
+func TestSuspendTemplateApprovals(t *testing.T) {
+	err := validate(suspendTemplateApprovals)
+	assert.NoError(t, err)
+	err = validate(strings.Replace(suspendTemplateApprovals, "approvals: 2", "approvals: -1", 1))
+	assert.ErrorContains(t, err, "templates.approve.suspend.approvals must not be negative")
+	err = validate(strings.Replace(suspendTemplateApprovals, "approvals: 2", "approvals: 2\n      duration: 1h", 1))
+	assert.ErrorContains(t, err, "templates.approve.suspend.approvals cannot be combined with a duration")
+}
+
+var pluginTemplateVersion = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: plugin-
+spec:
+  entrypoint: main
+  templates:
+  - name: main
+    plugin:
+      slack:
+        text: hello
+      version: ">=1.2"
+`
+
+func TestPluginTemplateVersion(t *testing.T) {
+	err := validate(pluginTemplateVersion)
+	assert.NoError(t, err)
+	err = validate(strings.Replace(pluginTemplateVersion, `">=1.2"`, `"latest"`, 1))
+	assert.ErrorContains(t, err, `templates.main.plugin.version "latest" is not a valid version constraint`)
+}
+
 var exitHandlerWorkflowStatusOnExit = `
 apiVersion: argoproj.io/v1alpha1
 kind: Workflow
@@ -1198,6 +1636,165 @@ func TestLeafWithParallelism(t *testing.T) {
 	}
 }
 
+var heartbeat = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: heartbeat-
+spec:
+  entrypoint: main
+  templates:
+  - name: main
+    heartbeat:
+      timeout: 10m
+    container:
+      image: argoproj/argosay:v2
+  - name: suspend
+    heartbeat:
+      timeout: 10m
+    suspend: {}
+`
+
+func TestHeartbeat(t *testing.T) {
+	wf := unmarshalWf(heartbeat)
+	err := ValidateWorkflow(wftmplGetter, cwftmplGetter, wf, ValidateOpts{})
+	assert.ErrorContains(t, err, "templates.suspend.heartbeat is only valid for container, containerSet and script templates")
+
+	wf.Spec.Templates = wf.Spec.Templates[:1]
+	err = ValidateWorkflow(wftmplGetter, cwftmplGetter, wf, ValidateOpts{})
+	assert.NoError(t, err)
+
+	wf.Spec.Templates[0].Heartbeat.Timeout = "0s"
+	err = ValidateWorkflow(wftmplGetter, cwftmplGetter, wf, ValidateOpts{})
+	assert.ErrorContains(t, err, "templates.main.heartbeat.timeout must be a positive duration")
+}
+
+var artifactBandwidth = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: artifact-bandwidth-
+spec:
+  entrypoint: main
+  templates:
+  - name: main
+    artifactBandwidth:
+      download: 10Mi
+    inputs:
+      artifacts:
+      - name: dataset
+        path: /tmp/dataset
+        http:
+          url: https://example.com/dataset.tgz
+    container:
+      image: argoproj/argosay:v2
+`
+
+func TestArtifactBandwidth(t *testing.T) {
+	wf := unmarshalWf(artifactBandwidth)
+	err := ValidateWorkflow(wftmplGetter, cwftmplGetter, wf, ValidateOpts{})
+	assert.NoError(t, err)
+
+	wf.Spec.Templates[0].Outputs.Artifacts = wfv1.Artifacts{{
+		Name:             "result",
+		Path:             "/tmp/result",
+		ArtifactLocation: wfv1.ArtifactLocation{GCS: &wfv1.GCSArtifact{GCSBucket: wfv1.GCSBucket{Bucket: "my-bucket"}, Key: "result.tgz"}},
+	}}
+	err = ValidateWorkflow(wftmplGetter, cwftmplGetter, wf, ValidateOpts{})
+	assert.ErrorContains(t, err, "templates.main.artifactBandwidth is not enforced for the gcs artifact result")
+
+	wf.Spec.Templates[0].Outputs.Artifacts = nil
+	wf.Spec.Templates[0].ArchiveLocation = &wfv1.ArtifactLocation{Azure: &wfv1.AzureArtifact{AzureBlobContainer: wfv1.AzureBlobContainer{Endpoint: "https://example.blob.core.windows.net", Container: "my-container"}, Blob: "logs"}}
+	err = ValidateWorkflow(wftmplGetter, cwftmplGetter, wf, ValidateOpts{})
+	assert.ErrorContains(t, err, "templates.main.artifactBandwidth is not enforced for the azure archive location")
+}
+
+var numa = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: numa-
+spec:
+  entrypoint: main
+  templates:
+  - name: main
+    numa:
+      exclusiveCPUs: 4
+      topologyPolicy: single-numa-node
+      hugePages:
+      - pageSize: 2Mi
+        quantity: 512Mi
+    container:
+      image: argoproj/argosay:v2
+      resources:
+        limits:
+          memory: 1Gi
+`
+
+func TestNUMA(t *testing.T) {
+	wf := unmarshalWf(numa)
+	err := ValidateWorkflow(wftmplGetter, cwftmplGetter, wf, ValidateOpts{})
+	assert.NoError(t, err)
+
+	wf.Spec.Templates[0].NUMA.TopologyPolicy = "numa-please"
+	err = ValidateWorkflow(wftmplGetter, cwftmplGetter, wf, ValidateOpts{})
+	assert.ErrorContains(t, err, "templates.main.numa.topologyPolicy must be one of: none, best-effort, restricted, single-numa-node")
+
+	wf = unmarshalWf(numa)
+	wf.Spec.Templates[0].NUMA.HugePages[0].PageSize = "4Ki"
+	err = ValidateWorkflow(wftmplGetter, cwftmplGetter, wf, ValidateOpts{})
+	assert.ErrorContains(t, err, "templates.main.numa.hugePages[0].pageSize must be one of: 2Mi, 1Gi")
+
+	wf = unmarshalWf(numa)
+	wf.Spec.Templates[0].NUMA.HugePages[0].Quantity = "3M"
+	err = ValidateWorkflow(wftmplGetter, cwftmplGetter, wf, ValidateOpts{})
+	assert.ErrorContains(t, err, "templates.main.numa.hugePages[0].quantity must be a multiple of the page size 2Mi")
+}
+
+var strictVariables = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: strict-variables-
+spec:
+  entrypoint: main
+  strictVariables: true
+  templates:
+  - name: main
+    inputs:
+      parameters:
+      - name: message
+        value: hello
+    retryStrategy:
+      limit: 2
+    metrics:
+      prometheus:
+      - name: duration
+        help: Duration of the step
+        gauge:
+          value: "{{duration}}"
+    container:
+      image: argoproj/argosay:v2
+      args: ["{{inputs.parameters.message}}", "{{retries}}"]
+`
+
+func TestStrictVariables(t *testing.T) {
+	wf := unmarshalWf(strictVariables)
+	err := ValidateWorkflow(wftmplGetter, cwftmplGetter, wf, ValidateOpts{})
+	assert.NoError(t, err)
+
+	wf.Spec.Templates[0].Container.Args = []string{"{{input.parameters.message}}"}
+	err = ValidateWorkflow(wftmplGetter, cwftmplGetter, wf, ValidateOpts{})
+	assert.ErrorContains(t, err, "templates.main: failed to resolve {{input.parameters.message}}")
+
+	wf.Spec.StrictVariables = false
+	err = ValidateWorkflow(wftmplGetter, cwftmplGetter, wf, ValidateOpts{})
+	assert.NoError(t, err)
+
+	err = ValidateWorkflow(wftmplGetter, cwftmplGetter, wf, ValidateOpts{StrictVariables: true})
+	assert.ErrorContains(t, err, "templates.main: failed to resolve {{input.parameters.message}}")
+}
+
 var invalidStepsArgumentNoFromOrLocation = `
 apiVersion: argoproj.io/v1alpha1
 kind: Workflow
@@ -1674,6 +2271,119 @@ func TestInvalidResourceWorkflow(t *testing.T) {
 	assert.EqualError(t, err, "templates.whalesay.resource.action must be one of: get, create, apply, delete, replace, patch")
 }
 
+var operatorResourceWorkflow = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: operator-resource-
+spec:
+  entrypoint: spark
+  templates:
+  - name: spark
+    resource:
+      action: create
+      operator: spark
+      manifest: |
+        apiVersion: sparkoperator.k8s.io/v1beta2
+        kind: SparkApplication
+        metadata:
+          name: spark-pi
+    outputs:
+      parameters:
+      - name: applicationId
+`
+
+func TestResourceOperator(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		wf := unmarshalWf(operatorResourceWorkflow)
+		err := ValidateWorkflow(wftmplGetter, cwftmplGetter, wf, ValidateOpts{})
+		assert.NoError(t, err)
+	})
+	t.Run("UnknownOperator", func(t *testing.T) {
+		wf := unmarshalWf(operatorResourceWorkflow)
+		wf.Spec.Templates[0].Resource.Operator = "foo"
+		err := ValidateWorkflow(wftmplGetter, cwftmplGetter, wf, ValidateOpts{})
+		assert.EqualError(t, err, `templates.spark.resource.operator unknown operator "foo", must be one of: spark, ray, flink`)
+	})
+	t.Run("WrongKind", func(t *testing.T) {
+		wf := unmarshalWf(operatorResourceWorkflow)
+		wf.Spec.Templates[0].Resource.Operator = wfv1.ResourceOperatorRay
+		err := ValidateWorkflow(wftmplGetter, cwftmplGetter, wf, ValidateOpts{})
+		assert.EqualError(t, err, "templates.spark.resource.manifest must be a RayJob for the ray operator")
+	})
+	t.Run("UnknownOutput", func(t *testing.T) {
+		wf := unmarshalWf(operatorResourceWorkflow)
+		wf.Spec.Templates[0].Outputs.Parameters[0].Name = "foo"
+		err := ValidateWorkflow(wftmplGetter, cwftmplGetter, wf, ValidateOpts{})
+		assert.EqualError(t, err, "templates.spark.outputs.parameters.foo does not have valueFrom or value specified")
+	})
+}
+
+var guardrailsWorkflow = `
+metadata:
+  generateName: guardrails-
+spec:
+  entrypoint: main
+  activeDeadlineSeconds: 7200
+  templates:
+  - name: main
+    steps:
+    - - name: items
+        template: echo
+        withItems: [a, b, c]
+      - name: sequence
+        template: echo
+        withSequence:
+          start: "1"
+          end: "5"
+    - - name: dag
+        template: dag
+  - name: dag
+    dag:
+      tasks:
+      - name: sequence
+        template: echo
+        withSequence:
+          count: "4"
+  - name: echo
+    container:
+      image: argoproj/argosay:v2
+`
+
+func TestGuardrails(t *testing.T) {
+	t.Run("Unlimited", func(t *testing.T) {
+		wf := unmarshalWf(guardrailsWorkflow)
+		err := ValidateWorkflow(wftmplGetter, cwftmplGetter, wf, ValidateOpts{Guardrails: &config.Guardrails{}})
+		assert.NoError(t, err)
+	})
+	t.Run("WithinLimits", func(t *testing.T) {
+		wf := unmarshalWf(guardrailsWorkflow)
+		err := ValidateWorkflow(wftmplGetter, cwftmplGetter, wf, ValidateOpts{Guardrails: &config.Guardrails{MaxActiveDeadlineSeconds: 7200, MaxFanOut: 5}})
+		assert.NoError(t, err)
+	})
+	t.Run("ActiveDeadlineSeconds", func(t *testing.T) {
+		wf := unmarshalWf(guardrailsWorkflow)
+		err := ValidateWorkflow(wftmplGetter, cwftmplGetter, wf, ValidateOpts{Guardrails: &config.Guardrails{MaxActiveDeadlineSeconds: 3600}})
+		assert.EqualError(t, err, "spec.activeDeadlineSeconds 7200 exceeds the maximum of 3600 set by the controller's guardrails")
+	})
+	t.Run("WithItems", func(t *testing.T) {
+		wf := unmarshalWf(guardrailsWorkflow)
+		err := ValidateWorkflow(wftmplGetter, cwftmplGetter, wf, ValidateOpts{Guardrails: &config.Guardrails{MaxFanOut: 2}})
+		assert.EqualError(t, err, "templates.main.steps[0].items loop of 3 items exceeds the maximum fan-out of 2 set by the controller's guardrails")
+	})
+	t.Run("WithSequence", func(t *testing.T) {
+		wf := unmarshalWf(guardrailsWorkflow)
+		err := ValidateWorkflow(wftmplGetter, cwftmplGetter, wf, ValidateOpts{Guardrails: &config.Guardrails{MaxFanOut: 4}})
+		assert.EqualError(t, err, "templates.main.steps[0].sequence loop of 5 items exceeds the maximum fan-out of 4 set by the controller's guardrails")
+	})
+	t.Run("DAG", func(t *testing.T) {
+		wf := unmarshalWf(guardrailsWorkflow)
+		wf.Spec.Templates[0].Steps = wf.Spec.Templates[0].Steps[1:]
+		err := ValidateWorkflow(wftmplGetter, cwftmplGetter, wf, ValidateOpts{Guardrails: &config.Guardrails{MaxFanOut: 3}})
+		assert.EqualError(t, err, "templates.main.steps[0].dag templates.dag.tasks.sequence loop of 4 items exceeds the maximum fan-out of 3 set by the controller's guardrails")
+	})
+}
+
 var invalidPodGC = `
 metadata:
   generateName: pod-gc-strategy-unknown-
@@ -2847,6 +3557,47 @@ func TestMaxLengthName(t *testing.T) {
 	assert.EqualError(t, err, "cron workflow name \"aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa\" must not be more than 52 characters long (currently 60)")
 }
 
+func TestCronWorkflowSchedules(t *testing.T) {
+	cronWf := func(schedule string, schedules ...wfv1.CronSchedule) *wfv1.CronWorkflow {
+		cronWf := wfv1.MustUnmarshalCronWorkflow(`
+metadata:
+  name: my-cwf
+spec:
+  workflowSpec:
+    entrypoint: main
+    templates:
+    - name: main
+      container:
+        image: alpine
+`)
+		cronWf.Spec.Schedule = schedule
+		cronWf.Spec.Schedules = schedules
+		return cronWf
+	}
+	value := wfv1.AnyStringPtr("full")
+	for name, tt := range map[string]struct {
+		cronWf *wfv1.CronWorkflow
+		err    string
+	}{
+		"Valid":          {cronWf("", wfv1.CronSchedule{Name: "hourly", Schedule: "0 * * * *"}, wfv1.CronSchedule{Name: "nightly", Schedule: "0 0 * * *", Parameters: []wfv1.Parameter{{Name: "mode", Value: value}}}), ""},
+		"Both":           {cronWf("0 * * * *", wfv1.CronSchedule{Name: "hourly", Schedule: "0 * * * *"}), "only one of schedule or schedules may be specified"},
+		"InvalidName":    {cronWf("", wfv1.CronSchedule{Name: "Hourly", Schedule: "0 * * * *"}), `schedule name "Hourly" is invalid`},
+		"DuplicateName":  {cronWf("", wfv1.CronSchedule{Name: "hourly", Schedule: "0 * * * *"}, wfv1.CronSchedule{Name: "hourly", Schedule: "30 * * * *"}), `schedule name "hourly" is not unique`},
+		"LongName":       {cronWf("", wfv1.CronSchedule{Name: strings.Repeat("a", 50), Schedule: "0 * * * *"}), "must not be more than 51 characters long together"},
+		"Malformed":      {cronWf("", wfv1.CronSchedule{Name: "hourly", Schedule: "hourly"}), `cron schedule "hourly" is malformed`},
+		"ParameterValue": {cronWf("", wfv1.CronSchedule{Name: "hourly", Schedule: "0 * * * *", Parameters: []wfv1.Parameter{{Name: "mode"}}}), `parameter "mode" of schedule "hourly" must have a value`},
+	} {
+		t.Run(name, func(t *testing.T) {
+			err := ValidateCronWorkflow(wftmplGetter, cwftmplGetter, tt.cronWf)
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.err)
+			}
+		})
+	}
+}
+
 var invalidContainerSetDependencyNotFound = `
 apiVersion: argoproj.io/v1alpha1
 kind: Workflow
@@ -3342,3 +4093,90 @@ func TestShouldCheckValidationToSpacedParameters(t *testing.T) {
 		assert.Contains(t, err.Error(), "failed to resolve {{  workflow.thisdoesnotexist  }}")
 	}
 }
+
+var windowsTemplate = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: hello-windows-
+spec:
+  entrypoint: main
+  templates:
+  - name: main
+    nodeSelector:
+      kubernetes.io/os: windows
+    inputs:
+      artifacts:
+      - name: message
+        path: /message
+        raw:
+          data: hello
+    container:
+      image: mcr.microsoft.com/windows/nanoserver:1809
+      command: ["cmd", "/c"]
+      args: ["type C:\\message"]
+`
+
+func TestWindowsTemplate(t *testing.T) {
+	wf := unmarshalWf(windowsTemplate)
+	err := ValidateWorkflow(wftmplGetter, cwftmplGetter, wf, ValidateOpts{})
+	assert.NoError(t, err)
+
+	wf.Spec.Templates[0].Container.TTY = true
+	err = ValidateWorkflow(wftmplGetter, cwftmplGetter, wf, ValidateOpts{})
+	assert.EqualError(t, err, "templates.main.container.tty is not supported on Windows")
+
+	wf = unmarshalWf(windowsTemplate)
+	wf.Spec.Templates[0].Inputs.Artifacts[0].Mode = pointer.Int32(0o755)
+	err = ValidateWorkflow(wftmplGetter, cwftmplGetter, wf, ValidateOpts{})
+	assert.EqualError(t, err, "templates.main.inputs.artifacts.message.mode is not supported on Windows, which has no file modes")
+
+	t.Run("WorkflowNodeSelector", func(t *testing.T) {
+		wf := unmarshalWf(windowsTemplate)
+		wf.Spec.NodeSelector = wf.Spec.Templates[0].NodeSelector
+		wf.Spec.Templates[0].NodeSelector = nil
+		wf.Spec.Templates[0].NUMA = &wfv1.NUMA{ExclusiveCPUs: 2}
+		err := ValidateWorkflow(wftmplGetter, cwftmplGetter, wf, ValidateOpts{})
+		assert.EqualError(t, err, "templates.main.numa is not supported on Windows")
+	})
+
+	t.Run("Linux", func(t *testing.T) {
+		wf := unmarshalWf(windowsTemplate)
+		wf.Spec.Templates[0].NodeSelector = nil
+		wf.Spec.Templates[0].Container.TTY = true
+		err := ValidateWorkflow(wftmplGetter, cwftmplGetter, wf, ValidateOpts{})
+		assert.NoError(t, err)
+	})
+}
+
+var retryFailureClasses = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: retry-failure-classes-
+spec:
+  entrypoint: main
+  templates:
+  - name: main
+    retryStrategy:
+      limit: 3
+      retryPolicy: OnTransientError
+      failureClasses: [Evicted, NodeLost]
+    container:
+      image: argoproj/argosay:v2
+`
+
+func TestRetryFailureClasses(t *testing.T) {
+	wf := unmarshalWf(retryFailureClasses)
+	err := ValidateWorkflow(wftmplGetter, cwftmplGetter, wf, ValidateOpts{})
+	assert.NoError(t, err)
+
+	wf.Spec.Templates[0].RetryStrategy.FailureClasses = []wfv1.NodeFailureClass{"Unknown"}
+	err = ValidateWorkflow(wftmplGetter, cwftmplGetter, wf, ValidateOpts{})
+	assert.ErrorContains(t, err, "Unknown is not a valid failure class")
+
+	wf = unmarshalWf(retryFailureClasses)
+	wf.Spec.Templates[0].RetryStrategy.RetryPolicy = wfv1.RetryPolicyOnFailure
+	err = ValidateWorkflow(wftmplGetter, cwftmplGetter, wf, ValidateOpts{})
+	assert.ErrorContains(t, err, "retryStrategy.failureClasses can only be used with the OnTransientError retryPolicy")
+}
@@ -0,0 +1,72 @@
+package validate
+
+import (
+	"fmt"
+
+	apiv1 "k8s.io/api/core/v1"
+
+	"github.com/argoproj/argo-workflows/v3/errors"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/templateresolution"
+)
+
+// isWindowsTemplate returns true if the template's pod is scheduled on Windows nodes, by its own node selector or the
+// one of its workflow spec
+func isWindowsTemplate(tmplCtx *templateresolution.Context, tmpl *wfv1.Template) bool {
+	nodeSelector := tmpl.NodeSelector
+	if len(nodeSelector) == 0 {
+		switch base := tmplCtx.GetCurrentTemplateBase().(type) {
+		case *wfv1.Workflow:
+			nodeSelector = base.Spec.NodeSelector
+		case wfv1.WorkflowSpecHolder:
+			nodeSelector = base.GetWorkflowSpec().NodeSelector
+		}
+	}
+	return nodeSelector[apiv1.LabelOSStable] == "windows"
+}
+
+// validateWindowsTemplate returns an error if a template that runs on Windows nodes uses a feature that Windows
+// containers do not support
+func validateWindowsTemplate(tmpl *wfv1.Template) error {
+	var containers []apiv1.Container
+	var paths []string
+	if tmpl.Container != nil {
+		containers = append(containers, *tmpl.Container)
+		paths = append(paths, "container")
+	}
+	if tmpl.Script != nil {
+		containers = append(containers, tmpl.Script.Container)
+		paths = append(paths, "script")
+	}
+	if tmpl.ContainerSet != nil {
+		for i, c := range tmpl.ContainerSet.Containers {
+			containers = append(containers, c.Container)
+			paths = append(paths, fmt.Sprintf("containerSet.containers[%d]", i))
+		}
+	}
+	for i, c := range tmpl.InitContainers {
+		containers = append(containers, c.Container)
+		paths = append(paths, fmt.Sprintf("initContainers[%d]", i))
+	}
+	for i, c := range tmpl.Sidecars {
+		containers = append(containers, c.Container)
+		paths = append(paths, fmt.Sprintf("sidecars[%d]", i))
+	}
+	for i, c := range containers {
+		if c.TTY {
+			return errors.Errorf(errors.CodeBadRequest, "templates.%s.%s.tty is not supported on Windows", tmpl.Name, paths[i])
+		}
+		if c.SecurityContext != nil && c.SecurityContext.Privileged != nil && *c.SecurityContext.Privileged {
+			return errors.Errorf(errors.CodeBadRequest, "templates.%s.%s.securityContext.privileged is not supported on Windows", tmpl.Name, paths[i])
+		}
+	}
+	for _, art := range tmpl.Inputs.Artifacts {
+		if art.Mode != nil {
+			return errors.Errorf(errors.CodeBadRequest, "templates.%s.inputs.artifacts.%s.mode is not supported on Windows, which has no file modes", tmpl.Name, art.Name)
+		}
+	}
+	if tmpl.NUMA != nil {
+		return errors.Errorf(errors.CodeBadRequest, "templates.%s.numa is not supported on Windows", tmpl.Name)
+	}
+	return nil
+}